@@ -36,6 +36,9 @@ milvus run [server type] [flags]
 		Start the datacoord server.
 	-alias ''
 		Set alias
+	-embed 'true'
+		Run etcd and the message queue in-process, with the data stored locally.
+		Only valid for the server type 'standalone', no external dependency is required.
 `
 	stopLine = `
 milvus stop [server type] [flags]
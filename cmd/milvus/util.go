@@ -123,11 +123,16 @@ func removePidFile(lock *flock.Flock) {
 
 func GetMilvusRoles(args []string, flags *flag.FlagSet) *roles.MilvusRoles {
 	alias, enableRootCoord, enableQueryCoord, enableIndexCoord, enableDataCoord, enableQueryNode,
-		enableDataNode, enableIndexNode, enableProxy := formatFlags(args, flags)
+		enableDataNode, enableIndexNode, enableProxy, embedDependencies := formatFlags(args, flags)
 
 	serverType := args[2]
+	if embedDependencies && serverType != typeutil.StandaloneRole {
+		fmt.Fprintf(os.Stderr, "Flag -embed is only valid for server type %s\n%s", typeutil.StandaloneRole, getHelp())
+		os.Exit(-1)
+	}
 	role := roles.NewMilvusRoles()
 	role.Alias = alias
+	role.EmbedDependencies = embedDependencies
 
 	switch serverType {
 	case typeutil.RootCoordRole:
@@ -175,9 +180,10 @@ func GetMilvusRoles(args []string, flags *flag.FlagSet) *roles.MilvusRoles {
 }
 
 func formatFlags(args []string, flags *flag.FlagSet) (alias string, enableRootCoord, enableQueryCoord,
-	enableIndexCoord, enableDataCoord, enableQueryNode, enableDataNode, enableIndexNode, enableProxy bool,
+	enableIndexCoord, enableDataCoord, enableQueryNode, enableDataNode, enableIndexNode, enableProxy, embedDependencies bool,
 ) {
 	flags.StringVar(&alias, "alias", "", "set alias")
+	flags.BoolVar(&embedDependencies, "embed", false, "run etcd and the message queue in-process, only valid for standalone")
 
 	flags.BoolVar(&enableRootCoord, typeutil.RootCoordRole, false, "enable root coordinator")
 	flags.BoolVar(&enableQueryCoord, typeutil.QueryCoordRole, false, "enable query coordinator")
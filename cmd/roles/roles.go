@@ -35,9 +35,14 @@ import (
 	"github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/http/healthz"
 	rocksmqimpl "github.com/milvus-io/milvus/internal/mq/mqimpl/rocksmq/server"
+	"github.com/milvus-io/milvus/internal/mq/mqimpl/walmq"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	kvfactory "github.com/milvus-io/milvus/internal/util/dependency/kv"
+	"github.com/milvus-io/milvus/internal/util/eventbus"
+	"github.com/milvus-io/milvus/internal/util/featureflag"
 	internalmetrics "github.com/milvus-io/milvus/internal/util/metrics"
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/config"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/tracer"
@@ -59,6 +64,7 @@ func init() {
 	metrics.RegisterMetaMetrics(Registry.GoRegistry)
 	metrics.RegisterMsgStreamMetrics(Registry.GoRegistry)
 	metrics.RegisterStorageMetrics(Registry.GoRegistry)
+	metrics.RegisterBufferPoolMetrics(Registry.GoRegistry)
 }
 
 func stopRocksmq() {
@@ -141,6 +147,8 @@ type MilvusRoles struct {
 	Local    bool
 	Alias    string
 	Embedded bool
+	// EmbedDependencies runs etcd and the message queue in-process, only valid for standalone
+	EmbedDependencies bool
 
 	closed chan struct{}
 	once   sync.Once
@@ -249,6 +257,87 @@ func (mr *MilvusRoles) setupLogger() {
 	logutil.SetupLogger(&logConfig)
 }
 
+// setupMetricsCardinality applies the cardinality control of metrics labels and watches the updates.
+func setupMetricsCardinality() {
+	params := paramtable.Get()
+	apply := func(*config.Event) {
+		metrics.SetCollectionLabelLimit(params.CommonCfg.MetricsCollectionLabelLimit.GetAsInt())
+		metrics.SetDetailedCollections(params.CommonCfg.MetricsDetailedCollections.GetAsStrings())
+	}
+	apply(nil)
+	params.Watch(params.CommonCfg.MetricsCollectionLabelLimit.Key, config.NewHandler("metrics.cardinality.limit", apply))
+	params.Watch(params.CommonCfg.MetricsDetailedCollections.Key, config.NewHandler("metrics.cardinality.detailed", apply))
+}
+
+// setupOTLPMetricsExporter pushes the metrics of the registry to the OTLP endpoint if enabled,
+// the metrics are pushed with all the enabled roles once the node id is assigned by the session registration.
+func setupOTLPMetricsExporter(r *internalmetrics.MilvusRegistry, roles []string) *metrics.OTLPExporter {
+	params := paramtable.Get()
+	if !params.CommonCfg.MetricsOTLPEnable.GetAsBool() {
+		return nil
+	}
+	exporter, err := metrics.NewOTLPExporter(r, &metrics.OTLPExporterConfig{
+		Endpoint: params.CommonCfg.MetricsOTLPEndpoint.GetValue(),
+		Protocol: params.CommonCfg.MetricsOTLPProtocol.GetValue(),
+		Secure:   params.CommonCfg.MetricsOTLPSecure.GetAsBool(),
+		Interval: params.CommonCfg.MetricsOTLPInterval.GetAsDuration(time.Second),
+		Timeout:  10 * time.Second,
+		Headers:  params.CommonCfg.MetricsOTLPHeaders.GetAsJSONMap(),
+		Roles:    roles,
+		NodeID:   paramtable.GetNodeID,
+	})
+	if err != nil {
+		log.Warn("failed to create otlp metrics exporter", zap.Error(err))
+		return nil
+	}
+	exporter.Start()
+	log.Info("otlp metrics exporter started", zap.String("endpoint", params.CommonCfg.MetricsOTLPEndpoint.GetValue()))
+	return exporter
+}
+
+// setupEventBus publishes the cluster lifecycle events to the configured sinks if enabled.
+func setupEventBus(localMsg bool) *eventbus.Bus {
+	params := paramtable.Get()
+	if !params.CommonCfg.EventBusEnable.GetAsBool() {
+		return nil
+	}
+	sinks := make([]eventbus.Sink, 0)
+	for _, name := range params.CommonCfg.EventBusSinks.GetAsStrings() {
+		switch strings.TrimSpace(name) {
+		case eventbus.SinkLog:
+			sinks = append(sinks, eventbus.NewLogSink())
+		case eventbus.SinkMQ:
+			factory := dependency.NewFactory(localMsg)
+			factory.Init(params)
+			sinks = append(sinks, eventbus.NewMQSink(factory.NewClient, params.CommonCfg.EventBusMQTopic.GetValue()))
+		case eventbus.SinkWebhook:
+			sinks = append(sinks, eventbus.NewWebhookSink(
+				params.CommonCfg.EventBusWebhookURL.GetValue(),
+				params.CommonCfg.EventBusWebhookHeaders.GetAsJSONMap(),
+				params.CommonCfg.EventBusWebhookRetryTimes.GetAsUint(),
+				params.CommonCfg.EventBusWebhookTimeout.GetAsDuration(time.Millisecond),
+			))
+		default:
+			log.Warn("unknown event bus sink", zap.String("sink", name))
+		}
+	}
+	bus := eventbus.NewBus(params.CommonCfg.EventBusBufferSize.GetAsInt(), sinks...)
+	eventbus.SetDefault(bus)
+	log.Info("event bus started", zap.Strings("sinks", params.CommonCfg.EventBusSinks.GetAsStrings()))
+	return bus
+}
+
+// setupFeatureGate negotiates the features with the other nodes by the sessions.
+func setupFeatureGate() *featureflag.Gate {
+	etcdCli, metaRoot := kvfactory.GetEtcdAndPath()
+	gate := featureflag.NewGate(sessionutil.NewFeatureLister(etcdCli, metaRoot),
+		paramtable.Get().CommonCfg.FeatureGateRefreshInterval.GetAsDuration(time.Second))
+	gate.Start()
+	featureflag.SetDefault(gate)
+	log.Info("feature gate started", zap.Strings("supported", featureflag.Supported()))
+	return gate
+}
+
 // Register serves prometheus http service
 func setupPrometheusHTTPServer(r *internalmetrics.MilvusRegistry) {
 	log.Info("setupPrometheusHTTPServer")
@@ -296,6 +385,26 @@ func (mr *MilvusRoles) handleSignals() func() {
 	}
 }
 
+// embeddedDependencyConfigs are the configs to run the dependencies of standalone in-process,
+// the embedded etcd, the walmq backed by the local write-ahead log and the local storage.
+var embeddedDependencyConfigs = map[string]string{
+	"etcd.use.embed":     "true",
+	"mq.type":            "walmq",
+	"common.storageType": "local",
+}
+
+// setupEmbeddedDependencies overrides the configs of dependencies by the environment variables,
+// which take precedence over milvus.yaml, so it must be called before the paramtable is initialized.
+func setupEmbeddedDependencies() error {
+	for key, value := range embeddedDependencyConfigs {
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	log.Info("run standalone with the embedded dependencies", zap.Any("configs", embeddedDependencyConfigs))
+	return nil
+}
+
 // Run Milvus components.
 func (mr *MilvusRoles) Run() {
 	// start signal handler, defer close func
@@ -313,6 +422,11 @@ func (mr *MilvusRoles) Run() {
 		if err := os.Setenv(metricsinfo.DeployModeEnvKey, metricsinfo.StandaloneDeployMode); err != nil {
 			log.Error("Failed to set deploy mode: ", zap.Error(err))
 		}
+		if mr.EmbedDependencies {
+			if err := setupEmbeddedDependencies(); err != nil {
+				panic(err)
+			}
+		}
 
 		if mr.Embedded {
 			// setup config for embedded milvus
@@ -341,6 +455,8 @@ func (mr *MilvusRoles) Run() {
 
 	http.ServeHTTP()
 	setupPrometheusHTTPServer(Registry)
+	setupMetricsCardinality()
+	eventBus := setupEventBus(mr.Local)
 
 	var wg sync.WaitGroup
 	local := mr.Local
@@ -382,6 +498,8 @@ func (mr *MilvusRoles) Run() {
 
 	mr.setupLogger()
 	tracer.Init()
+	otlpExporter := setupOTLPMetricsExporter(Registry, mr.GetRoles())
+	featureGate := setupFeatureGate()
 
 	paramtable.SetCreateTime(time.Now())
 	paramtable.SetUpdateTime(time.Now())
@@ -414,6 +532,21 @@ func (mr *MilvusRoles) Run() {
 		log.Info("proxy stopped!")
 	}
 
+	if otlpExporter != nil {
+		otlpExporter.Stop()
+	}
+
+	featureflag.SetDefault(nil)
+	featureGate.Stop()
+
+	if eventBus != nil {
+		eventbus.SetDefault(nil)
+		eventBus.Close()
+	}
+
+	// flush the positions of consumer groups if the embedded walmq is used
+	walmq.CloseWalMQ()
+
 	// close reused etcd client
 	kvfactory.CloseEtcdClient()
 
@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -94,3 +95,19 @@ func TestCleanLocalDir(t *testing.T) {
 		cleanLocalDir(localPath)
 	})
 }
+
+func TestSetupEmbeddedDependencies(t *testing.T) {
+	// register the cleanup of the environment variables
+	for key := range embeddedDependencyConfigs {
+		t.Setenv(key, "")
+	}
+	t.Setenv(metricsinfo.DeployModeEnvKey, metricsinfo.StandaloneDeployMode)
+
+	err := setupEmbeddedDependencies()
+	assert.NoError(t, err)
+
+	bt := paramtable.NewBaseTable(paramtable.SkipRemote(true))
+	assert.Equal(t, "true", bt.Get("etcd.use.embed"))
+	assert.Equal(t, "walmq", bt.Get("mq.type"))
+	assert.Equal(t, "local", bt.Get("common.storageType"))
+}
@@ -28,6 +28,8 @@ func Execute(args []string) {
 		Backup(cfg)
 	case configs.RollbackCmd:
 		Rollback(cfg)
+	case configs.TransferCmd:
+		Transfer(cfg)
 	default:
 		console.AbnormalExit(false, fmt.Sprintf("cmd not set or not supported: %s", cfg.Cmd))
 	}
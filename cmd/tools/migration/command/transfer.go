@@ -0,0 +1,26 @@
+package command
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/milvus-io/milvus/cmd/tools/migration/configs"
+	"github.com/milvus-io/milvus/cmd/tools/migration/console"
+	"github.com/milvus-io/milvus/cmd/tools/migration/transfer"
+)
+
+func Transfer(c *configs.Config) {
+	console.ErrorExitIf(c.TransferSource == c.TransferTarget, false, "source and target of transfer are the same")
+	source, err := transfer.NewMetaKV(c.MilvusConfig, c.TransferSource)
+	console.AbnormalExitIf(err, false)
+	defer source.Close()
+	target, err := transfer.NewMetaKV(c.MilvusConfig, c.TransferTarget)
+	console.AbnormalExitIf(err, false)
+	defer target.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	console.AbnormalExitIf(transfer.NewTransfer(source, target).Run(ctx, c.TransferFollow), false)
+}
@@ -13,6 +13,7 @@ const (
 	RunCmd      = "run"
 	BackupCmd   = "backup"
 	RollbackCmd = "rollback"
+	TransferCmd = "transfer"
 )
 
 type RunConfig struct {
@@ -22,6 +23,12 @@ type RunConfig struct {
 	SourceVersion  string
 	TargetVersion  string
 	BackupFilePath string
+
+	// TransferSource and TransferTarget are the meta store types to move the meta between.
+	TransferSource string
+	TransferTarget string
+	// TransferFollow keeps applying the changes of the source after the meta is copied, until the tool is stopped.
+	TransferFollow bool
 }
 
 func newRunConfig(base *paramtable.BaseTable) *RunConfig {
@@ -44,6 +51,9 @@ func (c *RunConfig) String() string {
 	case RollbackCmd:
 		return fmt.Sprintf("Cmd: %s, SourceVersion: %s, TargetVersion: %s, BackupFilePath: %s",
 			c.Cmd, c.SourceVersion, c.TargetVersion, c.BackupFilePath)
+	case TransferCmd:
+		return fmt.Sprintf("Cmd: %s, Source: %s, Target: %s, Follow: %v",
+			c.Cmd, c.TransferSource, c.TransferTarget, c.TransferFollow)
 	default:
 		return fmt.Sprintf("invalid cmd: %s", c.Cmd)
 	}
@@ -61,11 +71,15 @@ func (c *RunConfig) init(base *paramtable.BaseTable) {
 	c.SourceVersion = c.base.GetWithDefault("config.sourceVersion", "")
 	c.TargetVersion = c.base.GetWithDefault("config.targetVersion", "")
 	c.BackupFilePath = c.base.GetWithDefault("config.backupFilePath", "")
+	c.TransferSource = c.base.GetWithDefault("transfer.source", "")
+	c.TransferTarget = c.base.GetWithDefault("transfer.target", "")
+	c.TransferFollow, _ = strconv.ParseBool(c.base.GetWithDefault("transfer.follow", "true"))
 }
 
 type MilvusConfig struct {
 	MetaStoreCfg *paramtable.MetaStoreConfig
 	EtcdCfg      *paramtable.EtcdConfig
+	TiKVCfg      *paramtable.TiKVConfig
 }
 
 func newMilvusConfig(base *paramtable.BaseTable) *MilvusConfig {
@@ -77,9 +91,11 @@ func newMilvusConfig(base *paramtable.BaseTable) *MilvusConfig {
 func (c *MilvusConfig) init(base *paramtable.BaseTable) {
 	c.MetaStoreCfg = &paramtable.MetaStoreConfig{}
 	c.EtcdCfg = &paramtable.EtcdConfig{}
+	c.TiKVCfg = &paramtable.TiKVConfig{}
 
 	c.MetaStoreCfg.Init(base)
 	c.EtcdCfg.Init(base)
+	c.TiKVCfg.Init(base)
 }
 
 func (c *MilvusConfig) String() string {
@@ -89,6 +105,8 @@ func (c *MilvusConfig) String() string {
 	switch c.MetaStoreCfg.MetaStoreType.GetValue() {
 	case util.MetaStoreTypeEtcd:
 		return fmt.Sprintf("Type: %s, EndPoints: %v, MetaRootPath: %s", c.MetaStoreCfg.MetaStoreType.GetValue(), c.EtcdCfg.Endpoints.GetValue(), c.EtcdCfg.MetaRootPath.GetValue())
+	case util.MetaStoreTypeTiKV:
+		return fmt.Sprintf("Type: %s, EndPoints: %v, MetaRootPath: %s", c.MetaStoreCfg.MetaStoreType.GetValue(), c.TiKVCfg.Endpoints.GetValue(), c.TiKVCfg.MetaRootPath.GetValue())
 	default:
 		return fmt.Sprintf("unsupported meta store: %s", c.MetaStoreCfg.MetaStoreType.GetValue())
 	}
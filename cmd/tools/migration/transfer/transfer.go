@@ -0,0 +1,181 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/milvus-io/milvus/cmd/tools/migration/configs"
+	"github.com/milvus-io/milvus/cmd/tools/migration/console"
+	"github.com/milvus-io/milvus/internal/kv"
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/internal/kv/tikv"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/etcd"
+	tikvutil "github.com/milvus-io/milvus/pkg/util/tikv"
+)
+
+const (
+	walkPageSize = 1000
+	saveBatch    = 128
+	idleTimeout  = 5 * time.Second
+)
+
+// NewMetaKV creates the watchable meta kv of the meta store type.
+func NewMetaKV(cfg *configs.MilvusConfig, storeType string) (kv.WatchKV, error) {
+	switch storeType {
+	case util.MetaStoreTypeEtcd:
+		etcdCli, err := etcd.GetEtcdClient(
+			cfg.EtcdCfg.UseEmbedEtcd.GetAsBool(),
+			cfg.EtcdCfg.EtcdUseSSL.GetAsBool(),
+			cfg.EtcdCfg.Endpoints.GetAsStrings(),
+			cfg.EtcdCfg.EtcdTLSCert.GetValue(),
+			cfg.EtcdCfg.EtcdTLSKey.GetValue(),
+			cfg.EtcdCfg.EtcdTLSCACert.GetValue(),
+			cfg.EtcdCfg.EtcdTLSMinVersion.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		return etcdkv.NewEtcdKV(etcdCli, cfg.EtcdCfg.MetaRootPath.GetValue()), nil
+	case util.MetaStoreTypeTiKV:
+		tikvCli, err := tikvutil.GetTiKVClient(cfg.TiKVCfg)
+		if err != nil {
+			return nil, err
+		}
+		return tikv.NewTiKV(tikvCli, cfg.TiKVCfg.MetaRootPath.GetValue()), nil
+	default:
+		return nil, fmt.Errorf("meta store not supported: %s", storeType)
+	}
+}
+
+// Transfer moves the meta from the source to the target online.
+//
+// The changes of the source are watched before the meta is copied, and applied in order after it,
+// so the target converges to the source even if the meta is changed during the copy.
+type Transfer struct {
+	source kv.WatchKV
+	target kv.MetaKv
+}
+
+func NewTransfer(source kv.WatchKV, target kv.MetaKv) *Transfer {
+	return &Transfer{source: source, target: target}
+}
+
+// Run copies the meta and applies the changes. If follow is false, it returns once no change
+// is received within the idle timeout after the copy, otherwise it keeps applying the changes until the context is done.
+func (t *Transfer) Run(ctx context.Context, follow bool) error {
+	ch := t.source.WatchWithPrefix("")
+	if err := t.waitCreated(ch); err != nil {
+		return err
+	}
+
+	copied, err := t.copy()
+	if err != nil {
+		return err
+	}
+	console.Success(fmt.Sprintf("copied %d keys from %s to %s", copied, t.source.GetPath(""), t.target.GetPath("")))
+
+	applied := 0
+	for {
+		var idle <-chan time.Time
+		if !follow {
+			idle = time.After(idleTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			console.Success(fmt.Sprintf("stop following, %d changes applied", applied))
+			return nil
+		case <-idle:
+			console.Success(fmt.Sprintf("transfer done, %d changes applied", applied))
+			return nil
+		case resp, ok := <-ch:
+			if !ok {
+				return fmt.Errorf("watch of the source closed")
+			}
+			if err := resp.Err(); err != nil {
+				return err
+			}
+			if err := t.apply(resp.Events); err != nil {
+				return err
+			}
+			applied += len(resp.Events)
+		}
+	}
+}
+
+func (t *Transfer) waitCreated(ch clientv3.WatchChan) error {
+	resp, ok := <-ch
+	if !ok {
+		return fmt.Errorf("watch of the source closed")
+	}
+	if err := resp.Err(); err != nil {
+		return err
+	}
+	if !resp.Created {
+		return fmt.Errorf("unexpected watch response before created")
+	}
+	return nil
+}
+
+func (t *Transfer) copy() (int, error) {
+	count := 0
+	batch := make(map[string]string)
+	err := t.source.WalkWithPrefix("", walkPageSize, func(k []byte, v []byte) error {
+		key, ok := t.relativeKey(string(k))
+		if !ok {
+			return nil
+		}
+		batch[key] = string(v)
+		count++
+		if len(batch) >= saveBatch {
+			if err := t.target.MultiSave(batch); err != nil {
+				return err
+			}
+			batch = make(map[string]string)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(batch) > 0 {
+		if err := t.target.MultiSave(batch); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// apply applies the events one by one to keep the order of the changes.
+func (t *Transfer) apply(events []*clientv3.Event) error {
+	for _, ev := range events {
+		key, ok := t.relativeKey(string(ev.Kv.Key))
+		if !ok {
+			continue
+		}
+		var err error
+		switch ev.Type {
+		case clientv3.EventTypePut:
+			err = t.target.Save(key, string(ev.Kv.Value))
+		case clientv3.EventTypeDelete:
+			err = t.target.Remove(key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relativeKey returns the key relative to the root of the source,
+// false if the key is not under the root, e.g. by-dev/meta2 matched by the prefix by-dev/meta.
+func (t *Transfer) relativeKey(key string) (string, bool) {
+	root := t.source.GetPath("") + "/"
+	if !strings.HasPrefix(key, root) {
+		return "", false
+	}
+	return strings.TrimPrefix(key, root), true
+}
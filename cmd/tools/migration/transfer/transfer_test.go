@@ -0,0 +1,63 @@
+package transfer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/milvus-io/milvus/internal/kv/mocks"
+)
+
+func TestTransfer_Run(t *testing.T) {
+	source := mocks.NewWatchKV(t)
+	target := mocks.NewMetaKv(t)
+	source.EXPECT().GetPath("").Return("by-dev/meta")
+	target.EXPECT().GetPath("").Return("by-dev/meta").Maybe()
+
+	ch := make(chan clientv3.WatchResponse, 2)
+	ch <- clientv3.WatchResponse{Created: true}
+	ch <- clientv3.WatchResponse{Events: []*clientv3.Event{
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("by-dev/meta/a"), Value: []byte("2")}},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("by-dev/meta/b")}},
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("by-dev/meta2/c"), Value: []byte("3")}},
+	}}
+	source.EXPECT().WatchWithPrefix("").Return(ch)
+	source.EXPECT().WalkWithPrefix("", mock.Anything, mock.Anything).RunAndReturn(
+		func(prefix string, size int, fn func([]byte, []byte) error) error {
+			for _, k := range []string{"by-dev/meta/a", "by-dev/meta/b", "by-dev/meta2/c"} {
+				if err := fn([]byte(k), []byte("1")); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	target.EXPECT().MultiSave(map[string]string{"a": "1", "b": "1"}).Return(nil).Once()
+	target.EXPECT().Save("a", "2").Return(nil).Once()
+	target.EXPECT().Remove("b").Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- NewTransfer(source, target).Run(ctx, true)
+	}()
+	assert.Eventually(t, func() bool {
+		return len(ch) == 0
+	}, time.Second*5, time.Millisecond*10)
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestTransfer_WatchClosed(t *testing.T) {
+	source := mocks.NewWatchKV(t)
+	ch := make(chan clientv3.WatchResponse)
+	close(ch)
+	source.EXPECT().WatchWithPrefix("").Return(ch)
+	assert.Error(t, NewTransfer(source, mocks.NewMetaKv(t)).Run(context.Background(), false))
+}
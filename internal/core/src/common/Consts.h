@@ -37,6 +37,8 @@ const char ORIGIN_SIZE_KEY[] = "original_size";
 const char INDEX_BUILD_ID_KEY[] = "indexBuildID";
 
 const char INDEX_ROOT_PATH[] = "index_files";
+// load param to reuse the disk index files cached on local disk
+const char REUSE_LOCAL_INDEX_KEY[] = "reuse_local_index";
 const char RAWDATA_ROOT_PATH[] = "raw_datas";
 
 const int64_t DEFAULT_FIELD_MAX_MEMORY_LIMIT = 64 << 20;  // bytes
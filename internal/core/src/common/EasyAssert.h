@@ -18,6 +18,7 @@
 #include <string_view>
 #include <stdexcept>
 #include <exception>
+#include <new>
 #include <cstdio>
 #include <cstdlib>
 #include <string>
@@ -57,7 +58,9 @@ enum ErrorCode {
     MetricTypeInvalid = 2026,
     FieldNotLoaded = 2027,
     ExprInvalid = 2028,
+    MemAllocateFailed = 2029,
     UnistdError = 2030,
+    ScanLimitExceeded = 2031,
     KnowhereError = 2100,
 };
 namespace impl {
@@ -113,6 +116,10 @@ FailureCStatus(std::exception* ex) {
         return CStatus{static_cast<int>(segcore_error->get_error_code()),
                        strdup(ex->what())};
     }
+    if (dynamic_cast<std::bad_alloc*>(ex) != nullptr) {
+        return CStatus{static_cast<int>(MemAllocateFailed),
+                       strdup(ex->what())};
+    }
     return CStatus{static_cast<int>(UnexpectedError), strdup(ex->what())};
 }
 
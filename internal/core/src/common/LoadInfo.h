@@ -30,6 +30,11 @@ struct FieldBinlogInfo {
     int64_t row_count = -1;
     std::vector<int64_t> entries_nums;
     bool enable_mmap{false};
+    // keep the mmap file on local disk for local recovery
+    bool keep_mmap_file{false};
+    // reuse the mmap file kept on local disk instead of loading insert files,
+    // only set when the local file is verified by the caller
+    bool reuse_mmap_file{false};
     std::vector<std::string> insert_files;
 };
 
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+#include <cstdint>
+#include <cstring>
+#include <limits>
+#include <queue>
+#include <string>
+#include <utility>
+#include <vector>
+
+#include "common/Consts.h"
+#include "common/EasyAssert.h"
+#include "common/MinHash.h"
+#include "common/Utils.h"
+
+namespace milvus {
+
+namespace {
+// CountEqual is a plain loop over the aligned elements, which is vectorized
+// by the compiler.
+template <typename T>
+int64_t
+CountEqual(const T* __restrict x, const T* __restrict y, int64_t num_elements) {
+    int64_t equal = 0;
+    for (int64_t i = 0; i < num_elements; i++) {
+        equal += (x[i] == y[i]);
+    }
+    return equal;
+}
+
+bool
+IsAligned(const uint8_t* data, int64_t element_size) {
+    return reinterpret_cast<uintptr_t>(data) % element_size == 0;
+}
+
+float
+Distance(const uint8_t* x,
+         const uint8_t* y,
+         int64_t num_elements,
+         int64_t element_size) {
+    int64_t equal = 0;
+    if (element_size == sizeof(uint64_t)) {
+        equal = CountEqual(reinterpret_cast<const uint64_t*>(x),
+                           reinterpret_cast<const uint64_t*>(y),
+                           num_elements);
+    } else {
+        equal = CountEqual(reinterpret_cast<const uint32_t*>(x),
+                           reinterpret_cast<const uint32_t*>(y),
+                           num_elements);
+    }
+    return 1.0f - static_cast<float>(equal) / num_elements;
+}
+}  // namespace
+
+bool
+IsMinHashMetricType(const MetricType& metric_type) {
+    return IsMetricType(metric_type, METRIC_MHJACCARD);
+}
+
+int64_t
+GetMinHashElementSize(const Config& config) {
+    auto bit_width = DEFAULT_MH_ELEMENT_BIT_WIDTH;
+    if (config.contains(MH_ELEMENT_BIT_WIDTH)) {
+        auto& value = config.at(MH_ELEMENT_BIT_WIDTH);
+        bit_width = value.is_string() ? std::stoll(value.get<std::string>())
+                                      : value.get<int64_t>();
+    }
+    AssertInfo(bit_width == 32 || bit_width == 64,
+               "{} should be 32 or 64, but got {}",
+               MH_ELEMENT_BIT_WIDTH,
+               bit_width);
+    return bit_width / 8;
+}
+
+float
+MinHashJaccardDistance(const uint8_t* x,
+                       const uint8_t* y,
+                       int64_t dim,
+                       int64_t element_size) {
+    auto num_elements = dim / 8 / element_size;
+    AssertInfo(num_elements > 0 && num_elements * element_size * 8 == dim,
+               "dim {} isn't a multiple of the bit width {} of MinHash",
+               dim,
+               element_size * 8);
+    if (IsAligned(x, element_size) && IsAligned(y, element_size)) {
+        return Distance(x, y, num_elements, element_size);
+    }
+    std::vector<uint64_t> aligned_x((dim / 8 + 7) / 8);
+    std::vector<uint64_t> aligned_y(aligned_x.size());
+    std::memcpy(aligned_x.data(), x, dim / 8);
+    std::memcpy(aligned_y.data(), y, dim / 8);
+    return Distance(reinterpret_cast<const uint8_t*>(aligned_x.data()),
+                    reinterpret_cast<const uint8_t*>(aligned_y.data()),
+                    num_elements,
+                    element_size);
+}
+
+MinHashSearcher::MinHashSearcher(const uint8_t* queries,
+                                 int64_t num_queries,
+                                 int64_t dim,
+                                 int64_t topk,
+                                 const Config& config)
+    : num_queries_(num_queries),
+      dim_(dim),
+      topk_(topk),
+      element_size_(GetMinHashElementSize(config)),
+      radius_(std::numeric_limits<float>::max()),
+      range_filter_(std::numeric_limits<float>::lowest()),
+      results_(num_queries) {
+    AssertInfo(dim % (element_size_ * 8) == 0,
+               "dim {} isn't a multiple of the bit width {} of MinHash",
+               dim,
+               element_size_ * 8);
+    if (config.contains(RADIUS)) {
+        range_search_ = true;
+        radius_ = config.at(RADIUS).get<float>();
+        if (config.contains(RANGE_FILTER)) {
+            range_filter_ = config.at(RANGE_FILTER).get<float>();
+        }
+    }
+    auto code_size = dim / 8;
+    queries_.resize((num_queries * code_size + 7) / 8);
+    std::memcpy(queries_.data(), queries, num_queries * code_size);
+}
+
+void
+MinHashSearcher::Search(const uint8_t* base,
+                        int64_t num_base,
+                        int64_t base_offset,
+                        const BitsetView& bitset) {
+    auto code_size = dim_ / 8;
+    auto num_elements = code_size / element_size_;
+    // the rows are aligned to the elements if the base is, as the code size
+    // is a multiple of the element size
+    std::vector<uint64_t> aligned;
+    if (!IsAligned(base, element_size_)) {
+        aligned.resize((num_base * code_size + 7) / 8);
+        std::memcpy(aligned.data(), base, num_base * code_size);
+        base = reinterpret_cast<const uint8_t*>(aligned.data());
+    }
+
+    auto queries = reinterpret_cast<const uint8_t*>(queries_.data());
+    for (int64_t i = 0; i < num_queries_; i++) {
+        auto query = queries + i * code_size;
+        auto& pq = results_[i];
+        for (int64_t j = 0; j < num_base; j++) {
+            auto offset = base_offset + j;
+            if (!bitset.empty() && bitset.test(offset)) {
+                continue;
+            }
+            auto dist = Distance(
+                query, base + j * code_size, num_elements, element_size_);
+            if (range_search_ && (dist >= radius_ || dist < range_filter_)) {
+                continue;
+            }
+            if (static_cast<int64_t>(pq.size()) < topk_) {
+                pq.emplace(dist, offset);
+            } else if (dist < pq.top().first) {
+                pq.pop();
+                pq.emplace(dist, offset);
+            }
+        }
+    }
+}
+
+void
+MinHashSearcher::Fill(int64_t* seg_offsets, float* distances) {
+    for (int64_t i = 0; i < num_queries_; i++) {
+        auto& pq = results_[i];
+        auto offsets = seg_offsets + i * topk_;
+        auto dists = distances + i * topk_;
+        for (int64_t j = pq.size(); j < topk_; j++) {
+            offsets[j] = -1;
+            dists[j] = std::numeric_limits<float>::max();
+        }
+        for (int64_t j = pq.size() - 1; j >= 0; j--) {
+            dists[j] = pq.top().first;
+            offsets[j] = pq.top().second;
+            pq.pop();
+        }
+    }
+}
+
+void
+MinHashSearch(const uint8_t* base,
+              int64_t num_base,
+              const uint8_t* queries,
+              int64_t num_queries,
+              int64_t dim,
+              int64_t topk,
+              const Config& config,
+              const BitsetView& bitset,
+              int64_t* seg_offsets,
+              float* distances) {
+    MinHashSearcher searcher(queries, num_queries, dim, topk, config);
+    searcher.Search(base, num_base, 0, bitset);
+    searcher.Fill(seg_offsets, distances);
+}
+
+}  // namespace milvus
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+#pragma once
+
+#include <cstdint>
+#include <queue>
+#include <utility>
+#include <vector>
+
+#include "common/BitsetView.h"
+#include "common/Types.h"
+
+namespace milvus {
+
+// MinHash signatures are stored as binary vectors, whose elements are the
+// 32-bit or 64-bit min hash values, so the dim of the vector is
+// the number of hash values multiplied by the bit width of the element.
+constexpr const char* METRIC_MHJACCARD = "MHJACCARD";
+constexpr const char* MH_ELEMENT_BIT_WIDTH = "mh_element_bit_width";
+constexpr int64_t DEFAULT_MH_ELEMENT_BIT_WIDTH = 32;
+
+bool
+IsMinHashMetricType(const MetricType& metric_type);
+
+// the size in bytes of the elements of MinHash signatures, configured by
+// mh_element_bit_width of the config, 4 bytes by default
+int64_t
+GetMinHashElementSize(const Config& config);
+
+// the estimated jaccard distance of the sets of the signatures x and y,
+// which is the fraction of the different hash values.
+float
+MinHashJaccardDistance(const uint8_t* x,
+                       const uint8_t* y,
+                       int64_t dim,
+                       int64_t element_size);
+
+// MinHashSearcher searches the topk nearest signatures for each query by
+// MinHashJaccardDistance, over the bases searched where they are one by one,
+// e.g. the chunks of raw data, or the batches of rows read from an index.
+// If the config has radius, only the signatures of which the distance is less than
+// radius and not less than range_filter are returned.
+class MinHashSearcher {
+ public:
+    MinHashSearcher(const uint8_t* queries,
+                    int64_t num_queries,
+                    int64_t dim,
+                    int64_t topk,
+                    const Config& config);
+
+    // Search searches the signatures of the base, the offset of whose first row
+    // is base_offset, the rows filtered by the bitset of all the rows are skipped.
+    void
+    Search(const uint8_t* base,
+           int64_t num_base,
+           int64_t base_offset,
+           const BitsetView& bitset);
+
+    // Fill fills the topk results of each query, the missing results are filled
+    // with the offset -1.
+    void
+    Fill(int64_t* seg_offsets, float* distances);
+
+ private:
+    using ResultPair = std::pair<float, int64_t>;
+
+    int64_t num_queries_;
+    int64_t dim_;
+    int64_t topk_;
+    int64_t element_size_;
+    bool range_search_ = false;
+    float radius_;
+    float range_filter_;
+    // the queries copied to be aligned to the elements
+    std::vector<uint64_t> queries_;
+    // max heaps, the farthest of the topk nearest ones is on the top
+    std::vector<std::priority_queue<ResultPair>> results_;
+};
+
+// MinHashSearch searches the topk nearest signatures of the base for each query,
+// see MinHashSearcher.
+void
+MinHashSearch(const uint8_t* base,
+              int64_t num_base,
+              const uint8_t* queries,
+              int64_t num_queries,
+              int64_t dim,
+              int64_t topk,
+              const Config& config,
+              const BitsetView& bitset,
+              int64_t* seg_offsets,
+              float* distances);
+
+}  // namespace milvus
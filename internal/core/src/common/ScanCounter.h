@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+#pragma once
+
+#include <atomic>
+#include <cstdint>
+
+#include "fmt/core.h"
+
+#include "common/EasyAssert.h"
+
+namespace milvus {
+
+// ScanCounter counts the rows scanned by a request over all the segments it searches or retrieves,
+// the segments charge the rows before scanning them, so the request fails once the rows exceed
+// the limit, without scanning the rest.
+class ScanCounter {
+ public:
+    // non-positive limit means unlimited
+    explicit ScanCounter(int64_t limit) : limit_(limit) {
+    }
+
+    void
+    Charge(int64_t rows) {
+        auto scanned = scanned_.fetch_add(rows) + rows;
+        if (limit_ > 0 && scanned > limit_) {
+            throw SegcoreError(
+                ScanLimitExceeded,
+                fmt::format("too many rows to scan, scanned {} rows, limit {}",
+                            scanned,
+                            limit_));
+        }
+    }
+
+    int64_t
+    Scanned() const {
+        return scanned_.load();
+    }
+
+ private:
+    const int64_t limit_;
+    std::atomic<int64_t> scanned_{0};
+};
+
+}  // namespace milvus
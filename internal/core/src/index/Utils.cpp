@@ -30,6 +30,7 @@
 #include <google/protobuf/text_format.h>
 #include <unistd.h>
 #include "common/EasyAssert.h"
+#include "common/MinHash.h"
 #include "knowhere/comp/index_param.h"
 #include "common/Slice.h"
 #include "storage/FieldData.h"
@@ -83,6 +84,10 @@ is_in_nm_list(const IndexType& index_type) {
 
 bool
 is_unsupported(const IndexType& index_type, const MetricType& metric_type) {
+    // MinHash signatures are searched over the raw vectors of BIN_FLAT only
+    if (IsMinHashMetricType(metric_type)) {
+        return index_type != knowhere::IndexEnum::INDEX_FAISS_BIN_IDMAP;
+    }
     return is_in_list<std::tuple<IndexType, MetricType>>(
         std::make_tuple(index_type, metric_type),
         unsupported_index_combinations);
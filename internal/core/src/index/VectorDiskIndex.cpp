@@ -105,7 +105,11 @@ VectorDiskAnnIndex<T>::Load(const Config& config) {
         GetValueFromConfig<std::vector<std::string>>(config, "index_files");
     AssertInfo(index_files.has_value(),
                "index file paths is empty when load disk ann index data");
-    file_manager_->CacheIndexToDisk(index_files.value());
+    auto reuse_local =
+        GetValueFromConfig<std::string>(config, REUSE_LOCAL_INDEX_KEY);
+    file_manager_->CacheIndexToDisk(
+        index_files.value(),
+        reuse_local.has_value() && reuse_local.value() == "true");
 
     auto stat = index_.Deserialize(knowhere::BinarySet(), load_config);
     if (stat != knowhere::Status::success)
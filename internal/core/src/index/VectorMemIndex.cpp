@@ -19,9 +19,11 @@
 #include <unistd.h>
 #include <cmath>
 #include <cstdint>
+#include <algorithm>
 #include <cstring>
 #include <filesystem>
 #include <memory>
+#include <numeric>
 #include <string>
 #include <unordered_map>
 #include <unordered_set>
@@ -40,6 +42,7 @@
 #include "common/BitsetView.h"
 #include "common/Slice.h"
 #include "common/Consts.h"
+#include "common/MinHash.h"
 #include "common/RangeSearchHelper.h"
 #include "common/Utils.h"
 #include "log/Log.h"
@@ -157,7 +160,7 @@ template <typename T>
 void
 VectorMemIndex<T>::LoadWithoutAssemble(const BinarySet& binary_set,
                                        const Config& config) {
-    auto stat = index_.Deserialize(binary_set, config);
+    auto stat = index_.Deserialize(binary_set, ToKnowhereConfig(config));
     if (stat != knowhere::Status::success)
         PanicInfo(ErrorCode::UnexpectedError,
                   "failed to Deserialize index, " + KnowhereStatusString(stat));
@@ -386,8 +389,7 @@ template <typename T>
 void
 VectorMemIndex<T>::BuildWithDataset(const DatasetPtr& dataset,
                                     const Config& config) {
-    knowhere::Json index_config;
-    index_config.update(config);
+    auto index_config = ToKnowhereConfig(config);
 
     SetDim(dataset->GetDim());
 
@@ -500,8 +502,7 @@ template <typename T>
 void
 VectorMemIndex<T>::AddWithDataset(const DatasetPtr& dataset,
                                   const Config& config) {
-    knowhere::Json index_config;
-    index_config.update(config);
+    auto index_config = ToKnowhereConfig(config);
 
     knowhere::TimeRecorder rc("AddWithDataset", 1);
     auto stat = index_.Add(*dataset, index_config);
@@ -527,6 +528,30 @@ VectorMemIndex<T>::Query(const DatasetPtr dataset,
         search_conf[knowhere::meta::TOPK] = topk;
         search_conf[knowhere::meta::METRIC_TYPE] = GetMetricType();
         auto index_type = GetIndexType();
+        if (IsMinHashMetricType(GetMetricType())) {
+            if (!CheckKeyInConfig(search_conf, MH_ELEMENT_BIT_WIDTH)) {
+                search_conf[MH_ELEMENT_BIT_WIDTH] = minhash_element_size_ * 8;
+            }
+            if (CheckKeyInConfig(search_conf, RADIUS) &&
+                CheckKeyInConfig(search_conf, RANGE_FILTER)) {
+                CheckRangeSearchParam(search_conf[RADIUS],
+                                      search_conf[RANGE_FILTER],
+                                      GetMetricType());
+            }
+            auto p_id = new int64_t[topk * num_queries];
+            auto p_dist = new float[topk * num_queries];
+            milvus::tracer::AddEvent("start_minhash_search");
+            MinHashSearcher searcher(
+                static_cast<const uint8_t*>(dataset->GetTensor()),
+                num_queries,
+                GetDim(),
+                topk,
+                search_conf);
+            SearchMinHash(searcher, bitset);
+            searcher.Fill(p_id, p_dist);
+            milvus::tracer::AddEvent("finish_minhash_search");
+            return GenResultDataset(num_queries, topk, p_id, p_dist);
+        }
         if (CheckKeyInConfig(search_conf, RADIUS)) {
             if (CheckKeyInConfig(search_conf, RANGE_FILTER)) {
                 CheckRangeSearchParam(search_conf[RADIUS],
@@ -587,6 +612,9 @@ VectorMemIndex<T>::Query(const DatasetPtr dataset,
 template <typename T>
 const bool
 VectorMemIndex<T>::HasRawData() const {
+    if (IsMinHashMetricType(GetMetricType())) {
+        return index_.HasRawData(knowhere::metric::HAMMING);
+    }
     return index_.HasRawData(GetMetricType());
 }
 
@@ -614,6 +642,40 @@ VectorMemIndex<T>::GetVector(const DatasetPtr dataset) const {
     return raw_data;
 }
 
+template <typename T>
+knowhere::Json
+VectorMemIndex<T>::ToKnowhereConfig(const Config& config) {
+    knowhere::Json conf;
+    conf.update(config);
+    if (IsMinHashMetricType(GetMetricType())) {
+        minhash_element_size_ = GetMinHashElementSize(config);
+        conf[knowhere::meta::METRIC_TYPE] = knowhere::metric::HAMMING;
+    }
+    return conf;
+}
+
+template <typename T>
+void
+VectorMemIndex<T>::SearchMinHash(MinHashSearcher& searcher,
+                                 const BitsetView& bitset) const {
+    // the signatures are read from the index batch by batch, instead of
+    // keeping a copy of all the raw vectors out of the index
+    constexpr int64_t batch_rows = 4096;
+    auto num_rows = index_.Count();
+    std::vector<int64_t> seg_offsets(std::min(batch_rows, num_rows));
+    for (int64_t begin = 0; begin < num_rows; begin += batch_rows) {
+        auto rows = std::min(batch_rows, num_rows - begin);
+        std::iota(seg_offsets.begin(), seg_offsets.begin() + rows, begin);
+        auto ids_ds = std::make_shared<knowhere::DataSet>();
+        ids_ds->SetRows(rows);
+        ids_ds->SetDim(1);
+        ids_ds->SetIds(seg_offsets.data());
+        ids_ds->SetIsOwner(false);
+        auto signatures = GetVector(ids_ds);
+        searcher.Search(signatures.data(), rows, begin, bitset);
+    }
+}
+
 template <typename T>
 void
 VectorMemIndex<T>::LoadFromFile(const Config& config) {
@@ -711,7 +773,7 @@ VectorMemIndex<T>::LoadFromFile(const Config& config) {
     file.Close();
 
     LOG_SEGCORE_INFO_ << "load index into Knowhere...";
-    auto conf = config;
+    auto conf = ToKnowhereConfig(config);
     conf.erase(kMmapFilepath);
     conf[kEnableMmap] = true;
     auto stat = index_.DeserializeFromFile(filepath.value(), conf);
@@ -815,7 +877,7 @@ VectorMemIndex<T>::LoadFromFileV2(const Config& config) {
     file.Close();
 
     LOG_SEGCORE_INFO_ << "load index into Knowhere...";
-    auto conf = config;
+    auto conf = ToKnowhereConfig(config);
     conf.erase(kMmapFilepath);
     conf[kEnableMmap] = true;
     auto stat = index_.DeserializeFromFile(filepath.value(), conf);
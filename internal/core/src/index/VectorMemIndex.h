@@ -21,6 +21,7 @@
 #include <string>
 #include <vector>
 #include <boost/dynamic_bitset.hpp>
+#include "common/MinHash.h"
 #include "common/Types.h"
 #include "knowhere/factory.h"
 #include "index/VectorIndex.h"
@@ -101,6 +102,15 @@ class VectorMemIndex : public VectorIndex {
     void
     LoadFromFileV2(const Config& config);
 
+    // MinHash signatures are kept by the BIN_FLAT index of knowhere,
+    // which doesn't know the metric MHJACCARD, so they're searched
+    // over the raw vectors of the index
+    knowhere::Json
+    ToKnowhereConfig(const Config& config);
+
+    void
+    SearchMinHash(MinHashSearcher& searcher, const BitsetView& bitset) const;
+
  protected:
     Config config_;
     knowhere::Index<knowhere::IndexNode> index_;
@@ -108,6 +118,8 @@ class VectorMemIndex : public VectorIndex {
     std::shared_ptr<milvus_storage::Space> space_;
 
     CreateIndexInfo create_index_info_;
+
+    int64_t minhash_element_size_ = DEFAULT_MH_ELEMENT_BIT_WIDTH / 8;
 };
 
 template <typename T>
@@ -30,6 +30,7 @@
 #include "indexbuilder/types.h"
 #include "index/Utils.h"
 #include "pb/index_cgo_msg.pb.h"
+#include "storage/RoutingChunkManager.h"
 #include "storage/Util.h"
 #include "storage/space.h"
 #include "index/Meta.h"
@@ -122,8 +123,23 @@ CreateIndex(CIndex* res_index, CBuildIndexInfo c_build_index_info) {
                                               build_index_info->field_id,
                                               build_index_info->index_build_id,
                                               build_index_info->index_version};
-        auto chunk_manager = milvus::storage::CreateChunkManager(
-            build_index_info->storage_config);
+        milvus::storage::ChunkManagerPtr chunk_manager =
+            milvus::storage::CreateChunkManager(
+                build_index_info->storage_config);
+        if (build_index_info->data_storage_config.has_value()) {
+            // read the binlogs from the bucket the collection is routed to
+            const std::string data_profile = "data";
+            auto routing_chunk_manager =
+                std::make_shared<milvus::storage::RoutingChunkManager>(
+                    chunk_manager);
+            routing_chunk_manager->AddProfile(
+                data_profile,
+                milvus::storage::CreateChunkManager(
+                    build_index_info->data_storage_config.value()));
+            routing_chunk_manager->SetRoute(build_index_info->collection_id,
+                                            data_profile);
+            chunk_manager = routing_chunk_manager;
+        }
 
         milvus::storage::FileManagerContext fileManagerContext(
             field_meta, index_meta, chunk_manager);
@@ -575,6 +591,37 @@ AppendInsertFilePath(CBuildIndexInfo c_build_index_info,
     }
 }
 
+CStatus
+AppendDataStorageConfig(CBuildIndexInfo c_build_index_info,
+                        CStorageConfig c_storage_config) {
+    try {
+        auto build_index_info = (BuildIndexInfo*)c_build_index_info;
+        milvus::storage::StorageConfig storage_config;
+        storage_config.address = std::string(c_storage_config.address);
+        storage_config.bucket_name = std::string(c_storage_config.bucket_name);
+        storage_config.access_key_id =
+            std::string(c_storage_config.access_key_id);
+        storage_config.access_key_value =
+            std::string(c_storage_config.access_key_value);
+        storage_config.root_path = std::string(c_storage_config.root_path);
+        storage_config.storage_type =
+            std::string(c_storage_config.storage_type);
+        storage_config.cloud_provider =
+            std::string(c_storage_config.cloud_provider);
+        storage_config.iam_endpoint =
+            std::string(c_storage_config.iam_endpoint);
+        storage_config.useSSL = c_storage_config.useSSL;
+        storage_config.useIAM = c_storage_config.useIAM;
+        storage_config.region = c_storage_config.region;
+        storage_config.useVirtualHost = c_storage_config.useVirtualHost;
+        storage_config.requestTimeoutMs = c_storage_config.requestTimeoutMs;
+        build_index_info->data_storage_config = storage_config;
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(&e);
+    }
+}
+
 CStatus
 AppendIndexEngineVersionToBuildInfo(CBuildIndexInfo c_load_index_info,
                                     int32_t index_engine_version) {
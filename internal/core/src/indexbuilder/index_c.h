@@ -99,6 +99,10 @@ AppendIndexMetaInfo(CBuildIndexInfo c_build_index_info,
 CStatus
 AppendInsertFilePath(CBuildIndexInfo c_build_index_info, const char* file_path);
 
+CStatus
+AppendDataStorageConfig(CBuildIndexInfo c_build_index_info,
+                        CStorageConfig c_storage_config);
+
 CStatus
 AppendIndexEngineVersionToBuildInfo(CBuildIndexInfo c_load_index_info,
                                     int32_t c_index_engine_version);
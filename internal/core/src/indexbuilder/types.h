@@ -15,6 +15,7 @@
 // limitations under the License.
 
 #include <stdint.h>
+#include <optional>
 #include <string>
 #include <vector>
 #include "common/Types.h"
@@ -32,6 +33,9 @@ struct BuildIndexInfo {
     int64_t index_version;
     std::vector<std::string> insert_files;
     milvus::storage::StorageConfig storage_config;
+    // the bucket storing the binlogs if the collection is routed to a bucket profile,
+    // the index files are always written to the bucket of storage_config
+    std::optional<milvus::storage::StorageConfig> data_storage_config;
     milvus::Config config;
     std::string field_name;
     std::string data_store_path;
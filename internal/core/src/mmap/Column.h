@@ -20,6 +20,9 @@
 #include <cstddef>
 #include <cstring>
 #include <filesystem>
+#include <string_view>
+#include <unordered_map>
+#include <vector>
 
 #include "common/FieldMeta.h"
 #include "common/Span.h"
@@ -267,28 +270,47 @@ class VariableColumn : public ColumnBase {
     VariableColumn(VariableColumn&& column) noexcept
         : ColumnBase(std::move(column)),
           indices_(std::move(column.indices_)),
-          views_(std::move(column.views_)) {
+          views_(std::move(column.views_)),
+          codes_(std::move(column.codes_)),
+          dictionary_data_(std::move(column.dictionary_data_)),
+          dictionary_(std::move(column.dictionary_)) {
     }
 
     ~VariableColumn() override = default;
 
+    // the dictionary encoded column has no views of the rows,
+    // access the rows by RawAt or the codes instead
     SpanBase
     Span() const override {
+        AssertInfo(!IsDictionaryEncoded(),
+                   "no span of the dictionary encoded column");
         return SpanBase(views_.data(), views_.size(), sizeof(ViewType));
     }
 
     [[nodiscard]] const std::vector<ViewType>&
     Views() const {
+        AssertInfo(!IsDictionaryEncoded(),
+                   "no views of the dictionary encoded column");
         return views_;
     }
 
     ViewType
     operator[](const int i) const {
+        if constexpr (std::is_same_v<T, std::string>) {
+            if (IsDictionaryEncoded()) {
+                return dictionary_[codes_[i]];
+            }
+        }
         return views_[i];
     }
 
     std::string_view
     RawAt(const int i) const {
+        if constexpr (std::is_same_v<T, std::string>) {
+            if (IsDictionaryEncoded()) {
+                return dictionary_[codes_[i]];
+            }
+        }
         size_t len = (i == indices_.size() - 1) ? size_ - indices_.back()
                                                 : indices_[i + 1] - indices_[i];
         return std::string_view(data_ + indices_[i], len);
@@ -309,6 +331,85 @@ class VariableColumn : public ColumnBase {
         ConstructViews();
     }
 
+    // Replace the values with the codes of the distinct values if there are
+    // no more than max_cardinality distinct ones, only for the sealed column
+    // in memory mode. The views of the rows are dropped after encoded, so the
+    // column keeps only a code per row besides the distinct values.
+    // Returns whether the column is encoded.
+    bool
+    EncodeDictionary(size_t max_cardinality) {
+        static_assert(std::is_same_v<T, std::string>,
+                      "only string column could be dictionary encoded");
+        if (max_cardinality == 0 || views_.empty() || IsDictionaryEncoded()) {
+            return false;
+        }
+
+        std::unordered_map<std::string_view, uint32_t> lookup;
+        std::vector<uint32_t> codes;
+        codes.reserve(views_.size());
+        for (const auto& view : views_) {
+            auto it = lookup.emplace(view, lookup.size()).first;
+            if (lookup.size() > max_cardinality) {
+                return false;
+            }
+            codes.push_back(it->second);
+        }
+        if (lookup.size() >= views_.size()) {
+            return false;
+        }
+
+        // copy the distinct values into a compact buffer,
+        // the lookup refers to the original data so it's freed after that
+        std::vector<std::pair<size_t, size_t>> ranges(lookup.size());
+        size_t dictionary_size = 0;
+        for (const auto& [value, code] : lookup) {
+            ranges[code] = {dictionary_size, value.size()};
+            dictionary_size += value.size();
+        }
+        dictionary_data_.resize(dictionary_size);
+        for (const auto& [value, code] : lookup) {
+            std::copy_n(value.data(),
+                        value.size(),
+                        dictionary_data_.data() + ranges[code].first);
+        }
+        dictionary_.clear();
+        dictionary_.reserve(ranges.size());
+        for (const auto& [offset, size] : ranges) {
+            dictionary_.emplace_back(dictionary_data_.data() + offset, size);
+        }
+        codes_ = std::move(codes);
+        std::vector<ViewType>().swap(views_);
+
+        std::vector<uint64_t>().swap(indices_);
+        if (data_ != nullptr && munmap(data_, cap_size_ + padding_)) {
+            AssertInfo(false,
+                       "failed to unmap encoded variable field, err={}",
+                       strerror(errno));
+        }
+        data_ = nullptr;
+        cap_size_ = 0;
+        size_ = 0;
+        return true;
+    }
+
+    bool
+    IsDictionaryEncoded() const {
+        return !codes_.empty();
+    }
+
+    // the code of each row, only if the column is dictionary encoded
+    const std::vector<uint32_t>&
+    Codes() const {
+        return codes_;
+    }
+
+    // the distinct values indexed by the codes,
+    // only if the column is dictionary encoded
+    const std::vector<std::string_view>&
+    Dictionary() const {
+        return dictionary_;
+    }
+
  protected:
     void
     ConstructViews() {
@@ -325,6 +426,11 @@ class VariableColumn : public ColumnBase {
 
     // Compatible with current Span type
     std::vector<ViewType> views_{};
+
+    // dictionary encoding of low-cardinality strings
+    std::vector<uint32_t> codes_{};
+    std::vector<char> dictionary_data_{};
+    std::vector<std::string_view> dictionary_{};
 };
 
 class ArrayColumn : public ColumnBase {
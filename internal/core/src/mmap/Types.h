@@ -83,6 +83,9 @@ struct FieldDataInfo {
     int64_t field_id;
     size_t row_count;
     std::string mmap_dir_path;
+    // keep the mmap file of fixed width field on local disk after mapped,
+    // so that it could be reused after the process restarted
+    bool keep_mmap_file{false};
     storage::FieldDataChannelPtr channel;
 };
 }  // namespace milvus
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+#include <algorithm>
+#include <cmath>
+#include <limits>
+
+#include "common/EasyAssert.h"
+#include "common/Utils.h"
+#include "query/SQ8Quantizer.h"
+
+namespace milvus::query {
+
+namespace {
+float
+Norm(const float* vec, int64_t dim) {
+    float sum = 0;
+    for (int64_t d = 0; d < dim; d++) {
+        sum += vec[d] * vec[d];
+    }
+    return std::sqrt(sum);
+}
+}  // namespace
+
+SQ8Quantizer::SQ8Quantizer(const float* data, int64_t num_rows, int64_t dim)
+    : num_rows_(num_rows),
+      dim_(dim),
+      min_(dim, std::numeric_limits<float>::max()),
+      step_(dim, 0),
+      norms_(num_rows, 0),
+      codes_(num_rows * dim) {
+    AssertInfo(dim > 0, "invalid dim {} to quantize", dim);
+    std::vector<float> max(dim, std::numeric_limits<float>::lowest());
+    for (int64_t row = 0; row < num_rows; row++) {
+        for (int64_t d = 0; d < dim; d++) {
+            auto value = data[row * dim + d];
+            min_[d] = std::min(min_[d], value);
+            max[d] = std::max(max[d], value);
+        }
+    }
+    for (int64_t d = 0; d < dim; d++) {
+        step_[d] = num_rows > 0 ? (max[d] - min_[d]) / 255 : 0;
+    }
+
+    std::vector<float> decoded(dim);
+    for (int64_t row = 0; row < num_rows; row++) {
+        for (int64_t d = 0; d < dim; d++) {
+            uint8_t code = 0;
+            if (step_[d] > 0) {
+                auto value = (data[row * dim + d] - min_[d]) / step_[d];
+                code = static_cast<uint8_t>(
+                    std::clamp(std::round(value), 0.0f, 255.0f));
+            }
+            codes_[row * dim + d] = code;
+            decoded[d] = decode(row, d);
+        }
+        norms_[row] = Norm(decoded.data(), dim);
+    }
+}
+
+float
+SQ8Quantizer::Distance(const MetricType& metric_type,
+                       const float* query,
+                       int64_t row) const {
+    if (IsMetricType(metric_type, knowhere::metric::L2)) {
+        float sum = 0;
+        for (int64_t d = 0; d < dim_; d++) {
+            auto diff = query[d] - decode(row, d);
+            sum += diff * diff;
+        }
+        return sum;
+    }
+
+    float ip = 0;
+    for (int64_t d = 0; d < dim_; d++) {
+        ip += query[d] * decode(row, d);
+    }
+    if (IsMetricType(metric_type, knowhere::metric::COSINE)) {
+        auto norm = Norm(query, dim_) * norms_[row];
+        return norm > 0 ? ip / norm : 0;
+    }
+    return ip;
+}
+
+float
+ExactDistance(const MetricType& metric_type,
+              const float* query,
+              const float* vec,
+              int64_t dim) {
+    if (IsMetricType(metric_type, knowhere::metric::L2)) {
+        float sum = 0;
+        for (int64_t d = 0; d < dim; d++) {
+            auto diff = query[d] - vec[d];
+            sum += diff * diff;
+        }
+        return sum;
+    }
+
+    float ip = 0;
+    for (int64_t d = 0; d < dim; d++) {
+        ip += query[d] * vec[d];
+    }
+    if (IsMetricType(metric_type, knowhere::metric::COSINE)) {
+        auto norm = Norm(query, dim) * Norm(vec, dim);
+        return norm > 0 ? ip / norm : 0;
+    }
+    return ip;
+}
+
+}  // namespace milvus::query
@@ -14,6 +14,7 @@
 
 #include "common/Consts.h"
 #include "common/EasyAssert.h"
+#include "common/MinHash.h"
 #include "common/RangeSearchHelper.h"
 #include "common/Utils.h"
 #include "common/Tracer.h"
@@ -36,6 +37,18 @@ CheckBruteForceSearchParam(const FieldMeta& field,
     bool is_float_metric_type = IsFloatMetricType(metric_type);
     AssertInfo(is_float_data_type == is_float_metric_type,
                "[BruteForceSearch] Data type and metric type miss-match");
+    if (IsMinHashMetricType(metric_type)) {
+        AssertInfo(data_type == DataType::VECTOR_BINARY,
+                   "[BruteForceSearch] MinHash signatures must be binary "
+                   "vectors");
+        auto bit_width =
+            GetMinHashElementSize(search_info.search_params_) * 8;
+        AssertInfo(field.get_dim() % bit_width == 0,
+                   "[BruteForceSearch] dim {} isn't a multiple of {} {}",
+                   field.get_dim(),
+                   MH_ELEMENT_BIT_WIDTH,
+                   bit_width);
+    }
 }
 
 SubSearchResult
@@ -53,6 +66,28 @@ BruteForceSearch(const dataset::SearchDataset& dataset,
     auto dim = dataset.dim;
     auto topk = dataset.topk;
 
+    if (IsMinHashMetricType(dataset.metric_type)) {
+        // knowhere doesn't know MinHash signatures, search them in place
+        if (conf.contains(RADIUS) && conf.contains(RANGE_FILTER)) {
+            CheckRangeSearchParam(conf[RADIUS],
+                                  conf[RANGE_FILTER],
+                                  dataset.metric_type);
+        }
+        MinHashSearch(static_cast<const uint8_t*>(chunk_data_raw),
+                      chunk_rows,
+                      static_cast<const uint8_t*>(dataset.query_data),
+                      nq,
+                      dim,
+                      topk,
+                      conf,
+                      bitset,
+                      sub_result.mutable_seg_offsets().data(),
+                      sub_result.mutable_distances().data());
+        milvus::tracer::AddEvent("finish_MinHashSearch");
+        sub_result.round_values();
+        return sub_result;
+    }
+
     auto base_dataset = knowhere::GenDataSet(chunk_rows, dim, chunk_data_raw);
     auto query_dataset = knowhere::GenDataSet(nq, dim, dataset.query_data);
 
@@ -9,14 +9,20 @@
 // is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
+#include <algorithm>
 #include <cmath>
+#include <queue>
 #include <string>
+#include <utility>
+#include <vector>
 
 #include "common/QueryInfo.h"
 #include "common/Types.h"
 #include "query/SearchBruteForce.h"
 #include "query/SearchOnSealed.h"
 #include "query/helper.h"
+#include "query/SubSearchResult.h"
+#include "segcore/SegcoreConfig.h"
 
 namespace milvus::query {
 
@@ -99,4 +105,82 @@ SearchOnSealed(const Schema& schema,
     result.total_nq_ = dataset.num_queries;
 }
 
+void
+SearchOnQuantized(const Schema& schema,
+                  const SQ8Quantizer& quantizer,
+                  const void* vec_data,
+                  const SearchInfo& search_info,
+                  const void* query_data,
+                  int64_t num_queries,
+                  const BitsetView& bitset,
+                  SearchResult& result) {
+    auto& field = schema[search_info.field_id_];
+    CheckBruteForceSearchParam(field, search_info);
+    auto& metric_type = search_info.metric_type_;
+    auto topk = search_info.topk_;
+    auto dim = quantizer.dim();
+    auto row_count = quantizer.num_rows();
+    auto raw = static_cast<const float*>(vec_data);
+    auto queries = static_cast<const float*>(query_data);
+
+    auto refine_ratio = std::max<int64_t>(
+        segcore::SegcoreConfig::default_config()
+            .get_quantization_refine_ratio(),
+        1);
+    auto num_candidates = std::min(topk * refine_ratio, row_count);
+    // the smaller key is the better, whatever the metric type is
+    auto positive = PositivelyRelated(metric_type);
+    auto key = [positive](float distance) {
+        return positive ? -distance : distance;
+    };
+
+    SubSearchResult sub_result(
+        num_queries, topk, metric_type, search_info.round_decimal_);
+    auto seg_offsets = sub_result.get_seg_offsets();
+    auto distances = sub_result.get_distances();
+    for (int64_t i = 0; i < num_queries; i++) {
+        auto query = queries + i * dim;
+
+        // keep the best candidates by the approximate distances,
+        // the worst one on the top
+        std::priority_queue<std::pair<float, int64_t>> heap;
+        for (int64_t row = 0; row < row_count; row++) {
+            if (!bitset.empty() && bitset.test(row)) {
+                continue;
+            }
+            auto k = key(quantizer.Distance(metric_type, query, row));
+            if (static_cast<int64_t>(heap.size()) < num_candidates) {
+                heap.emplace(k, row);
+            } else if (k < heap.top().first) {
+                heap.pop();
+                heap.emplace(k, row);
+            }
+        }
+
+        std::vector<std::pair<float, int64_t>> refined;
+        refined.reserve(heap.size());
+        while (!heap.empty()) {
+            auto row = heap.top().second;
+            heap.pop();
+            auto distance =
+                ExactDistance(metric_type, query, raw + row * dim, dim);
+            refined.emplace_back(key(distance), row);
+        }
+        std::sort(refined.begin(), refined.end());
+
+        auto n = std::min<int64_t>(topk, refined.size());
+        for (int64_t j = 0; j < n; j++) {
+            seg_offsets[i * topk + j] = refined[j].second;
+            distances[i * topk + j] =
+                positive ? -refined[j].first : refined[j].first;
+        }
+    }
+    sub_result.round_values();
+
+    result.distances_ = std::move(sub_result.mutable_distances());
+    result.seg_offsets_ = std::move(sub_result.mutable_seg_offsets());
+    result.unity_topK_ = topk;
+    result.total_nq_ = num_queries;
+}
+
 }  // namespace milvus::query
@@ -13,6 +13,7 @@
 
 #include "common/BitsetView.h"
 #include "query/PlanNode.h"
+#include "query/SQ8Quantizer.h"
 #include "query/SearchOnGrowing.h"
 #include "segcore/SealedIndexingRecord.h"
 
@@ -37,4 +38,16 @@ SearchOnSealed(const Schema& schema,
                const BitsetView& bitset,
                SearchResult& result);
 
+// search the candidates by the quantized vectors, and refine them by the raw
+// vectors, which are usually mmapped so only the candidates are paged in
+void
+SearchOnQuantized(const Schema& schema,
+                  const SQ8Quantizer& quantizer,
+                  const void* vec_data,
+                  const SearchInfo& search_info,
+                  const void* query_data,
+                  int64_t num_queries,
+                  const BitsetView& bitset,
+                  SearchResult& result);
+
 }  // namespace milvus::query
@@ -13,6 +13,7 @@
 // Generated File
 // DO NOT EDIT
 #include "common/Json.h"
+#include "common/ScanCounter.h"
 #include "query/PlanImpl.h"
 #include "segcore/SegmentGrowing.h"
 #include <utility>
@@ -36,15 +37,20 @@ class ExecPlanNodeVisitor : public PlanNodeVisitor {
  public:
     ExecPlanNodeVisitor(const segcore::SegmentInterface& segment,
                         Timestamp timestamp,
-                        const PlaceholderGroup* placeholder_group)
+                        const PlaceholderGroup* placeholder_group,
+                        ScanCounter* scan_counter = nullptr)
         : segment_(segment),
           timestamp_(timestamp),
-          placeholder_group_(placeholder_group) {
+          placeholder_group_(placeholder_group),
+          scan_counter_(scan_counter) {
     }
 
     ExecPlanNodeVisitor(const segcore::SegmentInterface& segment,
-                        Timestamp timestamp)
-        : segment_(segment), timestamp_(timestamp) {
+                        Timestamp timestamp,
+                        ScanCounter* scan_counter = nullptr)
+        : segment_(segment),
+          timestamp_(timestamp),
+          scan_counter_(scan_counter) {
         placeholder_group_ = nullptr;
     }
 
@@ -101,10 +107,18 @@ class ExecPlanNodeVisitor : public PlanNodeVisitor {
     void
     VectorVisitorImpl(VectorPlanNode& node);
 
+    void
+    charge_scanned_rows(int64_t rows) {
+        if (scan_counter_ != nullptr) {
+            scan_counter_->Charge(rows);
+        }
+    }
+
  private:
     const segcore::SegmentInterface& segment_;
     Timestamp timestamp_;
     const PlaceholderGroup* placeholder_group_;
+    ScanCounter* scan_counter_ = nullptr;
 
     SearchResultOpt search_result_opt_;
     RetrieveResultOpt retrieve_result_opt_;
@@ -272,6 +272,27 @@ AssembleChunk(const std::vector<FixedVector<bool>>& results) {
     return assemble_result;
 }
 
+// evaluate the element func once per distinct value of the dictionary encoded
+// column, then map the results to the rows by their codes
+template <typename ElementFunc>
+FixedVector<bool>
+ExecOnDictionary(const VariableColumn<std::string>& column,
+                 int64_t offset,
+                 int64_t size,
+                 ElementFunc element_func) {
+    const auto& dictionary = column.Dictionary();
+    FixedVector<bool> matched(dictionary.size());
+    for (size_t code = 0; code < dictionary.size(); ++code) {
+        matched[code] = element_func(dictionary[code]);
+    }
+    const auto& codes = column.Codes();
+    FixedVector<bool> result(size);
+    for (int64_t i = 0; i < size; ++i) {
+        result[i] = matched[codes[offset + i]];
+    }
+    return result;
+}
+
 template <typename T,
           typename IndexFunc,
           typename ElementFunc,
@@ -316,6 +337,15 @@ ExecExprVisitor::ExecRangeVisitorImpl(FieldId field_id,
             results.emplace_back(std::move(chunk_res));
             continue;
         }
+        if constexpr (std::is_same_v<T, std::string_view>) {
+            if (auto column = segment_.get_dictionary_column(field_id)) {
+                results.emplace_back(ExecOnDictionary(*column,
+                                                      chunk_id * size_per_chunk,
+                                                      this_size,
+                                                      element_func));
+                continue;
+            }
+        }
         auto chunk = segment_.chunk_data<T>(field_id, chunk_id);
         const T* data = chunk.data();
         // Can use CPU SIMD optimazation to speed up
@@ -356,6 +386,15 @@ ExecExprVisitor::ExecDataRangeVisitorImpl(FieldId field_id,
         auto this_size = chunk_id == num_chunk - 1
                              ? row_count_ - chunk_id * size_per_chunk
                              : size_per_chunk;
+        if constexpr (std::is_same_v<T, std::string_view>) {
+            if (auto column = segment_.get_dictionary_column(field_id)) {
+                results.emplace_back(ExecOnDictionary(*column,
+                                                      chunk_id * size_per_chunk,
+                                                      this_size,
+                                                      element_func));
+                continue;
+            }
+        }
         FixedVector<bool> result(this_size);
         auto chunk = segment_.chunk_data<T>(field_id, chunk_id);
         const T* data = chunk.data();
@@ -2453,6 +2492,14 @@ ExecExprVisitor::ExecCompareExprDispatcher(CompareExpr& expr, Op op)
                             return [chunk_data](int i) -> const number {
                                 return chunk_data[i];
                             };
+                        } else if (auto column =
+                                       segment_.get_dictionary_column(
+                                           field_id)) {
+                            // the dictionary encoded column has no views
+                            auto offset = chunk_id * segment_.size_per_chunk();
+                            return [column, offset](int i) -> const number {
+                                return std::string(column->RawAt(offset + i));
+                            };
                         } else {
                             auto chunk_data = segment_
                                                   .chunk_data<std::string_view>(
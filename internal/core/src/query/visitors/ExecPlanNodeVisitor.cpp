@@ -96,6 +96,8 @@ ExecPlanNodeVisitor::VectorVisitorImpl(VectorPlanNode& node) {
             empty_search_result(num_queries, node.search_info_);
         return;
     }
+    // the rows are filtered and searched
+    charge_scanned_rows(active_count);
 
     std::unique_ptr<BitsetType> bitset_holder;
     if (node.predicate_.has_value()) {
@@ -127,6 +129,20 @@ ExecPlanNodeVisitor::VectorVisitorImpl(VectorPlanNode& node) {
     search_result_opt_ = std::move(search_result);
 }
 
+// is_primary_key_term returns whether the predicate is a term expr on the primary key,
+// which is looked up by the pk index instead of scanning the segment.
+static bool
+is_primary_key_term(const segcore::SegmentInternalInterface& segment,
+                    const Expr* predicate) {
+    auto term_expr = dynamic_cast<const TermExpr*>(predicate);
+    if (term_expr == nullptr) {
+        return false;
+    }
+    auto pk_field_id = segment.get_schema().get_primary_field_id();
+    return pk_field_id.has_value() &&
+           pk_field_id.value() == term_expr->column_.field_id;
+}
+
 std::unique_ptr<RetrieveResult>
 wrap_num_entities(int64_t cnt) {
     auto retrieve_result = std::make_unique<RetrieveResult>();
@@ -166,10 +182,21 @@ ExecPlanNodeVisitor::visit(RetrievePlanNode& node) {
     }
 
     if (node.predicate_.has_value() && node.predicate_.value() != nullptr) {
+        auto pk_term =
+            is_primary_key_term(*segment, node.predicate_.value().get());
+        if (!pk_term) {
+            charge_scanned_rows(active_count);
+        }
         bitset_holder =
             ExecExprVisitor(*segment, this, active_count, timestamp_)
                 .call_child(*(node.predicate_.value()));
         bitset_holder.flip();
+        if (pk_term) {
+            // only the rows of the primary keys found are scanned
+            charge_scanned_rows(GetExprUsePkIndex()
+                                    ? expr_cached_pk_id_offsets_.size()
+                                    : active_count);
+        }
     }
 
     segment->mask_with_timestamps(bitset_holder, timestamp_);
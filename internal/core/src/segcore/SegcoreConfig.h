@@ -73,11 +73,37 @@ class SegcoreConfig {
         return enable_interim_segment_index_;
     }
 
+    void
+    set_dictionary_max_cardinality(int64_t max_cardinality) {
+        dictionary_max_cardinality_ = max_cardinality;
+    }
+
+    int64_t
+    get_dictionary_max_cardinality() const {
+        return dictionary_max_cardinality_;
+    }
+
+    void
+    set_quantization_refine_ratio(int64_t refine_ratio) {
+        quantization_refine_ratio_ = refine_ratio;
+    }
+
+    int64_t
+    get_quantization_refine_ratio() const {
+        return quantization_refine_ratio_;
+    }
+
  private:
     inline static bool enable_interim_segment_index_ = false;
     inline static int64_t chunk_rows_ = 32 * 1024;
     inline static int64_t nlist_ = 100;
     inline static int64_t nprobe_ = 4;
+    // the string fields of sealed segments with no more distinct values
+    // are dictionary encoded in memory, 0 to disable
+    inline static int64_t dictionary_max_cardinality_ = 0;
+    // the candidates of the search on the quantized vectors are
+    // topk * refine ratio, which are refined by the raw vectors
+    inline static int64_t quantization_refine_ratio_ = 4;
 };
 
 }  // namespace milvus::segcore
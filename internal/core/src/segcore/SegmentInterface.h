@@ -28,6 +28,7 @@
 #include "common/BitsetView.h"
 #include "common/QueryResult.h"
 #include "common/QueryInfo.h"
+#include "common/ScanCounter.h"
 #include "query/Plan.h"
 #include "query/PlanNode.h"
 #include "pb/schema.pb.h"
@@ -52,14 +53,17 @@ class SegmentInterface {
     virtual bool
     Contain(const PkType& pk) const = 0;
 
+    // the rows scanned are charged to the scan counter if it's not nullptr
     virtual std::unique_ptr<SearchResult>
     Search(const query::Plan* Plan,
-           const query::PlaceholderGroup* placeholder_group) const = 0;
+           const query::PlaceholderGroup* placeholder_group,
+           ScanCounter* scan_counter = nullptr) const = 0;
 
     virtual std::unique_ptr<proto::segcore::RetrieveResults>
     Retrieve(const query::RetrievePlan* Plan,
              Timestamp timestamp,
-             int64_t limit_size) const = 0;
+             int64_t limit_size,
+             ScanCounter* scan_counter = nullptr) const = 0;
 
     // TODO: memory use is not correct when load string or load string index
     virtual int64_t
@@ -136,7 +140,8 @@ class SegmentInternalInterface : public SegmentInterface {
 
     std::unique_ptr<SearchResult>
     Search(const query::Plan* Plan,
-           const query::PlaceholderGroup* placeholder_group) const override;
+           const query::PlaceholderGroup* placeholder_group,
+           ScanCounter* scan_counter = nullptr) const override;
 
     void
     FillPrimaryKeys(const query::Plan* plan,
@@ -149,7 +154,8 @@ class SegmentInternalInterface : public SegmentInterface {
     std::unique_ptr<proto::segcore::RetrieveResults>
     Retrieve(const query::RetrievePlan* Plan,
              Timestamp timestamp,
-             int64_t limit_size) const override;
+             int64_t limit_size,
+             ScanCounter* scan_counter = nullptr) const override;
 
     virtual bool
     HasIndex(FieldId field_id) const = 0;
@@ -186,6 +192,13 @@ class SegmentInternalInterface : public SegmentInterface {
                         int64_t chunk_id,
                         const milvus::VariableColumn<std::string>& var_column);
 
+    // the column of the string field if it's dictionary encoded,
+    // nullptr otherwise
+    virtual const milvus::VariableColumn<std::string>*
+    get_dictionary_column(FieldId field_id) const {
+        return nullptr;
+    }
+
  public:
     virtual void
     vector_search(SearchInfo& search_info,
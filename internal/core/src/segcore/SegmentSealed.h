@@ -31,6 +31,17 @@ class SegmentSealed : public SegmentInternalInterface {
     LoadSegmentMeta(const milvus::proto::segcore::LoadSegmentMeta& meta) = 0;
     virtual void
     DropIndex(const FieldId field_id) = 0;
+    // serialize the vector index to host memory and free the device memory of it,
+    // the search on the field fails until the index is restored
+    virtual void
+    SpillIndex(const FieldId field_id) = 0;
+    virtual void
+    RestoreIndex(const FieldId field_id) = 0;
+    // quantize the loaded vectors of the field in memory, the search on the
+    // field refines the candidates of the quantized vectors by the raw ones
+    virtual void
+    QuantizeFieldData(const FieldId field_id,
+                      const std::string& quantization) = 0;
     virtual void
     DropFieldData(const FieldId field_id) = 0;
 
@@ -47,6 +47,8 @@
 #include "common/File.h"
 #include "common/Tracer.h"
 #include "index/VectorMemIndex.h"
+#include "index/IndexFactory.h"
+#include "index/Utils.h"
 
 namespace milvus::segcore {
 
@@ -111,6 +113,14 @@ SegmentSealedImpl::LoadVecIndex(const LoadIndexInfo& info) {
         vector_indexings_.drop_field_indexing(field_id);
     }
     update_row_count(row_count);
+    index::CreateIndexInfo create_info;
+    create_info.field_type = info.field_type;
+    create_info.index_type = info.index->Type();
+    create_info.metric_type = metric_type;
+    create_info.index_engine_version = info.index_engine_version;
+    vec_index_infos_[field_id] = std::make_pair(
+        create_info, index::ParseConfigFromIndexParams(info.index_params));
+    spilled_indexes_.erase(field_id);
     vector_indexings_.append_field_indexing(
         field_id,
         metric_type,
@@ -197,9 +207,19 @@ SegmentSealedImpl::LoadFieldData(const LoadFieldDataInfo& load_info) {
         auto insert_files = info.insert_files;
         auto field_data_info =
             FieldDataInfo(field_id.get(), num_rows, load_info.mmap_dir_path);
+        field_data_info.keep_mmap_file = info.keep_mmap_file;
 
         LOG_SEGCORE_INFO_ << "start to load field data " << id << " of segment "
                           << this->id_;
+        auto mapped = info.enable_mmap &&
+                      !SystemProperty::Instance().IsSystem(field_id);
+        if (mapped && info.reuse_mmap_file &&
+            ReuseMapFieldData(field_id, field_data_info)) {
+            LOG_SEGCORE_INFO_ << "reuse local mmap file of segment field, "
+                              << "segmentID:" << this->id_
+                              << ", fieldID:" << info.field_id;
+            continue;
+        }
         auto parallel_degree = static_cast<uint64_t>(
             DEFAULT_FIELD_MAX_MEMORY_LIMIT / FILE_SLICE_SIZE);
         field_data_info.channel->set_capacity(parallel_degree * 2);
@@ -211,8 +231,7 @@ SegmentSealedImpl::LoadFieldData(const LoadFieldDataInfo& load_info) {
                              "to thread pool, "
                           << "segmentID:" << this->id_
                           << ", fieldID:" << info.field_id;
-        if (!info.enable_mmap ||
-            SystemProperty::Instance().IsSystem(field_id)) {
+        if (!mapped) {
             LoadFieldData(field_id, field_data_info);
         } else {
             MapFieldData(field_id, field_data_info);
@@ -346,6 +365,10 @@ SegmentSealedImpl::LoadFieldData(FieldId field_id, FieldDataInfo& data) {
                         }
                     }
                     var_column->Seal();
+                    if (schema_->get_primary_field_id() != field_id) {
+                        var_column->EncodeDictionary(
+                            segcore_config_.get_dictionary_max_cardinality());
+                    }
                     LoadStringSkipIndex(field_id, 0, *var_column);
                     column = std::move(var_column);
                     break;
@@ -535,11 +558,15 @@ SegmentSealedImpl::MapFieldData(const FieldId field_id, FieldDataInfo& data) {
         fields_.emplace(field_id, column);
     }
 
-    auto ok = unlink(filepath.c_str());
-    AssertInfo(ok == 0,
-               fmt::format("failed to unlink mmap data file {}, err: {}",
-                           filepath.c_str(),
-                           strerror(errno)));
+    // only the fixed width fields could be mapped again from the file,
+    // the offsets of variable length fields are not persisted
+    if (!data.keep_mmap_file || datatype_is_variable(data_type)) {
+        auto ok = unlink(filepath.c_str());
+        AssertInfo(ok == 0,
+                   fmt::format("failed to unlink mmap data file {}, err: {}",
+                               filepath.c_str(),
+                               strerror(errno)));
+    }
 
     // set pks to offset
     if (schema_->get_primary_field_id() == field_id) {
@@ -553,6 +580,46 @@ SegmentSealedImpl::MapFieldData(const FieldId field_id, FieldDataInfo& data) {
     set_bit(field_data_ready_bitset_, field_id, true);
 }
 
+bool
+SegmentSealedImpl::ReuseMapFieldData(const FieldId field_id,
+                                     const FieldDataInfo& data) {
+    auto& field_meta = (*schema_)[field_id];
+    auto data_type = field_meta.get_data_type();
+    if (datatype_is_variable(data_type)) {
+        return false;
+    }
+
+    auto filepath = std::filesystem::path(data.mmap_dir_path) /
+                    std::to_string(get_segment_id()) /
+                    std::to_string(field_id.get());
+    std::error_code ec;
+    auto size = std::filesystem::file_size(filepath, ec);
+    if (ec || size != data.row_count * field_meta.get_sizeof()) {
+        LOG_SEGCORE_WARNING_ << "local mmap file " << filepath
+                             << " mismatched, load it from remote";
+        return false;
+    }
+
+    auto file = File::Open(filepath.string(), O_RDWR);
+    auto column = std::make_shared<Column>(file, size, field_meta);
+    {
+        std::unique_lock lck(mutex_);
+        fields_.emplace(field_id, column);
+    }
+
+    // set pks to offset
+    if (schema_->get_primary_field_id() == field_id) {
+        AssertInfo(field_id.get() != -1, "Primary key is -1");
+        AssertInfo(insert_record_.empty_pks(), "already exists");
+        insert_record_.insert_pks(data_type, column);
+        insert_record_.seal_pks();
+    }
+
+    std::unique_lock lck(mutex_);
+    set_bit(field_data_ready_bitset_, field_id, true);
+    return true;
+}
+
 void
 SegmentSealedImpl::LoadDeletedRecord(const LoadDeletedRecordInfo& info) {
     AssertInfo(info.row_count > 0, "The row count of deleted record is 0");
@@ -718,6 +785,19 @@ SegmentSealedImpl::vector_search(SearchInfo& search_info,
                                    bitset,
                                    output);
         milvus::tracer::AddEvent("finish_searching_vector_index");
+    } else if (quantized_vectors_.count(field_id) &&
+               !search_info.search_params_.contains(RADIUS)) {
+        // the range search is exact on the raw vectors
+        auto vec_data = fields_.at(field_id);
+        query::SearchOnQuantized(*schema_,
+                                 *quantized_vectors_.at(field_id),
+                                 vec_data->Data(),
+                                 search_info,
+                                 query_data,
+                                 query_count,
+                                 bitset,
+                                 output);
+        milvus::tracer::AddEvent("finish_searching_quantized_vector_data");
     } else {
         AssertInfo(
             get_bit(field_data_ready_bitset_, field_id),
@@ -871,6 +951,7 @@ SegmentSealedImpl::DropFieldData(const FieldId field_id) {
         if (get_bit(field_data_ready_bitset_, field_id)) {
             set_bit(field_data_ready_bitset_, field_id, false);
             insert_record_.drop_field_data(field_id);
+            quantized_vectors_.erase(field_id);
         }
         if (get_bit(binlog_index_bitset_, field_id)) {
             set_bit(binlog_index_bitset_, field_id, false);
@@ -892,9 +973,85 @@ SegmentSealedImpl::DropIndex(const FieldId field_id) {
 
     std::unique_lock lck(mutex_);
     vector_indexings_.drop_field_indexing(field_id);
+    vec_index_infos_.erase(field_id);
+    spilled_indexes_.erase(field_id);
     set_bit(index_ready_bitset_, field_id, false);
 }
 
+const VariableColumn<std::string>*
+SegmentSealedImpl::get_dictionary_column(FieldId field_id) const {
+    std::shared_lock lck(mutex_);
+    auto it = fields_.find(field_id);
+    if (it == fields_.end()) {
+        return nullptr;
+    }
+    auto column =
+        std::dynamic_pointer_cast<VariableColumn<std::string>>(it->second);
+    if (column == nullptr || !column->IsDictionaryEncoded()) {
+        return nullptr;
+    }
+    return column.get();
+}
+
+void
+SegmentSealedImpl::SpillIndex(const FieldId field_id) {
+    std::unique_lock lck(mutex_);
+    AssertInfo(get_bit(index_ready_bitset_, field_id) &&
+                   vec_index_infos_.count(field_id),
+               "vector index is not loaded at " +
+                   std::to_string(field_id.get()));
+    auto indexing = vector_indexings_.get_field_indexing(field_id);
+    spilled_indexes_[field_id] = indexing->indexing_->Serialize({});
+    vector_indexings_.drop_field_indexing(field_id);
+    set_bit(index_ready_bitset_, field_id, false);
+}
+
+void
+SegmentSealedImpl::RestoreIndex(const FieldId field_id) {
+    std::unique_lock lck(mutex_);
+    auto spilled = spilled_indexes_.find(field_id);
+    AssertInfo(spilled != spilled_indexes_.end(),
+               "vector index is not spilled at " +
+                   std::to_string(field_id.get()));
+    auto& [create_info, config] = vec_index_infos_.at(field_id);
+    auto index = index::IndexFactory::GetInstance().CreateIndex(
+        create_info, storage::FileManagerContext());
+    index->Load(spilled->second, config);
+    vector_indexings_.append_field_indexing(
+        field_id, create_info.metric_type, std::move(index));
+    spilled_indexes_.erase(spilled);
+    set_bit(index_ready_bitset_, field_id, true);
+}
+
+void
+SegmentSealedImpl::QuantizeFieldData(const FieldId field_id,
+                                     const std::string& quantization) {
+    if (quantization != "SQ8") {
+        PanicInfo(Unsupported,
+                  fmt::format("unsupported vector quantization {}",
+                              quantization));
+    }
+    auto& field_meta = schema_->operator[](field_id);
+    AssertInfo(field_meta.get_data_type() == DataType::VECTOR_FLOAT,
+               "only the float vectors can be quantized, field " +
+                   std::to_string(field_id.get()));
+
+    std::unique_lock lck(mutex_);
+    AssertInfo(get_bit(field_data_ready_bitset_, field_id),
+               "Field Data is not loaded: " + std::to_string(field_id.get()));
+    AssertInfo(num_rows_.has_value(), "Can't get row count value");
+    auto column = fields_.at(field_id);
+    quantized_vectors_[field_id] = std::make_unique<query::SQ8Quantizer>(
+        static_cast<const float*>(column->Data()),
+        num_rows_.value(),
+        field_meta.get_dim());
+    // the interim index keeps the vectors in memory, which is superseded
+    if (get_bit(binlog_index_bitset_, field_id)) {
+        set_bit(binlog_index_bitset_, field_id, false);
+        vector_indexings_.drop_field_indexing(field_id);
+    }
+}
+
 void
 SegmentSealedImpl::check_search(const query::Plan* plan) const {
     AssertInfo(plan, "Search plan is null");
@@ -32,6 +32,8 @@
 #include "google/protobuf/message_lite.h"
 #include "mmap/Column.h"
 #include "index/ScalarIndex.h"
+#include "index/IndexInfo.h"
+#include "query/SQ8Quantizer.h"
 #include "sys/mman.h"
 #include "common/Types.h"
 #include "common/IndexMeta.h"
@@ -59,6 +61,13 @@ class SegmentSealedImpl : public SegmentSealed {
     void
     DropIndex(const FieldId field_id) override;
     void
+    SpillIndex(const FieldId field_id) override;
+    void
+    RestoreIndex(const FieldId field_id) override;
+    void
+    QuantizeFieldData(const FieldId field_id,
+                      const std::string& quantization) override;
+    void
     DropFieldData(const FieldId field_id) override;
     bool
     HasIndex(FieldId field_id) const override;
@@ -74,6 +83,10 @@ class SegmentSealedImpl : public SegmentSealed {
     LoadFieldData(FieldId field_id, FieldDataInfo& data) override;
     void
     MapFieldData(const FieldId field_id, FieldDataInfo& data) override;
+    // map the fixed width field from the mmap file kept on local disk,
+    // returns false if the file is missing or mismatched
+    bool
+    ReuseMapFieldData(const FieldId field_id, const FieldDataInfo& data);
     void
     AddFieldDataInfoForSealed(
         const LoadFieldDataInfo& field_data_info) override;
@@ -86,6 +99,9 @@ class SegmentSealedImpl : public SegmentSealed {
     bool
     HasRawData(int64_t field_id) const override;
 
+    const VariableColumn<std::string>*
+    get_dictionary_column(FieldId field_id) const override;
+
  public:
     int64_t
     GetMemoryUsageInBytes() const override;
@@ -279,6 +295,13 @@ class SegmentSealedImpl : public SegmentSealed {
     std::unordered_map<FieldId, index::IndexBasePtr> scalar_indexings_;
     // vector field index
     SealedIndexingRecord vector_indexings_;
+    // the create info and load config of vector indexes, to restore the spilled ones
+    std::unordered_map<FieldId, std::pair<index::CreateIndexInfo, Config>>
+        vec_index_infos_;
+    // the vector indexes spilled to host memory
+    std::unordered_map<FieldId, BinarySet> spilled_indexes_;
+    // the vectors quantized in memory
+    std::unordered_map<FieldId, query::SQ8QuantizerPtr> quantized_vectors_;
 
     // inserted fields data and row_ids, timestamps
     InsertRecord<true> insert_record_;
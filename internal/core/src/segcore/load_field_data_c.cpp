@@ -109,3 +109,12 @@ EnableMmap(CLoadFieldDataInfo c_load_field_data_info,
     auto info = static_cast<LoadFieldDataInfo*>(c_load_field_data_info);
     info->field_infos[field_id].enable_mmap = enabled;
 }
+
+void
+EnableLocalRecovery(CLoadFieldDataInfo c_load_field_data_info,
+                    int64_t field_id,
+                    bool reuse) {
+    auto info = static_cast<LoadFieldDataInfo*>(c_load_field_data_info);
+    info->field_infos[field_id].keep_mmap_file = true;
+    info->field_infos[field_id].reuse_mmap_file = reuse;
+}
@@ -58,6 +58,11 @@ EnableMmap(CLoadFieldDataInfo c_load_field_data_info,
            int64_t field_id,
            bool enabled);
 
+void
+EnableLocalRecovery(CLoadFieldDataInfo c_load_field_data_info,
+                    int64_t field_id,
+                    bool reuse);
+
 #ifdef __cplusplus
 }
 #endif
@@ -52,6 +52,20 @@ SegcoreSetNprobe(const int64_t value) {
     config.set_nprobe(value);
 }
 
+extern "C" void
+SegcoreSetDictionaryMaxCardinality(const int64_t value) {
+    milvus::segcore::SegcoreConfig& config =
+        milvus::segcore::SegcoreConfig::default_config();
+    config.set_dictionary_max_cardinality(value);
+}
+
+extern "C" void
+SegcoreSetQuantizationRefineRatio(const int64_t value) {
+    milvus::segcore::SegcoreConfig& config =
+        milvus::segcore::SegcoreConfig::default_config();
+    config.set_quantization_refine_ratio(value);
+}
+
 extern "C" void
 SegcoreSetKnowhereBuildThreadPoolNum(const uint32_t num_threads) {
     milvus::config::KnowhereInitBuildThreadPool(num_threads);
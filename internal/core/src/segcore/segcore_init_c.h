@@ -30,6 +30,12 @@ SegcoreSetNlist(const int64_t);
 void
 SegcoreSetNprobe(const int64_t);
 
+void
+SegcoreSetDictionaryMaxCardinality(const int64_t);
+
+void
+SegcoreSetQuantizationRefineRatio(const int64_t);
+
 // return value must be freed by the caller
 char*
 SegcoreSetSimdType(const char*);
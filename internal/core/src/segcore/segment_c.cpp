@@ -13,6 +13,7 @@
 #include <memory>
 
 #include "common/LoadInfo.h"
+#include "common/ScanCounter.h"
 #include "common/Types.h"
 #include "common/Tracer.h"
 #include "common/type_c.h"
@@ -74,11 +75,27 @@ DeleteSearchResult(CSearchResult search_result) {
     delete res;
 }
 
+CScanCounter
+NewScanCounter(int64_t max_scanned_rows) {
+    return new milvus::ScanCounter(max_scanned_rows);
+}
+
+void
+DeleteScanCounter(CScanCounter c_scan_counter) {
+    delete static_cast<milvus::ScanCounter*>(c_scan_counter);
+}
+
+int64_t
+GetScannedRows(CScanCounter c_scan_counter) {
+    return static_cast<milvus::ScanCounter*>(c_scan_counter)->Scanned();
+}
+
 CStatus
 Search(CSegmentInterface c_segment,
        CSearchPlan c_plan,
        CPlaceholderGroup c_placeholder_group,
        CTraceContext c_trace,
+       CScanCounter c_scan_counter,
        CSearchResult* result) {
     try {
         auto segment = (milvus::segcore::SegmentInterface*)c_segment;
@@ -89,7 +106,8 @@ Search(CSegmentInterface c_segment,
             c_trace.traceID, c_trace.spanID, c_trace.flag};
         auto span = milvus::tracer::StartSpan("SegCoreSearch", &ctx);
         milvus::tracer::SetRootSpan(span);
-        auto search_result = segment->Search(plan, phg_ptr);
+        auto scan_counter = static_cast<milvus::ScanCounter*>(c_scan_counter);
+        auto search_result = segment->Search(plan, phg_ptr, scan_counter);
         if (!milvus::PositivelyRelated(
                 plan->plan_node_->search_info_.metric_type_)) {
             for (auto& dis : search_result->distances_) {
@@ -115,6 +133,7 @@ Retrieve(CSegmentInterface c_segment,
          CRetrievePlan c_plan,
          CTraceContext c_trace,
          uint64_t timestamp,
+         CScanCounter c_scan_counter,
          CRetrieveResult* result,
          int64_t limit_size) {
     try {
@@ -126,7 +145,9 @@ Retrieve(CSegmentInterface c_segment,
             c_trace.traceID, c_trace.spanID, c_trace.flag};
         auto span = milvus::tracer::StartSpan("SegCoreRetrieve", &ctx);
 
-        auto retrieve_result = segment->Retrieve(plan, timestamp, limit_size);
+        auto scan_counter = static_cast<milvus::ScanCounter*>(c_scan_counter);
+        auto retrieve_result =
+            segment->Retrieve(plan, timestamp, limit_size, scan_counter);
 
         auto size = retrieve_result->ByteSizeLong();
         void* buffer = malloc(size);
@@ -391,6 +412,54 @@ DropSealedSegmentIndex(CSegmentInterface c_segment, int64_t field_id) {
     }
 }
 
+CStatus
+SpillSealedSegmentIndex(CSegmentInterface c_segment, int64_t field_id) {
+    try {
+        auto segment_interface =
+            reinterpret_cast<milvus::segcore::SegmentInterface*>(c_segment);
+        auto segment =
+            dynamic_cast<milvus::segcore::SegmentSealed*>(segment_interface);
+        AssertInfo(segment != nullptr, "segment conversion failed");
+        segment->SpillIndex(milvus::FieldId(field_id));
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(&e);
+    }
+}
+
+CStatus
+RestoreSealedSegmentIndex(CSegmentInterface c_segment, int64_t field_id) {
+    try {
+        auto segment_interface =
+            reinterpret_cast<milvus::segcore::SegmentInterface*>(c_segment);
+        auto segment =
+            dynamic_cast<milvus::segcore::SegmentSealed*>(segment_interface);
+        AssertInfo(segment != nullptr, "segment conversion failed");
+        segment->RestoreIndex(milvus::FieldId(field_id));
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(&e);
+    }
+}
+
+CStatus
+QuantizeSealedSegmentFieldData(CSegmentInterface c_segment,
+                               int64_t field_id,
+                               const char* quantization) {
+    try {
+        auto segment_interface =
+            reinterpret_cast<milvus::segcore::SegmentInterface*>(c_segment);
+        auto segment =
+            dynamic_cast<milvus::segcore::SegmentSealed*>(segment_interface);
+        AssertInfo(segment != nullptr, "segment conversion failed");
+        segment->QuantizeFieldData(milvus::FieldId(field_id),
+                                   std::string(quantization));
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(&e);
+    }
+}
+
 CStatus
 AddFieldDataInfoForSealed(CSegmentInterface c_segment,
                           CLoadFieldDataInfo c_load_field_data_info) {
@@ -26,6 +26,7 @@ extern "C" {
 
 typedef void* CSearchResult;
 typedef CProto CRetrieveResult;
+typedef void* CScanCounter;
 
 //////////////////////////////    common interfaces    //////////////////////////////
 CStatus
@@ -40,11 +41,23 @@ DeleteSegment(CSegmentInterface c_segment);
 void
 DeleteSearchResult(CSearchResult search_result);
 
+// the scan counter counts the rows scanned by a search or retrieve request over its segments,
+// the request fails once they exceed max_scanned_rows, the counter passed may be nullptr for unlimited
+CScanCounter
+NewScanCounter(int64_t max_scanned_rows);
+
+void
+DeleteScanCounter(CScanCounter c_scan_counter);
+
+int64_t
+GetScannedRows(CScanCounter c_scan_counter);
+
 CStatus
 Search(CSegmentInterface c_segment,
        CSearchPlan c_plan,
        CPlaceholderGroup c_placeholder_group,
        CTraceContext c_trace,
+       CScanCounter c_scan_counter,
        CSearchResult* result);
 
 void
@@ -55,6 +68,7 @@ Retrieve(CSegmentInterface c_segment,
          CRetrievePlan c_plan,
          CTraceContext c_trace,
          uint64_t timestamp,
+         CScanCounter c_scan_counter,
          CRetrieveResult* result,
          int64_t limit_size);
 
@@ -121,6 +135,17 @@ DropFieldData(CSegmentInterface c_segment, int64_t field_id);
 CStatus
 DropSealedSegmentIndex(CSegmentInterface c_segment, int64_t field_id);
 
+CStatus
+SpillSealedSegmentIndex(CSegmentInterface c_segment, int64_t field_id);
+
+CStatus
+RestoreSealedSegmentIndex(CSegmentInterface c_segment, int64_t field_id);
+
+CStatus
+QuantizeSealedSegmentFieldData(CSegmentInterface c_segment,
+                               int64_t field_id,
+                               const char* quantization);
+
 CStatus
 AddFieldDataInfoForSealed(CSegmentInterface c_segment,
                           CLoadFieldDataInfo c_load_field_data_info);
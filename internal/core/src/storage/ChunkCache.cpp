@@ -24,7 +24,10 @@ ChunkCache::Read(const std::string& filepath) {
 
     ColumnTable::const_accessor ca;
     if (columns_.find(ca, path)) {
-        return ca->second;
+        auto column = ca->second;
+        ca.release();
+        Touch(path);
+        return column;
     }
     ca.release();
 
@@ -39,14 +42,77 @@ ChunkCache::Read(const std::string& filepath) {
                            path.c_str(),
                            strerror(errno)));
 
-    columns_.emplace(path, column);
+    int64_t bytes = column->ByteSize();
+    std::unique_lock lck(lru_mutex_);
+    auto capacity = capacity_.load();
+    if (capacity > 0 && size_ + bytes > capacity) {
+        EvictLocked(size_ + bytes - capacity);
+    }
+    if (columns_.emplace(path, column)) {
+        lru_index_[path] = {lru_.insert(lru_.end(), path), bytes};
+        size_ += bytes;
+    }
     return column;
 }
 
 void
 ChunkCache::Remove(const std::string& filepath) {
     auto path = std::filesystem::path(path_prefix_) / filepath;
+    std::unique_lock lck(lru_mutex_);
     columns_.erase(path);
+    auto iter = lru_index_.find(path);
+    if (iter != lru_index_.end()) {
+        lru_.erase(iter->second.first);
+        size_ -= iter->second.second;
+        lru_index_.erase(iter);
+    }
+}
+
+void
+ChunkCache::SetCapacity(int64_t capacity) {
+    capacity_.store(capacity);
+    std::unique_lock lck(lru_mutex_);
+    if (capacity > 0 && size_ > capacity) {
+        EvictLocked(size_ - capacity);
+    }
+}
+
+int64_t
+ChunkCache::Size() {
+    std::unique_lock lck(lru_mutex_);
+    return size_;
+}
+
+int64_t
+ChunkCache::Evict(int64_t size) {
+    std::unique_lock lck(lru_mutex_);
+    return EvictLocked(size);
+}
+
+void
+ChunkCache::Touch(const std::string& path) {
+    std::unique_lock lck(lru_mutex_);
+    auto iter = lru_index_.find(path);
+    if (iter != lru_index_.end()) {
+        lru_.splice(lru_.end(), lru_, iter->second.first);
+    }
+}
+
+int64_t
+ChunkCache::EvictLocked(int64_t size) {
+    int64_t freed = 0;
+    while (freed < size && !lru_.empty()) {
+        auto path = lru_.front();
+        lru_.pop_front();
+        auto iter = lru_index_.find(path);
+        freed += iter->second.second;
+        size_ -= iter->second.second;
+        lru_index_.erase(iter);
+        // the mmap data file is unlinked already, the disk is reclaimed
+        // once the last reference of the column is gone
+        columns_.erase(path);
+    }
+    return freed;
 }
 
 void
@@ -16,6 +16,10 @@
 
 #pragma once
 
+#include <atomic>
+#include <list>
+#include <unordered_map>
+
 #include <oneapi/tbb/concurrent_hash_map.h>
 #include "mmap/Column.h"
 
@@ -52,10 +56,30 @@ class ChunkCache {
     void
     Prefetch(const std::string& filepath);
 
+    // SetCapacity limits the bytes of the cached columns, 0 means unlimited,
+    // the least recently read columns are evicted to make room for new ones.
+    void
+    SetCapacity(int64_t capacity);
+
+    int64_t
+    Size();
+
+    // Evict evicts the least recently read columns until size bytes freed,
+    // returns the bytes freed. The columns still referenced by segments
+    // are unmapped once the segments release them.
+    int64_t
+    Evict(int64_t size);
+
  private:
     std::shared_ptr<ColumnBase>
     Mmap(const std::filesystem::path& path, const FieldDataPtr& field_data);
 
+    void
+    Touch(const std::string& path);
+
+    int64_t
+    EvictLocked(int64_t size);
+
  private:
     using ColumnTable =
         oneapi::tbb::concurrent_hash_map<std::string,
@@ -67,6 +91,16 @@ class ChunkCache {
     std::string path_prefix_;
     ChunkManagerPtr cm_;
     ColumnTable columns_;
+
+    // lru_mutex_ guards lru_, lru_index_ and size_,
+    // the front of lru_ is the least recently read column
+    std::mutex lru_mutex_;
+    std::list<std::string> lru_;
+    std::unordered_map<std::string,
+                       std::pair<std::list<std::string>::iterator, int64_t>>
+        lru_index_;
+    int64_t size_ = 0;
+    std::atomic<int64_t> capacity_ = 0;
 };
 
 using ChunkCachePtr = std::shared_ptr<milvus::storage::ChunkCache>;
@@ -277,7 +277,7 @@ DiskFileManagerImpl::CacheIndexToDisk() {
 
 void
 DiskFileManagerImpl::CacheIndexToDisk(
-    const std::vector<std::string>& remote_files) {
+    const std::vector<std::string>& remote_files, bool reuse_local) {
     auto local_chunk_manager =
         LocalChunkManagerSingleton::GetInstance().GetChunkManager();
 
@@ -302,6 +302,12 @@ DiskFileManagerImpl::CacheIndexToDisk(
         auto local_index_file_name =
             GetLocalIndexObjectPrefix() +
             prefix.substr(prefix.find_last_of('/') + 1);
+        if (reuse_local && local_chunk_manager->Exist(local_index_file_name)) {
+            LOG_SEGCORE_INFO_ << "reuse local index file "
+                              << local_index_file_name;
+            local_paths_.emplace_back(local_index_file_name);
+            continue;
+        }
         local_chunk_manager->CreateFile(local_index_file_name);
         int64_t offset = 0;
         std::vector<std::string> batch_remote_files;
@@ -74,8 +74,11 @@ class DiskFileManagerImpl : public FileManagerImpl {
         return local_paths_;
     }
 
+    // reuse_local: reuse the index files already cached on local disk,
+    // only set when the local files are verified by the caller
     void
-    CacheIndexToDisk(const std::vector<std::string>& remote_files);
+    CacheIndexToDisk(const std::vector<std::string>& remote_files,
+                     bool reuse_local = false);
 
     void
     CacheIndexToDisk();
@@ -19,7 +19,9 @@
 #include <memory>
 #include <shared_mutex>
 
+#include "storage/RoutingChunkManager.h"
 #include "storage/Util.h"
+#include "common/EasyAssert.h"
 #include "opendal.h"
 
 namespace milvus::storage {
@@ -43,10 +45,29 @@ class RemoteChunkManagerSingleton {
     void
     Init(const StorageConfig& storage_config) {
         if (rcm_ == nullptr) {
-            rcm_ = CreateChunkManager(storage_config);
+            rcm_ = std::make_shared<RoutingChunkManager>(
+                CreateChunkManager(storage_config));
         }
     }
 
+    // AddProfile adds the bucket profile which collections could be routed to
+    void
+    AddProfile(const std::string& profile,
+               const StorageConfig& storage_config) {
+        AssertInfo(rcm_ != nullptr,
+                   "remote chunk manager is not initialized");
+        rcm_->AddProfile(profile, CreateChunkManager(storage_config));
+    }
+
+    // SetRoute routes the binlogs of collection to the bucket profile,
+    // empty profile means the default bucket
+    void
+    SetRoute(int64_t collection_id, const std::string& profile) {
+        AssertInfo(rcm_ != nullptr,
+                   "remote chunk manager is not initialized");
+        rcm_->SetRoute(collection_id, profile);
+    }
+
     void
     Release() {
     }
@@ -57,7 +78,7 @@ class RemoteChunkManagerSingleton {
     }
 
  private:
-    ChunkManagerPtr rcm_ = nullptr;
+    RoutingChunkManagerPtr rcm_ = nullptr;
 };
 
 }  // namespace milvus::storage
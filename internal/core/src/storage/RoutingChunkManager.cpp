@@ -0,0 +1,186 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include "storage/RoutingChunkManager.h"
+
+#include <algorithm>
+#include <mutex>
+
+#include "common/EasyAssert.h"
+
+namespace milvus::storage {
+
+namespace {
+
+const std::vector<std::string> kRoutedLogPaths = {
+    "insert_log", "delta_log", "stats_log"};
+
+// parses the collection ID from the relative path `[log_type]/collID/...`
+bool
+ParseCollectionID(const std::string& rel_path, int64_t& collection_id) {
+    auto first = rel_path.find('/');
+    if (first == std::string::npos) {
+        return false;
+    }
+    auto log_type = rel_path.substr(0, first);
+    if (std::find(kRoutedLogPaths.begin(), kRoutedLogPaths.end(), log_type) ==
+        kRoutedLogPaths.end()) {
+        return false;
+    }
+    auto second = rel_path.find('/', first + 1);
+    if (second == std::string::npos) {
+        return false;
+    }
+    auto id = rel_path.substr(first + 1, second - first - 1);
+    if (id.empty() ||
+        id.find_first_not_of("0123456789") != std::string::npos) {
+        return false;
+    }
+    collection_id = std::stoll(id);
+    return true;
+}
+
+std::string
+TrimRootPath(const std::string& filepath, const std::string& root_path) {
+    auto rel = filepath;
+    if (!root_path.empty() && rel.rfind(root_path, 0) == 0) {
+        rel = rel.substr(root_path.size());
+    }
+    while (!rel.empty() && rel[0] == '/') {
+        rel = rel.substr(1);
+    }
+    return rel;
+}
+
+std::string
+JoinPath(const std::string& root_path, const std::string& rel) {
+    if (root_path.empty()) {
+        return rel;
+    }
+    if (root_path.back() == '/') {
+        return root_path + rel;
+    }
+    return root_path + "/" + rel;
+}
+
+}  // namespace
+
+void
+RoutingChunkManager::AddProfile(const std::string& profile,
+                                ChunkManagerPtr cm) {
+    std::unique_lock lck(mutex_);
+    profiles_[profile] = std::move(cm);
+}
+
+void
+RoutingChunkManager::SetRoute(int64_t collection_id,
+                              const std::string& profile) {
+    std::unique_lock lck(mutex_);
+    AssertInfo(profile.empty() || profiles_.count(profile) > 0,
+               "bucket profile {} not found",
+               profile);
+    routes_[collection_id] = profile;
+}
+
+std::pair<ChunkManagerPtr, std::string>
+RoutingChunkManager::Resolve(const std::string& filepath) {
+    auto root_path = default_cm_->GetRootPath();
+    auto rel = TrimRootPath(filepath, root_path);
+    int64_t collection_id;
+    if (!ParseCollectionID(rel, collection_id)) {
+        return {default_cm_, filepath};
+    }
+
+    std::shared_lock lck(mutex_);
+    auto route = routes_.find(collection_id);
+    if (route == routes_.end() || route->second.empty()) {
+        return {default_cm_, filepath};
+    }
+    auto cm = profiles_.at(route->second);
+    if (cm->GetRootPath() == root_path) {
+        return {cm, filepath};
+    }
+    return {cm, JoinPath(cm->GetRootPath(), rel)};
+}
+
+bool
+RoutingChunkManager::Exist(const std::string& filepath) {
+    auto [cm, path] = Resolve(filepath);
+    return cm->Exist(path);
+}
+
+uint64_t
+RoutingChunkManager::Size(const std::string& filepath) {
+    auto [cm, path] = Resolve(filepath);
+    return cm->Size(path);
+}
+
+uint64_t
+RoutingChunkManager::Read(const std::string& filepath,
+                          void* buf,
+                          uint64_t len) {
+    auto [cm, path] = Resolve(filepath);
+    return cm->Read(path, buf, len);
+}
+
+void
+RoutingChunkManager::Write(const std::string& filepath,
+                           void* buf,
+                           uint64_t len) {
+    auto [cm, path] = Resolve(filepath);
+    cm->Write(path, buf, len);
+}
+
+uint64_t
+RoutingChunkManager::Read(const std::string& filepath,
+                          uint64_t offset,
+                          void* buf,
+                          uint64_t len) {
+    auto [cm, path] = Resolve(filepath);
+    return cm->Read(path, offset, buf, len);
+}
+
+void
+RoutingChunkManager::Write(const std::string& filepath,
+                           uint64_t offset,
+                           void* buf,
+                           uint64_t len) {
+    auto [cm, path] = Resolve(filepath);
+    cm->Write(path, offset, buf, len);
+}
+
+std::vector<std::string>
+RoutingChunkManager::ListWithPrefix(const std::string& filepath) {
+    auto [cm, path] = Resolve(filepath);
+    auto files = cm->ListWithPrefix(path);
+    if (cm == default_cm_ || path == filepath) {
+        return files;
+    }
+    // translate the paths back to the default root path
+    for (auto& file : files) {
+        file = JoinPath(default_cm_->GetRootPath(),
+                        TrimRootPath(file, cm->GetRootPath()));
+    }
+    return files;
+}
+
+void
+RoutingChunkManager::Remove(const std::string& filepath) {
+    auto [cm, path] = Resolve(filepath);
+    cm->Remove(path);
+}
+
+}  // namespace milvus::storage
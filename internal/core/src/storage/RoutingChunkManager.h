@@ -0,0 +1,109 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#pragma once
+
+#include <memory>
+#include <shared_mutex>
+#include <string>
+#include <unordered_map>
+#include <utility>
+#include <vector>
+
+#include "storage/ChunkManager.h"
+
+namespace milvus::storage {
+
+/**
+ * @brief RoutingChunkManager routes the binlogs of collections to the bucket profiles,
+ * it's the counterpart of the RoutingChunkManager in go.
+ * Paths are always under the root path of the default chunk manager, and they are
+ * translated to the root path of the profile chunk manager on access.
+ * Paths not belonging to any routed collection, e.g. index files, are stored in the default bucket.
+ */
+class RoutingChunkManager : public ChunkManager {
+ public:
+    explicit RoutingChunkManager(ChunkManagerPtr default_cm)
+        : default_cm_(std::move(default_cm)) {
+    }
+
+    virtual ~RoutingChunkManager() {
+    }
+
+    void
+    AddProfile(const std::string& profile, ChunkManagerPtr cm);
+
+    /**
+     * @brief Route the binlogs of collection to the profile,
+     * the profile must be added before.
+     * @param collection_id
+     * @param profile
+     */
+    void
+    SetRoute(int64_t collection_id, const std::string& profile);
+
+    virtual bool
+    Exist(const std::string& filepath);
+
+    virtual uint64_t
+    Size(const std::string& filepath);
+
+    virtual uint64_t
+    Read(const std::string& filepath, void* buf, uint64_t len);
+
+    virtual void
+    Write(const std::string& filepath, void* buf, uint64_t len);
+
+    virtual uint64_t
+    Read(const std::string& filepath, uint64_t offset, void* buf, uint64_t len);
+
+    virtual void
+    Write(const std::string& filepath,
+          uint64_t offset,
+          void* buf,
+          uint64_t len);
+
+    virtual std::vector<std::string>
+    ListWithPrefix(const std::string& filepath);
+
+    virtual void
+    Remove(const std::string& filepath);
+
+    virtual std::string
+    GetName() const {
+        return "RoutingChunkManager";
+    }
+
+    virtual std::string
+    GetRootPath() const {
+        return default_cm_->GetRootPath();
+    }
+
+ private:
+    // returns the chunk manager storing the file and the path under its root path
+    std::pair<ChunkManagerPtr, std::string>
+    Resolve(const std::string& filepath);
+
+ private:
+    ChunkManagerPtr default_cm_;
+    std::shared_mutex mutex_;
+    std::unordered_map<std::string, ChunkManagerPtr> profiles_;
+    std::unordered_map<int64_t, std::string> routes_;
+};
+
+using RoutingChunkManagerPtr = std::shared_ptr<RoutingChunkManager>;
+
+}  // namespace milvus::storage
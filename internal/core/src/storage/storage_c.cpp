@@ -50,31 +50,41 @@ InitLocalChunkManagerSingleton(const char* c_path) {
     }
 }
 
+namespace {
+
+milvus::storage::StorageConfig
+ToStorageConfig(const CStorageConfig& c_storage_config) {
+    milvus::storage::StorageConfig storage_config;
+    storage_config.address = std::string(c_storage_config.address);
+    storage_config.bucket_name = std::string(c_storage_config.bucket_name);
+    storage_config.access_key_id =
+        std::string(c_storage_config.access_key_id);
+    storage_config.access_key_value =
+        std::string(c_storage_config.access_key_value);
+    storage_config.root_path = std::string(c_storage_config.root_path);
+    storage_config.storage_type =
+        std::string(c_storage_config.storage_type);
+    storage_config.cloud_provider =
+        std::string(c_storage_config.cloud_provider);
+    storage_config.iam_endpoint =
+        std::string(c_storage_config.iam_endpoint);
+    storage_config.cloud_provider =
+        std::string(c_storage_config.cloud_provider);
+    storage_config.log_level = std::string(c_storage_config.log_level);
+    storage_config.useSSL = c_storage_config.useSSL;
+    storage_config.useIAM = c_storage_config.useIAM;
+    storage_config.useVirtualHost = c_storage_config.useVirtualHost;
+    storage_config.region = c_storage_config.region;
+    storage_config.requestTimeoutMs = c_storage_config.requestTimeoutMs;
+    return storage_config;
+}
+
+}  // namespace
+
 CStatus
 InitRemoteChunkManagerSingleton(CStorageConfig c_storage_config) {
     try {
-        milvus::storage::StorageConfig storage_config;
-        storage_config.address = std::string(c_storage_config.address);
-        storage_config.bucket_name = std::string(c_storage_config.bucket_name);
-        storage_config.access_key_id =
-            std::string(c_storage_config.access_key_id);
-        storage_config.access_key_value =
-            std::string(c_storage_config.access_key_value);
-        storage_config.root_path = std::string(c_storage_config.root_path);
-        storage_config.storage_type =
-            std::string(c_storage_config.storage_type);
-        storage_config.cloud_provider =
-            std::string(c_storage_config.cloud_provider);
-        storage_config.iam_endpoint =
-            std::string(c_storage_config.iam_endpoint);
-        storage_config.cloud_provider =
-            std::string(c_storage_config.cloud_provider);
-        storage_config.log_level = std::string(c_storage_config.log_level);
-        storage_config.useSSL = c_storage_config.useSSL;
-        storage_config.useIAM = c_storage_config.useIAM;
-        storage_config.useVirtualHost = c_storage_config.useVirtualHost;
-        storage_config.region = c_storage_config.region;
-        storage_config.requestTimeoutMs = c_storage_config.requestTimeoutMs;
+        auto storage_config = ToStorageConfig(c_storage_config);
         milvus::storage::RemoteChunkManagerSingleton::GetInstance().Init(
             storage_config);
 
@@ -84,6 +94,29 @@ InitRemoteChunkManagerSingleton(CStorageConfig c_storage_config) {
     }
 }
 
+CStatus
+AddRemoteChunkManagerProfile(const char* c_profile,
+                             CStorageConfig c_storage_config) {
+    try {
+        milvus::storage::RemoteChunkManagerSingleton::GetInstance().AddProfile(
+            std::string(c_profile), ToStorageConfig(c_storage_config));
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(&e);
+    }
+}
+
+CStatus
+SetRemoteChunkManagerRoute(int64_t collection_id, const char* c_profile) {
+    try {
+        milvus::storage::RemoteChunkManagerSingleton::GetInstance().SetRoute(
+            collection_id, std::string(c_profile));
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(&e);
+    }
+}
+
 CStatus
 InitChunkCacheSingleton(const char* c_dir_path, const char* read_ahead_policy) {
     try {
@@ -95,6 +128,35 @@ InitChunkCacheSingleton(const char* c_dir_path, const char* read_ahead_policy) {
     }
 }
 
+void
+SetChunkCacheCapacity(int64_t capacity) {
+    auto cc =
+        milvus::storage::ChunkCacheSingleton::GetInstance().GetChunkCache();
+    if (cc != nullptr) {
+        cc->SetCapacity(capacity);
+    }
+}
+
+int64_t
+GetChunkCacheSize() {
+    auto cc =
+        milvus::storage::ChunkCacheSingleton::GetInstance().GetChunkCache();
+    if (cc == nullptr) {
+        return 0;
+    }
+    return cc->Size();
+}
+
+int64_t
+EvictChunkCache(int64_t size) {
+    auto cc =
+        milvus::storage::ChunkCacheSingleton::GetInstance().GetChunkCache();
+    if (cc == nullptr) {
+        return 0;
+    }
+    return cc->Evict(size);
+}
+
 void
 CleanRemoteChunkManagerSingleton() {
     milvus::storage::RemoteChunkManagerSingleton::GetInstance().Release();
@@ -30,9 +30,25 @@ InitLocalChunkManagerSingleton(const char* path);
 CStatus
 InitRemoteChunkManagerSingleton(CStorageConfig c_storage_config);
 
+CStatus
+AddRemoteChunkManagerProfile(const char* c_profile,
+                             CStorageConfig c_storage_config);
+
+CStatus
+SetRemoteChunkManagerRoute(int64_t collection_id, const char* c_profile);
+
 CStatus
 InitChunkCacheSingleton(const char* c_dir_path, const char* read_ahead_policy);
 
+void
+SetChunkCacheCapacity(int64_t capacity);
+
+int64_t
+GetChunkCacheSize();
+
+int64_t
+EvictChunkCache(int64_t size);
+
 void
 CleanRemoteChunkManagerSingleton();
 
@@ -60,8 +60,13 @@ CRetrieve(CSegmentInterface c_segment,
           CTraceContext c_trace,
           uint64_t timestamp,
           CRetrieveResult* result) {
-    return Retrieve(
-        c_segment, c_plan, c_trace, timestamp, result, DEFAULT_MAX_OUTPUT_SIZE);
+    return Retrieve(c_segment,
+                    c_plan,
+                    c_trace,
+                    timestamp,
+                    nullptr,
+                    result,
+                    DEFAULT_MAX_OUTPUT_SIZE);
 }
 
 const char*
@@ -808,6 +813,63 @@ TEST(CApiTest, DeleteRepeatedPksFromSealedSegment) {
     DeleteSegment(segment);
 }
 
+TEST(CApiTest, RetrieveScanLimit) {
+    auto collection = NewCollection(get_default_schema_config());
+    CSegmentInterface segment;
+    auto status = NewSegment(collection, Sealed, -1, &segment);
+    ASSERT_EQ(status.error_code, Success);
+    auto col = (milvus::segcore::Collection*)collection;
+
+    int N = 20;
+    auto dataset = DataGen(col->get_schema(), N);
+
+    auto segment_interface = reinterpret_cast<SegmentInterface*>(segment);
+    auto sealed_segment = dynamic_cast<SegmentSealed*>(segment_interface);
+    SealedLoadFieldData(dataset, *sealed_segment);
+
+    // the range expr scans all the rows of segment
+    auto schema = ((milvus::segcore::Collection*)collection)->get_schema();
+    auto plan = std::make_unique<query::RetrievePlan>(*schema);
+    auto range_expr = std::make_unique<query::UnaryRangeExprImpl<int64_t>>(
+        milvus::query::ColumnInfo(
+            FieldId(101), DataType::INT64, std::vector<std::string>()),
+        proto::plan::OpType::GreaterEqual,
+        0,
+        proto::plan::GenericValue::kInt64Val);
+    plan->plan_node_ = std::make_unique<query::RetrievePlanNode>();
+    plan->plan_node_->predicate_ = std::move(range_expr);
+    std::vector<FieldId> target_field_ids{FieldId(100), FieldId(101)};
+    plan->field_ids_ = target_field_ids;
+
+    CRetrieveResult retrieve_result;
+    auto scan_counter = NewScanCounter(N);
+    auto res = Retrieve(segment,
+                        plan.get(),
+                        {},
+                        dataset.timestamps_[N - 1],
+                        scan_counter,
+                        &retrieve_result,
+                        DEFAULT_MAX_OUTPUT_SIZE);
+    ASSERT_EQ(res.error_code, Success);
+    ASSERT_EQ(GetScannedRows(scan_counter), N);
+    DeleteRetrieveResult(&retrieve_result);
+
+    // the rows scanned of all the segments are charged to the same counter
+    res = Retrieve(segment,
+                   plan.get(),
+                   {},
+                   dataset.timestamps_[N - 1],
+                   scan_counter,
+                   &retrieve_result,
+                   DEFAULT_MAX_OUTPUT_SIZE);
+    ASSERT_EQ(res.error_code, ScanLimitExceeded);
+    DeleteScanCounter(scan_counter);
+
+    DeleteRetrievePlan(plan.release());
+    DeleteCollection(collection);
+    DeleteSegment(segment);
+}
+
 TEST(CApiTest, InsertSamePkAfterDeleteOnGrowingSegment) {
     auto collection = NewCollection(get_default_schema_config());
     CSegmentInterface segment;
@@ -1024,11 +1086,21 @@ TEST(CApiTest, SearchTest) {
     placeholderGroups.push_back(placeholderGroup);
 
     CSearchResult search_result;
-    auto res = Search(segment, plan, placeholderGroup, {}, &search_result);
+    auto res = Search(segment,
+                      plan,
+                      placeholderGroup,
+                      {},
+                      nullptr,
+                      &search_result);
     ASSERT_EQ(res.error_code, Success);
 
     CSearchResult search_result2;
-    auto res2 = Search(segment, plan, placeholderGroup, {}, &search_result2);
+    auto res2 = Search(segment,
+                       plan,
+                       placeholderGroup,
+                       {},
+                       nullptr,
+                       &search_result2);
     ASSERT_EQ(res2.error_code, Success);
 
     DeleteSearchPlan(plan);
@@ -1092,7 +1164,12 @@ TEST(CApiTest, SearchTestWithExpr) {
     dataset.timestamps_.push_back(1);
 
     CSearchResult search_result;
-    auto res = Search(segment, plan, placeholderGroup, {}, &search_result);
+    auto res = Search(segment,
+                      plan,
+                      placeholderGroup,
+                      {},
+                      nullptr,
+                      &search_result);
     ASSERT_EQ(res.error_code, Success);
 
     DeleteSearchPlan(plan);
@@ -1370,7 +1447,7 @@ TEST(CApiTest, ReudceNullResult) {
         auto slice_topKs = std::vector<int64_t>{1};
         std::vector<CSearchResult> results;
         CSearchResult res;
-        status = Search(segment, plan, placeholderGroup, {}, &res);
+        status = Search(segment, plan, placeholderGroup, {}, nullptr, &res);
         ASSERT_EQ(status.error_code, Success);
         results.push_back(res);
         CSearchResultDataBlobs cSearchResultData;
@@ -1457,9 +1534,9 @@ TEST(CApiTest, ReduceRemoveDuplicates) {
         auto slice_topKs = std::vector<int64_t>{topK / 2, topK};
         std::vector<CSearchResult> results;
         CSearchResult res1, res2;
-        status = Search(segment, plan, placeholderGroup, {}, &res1);
+        status = Search(segment, plan, placeholderGroup, {}, nullptr, &res1);
         ASSERT_EQ(status.error_code, Success);
-        status = Search(segment, plan, placeholderGroup, {}, &res2);
+        status = Search(segment, plan, placeholderGroup, {}, nullptr, &res2);
         ASSERT_EQ(status.error_code, Success);
         results.push_back(res1);
         results.push_back(res2);
@@ -1488,11 +1565,11 @@ TEST(CApiTest, ReduceRemoveDuplicates) {
         auto slice_topKs = std::vector<int64_t>{topK / 2, topK, topK};
         std::vector<CSearchResult> results;
         CSearchResult res1, res2, res3;
-        status = Search(segment, plan, placeholderGroup, {}, &res1);
+        status = Search(segment, plan, placeholderGroup, {}, nullptr, &res1);
         ASSERT_EQ(status.error_code, Success);
-        status = Search(segment, plan, placeholderGroup, {}, &res2);
+        status = Search(segment, plan, placeholderGroup, {}, nullptr, &res2);
         ASSERT_EQ(status.error_code, Success);
-        status = Search(segment, plan, placeholderGroup, {}, &res3);
+        status = Search(segment, plan, placeholderGroup, {}, nullptr, &res3);
         ASSERT_EQ(status.error_code, Success);
         results.push_back(res1);
         results.push_back(res2);
@@ -1581,9 +1658,14 @@ testReduceSearchWithExpr(int N, int topK, int num_queries) {
     std::vector<CSearchResult> results;
     CSearchResult res1;
     CSearchResult res2;
-    auto res = Search(segment, plan, placeholderGroup, {}, &res1);
+    auto res = Search(segment,
+                      plan,
+                      placeholderGroup,
+                      {},
+                      nullptr,
+                      &res1);
     ASSERT_EQ(res.error_code, Success);
-    res = Search(segment, plan, placeholderGroup, {}, &res2);
+    res = Search(segment, plan, placeholderGroup, {}, nullptr, &res2);
     ASSERT_EQ(res.error_code, Success);
     results.push_back(res1);
     results.push_back(res2);
@@ -1809,7 +1891,7 @@ TEST(CApiTest, Indexing_Without_Predicate) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_EQ(res_before_load_index.error_code, Success);
 
     // load index to segment
@@ -1869,6 +1951,7 @@ TEST(CApiTest, Indexing_Without_Predicate) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -1953,7 +2036,7 @@ TEST(CApiTest, Indexing_Expr_Without_Predicate) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_EQ(res_before_load_index.error_code, Success);
 
     // load index to segment
@@ -2014,6 +2097,7 @@ TEST(CApiTest, Indexing_Expr_Without_Predicate) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -2127,7 +2211,7 @@ TEST(CApiTest, Indexing_With_float_Predicate_Range) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_EQ(res_before_load_index.error_code, Success);
 
     // load index to segment
@@ -2188,6 +2272,7 @@ TEST(CApiTest, Indexing_With_float_Predicate_Range) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -2303,7 +2388,7 @@ TEST(CApiTest, Indexing_Expr_With_float_Predicate_Range) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_EQ(res_before_load_index.error_code, Success);
 
     // load index to segment
@@ -2364,6 +2449,7 @@ TEST(CApiTest, Indexing_Expr_With_float_Predicate_Range) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -2471,7 +2557,7 @@ TEST(CApiTest, Indexing_With_float_Predicate_Term) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_EQ(res_before_load_index.error_code, Success);
 
     // load index to segment
@@ -2532,6 +2618,7 @@ TEST(CApiTest, Indexing_With_float_Predicate_Term) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -2640,7 +2727,7 @@ TEST(CApiTest, Indexing_Expr_With_float_Predicate_Term) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_EQ(res_before_load_index.error_code, Success);
 
     // load index to segment
@@ -2701,6 +2788,7 @@ TEST(CApiTest, Indexing_Expr_With_float_Predicate_Term) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -2814,7 +2902,7 @@ TEST(CApiTest, Indexing_With_binary_Predicate_Range) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_EQ(res_before_load_index.error_code, Success);
 
     // load index to segment
@@ -2876,6 +2964,7 @@ TEST(CApiTest, Indexing_With_binary_Predicate_Range) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -2989,7 +3078,7 @@ TEST(CApiTest, Indexing_Expr_With_binary_Predicate_Range) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_TRUE(res_before_load_index.error_code == Success)
         << res_before_load_index.error_msg;
 
@@ -3051,6 +3140,7 @@ TEST(CApiTest, Indexing_Expr_With_binary_Predicate_Range) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -3159,7 +3249,7 @@ TEST(CApiTest, Indexing_With_binary_Predicate_Term) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_EQ(res_before_load_index.error_code, Success);
 
     // load index to segment
@@ -3220,6 +3310,7 @@ TEST(CApiTest, Indexing_With_binary_Predicate_Term) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -3351,7 +3442,7 @@ TEST(CApiTest, Indexing_Expr_With_binary_Predicate_Term) {
 
     CSearchResult c_search_result_on_smallIndex;
     auto res_before_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_smallIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_smallIndex);
     ASSERT_EQ(res_before_load_index.error_code, Success);
 
     // load index to segment
@@ -3412,6 +3503,7 @@ TEST(CApiTest, Indexing_Expr_With_binary_Predicate_Term) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -3609,6 +3701,7 @@ TEST(CApiTest, SealedSegment_search_float_Predicate_Range) {
                                        plan,
                                        placeholderGroup,
                                        {},
+                                       nullptr,
                                        &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
@@ -3687,12 +3780,22 @@ TEST(CApiTest, SealedSegment_search_without_predicates) {
     std::vector<CPlaceholderGroup> placeholderGroups;
     placeholderGroups.push_back(placeholderGroup);
     CSearchResult search_result;
-    auto res = Search(segment, plan, placeholderGroup, {}, &search_result);
+    auto res = Search(segment,
+                      plan,
+                      placeholderGroup,
+                      {},
+                      nullptr,
+                      &search_result);
     std::cout << res.error_msg << std::endl;
     ASSERT_EQ(res.error_code, Success);
 
     CSearchResult search_result2;
-    auto res2 = Search(segment, plan, placeholderGroup, {}, &search_result2);
+    auto res2 = Search(segment,
+                       plan,
+                       placeholderGroup,
+                       {},
+                       nullptr,
+                       &search_result2);
     ASSERT_EQ(res2.error_code, Success);
 
     DeleteSearchPlan(plan);
@@ -3841,7 +3944,7 @@ TEST(CApiTest, SealedSegment_search_float_With_Expr_Predicate_Range) {
 
     CSearchResult c_search_result_on_bigIndex;
     auto res_after_load_index = Search(
-        segment, plan, placeholderGroup, {}, &c_search_result_on_bigIndex);
+        segment, plan, placeholderGroup, {}, nullptr, &c_search_result_on_bigIndex);
     ASSERT_EQ(res_after_load_index.error_code, Success);
 
     auto search_result_on_bigIndex = (SearchResult*)c_search_result_on_bigIndex;
@@ -4134,7 +4237,12 @@ TEST(CApiTest, RANGE_SEARCH_WITH_RADIUS_WHEN_IP) {
     placeholderGroups.push_back(placeholderGroup);
 
     CSearchResult search_result;
-    auto res = Search(segment, plan, placeholderGroup, {}, &search_result);
+    auto res = Search(segment,
+                      plan,
+                      placeholderGroup,
+                      {},
+                      nullptr,
+                      &search_result);
     ASSERT_EQ(res.error_code, Success);
 
     DeleteSearchPlan(plan);
@@ -4197,7 +4305,12 @@ TEST(CApiTest, RANGE_SEARCH_WITH_RADIUS_AND_RANGE_FILTER_WHEN_IP) {
     placeholderGroups.push_back(placeholderGroup);
 
     CSearchResult search_result;
-    auto res = Search(segment, plan, placeholderGroup, {}, &search_result);
+    auto res = Search(segment,
+                      plan,
+                      placeholderGroup,
+                      {},
+                      nullptr,
+                      &search_result);
     ASSERT_EQ(res.error_code, Success);
 
     DeleteSearchPlan(plan);
@@ -4260,7 +4373,12 @@ TEST(CApiTest, RANGE_SEARCH_WITH_RADIUS_WHEN_L2) {
     placeholderGroups.push_back(placeholderGroup);
 
     CSearchResult search_result;
-    auto res = Search(segment, plan, placeholderGroup, {}, &search_result);
+    auto res = Search(segment,
+                      plan,
+                      placeholderGroup,
+                      {},
+                      nullptr,
+                      &search_result);
     ASSERT_EQ(res.error_code, Success);
 
     DeleteSearchPlan(plan);
@@ -4323,7 +4441,12 @@ TEST(CApiTest, RANGE_SEARCH_WITH_RADIUS_AND_RANGE_FILTER_WHEN_L2) {
     placeholderGroups.push_back(placeholderGroup);
 
     CSearchResult search_result;
-    auto res = Search(segment, plan, placeholderGroup, {}, &search_result);
+    auto res = Search(segment,
+                      plan,
+                      placeholderGroup,
+                      {},
+                      nullptr,
+                      &search_result);
     ASSERT_EQ(res.error_code, Success);
 
     DeleteSearchPlan(plan);
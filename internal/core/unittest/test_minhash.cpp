@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+#include <gtest/gtest.h>
+#include <cstring>
+#include <vector>
+
+#include "common/Consts.h"
+#include "common/MinHash.h"
+
+using namespace milvus;
+
+namespace {
+
+// signatures of num_hashes 32-bit hash values, the first num_equal ones of
+// each signature equal to the ones of the query
+std::vector<uint8_t>
+GenSignatures(const std::vector<int>& num_equals, int num_hashes) {
+    std::vector<uint8_t> data(num_equals.size() * num_hashes *
+                              sizeof(uint32_t));
+    for (size_t i = 0; i < num_equals.size(); i++) {
+        for (int j = 0; j < num_hashes; j++) {
+            uint32_t value = j < num_equals[i] ? j : j + 1000 * (i + 1);
+            std::memcpy(data.data() + (i * num_hashes + j) * sizeof(uint32_t),
+                        &value,
+                        sizeof(uint32_t));
+        }
+    }
+    return data;
+}
+
+}  // namespace
+
+TEST(MinHash, Metric) {
+    EXPECT_TRUE(IsMinHashMetricType("MHJACCARD"));
+    EXPECT_TRUE(IsMinHashMetricType("mhjaccard"));
+    EXPECT_FALSE(IsMinHashMetricType(knowhere::metric::JACCARD));
+
+    EXPECT_EQ(GetMinHashElementSize(Config{}), 4);
+    EXPECT_EQ(GetMinHashElementSize(Config{{MH_ELEMENT_BIT_WIDTH, "64"}}), 8);
+    EXPECT_EQ(GetMinHashElementSize(Config{{MH_ELEMENT_BIT_WIDTH, 32}}), 4);
+    EXPECT_ANY_THROW(
+        GetMinHashElementSize(Config{{MH_ELEMENT_BIT_WIDTH, "16"}}));
+}
+
+TEST(MinHash, Distance) {
+    int num_hashes = 8;
+    int dim = num_hashes * 32;
+    auto query = GenSignatures({num_hashes}, num_hashes);
+    auto base = GenSignatures({8, 6, 0}, num_hashes);
+
+    auto code_size = dim / 8;
+    EXPECT_FLOAT_EQ(MinHashJaccardDistance(query.data(), base.data(), dim, 4),
+                    0);
+    EXPECT_FLOAT_EQ(
+        MinHashJaccardDistance(query.data(), base.data() + code_size, dim, 4),
+        0.25);
+    EXPECT_FLOAT_EQ(MinHashJaccardDistance(
+                        query.data(), base.data() + 2 * code_size, dim, 4),
+                    1);
+    // the dim isn't a multiple of the 64-bit elements
+    EXPECT_ANY_THROW(
+        MinHashJaccardDistance(query.data(), base.data(), 32 * 3, 8));
+}
+
+TEST(MinHash, Search) {
+    int num_hashes = 8;
+    int dim = num_hashes * 32;
+    int topk = 3;
+    auto query = GenSignatures({num_hashes}, num_hashes);
+    auto base = GenSignatures({2, 8, 6, 4}, num_hashes);
+
+    std::vector<int64_t> offsets(topk);
+    std::vector<float> distances(topk);
+    MinHashSearch(base.data(),
+                  4,
+                  query.data(),
+                  1,
+                  dim,
+                  topk,
+                  Config{},
+                  nullptr,
+                  offsets.data(),
+                  distances.data());
+    EXPECT_EQ(offsets, (std::vector<int64_t>{1, 2, 3}));
+    EXPECT_EQ(distances, (std::vector<float>{0, 0.25, 0.5}));
+
+    // filter the nearest one out
+    std::vector<uint8_t> bitset{0b0010};
+    MinHashSearch(base.data(),
+                  4,
+                  query.data(),
+                  1,
+                  dim,
+                  topk,
+                  Config{},
+                  BitsetView(bitset.data(), 4),
+                  offsets.data(),
+                  distances.data());
+    EXPECT_EQ(offsets, (std::vector<int64_t>{2, 3, 0}));
+
+    // only the ones in [0.25, 0.6) are returned
+    MinHashSearch(base.data(),
+                  4,
+                  query.data(),
+                  1,
+                  dim,
+                  topk,
+                  Config{{RADIUS, 0.6}, {RANGE_FILTER, 0.25}},
+                  nullptr,
+                  offsets.data(),
+                  distances.data());
+    EXPECT_EQ(offsets, (std::vector<int64_t>{2, 3, -1}));
+    EXPECT_EQ(distances[1], 0.5);
+}
+
+TEST(MinHash, SearchInBatches) {
+    int num_hashes = 8;
+    int dim = num_hashes * 32;
+    int code_size = dim / 8;
+    int topk = 3;
+    auto query = GenSignatures({num_hashes}, num_hashes);
+    auto base = GenSignatures({2, 8, 6, 4}, num_hashes);
+
+    // the batches are searched one by one, with the offsets of all the rows
+    std::vector<int64_t> offsets(topk);
+    std::vector<float> distances(topk);
+    std::vector<uint8_t> bitset{0b0010};
+    MinHashSearcher searcher(query.data(), 1, dim, topk, Config{});
+    searcher.Search(base.data(), 2, 0, BitsetView(bitset.data(), 4));
+    searcher.Search(
+        base.data() + 2 * code_size, 2, 2, BitsetView(bitset.data(), 4));
+    searcher.Fill(offsets.data(), distances.data());
+    EXPECT_EQ(offsets, (std::vector<int64_t>{2, 3, 0}));
+    EXPECT_EQ(distances, (std::vector<float>{0.25, 0.5, 0.75}));
+
+    // the unaligned signatures are searched as well
+    std::vector<uint8_t> unaligned(base.size() + 1);
+    std::memcpy(unaligned.data() + 1, base.data(), base.size());
+    MinHashSearch(unaligned.data() + 1,
+                  4,
+                  query.data(),
+                  1,
+                  dim,
+                  topk,
+                  Config{},
+                  nullptr,
+                  offsets.data(),
+                  distances.data());
+    EXPECT_EQ(offsets, (std::vector<int64_t>{1, 2, 3}));
+    EXPECT_FLOAT_EQ(
+        MinHashJaccardDistance(query.data(), unaligned.data() + 1, dim, 4),
+        0.75);
+}
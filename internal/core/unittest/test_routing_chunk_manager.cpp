@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+#include <gtest/gtest.h>
+
+#include <string>
+
+#include "storage/LocalChunkManager.h"
+#include "storage/RoutingChunkManager.h"
+
+using namespace milvus::storage;
+
+TEST(RoutingChunkManager, Route) {
+    std::string default_root = "/tmp/routing-test-default";
+    std::string eu_root = "/tmp/routing-test-eu";
+    auto default_cm = std::make_shared<LocalChunkManager>(default_root);
+    auto eu_cm = std::make_shared<LocalChunkManager>(eu_root);
+    default_cm->RemoveDir(default_root);
+    eu_cm->RemoveDir(eu_root);
+
+    RoutingChunkManager rcm(default_cm);
+    EXPECT_EQ(rcm.GetRootPath(), default_root);
+    EXPECT_ANY_THROW(rcm.SetRoute(1, "eu"));
+    rcm.AddProfile("eu", eu_cm);
+    rcm.SetRoute(1, "eu");
+
+    uint8_t data[3] = {0x1, 0x2, 0x3};
+    auto routed = default_root + "/insert_log/1/10/100/101/1000";
+    auto unrouted = default_root + "/insert_log/2/20/200/101/2000";
+    auto index_file = default_root + "/index_files/1/1/10/100/index";
+    rcm.Write(routed, data, sizeof(data));
+    rcm.Write(unrouted, data, sizeof(data));
+    rcm.Write(index_file, data, sizeof(data));
+
+    // the routed binlog is stored under the root path of the profile
+    EXPECT_TRUE(eu_cm->Exist(eu_root + "/insert_log/1/10/100/101/1000"));
+    EXPECT_FALSE(default_cm->Exist(routed));
+    EXPECT_TRUE(default_cm->Exist(unrouted));
+    EXPECT_TRUE(default_cm->Exist(index_file));
+
+    uint8_t buf[3];
+    EXPECT_EQ(rcm.Size(routed), sizeof(data));
+    EXPECT_EQ(rcm.Read(routed, buf, sizeof(buf)), sizeof(buf));
+    EXPECT_EQ(buf[2], 0x3);
+
+    rcm.Remove(routed);
+    EXPECT_FALSE(rcm.Exist(routed));
+    EXPECT_TRUE(rcm.Exist(unrouted));
+
+    default_cm->RemoveDir(default_root);
+    eu_cm->RemoveDir(eu_root);
+}
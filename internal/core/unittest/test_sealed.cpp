@@ -10,6 +10,7 @@
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
 #include <gtest/gtest.h>
+#include <set>
 #include <boost/format.hpp>
 
 #include "common/Types.h"
@@ -1707,4 +1708,97 @@ TEST(Sealed, QueryAllFields) {
               dataset_size);
     EXPECT_EQ(float_array_result->scalars().array_data().data_size(),
               dataset_size);
-}
\ No newline at end of file
+}
+TEST(Sealed, DictionaryEncodedColumn) {
+    auto field_meta = milvus::FieldMeta(milvus::FieldName("category"),
+                                        milvus::FieldId(100),
+                                        milvus::DataType::VARCHAR,
+                                        64);
+    std::vector<std::string> values = {"red", "green", "", "blue"};
+    size_t num_rows = 1000;
+    auto column =
+        std::make_shared<VariableColumn<std::string>>(num_rows, field_meta);
+    for (size_t i = 0; i < num_rows; i++) {
+        auto& value = values[i % values.size()];
+        column->Append(value.data(), value.size());
+    }
+    column->Seal();
+
+    // too many distinct values
+    EXPECT_FALSE(column->EncodeDictionary(3));
+    EXPECT_FALSE(column->IsDictionaryEncoded());
+
+    EXPECT_TRUE(column->EncodeDictionary(4));
+    EXPECT_TRUE(column->IsDictionaryEncoded());
+    EXPECT_EQ(column->Dictionary().size(), values.size());
+    EXPECT_EQ(column->Codes().size(), num_rows);
+    for (size_t i = 0; i < num_rows; i++) {
+        auto& value = values[i % values.size()];
+        EXPECT_EQ(column->RawAt(i), value);
+        EXPECT_EQ((*column)[i], value);
+        EXPECT_EQ(column->Dictionary()[column->Codes()[i]], value);
+    }
+
+    // the views of the rows are dropped
+    EXPECT_ANY_THROW(column->Span());
+
+    // the dictionary survives the move
+    auto moved = VariableColumn<std::string>(std::move(*column));
+    EXPECT_EQ(moved.RawAt(1), values[1]);
+}
+
+TEST(Sealed, SearchOnQuantized) {
+    auto schema = std::make_shared<Schema>();
+    auto dim = 16;
+    auto topk = 10;
+    auto N = 1000;
+    auto fakevec_id = schema->AddDebugField(
+        "fakevec", DataType::VECTOR_FLOAT, dim, knowhere::metric::L2);
+    auto counter_id = schema->AddDebugField("counter", DataType::INT64);
+    schema->set_primary_field_id(counter_id);
+
+    auto dataset = DataGen(schema, N);
+    auto vec = dataset.get_col<float>(fakevec_id);
+    SQ8Quantizer quantizer(vec.data(), N, dim);
+    EXPECT_LT(quantizer.ByteSize(), int64_t(N * dim * sizeof(float) / 2));
+
+    auto query = vec.data();
+    for (std::string metric_type : {knowhere::metric::L2,
+                                    knowhere::metric::IP,
+                                    knowhere::metric::COSINE}) {
+        SearchInfo search_info;
+        search_info.field_id_ = fakevec_id;
+        search_info.topk_ = topk;
+        search_info.metric_type_ = metric_type;
+        search_info.round_decimal_ = -1;
+
+        SearchResult quantized;
+        SearchOnQuantized(*schema,
+                          quantizer,
+                          vec.data(),
+                          search_info,
+                          query,
+                          1,
+                          nullptr,
+                          quantized);
+        SearchResult exact;
+        SearchOnSealed(
+            *schema, vec.data(), search_info, query, 1, N, nullptr, exact);
+
+        // the distances are refined by the raw vectors
+        std::set<int64_t> exact_offsets(exact.seg_offsets_.begin(),
+                                        exact.seg_offsets_.end());
+        int hits = 0;
+        for (int i = 0; i < topk; i++) {
+            auto offset = quantized.seg_offsets_[i];
+            auto distance = ExactDistance(
+                metric_type, query, vec.data() + offset * dim, dim);
+            EXPECT_NEAR(quantized.distances_[i], distance, 1e-4);
+            hits += exact_offsets.count(offset);
+        }
+        EXPECT_GE(hits, topk * 8 / 10);
+        if (metric_type == knowhere::metric::L2) {
+            EXPECT_EQ(quantized.seg_offsets_[0], 0);
+        }
+    }
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
@@ -37,6 +38,7 @@ type Broker interface {
 	ShowPartitionsInternal(ctx context.Context, collectionID int64) ([]int64, error)
 	ShowCollections(ctx context.Context, dbName string) (*milvuspb.ShowCollectionsResponse, error)
 	ListDatabases(ctx context.Context) (*milvuspb.ListDatabasesResponse, error)
+	DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error)
 	HasCollection(ctx context.Context, collectionID int64) (bool, error)
 }
 
@@ -129,6 +131,21 @@ func (b *coordinatorBroker) ListDatabases(ctx context.Context) (*milvuspb.ListDa
 	return resp, nil
 }
 
+// DescribeDatabase returns the meta of database including the properties.
+func (b *coordinatorBroker) DescribeDatabase(ctx context.Context, dbName string) (*rootcoordpb.DescribeDatabaseResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
+	defer cancel()
+	resp, err := b.rootCoord.DescribeDatabase(ctx, &rootcoordpb.DescribeDatabaseRequest{
+		Base:   commonpbutil.NewMsgBase(),
+		DbName: dbName,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		log.Ctx(ctx).Warn("failed to DescribeDatabase", zap.String("dbName", dbName), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
 // HasCollection communicates with RootCoord and check whether this collection exist from the user's perspective.
 func (b *coordinatorBroker) HasCollection(ctx context.Context, collectionID int64) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, paramtable.Get().QueryCoordCfg.BrokerTimeout.GetAsDuration(time.Millisecond))
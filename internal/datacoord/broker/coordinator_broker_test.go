@@ -27,8 +27,10 @@ import (
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
@@ -210,6 +212,38 @@ func (s *BrokerSuite) TestListDatabases() {
 	})
 }
 
+func (s *BrokerSuite) TestDescribeDatabase() {
+	s.Run("return_success", func() {
+		s.SetupTest()
+
+		s.rootCoordClient.EXPECT().DescribeDatabase(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *rootcoordpb.DescribeDatabaseRequest, options ...grpc.CallOption) (*rootcoordpb.DescribeDatabaseResponse, error) {
+			s.Equal("db_1", req.GetDbName())
+			return &rootcoordpb.DescribeDatabaseResponse{
+				Status:     merr.Status(nil),
+				DbName:     "db_1",
+				Properties: []*commonpb.KeyValuePair{{Key: "key", Value: "value"}},
+			}, nil
+		})
+
+		resp, err := s.broker.DescribeDatabase(context.Background(), "db_1")
+		s.NoError(err)
+		s.Len(resp.GetProperties(), 1)
+
+		s.TearDownTest()
+	})
+
+	s.Run("return_error", func() {
+		s.SetupTest()
+
+		s.rootCoordClient.EXPECT().DescribeDatabase(mock.Anything, mock.Anything).Return(nil, errors.New("mocked"))
+
+		_, err := s.broker.DescribeDatabase(context.Background(), "db_1")
+		s.Error(err)
+
+		s.TearDownTest()
+	})
+}
+
 func (s *BrokerSuite) TestHasCollection() {
 	s.Run("return_success", func() {
 		s.SetupTest()
@@ -0,0 +1,295 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/datacoord/broker"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// channelIsolation keeps the dml channels of the isolated databases on the datanodes reserved by them,
+// so that the ingestion of a database can't delay the timeticks of others.
+//
+// The isolation is declared by the database properties in rootcoord, which reserves the physical channels
+// for the collections of database, datacoord refreshes the declarations periodically.
+// The datanodes reserved by databases don't consume the channels of others.
+type channelIsolation struct {
+	mu sync.RWMutex
+	// physical channel => the datanodes reserved by the database owning it
+	channelNodes  map[string]typeutil.UniqueSet
+	reservedNodes typeutil.UniqueSet
+
+	getBroker func() broker.Broker
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newChannelIsolation(getBroker func() broker.Broker) *channelIsolation {
+	return &channelIsolation{
+		channelNodes:  make(map[string]typeutil.UniqueSet),
+		reservedNodes: typeutil.NewUniqueSet(),
+		getBroker:     getBroker,
+		closeCh:       make(chan struct{}),
+	}
+}
+
+func (ci *channelIsolation) start(ctx context.Context) {
+	ci.wg.Add(1)
+	go func() {
+		defer ci.wg.Done()
+		ticker := time.NewTicker(Params.DataCoordCfg.DatabaseIsolationRefreshInterval.GetAsDuration(time.Second))
+		defer ticker.Stop()
+		for {
+			if err := ci.refresh(ctx); err != nil {
+				log.Warn("failed to refresh database isolation", zap.Error(err))
+			}
+			select {
+			case <-ci.closeCh:
+				log.Info("channel isolation refresher quit")
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (ci *channelIsolation) close() {
+	ci.closeOnce.Do(func() {
+		close(ci.closeCh)
+		ci.wg.Wait()
+	})
+}
+
+// refresh reloads the isolation declared by the properties of all databases.
+func (ci *channelIsolation) refresh(ctx context.Context) error {
+	b := ci.getBroker()
+	if b == nil {
+		return nil
+	}
+	resp, err := b.ListDatabases(ctx)
+	if err != nil {
+		return err
+	}
+	dbs := make([]*rootcoordpb.DescribeDatabaseResponse, 0, len(resp.GetDbNames()))
+	for _, dbName := range resp.GetDbNames() {
+		db, err := b.DescribeDatabase(ctx, dbName)
+		if err != nil {
+			return err
+		}
+		dbs = append(dbs, db)
+	}
+	ci.update(dbs)
+	return nil
+}
+
+func (ci *channelIsolation) update(dbs []*rootcoordpb.DescribeDatabaseResponse) {
+	channelNodes := make(map[string]typeutil.UniqueSet)
+	reservedNodes := typeutil.NewUniqueSet()
+	for _, db := range dbs {
+		nodeIDs, err := common.GetDatabaseDataNodes(db.GetProperties()...)
+		if err != nil {
+			log.Warn("invalid datanodes reserved by database", zap.String("db", db.GetDbName()), zap.Error(err))
+			continue
+		}
+		if len(nodeIDs) == 0 {
+			continue
+		}
+		nodes := typeutil.NewUniqueSet(nodeIDs...)
+		reservedNodes.Insert(nodeIDs...)
+		for _, channel := range common.GetDatabaseDmlChannels(db.GetProperties()...) {
+			channelNodes[channel] = nodes
+		}
+	}
+
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.channelNodes = channelNodes
+	ci.reservedNodes = reservedNodes
+}
+
+// allowed returns whether the virtual channel could be consumed by the datanode.
+func (ci *channelIsolation) allowed(channel string, nodeID int64) bool {
+	if ci == nil {
+		return true
+	}
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	return ci.allowedLocked(channel, nodeID)
+}
+
+func (ci *channelIsolation) allowedLocked(channel string, nodeID int64) bool {
+	if nodes, ok := ci.channelNodes[funcutil.ToPhysicalChannel(channel)]; ok {
+		return nodes.Contain(nodeID)
+	}
+	return !ci.reservedNodes.Contain(nodeID)
+}
+
+// isolate adjusts the channel assignments of updates which break the isolation,
+// the channels moving to a disallowed node stay on the original one if possible,
+// otherwise they're assigned to the least loaded allowed node, or the buffer if none.
+// The excluded nodes, e.g. the node going offline, are never chosen.
+func (ci *channelIsolation) isolate(store ROChannelStore, updates *ChannelOpSet, excluded ...int64) *ChannelOpSet {
+	if ci == nil || updates == nil {
+		return updates
+	}
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	if len(ci.channelNodes) == 0 && ci.reservedNodes.Len() == 0 {
+		return updates
+	}
+
+	excludedNodes := typeutil.NewUniqueSet(excluded...)
+	sources := make(map[string]int64)
+	for _, op := range updates.Collect() {
+		if op.Type == Delete {
+			for _, ch := range op.Channels {
+				sources[ch.GetName()] = op.NodeID
+			}
+		}
+	}
+	loads := make(map[int64]int)
+	for _, nodeID := range store.GetNodes() {
+		loads[nodeID] = store.GetNodeChannelCount(nodeID)
+	}
+
+	stays := typeutil.NewSet[string]()
+	targets := make(map[string]int64)
+	for _, op := range updates.Collect() {
+		if op.Type != Add || op.NodeID == bufferID {
+			continue
+		}
+		for _, ch := range op.Channels {
+			// the channel is already on the node, e.g. to be released
+			if ci.allowedLocked(ch.GetName(), op.NodeID) || hasChannel(store, op.NodeID, ch.GetName()) {
+				continue
+			}
+			source, moving := sources[ch.GetName()]
+			if !moving {
+				// the new channel without any source
+				source = bufferID
+			}
+			if source != bufferID && !excludedNodes.Contain(source) && ci.allowedLocked(ch.GetName(), source) {
+				stays.Insert(ch.GetName())
+				continue
+			}
+			target := ci.pickNodeLocked(ch.GetName(), loads, excludedNodes)
+			if moving && target == source {
+				stays.Insert(ch.GetName())
+				continue
+			}
+			targets[ch.GetName()] = target
+			loads[target]++
+		}
+	}
+	if stays.Len() == 0 && len(targets) == 0 {
+		return updates
+	}
+
+	result := NewChannelOpSet()
+	rerouted := make(map[int64][]RWChannel)
+	for _, op := range updates.Collect() {
+		channels := make([]RWChannel, 0, len(op.Channels))
+		for _, ch := range op.Channels {
+			if stays.Contain(ch.GetName()) {
+				continue
+			}
+			if target, ok := targets[ch.GetName()]; ok && op.Type == Add {
+				rerouted[target] = append(rerouted[target], ch)
+				continue
+			}
+			channels = append(channels, ch)
+		}
+		if len(channels) > 0 {
+			result.Insert(&ChannelOp{Type: op.Type, NodeID: op.NodeID, Channels: channels})
+		}
+	}
+	for nodeID, channels := range rerouted {
+		result.Add(nodeID, channels...)
+	}
+	log.Info("channel assignments adjusted by database isolation",
+		zap.Strings("stays", stays.Collect()), zap.Any("rerouted", targets))
+	if result.Len() == 0 {
+		return nil
+	}
+	return result
+}
+
+func hasChannel(store ROChannelStore, nodeID int64, channel string) bool {
+	info := store.GetNode(nodeID)
+	if info == nil {
+		return false
+	}
+	for _, ch := range info.Channels {
+		if ch.GetName() == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func (ci *channelIsolation) pickNodeLocked(channel string, loads map[int64]int, excluded typeutil.UniqueSet) int64 {
+	target := int64(bufferID)
+	for nodeID, load := range loads {
+		if excluded.Contain(nodeID) || !ci.allowedLocked(channel, nodeID) {
+			continue
+		}
+		if target == bufferID || load < loads[target] || (load == loads[target] && nodeID < target) {
+			target = nodeID
+		}
+	}
+	return target
+}
+
+// filterReleases removes the channels which can't be moved to the new node from the balance releases.
+func (ci *channelIsolation) filterReleases(updates *ChannelOpSet, nodeID int64) *ChannelOpSet {
+	if ci == nil || updates == nil {
+		return updates
+	}
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+
+	result := NewChannelOpSet()
+	for _, op := range updates.Collect() {
+		channels := make([]RWChannel, 0, len(op.Channels))
+		for _, ch := range op.Channels {
+			if ci.allowedLocked(ch.GetName(), nodeID) {
+				channels = append(channels, ch)
+			}
+		}
+		if len(channels) > 0 {
+			result.Insert(&ChannelOp{Type: op.Type, NodeID: op.NodeID, Channels: channels})
+		}
+	}
+	if result.Len() == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/datacoord/broker"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func newTestChannelIsolation() *channelIsolation {
+	ci := newChannelIsolation(func() broker.Broker { return nil })
+	ci.update([]*rootcoordpb.DescribeDatabaseResponse{
+		{
+			DbName: "db1",
+			Properties: []*commonpb.KeyValuePair{
+				{Key: common.DatabaseDmlChannelsKey, Value: "dml_0"},
+				{Key: common.DatabaseDataNodesKey, Value: "1"},
+			},
+		},
+		{
+			DbName: "default",
+		},
+	})
+	return ci
+}
+
+func TestChannelIsolation_Allowed(t *testing.T) {
+	var nilIsolation *channelIsolation
+	assert.True(t, nilIsolation.allowed("dml_0_1v0", 2))
+
+	ci := newTestChannelIsolation()
+	assert.True(t, ci.allowed("dml_0_1v0", 1))
+	assert.False(t, ci.allowed("dml_0_1v0", 2))
+	assert.False(t, ci.allowed("dml_1_2v0", 1))
+	assert.True(t, ci.allowed("dml_1_2v0", 2))
+}
+
+func TestChannelIsolation_Isolate(t *testing.T) {
+	ci := newTestChannelIsolation()
+	store := &ChannelStore{
+		memkv.NewMemoryKV(),
+		map[int64]*NodeChannelInfo{
+			1: {1, []RWChannel{}},
+			2: {2, []RWChannel{getChannel("dml_1_2v0", 2)}},
+			3: {3, []RWChannel{}},
+		},
+	}
+
+	t.Run("new channel to reserved node", func(t *testing.T) {
+		updates := ci.isolate(store, NewChannelOpSet(NewAddOp(2, getChannel("dml_0_1v0", 1))))
+		assert.Equal(t, NewChannelOpSet(NewAddOp(1, getChannel("dml_0_1v0", 1))), updates)
+	})
+
+	t.Run("new channel kept off reserved node", func(t *testing.T) {
+		updates := ci.isolate(store, NewChannelOpSet(NewAddOp(1, getChannel("dml_1_3v0", 3))))
+		assert.Equal(t, NewChannelOpSet(NewAddOp(3, getChannel("dml_1_3v0", 3))), updates)
+	})
+
+	t.Run("channel stays on allowed node", func(t *testing.T) {
+		updates := ci.isolate(store, NewChannelOpSet(
+			NewDeleteOp(2, getChannel("dml_1_2v0", 2)),
+			NewAddOp(1, getChannel("dml_1_2v0", 2)),
+		))
+		assert.Nil(t, updates)
+	})
+
+	t.Run("channel buffered without allowed node", func(t *testing.T) {
+		updates := ci.isolate(store, NewChannelOpSet(
+			NewDeleteOp(1, getChannel("dml_0_1v0", 1)),
+			NewAddOp(2, getChannel("dml_0_1v0", 1)),
+		), 1)
+		assert.Equal(t, NewChannelOpSet(
+			NewDeleteOp(1, getChannel("dml_0_1v0", 1)),
+			NewAddOp(bufferID, getChannel("dml_0_1v0", 1)),
+		), updates)
+	})
+
+	t.Run("allowed updates untouched", func(t *testing.T) {
+		updates := NewChannelOpSet(
+			NewDeleteOp(2, getChannel("dml_1_2v0", 2)),
+			NewAddOp(3, getChannel("dml_1_2v0", 2)),
+		)
+		assert.Equal(t, updates, ci.isolate(store, updates))
+	})
+}
+
+func TestChannelIsolation_FilterReleases(t *testing.T) {
+	ci := newTestChannelIsolation()
+	updates := NewChannelOpSet(NewAddOp(2, getChannel("dml_0_1v0", 1), getChannel("dml_1_2v0", 2)))
+
+	assert.Equal(t, NewChannelOpSet(NewAddOp(2, getChannel("dml_1_2v0", 2))), ci.filterReleases(updates, 3))
+	assert.Nil(t, ci.filterReleases(NewChannelOpSet(NewAddOp(2, getChannel("dml_1_2v0", 2))), 1))
+}
+
+func TestChannelIsolation_Refresh(t *testing.T) {
+	rc := mocks.NewMockRootCoordClient(t)
+	rc.EXPECT().ListDatabases(mock.Anything, mock.Anything).Return(&milvuspb.ListDatabasesResponse{
+		Status:  merr.Status(nil),
+		DbNames: []string{"default", "db1"},
+	}, nil)
+	rc.EXPECT().DescribeDatabase(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *rootcoordpb.DescribeDatabaseRequest, opts ...grpc.CallOption) (*rootcoordpb.DescribeDatabaseResponse, error) {
+			resp := &rootcoordpb.DescribeDatabaseResponse{Status: merr.Status(nil), DbName: req.GetDbName()}
+			if req.GetDbName() == "db1" {
+				resp.Properties = []*commonpb.KeyValuePair{
+					{Key: common.DatabaseDmlChannelsKey, Value: "dml_0"},
+					{Key: common.DatabaseDataNodesKey, Value: "1,2"},
+				}
+			}
+			return resp, nil
+		})
+
+	b := broker.NewCoordinatorBroker(rc)
+	ci := newChannelIsolation(func() broker.Broker { return b })
+	assert.NoError(t, ci.refresh(context.Background()))
+	assert.True(t, ci.allowed("dml_0_1v0", 2))
+	assert.False(t, ci.allowed("dml_0_1v0", 3))
+	assert.False(t, ci.allowed("dml_1_2v0", 1))
+}
@@ -0,0 +1,306 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/util/eventbus"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// channelLatency is the latest timetick consumed of a virtual channel.
+type channelLatency struct {
+	nodeID     UniqueID
+	consumedTs Timestamp
+	reportTime time.Time
+}
+
+// channelLatencyTracker tracks the timeticks consumed by datanodes,
+// to measure the end-to-end latency and the backlog of every virtual channel.
+//
+// The timeticks are produced by rootcoord with the physical time, so the latency of a timetick
+// is the time it's reported minus its physical time, which is exported as consume_datanode_tt_lag_ms,
+// and the backlog is the now time minus the latest timetick consumed, which keeps growing if the consumer
+// of channel is stuck. The lag in rows and bytes is the rows allocated to the channel but not consumed yet.
+type channelLatencyTracker struct {
+	mu       sync.RWMutex
+	channels map[string]*channelLatency
+
+	getCheckpoint func(channel string) *msgpb.MsgPosition
+	getLag        func(channel string) (rows int64, bytes int64)
+
+	// laggingNodes are the datanodes whose timeticks consumed lag behind the global watermark at the last check
+	laggingNodes map[UniqueID]bool
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newChannelLatencyTracker(getCheckpoint func(channel string) *msgpb.MsgPosition,
+	getLag func(channel string) (rows int64, bytes int64),
+) *channelLatencyTracker {
+	return &channelLatencyTracker{
+		channels:      make(map[string]*channelLatency),
+		getCheckpoint: getCheckpoint,
+		getLag:        getLag,
+		laggingNodes:  make(map[UniqueID]bool),
+		closeCh:       make(chan struct{}),
+	}
+}
+
+func (t *channelLatencyTracker) start() {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := time.NewTicker(Params.DataCoordCfg.ChannelLatencyCheckInterval.GetAsDuration(time.Second))
+		defer ticker.Stop()
+		skewTicker := time.NewTicker(Params.CommonCfg.TimeTickSkewCheckInterval.GetAsDuration(time.Second))
+		defer skewTicker.Stop()
+		for {
+			select {
+			case <-t.closeCh:
+				log.Info("channel latency tracker quit")
+				return
+			case <-ticker.C:
+				t.check()
+			case <-skewTicker.C:
+				t.checkSkew()
+			}
+		}
+	}()
+}
+
+func (t *channelLatencyTracker) close() {
+	t.closeOnce.Do(func() {
+		close(t.closeCh)
+		t.wg.Wait()
+	})
+}
+
+// observe records the timetick consumed by datanode.
+func (t *channelLatencyTracker) observe(nodeID UniqueID, channel string, ts Timestamp) {
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	latency, ok := t.channels[channel]
+	if !ok {
+		latency = &channelLatency{}
+		t.channels[channel] = latency
+	}
+	if ts < latency.consumedTs {
+		return
+	}
+	latency.nodeID = nodeID
+	latency.consumedTs = ts
+	latency.reportTime = now
+}
+
+// remove stops tracking the channel and deletes its metrics,
+// it's called when the channel is dropped or released by the datanode.
+func (t *channelLatencyTracker) remove(channel string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.channels, channel)
+	metrics.CleanupDataCoordChannelLatencyMetrics(channel)
+}
+
+// check refreshes the backlog metrics, and warns about the channels lagging behind.
+func (t *channelLatencyTracker) check() {
+	infos := t.snapshot()
+	for _, info := range infos.Channels {
+		metrics.DataCoordChannelBacklog.WithLabelValues(info.Channel).Set(float64(info.BacklogMs))
+		metrics.DataCoordChannelLagRows.WithLabelValues(info.Channel).Set(float64(info.LagRows))
+		metrics.DataCoordChannelLagBytes.WithLabelValues(info.Channel).Set(float64(info.LagBytes))
+		if info.Lagging {
+			metrics.DataCoordChannelLagging.WithLabelValues(info.Channel).Set(1)
+			log.Warn("channel lags behind",
+				zap.String("channel", info.Channel),
+				zap.Int64("nodeID", info.NodeID),
+				zap.Int64("backlog(ms)", info.BacklogMs),
+				zap.Int64("latency(ms)", info.LatencyMs),
+				zap.Int64("threshold(ms)", infos.ThresholdMs))
+		} else {
+			metrics.DataCoordChannelLagging.WithLabelValues(info.Channel).Set(0)
+		}
+	}
+}
+
+// snapshot returns the latency of all the channels, the channel with the largest backlog comes first.
+func (t *channelLatencyTracker) snapshot() *metricsinfo.ChannelLatencyInfos {
+	now := time.Now()
+	threshold := Params.DataCoordCfg.ChannelLatencyThreshold.GetAsDuration(time.Second)
+
+	t.mu.RLock()
+	infos := &metricsinfo.ChannelLatencyInfos{
+		ThresholdMs: threshold.Milliseconds(),
+		Channels:    make([]metricsinfo.ChannelLatency, 0, len(t.channels)),
+	}
+	for channel, latency := range t.channels {
+		physical, _ := tsoutil.ParseTS(latency.consumedTs)
+		backlog := now.Sub(physical)
+		infos.Channels = append(infos.Channels, metricsinfo.ChannelLatency{
+			Channel:           channel,
+			NodeID:            latency.nodeID,
+			ConsumedTimestamp: latency.consumedTs,
+			LatencyMs:         latency.reportTime.Sub(physical).Milliseconds(),
+			BacklogMs:         backlog.Milliseconds(),
+			Lagging:           backlog > threshold,
+		})
+	}
+	t.mu.RUnlock()
+
+	for i := range infos.Channels {
+		if cp := t.getCheckpoint(infos.Channels[i].Channel); cp != nil {
+			physical, _ := tsoutil.ParseTS(cp.GetTimestamp())
+			infos.Channels[i].CheckpointLagMs = now.Sub(physical).Milliseconds()
+		}
+		infos.Channels[i].LagRows, infos.Channels[i].LagBytes = t.getLag(infos.Channels[i].Channel)
+	}
+	sort.Slice(infos.Channels, func(i, j int) bool {
+		return infos.Channels[i].BacklogMs > infos.Channels[j].BacklogMs
+	})
+	return infos
+}
+
+// watermarks returns the timeticks consumed of the channels and the skew of the datanodes,
+// the global watermark is the latest timetick consumed by all the datanodes.
+func (t *channelLatencyTracker) watermarks() *metricsinfo.TimeTickWatermarkInfos {
+	now := time.Now()
+	threshold := Params.CommonCfg.TimeTickSkewThreshold.GetAsDuration(time.Second)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	infos := &metricsinfo.TimeTickWatermarkInfos{
+		ThresholdMs: threshold.Milliseconds(),
+		Channels:    make([]metricsinfo.ChannelWatermark, 0, len(t.channels)),
+	}
+	producers := make(map[UniqueID]*metricsinfo.ProducerSkew)
+	for channel, latency := range t.channels {
+		physical, _ := tsoutil.ParseTS(latency.consumedTs)
+		infos.Channels = append(infos.Channels, metricsinfo.ChannelWatermark{
+			Channel:   channel,
+			Watermark: latency.consumedTs,
+			LagMs:     now.Sub(physical).Milliseconds(),
+			NodeID:    latency.nodeID,
+		})
+		if latency.consumedTs > infos.GlobalWatermark {
+			infos.GlobalWatermark = latency.consumedTs
+		}
+
+		// the timetick of a datanode is the earliest one consumed of its channels
+		producer, ok := producers[latency.nodeID]
+		if !ok {
+			producer = &metricsinfo.ProducerSkew{
+				Role:      typeutil.DataNodeRole,
+				NodeID:    latency.nodeID,
+				Timestamp: latency.consumedTs,
+			}
+			producers[latency.nodeID] = producer
+		}
+		if latency.consumedTs < producer.Timestamp {
+			producer.Timestamp = latency.consumedTs
+		}
+		if lastReport := now.Sub(latency.reportTime).Milliseconds(); !ok || lastReport < producer.LastReportMs {
+			producer.LastReportMs = lastReport
+		}
+	}
+
+	infos.Producers = make([]metricsinfo.ProducerSkew, 0, len(producers))
+	for _, producer := range producers {
+		producer.SkewMs = tsoutil.PhysicalTime(infos.GlobalWatermark).Sub(tsoutil.PhysicalTime(producer.Timestamp)).Milliseconds()
+		producer.Lagging = producer.SkewMs > threshold.Milliseconds() || producer.LastReportMs > threshold.Milliseconds()
+		infos.Producers = append(infos.Producers, *producer)
+	}
+	sort.Slice(infos.Channels, func(i, j int) bool {
+		return infos.Channels[i].Channel < infos.Channels[j].Channel
+	})
+	sort.Slice(infos.Producers, func(i, j int) bool {
+		return infos.Producers[i].SkewMs > infos.Producers[j].SkewMs
+	})
+	return infos
+}
+
+// checkSkew refreshes the skew metrics of the datanodes, and alerts the ones start lagging behind the global watermark.
+func (t *channelLatencyTracker) checkSkew() {
+	infos := t.watermarks()
+	lagging := make(map[UniqueID]bool, len(infos.Producers))
+	for _, producer := range infos.Producers {
+		metrics.DataCoordDataNodeTimeTickSkew.WithLabelValues(strconv.FormatInt(producer.NodeID, 10)).Set(float64(producer.SkewMs))
+		lagging[producer.NodeID] = producer.Lagging
+		if producer.Lagging && !t.laggingNodes[producer.NodeID] {
+			log.Warn("timetick consumed by the datanode lags behind the global watermark",
+				zap.Int64("nodeID", producer.NodeID),
+				zap.Uint64("globalWatermark", infos.GlobalWatermark),
+				zap.Uint64("timestamp", producer.Timestamp),
+				zap.Int64("skewMs", producer.SkewMs),
+				zap.Int64("lastReportMs", producer.LastReportMs))
+			eventbus.Publish(eventbus.TimeTickLagging, 0, map[string]any{
+				"role":    producer.Role,
+				"node_id": producer.NodeID,
+				"skew_ms": producer.SkewMs,
+			})
+		}
+	}
+	for nodeID := range t.laggingNodes {
+		if _, ok := lagging[nodeID]; !ok {
+			metrics.DataCoordDataNodeTimeTickSkew.DeleteLabelValues(strconv.FormatInt(nodeID, 10))
+		}
+	}
+	t.laggingNodes = lagging
+}
+
+// channelLag returns the rows allocated to the channel but not consumed by the datanode yet and their estimated size,
+// the allocations expire once the datanode consumes the timetick after them.
+func (s *Server) channelLag(channel string) (int64, int64) {
+	var rows, bytes int64
+	sizePerRecord := make(map[UniqueID]int)
+	for _, segment := range s.meta.GetSegmentsByChannel(channel) {
+		if !isGrowing(segment) {
+			continue
+		}
+		segmentRows := int64(0)
+		for _, alloc := range segment.allocations {
+			segmentRows += alloc.NumOfRows
+		}
+		if segmentRows == 0 {
+			continue
+		}
+		size, ok := sizePerRecord[segment.GetCollectionID()]
+		if !ok {
+			if collection := s.meta.GetCollection(segment.GetCollectionID()); collection != nil {
+				size, _ = typeutil.EstimateSizePerRecord(collection.Schema)
+			}
+			sizePerRecord[segment.GetCollectionID()] = size
+		}
+		rows += segmentRows
+		bytes += segmentRows * int64(size)
+	}
+	return rows, bytes
+}
@@ -0,0 +1,140 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+func TestChannelLatencyTracker(t *testing.T) {
+	paramtable.Init()
+	now := time.Now()
+	checkpoints := map[string]*msgpb.MsgPosition{
+		"ch1": {Timestamp: tsoutil.ComposeTSByTime(now.Add(-10*time.Minute), 0)},
+	}
+	tracker := newChannelLatencyTracker(func(channel string) *msgpb.MsgPosition {
+		return checkpoints[channel]
+	}, func(channel string) (int64, int64) {
+		if channel == "ch1" {
+			return 100, 1000
+		}
+		return 0, 0
+	})
+	tracker.start()
+	defer tracker.close()
+
+	tracker.observe(1, "ch1", tsoutil.ComposeTSByTime(now.Add(-2*time.Minute), 0))
+	tracker.observe(2, "ch2", tsoutil.ComposeTSByTime(now.Add(-time.Second), 0))
+	// the stale timetick is ignored
+	tracker.observe(1, "ch1", tsoutil.ComposeTSByTime(now.Add(-3*time.Minute), 0))
+
+	infos := tracker.snapshot()
+	assert.Equal(t, int64(60000), infos.ThresholdMs)
+	assert.Len(t, infos.Channels, 2)
+
+	// the channel with the largest backlog comes first
+	ch1 := infos.Channels[0]
+	assert.Equal(t, "ch1", ch1.Channel)
+	assert.Equal(t, int64(1), ch1.NodeID)
+	assert.True(t, ch1.Lagging)
+	assert.GreaterOrEqual(t, ch1.BacklogMs, (2 * time.Minute).Milliseconds())
+	assert.GreaterOrEqual(t, ch1.LatencyMs, (2 * time.Minute).Milliseconds())
+	assert.GreaterOrEqual(t, ch1.CheckpointLagMs, (10 * time.Minute).Milliseconds())
+	assert.EqualValues(t, 100, ch1.LagRows)
+	assert.EqualValues(t, 1000, ch1.LagBytes)
+
+	ch2 := infos.Channels[1]
+	assert.Equal(t, "ch2", ch2.Channel)
+	assert.False(t, ch2.Lagging)
+	assert.Zero(t, ch2.CheckpointLagMs)
+	assert.Zero(t, ch2.LagRows)
+
+	tracker.check()
+
+	tracker.remove("ch1")
+	infos = tracker.snapshot()
+	assert.Len(t, infos.Channels, 1)
+	assert.Equal(t, "ch2", infos.Channels[0].Channel)
+}
+
+func TestChannelLatencyTracker_Watermarks(t *testing.T) {
+	paramtable.Init()
+	now := time.Now()
+	tracker := newChannelLatencyTracker(func(string) *msgpb.MsgPosition { return nil },
+		func(string) (int64, int64) { return 0, 0 })
+
+	tracker.observe(1, "ch1", tsoutil.ComposeTSByTime(now, 0))
+	tracker.observe(1, "ch2", tsoutil.ComposeTSByTime(now.Add(-time.Second), 0))
+	tracker.observe(2, "ch3", tsoutil.ComposeTSByTime(now.Add(-time.Minute), 0))
+
+	infos := tracker.watermarks()
+	assert.Equal(t, tsoutil.ComposeTSByTime(now, 0), infos.GlobalWatermark)
+	assert.Len(t, infos.Channels, 3)
+	assert.Equal(t, "ch1", infos.Channels[0].Channel)
+
+	// the datanode with the largest skew comes first
+	assert.Len(t, infos.Producers, 2)
+	assert.Equal(t, int64(2), infos.Producers[0].NodeID)
+	assert.Equal(t, time.Minute.Milliseconds(), infos.Producers[0].SkewMs)
+	assert.True(t, infos.Producers[0].Lagging)
+	assert.Equal(t, int64(1), infos.Producers[1].NodeID)
+	assert.Equal(t, time.Second.Milliseconds(), infos.Producers[1].SkewMs)
+	assert.False(t, infos.Producers[1].Lagging)
+
+	tracker.checkSkew()
+	assert.True(t, tracker.laggingNodes[2])
+	tracker.remove("ch3")
+	tracker.checkSkew()
+	assert.NotContains(t, tracker.laggingNodes, int64(2))
+}
+
+func TestServer_ChannelLag(t *testing.T) {
+	schema := &schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{
+		{FieldID: 100, DataType: schemapb.DataType_Int64},
+	}}
+	segments := NewSegmentsInfo()
+	segments.SetSegment(1, &SegmentInfo{
+		SegmentInfo: &datapb.SegmentInfo{ID: 1, CollectionID: 1, InsertChannel: "ch1", State: commonpb.SegmentState_Growing},
+		allocations: []*Allocation{{SegmentID: 1, NumOfRows: 10}, {SegmentID: 1, NumOfRows: 5}},
+	})
+	segments.SetSegment(2, &SegmentInfo{
+		SegmentInfo: &datapb.SegmentInfo{ID: 2, CollectionID: 1, InsertChannel: "ch1", State: commonpb.SegmentState_Flushed},
+		allocations: []*Allocation{{SegmentID: 2, NumOfRows: 100}},
+	})
+	svr := &Server{meta: &meta{
+		segments:    segments,
+		collections: map[UniqueID]*collectionInfo{1: {ID: 1, Schema: schema}},
+	}}
+
+	rows, bytes := svr.channelLag("ch1")
+	assert.EqualValues(t, 15, rows)
+	assert.EqualValues(t, 15*8, bytes)
+
+	rows, bytes = svr.channelLag("ch2")
+	assert.Zero(t, rows)
+	assert.Zero(t, bytes)
+}
@@ -28,6 +28,7 @@ import (
 	"go.uber.org/zap"
 	"stathat.com/c/consistent"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/common"
@@ -51,6 +52,9 @@ type ChannelManager struct {
 	bgChecker        ChannelBGChecker
 	balancePolicy    BalanceChannelPolicy
 	msgstreamFactory msgstream.Factory
+	isolation        *channelIsolation
+	// onRelease is called after the channel is released by the datanode
+	onRelease func(channelName string)
 
 	stateChecker channelStateChecker
 	stopChecker  context.CancelFunc
@@ -74,6 +78,14 @@ func withMsgstreamFactory(f msgstream.Factory) ChannelManagerOpt {
 	return func(c *ChannelManager) { c.msgstreamFactory = f }
 }
 
+func withChannelIsolation(ci *channelIsolation) ChannelManagerOpt {
+	return func(c *ChannelManager) { c.isolation = ci }
+}
+
+func withReleaseHook(fn func(channelName string)) ChannelManagerOpt {
+	return func(c *ChannelManager) { c.onRelease = fn }
+}
+
 func withStateChecker() ChannelManagerOpt {
 	return func(c *ChannelManager) { c.stateChecker = c.watchChannelStatesLoop }
 }
@@ -334,6 +346,8 @@ func (c *ChannelManager) AddNode(nodeID int64) error {
 	c.store.Add(nodeID)
 
 	bufferedUpdates, balanceUpdates := c.registerPolicy(c.store, nodeID)
+	bufferedUpdates = c.isolation.isolate(c.store, bufferedUpdates)
+	balanceUpdates = c.isolation.filterReleases(balanceUpdates, nodeID)
 
 	updates := bufferedUpdates
 	// try bufferedUpdates first
@@ -378,6 +392,7 @@ func (c *ChannelManager) DeleteNode(nodeID int64) error {
 	c.unsubAttempt(nodeChannelInfo)
 
 	updates := c.deregisterPolicy(c.store, nodeID)
+	updates = c.isolation.isolate(c.store, updates, nodeID)
 	if updates == nil {
 		return nil
 	}
@@ -433,7 +448,7 @@ func (c *ChannelManager) Watch(ctx context.Context, ch RWChannel) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	updates := c.assignPolicy(c.store, []RWChannel{ch})
+	updates := c.isolation.isolate(c.store, c.assignPolicy(c.store, []RWChannel{ch}))
 	if updates == nil {
 		return nil
 	}
@@ -457,10 +472,11 @@ func (c *ChannelManager) fillChannelWatchInfoWithState(op *ChannelOp, state data
 	for _, ch := range op.Channels {
 		vcInfo := c.h.GetDataVChanPositions(ch, allPartitionID)
 		info := &datapb.ChannelWatchInfo{
-			Vchan:   vcInfo,
-			StartTs: startTs,
-			State:   state,
-			Schema:  ch.GetSchema(),
+			Vchan:                vcInfo,
+			StartTs:              startTs,
+			State:                state,
+			Schema:               ch.GetSchema(),
+			CollectionProperties: c.getCollectionProperties(ch.GetCollectionID()),
 		}
 
 		// Only set timer for watchInfo not from bufferID
@@ -474,6 +490,16 @@ func (c *ChannelManager) fillChannelWatchInfoWithState(op *ChannelOp, state data
 	return channelsWithTimer
 }
 
+// getCollectionProperties returns the properties of the collection, nil if the collection is not found.
+func (c *ChannelManager) getCollectionProperties(collectionID UniqueID) []*commonpb.KeyValuePair {
+	collection, err := c.h.GetCollection(c.ctx, collectionID)
+	if err != nil || collection == nil {
+		log.Warn("failed to get collection properties", zap.Int64("collectionID", collectionID), zap.Error(err))
+		return nil
+	}
+	return funcutil.Map2KeyValuePair(collection.Properties)
+}
+
 // GetAssignedChannels gets channels info of registered nodes.
 func (c *ChannelManager) GetAssignedChannels() []*NodeChannelInfo {
 	c.mu.RLock()
@@ -664,6 +690,7 @@ func (c *ChannelManager) processAck(e *ackEvent) {
 		// Cleanup, Delete and Reassign
 		log.Warn("datanode release channel failed or timeout, will cleanup and reassign", zap.Int64("nodeID", e.nodeID),
 			zap.String("channel", e.channelName))
+		c.released(e.channelName)
 		err := c.CleanupAndReassign(e.nodeID, e.channelName)
 		if err != nil {
 			log.Warn("fail to clean and reassign channels for release failure ACKs",
@@ -674,6 +701,7 @@ func (c *ChannelManager) processAck(e *ackEvent) {
 		// Delete and Reassign
 		log.Info("datanode release channel successfully, will reassign", zap.Int64("nodeID", e.nodeID),
 			zap.String("channel", e.channelName))
+		c.released(e.channelName)
 		err := c.Reassign(e.nodeID, e.channelName)
 		if err != nil {
 			log.Warn("fail to response to release success ACK",
@@ -783,6 +811,12 @@ func (c *ChannelManager) Release(nodeID UniqueID, channelName string) error {
 	return err
 }
 
+func (c *ChannelManager) released(channelName string) {
+	if c.onRelease != nil {
+		c.onRelease(channelName)
+	}
+}
+
 // Reassign reassigns a channel to another DataNode.
 func (c *ChannelManager) Reassign(originNodeID UniqueID, channelName string) error {
 	c.mu.RLock()
@@ -815,7 +849,7 @@ func (c *ChannelManager) Reassign(originNodeID UniqueID, channelName string) err
 	}
 
 	// Reassign policy won't choose the original node when a reassigning a channel.
-	updates := c.reassignPolicy(c.store, []*NodeChannelInfo{reallocates})
+	updates := c.isolation.isolate(c.store, c.reassignPolicy(c.store, []*NodeChannelInfo{reallocates}), originNodeID)
 	if updates == nil {
 		// Skip the remove if reassign to the original node.
 		log.Warn("failed to reassign channel to other nodes, assigning to the original DataNode",
@@ -873,7 +907,7 @@ func (c *ChannelManager) CleanupAndReassign(nodeID UniqueID, channelName string)
 	}
 
 	// Reassign policy won't choose the original node when a reassigning a channel.
-	updates := c.reassignPolicy(c.store, []*NodeChannelInfo{reallocates})
+	updates := c.isolation.isolate(c.store, c.reassignPolicy(c.store, []*NodeChannelInfo{reallocates}), nodeID)
 	if updates == nil {
 		// Skip the remove if reassign to the original node.
 		log.Warn("failed to reassign channel to other nodes, add channel to the original node",
@@ -28,6 +28,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/eventbus"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/conc"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
@@ -53,6 +54,10 @@ type compactionPlanContext interface {
 	// get compaction tasks by signal id
 	getCompactionTasksBySignalID(signalID int64) []*compactionTask
 	removeTasksByChannel(channel string)
+	// listHistory returns the finished tasks which ended in [start, end)
+	listHistory(start, end time.Time) []*compactionTask
+	// purgeHistory removes the finished tasks which ended before the time
+	purgeHistory(before time.Time) []*compactionTask
 }
 
 type compactionTaskState int8
@@ -88,6 +93,8 @@ type compactionTask struct {
 	state       compactionTaskState
 	dataNodeID  int64
 	result      *datapb.CompactionPlanResult
+	// endTime is when the task completed or failed, the finished tasks are purged after the retention
+	endTime time.Time
 }
 
 func (t *compactionTask) shadowClone(opts ...compactionTaskOpt) *compactionTask {
@@ -96,6 +103,7 @@ func (t *compactionTask) shadowClone(opts ...compactionTaskOpt) *compactionTask
 		plan:        t.plan,
 		state:       t.state,
 		dataNodeID:  t.dataNodeID,
+		endTime:     t.endTime,
 	}
 	for _, opt := range opts {
 		opt(task)
@@ -142,6 +150,10 @@ func (c *compactionPlanHandler) checkResult() {
 		log.Warn("unable to alloc timestamp", zap.Error(err))
 	}
 	_ = c.updateCompaction(ts)
+
+	if retention := Params.DataCoordCfg.CompactionTaskRetention.GetAsDuration(time.Second); retention > 0 {
+		c.purgeHistory(time.Now().Add(-retention))
+	}
 }
 
 func (c *compactionPlanHandler) schedule() {
@@ -358,9 +370,34 @@ func (c *compactionPlanHandler) completeCompaction(result *datapb.CompactionPlan
 	c.plans[planID] = c.plans[planID].shadowClone(setState(completed), setResult(result))
 	// TODO: when to clean task list
 	UpdateCompactionSegmentSizeMetrics(result.GetSegments())
+	c.publishCompactionFinished(c.plans[planID])
 	return nil
 }
 
+func (c *compactionPlanHandler) publishCompactionFinished(task *compactionTask) {
+	var collectionID int64
+	if task.triggerInfo != nil {
+		collectionID = task.triggerInfo.collectionID
+	}
+	// the global signal doesn't carry the collection, get it from the result segments
+	for _, segment := range task.result.GetSegments() {
+		if collectionID != 0 {
+			break
+		}
+		if info := c.meta.GetHealthySegment(segment.GetSegmentID()); info != nil {
+			collectionID = info.GetCollectionID()
+		}
+	}
+	eventbus.Publish(eventbus.CompactionFinished, collectionID, map[string]any{
+		"plan_id":       task.plan.GetPlanID(),
+		"type":          task.plan.GetType().String(),
+		"channel":       task.plan.GetChannel(),
+		"from_segments": fetchSegIDs(task.plan.GetSegmentBinlogs()),
+		"to_segments":   lo.Map(task.result.GetSegments(), func(s *datapb.CompactionSegment, _ int) int64 { return s.GetSegmentID() }),
+		"node_id":       task.dataNodeID,
+	})
+}
+
 func (c *compactionPlanHandler) handleL0CompactionResult(plan *datapb.CompactionPlan, result *datapb.CompactionPlanResult) error {
 	var operators []UpdateOperator
 	for _, seg := range result.GetSegments() {
@@ -535,6 +572,38 @@ func (c *compactionPlanHandler) getTasksByState(state compactionTaskState) []*co
 	return tasks
 }
 
+// listHistory returns the finished tasks which ended in [start, end), zero time means unbounded.
+func (c *compactionPlanHandler) listHistory(start, end time.Time) []*compactionTask {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	tasks := make([]*compactionTask, 0)
+	for _, task := range c.plans {
+		if task.endTime.IsZero() || task.endTime.Before(start) || (!end.IsZero() && !task.endTime.Before(end)) {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// purgeHistory removes the finished tasks which ended before the time, returns the tasks removed.
+func (c *compactionPlanHandler) purgeHistory(before time.Time) []*compactionTask {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	purged := make([]*compactionTask, 0)
+	for planID, task := range c.plans {
+		if task.endTime.IsZero() || !task.endTime.Before(before) {
+			continue
+		}
+		delete(c.plans, planID)
+		purged = append(purged, task)
+	}
+	if len(purged) > 0 {
+		log.Info("compaction history purged", zap.Int("tasks", len(purged)), zap.Time("before", before))
+	}
+	return purged
+}
+
 // get compaction tasks by signal id; if signalID == 0 return all tasks
 func (c *compactionPlanHandler) getCompactionTasksBySignalID(signalID int64) []*compactionTask {
 	c.mu.RLock()
@@ -559,6 +628,9 @@ type compactionTaskOpt func(task *compactionTask)
 func setState(state compactionTaskState) compactionTaskOpt {
 	return func(task *compactionTask) {
 		task.state = state
+		if (state == completed || state == failed) && task.endTime.IsZero() {
+			task.endTime = time.Now()
+		}
 	}
 }
 
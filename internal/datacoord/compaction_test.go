@@ -80,6 +80,25 @@ func (s *CompactionPlanHandlerSuite) TestRemoveTasksByChannel() {
 	handler.mu.Unlock()
 }
 
+func (s *CompactionPlanHandlerSuite) TestHistory() {
+	handler := newCompactionPlanHandler(nil, nil, nil, nil)
+	now := time.Now()
+	handler.plans[1] = &compactionTask{plan: &datapb.CompactionPlan{PlanID: 1}, state: executing}
+	handler.plans[2] = &compactionTask{plan: &datapb.CompactionPlan{PlanID: 2}, state: completed, endTime: now.Add(-2 * time.Hour)}
+	handler.plans[3] = &compactionTask{plan: &datapb.CompactionPlan{PlanID: 3}, state: failed, endTime: now.Add(-time.Minute)}
+
+	s.Len(handler.listHistory(time.Time{}, time.Time{}), 2)
+	tasks := handler.listHistory(now.Add(-time.Hour), now)
+	s.Require().Len(tasks, 1)
+	s.EqualValues(3, tasks[0].plan.GetPlanID())
+
+	tasks = handler.purgeHistory(now.Add(-time.Hour))
+	s.Require().Len(tasks, 1)
+	s.EqualValues(2, tasks[0].plan.GetPlanID())
+	s.Len(handler.plans, 2)
+	s.Empty(handler.purgeHistory(now.Add(-time.Hour)))
+}
+
 func (s *CompactionPlanHandlerSuite) TestCheckResult() {
 	s.mockAlloc.EXPECT().allocTimestamp(mock.Anything).Return(19530, nil)
 
@@ -29,10 +29,12 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/fieldstats"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
 	"github.com/milvus-io/milvus/pkg/util/logutil"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type compactTime struct {
@@ -49,6 +51,8 @@ type trigger interface {
 	triggerSingleCompaction(collectionID, partitionID, segmentID int64, channel string, blockToSendSignal bool) error
 	// forceTriggerCompaction force to start a compaction
 	forceTriggerCompaction(collectionID int64) (UniqueID, error)
+	// triggerRecompaction rewrites the segment by single compaction regardless of the compaction conditions
+	triggerRecompaction(segment *SegmentInfo) error
 }
 
 type compactionSignal struct {
@@ -79,6 +83,9 @@ type compactionTrigger struct {
 
 	estimateNonDiskSegmentPolicy calUpperLimitPolicy
 	estimateDiskSegmentPolicy    calUpperLimitPolicy
+
+	// segments to be rewritten by the next single compaction, e.g. segments with corrupted stats logs
+	recompactSegments typeutil.ConcurrentSet[UniqueID]
 	// A sloopy hack, so we can test with different segment row count without worrying that
 	// they are re-calculated in every compaction.
 	testingOnly bool
@@ -106,7 +113,7 @@ func newCompactionTrigger(
 func (t *compactionTrigger) start() {
 	t.quit = make(chan struct{})
 	t.globalTrigger = time.NewTicker(Params.DataCoordCfg.GlobalCompactionInterval.GetAsDuration(time.Second))
-	t.wg.Add(2)
+	t.wg.Add(3)
 	go func() {
 		defer logutil.LogPanic()
 		defer t.wg.Done()
@@ -136,6 +143,7 @@ func (t *compactionTrigger) start() {
 	}()
 
 	go t.startGlobalCompactionLoop()
+	go t.startSmallSegmentLoop()
 }
 
 func (t *compactionTrigger) startGlobalCompactionLoop() {
@@ -264,6 +272,11 @@ func (t *compactionTrigger) triggerSingleCompaction(collectionID, partitionID, s
 	return nil
 }
 
+func (t *compactionTrigger) triggerRecompaction(segment *SegmentInfo) error {
+	t.recompactSegments.Insert(segment.GetID())
+	return t.triggerSingleCompaction(segment.GetCollectionID(), segment.GetPartitionID(), segment.GetID(), segment.GetInsertChannel(), false)
+}
+
 // forceTriggerCompaction force to start a compaction
 // invoked by user `ManualCompaction` operation
 func (t *compactionTrigger) forceTriggerCompaction(collectionID int64) (UniqueID, error) {
@@ -574,7 +587,7 @@ func (t *compactionTrigger) generatePlans(segments []*SegmentInfo, force bool, i
 		// TODO should we trigger compaction periodically even if the segment has no obvious reason to be compacted?
 		if force || t.ShouldDoSingleCompaction(segment, isDiskIndex, compactTime) {
 			prioritizedCandidates = append(prioritizedCandidates, segment)
-		} else if t.isSmallSegment(segment) {
+		} else if isSmallSegment(segment) {
 			smallCandidates = append(smallCandidates, segment)
 		} else {
 			nonPlannedSegments = append(nonPlannedSegments, segment)
@@ -815,7 +828,7 @@ func (t *compactionTrigger) getCandidateSegments(channel string, partitionID Uni
 	return res
 }
 
-func (t *compactionTrigger) isSmallSegment(segment *SegmentInfo) bool {
+func isSmallSegment(segment *SegmentInfo) bool {
 	return segment.GetNumOfRows() < int64(float64(segment.GetMaxRowNum())*Params.DataCoordCfg.SegmentSmallProportion.GetAsFloat())
 }
 
@@ -840,6 +853,11 @@ func (t *compactionTrigger) isStaleSegment(segment *SegmentInfo) bool {
 }
 
 func (t *compactionTrigger) ShouldDoSingleCompaction(segment *SegmentInfo, isDiskIndex bool, compactTime *compactTime) bool {
+	if t.recompactSegments.TryRemove(segment.GetID()) {
+		log.Info("segment is marked for recompaction", zap.Int64("segmentID", segment.GetID()))
+		return true
+	}
+
 	// no longer restricted binlog numbers because this is now related to field numbers
 
 	binlogCount := GetBinlogCount(segment.GetBinlogs())
@@ -931,6 +949,13 @@ func (t *compactionTrigger) ShouldDoSingleCompaction(segment *SegmentInfo, isDis
 		}
 	}
 
+	// segments flushed before field stats were introduced are rewritten, the compaction collects the stats of all fields.
+	if Params.DataCoordCfg.FieldStatsBackfill.GetAsBool() &&
+		segment.GetNumOfRows() > 0 && !fieldstats.HasStatsLog(segment.GetStatslogs()) {
+		log.Info("field stats are missing, trigger compaction to backfill", zap.Int64("segmentID", segment.ID))
+		return true
+	}
+
 	return false
 }
 
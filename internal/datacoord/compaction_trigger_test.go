@@ -75,6 +75,14 @@ func (h *spyCompactionHandler) getCompactionTasksBySignalID(signalID int64) []*c
 	panic("not implemented") // TODO: Implement
 }
 
+func (h *spyCompactionHandler) listHistory(start, end time.Time) []*compactionTask {
+	panic("not implemented") // TODO: Implement
+}
+
+func (h *spyCompactionHandler) purgeHistory(before time.Time) []*compactionTask {
+	panic("not implemented") // TODO: Implement
+}
+
 func (h *spyCompactionHandler) start() {}
 
 func (h *spyCompactionHandler) stop() {}
@@ -1901,6 +1909,25 @@ func Test_compactionTrigger_shouldDoSingleCompaction(t *testing.T) {
 	// expire time < Timestamp To, and index engine version is 2 which is larger than CurrentIndexVersion in segmentIndex but indexFileKeys is nil
 	couldDo = trigger.ShouldDoSingleCompaction(info6, false, &compactTime{expireTime: 300})
 	assert.False(t, couldDo)
+
+	// segment marked for recompaction is compacted once
+	trigger.recompactSegments.Insert(info6.GetID())
+	couldDo = trigger.ShouldDoSingleCompaction(info6, false, &compactTime{expireTime: 300})
+	assert.True(t, couldDo)
+	couldDo = trigger.ShouldDoSingleCompaction(info6, false, &compactTime{expireTime: 300})
+	assert.False(t, couldDo)
+
+	// segment without field stats is compacted to backfill them
+	Params.Save(Params.DataCoordCfg.FieldStatsBackfill.Key, "true")
+	defer Params.Reset(Params.DataCoordCfg.FieldStatsBackfill.Key)
+	couldDo = trigger.ShouldDoSingleCompaction(info6, false, &compactTime{expireTime: 300})
+	assert.True(t, couldDo)
+	info6.Statslogs = []*datapb.FieldBinlog{{
+		FieldID: 101,
+		Binlogs: []*datapb.Binlog{{LogPath: "stats_log/2/1/1/101/2"}},
+	}}
+	couldDo = trigger.ShouldDoSingleCompaction(info6, false, &compactTime{expireTime: 300})
+	assert.False(t, couldDo)
 }
 
 func Test_compactionTrigger_new(t *testing.T) {
@@ -30,5 +30,6 @@ const (
 	flatIndex    = "FLAT"
 	binFlatIndex = "BIN_FLAT"
 	diskAnnIndex = "DISKANN"
+	jsonKeyStats = "JSON_KEY_STATS"
 	invalidIndex = "invalid"
 )
@@ -30,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/eventbus"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -84,6 +85,7 @@ type indexBuilder struct {
 	chunkManager              storage.ChunkManager
 	indexEngineVersionManager IndexEngineVersionManager
 	handler                   Handler
+	readyNotifier             *indexReadyNotifier
 }
 
 func newIndexBuilder(
@@ -92,6 +94,7 @@ func newIndexBuilder(
 	chunkManager storage.ChunkManager,
 	indexEngineVersionManager IndexEngineVersionManager,
 	handler Handler,
+	readyNotifier *indexReadyNotifier,
 ) *indexBuilder {
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -107,6 +110,7 @@ func newIndexBuilder(
 		chunkManager:              chunkManager,
 		handler:                   handler,
 		indexEngineVersionManager: indexEngineVersionManager,
+		readyNotifier:             readyNotifier,
 	}
 	ib.reloadFromKV()
 	return ib
@@ -252,6 +256,7 @@ func (ib *indexBuilder) process(buildID UniqueID) bool {
 				log.Ctx(ib.ctx).Warn("IndexCoord update index state fail", zap.Int64("buildID", buildID), zap.Error(err))
 				return false
 			}
+			ib.readyNotifier.notify(meta.CollectionID, meta.SegmentID, meta.IndexID)
 			updateStateFunc(buildID, indexTaskDone)
 			return true
 		}
@@ -305,6 +310,17 @@ func (ib *indexBuilder) process(buildID UniqueID) bool {
 			}
 		}
 
+		// binlogs of the collection routed to a bucket profile are read from that bucket
+		var dataStorageConfig *indexpb.StorageConfig
+		profile, err := storage.GetRoute(ib.ctx, ib.chunkManager, segment.GetCollectionID())
+		if err != nil {
+			log.Ctx(ib.ctx).Warn("index builder get storage route failed", zap.Int64("collectionID", segment.GetCollectionID()), zap.Error(err))
+			return false
+		}
+		if profile != "" {
+			dataStorageConfig = storage.BucketProfileStorageConfig(storageConfig, Params.MinioCfg.GetBucketProfiles()[profile])
+		}
+
 		var req *indexpb.CreateJobRequest
 		if Params.CommonCfg.EnableStorageV2.GetAsBool() {
 			collectionInfo, err := ib.handler.GetCollection(ib.ctx, segment.GetCollectionID())
@@ -352,6 +368,7 @@ func (ib *indexBuilder) process(buildID UniqueID) bool {
 				IndexStorePath:      fmt.Sprintf("s3://%s:%s@%s/index/%d?scheme=%s&endpoint_override=%s&allow_bucket_creation=true", Params.MinioCfg.AccessKeyID.GetValue(), Params.MinioCfg.SecretAccessKey.GetValue(), Params.MinioCfg.BucketName.GetValue(), segment.GetID(), scheme, Params.MinioCfg.Address.GetValue()),
 				Dim:                 int64(dim),
 				CurrentIndexVersion: ib.indexEngineVersionManager.GetCurrentIndexEngineVersion(),
+				DataStorageConfig:   dataStorageConfig,
 			}
 		} else {
 			req = &indexpb.CreateJobRequest{
@@ -365,6 +382,8 @@ func (ib *indexBuilder) process(buildID UniqueID) bool {
 				TypeParams:          typeParams,
 				NumRows:             meta.NumRows,
 				CurrentIndexVersion: ib.indexEngineVersionManager.GetCurrentIndexEngineVersion(),
+				CollectionID:        segment.GetCollectionID(),
+				DataStorageConfig:   dataStorageConfig,
 			}
 		}
 
@@ -436,6 +455,9 @@ func (ib *indexBuilder) getTaskState(buildID, nodeID UniqueID) indexTaskState {
 							zap.String("index state", info.GetState().String()), zap.Error(err))
 						return indexTaskInProgress
 					}
+					if info.GetState() == commonpb.IndexState_Finished {
+						ib.publishIndexBuilt(buildID)
+					}
 					return indexTaskDone
 				} else if info.GetState() == commonpb.IndexState_Retry || info.GetState() == commonpb.IndexState_IndexStateNone {
 					log.Ctx(ib.ctx).Info("this task should be retry", zap.Int64("buildID", buildID), zap.String("fail reason", info.GetFailReason()))
@@ -454,6 +476,23 @@ func (ib *indexBuilder) getTaskState(buildID, nodeID UniqueID) indexTaskState {
 	return indexTaskRetry
 }
 
+func (ib *indexBuilder) publishIndexBuilt(buildID UniqueID) {
+	segIdx, exist := ib.meta.GetIndexJob(buildID)
+	if !exist {
+		return
+	}
+	ib.readyNotifier.notify(segIdx.CollectionID, segIdx.SegmentID, segIdx.IndexID)
+	eventbus.Publish(eventbus.IndexBuilt, segIdx.CollectionID, map[string]any{
+		"build_id":     segIdx.BuildID,
+		"index_id":     segIdx.IndexID,
+		"segment_id":   segIdx.SegmentID,
+		"partition_id": segIdx.PartitionID,
+		"num_rows":     segIdx.NumRows,
+		"index_size":   segIdx.IndexSize,
+		"node_id":      segIdx.NodeID,
+	})
+}
+
 func (ib *indexBuilder) dropIndexTask(buildID, nodeID UniqueID) bool {
 	client, exist := ib.nodeManager.GetClientByID(nodeID)
 	if exist {
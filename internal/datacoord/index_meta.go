@@ -20,6 +20,7 @@ package datacoord
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/prometheus/client_golang/prometheus"
@@ -31,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
 )
 
 func (m *meta) updateCollectionIndex(index *model.Index) {
@@ -159,6 +161,10 @@ func (m *meta) CanCreateIndex(req *indexpb.CreateIndexRequest) (UniqueID, error)
 			return 0, fmt.Errorf("CreateIndex failed: %s", errMsg)
 		}
 		if req.FieldID == index.FieldID {
+			// json key stats on different keys of the same json field are allowed
+			if !isSameJSONKeyStats(index.IndexParams, req.GetIndexParams()) {
+				continue
+			}
 			// creating multiple indexes on same field is not supported
 			errMsg := "CreateIndex failed: creating multiple indexes on same field is not supported"
 			log.Warn(errMsg)
@@ -532,6 +538,9 @@ func (m *meta) FinishTask(taskInfo *indexpb.IndexTaskInfo) error {
 		segIdx.FailReason = taskInfo.GetFailReason()
 		segIdx.IndexSize = taskInfo.GetSerializedSize()
 		segIdx.CurrentIndexVersion = taskInfo.GetCurrentIndexVersion()
+		if segIdx.IndexState == commonpb.IndexState_Finished || segIdx.IndexState == commonpb.IndexState_Failed {
+			segIdx.FinishTime = tsoutil.ComposeTSByTime(time.Now(), 0)
+		}
 		return m.alterSegmentIndexes([]*model.SegmentIndex{segIdx})
 	}
 
@@ -602,6 +611,31 @@ func (m *meta) BuildIndex(buildID UniqueID) error {
 	return nil
 }
 
+// ResetIndexTask set the index state to be Unissued, so that the index will be built again.
+func (m *meta) ResetIndexTask(buildID UniqueID) error {
+	m.Lock()
+	defer m.Unlock()
+
+	segIdx, ok := m.buildID2SegmentIndex[buildID]
+	if !ok {
+		return fmt.Errorf("there is no index with buildID: %d", buildID)
+	}
+
+	updateFunc := func(segIdx *model.SegmentIndex) error {
+		segIdx.IndexState = commonpb.IndexState_Unissued
+		segIdx.FailReason = ""
+		return m.alterSegmentIndexes([]*model.SegmentIndex{segIdx})
+	}
+	if err := m.updateSegIndexMeta(segIdx, updateFunc); err != nil {
+		return err
+	}
+	log.Info("meta update: reset segment index task success", zap.Int64("buildID", segIdx.BuildID),
+		zap.Int64("segmentID", segIdx.SegmentID))
+
+	m.updateIndexTasksMetrics()
+	return nil
+}
+
 func (m *meta) GetAllSegIndexes() map[int64]*model.SegmentIndex {
 	m.RLock()
 	defer m.RUnlock()
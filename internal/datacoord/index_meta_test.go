@@ -158,6 +158,41 @@ func TestMeta_CanCreateIndex(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, int64(0), tmpIndexID)
 	})
+
+	t.Run("json key stats", func(t *testing.T) {
+		jsonFieldID := fieldID + 10
+		jsonKeyParams := func(path string) []*commonpb.KeyValuePair {
+			return []*commonpb.KeyValuePair{
+				{Key: common.IndexTypeKey, Value: jsonKeyStats},
+				{Key: common.JSONPathKey, Value: path},
+			}
+		}
+		err := m.CreateIndex(&model.Index{
+			CollectionID:    collID,
+			FieldID:         jsonFieldID,
+			IndexID:         indexID + 1,
+			IndexName:       "meta_a",
+			IndexParams:     jsonKeyParams(`["a"]`),
+			UserIndexParams: jsonKeyParams(`["a"]`),
+		})
+		assert.NoError(t, err)
+
+		jsonReq := &indexpb.CreateIndexRequest{
+			CollectionID:    collID,
+			FieldID:         jsonFieldID,
+			IndexName:       "meta_b",
+			IndexParams:     jsonKeyParams(`["b"]`),
+			UserIndexParams: jsonKeyParams(`["b"]`),
+		}
+		tmpIndexID, err := m.CanCreateIndex(jsonReq)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), tmpIndexID)
+
+		jsonReq.IndexParams = jsonKeyParams(`["a"]`)
+		jsonReq.UserIndexParams = jsonReq.IndexParams
+		_, err = m.CanCreateIndex(jsonReq)
+		assert.Error(t, err)
+	})
 }
 
 func TestMeta_HasSameReq(t *testing.T) {
@@ -0,0 +1,58 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"path"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+)
+
+// indexReadyNotifier notifies querycoord of the segments whose index is built by etcd,
+// querycoord watches the keys to load the indexes and hand off the segments without polling,
+// and removes the keys once handled.
+type indexReadyNotifier struct {
+	kv kv.BaseKV
+}
+
+func newIndexReadyNotifier(kv kv.BaseKV) *indexReadyNotifier {
+	return &indexReadyNotifier{kv: kv}
+}
+
+func buildIndexReadyKey(collectionID, segmentID UniqueID) string {
+	return path.Join(util.IndexReadyPrefix, strconv.FormatInt(collectionID, 10), strconv.FormatInt(segmentID, 10))
+}
+
+// notify is best effort, querycoord still checks the index states of all segments periodically in case of lost notifications.
+func (n *indexReadyNotifier) notify(collectionID, segmentID, indexID UniqueID) {
+	if n == nil || !Params.CommonCfg.IndexReadyNotifyEnable.GetAsBool() {
+		return
+	}
+	err := n.kv.Save(buildIndexReadyKey(collectionID, segmentID), strconv.FormatInt(indexID, 10))
+	if err != nil {
+		log.Warn("failed to notify querycoord of index ready",
+			zap.Int64("collectionID", collectionID),
+			zap.Int64("segmentID", segmentID),
+			zap.Int64("indexID", indexID),
+			zap.Error(err))
+	}
+}
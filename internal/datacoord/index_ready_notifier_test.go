@@ -0,0 +1,50 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/kv/mocks"
+)
+
+func TestIndexReadyNotifier(t *testing.T) {
+	t.Run("notify", func(t *testing.T) {
+		watchKV := mocks.NewWatchKV(t)
+		watchKV.EXPECT().Save("index-ready/1/100", "1000").Return(nil).Once()
+		newIndexReadyNotifier(watchKV).notify(1, 100, 1000)
+	})
+
+	t.Run("save failed", func(t *testing.T) {
+		watchKV := mocks.NewWatchKV(t)
+		watchKV.EXPECT().Save("index-ready/1/100", "1000").Return(errors.New("mock error")).Once()
+		newIndexReadyNotifier(watchKV).notify(1, 100, 1000)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		Params.Save(Params.CommonCfg.IndexReadyNotifyEnable.Key, "false")
+		defer Params.Reset(Params.CommonCfg.IndexReadyNotifyEnable.Key)
+		newIndexReadyNotifier(mocks.NewWatchKV(t)).notify(1, 100, 1000)
+	})
+
+	t.Run("nil notifier", func(t *testing.T) {
+		var notifier *indexReadyNotifier
+		notifier.notify(1, 100, 1000)
+	})
+}
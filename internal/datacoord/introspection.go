@@ -0,0 +1,150 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/util/fieldstats"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// introspectionParams are the filters of the introspection requests.
+type introspectionParams struct {
+	CollectionID int64  `json:"collection_id"`
+	State        string `json:"state"`
+	SegmentID    int64  `json:"segment_id"`
+}
+
+// getIntrospectionMetrics returns the read-only view of the meta, so that operators don't need to read etcd directly.
+func (s *Server) getIntrospectionMetrics(ctx context.Context, metricType string, req *milvuspb.GetMetricsRequest) *milvuspb.GetMetricsResponse {
+	resp := &milvuspb.GetMetricsResponse{
+		Status:        merr.Success(),
+		ComponentName: metricsinfo.ConstructComponentName(typeutil.DataCoordRole, paramtable.GetNodeID()),
+	}
+	params := &introspectionParams{}
+	if err := json.Unmarshal([]byte(req.GetRequest()), params); err != nil {
+		resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("invalid request: %s", err.Error()))
+		return resp
+	}
+
+	var infos any
+	switch metricType {
+	case metricsinfo.ChannelCheckpointMetrics:
+		infos = s.listChannelCheckpoints()
+	case metricsinfo.SegmentMetaMetrics:
+		state := commonpb.SegmentState_SegmentStateNone
+		if params.State != "" {
+			v, ok := commonpb.SegmentState_value[params.State]
+			if !ok {
+				resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("invalid segment state: %s", params.State))
+				return resp
+			}
+			state = commonpb.SegmentState(v)
+		}
+		infos = s.listSegmentMeta(params.CollectionID, state)
+	case metricsinfo.SmallSegmentMetrics:
+		infos = &metricsinfo.SmallSegmentInfos{Collections: getSmallSegmentReports(s.meta, params.CollectionID)}
+	case metricsinfo.SegmentFieldStatsMetrics:
+		stats, err := s.getSegmentFieldStats(ctx, params.SegmentID)
+		if err != nil {
+			resp.Status = merr.Status(err)
+			return resp
+		}
+		infos = stats
+	}
+
+	var err error
+	resp.Response, err = metricsinfo.MarshalComponentInfos(infos)
+	if err != nil {
+		resp.Status = merr.Status(err)
+	}
+	return resp
+}
+
+func (s *Server) listChannelCheckpoints() *metricsinfo.ChannelCheckpointInfos {
+	infos := &metricsinfo.ChannelCheckpointInfos{Checkpoints: make([]metricsinfo.ChannelCheckpoint, 0)}
+	for channel, pos := range s.meta.ListChannelCheckpoints() {
+		physical, _ := tsoutil.ParseTS(pos.GetTimestamp())
+		infos.Checkpoints = append(infos.Checkpoints, metricsinfo.ChannelCheckpoint{
+			Channel:   channel,
+			Timestamp: pos.GetTimestamp(),
+			MsgID:     pos.GetMsgID(),
+			LagMs:     time.Since(physical).Milliseconds(),
+		})
+	}
+	sort.Slice(infos.Checkpoints, func(i, j int) bool {
+		return infos.Checkpoints[i].Channel < infos.Checkpoints[j].Channel
+	})
+	return infos
+}
+
+// listSegmentMeta returns the segments of the collection in the state, zero collection id or none state means any.
+func (s *Server) listSegmentMeta(collectionID int64, state commonpb.SegmentState) *metricsinfo.SegmentMetaInfos {
+	segments := s.meta.SelectSegments(func(segment *SegmentInfo) bool {
+		return (collectionID == 0 || segment.GetCollectionID() == collectionID) &&
+			(state == commonpb.SegmentState_SegmentStateNone || segment.GetState() == state)
+	})
+	infos := &metricsinfo.SegmentMetaInfos{Segments: make([]metricsinfo.SegmentMeta, 0, len(segments))}
+	for _, segment := range segments {
+		infos.Segments = append(infos.Segments, metricsinfo.SegmentMeta{
+			SegmentID:      segment.GetID(),
+			CollectionID:   segment.GetCollectionID(),
+			PartitionID:    segment.GetPartitionID(),
+			Channel:        segment.GetInsertChannel(),
+			State:          segment.GetState().String(),
+			Level:          segment.GetLevel().String(),
+			NumRows:        segment.GetNumOfRows(),
+			MaxRowNum:      segment.GetMaxRowNum(),
+			StartTimestamp: segment.GetStartPosition().GetTimestamp(),
+			DmlTimestamp:   segment.GetDmlPosition().GetTimestamp(),
+			BinlogNum:      GetBinlogCount(segment.GetBinlogs()),
+			StatslogNum:    GetBinlogCount(segment.GetStatslogs()),
+			DeltalogNum:    GetBinlogCount(segment.GetDeltalogs()),
+			CompactionFrom: segment.GetCompactionFrom(),
+			Compacted:      segment.GetCompacted(),
+			IsImporting:    segment.GetIsImporting(),
+			DroppedAt:      segment.GetDroppedAt(),
+		})
+	}
+	sort.Slice(infos.Segments, func(i, j int) bool {
+		return infos.Segments[i].SegmentID < infos.Segments[j].SegmentID
+	})
+	return infos
+}
+
+// getSegmentFieldStats reads the field stats logs of the segment, which are saved in the statslogs of each field.
+func (s *Server) getSegmentFieldStats(ctx context.Context, segmentID int64) (*fieldstats.SegmentStats, error) {
+	segment := s.meta.GetHealthySegment(segmentID)
+	if segment == nil {
+		return nil, merr.WrapErrSegmentNotFound(segmentID)
+	}
+	stats, err := fieldstats.Load(ctx, s.meta.chunkManager, segmentID, segment.GetStatslogs())
+	if err != nil || stats != nil {
+		return stats, err
+	}
+	return nil, merr.WrapErrParameterInvalidMsg("segment %d has no field stats, which are collected only on flush and compaction", segmentID)
+}
@@ -0,0 +1,102 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+func TestServer_IntrospectionMetrics(t *testing.T) {
+	meta, err := newMemoryMeta()
+	require.NoError(t, err)
+	for _, segment := range []*datapb.SegmentInfo{
+		{ID: 1, CollectionID: 100, InsertChannel: "ch1", State: commonpb.SegmentState_Flushed},
+		{ID: 2, CollectionID: 100, InsertChannel: "ch1", State: commonpb.SegmentState_Growing},
+		{ID: 3, CollectionID: 200, InsertChannel: "ch2", State: commonpb.SegmentState_Flushed},
+	} {
+		require.NoError(t, meta.AddSegment(context.TODO(), NewSegmentInfo(segment)))
+	}
+	ts := tsoutil.ComposeTSByTime(time.Now(), 0)
+	require.NoError(t, meta.UpdateChannelCheckpoint("ch1", &msgpb.MsgPosition{ChannelName: "ch1", MsgID: []byte{1}, Timestamp: ts}))
+	s := &Server{meta: meta}
+
+	t.Run("segment meta", func(t *testing.T) {
+		req, err := metricsinfo.ConstructRequestWithParams(metricsinfo.SegmentMetaMetrics, map[string]any{
+			metricsinfo.MetricCollectionIDKey: 100,
+			metricsinfo.MetricSegmentStateKey: commonpb.SegmentState_Flushed.String(),
+		})
+		require.NoError(t, err)
+		resp := s.getIntrospectionMetrics(context.TODO(), metricsinfo.SegmentMetaMetrics, req)
+		require.NoError(t, merr.Error(resp.GetStatus()))
+		infos := &metricsinfo.SegmentMetaInfos{}
+		require.NoError(t, json.Unmarshal([]byte(resp.GetResponse()), infos))
+		require.Equal(t, 1, len(infos.Segments))
+		assert.Equal(t, int64(1), infos.Segments[0].SegmentID)
+		assert.Equal(t, "Flushed", infos.Segments[0].State)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		req, err := metricsinfo.ConstructRequestWithParams(metricsinfo.SegmentMetaMetrics, map[string]any{
+			metricsinfo.MetricSegmentStateKey: "unknown",
+		})
+		require.NoError(t, err)
+		resp := s.getIntrospectionMetrics(context.TODO(), metricsinfo.SegmentMetaMetrics, req)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrParameterInvalid)
+	})
+
+	t.Run("segment field stats", func(t *testing.T) {
+		req, err := metricsinfo.ConstructRequestWithParams(metricsinfo.SegmentFieldStatsMetrics, map[string]any{
+			metricsinfo.MetricSegmentIDKey: 10,
+		})
+		require.NoError(t, err)
+		resp := s.getIntrospectionMetrics(context.TODO(), metricsinfo.SegmentFieldStatsMetrics, req)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrSegmentNotFound)
+
+		// segment without field stats log
+		req, err = metricsinfo.ConstructRequestWithParams(metricsinfo.SegmentFieldStatsMetrics, map[string]any{
+			metricsinfo.MetricSegmentIDKey: 1,
+		})
+		require.NoError(t, err)
+		resp = s.getIntrospectionMetrics(context.TODO(), metricsinfo.SegmentFieldStatsMetrics, req)
+		assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrParameterInvalid)
+	})
+
+	t.Run("channel checkpoints", func(t *testing.T) {
+		req, err := metricsinfo.ConstructRequestByMetricType(metricsinfo.ChannelCheckpointMetrics)
+		require.NoError(t, err)
+		resp := s.getIntrospectionMetrics(context.TODO(), metricsinfo.ChannelCheckpointMetrics, req)
+		require.NoError(t, merr.Error(resp.GetStatus()))
+		infos := &metricsinfo.ChannelCheckpointInfos{}
+		require.NoError(t, json.Unmarshal([]byte(resp.GetResponse()), infos))
+		require.Equal(t, 1, len(infos.Checkpoints))
+		assert.Equal(t, "ch1", infos.Checkpoints[0].Channel)
+		assert.Equal(t, ts, infos.Checkpoints[0].Timestamp)
+	})
+}
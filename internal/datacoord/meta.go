@@ -43,6 +43,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/lock"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metautil"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
@@ -404,31 +405,40 @@ func (m *meta) SetState(segmentID UniqueID, targetState commonpb.SegmentState) e
 	return nil
 }
 
-// UnsetIsImporting removes the `isImporting` flag of a segment.
-func (m *meta) UnsetIsImporting(segmentID UniqueID) error {
-	log.Debug("meta update: unsetting isImport state of segment",
-		zap.Int64("segmentID", segmentID))
+// UnsetIsImporting removes the `isImporting` flag of the segments atomically, fails if any segment not found.
+func (m *meta) UnsetIsImporting(segmentIDs ...UniqueID) error {
+	log.Debug("meta update: unsetting isImport state of segments",
+		zap.Int64s("segmentIDs", segmentIDs))
 	m.Lock()
 	defer m.Unlock()
-	curSegInfo := m.segments.GetSegment(segmentID)
-	if curSegInfo == nil {
-		return fmt.Errorf("segment not found %d", segmentID)
+	// The segments are persisted at once, so they become visible together or none of them does.
+	clonedSegments := make([]*SegmentInfo, 0, len(segmentIDs))
+	toPersist := make([]*datapb.SegmentInfo, 0, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		curSegInfo := m.segments.GetSegment(segmentID)
+		if curSegInfo == nil {
+			return fmt.Errorf("segment not found %d", segmentID)
+		}
+		clonedSegment := curSegInfo.Clone()
+		clonedSegment.IsImporting = false
+		clonedSegments = append(clonedSegments, clonedSegment)
+		if isSegmentHealthy(clonedSegment) {
+			toPersist = append(toPersist, clonedSegment.SegmentInfo)
+		}
 	}
 	// Persist segment updates first.
-	clonedSegment := curSegInfo.Clone()
-	clonedSegment.IsImporting = false
-	if isSegmentHealthy(clonedSegment) {
-		if err := m.catalog.AlterSegments(m.ctx, []*datapb.SegmentInfo{clonedSegment.SegmentInfo}); err != nil {
-			log.Warn("meta update: unsetting isImport state of segment - failed to unset segment isImporting state",
-				zap.Int64("segmentID", segmentID),
-				zap.Error(err))
-			return err
-		}
+	if err := m.catalog.AlterSegments(m.ctx, toPersist); err != nil {
+		log.Warn("meta update: unsetting isImport state of segments - failed to unset segment isImporting state",
+			zap.Int64s("segmentIDs", segmentIDs),
+			zap.Error(err))
+		return err
 	}
 	// Update in-memory meta.
-	m.segments.SetIsImporting(segmentID, false)
-	log.Info("meta update: unsetting isImport state of segment - complete",
-		zap.Int64("segmentID", segmentID))
+	for _, segment := range clonedSegments {
+		m.segments.SetIsImporting(segment.GetID(), false)
+	}
+	log.Info("meta update: unsetting isImport state of segments - complete",
+		zap.Int64s("segmentIDs", segmentIDs))
 	return nil
 }
 
@@ -1123,6 +1133,69 @@ func (m *meta) alterMetaStoreAfterCompaction(segmentCompactTo *SegmentInfo, segm
 	return nil
 }
 
+// SelectAndSetSegmentsCompacting selects the segments not compacting and marks them compacting under the same lock,
+// so that they're not picked by the compactions in between.
+func (m *meta) SelectAndSetSegmentsCompacting(selector SegmentInfoSelector) []*SegmentInfo {
+	m.Lock()
+	defer m.Unlock()
+	var ret []*SegmentInfo
+	for _, info := range m.segments.GetSegments() {
+		if !info.isCompacting && selector(info) {
+			m.segments.SetIsCompacting(info.GetID(), true)
+			ret = append(ret, m.segments.GetSegment(info.GetID()))
+		}
+	}
+	return ret
+}
+
+// ReplaceSegment drops the segment as compacted and adds the new segment replacing it in a single meta update,
+// the new segment has the same rows with some binlogs rewritten, e.g. by the vector updates.
+// copiedDeltalogs are the deltalogs of the segment copied into the new segment already,
+// the ones added to the segment since then are copied here, so that no deletion is lost.
+func (m *meta) ReplaceSegment(segmentID UniqueID, newSegment *datapb.SegmentInfo, copiedDeltalogs []*datapb.FieldBinlog) (*SegmentInfo, error) {
+	m.Lock()
+	defer m.Unlock()
+	segment := m.segments.GetSegment(segmentID)
+	if !isSegmentHealthy(segment) {
+		return nil, merr.WrapErrSegmentNotFound(segmentID)
+	}
+
+	addedDeltalogs := updateDeltalogs(segment.GetDeltalogs(), copiedDeltalogs, nil)
+	copied, err := m.copyDeltaFiles(addedDeltalogs, segment.GetCollectionID(), segment.GetPartitionID(), newSegment.GetID())
+	if err != nil {
+		return nil, err
+	}
+	newSegment.Deltalogs = append(newSegment.Deltalogs, copied...)
+	newSegment.State = commonpb.SegmentState_Flushing
+	newSegment.CreatedByCompaction = true
+	newSegment.CompactionFrom = []UniqueID{segmentID}
+
+	metricMutation := &segMetricMutation{
+		stateChange: make(map[string]map[string]int),
+	}
+	cloned := segment.Clone()
+	updateSegStateAndPrepareMetrics(cloned, commonpb.SegmentState_Dropped, metricMutation)
+	cloned.DroppedAt = uint64(time.Now().UnixNano())
+	cloned.Compacted = true
+	added := NewSegmentInfo(newSegment)
+	metricMutation.addNewSeg(added.GetState(), added.GetLevel(), added.GetNumOfRows())
+
+	err = m.catalog.AlterSegments(m.ctx, []*datapb.SegmentInfo{cloned.SegmentInfo, newSegment}, metastore.BinlogsIncrement{
+		Segment: newSegment,
+	})
+	if err != nil {
+		log.Warn("meta update: failed to replace segment", zap.Int64("segmentID", segmentID),
+			zap.Int64("new segmentID", newSegment.GetID()), zap.Error(err))
+		return nil, err
+	}
+	metricMutation.commit()
+	m.segments.SetSegment(segmentID, cloned)
+	m.segments.SetSegment(added.GetID(), added)
+	log.Info("meta update: replace segment - complete", zap.Int64("segmentID", segmentID),
+		zap.Int64("new segmentID", added.GetID()), zap.Int64("num of rows", added.GetNumOfRows()))
+	return added, nil
+}
+
 func (m *meta) updateBinlogs(origin []*datapb.FieldBinlog, removes []*datapb.FieldBinlog, adds []*datapb.FieldBinlog) []*datapb.FieldBinlog {
 	fieldBinlogs := make(map[int64]map[string]*datapb.Binlog)
 	for _, f := range origin {
@@ -1286,6 +1359,16 @@ func (m *meta) GetChannelCheckpoint(vChannel string) *msgpb.MsgPosition {
 	return proto.Clone(v).(*msgpb.MsgPosition)
 }
 
+// ListChannelCheckpoints returns the checkpoints of all the virtual channels.
+func (m *meta) ListChannelCheckpoints() map[string]*msgpb.MsgPosition {
+	result := make(map[string]*msgpb.MsgPosition)
+	m.channelCPs.Range(func(vChannel string, pos *msgpb.MsgPosition) bool {
+		result[vChannel] = proto.Clone(pos).(*msgpb.MsgPosition)
+		return true
+	})
+	return result
+}
+
 func (m *meta) DropChannelCheckpoint(vChannel string) error {
 	m.channelCPLocks.Lock(vChannel)
 	defer m.channelCPLocks.Unlock(vChannel)
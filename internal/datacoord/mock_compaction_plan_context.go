@@ -5,6 +5,8 @@ package datacoord
 import (
 	datapb "github.com/milvus-io/milvus/internal/proto/datapb"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockCompactionPlanContext is an autogenerated mock type for the compactionPlanContext type
@@ -192,6 +194,95 @@ func (_c *MockCompactionPlanContext_isFull_Call) RunAndReturn(run func() bool) *
 	return _c
 }
 
+// listHistory provides a mock function with given fields: start, end
+func (_m *MockCompactionPlanContext) listHistory(start, end time.Time) []*compactionTask {
+	ret := _m.Called(start, end)
+
+	var r0 []*compactionTask
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*compactionTask); ok {
+		r0 = rf(start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compactionTask)
+		}
+	}
+
+	return r0
+}
+
+// MockCompactionPlanContext_listHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'listHistory'
+type MockCompactionPlanContext_listHistory_Call struct {
+	*mock.Call
+}
+
+// listHistory is a helper method to define mock.On call
+//   - start time.Time
+//   - end time.Time
+func (_e *MockCompactionPlanContext_Expecter) listHistory(start interface{}, end interface{}) *MockCompactionPlanContext_listHistory_Call {
+	return &MockCompactionPlanContext_listHistory_Call{Call: _e.mock.On("listHistory", start, end)}
+}
+
+func (_c *MockCompactionPlanContext_listHistory_Call) Run(run func(start, end time.Time)) *MockCompactionPlanContext_listHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockCompactionPlanContext_listHistory_Call) Return(_a0 []*compactionTask) *MockCompactionPlanContext_listHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCompactionPlanContext_listHistory_Call) RunAndReturn(run func(time.Time, time.Time) []*compactionTask) *MockCompactionPlanContext_listHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// purgeHistory provides a mock function with given fields: before
+func (_m *MockCompactionPlanContext) purgeHistory(before time.Time) []*compactionTask {
+	ret := _m.Called(before)
+
+	var r0 []*compactionTask
+	if rf, ok := ret.Get(0).(func(time.Time) []*compactionTask); ok {
+		r0 = rf(before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compactionTask)
+		}
+	}
+
+	return r0
+}
+
+// MockCompactionPlanContext_purgeHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'purgeHistory'
+type MockCompactionPlanContext_purgeHistory_Call struct {
+	*mock.Call
+}
+
+// purgeHistory is a helper method to define mock.On call
+//   - before time.Time
+func (_e *MockCompactionPlanContext_Expecter) purgeHistory(before interface{}) *MockCompactionPlanContext_purgeHistory_Call {
+	return &MockCompactionPlanContext_purgeHistory_Call{Call: _e.mock.On("purgeHistory", before)}
+}
+
+func (_c *MockCompactionPlanContext_purgeHistory_Call) Run(run func(before time.Time)) *MockCompactionPlanContext_purgeHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockCompactionPlanContext_purgeHistory_Call) Return(_a0 []*compactionTask) *MockCompactionPlanContext_purgeHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCompactionPlanContext_purgeHistory_Call) RunAndReturn(run func(time.Time) []*compactionTask) *MockCompactionPlanContext_purgeHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // removeTasksByChannel provides a mock function with given fields: channel
 func (_m *MockCompactionPlanContext) removeTasksByChannel(channel string) {
 	_m.Called(channel)
@@ -0,0 +1,310 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/eventlog"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+)
+
+const (
+	scrubObjectInsertLog = "insert_log"
+	scrubObjectDeltaLog  = "delta_log"
+	scrubObjectStatsLog  = "stats_log"
+	scrubObjectIndex     = "index"
+)
+
+// ScrubOption scrubber options
+type ScrubOption struct {
+	cli              storage.ChunkManager             // client
+	enabled          bool                             // enable switch
+	checkInterval    time.Duration                    // each interval
+	batchSize        func() int                       // max segments verified in each round
+	autoRebuildIndex func() bool                      // rebuild index once its files are corrupted
+	restoreRootPath  func() string                    // root path of the backup copies used to restore corrupted binlogs
+	recompact        func(segment *SegmentInfo) error // regenerate the stats logs of the segment by compaction
+}
+
+// scrubber verifies the binlog and index files of flushed segments in object storage,
+// a few segments are verified in each round, so that the whole data set is covered in a rolling way.
+type scrubber struct {
+	option       ScrubOption
+	meta         *meta
+	indexBuilder *indexBuilder
+
+	// the last segment verified, segments are verified in the order of ID
+	cursor UniqueID
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+	closeCh   chan struct{}
+}
+
+func newScrubber(meta *meta, indexBuilder *indexBuilder, opt ScrubOption) *scrubber {
+	log.Info("Scrubber with option", zap.Bool("enabled", opt.enabled), zap.Duration("interval", opt.checkInterval))
+	return &scrubber{
+		option:       opt,
+		meta:         meta,
+		indexBuilder: indexBuilder,
+		closeCh:      make(chan struct{}),
+	}
+}
+
+// start a goroutine and verify a batch of segments every `checkInterval`
+func (s *scrubber) start() {
+	if s.option.enabled {
+		if s.option.cli == nil {
+			log.Warn("DataCoord scrubber enabled, but SSO client is not provided")
+			return
+		}
+		s.startOnce.Do(func() {
+			s.wg.Add(1)
+			go s.work()
+		})
+	}
+}
+
+func (s *scrubber) work() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.option.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.scrub()
+		case <-s.closeCh:
+			log.Warn("scrubber quit")
+			return
+		}
+	}
+}
+
+func (s *scrubber) close() {
+	s.stopOnce.Do(func() {
+		close(s.closeCh)
+		s.wg.Wait()
+	})
+}
+
+// scrub verifies the next batch of flushed segments
+func (s *scrubber) scrub() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	segments := s.nextBatch()
+	for _, segment := range segments {
+		s.scrubSegment(ctx, segment)
+		s.cursor = segment.GetID()
+		metrics.DataCoordScrubbedSegmentNum.Inc()
+	}
+	log.Info("scrubber verified segments", zap.Int("num", len(segments)), zap.Int64("cursor", s.cursor))
+}
+
+// nextBatch returns the flushed segments after the cursor, starts over once all the segments are verified.
+func (s *scrubber) nextBatch() []*SegmentInfo {
+	segments := s.meta.SelectSegments(func(segment *SegmentInfo) bool {
+		return isSegmentHealthy(segment) && isFlushState(segment.GetState())
+	})
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].GetID() < segments[j].GetID()
+	})
+	idx := sort.Search(len(segments), func(i int) bool {
+		return segments[i].GetID() > s.cursor
+	})
+	if idx == len(segments) {
+		idx = 0
+	}
+	batch := segments[idx:]
+	if batchSize := s.option.batchSize(); len(batch) > batchSize {
+		batch = batch[:batchSize]
+	}
+	return batch
+}
+
+func (s *scrubber) scrubSegment(ctx context.Context, segment *SegmentInfo) {
+	recompact := false
+	verify := func(objectType string, fieldBinlogs []*datapb.FieldBinlog) {
+		for _, fieldBinlog := range fieldBinlogs {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				err := s.verifyBinlog(ctx, binlog, objectType)
+				if err == nil {
+					continue
+				}
+				s.reportCorruption(segment, objectType, binlog.GetLogPath(), err)
+				if err := s.restoreBinlog(ctx, binlog); err != nil {
+					log.Warn("scrubber failed to restore the corrupted binlog",
+						zap.Int64("segmentID", segment.GetID()),
+						zap.String("binlog", binlog.GetLogPath()),
+						zap.Error(err))
+					// stats logs can be regenerated from the insert logs by compaction
+					recompact = recompact || objectType == scrubObjectStatsLog
+					continue
+				}
+				log.Info("scrubber restored the corrupted binlog",
+					zap.Int64("segmentID", segment.GetID()), zap.String("binlog", binlog.GetLogPath()))
+			}
+		}
+	}
+	verify(scrubObjectInsertLog, segment.GetBinlogs())
+	verify(scrubObjectDeltaLog, segment.GetDeltalogs())
+	verify(scrubObjectStatsLog, segment.GetStatslogs())
+	if recompact {
+		s.recompactSegment(segment)
+	}
+
+	for _, segIdx := range s.meta.GetSegmentIndexes(segment.GetID()) {
+		if segIdx.IsDeleted || segIdx.IndexState != commonpb.IndexState_Finished {
+			continue
+		}
+		if err := s.verifyIndex(ctx, segIdx.BuildID, segIdx.IndexVersion, segIdx.PartitionID,
+			segIdx.SegmentID, segIdx.IndexFileKeys, segIdx.IndexSize); err != nil {
+			s.reportCorruption(segment, scrubObjectIndex, fmt.Sprintf("buildID %d", segIdx.BuildID), err)
+			s.rebuildIndex(segIdx.BuildID)
+		}
+	}
+}
+
+// verifyBinlog checks the binlog content matches the checksum recorded on write.
+// Binlogs written without checksum are checked by the existence, the recorded size and the magic number only.
+func (s *scrubber) verifyBinlog(ctx context.Context, binlog *datapb.Binlog, objectType string) error {
+	if binlog.GetChecksum() != 0 {
+		data, err := s.option.cli.Read(ctx, binlog.GetLogPath())
+		if err != nil {
+			return err
+		}
+		return checkBinlogChecksum(data, binlog.GetChecksum())
+	}
+
+	size, err := s.option.cli.Size(ctx, binlog.GetLogPath())
+	if err != nil {
+		return err
+	}
+	// the log size of insert binlog is the memory size of the data, not the file size
+	if objectType != scrubObjectInsertLog && binlog.GetLogSize() > 0 && size != binlog.GetLogSize() {
+		return fmt.Errorf("binlog size mismatch, expected %d, actual %d", binlog.GetLogSize(), size)
+	}
+	if objectType == scrubObjectStatsLog {
+		return nil
+	}
+	header, err := s.option.cli.ReadAt(ctx, binlog.GetLogPath(), 0, 4)
+	if err != nil {
+		return err
+	}
+	return storage.CheckBinlogMagicNumber(header)
+}
+
+func checkBinlogChecksum(data []byte, expected uint32) error {
+	if actual := storage.BinlogChecksum(data); actual != expected {
+		return fmt.Errorf("binlog checksum mismatch, expected %d, actual %d", expected, actual)
+	}
+	return nil
+}
+
+// restoreBinlog overwrites the corrupted binlog with its backup copy under the restore root path,
+// the backup is verified by the recorded checksum before being written back.
+func (s *scrubber) restoreBinlog(ctx context.Context, binlog *datapb.Binlog) error {
+	restoreRoot := ""
+	if s.option.restoreRootPath != nil {
+		restoreRoot = s.option.restoreRootPath()
+	}
+	if restoreRoot == "" {
+		return errors.New("restore root path not configured")
+	}
+	if binlog.GetChecksum() == 0 {
+		return errors.New("binlog checksum not recorded, the backup can not be verified")
+	}
+	backupPath := path.Join(restoreRoot, strings.TrimPrefix(binlog.GetLogPath(), s.option.cli.RootPath()))
+	data, err := s.option.cli.Read(ctx, backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to read backup %s: %w", backupPath, err)
+	}
+	if err := checkBinlogChecksum(data, binlog.GetChecksum()); err != nil {
+		return fmt.Errorf("backup %s is corrupted: %w", backupPath, err)
+	}
+	return s.option.cli.Write(ctx, binlog.GetLogPath(), data)
+}
+
+func (s *scrubber) recompactSegment(segment *SegmentInfo) {
+	if s.option.recompact == nil {
+		return
+	}
+	if err := s.option.recompact(segment); err != nil {
+		log.Warn("scrubber failed to trigger recompaction", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+		return
+	}
+	log.Info("scrubber marked the segment for recompaction", zap.Int64("segmentID", segment.GetID()))
+}
+
+// verifyIndex checks all the index files exist, and the total size matches the serialized size of the index.
+func (s *scrubber) verifyIndex(ctx context.Context, buildID, indexVersion, partitionID, segmentID UniqueID,
+	fileKeys []string, indexSize uint64,
+) error {
+	total := uint64(0)
+	for _, key := range fileKeys {
+		filePath := metautil.BuildSegmentIndexFilePath(s.option.cli.RootPath(), buildID, indexVersion, partitionID, segmentID, key)
+		size, err := s.option.cli.Size(ctx, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to stat index file %s: %w", filePath, err)
+		}
+		total += uint64(size)
+	}
+	if indexSize > 0 && total != indexSize {
+		return fmt.Errorf("index size mismatch, expected %d, actual %d", indexSize, total)
+	}
+	return nil
+}
+
+func (s *scrubber) reportCorruption(segment *SegmentInfo, objectType string, object string, err error) {
+	log.Warn("scrubber found corrupted object",
+		zap.Int64("collectionID", segment.GetCollectionID()),
+		zap.Int64("segmentID", segment.GetID()),
+		zap.String("objectType", objectType),
+		zap.String("object", object),
+		zap.Error(err))
+	metrics.DataCoordCorruptedObjectNum.WithLabelValues(fmt.Sprint(segment.GetCollectionID()), objectType).Inc()
+	eventlog.Record(eventlog.NewRawEvt(eventlog.Level_Warn,
+		fmt.Sprintf("Segment %d[%d] has corrupted %s %s: %s", segment.GetID(), segment.GetCollectionID(), objectType, object, err.Error())))
+}
+
+func (s *scrubber) rebuildIndex(buildID UniqueID) {
+	if !s.option.autoRebuildIndex() || s.indexBuilder == nil {
+		return
+	}
+	if err := s.meta.ResetIndexTask(buildID); err != nil {
+		log.Warn("scrubber failed to reset index task", zap.Int64("buildID", buildID), zap.Error(err))
+		return
+	}
+	s.indexBuilder.enqueue(buildID)
+	log.Info("scrubber rebuilds the corrupted index", zap.Int64("buildID", buildID))
+}
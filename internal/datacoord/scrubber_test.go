@@ -0,0 +1,249 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	catalogmocks "github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+)
+
+type ScrubberSuite struct {
+	suite.Suite
+
+	cm       *mocks.ChunkManager
+	catalog  *catalogmocks.DataCoordCatalog
+	meta     *meta
+	scrubber *scrubber
+}
+
+func (s *ScrubberSuite) SetupTest() {
+	s.cm = mocks.NewChunkManager(s.T())
+	s.cm.EXPECT().RootPath().Return("root").Maybe()
+	s.catalog = catalogmocks.NewDataCoordCatalog(s.T())
+
+	segments := make(map[UniqueID]*SegmentInfo)
+	buildID2SegmentIndex := make(map[UniqueID]*model.SegmentIndex)
+	for i := UniqueID(1); i <= 3; i++ {
+		segIdx := &model.SegmentIndex{
+			SegmentID:     i,
+			CollectionID:  100,
+			PartitionID:   200,
+			IndexID:       300,
+			BuildID:       1000 + i,
+			IndexVersion:  1,
+			IndexState:    commonpb.IndexState_Finished,
+			IndexFileKeys: []string{"file"},
+			IndexSize:     100,
+		}
+		segments[i] = &SegmentInfo{
+			SegmentInfo: &datapb.SegmentInfo{
+				ID:           i,
+				CollectionID: 100,
+				PartitionID:  200,
+				State:        commonpb.SegmentState_Flushed,
+				Binlogs: []*datapb.FieldBinlog{{
+					FieldID: 101,
+					Binlogs: []*datapb.Binlog{{LogPath: metautil.BuildInsertLogPath("root", 100, 200, i, 101, 1), LogSize: 10}},
+				}},
+			},
+			segmentIndexes: map[UniqueID]*model.SegmentIndex{300: model.CloneSegmentIndex(segIdx)},
+		}
+		buildID2SegmentIndex[segIdx.BuildID] = segIdx
+	}
+	segments[4] = &SegmentInfo{
+		SegmentInfo: &datapb.SegmentInfo{
+			ID:           4,
+			CollectionID: 100,
+			PartitionID:  200,
+			State:        commonpb.SegmentState_Growing,
+		},
+	}
+
+	s.meta = &meta{
+		RWMutex:  sync.RWMutex{},
+		ctx:      context.Background(),
+		catalog:  s.catalog,
+		segments: &SegmentsInfo{segments: segments},
+		indexes: map[UniqueID]map[UniqueID]*model.Index{
+			100: {300: {CollectionID: 100, FieldID: 101, IndexID: 300, IndexName: "_default_idx"}},
+		},
+		buildID2SegmentIndex: buildID2SegmentIndex,
+	}
+	s.scrubber = newScrubber(s.meta, nil, ScrubOption{
+		cli:              s.cm,
+		batchSize:        func() int { return 2 },
+		autoRebuildIndex: func() bool { return true },
+	})
+}
+
+func (s *ScrubberSuite) magicNumber() []byte {
+	buf := new(bytes.Buffer)
+	s.Require().NoError(binary.Write(buf, common.Endian, storage.MagicNumber))
+	return buf.Bytes()
+}
+
+func (s *ScrubberSuite) TestNextBatch() {
+	batch := s.scrubber.nextBatch()
+	s.Len(batch, 2)
+	s.EqualValues(1, batch[0].GetID())
+	s.EqualValues(2, batch[1].GetID())
+
+	s.scrubber.cursor = 2
+	batch = s.scrubber.nextBatch()
+	s.Len(batch, 1)
+	s.EqualValues(3, batch[0].GetID())
+
+	// start over
+	s.scrubber.cursor = 3
+	batch = s.scrubber.nextBatch()
+	s.Len(batch, 2)
+	s.EqualValues(1, batch[0].GetID())
+}
+
+func (s *ScrubberSuite) TestScrub() {
+	for i := UniqueID(1); i <= 2; i++ {
+		s.cm.EXPECT().Size(mock.Anything, metautil.BuildInsertLogPath("root", 100, 200, i, 101, 1)).Return(10, nil).Once()
+		s.cm.EXPECT().Size(mock.Anything, metautil.BuildSegmentIndexFilePath("root", 1000+i, 1, 200, i, "file")).Return(100, nil).Once()
+	}
+	s.cm.EXPECT().ReadAt(mock.Anything, mock.Anything, int64(0), int64(4)).Return(s.magicNumber(), nil).Times(2)
+
+	s.scrubber.scrub()
+	s.EqualValues(2, s.scrubber.cursor)
+}
+
+func (s *ScrubberSuite) TestVerifyBinlog() {
+	ctx := context.Background()
+	binlog := &datapb.Binlog{LogPath: "root/delta_log/1", LogSize: 10}
+
+	s.Run("not exist", func() {
+		s.cm.EXPECT().Size(mock.Anything, binlog.LogPath).Return(0, errors.New("mock")).Once()
+		s.Error(s.scrubber.verifyBinlog(ctx, binlog, scrubObjectDeltaLog))
+	})
+
+	s.Run("size mismatch", func() {
+		s.cm.EXPECT().Size(mock.Anything, binlog.LogPath).Return(5, nil).Once()
+		s.Error(s.scrubber.verifyBinlog(ctx, binlog, scrubObjectDeltaLog))
+	})
+
+	s.Run("bad magic number", func() {
+		s.cm.EXPECT().Size(mock.Anything, binlog.LogPath).Return(10, nil).Once()
+		s.cm.EXPECT().ReadAt(mock.Anything, binlog.LogPath, int64(0), int64(4)).Return([]byte{0, 0, 0, 0}, nil).Once()
+		s.Error(s.scrubber.verifyBinlog(ctx, binlog, scrubObjectDeltaLog))
+	})
+
+	s.Run("skip magic number", func() {
+		s.cm.EXPECT().Size(mock.Anything, binlog.LogPath).Return(10, nil).Once()
+		s.NoError(s.scrubber.verifyBinlog(ctx, binlog, scrubObjectStatsLog))
+	})
+
+	s.Run("checksum", func() {
+		data := []byte("binlog")
+		checksumLog := &datapb.Binlog{LogPath: "root/insert_log/1", LogSize: 100, Checksum: storage.BinlogChecksum(data)}
+		s.cm.EXPECT().Read(mock.Anything, checksumLog.LogPath).Return(data, nil).Once()
+		s.NoError(s.scrubber.verifyBinlog(ctx, checksumLog, scrubObjectInsertLog))
+
+		s.cm.EXPECT().Read(mock.Anything, checksumLog.LogPath).Return([]byte("binLog"), nil).Once()
+		s.Error(s.scrubber.verifyBinlog(ctx, checksumLog, scrubObjectInsertLog))
+	})
+}
+
+func (s *ScrubberSuite) TestRestoreBinlog() {
+	ctx := context.Background()
+	data := []byte("binlog")
+	binlog := &datapb.Binlog{LogPath: "root/insert_log/1", Checksum: storage.BinlogChecksum(data)}
+
+	s.Run("not configured", func() {
+		s.Error(s.scrubber.restoreBinlog(ctx, binlog))
+	})
+
+	s.scrubber.option.restoreRootPath = func() string { return "backup" }
+
+	s.Run("no checksum", func() {
+		s.Error(s.scrubber.restoreBinlog(ctx, &datapb.Binlog{LogPath: binlog.LogPath}))
+	})
+
+	s.Run("corrupted backup", func() {
+		s.cm.EXPECT().Read(mock.Anything, "backup/insert_log/1").Return([]byte("binLog"), nil).Once()
+		s.Error(s.scrubber.restoreBinlog(ctx, binlog))
+	})
+
+	s.Run("restored", func() {
+		s.cm.EXPECT().Read(mock.Anything, "backup/insert_log/1").Return(data, nil).Once()
+		s.cm.EXPECT().Write(mock.Anything, binlog.LogPath, data).Return(nil).Once()
+		s.NoError(s.scrubber.restoreBinlog(ctx, binlog))
+	})
+}
+
+func (s *ScrubberSuite) TestCorruptedStatsLog() {
+	s.scrubber.option.batchSize = func() int { return 1 }
+	statsLog := metautil.BuildStatsLogPath("root", 100, 200, 1, 101, 1)
+	s.meta.segments.segments[1].Statslogs = []*datapb.FieldBinlog{{
+		FieldID: 101,
+		Binlogs: []*datapb.Binlog{{LogPath: statsLog, Checksum: storage.BinlogChecksum([]byte("stats"))}},
+	}}
+	s.cm.EXPECT().Size(mock.Anything, metautil.BuildInsertLogPath("root", 100, 200, 1, 101, 1)).Return(10, nil).Once()
+	s.cm.EXPECT().ReadAt(mock.Anything, mock.Anything, int64(0), int64(4)).Return(s.magicNumber(), nil).Once()
+	s.cm.EXPECT().Read(mock.Anything, statsLog).Return([]byte("corrupted"), nil).Once()
+	s.cm.EXPECT().Size(mock.Anything, metautil.BuildSegmentIndexFilePath("root", 1001, 1, 200, 1, "file")).Return(100, nil).Once()
+
+	var recompacted []UniqueID
+	s.scrubber.option.recompact = func(segment *SegmentInfo) error {
+		recompacted = append(recompacted, segment.GetID())
+		return nil
+	}
+	s.scrubber.scrub()
+	s.Equal([]UniqueID{1}, recompacted)
+}
+
+func (s *ScrubberSuite) TestCorruptedIndex() {
+	s.scrubber.option.batchSize = func() int { return 1 }
+	s.cm.EXPECT().Size(mock.Anything, metautil.BuildInsertLogPath("root", 100, 200, 1, 101, 1)).Return(10, nil).Once()
+	s.cm.EXPECT().ReadAt(mock.Anything, mock.Anything, int64(0), int64(4)).Return(s.magicNumber(), nil).Once()
+	s.cm.EXPECT().Size(mock.Anything, metautil.BuildSegmentIndexFilePath("root", 1001, 1, 200, 1, "file")).Return(50, nil).Once()
+	s.catalog.EXPECT().AlterSegmentIndexes(mock.Anything, mock.Anything).Return(nil).Once()
+
+	s.scrubber.indexBuilder = &indexBuilder{
+		tasks:      make(map[int64]indexTaskState),
+		notifyChan: make(chan struct{}, 1),
+	}
+	s.scrubber.scrub()
+
+	segIdx, ok := s.meta.GetIndexJob(1001)
+	s.True(ok)
+	s.Equal(commonpb.IndexState_Unissued, segIdx.IndexState)
+	s.Contains(s.scrubber.indexBuilder.tasks, UniqueID(1001))
+}
+
+func TestScrubber(t *testing.T) {
+	suite.Run(t, new(ScrubberSuite))
+}
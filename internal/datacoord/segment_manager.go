@@ -28,6 +28,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/eventbus"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/retry"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
@@ -488,6 +489,7 @@ func (s *SegmentManager) SealAllSegments(ctx context.Context, collectionID Uniqu
 		if err := s.meta.SetState(id, commonpb.SegmentState_Sealed); err != nil {
 			return nil, err
 		}
+		publishSegmentSealed(info, "flush")
 		ret = append(ret, id)
 	}
 	return ret, nil
@@ -592,6 +594,7 @@ func (s *SegmentManager) tryToSealSegment(ts Timestamp, channel string) error {
 				if err := s.meta.SetState(id, commonpb.SegmentState_Sealed); err != nil {
 					return err
 				}
+				publishSegmentSealed(info, "segment policy")
 				break
 			}
 		}
@@ -606,12 +609,23 @@ func (s *SegmentManager) tryToSealSegment(ts Timestamp, channel string) error {
 				if err := s.meta.SetState(info.GetID(), commonpb.SegmentState_Sealed); err != nil {
 					return err
 				}
+				publishSegmentSealed(info, "channel policy")
 			}
 		}
 	}
 	return nil
 }
 
+func publishSegmentSealed(info *SegmentInfo, reason string) {
+	eventbus.Publish(eventbus.SegmentSealed, info.GetCollectionID(), map[string]any{
+		"segment_id":   info.GetID(),
+		"partition_id": info.GetPartitionID(),
+		"channel":      info.GetInsertChannel(),
+		"num_rows":     info.GetNumOfRows(),
+		"reason":       reason,
+	})
+}
+
 // DropSegmentsOfChannel drops all segments in a channel
 func (s *SegmentManager) DropSegmentsOfChannel(ctx context.Context, channel string) {
 	s.mu.Lock()
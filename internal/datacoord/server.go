@@ -34,10 +34,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus/internal/datacoord/broker"
 	datanodeclient "github.com/milvus-io/milvus/internal/distributed/datanode/client"
 	indexnodeclient "github.com/milvus-io/milvus/internal/distributed/indexnode/client"
 	rootcoordclient "github.com/milvus-io/milvus/internal/distributed/rootcoord/client"
+	"github.com/milvus-io/milvus/internal/http/healthz"
 	"github.com/milvus-io/milvus/internal/kv"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/kv/tikv"
@@ -46,7 +48,9 @@ import (
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/dependency"
+	"github.com/milvus-io/milvus/internal/util/eventbus"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
@@ -120,7 +124,11 @@ type Server struct {
 	rootCoordClient  types.RootCoordClient
 	garbageCollector *garbageCollector
 	gcOpt            GcOption
+	scrubber         *scrubber
+	vectorUpdater    *vectorUpdater
 	handler          Handler
+	channelLatency   *channelLatencyTracker
+	channelIsolation *channelIsolation
 
 	compactionTrigger     trigger
 	compactionHandler     compactionPlanContext
@@ -223,6 +231,10 @@ func CreateServer(ctx context.Context, factory dependency.Factory, opts ...Optio
 		metricsCacheManager:    metricsinfo.NewMetricsCacheManager(),
 		enableActiveStandBy:    Params.DataCoordCfg.EnableActiveStandby.GetAsBool(),
 	}
+	s.channelLatency = newChannelLatencyTracker(func(channel string) *msgpb.MsgPosition {
+		return s.meta.GetChannelCheckpoint(channel)
+	}, s.channelLag)
+	s.channelIsolation = newChannelIsolation(func() broker.Broker { return s.broker })
 
 	for _, opt := range opts {
 		opt(s)
@@ -375,6 +387,8 @@ func (s *Server) initDataCoord() error {
 
 	s.initGarbageCollection(storageCli)
 	s.initIndexBuilder(storageCli)
+	s.initScrubber(storageCli)
+	s.initVectorUpdater()
 
 	s.serverLoopCtx, s.serverLoopCancel = context.WithCancel(s.ctx)
 
@@ -459,7 +473,8 @@ func (s *Server) initCluster() error {
 
 	var err error
 	s.channelManager, err = NewChannelManager(s.watchClient, s.handler, withMsgstreamFactory(s.factory),
-		withStateChecker(), withBgChecker())
+		withStateChecker(), withBgChecker(), withChannelIsolation(s.channelIsolation),
+		withReleaseHook(s.channelLatency.remove))
 	if err != nil {
 		return err
 	}
@@ -532,6 +547,36 @@ func (s *Server) initGarbageCollection(cli storage.ChunkManager) {
 	})
 }
 
+func (s *Server) initScrubber(cli storage.ChunkManager) {
+	s.scrubber = newScrubber(s.meta, s.indexBuilder, ScrubOption{
+		cli:           cli,
+		enabled:       Params.DataCoordCfg.EnableScrubber.GetAsBool(),
+		checkInterval: Params.DataCoordCfg.ScrubInterval.GetAsDuration(time.Second),
+		batchSize: func() int {
+			return Params.DataCoordCfg.ScrubBatchSize.GetAsInt()
+		},
+		autoRebuildIndex: func() bool {
+			return Params.DataCoordCfg.ScrubAutoRebuildIndex.GetAsBool()
+		},
+		restoreRootPath: func() string {
+			return Params.DataCoordCfg.ScrubRestoreRootPath.GetValue()
+		},
+		recompact: func(segment *SegmentInfo) error {
+			if s.compactionTrigger == nil {
+				return errors.New("compaction is disabled")
+			}
+			return s.compactionTrigger.triggerRecompaction(segment)
+		},
+	})
+}
+
+func (s *Server) initVectorUpdater() {
+	s.vectorUpdater = newVectorUpdater(s.ctx, s.meta, s.handler, s.allocator, s.sessionManager,
+		func(channel string) (int64, error) {
+			return s.channelManager.FindWatcher(channel)
+		}, s.Flush, s.flushCh)
+}
+
 func (s *Server) initServiceDiscovery() error {
 	r := semver.MustParseRange(">=2.2.3")
 	sessions, rev, err := s.session.GetSessionsWithVersionRange(typeutil.DataNodeRole, r)
@@ -622,6 +667,7 @@ func (s *Server) initMeta(chunkManager storage.ChunkManager) error {
 	}
 	log.Info("data coordinator successfully connected to metadata store", zap.String("metaType", metaType))
 
+	defer healthz.Track(typeutil.DataCoordRole, healthz.StageRecovering, healthz.ReasonRecoveringMeta, metaRootPath)()
 	reloadEtcdFn := func() error {
 		var err error
 		catalog := datacoord.NewCatalog(s.kv, chunkManager.RootPath(), metaRootPath)
@@ -636,7 +682,8 @@ func (s *Server) initMeta(chunkManager storage.ChunkManager) error {
 
 func (s *Server) initIndexBuilder(manager storage.ChunkManager) {
 	if s.indexBuilder == nil {
-		s.indexBuilder = newIndexBuilder(s.ctx, s.meta, s.indexNodeManager, manager, s.indexEngineVersionManager, s.handler)
+		s.indexBuilder = newIndexBuilder(s.ctx, s.meta, s.indexNodeManager, manager, s.indexEngineVersionManager, s.handler,
+			newIndexReadyNotifier(s.watchClient))
 	}
 }
 
@@ -656,6 +703,9 @@ func (s *Server) startServerLoop() {
 	s.startFlushLoop(s.serverLoopCtx)
 	s.startIndexService(s.serverLoopCtx)
 	s.garbageCollector.start()
+	s.scrubber.start()
+	s.channelLatency.start()
+	s.channelIsolation.start(s.serverLoopCtx)
 }
 
 // startDataNodeTtLoop start a goroutine to recv data node tt msg from msgstream
@@ -747,6 +797,8 @@ func (s *Server) handleTimetickMessage(ctx context.Context, ttMsg *msgstream.Dat
 		return nil
 	}
 
+	s.channelLatency.observe(ttMsg.GetBase().GetSourceID(), ch, ts)
+
 	sub := tsoutil.SubByNow(ts)
 	pChannelName := funcutil.ToPhysicalChannel(ch)
 	metrics.DataCoordConsumeDataNodeTimeTickLag.
@@ -909,6 +961,13 @@ func (s *Server) handleSessionEvent(ctx context.Context, role string, event *ses
 	if event == nil {
 		return nil
 	}
+	if event.EventType == sessionutil.SessionDelEvent {
+		eventbus.Publish(eventbus.NodeDown, 0, map[string]any{
+			"role":    role,
+			"node_id": event.Session.ServerID,
+			"address": event.Session.Address,
+		})
+	}
 	switch role {
 	case typeutil.DataNodeRole:
 		info := &datapb.DataNodeInfo{
@@ -1087,6 +1146,10 @@ func (s *Server) Stop() error {
 	logutil.Logger(s.ctx).Info("server shutdown")
 	s.cluster.Close()
 	s.garbageCollector.close()
+	s.scrubber.close()
+	s.vectorUpdater.close()
+	s.channelLatency.close()
+	s.channelIsolation.close()
 	s.stopServerLoop()
 
 	if Params.DataCoordCfg.EnableCompaction.GetAsBool() {
@@ -1158,6 +1221,11 @@ func (s *Server) loadCollectionFromRootCoord(ctx context.Context, collectionID i
 		properties[pair.GetKey()] = pair.GetValue()
 	}
 
+	// route the collection before any binlog of it is written
+	if err := storage.RouteCollection(ctx, s.meta.chunkManager, collectionID, properties[common.StorageProfileKey]); err != nil {
+		return err
+	}
+
 	collInfo := &collectionInfo{
 		ID:             resp.CollectionID,
 		Schema:         resp.Schema,
@@ -1217,6 +1217,18 @@ func TestServer_GetMetrics(t *testing.T) {
 	log.Info("TestServer_GetMetrics",
 		zap.String("name", resp.ComponentName),
 		zap.String("response", resp.Response))
+
+	// channel latency
+	svr.channelLatency.observe(1, "ch1", tsoutil.ComposeTSByTime(time.Now(), 0))
+	req, err = metricsinfo.ConstructRequestByMetricType(metricsinfo.ChannelLatencyMetrics)
+	assert.NoError(t, err)
+	resp, err = svr.GetMetrics(svr.ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, commonpb.ErrorCode_Success, resp.GetStatus().GetErrorCode())
+	infos := &metricsinfo.ChannelLatencyInfos{}
+	assert.NoError(t, metricsinfo.UnmarshalComponentInfos(resp.GetResponse(), infos))
+	assert.Len(t, infos.Channels, 1)
+	assert.Equal(t, "ch1", infos.Channels[0].Channel)
 }
 
 func TestServer_getSystemInfoMetrics(t *testing.T) {
@@ -4166,6 +4178,29 @@ func TestDataCoord_UnsetIsImportingState(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
 	})
+
+	t.Run("unset atomically", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+		svr.meta.AddSegment(context.TODO(), buildSegment(100, 100, 101, "ch1", true))
+		svr.meta.AddSegment(context.TODO(), buildSegment(100, 100, 102, "ch1", true))
+
+		// none of the segments is unset if any of them does not exist.
+		status, err := svr.UnsetIsImportingState(context.Background(), &datapb.UnsetIsImportingStateRequest{
+			SegmentIds: []int64{101, 999},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_UnexpectedError, status.GetErrorCode())
+		assert.True(t, svr.meta.GetSegment(101).GetIsImporting())
+
+		status, err = svr.UnsetIsImportingState(context.Background(), &datapb.UnsetIsImportingStateRequest{
+			SegmentIds: []int64{101, 102},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, commonpb.ErrorCode_Success, status.GetErrorCode())
+		assert.False(t, svr.meta.GetSegment(101).GetIsImporting())
+		assert.False(t, svr.meta.GetSegment(102).GetIsImporting())
+	})
 }
 
 func TestDataCoordServer_UpdateChannelCheckpoint(t *testing.T) {
@@ -589,6 +589,7 @@ func (s *Server) DropVirtualChannel(ctx context.Context, req *datapb.DropVirtual
 
 	metrics.CleanupDataCoordNumStoredRows(collectionID)
 	metrics.DataCoordCheckpointLag.DeleteLabelValues(fmt.Sprint(paramtable.GetNodeID()), channel)
+	s.channelLatency.remove(channel)
 
 	// no compaction triggered in Drop procedure
 	return resp, nil
@@ -1022,6 +1023,44 @@ func (s *Server) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest
 		return metrics, nil
 	}
 
+	if metricType == metricsinfo.ChannelCheckpointMetrics || metricType == metricsinfo.SegmentMetaMetrics ||
+		metricType == metricsinfo.SegmentFieldStatsMetrics || metricType == metricsinfo.SmallSegmentMetrics {
+		return s.getIntrospectionMetrics(ctx, metricType, req), nil
+	}
+
+	if metricType == metricsinfo.TaskHistoryMetrics || metricType == metricsinfo.PurgeTaskHistoryMetrics {
+		return s.getTaskHistoryMetrics(metricType, req), nil
+	}
+
+	if metricType == metricsinfo.VectorUpdateMetrics || metricType == metricsinfo.SubmitVectorUpdateMetrics ||
+		metricType == metricsinfo.CancelVectorUpdateMetrics {
+		return s.getVectorUpdateMetrics(ctx, metricType, req), nil
+	}
+
+	if metricType == metricsinfo.ChannelLatencyMetrics {
+		resp := &milvuspb.GetMetricsResponse{
+			Status:        merr.Success(),
+			ComponentName: metricsinfo.ConstructComponentName(typeutil.DataCoordRole, paramtable.GetNodeID()),
+		}
+		resp.Response, err = metricsinfo.MarshalComponentInfos(s.channelLatency.snapshot())
+		if err != nil {
+			resp.Status = merr.Status(err)
+		}
+		return resp, nil
+	}
+
+	if metricType == metricsinfo.TimeTickWatermarkMetrics {
+		resp := &milvuspb.GetMetricsResponse{
+			Status:        merr.Success(),
+			ComponentName: metricsinfo.ConstructComponentName(typeutil.DataCoordRole, paramtable.GetNodeID()),
+		}
+		resp.Response, err = metricsinfo.MarshalComponentInfos(s.channelLatency.watermarks())
+		if err != nil {
+			resp.Status = merr.Status(err)
+		}
+		return resp, nil
+	}
+
 	log.RatedWarn(60.0, "DataCoord.GetMetrics failed, request metric type is not implemented yet",
 		zap.Int64("nodeID", paramtable.GetNodeID()),
 		zap.String("req", req.Request),
@@ -1450,6 +1489,7 @@ func (s *Server) handleRPCTimetickMessage(ctx context.Context, ttMsg *msgpb.Data
 		return nil
 	}
 
+	s.channelLatency.observe(ttMsg.GetBase().GetSourceID(), ch, ts)
 	s.updateSegmentStatistics(ttMsg.GetSegmentsStats())
 
 	if err := s.segmentManager.ExpireAllocations(ch, ts); err != nil {
@@ -1548,18 +1588,14 @@ func (s *Server) UnsetIsImportingState(ctx context.Context, req *datapb.UnsetIsI
 	log := log.Ctx(ctx)
 	log.Info("unsetting isImport state of segments",
 		zap.Int64s("segments", req.GetSegmentIds()))
-	var reportErr error
-	for _, segID := range req.GetSegmentIds() {
-		if err := s.meta.UnsetIsImporting(segID); err != nil {
-			// Fail-open.
-			log.Error("failed to unset segment is importing state",
-				zap.Int64("segmentID", segID),
-			)
-			reportErr = err
-		}
+	// The segments are unset at once, so they are visible to the same query target.
+	if err := s.meta.UnsetIsImporting(req.GetSegmentIds()...); err != nil {
+		log.Error("failed to unset segment is importing state",
+			zap.Int64s("segments", req.GetSegmentIds()),
+			zap.Error(err))
+		return merr.Status(err), nil
 	}
-
-	return merr.Status(reportErr), nil
+	return merr.Success(), nil
 }
 
 // MarkSegmentsDropped marks the given segments as `Dropped`.
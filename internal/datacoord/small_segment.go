@@ -0,0 +1,139 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/logutil"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+)
+
+// getSmallSegmentReports returns the small sealed segments of the collections, zero collection id means all collections.
+// Only the collections having sealed segments are reported.
+func getSmallSegmentReports(m *meta, collectionID UniqueID) []metricsinfo.SmallSegmentReport {
+	segments := m.SelectSegments(func(segment *SegmentInfo) bool {
+		return (collectionID == 0 || segment.GetCollectionID() == collectionID) &&
+			isSegmentHealthy(segment) &&
+			isFlush(segment) &&
+			!segment.GetIsImporting() &&
+			segment.GetLevel() != datapb.SegmentLevel_L0
+	})
+
+	reports := make(map[UniqueID]*metricsinfo.SmallSegmentReport)
+	for _, segment := range segments {
+		report, ok := reports[segment.GetCollectionID()]
+		if !ok {
+			report = &metricsinfo.SmallSegmentReport{
+				CollectionID:  segment.GetCollectionID(),
+				SmallSegments: make([]int64, 0),
+			}
+			reports[segment.GetCollectionID()] = report
+		}
+		report.NumSegments++
+		if !isSmallSegment(segment) {
+			continue
+		}
+		report.NumSmallSegments++
+		report.SmallSegmentRows += segment.GetNumOfRows()
+		report.SmallSegments = append(report.SmallSegments, segment.GetID())
+		report.Compacting = report.Compacting || segment.isCompacting
+	}
+
+	result := make([]metricsinfo.SmallSegmentReport, 0, len(reports))
+	for _, report := range reports {
+		sort.Slice(report.SmallSegments, func(i, j int) bool { return report.SmallSegments[i] < report.SmallSegments[j] })
+		result = append(result, *report)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CollectionID < result[j].CollectionID })
+	return result
+}
+
+// startSmallSegmentLoop reports the small segments of collections periodically,
+// and triggers the merge compaction of the collections accumulating too many small segments,
+// which is common after trickle ingestion, without waiting for the global compaction.
+func (t *compactionTrigger) startSmallSegmentLoop() {
+	defer logutil.LogPanic()
+	defer t.wg.Done()
+
+	// If AutoCompaction disabled, small segment loop will not start
+	if !Params.DataCoordCfg.EnableAutoCompaction.GetAsBool() {
+		return
+	}
+
+	ticker := time.NewTicker(Params.DataCoordCfg.SmallSegmentCompactionInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.quit:
+			log.Info("small segment compaction loop exit")
+			return
+		case <-ticker.C:
+			t.checkSmallSegments()
+		}
+	}
+}
+
+// checkSmallSegments records the small segments of collections in metrics,
+// returns the collections whose merge compaction are triggered.
+func (t *compactionTrigger) checkSmallSegments() []UniqueID {
+	reports := getSmallSegmentReports(t.meta, 0)
+	metrics.DataCoordSmallSegmentNum.Reset()
+	for _, report := range reports {
+		metrics.DataCoordSmallSegmentNum.WithLabelValues(fmt.Sprint(report.CollectionID)).Set(float64(report.NumSmallSegments))
+	}
+
+	if !Params.DataCoordCfg.SmallSegmentCompactionEnabled.GetAsBool() {
+		return nil
+	}
+	threshold := Params.DataCoordCfg.SmallSegmentCompactionThreshold.GetAsInt()
+	triggered := make([]UniqueID, 0)
+	for _, report := range reports {
+		// the compacting segments will be merged soon, wait for them
+		if report.NumSmallSegments < threshold || report.Compacting {
+			continue
+		}
+		if t.compactionHandler.isFull() {
+			log.Warn("small segment compaction skipped due to handler full")
+			break
+		}
+		id, err := t.allocSignalID()
+		if err != nil {
+			log.Warn("failed to alloc signal id for small segment compaction", zap.Error(err))
+			break
+		}
+		t.signals <- &compactionSignal{
+			id:           id,
+			isGlobal:     true,
+			collectionID: report.CollectionID,
+		}
+		log.Info("trigger compaction to merge small segments",
+			zap.Int64("collectionID", report.CollectionID),
+			zap.Int("numSmallSegments", report.NumSmallSegments),
+			zap.Int64("smallSegmentRows", report.SmallSegmentRows))
+		metrics.DataCoordSmallSegmentCompactionNum.WithLabelValues(fmt.Sprint(report.CollectionID)).Inc()
+		triggered = append(triggered, report.CollectionID)
+	}
+	return triggered
+}
@@ -0,0 +1,107 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestSmallSegments(t *testing.T) {
+	paramtable.Init()
+	meta, err := newMemoryMeta()
+	require.NoError(t, err)
+	for _, segment := range []*datapb.SegmentInfo{
+		{ID: 1, CollectionID: 100, State: commonpb.SegmentState_Flushed, NumOfRows: 10, MaxRowNum: 1000},
+		{ID: 2, CollectionID: 100, State: commonpb.SegmentState_Flushed, NumOfRows: 20, MaxRowNum: 1000},
+		{ID: 3, CollectionID: 100, State: commonpb.SegmentState_Flushed, NumOfRows: 900, MaxRowNum: 1000},
+		{ID: 4, CollectionID: 100, State: commonpb.SegmentState_Growing, NumOfRows: 10, MaxRowNum: 1000},
+		{ID: 5, CollectionID: 100, State: commonpb.SegmentState_Flushed, NumOfRows: 10, MaxRowNum: 1000, Level: datapb.SegmentLevel_L0},
+		{ID: 6, CollectionID: 200, State: commonpb.SegmentState_Flushed, NumOfRows: 10, MaxRowNum: 1000},
+	} {
+		require.NoError(t, meta.AddSegment(context.TODO(), NewSegmentInfo(segment)))
+	}
+
+	t.Run("report", func(t *testing.T) {
+		reports := getSmallSegmentReports(meta, 0)
+		require.Equal(t, 2, len(reports))
+		assert.Equal(t, metricsinfo.SmallSegmentReport{
+			CollectionID:     100,
+			NumSegments:      3,
+			NumSmallSegments: 2,
+			SmallSegmentRows: 30,
+			SmallSegments:    []int64{1, 2},
+		}, reports[0])
+		assert.Equal(t, int64(200), reports[1].CollectionID)
+
+		reports = getSmallSegmentReports(meta, 200)
+		require.Equal(t, 1, len(reports))
+		assert.Equal(t, []int64{6}, reports[0].SmallSegments)
+	})
+
+	t.Run("trigger", func(t *testing.T) {
+		paramtable.Get().Save(Params.DataCoordCfg.SmallSegmentCompactionThreshold.Key, "2")
+		defer paramtable.Get().Reset(Params.DataCoordCfg.SmallSegmentCompactionThreshold.Key)
+
+		handler := NewMockCompactionPlanContext(t)
+		handler.EXPECT().isFull().Return(false)
+		tr := &compactionTrigger{
+			meta:              meta,
+			allocator:         newMockAllocator(),
+			signals:           make(chan *compactionSignal, 10),
+			compactionHandler: handler,
+		}
+		assert.Equal(t, []UniqueID{100}, tr.checkSmallSegments())
+		signal := <-tr.signals
+		assert.True(t, signal.isGlobal)
+		assert.False(t, signal.isForce)
+		assert.Equal(t, int64(100), signal.collectionID)
+
+		// wait for the compacting small segments
+		meta.SetSegmentCompacting(1, true)
+		defer meta.SetSegmentCompacting(1, false)
+		assert.Empty(t, tr.checkSmallSegments())
+
+		paramtable.Get().Save(Params.DataCoordCfg.SmallSegmentCompactionEnabled.Key, "false")
+		defer paramtable.Get().Reset(Params.DataCoordCfg.SmallSegmentCompactionEnabled.Key)
+		assert.Empty(t, tr.checkSmallSegments())
+	})
+
+	t.Run("introspection", func(t *testing.T) {
+		s := &Server{meta: meta}
+		req, err := metricsinfo.ConstructRequestWithParams(metricsinfo.SmallSegmentMetrics, map[string]any{
+			metricsinfo.MetricCollectionIDKey: 100,
+		})
+		require.NoError(t, err)
+		resp := s.getIntrospectionMetrics(context.TODO(), metricsinfo.SmallSegmentMetrics, req)
+		require.NoError(t, merr.Error(resp.GetStatus()))
+		infos := &metricsinfo.SmallSegmentInfos{}
+		require.NoError(t, json.Unmarshal([]byte(resp.GetResponse()), infos))
+		require.Equal(t, 1, len(infos.Collections))
+		assert.Equal(t, 2, infos.Collections[0].NumSmallSegments)
+	})
+}
@@ -0,0 +1,201 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// taskHistoryParams are the params of the task history requests.
+type taskHistoryParams struct {
+	Kind  string `json:"kind"`
+	Start int64  `json:"start"`
+	End   int64  `json:"end"`
+}
+
+// timeRange returns the time range of the params, zero time means unbounded.
+func (p *taskHistoryParams) timeRange() (time.Time, time.Time) {
+	var start, end time.Time
+	if p.Start > 0 {
+		start = time.Unix(p.Start, 0)
+	}
+	if p.End > 0 {
+		end = time.Unix(p.End, 0)
+	}
+	return start, end
+}
+
+// getTaskHistoryMetrics lists the finished compaction tasks and index builds in the time range,
+// or purges the ones finished before the end time.
+// The compaction tasks are kept in memory only, the index builds purged are the records in the metastore
+// of the segments or indexes dropped, the ones of the segments serving are kept.
+func (s *Server) getTaskHistoryMetrics(metricType string, req *milvuspb.GetMetricsRequest) *milvuspb.GetMetricsResponse {
+	resp := &milvuspb.GetMetricsResponse{
+		Status:        merr.Success(),
+		ComponentName: metricsinfo.ConstructComponentName(typeutil.DataCoordRole, paramtable.GetNodeID()),
+	}
+	params := &taskHistoryParams{}
+	if err := json.Unmarshal([]byte(req.GetRequest()), params); err != nil {
+		resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("invalid request: %s", err.Error()))
+		return resp
+	}
+	start, end := params.timeRange()
+
+	infos := &metricsinfo.TaskHistoryInfos{Tasks: make([]metricsinfo.TaskHistory, 0)}
+	switch params.Kind {
+	case metricsinfo.TaskKindCompaction:
+		infos.Retention = int64(Params.DataCoordCfg.CompactionTaskRetention.GetAsDuration(time.Second).Seconds())
+		var tasks []*compactionTask
+		if metricType == metricsinfo.PurgeTaskHistoryMetrics {
+			if end.IsZero() {
+				resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("%s is required to purge", metricsinfo.MetricEndTimeKey))
+				return resp
+			}
+			tasks = s.compactionHandler.purgeHistory(end)
+		} else {
+			tasks = s.compactionHandler.listHistory(start, end)
+		}
+		for _, task := range tasks {
+			infos.Tasks = append(infos.Tasks, compactionTaskHistory(task))
+		}
+	case metricsinfo.TaskKindIndex:
+		if metricType == metricsinfo.PurgeTaskHistoryMetrics {
+			if end.IsZero() {
+				resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("%s is required to purge", metricsinfo.MetricEndTimeKey))
+				return resp
+			}
+			infos.Tasks = s.purgeIndexBuildHistory(end)
+		} else {
+			infos.Tasks = s.listIndexBuildHistory(start, end)
+		}
+	default:
+		resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("invalid task kind: %s, should be %s or %s",
+			params.Kind, metricsinfo.TaskKindCompaction, metricsinfo.TaskKindIndex))
+		return resp
+	}
+	sort.Slice(infos.Tasks, func(i, j int) bool {
+		return infos.Tasks[i].EndTime < infos.Tasks[j].EndTime
+	})
+
+	var err error
+	resp.Response, err = metricsinfo.MarshalComponentInfos(infos)
+	if err != nil {
+		resp.Status = merr.Status(err)
+	}
+	return resp
+}
+
+func compactionTaskHistory(task *compactionTask) metricsinfo.TaskHistory {
+	history := metricsinfo.TaskHistory{
+		Kind:      metricsinfo.TaskKindCompaction,
+		TaskID:    task.plan.GetPlanID(),
+		State:     "Completed",
+		StartTime: tsoutil.PhysicalTime(task.plan.GetStartTime()).Unix(),
+		EndTime:   task.endTime.Unix(),
+	}
+	if task.state == failed {
+		history.State = "Failed"
+	}
+	if task.triggerInfo != nil {
+		history.CollectionID = task.triggerInfo.collectionID
+	}
+	return history
+}
+
+// indexBuildEndTime returns the time the index build finished,
+// the builds finished before the finish time is recorded fall back to the time created.
+func indexBuildEndTime(segIndex *model.SegmentIndex) time.Time {
+	if segIndex.FinishTime > 0 {
+		return tsoutil.PhysicalTime(segIndex.FinishTime)
+	}
+	return tsoutil.PhysicalTime(segIndex.CreateTime)
+}
+
+func indexBuildHistory(segIndex *model.SegmentIndex) metricsinfo.TaskHistory {
+	return metricsinfo.TaskHistory{
+		Kind:         metricsinfo.TaskKindIndex,
+		TaskID:       segIndex.BuildID,
+		CollectionID: segIndex.CollectionID,
+		State:        segIndex.IndexState.String(),
+		StartTime:    tsoutil.PhysicalTime(segIndex.CreateTime).Unix(),
+		EndTime:      indexBuildEndTime(segIndex).Unix(),
+		Reason:       segIndex.FailReason,
+	}
+}
+
+// listFinishedIndexBuilds returns the finished or failed index builds which ended in [start, end).
+func (s *Server) listFinishedIndexBuilds(start, end time.Time) []*model.SegmentIndex {
+	segIndexes := make([]*model.SegmentIndex, 0)
+	for _, segIndex := range s.meta.GetAllSegIndexes() {
+		if segIndex.IsDeleted || (segIndex.IndexState != commonpb.IndexState_Finished && segIndex.IndexState != commonpb.IndexState_Failed) {
+			continue
+		}
+		endTime := indexBuildEndTime(segIndex)
+		if endTime.Before(start) || (!end.IsZero() && !endTime.Before(end)) {
+			continue
+		}
+		segIndexes = append(segIndexes, segIndex)
+	}
+	return segIndexes
+}
+
+// listIndexBuildHistory returns the finished or failed index builds which ended in [start, end).
+func (s *Server) listIndexBuildHistory(start, end time.Time) []metricsinfo.TaskHistory {
+	tasks := make([]metricsinfo.TaskHistory, 0)
+	for _, segIndex := range s.listFinishedIndexBuilds(start, end) {
+		tasks = append(tasks, indexBuildHistory(segIndex))
+	}
+	return tasks
+}
+
+// purgeIndexBuildHistory removes the records of the index builds finished before the time from the metastore,
+// if their segments or indexes are dropped, the index files are recycled by the garbage collector then.
+func (s *Server) purgeIndexBuildHistory(before time.Time) []metricsinfo.TaskHistory {
+	tasks := make([]metricsinfo.TaskHistory, 0)
+	for _, segIndex := range s.listFinishedIndexBuilds(time.Time{}, before) {
+		segment := s.meta.GetSegment(segIndex.SegmentID)
+		if segment != nil && segment.GetState() != commonpb.SegmentState_Dropped &&
+			s.meta.IsIndexExist(segIndex.CollectionID, segIndex.IndexID) {
+			continue
+		}
+		if err := s.meta.RemoveSegmentIndex(segIndex.CollectionID, segIndex.PartitionID, segIndex.SegmentID,
+			segIndex.IndexID, segIndex.BuildID); err != nil {
+			log.Warn("failed to purge the index build", zap.Int64("buildID", segIndex.BuildID),
+				zap.Int64("segmentID", segIndex.SegmentID), zap.Error(err))
+			continue
+		}
+		tasks = append(tasks, indexBuildHistory(segIndex))
+	}
+	if len(tasks) > 0 {
+		log.Info("index build history purged", zap.Int("builds", len(tasks)), zap.Time("before", before))
+	}
+	return tasks
+}
@@ -185,6 +185,23 @@ func getIndexType(indexParams []*commonpb.KeyValuePair) string {
 	return invalidIndex
 }
 
+func getJSONPath(indexParams []*commonpb.KeyValuePair) string {
+	for _, param := range indexParams {
+		if param.Key == common.JSONPathKey {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+// isSameJSONKeyStats returns false if both are json key stats on different keys.
+func isSameJSONKeyStats(indexParams1, indexParams2 []*commonpb.KeyValuePair) bool {
+	if getIndexType(indexParams1) != jsonKeyStats || getIndexType(indexParams2) != jsonKeyStats {
+		return true
+	}
+	return getJSONPath(indexParams1) == getJSONPath(indexParams2)
+}
+
 func isFlatIndex(indexType string) bool {
 	return indexType == flatIndex || indexType == binFlatIndex
 }
@@ -0,0 +1,900 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/cockroachdb/errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// vectorUpdatePathPrefix is the prefix of the files of the vector updates under the root path of bucket,
+// the same as the files exported by the proxies.
+const vectorUpdatePathPrefix = "export"
+
+// vectorUpdateReadBatchSize is the number of rows decoded from the files at once.
+const vectorUpdateReadBatchSize = 1024
+
+// vectorUpdateWaitInterval is the interval to check the segments being flushed or compacted again.
+var vectorUpdateWaitInterval = time.Second
+
+// errVectorUpdateCanceled is the cause of the vector updates canceled through the management API.
+var errVectorUpdateCanceled = errors.New("vector update canceled")
+
+type vectorUpdateJob struct {
+	info   *metricsinfo.VectorUpdateJob
+	cancel context.CancelCauseFunc
+}
+
+type vectorUpdateFlushFunc func(ctx context.Context, req *datapb.FlushRequest) (*datapb.FlushResponse, error)
+
+// vectorPatch is the new vectors of a segment, rows maps the offsets of the rows in the segment
+// to the rows of data.
+type vectorPatch struct {
+	data storage.FieldData
+	rows map[int]int
+}
+
+// vectorUpdater runs the vector updates, which replace the values of a vector field for the primary keys listed
+// in parquet files, so that the embeddings can be refreshed without reinserting the rows.
+// The collection is flushed first, then the flushed segments are rewritten like compactions: the binlogs of the vector
+// field are rewritten with the new vectors into a new segment, and the binlogs of the other fields, the stats logs and
+// the delta logs are copied as they are, so the primary keys, the auto IDs and the timestamps of rows are kept.
+// The new segment replaces the old one in a single meta update, and its index is built as a flushed segment,
+// the old one is served until then. Only the rows inserted before the job starts are updated, the segments are
+// marked compacting while they're rewritten, and the ones being compacted are rewritten once the compaction is done.
+// Jobs are kept in memory, so they're lost once datacoord restarts, the segments replaced already stay replaced,
+// and the finished ones are evicted after dataCoord.vectorUpdate.jobRetention.
+type vectorUpdater struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	meta        *meta
+	handler     Handler
+	allocator   allocator
+	sessions    SessionManager
+	findWatcher func(channel string) (int64, error)
+	flush       vectorUpdateFlushFunc
+	flushCh     chan<- UniqueID
+
+	mu   sync.RWMutex
+	jobs map[int64]*vectorUpdateJob
+	sem  chan struct{}
+}
+
+func newVectorUpdater(ctx context.Context, meta *meta, handler Handler, allocator allocator, sessions SessionManager,
+	findWatcher func(channel string) (int64, error), flush vectorUpdateFlushFunc, flushCh chan<- UniqueID,
+) *vectorUpdater {
+	ctx, cancel := context.WithCancel(ctx)
+	return &vectorUpdater{
+		ctx:         ctx,
+		cancel:      cancel,
+		meta:        meta,
+		handler:     handler,
+		allocator:   allocator,
+		sessions:    sessions,
+		findWatcher: findWatcher,
+		flush:       flush,
+		flushCh:     flushCh,
+		jobs:        make(map[int64]*vectorUpdateJob),
+		sem:         make(chan struct{}, Params.DataCoordCfg.VectorUpdateMaxRunningJobs.GetAsInt()),
+	}
+}
+
+func (u *vectorUpdater) close() {
+	u.cancel()
+}
+
+func validateVectorUpdatePath(filePath string) error {
+	if filePath == "" {
+		return merr.WrapErrParameterInvalidMsg("path is empty")
+	}
+	if path.IsAbs(filePath) || strings.HasPrefix(filePath, "\\") {
+		return merr.WrapErrParameterInvalidMsg("path %s must be relative", filePath)
+	}
+	for _, elem := range strings.FieldsFunc(filePath, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if elem == ".." {
+			return merr.WrapErrParameterInvalidMsg("path %s must not contain ..", filePath)
+		}
+	}
+	return nil
+}
+
+// submit starts the vector update in background, returns a copy of the job submitted.
+func (u *vectorUpdater) submit(ctx context.Context, req *metricsinfo.VectorUpdateRequest) (*metricsinfo.VectorUpdateJob, error) {
+	if req.CollectionID <= 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("collection id is required")
+	}
+	if req.FieldID <= 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("field id is required")
+	}
+	if err := validateVectorUpdatePath(req.Path); err != nil {
+		return nil, err
+	}
+	jobID, err := u.allocator.allocID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u.evict()
+	jobCtx, cancel := context.WithCancelCause(u.ctx)
+	job := &vectorUpdateJob{
+		info: &metricsinfo.VectorUpdateJob{
+			VectorUpdateRequest: *req,
+			JobID:               jobID,
+			State:               metricsinfo.VectorUpdateJobPending,
+			Segments:            make(map[int64]int64),
+			StartTime:           time.Now().Unix(),
+		},
+		cancel: cancel,
+	}
+	u.mu.Lock()
+	u.jobs[jobID] = job
+	cloned := cloneVectorUpdateJob(job.info)
+	u.mu.Unlock()
+
+	go u.run(jobCtx, job)
+	return cloned, nil
+}
+
+// cancelJob cancels the pending or running job, the segments replaced already stay replaced.
+func (u *vectorUpdater) cancelJob(jobID int64) (*metricsinfo.VectorUpdateJob, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	job, ok := u.jobs[jobID]
+	if !ok {
+		return nil, merr.WrapErrParameterInvalidMsg("vector update job %d not found", jobID)
+	}
+	if job.info.State != metricsinfo.VectorUpdateJobPending && job.info.State != metricsinfo.VectorUpdateJobRunning {
+		return nil, merr.WrapErrParameterInvalidMsg("vector update job %d is %s already", jobID, job.info.State)
+	}
+	job.cancel(errVectorUpdateCanceled)
+	return cloneVectorUpdateJob(job.info), nil
+}
+
+func cloneVectorUpdateJob(job *metricsinfo.VectorUpdateJob) *metricsinfo.VectorUpdateJob {
+	cloned := *job
+	cloned.PartitionIDs = append([]int64{}, job.PartitionIDs...)
+	cloned.Segments = make(map[int64]int64, len(job.Segments))
+	for from, to := range job.Segments {
+		cloned.Segments[from] = to
+	}
+	return &cloned
+}
+
+// get returns a copy of the job.
+func (u *vectorUpdater) get(jobID int64) (*metricsinfo.VectorUpdateJob, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	job, ok := u.jobs[jobID]
+	if !ok {
+		return nil, false
+	}
+	return cloneVectorUpdateJob(job.info), true
+}
+
+// list returns copies of all the jobs in the order of ID.
+func (u *vectorUpdater) list() []*metricsinfo.VectorUpdateJob {
+	u.evict()
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	jobs := make([]*metricsinfo.VectorUpdateJob, 0, len(u.jobs))
+	for _, job := range u.jobs {
+		jobs = append(jobs, cloneVectorUpdateJob(job.info))
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].JobID < jobs[j].JobID })
+	return jobs
+}
+
+// evict removes the finished jobs which ended before the retention.
+func (u *vectorUpdater) evict() {
+	retention := Params.DataCoordCfg.VectorUpdateJobRetention.GetAsDuration(time.Second)
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for id, job := range u.jobs {
+		finished := job.info.State == metricsinfo.VectorUpdateJobCompleted || job.info.State == metricsinfo.VectorUpdateJobFailed
+		if finished && time.Since(time.Unix(job.info.EndTime, 0)) > retention {
+			delete(u.jobs, id)
+		}
+	}
+}
+
+func (u *vectorUpdater) update(job *vectorUpdateJob, fn func(job *metricsinfo.VectorUpdateJob)) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	fn(job.info)
+}
+
+func (u *vectorUpdater) run(ctx context.Context, job *vectorUpdateJob) {
+	finish := func(err error) {
+		u.update(job, func(job *metricsinfo.VectorUpdateJob) {
+			job.EndTime = time.Now().Unix()
+			job.State = metricsinfo.VectorUpdateJobCompleted
+			if err != nil {
+				job.State = metricsinfo.VectorUpdateJobFailed
+				job.Reason = err.Error()
+			}
+		})
+		job.cancel(nil)
+	}
+
+	select {
+	case u.sem <- struct{}{}:
+		defer func() { <-u.sem }()
+	case <-ctx.Done():
+		finish(context.Cause(ctx))
+		return
+	}
+
+	log := log.Ctx(ctx).With(zap.Int64("jobID", job.info.JobID),
+		zap.Int64("collectionID", job.info.CollectionID),
+		zap.Int64("fieldID", job.info.FieldID))
+	log.Info("vector update job started")
+	u.update(job, func(job *metricsinfo.VectorUpdateJob) { job.State = metricsinfo.VectorUpdateJobRunning })
+
+	err := u.updateVectors(ctx, job)
+	if err != nil && errors.Is(context.Cause(ctx), errVectorUpdateCanceled) {
+		err = errors.Wrapf(errVectorUpdateCanceled, "%d segments replaced before canceled", len(job.info.Segments))
+	}
+	finish(err)
+	if err != nil {
+		log.Warn("vector update job failed", zap.Error(err))
+		return
+	}
+	log.Info("vector update job completed", zap.Int64("updatedRows", job.info.UpdatedRows),
+		zap.Int("segments", len(job.info.Segments)))
+}
+
+func (u *vectorUpdater) updateVectors(ctx context.Context, job *vectorUpdateJob) error {
+	req := job.info.VectorUpdateRequest
+	coll, err := u.handler.GetCollection(ctx, req.CollectionID)
+	if err != nil {
+		return err
+	}
+	if coll == nil {
+		return merr.WrapErrCollectionNotFound(req.CollectionID)
+	}
+	pkField, err := typeutil.GetPrimaryFieldSchema(coll.Schema)
+	if err != nil {
+		return err
+	}
+	field, ok := lo.Find(coll.Schema.GetFields(), func(field *schemapb.FieldSchema) bool {
+		return field.GetFieldID() == req.FieldID
+	})
+	if !ok {
+		return merr.WrapErrFieldNotFound(req.FieldID)
+	}
+	vectorSize, err := vectorUpdateRowSize(field)
+	if err != nil {
+		return err
+	}
+
+	cm, err := u.getProfileChunkManager(req.Profile)
+	if err != nil {
+		return err
+	}
+	dir := path.Join(cm.RootPath(), vectorUpdatePathPrefix, path.Clean(req.Path))
+	filePaths, _, err := cm.ListWithPrefix(ctx, dir+"/", true)
+	if err != nil {
+		return err
+	}
+	filePaths = lo.Filter(filePaths, func(filePath string, _ int) bool { return strings.HasSuffix(filePath, ".parquet") })
+	if len(filePaths) == 0 {
+		return merr.WrapErrParameterInvalidMsg("no parquet files found in %s", req.Path)
+	}
+	sort.Strings(filePaths)
+
+	// all the rows inserted before are in the segments sealed by the flush
+	startTs, err := u.allocator.allocTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	if err := u.flushCollection(ctx, req.CollectionID); err != nil {
+		return err
+	}
+
+	partitions := typeutil.NewSet(req.PartitionIDs...)
+	// done records the segments rewritten and the ones replacing them, which are never selected again
+	done := typeutil.NewUniqueSet()
+	selector := func(segment *SegmentInfo) bool {
+		return segment.GetCollectionID() == req.CollectionID &&
+			(partitions.Len() == 0 || partitions.Contain(segment.GetPartitionID())) &&
+			isSegmentHealthy(segment) &&
+			segment.GetState() == commonpb.SegmentState_Flushed &&
+			segment.GetLevel() != datapb.SegmentLevel_L0 &&
+			!done.Contain(segment.GetID()) &&
+			(segment.GetStartPosition() == nil || segment.GetStartPosition().GetTimestamp() <= startTs)
+	}
+	countFileRows := true
+	for {
+		segments := u.meta.SelectAndSetSegmentsCompacting(selector)
+		if len(segments) == 0 {
+			// wait for the segments being compacted, the segments compacted into are rewritten then
+			compacting := u.meta.SelectSegments(func(segment *SegmentInfo) bool {
+				return segment.isCompacting && selector(segment)
+			})
+			if len(compacting) == 0 {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			case <-time.After(vectorUpdateWaitInterval):
+			}
+			continue
+		}
+
+		err := u.updateSegments(ctx, job, filePaths, cm, pkField, field, startTs, segments, vectorSize, countFileRows, done)
+		if err != nil {
+			return err
+		}
+		countFileRows = false
+	}
+}
+
+// flushCollection seals the growing segments of the collection and waits until they're flushed.
+func (u *vectorUpdater) flushCollection(ctx context.Context, collectionID int64) error {
+	resp, err := u.flush(ctx, &datapb.FlushRequest{CollectionID: collectionID})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return err
+	}
+	pending := append(resp.GetSegmentIDs(), resp.GetFlushSegmentIDs()...)
+	for {
+		pending = lo.Filter(pending, func(segmentID int64, _ int) bool {
+			segment := u.meta.GetHealthySegment(segmentID)
+			return segment != nil && segment.GetState() != commonpb.SegmentState_Flushed
+		})
+		if len(pending) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(vectorUpdateWaitInterval):
+		}
+	}
+}
+
+// updateSegments rewrites the segments reserved in rounds within the memory budget,
+// the segments not replaced are released for compaction.
+func (u *vectorUpdater) updateSegments(ctx context.Context, job *vectorUpdateJob, filePaths []string, cm storage.ChunkManager,
+	pkField, field *schemapb.FieldSchema, startTs Timestamp, segments []*SegmentInfo, vectorSize int64, countFileRows bool,
+	done typeutil.UniqueSet,
+) error {
+	defer func() {
+		for _, segment := range segments {
+			if !done.Contain(segment.GetID()) {
+				u.meta.SetSegmentCompacting(segment.GetID(), false)
+			}
+		}
+	}()
+	sort.Slice(segments, func(i, j int) bool { return segments[i].GetID() < segments[j].GetID() })
+
+	budget := Params.DataCoordCfg.VectorUpdateMemoryBudget.GetAsInt64() * 1024 * 1024
+	for len(segments) > 0 {
+		size, n := int64(0), 0
+		for n < len(segments) && (n == 0 || size+segments[n].GetNumOfRows()*vectorSize <= budget) {
+			size += segments[n].GetNumOfRows() * vectorSize
+			n++
+		}
+		round := segments[:n]
+		segments = segments[n:]
+		if err := u.updateRound(ctx, job, filePaths, cm, pkField, field, startTs, round, countFileRows, done); err != nil {
+			return err
+		}
+		countFileRows = false
+	}
+	return nil
+}
+
+// updateRound scans the files once for the new vectors of the rows in the segments, and replaces the segments.
+func (u *vectorUpdater) updateRound(ctx context.Context, job *vectorUpdateJob, filePaths []string, cm storage.ChunkManager,
+	pkField, field *schemapb.FieldSchema, startTs Timestamp, segments []*SegmentInfo, countFileRows bool,
+	done typeutil.UniqueSet,
+) error {
+	type rowLocation struct {
+		segment int
+		offset  int
+	}
+	// the rows of the same primary key may be in several segments, e.g. the ones deleted and reinserted
+	locations := make(map[any][]rowLocation)
+	patches := make([]*vectorPatch, len(segments))
+	for i, segment := range segments {
+		pks, err := u.readFieldBinlogs(ctx, segment, pkField.GetFieldID())
+		if err != nil {
+			return err
+		}
+		tss, err := u.readFieldBinlogs(ctx, segment, common.TimeStampField)
+		if err != nil {
+			return err
+		}
+		if pks.RowNum() != tss.RowNum() {
+			return merr.WrapErrServiceInternal(fmt.Sprintf("rows of primary keys and timestamps mismatch in segment %d", segment.GetID()))
+		}
+		for offset := 0; offset < pks.RowNum(); offset++ {
+			if Timestamp(tss.GetRow(offset).(int64)) <= startTs {
+				locations[pks.GetRow(offset)] = append(locations[pks.GetRow(offset)], rowLocation{segment: i, offset: offset})
+			}
+		}
+		data, err := storage.NewFieldData(field.GetDataType(), field)
+		if err != nil {
+			return err
+		}
+		patches[i] = &vectorPatch{data: data, rows: make(map[int]int)}
+	}
+
+	fileRows := int64(0)
+	for _, filePath := range filePaths {
+		data, err := cm.Read(ctx, filePath)
+		if err != nil {
+			return err
+		}
+		err = readVectorUpdateFile(ctx, data, pkField, field, func(pk any, vector any) error {
+			fileRows++
+			for _, location := range locations[pk] {
+				patch := patches[location.segment]
+				// the latest one wins if the primary key is duplicated in the files
+				patch.rows[location.offset] = patch.data.RowNum()
+				if err := patch.data.AppendRow(vector); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to read vectors of %s", filePath)
+		}
+	}
+	if countFileRows {
+		u.update(job, func(job *metricsinfo.VectorUpdateJob) { job.FileRows = fileRows })
+	}
+
+	for i, segment := range segments {
+		if len(patches[i].rows) == 0 {
+			done.Insert(segment.GetID())
+			u.meta.SetSegmentCompacting(segment.GetID(), false)
+			continue
+		}
+		replaced, err := u.replaceSegment(ctx, segment, field, patches[i])
+		if err != nil {
+			return errors.Wrapf(err, "failed to rewrite segment %d", segment.GetID())
+		}
+		done.Insert(segment.GetID(), replaced.GetID())
+		u.update(job, func(job *metricsinfo.VectorUpdateJob) {
+			job.Segments[segment.GetID()] = replaced.GetID()
+			job.UpdatedRows += int64(len(patches[i].rows))
+		})
+		// release the new vectors once the segment is rewritten
+		patches[i] = nil
+	}
+	return nil
+}
+
+// readFieldBinlogs reads the values of the field in the segment, in the order of rows.
+func (u *vectorUpdater) readFieldBinlogs(ctx context.Context, segment *SegmentInfo, fieldID int64) (storage.FieldData, error) {
+	blobs := make([]*storage.Blob, 0)
+	for _, fieldBinlog := range segment.GetBinlogs() {
+		if fieldBinlog.GetFieldID() != fieldID {
+			continue
+		}
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			data, err := u.meta.chunkManager.Read(ctx, binlog.GetLogPath())
+			if err != nil {
+				return nil, err
+			}
+			blobs = append(blobs, &storage.Blob{Key: binlog.GetLogPath(), Value: data})
+		}
+	}
+	insertData := &storage.InsertData{Data: make(map[storage.FieldID]storage.FieldData)}
+	if _, _, _, err := storage.NewInsertCodec().DeserializeInto(blobs, 0, insertData); err != nil {
+		return nil, err
+	}
+	fieldData, ok := insertData.Data[fieldID]
+	if !ok {
+		return nil, merr.WrapErrServiceInternal(fmt.Sprintf("binlogs of field %d not found in segment %d", fieldID, segment.GetID()))
+	}
+	return fieldData, nil
+}
+
+// replaceSegment writes the new segment with the vector binlogs rewritten by the patch and the other logs copied,
+// and replaces the segment by it, then the index of the new segment is built once it's flushed.
+func (u *vectorUpdater) replaceSegment(ctx context.Context, segment *SegmentInfo, field *schemapb.FieldSchema, patch *vectorPatch) (*SegmentInfo, error) {
+	cm := u.meta.chunkManager
+	rootPath := cm.RootPath()
+	collectionID, partitionID := segment.GetCollectionID(), segment.GetPartitionID()
+	newSegmentID, err := u.allocator.allocID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	binlogs := make([]*datapb.FieldBinlog, 0, len(segment.GetBinlogs()))
+	offset := 0
+	for _, fieldBinlog := range segment.GetBinlogs() {
+		fieldID := fieldBinlog.GetFieldID()
+		if fieldID != field.GetFieldID() {
+			copied, err := u.copyLogs(ctx, fieldBinlog, func(logID int64) string {
+				return metautil.BuildInsertLogPath(rootPath, collectionID, partitionID, newSegmentID, fieldID, logID)
+			})
+			if err != nil {
+				return nil, err
+			}
+			binlogs = append(binlogs, copied)
+			continue
+		}
+
+		rewritten := &datapb.FieldBinlog{FieldID: fieldID, Binlogs: make([]*datapb.Binlog, 0, len(fieldBinlog.GetBinlogs()))}
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			content, err := cm.Read(ctx, binlog.GetLogPath())
+			if err != nil {
+				return nil, err
+			}
+			reader, err := storage.NewBinlogReader(content)
+			if err != nil {
+				return nil, err
+			}
+			startTs, endTs := reader.StartTimestamp, reader.EndTimestamp
+			reader.Close()
+			insertData := &storage.InsertData{Data: make(map[storage.FieldID]storage.FieldData)}
+			if _, _, _, err := storage.NewInsertCodec().DeserializeInto([]*storage.Blob{{Key: binlog.GetLogPath(), Value: content}}, 0, insertData); err != nil {
+				return nil, err
+			}
+			old, ok := insertData.Data[fieldID]
+			if !ok {
+				return nil, merr.WrapErrServiceInternal("invalid vector binlog", binlog.GetLogPath())
+			}
+
+			vectors, err := storage.NewFieldData(field.GetDataType(), field)
+			if err != nil {
+				return nil, err
+			}
+			for i := 0; i < old.RowNum(); i++ {
+				row := old.GetRow(i)
+				if j, ok := patch.rows[offset+i]; ok {
+					row = patch.data.GetRow(j)
+				}
+				if err := vectors.AppendRow(row); err != nil {
+					return nil, err
+				}
+			}
+			offset += old.RowNum()
+
+			logID, err := u.allocator.allocID(ctx)
+			if err != nil {
+				return nil, err
+			}
+			content, err = storage.SerializeVectorBinlog(collectionID, partitionID, newSegmentID, field, vectors, startTs, endTs)
+			if err != nil {
+				return nil, err
+			}
+			logPath := metautil.BuildInsertLogPath(rootPath, collectionID, partitionID, newSegmentID, fieldID, logID)
+			if err := cm.Write(ctx, logPath, content); err != nil {
+				return nil, err
+			}
+			newBinlog := proto.Clone(binlog).(*datapb.Binlog)
+			newBinlog.LogID = logID
+			newBinlog.LogPath = logPath
+			newBinlog.LogSize = int64(len(content))
+			newBinlog.Checksum = storage.BinlogChecksum(content)
+			rewritten.Binlogs = append(rewritten.Binlogs, newBinlog)
+		}
+		binlogs = append(binlogs, rewritten)
+	}
+
+	statslogs := make([]*datapb.FieldBinlog, 0, len(segment.GetStatslogs()))
+	for _, fieldBinlog := range segment.GetStatslogs() {
+		fieldID := fieldBinlog.GetFieldID()
+		copied, err := u.copyLogs(ctx, fieldBinlog, func(logID int64) string {
+			return metautil.BuildStatsLogPath(rootPath, collectionID, partitionID, newSegmentID, fieldID, logID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		statslogs = append(statslogs, copied)
+	}
+	deltalogs := segment.GetDeltalogs()
+	copiedDeltalogs, err := u.meta.copyDeltaFiles(deltalogs, collectionID, partitionID, newSegmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced, err := u.meta.ReplaceSegment(segment.GetID(), &datapb.SegmentInfo{
+		ID:             newSegmentID,
+		CollectionID:   collectionID,
+		PartitionID:    partitionID,
+		InsertChannel:  segment.GetInsertChannel(),
+		NumOfRows:      segment.GetNumOfRows(),
+		MaxRowNum:      segment.GetMaxRowNum(),
+		Binlogs:        binlogs,
+		Statslogs:      statslogs,
+		Deltalogs:      copiedDeltalogs,
+		StartPosition:  segment.GetStartPosition(),
+		DmlPosition:    segment.GetDmlPosition(),
+		LastExpireTime: segment.GetLastExpireTime(),
+		Level:          segment.GetLevel(),
+	}, deltalogs)
+	if err != nil {
+		return nil, err
+	}
+
+	// the index of the new segment is built once it's flushed
+	u.flushCh <- replaced.GetID()
+	nodeID, err := u.findWatcher(replaced.GetInsertChannel())
+	if err == nil {
+		err = u.sessions.SyncSegments(nodeID, &datapb.SyncSegmentsRequest{
+			CompactedTo:   replaced.GetID(),
+			CompactedFrom: replaced.GetCompactionFrom(),
+			NumOfRows:     replaced.GetNumOfRows(),
+			StatsLogs:     replaced.GetStatslogs(),
+			ChannelName:   replaced.GetInsertChannel(),
+			PartitionId:   partitionID,
+			CollectionId:  collectionID,
+		})
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "segment replaced by %d but failed to sync with datanode", replaced.GetID())
+	}
+	return replaced, nil
+}
+
+// copyLogs copies the logs into the paths of the new segment, the contents are unchanged.
+func (u *vectorUpdater) copyLogs(ctx context.Context, fieldBinlog *datapb.FieldBinlog, buildPath func(logID int64) string) (*datapb.FieldBinlog, error) {
+	copied := proto.Clone(fieldBinlog).(*datapb.FieldBinlog)
+	for _, binlog := range copied.GetBinlogs() {
+		content, err := u.meta.chunkManager.Read(ctx, binlog.GetLogPath())
+		if err != nil {
+			return nil, err
+		}
+		logPath := buildPath(binlog.GetLogID())
+		if err := u.meta.chunkManager.Write(ctx, logPath, content); err != nil {
+			return nil, err
+		}
+		binlog.LogPath = logPath
+	}
+	return copied, nil
+}
+
+// getProfileChunkManager returns the chunk manager of the bucket profile, empty profile means the default bucket.
+func (u *vectorUpdater) getProfileChunkManager(profile string) (storage.ChunkManager, error) {
+	if profile == "" {
+		return u.meta.chunkManager, nil
+	}
+	if rcm, ok := u.meta.chunkManager.(*storage.RoutingChunkManager); ok {
+		if cm, ok := rcm.Profile(profile); ok {
+			return cm, nil
+		}
+	}
+	return nil, merr.WrapErrParameterInvalidMsg("bucket profile %s not found", profile)
+}
+
+// vectorUpdateRowSize returns the size of a vector of the field.
+func vectorUpdateRowSize(field *schemapb.FieldSchema) (int64, error) {
+	dim, err := typeutil.GetDim(field)
+	if err != nil {
+		return 0, err
+	}
+	switch field.GetDataType() {
+	case schemapb.DataType_FloatVector:
+		return dim * 4, nil
+	case schemapb.DataType_BinaryVector:
+		return dim / 8, nil
+	case schemapb.DataType_Float16Vector:
+		return dim * 2, nil
+	default:
+		return 0, merr.WrapErrParameterInvalidMsg("field %s of type %s is not a vector field", field.GetName(), field.GetDataType().String())
+	}
+}
+
+// readVectorUpdateFile reads the primary keys and the new vectors of the parquet file row by row,
+// the vectors are []float32 or []byte and only valid during the call.
+func readVectorUpdateFile(ctx context.Context, data []byte, pkField, field *schemapb.FieldSchema, fn func(pk any, vector any) error) error {
+	reader, err := file.NewParquetReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{BatchSize: vectorUpdateReadBatchSize}, memory.DefaultAllocator)
+	if err != nil {
+		return err
+	}
+	recordReader, err := fileReader.GetRecordReader(ctx, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer recordReader.Release()
+
+	for recordReader.Next() {
+		if err := decodeVectorUpdateRecord(recordReader.Record(), pkField, field, fn); err != nil {
+			return err
+		}
+	}
+	if err := recordReader.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+func decodeVectorUpdateRecord(record arrow.Record, pkField, field *schemapb.FieldSchema, fn func(pk any, vector any) error) error {
+	column := func(name string) (arrow.Array, error) {
+		indices := record.Schema().FieldIndices(name)
+		if len(indices) == 0 {
+			return nil, merr.WrapErrParameterInvalidMsg("column %s not found in the file", name)
+		}
+		return record.Column(indices[0]), nil
+	}
+	pkColumn, err := column(pkField.GetName())
+	if err != nil {
+		return err
+	}
+	vectorColumn, err := column(field.GetName())
+	if err != nil {
+		return err
+	}
+	var pkAt func(i int) any
+	switch pkColumn := pkColumn.(type) {
+	case *array.Int64:
+		pkAt = func(i int) any { return pkColumn.Value(i) }
+	case *array.String:
+		pkAt = func(i int) any { return pkColumn.Value(i) }
+	default:
+		return merr.WrapErrParameterInvalidMsg("invalid type %s of primary key column %s", pkColumn.DataType(), pkField.GetName())
+	}
+
+	dim, err := typeutil.GetDim(field)
+	if err != nil {
+		return err
+	}
+	width, err := vectorUpdateRowSize(field)
+	if err != nil {
+		return err
+	}
+	invalid := merr.WrapErrParameterInvalidMsg("invalid type %s of vector column %s, should be %s",
+		vectorColumn.DataType(), field.GetName(), field.GetDataType().String())
+	for i := 0; i < int(record.NumRows()); i++ {
+		var vector any
+		if field.GetDataType() == schemapb.DataType_FloatVector {
+			var values []float32
+			switch vectorColumn := vectorColumn.(type) {
+			case *array.FixedSizeList:
+				floats, ok := vectorColumn.ListValues().(*array.Float32)
+				if !ok {
+					return invalid
+				}
+				start := (vectorColumn.Data().Offset() + i) * int(dim)
+				if start+int(dim) > floats.Len() {
+					return merr.WrapErrParameterInvalidMsg("dim of vector column %s mismatch", field.GetName())
+				}
+				values = floats.Float32Values()[start : start+int(dim)]
+			case *array.List:
+				floats, ok := vectorColumn.ListValues().(*array.Float32)
+				if !ok {
+					return invalid
+				}
+				start, end := vectorColumn.ValueOffsets(i)
+				values = floats.Float32Values()[start:end]
+			default:
+				return invalid
+			}
+			if len(values) != int(dim) {
+				return merr.WrapErrParameterInvalidMsg("dim of vector column %s mismatch, expected %d, got %d", field.GetName(), dim, len(values))
+			}
+			vector = values
+		} else {
+			var value []byte
+			switch vectorColumn := vectorColumn.(type) {
+			case *array.FixedSizeBinary:
+				value = vectorColumn.Value(i)
+			case *array.Binary:
+				value = vectorColumn.Value(i)
+			default:
+				return invalid
+			}
+			if len(value) != int(width) {
+				return merr.WrapErrParameterInvalidMsg("dim of vector column %s mismatch, expected %d bytes, got %d", field.GetName(), width, len(value))
+			}
+			vector = value
+		}
+		if err := fn(pkAt(i), vector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vectorUpdateParams are the params of the vector update requests.
+type vectorUpdateParams struct {
+	JobID int64 `json:"job_id"`
+}
+
+// getVectorUpdateMetrics submits, cancels or lists the vector update jobs,
+// the job of job_id or all the jobs if it's absent are returned.
+func (s *Server) getVectorUpdateMetrics(ctx context.Context, metricType string, req *milvuspb.GetMetricsRequest) *milvuspb.GetMetricsResponse {
+	resp := &milvuspb.GetMetricsResponse{
+		Status:        merr.Success(),
+		ComponentName: metricsinfo.ConstructComponentName(typeutil.DataCoordRole, paramtable.GetNodeID()),
+	}
+	params := &vectorUpdateParams{}
+	if err := json.Unmarshal([]byte(req.GetRequest()), params); err != nil {
+		resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("invalid request: %s", err.Error()))
+		return resp
+	}
+
+	infos := &metricsinfo.VectorUpdateJobInfos{Jobs: make([]*metricsinfo.VectorUpdateJob, 0)}
+	switch metricType {
+	case metricsinfo.SubmitVectorUpdateMetrics:
+		request := &metricsinfo.VectorUpdateRequest{}
+		if err := json.Unmarshal([]byte(req.GetRequest()), request); err != nil {
+			resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("invalid request: %s", err.Error()))
+			return resp
+		}
+		job, err := s.vectorUpdater.submit(ctx, request)
+		if err != nil {
+			resp.Status = merr.Status(err)
+			return resp
+		}
+		infos.Jobs = append(infos.Jobs, job)
+	case metricsinfo.CancelVectorUpdateMetrics:
+		job, err := s.vectorUpdater.cancelJob(params.JobID)
+		if err != nil {
+			resp.Status = merr.Status(err)
+			return resp
+		}
+		infos.Jobs = append(infos.Jobs, job)
+	default:
+		if params.JobID == 0 {
+			infos.Jobs = s.vectorUpdater.list()
+		} else if job, ok := s.vectorUpdater.get(params.JobID); ok {
+			infos.Jobs = append(infos.Jobs, job)
+		}
+	}
+
+	var err error
+	resp.Response, err = metricsinfo.MarshalComponentInfos(infos)
+	if err != nil {
+		resp.Status = merr.Status(err)
+	}
+	return resp
+}
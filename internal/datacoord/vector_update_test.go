@@ -0,0 +1,274 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	catalogmocks "github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type VectorUpdaterSuite struct {
+	suite.Suite
+
+	ctx      context.Context
+	cm       storage.ChunkManager
+	catalog  *catalogmocks.DataCoordCatalog
+	sessions *MockSessionManager
+	meta     *meta
+	schema   *schemapb.CollectionSchema
+	updater  *vectorUpdater
+	flushCh  chan UniqueID
+}
+
+func (s *VectorUpdaterSuite) SetupSuite() {
+	paramtable.Init()
+	vectorUpdateWaitInterval = 10 * time.Millisecond
+}
+
+func (s *VectorUpdaterSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.cm = storage.NewLocalChunkManager(storage.RootPath(s.T().TempDir()))
+	s.catalog = catalogmocks.NewDataCoordCatalog(s.T())
+	s.sessions = NewMockSessionManager(s.T())
+	s.schema = &schemapb.CollectionSchema{
+		Name: "coll",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.RowIDField, Name: common.RowIDFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: common.TimeStampField, Name: common.TimeStampFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{
+				FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+			},
+		},
+	}
+
+	s.meta = &meta{
+		RWMutex:      sync.RWMutex{},
+		ctx:          s.ctx,
+		catalog:      s.catalog,
+		chunkManager: s.cm,
+		segments: &SegmentsInfo{segments: map[UniqueID]*SegmentInfo{
+			1: s.newSegment(1, []int64{1, 2, 3}, 10),
+			2: s.newSegment(2, []int64{4, 5}, 10),
+		}},
+	}
+
+	handler := NewNMockHandler(s.T())
+	handler.EXPECT().GetCollection(mock.Anything, int64(100)).Return(&collectionInfo{ID: 100, Schema: s.schema}, nil).Maybe()
+	alloc := NewNMockAllocator(s.T())
+	var id int64 = 1000
+	alloc.EXPECT().allocID(mock.Anything).RunAndReturn(func(ctx context.Context) (int64, error) {
+		id++
+		return id, nil
+	}).Maybe()
+	alloc.EXPECT().allocTimestamp(mock.Anything).Return(100, nil).Maybe()
+	flush := func(ctx context.Context, req *datapb.FlushRequest) (*datapb.FlushResponse, error) {
+		return &datapb.FlushResponse{Status: merr.Success()}, nil
+	}
+	findWatcher := func(channel string) (int64, error) { return 1, nil }
+	s.flushCh = make(chan UniqueID, 10)
+	s.updater = newVectorUpdater(s.ctx, s.meta, handler, alloc, s.sessions, findWatcher, flush, s.flushCh)
+}
+
+func (s *VectorUpdaterSuite) TearDownTest() {
+	s.updater.close()
+}
+
+// newSegment writes the binlogs of the rows of pks, whose vectors are [pk, pk].
+func (s *VectorUpdaterSuite) newSegment(segmentID int64, pks []int64, ts int64) *SegmentInfo {
+	insertData := &storage.InsertData{Data: map[storage.FieldID]storage.FieldData{
+		common.RowIDField:     &storage.Int64FieldData{},
+		common.TimeStampField: &storage.Int64FieldData{},
+		100:                   &storage.Int64FieldData{},
+		101:                   &storage.FloatVectorFieldData{Dim: 2},
+	}}
+	for _, pk := range pks {
+		insertData.Data[common.RowIDField].(*storage.Int64FieldData).Data = append(insertData.Data[common.RowIDField].(*storage.Int64FieldData).Data, pk)
+		insertData.Data[common.TimeStampField].(*storage.Int64FieldData).Data = append(insertData.Data[common.TimeStampField].(*storage.Int64FieldData).Data, ts)
+		insertData.Data[100].(*storage.Int64FieldData).Data = append(insertData.Data[100].(*storage.Int64FieldData).Data, pk)
+		insertData.Data[101].(*storage.FloatVectorFieldData).Data = append(insertData.Data[101].(*storage.FloatVectorFieldData).Data, float32(pk), float32(pk))
+	}
+	codec := storage.NewInsertCodecWithSchema(&etcdpb.CollectionMeta{ID: 100, Schema: s.schema})
+	blobs, err := codec.Serialize(200, segmentID, insertData)
+	s.Require().NoError(err)
+
+	binlogs := make([]*datapb.FieldBinlog, 0, len(blobs))
+	for _, blob := range blobs {
+		fieldID, err := strconv.ParseInt(blob.GetKey(), 10, 64)
+		s.Require().NoError(err)
+		logPath := metautil.BuildInsertLogPath(s.cm.RootPath(), 100, 200, segmentID, fieldID, 1)
+		s.Require().NoError(s.cm.Write(s.ctx, logPath, blob.GetValue()))
+		binlogs = append(binlogs, &datapb.FieldBinlog{
+			FieldID: fieldID,
+			Binlogs: []*datapb.Binlog{{LogID: 1, LogPath: logPath, LogSize: int64(len(blob.GetValue())), EntriesNum: int64(len(pks))}},
+		})
+	}
+	return NewSegmentInfo(&datapb.SegmentInfo{
+		ID:            segmentID,
+		CollectionID:  100,
+		PartitionID:   200,
+		InsertChannel: "ch",
+		State:         commonpb.SegmentState_Flushed,
+		NumOfRows:     int64(len(pks)),
+		Binlogs:       binlogs,
+	})
+}
+
+// writeFile writes the new vectors [pk+100, pk+100] of pks.
+func (s *VectorUpdaterSuite) writeFile(pks []int64) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "pk", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "vec", Type: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Float32)},
+	}, nil)
+	pkBuilder := array.NewInt64Builder(memory.DefaultAllocator)
+	defer pkBuilder.Release()
+	vecBuilder := array.NewFixedSizeListBuilder(memory.DefaultAllocator, 2, arrow.PrimitiveTypes.Float32)
+	defer vecBuilder.Release()
+	for _, pk := range pks {
+		pkBuilder.Append(pk)
+		vecBuilder.Append(true)
+		vecBuilder.ValueBuilder().(*array.Float32Builder).AppendValues([]float32{float32(pk + 100), float32(pk + 100)}, nil)
+	}
+	record := array.NewRecord(schema, []arrow.Array{pkBuilder.NewArray(), vecBuilder.NewArray()}, int64(len(pks)))
+	defer record.Release()
+
+	buf := new(bytes.Buffer)
+	writer, err := pqarrow.NewFileWriter(schema, buf, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+	s.Require().NoError(err)
+	s.Require().NoError(writer.Write(record))
+	s.Require().NoError(writer.Close())
+	s.Require().NoError(s.cm.Write(s.ctx, path.Join(s.cm.RootPath(), vectorUpdatePathPrefix, "reembed", "0.parquet"), buf.Bytes()))
+}
+
+func (s *VectorUpdaterSuite) readVectors(segment *SegmentInfo) []float32 {
+	data, err := s.updater.readFieldBinlogs(s.ctx, segment, 101)
+	s.Require().NoError(err)
+	return data.(*storage.FloatVectorFieldData).Data
+}
+
+func (s *VectorUpdaterSuite) wait(jobID int64) *metricsinfo.VectorUpdateJob {
+	var job *metricsinfo.VectorUpdateJob
+	s.Eventually(func() bool {
+		job, _ = s.updater.get(jobID)
+		return job.State == metricsinfo.VectorUpdateJobCompleted || job.State == metricsinfo.VectorUpdateJobFailed
+	}, 10*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func (s *VectorUpdaterSuite) TestSubmitInvalid() {
+	_, err := s.updater.submit(s.ctx, &metricsinfo.VectorUpdateRequest{CollectionID: 100, Path: "reembed"})
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+	_, err = s.updater.submit(s.ctx, &metricsinfo.VectorUpdateRequest{CollectionID: 100, FieldID: 101, Path: "../reembed"})
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+	_, err = s.updater.cancelJob(1)
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+}
+
+func (s *VectorUpdaterSuite) TestUpdateVectors() {
+	// pk 9 doesn't exist, segment 2 isn't updated
+	s.writeFile([]int64{2, 3, 9})
+	s.catalog.EXPECT().AlterSegments(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.sessions.EXPECT().SyncSegments(int64(1), mock.Anything).RunAndReturn(func(nodeID int64, req *datapb.SyncSegmentsRequest) error {
+		s.Equal([]int64{1}, req.GetCompactedFrom())
+		s.EqualValues(3, req.GetNumOfRows())
+		return nil
+	}).Once()
+
+	job, err := s.updater.submit(s.ctx, &metricsinfo.VectorUpdateRequest{CollectionID: 100, FieldID: 101, Path: "reembed", Owner: "alice"})
+	s.Require().NoError(err)
+	job = s.wait(job.JobID)
+	s.Equal(metricsinfo.VectorUpdateJobCompleted, job.State, job.Reason)
+	s.EqualValues(3, job.FileRows)
+	s.EqualValues(2, job.UpdatedRows)
+	s.Len(job.Segments, 1)
+
+	newSegmentID := job.Segments[1]
+	s.Equal(newSegmentID, <-s.flushCh)
+	old := s.meta.GetSegment(1)
+	s.Equal(commonpb.SegmentState_Dropped, old.GetState())
+	s.False(s.meta.GetSegment(2).isCompacting)
+	replaced := s.meta.GetHealthySegment(newSegmentID)
+	s.Require().NotNil(replaced)
+	s.Equal(commonpb.SegmentState_Flushing, replaced.GetState())
+	s.Equal([]float32{1, 1, 102, 102, 103, 103}, s.readVectors(replaced))
+	s.Equal([]float32{1, 1, 2, 2, 3, 3}, s.readVectors(old))
+	// the binlogs of the other fields are copied into the new segment
+	for _, fieldBinlog := range replaced.GetBinlogs() {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			s.Contains(binlog.GetLogPath(), strconv.FormatInt(newSegmentID, 10))
+		}
+	}
+
+	s.Len(s.updater.list(), 1)
+	_, err = s.updater.cancelJob(job.JobID)
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+	// finished jobs are evicted after the retention
+	paramtable.Get().Save(Params.DataCoordCfg.VectorUpdateJobRetention.Key, "-1")
+	defer paramtable.Get().Reset(Params.DataCoordCfg.VectorUpdateJobRetention.Key)
+	s.Len(s.updater.list(), 0)
+}
+
+func (s *VectorUpdaterSuite) TestSkipNewRows() {
+	// the rows inserted after the job starts are kept
+	s.meta.segments.SetSegment(1, s.newSegment(1, []int64{1, 2, 3}, 200))
+	s.writeFile([]int64{2, 3})
+
+	job, err := s.updater.submit(s.ctx, &metricsinfo.VectorUpdateRequest{CollectionID: 100, FieldID: 101, Path: "reembed"})
+	s.Require().NoError(err)
+	job = s.wait(job.JobID)
+	s.Equal(metricsinfo.VectorUpdateJobCompleted, job.State, job.Reason)
+	s.EqualValues(0, job.UpdatedRows)
+	s.Empty(job.Segments)
+	s.Equal(commonpb.SegmentState_Flushed, s.meta.GetSegment(1).GetState())
+}
+
+func (s *VectorUpdaterSuite) TestNoFiles() {
+	job, err := s.updater.submit(s.ctx, &metricsinfo.VectorUpdateRequest{CollectionID: 100, FieldID: 101, Path: "reembed"})
+	s.Require().NoError(err)
+	job = s.wait(job.JobID)
+	s.Equal(metricsinfo.VectorUpdateJobFailed, job.State)
+}
+
+func TestVectorUpdater(t *testing.T) {
+	suite.Run(t, new(VectorUpdaterSuite))
+}
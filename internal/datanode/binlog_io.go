@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/fieldstats"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/conc"
@@ -59,6 +60,8 @@ type uploader interface {
 	uploadInsertLog(ctx context.Context, segID, partID UniqueID, iData *InsertData, meta *etcdpb.CollectionMeta) (map[UniqueID]*datapb.FieldBinlog, error)
 	uploadStatsLog(ctx context.Context, segID, partID UniqueID, iData *InsertData, stats *storage.PrimaryKeyStats, totRows int64, meta *etcdpb.CollectionMeta) (map[UniqueID]*datapb.FieldBinlog, map[UniqueID]*datapb.FieldBinlog, error)
 	uploadDeltaLog(ctx context.Context, segID, partID UniqueID, dData *DeleteData, meta *etcdpb.CollectionMeta) ([]*datapb.FieldBinlog, error)
+	// uploadFieldStatsLog saves the statistics of each scalar field as the field stats log in the statslogs of the field.
+	uploadFieldStatsLog(ctx context.Context, segID, partID UniqueID, stats *fieldstats.SegmentStats, meta *etcdpb.CollectionMeta) (map[UniqueID]*datapb.FieldBinlog, error)
 }
 
 type binlogIO struct {
@@ -201,7 +204,7 @@ func (b *binlogIO) genInsertBlobs(data *InsertData, partID, segID UniqueID, iCod
 		kvs[key] = value
 		inpaths[fID] = &datapb.FieldBinlog{
 			FieldID: fID,
-			Binlogs: []*datapb.Binlog{{LogSize: int64(fileLen), LogPath: key, EntriesNum: blob.RowNum}},
+			Binlogs: []*datapb.Binlog{{LogSize: int64(fileLen), LogPath: key, EntriesNum: blob.RowNum, Checksum: storage.BinlogChecksum(value)}},
 		}
 	}
 
@@ -232,7 +235,7 @@ func (b *binlogIO) genStatBlobs(stats *storage.PrimaryKeyStats, partID, segID Un
 
 	statPaths[fID] = &datapb.FieldBinlog{
 		FieldID: fID,
-		Binlogs: []*datapb.Binlog{{LogSize: int64(fileLen), LogPath: key, EntriesNum: totRows}},
+		Binlogs: []*datapb.Binlog{{LogSize: int64(fileLen), LogPath: key, EntriesNum: totRows, Checksum: storage.BinlogChecksum(value)}},
 	}
 	return statPaths, nil
 }
@@ -309,6 +312,37 @@ func (b *binlogIO) uploadInsertLog(
 	return inpaths, nil
 }
 
+func (b *binlogIO) uploadFieldStatsLog(
+	ctx context.Context,
+	segID UniqueID,
+	partID UniqueID,
+	stats *fieldstats.SegmentStats,
+	meta *etcdpb.CollectionMeta,
+) (map[UniqueID]*datapb.FieldBinlog, error) {
+	kvs := make(map[string][]byte, len(stats.Fields))
+	paths := make(map[UniqueID]*datapb.FieldBinlog, len(stats.Fields))
+	for _, fieldStats := range stats.Fields {
+		value, err := fieldStats.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		k := metautil.JoinIDPath(meta.GetID(), partID, segID, fieldStats.FieldID, int64(storage.FieldStatsType))
+		key := path.Join(b.ChunkManager.RootPath(), common.SegmentStatslogPath, k)
+		kvs[key] = value
+		paths[fieldStats.FieldID] = &datapb.FieldBinlog{
+			FieldID: fieldStats.FieldID,
+			Binlogs: []*datapb.Binlog{{LogSize: int64(len(value)), LogPath: key, EntriesNum: stats.RowCount, Checksum: storage.BinlogChecksum(value)}},
+		}
+	}
+
+	err := b.uploadSegmentFiles(ctx, meta.GetID(), segID, kvs)
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
 func (b *binlogIO) uploadDeltaLog(
 	ctx context.Context,
 	segID UniqueID,
@@ -338,6 +372,7 @@ func (b *binlogIO) uploadDeltaLog(
 				EntriesNum: dData.RowCount,
 				LogPath:    k,
 				LogSize:    int64(len(v)),
+				Checksum:   storage.BinlogChecksum(v),
 			}},
 		})
 	} else {
@@ -32,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/fieldstats"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
@@ -356,6 +357,7 @@ func (t *compactionTask) merge(
 	if err != nil {
 		return nil, nil, 0, err
 	}
+	fieldStats := fieldstats.NewSegmentCollector(meta.GetSchema())
 	// initial timestampFrom, timestampTo = -1, -1 is an illegal value, only to mark initial state
 	var (
 		timestampTo   int64 = -1
@@ -418,6 +420,7 @@ func (t *compactionTask) merge(
 			}
 			// update pk to new stats log
 			stats.Update(v.PK)
+			fieldStats.AddRow(row)
 
 			currentRows++
 			if currentRows >= maxRowsPerBinlog {
@@ -452,6 +455,12 @@ func (t *compactionTask) merge(
 		uploadInsertTimeCost += time.Since(uploadStart)
 		addInsertFieldPath(inPaths, timestampFrom, timestampTo)
 		addStatFieldPath(statsPaths)
+
+		fieldStatsPaths, err := t.uploadFieldStatsLog(ctxTimeout, targetSegID, partID, fieldStats.Build(targetSegID), meta)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		addStatFieldPath(fieldStatsPaths)
 		numRows += int64(currentRows)
 		numBinlogs += len(inPaths)
 	}
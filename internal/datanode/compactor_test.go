@@ -325,7 +325,8 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, int64(2), numOfRow)
 			assert.Equal(t, 1, len(inPaths[0].GetBinlogs()))
-			assert.Equal(t, 1, len(statsPaths))
+			// the field stats logs are saved in the statslogs of the 8 scalar fields
+			assert.Equal(t, 8, len(statsPaths))
 			assert.NotEqual(t, -1, inPaths[0].GetBinlogs()[0].GetTimestampFrom())
 			assert.NotEqual(t, -1, inPaths[0].GetBinlogs()[0].GetTimestampTo())
 		})
@@ -372,8 +373,9 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, int64(2), numOfRow)
 			assert.Equal(t, 2, len(inPaths[0].GetBinlogs()))
-			assert.Equal(t, 1, len(statsPaths))
-			assert.Equal(t, 1, len(statsPaths[0].GetBinlogs()))
+			assert.Equal(t, 8, len(statsPaths))
+			// pk stats log and the field stats logs
+			assert.Equal(t, 9, lo.SumBy(statsPaths, func(fieldBinlog *datapb.FieldBinlog) int { return len(fieldBinlog.GetBinlogs()) }))
 			assert.NotEqual(t, -1, inPaths[0].GetBinlogs()[0].GetTimestampFrom())
 			assert.NotEqual(t, -1, inPaths[0].GetBinlogs()[0].GetTimestampTo())
 		})
@@ -422,7 +424,7 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, int64(2), numOfRow)
 			assert.Equal(t, 2, len(inPaths[0].GetBinlogs()))
-			assert.Equal(t, 1, len(statsPaths))
+			assert.Equal(t, 8, len(statsPaths))
 			for _, inpath := range inPaths {
 				assert.NotEqual(t, -1, inpath.GetBinlogs()[0].GetTimestampFrom())
 				assert.NotEqual(t, -1, inpath.GetBinlogs()[0].GetTimestampTo())
@@ -31,6 +31,7 @@ import (
 	"github.com/milvus-io/milvus/internal/datanode/metacache"
 	"github.com/milvus-io/milvus/internal/datanode/syncmgr"
 	"github.com/milvus-io/milvus/internal/datanode/writebuffer"
+	"github.com/milvus-io/milvus/internal/http/healthz"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/storage"
@@ -278,6 +279,9 @@ func loadStats(ctx context.Context, chunkManager storage.ChunkManager, schema *s
 				bloomFilterFiles = []string{log.GetLogPath()}
 				logType = storage.CompoundStatsType
 				break Loop
+			case storage.FieldStatsType.LogIdx():
+				// field stats log has no bloom filter
+				continue
 			default:
 				bloomFilterFiles = append(bloomFilterFiles, log.GetLogPath())
 			}
@@ -435,11 +439,22 @@ func getServiceWithChannel(initCtx context.Context, node *DataNode, info *datapb
 	return ds, nil
 }
 
+// applyBinlogCompression fills the collection-wide binlog codec into the fields without one,
+// so the codec altered after the collection created takes effect once the channel is watched again.
+func applyBinlogCompression(info *datapb.ChannelWatchInfo) {
+	if err := storage.ApplyBinlogCompression(info.GetSchema(), info.GetCollectionProperties()); err != nil {
+		log.Warn("invalid collection binlog codec, use the default one",
+			zap.String("channel", info.GetVchan().GetChannelName()),
+			zap.Error(err))
+	}
+}
+
 // newServiceWithEtcdTickler gets a dataSyncService, but flowgraphs are not running
 // initCtx is used to init the dataSyncService only, if initCtx.Canceled or initCtx.Timeout
 // newServiceWithEtcdTickler stops and returns the initCtx.Err()
 func newServiceWithEtcdTickler(initCtx context.Context, node *DataNode, info *datapb.ChannelWatchInfo, tickler *etcdTickler) (*dataSyncService, error) {
 	// recover segment checkpoints
+	defer healthz.Track(typeutil.DataNodeRole, healthz.StageRecovering, healthz.ReasonRecoveringChannelCheckpoints, info.GetVchan().GetChannelName())()
 	unflushedSegmentInfos, err := node.broker.GetSegmentInfo(initCtx, info.GetVchan().GetUnflushedSegmentIds())
 	if err != nil {
 		return nil, err
@@ -449,6 +464,8 @@ func newServiceWithEtcdTickler(initCtx context.Context, node *DataNode, info *da
 		return nil, err
 	}
 
+	applyBinlogCompression(info)
+
 	var storageCache *metacache.StorageV2Cache
 	if params.Params.CommonCfg.EnableStorageV2.GetAsBool() {
 		storageCache, err = metacache.NewStorageV2Cache(info.Schema)
@@ -471,6 +488,7 @@ func newServiceWithEtcdTickler(initCtx context.Context, node *DataNode, info *da
 // NOTE: compactiable for event manager
 func newDataSyncService(initCtx context.Context, node *DataNode, info *datapb.ChannelWatchInfo, tickler *tickler) (*dataSyncService, error) {
 	// recover segment checkpoints
+	defer healthz.Track(typeutil.DataNodeRole, healthz.StageRecovering, healthz.ReasonRecoveringChannelCheckpoints, info.GetVchan().GetChannelName())()
 	unflushedSegmentInfos, err := node.broker.GetSegmentInfo(initCtx, info.GetVchan().GetUnflushedSegmentIds())
 	if err != nil {
 		return nil, err
@@ -480,6 +498,8 @@ func newDataSyncService(initCtx context.Context, node *DataNode, info *datapb.Ch
 		return nil, err
 	}
 
+	applyBinlogCompression(info)
+
 	var storageCache *metacache.StorageV2Cache
 	if params.Params.CommonCfg.EnableStorageV2.GetAsBool() {
 		storageCache, err = metacache.NewStorageV2Cache(info.Schema)
@@ -309,9 +309,10 @@ func (t *levelZeroCompactionTask) composeDeltalog(segmentID int64, dData *storag
 
 	// TODO Timestamp?
 	deltalog := &datapb.Binlog{
-		LogSize: int64(len(blob.GetValue())),
-		LogPath: blobPath,
-		LogID:   logID,
+		LogSize:  int64(len(blob.GetValue())),
+		LogPath:  blobPath,
+		LogID:    logID,
+		Checksum: storage.BinlogChecksum(blob.GetValue()),
 	}
 
 	return uploadKv, deltalog, nil
@@ -26,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/fieldstats"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
@@ -101,6 +102,9 @@ func (c *metaCacheImpl) Schema() *schemapb.CollectionSchema {
 // AddSegment adds a segment from segment info.
 func (c *metaCacheImpl) AddSegment(segInfo *datapb.SegmentInfo, factory PkStatsFactory, actions ...SegmentAction) {
 	segment := NewSegmentInfo(segInfo, factory(segInfo))
+	if segInfo.GetState() == commonpb.SegmentState_Growing && segInfo.GetLevel() != datapb.SegmentLevel_L0 {
+		segment.fieldStats = fieldstats.NewSegmentCollector(c.schema)
+	}
 
 	for _, action := range actions {
 		action(segment)
@@ -21,6 +21,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/fieldstats"
 )
 
 const (
@@ -44,6 +45,9 @@ type SegmentInfo struct {
 	importing        bool
 	level            datapb.SegmentLevel
 	syncingTasks     int32
+	// fieldStats collects the statistics of scalar fields synced,
+	// nil if the segment is recovered since the rows synced before are unknown
+	fieldStats *fieldstats.SegmentCollector
 }
 
 func (s *SegmentInfo) SegmentID() int64 {
@@ -89,6 +93,10 @@ func (s *SegmentInfo) GetBloomFilterSet() *BloomFilterSet {
 	return s.bfs
 }
 
+func (s *SegmentInfo) GetFieldStats() *fieldstats.SegmentCollector {
+	return s.fieldStats
+}
+
 func (s *SegmentInfo) Level() datapb.SegmentLevel {
 	return s.level
 }
@@ -109,6 +117,7 @@ func (s *SegmentInfo) Clone() *SegmentInfo {
 		level:            s.level,
 		importing:        s.importing,
 		syncingTasks:     s.syncingTasks,
+		fieldStats:       s.fieldStats,
 	}
 }
 
@@ -51,6 +51,14 @@ func (s *StorageV2Cache) GetOrCreateSpace(segmentID int64, creator func() (*milv
 	return space, nil
 }
 
+// HasSpace returns true if the segment is written or loaded by storage v2.
+func (s *StorageV2Cache) HasSpace(segmentID int64) bool {
+	s.spaceMu.Lock()
+	defer s.spaceMu.Unlock()
+	_, ok := s.spaces[segmentID]
+	return ok
+}
+
 // only for unit test
 func (s *StorageV2Cache) SetSpace(segmentID int64, space *milvus_storage.Space) {
 	s.spaceMu.Lock()
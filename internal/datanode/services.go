@@ -875,6 +875,7 @@ func createBinLogs(rowNum int, schema *schemapb.CollectionSchema, ts Timestamp,
 			TimestampTo:   ts,
 			LogPath:       key,
 			LogSize:       int64(len(blob.Value)),
+			Checksum:      storage.BinlogChecksum(blob.Value),
 		}
 		field2Logidx[fieldID] = logidx
 	}
@@ -905,6 +906,7 @@ func createBinLogs(rowNum int, schema *schemapb.CollectionSchema, ts Timestamp,
 		TimestampTo:   ts,
 		LogPath:       key,
 		LogSize:       int64(len(statsBinLog.Value)),
+		Checksum:      storage.BinlogChecksum(statsBinLog.Value),
 	}
 
 	err = node.chunkManager.MultiWrite(ctx, kvs)
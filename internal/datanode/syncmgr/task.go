@@ -159,6 +159,11 @@ func (t *SyncTask) serializeInsertData() error {
 		return err
 	}
 
+	err = t.serializeFieldStatsLog()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -188,6 +193,7 @@ func (t *SyncTask) serializeDeleteData() error {
 	t.segmentData[blobPath] = value
 	data.LogSize = int64(len(blob.Value))
 	data.LogPath = blobPath
+	data.Checksum = storage.BinlogChecksum(value)
 	data.TimestampFrom = t.tsFrom
 	data.TimestampTo = t.tsTo
 	data.EntriesNum = t.deleteData.RowCount
@@ -236,6 +242,7 @@ func (t *SyncTask) serializeBinlog() error {
 			TimestampTo:   t.tsTo,
 			LogPath:       key,
 			LogSize:       int64(memSize[fieldID]),
+			Checksum:      storage.BinlogChecksum(blob.GetValue()),
 		})
 
 		logidx += 1
@@ -309,6 +316,7 @@ func (t *SyncTask) convertBlob2StatsBinlog(blob *storage.Blob, fieldID, logID in
 		TimestampTo:   t.tsTo,
 		LogPath:       key,
 		LogSize:       int64(len(value)),
+		Checksum:      storage.BinlogChecksum(value),
 	})
 }
 
@@ -336,6 +344,35 @@ func (t *SyncTask) serializePkStatsLog() error {
 	return nil
 }
 
+// serializeFieldStatsLog collects the statistics of scalar fields in insert data,
+// and saves them as the field stats log along with the pk stats log when the segment is flushed.
+func (t *SyncTask) serializeFieldStatsLog() error {
+	collector := t.segment.GetFieldStats()
+	if collector == nil || t.segment.CompactTo() > 0 {
+		return nil
+	}
+	collector.AddInsertData(t.insertData)
+	if !t.isFlush || t.segment.NumOfRows() == 0 {
+		return nil
+	}
+	if collector.RowCount() != t.segment.NumOfRows() {
+		t.getLogger().Info("skip field stats log since the statistics are incomplete",
+			zap.Int64("collectedRows", collector.RowCount()),
+			zap.Int64("numRows", t.segment.NumOfRows()))
+		return nil
+	}
+
+	// saved in the statslogs of each field, see fieldstats.IsStatsLog
+	for _, fieldStats := range collector.Build(t.segmentID).Fields {
+		value, err := fieldStats.Marshal()
+		if err != nil {
+			return err
+		}
+		t.convertBlob2StatsBinlog(&storage.Blob{Value: value}, fieldStats.FieldID, int64(storage.FieldStatsType), collector.RowCount())
+	}
+	return nil
+}
+
 func (t *SyncTask) appendBinlog(fieldID int64, binlog *datapb.Binlog) {
 	fieldBinlog, ok := t.insertBinlogs[fieldID]
 	if !ok {
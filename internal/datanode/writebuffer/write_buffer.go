@@ -22,6 +22,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/featureflag"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
@@ -371,6 +372,15 @@ func SpaceCreatorFunc(segmentID int64, collSchema *schemapb.CollectionSchema, ar
 	}
 }
 
+// useStorageV2 returns true if the segment is synced by storage v2, which is used by new segments only if
+// all the nodes support it, the segments already in storage v2 keep using it.
+func (wb *writeBufferBase) useStorageV2(segmentID int64) bool {
+	if !params.Params.CommonCfg.EnableStorageV2.GetAsBool() {
+		return false
+	}
+	return featureflag.Enabled(featureflag.BinlogV2) || wb.storagev2Cache.HasSpace(segmentID)
+}
+
 func (wb *writeBufferBase) getSyncTask(ctx context.Context, segmentID int64) syncmgr.Task {
 	log := log.Ctx(ctx).With(
 		zap.Int64("segmentID", segmentID),
@@ -396,7 +406,7 @@ func (wb *writeBufferBase) getSyncTask(ctx context.Context, segmentID int64) syn
 	wb.metaCache.UpdateSegments(metacache.MergeSegmentAction(actions...), metacache.WithSegmentIDs(segmentID))
 
 	var syncTask syncmgr.Task
-	if params.Params.CommonCfg.EnableStorageV2.GetAsBool() {
+	if wb.useStorageV2(segmentID) {
 		arrowSchema := wb.storagev2Cache.ArrowSchema()
 		space, err := wb.storagev2Cache.GetOrCreateSpace(segmentID, SpaceCreatorFunc(segmentID, wb.collSchema, arrowSchema))
 		if err != nil {
@@ -9,6 +9,7 @@ const (
 	VectorInsertPath              = "/vector/insert"
 	VectorUpsertPath              = "/vector/upsert"
 	VectorSearchPath              = "/vector/search"
+	VectorFederatedSearchPath     = "/vector/search/federated"
 	VectorGetPath                 = "/vector/get"
 	VectorQueryPath               = "/vector/query"
 	VectorDeletePath              = "/vector/delete"
@@ -39,7 +40,9 @@ const (
 	HTTPReturnIndexField       = "fieldName"
 	HTTPReturnIndexMetricsType = "metricType"
 
-	HTTPReturnDistance = "distance"
+	HTTPReturnDistance   = "distance"
+	HTTPReturnCollection = "collection"
+	HTTPReturnFailures   = "failures"
 
 	DefaultMetricType       = "L2"
 	DefaultPrimaryFieldName = "id"
@@ -122,6 +122,7 @@ func (h *Handlers) RegisterRoutesToV1(router gin.IRouter) {
 	router.POST(VectorInsertPath, h.insert)
 	router.POST(VectorUpsertPath, h.upsert)
 	router.POST(VectorSearchPath, h.search)
+	router.POST(VectorFederatedSearchPath, h.federatedSearch)
 }
 
 func (h *Handlers) registerRestRequestInterceptor() {
@@ -912,3 +913,101 @@ func (h *Handlers) search(c *gin.Context) {
 		}
 	}
 }
+
+func (h *Handlers) federatedSearch(c *gin.Context) {
+	httpReq := FederatedSearchReq{
+		DbName: DefaultDbName,
+		Limit:  100,
+	}
+	if err := c.ShouldBindBodyWith(&httpReq, binding.JSON); err != nil {
+		log.Warn("high level restful api, the parameter of federated search is incorrect", zap.Any("request", httpReq), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(merr.ErrIncorrectParameterFormat),
+			HTTPReturnMessage: merr.ErrIncorrectParameterFormat.Error() + ", error: " + err.Error(),
+		})
+		return
+	}
+	if len(httpReq.CollectionNames) == 0 || httpReq.Vector == nil || httpReq.MetricType == "" {
+		log.Warn("high level restful api, federated search require parameter: [collectionNames, vector, metricType], but miss")
+		c.AbortWithStatusJSON(http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(merr.ErrMissingRequiredParameters),
+			HTTPReturnMessage: merr.ErrMissingRequiredParameters.Error() + ", required parameters: [collectionNames, vector, metricType]",
+		})
+		return
+	}
+	params := map[string]interface{}{ // auto generated mapping
+		"level": int(commonpb.ConsistencyLevel_Bounded),
+	}
+	bs, _ := json.Marshal(params)
+	searchParams := []*commonpb.KeyValuePair{
+		{Key: common.TopKKey, Value: strconv.FormatInt(int64(httpReq.Limit), 10)},
+		{Key: Params, Value: string(bs)},
+		{Key: ParamRoundDecimal, Value: "-1"},
+		{Key: ParamOffset, Value: strconv.FormatInt(int64(httpReq.Offset), 10)},
+		{Key: common.MetricTypeKey, Value: httpReq.MetricType},
+	}
+	if httpReq.AnnsField != "" {
+		searchParams = append(searchParams, &commonpb.KeyValuePair{Key: ParamAnnsField, Value: httpReq.AnnsField})
+	}
+	req := &milvuspb.SearchRequest{
+		DbName:             httpReq.DbName,
+		Dsl:                httpReq.Filter,
+		PlaceholderGroup:   vector2PlaceholderGroupBytes(httpReq.Vector),
+		DslType:            commonpb.DslType_BoolExprV1,
+		OutputFields:       httpReq.OutputFields,
+		SearchParams:       searchParams,
+		GuaranteeTimestamp: BoundedTimestamp,
+		Nq:                 int64(1),
+	}
+	username, _ := c.Get(ContextUsername)
+	ctx := proxy.NewContextWithMetadata(c, username.(string), req.DbName)
+	// the privileges are checked per collection, the collections not permitted are reported in the failures
+	authorize := func(ctx context.Context, req *milvuspb.SearchRequest) error {
+		_, err := proxy.PrivilegeInterceptor(ctx, req)
+		return err
+	}
+	response, err := h.executeRestRequestInterceptor(ctx, c, &httpReq, func(reqCtx context.Context, _ any) (any, error) {
+		return proxy.FederatedSearch(reqCtx, h.proxy.Search, &proxy.FederatedSearchRequest{
+			DbName:          httpReq.DbName,
+			CollectionNames: httpReq.CollectionNames,
+			Request:         req,
+			Authorize:       authorize,
+		})
+	})
+	if err == RestRequestInterceptorErr {
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return
+	}
+	results := response.(*proxy.FederatedSearchResults)
+
+	allowJS, _ := strconv.ParseBool(c.Request.Header.Get(HTTPHeaderAllowInt64))
+	collectionRows := make(map[string][]map[string]interface{}, len(results.Results))
+	for name, data := range results.Results {
+		rows, err := buildQueryResp(int64(len(data.GetScores())), data.GetOutputFields(), data.GetFieldsData(), data.GetIds(), data.GetScores(), allowJS)
+		if err != nil {
+			log.Warn("high level restful api, fail to deal with federated search result", zap.String("collection", name), zap.Error(err))
+			c.JSON(http.StatusOK, gin.H{
+				HTTPReturnCode:    merr.Code(merr.ErrInvalidSearchResult),
+				HTTPReturnMessage: merr.ErrInvalidSearchResult.Error() + ", error: " + err.Error(),
+			})
+			return
+		}
+		collectionRows[name] = rows
+	}
+	outputData := make([]map[string]interface{}, 0)
+	if len(results.Hits) > 0 {
+		for _, hit := range results.Hits[0] {
+			row := collectionRows[hit.Collection][hit.Offset]
+			row[HTTPReturnCollection] = hit.Collection
+			outputData = append(outputData, row)
+		}
+	}
+	failures := make(map[string]string, len(results.Failures))
+	for name, err := range results.Failures {
+		failures[name] = err.Error()
+	}
+	c.JSON(http.StatusOK, gin.H{HTTPReturnCode: http.StatusOK, HTTPReturnData: outputData, HTTPReturnFailures: failures})
+}
@@ -1296,6 +1296,36 @@ func TestSearch(t *testing.T) {
 	}
 }
 
+func TestFederatedSearch(t *testing.T) {
+	paramtable.Init()
+	testEngine := initHTTPServer(mocks.NewMockProxy(t), true)
+	post := func(body map[string]interface{}) string {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, versional(VectorFederatedSearchPath), bytes.NewReader(data))
+		req.SetBasicAuth(util.UserRoot, util.DefaultRootPassword)
+		w := httptest.NewRecorder()
+		testEngine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		return w.Body.String()
+	}
+
+	body := post(map[string]interface{}{
+		"collectionNames": []string{"tenant1", "tenant2"},
+		"vector":          []float32{0.0, 0.0},
+	})
+	assert.True(t, CheckErrCode(body, merr.ErrMissingRequiredParameters))
+
+	// the meta cache is not initialized, so all the collections fail
+	body = post(map[string]interface{}{
+		"collectionNames": []string{"tenant1", "tenant2"},
+		"vector":          []float32{0.0, 0.0},
+		"metricType":      "L2",
+	})
+	resp := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(body), &resp))
+	assert.Equal(t, float64(merr.Code(merr.ErrServiceNotReady)), resp[HTTPReturnCode])
+}
+
 type ReturnType int
 
 func wrapWithDescribeColl(t *testing.T, mp *mocks.MockProxy, returnType ReturnType, times int, testCases []testCase) (*mocks.MockProxy, []testCase) {
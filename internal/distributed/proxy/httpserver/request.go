@@ -71,3 +71,17 @@ type SearchReq struct {
 	OutputFields   []string  `json:"outputFields"`
 	Vector         []float32 `json:"vector"`
 }
+
+type FederatedSearchReq struct {
+	DbName          string    `json:"dbName"`
+	CollectionNames []string  `json:"collectionNames" validate:"required"`
+	AnnsField       string    `json:"annsField"`
+	MetricType      string    `json:"metricType"`
+	Filter          string    `json:"filter"`
+	Limit           int32     `json:"limit"`
+	Offset          int32     `json:"offset"`
+	OutputFields    []string  `json:"outputFields"`
+	Vector          []float32 `json:"vector"`
+}
+
+func (req *FederatedSearchReq) GetDbName() string { return req.DbName }
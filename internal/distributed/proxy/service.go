@@ -55,6 +55,7 @@ import (
 	rcc "github.com/milvus-io/milvus/internal/distributed/rootcoord/client"
 	"github.com/milvus-io/milvus/internal/distributed/utils"
 	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/http/healthz"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proxy"
@@ -75,6 +76,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 var (
@@ -243,7 +245,7 @@ func (s *Server) startExternalGrpc(grpcPort int, errChan chan error) {
 
 	opts := tracer.GetInterceptorOpts()
 
-	var unaryServerOption grpc.ServerOption
+	var unaryServerOption, streamServerOption grpc.ServerOption
 	if enableCustomInterceptor {
 		unaryServerOption = grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			accesslog.UnaryAccessLogInterceptor,
@@ -253,13 +255,16 @@ func (s *Server) startExternalGrpc(grpcPort int, errChan chan error) {
 			proxy.UnaryServerHookInterceptor(),
 			proxy.UnaryServerInterceptor(proxy.PrivilegeInterceptor),
 			logutil.UnaryTraceLoggerInterceptor,
+			connection.LimitInterceptor(proxy.GetCurUserFromContext),
 			proxy.RateLimitInterceptor(limiter),
 			accesslog.UnaryUpdateAccessInfoInterceptor,
 			proxy.TraceLogInterceptor,
 			connection.KeepActiveInterceptor,
 		))
+		streamServerOption = grpc.StreamInterceptor(connection.LimitStreamInterceptor(proxy.GetCurUserFromContext))
 	} else {
 		unaryServerOption = grpc.EmptyServerOption{}
+		streamServerOption = grpc.EmptyServerOption{}
 	}
 
 	grpcOpts := []grpc.ServerOption{
@@ -267,7 +272,9 @@ func (s *Server) startExternalGrpc(grpcPort int, errChan chan error) {
 		grpc.KeepaliveParams(kasp),
 		grpc.MaxRecvMsgSize(Params.ServerMaxRecvSize.GetAsInt()),
 		grpc.MaxSendMsgSize(Params.ServerMaxSendSize.GetAsInt()),
+		grpc.StatsHandler(connection.NewLimitStatsHandler()),
 		unaryServerOption,
+		streamServerOption,
 	}
 
 	if Params.TLSMode.GetAsInt() == 1 {
@@ -565,7 +572,10 @@ func (s *Server) init() error {
 	}
 
 	log.Debug("Proxy wait for RootCoord to be healthy")
-	if err := componentutil.WaitForComponentHealthy(s.ctx, s.rootCoordClient, "RootCoord", 1000000, time.Millisecond*200); err != nil {
+	waitForRootCoord := healthz.Track(typeutil.ProxyRole, healthz.StageWaitingDependency, healthz.WaitingFor("RootCoord"), "")
+	err = componentutil.WaitForComponentHealthy(s.ctx, s.rootCoordClient, "RootCoord", 1000000, time.Millisecond*200)
+	waitForRootCoord()
+	if err != nil {
 		log.Warn("Proxy failed to wait for RootCoord to be healthy", zap.Error(err))
 		return err
 	}
@@ -587,7 +597,10 @@ func (s *Server) init() error {
 	}
 
 	log.Debug("Proxy wait for DataCoord to be healthy")
-	if err := componentutil.WaitForComponentHealthy(s.ctx, s.dataCoordClient, "DataCoord", 1000000, time.Millisecond*200); err != nil {
+	waitForDataCoord := healthz.Track(typeutil.ProxyRole, healthz.StageWaitingDependency, healthz.WaitingFor("DataCoord"), "")
+	err = componentutil.WaitForComponentHealthy(s.ctx, s.dataCoordClient, "DataCoord", 1000000, time.Millisecond*200)
+	waitForDataCoord()
+	if err != nil {
 		log.Warn("Proxy failed to wait for DataCoord to be healthy", zap.Error(err))
 		return err
 	}
@@ -609,7 +622,10 @@ func (s *Server) init() error {
 	}
 
 	log.Debug("Proxy wait for QueryCoord to be healthy")
-	if err := componentutil.WaitForComponentHealthy(s.ctx, s.queryCoordClient, "QueryCoord", 1000000, time.Millisecond*200); err != nil {
+	waitForQueryCoord := healthz.Track(typeutil.ProxyRole, healthz.StageWaitingDependency, healthz.WaitingFor("QueryCoord"), "")
+	err = componentutil.WaitForComponentHealthy(s.ctx, s.queryCoordClient, "QueryCoord", 1000000, time.Millisecond*200)
+	waitForQueryCoord()
+	if err != nil {
 		log.Warn("Proxy failed to wait for QueryCoord to be healthy", zap.Error(err))
 		return err
 	}
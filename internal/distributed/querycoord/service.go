@@ -37,6 +37,7 @@ import (
 	dcc "github.com/milvus-io/milvus/internal/distributed/datacoord/client"
 	rcc "github.com/milvus-io/milvus/internal/distributed/rootcoord/client"
 	"github.com/milvus-io/milvus/internal/distributed/utils"
+	"github.com/milvus-io/milvus/internal/http/healthz"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	qc "github.com/milvus-io/milvus/internal/querycoordv2"
@@ -53,6 +54,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/logutil"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/tikv"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 // Server is the grpc server of QueryCoord.
@@ -163,7 +165,9 @@ func (s *Server) init() error {
 
 	// wait for master init or healthy
 	log.Debug("QueryCoord try to wait for RootCoord ready")
+	waitForRootCoord := healthz.Track(typeutil.QueryCoordRole, healthz.StageWaitingDependency, healthz.WaitingFor("RootCoord"), "")
 	err = componentutil.WaitForComponentHealthy(s.loopCtx, s.rootCoord, "RootCoord", 1000000, time.Millisecond*200)
+	waitForRootCoord()
 	if err != nil {
 		log.Error("QueryCoord wait for RootCoord ready failed", zap.Error(err))
 		panic(err)
@@ -184,7 +188,9 @@ func (s *Server) init() error {
 	}
 
 	log.Debug("QueryCoord try to wait for DataCoord ready")
+	waitForDataCoord := healthz.Track(typeutil.QueryCoordRole, healthz.StageWaitingDependency, healthz.WaitingFor("DataCoord"), "")
 	err = componentutil.WaitForComponentHealthy(s.loopCtx, s.dataCoord, "DataCoord", 1000000, time.Millisecond*200)
+	waitForDataCoord()
 	if err != nil {
 		log.Error("QueryCoord wait for DataCoord ready failed", zap.Error(err))
 		panic(err)
@@ -329,3 +329,14 @@ func (c *Client) Delete(ctx context.Context, req *querypb.DeleteRequest, _ ...gr
 		return client.Delete(ctx, req)
 	})
 }
+
+func (c *Client) FetchIndexFile(ctx context.Context, req *querypb.FetchIndexFileRequest, _ ...grpc.CallOption) (*querypb.FetchIndexFileResponse, error) {
+	req = typeutil.Clone(req)
+	commonpbutil.UpdateMsgBase(
+		req.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID()),
+	)
+	return wrapGrpcCall(ctx, c, func(client querypb.QueryNodeClient) (*querypb.FetchIndexFileResponse, error) {
+		return client.FetchIndexFile(ctx, req)
+	})
+}
@@ -371,3 +371,8 @@ func (s *Server) SyncDistribution(ctx context.Context, req *querypb.SyncDistribu
 func (s *Server) Delete(ctx context.Context, req *querypb.DeleteRequest) (*commonpb.Status, error) {
 	return s.querynode.Delete(ctx, req)
 }
+
+// FetchIndexFile fetches a chunk of the disk index files cached by the querynode.
+func (s *Server) FetchIndexFile(ctx context.Context, req *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error) {
+	return s.querynode.FetchIndexFile(ctx, req)
+}
@@ -0,0 +1,214 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/util/conc"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// BundleMeta describes the process where the bundle is collected.
+type BundleMeta struct {
+	Role         string    `json:"role"`
+	NodeID       int64     `json:"node_id"`
+	CollectTime  time.Time `json:"collect_time"`
+	GoVersion    string    `json:"go_version"`
+	GOMAXPROCS   int       `json:"gomaxprocs"`
+	NumGoroutine int       `json:"num_goroutine"`
+	NumCgoCall   int64     `json:"num_cgo_call"`
+	CPUProfile   string    `json:"cpu_profile"`
+}
+
+// ThreadInfo describes an os thread of the process.
+type ThreadInfo struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name,omitempty"`
+	State string `json:"state,omitempty"`
+	// Pool is the tag of the pool which locks the thread, empty if it's not locked by any pool
+	Pool string `json:"pool,omitempty"`
+}
+
+// CollectBundle collects the cpu profile in the duration, the heap and goroutine profiles,
+// and the inventory of os threads, returns them bundled as a tar.gz archive.
+// The cpu profile is skipped if the duration is not positive.
+func CollectBundle(ctx context.Context, cpuDuration time.Duration) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+
+	meta := &BundleMeta{
+		Role:         paramtable.GetRole(),
+		NodeID:       paramtable.GetNodeID(),
+		CollectTime:  now,
+		GoVersion:    runtime.Version(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		NumGoroutine: runtime.NumGoroutine(),
+		NumCgoCall:   runtime.NumCgoCall(),
+	}
+
+	if cpuDuration > 0 {
+		cpu := &bytes.Buffer{}
+		if err := collectCPUProfile(ctx, cpu, cpuDuration); err != nil {
+			return nil, err
+		}
+		if err := writeFile(tw, "cpu.pprof", cpu.Bytes(), now); err != nil {
+			return nil, err
+		}
+		meta.CPUProfile = cpuDuration.String()
+	}
+
+	for _, profile := range []struct {
+		name  string
+		file  string
+		debug int
+	}{
+		{"heap", "heap.pprof", 0},
+		{"goroutine", "goroutine.txt", 2},
+		{"threadcreate", "threadcreate.pprof", 0},
+	} {
+		data := &bytes.Buffer{}
+		if err := pprof.Lookup(profile.name).WriteTo(data, profile.debug); err != nil {
+			return nil, err
+		}
+		if err := writeFile(tw, profile.file, data.Bytes(), now); err != nil {
+			return nil, err
+		}
+	}
+
+	for file, v := range map[string]any{
+		"meta.json":    meta,
+		"threads.json": GetThreads(),
+	} {
+		bs, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeFile(tw, file, bs, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func collectCPUProfile(ctx context.Context, buf *bytes.Buffer, duration time.Duration) error {
+	if err := pprof.StartCPUProfile(buf); err != nil {
+		return err
+	}
+	defer pprof.StopCPUProfile()
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// GetThreads returns the inventory of os threads,
+// the threads are listed from procfs, or only the ones locked by pools if procfs is unavailable.
+func GetThreads() []*ThreadInfo {
+	locked := conc.GetLockedThreads()
+
+	var threads []*ThreadInfo
+	entries, err := os.ReadDir("/proc/self/task")
+	if err == nil {
+		threads = make([]*ThreadInfo, 0, len(entries))
+		for _, entry := range entries {
+			tid, err := strconv.Atoi(entry.Name())
+			if err != nil {
+				continue
+			}
+			thread := &ThreadInfo{
+				ID:   tid,
+				Pool: locked[tid],
+			}
+			dir := path.Join("/proc/self/task", entry.Name())
+			if bs, err := os.ReadFile(path.Join(dir, "comm")); err == nil {
+				thread.Name = strings.TrimSpace(string(bs))
+			}
+			if bs, err := os.ReadFile(path.Join(dir, "stat")); err == nil {
+				thread.State = parseThreadState(string(bs))
+			}
+			threads = append(threads, thread)
+		}
+	} else {
+		threads = make([]*ThreadInfo, 0, len(locked))
+		for tid, pool := range locked {
+			threads = append(threads, &ThreadInfo{
+				ID:   tid,
+				Pool: pool,
+			})
+		}
+	}
+
+	sort.Slice(threads, func(i, j int) bool {
+		return threads[i].ID < threads[j].ID
+	})
+	return threads
+}
+
+// parseThreadState parses the state from the content of /proc/{pid}/task/{tid}/stat,
+// which is formatted as "{tid} ({comm}) {state} ...".
+func parseThreadState(stat string) string {
+	idx := strings.LastIndex(stat, ")")
+	if idx < 0 {
+		return ""
+	}
+	fields := strings.Fields(stat[idx+1:])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
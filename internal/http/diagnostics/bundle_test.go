@@ -0,0 +1,112 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/util/conc"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func listBundleFiles(t *testing.T, data []byte) []string {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	tr := tar.NewReader(gr)
+	var files []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		files = append(files, header.Name)
+	}
+	return files
+}
+
+func TestCollectBundle(t *testing.T) {
+	paramtable.Init()
+
+	data, err := CollectBundle(context.Background(), 100*time.Millisecond)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cpu.pprof", "heap.pprof", "goroutine.txt", "threadcreate.pprof", "meta.json", "threads.json"}, listBundleFiles(t, data))
+
+	data, err = CollectBundle(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.NotContains(t, listBundleFiles(t, data), "cpu.pprof")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = CollectBundle(ctx, time.Minute)
+	assert.Error(t, err)
+}
+
+func TestGetThreads(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	locked := make(chan struct{})
+	go func() {
+		conc.LockOSThread("mock")()
+		close(locked)
+		<-done
+	}()
+	<-locked
+
+	threads := GetThreads()
+	assert.NotEmpty(t, threads)
+	if len(conc.GetLockedThreads()) > 0 {
+		pools := make([]string, 0)
+		for _, thread := range threads {
+			if thread.Pool != "" {
+				pools = append(pools, thread.Pool)
+			}
+		}
+		assert.Contains(t, pools, "mock")
+	}
+}
+
+func TestParseThreadState(t *testing.T) {
+	assert.Equal(t, "S", parseThreadState("100 (milvus (x)) S 1 2 3"))
+	assert.Equal(t, "", parseThreadState("100 milvus"))
+	assert.Equal(t, "", parseThreadState("100 (milvus)"))
+}
+
+func TestBundleHandler(t *testing.T) {
+	paramtable.Init()
+	h := Handler()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/diagnostics?seconds=0", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/gzip", w.Header().Get("Content-Type"))
+	assert.Contains(t, listBundleFiles(t, w.Body.Bytes()), "threads.json")
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/diagnostics?seconds=-1", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
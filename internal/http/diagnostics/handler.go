@@ -0,0 +1,88 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const (
+	defaultCPUProfileSeconds = 10
+	maxCPUProfileSeconds     = 120
+)
+
+type bundleHandler struct {
+	// only one bundle is collected at the same time
+	mu sync.Mutex
+}
+
+var _ http.Handler = (*bundleHandler)(nil)
+
+// Handler returns the handler collecting the diagnostics bundle,
+// the duration of cpu profile is specified by the query parameter "seconds", 0 means skipping it.
+func Handler() http.Handler {
+	return &bundleHandler{}
+}
+
+// ServeHTTP responses the diagnostics bundle as a tar.gz archive.
+func (h *bundleHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	seconds := defaultCPUProfileSeconds
+	if s := req.URL.Query().Get("seconds"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 || n > maxCPUProfileSeconds {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid seconds %s, should be in [0, %d]", s, maxCPUProfileSeconds))
+			return
+		}
+		seconds = n
+	}
+
+	if !h.mu.TryLock() {
+		writeError(w, http.StatusTooManyRequests, "another diagnostics bundle is being collected")
+		return
+	}
+	defer h.mu.Unlock()
+
+	log.Info("start to collect diagnostics bundle", zap.Int("cpuProfileSeconds", seconds))
+	data, err := CollectBundle(req.Context(), time.Duration(seconds)*time.Second)
+	if err != nil {
+		log.Warn("failed to collect diagnostics bundle", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("milvus-diagnostics-%s-%d-%s.tar.gz",
+		paramtable.GetRole(), paramtable.GetNodeID(), time.Now().Format("20060102150405"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(fmt.Sprintf(`{"msg": %q}`, msg)))
+}
@@ -0,0 +1,270 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// Stage is the stage of the lifecycle which a component is in.
+type Stage string
+
+const (
+	StageStarting          Stage = "Starting"
+	StageWaitingDependency Stage = "WaitingDependency"
+	StageRecovering        Stage = "Recovering"
+	StageLoading           Stage = "Loading"
+	StageServing           Stage = "Serving"
+	StageStandBy           Stage = "StandBy"
+	StageStopping          Stage = "Stopping"
+	StageAbnormal          Stage = "Abnormal"
+)
+
+// The machine-readable reasons of the stages.
+const (
+	ReasonStarting                     = "Starting"
+	ReasonServing                      = "Serving"
+	ReasonStandBy                      = "StandBy"
+	ReasonStopping                     = "Stopping"
+	ReasonAbnormal                     = "Abnormal"
+	ReasonRecoveringMeta               = "RecoveringMeta"
+	ReasonRecoveringChannelCheckpoints = "RecoveringChannelCheckpoints"
+	ReasonLoadingSegments              = "LoadingSegments"
+)
+
+// WaitingFor returns the reason of waiting for the dependency, e.g. WaitingForEtcd.
+func WaitingFor(dependency string) string {
+	if dependency == "" {
+		return "WaitingFor"
+	}
+	return "WaitingFor" + strings.ToUpper(dependency[:1]) + dependency[1:]
+}
+
+// progress is a stage reported by a component before it's fully serving.
+type progress struct {
+	id     int64
+	stage  Stage
+	reason string
+	detail string
+	since  time.Time
+}
+
+// ReadinessState is the readiness of a component.
+type ReadinessState struct {
+	Role      string     `json:"role"`
+	StateCode string     `json:"stateCode"`
+	Stage     Stage      `json:"stage"`
+	Reason    string     `json:"reason"`
+	Detail    string     `json:"detail,omitempty"`
+	Since     *time.Time `json:"since,omitempty"`
+	Ready     bool       `json:"ready"`
+	WarmingUp bool       `json:"warmingUp"`
+}
+
+// ReadinessResponse is the response of the readiness and liveness probes.
+type ReadinessResponse struct {
+	Ready     bool              `json:"ready"`
+	WarmingUp bool              `json:"warmingUp"`
+	Roles     []*ReadinessState `json:"roles"`
+}
+
+// tracker records the stages reported by the components.
+type tracker struct {
+	mu       sync.Mutex
+	nextID   int64
+	progress map[string][]*progress
+}
+
+var defaultTracker = &tracker{progress: make(map[string][]*progress)}
+
+// Track reports that the component of the role enters the stage for the reason,
+// the returned func must be called once the component leaves the stage.
+// The latest stage not left yet is the one reported by the probes.
+func Track(role string, stage Stage, reason string, detail string) func() {
+	return defaultTracker.track(role, stage, reason, detail)
+}
+
+func (t *tracker) track(role string, stage Stage, reason string, detail string) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextID++
+	id := t.nextID
+	t.progress[role] = append(t.progress[role], &progress{
+		id:     id,
+		stage:  stage,
+		reason: reason,
+		detail: detail,
+		since:  time.Now(),
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { t.clear(role, id) })
+	}
+}
+
+func (t *tracker) clear(role string, id int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	list := t.progress[role]
+	for i, p := range list {
+		if p.id == id {
+			list = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+	if len(list) == 0 {
+		delete(t.progress, role)
+		return
+	}
+	t.progress[role] = list
+}
+
+// latest returns the latest stage reported by the component of the role, nil if there's none.
+func (t *tracker) latest(role string) *progress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	list := t.progress[role]
+	if len(list) == 0 {
+		return nil
+	}
+	p := *list[len(list)-1]
+	return &p
+}
+
+// evaluate combines the state code of the component with the stage it reported.
+func (t *tracker) evaluate(role string, code commonpb.StateCode) *ReadinessState {
+	state := &ReadinessState{
+		Role:      role,
+		StateCode: code.String(),
+	}
+	p := t.latest(role)
+	fromProgress := func() {
+		state.Stage = p.stage
+		state.Reason = p.reason
+		state.Detail = p.detail
+		state.Since = &p.since
+		state.WarmingUp = true
+	}
+
+	switch code {
+	case commonpb.StateCode_Healthy:
+		// a healthy node may be recovering the channels or loading the segments assigned to it,
+		// which is reported for information only, as it keeps serving the others meanwhile
+		if p != nil && (p.stage == StageRecovering || p.stage == StageLoading) {
+			fromProgress()
+			state.Ready, state.WarmingUp = true, false
+			return state
+		}
+		state.Stage, state.Reason, state.Ready = StageServing, ReasonServing, true
+	case commonpb.StateCode_StandBy:
+		// the standby coordinators are alive but don't serve
+		state.Stage, state.Reason = StageStandBy, ReasonStandBy
+	case commonpb.StateCode_Stopping:
+		state.Stage, state.Reason = StageStopping, ReasonStopping
+	case commonpb.StateCode_Initializing:
+		if p != nil {
+			fromProgress()
+			return state
+		}
+		state.Stage, state.Reason, state.WarmingUp = StageStarting, ReasonStarting, true
+	default:
+		// the component isn't able to report its state before it's initialized
+		if p != nil {
+			fromProgress()
+			return state
+		}
+		state.Stage, state.Reason = StageAbnormal, ReasonAbnormal
+	}
+	return state
+}
+
+// ReadinessHandler serves the readiness and liveness probes of the registered components.
+type ReadinessHandler struct {
+	health  *HealthHandler
+	tracker *tracker
+	// liveness reports whether the components are alive instead of ready
+	liveness bool
+}
+
+var _ http.Handler = (*ReadinessHandler)(nil)
+
+// ReadyzHandler returns the handler of the readiness probe,
+// which succeeds only if all the components are serving.
+func ReadyzHandler() *ReadinessHandler {
+	return &ReadinessHandler{health: &defaultHandler, tracker: defaultTracker}
+}
+
+// LivezHandler returns the handler of the liveness probe,
+// which succeeds as long as all the components are serving or warming up,
+// so the orchestration won't kill the ones recovering or loading.
+func LivezHandler() *ReadinessHandler {
+	return &ReadinessHandler{health: &defaultHandler, tracker: defaultTracker, liveness: true}
+}
+
+func (handler *ReadinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	role := r.URL.Query().Get("role")
+	resp := &ReadinessResponse{
+		Ready: true,
+	}
+	ctx := context.Background()
+	for _, in := range handler.health.indicators {
+		if role != "" && in.GetName() != role {
+			continue
+		}
+		state := handler.tracker.evaluate(in.GetName(), in.Health(ctx))
+		resp.Roles = append(resp.Roles, state)
+		resp.Ready = resp.Ready && state.Ready
+		resp.WarmingUp = resp.WarmingUp || state.WarmingUp
+	}
+
+	ok := resp.Ready
+	if handler.liveness {
+		ok = true
+		for _, state := range resp.Roles {
+			ok = ok && (state.Ready || state.WarmingUp || state.Stage == StageStandBy)
+		}
+	}
+	if len(resp.Roles) == 0 {
+		// the components aren't registered yet, the process is alive but not ready
+		resp.Ready, resp.WarmingUp = false, true
+		ok = handler.liveness
+	}
+
+	w.Header().Set(ContentTypeHeader, ContentTypeJSON)
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	bs, err := json.Marshal(resp)
+	if err != nil {
+		log.Warn("failed to marshal readiness response", zap.Error(err))
+		return
+	}
+	w.Write(bs)
+}
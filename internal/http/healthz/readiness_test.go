@@ -0,0 +1,163 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+)
+
+type mockIndicator struct {
+	name string
+	code commonpb.StateCode
+}
+
+func (m *mockIndicator) GetName() string {
+	return m.name
+}
+
+func (m *mockIndicator) Health(ctx context.Context) commonpb.StateCode {
+	return m.code
+}
+
+func TestWaitingFor(t *testing.T) {
+	assert.Equal(t, "WaitingForEtcd", WaitingFor("etcd"))
+	assert.Equal(t, "WaitingForRootCoord", WaitingFor("RootCoord"))
+}
+
+func TestTracker(t *testing.T) {
+	tr := &tracker{progress: make(map[string][]*progress)}
+	assert.Nil(t, tr.latest("querynode"))
+
+	done1 := tr.track("querynode", StageWaitingDependency, WaitingFor("etcd"), "")
+	done2 := tr.track("querynode", StageLoading, ReasonLoadingSegments, "collection 1")
+	assert.Equal(t, ReasonLoadingSegments, tr.latest("querynode").reason)
+
+	// the earlier stage is reported once the latest one is left
+	done2()
+	done2()
+	assert.Equal(t, WaitingFor("etcd"), tr.latest("querynode").reason)
+	done1()
+	assert.Nil(t, tr.latest("querynode"))
+	assert.Empty(t, tr.progress)
+}
+
+func TestEvaluate(t *testing.T) {
+	tr := &tracker{progress: make(map[string][]*progress)}
+
+	state := tr.evaluate("querynode", commonpb.StateCode_Healthy)
+	assert.Equal(t, StageServing, state.Stage)
+	assert.True(t, state.Ready)
+	assert.False(t, state.WarmingUp)
+
+	state = tr.evaluate("querynode", commonpb.StateCode_Initializing)
+	assert.Equal(t, StageStarting, state.Stage)
+	assert.False(t, state.Ready)
+	assert.True(t, state.WarmingUp)
+
+	done := tr.track("querynode", StageLoading, ReasonLoadingSegments, "collection 1, 2 segments")
+	state = tr.evaluate("querynode", commonpb.StateCode_Healthy)
+	assert.Equal(t, StageLoading, state.Stage)
+	assert.Equal(t, ReasonLoadingSegments, state.Reason)
+	assert.Equal(t, "collection 1, 2 segments", state.Detail)
+	// the healthy node keeps serving while loading
+	assert.True(t, state.Ready)
+	assert.False(t, state.WarmingUp)
+	done()
+
+	// the component is abnormal until it's initialized
+	done = tr.track("querynode", StageWaitingDependency, WaitingFor("etcd"), "")
+	state = tr.evaluate("querynode", commonpb.StateCode_Abnormal)
+	assert.Equal(t, StageWaitingDependency, state.Stage)
+	assert.True(t, state.WarmingUp)
+	done()
+	state = tr.evaluate("querynode", commonpb.StateCode_Abnormal)
+	assert.Equal(t, StageAbnormal, state.Stage)
+	assert.False(t, state.WarmingUp)
+
+	state = tr.evaluate("querycoord", commonpb.StateCode_StandBy)
+	assert.Equal(t, StageStandBy, state.Stage)
+	assert.False(t, state.Ready)
+
+	state = tr.evaluate("querycoord", commonpb.StateCode_Stopping)
+	assert.Equal(t, StageStopping, state.Stage)
+	assert.False(t, state.Ready)
+	assert.False(t, state.WarmingUp)
+}
+
+func TestReadinessHandler(t *testing.T) {
+	qn := &mockIndicator{"querynode", commonpb.StateCode_Healthy}
+	dn := &mockIndicator{"datanode", commonpb.StateCode_Healthy}
+	health := &HealthHandler{}
+	tr := &tracker{progress: make(map[string][]*progress)}
+	readyz := &ReadinessHandler{health: health, tracker: tr}
+	livez := &ReadinessHandler{health: health, tracker: tr, liveness: true}
+
+	serve := func(handler http.Handler, url string) (int, *ReadinessResponse) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		resp := &ReadinessResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		assert.Equal(t, ContentTypeJSON, w.Header().Get(ContentTypeHeader))
+		return w.Code, resp
+	}
+
+	// no component registered yet
+	code, _ := serve(readyz, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, _ = serve(livez, "/livez")
+	assert.Equal(t, http.StatusOK, code)
+
+	health.indicators = []Indicator{qn, dn}
+	code, resp := serve(readyz, "/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Ready)
+	assert.Len(t, resp.Roles, 2)
+
+	// the healthy node recovering a channel is still ready, the stage is for information only
+	done := tr.track("datanode", StageRecovering, ReasonRecoveringChannelCheckpoints, "by-dev-rootcoord-dml_0_1v0")
+	code, resp = serve(readyz, "/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Ready)
+	assert.False(t, resp.WarmingUp)
+	assert.Equal(t, StageRecovering, resp.Roles[1].Stage)
+	assert.Equal(t, ReasonRecoveringChannelCheckpoints, resp.Roles[1].Reason)
+	code, _ = serve(livez, "/livez")
+	assert.Equal(t, http.StatusOK, code)
+
+	// the other role is filtered out
+	code, resp = serve(readyz, "/readyz?role=querynode")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, resp.Roles, 1)
+	assert.Equal(t, "querynode", resp.Roles[0].Role)
+	done()
+
+	qn.code = commonpb.StateCode_Abnormal
+	code, _ = serve(readyz, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, resp = serve(livez, "/livez")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StageAbnormal, resp.Roles[0].Stage)
+}
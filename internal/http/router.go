@@ -19,8 +19,23 @@ package http
 // HealthzRouterPath is default path for check health state.
 const HealthzRouterPath = "/healthz"
 
+// ReadyzRouterPath is path for the readiness probe, which reports the stage of each component.
+const ReadyzRouterPath = "/readyz"
+
+// LivezRouterPath is path for the liveness probe, which tolerates the components warming up.
+const LivezRouterPath = "/livez"
+
 // LogLevelRouterPath is path for Get and Update log level at runtime.
 const LogLevelRouterPath = "/log/level"
 
 // EventLogRouterPath is path for eventlog control.
 const EventLogRouterPath = "/eventlog"
+
+// ConfigStatusRouterPath is path for listing the declared and effective values of validated configs.
+const ConfigStatusRouterPath = "/config/status"
+
+// EffectiveConfigRouterPath is path for listing the configs in effect and the scopes supplying them.
+const EffectiveConfigRouterPath = "/config/effective"
+
+// DiagnosticsRouterPath is path for collecting the bundle of profiles and thread inventory.
+const DiagnosticsRouterPath = "/debug/diagnostics"
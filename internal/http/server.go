@@ -17,6 +17,7 @@
 package http
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -25,6 +26,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus/internal/http/diagnostics"
 	"github.com/milvus-io/milvus/internal/http/healthz"
 	"github.com/milvus-io/milvus/pkg/eventlog"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -66,10 +68,50 @@ func registerDefaults() {
 		Path:    HealthzRouterPath,
 		Handler: healthz.Handler(),
 	})
+	Register(&Handler{
+		Path:    ReadyzRouterPath,
+		Handler: healthz.ReadyzHandler(),
+	})
+	Register(&Handler{
+		Path:    LivezRouterPath,
+		Handler: healthz.LivezHandler(),
+	})
 	Register(&Handler{
 		Path:    EventLogRouterPath,
 		Handler: eventlog.Handler(),
 	})
+	if paramtable.Get().HTTPCfg.EnablePprof.GetAsBool() {
+		Register(&Handler{
+			Path:    DiagnosticsRouterPath,
+			Handler: diagnostics.Handler(),
+		})
+	}
+	Register(&Handler{
+		Path: ConfigStatusRouterPath,
+		HandlerFunc: func(w http.ResponseWriter, req *http.Request) {
+			bs, err := json.Marshal(paramtable.GetBaseTable().GetValueStatus())
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(bs)
+		},
+	})
+	Register(&Handler{
+		Path: EffectiveConfigRouterPath,
+		HandlerFunc: func(w http.ResponseWriter, req *http.Request) {
+			bs, err := json.Marshal(paramtable.GetBaseTable().GetEffectiveConfigs(req.URL.Query().Get("prefix")))
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(bs)
+		},
+	})
 }
 
 func Register(h *Handler) {
@@ -127,6 +127,34 @@ func (suite *HTTPServerTestSuite) TestHealthzHandler() {
 	suite.Equal("{\"state\":\"component m2 state is Abnormal\",\"detail\":[{\"name\":\"m1\",\"code\":1},{\"name\":\"m2\",\"code\":2}]}", string(body))
 }
 
+func (suite *HTTPServerTestSuite) TestConfigStatusHandler() {
+	url := "http://localhost:" + DefaultListenPort + ConfigStatusRouterPath
+	client := http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	resp, err := client.Do(req)
+	suite.Nil(err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.True(strings.Contains(string(body), paramtable.Get().QueryNodeCfg.MaxReadConcurrency.Key))
+}
+
+func (suite *HTTPServerTestSuite) TestEffectiveConfigHandler() {
+	url := "http://localhost:" + DefaultListenPort + EffectiveConfigRouterPath + "?prefix=querynode."
+	client := http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	resp, err := client.Do(req)
+	suite.Nil(err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	configs := make(map[string]map[string]any)
+	suite.NoError(json.Unmarshal(body, &configs))
+	for key := range configs {
+		suite.True(strings.HasPrefix(key, "querynode."))
+	}
+}
+
 func (suite *HTTPServerTestSuite) TestEventlogHandler() {
 	url := "http://localhost:" + DefaultListenPort + EventLogRouterPath
 	client := http.Client{}
@@ -0,0 +1,391 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuning
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const maxChanges = 100
+
+// The routes are prefixed by the roles, so that the components running in the same process,
+// e.g. the proxy and the query node in standalone mode, serve their own knobs.
+
+// RouteKnobs returns the route of the role to list the knobs by GET, and tune a knob by POST,
+// e.g. /management/proxy/tuning/knobs.
+func RouteKnobs(role string) string {
+	return "/management/" + role + "/tuning/knobs"
+}
+
+// RouteReset returns the route of the role to clear the runtime value of a knob by POST,
+// the value from config sources takes effect again.
+func RouteReset(role string) string {
+	return "/management/" + role + "/tuning/reset"
+}
+
+// RouteChanges returns the route of the role to list the recent changes of the knobs by GET.
+func RouteChanges(role string) string {
+	return "/management/" + role + "/tuning/changes"
+}
+
+// Knob is a config which could be tuned at runtime, the component applies the new value by watching the key.
+type Knob struct {
+	Key string `json:"key"`
+	Doc string `json:"doc"`
+}
+
+// KnobStatus is the current value of a knob.
+type KnobStatus struct {
+	Knob
+	Value string `json:"value"`
+	// Overridden is true if the value is tuned at runtime instead of from the config sources
+	Overridden bool `json:"overridden"`
+}
+
+// Change is the audit record of a tuning.
+type Change struct {
+	Time      time.Time `json:"time"`
+	NodeID    int64     `json:"node_id"`
+	User      string    `json:"user,omitempty"`
+	Remote    string    `json:"remote"`
+	Key       string    `json:"key"`
+	PrevValue string    `json:"prev_value"`
+	Value     string    `json:"value"`
+	Reset     bool      `json:"reset,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// tuneRequest tunes or resets a knob, e.g. {"key": "proxy.slowLog.searchThreshold", "value": "500", "reason": "incident 42"}.
+type tuneRequest struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// Authenticator authenticates the request and returns the user to audit,
+// it writes the error response and returns false if the request is not permitted.
+type Authenticator func(w http.ResponseWriter, req *http.Request) (user string, ok bool)
+
+// TokenAuthenticator permits the requests carrying common.security.managementToken in the Authorization header,
+// optionally prefixed by the Bearer scheme, which is for the nodes that can't authenticate the users, e.g. query nodes.
+// All the requests are rejected if the token is not configured.
+func TokenAuthenticator(w http.ResponseWriter, req *http.Request) (string, bool) {
+	token := paramtable.Get().CommonCfg.ManagementToken.GetValue()
+	if token == "" {
+		writeError(w, http.StatusForbidden, "management token is not configured")
+		return "", false
+	}
+	got := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+		writeError(w, http.StatusUnauthorized, "invalid management token")
+		return "", false
+	}
+	return "", true
+}
+
+// ChangeStore keeps the recent changes of the knobs for auditing.
+type ChangeStore interface {
+	Save(change *Change) error
+	// List returns the recent changes, the oldest first.
+	List() ([]*Change, error)
+}
+
+// memoryChangeStore keeps the changes in memory, which are lost after restart.
+type memoryChangeStore struct {
+	mu      sync.Mutex
+	changes []*Change
+}
+
+func (s *memoryChangeStore) Save(change *Change) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changes = append(s.changes, change)
+	if len(s.changes) > maxChanges {
+		s.changes = s.changes[len(s.changes)-maxChanges:]
+	}
+	return nil
+}
+
+func (s *memoryChangeStore) List() ([]*Change, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Change{}, s.changes...), nil
+}
+
+// kvChangeStore persists the changes of the nodes of a role in the meta kv,
+// so that the audit survives the restarts of the nodes.
+type kvChangeStore struct {
+	kv     kv.TxnKV
+	prefix string
+}
+
+// NewKVChangeStore returns the store persisting the changes of the nodes of the role in the meta kv,
+// the recent changes are kept and the older ones are removed.
+func NewKVChangeStore(txnKV kv.TxnKV, role string) ChangeStore {
+	return &kvChangeStore{
+		kv:     txnKV,
+		prefix: "tuning/changes/" + role + "/",
+	}
+}
+
+func (s *kvChangeStore) Save(change *Change) error {
+	bs, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	// the keys are ordered by the time of the changes
+	key := fmt.Sprintf("%s%020d-%d", s.prefix, change.Time.UnixNano(), change.NodeID)
+	if err := s.kv.Save(key, string(bs)); err != nil {
+		return err
+	}
+	keys, _, err := s.kv.LoadWithPrefix(s.prefix)
+	if err != nil || len(keys) <= maxChanges {
+		return err
+	}
+	sort.Strings(keys)
+	return s.kv.MultiRemove(keys[:len(keys)-maxChanges])
+}
+
+func (s *kvChangeStore) List() ([]*Change, error) {
+	keys, values, err := s.kv.LoadWithPrefix(s.prefix)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*Change, 0, len(values))
+	for i, value := range values {
+		change := &Change{}
+		if err := json.Unmarshal([]byte(value), change); err != nil {
+			log.Warn("skip the invalid tuning change", zap.String("key", keys[i]), zap.Error(err))
+			continue
+		}
+		changes = append(changes, change)
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Time.Before(changes[j].Time) })
+	if len(changes) > maxChanges {
+		changes = changes[len(changes)-maxChanges:]
+	}
+	return changes, nil
+}
+
+// Tuner serves the management endpoints to inspect and tune the knobs of the node at runtime.
+// The tuned values are kept in memory, which are lost after restart,
+// the changes are logged and the recent ones are kept in the change store for auditing.
+type Tuner struct {
+	role  string
+	auth  Authenticator
+	store ChangeStore
+	knobs []Knob
+	index map[string]Knob
+}
+
+// NewTuner creates the tuner of the knobs of the role, the requests are authenticated by auth.
+// The changes are kept in memory if store is nil.
+func NewTuner(role string, auth Authenticator, store ChangeStore, knobs ...Knob) *Tuner {
+	if store == nil {
+		store = &memoryChangeStore{}
+	}
+	t := &Tuner{
+		role:  role,
+		auth:  auth,
+		store: store,
+		knobs: knobs,
+		index: make(map[string]Knob, len(knobs)),
+	}
+	for _, knob := range knobs {
+		t.index[knob.Key] = knob
+	}
+	return t
+}
+
+// Register registers the routes of the tuner of the role into the management server.
+func (t *Tuner) Register() {
+	management.Register(&management.Handler{
+		Path:        RouteKnobs(t.role),
+		HandlerFunc: t.ServeKnobs,
+	})
+	management.Register(&management.Handler{
+		Path:        RouteReset(t.role),
+		HandlerFunc: t.ServeReset,
+	})
+	management.Register(&management.Handler{
+		Path:        RouteChanges(t.role),
+		HandlerFunc: t.ServeChanges,
+	})
+}
+
+// ServeKnobs lists the knobs with their current values by GET, and tunes a knob by POST.
+func (t *Tuner) ServeKnobs(w http.ResponseWriter, req *http.Request) {
+	user, ok := t.auth(w, req)
+	if !ok {
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		params := paramtable.GetBaseTable()
+		status := make([]*KnobStatus, 0, len(t.knobs))
+		for _, knob := range t.knobs {
+			status = append(status, &KnobStatus{
+				Knob:       knob,
+				Value:      params.Get(knob.Key),
+				Overridden: params.IsOverridden(knob.Key),
+			})
+		}
+		writeJSON(w, status)
+	case http.MethodPost:
+		request, ok := t.decode(w, req)
+		if !ok {
+			return
+		}
+		prev := paramtable.GetBaseTable().Get(request.Key)
+		if err := paramtable.GetBaseTable().SaveAndNotify(request.Key, request.Value); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid value %s of %s, %s", request.Value, request.Key, err.Error()))
+			return
+		}
+		t.record(&Change{
+			Time:      time.Now(),
+			User:      user,
+			Remote:    req.RemoteAddr,
+			Key:       request.Key,
+			PrevValue: prev,
+			Value:     request.Value,
+			Reason:    request.Reason,
+		})
+		writeJSON(w, map[string]string{"msg": "OK"})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "only GET and POST are allowed")
+	}
+}
+
+// ServeReset clears the runtime value of a knob by POST.
+func (t *Tuner) ServeReset(w http.ResponseWriter, req *http.Request) {
+	user, ok := t.auth(w, req)
+	if !ok {
+		return
+	}
+	if req.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "only POST is allowed")
+		return
+	}
+	request, ok := t.decode(w, req)
+	if !ok {
+		return
+	}
+	params := paramtable.GetBaseTable()
+	prev := params.Get(request.Key)
+	params.ResetAndNotify(request.Key)
+	t.record(&Change{
+		Time:      time.Now(),
+		User:      user,
+		Remote:    req.RemoteAddr,
+		Key:       request.Key,
+		PrevValue: prev,
+		Value:     params.Get(request.Key),
+		Reset:     true,
+		Reason:    request.Reason,
+	})
+	writeJSON(w, map[string]string{"msg": "OK"})
+}
+
+// ServeChanges lists the recent changes of the knobs by GET, the latest first.
+func (t *Tuner) ServeChanges(w http.ResponseWriter, req *http.Request) {
+	if _, ok := t.auth(w, req); !ok {
+		return
+	}
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET is allowed")
+		return
+	}
+	changes, err := t.Changes()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list the changes, %s", err.Error()))
+		return
+	}
+	writeJSON(w, changes)
+}
+
+// Changes returns the recent changes of the knobs, the latest first.
+func (t *Tuner) Changes() ([]*Change, error) {
+	stored, err := t.store.List()
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*Change, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		changes = append(changes, stored[i])
+	}
+	return changes, nil
+}
+
+func (t *Tuner) decode(w http.ResponseWriter, req *http.Request) (*tuneRequest, bool) {
+	request := &tuneRequest{}
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request, %s", err.Error()))
+		return nil, false
+	}
+	if _, ok := t.index[request.Key]; !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a tunable knob", request.Key))
+		return nil, false
+	}
+	return request, true
+}
+
+func (t *Tuner) record(change *Change) {
+	log.Info("runtime knob tuned",
+		zap.String("key", change.Key),
+		zap.String("prevValue", change.PrevValue),
+		zap.String("value", change.Value),
+		zap.Bool("reset", change.Reset),
+		zap.String("user", change.User),
+		zap.String("remote", change.Remote),
+		zap.String("reason", change.Reason))
+
+	change.NodeID = paramtable.GetNodeID()
+	if err := t.store.Save(change); err != nil {
+		log.Warn("failed to save the tuning change for auditing", zap.String("key", change.Key), zap.Error(err))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(bs)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(fmt.Sprintf(`{"msg": %q}`, msg)))
+}
@@ -0,0 +1,142 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuning
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestTuner(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	key := params.ProxyCfg.SlowLog.SearchThreshold.Key
+	defer params.Reset(key)
+
+	tuner := NewTuner("proxy", func(w http.ResponseWriter, req *http.Request) (string, bool) {
+		return "admin", true
+	}, nil, Knob{Key: key, Doc: "threshold"})
+
+	t.Run("tune", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		tuner.ServeKnobs(w, httptest.NewRequest(http.MethodPost, RouteKnobs("proxy"),
+			strings.NewReader(`{"key": "proxy.slowLog.searchThreshold", "value": "500", "reason": "incident"}`)))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "500", params.ProxyCfg.SlowLog.SearchThreshold.GetValue())
+
+		w = httptest.NewRecorder()
+		tuner.ServeKnobs(w, httptest.NewRequest(http.MethodGet, RouteKnobs("proxy"), nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		var status []*KnobStatus
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		assert.Len(t, status, 1)
+		assert.Equal(t, "500", status[0].Value)
+		assert.True(t, status[0].Overridden)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		tuner.ServeReset(w, httptest.NewRequest(http.MethodPost, RouteReset("proxy"),
+			strings.NewReader(`{"key": "proxy.slowLog.searchThreshold"}`)))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, params.ProxyCfg.SlowLog.SearchThreshold.DefaultValue, params.ProxyCfg.SlowLog.SearchThreshold.GetValue())
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		tuner.ServeChanges(w, httptest.NewRequest(http.MethodGet, RouteChanges("proxy"), nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		var changes []*Change
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &changes))
+		assert.Len(t, changes, 2)
+		assert.True(t, changes[0].Reset)
+		assert.Equal(t, "500", changes[1].Value)
+		assert.Equal(t, "admin", changes[1].User)
+		assert.Equal(t, "incident", changes[1].Reason)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		tuner.ServeKnobs(w, httptest.NewRequest(http.MethodPost, RouteKnobs("proxy"),
+			strings.NewReader(`{"key": "proxy.port", "value": "1"}`)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = httptest.NewRecorder()
+		tuner.ServeKnobs(w, httptest.NewRequest(http.MethodPost, RouteKnobs("proxy"), strings.NewReader(`invalid`)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = httptest.NewRecorder()
+		tuner.ServeReset(w, httptest.NewRequest(http.MethodGet, RouteReset("proxy"), nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestRoutes(t *testing.T) {
+	assert.Equal(t, "/management/proxy/tuning/knobs", RouteKnobs("proxy"))
+	assert.Equal(t, "/management/querynode/tuning/reset", RouteReset("querynode"))
+	assert.Equal(t, "/management/querynode/tuning/changes", RouteChanges("querynode"))
+}
+
+func TestKVChangeStore(t *testing.T) {
+	memKV := memkv.NewMemoryKV()
+	store := NewKVChangeStore(memKV, "querynode")
+	now := time.Now()
+	for i := 0; i < maxChanges+5; i++ {
+		assert.NoError(t, store.Save(&Change{Time: now.Add(time.Duration(i) * time.Second), NodeID: 1, Key: "key", Value: strconv.Itoa(i)}))
+	}
+	// the changes of the other roles are not listed
+	assert.NoError(t, NewKVChangeStore(memKV, "proxy").Save(&Change{Time: now, Key: "key"}))
+
+	// the changes survive the restart, and the oldest ones are removed
+	changes, err := NewKVChangeStore(memKV, "querynode").List()
+	assert.NoError(t, err)
+	assert.Len(t, changes, maxChanges)
+	assert.Equal(t, "5", changes[0].Value)
+	assert.Equal(t, strconv.Itoa(maxChanges+4), changes[len(changes)-1].Value)
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	defer params.Reset(params.CommonCfg.ManagementToken.Key)
+
+	req := httptest.NewRequest(http.MethodGet, RouteKnobs("querynode"), nil)
+	w := httptest.NewRecorder()
+	_, ok := TokenAuthenticator(w, req)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	params.Save(params.CommonCfg.ManagementToken.Key, "secret")
+	w = httptest.NewRecorder()
+	_, ok = TokenAuthenticator(w, req)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req.Header.Set("Authorization", "Bearer secret")
+	_, ok = TokenAuthenticator(httptest.NewRecorder(), req)
+	assert.True(t, ok)
+}
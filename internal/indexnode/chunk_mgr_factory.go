@@ -9,6 +9,10 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
+// dataBucketProfile is the profile name of the bucket storing the binlogs to build index on,
+// see indexpb.CreateJobRequest.data_storage_config
+const dataBucketProfile = "data"
+
 type StorageFactory interface {
 	NewChunkManager(ctx context.Context, config *indexpb.StorageConfig) (storage.ChunkManager, error)
 }
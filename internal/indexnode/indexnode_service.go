@@ -30,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
@@ -91,6 +92,21 @@ func (i *IndexNode) CreateJob(ctx context.Context, req *indexpb.CreateJobRequest
 		metrics.IndexNodeBuildIndexTaskCounter.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.FailLabel).Inc()
 		return merr.Status(err), nil
 	}
+	if req.GetDataStorageConfig() != nil {
+		// the binlogs are in the bucket the collection is routed to, while index files are in the default one
+		dataCM, err := i.storageFactory.NewChunkManager(i.loopCtx, req.GetDataStorageConfig())
+		if err != nil {
+			log.Error("create chunk manager of data bucket failed", zap.String("bucket", req.GetDataStorageConfig().GetBucketName()),
+				zap.Error(err),
+			)
+			i.deleteTaskInfos(ctx, []taskKey{{ClusterID: req.GetClusterID(), BuildID: req.GetBuildID()}})
+			metrics.IndexNodeBuildIndexTaskCounter.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.FailLabel).Inc()
+			return merr.Status(err), nil
+		}
+		rcm := storage.NewRoutingChunkManager(cm, map[string]storage.ChunkManager{dataBucketProfile: dataCM})
+		rcm.PinRoute(req.GetCollectionID(), dataBucketProfile)
+		cm = rcm
+	}
 	var task task
 	if Params.CommonCfg.EnableStorageV2.GetAsBool() {
 		task = &indexBuildTaskV2{
@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexnode
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/indexcgowrapper"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
+)
+
+// jsonKeyStats collects the statistics of a json key in the segment in go, it's built and uploaded as an index file,
+// only uploading is supported.
+type jsonKeyStats struct {
+	ctx      context.Context
+	cm       storage.ChunkManager
+	filePath string
+	stats    *jsonkey.Stats
+}
+
+var _ indexcgowrapper.CodecIndex = (*jsonKeyStats)(nil)
+
+func newJSONKeyStats(ctx context.Context, cm storage.ChunkManager, filePath string, path []string, fieldData storage.FieldData) (*jsonKeyStats, error) {
+	data, ok := fieldData.(*storage.JSONFieldData)
+	if !ok {
+		return nil, errors.Newf("json key stats can only be built on json field, but got %T", fieldData)
+	}
+	return &jsonKeyStats{
+		ctx:      ctx,
+		cm:       cm,
+		filePath: filePath,
+		stats:    jsonkey.Build(path, data.Data),
+	}, nil
+}
+
+func (index *jsonKeyStats) Build(*indexcgowrapper.Dataset) error {
+	return errors.New("json key stats are built from field data")
+}
+
+func (index *jsonKeyStats) Serialize() ([]*storage.Blob, error) {
+	data, err := index.stats.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return []*storage.Blob{{Key: jsonkey.StatsFileKey, Value: data}}, nil
+}
+
+func (index *jsonKeyStats) GetIndexFileInfo() ([]*indexcgowrapper.IndexFileInfo, error) {
+	return nil, errors.New("json key stats don't support GetIndexFileInfo")
+}
+
+func (index *jsonKeyStats) Load([]*storage.Blob) error {
+	return errors.New("json key stats don't support Load")
+}
+
+func (index *jsonKeyStats) Delete() error {
+	return nil
+}
+
+func (index *jsonKeyStats) CleanLocalData() error {
+	return nil
+}
+
+// UpLoad writes the statistics to the index file path, returns the path and size of the file.
+func (index *jsonKeyStats) UpLoad() (map[string]int64, error) {
+	data, err := index.stats.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	if err := index.cm.Write(index.ctx, index.filePath, data); err != nil {
+		return nil, err
+	}
+	return map[string]int64{index.filePath: int64(len(data))}, nil
+}
+
+func (index *jsonKeyStats) UpLoadV2() (int64, error) {
+	return 0, errors.New("json key stats don't support storage v2")
+}
@@ -0,0 +1,60 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexnode
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
+)
+
+func TestJSONKeyStats(t *testing.T) {
+	ctx := context.Background()
+	rootPath := t.TempDir()
+	cm := storage.NewLocalChunkManager(storage.RootPath(rootPath))
+	filePath := path.Join(rootPath, "index", jsonkey.StatsFileKey)
+
+	_, err := newJSONKeyStats(ctx, cm, filePath, []string{"a"}, &storage.Int64FieldData{Data: []int64{1}})
+	assert.Error(t, err)
+
+	index, err := newJSONKeyStats(ctx, cm, filePath, []string{"a"}, &storage.JSONFieldData{
+		Data: [][]byte{[]byte(`{"a": 1}`), []byte(`{"b": 2}`)},
+	})
+	require.NoError(t, err)
+	files, err := index.UpLoad()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := cm.Read(ctx, filePath)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(data), files[filePath])
+	stats, err := jsonkey.Unmarshal(data)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, stats.Rows)
+	assert.EqualValues(t, 1, stats.Present)
+
+	blobs, err := index.Serialize()
+	require.NoError(t, err)
+	assert.Equal(t, jsonkey.StatsFileKey, blobs[0].Key)
+	assert.NoError(t, index.Delete())
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/indexcgowrapper"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
@@ -41,6 +42,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
 	"github.com/milvus-io/milvus/pkg/util/indexparams"
 	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
 )
@@ -100,6 +102,9 @@ func (it *indexBuildTaskV2) BuildIndex(ctx context.Context) error {
 	}
 
 	indexType := it.newIndexParams[common.IndexTypeKey]
+	if indexType == indexparamcheck.IndexJSONKeyStats {
+		return it.buildJSONKeyStats(ctx)
+	}
 	if indexType == indexparamcheck.IndexDISKANN {
 		// check index node support disk index
 		if !Params.IndexNodeCfg.EnableDisk.GetAsBool() {
@@ -458,6 +463,12 @@ func (it *indexBuildTask) BuildIndex(ctx context.Context) error {
 		log.Ctx(ctx).Warn("create build index info failed", zap.Error(err))
 		return err
 	}
+	if it.req.GetDataStorageConfig() != nil {
+		if err := buildIndexInfo.AppendDataStorageConfig(it.req.GetDataStorageConfig()); err != nil {
+			log.Ctx(ctx).Warn("append data storage config failed", zap.Error(err))
+			return err
+		}
+	}
 	err = buildIndexInfo.AppendFieldMetaInfo(it.collectionID, it.partitionID, it.segmentID, it.fieldID, it.fieldType)
 	if err != nil {
 		log.Ctx(ctx).Warn("append field meta failed", zap.Error(err))
@@ -524,6 +535,28 @@ func (it *indexBuildTask) BuildIndex(ctx context.Context) error {
 	return nil
 }
 
+// buildJSONKeyStats builds the json key stats in go instead of segcore, which has no index on json keys.
+func (it *indexBuildTask) buildJSONKeyStats(ctx context.Context) error {
+	path, err := jsonkey.ParsePath(it.newIndexParams[common.JSONPathKey])
+	if err != nil {
+		return err
+	}
+	it.currentIndexVersion = getCurrentIndexVersion(it.req.GetCurrentIndexVersion())
+	filePath := metautil.BuildSegmentIndexFilePath(it.cm.RootPath(), it.BuildID, it.req.GetIndexVersion(),
+		it.partitionID, it.segmentID, jsonkey.StatsFileKey)
+	it.index, err = newJSONKeyStats(ctx, it.cm, filePath, path, it.fieldData)
+	if err != nil {
+		log.Ctx(ctx).Warn("failed to build json key stats", zap.Error(err))
+		return err
+	}
+
+	buildIndexLatency := it.tr.RecordSpan()
+	metrics.IndexNodeKnowhereBuildIndexLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10)).Observe(buildIndexLatency.Seconds())
+	log.Ctx(ctx).Info("Successfully build json key stats", zap.Int64("buildID", it.BuildID), zap.Int64("Collection", it.collectionID),
+		zap.Int64("SegmentID", it.segmentID), zap.Strings("path", path))
+	return nil
+}
+
 func (it *indexBuildTask) SaveIndexFiles(ctx context.Context) error {
 	gcIndex := func() {
 		if err := it.index.Delete(); err != nil {
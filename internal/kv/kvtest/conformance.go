@@ -0,0 +1,242 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kvtest provides the conformance test suite of the meta kv backends,
+// every backend is expected to pass it so that the metastore works the same on any of them.
+package kvtest
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/kv/predicates"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// MetaKvSuite checks the semantics of kv.MetaKv, and kv.WatchKV if the backend implements it.
+//
+// Run it in the test of the backend with suite.Run, NewKV creates the kv rooted at the given path.
+type MetaKvSuite struct {
+	suite.Suite
+
+	NewKV func(rootPath string) kv.MetaKv
+
+	rootPath string
+	kv       kv.MetaKv
+}
+
+func (s *MetaKvSuite) SetupTest() {
+	s.rootPath = path.Join("unittest/kvtest", funcutil.RandomString(8))
+	s.kv = s.NewKV(s.rootPath)
+}
+
+func (s *MetaKvSuite) TearDownTest() {
+	s.NoError(s.kv.RemoveWithPrefix(""))
+	s.kv.Close()
+}
+
+func (s *MetaKvSuite) TestSaveLoad() {
+	s.NoError(s.kv.Save("k1", "v1"))
+	s.NoError(s.kv.Save("empty", ""))
+
+	val, err := s.kv.Load("k1")
+	s.NoError(err)
+	s.Equal("v1", val)
+
+	val, err = s.kv.Load("empty")
+	s.NoError(err)
+	s.Equal("", val)
+
+	_, err = s.kv.Load("not_exist")
+	s.ErrorIs(err, merr.ErrIoKeyNotFound)
+
+	has, err := s.kv.Has("k1")
+	s.NoError(err)
+	s.True(has)
+	has, err = s.kv.HasPrefix("not_exist")
+	s.NoError(err)
+	s.False(has)
+
+	s.NoError(s.kv.Remove("k1"))
+	has, err = s.kv.Has("k1")
+	s.NoError(err)
+	s.False(has)
+}
+
+func (s *MetaKvSuite) TestLoadWithPrefix() {
+	s.NoError(s.kv.MultiSave(map[string]string{"p/b": "2", "p/a": "1", "q/a": "3"}))
+
+	keys, values, err := s.kv.LoadWithPrefix("p")
+	s.NoError(err)
+	s.Equal([]string{s.kv.GetPath("p/a"), s.kv.GetPath("p/b")}, keys)
+	s.Equal([]string{"1", "2"}, values)
+
+	s.NoError(s.kv.RemoveWithPrefix("p"))
+	keys, _, err = s.kv.LoadWithPrefix("p")
+	s.NoError(err)
+	s.Empty(keys)
+	keys, _, err = s.kv.LoadWithPrefix("q")
+	s.NoError(err)
+	s.Equal(1, len(keys))
+}
+
+func (s *MetaKvSuite) TestTransaction() {
+	s.NoError(s.kv.MultiSave(map[string]string{"lease": "1", "old/a": "a", "old/b": "b"}))
+
+	// the predicate is not satisfied, nothing is applied.
+	err := s.kv.MultiSaveAndRemove(map[string]string{"new": "v"}, []string{"old/a"}, predicates.ValueEqual("lease", "2"))
+	s.Error(err)
+	has, err := s.kv.Has("new")
+	s.NoError(err)
+	s.False(has)
+	has, err = s.kv.Has("old/a")
+	s.NoError(err)
+	s.True(has)
+
+	s.NoError(s.kv.MultiSaveAndRemove(map[string]string{"new": "v"}, []string{"old/a"}, predicates.ValueEqual("lease", "1")))
+	vals, err := s.kv.MultiLoad([]string{"new", "old/b"})
+	s.NoError(err)
+	s.Equal([]string{"v", "b"}, vals)
+	has, err = s.kv.Has("old/a")
+	s.NoError(err)
+	s.False(has)
+
+	s.NoError(s.kv.MultiSaveAndRemoveWithPrefix(map[string]string{"new2": "v"}, []string{"old"}, predicates.ValueEqual("lease", "1")))
+	has, err = s.kv.HasPrefix("old")
+	s.NoError(err)
+	s.False(has)
+	has, err = s.kv.Has("new2")
+	s.NoError(err)
+	s.True(has)
+}
+
+func (s *MetaKvSuite) TestWalkWithPrefix() {
+	saves := make(map[string]string)
+	for i := 0; i < 25; i++ {
+		saves[fmt.Sprintf("walk/%02d", i)] = fmt.Sprint(i)
+	}
+	s.NoError(s.kv.MultiSave(saves))
+
+	for _, pageSize := range []int{1, 7, 25, 100} {
+		keys := make([]string, 0)
+		err := s.kv.WalkWithPrefix("walk", pageSize, func(k []byte, v []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+		s.NoError(err)
+		s.Equal(25, len(keys))
+		s.IsIncreasing(keys)
+	}
+
+	err := s.kv.WalkWithPrefix("walk", 10, func(k []byte, v []byte) error {
+		return fmt.Errorf("mock error")
+	})
+	s.Error(err)
+}
+
+func (s *MetaKvSuite) TestWatchWithPrefix() {
+	watchKV, ok := s.kv.(kv.WatchKV)
+	if !ok {
+		s.T().Skip("the backend is not watchable")
+	}
+
+	ch := watchKV.WatchWithPrefix("watch")
+	s.waitCreated(ch)
+	s.NoError(s.kv.Save("watch/a", "1"))
+	ev := s.nextEvent(ch)
+	s.Equal(clientv3.EventTypePut, ev.Type)
+	s.Equal(s.kv.GetPath("watch/a"), string(ev.Kv.Key))
+	s.Equal("1", string(ev.Kv.Value))
+
+	s.NoError(s.kv.Remove("watch/a"))
+	ev = s.nextEvent(ch)
+	s.Equal(clientv3.EventTypeDelete, ev.Type)
+	s.Equal(s.kv.GetPath("watch/a"), string(ev.Kv.Key))
+}
+
+func (s *MetaKvSuite) TestWatchDeleteAndPut() {
+	watchKV, ok := s.kv.(kv.WatchKV)
+	if !ok {
+		s.T().Skip("the backend is not watchable")
+	}
+
+	ch := watchKV.WatchWithPrefix("watch")
+	s.waitCreated(ch)
+	s.NoError(s.kv.Save("watch/a", "1"))
+	s.NoError(s.kv.Remove("watch/a"))
+	s.NoError(s.kv.Save("watch/a", "2"))
+
+	// every change is reported in order, even if they happen in a short time
+	events := s.nextEvents(ch, 3)
+	s.Equal(clientv3.EventTypePut, events[0].Type)
+	s.Equal("1", string(events[0].Kv.Value))
+	s.Equal(clientv3.EventTypeDelete, events[1].Type)
+	s.Equal(clientv3.EventTypePut, events[2].Type)
+	s.Equal("2", string(events[2].Kv.Value))
+}
+
+func (s *MetaKvSuite) waitCreated(ch clientv3.WatchChan) {
+	select {
+	case resp, ok := <-ch:
+		s.Require().True(ok)
+		s.Require().NoError(resp.Err())
+		s.Require().True(resp.Created)
+	case <-time.After(10 * time.Second):
+		s.FailNow("wait watch created timeout")
+	}
+}
+
+// nextEvent returns the next event of the watch, the responses without events are skipped.
+func (s *MetaKvSuite) nextEvent(ch clientv3.WatchChan) *clientv3.Event {
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case resp, ok := <-ch:
+			s.Require().True(ok)
+			s.Require().NoError(resp.Err())
+			if len(resp.Events) > 0 {
+				return resp.Events[0]
+			}
+		case <-timeout:
+			s.FailNow("wait watch event timeout")
+			return nil
+		}
+	}
+}
+
+// nextEvents returns the next n events of the watch, which may be across several responses.
+func (s *MetaKvSuite) nextEvents(ch clientv3.WatchChan, n int) []*clientv3.Event {
+	events := make([]*clientv3.Event, 0, n)
+	timeout := time.After(10 * time.Second)
+	for len(events) < n {
+		select {
+		case resp, ok := <-ch:
+			s.Require().True(ok)
+			s.Require().NoError(resp.Err())
+			events = append(events, resp.Events...)
+		case <-timeout:
+			s.FailNow("wait watch events timeout")
+			return nil
+		}
+	}
+	return events
+}
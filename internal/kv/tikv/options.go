@@ -19,7 +19,9 @@ package tikv
 import "time"
 
 type tikvOpt struct {
-	requestTimeout time.Duration
+	requestTimeout     time.Duration
+	watchInterval      time.Duration
+	changeLogRetention time.Duration
 }
 
 type Option func(*tikvOpt)
@@ -30,8 +32,25 @@ func WithRequestTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithWatchInterval sets the interval to poll the changes for the watchers.
+func WithWatchInterval(interval time.Duration) Option {
+	return func(opt *tikvOpt) {
+		opt.watchInterval = interval
+	}
+}
+
+// WithChangeLogRetention sets how long the changes are kept for the watchers,
+// the watchers falling behind more than it are canceled as compacted.
+func WithChangeLogRetention(retention time.Duration) Option {
+	return func(opt *tikvOpt) {
+		opt.changeLogRetention = retention
+	}
+}
+
 func defaultOption() *tikvOpt {
 	return &tikvOpt{
-		requestTimeout: defaultRequestTimeout,
+		requestTimeout:     defaultRequestTimeout,
+		watchInterval:      defaultWatchInterval,
+		changeLogRetention: defaultChangeLogRetention,
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"path"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -30,6 +31,7 @@ import (
 	"github.com/tikv/client-go/v2/txnkv"
 	"github.com/tikv/client-go/v2/txnkv/transaction"
 	"github.com/tikv/client-go/v2/txnkv/txnsnapshot"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/kv"
@@ -66,6 +68,9 @@ var SnapshotScanSize int
 // defaultRequestTimeout is the default timeout for tikv request.
 const (
 	defaultRequestTimeout = 10 * time.Second
+	defaultWatchInterval  = time.Second
+	// defaultChangeLogRetention is how long the changes are kept for the watchers.
+	defaultChangeLogRetention = 10 * time.Minute
 )
 
 var EmptyValueByte = []byte(EmptyValueString)
@@ -91,14 +96,21 @@ var (
 )
 
 // implementation assertion
-var _ kv.MetaKv = (*txnTiKV)(nil)
+var _ kv.WatchKV = (*txnTiKV)(nil)
 
-// txnTiKV implements MetaKv and TxnKV interface. It supports processing multiple kvs within one transaction.
+// txnTiKV implements WatchKV, MetaKv and TxnKV interface. It supports processing multiple kvs within one transaction.
 type txnTiKV struct {
 	txn      *txnkv.Client
 	rootPath string
 
 	requestTimeout time.Duration
+	watchInterval  time.Duration
+
+	changeLogRetention   time.Duration
+	lastChangeLogCompact atomic.Int64 // the unix milliseconds of the last compaction of the change log
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
 }
 
 // NewTiKV creates a new txnTiKV client.
@@ -111,15 +123,21 @@ func NewTiKV(txn *txnkv.Client, rootPath string, options ...Option) *txnTiKV {
 	}
 
 	kv := &txnTiKV{
-		txn:            txn,
-		rootPath:       rootPath,
-		requestTimeout: opt.requestTimeout,
+		txn:                txn,
+		rootPath:           rootPath,
+		requestTimeout:     opt.requestTimeout,
+		watchInterval:      opt.watchInterval,
+		changeLogRetention: opt.changeLogRetention,
+		closeCh:            make(chan struct{}),
 	}
 	return kv
 }
 
-// Close closes the connection to TiKV.
+// Close closes the connection to TiKV, the watchers are stopped.
 func (kv *txnTiKV) Close() {
+	kv.closeOnce.Do(func() {
+		close(kv.closeCh)
+	})
 	log.Info("txnTiKV closed", zap.String("path", kv.rootPath))
 }
 
@@ -341,6 +359,7 @@ func (kv *txnTiKV) MultiSave(kvs map[string]string) error {
 	// Defer a rollback only if the transaction hasn't been committed
 	defer rollbackOnFailure(&loggingErr, txn)
 
+	changes := make(changeSet, 0, len(kvs))
 	for key, value := range kvs {
 		key = path.Join(kv.rootPath, key)
 		// Check if value is empty or taking reserved EmptyValue
@@ -355,8 +374,9 @@ func (kv *txnTiKV) MultiSave(kvs map[string]string) error {
 			loggingErr = errors.Wrap(err, fmt.Sprintf("Failed to set (%s:%s) for MultiSave()", key, value))
 			return loggingErr
 		}
+		changes.put([]byte(key), byteValue)
 	}
-	err = kv.executeTxn(ctx, txn)
+	err = kv.executeTxn(ctx, txn, changes)
 	if err != nil {
 		loggingErr = errors.Wrap(err, "Failed to commit for MultiSave()")
 		return loggingErr
@@ -396,6 +416,7 @@ func (kv *txnTiKV) MultiRemove(keys []string) error {
 	// Defer a rollback only if the transaction hasn't been committed
 	defer rollbackOnFailure(&loggingErr, txn)
 
+	changes := make(changeSet, 0, len(keys))
 	for _, key := range keys {
 		key = path.Join(kv.rootPath, key)
 		loggingErr = txn.Delete([]byte(key))
@@ -403,9 +424,10 @@ func (kv *txnTiKV) MultiRemove(keys []string) error {
 			loggingErr = errors.Wrap(err, fmt.Sprintf("Failed to delete %s for MultiRemove", key))
 			return loggingErr
 		}
+		changes.delete([]byte(key))
 	}
 
-	err = kv.executeTxn(ctx, txn)
+	err = kv.executeTxn(ctx, txn, changes)
 	if err != nil {
 		loggingErr = errors.Wrap(err, "Failed to commit for MultiRemove()")
 		return loggingErr
@@ -415,23 +437,17 @@ func (kv *txnTiKV) MultiRemove(keys []string) error {
 }
 
 // RemoveWithPrefix removes the keys for the given prefix.
+// The keys are removed in a transaction instead of DeleteRange, so the removals are recorded in the change log.
 func (kv *txnTiKV) RemoveWithPrefix(prefix string) error {
 	start := time.Now()
-	prefix = path.Join(kv.rootPath, prefix)
-	ctx, cancel := context.WithTimeout(context.Background(), kv.requestTimeout)
-	defer cancel()
-
 	var loggingErr error
-	defer logWarnOnFailure(&loggingErr, "txnTiKV RemoveWithPrefix() error", zap.String("prefix", prefix))
+	defer logWarnOnFailure(&loggingErr, "txnTiKV RemoveWithPrefix() error", zap.String("prefix", path.Join(kv.rootPath, prefix)))
 
-	startKey := []byte(prefix)
-	endKey := tikv.PrefixNextKey(startKey)
-	_, err := kv.txn.DeleteRange(ctx, startKey, endKey, 1)
-	if err != nil {
-		loggingErr = errors.Wrap(err, "Failed to DeleteRange for RemoveWithPrefix")
+	loggingErr = kv.MultiSaveAndRemoveWithPrefix(nil, []string{prefix})
+	if loggingErr != nil {
 		return loggingErr
 	}
-	CheckElapseAndWarn(start, "Slow txnTiKV RemoveWithPrefix() operation", zap.String("prefix", prefix))
+	CheckElapseAndWarn(start, "Slow txnTiKV RemoveWithPrefix() operation", zap.String("prefix", path.Join(kv.rootPath, prefix)))
 	return nil
 }
 
@@ -466,6 +482,7 @@ func (kv *txnTiKV) MultiSaveAndRemove(saves map[string]string, removals []string
 		}
 	}
 
+	changes := make(changeSet, 0, len(saves)+len(removals))
 	for key, value := range saves {
 		key = path.Join(kv.rootPath, key)
 		// Check if value is empty or taking reserved EmptyValue
@@ -479,6 +496,7 @@ func (kv *txnTiKV) MultiSaveAndRemove(saves map[string]string, removals []string
 			loggingErr = errors.Wrap(err, fmt.Sprintf("Failed to set (%s:%s) for MultiSaveAndRemove", key, value))
 			return loggingErr
 		}
+		changes.put([]byte(key), byteValue)
 	}
 
 	for _, key := range removals {
@@ -487,9 +505,10 @@ func (kv *txnTiKV) MultiSaveAndRemove(saves map[string]string, removals []string
 			loggingErr = errors.Wrap(err, fmt.Sprintf("Failed to delete %s for MultiSaveAndRemove", key))
 			return loggingErr
 		}
+		changes.delete([]byte(key))
 	}
 
-	err = kv.executeTxn(ctx, txn)
+	err = kv.executeTxn(ctx, txn, changes)
 	if err != nil {
 		loggingErr = errors.Wrap(err, "Failed to commit for MultiSaveAndRemove")
 		return loggingErr
@@ -529,6 +548,7 @@ func (kv *txnTiKV) MultiSaveAndRemoveWithPrefix(saves map[string]string, removal
 		}
 	}
 
+	changes := make(changeSet, 0, len(saves))
 	// Save key-value pairs
 	for key, value := range saves {
 		key = path.Join(kv.rootPath, key)
@@ -543,6 +563,7 @@ func (kv *txnTiKV) MultiSaveAndRemoveWithPrefix(saves map[string]string, removal
 			loggingErr = errors.Wrap(err, fmt.Sprintf("Failed to set (%s:%s) for MultiSaveAndRemoveWithPrefix()", key, value))
 			return loggingErr
 		}
+		changes.put([]byte(key), byteValue)
 	}
 	// Remove keys with prefix
 	for _, prefix := range removals {
@@ -566,6 +587,7 @@ func (kv *txnTiKV) MultiSaveAndRemoveWithPrefix(saves map[string]string, removal
 				loggingErr = errors.Wrap(err, fmt.Sprintf("Failed to delete %s for MultiSaveAndRemoveWithPrefix", string(key)))
 				return loggingErr
 			}
+			changes.delete(append([]byte{}, key...))
 
 			// Move the iterator to the next key
 			err = iter.Next()
@@ -575,7 +597,7 @@ func (kv *txnTiKV) MultiSaveAndRemoveWithPrefix(saves map[string]string, removal
 			}
 		}
 	}
-	err = kv.executeTxn(ctx, txn)
+	err = kv.executeTxn(ctx, txn, changes)
 	if err != nil {
 		loggingErr = errors.Wrap(err, "Failed to commit for MultiSaveAndRemoveWithPrefix")
 		return loggingErr
@@ -628,8 +650,11 @@ func (kv *txnTiKV) WalkWithPrefix(prefix string, paginationSize int, fn func([]b
 	return nil
 }
 
-func (kv *txnTiKV) executeTxn(ctx context.Context, txn *transaction.KVTxn) error {
+func (kv *txnTiKV) executeTxn(ctx context.Context, txn *transaction.KVTxn, changes changeSet) error {
 	start := timerecord.NewTimeRecorder("executeTxn")
+	if err := kv.appendChangeLog(txn, changes); err != nil {
+		return err
+	}
 
 	elapsed := start.ElapseSpan()
 	metrics.MetaOpCounter.WithLabelValues(metrics.MetaTxnLabel, metrics.TotalLabel).Inc()
@@ -699,6 +724,11 @@ func (kv *txnTiKV) putTiKVMeta(ctx context.Context, key, val string) error {
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("Failed to set value for key %s in putTiKVMeta", key))
 	}
+	changes := changeSet{}
+	changes.put([]byte(key), byteValue)
+	if err = kv.appendChangeLog(txn, changes); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Failed to record the change of key %s in putTiKVMeta", key))
+	}
 	err = commitTxn(ctx1, txn)
 
 	elapsed := start.ElapseSpan()
@@ -731,6 +761,11 @@ func (kv *txnTiKV) removeTiKVMeta(ctx context.Context, key string) error {
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("Failed to remove key %s in removeTiKVMeta", key))
 	}
+	changes := changeSet{}
+	changes.delete([]byte(key))
+	if err = kv.appendChangeLog(txn, changes); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Failed to record the change of key %s in removeTiKVMeta", key))
+	}
 	err = commitTxn(ctx1, txn)
 
 	elapsed := start.ElapseSpan()
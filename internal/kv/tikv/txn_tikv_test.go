@@ -17,6 +17,7 @@
 package tikv
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sort"
@@ -26,10 +27,15 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/tikv/client-go/v2/oracle"
 	"github.com/tikv/client-go/v2/txnkv"
 	"github.com/tikv/client-go/v2/txnkv/transaction"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"golang.org/x/exp/maps"
 
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/kv/kvtest"
 	"github.com/milvus-io/milvus/internal/kv/predicates"
 )
 
@@ -640,3 +646,52 @@ func TestTxnWithPredicates(t *testing.T) {
 		})
 	}
 }
+
+func TestTiKVConformance(t *testing.T) {
+	suite.Run(t, &kvtest.MetaKvSuite{NewKV: func(rootPath string) kv.MetaKv {
+		return NewTiKV(txnClient, rootPath, WithWatchInterval(100*time.Millisecond))
+	}})
+}
+
+func TestChangeLogKey(t *testing.T) {
+	key := changeLogKey(123)
+	assert.Equal(t, changeLogPrefix+"00000000000000000123", string(key))
+	ts, err := parseChangeLogKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(123), ts)
+
+	// the keys are ordered by the timestamps
+	assert.True(t, bytes.Compare(changeLogKey(99), changeLogKey(100)) < 0)
+}
+
+func TestWatchDeleteAndPut(t *testing.T) {
+	kv := NewTiKV(txnClient, "/unittest/watch", WithWatchInterval(time.Hour))
+	defer kv.RemoveWithPrefix("")
+	defer kv.Close()
+
+	ts, err := txnClient.CurrentTimestamp(oracle.GlobalTxnScope)
+	require.NoError(t, err)
+	reader := kv.newChangeLogReader(kv.GetPath("k"), true)
+	require.NoError(t, reader.init(ts))
+
+	require.NoError(t, kv.Save("k1", "v1"))
+	require.NoError(t, kv.Remove("k1"))
+	require.NoError(t, kv.Save("k1", "v2"))
+
+	ts, err = txnClient.CurrentTimestamp(oracle.GlobalTxnScope)
+	require.NoError(t, err)
+	events, err := reader.poll(ts)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(events))
+	assert.Equal(t, clientv3.EventTypePut, events[0].Type)
+	assert.Equal(t, "v1", string(events[0].Kv.Value))
+	assert.Equal(t, clientv3.EventTypeDelete, events[1].Type)
+	assert.Equal(t, "v1", string(events[1].PrevKv.Value))
+	assert.Equal(t, clientv3.EventTypePut, events[2].Type)
+	assert.Equal(t, "v2", string(events[2].Kv.Value))
+
+	// the changes are reported once
+	events, err = reader.poll(ts)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(events))
+}
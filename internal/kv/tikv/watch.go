@@ -0,0 +1,406 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tikv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	tikverr "github.com/tikv/client-go/v2/error"
+	tikv "github.com/tikv/client-go/v2/kv"
+	"github.com/tikv/client-go/v2/oracle"
+	"github.com/tikv/client-go/v2/txnkv/transaction"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	v3rpc "go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// TiKV has no native watch, every transaction of txnTiKV records its changes in the change log,
+// keyed by the start timestamp of the transaction, and the watchers poll the change log periodically
+// and report the changes as etcd events, so that the callers of WatchKV work the same with both backends.
+// The revisions are the TiKV timestamps of the polls, each change is reported in order,
+// e.g. a key deleted and put again in one interval gets both the delete and the put events.
+
+const (
+	// changeLogPrefix is shared by all the txnTiKV instances, it's out of any root path,
+	// so the change log is invisible to the kv operations.
+	changeLogPrefix = "__milvus_tikv_changelog__/"
+	// changeLogCompactedKey records the timestamp before which the change log is removed.
+	changeLogCompactedKey = "__milvus_tikv_changelog_compacted__"
+)
+
+type changeType string
+
+const (
+	changePut    changeType = "put"
+	changeDelete changeType = "delete"
+)
+
+type change struct {
+	Type  changeType `json:"type"`
+	Key   []byte     `json:"key"`
+	Value []byte     `json:"value,omitempty"`
+}
+
+// changeSet collects the changes of a transaction.
+type changeSet []*change
+
+func (cs *changeSet) put(key, value []byte) {
+	*cs = append(*cs, &change{Type: changePut, Key: key, Value: value})
+}
+
+func (cs *changeSet) delete(key []byte) {
+	*cs = append(*cs, &change{Type: changeDelete, Key: key})
+}
+
+func changeLogKey(ts uint64) []byte {
+	return []byte(fmt.Sprintf("%s%020d", changeLogPrefix, ts))
+}
+
+func parseChangeLogKey(key []byte) (uint64, error) {
+	return strconv.ParseUint(strings.TrimPrefix(string(key), changeLogPrefix), 10, 64)
+}
+
+// appendChangeLog records the changes within the transaction, so they are committed atomically.
+func (kv *txnTiKV) appendChangeLog(txn *transaction.KVTxn, changes changeSet) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	value, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	if err := txn.Set(changeLogKey(txn.StartTS()), value); err != nil {
+		return err
+	}
+	kv.tryCompactChangeLog()
+	return nil
+}
+
+// tryCompactChangeLog removes the change log older than the retention in background, at most once per half of the retention.
+func (kv *txnTiKV) tryCompactChangeLog() {
+	now := time.Now()
+	last := kv.lastChangeLogCompact.Load()
+	if now.Sub(time.UnixMilli(last)) < kv.changeLogRetention/2 || !kv.lastChangeLogCompact.CompareAndSwap(last, now.UnixMilli()) {
+		return
+	}
+	go func() {
+		if err := kv.compactChangeLog(); err != nil {
+			log.Warn("txnTiKV failed to compact the change log", zap.Error(err))
+		}
+	}()
+}
+
+func (kv *txnTiKV) compactChangeLog() error {
+	ctx, cancel := context.WithTimeout(context.Background(), kv.requestTimeout)
+	defer cancel()
+
+	ts, err := kv.txn.CurrentTimestamp(oracle.GlobalTxnScope)
+	if err != nil {
+		return err
+	}
+	retention := oracle.ComposeTS(kv.changeLogRetention.Milliseconds(), 0)
+	if ts <= retention {
+		return nil
+	}
+	compactTS := ts - retention
+
+	// the compacted timestamp is recorded before removing, so the watchers fall behind are always canceled
+	txn, err := beginTxn(kv.txn)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnFailure(&err, txn)
+	prev, err := loadCompactedTS(ctx, txn.Get)
+	if err != nil {
+		return err
+	}
+	if prev >= compactTS {
+		return txn.Rollback()
+	}
+	if err = txn.Set([]byte(changeLogCompactedKey), []byte(strconv.FormatUint(compactTS, 10))); err != nil {
+		return err
+	}
+	if err = commitTxn(ctx, txn); err != nil {
+		return err
+	}
+	_, deleteErr := kv.txn.DeleteRange(ctx, []byte(changeLogPrefix), changeLogKey(compactTS), 1)
+	return deleteErr
+}
+
+func loadCompactedTS(ctx context.Context, get func(context.Context, []byte) ([]byte, error)) (uint64, error) {
+	val, err := get(ctx, []byte(changeLogCompactedKey))
+	if err != nil {
+		if tikverr.IsErrNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return strconv.ParseUint(string(val), 10, 64)
+}
+
+// Watch starts watching a key, returns a watch channel.
+func (kv *txnTiKV) Watch(key string) clientv3.WatchChan {
+	return kv.watch(path.Join(kv.rootPath, key), false, 0)
+}
+
+// WatchWithPrefix starts watching a key with prefix, returns a watch channel.
+func (kv *txnTiKV) WatchWithPrefix(key string) clientv3.WatchChan {
+	return kv.watch(path.Join(kv.rootPath, key), true, 0)
+}
+
+// WatchWithRevision starts watching a key with prefix from the revision, returns a watch channel.
+// The revision must be within the retention of the change log, otherwise the watch is canceled as compacted.
+func (kv *txnTiKV) WatchWithRevision(key string, revision int64) clientv3.WatchChan {
+	return kv.watch(path.Join(kv.rootPath, key), true, revision)
+}
+
+// changeLogReader reads the changes of the watched keys from the change log.
+//
+// The change log is ordered by the start timestamps of the transactions, while it's visible after committed,
+// so the reader looks back for the transactions started before the last poll but committed after it,
+// and remembers the ones reported.
+type changeLogReader struct {
+	kv         *txnTiKV
+	key        []byte
+	withPrefix bool
+	lookback   uint64
+
+	lastTS   uint64
+	reported map[uint64]struct{}
+}
+
+func (kv *txnTiKV) newChangeLogReader(key string, withPrefix bool) *changeLogReader {
+	return &changeLogReader{
+		kv:         kv,
+		key:        []byte(key),
+		withPrefix: withPrefix,
+		// the transactions never last longer than the request timeout
+		lookback: oracle.ComposeTS(2*kv.requestTimeout.Milliseconds(), 0),
+		reported: make(map[uint64]struct{}),
+	}
+}
+
+func (r *changeLogReader) match(key []byte) bool {
+	if r.withPrefix {
+		return bytes.HasPrefix(key, r.key)
+	}
+	return bytes.Equal(key, r.key)
+}
+
+func (r *changeLogReader) lowerTS() uint64 {
+	if r.lastTS <= r.lookback {
+		return 0
+	}
+	return r.lastTS - r.lookback
+}
+
+// init starts reading after the timestamp, the changes committed before it are skipped.
+func (r *changeLogReader) init(ts uint64) error {
+	r.lastTS = ts
+	_, err := r.read(ts, false)
+	return err
+}
+
+// poll returns the changes committed since the last poll, up to the timestamp.
+func (r *changeLogReader) poll(ts uint64) ([]*clientv3.Event, error) {
+	events, err := r.read(ts, true)
+	if err != nil {
+		return nil, err
+	}
+	r.lastTS = ts
+	return events, nil
+}
+
+func (r *changeLogReader) read(ts uint64, report bool) ([]*clientv3.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.kv.requestTimeout)
+	defer cancel()
+
+	lower := r.lowerTS()
+	ss := r.kv.txn.GetSnapshot(ts)
+	ss.SetScanBatchSize(SnapshotScanSize)
+	compacted, err := loadCompactedTS(ctx, ss.Get)
+	if err != nil {
+		return nil, err
+	}
+	if compacted > lower {
+		return nil, v3rpc.ErrCompacted
+	}
+
+	iter, err := ss.Iter(changeLogKey(lower+1), tikv.PrefixNextKey(changeLogKey(ts)))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	events := make([]*clientv3.Event, 0)
+	for iter.Valid() {
+		startTS, err := parseChangeLogKey(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := r.reported[startTS]; !ok {
+			r.reported[startTS] = struct{}{}
+			if report {
+				changes := make(changeSet, 0)
+				if err := json.Unmarshal(iter.Value(), &changes); err != nil {
+					return nil, err
+				}
+				evs, err := r.toEvents(ctx, startTS, ts, changes)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, evs...)
+			}
+		}
+		if err := iter.Next(); err != nil {
+			return nil, err
+		}
+	}
+	for startTS := range r.reported {
+		if startTS <= lower {
+			delete(r.reported, startTS)
+		}
+	}
+	return events, nil
+}
+
+// toEvents converts the changes of the watched keys to events,
+// the previous values of the deleted keys are read from the snapshot at the start of the transaction.
+func (r *changeLogReader) toEvents(ctx context.Context, startTS uint64, revision uint64, changes changeSet) ([]*clientv3.Event, error) {
+	var prevSnapshot interface {
+		Get(context.Context, []byte) ([]byte, error)
+	}
+	events := make([]*clientv3.Event, 0)
+	for _, c := range changes {
+		if !r.match(c.Key) {
+			continue
+		}
+		switch c.Type {
+		case changePut:
+			events = append(events, &clientv3.Event{
+				Type: mvccpb.PUT,
+				Kv:   &mvccpb.KeyValue{Key: c.Key, Value: restoreEmptyValue(c.Value), ModRevision: int64(revision)},
+			})
+		case changeDelete:
+			if prevSnapshot == nil {
+				prevSnapshot = r.kv.txn.GetSnapshot(startTS)
+			}
+			prev, err := prevSnapshot.Get(ctx, c.Key)
+			if err != nil && !tikverr.IsErrNotFound(err) {
+				return nil, err
+			}
+			ev := &clientv3.Event{
+				Type: mvccpb.DELETE,
+				Kv:   &mvccpb.KeyValue{Key: c.Key, ModRevision: int64(revision)},
+			}
+			if err == nil {
+				ev.PrevKv = &mvccpb.KeyValue{Key: c.Key, Value: restoreEmptyValue(prev)}
+			}
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+func (kv *txnTiKV) watch(key string, withPrefix bool, revision int64) clientv3.WatchChan {
+	ch := make(chan clientv3.WatchResponse, 1)
+	// the start timestamp is taken before returning, so the changes made after it are never missed.
+	ts := uint64(revision)
+	var err error
+	if ts == 0 {
+		ts, err = kv.txn.CurrentTimestamp(oracle.GlobalTxnScope)
+	}
+	go func() {
+		defer close(ch)
+		if err != nil {
+			kv.cancelWatch(ch, key, err)
+			return
+		}
+		reader := kv.newChangeLogReader(key, withPrefix)
+		if err := reader.init(ts); err != nil {
+			kv.cancelWatch(ch, key, err)
+			return
+		}
+		if !kv.sendWatchResponse(ch, clientv3.WatchResponse{Header: etcdserverpb.ResponseHeader{Revision: int64(ts)}, Created: true}) {
+			return
+		}
+
+		ticker := time.NewTicker(kv.watchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-kv.closeCh:
+				return
+			case <-ticker.C:
+			}
+			ts, err := kv.txn.CurrentTimestamp(oracle.GlobalTxnScope)
+			if err != nil {
+				kv.cancelWatch(ch, key, err)
+				return
+			}
+			events, err := reader.poll(ts)
+			if err != nil {
+				kv.cancelWatch(ch, key, err)
+				return
+			}
+			if len(events) == 0 {
+				continue
+			}
+			if !kv.sendWatchResponse(ch, clientv3.WatchResponse{Header: etcdserverpb.ResponseHeader{Revision: int64(ts)}, Events: events}) {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func (kv *txnTiKV) sendWatchResponse(ch chan<- clientv3.WatchResponse, resp clientv3.WatchResponse) bool {
+	select {
+	case <-kv.closeCh:
+		return false
+	case ch <- resp:
+		return true
+	}
+}
+
+// cancelWatch reports the error as a canceled response, the same as the broken etcd watch,
+// Err() of the response is ErrCompacted if the change log is compacted, otherwise a non-nil error.
+func (kv *txnTiKV) cancelWatch(ch chan<- clientv3.WatchResponse, key string, err error) {
+	log.Warn("txnTiKV watch canceled", zap.String("key", key), zap.Error(err))
+	resp := clientv3.WatchResponse{Canceled: true}
+	if err == v3rpc.ErrCompacted {
+		resp.CompactRevision = 1
+	}
+	kv.sendWatchResponse(ch, resp)
+}
+
+func restoreEmptyValue(val []byte) []byte {
+	if isEmptyByte(val) {
+		return []byte{}
+	}
+	return val
+}
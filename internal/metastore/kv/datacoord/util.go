@@ -53,8 +53,9 @@ func CompressBinLog(fieldBinLogs []*datapb.FieldBinlog) ([]*datapb.FieldBinlog,
 			binlog := &datapb.Binlog{
 				EntriesNum: binlog.EntriesNum,
 				// remove timestamp since it's not necessary
-				LogSize: binlog.LogSize,
-				LogID:   logID,
+				LogSize:  binlog.LogSize,
+				LogID:    logID,
+				Checksum: binlog.Checksum,
 			}
 			compressedFieldBinLog.Binlogs = append(compressedFieldBinLog.Binlogs, binlog)
 		}
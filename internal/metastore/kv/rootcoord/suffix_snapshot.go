@@ -96,8 +96,23 @@ type tsv struct {
 // type conversion make sure implementation
 var _ kv.SnapShotKV = (*SuffixSnapshot)(nil)
 
+type suffixSnapshotOption struct {
+	disableGC bool
+}
+
+// SuffixSnapshotOption is the option to create the SuffixSnapshot.
+type SuffixSnapshotOption func(opt *suffixSnapshotOption)
+
+// WithoutBackgroundGC disables the background gc of the expired snapshots,
+// which is used by the readers never writing the meta, e.g. the hot standby rootcoord.
+func WithoutBackgroundGC() SuffixSnapshotOption {
+	return func(opt *suffixSnapshotOption) {
+		opt.disableGC = true
+	}
+}
+
 // NewSuffixSnapshot creates a NewSuffixSnapshot with provided kv
-func NewSuffixSnapshot(metaKV kv.MetaKv, sep, root, snapshot string) (*SuffixSnapshot, error) {
+func NewSuffixSnapshot(metaKV kv.MetaKv, sep, root, snapshot string, opts ...SuffixSnapshotOption) (*SuffixSnapshot, error) {
 	if metaKV == nil {
 		return nil, retry.Unrecoverable(errors.New("MetaKv is nil"))
 	}
@@ -121,7 +136,13 @@ func NewSuffixSnapshot(metaKV kv.MetaKv, sep, root, snapshot string) (*SuffixSna
 		rootLen:        rootLen,
 		closeGC:        make(chan struct{}, 1),
 	}
-	go ss.startBackgroundGC()
+	opt := &suffixSnapshotOption{}
+	for _, o := range opts {
+		o(opt)
+	}
+	if !opt.disableGC {
+		go ss.startBackgroundGC()
+	}
 	return ss, nil
 }
 
@@ -7,18 +7,20 @@ import (
 )
 
 type SegmentIndex struct {
-	SegmentID     int64
-	CollectionID  int64
-	PartitionID   int64
-	NumRows       int64
-	IndexID       int64
-	BuildID       int64
-	NodeID        int64
-	IndexVersion  int64
-	IndexState    commonpb.IndexState
-	FailReason    string
-	IsDeleted     bool
-	CreateTime    uint64
+	SegmentID    int64
+	CollectionID int64
+	PartitionID  int64
+	NumRows      int64
+	IndexID      int64
+	BuildID      int64
+	NodeID       int64
+	IndexVersion int64
+	IndexState   commonpb.IndexState
+	FailReason   string
+	IsDeleted    bool
+	CreateTime   uint64
+	// FinishTime is when the index build finished or failed
+	FinishTime    uint64
 	IndexFileKeys []string
 	IndexSize     uint64
 	// deprecated
@@ -45,6 +47,7 @@ func UnmarshalSegmentIndexModel(segIndex *indexpb.SegmentIndex) *SegmentIndex {
 		IndexVersion:        segIndex.IndexVersion,
 		IsDeleted:           segIndex.Deleted,
 		CreateTime:          segIndex.CreateTime,
+		FinishTime:          segIndex.FinishTime,
 		IndexFileKeys:       common.CloneStringList(segIndex.IndexFileKeys),
 		IndexSize:           segIndex.SerializeSize,
 		WriteHandoff:        segIndex.WriteHandoff,
@@ -71,6 +74,7 @@ func MarshalSegmentIndexModel(segIdx *SegmentIndex) *indexpb.SegmentIndex {
 		IndexFileKeys:       common.CloneStringList(segIdx.IndexFileKeys),
 		Deleted:             segIdx.IsDeleted,
 		CreateTime:          segIdx.CreateTime,
+		FinishTime:          segIdx.FinishTime,
 		SerializeSize:       segIdx.IndexSize,
 		WriteHandoff:        segIdx.WriteHandoff,
 		CurrentIndexVersion: segIdx.CurrentIndexVersion,
@@ -91,6 +95,7 @@ func CloneSegmentIndex(segIndex *SegmentIndex) *SegmentIndex {
 		IndexVersion:        segIndex.IndexVersion,
 		IsDeleted:           segIndex.IsDeleted,
 		CreateTime:          segIndex.CreateTime,
+		FinishTime:          segIndex.FinishTime,
 		IndexFileKeys:       common.CloneStringList(segIndex.IndexFileKeys),
 		IndexSize:           segIndex.IndexSize,
 		WriteHandoff:        segIndex.WriteHandoff,
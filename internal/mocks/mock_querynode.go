@@ -85,6 +85,61 @@ func (_c *MockQueryNode_Delete_Call) RunAndReturn(run func(context.Context, *que
 	return _c
 }
 
+// FetchIndexFile provides a mock function with given fields: _a0, _a1
+func (_m *MockQueryNode) FetchIndexFile(_a0 context.Context, _a1 *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *querypb.FetchIndexFileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.FetchIndexFileRequest) *querypb.FetchIndexFileResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.FetchIndexFileResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.FetchIndexFileRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryNode_FetchIndexFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchIndexFile'
+type MockQueryNode_FetchIndexFile_Call struct {
+	*mock.Call
+}
+
+// FetchIndexFile is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *querypb.FetchIndexFileRequest
+func (_e *MockQueryNode_Expecter) FetchIndexFile(_a0 interface{}, _a1 interface{}) *MockQueryNode_FetchIndexFile_Call {
+	return &MockQueryNode_FetchIndexFile_Call{Call: _e.mock.On("FetchIndexFile", _a0, _a1)}
+}
+
+func (_c *MockQueryNode_FetchIndexFile_Call) Run(run func(_a0 context.Context, _a1 *querypb.FetchIndexFileRequest)) *MockQueryNode_FetchIndexFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*querypb.FetchIndexFileRequest))
+	})
+	return _c
+}
+
+func (_c *MockQueryNode_FetchIndexFile_Call) Return(_a0 *querypb.FetchIndexFileResponse, _a1 error) *MockQueryNode_FetchIndexFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryNode_FetchIndexFile_Call) RunAndReturn(run func(context.Context, *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error)) *MockQueryNode_FetchIndexFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAddress provides a mock function with given fields:
 func (_m *MockQueryNode) GetAddress() string {
 	ret := _m.Called()
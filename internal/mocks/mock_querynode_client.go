@@ -142,6 +142,76 @@ func (_c *MockQueryNodeClient_Delete_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// FetchIndexFile provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryNodeClient) FetchIndexFile(ctx context.Context, in *querypb.FetchIndexFileRequest, opts ...grpc.CallOption) (*querypb.FetchIndexFileResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *querypb.FetchIndexFileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.FetchIndexFileRequest, ...grpc.CallOption) (*querypb.FetchIndexFileResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.FetchIndexFileRequest, ...grpc.CallOption) *querypb.FetchIndexFileResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.FetchIndexFileResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.FetchIndexFileRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryNodeClient_FetchIndexFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchIndexFile'
+type MockQueryNodeClient_FetchIndexFile_Call struct {
+	*mock.Call
+}
+
+// FetchIndexFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *querypb.FetchIndexFileRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryNodeClient_Expecter) FetchIndexFile(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryNodeClient_FetchIndexFile_Call {
+	return &MockQueryNodeClient_FetchIndexFile_Call{Call: _e.mock.On("FetchIndexFile",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryNodeClient_FetchIndexFile_Call) Run(run func(ctx context.Context, in *querypb.FetchIndexFileRequest, opts ...grpc.CallOption)) *MockQueryNodeClient_FetchIndexFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*querypb.FetchIndexFileRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryNodeClient_FetchIndexFile_Call) Return(_a0 *querypb.FetchIndexFileResponse, _a1 error) *MockQueryNodeClient_FetchIndexFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryNodeClient_FetchIndexFile_Call) RunAndReturn(run func(context.Context, *querypb.FetchIndexFileRequest, ...grpc.CallOption) (*querypb.FetchIndexFileResponse, error)) *MockQueryNodeClient_FetchIndexFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetComponentStates provides a mock function with given fields: ctx, in, opts
 func (_m *MockQueryNodeClient) GetComponentStates(ctx context.Context, in *milvuspb.GetComponentStatesRequest, opts ...grpc.CallOption) (*milvuspb.ComponentStates, error) {
 	_va := make([]interface{}, len(opts))
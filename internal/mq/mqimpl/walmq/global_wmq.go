@@ -0,0 +1,49 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walmq
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// Wmq is global walmq instance that will be initialized only once
+var Wmq *walmq
+
+// once is used to init global walmq
+var once sync.Once
+
+// InitWalMQ init global walmq single instance
+func InitWalMQ(path string) error {
+	var finalErr error
+	once.Do(func() {
+		log.Debug("initializing global walmq", zap.String("path", path))
+		Wmq, finalErr = NewWalMQ(path)
+	})
+	return finalErr
+}
+
+// CloseWalMQ is used to close global walmq
+func CloseWalMQ() {
+	log.Debug("Close Walmq!")
+	if Wmq != nil {
+		Wmq.Close()
+	}
+}
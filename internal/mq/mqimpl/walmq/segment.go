@@ -0,0 +1,184 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walmq
+
+import (
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+const (
+	segmentSuffix = ".log"
+
+	// record header: message id (8 bytes) | payload length (4 bytes) | payload crc32 (4 bytes)
+	recordHeaderSize = 16
+)
+
+var errCorruptedRecord = errors.New("corrupted walmq record")
+
+// segment is an append-only log file of a topic, it's named by the id of its first message.
+// The ids and offsets of all the records are indexed in memory, so that consumers could seek to any message.
+type segment struct {
+	path    string
+	firstID UniqueID
+	ids     []UniqueID
+	offsets []int64
+	size    int64
+	modTime time.Time
+
+	// file is only opened for the active segment
+	file *os.File
+}
+
+func segmentFileName(firstID UniqueID) string {
+	return fmt.Sprintf("%020d%s", firstID, segmentSuffix)
+}
+
+func (s *segment) lastID() UniqueID {
+	if len(s.ids) == 0 {
+		return DefaultMessageID
+	}
+	return s.ids[len(s.ids)-1]
+}
+
+// search returns the index of the first record whose id >= msgID.
+func (s *segment) search(msgID UniqueID) int {
+	return sort.Search(len(s.ids), func(i int) bool { return s.ids[i] >= msgID })
+}
+
+func encodeRecord(buf []byte, msgID UniqueID, payload []byte) []byte {
+	header := make([]byte, recordHeaderSize)
+	common.Endian.PutUint64(header[0:8], uint64(msgID))
+	common.Endian.PutUint32(header[8:12], uint32(len(payload)))
+	common.Endian.PutUint32(header[12:16], crc32.ChecksumIEEE(payload))
+	buf = append(buf, header...)
+	return append(buf, payload...)
+}
+
+// decodeRecord decodes the record at the beginning of data, returns the size of the record.
+func decodeRecord(data []byte) (UniqueID, []byte, int, error) {
+	if len(data) < recordHeaderSize {
+		return 0, nil, 0, errCorruptedRecord
+	}
+	msgID := UniqueID(common.Endian.Uint64(data[0:8]))
+	length := int(common.Endian.Uint32(data[8:12]))
+	checksum := common.Endian.Uint32(data[12:16])
+	if len(data) < recordHeaderSize+length {
+		return 0, nil, 0, errCorruptedRecord
+	}
+	payload := data[recordHeaderSize : recordHeaderSize+length]
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return 0, nil, 0, errCorruptedRecord
+	}
+	return msgID, payload, recordHeaderSize + length, nil
+}
+
+// loadSegment scans the segment file and builds the index,
+// the torn records at the end of file, which are written partially before crash, are truncated.
+func loadSegment(path string) (*segment, error) {
+	name := filepath.Base(path)
+	firstID, err := strconv.ParseInt(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid walmq segment name %s", name)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &segment{path: path, firstID: firstID, modTime: info.ModTime()}
+	offset := 0
+	for offset < len(data) {
+		msgID, _, size, err := decodeRecord(data[offset:])
+		if err != nil {
+			log.Warn("walmq truncates the corrupted tail of segment", zap.String("segment", path),
+				zap.Int("offset", offset), zap.Int("size", len(data)))
+			if err := os.Truncate(path, int64(offset)); err != nil {
+				return nil, err
+			}
+			break
+		}
+		s.ids = append(s.ids, msgID)
+		s.offsets = append(s.offsets, int64(offset))
+		offset += size
+	}
+	s.size = int64(offset)
+	return s, nil
+}
+
+// read reads the records from the idx-th one, until n records or the end of segment.
+func (s *segment) read(idx int, n int) ([]ConsumerMessage, error) {
+	if idx >= len(s.ids) || n <= 0 {
+		return nil, nil
+	}
+	end := idx + n
+	if end > len(s.ids) {
+		end = len(s.ids)
+	}
+	start := s.offsets[idx]
+	stop := s.size
+	if end < len(s.ids) {
+		stop = s.offsets[end]
+	}
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data := make([]byte, stop-start)
+	if _, err := f.ReadAt(data, start); err != nil {
+		return nil, err
+	}
+
+	msgs := make([]ConsumerMessage, 0, end-idx)
+	offset := 0
+	for i := idx; i < end; i++ {
+		msgID, payload, size, err := decodeRecord(data[offset:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "segment %s, offset %d", s.path, start+int64(offset))
+		}
+		msgs = append(msgs, ConsumerMessage{MsgID: msgID, Payload: payload})
+		offset += size
+	}
+	return msgs, nil
+}
+
+func (s *segment) close() error {
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
@@ -0,0 +1,728 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walmq
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/mq/mqimpl/rocksmq/server"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/retry"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+type (
+	UniqueID        = typeutil.UniqueID
+	Consumer        = server.Consumer
+	ProducerMessage = server.ProducerMessage
+	ConsumerMessage = server.ConsumerMessage
+)
+
+// DefaultMessageID is the position before the first message of a topic
+const DefaultMessageID = server.DefaultMessageID
+
+const (
+	topicsDir  = "topics"
+	cursorsDir = "cursors"
+
+	// cursors are persisted in this interval, they're also persisted on close
+	cursorFlushInterval = time.Second
+
+	walmqNotServingErrMsg = "WalMQ is not serving"
+)
+
+// topic is the log of a topic, which is made of segments in the order of message id.
+type topic struct {
+	mu       sync.Mutex
+	name     string
+	dir      string
+	segments []*segment
+}
+
+func (t *topic) lastID() UniqueID {
+	for i := len(t.segments) - 1; i >= 0; i-- {
+		if id := t.segments[i].lastID(); id != DefaultMessageID {
+			return id
+		}
+	}
+	return DefaultMessageID
+}
+
+func (t *topic) size() int64 {
+	size := int64(0)
+	for _, s := range t.segments {
+		size += s.size
+	}
+	return size
+}
+
+func (t *topic) close() {
+	for _, s := range t.segments {
+		if err := s.close(); err != nil {
+			log.Warn("walmq failed to close segment", zap.String("segment", s.path), zap.Error(err))
+		}
+	}
+}
+
+// walmq is an embedded message queue backed by the local write-ahead log, it implements server.RocksMQ,
+// so that it works with the rocksmq client and message stream.
+//
+// Each topic is stored as segment files in its own directory, records are appended to the last segment,
+// a new segment is created once it exceeds the segment size. The positions of consumer groups are persisted,
+// so that the groups subscribed again after restart continue from where they're. The sealed segments are removed
+// once they expire or the topic exceeds the retention size, no matter whether they're consumed, like Pulsar and Kafka.
+//
+// It's not replicated, so it's only valid in standalone mode.
+type walmq struct {
+	path        string
+	segmentSize int64
+	syncWrite   bool
+
+	mu        sync.RWMutex
+	topics    map[string]*topic
+	consumers map[string][]*Consumer
+	// topic/group -> the id of the next message to consume
+	cursors      map[string]UniqueID
+	dirtyCursors typeutil.Set[string]
+
+	nextID  atomic.Int64
+	closed  atomic.Bool
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+var _ server.RocksMQ = (*walmq)(nil)
+
+// NewWalMQ opens the write-ahead log under path, and recovers the topics and positions of consumer groups.
+func NewWalMQ(path string) (*walmq, error) {
+	params := paramtable.Get()
+	wmq := &walmq{
+		path:         path,
+		segmentSize:  params.WalmqCfg.SegmentSize.GetAsInt64(),
+		syncWrite:    params.WalmqCfg.SyncWrite.GetAsBool(),
+		topics:       make(map[string]*topic),
+		consumers:    make(map[string][]*Consumer),
+		cursors:      make(map[string]UniqueID),
+		dirtyCursors: typeutil.NewSet[string](),
+		closeCh:      make(chan struct{}),
+	}
+	for _, dir := range []string{filepath.Join(path, topicsDir), filepath.Join(path, cursorsDir)} {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return nil, err
+		}
+	}
+	if err := wmq.recover(); err != nil {
+		return nil, err
+	}
+
+	wmq.wg.Add(2)
+	go wmq.flushCursorsLoop()
+	go wmq.retentionLoop(params.WalmqCfg.TickerTimeInSeconds.GetAsDuration(time.Second))
+	log.Info("walmq started", zap.String("path", path), zap.Int("topics", len(wmq.topics)), zap.Int64("nextID", wmq.nextID.Load()))
+	return wmq, nil
+}
+
+func (wmq *walmq) recover() error {
+	entries, err := os.ReadDir(filepath.Join(wmq.path, topicsDir))
+	if err != nil {
+		return err
+	}
+	maxID := DefaultMessageID
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		t, err := wmq.loadTopic(entry.Name())
+		if err != nil {
+			return err
+		}
+		wmq.topics[t.name] = t
+		if id := t.lastID(); id > maxID {
+			maxID = id
+		}
+	}
+	wmq.nextID.Store(maxID + 1)
+
+	// drop the cursors whose topic doesn't exist anymore
+	cursorTopics, err := os.ReadDir(filepath.Join(wmq.path, cursorsDir))
+	if err != nil {
+		return err
+	}
+	for _, entry := range cursorTopics {
+		topicName := unescapeName(entry.Name())
+		if _, ok := wmq.topics[topicName]; !ok {
+			if err := os.RemoveAll(filepath.Join(wmq.path, cursorsDir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (wmq *walmq) loadTopic(dirName string) (*topic, error) {
+	dir := filepath.Join(wmq.path, topicsDir, dirName)
+	t := &topic{name: unescapeName(dirName), dir: dir}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	// file names are zero padded, so they're in the order of id
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), segmentSuffix) {
+			continue
+		}
+		s, err := loadSegment(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		t.segments = append(t.segments, s)
+	}
+	return t, nil
+}
+
+func escapeName(name string) string {
+	return url.PathEscape(name)
+}
+
+func unescapeName(name string) string {
+	unescaped, err := url.PathUnescape(name)
+	if err != nil {
+		return name
+	}
+	return unescaped
+}
+
+func cursorKey(topicName, groupName string) string {
+	return topicName + "/" + groupName
+}
+
+func (wmq *walmq) cursorPath(topicName, groupName string) string {
+	return filepath.Join(wmq.path, cursorsDir, escapeName(topicName), escapeName(groupName))
+}
+
+func (wmq *walmq) isClosed() bool {
+	return wmq.closed.Load()
+}
+
+func (wmq *walmq) getTopic(topicName string) (*topic, error) {
+	wmq.mu.RLock()
+	defer wmq.mu.RUnlock()
+	t, ok := wmq.topics[topicName]
+	if !ok {
+		return nil, merr.WrapErrMqTopicNotFound(topicName)
+	}
+	return t, nil
+}
+
+// Close flushes the cursors and closes the segments.
+func (wmq *walmq) Close() {
+	if !wmq.closed.CompareAndSwap(false, true) {
+		return
+	}
+	close(wmq.closeCh)
+	wmq.wg.Wait()
+	wmq.flushCursors()
+
+	wmq.mu.Lock()
+	defer wmq.mu.Unlock()
+	for _, t := range wmq.topics {
+		t.mu.Lock()
+		t.close()
+		t.mu.Unlock()
+	}
+	log.Info("walmq closed", zap.String("path", wmq.path))
+}
+
+func (wmq *walmq) CreateTopic(topicName string) error {
+	if wmq.isClosed() {
+		return errors.New(walmqNotServingErrMsg)
+	}
+	if strings.Contains(topicName, "/") {
+		log.Warn("walmq failed to create topic for topic name contains \"/\"", zap.String("topic", topicName))
+		return retry.Unrecoverable(fmt.Errorf("topic name = %s contains \"/\"", topicName))
+	}
+
+	wmq.mu.Lock()
+	defer wmq.mu.Unlock()
+	if _, ok := wmq.topics[topicName]; ok {
+		return nil
+	}
+	dir := filepath.Join(wmq.path, topicsDir, escapeName(topicName))
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return retry.Unrecoverable(err)
+	}
+	wmq.topics[topicName] = &topic{name: topicName, dir: dir}
+	log.Debug("walmq create topic successfully", zap.String("topic", topicName))
+	return nil
+}
+
+func (wmq *walmq) DestroyTopic(topicName string) error {
+	wmq.mu.Lock()
+	defer wmq.mu.Unlock()
+	t, ok := wmq.topics[topicName]
+	if !ok {
+		return fmt.Errorf("topic name = %s not exist", topicName)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.close()
+	if err := os.RemoveAll(t.dir); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(wmq.path, cursorsDir, escapeName(topicName))); err != nil {
+		return err
+	}
+
+	delete(wmq.topics, topicName)
+	delete(wmq.consumers, topicName)
+	prefix := cursorKey(topicName, "")
+	for key := range wmq.cursors {
+		if strings.HasPrefix(key, prefix) {
+			delete(wmq.cursors, key)
+			wmq.dirtyCursors.Remove(key)
+		}
+	}
+	log.Debug("walmq destroy topic successfully", zap.String("topic", topicName))
+	return nil
+}
+
+func (wmq *walmq) ExistConsumerGroup(topicName, groupName string) (bool, *Consumer, error) {
+	wmq.mu.RLock()
+	defer wmq.mu.RUnlock()
+	if _, ok := wmq.cursors[cursorKey(topicName, groupName)]; !ok {
+		return false, nil, nil
+	}
+	for _, c := range wmq.consumers[topicName] {
+		if c.GroupName == groupName {
+			return true, c, nil
+		}
+	}
+	return false, nil, nil
+}
+
+// CreateConsumerGroup creates the consumer group, its position is recovered if it's persisted before.
+func (wmq *walmq) CreateConsumerGroup(topicName, groupName string) error {
+	if wmq.isClosed() {
+		return errors.New(walmqNotServingErrMsg)
+	}
+	wmq.mu.Lock()
+	defer wmq.mu.Unlock()
+	key := cursorKey(topicName, groupName)
+	if _, ok := wmq.cursors[key]; ok {
+		return fmt.Errorf("WalMQ CreateConsumerGroup key already exists, key = %s", key)
+	}
+
+	cursor := DefaultMessageID
+	data, err := os.ReadFile(wmq.cursorPath(topicName, groupName))
+	if err == nil {
+		cursor, err = strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			log.Warn("walmq found invalid persisted cursor, consume from the earliest", zap.String("topic", topicName),
+				zap.String("group", groupName), zap.Error(err))
+			cursor = DefaultMessageID
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	wmq.cursors[key] = cursor
+	log.Debug("walmq create consumer group successfully", zap.String("topic", topicName),
+		zap.String("group", groupName), zap.Int64("position", cursor))
+	return nil
+}
+
+func (wmq *walmq) RegisterConsumer(consumer *Consumer) error {
+	if wmq.isClosed() {
+		return errors.New(walmqNotServingErrMsg)
+	}
+	wmq.mu.Lock()
+	defer wmq.mu.Unlock()
+	for _, c := range wmq.consumers[consumer.Topic] {
+		if c.GroupName == consumer.GroupName {
+			return nil
+		}
+	}
+	wmq.consumers[consumer.Topic] = append(wmq.consumers[consumer.Topic], consumer)
+	return nil
+}
+
+// DestroyConsumerGroup unregisters the consumer group, its position is kept so that it could be subscribed again.
+func (wmq *walmq) DestroyConsumerGroup(topicName, groupName string) error {
+	if wmq.isClosed() {
+		return errors.New(walmqNotServingErrMsg)
+	}
+	wmq.mu.Lock()
+	defer wmq.mu.Unlock()
+	if _, ok := wmq.topics[topicName]; !ok {
+		return fmt.Errorf("topic name = %s not exist", topicName)
+	}
+	key := cursorKey(topicName, groupName)
+	if cursor, ok := wmq.cursors[key]; ok {
+		if err := wmq.persistCursor(topicName, groupName, cursor); err != nil {
+			log.Warn("walmq failed to persist cursor", zap.String("topic", topicName), zap.String("group", groupName), zap.Error(err))
+		}
+		delete(wmq.cursors, key)
+		wmq.dirtyCursors.Remove(key)
+	}
+	consumers := wmq.consumers[topicName]
+	for i, c := range consumers {
+		if c.GroupName == groupName {
+			close(c.MsgMutex)
+			wmq.consumers[topicName] = append(consumers[:i], consumers[i+1:]...)
+			break
+		}
+	}
+	log.Debug("walmq destroy consumer group successfully", zap.String("topic", topicName), zap.String("group", groupName))
+	return nil
+}
+
+func (wmq *walmq) GetLatestMsg(topicName string) (int64, error) {
+	if wmq.isClosed() {
+		return DefaultMessageID, errors.New(walmqNotServingErrMsg)
+	}
+	t, err := wmq.getTopic(topicName)
+	if err != nil {
+		return DefaultMessageID, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lastID(), nil
+}
+
+func (wmq *walmq) CheckTopicValid(topicName string) error {
+	_, err := wmq.getTopic(topicName)
+	return err
+}
+
+// Produce appends the messages to the last segment of topic.
+func (wmq *walmq) Produce(topicName string, messages []ProducerMessage) ([]UniqueID, error) {
+	if wmq.isClosed() {
+		return nil, errors.New(walmqNotServingErrMsg)
+	}
+	t, err := wmq.getTopic(topicName)
+	if err != nil {
+		return []UniqueID{}, err
+	}
+	if len(messages) == 0 {
+		return []UniqueID{}, nil
+	}
+
+	t.mu.Lock()
+	msgIDs, err := wmq.append(t, messages)
+	t.mu.Unlock()
+	if err != nil {
+		return []UniqueID{}, err
+	}
+
+	wmq.mu.RLock()
+	for _, c := range wmq.consumers[topicName] {
+		select {
+		case c.MsgMutex <- struct{}{}:
+		default:
+		}
+	}
+	wmq.mu.RUnlock()
+	return msgIDs, nil
+}
+
+func (wmq *walmq) append(t *topic, messages []ProducerMessage) ([]UniqueID, error) {
+	var active *segment
+	if len(t.segments) > 0 && t.segments[len(t.segments)-1].size < wmq.segmentSize {
+		active = t.segments[len(t.segments)-1]
+	}
+
+	// ids are allocated under the lock of topic, so they're increasing in each topic
+	firstID := wmq.nextID.Add(int64(len(messages))) - int64(len(messages))
+	if active == nil {
+		if len(t.segments) > 0 {
+			if err := t.segments[len(t.segments)-1].close(); err != nil {
+				return nil, err
+			}
+		}
+		active = &segment{path: filepath.Join(t.dir, segmentFileName(firstID)), firstID: firstID}
+		t.segments = append(t.segments, active)
+	}
+	if active.file == nil {
+		file, err := os.OpenFile(active.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		active.file = file
+	}
+
+	msgIDs := make([]UniqueID, 0, len(messages))
+	offsets := make([]int64, 0, len(messages))
+	buf := make([]byte, 0)
+	for i, msg := range messages {
+		msgID := firstID + UniqueID(i)
+		offsets = append(offsets, active.size+int64(len(buf)))
+		buf = encodeRecord(buf, msgID, msg.Payload)
+		msgIDs = append(msgIDs, msgID)
+	}
+	if _, err := active.file.Write(buf); err != nil {
+		// drop the partial records, they're also truncated on recovery if it fails
+		if truncateErr := active.file.Truncate(active.size); truncateErr != nil {
+			log.Warn("walmq failed to truncate segment", zap.String("segment", active.path), zap.Error(truncateErr))
+		}
+		active.close()
+		return nil, err
+	}
+	if wmq.syncWrite {
+		if err := active.file.Sync(); err != nil {
+			return nil, err
+		}
+	}
+	active.ids = append(active.ids, msgIDs...)
+	active.offsets = append(active.offsets, offsets...)
+	active.size += int64(len(buf))
+	active.modTime = time.Now()
+	return msgIDs, nil
+}
+
+// Consume reads at most n messages from the position of consumer group, and moves the position forward.
+func (wmq *walmq) Consume(topicName string, groupName string, n int) ([]ConsumerMessage, error) {
+	if wmq.isClosed() {
+		return nil, errors.New(walmqNotServingErrMsg)
+	}
+	t, err := wmq.getTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+	key := cursorKey(topicName, groupName)
+	wmq.mu.RLock()
+	cursor, ok := wmq.cursors[key]
+	wmq.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("currentID of topicName=%s, groupName=%s not exist", topicName, groupName)
+	}
+
+	t.mu.Lock()
+	msgs := make([]ConsumerMessage, 0, n)
+	for _, s := range t.segments {
+		if len(msgs) >= n {
+			break
+		}
+		if s.lastID() < cursor {
+			continue
+		}
+		batch, err := s.read(s.search(cursor), n-len(msgs))
+		if err != nil {
+			t.mu.Unlock()
+			return nil, err
+		}
+		msgs = append(msgs, batch...)
+	}
+	t.mu.Unlock()
+
+	if len(msgs) == 0 {
+		return msgs, nil
+	}
+	wmq.moveCursor(topicName, groupName, cursor, msgs[len(msgs)-1].MsgID+1)
+	return msgs, nil
+}
+
+// moveCursor moves the position of consumer group if it's not moved by others, e.g. Seek.
+func (wmq *walmq) moveCursor(topicName, groupName string, from, to UniqueID) {
+	wmq.mu.Lock()
+	defer wmq.mu.Unlock()
+	key := cursorKey(topicName, groupName)
+	if cursor, ok := wmq.cursors[key]; ok && cursor == from {
+		wmq.cursors[key] = to
+		wmq.dirtyCursors.Insert(key)
+	}
+}
+
+func (wmq *walmq) setCursor(topicName, groupName string, msgID UniqueID) error {
+	wmq.mu.Lock()
+	defer wmq.mu.Unlock()
+	key := cursorKey(topicName, groupName)
+	if _, ok := wmq.cursors[key]; !ok {
+		return fmt.Errorf("ConsumerGroup %s, channel %s not exists", groupName, topicName)
+	}
+	wmq.cursors[key] = msgID
+	wmq.dirtyCursors.Insert(key)
+	return nil
+}
+
+// Seek moves the position of consumer group to msgID, it's moved to the earliest if msgID doesn't exist.
+func (wmq *walmq) Seek(topicName string, groupName string, msgID UniqueID) error {
+	if wmq.isClosed() {
+		return errors.New(walmqNotServingErrMsg)
+	}
+	t, err := wmq.getTopic(topicName)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	exist := false
+	for _, s := range t.segments {
+		if idx := s.search(msgID); idx < len(s.ids) && s.ids[idx] == msgID {
+			exist = true
+			break
+		}
+	}
+	t.mu.Unlock()
+
+	if !exist {
+		log.Warn("WalMQ: trying to seek to no exist position, reset current id",
+			zap.String("topic", topicName), zap.String("group", groupName), zap.Int64("msgId", msgID))
+		msgID = DefaultMessageID
+	}
+	if err := wmq.setCursor(topicName, groupName, msgID); err != nil {
+		return err
+	}
+	log.Debug("successfully seek", zap.String("topic", topicName), zap.String("group", groupName), zap.Int64("msgId", msgID))
+	return nil
+}
+
+// SeekToLatest moves the position of consumer group to the next message of the latest one.
+func (wmq *walmq) SeekToLatest(topicName, groupName string) error {
+	if wmq.isClosed() {
+		return errors.New(walmqNotServingErrMsg)
+	}
+	latest, err := wmq.GetLatestMsg(topicName)
+	if err != nil {
+		return err
+	}
+	if latest == DefaultMessageID {
+		latest = wmq.nextID.Load() - 1
+	}
+	return wmq.setCursor(topicName, groupName, latest+1)
+}
+
+func (wmq *walmq) Notify(topicName, groupName string) {
+	wmq.mu.RLock()
+	defer wmq.mu.RUnlock()
+	for _, c := range wmq.consumers[topicName] {
+		if c.GroupName == groupName {
+			select {
+			case c.MsgMutex <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (wmq *walmq) persistCursor(topicName, groupName string, cursor UniqueID) error {
+	path := wmq.cursorPath(topicName, groupName)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	// write to a temporary file then rename, so that the cursor is never partially written
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatInt(cursor, 10)), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (wmq *walmq) flushCursors() {
+	wmq.mu.Lock()
+	defer wmq.mu.Unlock()
+	for key := range wmq.dirtyCursors {
+		cursor, ok := wmq.cursors[key]
+		if !ok {
+			continue
+		}
+		idx := strings.Index(key, "/")
+		if err := wmq.persistCursor(key[:idx], key[idx+1:], cursor); err != nil {
+			log.Warn("walmq failed to persist cursor", zap.String("key", key), zap.Error(err))
+			continue
+		}
+	}
+	wmq.dirtyCursors = typeutil.NewSet[string]()
+}
+
+func (wmq *walmq) flushCursorsLoop() {
+	defer wmq.wg.Done()
+	ticker := time.NewTicker(cursorFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-wmq.closeCh:
+			return
+		case <-ticker.C:
+			wmq.flushCursors()
+		}
+	}
+}
+
+func (wmq *walmq) retentionLoop(interval time.Duration) {
+	defer wmq.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-wmq.closeCh:
+			return
+		case <-ticker.C:
+			wmq.expire()
+		}
+	}
+}
+
+// expire removes the sealed segments older than the retention time, and the oldest ones exceeding the retention size.
+// The active segment is never removed, so the latest message of a topic is always kept.
+func (wmq *walmq) expire() {
+	params := paramtable.Get()
+	retentionTime := params.WalmqCfg.RetentionTimeInMinutes.GetAsDuration(time.Minute)
+	retentionSize := params.WalmqCfg.RetentionSizeInMB.GetAsInt64() << 20
+
+	wmq.mu.RLock()
+	topics := make([]*topic, 0, len(wmq.topics))
+	for _, t := range wmq.topics {
+		topics = append(topics, t)
+	}
+	wmq.mu.RUnlock()
+
+	for _, t := range topics {
+		t.mu.Lock()
+		size := t.size()
+		removed := 0
+		for removed < len(t.segments)-1 {
+			s := t.segments[removed]
+			expired := retentionTime > 0 && time.Since(s.modTime) > retentionTime
+			oversized := retentionSize >= 0 && size > retentionSize
+			if !expired && !oversized {
+				break
+			}
+			if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+				log.Warn("walmq failed to remove expired segment", zap.String("segment", s.path), zap.Error(err))
+				break
+			}
+			size -= s.size
+			removed++
+		}
+		if removed > 0 {
+			t.segments = t.segments[removed:]
+			log.Info("walmq removed expired segments", zap.String("topic", t.name), zap.Int("num", removed))
+		}
+		t.mu.Unlock()
+	}
+}
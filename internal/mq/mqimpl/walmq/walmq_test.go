@@ -0,0 +1,241 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walmq
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type WalMQSuite struct {
+	suite.Suite
+
+	path string
+	wmq  *walmq
+}
+
+func (s *WalMQSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *WalMQSuite) SetupTest() {
+	params := paramtable.Get()
+	// 3 records of 20 bytes in each segment
+	params.Save(params.WalmqCfg.SegmentSize.Key, "60")
+	s.path = s.T().TempDir()
+	var err error
+	s.wmq, err = NewWalMQ(s.path)
+	s.Require().NoError(err)
+}
+
+func (s *WalMQSuite) TearDownTest() {
+	s.wmq.Close()
+	params := paramtable.Get()
+	params.Reset(params.WalmqCfg.SegmentSize.Key)
+	params.Reset(params.WalmqCfg.RetentionTimeInMinutes.Key)
+	params.Reset(params.WalmqCfg.RetentionSizeInMB.Key)
+}
+
+func (s *WalMQSuite) produce(topicName string, n int) []UniqueID {
+	msgs := make([]ProducerMessage, 0, n)
+	for i := 0; i < n; i++ {
+		msgs = append(msgs, ProducerMessage{Payload: []byte(fmt.Sprintf("msg%d", i))})
+	}
+	ids, err := s.wmq.Produce(topicName, msgs)
+	s.Require().NoError(err)
+	s.Require().Len(ids, n)
+	return ids
+}
+
+func (s *WalMQSuite) subscribe(topicName, groupName string) {
+	s.Require().NoError(s.wmq.CreateConsumerGroup(topicName, groupName))
+	s.Require().NoError(s.wmq.RegisterConsumer(&Consumer{Topic: topicName, GroupName: groupName, MsgMutex: make(chan struct{}, 1)}))
+}
+
+func (s *WalMQSuite) TestProduceConsume() {
+	_, err := s.wmq.Produce("t1", []ProducerMessage{{Payload: []byte("msg")}})
+	s.ErrorIs(err, merr.ErrMqTopicNotFound)
+	s.Error(s.wmq.CreateTopic("a/b"))
+
+	s.Require().NoError(s.wmq.CreateTopic("t1"))
+	s.NoError(s.wmq.CheckTopicValid("t1"))
+	latest, err := s.wmq.GetLatestMsg("t1")
+	s.NoError(err)
+	s.Equal(DefaultMessageID, latest)
+
+	s.subscribe("t1", "g1")
+	exist, consumer, err := s.wmq.ExistConsumerGroup("t1", "g1")
+	s.NoError(err)
+	s.True(exist)
+	s.Equal("g1", consumer.GroupName)
+
+	ids := s.produce("t1", 10)
+	for i := 1; i < len(ids); i++ {
+		s.Equal(ids[i-1]+1, ids[i])
+	}
+	// consumers are notified
+	s.Len(consumer.MsgMutex, 1)
+	// segments are rolled
+	s.Len(s.wmq.topics["t1"].segments, 1)
+	s.produce("t1", 5)
+	s.Len(s.wmq.topics["t1"].segments, 2)
+
+	msgs, err := s.wmq.Consume("t1", "g1", 12)
+	s.NoError(err)
+	s.Len(msgs, 12)
+	s.Equal(ids[0], msgs[0].MsgID)
+	s.Equal([]byte("msg0"), msgs[0].Payload)
+	msgs, err = s.wmq.Consume("t1", "g1", 12)
+	s.NoError(err)
+	s.Len(msgs, 3)
+	msgs, err = s.wmq.Consume("t1", "g1", 12)
+	s.NoError(err)
+	s.Empty(msgs)
+
+	latest, err = s.wmq.GetLatestMsg("t1")
+	s.NoError(err)
+	s.Equal(ids[0]+14, latest)
+}
+
+func (s *WalMQSuite) TestSeek() {
+	s.Require().NoError(s.wmq.CreateTopic("t1"))
+	s.subscribe("t1", "g1")
+	ids := s.produce("t1", 10)
+
+	s.NoError(s.wmq.Seek("t1", "g1", ids[5]))
+	msgs, err := s.wmq.Consume("t1", "g1", 1)
+	s.NoError(err)
+	s.Equal(ids[5], msgs[0].MsgID)
+
+	// seek to the earliest if the message doesn't exist
+	s.NoError(s.wmq.Seek("t1", "g1", ids[9]+100))
+	msgs, err = s.wmq.Consume("t1", "g1", 1)
+	s.NoError(err)
+	s.Equal(ids[0], msgs[0].MsgID)
+
+	s.NoError(s.wmq.SeekToLatest("t1", "g1"))
+	msgs, err = s.wmq.Consume("t1", "g1", 1)
+	s.NoError(err)
+	s.Empty(msgs)
+	newIDs := s.produce("t1", 1)
+	msgs, err = s.wmq.Consume("t1", "g1", 1)
+	s.NoError(err)
+	s.Equal(newIDs[0], msgs[0].MsgID)
+
+	s.Error(s.wmq.Seek("t1", "g2", ids[0]))
+	s.ErrorIs(s.wmq.Seek("t2", "g1", ids[0]), merr.ErrMqTopicNotFound)
+}
+
+func (s *WalMQSuite) TestRecover() {
+	s.Require().NoError(s.wmq.CreateTopic("t1"))
+	s.subscribe("t1", "g1")
+	ids := s.produce("t1", 10)
+	msgs, err := s.wmq.Consume("t1", "g1", 4)
+	s.NoError(err)
+	s.Len(msgs, 4)
+	s.wmq.Close()
+
+	// append a torn record to the last segment
+	segments := s.wmq.topics["t1"].segments
+	last := segments[len(segments)-1].path
+	f, err := os.OpenFile(last, os.O_WRONLY|os.O_APPEND, 0o644)
+	s.Require().NoError(err)
+	_, err = f.Write([]byte{1, 2, 3})
+	s.Require().NoError(err)
+	s.Require().NoError(f.Close())
+
+	s.wmq, err = NewWalMQ(s.path)
+	s.Require().NoError(err)
+	latest, err := s.wmq.GetLatestMsg("t1")
+	s.NoError(err)
+	s.Equal(ids[9], latest)
+
+	// the position of consumer group is recovered
+	s.subscribe("t1", "g1")
+	msgs, err = s.wmq.Consume("t1", "g1", 100)
+	s.NoError(err)
+	s.Len(msgs, 6)
+	s.Equal(ids[4], msgs[0].MsgID)
+
+	// ids keep increasing after recovery
+	newIDs := s.produce("t1", 1)
+	s.Greater(newIDs[0], ids[9])
+	msgs, err = s.wmq.Consume("t1", "g1", 100)
+	s.NoError(err)
+	s.Len(msgs, 1)
+
+	// destroying the group keeps its position
+	s.NoError(s.wmq.DestroyConsumerGroup("t1", "g1"))
+	exist, _, err := s.wmq.ExistConsumerGroup("t1", "g1")
+	s.NoError(err)
+	s.False(exist)
+	s.subscribe("t1", "g1")
+	msgs, err = s.wmq.Consume("t1", "g1", 100)
+	s.NoError(err)
+	s.Empty(msgs)
+
+	// destroying the topic removes the data and positions
+	s.NoError(s.wmq.DestroyTopic("t1"))
+	s.ErrorIs(s.wmq.CheckTopicValid("t1"), merr.ErrMqTopicNotFound)
+	_, err = os.Stat(filepath.Join(s.path, cursorsDir, "t1"))
+	s.True(os.IsNotExist(err))
+}
+
+func (s *WalMQSuite) TestExpire() {
+	params := paramtable.Get()
+	s.Require().NoError(s.wmq.CreateTopic("t1"))
+	s.subscribe("t1", "g1")
+	ids := s.produce("t1", 3)
+	s.produce("t1", 3)
+	s.produce("t1", 3)
+	s.Len(s.wmq.topics["t1"].segments, 3)
+
+	// nothing expires
+	s.wmq.expire()
+	s.Len(s.wmq.topics["t1"].segments, 3)
+
+	// the topic exceeds the retention size
+	params.Save(params.WalmqCfg.RetentionSizeInMB.Key, "0")
+	s.wmq.expire()
+	s.Len(s.wmq.topics["t1"].segments, 1)
+
+	// consumers start from the earliest message kept
+	msgs, err := s.wmq.Consume("t1", "g1", 100)
+	s.NoError(err)
+	s.Len(msgs, 3)
+	s.Equal(ids[0]+6, msgs[0].MsgID)
+
+	params.Save(params.WalmqCfg.RetentionSizeInMB.Key, "-1")
+	params.Save(params.WalmqCfg.RetentionTimeInMinutes.Key, "1")
+	s.produce("t1", 3)
+	s.Len(s.wmq.topics["t1"].segments, 2)
+	s.wmq.topics["t1"].segments[0].modTime = time.Now().Add(-time.Hour)
+	s.wmq.expire()
+	s.Len(s.wmq.topics["t1"].segments, 1)
+}
+
+func TestWalMQ(t *testing.T) {
+	suite.Run(t, new(WalMQSuite))
+}
@@ -1,12 +1,17 @@
 package msgstream
 
 import (
+	"context"
+
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus/internal/mq/mqimpl/rocksmq/client"
 	"github.com/milvus-io/milvus/internal/mq/mqimpl/rocksmq/server"
+	"github.com/milvus-io/milvus/internal/mq/mqimpl/walmq"
 	"github.com/milvus-io/milvus/internal/mq/msgstream/mqwrapper/rmq"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -24,3 +29,25 @@ func NewRocksmqFactory(path string, cfg *paramtable.ServiceParam) msgstream.Fact
 		MQBufSize:         cfg.MQCfg.MQBufSize.GetAsInt64(),
 	}
 }
+
+// NewWalmqFactory creates a new message stream factory based on the embedded write-ahead log,
+// it shares the client of rocksmq since walmq implements the same server interface.
+func NewWalmqFactory(path string, cfg *paramtable.ServiceParam) msgstream.Factory {
+	if err := walmq.InitWalMQ(path); err != nil {
+		log.Fatal("fail to init walmq", zap.Error(err))
+	}
+	log.Info("init walmq msgstream success", zap.String("path", path))
+
+	return &msgstream.CommonFactory{
+		Newer: func(ctx context.Context) (mqwrapper.Client, error) {
+			cli, err := rmq.NewClient(client.Options{Server: walmq.Wmq})
+			if err != nil {
+				return nil, err
+			}
+			return cli, nil
+		},
+		DispatcherFactory: msgstream.ProtoUDFactory{},
+		ReceiveBufSize:    cfg.MQCfg.ReceiveBufSize.GetAsInt64(),
+		MQBufSize:         cfg.MQCfg.MQBufSize.GetAsInt64(),
+	}
+}
@@ -18,6 +18,7 @@ package rmq
 
 import (
 	"context"
+	"reflect"
 	"strconv"
 
 	"github.com/cockroachdb/errors"
@@ -28,15 +29,20 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
 )
 
 // nmqClient implements mqwrapper.Client.
-var _ mqwrapper.Client = &rmqClient{}
+var (
+	_ mqwrapper.Client       = &rmqClient{}
+	_ mqwrapper.TopicDeleter = &rmqClient{}
+)
 
 // rmqClient contains a rocksmq client
 type rmqClient struct {
 	client client.Client
+	server server.RocksMQ
 }
 
 func NewClientWithDefaultOptions(ctx context.Context) (mqwrapper.Client, error) {
@@ -51,7 +57,7 @@ func NewClient(opts client.Options) (*rmqClient, error) {
 		log.Error("Failed to set rmq client: ", zap.Error(err))
 		return nil, err
 	}
-	return &rmqClient{client: c}, nil
+	return &rmqClient{client: c, server: opts.Server}, nil
 }
 
 // CreateProducer creates a producer for rocksmq client
@@ -126,6 +132,17 @@ func (rc *rmqClient) BytesToMsgID(id []byte) (mqwrapper.MessageID, error) {
 	return &server.RmqID{MessageID: rID}, nil
 }
 
+// DeleteTopic destroys the topic and all its consumer groups
+func (rc *rmqClient) DeleteTopic(topic string) error {
+	if rc.server == nil || reflect.ValueOf(rc.server).IsNil() {
+		return errors.New("rmq server is nil")
+	}
+	if err := rc.server.CheckTopicValid(topic); errors.Is(err, merr.ErrMqTopicNotFound) {
+		return nil
+	}
+	return rc.server.DestroyTopic(topic)
+}
+
 func (rc *rmqClient) Close() {
 	rc.client.Close()
 }
@@ -0,0 +1,278 @@
+package planparserv2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	antlrparser "github.com/milvus-io/milvus/internal/parser/planparserv2/generated"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/cache"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const exprCacheName = "ExprTemplate"
+
+var (
+	exprCacheOnce sync.Once
+	exprCache     cache.Cache[string, *exprTemplate]
+)
+
+// exprTemplate is the parsed expression of a template, which is the expression with the constants
+// replaced by placeholders, the expressions of the same template share it by binding their constants.
+type exprTemplate struct {
+	expr *planpb.Expr
+	// slots[i] is the index of the constant bound to the i-th value of expr, in the order of walkValues
+	slots []int
+	// the constants of the template can't be bound to the values unambiguously,
+	// the expressions of the template are always parsed.
+	uncacheable bool
+}
+
+func getExprCache() cache.Cache[string, *exprTemplate] {
+	exprCacheOnce.Do(func() {
+		exprCache = cache.NewCache[string, *exprTemplate](
+			cache.WithMaximumSize[string, *exprTemplate](paramtable.Get().ProxyCfg.ExprCacheSize.GetAsInt64()))
+	})
+	return exprCache
+}
+
+// parseExprWithCache parses the expression, the parsed expressions are cached by the templates,
+// so the expressions of the same shape with different constants are not parsed again.
+func parseExprWithCache(schemaPb *schemapb.CollectionSchema, schema *typeutil.SchemaHelper, exprStr string) (*planpb.Expr, error) {
+	if !paramtable.Get().ProxyCfg.ExprCacheEnabled.GetAsBool() || isEmptyExpression(exprStr) {
+		return ParseExpr(schema, exprStr)
+	}
+	template, constants, ok := templatize(exprStr)
+	if !ok {
+		return ParseExpr(schema, exprStr)
+	}
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	key := schemaFingerprint(schemaPb) + "\n" + template
+	cached, ok := getExprCache().GetIfPresent(key)
+	if ok && !cached.uncacheable {
+		metrics.ProxyCacheStatsCounter.WithLabelValues(nodeID, exprCacheName, metrics.CacheHitLabel).Inc()
+		return cached.bind(constants), nil
+	}
+	// the expressions of the uncacheable templates are always parsed, which are not hits
+	metrics.ProxyCacheStatsCounter.WithLabelValues(nodeID, exprCacheName, metrics.CacheMissLabel).Inc()
+
+	expr, err := ParseExpr(schema, exprStr)
+	if err != nil || ok {
+		return expr, err
+	}
+	getExprCache().Put(key, newExprTemplate(expr, constants))
+	return expr, nil
+}
+
+// templatize replaces the constants of the expression by the placeholders of their types,
+// returns false if the expression is invalid or not supported to be cached.
+func templatize(exprStr string) (string, []*planpb.GenericValue, bool) {
+	errorListener := &errorListener{}
+	lexer := getLexer(antlr.NewInputStream(exprStr), errorListener)
+	tokens := lexer.GetAllTokens()
+	lexer.RemoveErrorListeners()
+	putLexer(lexer)
+	if errorListener.err != nil {
+		return "", nil, false
+	}
+
+	var (
+		builder   strings.Builder
+		constants = make([]*planpb.GenericValue, 0)
+		negative  = false
+	)
+	for i, token := range tokens {
+		var (
+			value       *planpb.GenericValue
+			placeholder string
+			err         error
+		)
+		text := token.GetText()
+		switch token.GetTokenType() {
+		case antlrparser.PlanLexerLIKE:
+			// the pattern decides the op of the expression
+			return "", nil, false
+		case antlrparser.PlanLexerSUB:
+			// the sign of a constant is a part of the constant
+			if i+1 < len(tokens) && isNumberToken(tokens[i+1]) && (i == 0 || !isOperandEnd(tokens[i-1])) {
+				negative = true
+				continue
+			}
+		case antlrparser.PlanLexerBooleanConstant:
+			var b bool
+			b, err = strconv.ParseBool(text)
+			value, placeholder = NewBool(b), "?bool"
+		case antlrparser.PlanLexerIntegerConstant:
+			var n int64
+			n, err = strconv.ParseInt(text, 0, 64)
+			if negative {
+				n = -n
+			}
+			value, placeholder = NewInt(n), "?int"
+		case antlrparser.PlanLexerFloatingConstant:
+			var f float64
+			f, err = strconv.ParseFloat(text, 64)
+			if negative {
+				f = -f
+			}
+			value, placeholder = NewFloat(f), "?float"
+		case antlrparser.PlanLexerStringLiteral:
+			var s string
+			s, err = convertEscapeSingle(text)
+			value, placeholder = NewString(s), "?str"
+		}
+		if err != nil {
+			return "", nil, false
+		}
+		negative = false
+
+		if builder.Len() > 0 {
+			builder.WriteByte(' ')
+		}
+		if value != nil {
+			constants = append(constants, value)
+			builder.WriteString(placeholder)
+		} else {
+			builder.WriteString(text)
+		}
+	}
+	return builder.String(), constants, true
+}
+
+func isNumberToken(token antlr.Token) bool {
+	return token.GetTokenType() == antlrparser.PlanLexerIntegerConstant ||
+		token.GetTokenType() == antlrparser.PlanLexerFloatingConstant
+}
+
+// isOperandEnd returns whether the token ends an operand, the SUB after it is a binary op.
+func isOperandEnd(token antlr.Token) bool {
+	switch token.GetTokenType() {
+	case antlrparser.PlanLexerIdentifier, antlrparser.PlanLexerJSONIdentifier,
+		antlrparser.PlanLexerBooleanConstant, antlrparser.PlanLexerIntegerConstant,
+		antlrparser.PlanLexerFloatingConstant, antlrparser.PlanLexerStringLiteral,
+		antlrparser.PlanLexerEmptyTerm, antlrparser.PlanLexerT__1, antlrparser.PlanLexerT__4:
+		return true
+	default:
+		return false
+	}
+}
+
+// schemaFingerprint returns the identity of the fields which the parsing depends on.
+func schemaFingerprint(schema *schemapb.CollectionSchema) string {
+	var builder strings.Builder
+	builder.WriteString(strconv.FormatBool(schema.GetEnableDynamicField()))
+	for _, field := range schema.GetFields() {
+		fmt.Fprintf(&builder, "|%d:%s:%d:%d:%t:%t:%t", field.GetFieldID(), field.GetName(), field.GetDataType(),
+			field.GetElementType(), field.GetIsPrimaryKey(), field.GetIsPartitionKey(), field.GetIsDynamic())
+	}
+	return builder.String()
+}
+
+// newExprTemplate binds each value of the parsed expression to the constant of the template,
+// the template is cacheable only if the constants are distinct and each of them is a value of the expression,
+// so the constants are not folded or transformed by the parser.
+func newExprTemplate(expr *planpb.Expr, constants []*planpb.GenericValue) *exprTemplate {
+	values, ok := walkValues(expr)
+	if !ok || len(values) != len(constants) {
+		return &exprTemplate{uncacheable: true}
+	}
+	for i := range constants {
+		for j := 0; j < i; j++ {
+			if equalConstant(constants[j], constants[i]) {
+				return &exprTemplate{uncacheable: true}
+			}
+		}
+	}
+
+	slots := make([]int, len(values))
+	used := make([]bool, len(constants))
+	for i, value := range values {
+		slot := -1
+		for j, constant := range constants {
+			if !used[j] && equalConstant(constant, value) {
+				slot = j
+				break
+			}
+		}
+		if slot < 0 {
+			return &exprTemplate{uncacheable: true}
+		}
+		used[slot] = true
+		slots[i] = slot
+	}
+	return &exprTemplate{
+		expr:  proto.Clone(expr).(*planpb.Expr),
+		slots: slots,
+	}
+}
+
+// bind returns the expression of the template with the constants.
+func (t *exprTemplate) bind(constants []*planpb.GenericValue) *planpb.Expr {
+	expr := proto.Clone(t.expr).(*planpb.Expr)
+	values, _ := walkValues(expr)
+	for i, value := range values {
+		constant := constants[t.slots[i]]
+		// integers are cast to floats for the floating fields
+		if IsFloating(value) && IsInteger(constant) {
+			constant = NewFloat(float64(constant.GetInt64Val()))
+		}
+		value.Val = constant.Val
+	}
+	return expr
+}
+
+// walkValues returns the values of expression in a fixed order,
+// returns false if the expression has the nodes whose plans depend on the values.
+func walkValues(expr *planpb.Expr) ([]*planpb.GenericValue, bool) {
+	values := make([]*planpb.GenericValue, 0)
+	var walk func(expr *planpb.Expr) bool
+	walk = func(expr *planpb.Expr) bool {
+		switch e := expr.GetExpr().(type) {
+		case *planpb.Expr_TermExpr:
+			values = append(values, e.TermExpr.GetValues()...)
+		case *planpb.Expr_UnaryRangeExpr:
+			values = append(values, e.UnaryRangeExpr.GetValue())
+		case *planpb.Expr_BinaryRangeExpr:
+			values = append(values, e.BinaryRangeExpr.GetLowerValue(), e.BinaryRangeExpr.GetUpperValue())
+		case *planpb.Expr_UnaryExpr:
+			return walk(e.UnaryExpr.GetChild())
+		case *planpb.Expr_BinaryExpr:
+			return walk(e.BinaryExpr.GetLeft()) && walk(e.BinaryExpr.GetRight())
+		case *planpb.Expr_CompareExpr, *planpb.Expr_ExistsExpr, *planpb.Expr_AlwaysTrueExpr:
+		default:
+			return false
+		}
+		return true
+	}
+	if !walk(expr) {
+		return nil, false
+	}
+	return values, true
+}
+
+// equalConstant returns whether the constant equals to the value, the integers equal to the same floats.
+func equalConstant(constant, value *planpb.GenericValue) bool {
+	switch {
+	case IsBool(constant):
+		return IsBool(value) && constant.GetBoolVal() == value.GetBoolVal()
+	case IsString(constant):
+		return IsString(value) && constant.GetStringVal() == value.GetStringVal()
+	case IsInteger(constant):
+		return (IsInteger(value) && constant.GetInt64Val() == value.GetInt64Val()) ||
+			(IsFloating(value) && float64(constant.GetInt64Val()) == value.GetFloatVal())
+	case IsFloating(constant):
+		return (IsFloating(value) && constant.GetFloatVal() == value.GetFloatVal()) ||
+			(IsInteger(value) && constant.GetFloatVal() == float64(value.GetInt64Val()))
+	default:
+		return false
+	}
+}
@@ -0,0 +1,88 @@
+package planparserv2
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestMain(m *testing.M) {
+	paramtable.Init()
+	os.Exit(m.Run())
+}
+
+func TestTemplatize(t *testing.T) {
+	template, constants, ok := templatize(`Int64Field > -1 and VarCharField in ["a", 'b'] and BoolField == true`)
+	assert.True(t, ok)
+	assert.Equal(t, `Int64Field > ?int and VarCharField in [ ?str , ?str ] and BoolField == ?bool`, template)
+	assert.Len(t, constants, 4)
+	assert.EqualValues(t, -1, constants[0].GetInt64Val())
+	assert.Equal(t, "a", constants[1].GetStringVal())
+	assert.Equal(t, "b", constants[2].GetStringVal())
+	assert.True(t, constants[3].GetBoolVal())
+
+	other, _, ok := templatize(`Int64Field>10 and VarCharField in ["x","y"] and BoolField == false`)
+	assert.True(t, ok)
+	assert.Equal(t, template, other)
+
+	// the SUB after an operand is an arithmetic op
+	template, constants, ok = templatize(`Int64Field - 1 > 2`)
+	assert.True(t, ok)
+	assert.Equal(t, `Int64Field - ?int > ?int`, template)
+	assert.EqualValues(t, 1, constants[0].GetInt64Val())
+
+	_, _, ok = templatize(`VarCharField like "a%"`)
+	assert.False(t, ok)
+}
+
+func TestParseExprWithCache(t *testing.T) {
+	schemaPb := newTestSchema()
+	helper, err := typeutil.CreateSchemaHelper(schemaPb)
+	require.NoError(t, err)
+
+	exprs := []string{
+		`Int64Field > 1 and Int64Field < 100`,
+		`Int64Field > 5 and Int64Field < 50`,
+		`FloatField >= 1 or VarCharField in ["a", "b"]`,
+		`FloatField >= 2.5 or VarCharField in ["c", "d"]`,
+		`1 < Int64Field <= 10`,
+		`10 < Int64Field <= 20`,
+		`not (BoolField == true) and JSONField["a"] != -3`,
+		`not (BoolField == false) and JSONField["a"] != 3`,
+		// the constants are not distinct, always parsed
+		`Int64Field > 1 and Int32Field < 1`,
+		`Int64Field > 2 and Int32Field < 3`,
+		// the constants are folded, always parsed
+		`Int64Field > 1 + 2`,
+		`Int64Field > 3 + 4`,
+		`Int64Field + 1 > 2`,
+		`Int64Field + 3 > 4`,
+	}
+	for _, exprStr := range exprs {
+		expected, err := ParseExpr(helper, exprStr)
+		require.NoError(t, err, exprStr)
+		for i := 0; i < 2; i++ {
+			expr, err := parseExprWithCache(schemaPb, helper, exprStr)
+			require.NoError(t, err, exprStr)
+			assert.True(t, proto.Equal(expected, expr), exprStr)
+		}
+	}
+
+	_, err = parseExprWithCache(schemaPb, helper, `Int64Field > "a"`)
+	assert.Error(t, err)
+	_, err = parseExprWithCache(schemaPb, helper, `Int64Field > "b"`)
+	assert.Error(t, err)
+
+	template, constants, ok := templatize(`Int64Field > 1 and Int32Field < 1`)
+	assert.True(t, ok)
+	cached, ok := getExprCache().GetIfPresent(schemaFingerprint(schemaPb) + "\n" + template)
+	assert.True(t, ok)
+	assert.True(t, cached.uncacheable)
+	assert.Len(t, constants, 2)
+}
@@ -94,7 +94,7 @@ func CreateRetrievePlan(schemaPb *schemapb.CollectionSchema, exprStr string) (*p
 		return nil, err
 	}
 
-	expr, err := ParseExpr(schema, exprStr)
+	expr, err := parseExprWithCache(schemaPb, schema, exprStr)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +119,7 @@ func CreateSearchPlan(schemaPb *schemapb.CollectionSchema, exprStr string, vecto
 		if len(exprStr) <= 0 {
 			return nil, nil
 		}
-		return ParseExpr(schema, exprStr)
+		return parseExprWithCache(schemaPb, schema, exprStr)
 	}
 
 	expr, err := parse()
@@ -0,0 +1,316 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// analyzeUIPage is the web page to submit the analyze jobs and browse their reports,
+// it's served by the management http server of proxy along with the analyze API.
+//
+//go:embed webui/analyze.html
+var analyzeUIPage []byte
+
+// analyzeRequest is the request of AnalyzeCollection.
+type analyzeRequest struct {
+	DbName         string   `json:"db_name"`
+	CollectionName string   `json:"collection_name"`
+	PartitionNames []string `json:"partition_names"`
+	// SampleSize is the number of rows sampled for the vector distribution, 0 means proxy.analyze.sampleSize
+	SampleSize int `json:"sample_size"`
+}
+
+type analyzeJob struct {
+	ID        int64           `json:"job_id"`
+	Request   *analyzeRequest `json:"request"`
+	State     exportJobState  `json:"state"`
+	Reason    string          `json:"reason,omitempty"`
+	Report    *analyzeReport  `json:"report,omitempty"`
+	StartTime time.Time       `json:"start_time"`
+	EndTime   time.Time       `json:"end_time,omitempty"`
+}
+
+// analyzeJobManager runs the analyze jobs, which sample the entities of collection
+// and report the data distribution and the recommended indexes.
+// The entities are scanned in the order of primary key like export, at most proxy.analyze.maxScanRows rows.
+// Jobs are kept in memory, so they're lost once the proxy restarts.
+type analyzeJobManager struct {
+	mu   sync.RWMutex
+	jobs map[int64]*analyzeJob
+
+	sem       chan struct{}
+	query     exportQueryFunc
+	getSchema exportSchemaFunc
+}
+
+func newAnalyzeJobManager(query exportQueryFunc, getSchema exportSchemaFunc) *analyzeJobManager {
+	return &analyzeJobManager{
+		jobs:      make(map[int64]*analyzeJob),
+		sem:       make(chan struct{}, paramtable.Get().ProxyCfg.AnalyzeMaxRunningJobs.GetAsInt()),
+		query:     query,
+		getSchema: getSchema,
+	}
+}
+
+// Submit starts the analyze job in background.
+func (m *analyzeJobManager) Submit(ctx context.Context, jobID int64, req *analyzeRequest) error {
+	if req.CollectionName == "" {
+		return merr.WrapErrParameterInvalidMsg("collection name is empty")
+	}
+	if req.SampleSize < 0 {
+		return merr.WrapErrParameterInvalidMsg("invalid sample size %d", req.SampleSize)
+	}
+	if req.SampleSize == 0 {
+		req.SampleSize = paramtable.Get().ProxyCfg.AnalyzeSampleSize.GetAsInt()
+	}
+
+	job := &analyzeJob{
+		ID:        jobID,
+		Request:   req,
+		State:     exportJobPending,
+		StartTime: time.Now(),
+	}
+	m.mu.Lock()
+	m.jobs[jobID] = job
+	m.mu.Unlock()
+
+	go m.run(ctx, job)
+	return nil
+}
+
+// Get returns a copy of the job, the report is immutable once set.
+func (m *analyzeJobManager) Get(jobID int64) (*analyzeJob, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.jobs[jobID]
+	if !ok {
+		return nil, false
+	}
+	cloned := *job
+	return &cloned, true
+}
+
+// List returns copies of all the jobs in the order of ID.
+func (m *analyzeJobManager) List() []*analyzeJob {
+	m.mu.RLock()
+	ids := make([]int64, 0, len(m.jobs))
+	for id := range m.jobs {
+		ids = append(ids, id)
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	jobs := make([]*analyzeJob, 0, len(ids))
+	for _, id := range ids {
+		if job, ok := m.Get(id); ok {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+func (m *analyzeJobManager) update(job *analyzeJob, fn func(job *analyzeJob)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(job)
+}
+
+func (m *analyzeJobManager) run(ctx context.Context, job *analyzeJob) {
+	select {
+	case m.sem <- struct{}{}:
+		defer func() { <-m.sem }()
+	case <-ctx.Done():
+		m.update(job, func(job *analyzeJob) {
+			job.State = exportJobFailed
+			job.Reason = ctx.Err().Error()
+			job.EndTime = time.Now()
+		})
+		return
+	}
+
+	log := log.Ctx(ctx).With(zap.Int64("jobID", job.ID),
+		zap.String("db", job.Request.DbName),
+		zap.String("collection", job.Request.CollectionName))
+	log.Info("analyze job started")
+	m.update(job, func(job *analyzeJob) { job.State = exportJobRunning })
+
+	report, err := m.analyze(ctx, job.Request)
+	m.update(job, func(job *analyzeJob) {
+		job.EndTime = time.Now()
+		if err != nil {
+			job.State = exportJobFailed
+			job.Reason = err.Error()
+			return
+		}
+		job.State = exportJobCompleted
+		job.Report = report
+	})
+	if err != nil {
+		log.Warn("analyze job failed", zap.Error(err))
+		return
+	}
+	log.Info("analyze job completed", zap.Int64("scannedRows", report.ScannedRows), zap.Duration("elapse", time.Since(job.StartTime)))
+}
+
+func (m *analyzeJobManager) analyze(ctx context.Context, req *analyzeRequest) (*analyzeReport, error) {
+	schema, err := m.getSchema(ctx, req.DbName, req.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	mvccTs := tsoutil.ComposeTSByTime(time.Now(), 0)
+	countResult, err := m.query(ctx, &milvuspb.QueryRequest{
+		DbName:           req.DbName,
+		CollectionName:   req.CollectionName,
+		PartitionNames:   req.PartitionNames,
+		OutputFields:     []string{"count(*)"},
+		ConsistencyLevel: commonpb.ConsistencyLevel_Strong,
+	}, mvccTs)
+	if err := merr.CheckRPCCall(countResult, err); err != nil {
+		return nil, err
+	}
+	totalRows := int64(0)
+	if len(countResult.GetFieldsData()) > 0 {
+		if data := countResult.GetFieldsData()[0].GetScalars().GetLongData().GetData(); len(data) > 0 {
+			totalRows = data[0]
+		}
+	}
+
+	collector := newAnalyzeCollector(schema, req.SampleSize)
+	batchSize := paramtable.Get().ProxyCfg.ExportBatchSize.GetAsInt64()
+	maxScanRows := paramtable.Get().ProxyCfg.AnalyzeMaxScanRows.GetAsInt64()
+	var lastPK *schemapb.IDs
+	for collector.rows < maxScanRows {
+		result, err := m.query(ctx, &milvuspb.QueryRequest{
+			DbName:           req.DbName,
+			CollectionName:   req.CollectionName,
+			PartitionNames:   req.PartitionNames,
+			Expr:             buildExportExpr("", pkField, lastPK),
+			OutputFields:     []string{"*"},
+			QueryParams:      []*commonpb.KeyValuePair{{Key: LimitKey, Value: strconv.FormatInt(batchSize, 10)}},
+			ConsistencyLevel: commonpb.ConsistencyLevel_Strong,
+		}, mvccTs)
+		if err := merr.CheckRPCCall(result, err); err != nil {
+			return nil, err
+		}
+		rows, maxPK, err := collector.Collect(result.GetFieldsData(), pkField)
+		if err != nil {
+			return nil, err
+		}
+		if int64(rows) < batchSize {
+			break
+		}
+		lastPK = maxPK
+	}
+	return collector.Report(totalRows), nil
+}
+
+func (node *Proxy) initAnalyzeJobManager() {
+	node.analyzeManager = newAnalyzeJobManager(node.queryAt, globalMetaCache.GetCollectionSchema)
+}
+
+// AnalyzeCollection submits a job to analyze the data distribution of the collection and recommend the indexes.
+func (node *Proxy) AnalyzeCollection(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return
+	}
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to analyze collection, %s"}`, err.Error())))
+		return
+	}
+	analyzeReq := &analyzeRequest{}
+	if err := json.NewDecoder(req.Body).Decode(analyzeReq); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to analyze collection, %s"}`, err.Error())))
+		return
+	}
+	jobID, err := node.rowIDAllocator.AllocOne()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to analyze collection, %s"}`, err.Error())))
+		return
+	}
+	// the job outlives the http request
+	if err := node.analyzeManager.Submit(node.ctx, jobID, analyzeReq); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to analyze collection, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(fmt.Sprintf(`{"msg": "OK", "job_id": %d}`, jobID)))
+}
+
+// GetAnalyzeState returns the state and the report of the analyze job.
+func (node *Proxy) GetAnalyzeState(w http.ResponseWriter, req *http.Request) {
+	jobID, err := strconv.ParseInt(req.URL.Query().Get("job_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid job_id, %s"}`, err.Error())))
+		return
+	}
+	job, ok := node.analyzeManager.Get(jobID)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "analyze job %d not found"}`, jobID)))
+		return
+	}
+	mgrWriteJSON(w, job)
+}
+
+// ListAnalyzeJobs lists the analyze jobs submitted to this proxy.
+func (node *Proxy) ListAnalyzeJobs(w http.ResponseWriter, req *http.Request) {
+	mgrWriteJSON(w, node.analyzeManager.List())
+}
+
+// AnalyzeUI serves the web page of the analyze jobs.
+func (node *Proxy) AnalyzeUI(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only GET is allowed"}`))
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(analyzeUIPage)
+}
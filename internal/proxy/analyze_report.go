@@ -0,0 +1,626 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"strconv"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metric"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	// analyzeMaxDistinct caps the distinct values tracked for each scalar field
+	analyzeMaxDistinct = 10000
+	// analyzeContrastQueries is the number of sampled vectors used as queries to estimate the relative contrast
+	analyzeContrastQueries = 100
+	// normalizedTolerance is the max deviation of norm from 1 for a vector to be treated as normalized
+	normalizedTolerance = 1e-3
+
+	analyzeDifficultyEasy     = "easy"
+	analyzeDifficultyModerate = "moderate"
+	analyzeDifficultyHard     = "hard"
+)
+
+type analyzeReport struct {
+	TotalRows   int64                 `json:"total_rows"`
+	ScannedRows int64                 `json:"scanned_rows"`
+	SampledRows int64                 `json:"sampled_rows"`
+	Fields      []*analyzeFieldReport `json:"fields"`
+}
+
+type analyzeFieldReport struct {
+	FieldName       string                 `json:"field_name"`
+	DataType        string                 `json:"data_type"`
+	Vector          *vectorDistribution    `json:"vector,omitempty"`
+	Scalar          *scalarDistribution    `json:"scalar,omitempty"`
+	Recommendations []*indexRecommendation `json:"recommendations,omitempty"`
+}
+
+// vectorDistribution describes the distribution of vector field,
+// norms are computed over the scanned rows, variances and contrast over the sampled rows.
+type vectorDistribution struct {
+	Dim         int64   `json:"dim"`
+	ZeroVectors int64   `json:"zero_vectors,omitempty"`
+	NormMin     float64 `json:"norm_min,omitempty"`
+	NormMax     float64 `json:"norm_max,omitempty"`
+	NormMean    float64 `json:"norm_mean,omitempty"`
+	Normalized  bool    `json:"normalized,omitempty"`
+	// per-dimension variances, the dimensions with tiny variance contribute little to the distance
+	VarianceMin  float64 `json:"variance_min,omitempty"`
+	VarianceMax  float64 `json:"variance_max,omitempty"`
+	VarianceMean float64 `json:"variance_mean,omitempty"`
+	// RelativeContrast is the mean distance divided by the nearest neighbor distance,
+	// the closer to 1, the harder for the approximate indexes to tell the neighbors from the others.
+	RelativeContrast float64 `json:"relative_contrast,omitempty"`
+	Difficulty       string  `json:"difficulty,omitempty"`
+	// BitDensity is the ratio of set bits of binary vectors
+	BitDensity float64 `json:"bit_density,omitempty"`
+}
+
+type scalarDistribution struct {
+	// Distinct stops growing at analyzeMaxDistinct, DistinctCapped is set then
+	Distinct       int64    `json:"distinct"`
+	DistinctCapped bool     `json:"distinct_capped,omitempty"`
+	Min            *float64 `json:"min,omitempty"`
+	Max            *float64 `json:"max,omitempty"`
+	AvgLength      float64  `json:"avg_length,omitempty"`
+	MaxLength      int      `json:"max_length,omitempty"`
+}
+
+type indexRecommendation struct {
+	IndexType       string            `json:"index_type"`
+	MetricType      string            `json:"metric_type,omitempty"`
+	Params          map[string]string `json:"params,omitempty"`
+	ExpectedRecall  string            `json:"expected_recall,omitempty"`
+	ExpectedLatency string            `json:"expected_latency,omitempty"`
+	Reason          string            `json:"reason"`
+}
+
+type vectorCollector struct {
+	field  *schemapb.FieldSchema
+	dim    int
+	sample [][]float32
+	bytes  [][]byte
+
+	zeros     int64
+	normSum   float64
+	normMin   float64
+	normMax   float64
+	normRows  int64
+	setBits   int64
+	totalBits int64
+}
+
+type scalarCollector struct {
+	field    *schemapb.FieldSchema
+	distinct map[any]struct{}
+	capped   bool
+	min      float64
+	max      float64
+	numbers  int64
+	lenSum   int64
+	lenRows  int64
+	maxLen   int
+}
+
+// analyzeCollector collects the distribution of query results batch by batch,
+// the vectors are sampled by reservoir sampling, so each scanned row has the same chance to be sampled.
+type analyzeCollector struct {
+	schema     *schemapb.CollectionSchema
+	sampleSize int
+	rows       int64
+	sampled    int
+	rnd        *rand.Rand
+
+	vectors []*vectorCollector
+	scalars []*scalarCollector
+}
+
+func newAnalyzeCollector(schema *schemapb.CollectionSchema, sampleSize int) *analyzeCollector {
+	c := &analyzeCollector{
+		schema:     schema,
+		sampleSize: sampleSize,
+		rnd:        rand.New(rand.NewSource(rand.Int63())),
+	}
+	for _, field := range schema.GetFields() {
+		if field.GetFieldID() < common.StartOfUserFieldID {
+			continue
+		}
+		if typeutil.IsVectorType(field.GetDataType()) {
+			dim, _ := typeutil.GetDim(field)
+			c.vectors = append(c.vectors, &vectorCollector{field: field, dim: int(dim), normMin: math.MaxFloat64})
+			continue
+		}
+		c.scalars = append(c.scalars, &scalarCollector{field: field, distinct: make(map[any]struct{}), min: math.MaxFloat64, max: -math.MaxFloat64})
+	}
+	return c
+}
+
+// Collect collects the query results, returns the number of rows and the max primary key.
+func (c *analyzeCollector) Collect(fieldsData []*schemapb.FieldData, pkField *schemapb.FieldSchema) (int, *schemapb.IDs, error) {
+	columns := make(map[string]*schemapb.FieldData, len(fieldsData))
+	for _, fieldData := range fieldsData {
+		columns[fieldData.GetFieldName()] = fieldData
+	}
+	pkData, ok := columns[pkField.GetName()]
+	if !ok {
+		return 0, nil, merr.WrapErrServiceInternal("primary key not found in query results")
+	}
+	rows := typeutil.GetPKSize(pkData)
+	if rows == 0 {
+		return 0, nil, nil
+	}
+
+	// decide the sample slot of each row once, so the sampled vectors of different fields belong to the same rows
+	slots := make([]int, rows)
+	for i := range slots {
+		slots[i] = -1
+		seen := c.rows + int64(i)
+		if c.sampled < c.sampleSize {
+			slots[i] = c.sampled
+			c.sampled++
+		} else if j := c.rnd.Int63n(seen + 1); j < int64(c.sampleSize) {
+			slots[i] = int(j)
+		}
+	}
+
+	for _, vc := range c.vectors {
+		fieldData, ok := columns[vc.field.GetName()]
+		if !ok {
+			return 0, nil, merr.WrapErrServiceInternal(fmt.Sprintf("field %s not found in query results", vc.field.GetName()))
+		}
+		vc.collect(fieldData, rows, slots)
+	}
+	for _, sc := range c.scalars {
+		fieldData, ok := columns[sc.field.GetName()]
+		if !ok {
+			// the dynamic field is not returned if no row has dynamic values
+			continue
+		}
+		sc.collect(fieldData, rows)
+	}
+	c.rows += int64(rows)
+	return rows, exportMaxPK(pkField, pkData), nil
+}
+
+func (vc *vectorCollector) collect(fieldData *schemapb.FieldData, rows int, slots []int) {
+	switch vc.field.GetDataType() {
+	case schemapb.DataType_FloatVector:
+		data := fieldData.GetVectors().GetFloatVector().GetData()
+		for i := 0; i < rows && (i+1)*vc.dim <= len(data); i++ {
+			vector := data[i*vc.dim : (i+1)*vc.dim]
+			norm := float64(0)
+			for _, v := range vector {
+				norm += float64(v) * float64(v)
+			}
+			norm = math.Sqrt(norm)
+			if norm == 0 {
+				vc.zeros++
+			}
+			vc.normSum += norm
+			vc.normMin = math.Min(vc.normMin, norm)
+			vc.normMax = math.Max(vc.normMax, norm)
+			vc.normRows++
+			if slots[i] >= 0 {
+				vc.sample = setSample(vc.sample, slots[i], append([]float32(nil), vector...))
+			}
+		}
+	case schemapb.DataType_BinaryVector:
+		data := fieldData.GetVectors().GetBinaryVector()
+		width := vc.dim / 8
+		for i := 0; i < rows && (i+1)*width <= len(data); i++ {
+			vector := data[i*width : (i+1)*width]
+			for _, b := range vector {
+				vc.setBits += int64(bits.OnesCount8(b))
+			}
+			vc.totalBits += int64(vc.dim)
+			if slots[i] >= 0 {
+				vc.bytes = setSample(vc.bytes, slots[i], append([]byte(nil), vector...))
+			}
+		}
+	}
+}
+
+func setSample[T any](sample []T, slot int, v T) []T {
+	if slot >= len(sample) {
+		return append(sample, v)
+	}
+	sample[slot] = v
+	return sample
+}
+
+func (sc *scalarCollector) collect(fieldData *schemapb.FieldData, rows int) {
+	scalars := fieldData.GetScalars()
+	var numbers []float64
+	var strs []string
+	switch sc.field.GetDataType() {
+	case schemapb.DataType_Bool:
+		for _, v := range scalars.GetBoolData().GetData() {
+			sc.addDistinct(v)
+		}
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		for _, v := range scalars.GetIntData().GetData() {
+			numbers = append(numbers, float64(v))
+			sc.addDistinct(int64(v))
+		}
+	case schemapb.DataType_Int64:
+		for _, v := range scalars.GetLongData().GetData() {
+			numbers = append(numbers, float64(v))
+			sc.addDistinct(v)
+		}
+	case schemapb.DataType_Float:
+		for _, v := range scalars.GetFloatData().GetData() {
+			numbers = append(numbers, float64(v))
+			sc.addDistinct(float64(v))
+		}
+	case schemapb.DataType_Double:
+		for _, v := range scalars.GetDoubleData().GetData() {
+			numbers = append(numbers, v)
+			sc.addDistinct(v)
+		}
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		strs = scalars.GetStringData().GetData()
+		for _, v := range strs {
+			sc.addDistinct(v)
+		}
+	case schemapb.DataType_JSON:
+		for _, v := range scalars.GetJsonData().GetData() {
+			strs = append(strs, string(v))
+		}
+	}
+	for _, v := range numbers {
+		sc.min = math.Min(sc.min, v)
+		sc.max = math.Max(sc.max, v)
+		sc.numbers++
+	}
+	for _, v := range strs {
+		sc.lenSum += int64(len(v))
+		sc.lenRows++
+		if len(v) > sc.maxLen {
+			sc.maxLen = len(v)
+		}
+	}
+}
+
+func (sc *scalarCollector) addDistinct(v any) {
+	if sc.capped {
+		return
+	}
+	sc.distinct[v] = struct{}{}
+	if len(sc.distinct) >= analyzeMaxDistinct {
+		sc.capped = true
+	}
+}
+
+// Report builds the report, totalRows is the number of rows in collection, which may exceed the scanned rows.
+func (c *analyzeCollector) Report(totalRows int64) *analyzeReport {
+	if totalRows < c.rows {
+		totalRows = c.rows
+	}
+	report := &analyzeReport{
+		TotalRows:   totalRows,
+		ScannedRows: c.rows,
+		SampledRows: int64(c.sampled),
+	}
+	for _, vc := range c.vectors {
+		dist := vc.distribution()
+		report.Fields = append(report.Fields, &analyzeFieldReport{
+			FieldName:       vc.field.GetName(),
+			DataType:        vc.field.GetDataType().String(),
+			Vector:          dist,
+			Recommendations: recommendVectorIndexes(vc.field.GetDataType(), dist, totalRows),
+		})
+	}
+	for _, sc := range c.scalars {
+		dist := sc.distribution()
+		fieldReport := &analyzeFieldReport{
+			FieldName: sc.field.GetName(),
+			DataType:  sc.field.GetDataType().String(),
+			Scalar:    dist,
+		}
+		if !sc.field.GetIsPrimaryKey() {
+			if recommendation := recommendScalarIndex(sc.field.GetDataType(), dist); recommendation != nil {
+				fieldReport.Recommendations = []*indexRecommendation{recommendation}
+			}
+		}
+		report.Fields = append(report.Fields, fieldReport)
+	}
+	return report
+}
+
+func (vc *vectorCollector) distribution() *vectorDistribution {
+	dist := &vectorDistribution{Dim: int64(vc.dim)}
+	switch vc.field.GetDataType() {
+	case schemapb.DataType_FloatVector:
+		if vc.normRows == 0 {
+			return dist
+		}
+		dist.ZeroVectors = vc.zeros
+		dist.NormMin = vc.normMin
+		dist.NormMax = vc.normMax
+		dist.NormMean = vc.normSum / float64(vc.normRows)
+		dist.Normalized = math.Abs(vc.normMin-1) < normalizedTolerance && math.Abs(vc.normMax-1) < normalizedTolerance
+		dist.VarianceMin, dist.VarianceMax, dist.VarianceMean = dimensionVariances(vc.sample, vc.dim)
+		dist.RelativeContrast = relativeContrast(vc.sample)
+		dist.Difficulty = contrastDifficulty(dist.RelativeContrast)
+	case schemapb.DataType_BinaryVector:
+		if vc.totalBits > 0 {
+			dist.BitDensity = float64(vc.setBits) / float64(vc.totalBits)
+		}
+	}
+	return dist
+}
+
+func (sc *scalarCollector) distribution() *scalarDistribution {
+	dist := &scalarDistribution{
+		Distinct:       int64(len(sc.distinct)),
+		DistinctCapped: sc.capped,
+	}
+	if sc.numbers > 0 {
+		min, max := sc.min, sc.max
+		dist.Min, dist.Max = &min, &max
+	}
+	if sc.lenRows > 0 {
+		dist.AvgLength = float64(sc.lenSum) / float64(sc.lenRows)
+		dist.MaxLength = sc.maxLen
+	}
+	return dist
+}
+
+// dimensionVariances returns the min, max and mean of the per-dimension variances.
+func dimensionVariances(sample [][]float32, dim int) (float64, float64, float64) {
+	if len(sample) == 0 || dim == 0 {
+		return 0, 0, 0
+	}
+	sum := make([]float64, dim)
+	sqSum := make([]float64, dim)
+	for _, vector := range sample {
+		for d, v := range vector {
+			sum[d] += float64(v)
+			sqSum[d] += float64(v) * float64(v)
+		}
+	}
+	n := float64(len(sample))
+	min, max, total := math.MaxFloat64, float64(0), float64(0)
+	for d := 0; d < dim; d++ {
+		mean := sum[d] / n
+		variance := math.Max(sqSum[d]/n-mean*mean, 0)
+		min = math.Min(min, variance)
+		max = math.Max(max, variance)
+		total += variance
+	}
+	return min, max, total / float64(dim)
+}
+
+// relativeContrast estimates the relative contrast of the sample in euclidean distance,
+// see "On the Difficulty of Nearest Neighbor Search", He et al., ICML 2012.
+func relativeContrast(sample [][]float32) float64 {
+	if len(sample) < 2 {
+		return 0
+	}
+	queries := analyzeContrastQueries
+	if queries > len(sample) {
+		queries = len(sample)
+	}
+	total, counted := float64(0), 0
+	for q := 0; q < queries; q++ {
+		nearest, sum := math.MaxFloat64, float64(0)
+		for i, vector := range sample {
+			if i == q {
+				continue
+			}
+			distance := math.Sqrt(squaredL2(sample[q], vector))
+			nearest = math.Min(nearest, distance)
+			sum += distance
+		}
+		// duplicated vectors make the contrast infinite, skip them
+		if nearest == 0 {
+			continue
+		}
+		total += sum / float64(len(sample)-1) / nearest
+		counted++
+	}
+	if counted == 0 {
+		return 0
+	}
+	return total / float64(counted)
+}
+
+func squaredL2(a, b []float32) float64 {
+	sum := float64(0)
+	for i := range a {
+		diff := float64(a[i]) - float64(b[i])
+		sum += diff * diff
+	}
+	return sum
+}
+
+func contrastDifficulty(contrast float64) string {
+	switch {
+	case contrast == 0:
+		return ""
+	case contrast >= 2:
+		return analyzeDifficultyEasy
+	case contrast >= 1.5:
+		return analyzeDifficultyModerate
+	default:
+		return analyzeDifficultyHard
+	}
+}
+
+// ivfNlist returns the recommended nlist, about 4 * sqrt(rows).
+func ivfNlist(rows int64) int64 {
+	nlist := int64(4 * math.Sqrt(float64(rows)))
+	if nlist < 1024 {
+		nlist = 1024
+	}
+	if nlist > 65536 {
+		nlist = 65536
+	}
+	return nlist
+}
+
+// adjustRecall lowers the expected recall of the approximate indexes for the hard data.
+func adjustRecall(recall string, dist *vectorDistribution) string {
+	switch dist.Difficulty {
+	case analyzeDifficultyModerate:
+		return recall + ", lower on this data"
+	case analyzeDifficultyHard:
+		return recall + ", much lower on this data, raise nprobe/ef"
+	default:
+		return recall
+	}
+}
+
+// recommendVectorIndexes recommends the indexes by the scale of collection,
+// the recall and latency are rough estimates with default search params, not measured.
+func recommendVectorIndexes(dataType schemapb.DataType, dist *vectorDistribution, rows int64) []*indexRecommendation {
+	switch dataType {
+	case schemapb.DataType_BinaryVector:
+		recommendations := []*indexRecommendation{{
+			IndexType:       indexparamcheck.IndexFaissBinIDMap,
+			MetricType:      metric.HAMMING,
+			ExpectedRecall:  "100%",
+			ExpectedLatency: "grows linearly with rows",
+			Reason:          "brute force search is exact",
+		}}
+		if rows > 100000 {
+			nlist := ivfNlist(rows)
+			recommendations = append(recommendations, &indexRecommendation{
+				IndexType:       indexparamcheck.IndexFaissBinIvfFlat,
+				MetricType:      metric.HAMMING,
+				Params:          map[string]string{"nlist": strconv.FormatInt(nlist, 10)},
+				ExpectedRecall:  "~95% with nprobe=nlist/64",
+				ExpectedLatency: "low",
+				Reason:          fmt.Sprintf("%d rows are too many for brute force search", rows),
+			})
+		}
+		return recommendations
+	case schemapb.DataType_FloatVector:
+	case schemapb.DataType_Float16Vector:
+		// no distribution of float16 vectors is collected, recommend by scale only
+	default:
+		return nil
+	}
+
+	metricType := metric.L2
+	if dist.Normalized {
+		metricType = metric.IP
+	}
+	hnswM := "16"
+	if dist.Dim > 512 || dist.Difficulty == analyzeDifficultyHard {
+		hnswM = "32"
+	}
+	hnsw := &indexRecommendation{
+		IndexType:       indexparamcheck.IndexHNSW,
+		MetricType:      metricType,
+		Params:          map[string]string{"M": hnswM, "efConstruction": "200"},
+		ExpectedRecall:  adjustRecall("~98% with ef=64", dist),
+		ExpectedLatency: "lowest, memory about 1.1x to 1.5x of raw vectors",
+		Reason:          "graph index performs best for in-memory search",
+	}
+
+	switch {
+	case rows <= 100000:
+		return []*indexRecommendation{
+			{
+				IndexType:       indexparamcheck.IndexFaissIDMap,
+				MetricType:      metricType,
+				ExpectedRecall:  "100%",
+				ExpectedLatency: "low for small collections",
+				Reason:          fmt.Sprintf("brute force search is exact and cheap for %d rows", rows),
+			},
+			hnsw,
+		}
+	case rows <= 10000000:
+		nlist := strconv.FormatInt(ivfNlist(rows), 10)
+		return []*indexRecommendation{
+			hnsw,
+			{
+				IndexType:       indexparamcheck.IndexFaissIvfFlat,
+				MetricType:      metricType,
+				Params:          map[string]string{"nlist": nlist},
+				ExpectedRecall:  adjustRecall("~95% with nprobe=nlist/64", dist),
+				ExpectedLatency: "moderate, memory about the size of raw vectors",
+				Reason:          "cheaper to build and smaller than HNSW",
+			},
+			{
+				IndexType:       indexparamcheck.IndexFaissIvfSQ8,
+				MetricType:      metricType,
+				Params:          map[string]string{"nlist": nlist},
+				ExpectedRecall:  adjustRecall("~93% with nprobe=nlist/64", dist),
+				ExpectedLatency: "moderate, memory about 1/4 of raw vectors",
+				Reason:          "scalar quantization saves memory at little recall cost",
+			},
+		}
+	default:
+		m := dist.Dim / 4
+		if m < 1 {
+			m = 1
+		}
+		for m > 1 && dist.Dim%m != 0 {
+			m--
+		}
+		return []*indexRecommendation{
+			{
+				IndexType:       indexparamcheck.IndexDISKANN,
+				MetricType:      metricType,
+				ExpectedRecall:  adjustRecall("~95% with search_list=100", dist),
+				ExpectedLatency: "moderate, most vectors stay on local disk",
+				Reason:          fmt.Sprintf("%d rows are too many to be held in memory", rows),
+			},
+			{
+				IndexType:       indexparamcheck.IndexFaissIvfPQ,
+				MetricType:      metricType,
+				Params:          map[string]string{"nlist": strconv.FormatInt(ivfNlist(rows), 10), "m": strconv.FormatInt(m, 10), "nbits": "8"},
+				ExpectedRecall:  adjustRecall("~85% with nprobe=nlist/64", dist),
+				ExpectedLatency: "low, memory about 1/16 of raw vectors",
+				Reason:          "product quantization fits large collections in memory",
+			},
+		}
+	}
+}
+
+// recommendScalarIndex recommends the default index of scalar field, nil if the index won't help filtering.
+func recommendScalarIndex(dataType schemapb.DataType, dist *scalarDistribution) *indexRecommendation {
+	if dist.Distinct <= 1 {
+		return nil
+	}
+	switch {
+	case typeutil.IsArithmetic(dataType):
+		return &indexRecommendation{
+			IndexType: DefaultArithmeticIndexType,
+			Reason:    fmt.Sprintf("speeds up range and equality filters over %d distinct values", dist.Distinct),
+		}
+	case typeutil.IsStringType(dataType):
+		return &indexRecommendation{
+			IndexType: DefaultStringIndexType,
+			Reason:    fmt.Sprintf("speeds up equality and prefix filters over %d distinct values", dist.Distinct),
+		}
+	default:
+		return nil
+	}
+}
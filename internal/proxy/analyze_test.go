@@ -0,0 +1,201 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metric"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestAnalyzeCollector(t *testing.T) {
+	schema := newExportTestSchema()
+	pkField := schema.GetFields()[1]
+	collector := newAnalyzeCollector(schema, 3)
+
+	rows, maxPK, err := collector.Collect(newExportTestFieldsData([]int64{1, 2, 3}), pkField)
+	require.NoError(t, err)
+	assert.Equal(t, 3, rows)
+	assert.EqualValues(t, 3, maxPK.GetIntId().GetData()[0])
+	rows, _, err = collector.Collect(newExportTestFieldsData([]int64{4, 5}), pkField)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rows)
+
+	_, _, err = collector.Collect(newExportTestFieldsData([]int64{6})[1:], pkField)
+	assert.ErrorIs(t, err, merr.ErrServiceInternal)
+
+	report := collector.Report(10)
+	assert.EqualValues(t, 10, report.TotalRows)
+	assert.EqualValues(t, 5, report.ScannedRows)
+	assert.EqualValues(t, 3, report.SampledRows)
+	require.Len(t, report.Fields, 3)
+
+	vec := report.Fields[0]
+	assert.Equal(t, "vec", vec.FieldName)
+	assert.EqualValues(t, 2, vec.Vector.Dim)
+	assert.InDelta(t, 1.414, vec.Vector.NormMin, 1e-3)
+	assert.InDelta(t, 7.071, vec.Vector.NormMax, 1e-3)
+	assert.False(t, vec.Vector.Normalized)
+	assert.Greater(t, vec.Vector.RelativeContrast, 1.0)
+	require.NotEmpty(t, vec.Recommendations)
+	assert.Equal(t, indexparamcheck.IndexFaissIDMap, vec.Recommendations[0].IndexType)
+	assert.Equal(t, metric.L2, vec.Recommendations[0].MetricType)
+
+	pk := report.Fields[1]
+	assert.EqualValues(t, 5, pk.Scalar.Distinct)
+	assert.EqualValues(t, 1, *pk.Scalar.Min)
+	assert.EqualValues(t, 5, *pk.Scalar.Max)
+	assert.Empty(t, pk.Recommendations)
+
+	name := report.Fields[2]
+	assert.EqualValues(t, 1, name.Scalar.Distinct)
+	assert.EqualValues(t, 4, name.Scalar.MaxLength)
+	assert.Empty(t, name.Recommendations)
+}
+
+func TestRelativeContrast(t *testing.T) {
+	assert.Zero(t, relativeContrast([][]float32{{1, 1}}))
+	assert.Zero(t, relativeContrast([][]float32{{1, 1}, {1, 1}}))
+	// the neighbors are much closer than the others
+	sample := [][]float32{{0, 0}, {0, 1}, {100, 100}, {100, 101}}
+	contrast := relativeContrast(sample)
+	assert.Greater(t, contrast, 2.0)
+	assert.Equal(t, analyzeDifficultyEasy, contrastDifficulty(contrast))
+	assert.Equal(t, analyzeDifficultyModerate, contrastDifficulty(1.8))
+	assert.Equal(t, analyzeDifficultyHard, contrastDifficulty(1.1))
+
+	min, max, mean := dimensionVariances([][]float32{{0, 1}, {2, 1}}, 2)
+	assert.InDelta(t, 0, min, 1e-9)
+	assert.InDelta(t, 1, max, 1e-9)
+	assert.InDelta(t, 0.5, mean, 1e-9)
+}
+
+func TestRecommendIndexes(t *testing.T) {
+	dist := &vectorDistribution{Dim: 128, Normalized: true, Difficulty: analyzeDifficultyHard}
+	recommendations := recommendVectorIndexes(schemapb.DataType_FloatVector, dist, 1000000)
+	require.Len(t, recommendations, 3)
+	assert.Equal(t, indexparamcheck.IndexHNSW, recommendations[0].IndexType)
+	assert.Equal(t, metric.IP, recommendations[0].MetricType)
+	assert.Equal(t, "32", recommendations[0].Params["M"])
+	assert.Equal(t, "4000", recommendations[1].Params["nlist"])
+
+	recommendations = recommendVectorIndexes(schemapb.DataType_FloatVector, &vectorDistribution{Dim: 100}, 100000000)
+	require.Len(t, recommendations, 2)
+	assert.Equal(t, indexparamcheck.IndexDISKANN, recommendations[0].IndexType)
+	assert.Equal(t, "25", recommendations[1].Params["m"])
+	assert.Equal(t, "40000", recommendations[1].Params["nlist"])
+
+	recommendations = recommendVectorIndexes(schemapb.DataType_BinaryVector, &vectorDistribution{Dim: 128}, 1000000)
+	require.Len(t, recommendations, 2)
+	assert.Equal(t, metric.HAMMING, recommendations[1].MetricType)
+
+	assert.Nil(t, recommendScalarIndex(schemapb.DataType_Int64, &scalarDistribution{Distinct: 1}))
+	assert.Equal(t, DefaultArithmeticIndexType, recommendScalarIndex(schemapb.DataType_Int64, &scalarDistribution{Distinct: 10}).IndexType)
+	assert.Equal(t, DefaultStringIndexType, recommendScalarIndex(schemapb.DataType_VarChar, &scalarDistribution{Distinct: 10}).IndexType)
+	assert.Nil(t, recommendScalarIndex(schemapb.DataType_JSON, &scalarDistribution{Distinct: 10}))
+}
+
+func TestAnalyzeJobManager(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.ExportBatchSize.Key, "2")
+	defer params.Reset(params.ProxyCfg.ExportBatchSize.Key)
+
+	pks := []int64{1, 2, 3, 4, 5}
+	exprs := make([]string, 0)
+	query := func(ctx context.Context, req *milvuspb.QueryRequest, mvccTs Timestamp) (*milvuspb.QueryResults, error) {
+		if len(req.GetOutputFields()) == 1 && req.GetOutputFields()[0] == "count(*)" {
+			return &milvuspb.QueryResults{
+				Status: merr.Success(),
+				FieldsData: []*schemapb.FieldData{{
+					FieldName: "count(*)",
+					Type:      schemapb.DataType_Int64,
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{int64(len(pks))}}},
+					}},
+				}},
+			}, nil
+		}
+		exprs = append(exprs, req.GetExpr())
+		offset := 2 * (len(exprs) - 1)
+		end := offset + 2
+		if end > len(pks) {
+			end = len(pks)
+		}
+		return &milvuspb.QueryResults{
+			Status:     merr.Success(),
+			FieldsData: newExportTestFieldsData(pks[offset:end]),
+		}, nil
+	}
+	getSchema := func(ctx context.Context, dbName, collectionName string) (*schemapb.CollectionSchema, error) {
+		if collectionName != "test_export" {
+			return nil, merr.WrapErrCollectionNotFound(collectionName)
+		}
+		return newExportTestSchema(), nil
+	}
+	manager := newAnalyzeJobManager(query, getSchema)
+
+	ctx := context.Background()
+	assert.ErrorIs(t, manager.Submit(ctx, 1, &analyzeRequest{}), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, manager.Submit(ctx, 1, &analyzeRequest{CollectionName: "test_export", SampleSize: -1}), merr.ErrParameterInvalid)
+	require.NoError(t, manager.Submit(ctx, 1, &analyzeRequest{CollectionName: "test_export"}))
+	require.NoError(t, manager.Submit(ctx, 2, &analyzeRequest{CollectionName: "not_exist"}))
+
+	assert.Eventually(t, func() bool {
+		job1, _ := manager.Get(1)
+		job2, _ := manager.Get(2)
+		return job1.State == exportJobCompleted && job2.State == exportJobFailed
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []string{"", "pk > 2", "pk > 4"}, exprs)
+	job, ok := manager.Get(1)
+	assert.True(t, ok)
+	assert.EqualValues(t, 10000, job.Request.SampleSize)
+	assert.EqualValues(t, 5, job.Report.TotalRows)
+	assert.EqualValues(t, 5, job.Report.ScannedRows)
+	assert.EqualValues(t, 5, job.Report.SampledRows)
+
+	_, ok = manager.Get(3)
+	assert.False(t, ok)
+	assert.Len(t, manager.List(), 2)
+}
+
+func TestAnalyzeUI(t *testing.T) {
+	node := &Proxy{}
+
+	w := httptest.NewRecorder()
+	node.AnalyzeUI(w, httptest.NewRequest(http.MethodGet, mgrRouteAnalyzeUI, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "Collection Analyze")
+
+	w = httptest.NewRecorder()
+	node.AnalyzeUI(w, httptest.NewRequest(http.MethodPost, mgrRouteAnalyzeUI, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,285 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proxy/connection"
+	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const (
+	// insertPayloadFormatKey is the key of the reserved client info and server info of Connect
+	// to negotiate the format of insert and upsert payloads for the session.
+	insertPayloadFormatKey = "insert_payload_format"
+	// insertPayloadFormatArrow is the arrow IPC stream format, whose columns are laid out like the storage v2 schema,
+	// see typeutil.ConvertToArrowSchema.
+	insertPayloadFormatArrow = "arrow"
+	// arrowPayloadFieldName is the name of the only field data carrying the arrow IPC streams in bytes data,
+	// sent by the sessions negotiated the arrow format instead of the protobuf columns.
+	arrowPayloadFieldName = "$arrow_payload"
+)
+
+// negotiateInsertPayloadFormat accepts the arrow format requested by the client if it's enabled,
+// the accepted format is set in both the client info registered for the session and the server info.
+func negotiateInsertPayloadFormat(clientInfo *commonpb.ClientInfo, serverInfo *commonpb.ServerInfo) *commonpb.ClientInfo {
+	if clientInfo.GetReserved()[insertPayloadFormatKey] == "" {
+		return clientInfo
+	}
+	clientInfo = proto.Clone(clientInfo).(*commonpb.ClientInfo)
+	if clientInfo.GetReserved()[insertPayloadFormatKey] != insertPayloadFormatArrow ||
+		!paramtable.Get().ProxyCfg.ArrowInsertPayloadEnabled.GetAsBool() {
+		delete(clientInfo.Reserved, insertPayloadFormatKey)
+		return clientInfo
+	}
+	serverInfo.Reserved[insertPayloadFormatKey] = insertPayloadFormatArrow
+	return clientInfo
+}
+
+// isArrowPayload returns whether the fields data is the arrow payload.
+func isArrowPayload(fieldsData []*schemapb.FieldData) bool {
+	return len(fieldsData) == 1 && fieldsData[0].GetFieldName() == arrowPayloadFieldName
+}
+
+// decodeInsertPayload returns the protobuf columns and the number of rows of the insert or upsert request,
+// the arrow payload is decoded by the schema of the collection, others are returned as they are.
+func decodeInsertPayload(ctx context.Context, dbName, collectionName string, fieldsData []*schemapb.FieldData, numRows uint32) ([]*schemapb.FieldData, uint32, error) {
+	if !isArrowPayload(fieldsData) {
+		return fieldsData, numRows, nil
+	}
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, dbName, collectionName)
+	if err != nil {
+		return nil, 0, err
+	}
+	return decodeArrowPayload(ctx, schema, fieldsData[0])
+}
+
+// decodeArrowPayload decodes the arrow payload into the protobuf columns, returns the columns and the number of rows.
+// It's rejected if the session of the request didn't negotiate the arrow format.
+// The decoded columns go through the same checks and repacking as the protobuf columns,
+// the insert messages to the datanodes are still in the protobuf columnar format of msgpb.
+func decodeArrowPayload(ctx context.Context, schema *schemapb.CollectionSchema, payload *schemapb.FieldData) ([]*schemapb.FieldData, uint32, error) {
+	if connection.GetManager().Get(ctx).GetReserved()[insertPayloadFormatKey] != insertPayloadFormatArrow {
+		return nil, 0, merr.WrapErrParameterInvalidMsg("arrow payload is not negotiated by the session, connect with %s=%s first",
+			insertPayloadFormatKey, insertPayloadFormatArrow)
+	}
+
+	columns := make(map[string]*schemapb.FieldData)
+	fieldsData := make([]*schemapb.FieldData, 0)
+	numRows := int64(0)
+	for _, stream := range payload.GetScalars().GetBytesData().GetData() {
+		reader, err := ipc.NewReader(bytes.NewReader(stream))
+		if err != nil {
+			return nil, 0, merr.WrapErrParameterInvalidMsg("invalid arrow payload, %s", err.Error())
+		}
+		for reader.Next() {
+			record := reader.Record()
+			for i, arrowField := range record.Schema().Fields() {
+				field, err := getArrowPayloadField(schema, arrowField)
+				if err != nil {
+					reader.Release()
+					return nil, 0, err
+				}
+				column, ok := columns[field.GetName()]
+				if !ok {
+					column = &schemapb.FieldData{
+						Type:      field.GetDataType(),
+						FieldName: field.GetName(),
+						FieldId:   field.GetFieldID(),
+						IsDynamic: field.GetIsDynamic(),
+					}
+					columns[field.GetName()] = column
+					fieldsData = append(fieldsData, column)
+				}
+				if err := appendArrowColumn(column, field, record.Column(i)); err != nil {
+					reader.Release()
+					return nil, 0, err
+				}
+			}
+			numRows += record.NumRows()
+		}
+		err = reader.Err()
+		reader.Release()
+		if err != nil {
+			return nil, 0, merr.WrapErrParameterInvalidMsg("invalid arrow payload, %s", err.Error())
+		}
+	}
+	if numRows > math.MaxUint32 {
+		return nil, 0, merr.WrapErrParameterInvalidMsg("too many rows in arrow payload: %d", numRows)
+	}
+	return fieldsData, uint32(numRows), nil
+}
+
+// getArrowPayloadField returns the field of the arrow column, the type must be the same as the storage v2 schema.
+func getArrowPayloadField(schema *schemapb.CollectionSchema, arrowField arrow.Field) (*schemapb.FieldSchema, error) {
+	for _, field := range schema.GetFields() {
+		if field.GetName() != arrowField.Name {
+			continue
+		}
+		expected, err := typeutil2.ConvertToArrowSchema([]*schemapb.FieldSchema{field})
+		if err != nil {
+			return nil, err
+		}
+		if !arrow.TypeEqual(expected.Field(0).Type, arrowField.Type) {
+			return nil, merr.WrapErrParameterInvalid(expected.Field(0).Type.String(), arrowField.Type.String(),
+				"arrow type of field "+field.GetName()+" mismatch")
+		}
+		if arrowField.Type.ID() == arrow.LIST {
+			return nil, merr.WrapErrParameterInvalidMsg("array field %s is not supported in arrow payload", field.GetName())
+		}
+		return field, nil
+	}
+	return nil, merr.WrapErrFieldNotFound(arrowField.Name, "field of arrow payload not found in schema")
+}
+
+// appendArrowColumn appends the arrow array to the column of the field, null values are not allowed.
+func appendArrowColumn(column *schemapb.FieldData, field *schemapb.FieldSchema, arr arrow.Array) error {
+	if arr.NullN() > 0 {
+		return merr.WrapErrParameterInvalidMsg("null values of field %s are not supported", field.GetName())
+	}
+
+	scalars := func() *schemapb.ScalarField {
+		if column.GetScalars() == nil {
+			column.Field = &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{}}
+		}
+		return column.GetScalars()
+	}
+	vectors := func(dim int64) *schemapb.VectorField {
+		if column.GetVectors() == nil {
+			column.Field = &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{Dim: dim}}
+		}
+		return column.GetVectors()
+	}
+
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		s := scalars()
+		if s.GetBoolData() == nil {
+			s.Data = &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{}}
+		}
+		values := arr.(*array.Boolean)
+		for i := 0; i < values.Len(); i++ {
+			s.GetBoolData().Data = append(s.GetBoolData().Data, values.Value(i))
+		}
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		s := scalars()
+		if s.GetIntData() == nil {
+			s.Data = &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{}}
+		}
+		data := s.GetIntData()
+		switch values := arr.(type) {
+		case *array.Int8:
+			for _, v := range values.Int8Values() {
+				data.Data = append(data.Data, int32(v))
+			}
+		case *array.Int16:
+			for _, v := range values.Int16Values() {
+				data.Data = append(data.Data, int32(v))
+			}
+		case *array.Int32:
+			data.Data = append(data.Data, values.Int32Values()...)
+		}
+	case schemapb.DataType_Int64:
+		s := scalars()
+		if s.GetLongData() == nil {
+			s.Data = &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{}}
+		}
+		s.GetLongData().Data = append(s.GetLongData().Data, arr.(*array.Int64).Int64Values()...)
+	case schemapb.DataType_Float:
+		s := scalars()
+		if s.GetFloatData() == nil {
+			s.Data = &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{}}
+		}
+		s.GetFloatData().Data = append(s.GetFloatData().Data, arr.(*array.Float32).Float32Values()...)
+	case schemapb.DataType_Double:
+		s := scalars()
+		if s.GetDoubleData() == nil {
+			s.Data = &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{}}
+		}
+		s.GetDoubleData().Data = append(s.GetDoubleData().Data, arr.(*array.Float64).Float64Values()...)
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		s := scalars()
+		if s.GetStringData() == nil {
+			s.Data = &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{}}
+		}
+		values := arr.(*array.String)
+		for i := 0; i < values.Len(); i++ {
+			s.GetStringData().Data = append(s.GetStringData().Data, values.Value(i))
+		}
+	case schemapb.DataType_JSON:
+		s := scalars()
+		if s.GetJsonData() == nil {
+			s.Data = &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{}}
+		}
+		values := arr.(*array.Binary)
+		for i := 0; i < values.Len(); i++ {
+			s.GetJsonData().Data = append(s.GetJsonData().Data, bytes.Clone(values.Value(i)))
+		}
+	case schemapb.DataType_FloatVector:
+		values := arr.(*array.FixedSizeBinary)
+		width := values.DataType().(*arrow.FixedSizeBinaryType).ByteWidth
+		v := vectors(int64(width / 4))
+		if v.GetFloatVector() == nil {
+			v.Data = &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{}}
+		}
+		data := v.GetFloatVector()
+		for i := 0; i < values.Len(); i++ {
+			row := values.Value(i)
+			for j := 0; j < len(row); j += 4 {
+				data.Data = append(data.Data, math.Float32frombits(binary.LittleEndian.Uint32(row[j:])))
+			}
+		}
+	case schemapb.DataType_BinaryVector:
+		values := arr.(*array.FixedSizeBinary)
+		width := values.DataType().(*arrow.FixedSizeBinaryType).ByteWidth
+		v := vectors(int64(width * 8))
+		if v.GetBinaryVector() == nil {
+			v.Data = &schemapb.VectorField_BinaryVector{}
+		}
+		data := v.Data.(*schemapb.VectorField_BinaryVector)
+		for i := 0; i < values.Len(); i++ {
+			data.BinaryVector = append(data.BinaryVector, values.Value(i)...)
+		}
+	case schemapb.DataType_Float16Vector:
+		values := arr.(*array.FixedSizeBinary)
+		width := values.DataType().(*arrow.FixedSizeBinaryType).ByteWidth
+		v := vectors(int64(width / 2))
+		if v.GetFloat16Vector() == nil {
+			v.Data = &schemapb.VectorField_Float16Vector{}
+		}
+		data := v.Data.(*schemapb.VectorField_Float16Vector)
+		for i := 0; i < values.Len(); i++ {
+			data.Float16Vector = append(data.Float16Vector, values.Value(i)...)
+		}
+	default:
+		return merr.WrapErrParameterInvalidMsg("%s field %s is not supported in arrow payload", field.GetDataType().String(), field.GetName())
+	}
+	return nil
+}
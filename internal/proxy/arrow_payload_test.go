@@ -0,0 +1,181 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proxy/connection"
+	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type ArrowPayloadSuite struct {
+	suite.Suite
+
+	schema *schemapb.CollectionSchema
+}
+
+func (s *ArrowPayloadSuite) SetupSuite() {
+	paramtable.Init()
+	s.schema = &schemapb.CollectionSchema{
+		Name: "test_arrow_payload",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "name", DataType: schemapb.DataType_VarChar, TypeParams: []*commonpb.KeyValuePair{{Key: "max_length", Value: "64"}}},
+			{FieldID: 102, Name: "vec", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "2"}}},
+		},
+	}
+}
+
+func (s *ArrowPayloadSuite) connect(format string) context.Context {
+	identifier := int64(1000)
+	connection.GetManager().Register(context.Background(), identifier, &commonpb.ClientInfo{
+		Reserved: map[string]string{insertPayloadFormatKey: format},
+	})
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.IdentifierKey, strconv.FormatInt(identifier, 10)))
+}
+
+func (s *ArrowPayloadSuite) encode(fields []*schemapb.FieldSchema, fill func(builder *array.RecordBuilder)) *schemapb.FieldData {
+	schema, err := typeutil2.ConvertToArrowSchema(fields)
+	s.Require().NoError(err)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	fill(builder)
+	record := builder.NewRecord()
+	defer record.Release()
+
+	buf := &bytes.Buffer{}
+	writer := ipc.NewWriter(buf, ipc.WithSchema(schema))
+	s.Require().NoError(writer.Write(record))
+	s.Require().NoError(writer.Close())
+	return &schemapb.FieldData{
+		FieldName: arrowPayloadFieldName,
+		Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_BytesData{BytesData: &schemapb.BytesArray{Data: [][]byte{buf.Bytes()}}},
+		}},
+	}
+}
+
+func (s *ArrowPayloadSuite) fillRows(builder *array.RecordBuilder) {
+	builder.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	builder.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	vec := builder.Field(2).(*array.FixedSizeBinaryBuilder)
+	for _, row := range [][]float32{{0.1, 0.2}, {0.3, 0.4}} {
+		data := make([]byte, 8)
+		binary.LittleEndian.PutUint32(data, math.Float32bits(row[0]))
+		binary.LittleEndian.PutUint32(data[4:], math.Float32bits(row[1]))
+		vec.Append(data)
+	}
+}
+
+func (s *ArrowPayloadSuite) TestNegotiate() {
+	serverInfo := &commonpb.ServerInfo{Reserved: make(map[string]string)}
+	clientInfo := negotiateInsertPayloadFormat(&commonpb.ClientInfo{
+		Reserved: map[string]string{insertPayloadFormatKey: insertPayloadFormatArrow},
+	}, serverInfo)
+	s.Equal(insertPayloadFormatArrow, clientInfo.GetReserved()[insertPayloadFormatKey])
+	s.Equal(insertPayloadFormatArrow, serverInfo.GetReserved()[insertPayloadFormatKey])
+
+	paramtable.Get().Save(paramtable.Get().ProxyCfg.ArrowInsertPayloadEnabled.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().ProxyCfg.ArrowInsertPayloadEnabled.Key)
+	serverInfo = &commonpb.ServerInfo{Reserved: make(map[string]string)}
+	requested := &commonpb.ClientInfo{
+		Reserved: map[string]string{insertPayloadFormatKey: insertPayloadFormatArrow},
+	}
+	clientInfo = negotiateInsertPayloadFormat(requested, serverInfo)
+	s.NotContains(clientInfo.GetReserved(), insertPayloadFormatKey)
+	s.NotContains(serverInfo.GetReserved(), insertPayloadFormatKey)
+	// the request is not modified
+	s.Contains(requested.GetReserved(), insertPayloadFormatKey)
+}
+
+func (s *ArrowPayloadSuite) TestDecode() {
+	ctx := s.connect(insertPayloadFormatArrow)
+	payload := s.encode(s.schema.GetFields(), s.fillRows)
+	s.True(isArrowPayload([]*schemapb.FieldData{payload}))
+
+	fieldsData, numRows, err := decodeArrowPayload(ctx, s.schema, payload)
+	s.Require().NoError(err)
+	s.EqualValues(2, numRows)
+	s.Require().Len(fieldsData, 3)
+	s.Equal([]int64{1, 2}, fieldsData[0].GetScalars().GetLongData().GetData())
+	s.EqualValues(100, fieldsData[0].GetFieldId())
+	s.Equal([]string{"a", "b"}, fieldsData[1].GetScalars().GetStringData().GetData())
+	s.EqualValues(2, fieldsData[2].GetVectors().GetDim())
+	s.Equal([]float32{0.1, 0.2, 0.3, 0.4}, fieldsData[2].GetVectors().GetFloatVector().GetData())
+}
+
+func (s *ArrowPayloadSuite) TestNotNegotiated() {
+	ctx := s.connect("protobuf")
+	payload := s.encode(s.schema.GetFields(), s.fillRows)
+	_, _, err := decodeArrowPayload(ctx, s.schema, payload)
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+
+	_, _, err = decodeArrowPayload(context.Background(), s.schema, payload)
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+}
+
+func (s *ArrowPayloadSuite) TestTypeMismatch() {
+	ctx := s.connect(insertPayloadFormatArrow)
+	fields := []*schemapb.FieldSchema{{Name: "pk", DataType: schemapb.DataType_Int32}}
+	payload := s.encode(fields, func(builder *array.RecordBuilder) {
+		builder.Field(0).(*array.Int32Builder).AppendValues([]int32{1}, nil)
+	})
+	_, _, err := decodeArrowPayload(ctx, s.schema, payload)
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+
+	fields = []*schemapb.FieldSchema{{Name: "unknown", DataType: schemapb.DataType_Int64}}
+	payload = s.encode(fields, func(builder *array.RecordBuilder) {
+		builder.Field(0).(*array.Int64Builder).AppendValues([]int64{1}, nil)
+	})
+	_, _, err = decodeArrowPayload(ctx, s.schema, payload)
+	s.ErrorIs(err, merr.ErrFieldNotFound)
+
+	payload = s.encode(s.schema.GetFields()[:1], func(builder *array.RecordBuilder) {
+		builder.Field(0).(*array.Int64Builder).AppendNull()
+	})
+	_, _, err = decodeArrowPayload(ctx, s.schema, payload)
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+}
+
+func (s *ArrowPayloadSuite) TestNotArrowPayload() {
+	fieldsData := []*schemapb.FieldData{{FieldName: "pk"}}
+	decoded, numRows, err := decodeInsertPayload(context.Background(), "", "", fieldsData, 3)
+	s.NoError(err)
+	s.Equal(fieldsData, decoded)
+	s.EqualValues(3, numRows)
+}
+
+func TestArrowPayload(t *testing.T) {
+	suite.Run(t, new(ArrowPayloadSuite))
+}
@@ -0,0 +1,205 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// the modes of collection.freeze.mode
+const (
+	// collectionFreezeWrite rejects the writes, the reads continue
+	collectionFreezeWrite = "write"
+	// collectionFreezeAll rejects both the writes and the reads
+	collectionFreezeAll = "all"
+)
+
+// collectionFreezeRequest freezes the collection, or unfreezes it by the unfreeze route, e.g.
+// {"db_name": "default", "collection_name": "coll", "mode": "write", "duration": "30m"}.
+// The collection is unfrozen automatically after the duration, or frozen until unfrozen if it's absent.
+type collectionFreezeRequest struct {
+	DbName         string `json:"db_name"`
+	CollectionName string `json:"collection_name"`
+	Mode           string `json:"mode"`
+	Duration       string `json:"duration"`
+}
+
+// parseCollectionFreeze returns the freeze mode in the collection properties,
+// and the time the freeze expires, zero if it never expires.
+func parseCollectionFreeze(kvs []*commonpb.KeyValuePair) (string, time.Time, error) {
+	var (
+		mode  string
+		until time.Time
+	)
+	for _, kv := range kvs {
+		switch kv.GetKey() {
+		case common.CollectionFreezeKey:
+			mode = kv.GetValue()
+			if mode != "" && mode != collectionFreezeWrite && mode != collectionFreezeAll {
+				return "", time.Time{}, merr.WrapErrParameterInvalidMsg("invalid collection property %s: %s, should be %s or %s",
+					common.CollectionFreezeKey, mode, collectionFreezeWrite, collectionFreezeAll)
+			}
+		case common.CollectionFreezeUntilKey:
+			if kv.GetValue() == "" {
+				continue
+			}
+			seconds, err := strconv.ParseInt(kv.GetValue(), 10, 64)
+			if err != nil || seconds <= 0 {
+				return "", time.Time{}, merr.WrapErrParameterInvalidMsg("invalid collection property %s: %s, should be positive unix seconds",
+					common.CollectionFreezeUntilKey, kv.GetValue())
+			}
+			until = time.Unix(seconds, 0)
+		}
+	}
+	return mode, until, nil
+}
+
+// checkCollectionFrozen returns ErrCollectionFrozen if the collection rejects the writes,
+// or the reads if write is false. The failures to get the collection are left to the request.
+func checkCollectionFrozen(ctx context.Context, dbName, collectionName string, write bool) error {
+	if globalMetaCache == nil {
+		return nil
+	}
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, dbName, collectionName)
+	if err != nil {
+		return nil
+	}
+	info, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, collectionID)
+	if err != nil {
+		return nil
+	}
+	mode, until, err := parseCollectionFreeze(info.properties)
+	if err != nil || mode == "" {
+		return nil
+	}
+	// the collection is unfrozen once the freeze expires
+	if !until.IsZero() && !time.Now().Before(until) {
+		return nil
+	}
+	if !write && mode != collectionFreezeAll {
+		return nil
+	}
+	if until.IsZero() {
+		return merr.WrapErrCollectionFrozen(collectionName, mode, "frozen for maintenance until unfrozen")
+	}
+	return merr.WrapErrCollectionFrozen(collectionName, mode,
+		fmt.Sprintf("frozen for maintenance until %s", until.Format(time.RFC3339)))
+}
+
+// FreezeCollection freezes the collection for writes, or for both writes and reads, by POST.
+func (node *Proxy) FreezeCollection(w http.ResponseWriter, req *http.Request) {
+	request, ok := node.decodeFreezeRequest(w, req)
+	if !ok {
+		return
+	}
+	if request.Mode == "" {
+		request.Mode = collectionFreezeWrite
+	}
+	var until string
+	if request.Duration != "" {
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil || duration <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "invalid duration %s, should be positive, e.g. 30m"}`, request.Duration)))
+			return
+		}
+		until = strconv.FormatInt(time.Now().Add(duration).Unix(), 10)
+	}
+	properties := []*commonpb.KeyValuePair{
+		{Key: common.CollectionFreezeKey, Value: request.Mode},
+		// overwrite the expiration of the last freeze
+		{Key: common.CollectionFreezeUntilKey, Value: until},
+	}
+	if _, _, err := parseCollectionFreeze(properties); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to freeze collection, %s"}`, err.Error())))
+		return
+	}
+	node.alterCollectionProperties(w, req.Context(), request.DbName, request.CollectionName, properties)
+}
+
+// UnfreezeCollection unfreezes the collection by POST.
+func (node *Proxy) UnfreezeCollection(w http.ResponseWriter, req *http.Request) {
+	request, ok := node.decodeFreezeRequest(w, req)
+	if !ok {
+		return
+	}
+	node.alterCollectionProperties(w, req.Context(), request.DbName, request.CollectionName, []*commonpb.KeyValuePair{
+		{Key: common.CollectionFreezeKey, Value: ""},
+		{Key: common.CollectionFreezeUntilKey, Value: ""},
+	})
+}
+
+func (node *Proxy) decodeFreezeRequest(w http.ResponseWriter, req *http.Request) (*collectionFreezeRequest, bool) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+		return nil, false
+	}
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return nil, false
+	}
+	request := &collectionFreezeRequest{}
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid request, %s"}`, err.Error())))
+		return nil, false
+	}
+	if request.CollectionName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"msg": "collection_name is required"}`))
+		return nil, false
+	}
+	if request.DbName == "" {
+		request.DbName = util.DefaultDBName
+	}
+	return request, true
+}
+
+// alterCollectionProperties alters the properties of collection, the proxies reload them once
+// their meta caches are expired by the alteration.
+func (node *Proxy) alterCollectionProperties(w http.ResponseWriter, ctx context.Context, dbName, collectionName string, properties []*commonpb.KeyValuePair) {
+	status, err := node.rootCoord.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_AlterCollection),
+		),
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Properties:     properties,
+	})
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to alter collection, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
@@ -0,0 +1,139 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestParseCollectionFreeze(t *testing.T) {
+	mode, until, err := parseCollectionFreeze(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, mode)
+	assert.True(t, until.IsZero())
+
+	mode, until, err = parseCollectionFreeze([]*commonpb.KeyValuePair{
+		{Key: common.CollectionFreezeKey, Value: collectionFreezeAll},
+		{Key: common.CollectionFreezeUntilKey, Value: "1700000000"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, collectionFreezeAll, mode)
+	assert.Equal(t, int64(1700000000), until.Unix())
+
+	_, _, err = parseCollectionFreeze([]*commonpb.KeyValuePair{{Key: common.CollectionFreezeKey, Value: "read"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	_, _, err = parseCollectionFreeze([]*commonpb.KeyValuePair{{Key: common.CollectionFreezeUntilKey, Value: "-1"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestCheckCollectionFrozen(t *testing.T) {
+	cache := globalMetaCache
+	defer func() { globalMetaCache = cache }()
+	mockCache := NewMockCache(t)
+	globalMetaCache = mockCache
+
+	mockCache.EXPECT().GetCollectionID(mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
+	setProperties := func(kvs ...*commonpb.KeyValuePair) {
+		mockCache.EXPECT().GetCollectionInfo(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&collectionBasicInfo{collID: 1, properties: kvs}, nil).Once()
+	}
+	ctx := context.Background()
+
+	setProperties()
+	assert.NoError(t, checkCollectionFrozen(ctx, "", "coll", true))
+
+	setProperties(&commonpb.KeyValuePair{Key: common.CollectionFreezeKey, Value: collectionFreezeWrite})
+	assert.ErrorIs(t, checkCollectionFrozen(ctx, "", "coll", true), merr.ErrCollectionFrozen)
+	setProperties(&commonpb.KeyValuePair{Key: common.CollectionFreezeKey, Value: collectionFreezeWrite})
+	assert.NoError(t, checkCollectionFrozen(ctx, "", "coll", false))
+
+	until := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	setProperties(
+		&commonpb.KeyValuePair{Key: common.CollectionFreezeKey, Value: collectionFreezeAll},
+		&commonpb.KeyValuePair{Key: common.CollectionFreezeUntilKey, Value: until},
+	)
+	assert.ErrorIs(t, checkCollectionFrozen(ctx, "", "coll", false), merr.ErrCollectionFrozen)
+
+	// expired
+	until = strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10)
+	setProperties(
+		&commonpb.KeyValuePair{Key: common.CollectionFreezeKey, Value: collectionFreezeAll},
+		&commonpb.KeyValuePair{Key: common.CollectionFreezeUntilKey, Value: until},
+	)
+	assert.NoError(t, checkCollectionFrozen(ctx, "", "coll", true))
+}
+
+func TestProxy_FreezeCollection(t *testing.T) {
+	rootcoord := mocks.NewMockRootCoordClient(t)
+	node := &Proxy{rootCoord: rootcoord}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+
+	t.Run("freeze", func(t *testing.T) {
+		rootcoord.EXPECT().AlterCollection(mock.Anything, mock.MatchedBy(func(req *milvuspb.AlterCollectionRequest) bool {
+			mode, until, err := parseCollectionFreeze(req.GetProperties())
+			return err == nil && req.GetCollectionName() == "coll" && mode == collectionFreezeAll && until.After(time.Now())
+		})).Return(merr.Success(), nil).Once()
+
+		body := `{"collection_name": "coll", "mode": "all", "duration": "10m"}`
+		w := httptest.NewRecorder()
+		node.FreezeCollection(w, httptest.NewRequest(http.MethodPost, mgrRouteCollectionFreeze, strings.NewReader(body)))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("unfreeze", func(t *testing.T) {
+		rootcoord.EXPECT().AlterCollection(mock.Anything, mock.MatchedBy(func(req *milvuspb.AlterCollectionRequest) bool {
+			mode, until, err := parseCollectionFreeze(req.GetProperties())
+			return err == nil && mode == "" && until.IsZero()
+		})).Return(merr.Success(), nil).Once()
+
+		w := httptest.NewRecorder()
+		node.UnfreezeCollection(w, httptest.NewRequest(http.MethodPost, mgrRouteCollectionUnfreeze, strings.NewReader(`{"collection_name": "coll"}`)))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, body := range []string{
+			`{"mode": "write"}`,
+			`{"collection_name": "coll", "mode": "read"}`,
+			`{"collection_name": "coll", "duration": "-1m"}`,
+			`invalid`,
+		} {
+			w := httptest.NewRecorder()
+			node.FreezeCollection(w, httptest.NewRequest(http.MethodPost, mgrRouteCollectionFreeze, strings.NewReader(body)))
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+
+		w := httptest.NewRecorder()
+		node.FreezeCollection(w, httptest.NewRequest(http.MethodGet, mgrRouteCollectionFreeze, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// insertRateOverrideRequest overrides the insert rate of collection temporarily, e.g. during backfills,
+// or clears the override by the reset route, e.g.
+// {"db_name": "default", "collection_name": "coll", "rate_mb": 100, "burst_mb": 200, "duration": "2h"}.
+// The burst is optional, the configured one applies if it's absent. The override expires after the duration.
+type insertRateOverrideRequest struct {
+	DbName         string  `json:"db_name"`
+	CollectionName string  `json:"collection_name"`
+	RateMB         float64 `json:"rate_mb"`
+	BurstMB        float64 `json:"burst_mb"`
+	Duration       string  `json:"duration"`
+}
+
+// OverrideInsertRate overrides the insert rate of collection temporarily by POST,
+// the quota center applies it to the proxies in its next round.
+func (node *Proxy) OverrideInsertRate(w http.ResponseWriter, req *http.Request) {
+	request, ok := node.decodeInsertRateOverrideRequest(w, req)
+	if !ok {
+		return
+	}
+	if request.RateMB <= 0 || request.BurstMB < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid rate_mb %v or burst_mb %v, rate_mb should be positive and burst_mb should be non-negative"}`,
+			request.RateMB, request.BurstMB)))
+		return
+	}
+	duration, err := time.ParseDuration(request.Duration)
+	if err != nil || duration <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid duration %s, should be positive, e.g. 2h"}`, request.Duration)))
+		return
+	}
+	burst := ""
+	if request.BurstMB > 0 {
+		burst = strconv.FormatFloat(request.BurstMB, 'f', -1, 64)
+	}
+	node.alterCollectionProperties(w, req.Context(), request.DbName, request.CollectionName, []*commonpb.KeyValuePair{
+		{Key: common.CollectionInsertRateOverrideKey, Value: strconv.FormatFloat(request.RateMB, 'f', -1, 64)},
+		// overwrite the burst of the last override
+		{Key: common.CollectionInsertBurstOverrideKey, Value: burst},
+		{Key: common.CollectionInsertRateOverrideUntilKey, Value: strconv.FormatInt(time.Now().Add(duration).Unix(), 10)},
+	})
+}
+
+// ResetInsertRate clears the insert rate override of collection by POST.
+func (node *Proxy) ResetInsertRate(w http.ResponseWriter, req *http.Request) {
+	request, ok := node.decodeInsertRateOverrideRequest(w, req)
+	if !ok {
+		return
+	}
+	node.alterCollectionProperties(w, req.Context(), request.DbName, request.CollectionName, []*commonpb.KeyValuePair{
+		{Key: common.CollectionInsertRateOverrideKey, Value: ""},
+		{Key: common.CollectionInsertBurstOverrideKey, Value: ""},
+		{Key: common.CollectionInsertRateOverrideUntilKey, Value: ""},
+	})
+}
+
+func (node *Proxy) decodeInsertRateOverrideRequest(w http.ResponseWriter, req *http.Request) (*insertRateOverrideRequest, bool) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+		return nil, false
+	}
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return nil, false
+	}
+	request := &insertRateOverrideRequest{}
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid request, %s"}`, err.Error())))
+		return nil, false
+	}
+	if request.CollectionName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"msg": "collection_name is required"}`))
+		return nil, false
+	}
+	if request.DbName == "" {
+		request.DbName = util.DefaultDBName
+	}
+	return request, true
+}
@@ -0,0 +1,93 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestProxy_OverrideInsertRate(t *testing.T) {
+	rootcoord := mocks.NewMockRootCoordClient(t)
+	node := &Proxy{rootCoord: rootcoord}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+
+	t.Run("override", func(t *testing.T) {
+		rootcoord.EXPECT().AlterCollection(mock.Anything, mock.MatchedBy(func(req *milvuspb.AlterCollectionRequest) bool {
+			props := make(map[string]string)
+			for _, kv := range req.GetProperties() {
+				props[kv.GetKey()] = kv.GetValue()
+			}
+			until, err := strconv.ParseInt(props[common.CollectionInsertRateOverrideUntilKey], 10, 64)
+			return err == nil && req.GetCollectionName() == "coll" &&
+				props[common.CollectionInsertRateOverrideKey] == "100" &&
+				props[common.CollectionInsertBurstOverrideKey] == "200" &&
+				time.Unix(until, 0).After(time.Now())
+		})).Return(merr.Success(), nil).Once()
+
+		body := `{"collection_name": "coll", "rate_mb": 100, "burst_mb": 200, "duration": "2h"}`
+		w := httptest.NewRecorder()
+		node.OverrideInsertRate(w, httptest.NewRequest(http.MethodPost, mgrRouteInsertRateOverride, strings.NewReader(body)))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		rootcoord.EXPECT().AlterCollection(mock.Anything, mock.MatchedBy(func(req *milvuspb.AlterCollectionRequest) bool {
+			for _, kv := range req.GetProperties() {
+				if kv.GetValue() != "" {
+					return false
+				}
+			}
+			return len(req.GetProperties()) == 3
+		})).Return(merr.Success(), nil).Once()
+
+		w := httptest.NewRecorder()
+		node.ResetInsertRate(w, httptest.NewRequest(http.MethodPost, mgrRouteInsertRateReset, strings.NewReader(`{"collection_name": "coll"}`)))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, body := range []string{
+			`{"rate_mb": 100, "duration": "2h"}`,
+			`{"collection_name": "coll", "rate_mb": 0, "duration": "2h"}`,
+			`{"collection_name": "coll", "rate_mb": 100, "burst_mb": -1, "duration": "2h"}`,
+			`{"collection_name": "coll", "rate_mb": 100}`,
+			`invalid`,
+		} {
+			w := httptest.NewRecorder()
+			node.OverrideInsertRate(w, httptest.NewRequest(http.MethodPost, mgrRouteInsertRateOverride, strings.NewReader(body)))
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+
+		w := httptest.NewRecorder()
+		node.OverrideInsertRate(w, httptest.NewRequest(http.MethodGet, mgrRouteInsertRateOverride, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
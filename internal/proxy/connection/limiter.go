@@ -0,0 +1,239 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// RetryAfterKey is the response header of the seconds the client is hinted to wait
+// before retrying the request rejected by the client limits.
+const RetryAfterKey = "retry-after"
+
+// clientLimiter caps the concurrent connections and in-flight requests of each client ip and user,
+// so that a single misbehaving client can't exhaust the proxy.
+type clientLimiter struct {
+	mu sync.Mutex
+
+	conns        map[string]int // client ip -> accepted connections
+	ipInFlight   map[string]int // client ip -> in-flight requests
+	userInFlight map[string]int // user -> in-flight requests
+}
+
+func newClientLimiter() *clientLimiter {
+	return &clientLimiter{
+		conns:        make(map[string]int),
+		ipInFlight:   make(map[string]int),
+		userInFlight: make(map[string]int),
+	}
+}
+
+// addConn accepts the connection from the client ip, returns false if it exceeds the limit.
+func (l *clientLimiter) addConn(tag *connTag) bool {
+	limit := paramtable.Get().ProxyCfg.MaxConnectionsPerClient.GetAsInt()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if tag.admitted {
+		return true
+	}
+	if limit > 0 && l.conns[tag.ip] >= limit {
+		return false
+	}
+	l.conns[tag.ip]++
+	tag.admitted = true
+	metrics.ProxyClientConnections.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Inc()
+	return true
+}
+
+func (l *clientLimiter) removeConn(tag *connTag) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !tag.admitted {
+		return
+	}
+	decrease(l.conns, tag.ip)
+	tag.admitted = false
+	metrics.ProxyClientConnections.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Dec()
+}
+
+// acquire admits the request from the client ip and user, returns the shed reason if it exceeds the limits.
+// The user is empty if unknown, then only the limit of ip applies.
+func (l *clientLimiter) acquire(ip, user string) (string, bool) {
+	ipLimit := paramtable.Get().ProxyCfg.MaxInFlightPerClient.GetAsInt()
+	userLimit := paramtable.Get().ProxyCfg.MaxInFlightPerUser.GetAsInt()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if ipLimit > 0 && l.ipInFlight[ip] >= ipLimit {
+		return metrics.ShedClientInFlight, false
+	}
+	if user != "" && userLimit > 0 && l.userInFlight[user] >= userLimit {
+		return metrics.ShedUserInFlight, false
+	}
+	l.ipInFlight[ip]++
+	if user != "" {
+		l.userInFlight[user]++
+	}
+	metrics.ProxyClientInFlightRequests.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Inc()
+	return "", true
+}
+
+func (l *clientLimiter) release(ip, user string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	decrease(l.ipInFlight, ip)
+	if user != "" {
+		decrease(l.userInFlight, user)
+	}
+	metrics.ProxyClientInFlightRequests.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Dec()
+}
+
+func decrease(counts map[string]int, key string) {
+	if counts[key] <= 1 {
+		delete(counts, key)
+		return
+	}
+	counts[key]--
+}
+
+var (
+	clientLimiterInstance *clientLimiter
+	getClientLimiterOnce  sync.Once
+)
+
+func getClientLimiter() *clientLimiter {
+	getClientLimiterOnce.Do(func() {
+		clientLimiterInstance = newClientLimiter()
+	})
+	return clientLimiterInstance
+}
+
+type connTagKey struct{}
+
+// connTag is attached to the contexts of the connection and the requests over it.
+type connTag struct {
+	ip string
+	// admitted is false if the connection exceeded the limit when it's accepted,
+	// then the limit is evaluated again by each request over it, until the connection is admitted.
+	// It's guarded by the mutex of the limiter.
+	admitted bool
+}
+
+// LimitStatsHandler counts the connections of each client ip, the excess connections are not admitted.
+type LimitStatsHandler struct {
+	limiter *clientLimiter
+}
+
+// NewLimitStatsHandler returns the stats handler to count the client connections of proxy.
+func NewLimitStatsHandler() stats.Handler {
+	return &LimitStatsHandler{limiter: getClientLimiter()}
+}
+
+func (h *LimitStatsHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	tag := &connTag{ip: addrIP(info.RemoteAddr)}
+	if !h.limiter.addConn(tag) {
+		log.RatedWarn(10, "too many connections from the client, the requests over the connection are rejected until the others are closed",
+			zap.String("ip", tag.ip))
+	}
+	return context.WithValue(ctx, connTagKey{}, tag)
+}
+
+func (h *LimitStatsHandler) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); !ok {
+		return
+	}
+	if tag, ok := ctx.Value(connTagKey{}).(*connTag); ok {
+		h.limiter.removeConn(tag)
+	}
+}
+
+func (h *LimitStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h *LimitStatsHandler) HandleRPC(context.Context, stats.RPCStats) {}
+
+// admit admits the request under the client limits, returns the release func of the request.
+func (l *clientLimiter) admit(ctx context.Context, getUser func(ctx context.Context) (string, error)) (func(), error) {
+	ip := ""
+	if tag, ok := ctx.Value(connTagKey{}).(*connTag); ok {
+		// the excess connection is admitted once the other connections of the client are closed
+		if !l.addConn(tag) {
+			return nil, shed(ctx, metrics.ShedConnection, fmt.Sprintf("too many connections from client %s", tag.ip))
+		}
+		ip = tag.ip
+	} else if p, ok := peer.FromContext(ctx); ok {
+		ip = addrIP(p.Addr)
+	}
+
+	user, _ := getUser(ctx)
+	reason, ok := l.acquire(ip, user)
+	if !ok {
+		return nil, shed(ctx, reason, fmt.Sprintf("too many in-flight requests from client %s, user %s", ip, user))
+	}
+	return func() { l.release(ip, user) }, nil
+}
+
+// LimitInterceptor returns the unary server interceptor which rejects the requests exceeding the client limits
+// with RESOURCE_EXHAUSTED and the retry-after hint. getUser returns the user of the request.
+func LimitInterceptor(getUser func(ctx context.Context) (string, error)) grpc.UnaryServerInterceptor {
+	limiter := getClientLimiter()
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		release, err := limiter.admit(ctx, getUser)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// LimitStreamInterceptor returns the stream server interceptor which rejects the streams exceeding the client limits,
+// a stream is counted as an in-flight request until it's done.
+func LimitStreamInterceptor(getUser func(ctx context.Context) (string, error)) grpc.StreamServerInterceptor {
+	limiter := getClientLimiter()
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		release, err := limiter.admit(ss.Context(), getUser)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return handler(srv, ss)
+	}
+}
+
+// shed rejects the request with RESOURCE_EXHAUSTED, the client is hinted to retry after a while.
+func shed(ctx context.Context, reason string, msg string) error {
+	metrics.ProxyClientShedCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), reason).Inc()
+	retryAfter := paramtable.Get().ProxyCfg.ClientLimitRetryAfter.GetAsInt()
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RetryAfterKey, strconv.Itoa(retryAfter))); err != nil {
+		log.Ctx(ctx).Debug("failed to set retry-after header", zap.Error(err))
+	}
+	return status.Errorf(codes.ResourceExhausted, "%s, retry after %d seconds", msg, retryAfter)
+}
+
+func addrIP(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
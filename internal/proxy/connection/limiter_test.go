@@ -0,0 +1,154 @@
+package connection
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestClientLimiter(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+
+	t.Run("connections", func(t *testing.T) {
+		params.Save(params.ProxyCfg.MaxConnectionsPerClient.Key, "2")
+		defer params.Reset(params.ProxyCfg.MaxConnectionsPerClient.Key)
+
+		l := newClientLimiter()
+		conn1, conn2, conn3 := &connTag{ip: "10.0.0.1"}, &connTag{ip: "10.0.0.1"}, &connTag{ip: "10.0.0.1"}
+		other := &connTag{ip: "10.0.0.2"}
+		assert.True(t, l.addConn(conn1))
+		assert.True(t, l.addConn(conn2))
+		assert.False(t, l.addConn(conn3))
+		assert.True(t, l.addConn(other))
+		// the excess connection is admitted once another one is closed
+		l.removeConn(conn1)
+		l.removeConn(conn1)
+		assert.True(t, l.addConn(conn3))
+		assert.True(t, l.addConn(conn3))
+		assert.Equal(t, 2, l.conns["10.0.0.1"])
+		l.removeConn(other)
+		assert.NotContains(t, l.conns, "10.0.0.2")
+	})
+
+	t.Run("in-flight", func(t *testing.T) {
+		params.Save(params.ProxyCfg.MaxInFlightPerClient.Key, "2")
+		defer params.Reset(params.ProxyCfg.MaxInFlightPerClient.Key)
+		params.Save(params.ProxyCfg.MaxInFlightPerUser.Key, "1")
+		defer params.Reset(params.ProxyCfg.MaxInFlightPerUser.Key)
+
+		l := newClientLimiter()
+		_, ok := l.acquire("10.0.0.1", "alice")
+		assert.True(t, ok)
+		reason, ok := l.acquire("10.0.0.2", "alice")
+		assert.False(t, ok)
+		assert.Equal(t, "user_in_flight", reason)
+		_, ok = l.acquire("10.0.0.1", "")
+		assert.True(t, ok)
+		reason, ok = l.acquire("10.0.0.1", "bob")
+		assert.False(t, ok)
+		assert.Equal(t, "client_in_flight", reason)
+
+		l.release("10.0.0.1", "alice")
+		_, ok = l.acquire("10.0.0.2", "alice")
+		assert.True(t, ok)
+		l.release("10.0.0.1", "")
+		l.release("10.0.0.2", "alice")
+		assert.Empty(t, l.ipInFlight)
+		assert.Empty(t, l.userInFlight)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		l := newClientLimiter()
+		for i := 0; i < 10; i++ {
+			assert.True(t, l.addConn(&connTag{ip: "10.0.0.1"}))
+			_, ok := l.acquire("10.0.0.1", "alice")
+			assert.True(t, ok)
+		}
+	})
+}
+
+func TestLimitInterceptor(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.MaxConnectionsPerClient.Key, "1")
+	defer params.Reset(params.ProxyCfg.MaxConnectionsPerClient.Key)
+	params.Save(params.ProxyCfg.MaxInFlightPerUser.Key, "1")
+	defer params.Reset(params.ProxyCfg.MaxInFlightPerUser.Key)
+
+	handler := NewLimitStatsHandler()
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.3"), Port: 19530}
+	conn1 := handler.TagConn(context.Background(), &stats.ConnTagInfo{RemoteAddr: addr})
+	conn2 := handler.TagConn(context.Background(), &stats.ConnTagInfo{RemoteAddr: addr})
+	defer handler.HandleConn(conn1, &stats.ConnEnd{})
+	defer handler.HandleConn(conn2, &stats.ConnEnd{})
+
+	getUser := func(ctx context.Context) (string, error) {
+		return "alice", nil
+	}
+	interceptor := LimitInterceptor(getUser)
+	info := &grpc.UnaryServerInfo{FullMethod: "/milvus.proto.milvus.MilvusService/Query"}
+
+	// the requests over the excess connection are rejected
+	_, err := interceptor(conn2, nil, info, func(ctx context.Context, req any) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the requests exceeding the in-flight limit of user are rejected
+	resp, err := interceptor(conn1, nil, info, func(ctx context.Context, req any) (interface{}, error) {
+		ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.4"), Port: 19530}})
+		_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (interface{}, error) {
+			return "ok", nil
+		})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	// the in-flight request is released once done
+	resp, err = interceptor(conn1, nil, info, func(ctx context.Context, req any) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	// the streams are limited as well
+	streamInterceptor := LimitStreamInterceptor(getUser)
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/milvus.proto.milvus.MilvusService/Watch"}
+	err = streamInterceptor(nil, &mockServerStream{ctx: conn2}, streamInfo, func(srv any, stream grpc.ServerStream) error {
+		return nil
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the excess connection is admitted once the other one is closed
+	handler.HandleConn(conn1, &stats.ConnEnd{})
+	err = streamInterceptor(nil, &mockServerStream{ctx: conn2}, streamInfo, func(srv any, stream grpc.ServerStream) error {
+		return nil
+	})
+	assert.NoError(t, err)
+	resp, err = interceptor(conn2, nil, info, func(ctx context.Context, req any) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
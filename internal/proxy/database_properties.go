@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// databasePropertiesRequest sets the properties of database, the ones with empty value are removed,
+// e.g. {"db_name": "tenant", "properties": {"database.dmlChannels": "by-dev-rootcoord-dml_0", "database.dataNodes": "3"}}
+type databasePropertiesRequest struct {
+	DbName     string            `json:"db_name"`
+	Properties map[string]string `json:"properties"`
+}
+
+type databasePropertiesResponse struct {
+	DbName     string            `json:"db_name"`
+	DbID       int64             `json:"db_id"`
+	Properties map[string]string `json:"properties"`
+}
+
+// DatabaseProperties describes the properties of database by GET, and alters them by POST.
+func (node *Proxy) DatabaseProperties(w http.ResponseWriter, req *http.Request) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		node.describeDatabaseProperties(w, req)
+	case http.MethodPost:
+		node.alterDatabaseProperties(w, req)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only GET and POST are allowed"}`))
+	}
+}
+
+func (node *Proxy) describeDatabaseProperties(w http.ResponseWriter, req *http.Request) {
+	dbName := req.URL.Query().Get("db_name")
+	if dbName == "" {
+		dbName = util.DefaultDBName
+	}
+	resp, err := node.rootCoord.DescribeDatabase(req.Context(), &rootcoordpb.DescribeDatabaseRequest{
+		Base:   commonpbutil.NewMsgBase(),
+		DbName: dbName,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to describe database, %s"}`, err.Error())))
+		return
+	}
+	bs, _ := json.Marshal(&databasePropertiesResponse{
+		DbName:     resp.GetDbName(),
+		DbID:       resp.GetDbID(),
+		Properties: funcutil.KeyValuePair2Map(resp.GetProperties()),
+	})
+	w.WriteHeader(http.StatusOK)
+	w.Write(bs)
+}
+
+func (node *Proxy) alterDatabaseProperties(w http.ResponseWriter, req *http.Request) {
+	request := &databasePropertiesRequest{}
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to alter database, %s"}`, err.Error())))
+		return
+	}
+	if request.DbName == "" {
+		request.DbName = util.DefaultDBName
+	}
+	keys := make([]string, 0, len(request.Properties))
+	for key := range request.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	properties := make([]*commonpb.KeyValuePair, 0, len(keys))
+	for _, key := range keys {
+		properties = append(properties, &commonpb.KeyValuePair{Key: key, Value: request.Properties[key]})
+	}
+
+	status, err := node.rootCoord.AlterDatabase(req.Context(), &rootcoordpb.AlterDatabaseRequest{
+		Base:       commonpbutil.NewMsgBase(),
+		DbName:     request.DbName,
+		Properties: properties,
+	})
+	if err := merr.CheckRPCCall(status, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to alter database, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
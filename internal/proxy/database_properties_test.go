@@ -0,0 +1,81 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestProxy_DatabaseProperties(t *testing.T) {
+	rootcoord := mocks.NewMockRootCoordClient(t)
+	node := &Proxy{rootCoord: rootcoord}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+
+	t.Run("alter", func(t *testing.T) {
+		rootcoord.EXPECT().AlterDatabase(mock.Anything, mock.MatchedBy(func(req *rootcoordpb.AlterDatabaseRequest) bool {
+			return req.GetDbName() == "tenant" && len(req.GetProperties()) == 2 &&
+				req.GetProperties()[0].GetKey() == common.DatabaseDataNodesKey
+		})).Return(merr.Success(), nil).Once()
+
+		body := `{"db_name": "tenant", "properties": {"database.dmlChannels": "dml_0", "database.dataNodes": "1"}}`
+		w := httptest.NewRecorder()
+		node.DatabaseProperties(w, httptest.NewRequest(http.MethodPost, mgrRouteDatabaseProperties, strings.NewReader(body)))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("alter failed", func(t *testing.T) {
+		rootcoord.EXPECT().AlterDatabase(mock.Anything, mock.Anything).Return(merr.Status(merr.WrapErrParameterInvalidMsg("reserved")), nil).Once()
+		w := httptest.NewRecorder()
+		node.DatabaseProperties(w, httptest.NewRequest(http.MethodPost, mgrRouteDatabaseProperties, strings.NewReader(`{"properties": {"k": "v"}}`)))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+		w = httptest.NewRecorder()
+		node.DatabaseProperties(w, httptest.NewRequest(http.MethodPost, mgrRouteDatabaseProperties, strings.NewReader(`invalid`)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		rootcoord.EXPECT().DescribeDatabase(mock.Anything, mock.Anything).Return(&rootcoordpb.DescribeDatabaseResponse{
+			Status:     merr.Success(),
+			DbName:     "tenant",
+			DbID:       2,
+			Properties: []*commonpb.KeyValuePair{{Key: common.DatabaseDmlChannelsKey, Value: "dml_0"}},
+		}, nil).Once()
+		w := httptest.NewRecorder()
+		node.DatabaseProperties(w, httptest.NewRequest(http.MethodGet, mgrRouteDatabaseProperties+"?db_name=tenant", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"db_name": "tenant", "db_id": 2, "properties": {"database.dmlChannels": "dml_0"}}`, w.Body.String())
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		node.DatabaseProperties(w, httptest.NewRequest(http.MethodDelete, mgrRouteDatabaseProperties, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
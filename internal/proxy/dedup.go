@@ -0,0 +1,440 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// the modes of collection.dedup.mode
+const (
+	// dedupModeDrop drops the duplicates, the rows inserted first are kept
+	dedupModeDrop = "drop"
+	// dedupModeUpsert inserts the duplicates and deletes the rows duplicated, the rows inserted last are kept
+	dedupModeUpsert = "upsert"
+)
+
+// dedupPolicy is the dedup at ingest of a collection, see common.CollectionDedupFieldsKey.
+type dedupPolicy struct {
+	fields []string
+	mode   string
+	window time.Duration
+}
+
+// parseDedupPolicy returns the dedup policy in the collection properties, nil if the dedup is disabled.
+func parseDedupPolicy(kvs []*commonpb.KeyValuePair) (*dedupPolicy, error) {
+	policy := &dedupPolicy{
+		mode:   dedupModeDrop,
+		window: paramtable.Get().ProxyCfg.DedupWindow.GetAsDuration(time.Second),
+	}
+	for _, kv := range kvs {
+		switch kv.GetKey() {
+		case common.CollectionDedupFieldsKey:
+			policy.fields = nil
+			for _, field := range strings.Split(kv.GetValue(), ",") {
+				if field = strings.TrimSpace(field); field != "" {
+					policy.fields = append(policy.fields, field)
+				}
+			}
+		case common.CollectionDedupModeKey:
+			if kv.GetValue() == "" {
+				continue
+			}
+			policy.mode = kv.GetValue()
+			if policy.mode != dedupModeDrop && policy.mode != dedupModeUpsert {
+				return nil, merr.WrapErrParameterInvalidMsg("invalid collection property %s: %s, should be %s or %s",
+					common.CollectionDedupModeKey, policy.mode, dedupModeDrop, dedupModeUpsert)
+			}
+		case common.CollectionDedupWindowKey:
+			if kv.GetValue() == "" {
+				continue
+			}
+			seconds, err := strconv.ParseInt(kv.GetValue(), 10, 64)
+			if err != nil || seconds <= 0 {
+				return nil, merr.WrapErrParameterInvalidMsg("invalid collection property %s: %s, should be positive seconds",
+					common.CollectionDedupWindowKey, kv.GetValue())
+			}
+			policy.window = time.Duration(seconds) * time.Second
+		}
+	}
+	if len(policy.fields) == 0 {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+// checkSchema checks the fields of the policy are in the schema.
+func (p *dedupPolicy) checkSchema(schema *schemapb.CollectionSchema) error {
+	for _, name := range p.fields {
+		found := false
+		for _, field := range schema.GetFields() {
+			if field.GetName() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return merr.WrapErrParameterInvalidMsg("invalid collection property %s: field %s not found",
+				common.CollectionDedupFieldsKey, name)
+		}
+	}
+	return nil
+}
+
+// hashRows returns the content hashes of the rows over the fields of the policy.
+func (p *dedupPolicy) hashRows(data []*schemapb.FieldData, numRows int) ([]string, error) {
+	fields := make([]*schemapb.FieldData, 0, len(p.fields))
+	for _, name := range p.fields {
+		for _, field := range data {
+			if field.GetFieldName() == name {
+				fields = append(fields, field)
+				break
+			}
+		}
+	}
+
+	hashes := make([]string, numRows)
+	for i := 0; i < numRows; i++ {
+		hasher := sha256.New()
+		for _, field := range fields {
+			row := make([]*schemapb.FieldData, 1)
+			typeutil.AppendFieldDataByOffsets(row, []*schemapb.FieldData{field}, []int{i})
+			bs, err := proto.Marshal(row[0])
+			if err != nil {
+				return nil, err
+			}
+			hasher.Write([]byte(field.GetFieldName()))
+			hasher.Write(bs)
+		}
+		hashes[i] = string(hasher.Sum(nil))
+	}
+	return hashes, nil
+}
+
+type dedupEntry struct {
+	hash     string
+	pk       interface{}
+	expireAt time.Time
+	// owner is the request inserting the row of the hash, nil once it's inserted
+	owner interface{}
+}
+
+// dedupCache keeps the content hashes of the rows inserted into a collection within the window,
+// in the order they're inserted, the earliest ones are forgotten beyond proxy.dedup.maxEntries.
+// The cache is per proxy and in memory, so the duplicates inserted through the other proxies
+// or before the proxy restarts aren't deduplicated.
+type dedupCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func newDedupCache() *dedupCache {
+	return &dedupCache{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// reserve looks up the row of the hash within the window, and reserves the hash for the owner to insert the row
+// if there's none, so the same rows of the concurrent requests are deduplicated as well.
+// It returns the primary key of the row inserted with the hash, pending if the row is being inserted by another request,
+// and false if the hash is reserved. The row inserted is reserved to replace only if replace is true.
+func (c *dedupCache) reserve(hash string, owner interface{}, replace bool, window time.Duration, now time.Time) (interface{}, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expire(now)
+	if elem, ok := c.entries[hash]; ok {
+		entry := elem.Value.(*dedupEntry)
+		if now.Before(entry.expireAt) {
+			if entry.owner != nil {
+				return nil, true, true
+			}
+			if replace {
+				entry.owner = owner
+			}
+			return entry.pk, false, true
+		}
+		c.remove(elem)
+	}
+	c.push(&dedupEntry{hash: hash, expireAt: now.Add(window), owner: owner})
+	return nil, false, false
+}
+
+// record records the row inserted with the hash, which is deduplicated against until the window passes.
+func (c *dedupCache) record(hash string, pk interface{}, window time.Duration, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[hash]; ok {
+		c.order.Remove(elem)
+	}
+	c.push(&dedupEntry{hash: hash, pk: pk, expireAt: now.Add(window)})
+}
+
+// release releases the hashes reserved by the owner, once it fails to insert the rows.
+func (c *dedupCache) release(hashes []string, owner interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, hash := range hashes {
+		elem, ok := c.entries[hash]
+		if !ok {
+			continue
+		}
+		entry := elem.Value.(*dedupEntry)
+		if entry.owner != owner {
+			continue
+		}
+		if entry.pk == nil {
+			c.remove(elem)
+			continue
+		}
+		// the row inserted before isn't replaced
+		entry.owner = nil
+	}
+}
+
+func (c *dedupCache) push(entry *dedupEntry) {
+	c.entries[entry.hash] = c.order.PushBack(entry)
+	for maxEntries := paramtable.Get().ProxyCfg.DedupMaxEntries.GetAsInt(); c.order.Len() > maxEntries; {
+		c.remove(c.order.Front())
+	}
+}
+
+// expire removes the entries past the window from the front, the window of a collection may be altered,
+// so the entries behind the front are checked as they're looked up.
+func (c *dedupCache) expire(now time.Time) {
+	for elem := c.order.Front(); elem != nil && !now.Before(elem.Value.(*dedupEntry).expireAt); elem = c.order.Front() {
+		c.remove(elem)
+	}
+}
+
+func (c *dedupCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*dedupEntry).hash)
+}
+
+var (
+	dedupCachesMu sync.Mutex
+	dedupCaches   = make(map[UniqueID]*dedupCache)
+)
+
+// getDedupCache returns the dedup cache of the collection.
+func getDedupCache(collectionID UniqueID) *dedupCache {
+	dedupCachesMu.Lock()
+	defer dedupCachesMu.Unlock()
+	cache, ok := dedupCaches[collectionID]
+	if !ok {
+		cache = newDedupCache()
+		dedupCaches[collectionID] = cache
+	}
+	return cache
+}
+
+// removeDedupCache removes the dedup cache of the collection dropped.
+func removeDedupCache(collectionID UniqueID) {
+	dedupCachesMu.Lock()
+	defer dedupCachesMu.Unlock()
+	delete(dedupCaches, collectionID)
+}
+
+// dedupRows drops the duplicates in the insert message by the dedup policy of the collection,
+// in upsert mode the rows duplicated are collected to delete once the rows are inserted.
+// The duplicates are reported as row errors, the same as the invalid rows skipped.
+func (it *insertTask) dedupRows(ctx context.Context) error {
+	dbName, collectionName := it.insertMsg.GetDbName(), it.insertMsg.GetCollectionName()
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, dbName, collectionName)
+	if err != nil {
+		return err
+	}
+	info, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, collectionID)
+	if err != nil {
+		return err
+	}
+	policy, err := parseDedupPolicy(info.properties)
+	if err != nil || policy == nil {
+		return err
+	}
+	numRows := int(it.insertMsg.NRows())
+	hashes, err := policy.hashRows(it.insertMsg.GetFieldsData(), numRows)
+	if err != nil {
+		return err
+	}
+
+	cache := getDedupCache(collectionID)
+	now := time.Now()
+	// the rows of the hashes in the request, the duplicates within the request are deduplicated as well
+	seen := make(map[string]int, numRows)
+	duplicates := make([]*rowError, 0)
+	for i, hash := range hashes {
+		if j, ok := seen[hash]; ok {
+			if policy.mode == dedupModeDrop {
+				duplicates = append(duplicates, &rowError{Index: i, Reason: fmt.Sprintf("duplicate of row %d", j)})
+				continue
+			}
+			duplicates = append(duplicates, &rowError{Index: j, Reason: fmt.Sprintf("duplicate of row %d", i)})
+			seen[hash] = i
+			continue
+		}
+		seen[hash] = i
+		pk, pending, ok := cache.reserve(hash, it, policy.mode == dedupModeUpsert, policy.window, now)
+		if !ok {
+			continue
+		}
+		if pending {
+			duplicates = append(duplicates, &rowError{Index: i, Reason: "duplicate of a row being inserted concurrently"})
+			continue
+		}
+		if policy.mode == dedupModeDrop {
+			duplicates = append(duplicates, &rowError{Index: i, Reason: "duplicate of a row inserted within the dedup window"})
+			continue
+		}
+		if it.dedupDeletes == nil {
+			it.dedupDeletes = &schemapb.IDs{}
+		}
+		typeutil.AppendPKs(it.dedupDeletes, pk)
+	}
+	it.dedupPolicy = policy
+	it.dedupCache = cache
+	it.dedupHashes = hashes
+	suppressed := len(duplicates)
+	if it.dedupDeletes != nil {
+		suppressed += typeutil.GetSizeOfIDs(it.dedupDeletes)
+	}
+	if suppressed > 0 {
+		metrics.ProxyDedupSuppressedRows.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), policy.mode).Add(float64(suppressed))
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	// the row errors are reported by the indexes in the request
+	toRequestIndex := func(i int) int {
+		if it.rowIndexes != nil {
+			return int(it.rowIndexes[i])
+		}
+		return i
+	}
+	for _, duplicate := range duplicates {
+		it.rowErrors = append(it.rowErrors, &rowError{Index: toRequestIndex(duplicate.Index), Reason: duplicate.Reason})
+	}
+	sort.Slice(it.rowErrors, func(i, j int) bool { return it.rowErrors[i].Index < it.rowErrors[j].Index })
+
+	var offsets []uint32
+	if len(duplicates) == numRows {
+		// nothing to insert
+		it.insertMsg.FieldsData = nil
+		offsets = []uint32{}
+	} else {
+		it.insertMsg.FieldsData, offsets, err = dropInvalidRows(it.insertMsg.GetFieldsData(), uint64(numRows), duplicates)
+		if err != nil {
+			return err
+		}
+	}
+	if len(it.insertMsg.HashValues) == numRows {
+		hashValues := it.insertMsg.HashValues
+		it.insertMsg.HashValues = lo.Map(offsets, func(i uint32, _ int) uint32 { return hashValues[i] })
+	}
+	it.dedupHashes = lo.Map(offsets, func(i uint32, _ int) string { return hashes[i] })
+	it.rowIndexes = lo.Map(offsets, func(i uint32, _ int) uint32 { return uint32(toRequestIndex(int(i))) })
+	it.insertMsg.NumRows = uint64(len(offsets))
+	return nil
+}
+
+// releaseDedup releases the hashes reserved by the task if it fails to insert the rows.
+func (it *insertTask) releaseDedup() {
+	if it.dedupPolicy == nil {
+		return
+	}
+	it.dedupCache.release(it.dedupHashes, it)
+}
+
+// recordDedup records the hashes of the rows inserted, and deletes the rows duplicated in upsert mode.
+func (it *insertTask) recordDedup(ctx context.Context, stream msgstream.MsgStream, channelNames []string) error {
+	if it.dedupPolicy == nil {
+		return nil
+	}
+	now := time.Now()
+	for i, hash := range it.dedupHashes {
+		it.dedupCache.record(hash, typeutil.GetPK(it.result.GetIDs(), int64(i)), it.dedupPolicy.window, now)
+	}
+	if it.dedupDeletes == nil {
+		return nil
+	}
+
+	// the rows duplicated were inserted before, so they're deleted by the timestamp of the insertion
+	msgs := make(map[uint32]*msgstream.DeleteMsg)
+	pack := &msgstream.MsgPack{BeginTs: it.BeginTs(), EndTs: it.EndTs()}
+	for i, key := range typeutil.HashPK2Channels(it.dedupDeletes, channelNames) {
+		msg, ok := msgs[key]
+		if !ok {
+			msgID, err := it.idAllocator.AllocOne()
+			if err != nil {
+				return err
+			}
+			msg = &msgstream.DeleteMsg{
+				BaseMsg: msgstream.BaseMsg{Ctx: ctx},
+				DeleteRequest: msgpb.DeleteRequest{
+					Base: commonpbutil.NewMsgBase(
+						commonpbutil.WithMsgType(commonpb.MsgType_Delete),
+						commonpbutil.WithTimeStamp(it.BeginTs()),
+						commonpbutil.WithMsgID(msgID),
+						commonpbutil.WithSourceID(paramtable.GetNodeID()),
+					),
+					CollectionID:   it.insertMsg.GetCollectionID(),
+					PartitionID:    common.InvalidPartitionID,
+					CollectionName: it.insertMsg.GetCollectionName(),
+					ShardName:      channelNames[key],
+					PrimaryKeys:    &schemapb.IDs{},
+				},
+			}
+			msgs[key] = msg
+			pack.Msgs = append(pack.Msgs, msg)
+		}
+		msg.HashValues = append(msg.HashValues, key)
+		msg.Timestamps = append(msg.Timestamps, it.BeginTs())
+		typeutil.AppendIDs(msg.PrimaryKeys, it.dedupDeletes, i)
+		msg.NumRows++
+	}
+	log.Ctx(ctx).Debug("delete the rows duplicated by dedup",
+		zap.Int64("collectionID", it.insertMsg.GetCollectionID()),
+		zap.Int("rows", typeutil.GetSizeOfIDs(it.dedupDeletes)))
+	return stream.Produce(pack)
+}
@@ -0,0 +1,212 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestParseDedupPolicy(t *testing.T) {
+	paramtable.Init()
+
+	policy, err := parseDedupPolicy(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = parseDedupPolicy([]*commonpb.KeyValuePair{{Key: common.CollectionDedupFieldsKey, Value: "text, vector"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"text", "vector"}, policy.fields)
+	assert.Equal(t, dedupModeDrop, policy.mode)
+	assert.Equal(t, time.Hour, policy.window)
+
+	policy, err = parseDedupPolicy([]*commonpb.KeyValuePair{
+		{Key: common.CollectionDedupFieldsKey, Value: "text"},
+		{Key: common.CollectionDedupModeKey, Value: dedupModeUpsert},
+		{Key: common.CollectionDedupWindowKey, Value: "60"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, dedupModeUpsert, policy.mode)
+	assert.Equal(t, time.Minute, policy.window)
+
+	_, err = parseDedupPolicy([]*commonpb.KeyValuePair{{Key: common.CollectionDedupModeKey, Value: "merge"}})
+	assert.Error(t, err)
+	_, err = parseDedupPolicy([]*commonpb.KeyValuePair{{Key: common.CollectionDedupWindowKey, Value: "0"}})
+	assert.Error(t, err)
+
+	schema := &schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{{Name: "text"}}}
+	assert.NoError(t, (&dedupPolicy{fields: []string{"text"}}).checkSchema(schema))
+	assert.Error(t, (&dedupPolicy{fields: []string{"vector"}}).checkSchema(schema))
+}
+
+func TestDedupCache(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.DedupMaxEntries.Key, "2")
+	defer params.Reset(params.ProxyCfg.DedupMaxEntries.Key)
+
+	cache := newDedupCache()
+	now := time.Now()
+	cache.record("a", int64(1), time.Minute, now)
+	cache.record("b", int64(2), time.Second, now)
+
+	pk, pending, ok := cache.reserve("a", "owner1", false, time.Minute, now)
+	assert.True(t, ok)
+	assert.False(t, pending)
+	assert.EqualValues(t, 1, pk)
+	// b expires although it's behind a, then it's reserved
+	_, _, ok = cache.reserve("b", "owner1", false, time.Minute, now.Add(time.Second))
+	assert.False(t, ok)
+	// the row being inserted is pending to the others
+	_, pending, ok = cache.reserve("b", "owner2", false, time.Minute, now.Add(time.Second))
+	assert.True(t, ok)
+	assert.True(t, pending)
+	cache.release([]string{"b"}, "owner2")
+	cache.release([]string{"b"}, "owner1")
+	_, _, ok = cache.reserve("b", "owner2", false, time.Minute, now.Add(time.Second))
+	assert.False(t, ok)
+	cache.release([]string{"b"}, "owner2")
+
+	// the row inserted is kept once the replacing fails
+	_, _, ok = cache.reserve("a", "owner1", true, time.Minute, now)
+	assert.True(t, ok)
+	_, pending, _ = cache.reserve("a", "owner2", true, time.Minute, now)
+	assert.True(t, pending)
+	cache.release([]string{"a"}, "owner1")
+	pk, pending, ok = cache.reserve("a", "owner2", false, time.Minute, now)
+	assert.True(t, ok)
+	assert.False(t, pending)
+	assert.EqualValues(t, 1, pk)
+
+	// the earliest is forgotten beyond the max entries
+	cache.record("c", int64(3), time.Minute, now)
+	cache.record("d", int64(4), time.Minute, now)
+	_, _, ok = cache.reserve("a", "owner1", false, time.Minute, now)
+	assert.False(t, ok)
+	_, _, ok = cache.reserve("d", "owner1", false, time.Minute, now)
+	assert.True(t, ok)
+
+	_, _, ok = cache.reserve("d", "owner1", false, time.Minute, now.Add(time.Minute))
+	assert.False(t, ok)
+}
+
+func TestInsertTask_DedupRows(t *testing.T) {
+	paramtable.Init()
+	cache := globalMetaCache
+	defer func() { globalMetaCache = cache }()
+	mockCache := NewMockCache(t)
+	globalMetaCache = mockCache
+
+	collectionID := UniqueID(1000)
+	mockCache.EXPECT().GetCollectionID(mock.Anything, mock.Anything, mock.Anything).Return(collectionID, nil)
+	setMode := func(mode string) {
+		mockCache.EXPECT().GetCollectionInfo(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&collectionBasicInfo{collID: collectionID, properties: []*commonpb.KeyValuePair{
+				{Key: common.CollectionDedupFieldsKey, Value: "text"},
+				{Key: common.CollectionDedupModeKey, Value: mode},
+			}}, nil).Once()
+	}
+	newTask := func(texts ...string) *insertTask {
+		return &insertTask{
+			insertMsg: &msgstream.InsertMsg{
+				InsertRequest: msgpb.InsertRequest{
+					CollectionName: "coll",
+					CollectionID:   collectionID,
+					NumRows:        uint64(len(texts)),
+					FieldsData: []*schemapb.FieldData{{
+						Type:      schemapb.DataType_VarChar,
+						FieldName: "text",
+						Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+							Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: texts}},
+						}},
+					}},
+				},
+			},
+		}
+	}
+	texts := func(it *insertTask) []string {
+		return it.insertMsg.GetFieldsData()[0].GetScalars().GetStringData().GetData()
+	}
+	ctx := context.Background()
+
+	// drop mode, the duplicates within the request
+	setMode(dedupModeDrop)
+	it := newTask("a", "b", "a")
+	assert.NoError(t, it.dedupRows(ctx))
+	assert.Equal(t, []string{"a", "b"}, texts(it))
+	assert.Equal(t, []uint32{0, 1}, it.rowIndexes)
+	assert.Len(t, it.rowErrors, 1)
+	assert.Equal(t, 2, it.rowErrors[0].Index)
+
+	// the rows inserted are recorded
+	it.result = &milvuspb.MutationResult{IDs: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2}}}}}
+	assert.NoError(t, it.recordDedup(ctx, nil, nil))
+
+	// drop mode, the duplicates of the rows inserted
+	setMode(dedupModeDrop)
+	it = newTask("b", "c")
+	assert.NoError(t, it.dedupRows(ctx))
+	assert.Equal(t, []string{"c"}, texts(it))
+	assert.Equal(t, []uint32{1}, it.rowIndexes)
+
+	setMode(dedupModeDrop)
+	it = newTask("a", "b")
+	assert.NoError(t, it.dedupRows(ctx))
+	assert.EqualValues(t, 0, it.insertMsg.NRows())
+	assert.Empty(t, it.rowIndexes)
+	assert.Len(t, it.rowErrors, 2)
+
+	// upsert mode, the rows duplicated are deleted, the last row within the request is kept
+	setMode(dedupModeUpsert)
+	it = newTask("a", "d", "d")
+	assert.NoError(t, it.dedupRows(ctx))
+	assert.Equal(t, []string{"a", "d"}, texts(it))
+	assert.Equal(t, []uint32{0, 2}, it.rowIndexes)
+	assert.Equal(t, []int64{1}, it.dedupDeletes.GetIntId().GetData())
+
+	// the same rows being inserted concurrently are dropped
+	setMode(dedupModeUpsert)
+	concurrent := newTask("d", "e")
+	assert.NoError(t, concurrent.dedupRows(ctx))
+	assert.Equal(t, []string{"e"}, texts(concurrent))
+	assert.Len(t, concurrent.rowErrors, 1)
+
+	// the hashes are released once the insertion fails
+	it.releaseDedup()
+	setMode(dedupModeDrop)
+	it = newTask("d")
+	assert.NoError(t, it.dedupRows(ctx))
+	assert.Equal(t, []string{"d"}, texts(it))
+
+	removeDedupCache(collectionID)
+	setMode(dedupModeDrop)
+	it = newTask("b")
+	assert.NoError(t, it.dedupRows(ctx))
+	assert.Equal(t, []string{"b"}, texts(it))
+}
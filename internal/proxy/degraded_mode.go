@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/util/healthcheck"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// degradedMonitor probes the object storage and the message queue periodically,
+// the proxy turns read-only once any of them is unavailable,
+// and recovers after the probes succeed for the configured consecutive rounds.
+type degradedMonitor struct {
+	checker *healthcheck.Checker
+
+	mu            sync.RWMutex
+	readOnly      bool
+	reason        string
+	healthyRounds int
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func newDegradedMonitor(checker *healthcheck.Checker) *degradedMonitor {
+	return &degradedMonitor{
+		checker: checker,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// initDegradedMonitor creates the monitor of the dependencies used by the degraded mode.
+func (node *Proxy) initDegradedMonitor() {
+	if node.factory == nil {
+		return
+	}
+	params := paramtable.Get()
+	checker := healthcheck.NewChecker(probeThreshold(&params.ProxyCfg.HealthProbe.Timeout))
+	node.registerDependencyProbes(checker, "degraded")
+	node.degradedMonitor = newDegradedMonitor(checker)
+}
+
+func (m *degradedMonitor) start(interval time.Duration) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.closeCh:
+				return
+			case <-ticker.C:
+				if !paramtable.Get().CommonCfg.DegradedModeEnable.GetAsBool() {
+					m.update(nil)
+					continue
+				}
+				m.update(m.checker.Check(context.Background()))
+			}
+		}
+	}()
+}
+
+// update applies the report of the probes, nil report means the degraded mode is disabled.
+func (m *degradedMonitor) update(report *healthcheck.Report) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if report == nil {
+		m.setReadOnly(false, "")
+		return
+	}
+	problems := make([]string, 0)
+	for _, d := range report.Problems() {
+		if d.Severity == healthcheck.SeverityCritical {
+			problems = append(problems, d.String())
+		}
+	}
+	if len(problems) > 0 {
+		m.healthyRounds = 0
+		m.setReadOnly(true, strings.Join(problems, "; "))
+		return
+	}
+	if !m.readOnly {
+		return
+	}
+	m.healthyRounds++
+	if m.healthyRounds >= paramtable.Get().CommonCfg.DegradedModeRecoverRounds.GetAsInt() {
+		m.setReadOnly(false, "")
+	}
+}
+
+func (m *degradedMonitor) setReadOnly(readOnly bool, reason string) {
+	if readOnly && !m.readOnly {
+		log.Warn("proxy turns read-only due to dependency outage", zap.String("reason", reason))
+	} else if !readOnly && m.readOnly {
+		log.Info("proxy recovers from read-only mode")
+	}
+	m.readOnly = readOnly
+	m.reason = reason
+	m.healthyRounds = 0
+	value := 0.0
+	if readOnly {
+		value = 1
+	}
+	metrics.ProxyReadOnly.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Set(value)
+}
+
+// readOnlyReason returns the reason if the proxy is read-only.
+func (m *degradedMonitor) readOnlyReason() (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.reason, m.readOnly
+}
+
+func (m *degradedMonitor) close() {
+	m.closeOnce.Do(func() {
+		close(m.closeCh)
+		m.wg.Wait()
+	})
+}
+
+// checkWritable returns error if the proxy is unhealthy or read-only due to dependency outage.
+func (node *Proxy) checkWritable() error {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		return err
+	}
+	if node.degradedMonitor != nil {
+		if reason, readOnly := node.degradedMonitor.readOnlyReason(); readOnly {
+			return merr.WrapErrServiceReadOnly(reason)
+		}
+	}
+	return nil
+}
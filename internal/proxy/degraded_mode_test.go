@@ -0,0 +1,71 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/util/healthcheck"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestDegradedMonitor(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.CommonCfg.DegradedModeRecoverRounds.Key, "2")
+	defer params.Reset(params.CommonCfg.DegradedModeRecoverRounds.Key)
+
+	healthy := atomic.NewBool(false)
+	checker := healthcheck.NewChecker(func() time.Duration { return time.Second })
+	checker.Register(healthcheck.ProbeMQ, func(ctx context.Context) []*healthcheck.Diagnosis {
+		if healthy.Load() {
+			return []*healthcheck.Diagnosis{{Probe: healthcheck.ProbeMQ, Severity: healthcheck.SeverityOK}}
+		}
+		return []*healthcheck.Diagnosis{{Probe: healthcheck.ProbeMQ, Severity: healthcheck.SeverityCritical, Code: healthcheck.CodeMQUnreachable}}
+	})
+
+	node := &Proxy{degradedMonitor: newDegradedMonitor(checker)}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+	assert.NoError(t, node.checkWritable())
+
+	monitor := node.degradedMonitor
+	monitor.update(checker.Check(context.Background()))
+	err := node.checkWritable()
+	assert.ErrorIs(t, err, merr.ErrServiceReadOnly)
+	assert.Contains(t, err.Error(), string(healthcheck.CodeMQUnreachable))
+
+	// recovers after the consecutive healthy rounds
+	healthy.Store(true)
+	monitor.update(checker.Check(context.Background()))
+	assert.ErrorIs(t, node.checkWritable(), merr.ErrServiceReadOnly)
+	monitor.update(checker.Check(context.Background()))
+	assert.NoError(t, node.checkWritable())
+
+	// disabling the degraded mode recovers immediately
+	healthy.Store(false)
+	monitor.update(checker.Check(context.Background()))
+	assert.Error(t, node.checkWritable())
+	monitor.update(nil)
+	assert.NoError(t, node.checkWritable())
+}
@@ -0,0 +1,289 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// errDeleteCanceled is the cause of the deletes canceled through the management API.
+var errDeleteCanceled = errors.New("delete canceled")
+
+// deleteJobState is the state of a delete with filter expression.
+type deleteJobState string
+
+const (
+	deleteJobRunning   deleteJobState = "Running"
+	deleteJobCompleted deleteJobState = "Completed"
+	deleteJobFailed    deleteJobState = "Failed"
+	deleteJobCanceled  deleteJobState = "Canceled"
+)
+
+// deleteJob is the progress of a delete with filter expression,
+// the primary keys matched by the filter are deleted batch by batch.
+type deleteJob struct {
+	ID             int64          `json:"job_id"`
+	Owner          string         `json:"owner,omitempty"`
+	DbName         string         `json:"db_name"`
+	CollectionName string         `json:"collection_name"`
+	PartitionName  string         `json:"partition_name,omitempty"`
+	Expr           string         `json:"expr"`
+	State          deleteJobState `json:"state"`
+	Reason         string         `json:"reason,omitempty"`
+	DeletedRows    int64          `json:"deleted_rows"`
+	Batches        int64          `json:"batches"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time,omitempty"`
+
+	cancel context.CancelCauseFunc
+}
+
+// deleteJobManager tracks the deletes with filter expression running on this proxy,
+// so their progress can be watched and they can be canceled through the management API.
+// Jobs are kept in memory, the finished ones are evicted after proxy.delete.jobRetention.
+// All the methods are no-op on a nil manager.
+type deleteJobManager struct {
+	mu   sync.RWMutex
+	jobs map[int64]*deleteJob
+}
+
+func newDeleteJobManager() *deleteJobManager {
+	return &deleteJobManager{
+		jobs: make(map[int64]*deleteJob),
+	}
+}
+
+// Start registers the delete of owner, the returned context is canceled once the job is canceled.
+func (m *deleteJobManager) Start(ctx context.Context, jobID int64, owner string, req *milvuspb.DeleteRequest) (context.Context, *deleteJob) {
+	if m == nil {
+		return ctx, nil
+	}
+	m.evict()
+	ctx, cancel := context.WithCancelCause(ctx)
+	job := &deleteJob{
+		ID:             jobID,
+		Owner:          owner,
+		DbName:         req.GetDbName(),
+		CollectionName: req.GetCollectionName(),
+		PartitionName:  req.GetPartitionName(),
+		Expr:           req.GetExpr(),
+		State:          deleteJobRunning,
+		StartTime:      time.Now(),
+		cancel:         cancel,
+	}
+	m.mu.Lock()
+	m.jobs[jobID] = job
+	m.mu.Unlock()
+	return ctx, job
+}
+
+// Progress records a batch of deleted rows of the job.
+func (m *deleteJobManager) Progress(job *deleteJob, rows int64) {
+	if m == nil || job == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job.DeletedRows += rows
+	job.Batches++
+}
+
+// Finish marks the job finished with the error, nil means completed.
+func (m *deleteJobManager) Finish(job *deleteJob, err error) {
+	if m == nil || job == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job.EndTime = time.Now()
+	switch {
+	case err == nil:
+		job.State = deleteJobCompleted
+	case errors.Is(err, errDeleteCanceled):
+		job.State = deleteJobCanceled
+		job.Reason = err.Error()
+	default:
+		job.State = deleteJobFailed
+		job.Reason = err.Error()
+	}
+	job.cancel(nil)
+}
+
+// Cancel cancels the running job, the batches deleted already are not reverted.
+func (m *deleteJobManager) Cancel(jobID int64) error {
+	if m == nil {
+		return merr.WrapErrParameterInvalidMsg("delete job %d not found", jobID)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.jobs[jobID]
+	if !ok {
+		return merr.WrapErrParameterInvalidMsg("delete job %d not found", jobID)
+	}
+	if job.State != deleteJobRunning {
+		return merr.WrapErrParameterInvalidMsg("delete job %d is %s already", jobID, job.State)
+	}
+	job.cancel(errDeleteCanceled)
+	return nil
+}
+
+// Get returns a copy of the job.
+func (m *deleteJobManager) Get(jobID int64) (*deleteJob, bool) {
+	if m == nil {
+		return nil, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.jobs[jobID]
+	if !ok {
+		return nil, false
+	}
+	cloned := *job
+	return &cloned, true
+}
+
+// List returns copies of all the jobs in the order of ID.
+func (m *deleteJobManager) List() []*deleteJob {
+	if m == nil {
+		return nil
+	}
+	m.evict()
+	m.mu.RLock()
+	jobs := make([]*deleteJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		cloned := *job
+		jobs = append(jobs, &cloned)
+	}
+	m.mu.RUnlock()
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs
+}
+
+// evict removes the finished jobs which ended before the retention.
+func (m *deleteJobManager) evict() {
+	retention := paramtable.Get().ProxyCfg.DeleteJobRetention.GetAsDuration(time.Second)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, job := range m.jobs {
+		if job.State != deleteJobRunning && time.Since(job.EndTime) > retention {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+// deleteJobVisible returns whether the job is visible to the user of ctx, admins see all the jobs.
+func deleteJobVisible(ctx context.Context, job *deleteJob) (bool, error) {
+	isAdmin, err := mgrIsAdmin(ctx)
+	if err != nil {
+		return false, err
+	}
+	return isAdmin || job.Owner == mgrCurUser(ctx), nil
+}
+
+// getVisibleDeleteJob returns the job of job_id in the request if it's visible to the user,
+// the response is written if it's not found.
+func (node *Proxy) getVisibleDeleteJob(w http.ResponseWriter, req *http.Request) (*deleteJob, bool) {
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return nil, false
+	}
+	jobID, err := strconv.ParseInt(req.URL.Query().Get("job_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid job_id, %s"}`, err.Error())))
+		return nil, false
+	}
+	job, ok := node.deleteManager.Get(jobID)
+	if ok {
+		visible, err := deleteJobVisible(ctx, job)
+		if err != nil {
+			mgrWriteAuthError(w, err)
+			return nil, false
+		}
+		// don't leak the existence of the jobs of others
+		ok = visible
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "delete job %d not found"}`, jobID)))
+		return nil, false
+	}
+	return job, true
+}
+
+// GetDeleteState returns the progress of the delete with filter expression.
+func (node *Proxy) GetDeleteState(w http.ResponseWriter, req *http.Request) {
+	job, ok := node.getVisibleDeleteJob(w, req)
+	if !ok {
+		return
+	}
+	mgrWriteJSON(w, job)
+}
+
+// ListDeleteJobs lists the deletes with filter expression of this proxy, which are visible to the user.
+func (node *Proxy) ListDeleteJobs(w http.ResponseWriter, req *http.Request) {
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	jobs := make([]*deleteJob, 0)
+	for _, job := range node.deleteManager.List() {
+		visible, err := deleteJobVisible(ctx, job)
+		if err != nil {
+			mgrWriteAuthError(w, err)
+			return
+		}
+		if visible {
+			jobs = append(jobs, job)
+		}
+	}
+	mgrWriteJSON(w, jobs)
+}
+
+// CancelDelete cancels the running delete with filter expression.
+func (node *Proxy) CancelDelete(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return
+	}
+	job, ok := node.getVisibleDeleteJob(w, req)
+	if !ok {
+		return
+	}
+	if err := node.deleteManager.Cancel(job.ID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to cancel delete job, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
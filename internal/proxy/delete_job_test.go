@@ -0,0 +1,115 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestDeleteJobManager(t *testing.T) {
+	paramtable.Init()
+	m := newDeleteJobManager()
+	req := &milvuspb.DeleteRequest{CollectionName: "coll", Expr: "a > 1"}
+
+	ctx, job := m.Start(context.Background(), 1, "", req)
+	m.Progress(job, 10)
+	m.Progress(job, 5)
+	got, ok := m.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, deleteJobRunning, got.State)
+	assert.EqualValues(t, 15, got.DeletedRows)
+	assert.EqualValues(t, 2, got.Batches)
+
+	assert.NoError(t, m.Cancel(1))
+	assert.ErrorIs(t, context.Cause(ctx), errDeleteCanceled)
+	m.Finish(job, context.Cause(ctx))
+	got, _ = m.Get(1)
+	assert.Equal(t, deleteJobCanceled, got.State)
+	assert.Error(t, m.Cancel(1))
+	assert.Error(t, m.Cancel(2))
+
+	_, job = m.Start(context.Background(), 2, "", req)
+	m.Finish(job, nil)
+	jobs := m.List()
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, deleteJobCompleted, jobs[1].State)
+
+	// finished jobs are evicted after the retention
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.DeleteJobRetention.Key, "0")
+	defer params.Reset(params.ProxyCfg.DeleteJobRetention.Key)
+	assert.Empty(t, m.List())
+
+	// nil manager is no-op
+	var nilManager *deleteJobManager
+	ctx, job = nilManager.Start(context.Background(), 3, "", req)
+	assert.NotNil(t, ctx)
+	assert.Nil(t, job)
+	nilManager.Progress(job, 1)
+	nilManager.Finish(job, nil)
+	assert.Empty(t, nilManager.List())
+}
+
+func TestProxy_DeleteJobRoutes(t *testing.T) {
+	paramtable.Init()
+	node := &Proxy{deleteManager: newDeleteJobManager()}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+	node.deleteManager.Start(context.Background(), 100, "", &milvuspb.DeleteRequest{CollectionName: "coll", Expr: "a > 1"})
+
+	w := httptest.NewRecorder()
+	node.GetDeleteState(w, httptest.NewRequest(http.MethodGet, mgrRouteDeleteState+"?job_id=100", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	job := &deleteJob{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), job))
+	assert.Equal(t, "coll", job.CollectionName)
+
+	w = httptest.NewRecorder()
+	node.GetDeleteState(w, httptest.NewRequest(http.MethodGet, mgrRouteDeleteState+"?job_id=101", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	node.ListDeleteJobs(w, httptest.NewRequest(http.MethodGet, mgrRouteDeleteList, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	jobs := make([]*deleteJob, 0)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &jobs))
+	assert.Len(t, jobs, 1)
+
+	w = httptest.NewRecorder()
+	node.CancelDelete(w, httptest.NewRequest(http.MethodGet, mgrRouteDeleteCancel+"?job_id=100", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	w = httptest.NewRecorder()
+	node.CancelDelete(w, httptest.NewRequest(http.MethodPost, mgrRouteDeleteCancel+"?job_id=100", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSliceIDs(t *testing.T) {
+	ids := &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}}}}
+	assert.Equal(t, []int64{2, 3}, sliceIDs(ids, 1, 3).GetIntId().GetData())
+	ids = &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"a", "b"}}}}
+	assert.Equal(t, []string{"a"}, sliceIDs(ids, 0, 1).GetStrId().GetData())
+}
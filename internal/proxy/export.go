@@ -0,0 +1,701 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+type exportJobState string
+
+const (
+	exportJobPending   exportJobState = "Pending"
+	exportJobRunning   exportJobState = "Running"
+	exportJobCompleted exportJobState = "Completed"
+	exportJobFailed    exportJobState = "Failed"
+)
+
+// exportPathPrefix is the directory under the root path of bucket, which all the exported files are confined to.
+const exportPathPrefix = "export"
+
+// exportRequest is the request of ExportCollection.
+type exportRequest struct {
+	DbName         string   `json:"db_name"`
+	CollectionName string   `json:"collection_name"`
+	PartitionNames []string `json:"partition_names"`
+	// Expr filters the exported entities, all the entities are exported if it's empty
+	Expr string `json:"expr"`
+	// Timestamp is the hybrid timestamp of the snapshot to export, 0 means now
+	Timestamp uint64 `json:"timestamp"`
+	// Profile is the bucket profile to write to, see minio.bucketProfiles, empty means the default bucket
+	Profile string `json:"profile"`
+	// Path is the directory of the exported files, relative to the export prefix under the root path of bucket
+	Path string `json:"path"`
+}
+
+type exportJob struct {
+	ID        int64          `json:"job_id"`
+	Owner     string         `json:"owner,omitempty"`
+	Request   *exportRequest `json:"request"`
+	State     exportJobState `json:"state"`
+	Reason    string         `json:"reason,omitempty"`
+	Rows      int64          `json:"rows"`
+	Files     []string       `json:"files"`
+	StartTime time.Time      `json:"start_time"`
+	EndTime   time.Time      `json:"end_time,omitempty"`
+}
+
+type exportQueryFunc func(ctx context.Context, req *milvuspb.QueryRequest, mvccTs Timestamp) (*milvuspb.QueryResults, error)
+
+type exportSchemaFunc func(ctx context.Context, dbName, collectionName string) (*schemapb.CollectionSchema, error)
+
+// exportJobManager runs the export jobs, which write the entities of collection to object storage as parquet files.
+// The entities are retrieved by queries in the order of primary key at the same timestamp, so deletes are applied.
+// Jobs are kept in memory, so they're lost once the proxy restarts,
+// and the finished ones are evicted after proxy.export.jobRetention.
+type exportJobManager struct {
+	mu   sync.RWMutex
+	jobs map[int64]*exportJob
+
+	sem       chan struct{}
+	query     exportQueryFunc
+	getSchema exportSchemaFunc
+	getCM     func(ctx context.Context, profile string) (storage.ChunkManager, error)
+}
+
+func newExportJobManager(query exportQueryFunc, getSchema exportSchemaFunc,
+	getCM func(ctx context.Context, profile string) (storage.ChunkManager, error),
+) *exportJobManager {
+	return &exportJobManager{
+		jobs:      make(map[int64]*exportJob),
+		sem:       make(chan struct{}, paramtable.Get().ProxyCfg.ExportMaxRunningJobs.GetAsInt()),
+		query:     query,
+		getSchema: getSchema,
+		getCM:     getCM,
+	}
+}
+
+// validateExportPath checks the export path is a relative path which doesn't escape the export prefix.
+func validateExportPath(exportPath string) error {
+	if exportPath == "" {
+		return merr.WrapErrParameterInvalidMsg("export path is empty")
+	}
+	if path.IsAbs(exportPath) || strings.HasPrefix(exportPath, "\\") {
+		return merr.WrapErrParameterInvalidMsg("export path %s must be relative", exportPath)
+	}
+	for _, elem := range strings.FieldsFunc(exportPath, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if elem == ".." {
+			return merr.WrapErrParameterInvalidMsg("export path %s must not contain ..", exportPath)
+		}
+	}
+	return nil
+}
+
+// Submit starts the export job of owner in background.
+func (m *exportJobManager) Submit(ctx context.Context, jobID int64, owner string, req *exportRequest) error {
+	if req.CollectionName == "" {
+		return merr.WrapErrParameterInvalidMsg("collection name is empty")
+	}
+	if err := validateExportPath(req.Path); err != nil {
+		return err
+	}
+
+	m.evict()
+	job := &exportJob{
+		ID:        jobID,
+		Owner:     owner,
+		Request:   req,
+		State:     exportJobPending,
+		Files:     make([]string, 0),
+		StartTime: time.Now(),
+	}
+	m.mu.Lock()
+	m.jobs[jobID] = job
+	m.mu.Unlock()
+
+	go m.run(ctx, job)
+	return nil
+}
+
+// Get returns a copy of the job.
+func (m *exportJobManager) Get(jobID int64) (*exportJob, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.jobs[jobID]
+	if !ok {
+		return nil, false
+	}
+	cloned := *job
+	cloned.Files = append([]string{}, job.Files...)
+	return &cloned, true
+}
+
+// evict removes the finished jobs which ended before the retention.
+func (m *exportJobManager) evict() {
+	retention := paramtable.Get().ProxyCfg.ExportJobRetention.GetAsDuration(time.Second)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, job := range m.jobs {
+		finished := job.State == exportJobCompleted || job.State == exportJobFailed
+		if finished && time.Since(job.EndTime) > retention {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+// List returns copies of all the jobs in the order of ID.
+func (m *exportJobManager) List() []*exportJob {
+	m.evict()
+	m.mu.RLock()
+	ids := make([]int64, 0, len(m.jobs))
+	for id := range m.jobs {
+		ids = append(ids, id)
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	jobs := make([]*exportJob, 0, len(ids))
+	for _, id := range ids {
+		if job, ok := m.Get(id); ok {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+func (m *exportJobManager) update(job *exportJob, fn func(job *exportJob)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(job)
+}
+
+func (m *exportJobManager) run(ctx context.Context, job *exportJob) {
+	select {
+	case m.sem <- struct{}{}:
+		defer func() { <-m.sem }()
+	case <-ctx.Done():
+		m.update(job, func(job *exportJob) {
+			job.State = exportJobFailed
+			job.Reason = ctx.Err().Error()
+			job.EndTime = time.Now()
+		})
+		return
+	}
+
+	log := log.Ctx(ctx).With(zap.Int64("jobID", job.ID),
+		zap.String("db", job.Request.DbName),
+		zap.String("collection", job.Request.CollectionName))
+	log.Info("export job started")
+	m.update(job, func(job *exportJob) { job.State = exportJobRunning })
+
+	err := m.export(ctx, job)
+	m.update(job, func(job *exportJob) {
+		job.EndTime = time.Now()
+		if err != nil {
+			job.State = exportJobFailed
+			job.Reason = err.Error()
+			return
+		}
+		job.State = exportJobCompleted
+	})
+	if err != nil {
+		log.Warn("export job failed", zap.Error(err))
+		return
+	}
+	log.Info("export job completed", zap.Int64("rows", job.Rows), zap.Duration("elapse", time.Since(job.StartTime)))
+}
+
+func (m *exportJobManager) export(ctx context.Context, job *exportJob) error {
+	req := job.Request
+	schema, err := m.getSchema(ctx, req.DbName, req.CollectionName)
+	if err != nil {
+		return err
+	}
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return err
+	}
+	cm, err := m.getCM(ctx, req.Profile)
+	if err != nil {
+		return err
+	}
+	dir := path.Join(cm.RootPath(), exportPathPrefix, path.Clean(req.Path), strconv.FormatInt(job.ID, 10))
+	writer, err := newExportFileWriter(schema)
+	if err != nil {
+		return err
+	}
+	defer writer.Release()
+
+	mvccTs := req.Timestamp
+	if mvccTs == 0 {
+		mvccTs = tsoutil.ComposeTSByTime(time.Now(), 0)
+	}
+	batchSize := paramtable.Get().ProxyCfg.ExportBatchSize.GetAsInt64()
+	rowsPerFile := paramtable.Get().ProxyCfg.ExportRowsPerFile.GetAsInt()
+
+	flush := func() error {
+		if writer.Rows() == 0 {
+			return nil
+		}
+		filePath := path.Join(dir, fmt.Sprintf("part-%05d.parquet", len(job.Files)))
+		data, err := writer.Flush()
+		if err != nil {
+			return err
+		}
+		if err := cm.Write(ctx, filePath, data); err != nil {
+			return err
+		}
+		m.update(job, func(job *exportJob) { job.Files = append(job.Files, filePath) })
+		return nil
+	}
+
+	// entities are paged by the primary key cursor instead of offset,
+	// the results of query are merged in the order of primary key, so the max pk of a page is the cursor of the next one.
+	var lastPK *schemapb.IDs
+	for {
+		result, err := m.query(ctx, &milvuspb.QueryRequest{
+			DbName:           req.DbName,
+			CollectionName:   req.CollectionName,
+			PartitionNames:   req.PartitionNames,
+			Expr:             buildExportExpr(req.Expr, pkField, lastPK),
+			OutputFields:     []string{"*"},
+			QueryParams:      []*commonpb.KeyValuePair{{Key: LimitKey, Value: strconv.FormatInt(batchSize, 10)}},
+			ConsistencyLevel: commonpb.ConsistencyLevel_Strong,
+		}, mvccTs)
+		if err := merr.CheckRPCCall(result, err); err != nil {
+			return err
+		}
+
+		rows, maxPK, err := writer.Append(result.GetFieldsData(), pkField)
+		if err != nil {
+			return err
+		}
+		m.update(job, func(job *exportJob) { job.Rows += int64(rows) })
+		if writer.Rows() >= rowsPerFile {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if int64(rows) < batchSize {
+			break
+		}
+		lastPK = maxPK
+	}
+	return flush()
+}
+
+// buildExportExpr filters the entities after the last primary key retrieved.
+func buildExportExpr(expr string, pkField *schemapb.FieldSchema, lastPK *schemapb.IDs) string {
+	if lastPK == nil {
+		return expr
+	}
+	var bound string
+	switch pkField.GetDataType() {
+	case schemapb.DataType_Int64:
+		bound = fmt.Sprintf("%s > %d", pkField.GetName(), lastPK.GetIntId().GetData()[0])
+	default:
+		bound = fmt.Sprintf("%s > %s", pkField.GetName(), strconv.Quote(lastPK.GetStrId().GetData()[0]))
+	}
+	if expr == "" {
+		return bound
+	}
+	return fmt.Sprintf("(%s) and %s", expr, bound)
+}
+
+// exportFileWriter converts the query results into arrow records and writes them as parquet files.
+type exportFileWriter struct {
+	fields  []*schemapb.FieldSchema
+	schema  *arrow.Schema
+	builder *array.RecordBuilder
+	rows    int
+}
+
+func newExportFileWriter(collSchema *schemapb.CollectionSchema) (*exportFileWriter, error) {
+	fields := make([]*schemapb.FieldSchema, 0, len(collSchema.GetFields()))
+	arrowFields := make([]arrow.Field, 0, len(collSchema.GetFields()))
+	for _, field := range collSchema.GetFields() {
+		if field.GetFieldID() < common.StartOfUserFieldID {
+			continue
+		}
+		dataType, err := exportArrowType(field)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		arrowFields = append(arrowFields, arrow.Field{Name: field.GetName(), Type: dataType})
+	}
+	schema := arrow.NewSchema(arrowFields, nil)
+	return &exportFileWriter{
+		fields:  fields,
+		schema:  schema,
+		builder: array.NewRecordBuilder(memory.DefaultAllocator, schema),
+	}, nil
+}
+
+func exportArrowType(field *schemapb.FieldSchema) (arrow.DataType, error) {
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case schemapb.DataType_Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case schemapb.DataType_Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case schemapb.DataType_Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case schemapb.DataType_Int64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case schemapb.DataType_Float:
+		return arrow.PrimitiveTypes.Float32, nil
+	case schemapb.DataType_Double:
+		return arrow.PrimitiveTypes.Float64, nil
+	case schemapb.DataType_String, schemapb.DataType_VarChar, schemapb.DataType_JSON:
+		return arrow.BinaryTypes.String, nil
+	case schemapb.DataType_FloatVector:
+		dim, err := typeutil.GetDim(field)
+		if err != nil {
+			return nil, err
+		}
+		return arrow.FixedSizeListOf(int32(dim), arrow.PrimitiveTypes.Float32), nil
+	case schemapb.DataType_BinaryVector:
+		dim, err := typeutil.GetDim(field)
+		if err != nil {
+			return nil, err
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: int(dim / 8)}, nil
+	case schemapb.DataType_Float16Vector:
+		dim, err := typeutil.GetDim(field)
+		if err != nil {
+			return nil, err
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: int(dim * 2)}, nil
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("export doesn't support field %s of type %s", field.GetName(), field.GetDataType().String())
+	}
+}
+
+// Append appends the query results, returns the number of rows and the max primary key appended.
+func (w *exportFileWriter) Append(fieldsData []*schemapb.FieldData, pkField *schemapb.FieldSchema) (int, *schemapb.IDs, error) {
+	columns := make(map[string]*schemapb.FieldData, len(fieldsData))
+	for _, fieldData := range fieldsData {
+		columns[fieldData.GetFieldName()] = fieldData
+	}
+	pkData, ok := columns[pkField.GetName()]
+	if !ok {
+		return 0, nil, merr.WrapErrServiceInternal("primary key not found in query results")
+	}
+	rows := typeutil.GetPKSize(pkData)
+	if rows == 0 {
+		return 0, nil, nil
+	}
+
+	for i, field := range w.fields {
+		fieldData, ok := columns[field.GetName()]
+		if !ok {
+			return 0, nil, merr.WrapErrServiceInternal(fmt.Sprintf("field %s not found in query results", field.GetName()))
+		}
+		if err := appendExportColumn(w.builder.Field(i), field, fieldData); err != nil {
+			return 0, nil, err
+		}
+	}
+	w.rows += rows
+	return rows, exportMaxPK(pkField, pkData), nil
+}
+
+// exportMaxPK returns the max primary key of the non-empty pk column.
+func exportMaxPK(pkField *schemapb.FieldSchema, pkData *schemapb.FieldData) *schemapb.IDs {
+	switch pkField.GetDataType() {
+	case schemapb.DataType_Int64:
+		data := pkData.GetScalars().GetLongData().GetData()
+		max := data[0]
+		for _, v := range data {
+			if v > max {
+				max = v
+			}
+		}
+		return &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{max}}}}
+	default:
+		data := pkData.GetScalars().GetStringData().GetData()
+		max := data[0]
+		for _, v := range data {
+			if v > max {
+				max = v
+			}
+		}
+		return &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{max}}}}
+	}
+}
+
+func appendExportColumn(builder array.Builder, field *schemapb.FieldSchema, fieldData *schemapb.FieldData) error {
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		builder.(*array.BooleanBuilder).AppendValues(fieldData.GetScalars().GetBoolData().GetData(), nil)
+	case schemapb.DataType_Int8:
+		for _, v := range fieldData.GetScalars().GetIntData().GetData() {
+			builder.(*array.Int8Builder).Append(int8(v))
+		}
+	case schemapb.DataType_Int16:
+		for _, v := range fieldData.GetScalars().GetIntData().GetData() {
+			builder.(*array.Int16Builder).Append(int16(v))
+		}
+	case schemapb.DataType_Int32:
+		builder.(*array.Int32Builder).AppendValues(fieldData.GetScalars().GetIntData().GetData(), nil)
+	case schemapb.DataType_Int64:
+		builder.(*array.Int64Builder).AppendValues(fieldData.GetScalars().GetLongData().GetData(), nil)
+	case schemapb.DataType_Float:
+		builder.(*array.Float32Builder).AppendValues(fieldData.GetScalars().GetFloatData().GetData(), nil)
+	case schemapb.DataType_Double:
+		builder.(*array.Float64Builder).AppendValues(fieldData.GetScalars().GetDoubleData().GetData(), nil)
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		builder.(*array.StringBuilder).AppendValues(fieldData.GetScalars().GetStringData().GetData(), nil)
+	case schemapb.DataType_JSON:
+		for _, v := range fieldData.GetScalars().GetJsonData().GetData() {
+			builder.(*array.StringBuilder).Append(string(v))
+		}
+	case schemapb.DataType_FloatVector:
+		listBuilder := builder.(*array.FixedSizeListBuilder)
+		valueBuilder := listBuilder.ValueBuilder().(*array.Float32Builder)
+		dim := int(listBuilder.Type().(*arrow.FixedSizeListType).Len())
+		data := fieldData.GetVectors().GetFloatVector().GetData()
+		for i := 0; i+dim <= len(data); i += dim {
+			listBuilder.Append(true)
+			valueBuilder.AppendValues(data[i:i+dim], nil)
+		}
+	case schemapb.DataType_BinaryVector, schemapb.DataType_Float16Vector:
+		binaryBuilder := builder.(*array.FixedSizeBinaryBuilder)
+		width := binaryBuilder.Type().(*arrow.FixedSizeBinaryType).ByteWidth
+		data := fieldData.GetVectors().GetBinaryVector()
+		if field.GetDataType() == schemapb.DataType_Float16Vector {
+			data = fieldData.GetVectors().GetFloat16Vector()
+		}
+		for i := 0; i+width <= len(data); i += width {
+			binaryBuilder.Append(data[i : i+width])
+		}
+	default:
+		return merr.WrapErrParameterInvalidMsg("export doesn't support field %s of type %s", field.GetName(), field.GetDataType().String())
+	}
+	return nil
+}
+
+// Rows returns the number of rows not flushed.
+func (w *exportFileWriter) Rows() int {
+	return w.rows
+}
+
+// Flush encodes the rows appended as a parquet file.
+func (w *exportFileWriter) Flush() ([]byte, error) {
+	record := w.builder.NewRecord()
+	defer record.Release()
+	w.rows = 0
+
+	buf := new(bytes.Buffer)
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Zstd))
+	fw, err := pqarrow.NewFileWriter(w.schema, buf, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Write(record); err != nil {
+		return nil, err
+	}
+	if err := fw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *exportFileWriter) Release() {
+	w.builder.Release()
+}
+
+func (node *Proxy) initExportJobManager() {
+	node.exportManager = newExportJobManager(node.queryAt, globalMetaCache.GetCollectionSchema, node.getProfileChunkManager)
+}
+
+// getProfileChunkManager returns the chunk manager of the bucket profile, empty profile means the default bucket.
+func (node *Proxy) getProfileChunkManager(ctx context.Context, profile string) (storage.ChunkManager, error) {
+	cm, err := node.getChunkManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		return cm, nil
+	}
+	if rcm, ok := cm.(*storage.RoutingChunkManager); ok {
+		if profileCM, ok := rcm.Profile(profile); ok {
+			return profileCM, nil
+		}
+	}
+	return nil, merr.WrapErrParameterInvalidMsg("bucket profile %s not found", profile)
+}
+
+// queryAt runs the query at the snapshot of mvccTs, which is used by the background jobs.
+func (node *Proxy) queryAt(ctx context.Context, req *milvuspb.QueryRequest, mvccTs Timestamp) (*milvuspb.QueryResults, error) {
+	qt := &queryTask{
+		ctx:       ctx,
+		Condition: NewTaskCondition(ctx),
+		RetrieveRequest: &internalpb.RetrieveRequest{
+			Base: commonpbutil.NewMsgBase(
+				commonpbutil.WithMsgType(commonpb.MsgType_Retrieve),
+				commonpbutil.WithSourceID(paramtable.GetNodeID()),
+			),
+			ReqID: paramtable.GetNodeID(),
+		},
+		request:       req,
+		qc:            node.queryCoord,
+		lb:            node.lbPolicy,
+		mvccTimestamp: mvccTs,
+	}
+	return node.query(ctx, qt)
+}
+
+// ExportCollection submits a job to export the collection to object storage as parquet files.
+func (node *Proxy) ExportCollection(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return
+	}
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to export collection, %s"}`, err.Error())))
+		return
+	}
+	exportReq := &exportRequest{}
+	if err := json.NewDecoder(req.Body).Decode(exportReq); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to export collection, %s"}`, err.Error())))
+		return
+	}
+	ctx, err := mgrAuthenticate(req, exportReq.DbName)
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	// exporting is reading all the entities, so it requires the privilege of query
+	if _, err := PrivilegeInterceptor(ctx, &milvuspb.QueryRequest{
+		DbName:         exportReq.DbName,
+		CollectionName: exportReq.CollectionName,
+	}); err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	jobID, err := node.rowIDAllocator.AllocOne()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to export collection, %s"}`, err.Error())))
+		return
+	}
+	// the job outlives the http request
+	if err := node.exportManager.Submit(node.ctx, jobID, mgrCurUser(ctx), exportReq); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to export collection, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(fmt.Sprintf(`{"msg": "OK", "job_id": %d}`, jobID)))
+}
+
+// exportJobVisible returns whether the job is visible to the user of ctx, admins see all the jobs.
+func exportJobVisible(ctx context.Context, job *exportJob) (bool, error) {
+	isAdmin, err := mgrIsAdmin(ctx)
+	if err != nil {
+		return false, err
+	}
+	return isAdmin || job.Owner == mgrCurUser(ctx), nil
+}
+
+// GetExportState returns the state of the export job.
+func (node *Proxy) GetExportState(w http.ResponseWriter, req *http.Request) {
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	jobID, err := strconv.ParseInt(req.URL.Query().Get("job_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid job_id, %s"}`, err.Error())))
+		return
+	}
+	job, ok := node.exportManager.Get(jobID)
+	if ok {
+		visible, err := exportJobVisible(ctx, job)
+		if err != nil {
+			mgrWriteAuthError(w, err)
+			return
+		}
+		// don't leak the existence of the jobs of others
+		ok = visible
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "export job %d not found"}`, jobID)))
+		return
+	}
+	mgrWriteJSON(w, job)
+}
+
+// ListExportJobs lists the export jobs submitted to this proxy, which are visible to the user.
+func (node *Proxy) ListExportJobs(w http.ResponseWriter, req *http.Request) {
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	jobs := make([]*exportJob, 0)
+	for _, job := range node.exportManager.List() {
+		visible, err := exportJobVisible(ctx, job)
+		if err != nil {
+			mgrWriteAuthError(w, err)
+			return
+		}
+		if visible {
+			jobs = append(jobs, job)
+		}
+	}
+	mgrWriteJSON(w, jobs)
+}
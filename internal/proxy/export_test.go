@@ -0,0 +1,227 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func newExportTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "test_export",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: common.RowIDField, Name: common.RowIDFieldName, DataType: schemapb.DataType_Int64},
+			{FieldID: common.StartOfUserFieldID, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: common.StartOfUserFieldID + 1, Name: "name", DataType: schemapb.DataType_VarChar},
+			{
+				FieldID: common.StartOfUserFieldID + 2, Name: "vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+			},
+		},
+	}
+}
+
+func newExportTestFieldsData(pks []int64) []*schemapb.FieldData {
+	names := make([]string, 0, len(pks))
+	vectors := make([]float32, 0, len(pks)*2)
+	for _, pk := range pks {
+		names = append(names, "name")
+		vectors = append(vectors, float32(pk), float32(pk))
+	}
+	return []*schemapb.FieldData{
+		{
+			FieldName: "pk",
+			Type:      schemapb.DataType_Int64,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+			}},
+		},
+		{
+			FieldName: "name",
+			Type:      schemapb.DataType_VarChar,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: names}},
+			}},
+		},
+		{
+			FieldName: "vec",
+			Type:      schemapb.DataType_FloatVector,
+			Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+				Dim:  2,
+				Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: vectors}},
+			}},
+		},
+	}
+}
+
+func readExportFile(t *testing.T, data []byte) int64 {
+	reader, err := file.NewParquetReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	defer reader.Close()
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	table, err := fileReader.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer table.Release()
+	assert.EqualValues(t, 3, table.NumCols())
+	return table.NumRows()
+}
+
+func TestExportFileWriter(t *testing.T) {
+	schema := newExportTestSchema()
+	pkField := schema.GetFields()[1]
+	writer, err := newExportFileWriter(schema)
+	require.NoError(t, err)
+	defer writer.Release()
+
+	rows, maxPK, err := writer.Append(newExportTestFieldsData([]int64{3, 1, 2}), pkField)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rows)
+	assert.Equal(t, []int64{3}, maxPK.GetIntId().GetData())
+	assert.Equal(t, 3, writer.Rows())
+
+	data, err := writer.Flush()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, writer.Rows())
+	assert.EqualValues(t, 3, readExportFile(t, data))
+
+	_, _, err = writer.Append(newExportTestFieldsData([]int64{1})[:1], pkField)
+	assert.Error(t, err)
+
+	unsupported := &schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{
+		{FieldID: common.StartOfUserFieldID, Name: "arr", DataType: schemapb.DataType_Array},
+	}}
+	_, err = newExportFileWriter(unsupported)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestBuildExportExpr(t *testing.T) {
+	int64PK := &schemapb.FieldSchema{Name: "pk", DataType: schemapb.DataType_Int64}
+	varcharPK := &schemapb.FieldSchema{Name: "pk", DataType: schemapb.DataType_VarChar}
+
+	assert.Equal(t, "a > 1", buildExportExpr("a > 1", int64PK, nil))
+	assert.Equal(t, "pk > 10", buildExportExpr("", int64PK,
+		&schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{10}}}}))
+	assert.Equal(t, `(a > 1) and pk > "x\"y"`, buildExportExpr("a > 1", varcharPK,
+		&schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{`x"y`}}}}))
+}
+
+func TestExportJobManager(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.ExportBatchSize.Key, "2")
+	params.Save(params.ProxyCfg.ExportRowsPerFile.Key, "3")
+	defer params.Reset(params.ProxyCfg.ExportBatchSize.Key)
+	defer params.Reset(params.ProxyCfg.ExportRowsPerFile.Key)
+
+	ctx := context.Background()
+	rootPath := t.TempDir()
+	cm := storage.NewLocalChunkManager(storage.RootPath(rootPath))
+
+	pks := []int64{1, 2, 3, 4, 5}
+	exprs := make([]string, 0)
+	query := func(ctx context.Context, req *milvuspb.QueryRequest, mvccTs Timestamp) (*milvuspb.QueryResults, error) {
+		assert.EqualValues(t, 100, mvccTs)
+		exprs = append(exprs, req.GetExpr())
+		offset := 2 * (len(exprs) - 1)
+		end := offset + 2
+		if end > len(pks) {
+			end = len(pks)
+		}
+		return &milvuspb.QueryResults{
+			Status:     merr.Success(),
+			FieldsData: newExportTestFieldsData(pks[offset:end]),
+		}, nil
+	}
+	getSchema := func(ctx context.Context, dbName, collectionName string) (*schemapb.CollectionSchema, error) {
+		return newExportTestSchema(), nil
+	}
+	getCM := func(ctx context.Context, profile string) (storage.ChunkManager, error) {
+		if profile != "" {
+			return nil, merr.WrapErrParameterInvalidMsg("bucket profile %s not found", profile)
+		}
+		return cm, nil
+	}
+	manager := newExportJobManager(query, getSchema, getCM)
+
+	err := manager.Submit(ctx, 1, "", &exportRequest{CollectionName: "test_export"})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	err = manager.Submit(ctx, 1, "", &exportRequest{CollectionName: "test_export", Path: "/tmp"})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	dir := "daily"
+	require.NoError(t, manager.Submit(ctx, 1, "alice", &exportRequest{CollectionName: "test_export", Timestamp: 100, Path: dir}))
+	require.NoError(t, manager.Submit(ctx, 2, "bob", &exportRequest{CollectionName: "test_export", Profile: "eu", Path: dir}))
+
+	assert.Eventually(t, func() bool {
+		job1, _ := manager.Get(1)
+		job2, _ := manager.Get(2)
+		return job1.State == exportJobCompleted && job2.State == exportJobFailed
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []string{"", "pk > 2", "pk > 4"}, exprs)
+	job, ok := manager.Get(1)
+	assert.True(t, ok)
+	assert.EqualValues(t, 5, job.Rows)
+	assert.Equal(t, "alice", job.Owner)
+	assert.Len(t, job.Files, 2)
+	total := int64(0)
+	for _, filePath := range job.Files {
+		assert.True(t, strings.HasPrefix(filePath, path.Join(rootPath, exportPathPrefix, dir, "1")))
+		data, err := cm.Read(ctx, filePath)
+		require.NoError(t, err)
+		total += readExportFile(t, data)
+	}
+	assert.EqualValues(t, 5, total)
+
+	_, ok = manager.Get(3)
+	assert.False(t, ok)
+	assert.Len(t, manager.List(), 2)
+
+	// finished jobs are evicted after the retention
+	params.Save(params.ProxyCfg.ExportJobRetention.Key, "0")
+	defer params.Reset(params.ProxyCfg.ExportJobRetention.Key)
+	assert.Len(t, manager.List(), 0)
+}
+
+func TestValidateExportPath(t *testing.T) {
+	assert.NoError(t, validateExportPath("a"))
+	assert.NoError(t, validateExportPath("a/b..c/d"))
+	assert.ErrorIs(t, validateExportPath(""), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, validateExportPath("/a"), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, validateExportPath("a/../../b"), merr.ErrParameterInvalid)
+	assert.ErrorIs(t, validateExportPath("..\\b"), merr.ErrParameterInvalid)
+}
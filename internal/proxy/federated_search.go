@@ -0,0 +1,323 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metric"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// FederatedSearchRequest searches the collections sharing a compatible vector field as one search,
+// e.g. the collections per tenant.
+type FederatedSearchRequest struct {
+	DbName          string
+	CollectionNames []string
+	// Request is the template of the sub-searches, whose collection is replaced by each of CollectionNames.
+	// The metric type is required in its search params to rank the hits of the collections together.
+	Request *milvuspb.SearchRequest
+	// Authorize checks the privilege of the sub-search of each collection before anything of the collection is read,
+	// the collections not permitted are reported in the failures, nil means no authorization.
+	Authorize func(ctx context.Context, req *milvuspb.SearchRequest) error
+}
+
+// FederatedHit is a hit of the federated search, which is namespaced by its collection
+// since the primary keys of the collections may collide.
+type FederatedHit struct {
+	Collection string
+	// Offset is the offset of the hit in the results of its collection
+	Offset int
+	Score  float32
+}
+
+// FederatedSearchResults are the results of the federated search.
+type FederatedSearchResults struct {
+	NumQueries int64
+	// Hits are the hits of each query across the collections, the best first
+	Hits [][]*FederatedHit
+	// Results are the results of the collections searched successfully
+	Results map[string]*schemapb.SearchResultData
+	// Failures are the errors of the collections failed to search,
+	// which don't fail the federated search as long as any collection succeeds
+	Failures map[string]error
+}
+
+// SearchFunc searches a collection, e.g. Proxy.Search with the authorization.
+type SearchFunc func(ctx context.Context, req *milvuspb.SearchRequest) (*milvuspb.SearchResults, error)
+
+// FederatedSearch fans out the sub-searches to the collections in parallel,
+// and merges their hits by score, the collections failed are reported in the failures.
+func FederatedSearch(ctx context.Context, search SearchFunc, req *FederatedSearchRequest) (*FederatedSearchResults, error) {
+	log := log.Ctx(ctx).With(zap.String("db", req.DbName), zap.Strings("collections", req.CollectionNames))
+
+	if err := validateFederatedSearch(req); err != nil {
+		return nil, err
+	}
+	searchParams := req.Request.GetSearchParams()
+	metricType, err := funcutil.GetAttrByKeyFromRepeatedKV(common.MetricTypeKey, searchParams)
+	if err != nil || metricType == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("%s is required to rank the hits of the collections", common.MetricTypeKey)
+	}
+	queryInfo, offset, err := parseSearchInfo(searchParams)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
+	// the topk of query info includes the offset
+	topK := queryInfo.GetTopk() - offset
+
+	// the offset applies to the merged hits, so each collection returns the hits before the offset too
+	subParams := make([]*commonpb.KeyValuePair, 0, len(searchParams))
+	for _, kv := range searchParams {
+		if kv.GetKey() != TopKKey && kv.GetKey() != OffsetKey {
+			subParams = append(subParams, kv)
+		}
+	}
+	subParams = append(subParams,
+		&commonpb.KeyValuePair{Key: TopKKey, Value: strconv.FormatInt(topK+offset, 10)},
+		&commonpb.KeyValuePair{Key: OffsetKey, Value: "0"},
+	)
+
+	results := &FederatedSearchResults{
+		Results:  make(map[string]*schemapb.SearchResultData),
+		Failures: make(map[string]error),
+	}
+	subReqs := make(map[string]*milvuspb.SearchRequest, len(req.CollectionNames))
+	authorized := make([]string, 0, len(req.CollectionNames))
+	for _, name := range req.CollectionNames {
+		subReq := proto.Clone(req.Request).(*milvuspb.SearchRequest)
+		subReq.DbName = req.DbName
+		subReq.CollectionName = name
+		subReq.SearchParams = subParams
+		if req.Authorize != nil {
+			if err := req.Authorize(ctx, subReq); err != nil {
+				results.Failures[name] = err
+				continue
+			}
+		}
+		subReqs[name] = subReq
+		authorized = append(authorized, name)
+	}
+	// the schemas are checked among the collections permitted only, not to leak the others
+	for name, err := range checkFederatedSchemas(ctx, req.DbName, authorized, req.Request.GetSearchParams()) {
+		results.Failures[name] = err
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, name := range authorized {
+		if _, ok := results.Failures[name]; ok {
+			continue
+		}
+		wg.Add(1)
+		go func(name string, subReq *milvuspb.SearchRequest) {
+			defer wg.Done()
+			resp, err := search(ctx, subReq)
+			if err == nil {
+				err = merr.Error(resp.GetStatus())
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				results.Failures[name] = err
+				return
+			}
+			results.Results[name] = resp.GetResults()
+		}(name, subReqs[name])
+	}
+	wg.Wait()
+
+	if len(results.Results) == 0 {
+		// report the error of the first collection, the code of which tells the cause
+		name := req.CollectionNames[0]
+		return nil, errors.Wrapf(results.Failures[name], "federated search failed on all the %d collections, collection %s", len(req.CollectionNames), name)
+	}
+	for name, err := range results.Failures {
+		log.Warn("federated search failed on collection", zap.String("collection", name), zap.Error(err))
+	}
+
+	for _, data := range results.Results {
+		results.NumQueries = data.GetNumQueries()
+		break
+	}
+	results.Hits = mergeFederatedHits(req.CollectionNames, results.Results, results.NumQueries, topK, offset, metric.PositivelyRelated(metricType))
+	return results, nil
+}
+
+func validateFederatedSearch(req *FederatedSearchRequest) error {
+	if req.Request == nil {
+		return merr.WrapErrParameterInvalidMsg("search request is required")
+	}
+	if len(req.CollectionNames) == 0 {
+		return merr.WrapErrParameterInvalidMsg("collection names are required")
+	}
+	if maxCollections := Params.ProxyCfg.MaxFederatedCollections.GetAsInt(); len(req.CollectionNames) > maxCollections {
+		return merr.WrapErrParameterInvalidMsg("federated search supports at most %d collections, but got %d", maxCollections, len(req.CollectionNames))
+	}
+	names := typeutil.NewSet[string]()
+	for _, name := range req.CollectionNames {
+		if name == "" {
+			return merr.WrapErrParameterInvalidMsg("collection name should not be empty")
+		}
+		if names.Contain(name) {
+			return merr.WrapErrParameterInvalidMsg("duplicate collection %s", name)
+		}
+		names.Insert(name)
+	}
+	return nil
+}
+
+// checkFederatedSchemas checks the vector fields searched of the collections are compatible,
+// i.e. have the same data type and dim as the first collection found, returns the failures of the others.
+func checkFederatedSchemas(ctx context.Context, dbName string, collections []string, searchParams []*commonpb.KeyValuePair) map[string]error {
+	failures := make(map[string]error)
+	annsField, _ := funcutil.GetAttrByKeyFromRepeatedKV(AnnsFieldKey, searchParams)
+
+	var (
+		expect     *schemapb.FieldSchema
+		expectDim  int64
+		expectColl string
+	)
+	for _, name := range collections {
+		field, dim, err := getFederatedVectorField(ctx, dbName, name, annsField)
+		if err != nil {
+			failures[name] = err
+			continue
+		}
+		if expect == nil {
+			expect, expectDim, expectColl = field, dim, name
+			continue
+		}
+		if field.GetDataType() != expect.GetDataType() || dim != expectDim {
+			failures[name] = merr.WrapErrParameterInvalidMsg("vector field %s (%s, dim %d) is incompatible with %s of collection %s (%s, dim %d)",
+				field.GetName(), field.GetDataType().String(), dim, expect.GetName(), expectColl, expect.GetDataType().String(), expectDim)
+		}
+	}
+	return failures
+}
+
+func getFederatedVectorField(ctx context.Context, dbName, collectionName, annsField string) (*schemapb.FieldSchema, int64, error) {
+	schema, err := GetCachedCollectionSchema(ctx, dbName, collectionName)
+	if err != nil {
+		return nil, 0, err
+	}
+	var field *schemapb.FieldSchema
+	if annsField == "" {
+		field, err = typeutil.GetVectorFieldSchema(schema)
+		if err != nil {
+			return nil, 0, err
+		}
+	} else {
+		for _, f := range schema.GetFields() {
+			if f.GetName() == annsField {
+				field = f
+				break
+			}
+		}
+		if field == nil || !typeutil.IsVectorType(field.GetDataType()) {
+			return nil, 0, merr.WrapErrFieldNotFound(annsField, "vector field not found in collection "+collectionName)
+		}
+	}
+	dim, err := typeutil.GetDim(field)
+	if err != nil {
+		return nil, 0, err
+	}
+	return field, dim, nil
+}
+
+// mergeFederatedHits merges the hits of each query of the collections by score,
+// the ties are broken by the order of the collections requested.
+func mergeFederatedHits(collections []string, results map[string]*schemapb.SearchResultData, nq, topK, offset int64, positivelyRelated bool) [][]*FederatedHit {
+	better := func(a, b float32) bool {
+		if positivelyRelated {
+			return a > b
+		}
+		return a < b
+	}
+
+	type source struct {
+		name   string
+		data   *schemapb.SearchResultData
+		start  int64 // offset of the current query in the results
+		cursor int64
+	}
+	sources := make([]*source, 0, len(results))
+	for _, name := range collections {
+		if data, ok := results[name]; ok {
+			sources = append(sources, &source{name: name, data: data})
+		}
+	}
+
+	hits := make([][]*FederatedHit, 0, nq)
+	for i := int64(0); i < nq; i++ {
+		queryHits := make([]*FederatedHit, 0, topK)
+		for n := int64(0); n < topK+offset; n++ {
+			var best *source
+			for _, src := range sources {
+				if src.cursor >= federatedTopK(src.data, i) {
+					continue
+				}
+				if best == nil || better(src.data.GetScores()[src.start+src.cursor], best.data.GetScores()[best.start+best.cursor]) {
+					best = src
+				}
+			}
+			if best == nil {
+				break
+			}
+			if n >= offset {
+				idx := best.start + best.cursor
+				queryHits = append(queryHits, &FederatedHit{
+					Collection: best.name,
+					Offset:     int(idx),
+					Score:      best.data.GetScores()[idx],
+				})
+			}
+			best.cursor++
+		}
+		hits = append(hits, queryHits)
+
+		for _, src := range sources {
+			src.start += federatedTopK(src.data, i)
+			src.cursor = 0
+		}
+	}
+	return hits
+}
+
+// federatedTopK returns the number of hits of the i-th query in the results,
+// the empty results may have no topks.
+func federatedTopK(data *schemapb.SearchResultData, i int64) int64 {
+	if i >= int64(len(data.GetTopks())) {
+		return 0
+	}
+	return data.GetTopks()[i]
+}
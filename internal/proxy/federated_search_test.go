@@ -0,0 +1,161 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metric"
+)
+
+func federatedSchema(dim string) *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vector", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: dim}}},
+		},
+	}
+}
+
+func federatedResults(scores ...float32) *milvuspb.SearchResults {
+	ids := make([]int64, len(scores))
+	for i := range scores {
+		ids[i] = int64(i)
+	}
+	return &milvuspb.SearchResults{
+		Status: merr.Success(),
+		Results: &schemapb.SearchResultData{
+			NumQueries: 1,
+			TopK:       int64(len(scores)),
+			Topks:      []int64{int64(len(scores))},
+			Scores:     scores,
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+		},
+	}
+}
+
+func TestFederatedSearch(t *testing.T) {
+	cache := globalMetaCache
+	defer func() { globalMetaCache = cache }()
+	mockCache := NewMockCache(t)
+	globalMetaCache = mockCache
+
+	mockCache.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything, "tenant1").Return(federatedSchema("8"), nil).Maybe()
+	mockCache.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything, "tenant2").Return(federatedSchema("8"), nil).Maybe()
+	mockCache.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything, "tenant3").Return(federatedSchema("8"), nil).Maybe()
+	mockCache.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything, "incompatible").Return(federatedSchema("16"), nil).Maybe()
+
+	search := func(ctx context.Context, req *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
+		switch req.GetCollectionName() {
+		case "tenant1":
+			return federatedResults(0.9, 0.5, 0.1), nil
+		case "tenant2":
+			return federatedResults(0.8, 0.7), nil
+		default:
+			return nil, merr.WrapErrServiceUnavailable("mock")
+		}
+	}
+	newRequest := func(metricType string, collections ...string) *FederatedSearchRequest {
+		return &FederatedSearchRequest{
+			DbName:          "default",
+			CollectionNames: collections,
+			Request: &milvuspb.SearchRequest{
+				SearchParams: []*commonpb.KeyValuePair{
+					{Key: TopKKey, Value: "3"},
+					{Key: OffsetKey, Value: "1"},
+					{Key: common.MetricTypeKey, Value: metricType},
+				},
+			},
+		}
+	}
+
+	t.Run("merge", func(t *testing.T) {
+		results, err := FederatedSearch(context.Background(), search, newRequest(metric.IP, "tenant1", "tenant2", "tenant3", "incompatible"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), results.NumQueries)
+		assert.Len(t, results.Results, 2)
+		assert.Len(t, results.Failures, 2)
+		assert.ErrorIs(t, results.Failures["tenant3"], merr.ErrServiceUnavailable)
+		assert.ErrorIs(t, results.Failures["incompatible"], merr.ErrParameterInvalid)
+
+		// 0.9 is skipped by the offset
+		assert.Equal(t, []*FederatedHit{
+			{Collection: "tenant2", Offset: 0, Score: 0.8},
+			{Collection: "tenant2", Offset: 1, Score: 0.7},
+			{Collection: "tenant1", Offset: 1, Score: 0.5},
+		}, results.Hits[0])
+	})
+
+	t.Run("authorize", func(t *testing.T) {
+		req := newRequest(metric.IP, "tenant1", "tenant2", "incompatible")
+		req.Authorize = func(ctx context.Context, req *milvuspb.SearchRequest) error {
+			if req.GetCollectionName() == "tenant1" {
+				return nil
+			}
+			return merr.WrapErrPrivilegeNotPermitted("mock")
+		}
+		results, err := FederatedSearch(context.Background(), search, req)
+		assert.NoError(t, err)
+		assert.Len(t, results.Results, 1)
+		// the schemas of the collections not permitted are never compared
+		assert.ErrorIs(t, results.Failures["tenant2"], merr.ErrPrivilegeNotPermitted)
+		assert.ErrorIs(t, results.Failures["incompatible"], merr.ErrPrivilegeNotPermitted)
+
+		req = newRequest(metric.IP, "tenant2")
+		req.Authorize = func(ctx context.Context, req *milvuspb.SearchRequest) error {
+			return merr.WrapErrPrivilegeNotPermitted("mock")
+		}
+		_, err = FederatedSearch(context.Background(), search, req)
+		assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+	})
+
+	t.Run("all failed", func(t *testing.T) {
+		_, err := FederatedSearch(context.Background(), search, newRequest(metric.IP, "tenant3"))
+		assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := FederatedSearch(context.Background(), search, newRequest(""))
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+		_, err = FederatedSearch(context.Background(), search, newRequest(metric.IP, "tenant1", "tenant1"))
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+		_, err = FederatedSearch(context.Background(), search, newRequest("", "tenant1"))
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+}
+
+func TestMergeFederatedHits(t *testing.T) {
+	results := map[string]*schemapb.SearchResultData{
+		"a": {Topks: []int64{2, 1}, Scores: []float32{0.1, 0.4, 0.2}},
+		"b": {Topks: []int64{1, 2}, Scores: []float32{0.1, 0.3, 0.5}},
+		"c": {},
+	}
+	hits := mergeFederatedHits([]string{"a", "b", "c"}, results, 2, 2, 0, false)
+	assert.Equal(t, [][]*FederatedHit{
+		{{Collection: "a", Offset: 0, Score: 0.1}, {Collection: "b", Offset: 0, Score: 0.1}},
+		{{Collection: "a", Offset: 2, Score: 0.2}, {Collection: "b", Offset: 1, Score: 0.3}},
+	}, hits)
+}
@@ -0,0 +1,93 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/util/healthcheck"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// initHealthChecker registers the active probes of the dependencies and the components.
+func (node *Proxy) initHealthChecker() {
+	params := paramtable.Get()
+	nodeID := paramtable.GetNodeID()
+	checker := healthcheck.NewChecker(probeThreshold(&params.ProxyCfg.HealthProbe.Timeout))
+	if node.etcdCli != nil {
+		key := path.Join(params.EtcdCfg.MetaRootPath.GetValue(), "health-probe", fmt.Sprint(nodeID))
+		checker.Register(healthcheck.ProbeEtcd, healthcheck.EtcdProbe(node.etcdCli, key, probeThreshold(&params.ProxyCfg.HealthProbe.EtcdLatencyThreshold)))
+		if node.session != nil {
+			checker.Register(healthcheck.ProbeHeartbeat, healthcheck.HeartbeatProbe(node.session, node.etcdCli, probeThreshold(&params.ProxyCfg.HealthProbe.HeartbeatStaleThreshold)))
+		}
+	}
+	if node.factory != nil {
+		node.registerDependencyProbes(checker, "diagnose")
+	}
+	if node.chTicker != nil {
+		checker.Register(healthcheck.ProbeTimeTick, healthcheck.TimeTickProbe(func() (map[string]uint64, error) {
+			stats, _, err := node.chTicker.getMinTsStatistics()
+			return stats, err
+		}, probeThreshold(&params.ProxyCfg.HealthProbe.TimeTickLagThreshold)))
+	}
+	node.healthChecker = checker
+}
+
+// startHealthChecker runs the probes in background if they are enabled in CheckHealth,
+// so CheckHealth uses the cached report rather than probing the dependencies on every call.
+func (node *Proxy) startHealthChecker() {
+	if node.healthChecker == nil {
+		return
+	}
+	params := paramtable.Get()
+	node.healthChecker.Start(probeThreshold(&params.ProxyCfg.HealthProbe.Interval), params.ProxyCfg.HealthProbe.Enable.GetAsBool)
+}
+
+// registerDependencyProbes registers the probes of the message queue and the object storage,
+// the purpose distinguishes the subscriptions of the checkers running concurrently.
+func (node *Proxy) registerDependencyProbes(checker *healthcheck.Checker, purpose string) {
+	params := paramtable.Get()
+	nodeID := paramtable.GetNodeID()
+	channel := fmt.Sprintf("%s-health-probe", params.CommonCfg.ClusterPrefix.GetValue())
+	subName := fmt.Sprintf("%s-%s-%d", channel, purpose, nodeID)
+	checker.Register(healthcheck.ProbeMQ, healthcheck.MQProbe(node.factory, channel, subName, nodeID, probeThreshold(&params.ProxyCfg.HealthProbe.MQLatencyThreshold)))
+	checker.Register(healthcheck.ProbeStorage, healthcheck.StorageProbe(node.getChunkManager, path.Join("health-probe", purpose, fmt.Sprint(nodeID)), probeThreshold(&params.ProxyCfg.HealthProbe.StorageLatencyThreshold)))
+}
+
+func probeThreshold(item *paramtable.ParamItem) func() time.Duration {
+	return func() time.Duration {
+		return item.GetAsDuration(time.Millisecond)
+	}
+}
+
+// DiagnoseHealth runs the active probes and returns the diagnoses.
+func (node *Proxy) DiagnoseHealth(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only GET method is allowed"}`))
+		return
+	}
+	if node.healthChecker == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"msg": "proxy is not initialized"}`))
+		return
+	}
+	mgrWriteJSON(w, node.healthChecker.Check(req.Context()))
+}
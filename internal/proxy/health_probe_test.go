@@ -0,0 +1,57 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/util/healthcheck"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestProxy_DiagnoseHealth(t *testing.T) {
+	paramtable.Init()
+	node := &Proxy{}
+
+	w := httptest.NewRecorder()
+	node.DiagnoseHealth(w, httptest.NewRequest(http.MethodGet, mgrRouteHealthDiagnose, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	node.initHealthChecker()
+	node.healthChecker.Register("mock", func(ctx context.Context) []*healthcheck.Diagnosis {
+		return []*healthcheck.Diagnosis{{Probe: "mock", Severity: healthcheck.SeverityCritical, Code: healthcheck.CodeMQSlow}}
+	})
+	w = httptest.NewRecorder()
+	node.DiagnoseHealth(w, httptest.NewRequest(http.MethodGet, mgrRouteHealthDiagnose, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	report := &healthcheck.Report{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), report))
+	assert.False(t, report.Healthy)
+	assert.Equal(t, 1, len(report.Diagnoses))
+	assert.WithinDuration(t, time.Now(), report.Time, time.Minute)
+
+	w = httptest.NewRecorder()
+	node.DiagnoseHealth(w, httptest.NewRequest(http.MethodPost, mgrRouteHealthDiagnose, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/internal/util/healthcheck"
 	"github.com/milvus-io/milvus/internal/util/importutil"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -126,6 +127,7 @@ func (node *Proxy) InvalidateCollectionMetaCache(ctx context.Context, request *p
 	if request.GetBase().GetMsgType() == commonpb.MsgType_DropCollection {
 		// no need to handle error, since this Proxy may not create dml stream for the collection.
 		node.chMgr.removeDMLStream(request.GetCollectionID())
+		removeDedupCache(request.GetCollectionID())
 		// clean up collection level metrics
 		metrics.CleanupCollectionMetrics(paramtable.GetNodeID(), collectionName)
 		for _, alias := range aliasName {
@@ -1014,6 +1016,7 @@ func (node *Proxy) AlterCollection(ctx context.Context, request *milvuspb.AlterC
 		Condition:              NewTaskCondition(ctx),
 		AlterCollectionRequest: request,
 		rootCoord:              node.rootCoord,
+		dataCoord:              node.dataCoord,
 	}
 
 	log := log.Ctx(ctx).With(
@@ -2191,7 +2194,34 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Insert")
 	defer sp.End()
 
-	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+	if err := node.checkWritable(); err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
+	fieldsData, numRows, err := decodeInsertPayload(ctx, request.GetDbName(), request.GetCollectionName(), request.GetFieldsData(), request.GetNumRows())
+	if err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
+	request.FieldsData, request.NumRows = fieldsData, numRows
+	if txnID, ok, err := getTxnIDFromContext(ctx); ok {
+		if err == nil {
+			err = node.txnManager.addInsert(txnID, mgrCurUser(ctx), request)
+		}
+		if err != nil {
+			return &milvuspb.MutationResult{
+				Status: merr.Status(err),
+			}, nil
+		}
+		// the inserts are written when the transaction is committed
+		return &milvuspb.MutationResult{
+			Status:    merr.Success(),
+			InsertCnt: int64(request.GetNumRows()),
+		}, nil
+	}
+	if err := checkCollectionFrozen(ctx, request.GetDbName(), request.GetCollectionName(), true); err != nil {
 		return &milvuspb.MutationResult{
 			Status: merr.Status(err),
 		}, nil
@@ -2207,11 +2237,28 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 	)
 	method := "Insert"
 	tr := timerecord.NewTimeRecorder(method)
+	ctx, slowTrace := withSlowLogTrace(ctx)
+	defer func() {
+		if slowTrace != nil {
+			node.slowLogger.observe(&SlowLogEntry{
+				Type:       slowLogInsert,
+				Database:   request.GetDbName(),
+				Collection: request.GetCollectionName(),
+				NumRows:    int64(request.GetNumRows()),
+			}, slowTrace, tr.ElapseSpan())
+		}
+	}()
 	metrics.ProxyReceiveBytes.WithLabelValues(
 		strconv.FormatInt(paramtable.GetNodeID(), 10),
-		metrics.InsertLabel, request.GetCollectionName()).Add(float64(proto.Size(request)))
+		metrics.InsertLabel, metrics.CollectionNameLabel(request.GetCollectionName())).Add(float64(proto.Size(request)))
 	metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method, metrics.TotalLabel).Inc()
 
+	partial, err := isPartialMutation(ctx)
+	if err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
 	it := &insertTask{
 		ctx:       ctx,
 		Condition: NewTaskCondition(ctx),
@@ -2236,6 +2283,8 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 		segIDAssigner: node.segAssigner,
 		chMgr:         node.chMgr,
 		chTicker:      node.chTicker,
+		batcher:       node.mutationBatcher,
+		partial:       partial,
 	}
 
 	constructFailedResponse := func(err error) *milvuspb.MutationResult {
@@ -2245,10 +2294,13 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 			errIndex[i] = i
 		}
 
-		return &milvuspb.MutationResult{
+		result := &milvuspb.MutationResult{
 			Status:   merr.Status(err),
 			ErrIndex: errIndex,
 		}
+		// nothing is inserted, the row errors tell which rows to fix
+		setRowErrors(result, nil, it.rowErrors)
+		return result
 	}
 
 	log.Debug("Enqueue insert request in Proxy")
@@ -2285,6 +2337,14 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 
 	// InsertCnt always equals to the number of entities in the request
 	it.result.InsertCnt = int64(request.NumRows)
+	if it.rowIndexes != nil {
+		// except the invalid rows skipped
+		it.result.InsertCnt = int64(len(it.rowIndexes))
+	}
+	setRowErrors(it.result, it.rowIndexes, it.rowErrors)
+	if it.result.GetStatus().GetErrorCode() == commonpb.ErrorCode_Success {
+		getSessionRouter().recordWrite(ctx, it.insertMsg.GetCollectionID(), it.result.GetTimestamp())
+	}
 
 	rateCol.Add(internalpb.RateType_DMLInsert.String(), float64(it.insertMsg.Size()))
 
@@ -2293,7 +2353,7 @@ func (node *Proxy) Insert(ctx context.Context, request *milvuspb.InsertRequest)
 	successCnt := it.result.InsertCnt - int64(len(it.result.ErrIndex))
 	metrics.ProxyInsertVectors.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10)).Add(float64(successCnt))
 	metrics.ProxyMutationLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.InsertLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
-	metrics.ProxyCollectionMutationLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.InsertLabel, request.CollectionName).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	metrics.ProxyCollectionMutationLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.InsertLabel, metrics.CollectionNameLabel(request.GetCollectionName())).Observe(float64(tr.ElapseSpan().Milliseconds()))
 	return it.result, nil
 }
 
@@ -2313,9 +2373,28 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 
 	metrics.ProxyReceiveBytes.WithLabelValues(
 		strconv.FormatInt(paramtable.GetNodeID(), 10),
-		metrics.DeleteLabel, request.GetCollectionName()).Add(float64(proto.Size(request)))
+		metrics.DeleteLabel, metrics.CollectionNameLabel(request.GetCollectionName())).Add(float64(proto.Size(request)))
 
-	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+	if err := node.checkWritable(); err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
+	if txnID, ok, err := getTxnIDFromContext(ctx); ok {
+		if err == nil {
+			err = node.txnManager.addDelete(txnID, mgrCurUser(ctx), request)
+		}
+		if err != nil {
+			return &milvuspb.MutationResult{
+				Status: merr.Status(err),
+			}, nil
+		}
+		// the deletes are written when the transaction is committed
+		return &milvuspb.MutationResult{
+			Status: merr.Success(),
+		}, nil
+	}
+	if err := checkCollectionFrozen(ctx, request.GetDbName(), request.GetCollectionName(), true); err != nil {
 		return &milvuspb.MutationResult{
 			Status: merr.Status(err),
 		}, nil
@@ -2334,6 +2413,7 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 		chMgr:       node.chMgr,
 		chTicker:    node.chTicker,
 		lb:          node.lbPolicy,
+		jobManager:  node.deleteManager,
 	}
 
 	log.Debug("Enqueue delete request in Proxy")
@@ -2360,13 +2440,14 @@ func (node *Proxy) Delete(ctx context.Context, request *milvuspb.DeleteRequest)
 		}, nil
 	}
 
+	getSessionRouter().recordWrite(ctx, dt.collectionID, dt.result.GetTimestamp())
 	receiveSize := proto.Size(dt.req)
 	rateCol.Add(internalpb.RateType_DMLDelete.String(), float64(receiveSize))
 
 	metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method,
 		metrics.SuccessLabel).Inc()
 	metrics.ProxyMutationLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.DeleteLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
-	metrics.ProxyCollectionMutationLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.DeleteLabel, request.CollectionName).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	metrics.ProxyCollectionMutationLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.DeleteLabel, metrics.CollectionNameLabel(request.GetCollectionName())).Observe(float64(tr.ElapseSpan().Milliseconds()))
 	return dt.result, nil
 }
 
@@ -2384,17 +2465,34 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 	)
 	log.Debug("Start processing upsert request in Proxy")
 
-	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+	if err := node.checkWritable(); err != nil {
 		return &milvuspb.MutationResult{
 			Status: merr.Status(err),
 		}, nil
 	}
+	if _, ok, _ := getTxnIDFromContext(ctx); ok {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("upsert is not supported in transaction, use delete and insert instead")),
+		}, nil
+	}
+	if err := checkCollectionFrozen(ctx, request.GetDbName(), request.GetCollectionName(), true); err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
+	fieldsData, numRows, err := decodeInsertPayload(ctx, request.GetDbName(), request.GetCollectionName(), request.GetFieldsData(), request.GetNumRows())
+	if err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
+	request.FieldsData, request.NumRows = fieldsData, numRows
 	method := "Upsert"
 	tr := timerecord.NewTimeRecorder(method)
 
 	metrics.ProxyReceiveBytes.WithLabelValues(
 		strconv.FormatInt(paramtable.GetNodeID(), 10),
-		metrics.UpsertLabel, request.GetCollectionName()).Add(float64(proto.Size(request)))
+		metrics.UpsertLabel, metrics.CollectionNameLabel(request.GetCollectionName())).Add(float64(proto.Size(request)))
 	metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method, metrics.TotalLabel).Inc()
 
 	request.Base = commonpbutil.NewMsgBase(
@@ -2402,6 +2500,12 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 		commonpbutil.WithSourceID(paramtable.GetNodeID()),
 	)
 
+	partial, err := isPartialMutation(ctx)
+	if err != nil {
+		return &milvuspb.MutationResult{
+			Status: merr.Status(err),
+		}, nil
+	}
 	it := &upsertTask{
 		baseMsg: msgstream.BaseMsg{
 			HashValues: request.HashKeys,
@@ -2420,6 +2524,7 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 		segIDAssigner: node.segAssigner,
 		chMgr:         node.chMgr,
 		chTicker:      node.chTicker,
+		partial:       partial,
 	}
 
 	log.Debug("Enqueue upsert request in Proxy",
@@ -2457,10 +2562,13 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 			errIndex[i] = i
 		}
 
-		return &milvuspb.MutationResult{
+		result := &milvuspb.MutationResult{
 			Status:   merr.Status(err),
 			ErrIndex: errIndex,
-		}, nil
+		}
+		// nothing is upserted, the row errors tell which rows to fix
+		setRowErrors(result, nil, it.rowErrors)
+		return result, nil
 	}
 
 	if it.result.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
@@ -2473,6 +2581,9 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 			it.result.ErrIndex = errIndex
 		}
 		setErrorIndex()
+	} else {
+		setRowErrors(it.result, it.rowIndexes, it.rowErrors)
+		getSessionRouter().recordWrite(ctx, it.collectionID, it.result.GetTimestamp())
 	}
 
 	rateCol.Add(internalpb.RateType_DMLUpsert.String(), float64(it.upsertMsg.DeleteMsg.Size()+it.upsertMsg.DeleteMsg.Size()))
@@ -2480,7 +2591,7 @@ func (node *Proxy) Upsert(ctx context.Context, request *milvuspb.UpsertRequest)
 	metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method,
 		metrics.SuccessLabel).Inc()
 	metrics.ProxyMutationLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.UpsertLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
-	metrics.ProxyCollectionMutationLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.UpsertLabel, request.CollectionName).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	metrics.ProxyCollectionMutationLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.UpsertLabel, metrics.CollectionNameLabel(request.GetCollectionName())).Observe(float64(tr.ElapseSpan().Milliseconds()))
 
 	log.Debug("Finish processing upsert request in Proxy")
 	return it.result, nil
@@ -2492,13 +2603,13 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 	metrics.ProxyReceiveBytes.WithLabelValues(
 		strconv.FormatInt(paramtable.GetNodeID(), 10),
 		metrics.SearchLabel,
-		request.GetCollectionName(),
+		metrics.CollectionNameLabel(request.GetCollectionName()),
 	).Add(float64(receiveSize))
 
 	metrics.ProxyReceivedNQ.WithLabelValues(
 		strconv.FormatInt(paramtable.GetNodeID(), 10),
 		metrics.SearchLabel,
-		request.GetCollectionName(),
+		metrics.CollectionNameLabel(request.GetCollectionName()),
 	).Add(float64(request.GetNq()))
 
 	rateCol.Add(internalpb.RateType_DQLSearch.String(), float64(request.GetNq()))
@@ -2508,6 +2619,11 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 			Status: merr.Status(err),
 		}, nil
 	}
+	if err := checkCollectionFrozen(ctx, request.GetDbName(), request.GetCollectionName(), false); err != nil {
+		return &milvuspb.SearchResults{
+			Status: merr.Status(err),
+		}, nil
+	}
 
 	method := "Search"
 	tr := timerecord.NewTimeRecorder(method)
@@ -2519,6 +2635,14 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Search")
 	defer sp.End()
+	ctx, slowTrace := withSlowLogTrace(ctx)
+	ctx, cancel, err := withQueryLimits(ctx, request.GetSearchParams())
+	if err != nil {
+		return &milvuspb.SearchResults{
+			Status: merr.Status(err),
+		}, nil
+	}
+	defer cancel()
 
 	if request.SearchByPrimaryKeys {
 		placeholderGroupBytes, err := node.getVectorPlaceholderGroupForSearchByPks(ctx, request)
@@ -2541,11 +2665,12 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 			),
 			ReqID: paramtable.GetNodeID(),
 		},
-		request: request,
-		tr:      timerecord.NewTimeRecorder("search"),
-		qc:      node.queryCoord,
-		node:    node,
-		lb:      node.lbPolicy,
+		request:         request,
+		tr:              timerecord.NewTimeRecorder("search"),
+		qc:              node.queryCoord,
+		node:            node,
+		lb:              node.lbPolicy,
+		vectorURLWriter: node.vectorURLWriter,
 	}
 
 	guaranteeTs := request.GuaranteeTimestamp
@@ -2567,6 +2692,15 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 		if span >= SlowReadSpan {
 			log.Info(rpcSlow(method), zap.Int64("nq", qt.SearchRequest.GetNq()), zap.Duration("duration", span))
 		}
+		if slowTrace != nil {
+			node.slowLogger.observe(&SlowLogEntry{
+				Type:       slowLogSearch,
+				Database:   request.GetDbName(),
+				Collection: request.GetCollectionName(),
+				NQ:         request.GetNq(),
+				Expr:       request.GetDsl(),
+			}, slowTrace, span)
+		}
 	}()
 
 	log.Debug(rpcReceived(method))
@@ -2638,7 +2772,7 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 	metrics.ProxyCollectionSQLatency.WithLabelValues(
 		strconv.FormatInt(paramtable.GetNodeID(), 10),
 		metrics.SearchLabel,
-		request.CollectionName,
+		metrics.CollectionNameLabel(request.GetCollectionName()),
 	).Observe(float64(searchDur))
 
 	if qt.result != nil {
@@ -2778,17 +2912,18 @@ func (node *Proxy) Flush(ctx context.Context, request *milvuspb.FlushRequest) (*
 // Query get the records by primary keys.
 func (node *Proxy) query(ctx context.Context, qt *queryTask) (*milvuspb.QueryResults, error) {
 	request := qt.request
+	qt.vectorURLWriter = node.vectorURLWriter
 	receiveSize := proto.Size(request)
 	metrics.ProxyReceiveBytes.WithLabelValues(
 		strconv.FormatInt(paramtable.GetNodeID(), 10),
 		metrics.QueryLabel,
-		request.GetCollectionName(),
+		metrics.CollectionNameLabel(request.GetCollectionName()),
 	).Add(float64(receiveSize))
 
 	metrics.ProxyReceivedNQ.WithLabelValues(
 		strconv.FormatInt(paramtable.GetNodeID(), 10),
 		metrics.SearchLabel,
-		request.GetCollectionName(),
+		metrics.CollectionNameLabel(request.GetCollectionName()),
 	).Add(float64(1))
 
 	rateCol.Add(internalpb.RateType_DQLQuery.String(), 1)
@@ -2798,10 +2933,17 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask) (*milvuspb.QueryRes
 			Status: merr.Status(err),
 		}, nil
 	}
+	if err := checkCollectionFrozen(ctx, request.GetDbName(), request.GetCollectionName(), false); err != nil {
+		return &milvuspb.QueryResults{
+			Status: merr.Status(err),
+		}, nil
+	}
 
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Query")
 	defer sp.End()
 	tr := timerecord.NewTimeRecorder("Query")
+	var slowTrace *slowLogTrace
+	qt.ctx, slowTrace = withSlowLogTrace(qt.ctx)
 
 	method := "Query"
 
@@ -2829,6 +2971,14 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask) (*milvuspb.QueryRes
 				zap.Uint64("guarantee_timestamp", request.GuaranteeTimestamp),
 				zap.Duration("duration", span))
 		}
+		if slowTrace != nil {
+			node.slowLogger.observe(&SlowLogEntry{
+				Type:       slowLogQuery,
+				Database:   request.GetDbName(),
+				Collection: request.GetCollectionName(),
+				Expr:       request.GetExpr(),
+			}, slowTrace, span)
+		}
 	}()
 
 	log.Debug(
@@ -2893,7 +3043,7 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask) (*milvuspb.QueryRes
 	metrics.ProxyCollectionSQLatency.WithLabelValues(
 		strconv.FormatInt(paramtable.GetNodeID(), 10),
 		metrics.QueryLabel,
-		request.CollectionName,
+		metrics.CollectionNameLabel(request.GetCollectionName()),
 	).Observe(float64(tr.ElapseSpan().Milliseconds()))
 
 	sentSize := proto.Size(qt.result)
@@ -2905,6 +3055,14 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask) (*milvuspb.QueryRes
 
 // Query get the records by primary keys.
 func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+	ctx, cancel, err := withQueryLimits(ctx, request.GetQueryParams())
+	if err != nil {
+		return &milvuspb.QueryResults{
+			Status: merr.Status(err),
+		}, nil
+	}
+	defer cancel()
+
 	qt := &queryTask{
 		ctx:       ctx,
 		Condition: NewTaskCondition(ctx),
@@ -3850,7 +4008,11 @@ func (node *Proxy) Import(ctx context.Context, req *milvuspb.ImportRequest) (*mi
 	resp := &milvuspb.ImportResponse{
 		Status: merr.Success(),
 	}
-	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+	if err := node.checkWritable(); err != nil {
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+	if err := checkCollectionFrozen(ctx, req.GetDbName(), req.GetCollectionName(), true); err != nil {
 		resp.Status = merr.Status(err)
 		return resp, nil
 	}
@@ -4531,6 +4693,19 @@ func (node *Proxy) CheckHealth(ctx context.Context, request *milvuspb.CheckHealt
 		return fn("datacoord", resp, err)
 	})
 
+	// the probes run in background, the latest report is used
+	if node.healthChecker != nil && Params.ProxyCfg.HealthProbe.Enable.GetAsBool() {
+		if report := node.healthChecker.Latest(); report != nil {
+			mu.Lock()
+			for _, d := range report.Problems() {
+				if d.Severity == healthcheck.SeverityCritical {
+					errReasons = append(errReasons, d.String())
+				}
+			}
+			mu.Unlock()
+		}
+	}
+
 	err := group.Wait()
 	if err != nil || len(errReasons) != 0 {
 		return &milvuspb.CheckHealthResponse{
@@ -4541,6 +4716,12 @@ func (node *Proxy) CheckHealth(ctx context.Context, request *milvuspb.CheckHealt
 	}
 
 	states, reasons := node.multiRateLimiter.GetQuotaStates()
+	if node.degradedMonitor != nil {
+		if reason, readOnly := node.degradedMonitor.readOnlyReason(); readOnly && !lo.Contains(states, milvuspb.QuotaState_DenyToWrite) {
+			states = append(states, milvuspb.QuotaState_DenyToWrite)
+			reasons = append(reasons, merr.WrapErrServiceReadOnly(reason).Error())
+		}
+	}
 	return &milvuspb.CheckHealthResponse{
 		Status:      merr.Success(),
 		QuotaStates: states,
@@ -5024,7 +5205,8 @@ func (node *Proxy) Connect(ctx context.Context, request *milvuspb.ConnectRequest
 		Reserved:   make(map[string]string),
 	}
 
-	connection.GetManager().Register(ctx, int64(ts), request.GetClientInfo())
+	clientInfo := negotiateInsertPayloadFormat(request.GetClientInfo(), serverInfo)
+	connection.GetManager().Register(ctx, int64(ts), clientInfo)
 
 	return &milvuspb.ConnectResponse{
 		Status:     merr.Success(),
@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+)
+
+// the introspection routes are read-only, they return the meta of coordinators through GetMetrics,
+// so that operators don't need to read etcd directly with external tools.
+
+type introspectionFunc func(ctx context.Context, req *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error)
+
+// introspect returns the handler of the introspection route,
+// the query params listed are passed to the coordinator as the params of request.
+func introspect(metricType string, getMetrics introspectionFunc, queryParams ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"msg": "only GET method is allowed"}`))
+			return
+		}
+
+		params := make(map[string]any)
+		for _, key := range queryParams {
+			v := req.URL.Query().Get(key)
+			if v == "" {
+				continue
+			}
+			if key == metricsinfo.MetricCollectionIDKey || key == metricsinfo.MetricSegmentIDKey ||
+				key == metricsinfo.MetricStartTimeKey || key == metricsinfo.MetricEndTimeKey {
+				id, err := strconv.ParseInt(v, 10, 64)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(fmt.Sprintf(`{"msg": "invalid %s"}`, key)))
+					return
+				}
+				params[key] = id
+				continue
+			}
+			params[key] = v
+		}
+
+		request, err := metricsinfo.ConstructRequestWithParams(metricType, params)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+			return
+		}
+		resp, err := getMetrics(req.Context(), request)
+		if err := merr.CheckRPCCall(resp, err); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get %s, %s"}`, metricType, err.Error())))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(resp.GetResponse()))
+	}
+}
+
+// ListChannelCheckpoints returns the checkpoints of the virtual channels in datacoord.
+func (node *Proxy) ListChannelCheckpoints(w http.ResponseWriter, req *http.Request) {
+	introspect(metricsinfo.ChannelCheckpointMetrics, node.dataCoord.GetMetrics)(w, req)
+}
+
+// ListSegmentMeta returns the segment meta in datacoord, filtered by collection_id and state, e.g. Flushed.
+func (node *Proxy) ListSegmentMeta(w http.ResponseWriter, req *http.Request) {
+	introspect(metricsinfo.SegmentMetaMetrics, node.dataCoord.GetMetrics,
+		metricsinfo.MetricCollectionIDKey, metricsinfo.MetricSegmentStateKey)(w, req)
+}
+
+// GetSegmentFieldStats returns the statistics of scalar fields of the flushed segment specified by segment_id.
+func (node *Proxy) GetSegmentFieldStats(w http.ResponseWriter, req *http.Request) {
+	introspect(metricsinfo.SegmentFieldStatsMetrics, node.dataCoord.GetMetrics, metricsinfo.MetricSegmentIDKey)(w, req)
+}
+
+// ListSmallSegments returns the small sealed segments of collections in datacoord, filtered by collection_id.
+func (node *Proxy) ListSmallSegments(w http.ResponseWriter, req *http.Request) {
+	introspect(metricsinfo.SmallSegmentMetrics, node.dataCoord.GetMetrics, metricsinfo.MetricCollectionIDKey)(w, req)
+}
+
+// ListTargetDistributions returns the targets versus the distributions of collections in querycoord.
+func (node *Proxy) ListTargetDistributions(w http.ResponseWriter, req *http.Request) {
+	introspect(metricsinfo.TargetDistributionMetrics, node.queryCoord.GetMetrics, metricsinfo.MetricCollectionIDKey)(w, req)
+}
+
+// ListReplicaAssignments returns the replicas and the querynodes assigned to them.
+func (node *Proxy) ListReplicaAssignments(w http.ResponseWriter, req *http.Request) {
+	introspect(metricsinfo.ReplicaAssignmentMetrics, node.queryCoord.GetMetrics, metricsinfo.MetricCollectionIDKey)(w, req)
+}
+
+// ListResourceGroupUsages returns the nodes, collections and utilization of the resource groups,
+// filtered by resource_group.
+func (node *Proxy) ListResourceGroupUsages(w http.ResponseWriter, req *http.Request) {
+	introspect(metricsinfo.ResourceGroupMetrics, node.queryCoord.GetMetrics, metricsinfo.MetricResourceGroupKey)(w, req)
+}
@@ -0,0 +1,129 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+)
+
+func TestProxy_Introspection(t *testing.T) {
+	datacoord := mocks.NewMockDataCoordClient(t)
+	querycoord := mocks.NewMockQueryCoordClient(t)
+	node := &Proxy{dataCoord: datacoord, queryCoord: querycoord}
+
+	t.Run("segments", func(t *testing.T) {
+		datacoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
+				params := make(map[string]any)
+				assert.NoError(t, json.Unmarshal([]byte(req.GetRequest()), &params))
+				assert.Equal(t, metricsinfo.SegmentMetaMetrics, params[metricsinfo.MetricTypeKey])
+				assert.Equal(t, float64(100), params[metricsinfo.MetricCollectionIDKey])
+				assert.Equal(t, "Flushed", params[metricsinfo.MetricSegmentStateKey])
+				return &milvuspb.GetMetricsResponse{Status: merr.Success(), Response: `{"segments":[]}`}, nil
+			}).Once()
+
+		w := httptest.NewRecorder()
+		node.ListSegmentMeta(w, httptest.NewRequest(http.MethodGet, mgrRouteSegmentMeta+"?collection_id=100&state=Flushed", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"segments":[]}`, w.Body.String())
+	})
+
+	t.Run("segment field stats", func(t *testing.T) {
+		datacoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
+				params := make(map[string]any)
+				assert.NoError(t, json.Unmarshal([]byte(req.GetRequest()), &params))
+				assert.Equal(t, metricsinfo.SegmentFieldStatsMetrics, params[metricsinfo.MetricTypeKey])
+				assert.Equal(t, float64(10), params[metricsinfo.MetricSegmentIDKey])
+				return &milvuspb.GetMetricsResponse{Status: merr.Success(), Response: `{"segmentID":10}`}, nil
+			}).Once()
+
+		w := httptest.NewRecorder()
+		node.GetSegmentFieldStats(w, httptest.NewRequest(http.MethodGet, mgrRouteSegmentFieldStats+"?segment_id=10", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"segmentID":10}`, w.Body.String())
+
+		w = httptest.NewRecorder()
+		node.GetSegmentFieldStats(w, httptest.NewRequest(http.MethodGet, mgrRouteSegmentFieldStats+"?segment_id=abc", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("small segments", func(t *testing.T) {
+		datacoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
+				params := make(map[string]any)
+				assert.NoError(t, json.Unmarshal([]byte(req.GetRequest()), &params))
+				assert.Equal(t, metricsinfo.SmallSegmentMetrics, params[metricsinfo.MetricTypeKey])
+				assert.Equal(t, float64(100), params[metricsinfo.MetricCollectionIDKey])
+				return &milvuspb.GetMetricsResponse{Status: merr.Success(), Response: `{"collections":[]}`}, nil
+			}).Once()
+
+		w := httptest.NewRecorder()
+		node.ListSmallSegments(w, httptest.NewRequest(http.MethodGet, mgrRouteSmallSegments+"?collection_id=100", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"collections":[]}`, w.Body.String())
+	})
+
+	t.Run("resource groups", func(t *testing.T) {
+		querycoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
+				params := make(map[string]any)
+				assert.NoError(t, json.Unmarshal([]byte(req.GetRequest()), &params))
+				assert.Equal(t, metricsinfo.ResourceGroupMetrics, params[metricsinfo.MetricTypeKey])
+				assert.Equal(t, "rg1", params[metricsinfo.MetricResourceGroupKey])
+				return &milvuspb.GetMetricsResponse{Status: merr.Success(), Response: `{"resource_groups":[]}`}, nil
+			}).Once()
+
+		w := httptest.NewRecorder()
+		node.ListResourceGroupUsages(w, httptest.NewRequest(http.MethodGet, mgrRouteResourceGroups+"?resource_group=rg1", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"resource_groups":[]}`, w.Body.String())
+	})
+
+	t.Run("invalid collection id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		node.ListTargetDistributions(w, httptest.NewRequest(http.MethodGet, mgrRouteTargets+"?collection_id=abc", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("coordinator failure", func(t *testing.T) {
+		querycoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).Return(nil, errors.New("mock")).Once()
+		w := httptest.NewRecorder()
+		node.ListReplicaAssignments(w, httptest.NewRequest(http.MethodGet, mgrRouteReplicas, nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		node.ListChannelCheckpoints(w, httptest.NewRequest(http.MethodPost, mgrRouteChannelCheckpoints, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
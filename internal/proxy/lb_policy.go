@@ -17,9 +17,11 @@ package proxy
 
 import (
 	"context"
+	"math"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
@@ -28,7 +30,6 @@ import (
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/merr"
-	"github.com/milvus-io/milvus/pkg/util/retry"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -40,17 +41,25 @@ type ChannelWorkload struct {
 	collectionID   int64
 	channel        string
 	shardLeaders   []int64
+	partitionIDs   []int64
 	nq             int64
 	exec           executeFunc
 	retryTimes     uint
+	// shared by all channels of one request, nil means no limit
+	retryBudget *atomic.Int64
+	// the identifier of the client whose reads stick to the shard delegators, 0 means no sticky routing
+	sessionClient int64
 }
 
 type CollectionWorkLoad struct {
 	db             string
 	collectionName string
 	collectionID   int64
+	partitionIDs   []int64
 	nq             int64
 	exec           executeFunc
+	// the identifier of the client whose reads stick to the shard delegators, 0 means no sticky routing
+	sessionClient int64
 }
 
 type LBPolicy interface {
@@ -107,10 +116,18 @@ func (lb *LBPolicyImpl) selectNode(ctx context.Context, workload ChannelWorkload
 			return nil, err
 		}
 
-		return lo.Map(shardLeaders[workload.channel], func(node nodeInfo, _ int) int64 { return node.nodeID }), nil
+		replicaNumbers := globalMetaCache.GetPartitionReplicaNumbers(workload.db, workload.collectionName)
+		nodes := filterLeadersByPartitions(shardLeaders[workload.channel], replicaNumbers, workload.partitionIDs)
+		return lo.Map(nodes, func(node nodeInfo, _ int) int64 { return node.nodeID }), nil
 	}
 
 	availableNodes := lo.Filter(workload.shardLeaders, filterAvailableNodes)
+	// the reads of the client stick to the shard delegator which served its prior reads, if it's still available
+	if workload.sessionClient != 0 {
+		if node, ok := getSessionRouter().node(workload.sessionClient, workload.channel); ok && lo.Contains(availableNodes, node) {
+			availableNodes = []int64{node}
+		}
+	}
 	targetNode, err := lb.balancer.SelectNode(ctx, availableNodes, workload.nq)
 	if err != nil {
 		globalMetaCache.DeprecateShardCache(workload.db, workload.collectionName)
@@ -141,7 +158,7 @@ func (lb *LBPolicyImpl) selectNode(ctx context.Context, workload ChannelWorkload
 	return targetNode, nil
 }
 
-// ExecuteWithRetry will choose a qn to execute the workload, and retry if failed, until reach the max retryTimes.
+// ExecuteWithRetry will choose a qn to execute the workload, and retry if failed according to the shard retry policy.
 func (lb *LBPolicyImpl) ExecuteWithRetry(ctx context.Context, workload ChannelWorkload) error {
 	excludeNodes := typeutil.NewUniqueSet()
 	log := log.Ctx(ctx).With(
@@ -150,8 +167,13 @@ func (lb *LBPolicyImpl) ExecuteWithRetry(ctx context.Context, workload ChannelWo
 		zap.String("channelName", workload.channel),
 	)
 
+	// refresh the shard leaders before retrying if they may be stale
+	policy := newShardRetryPolicy(workload.retryTimes, workload.retryBudget, func() {
+		globalMetaCache.DeprecateShardCache(workload.db, workload.collectionName)
+	})
+
 	var lastErr error
-	err := retry.Do(ctx, func() error {
+	return policy.Do(ctx, func() error {
 		targetNode, err := lb.selectNode(ctx, workload, excludeNodes)
 		if err != nil {
 			log.Warn("failed to select node for shard",
@@ -190,10 +212,11 @@ func (lb *LBPolicyImpl) ExecuteWithRetry(ctx context.Context, workload ChannelWo
 		}
 
 		lb.balancer.CancelWorkload(targetNode, workload.nq)
+		if workload.sessionClient != 0 {
+			getSessionRouter().stick(workload.sessionClient, workload.channel, targetNode)
+		}
 		return nil
-	}, retry.Attempts(workload.retryTimes))
-
-	return err
+	})
 }
 
 // Execute will execute collection workload in parallel
@@ -204,6 +227,19 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 		return err
 	}
 
+	// the partitions loaded with fewer replicas are only served by part of the shard leaders
+	replicaNumbers := globalMetaCache.GetPartitionReplicaNumbers(workload.db, workload.collectionName)
+	for channel, nodes := range dml2leaders {
+		dml2leaders[channel] = filterLeadersByPartitions(nodes, replicaNumbers, workload.partitionIDs)
+		if len(dml2leaders[channel]) == 0 {
+			log.Ctx(ctx).Warn("no shard leader serves the partitions",
+				zap.String("channel", channel),
+				zap.Int64s("partitionIDs", workload.partitionIDs))
+			return merr.WrapErrChannelNotAvailable(channel, "no shard leader serves the partitions")
+		}
+	}
+
+	retryBudget := newShardRetryBudget()
 	wg, ctx := errgroup.WithContext(ctx)
 	for channel, nodes := range dml2leaders {
 		channel := channel
@@ -216,9 +252,12 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 				collectionID:   workload.collectionID,
 				channel:        channel,
 				shardLeaders:   nodes,
+				partitionIDs:   workload.partitionIDs,
 				nq:             workload.nq,
 				exec:           workload.exec,
 				retryTimes:     uint(len(nodes) * retryOnReplica),
+				retryBudget:    retryBudget,
+				sessionClient:  workload.sessionClient,
 			})
 		})
 	}
@@ -226,6 +265,33 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 	return wg.Wait()
 }
 
+// filterLeadersByPartitions returns the shard leaders serving all the partitions,
+// the partition loaded with replica number k is served by the leaders of the first k replicas only.
+// All the loaded partitions are considered if no partition specified.
+func filterLeadersByPartitions(leaders []nodeInfo, replicaNumbers map[int64]int32, partitionIDs []int64) []nodeInfo {
+	if len(replicaNumbers) == 0 {
+		return leaders
+	}
+
+	limit := int32(math.MaxInt32)
+	if len(partitionIDs) == 0 {
+		for _, replicaNumber := range replicaNumbers {
+			if replicaNumber < limit {
+				limit = replicaNumber
+			}
+		}
+	}
+	for _, partitionID := range partitionIDs {
+		if replicaNumber, ok := replicaNumbers[partitionID]; ok && replicaNumber < limit {
+			limit = replicaNumber
+		}
+	}
+
+	return lo.Filter(leaders, func(node nodeInfo, _ int) bool {
+		return node.replicaRank < limit
+	})
+}
+
 func (lb *LBPolicyImpl) UpdateCostMetrics(node int64, cost *internalpb.CostAggregation) {
 	lb.balancer.UpdateCostMetrics(node, cost)
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/golang/protobuf/proto"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/atomic"
@@ -233,6 +234,44 @@ func (s *LBPolicySuite) TestSelectNode() {
 	s.Equal(int64(-1), targetNode)
 }
 
+func (s *LBPolicySuite) TestSelectNodeSticky() {
+	ctx := context.Background()
+	client := int64(1001)
+	getSessionRouter().stick(client, s.channels[0], s.nodes[1])
+
+	// the sticky node is the only candidate
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, []int64{s.nodes[1]}, mock.Anything).Return(s.nodes[1], nil).Once()
+	targetNode, err := s.lbPolicy.selectNode(ctx, ChannelWorkload{
+		db:             dbName,
+		collectionName: s.collectionName,
+		collectionID:   s.collectionID,
+		channel:        s.channels[0],
+		shardLeaders:   s.nodes,
+		nq:             1,
+		sessionClient:  client,
+	}, typeutil.NewUniqueSet())
+	s.NoError(err)
+	s.Equal(s.nodes[1], targetNode)
+
+	// fall back to all the available nodes if the sticky node is excluded
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, nodes []int64, nq int64) (int64, error) {
+			s.NotContains(nodes, s.nodes[1])
+			return nodes[0], nil
+		}).Once()
+	targetNode, err = s.lbPolicy.selectNode(ctx, ChannelWorkload{
+		db:             dbName,
+		collectionName: s.collectionName,
+		collectionID:   s.collectionID,
+		channel:        s.channels[0],
+		shardLeaders:   s.nodes,
+		nq:             1,
+		sessionClient:  client,
+	}, typeutil.NewUniqueSet(s.nodes[1]))
+	s.NoError(err)
+	s.NotEqual(s.nodes[1], targetNode)
+}
+
 func (s *LBPolicySuite) TestExecuteWithRetry() {
 	ctx := context.Background()
 
@@ -411,6 +450,28 @@ func (s *LBPolicySuite) TestExecute() {
 	s.ErrorIs(err, mockErr)
 }
 
+func (s *LBPolicySuite) TestFilterLeadersByPartitions() {
+	leaders := []nodeInfo{
+		{nodeID: 1, address: "localhost:9000", replicaRank: 0},
+		{nodeID: 2, address: "localhost:9001", replicaRank: 1},
+		{nodeID: 3, address: "localhost:9002", replicaRank: 2},
+	}
+	getNodeIDs := func(nodes []nodeInfo) []int64 {
+		return lo.Map(nodes, func(node nodeInfo, _ int) int64 { return node.nodeID })
+	}
+
+	// all partitions served by all replicas
+	s.Equal([]int64{1, 2, 3}, getNodeIDs(filterLeadersByPartitions(leaders, nil, []int64{100})))
+
+	replicaNumbers := map[int64]int32{100: 1, 101: 2}
+	s.Equal([]int64{1}, getNodeIDs(filterLeadersByPartitions(leaders, replicaNumbers, []int64{100})))
+	s.Equal([]int64{1, 2}, getNodeIDs(filterLeadersByPartitions(leaders, replicaNumbers, []int64{101})))
+	s.Equal([]int64{1}, getNodeIDs(filterLeadersByPartitions(leaders, replicaNumbers, []int64{100, 101})))
+	s.Equal([]int64{1, 2, 3}, getNodeIDs(filterLeadersByPartitions(leaders, replicaNumbers, []int64{102})))
+	// no partition specified, all the loaded partitions are considered
+	s.Equal([]int64{1}, getNodeIDs(filterLeadersByPartitions(leaders, replicaNumbers, nil)))
+}
+
 func (s *LBPolicySuite) TestUpdateCostMetrics() {
 	s.lbBalancer.EXPECT().UpdateCostMetrics(mock.Anything, mock.Anything)
 	s.lbPolicy.UpdateCostMetrics(1, &internalpb.CostAggregation{})
@@ -17,14 +17,25 @@
 package proxy
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	management "github.com/milvus-io/milvus/internal/http"
+	"github.com/milvus-io/milvus/internal/http/diagnostics"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 )
 
 // this file contains proxy management restful API handler
@@ -32,6 +43,53 @@ import (
 const (
 	mgrRouteGcPause  = `/management/datacoord/garbage_collection/pause`
 	mgrRouteGcResume = `/management/datacoord/garbage_collection/resume`
+
+	mgrRouteExport      = `/management/export`
+	mgrRouteExportState = `/management/export/state`
+	mgrRouteExportList  = `/management/export/list`
+
+	mgrRouteAnalyze      = `/management/analyze`
+	mgrRouteAnalyzeState = `/management/analyze/state`
+	mgrRouteAnalyzeList  = `/management/analyze/list`
+	mgrRouteAnalyzeUI    = `/management/analyze/ui`
+
+	mgrRouteDeleteState  = `/management/delete/state`
+	mgrRouteDeleteList   = `/management/delete/list`
+	mgrRouteDeleteCancel = `/management/delete/cancel`
+
+	mgrRouteVectorUpdate       = `/management/vector_update`
+	mgrRouteVectorUpdateState  = `/management/vector_update/state`
+	mgrRouteVectorUpdateList   = `/management/vector_update/list`
+	mgrRouteVectorUpdateCancel = `/management/vector_update/cancel`
+
+	mgrRouteTxnBegin  = `/management/txn/begin`
+	mgrRouteTxnCommit = `/management/txn/commit`
+	mgrRouteTxnAbort  = `/management/txn/abort`
+
+	mgrRouteSlowLog = `/management/slowlog`
+
+	mgrRouteHealthDiagnose = `/management/health/diagnose`
+
+	mgrRouteDiagnosticsBundle = `/management/diagnostics/bundle`
+
+	mgrRouteChannelCheckpoints = `/management/introspect/datacoord/channel_checkpoints`
+	mgrRouteSegmentMeta        = `/management/introspect/datacoord/segments`
+	mgrRouteSegmentFieldStats  = `/management/introspect/datacoord/segment_field_stats`
+	mgrRouteSmallSegments      = `/management/introspect/datacoord/small_segments`
+	mgrRouteTargets            = `/management/introspect/querycoord/targets`
+	mgrRouteReplicas           = `/management/introspect/querycoord/replicas`
+	mgrRouteResourceGroups     = `/management/introspect/querycoord/resource_groups`
+
+	mgrRouteTaskHistory      = `/management/task_history`
+	mgrRouteTaskHistoryPurge = `/management/task_history/purge`
+
+	mgrRouteDatabaseProperties = `/management/database/properties`
+
+	mgrRouteCollectionFreeze   = `/management/collection/freeze`
+	mgrRouteCollectionUnfreeze = `/management/collection/unfreeze`
+
+	mgrRouteInsertRateOverride = `/management/collection/insert_rate/override`
+	mgrRouteInsertRateReset    = `/management/collection/insert_rate/reset`
 )
 
 var mgrRouteRegisterOnce sync.Once
@@ -40,15 +98,246 @@ func RegisterMgrRoute(proxy *Proxy) {
 	mgrRouteRegisterOnce.Do(func() {
 		management.Register(&management.Handler{
 			Path:        mgrRouteGcPause,
-			HandlerFunc: proxy.PauseDatacoordGC,
+			HandlerFunc: mgrAdminOnly(proxy.PauseDatacoordGC),
 		})
 		management.Register(&management.Handler{
 			Path:        mgrRouteGcResume,
-			HandlerFunc: proxy.ResumeDatacoordGC,
+			HandlerFunc: mgrAdminOnly(proxy.ResumeDatacoordGC),
 		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteExport,
+			HandlerFunc: proxy.ExportCollection,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteExportState,
+			HandlerFunc: proxy.GetExportState,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteExportList,
+			HandlerFunc: proxy.ListExportJobs,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteAnalyze,
+			HandlerFunc: proxy.AnalyzeCollection,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteAnalyzeState,
+			HandlerFunc: proxy.GetAnalyzeState,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteAnalyzeList,
+			HandlerFunc: proxy.ListAnalyzeJobs,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteAnalyzeUI,
+			HandlerFunc: proxy.AnalyzeUI,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteDeleteState,
+			HandlerFunc: proxy.GetDeleteState,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteDeleteList,
+			HandlerFunc: proxy.ListDeleteJobs,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteDeleteCancel,
+			HandlerFunc: proxy.CancelDelete,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteVectorUpdate,
+			HandlerFunc: proxy.UpdateVectors,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteVectorUpdateState,
+			HandlerFunc: proxy.GetVectorUpdateState,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteVectorUpdateList,
+			HandlerFunc: proxy.ListVectorUpdateJobs,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteVectorUpdateCancel,
+			HandlerFunc: proxy.CancelVectorUpdate,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteTxnBegin,
+			HandlerFunc: proxy.BeginTxn,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteTxnCommit,
+			HandlerFunc: proxy.CommitTxn,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteTxnAbort,
+			HandlerFunc: proxy.AbortTxn,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteSlowLog,
+			HandlerFunc: proxy.ListSlowLogs,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteHealthDiagnose,
+			HandlerFunc: proxy.DiagnoseHealth,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteDiagnosticsBundle,
+			HandlerFunc: mgrAdminOnly(diagnostics.Handler().ServeHTTP),
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteChannelCheckpoints,
+			HandlerFunc: proxy.ListChannelCheckpoints,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteSegmentMeta,
+			HandlerFunc: proxy.ListSegmentMeta,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteSmallSegments,
+			HandlerFunc: proxy.ListSmallSegments,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteSegmentFieldStats,
+			HandlerFunc: proxy.GetSegmentFieldStats,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteTargets,
+			HandlerFunc: proxy.ListTargetDistributions,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteReplicas,
+			HandlerFunc: proxy.ListReplicaAssignments,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteResourceGroups,
+			HandlerFunc: proxy.ListResourceGroupUsages,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteTaskHistory,
+			HandlerFunc: proxy.ListTaskHistory,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteTaskHistoryPurge,
+			HandlerFunc: mgrAdminOnly(proxy.PurgeTaskHistory),
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteDatabaseProperties,
+			HandlerFunc: proxy.DatabaseProperties,
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteCollectionFreeze,
+			HandlerFunc: mgrAdminOnly(proxy.FreezeCollection),
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteCollectionUnfreeze,
+			HandlerFunc: mgrAdminOnly(proxy.UnfreezeCollection),
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteInsertRateOverride,
+			HandlerFunc: mgrAdminOnly(proxy.OverrideInsertRate),
+		})
+		management.Register(&management.Handler{
+			Path:        mgrRouteInsertRateReset,
+			HandlerFunc: mgrAdminOnly(proxy.ResetInsertRate),
+		})
+
+		proxy.newTuner().Register()
 	})
 }
 
+// mgrAuthenticate verifies the credential of the management request when authorization is enabled.
+// The `Authorization` header carries the same token as the sdk, base64<username:password> or base64<api key>,
+// optionally prefixed by the `Basic` or `Bearer` scheme.
+// The returned context carries the credential and the database, so it can be passed to PrivilegeInterceptor.
+func mgrAuthenticate(req *http.Request, dbName string) (context.Context, error) {
+	md := metadata.MD{}
+	if dbName != "" {
+		md.Set(util.HeaderDBName, dbName)
+	}
+	token := req.Header.Get("Authorization")
+	for _, scheme := range []string{"Basic ", "Bearer "} {
+		token = strings.TrimPrefix(token, scheme)
+	}
+	if token != "" {
+		md.Set(util.HeaderAuthorize, token)
+	}
+	ctx := metadata.NewIncomingContext(req.Context(), md)
+	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return ctx, nil
+	}
+	return AuthenticationInterceptor(ctx)
+}
+
+// mgrIsAdmin returns whether the user of ctx is root or granted the admin role,
+// everyone is admin if authorization is disabled.
+func mgrIsAdmin(ctx context.Context) (bool, error) {
+	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return true, nil
+	}
+	username, err := GetCurUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	if username == util.UserRoot {
+		return true, nil
+	}
+	roles, err := GetRole(username)
+	if err != nil {
+		return false, err
+	}
+	return lo.Contains(roles, util.RoleAdmin), nil
+}
+
+// mgrCurUser returns the user of ctx, or empty if authorization is disabled.
+func mgrCurUser(ctx context.Context) string {
+	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return ""
+	}
+	username, _ := GetCurUserFromContext(ctx)
+	return username
+}
+
+// mgrAdminOnly rejects the management request unless it's sent by an admin.
+func mgrAdminOnly(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx, err := mgrAuthenticate(req, "")
+		if err != nil {
+			mgrWriteAuthError(w, err)
+			return
+		}
+		isAdmin, err := mgrIsAdmin(ctx)
+		if err == nil && !isAdmin {
+			err = merr.WrapErrPrivilegeNotPermitted("admin role is required")
+		}
+		if err != nil {
+			mgrWriteAuthError(w, err)
+			return
+		}
+		handler(w, req.WithContext(ctx))
+	}
+}
+
+// mgrWriteJSON writes v as the JSON response of the management API.
+func mgrWriteJSON(w http.ResponseWriter, v any) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bs)
+}
+
+func mgrWriteAuthError(w http.ResponseWriter, err error) {
+	if status.Code(err) == codes.Unauthenticated {
+		w.WriteHeader(http.StatusUnauthorized)
+	} else {
+		w.WriteHeader(http.StatusForbidden)
+	}
+	w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+}
+
 func (node *Proxy) PauseDatacoordGC(w http.ResponseWriter, req *http.Request) {
 	pauseSeconds := req.URL.Query().Get("pause_seconds")
 
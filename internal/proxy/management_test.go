@@ -30,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
 type ProxyManagementSuite struct {
@@ -158,6 +159,38 @@ func (s *ProxyManagementSuite) TestResumeDatacoordGC() {
 	})
 }
 
+func (s *ProxyManagementSuite) TestAdminOnly() {
+	called := false
+	handler := mgrAdminOnly(func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+	req, err := http.NewRequest(http.MethodGet, mgrRouteGcPause, nil)
+	s.Require().NoError(err)
+
+	s.Run("authorization_disabled", func() {
+		called = false
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		s.True(called)
+		s.Equal(http.StatusOK, recorder.Code)
+	})
+
+	s.Run("unauthenticated", func() {
+		paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+		defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+		cache := globalMetaCache
+		globalMetaCache = NewMockCache(s.T())
+		defer func() { globalMetaCache = cache }()
+
+		called = false
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		s.False(called)
+		s.Equal(http.StatusUnauthorized, recorder.Code)
+	})
+}
+
 func TestProxyManagement(t *testing.T) {
 	suite.Run(t, new(ProxyManagementSuite))
 }
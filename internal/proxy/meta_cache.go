@@ -67,6 +67,8 @@ type Cache interface {
 	// GetCollectionSchema get collection's schema.
 	GetCollectionSchema(ctx context.Context, database, collectionName string) (*schemapb.CollectionSchema, error)
 	GetShards(ctx context.Context, withCache bool, database, collectionName string, collectionID int64) (map[string][]nodeInfo, error)
+	// GetPartitionReplicaNumbers get the cached partitions loaded with fewer replicas than the collection.
+	GetPartitionReplicaNumbers(database, collectionName string) map[int64]int32
 	DeprecateShardCache(database, collectionName string)
 	expireShardLeaderCache(ctx context.Context)
 	RemoveCollection(ctx context.Context, database, collectionName string)
@@ -93,6 +95,7 @@ type collectionBasicInfo struct {
 	createdUtcTimestamp uint64
 	consistencyLevel    commonpb.ConsistencyLevel
 	partInfo            map[string]*partitionInfo
+	properties          []*commonpb.KeyValuePair
 }
 
 type collectionInfo struct {
@@ -104,6 +107,7 @@ type collectionInfo struct {
 	createdTimestamp    uint64
 	createdUtcTimestamp uint64
 	consistencyLevel    commonpb.ConsistencyLevel
+	properties          []*commonpb.KeyValuePair
 }
 
 // getBasicInfo get a basic info by deep copy.
@@ -115,6 +119,7 @@ func (info *collectionInfo) getBasicInfo() *collectionBasicInfo {
 		createdUtcTimestamp: info.createdUtcTimestamp,
 		consistencyLevel:    info.consistencyLevel,
 		partInfo:            make(map[string]*partitionInfo, len(info.partInfo)),
+		properties:          info.properties,
 	}
 	for s, info := range info.partInfo {
 		info2 := *info
@@ -141,6 +146,8 @@ type shardLeaders struct {
 	deprecated *atomic.Bool
 
 	shardLeaders map[string][]nodeInfo
+	// partition -> replica number, for the partitions loaded with fewer replicas than the collection
+	partitionReplicaNumbers map[int64]int32
 }
 
 type shardLeadersReader struct {
@@ -440,6 +447,7 @@ func (m *MetaCache) updateCollection(coll *milvuspb.DescribeCollectionResponse,
 	m.collInfo[database][collectionName].createdTimestamp = coll.CreatedTimestamp
 	m.collInfo[database][collectionName].createdUtcTimestamp = coll.CreatedUtcTimestamp
 	m.collInfo[database][collectionName].consistencyLevel = coll.ConsistencyLevel
+	m.collInfo[database][collectionName].properties = coll.Properties
 }
 
 func (m *MetaCache) GetPartitionID(ctx context.Context, database, collectionName string, partitionName string) (typeutil.UniqueID, error) {
@@ -597,6 +605,7 @@ func (m *MetaCache) describeCollection(ctx context.Context, database, collection
 		CreatedUtcTimestamp:  coll.CreatedUtcTimestamp,
 		ConsistencyLevel:     coll.ConsistencyLevel,
 		DbName:               coll.GetDbName(),
+		Properties:           coll.Properties,
 	}
 	for _, field := range coll.Schema.Fields {
 		if field.FieldID >= common.StartOfUserFieldID {
@@ -816,9 +825,10 @@ func (m *MetaCache) GetShards(ctx context.Context, withCache bool, database, col
 	info.leaderMutex.Lock()
 	oldShards := info.shardLeaders
 	info.shardLeaders = &shardLeaders{
-		shardLeaders: shards,
-		deprecated:   atomic.NewBool(false),
-		idx:          atomic.NewInt64(0),
+		shardLeaders:            shards,
+		partitionReplicaNumbers: resp.GetPartitionReplicaNumbers(),
+		deprecated:              atomic.NewBool(false),
+		idx:                     atomic.NewInt64(0),
 	}
 	iterator := info.shardLeaders.GetReader()
 	info.leaderMutex.Unlock()
@@ -843,7 +853,11 @@ func parseShardLeaderList2QueryNode(shardsLeaders []*querypb.ShardLeadersList) m
 		qns := make([]nodeInfo, len(leaders.GetNodeIds()))
 
 		for j := range qns {
-			qns[j] = nodeInfo{leaders.GetNodeIds()[j], leaders.GetNodeAddrs()[j]}
+			qns[j] = nodeInfo{nodeID: leaders.GetNodeIds()[j], address: leaders.GetNodeAddrs()[j]}
+			// the ranks are absent if the QueryCoord doesn't support partition replica number
+			if j < len(leaders.GetReplicaRanks()) {
+				qns[j].replicaRank = leaders.GetReplicaRanks()[j]
+			}
 		}
 
 		shard2QueryNodes[leaders.GetChannelName()] = qns
@@ -852,6 +866,28 @@ func parseShardLeaderList2QueryNode(shardsLeaders []*querypb.ShardLeadersList) m
 	return shard2QueryNodes
 }
 
+// GetPartitionReplicaNumbers returns the partition replica numbers along with the cached shard leaders,
+// nil if the shard leaders are not cached.
+func (m *MetaCache) GetPartitionReplicaNumbers(database, collectionName string) map[int64]int32 {
+	m.mu.RLock()
+	var info *collectionInfo
+	db, ok := m.collInfo[database]
+	if ok {
+		info = db[collectionName]
+	}
+	m.mu.RUnlock()
+	if info == nil {
+		return nil
+	}
+
+	info.leaderMutex.RLock()
+	defer info.leaderMutex.RUnlock()
+	if info.shardLeaders == nil {
+		return nil
+	}
+	return info.shardLeaders.partitionReplicaNumbers
+}
+
 // DeprecateShardCache clear the shard leader cache of a collection
 func (m *MetaCache) DeprecateShardCache(database, collectionName string) {
 	log.Info("clearing shard cache for collection", zap.String("collectionName", collectionName))
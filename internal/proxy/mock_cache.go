@@ -504,6 +504,51 @@ func (_c *MockCache_GetPartitions_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// GetPartitionReplicaNumbers provides a mock function with given fields: database, collectionName
+func (_m *MockCache) GetPartitionReplicaNumbers(database string, collectionName string) map[int64]int32 {
+	ret := _m.Called(database, collectionName)
+
+	var r0 map[int64]int32
+	if rf, ok := ret.Get(0).(func(string, string) map[int64]int32); ok {
+		r0 = rf(database, collectionName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]int32)
+		}
+	}
+
+	return r0
+}
+
+// MockCache_GetPartitionReplicaNumbers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPartitionReplicaNumbers'
+type MockCache_GetPartitionReplicaNumbers_Call struct {
+	*mock.Call
+}
+
+// GetPartitionReplicaNumbers is a helper method to define mock.On call
+//   - database string
+//   - collectionName string
+func (_e *MockCache_Expecter) GetPartitionReplicaNumbers(database interface{}, collectionName interface{}) *MockCache_GetPartitionReplicaNumbers_Call {
+	return &MockCache_GetPartitionReplicaNumbers_Call{Call: _e.mock.On("GetPartitionReplicaNumbers", database, collectionName)}
+}
+
+func (_c *MockCache_GetPartitionReplicaNumbers_Call) Run(run func(database string, collectionName string)) *MockCache_GetPartitionReplicaNumbers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCache_GetPartitionReplicaNumbers_Call) Return(_a0 map[int64]int32) *MockCache_GetPartitionReplicaNumbers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_GetPartitionReplicaNumbers_Call) RunAndReturn(run func(string, string) map[int64]int32) *MockCache_GetPartitionReplicaNumbers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPrivilegeInfo provides a mock function with given fields: ctx
 func (_m *MockCache) GetPrivilegeInfo(ctx context.Context) []string {
 	ret := _m.Called(ctx)
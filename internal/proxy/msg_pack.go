@@ -76,29 +76,41 @@ func genInsertMsgsByPartition(ctx context.Context,
 		return msg
 	}
 
-	repackedMsgs := make([]msgstream.TsMsg, 0)
-	requestSize := 0
-	msg := createInsertMsg(segmentID, channelName)
-	for _, offset := range rowOffsets {
-		curRowMessageSize, err := typeutil.EstimateEntitySize(insertMsg.GetFieldsData(), offset)
-		if err != nil {
-			return nil, err
+	rowSizes, err := typeutil.EstimateEntitySizes(insertMsg.GetFieldsData(), rowOffsets)
+	if err != nil {
+		return nil, err
+	}
+
+	// fill the rows into the msg column by column
+	fillInsertMsg := func(msg *msgstream.InsertMsg, offsets []int) {
+		typeutil.AppendFieldDataByOffsets(msg.FieldsData, insertMsg.GetFieldsData(), offsets)
+		msg.HashValues = make([]uint32, 0, len(offsets))
+		msg.Timestamps = make([]uint64, 0, len(offsets))
+		msg.RowIDs = make([]int64, 0, len(offsets))
+		for _, offset := range offsets {
+			msg.HashValues = append(msg.HashValues, insertMsg.HashValues[offset])
+			msg.Timestamps = append(msg.Timestamps, insertMsg.Timestamps[offset])
+			msg.RowIDs = append(msg.RowIDs, insertMsg.RowIDs[offset])
 		}
+		msg.NumRows = uint64(len(offsets))
+	}
 
+	repackedMsgs := make([]msgstream.TsMsg, 0)
+	requestSize := 0
+	start := 0
+	for i := range rowOffsets {
 		// if insertMsg's size is greater than the threshold, split into multiple insertMsgs
-		if requestSize+curRowMessageSize >= threshold {
+		if requestSize+rowSizes[i] >= threshold {
+			msg := createInsertMsg(segmentID, channelName)
+			fillInsertMsg(msg, rowOffsets[start:i])
 			repackedMsgs = append(repackedMsgs, msg)
-			msg = createInsertMsg(segmentID, channelName)
+			start = i
 			requestSize = 0
 		}
-
-		typeutil.AppendFieldData(msg.FieldsData, insertMsg.GetFieldsData(), int64(offset))
-		msg.HashValues = append(msg.HashValues, insertMsg.HashValues[offset])
-		msg.Timestamps = append(msg.Timestamps, insertMsg.Timestamps[offset])
-		msg.RowIDs = append(msg.RowIDs, insertMsg.RowIDs[offset])
-		msg.NumRows++
-		requestSize += curRowMessageSize
+		requestSize += rowSizes[i]
 	}
+	msg := createInsertMsg(segmentID, channelName)
+	fillInsertMsg(msg, rowOffsets[start:])
 	repackedMsgs = append(repackedMsgs, msg)
 
 	return repackedMsgs, nil
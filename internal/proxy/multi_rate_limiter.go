@@ -87,7 +87,8 @@ func (m *MultiRateLimiter) Check(collectionID int64, rt internalpb.RateType, n i
 			return limiter.getError(rt)
 		}
 		if limit {
-			return merr.WrapErrServiceRateLimit(rate)
+			burst, wait := limiter.throttleState(rt, n)
+			return merr.WrapErrServiceRateLimitWithWait(rate, burst, wait)
 		}
 		return nil
 	}
@@ -199,6 +200,16 @@ func (rl *rateLimiter) limit(rt internalpb.RateType, n int) (bool, float64) {
 	return !limit.AllowN(time.Now(), n), float64(limit.Limit())
 }
 
+// throttleState returns the burst of the limiter, and the suggested duration to wait
+// before the request of n may pass.
+func (rl *rateLimiter) throttleState(rt internalpb.RateType, n int) (float64, time.Duration) {
+	limit, ok := rl.limiters.Get(rt)
+	if !ok {
+		return 0, 0
+	}
+	return limit.Burst(), limit.WaitN(time.Now(), n)
+}
+
 func (rl *rateLimiter) cancel(rt internalpb.RateType, n int) {
 	limit, ok := rl.limiters.Get(rt)
 	if !ok {
@@ -215,6 +226,8 @@ func (rl *rateLimiter) setRates(collectionRate *proxypb.CollectionRate) error {
 	for _, r := range collectionRate.GetRates() {
 		if limit, ok := rl.limiters.Get(r.GetRt()); ok {
 			limit.SetLimit(ratelimitutil.Limit(r.GetR()))
+			// the limiter works as a token bucket if the burst is set
+			limit.SetBurst(r.GetBurst())
 			setRateGaugeByRateType(r.GetRt(), paramtable.GetNodeID(), collectionRate.Collection, r.GetR())
 		} else {
 			return fmt.Errorf("unregister rateLimiter for rateType %s", r.GetRt().String())
@@ -222,6 +235,7 @@ func (rl *rateLimiter) setRates(collectionRate *proxypb.CollectionRate) error {
 		log.RatedDebug(30, "current collection rates in proxy",
 			zap.String("rateType", r.Rt.String()),
 			zap.String("rateLimit", ratelimitutil.Limit(r.GetR()).String()),
+			zap.Float64("burst", r.GetBurst()),
 		)
 	}
 
@@ -258,7 +272,7 @@ func setRateGaugeByRateType(rateType internalpb.RateType, nodeID int64, collecti
 		return
 	}
 	nodeIDStr := strconv.FormatInt(nodeID, 10)
-	collectionIDStr := strconv.FormatInt(collectionID, 10)
+	collectionIDStr := metrics.CollectionIDLabel(collectionID)
 	switch rateType {
 	case internalpb.RateType_DMLInsert:
 		metrics.ProxyLimiterRate.WithLabelValues(nodeIDStr, collectionIDStr, metrics.InsertLabel).Set(rate)
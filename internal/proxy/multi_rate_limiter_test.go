@@ -107,6 +107,28 @@ func TestMultiRateLimiter(t *testing.T) {
 		Params.Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, bak)
 	})
 
+	t.Run("test insert token bucket", func(t *testing.T) {
+		bak := Params.QuotaConfig.QuotaAndLimitsEnabled.GetValue()
+		paramtable.Get().Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, "true")
+		defer Params.Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, bak)
+		multiLimiter := NewMultiRateLimiter()
+		err := multiLimiter.SetRates([]*proxypb.CollectionRate{
+			{
+				Collection: collectionID,
+				Rates:      []*internalpb.Rate{{Rt: internalpb.RateType_DMLInsert, R: 100, Burst: 20}},
+			},
+		})
+		assert.NoError(t, err)
+
+		// the bucket is full initially
+		err = multiLimiter.Check(collectionID, internalpb.RateType_DMLInsert, 15)
+		assert.NoError(t, err)
+		err = multiLimiter.Check(collectionID, internalpb.RateType_DMLInsert, 10)
+		assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+		assert.Contains(t, err.Error(), "burst=20")
+		assert.Contains(t, err.Error(), "suggestedWait=")
+	})
+
 	t.Run("not enable quotaAndLimit", func(t *testing.T) {
 		multiLimiter := NewMultiRateLimiter()
 		multiLimiter.collectionLimiters[collectionID] = newRateLimiter(false)
@@ -0,0 +1,287 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// mutationBatch is the insert msg packs of a collection coalesced within a window.
+type mutationBatch struct {
+	stream msgstream.MsgStream
+	packs  []*msgstream.MsgPack
+	rows   int
+	// the batch is being produced, the packs can't be withdrawn anymore
+	flushing bool
+
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+// mutationBatcher coalesces the small insert requests to the same collection within a window,
+// the rows to the same shard, partition and segment are merged into one insert msg.
+// Each request waits for the batch produced and gets the result of its own,
+// so the inserts are acknowledged as they're produced one by one.
+//
+// The requests are still running while they wait, so their timestamps hold the timeticks of
+// the channels back, and the merged messages never fall behind the timeticks.
+type mutationBatcher struct {
+	mu      sync.Mutex
+	batches map[UniqueID]*mutationBatch
+	closed  bool
+}
+
+func newMutationBatcher() *mutationBatcher {
+	return &mutationBatcher{
+		batches: make(map[UniqueID]*mutationBatch),
+	}
+}
+
+// produce produces the insert msg pack of the collection, by a batch if the batching is enabled and the pack is small.
+func (b *mutationBatcher) produce(ctx context.Context, collectionID UniqueID, stream msgstream.MsgStream, pack *msgstream.MsgPack) error {
+	params := paramtable.Get()
+	maxRows := params.ProxyCfg.MutationBatchingMaxRows.GetAsInt()
+	rows := packRows(pack)
+	if !params.ProxyCfg.MutationBatchingEnabled.GetAsBool() || rows >= maxRows {
+		return stream.Produce(pack)
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return stream.Produce(pack)
+	}
+	batch, ok := b.batches[collectionID]
+	if !ok {
+		batch = &mutationBatch{
+			stream: stream,
+			done:   make(chan struct{}),
+		}
+		b.batches[collectionID] = batch
+		time.AfterFunc(params.ProxyCfg.MutationBatchingWindow.GetAsDuration(time.Millisecond), func() {
+			b.flush(collectionID, batch)
+		})
+	}
+	batch.packs = append(batch.packs, pack)
+	batch.rows += rows
+	full := batch.rows >= maxRows
+	b.mu.Unlock()
+
+	if full {
+		b.flush(collectionID, batch)
+	}
+	select {
+	case <-batch.done:
+		return batch.err
+	case <-ctx.Done():
+		if b.withdraw(collectionID, batch, pack) {
+			return ctx.Err()
+		}
+		// the rows are being produced, the request gets the result of them
+		<-batch.done
+		return batch.err
+	}
+}
+
+// withdraw removes the pack of the canceled request from the batch,
+// returns false if the batch is already being produced.
+func (b *mutationBatcher) withdraw(collectionID UniqueID, batch *mutationBatch, pack *msgstream.MsgPack) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if batch.flushing {
+		return false
+	}
+	for i, p := range batch.packs {
+		if p == pack {
+			batch.packs = append(batch.packs[:i], batch.packs[i+1:]...)
+			batch.rows -= packRows(pack)
+			break
+		}
+	}
+	if len(batch.packs) == 0 && b.batches[collectionID] == batch {
+		delete(b.batches, collectionID)
+	}
+	return true
+}
+
+// flush produces the batch once, either the window passes or it's full.
+func (b *mutationBatcher) flush(collectionID UniqueID, batch *mutationBatch) {
+	b.mu.Lock()
+	if b.batches[collectionID] == batch {
+		delete(b.batches, collectionID)
+	}
+	batch.flushing = true
+	b.mu.Unlock()
+
+	batch.once.Do(func() {
+		defer close(batch.done)
+		if len(batch.packs) == 0 {
+			// all the requests of the batch are canceled
+			return
+		}
+		metrics.ProxyMutationBatchRequests.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10)).Observe(float64(len(batch.packs)))
+		batch.err = batch.stream.Produce(mergeInsertPacks(batch.packs, paramtable.Get().ProxyCfg.MutationBatchingMaxRows.GetAsInt()))
+		if batch.err != nil {
+			log.Warn("failed to produce mutation batch", zap.Int64("collectionID", collectionID),
+				zap.Int("requests", len(batch.packs)), zap.Int("rows", batch.rows), zap.Error(batch.err))
+		}
+	})
+}
+
+// close produces the pending batches, the requests afterwards are produced directly.
+func (b *mutationBatcher) close() {
+	b.mu.Lock()
+	b.closed = true
+	batches := b.batches
+	b.batches = make(map[UniqueID]*mutationBatch)
+	b.mu.Unlock()
+
+	for collectionID, batch := range batches {
+		b.flush(collectionID, batch)
+	}
+}
+
+func packRows(pack *msgstream.MsgPack) int {
+	rows := 0
+	for _, msg := range pack.Msgs {
+		if insertMsg, ok := msg.(*msgstream.InsertMsg); ok {
+			rows += int(insertMsg.NRows())
+		}
+	}
+	return rows
+}
+
+// mergeInsertPacks merges the insert msgs to the same shard, partition and segment in the packs,
+// the merged msgs are limited by the rows and the max message size.
+func mergeInsertPacks(packs []*msgstream.MsgPack, maxRows int) *msgstream.MsgPack {
+	type mergeKey struct {
+		channel     string
+		partitionID UniqueID
+		segmentID   UniqueID
+	}
+	maxSize := Params.PulsarCfg.MaxMessageSize.GetAsInt()
+
+	merged := &msgstream.MsgPack{}
+	targets := make(map[mergeKey]*msgstream.InsertMsg)
+	sizes := make(map[*msgstream.InsertMsg]int)
+	for _, pack := range packs {
+		if merged.BeginTs == 0 || pack.BeginTs < merged.BeginTs {
+			merged.BeginTs = pack.BeginTs
+		}
+		if pack.EndTs > merged.EndTs {
+			merged.EndTs = pack.EndTs
+		}
+		for _, msg := range pack.Msgs {
+			insertMsg, ok := msg.(*msgstream.InsertMsg)
+			if !ok {
+				merged.Msgs = append(merged.Msgs, msg)
+				continue
+			}
+			key := mergeKey{
+				channel:     insertMsg.GetShardName(),
+				partitionID: insertMsg.GetPartitionID(),
+				segmentID:   insertMsg.GetSegmentID(),
+			}
+			size := proto.Size(&insertMsg.InsertRequest)
+			target, ok := targets[key]
+			if ok && int(target.NRows()+insertMsg.NRows()) <= maxRows && sizes[target]+size < maxSize &&
+				mergeInsertMsg(target, insertMsg) == nil {
+				sizes[target] += size
+				continue
+			}
+			targets[key] = insertMsg
+			sizes[insertMsg] = size
+			merged.Msgs = append(merged.Msgs, insertMsg)
+		}
+	}
+	return merged
+}
+
+// mergeInsertMsg appends the rows of src to dst, fails if their fields mismatch.
+// dst is left intact if it fails.
+func mergeInsertMsg(dst, src *msgstream.InsertMsg) error {
+	if len(dst.GetFieldsData()) != len(src.GetFieldsData()) {
+		return merr.WrapErrParameterInvalidMsg("the fields of the insert msgs mismatch")
+	}
+	dstFields := make(map[int64]*schemapb.FieldData)
+	for _, field := range dst.GetFieldsData() {
+		dstFields[field.GetFieldId()] = field
+	}
+	for _, field := range src.GetFieldsData() {
+		target, ok := dstFields[field.GetFieldId()]
+		if !ok || !mergeableFieldData(target, field) {
+			return merr.WrapErrParameterInvalidMsg("the fields of the insert msgs mismatch")
+		}
+	}
+	// MergeFieldData fails only if the fields are unmergeable, which is checked above,
+	// otherwise the fields merged before the failure are left appended
+	if err := typeutil.MergeFieldData(dst.FieldsData, src.GetFieldsData()); err != nil {
+		return err
+	}
+	dst.HashValues = append(dst.HashValues, src.HashValues...)
+	dst.Timestamps = append(dst.Timestamps, src.GetTimestamps()...)
+	dst.RowIDs = append(dst.RowIDs, src.GetRowIDs()...)
+	dst.NumRows += src.NRows()
+	if src.GetBase().GetTimestamp() < dst.GetBase().GetTimestamp() {
+		dst.Base.Timestamp = src.GetBase().GetTimestamp()
+	}
+	return nil
+}
+
+// mergeableFieldData returns whether typeutil.MergeFieldData appends src to dst without failure.
+func mergeableFieldData(dst, src *schemapb.FieldData) bool {
+	if dst.GetType() != src.GetType() {
+		return false
+	}
+	switch src.GetField().(type) {
+	case *schemapb.FieldData_Scalars:
+		switch src.GetScalars().GetData().(type) {
+		case *schemapb.ScalarField_BoolData, *schemapb.ScalarField_IntData, *schemapb.ScalarField_LongData,
+			*schemapb.ScalarField_FloatData, *schemapb.ScalarField_DoubleData, *schemapb.ScalarField_StringData,
+			*schemapb.ScalarField_ArrayData, *schemapb.ScalarField_JsonData:
+		default:
+			return false
+		}
+		return reflect.TypeOf(dst.GetScalars().GetData()) == reflect.TypeOf(src.GetScalars().GetData())
+	case *schemapb.FieldData_Vectors:
+		switch src.GetVectors().GetData().(type) {
+		case *schemapb.VectorField_BinaryVector, *schemapb.VectorField_FloatVector:
+		default:
+			return false
+		}
+		return dst.GetVectors().GetDim() == src.GetVectors().GetDim() &&
+			reflect.TypeOf(dst.GetVectors().GetData()) == reflect.TypeOf(src.GetVectors().GetData())
+	default:
+		return false
+	}
+}
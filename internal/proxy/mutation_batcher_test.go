@@ -0,0 +1,228 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type producedMsgStream struct {
+	mockMsgStream
+	mu    sync.Mutex
+	packs []*msgstream.MsgPack
+	err   error
+}
+
+func (s *producedMsgStream) Produce(pack *msgstream.MsgPack) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.packs = append(s.packs, pack)
+	return s.err
+}
+
+func newBatchedInsertPack(channel string, segmentID UniqueID, ts uint64, ids ...int64) *msgstream.MsgPack {
+	msg := &msgstream.InsertMsg{
+		BaseMsg: msgstream.BaseMsg{HashValues: make([]uint32, len(ids))},
+		InsertRequest: msgpb.InsertRequest{
+			Base: commonpbutil.NewMsgBase(
+				commonpbutil.WithMsgType(commonpb.MsgType_Insert),
+				commonpbutil.WithTimeStamp(ts),
+			),
+			ShardName:  channel,
+			SegmentID:  segmentID,
+			RowIDs:     ids,
+			Timestamps: make([]uint64, len(ids)),
+			NumRows:    uint64(len(ids)),
+			FieldsData: []*schemapb.FieldData{{
+				Type:    schemapb.DataType_Int64,
+				FieldId: 100,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: ids}},
+				}},
+			}},
+			Version: msgpb.InsertDataVersion_ColumnBased,
+		},
+	}
+	return &msgstream.MsgPack{BeginTs: ts, EndTs: ts, Msgs: []msgstream.TsMsg{msg}}
+}
+
+func TestMutationBatcher_Disabled(t *testing.T) {
+	paramtable.Init()
+	stream := &producedMsgStream{}
+	b := newMutationBatcher()
+	defer b.close()
+
+	assert.NoError(t, b.produce(context.Background(), 1, stream, newBatchedInsertPack("ch1", 10, 100, 1)))
+	assert.NoError(t, b.produce(context.Background(), 1, stream, newBatchedInsertPack("ch1", 10, 101, 2)))
+	assert.Len(t, stream.packs, 2)
+}
+
+func TestMutationBatcher_Batch(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.MutationBatchingEnabled.Key, "true")
+	params.Save(params.ProxyCfg.MutationBatchingWindow.Key, "3600000")
+	params.Save(params.ProxyCfg.MutationBatchingMaxRows.Key, "4")
+	defer params.Reset(params.ProxyCfg.MutationBatchingEnabled.Key)
+	defer params.Reset(params.ProxyCfg.MutationBatchingWindow.Key)
+	defer params.Reset(params.ProxyCfg.MutationBatchingMaxRows.Key)
+
+	t.Run("flush when full", func(t *testing.T) {
+		stream := &producedMsgStream{}
+		b := newMutationBatcher()
+		defer b.close()
+
+		wg := sync.WaitGroup{}
+		produced := atomic.NewInt32(0)
+		for _, pack := range []*msgstream.MsgPack{
+			newBatchedInsertPack("ch1", 10, 103, 1),
+			newBatchedInsertPack("ch1", 10, 101, 2),
+			newBatchedInsertPack("ch2", 20, 102, 3),
+		} {
+			wg.Add(1)
+			go func(pack *msgstream.MsgPack) {
+				defer wg.Done()
+				assert.NoError(t, b.produce(context.Background(), 1, stream, pack))
+				produced.Inc()
+			}(pack)
+		}
+		// the fourth row fills the batch
+		assert.Eventually(t, func() bool {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			return b.batches[1] != nil && b.batches[1].rows == 3
+		}, time.Second, 10*time.Millisecond)
+		assert.Zero(t, produced.Load())
+		assert.NoError(t, b.produce(context.Background(), 1, stream, newBatchedInsertPack("ch1", 10, 104, 4)))
+		wg.Wait()
+
+		assert.Len(t, stream.packs, 1)
+		pack := stream.packs[0]
+		assert.EqualValues(t, 101, pack.BeginTs)
+		assert.EqualValues(t, 104, pack.EndTs)
+		assert.Len(t, pack.Msgs, 2)
+		rows := 0
+		for _, msg := range pack.Msgs {
+			insertMsg := msg.(*msgstream.InsertMsg)
+			rows += int(insertMsg.NRows())
+			assert.Len(t, insertMsg.GetFieldsData()[0].GetScalars().GetLongData().GetData(), int(insertMsg.NRows()))
+			assert.Len(t, insertMsg.GetRowIDs(), int(insertMsg.NRows()))
+			if insertMsg.GetShardName() == "ch1" {
+				assert.EqualValues(t, 3, insertMsg.NRows())
+				assert.EqualValues(t, 101, insertMsg.GetBase().GetTimestamp())
+			}
+		}
+		assert.Equal(t, 4, rows)
+	})
+
+	t.Run("large request produced directly", func(t *testing.T) {
+		stream := &producedMsgStream{}
+		b := newMutationBatcher()
+		defer b.close()
+
+		assert.NoError(t, b.produce(context.Background(), 1, stream, newBatchedInsertPack("ch1", 10, 100, 1, 2, 3, 4)))
+		assert.Len(t, stream.packs, 1)
+	})
+
+	t.Run("error acknowledged to all", func(t *testing.T) {
+		stream := &producedMsgStream{err: errors.New("mock")}
+		b := newMutationBatcher()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- b.produce(context.Background(), 1, stream, newBatchedInsertPack("ch1", 10, 100, 1))
+		}()
+		assert.Eventually(t, func() bool {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			return b.batches[1] != nil
+		}, time.Second, 10*time.Millisecond)
+		// closing flushes the pending batches
+		b.close()
+		assert.Error(t, <-errCh)
+		// produced directly after closed
+		assert.Error(t, b.produce(context.Background(), 1, stream, newBatchedInsertPack("ch1", 10, 101, 2)))
+		assert.Len(t, stream.packs, 2)
+	})
+
+	t.Run("canceled request withdrawn", func(t *testing.T) {
+		stream := &producedMsgStream{}
+		b := newMutationBatcher()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- b.produce(ctx, 1, stream, newBatchedInsertPack("ch1", 10, 100, 1))
+		}()
+		assert.Eventually(t, func() bool {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			return b.batches[1] != nil
+		}, time.Second, 10*time.Millisecond)
+		cancel()
+		assert.ErrorIs(t, <-errCh, context.Canceled)
+		b.mu.Lock()
+		assert.Nil(t, b.batches[1])
+		b.mu.Unlock()
+
+		b.close()
+		// the rows of the canceled request are never produced
+		assert.Empty(t, stream.packs)
+	})
+}
+
+func TestMergeInsertMsg(t *testing.T) {
+	getMsg := func(pack *msgstream.MsgPack) *msgstream.InsertMsg {
+		return pack.Msgs[0].(*msgstream.InsertMsg)
+	}
+
+	dst := getMsg(newBatchedInsertPack("ch1", 10, 101, 1, 2))
+	assert.NoError(t, mergeInsertMsg(dst, getMsg(newBatchedInsertPack("ch1", 10, 100, 3))))
+	assert.EqualValues(t, 3, dst.NRows())
+	assert.Equal(t, []int64{1, 2, 3}, dst.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+	assert.EqualValues(t, 100, dst.GetBase().GetTimestamp())
+
+	// the mismatched field leaves dst intact
+	src := getMsg(newBatchedInsertPack("ch1", 10, 102, 4))
+	src.FieldsData[0].Type = schemapb.DataType_Int32
+	src.FieldsData[0].Field = &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+		Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: []int32{4}}},
+	}}
+	assert.Error(t, mergeInsertMsg(dst, src))
+	assert.EqualValues(t, 3, dst.NRows())
+	assert.Equal(t, []int64{1, 2, 3}, dst.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+	assert.Len(t, dst.GetRowIDs(), 3)
+
+	src = getMsg(newBatchedInsertPack("ch1", 10, 102, 4))
+	src.FieldsData[0].FieldId = 101
+	assert.Error(t, mergeInsertMsg(dst, src))
+	assert.EqualValues(t, 3, dst.NRows())
+}
@@ -36,8 +36,10 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proxy/accesslog"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/dependency"
+	"github.com/milvus-io/milvus/internal/util/healthcheck"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
@@ -120,6 +122,20 @@ type Proxy struct {
 	// resource manager
 	resourceManager        resource.Manager
 	replicateStreamManager *ReplicateStreamManager
+
+	exportManager   *exportJobManager
+	analyzeManager  *analyzeJobManager
+	deleteManager   *deleteJobManager
+	vectorURLWriter *vectorURLWriter
+	txnManager      *txnManager
+	slowLogger      *slowLogger
+	healthChecker   *healthcheck.Checker
+	degradedMonitor *degradedMonitor
+	mutationBatcher *mutationBatcher
+
+	// the chunk manager of object storage, created once it's used
+	chunkManagerMu sync.Mutex
+	chunkManager   storage.ChunkManager
 }
 
 // NewProxy returns a Proxy struct.
@@ -142,6 +158,9 @@ func NewProxy(ctx context.Context, factory dependency.Factory) (*Proxy, error) {
 		lbPolicy:               lbPolicy,
 		resourceManager:        resourceManager,
 		replicateStreamManager: replicateStreamManager,
+		txnManager:             newTxnManager(),
+		slowLogger:             newSlowLogger(),
+		mutationBatcher:        newMutationBatcher(),
 	}
 	node.UpdateStateCode(commonpb.StateCode_Abnormal)
 	logutil.Logger(ctx).Debug("create a new Proxy instance", zap.Any("state", node.stateCode.Load()))
@@ -294,6 +313,9 @@ func (node *Proxy) Init() error {
 	}
 	log.Debug("init meta cache done", zap.String("role", typeutil.ProxyRole))
 
+	node.initHealthChecker()
+	node.initDegradedMonitor()
+
 	log.Info("init proxy done", zap.Int64("nodeID", paramtable.GetNodeID()), zap.String("Address", node.address))
 	return nil
 }
@@ -409,6 +431,18 @@ func (node *Proxy) Start() error {
 
 	node.sendChannelsTimeTickLoop()
 
+	node.initExportJobManager()
+	node.initAnalyzeJobManager()
+	node.deleteManager = newDeleteJobManager()
+	node.vectorURLWriter = newVectorURLWriter(node.getChunkManager)
+	node.vectorURLWriter.start()
+	node.txnManager.start()
+	node.startHealthChecker()
+	if node.degradedMonitor != nil {
+		node.degradedMonitor.start(Params.CommonCfg.DegradedModeCheckInterval.GetAsDuration(time.Second))
+	}
+	RegisterMgrRoute(node)
+
 	// Start callbacks
 	for _, cb := range node.startCallbacks {
 		cb()
@@ -439,6 +473,30 @@ func (node *Proxy) Stop() error {
 		log.Info("close scheduler", zap.String("role", typeutil.ProxyRole))
 	}
 
+	if node.vectorURLWriter != nil {
+		node.vectorURLWriter.close()
+	}
+
+	if node.txnManager != nil {
+		node.txnManager.close()
+	}
+
+	if node.healthChecker != nil {
+		node.healthChecker.Stop()
+	}
+
+	if node.slowLogger != nil {
+		node.slowLogger.close()
+	}
+
+	if node.degradedMonitor != nil {
+		node.degradedMonitor.close()
+	}
+
+	if node.mutationBatcher != nil {
+		node.mutationBatcher.close()
+	}
+
 	if node.chTicker != nil {
 		err := node.chTicker.close()
 		if err != nil {
@@ -509,6 +567,20 @@ func (node *Proxy) SetEtcdClient(client *clientv3.Client) {
 }
 
 // SetRootCoordClient sets RootCoord client for proxy.
+// getChunkManager returns the chunk manager of object storage, it's created in the first call.
+func (node *Proxy) getChunkManager(ctx context.Context) (storage.ChunkManager, error) {
+	node.chunkManagerMu.Lock()
+	defer node.chunkManagerMu.Unlock()
+	if node.chunkManager == nil {
+		cm, err := node.factory.NewPersistentStorageChunkManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+		node.chunkManager = cm
+	}
+	return node.chunkManager, nil
+}
+
 func (node *Proxy) SetRootCoordClient(cli types.RootCoordClient) {
 	node.rootCoord = cli
 }
@@ -0,0 +1,133 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+const (
+	MaxScannedRowsKey = "max_scanned_rows"
+	MaxResultBytesKey = "max_result_bytes"
+	TimeoutClassKey   = "timeout_class"
+)
+
+// withQueryLimits returns the context limited by the resource limits in the params of search or query,
+// e.g. {"max_scanned_rows": "1000000", "max_result_bytes": "1048576", "timeout_class": "interactive"},
+// so the exploratory requests can't take too much resource of the query nodes.
+// The limits of rows and bytes are lowered to the configured ones, and enforced by the query nodes for the part
+// of the request they serve, and by proxy for the whole request, see chargeQueryLimits,
+// the requests exceeding them fail with merr.ErrServiceQueryLimitExceeded.
+// The timeout class shortens the deadline of the request to the timeout of the class.
+func withQueryLimits(ctx context.Context, params []*commonpb.KeyValuePair) (context.Context, context.CancelFunc, error) {
+	limits := contextutil.QueryLimits{
+		MaxScannedRows: Params.ProxyCfg.QueryMaxScannedRows.GetAsInt64(),
+		MaxResultBytes: Params.ProxyCfg.QueryMaxResultBytes.GetAsInt64(),
+	}
+	var timeout time.Duration
+	for _, kv := range params {
+		var err error
+		switch kv.GetKey() {
+		case MaxScannedRowsKey:
+			limits.MaxScannedRows, err = lowerQueryLimit(kv, limits.MaxScannedRows)
+		case MaxResultBytesKey:
+			limits.MaxResultBytes, err = lowerQueryLimit(kv, limits.MaxResultBytes)
+		case TimeoutClassKey:
+			timeout, err = queryTimeoutOfClass(kv.GetValue())
+		}
+		if err != nil {
+			return ctx, func() {}, err
+		}
+	}
+
+	ctx = contextutil.WithQueryLimits(ctx, limits)
+	if !limits.IsZero() {
+		ctx = context.WithValue(ctx, queryLimitTrackerKey{}, &queryLimitTracker{limits: limits})
+	}
+	if timeout <= 0 {
+		return ctx, func() {}, nil
+	}
+	// the deadline is carried to the query nodes by the rpcs
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, nil
+}
+
+// lowerQueryLimit returns the limit of the param if it's lower than the configured one.
+func lowerQueryLimit(kv *commonpb.KeyValuePair, configured int64) (int64, error) {
+	limit, err := strconv.ParseInt(kv.GetValue(), 10, 64)
+	if err != nil || limit <= 0 {
+		return 0, merr.WrapErrParameterInvalidMsg("%s should be a positive integer, but got %s", kv.GetKey(), kv.GetValue())
+	}
+	if configured > 0 && configured < limit {
+		return configured, nil
+	}
+	return limit, nil
+}
+
+func queryTimeoutOfClass(class string) (time.Duration, error) {
+	classes := Params.ProxyCfg.QueryTimeoutClasses.GetValue()
+	value, ok := classes[strings.ToLower(class)]
+	if !ok {
+		return 0, merr.WrapErrParameterInvalidMsg("unknown %s %s", TimeoutClassKey, class)
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds <= 0 {
+		return 0, merr.WrapErrParameterInvalidMsg("invalid timeout %s of %s %s", value, TimeoutClassKey, class)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+type queryLimitTrackerKey struct{}
+
+// queryLimitTracker accumulates the rows scanned and the result bytes of all the shards of a request,
+// including the ones of its requery.
+type queryLimitTracker struct {
+	limits      contextutil.QueryLimits
+	scannedRows atomic.Int64
+	resultBytes atomic.Int64
+}
+
+// chargeQueryLimits charges the result of a shard to the limits of the request,
+// fails if the total of the shards returned so far exceeds any of them.
+func chargeQueryLimits(ctx context.Context, cost *internalpb.CostAggregation, result proto.Message) error {
+	tracker, ok := ctx.Value(queryLimitTrackerKey{}).(*queryLimitTracker)
+	if !ok {
+		return nil
+	}
+	if limit := tracker.limits.MaxScannedRows; limit > 0 {
+		if rows := tracker.scannedRows.Add(cost.GetScannedRows()); rows > limit {
+			return merr.WrapErrServiceQueryLimitExceeded("maxScannedRows", rows, limit, "too many rows to scan")
+		}
+	}
+	if limit := tracker.limits.MaxResultBytes; limit > 0 {
+		if size := tracker.resultBytes.Add(int64(proto.Size(result))); size > limit {
+			return merr.WrapErrServiceQueryLimitExceeded("maxResultBytes", size, limit, "results too large")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestWithQueryLimits(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	ctx := context.Background()
+
+	t.Run("unlimited", func(t *testing.T) {
+		limited, cancel, err := withQueryLimits(ctx, nil)
+		require.NoError(t, err)
+		defer cancel()
+		assert.True(t, contextutil.GetQueryLimits(limited).IsZero())
+		_, ok := limited.Deadline()
+		assert.False(t, ok)
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		params.Save(params.ProxyCfg.QueryMaxScannedRows.Key, "1000")
+		defer params.Reset(params.ProxyCfg.QueryMaxScannedRows.Key)
+		params.Save(params.ProxyCfg.QueryTimeoutClasses.KeyPrefix+"interactive", "5")
+		defer params.Reset(params.ProxyCfg.QueryTimeoutClasses.KeyPrefix + "interactive")
+
+		limited, cancel, err := withQueryLimits(ctx, []*commonpb.KeyValuePair{
+			{Key: MaxScannedRowsKey, Value: "2000"},
+			{Key: MaxResultBytesKey, Value: "1024"},
+			{Key: TimeoutClassKey, Value: "interactive"},
+		})
+		require.NoError(t, err)
+		defer cancel()
+		// the requests can't exceed the configured limits
+		assert.Equal(t, contextutil.QueryLimits{MaxScannedRows: 1000, MaxResultBytes: 1024}, contextutil.GetQueryLimits(limited))
+		deadline, ok := limited.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(5*time.Second), deadline, time.Second)
+
+		limited, cancel, err = withQueryLimits(ctx, []*commonpb.KeyValuePair{{Key: MaxScannedRowsKey, Value: "10"}})
+		require.NoError(t, err)
+		defer cancel()
+		assert.EqualValues(t, 10, contextutil.GetQueryLimits(limited).MaxScannedRows)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, kv := range []*commonpb.KeyValuePair{
+			{Key: MaxScannedRowsKey, Value: "x"},
+			{Key: MaxResultBytesKey, Value: "-1"},
+			{Key: TimeoutClassKey, Value: "unknown"},
+		} {
+			_, _, err := withQueryLimits(ctx, []*commonpb.KeyValuePair{kv})
+			assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+		}
+	})
+}
+
+func TestChargeQueryLimits(t *testing.T) {
+	paramtable.Init()
+	result := &internalpb.RetrieveResults{
+		Ids:             &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}}}},
+		CostAggregation: &internalpb.CostAggregation{ScannedRows: 100},
+	}
+	size := proto.Size(result)
+
+	unlimited, cancel, err := withQueryLimits(context.Background(), nil)
+	require.NoError(t, err)
+	defer cancel()
+	assert.NoError(t, chargeQueryLimits(unlimited, result.GetCostAggregation(), result))
+
+	t.Run("scanned rows", func(t *testing.T) {
+		limited, cancel, err := withQueryLimits(context.Background(), []*commonpb.KeyValuePair{{Key: MaxScannedRowsKey, Value: "200"}})
+		require.NoError(t, err)
+		defer cancel()
+		// the rows scanned of all the shards are summed up
+		assert.NoError(t, chargeQueryLimits(limited, result.GetCostAggregation(), result))
+		assert.NoError(t, chargeQueryLimits(limited, result.GetCostAggregation(), result))
+		assert.ErrorIs(t, chargeQueryLimits(limited, result.GetCostAggregation(), result), merr.ErrServiceQueryLimitExceeded)
+	})
+
+	t.Run("result bytes", func(t *testing.T) {
+		limited, cancel, err := withQueryLimits(context.Background(), []*commonpb.KeyValuePair{
+			{Key: MaxResultBytesKey, Value: strconv.Itoa(2 * size)},
+		})
+		require.NoError(t, err)
+		defer cancel()
+		assert.NoError(t, chargeQueryLimits(limited, result.GetCostAggregation(), result))
+		assert.NoError(t, chargeQueryLimits(limited, result.GetCostAggregation(), result))
+		assert.ErrorIs(t, chargeQueryLimits(limited, result.GetCostAggregation(), result), merr.ErrServiceQueryLimitExceeded)
+	})
+}
@@ -0,0 +1,170 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// maxReportedRowErrors is the max number of row errors reported in the status detail of a mutation.
+const maxReportedRowErrors = 100
+
+// rowError is the violation of the schema by a row of the insert/upsert request.
+type rowError struct {
+	Index  int    `json:"index"`
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// rowErrorsDetail is the status detail of the mutations which have row errors.
+type rowErrorsDetail struct {
+	RowErrors []*rowError `json:"row_errors"`
+	// Truncated is the number of the row errors not reported
+	Truncated int `json:"truncated,omitempty"`
+}
+
+// isPartialMutation returns whether the client asks the insert/upsert to skip the invalid rows.
+func isPartialMutation(ctx context.Context) (bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	values := md[strings.ToLower(util.HeaderPartialMutation)]
+	if len(values) < 1 || values[0] == "" {
+		return false, nil
+	}
+	partial, err := strconv.ParseBool(values[0])
+	if err != nil {
+		return false, merr.WrapErrParameterInvalidMsg("invalid %s: %s", util.HeaderPartialMutation, values[0])
+	}
+	return partial, nil
+}
+
+// findRowErrors validates the fields which fail the validation row by row, and returns the errors of the invalid rows.
+// Returns error if the invalid field can't be split into rows, e.g. the data length doesn't match the dim,
+// so the whole batch is invalid.
+func (v *validateUtil) findRowErrors(data []*schemapb.FieldData, schema *schemapb.CollectionSchema, numRows uint64) ([]*rowError, error) {
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	rowErrors := make([]*rowError, 0)
+	for _, field := range data {
+		fieldSchema, err := helper.GetFieldFromName(field.GetFieldName())
+		if err != nil {
+			// unknown fields are rejected by the validation of the whole batch
+			continue
+		}
+		fieldErr := v.checkFieldData(field, fieldSchema)
+		if fieldErr == nil {
+			continue
+		}
+		rows, err := funcutil.GetNumRowOfFieldData(field)
+		if err != nil || rows != numRows {
+			return nil, fieldErr
+		}
+
+		found := false
+		for i := 0; i < int(rows); i++ {
+			row := make([]*schemapb.FieldData, 1)
+			typeutil.AppendFieldDataByOffsets(row, []*schemapb.FieldData{field}, []int{i})
+			if err := v.checkFieldData(row[0], fieldSchema); err != nil {
+				rowErrors = append(rowErrors, &rowError{Index: i, Field: field.GetFieldName(), Reason: err.Error()})
+				found = true
+			}
+		}
+		if !found {
+			return nil, fieldErr
+		}
+	}
+	return rowErrors, nil
+}
+
+// dropInvalidRows removes the rows violating the schema from the data,
+// returns the remaining data and the indexes of the remaining rows in the request.
+func dropInvalidRows(data []*schemapb.FieldData, numRows uint64, rowErrors []*rowError) ([]*schemapb.FieldData, []uint32, error) {
+	invalid := typeutil.NewSet(lo.Map(rowErrors, func(e *rowError, _ int) int { return e.Index })...)
+	offsets := make([]int, 0, int(numRows)-invalid.Len())
+	for i := 0; i < int(numRows); i++ {
+		if !invalid.Contain(i) {
+			offsets = append(offsets, i)
+		}
+	}
+	if len(offsets) == 0 {
+		return nil, nil, merr.WrapErrParameterInvalidMsg("all the %d rows are invalid", numRows)
+	}
+
+	remaining := make([]*schemapb.FieldData, len(data))
+	for i, field := range data {
+		rows, err := funcutil.GetNumRowOfFieldData(field)
+		if err != nil || rows != numRows {
+			// the fields filled by default values or invalid are left to the validation
+			remaining[i] = field
+			continue
+		}
+		filtered := make([]*schemapb.FieldData, 1)
+		typeutil.AppendFieldDataByOffsets(filtered, []*schemapb.FieldData{field}, offsets)
+		remaining[i] = filtered[0]
+	}
+	return remaining, lo.Map(offsets, func(offset int, _ int) uint32 { return uint32(offset) }), nil
+}
+
+// setRowErrors maps the result of the remaining rows back to the rows of request,
+// and reports the row errors in the status detail.
+func setRowErrors(result *milvuspb.MutationResult, rowIndexes []uint32, rowErrors []*rowError) {
+	if len(rowErrors) == 0 {
+		return
+	}
+	if rowIndexes != nil {
+		result.SuccIndex = lo.Map(result.GetSuccIndex(), func(i uint32, _ int) uint32 { return rowIndexes[i] })
+		// a row may violate the schema in several fields
+		errIndex := lo.Uniq(lo.Map(rowErrors, func(e *rowError, _ int) uint32 { return uint32(e.Index) }))
+		sort.Slice(errIndex, func(i, j int) bool { return errIndex[i] < errIndex[j] })
+		result.ErrIndex = errIndex
+	}
+	if result.GetStatus() != nil {
+		result.Status.Detail = formatRowErrors(rowErrors)
+	}
+}
+
+func formatRowErrors(rowErrors []*rowError) string {
+	detail := &rowErrorsDetail{RowErrors: rowErrors}
+	if len(rowErrors) > maxReportedRowErrors {
+		detail.RowErrors = rowErrors[:maxReportedRowErrors]
+		detail.Truncated = len(rowErrors) - maxReportedRowErrors
+	}
+	bs, err := json.Marshal(detail)
+	if err != nil {
+		return ""
+	}
+	return string(bs)
+}
@@ -0,0 +1,146 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestIsPartialMutation(t *testing.T) {
+	partial, err := isPartialMutation(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, partial)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderPartialMutation, "true"))
+	partial, err = isPartialMutation(ctx)
+	assert.NoError(t, err)
+	assert.True(t, partial)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderPartialMutation, "yes"))
+	_, err = isPartialMutation(ctx)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestRowErrors(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{
+				FieldID:  101,
+				Name:     "name",
+				DataType: schemapb.DataType_VarChar,
+				TypeParams: []*commonpb.KeyValuePair{
+					{Key: common.MaxLengthKey, Value: "3"},
+				},
+			},
+		},
+	}
+	data := []*schemapb.FieldData{
+		{
+			FieldName: "pk",
+			Type:      schemapb.DataType_Int64,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2, 3}}},
+				},
+			},
+		},
+		{
+			FieldName: "name",
+			Type:      schemapb.DataType_VarChar,
+			Field: &schemapb.FieldData_Scalars{
+				Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"abcd", "ab", "wxyz"}}},
+				},
+			},
+		},
+	}
+
+	v := newValidateUtil(withMaxLenCheck())
+	assert.Error(t, v.Validate(data, schema, 3))
+	rowErrors, err := v.findRowErrors(data, schema, 3)
+	require.NoError(t, err)
+	require.Len(t, rowErrors, 2)
+	assert.Equal(t, 0, rowErrors[0].Index)
+	assert.Equal(t, 2, rowErrors[1].Index)
+	assert.Equal(t, "name", rowErrors[0].Field)
+
+	// the length of the field doesn't match the rows, the whole batch is invalid
+	_, err = v.findRowErrors(data, schema, 4)
+	assert.Error(t, err)
+
+	remaining, rowIndexes, err := dropInvalidRows(data, 3, rowErrors)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1}, rowIndexes)
+	assert.Equal(t, []int64{2}, remaining[0].GetScalars().GetLongData().GetData())
+	assert.Equal(t, []string{"ab"}, remaining[1].GetScalars().GetStringData().GetData())
+	assert.NoError(t, v.Validate(remaining, schema, 1))
+
+	_, _, err = dropInvalidRows(data, 3, append(rowErrors, &rowError{Index: 1}))
+	assert.Error(t, err)
+
+	// the upsert skips the invalid rows from the message and keeps the request as it is
+	req := &milvuspb.UpsertRequest{FieldsData: data, NumRows: 3, HashKeys: []uint32{7, 8, 9}}
+	upsert := &upsertTask{
+		req:     req,
+		schema:  schema,
+		baseMsg: msgstream.BaseMsg{HashValues: req.HashKeys},
+		upsertMsg: &msgstream.UpsertMsg{
+			InsertMsg: &msgstream.InsertMsg{InsertRequest: msgpb.InsertRequest{FieldsData: req.FieldsData, NumRows: 3}},
+			DeleteMsg: &msgstream.DeleteMsg{DeleteRequest: msgpb.DeleteRequest{NumRows: 3}},
+		},
+	}
+	require.NoError(t, upsert.skipInvalidRows())
+	assert.Equal(t, []uint32{1}, upsert.rowIndexes)
+	assert.Equal(t, []uint32{8}, upsert.baseMsg.HashValues)
+	assert.EqualValues(t, 1, upsert.upsertMsg.InsertMsg.NRows())
+	assert.EqualValues(t, 1, upsert.upsertMsg.DeleteMsg.NumRows)
+	assert.EqualValues(t, 3, req.GetNumRows())
+	assert.Len(t, req.GetFieldsData()[0].GetScalars().GetLongData().GetData(), 3)
+
+	result := &milvuspb.MutationResult{Status: merr.Success(), SuccIndex: []uint32{0}}
+	setRowErrors(result, rowIndexes, rowErrors)
+	assert.Equal(t, []uint32{1}, result.GetSuccIndex())
+	assert.Equal(t, []uint32{0, 2}, result.GetErrIndex())
+	detail := &rowErrorsDetail{}
+	require.NoError(t, json.Unmarshal([]byte(result.GetStatus().GetDetail()), detail))
+	assert.Len(t, detail.RowErrors, 2)
+	assert.Zero(t, detail.Truncated)
+
+	many := make([]*rowError, maxReportedRowErrors+5)
+	for i := range many {
+		many[i] = &rowError{Index: i, Field: "name", Reason: "too long"}
+	}
+	require.NoError(t, json.Unmarshal([]byte(formatRowErrors(many)), detail))
+	assert.Len(t, detail.RowErrors, maxReportedRowErrors)
+	assert.Equal(t, 5, detail.Truncated)
+}
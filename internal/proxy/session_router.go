@@ -0,0 +1,145 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proxy/connection"
+)
+
+// sessionRouter keeps the write timestamps and the read routes of the clients reading at Session consistency.
+// A client reads its own writes by waiting for the timestamp of its last write, and keeps reading from
+// the same shard delegators instead of jumping between the replicas which may serve different views.
+type sessionRouter struct {
+	mu        sync.Mutex
+	sessions  map[int64]*routingSession // client identifier -> session
+	lastSweep time.Time
+}
+
+type routingSession struct {
+	writeTs    map[UniqueID]Timestamp // collection -> timestamp of the last write
+	nodes      map[string]int64       // channel -> shard delegator serving the reads
+	lastActive time.Time
+}
+
+func newSessionRouter() *sessionRouter {
+	return &sessionRouter{
+		sessions:  make(map[int64]*routingSession),
+		lastSweep: time.Now(),
+	}
+}
+
+var (
+	sessionRouterInstance *sessionRouter
+	getSessionRouterOnce  sync.Once
+)
+
+func getSessionRouter() *sessionRouter {
+	getSessionRouterOnce.Do(func() {
+		sessionRouterInstance = newSessionRouter()
+	})
+	return sessionRouterInstance
+}
+
+// sessionClient returns the identifier of the client if the sticky routing applies, 0 otherwise.
+func sessionClient(ctx context.Context) int64 {
+	if !Params.ProxyCfg.SessionStickyRouting.GetAsBool() {
+		return 0
+	}
+	identifier, err := connection.GetIdentifierFromContext(ctx)
+	if err != nil {
+		return 0
+	}
+	return identifier
+}
+
+// recordWrite records the timestamp of the write of the client to the collection.
+func (r *sessionRouter) recordWrite(ctx context.Context, collectionID UniqueID, ts Timestamp) {
+	client := sessionClient(ctx)
+	if client == 0 || ts == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := r.getSession(client)
+	if ts > session.writeTs[collectionID] {
+		session.writeTs[collectionID] = ts
+	}
+}
+
+// guaranteeTs returns the guarantee timestamp for the read of the client,
+// which is no earlier than the last write of the client to the collection.
+func (r *sessionRouter) guaranteeTs(client int64, collectionID UniqueID, ts Timestamp) Timestamp {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[client]
+	if !ok {
+		return ts
+	}
+	session.lastActive = time.Now()
+	if writeTs := session.writeTs[collectionID]; writeTs > ts {
+		return writeTs
+	}
+	return ts
+}
+
+// node returns the shard delegator serving the reads of the client on the channel.
+func (r *sessionRouter) node(client int64, channel string) (int64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[client]
+	if !ok {
+		return 0, false
+	}
+	node, ok := session.nodes[channel]
+	return node, ok
+}
+
+// stick routes the following reads of the client on the channel to the node.
+func (r *sessionRouter) stick(client int64, channel string, node int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.getSession(client).nodes[channel] = node
+}
+
+// getSession returns the session of the client, and sweeps the idle sessions, requires that r.mu is held.
+func (r *sessionRouter) getSession(client int64) *routingSession {
+	now := time.Now()
+	ttl := Params.ProxyCfg.SessionStickyTTL.GetAsDuration(time.Second)
+	if now.Sub(r.lastSweep) > ttl {
+		for id, session := range r.sessions {
+			if now.Sub(session.lastActive) > ttl {
+				delete(r.sessions, id)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	session, ok := r.sessions[client]
+	if !ok {
+		session = &routingSession{
+			writeTs: make(map[UniqueID]Timestamp),
+			nodes:   make(map[string]int64),
+		}
+		r.sessions[client] = session
+	}
+	session.lastActive = now
+	return session
+}
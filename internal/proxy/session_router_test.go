@@ -0,0 +1,88 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestSessionRouter(t *testing.T) {
+	paramtable.Init()
+	client := int64(2000)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.IdentifierKey, strconv.FormatInt(client, 10)))
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, int64(0), sessionClient(ctx))
+	})
+
+	paramtable.Get().Save(Params.ProxyCfg.SessionStickyRouting.Key, "true")
+	defer paramtable.Get().Reset(Params.ProxyCfg.SessionStickyRouting.Key)
+
+	t.Run("session client", func(t *testing.T) {
+		assert.Equal(t, client, sessionClient(ctx))
+		assert.Equal(t, int64(0), sessionClient(context.Background()))
+	})
+
+	t.Run("read own writes", func(t *testing.T) {
+		router := newSessionRouter()
+		assert.Equal(t, Timestamp(100), router.guaranteeTs(client, 1, 100))
+
+		router.recordWrite(ctx, 1, 200)
+		router.recordWrite(ctx, 1, 150)
+		assert.Equal(t, Timestamp(200), router.guaranteeTs(client, 1, 100))
+		assert.Equal(t, Timestamp(300), router.guaranteeTs(client, 1, 300))
+		assert.Equal(t, Timestamp(100), router.guaranteeTs(client, 2, 100))
+		assert.Equal(t, Timestamp(100), router.guaranteeTs(client+1, 1, 100))
+	})
+
+	t.Run("stick", func(t *testing.T) {
+		router := newSessionRouter()
+		_, ok := router.node(client, "ch1")
+		assert.False(t, ok)
+
+		router.stick(client, "ch1", 3)
+		node, ok := router.node(client, "ch1")
+		assert.True(t, ok)
+		assert.Equal(t, int64(3), node)
+		_, ok = router.node(client, "ch2")
+		assert.False(t, ok)
+	})
+
+	t.Run("sweep idle sessions", func(t *testing.T) {
+		paramtable.Get().Save(Params.ProxyCfg.SessionStickyTTL.Key, "0")
+		defer paramtable.Get().Reset(Params.ProxyCfg.SessionStickyTTL.Key)
+
+		router := newSessionRouter()
+		router.stick(client, "ch1", 3)
+		time.Sleep(time.Millisecond)
+		router.stick(client+1, "ch1", 4)
+		_, ok := router.node(client, "ch1")
+		assert.False(t, ok)
+		node, ok := router.node(client+1, "ch1")
+		assert.True(t, ok)
+		assert.Equal(t, int64(4), node)
+	})
+}
@@ -18,6 +18,8 @@ type queryNodeCreatorFunc func(ctx context.Context, addr string, nodeID int64) (
 type nodeInfo struct {
 	nodeID  UniqueID
 	address string
+	// rank of the replica the shard leader belongs to, ordered by replica ID
+	replicaRank int32
 }
 
 func (n nodeInfo) String() string {
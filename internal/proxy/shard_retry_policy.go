@@ -0,0 +1,194 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// shardErrorClass classifies the errors of search/query on shard delegators.
+type shardErrorClass string
+
+const (
+	// the shard leader moved or is not serving the channel, retry with the refreshed shard leaders
+	shardErrLeaderChanged shardErrorClass = "leader_changed"
+	// the query node is unreachable or not ready
+	shardErrNodeUnavailable shardErrorClass = "node_unavailable"
+	// the query node rejects the request due to resource limits
+	shardErrOverloaded shardErrorClass = "overloaded"
+	// the request itself is invalid, e.g. bad plan, which fails on any replica
+	shardErrBadRequest shardErrorClass = "bad_request"
+	// the request is canceled or timeout
+	shardErrCanceled shardErrorClass = "canceled"
+	shardErrUnknown  shardErrorClass = "unknown"
+)
+
+// retriable returns whether the request may succeed on retrying.
+func (c shardErrorClass) retriable() bool {
+	return c != shardErrBadRequest && c != shardErrCanceled
+}
+
+// classifyShardError classifies the error returned by executing the workload on a shard delegator,
+// the context errors are regarded as canceled only if the request context is done.
+func classifyShardError(ctx context.Context, err error) shardErrorClass {
+	switch {
+	case ctx.Err() != nil, errors.Is(err, merr.ErrSegcoreRequestCanceled):
+		return shardErrCanceled
+	case errors.Is(err, errInvalidShardLeaders),
+		errors.Is(err, merr.ErrChannelNotFound),
+		errors.Is(err, merr.ErrChannelNotAvailable),
+		errors.Is(err, merr.ErrChannelLack),
+		errors.Is(err, merr.ErrNodeNotMatch),
+		errors.Is(err, merr.ErrReplicaNotAvailable),
+		errors.Is(err, merr.ErrSegmentNotLoaded),
+		errors.Is(err, merr.ErrSegmentLack),
+		errors.Is(err, merr.ErrCollectionNotLoaded),
+		errors.Is(err, merr.ErrPartitionNotLoaded):
+		return shardErrLeaderChanged
+	case errors.Is(err, merr.ErrNodeNotFound),
+		errors.Is(err, merr.ErrNodeOffline),
+		errors.Is(err, merr.ErrNodeNotAvailable),
+		errors.Is(err, merr.ErrServiceNotReady),
+		errors.Is(err, merr.ErrServiceUnavailable),
+		errors.Is(err, merr.ErrSegcoreFieldNotLoaded),
+		merr.IsCanceledOrTimeout(err),
+		funcutil.IsGrpcErr(err, codes.Unavailable, codes.Canceled, codes.DeadlineExceeded):
+		return shardErrNodeUnavailable
+	case errors.Is(err, merr.ErrServiceRateLimit),
+		errors.Is(err, merr.ErrServiceRequestLimitExceeded),
+		errors.Is(err, merr.ErrServiceMemoryLimitExceeded),
+		errors.Is(err, merr.ErrServiceDiskLimitExceeded),
+		errors.Is(err, merr.ErrSegcoreOutOfMemory):
+		return shardErrOverloaded
+	case errors.Is(err, merr.ErrParameterInvalid),
+		errors.Is(err, merr.ErrFieldNotFound),
+		errors.Is(err, merr.ErrIndexNotFound),
+		errors.Is(err, merr.ErrSegcorePlanInvalid),
+		errors.Is(err, merr.ErrServiceUnimplemented),
+		errors.Is(err, merr.ErrServiceQueryLimitExceeded),
+		errors.Is(err, merr.ErrPrivilegeNotPermitted):
+		return shardErrBadRequest
+	default:
+		return shardErrUnknown
+	}
+}
+
+// newShardRetryBudget returns the retry budget shared by all shards of one request, nil means no limit.
+func newShardRetryBudget() *atomic.Int64 {
+	budget := paramtable.Get().ProxyCfg.ShardRetryBudget.GetAsInt64()
+	if budget <= 0 {
+		return nil
+	}
+	return atomic.NewInt64(budget)
+}
+
+// shardRetryPolicy retries the workload on shard delegators with exponential backoff and jitter,
+// until it succeeds, or the error is not retriable, or the attempts or the retry budget run out.
+type shardRetryPolicy struct {
+	attempts   uint
+	backoff    time.Duration
+	maxBackoff time.Duration
+	jitter     float64
+	budget     *atomic.Int64
+
+	// called before retrying if the shard leaders may be stale
+	refreshLeaders func()
+}
+
+func newShardRetryPolicy(attempts uint, budget *atomic.Int64, refreshLeaders func()) *shardRetryPolicy {
+	params := paramtable.Get()
+	jitter := params.ProxyCfg.ShardRetryBackoffJitter.GetAsFloat()
+	if jitter < 0 {
+		jitter = 0
+	} else if jitter > 1 {
+		jitter = 1
+	}
+	return &shardRetryPolicy{
+		attempts:       attempts,
+		backoff:        params.ProxyCfg.ShardRetryBackoffInitial.GetAsDuration(time.Millisecond),
+		maxBackoff:     params.ProxyCfg.ShardRetryBackoffMax.GetAsDuration(time.Millisecond),
+		jitter:         jitter,
+		budget:         budget,
+		refreshLeaders: refreshLeaders,
+	}
+}
+
+// acquire takes one retry from the budget, returns false if the budget runs out.
+func (p *shardRetryPolicy) acquire() bool {
+	return p.budget == nil || p.budget.Dec() >= 0
+}
+
+// nextBackoff returns the backoff before the i-th retry, which grows exponentially with random jitter.
+func (p *shardRetryPolicy) nextBackoff(i uint) time.Duration {
+	backoff := p.backoff
+	for ; i > 0 && backoff < p.maxBackoff; i-- {
+		backoff *= 2
+	}
+	if backoff > p.maxBackoff {
+		backoff = p.maxBackoff
+	}
+	return time.Duration(float64(backoff) * (1 + p.jitter*(2*rand.Float64()-1)))
+}
+
+func (p *shardRetryPolicy) record(class shardErrorClass, status string) {
+	metrics.ProxyShardRetryCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), string(class), status).Inc()
+}
+
+// Do executes fn with retries, returns the last meaningful error if all the attempts failed.
+func (p *shardRetryPolicy) Do(ctx context.Context, fn func() error) error {
+	var lastErr error
+	for i := uint(0); i < p.attempts; i++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		class := classifyShardError(ctx, err)
+		if class == shardErrCanceled && lastErr != nil {
+			// the error caused by the request context is meaningless
+			err = lastErr
+		}
+		if !class.retriable() || i+1 >= p.attempts || !p.acquire() {
+			p.record(class, metrics.AbandonLabel)
+			return err
+		}
+		p.record(class, metrics.RetryLabel)
+		lastErr = err
+
+		if (class == shardErrLeaderChanged || class == shardErrNodeUnavailable) && p.refreshLeaders != nil {
+			p.refreshLeaders()
+		}
+		select {
+		case <-time.After(p.nextBackoff(i)):
+		case <-ctx.Done():
+			return lastErr
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,153 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type ShardRetryPolicySuite struct {
+	suite.Suite
+}
+
+func (s *ShardRetryPolicySuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *ShardRetryPolicySuite) TestClassify() {
+	ctx := context.Background()
+	s.Equal(shardErrLeaderChanged, classifyShardError(ctx, errInvalidShardLeaders))
+	s.Equal(shardErrLeaderChanged, classifyShardError(ctx, merr.WrapErrChannelNotAvailable("ch")))
+	s.Equal(shardErrLeaderChanged, classifyShardError(ctx, merr.WrapErrSegmentNotLoaded(1)))
+	s.Equal(shardErrNodeUnavailable, classifyShardError(ctx, merr.WrapErrServiceNotReady("querynode", 1, "initializing")))
+	s.Equal(shardErrNodeUnavailable, classifyShardError(ctx, status.Error(codes.Unavailable, "connection refused")))
+	s.Equal(shardErrNodeUnavailable, classifyShardError(ctx, context.DeadlineExceeded))
+	s.Equal(shardErrOverloaded, classifyShardError(ctx, merr.WrapErrServiceMemoryLimitExceeded(100, 10)))
+	s.Equal(shardErrBadRequest, classifyShardError(ctx, merr.WrapErrParameterInvalidMsg("bad plan")))
+	s.Equal(shardErrNodeUnavailable, classifyShardError(ctx, merr.WrapErrSegcoreFieldNotLoaded(2027, "index not loaded")))
+	s.Equal(shardErrOverloaded, classifyShardError(ctx, merr.WrapErrSegcoreOutOfMemory(2029, "bad alloc")))
+	s.Equal(shardErrBadRequest, classifyShardError(ctx, merr.WrapErrSegcorePlanInvalid(2028, "bad expr")))
+	s.Equal(shardErrBadRequest, classifyShardError(ctx, merr.WrapErrServiceQueryLimitExceeded("maxScannedRows", 200, 100)))
+	s.Equal(shardErrCanceled, classifyShardError(ctx, merr.WrapErrSegcoreRequestCanceled(1)))
+	s.Equal(shardErrUnknown, classifyShardError(ctx, errors.New("mock error")))
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	s.Equal(shardErrCanceled, classifyShardError(canceledCtx, context.Canceled))
+}
+
+func (s *ShardRetryPolicySuite) TestDo() {
+	ctx := context.Background()
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.ShardRetryBackoffInitial.Key, "1")
+	defer params.Reset(params.ProxyCfg.ShardRetryBackoffInitial.Key)
+
+	s.Run("retry until success", func() {
+		counter := 0
+		refreshed := 0
+		policy := newShardRetryPolicy(3, nil, func() { refreshed++ })
+		err := policy.Do(ctx, func() error {
+			counter++
+			if counter < 3 {
+				return errInvalidShardLeaders
+			}
+			return nil
+		})
+		s.NoError(err)
+		s.Equal(3, counter)
+		s.Equal(2, refreshed)
+	})
+
+	s.Run("not retriable", func() {
+		counter := 0
+		policy := newShardRetryPolicy(3, nil, nil)
+		err := policy.Do(ctx, func() error {
+			counter++
+			return merr.WrapErrParameterInvalidMsg("bad plan")
+		})
+		s.ErrorIs(err, merr.ErrParameterInvalid)
+		s.Equal(1, counter)
+	})
+
+	s.Run("attempts run out", func() {
+		counter := 0
+		policy := newShardRetryPolicy(3, nil, nil)
+		err := policy.Do(ctx, func() error {
+			counter++
+			return errors.New("mock error")
+		})
+		s.Error(err)
+		s.Equal(3, counter)
+	})
+
+	s.Run("budget run out", func() {
+		budget := atomic.NewInt64(2)
+		counter := 0
+		for i := 0; i < 2; i++ {
+			policy := newShardRetryPolicy(3, budget, nil)
+			err := policy.Do(ctx, func() error {
+				counter++
+				return errors.New("mock error")
+			})
+			s.Error(err)
+		}
+		// the first workload takes all the budget, the second one is not retried
+		s.Equal(4, counter)
+	})
+
+	s.Run("canceled", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		counter := 0
+		policy := newShardRetryPolicy(3, nil, nil)
+		err := policy.Do(ctx, func() error {
+			counter++
+			if counter == 1 {
+				return merr.WrapErrNodeNotAvailable(1)
+			}
+			cancel()
+			return context.Canceled
+		})
+		s.ErrorIs(err, merr.ErrNodeNotAvailable)
+		s.Equal(2, counter)
+	})
+}
+
+func (s *ShardRetryPolicySuite) TestNextBackoff() {
+	policy := &shardRetryPolicy{
+		backoff:    10 * time.Millisecond,
+		maxBackoff: 50 * time.Millisecond,
+		jitter:     0.2,
+	}
+	s.InDelta(10*time.Millisecond, policy.nextBackoff(0), float64(2*time.Millisecond))
+	s.InDelta(20*time.Millisecond, policy.nextBackoff(1), float64(4*time.Millisecond))
+	s.InDelta(50*time.Millisecond, policy.nextBackoff(10), float64(10*time.Millisecond))
+}
+
+func TestShardRetryPolicy(t *testing.T) {
+	suite.Run(t, new(ShardRetryPolicySuite))
+}
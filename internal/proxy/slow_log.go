@@ -0,0 +1,285 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const (
+	slowLogSearch = "Search"
+	slowLogQuery  = "Query"
+	slowLogInsert = "Insert"
+)
+
+type slowLogTraceKey struct{}
+
+// SlowLogTaskCost is the time cost of the phases of a task in proxy scheduler.
+type SlowLogTaskCost struct {
+	Task        string `json:"task"`
+	QueueMs     int64  `json:"queue_ms"`
+	PreExecMs   int64  `json:"pre_execute_ms"`
+	ExecMs      int64  `json:"execute_ms"`
+	PostExecMs  int64  `json:"post_execute_ms"`
+	Interrupted bool   `json:"interrupted,omitempty"`
+}
+
+// SlowLogNodeCost is the time cost reported by a querynode,
+// the service time is spent in segcore to execute and reduce the request on segments.
+type SlowLogNodeCost struct {
+	NodeID      int64  `json:"node_id"`
+	Task        string `json:"task"`
+	ResponseMs  int64  `json:"response_ms"`
+	ServiceMs   int64  `json:"service_ms"`
+	TotalNQ     int64  `json:"total_nq"`
+	RoundTripMs int64  `json:"round_trip_ms"`
+}
+
+// SlowLogEntry is the record of a slow request.
+type SlowLogEntry struct {
+	Time        time.Time          `json:"time"`
+	Type        string             `json:"type"`
+	Database    string             `json:"db"`
+	Collection  string             `json:"collection"`
+	DurationMs  int64              `json:"duration_ms"`
+	ThresholdMs int64              `json:"threshold_ms"`
+	NQ          int64              `json:"nq,omitempty"`
+	NumRows     int64              `json:"num_rows,omitempty"`
+	Expr        string             `json:"expr,omitempty"`
+	Tasks       []*SlowLogTaskCost `json:"tasks,omitempty"`
+	Nodes       []*SlowLogNodeCost `json:"nodes,omitempty"`
+}
+
+// slowLogTrace collects the time cost of a request along the proxy, querynode and segcore.
+type slowLogTrace struct {
+	mu    sync.Mutex
+	start time.Time
+	tasks []*SlowLogTaskCost
+	nodes []*SlowLogNodeCost
+}
+
+func withSlowLogTrace(ctx context.Context) (context.Context, *slowLogTrace) {
+	if trace := getSlowLogTrace(ctx); trace != nil {
+		return ctx, nil
+	}
+	trace := &slowLogTrace{start: time.Now()}
+	return context.WithValue(ctx, slowLogTraceKey{}, trace), trace
+}
+
+func getSlowLogTrace(ctx context.Context) *slowLogTrace {
+	if ctx == nil {
+		return nil
+	}
+	trace, _ := ctx.Value(slowLogTraceKey{}).(*slowLogTrace)
+	return trace
+}
+
+// startTask returns the cost of the task which is being processed by scheduler.
+func (t *slowLogTrace) startTask(name string) *SlowLogTaskCost {
+	if t == nil {
+		return nil
+	}
+	cost := &SlowLogTaskCost{
+		Task:    name,
+		QueueMs: time.Since(t.start).Milliseconds(),
+	}
+	t.mu.Lock()
+	t.tasks = append(t.tasks, cost)
+	t.mu.Unlock()
+	return cost
+}
+
+func (t *slowLogTrace) addNodeCost(task string, nodeID int64, cost *internalpb.CostAggregation, rtt time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nodes = append(t.nodes, &SlowLogNodeCost{
+		NodeID:      nodeID,
+		Task:        task,
+		ResponseMs:  cost.GetResponseTime(),
+		ServiceMs:   cost.GetServiceTime(),
+		TotalNQ:     cost.GetTotalNQ(),
+		RoundTripMs: rtt.Milliseconds(),
+	})
+}
+
+// slowLogger records the requests whose latency exceeds the thresholds,
+// into a dedicated rotating file and a buffer of the recent entries.
+type slowLogger struct {
+	mu     sync.Mutex
+	recent []*SlowLogEntry
+	writer io.WriteCloser
+	closed bool
+}
+
+func newSlowLogger() *slowLogger {
+	return &slowLogger{}
+}
+
+// threshold returns the latency threshold of the request,
+// the threshold of the collection takes precedence over the one of the request type.
+func (l *slowLogger) threshold(typ, db, collection string) time.Duration {
+	params := paramtable.Get()
+	thresholds := params.ProxyCfg.SlowLog.CollectionThresholds.GetAsJSONMap()
+	for _, key := range []string{db + "." + collection, collection} {
+		if v, ok := thresholds[key]; ok {
+			if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return time.Duration(ms) * time.Millisecond
+			}
+			log.RatedWarn(60, "invalid slow log threshold of collection", zap.String("key", key), zap.String("value", v))
+		}
+	}
+
+	switch typ {
+	case slowLogSearch:
+		return params.ProxyCfg.SlowLog.SearchThreshold.GetAsDuration(time.Millisecond)
+	case slowLogQuery:
+		return params.ProxyCfg.SlowLog.QueryThreshold.GetAsDuration(time.Millisecond)
+	default:
+		return params.ProxyCfg.SlowLog.InsertThreshold.GetAsDuration(time.Millisecond)
+	}
+}
+
+// observe records the entry if it's slower than the threshold and sampled.
+func (l *slowLogger) observe(entry *SlowLogEntry, trace *slowLogTrace, duration time.Duration) bool {
+	params := paramtable.Get()
+	if l == nil || !params.ProxyCfg.SlowLog.Enable.GetAsBool() {
+		return false
+	}
+	threshold := l.threshold(entry.Type, entry.Database, entry.Collection)
+	if duration < threshold {
+		return false
+	}
+	if ratio := params.ProxyCfg.SlowLog.SampleRatio.GetAsFloat(); ratio < 1 && rand.Float64() >= ratio {
+		return false
+	}
+
+	entry.Time = time.Now()
+	entry.DurationMs = duration.Milliseconds()
+	entry.ThresholdMs = threshold.Milliseconds()
+	if trace != nil {
+		trace.mu.Lock()
+		entry.Tasks = append(entry.Tasks, trace.tasks...)
+		entry.Nodes = append(entry.Nodes, trace.nodes...)
+		trace.mu.Unlock()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return false
+	}
+	l.recent = append(l.recent, entry)
+	if size := params.ProxyCfg.SlowLog.RecentSize.GetAsInt(); len(l.recent) > size {
+		l.recent = append([]*SlowLogEntry{}, l.recent[len(l.recent)-size:]...)
+	}
+	l.write(entry)
+	return true
+}
+
+func (l *slowLogger) write(entry *SlowLogEntry) {
+	params := paramtable.Get()
+	if params.ProxyCfg.SlowLog.Filename.GetValue() == "" {
+		return
+	}
+	if l.writer == nil {
+		writer, err := log.NewFileWriter(&log.FileLogConfig{
+			RootPath:   params.ProxyCfg.SlowLog.LocalPath.GetValue(),
+			Filename:   params.ProxyCfg.SlowLog.Filename.GetValue(),
+			MaxSize:    params.ProxyCfg.SlowLog.MaxSize.GetAsInt(),
+			MaxBackups: params.ProxyCfg.SlowLog.MaxBackups.GetAsInt(),
+		})
+		if err != nil {
+			log.RatedWarn(60, "failed to create slow log file", zap.Error(err))
+			return
+		}
+		l.writer = writer
+	}
+
+	bs, err := json.Marshal(entry)
+	if err != nil {
+		log.Warn("failed to marshal slow log", zap.Error(err))
+		return
+	}
+	if _, err := l.writer.Write(append(bs, '\n')); err != nil {
+		log.RatedWarn(60, "failed to write slow log", zap.Error(err))
+	}
+}
+
+// getRecent returns the recent entries in time order, filtered by the collection if it's not empty.
+func (l *slowLogger) getRecent(collection string, limit int) []*SlowLogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := make([]*SlowLogEntry, 0, len(l.recent))
+	for _, entry := range l.recent {
+		if collection == "" || entry.Collection == collection {
+			result = append(result, entry)
+		}
+	}
+	if limit > 0 && len(result) > limit {
+		result = result[len(result)-limit:]
+	}
+	return result
+}
+
+func (l *slowLogger) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	if l.writer != nil {
+		l.writer.Close()
+		l.writer = nil
+	}
+}
+
+// ListSlowLogs returns the recent slow requests,
+// the optional parameters are the collection name and the max number of entries.
+func (node *Proxy) ListSlowLogs(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only GET method is allowed"}`))
+		return
+	}
+
+	var limit int
+	if v := req.URL.Query().Get("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"msg": "invalid limit"}`))
+			return
+		}
+	}
+	entries := node.slowLogger.getRecent(req.URL.Query().Get("collection"), limit)
+	mgrWriteJSON(w, map[string]any{"entries": entries})
+}
@@ -0,0 +1,134 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestSlowLogTrace(t *testing.T) {
+	ctx, trace := withSlowLogTrace(context.Background())
+	assert.NotNil(t, trace)
+	assert.Equal(t, trace, getSlowLogTrace(ctx))
+
+	// the nested requests share the trace of the outer one
+	nested, nestedTrace := withSlowLogTrace(ctx)
+	assert.Nil(t, nestedTrace)
+	assert.Equal(t, trace, getSlowLogTrace(nested))
+
+	cost := getSlowLogTrace(ctx).startTask("SearchTask")
+	assert.NotNil(t, cost)
+	getSlowLogTrace(ctx).addNodeCost("SearchTask", 1, &internalpb.CostAggregation{ServiceTime: 10, ResponseTime: 20}, time.Second)
+	assert.Equal(t, 1, len(trace.tasks))
+	assert.Equal(t, int64(10), trace.nodes[0].ServiceMs)
+	assert.Equal(t, int64(1000), trace.nodes[0].RoundTripMs)
+
+	// no-op without trace
+	assert.Nil(t, getSlowLogTrace(context.Background()).startTask("SearchTask"))
+	getSlowLogTrace(context.Background()).addNodeCost("SearchTask", 1, nil, time.Second)
+}
+
+func TestSlowLogger(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	dir := t.TempDir()
+	params.Save(params.ProxyCfg.SlowLog.LocalPath.Key, dir)
+	params.Save(params.ProxyCfg.SlowLog.SearchThreshold.Key, "100")
+	params.Save(params.ProxyCfg.SlowLog.CollectionThresholds.Key, `{"db1.c1": "10", "c2": "1000"}`)
+	params.Save(params.ProxyCfg.SlowLog.RecentSize.Key, "2")
+	defer func() {
+		params.Reset(params.ProxyCfg.SlowLog.Enable.Key)
+		params.Reset(params.ProxyCfg.SlowLog.LocalPath.Key)
+		params.Reset(params.ProxyCfg.SlowLog.SearchThreshold.Key)
+		params.Reset(params.ProxyCfg.SlowLog.CollectionThresholds.Key)
+		params.Reset(params.ProxyCfg.SlowLog.RecentSize.Key)
+	}()
+
+	l := newSlowLogger()
+	defer l.close()
+	assert.Equal(t, 10*time.Millisecond, l.threshold(slowLogSearch, "db1", "c1"))
+	assert.Equal(t, time.Second, l.threshold(slowLogSearch, "db2", "c2"))
+	assert.Equal(t, 100*time.Millisecond, l.threshold(slowLogSearch, "db2", "c1"))
+	assert.Equal(t, 5*time.Second, l.threshold(slowLogQuery, "db2", "c1"))
+
+	// disabled
+	assert.False(t, l.observe(&SlowLogEntry{Type: slowLogSearch, Collection: "c1"}, nil, time.Second))
+
+	params.Save(params.ProxyCfg.SlowLog.Enable.Key, "true")
+	_, trace := withSlowLogTrace(context.Background())
+	trace.startTask("SearchTask")
+	assert.True(t, l.observe(&SlowLogEntry{Type: slowLogSearch, Database: "db1", Collection: "c1"}, trace, 50*time.Millisecond))
+	assert.False(t, l.observe(&SlowLogEntry{Type: slowLogSearch, Database: "db1", Collection: "c2"}, nil, 500*time.Millisecond))
+	assert.True(t, l.observe(&SlowLogEntry{Type: slowLogSearch, Database: "db1", Collection: "c3"}, nil, 500*time.Millisecond))
+	assert.True(t, l.observe(&SlowLogEntry{Type: slowLogSearch, Database: "db1", Collection: "c1"}, nil, 500*time.Millisecond))
+
+	// only the recent entries are kept
+	entries := l.getRecent("", 0)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "c3", entries[0].Collection)
+	assert.Equal(t, 1, len(l.getRecent("c1", 0)))
+	assert.Equal(t, 1, len(l.getRecent("", 1)))
+
+	bs, err := os.ReadFile(path.Join(dir, params.ProxyCfg.SlowLog.Filename.GetValue()))
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(bs)), "\n")
+	assert.Equal(t, 3, len(lines))
+	entry := &SlowLogEntry{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), entry))
+	assert.Equal(t, int64(10), entry.ThresholdMs)
+	assert.Equal(t, 1, len(entry.Tasks))
+
+	// no entry is sampled
+	params.Save(params.ProxyCfg.SlowLog.SampleRatio.Key, "0")
+	defer params.Reset(params.ProxyCfg.SlowLog.SampleRatio.Key)
+	assert.False(t, l.observe(&SlowLogEntry{Type: slowLogSearch, Collection: "c3"}, nil, time.Second))
+}
+
+func TestProxy_ListSlowLogs(t *testing.T) {
+	paramtable.Init()
+	node := &Proxy{slowLogger: newSlowLogger()}
+	node.slowLogger.recent = []*SlowLogEntry{{Collection: "c1"}, {Collection: "c2"}}
+
+	w := httptest.NewRecorder()
+	node.ListSlowLogs(w, httptest.NewRequest(http.MethodGet, mgrRouteSlowLog+"?collection=c2", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp := map[string][]*SlowLogEntry{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1, len(resp["entries"]))
+	assert.Equal(t, "c2", resp["entries"][0].Collection)
+
+	w = httptest.NewRecorder()
+	node.ListSlowLogs(w, httptest.NewRequest(http.MethodGet, mgrRouteSlowLog+"?limit=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	node.ListSlowLogs(w, httptest.NewRequest(http.MethodPost, mgrRouteSlowLog, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,75 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/util/featureflag"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// systemFields are the system fields the query filters are allowed to reference,
+// e.g. "Timestamp > 449000000000000000" for the rows inserted after the hybrid timestamp,
+// or "SegmentID == 449000000000000001" for the rows of the segment.
+var systemFields = []*schemapb.FieldSchema{
+	{FieldID: common.TimeStampField, Name: common.TimeStampFieldName, DataType: schemapb.DataType_Int64},
+	{FieldID: common.SegmentIDField, Name: common.SegmentIDFieldName, DataType: schemapb.DataType_Int64},
+}
+
+var systemFieldPattern = regexp.MustCompile(`\b(` + common.TimeStampFieldName + `|` + common.SegmentIDFieldName + `)\b`)
+
+// withSystemFields returns the schema with the system fields to parse the query filter, if the filter
+// may reference them and the caller is an admin. Otherwise the schema is returned as is,
+// then the filter referencing them fails to parse.
+func withSystemFields(ctx context.Context, schema *schemapb.CollectionSchema, expr string) *schemapb.CollectionSchema {
+	if !Params.ProxyCfg.SystemFieldFilterEnabled.GetAsBool() || !systemFieldPattern.MatchString(expr) {
+		return schema
+	}
+	if isAdmin, err := mgrIsAdmin(ctx); err != nil || !isAdmin {
+		return schema
+	}
+	if !featureflag.Enabled(featureflag.SystemFieldFilter) {
+		log.Ctx(ctx).RatedInfo(60, "filter by system fields is not supported by all the nodes, ignore it")
+		return schema
+	}
+
+	cloned := proto.Clone(schema).(*schemapb.CollectionSchema)
+	for _, field := range systemFields {
+		// the fields of the same name defined by user take precedence
+		if !hasFieldName(schema, field.GetName()) {
+			cloned.Fields = append(cloned.Fields, proto.Clone(field).(*schemapb.FieldSchema))
+		}
+	}
+	log.Ctx(ctx).Info("query filtered by system fields", zap.String("expr", expr))
+	return cloned
+}
+
+func hasFieldName(schema *schemapb.CollectionSchema, name string) bool {
+	for _, field := range schema.GetFields() {
+		if field.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestWithSystemFields(t *testing.T) {
+	paramtable.Init()
+	schema := &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	ctx := context.Background()
+	expr := "pk > 1 and Timestamp > 100 and SegmentID in [1, 2]"
+
+	// disabled
+	assert.Equal(t, schema, withSystemFields(ctx, schema, expr))
+	_, err := planparserv2.CreateRetrievePlan(schema, expr)
+	assert.Error(t, err)
+
+	paramtable.Get().Save(Params.ProxyCfg.SystemFieldFilterEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.ProxyCfg.SystemFieldFilterEnabled.Key)
+	assert.Equal(t, schema, withSystemFields(ctx, schema, "pk > 1"))
+
+	withSystem := withSystemFields(ctx, schema, expr)
+	assert.Equal(t, 4, len(withSystem.GetFields()))
+	assert.Equal(t, 2, len(schema.GetFields()))
+	plan, err := planparserv2.CreateRetrievePlan(withSystem, expr)
+	require.NoError(t, err)
+	right := plan.GetQuery().GetPredicates().GetBinaryExpr().GetRight()
+	assert.Equal(t, int64(common.SegmentIDField), right.GetTermExpr().GetColumnInfo().GetFieldId())
+}
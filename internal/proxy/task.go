@@ -31,11 +31,15 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
+	"github.com/milvus-io/milvus/internal/util/timepartition"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -295,6 +299,31 @@ func (t *createCollectionTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if err := fillBinlogCompression(t.schema, t.GetProperties()); err != nil {
+		return err
+	}
+
+	if t.Properties, err = fillStorageProfile(t.GetDbName(), t.GetProperties()); err != nil {
+		return err
+	}
+
+	if err := validateVectorQuantization(t.schema, t.GetProperties()); err != nil {
+		return err
+	}
+
+	if policy, err := timepartition.Parse(t.GetProperties()); err != nil {
+		return err
+	} else if policy != nil && typeutil.HasPartitionKey(t.schema) {
+		return merr.WrapErrParameterInvalidMsg("collection property %s is not supported in partition key mode", common.CollectionPartitionRolloverKey)
+	}
+	if policy, err := parseDedupPolicy(t.GetProperties()); err != nil {
+		return err
+	} else if policy != nil {
+		if err := policy.checkSchema(t.schema); err != nil {
+			return err
+		}
+	}
+
 	t.CreateCollectionRequest.Schema, err = proto.Marshal(t.schema)
 	if err != nil {
 		return err
@@ -754,6 +783,7 @@ type alterCollectionTask struct {
 	*milvuspb.AlterCollectionRequest
 	ctx       context.Context
 	rootCoord types.RootCoordClient
+	dataCoord types.DataCoordClient
 	result    *commonpb.Status
 }
 
@@ -800,6 +830,81 @@ func (t *alterCollectionTask) PreExecute(ctx context.Context) error {
 	t.Base.MsgType = commonpb.MsgType_AlterCollection
 	t.Base.SourceID = paramtable.GetNodeID()
 
+	if _, ok := common.GetStorageProfile(t.GetProperties()...); ok {
+		return merr.WrapErrParameterInvalidMsg("collection property %s can't be altered", common.StorageProfileKey)
+	}
+	if value, ok := common.GetBinlogCompression(t.GetProperties()...); ok {
+		if _, err := storage.ParseBinlogCompressType(value); err != nil {
+			return merr.WrapErrParameterInvalidMsg("invalid collection property %s: %s", common.BinlogCompressionKey, err.Error())
+		}
+	}
+	if _, _, err := parseCollectionFreeze(t.GetProperties()); err != nil {
+		return err
+	}
+	if policy, err := timepartition.Parse(t.GetProperties()); err != nil {
+		return err
+	} else if policy != nil {
+		schema, err := globalMetaCache.GetCollectionSchema(ctx, t.GetDbName(), t.GetCollectionName())
+		if err != nil {
+			return err
+		}
+		if typeutil.HasPartitionKey(schema) {
+			return merr.WrapErrParameterInvalidMsg("collection property %s is not supported in partition key mode", common.CollectionPartitionRolloverKey)
+		}
+	}
+	if policy, err := parseDedupPolicy(t.GetProperties()); err != nil {
+		return err
+	} else if policy != nil {
+		schema, err := globalMetaCache.GetCollectionSchema(ctx, t.GetDbName(), t.GetCollectionName())
+		if err != nil {
+			return err
+		}
+		if err := policy.checkSchema(schema); err != nil {
+			return err
+		}
+	}
+	if _, ok := common.GetVectorQuantization(t.GetProperties()...); ok {
+		if err := t.checkVectorQuantization(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkVectorQuantization checks the quantization altered applies to the indexes built already.
+func (t *alterCollectionTask) checkVectorQuantization(ctx context.Context) error {
+	if err := validateVectorQuantization(nil, t.GetProperties()); err != nil {
+		return err
+	}
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, t.GetDbName(), t.GetCollectionName())
+	if err != nil {
+		return err
+	}
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, t.GetDbName(), t.GetCollectionName())
+	if err != nil {
+		return err
+	}
+	resp, err := t.dataCoord.DescribeIndex(ctx, &indexpb.DescribeIndexRequest{CollectionID: collectionID})
+	if err == nil {
+		err = merr.Error(resp.GetStatus())
+	}
+	if errors.Is(err, merr.ErrIndexNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, index := range resp.GetIndexInfos() {
+		field := typeutil.GetField(schema, index.GetFieldID())
+		if field == nil {
+			continue
+		}
+		indexType, _ := funcutil.GetAttrByKeyFromRepeatedKV(common.IndexTypeKey, index.GetIndexParams())
+		if err := checkQuantizedIndexType(field, t.GetProperties(), indexType); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1459,6 +1564,10 @@ func (t *loadCollectionTask) Execute(ctx context.Context) (err error) {
 	hasVecIndex := false
 	fieldIndexIDs := make(map[int64]int64)
 	for _, index := range indexResponse.IndexInfos {
+		// json key stats are loaded along with the segments, query coord doesn't track them
+		if jsonkey.IsStats(index.GetIndexParams()) {
+			continue
+		}
 		fieldIndexIDs[index.FieldID] = index.IndexID
 		for _, field := range collSchema.Fields {
 			if index.FieldID == field.FieldID && (field.DataType == schemapb.DataType_FloatVector || field.DataType == schemapb.DataType_BinaryVector || field.DataType == schemapb.DataType_Float16Vector) {
@@ -1699,6 +1808,10 @@ func (t *loadPartitionsTask) Execute(ctx context.Context) error {
 	hasVecIndex := false
 	fieldIndexIDs := make(map[int64]int64)
 	for _, index := range indexResponse.IndexInfos {
+		// json key stats are loaded along with the segments, query coord doesn't track them
+		if jsonkey.IsStats(index.GetIndexParams()) {
+			continue
+		}
 		fieldIndexIDs[index.FieldID] = index.IndexID
 		for _, field := range collSchema.Fields {
 			if index.FieldID == field.FieldID && (field.DataType == schemapb.DataType_FloatVector || field.DataType == schemapb.DataType_BinaryVector || field.DataType == schemapb.DataType_Float16Vector) {
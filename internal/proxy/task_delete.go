@@ -57,6 +57,10 @@ type deleteTask struct {
 	partitionID      UniqueID
 	count            int
 	partitionKeyMode bool
+
+	// progress of the delete with filter expression
+	jobManager *deleteJobManager
+	job        *deleteJob
 }
 
 func (dt *deleteTask) TraceCtx() context.Context {
@@ -247,9 +251,14 @@ func (dt *deleteTask) Execute(ctx context.Context) (err error) {
 	} else {
 		// if get complex delete expr
 		// need query from querynode before delete
+		ctx, dt.job = dt.jobManager.Start(ctx, dt.ID(), mgrCurUser(ctx), dt.req)
 		err = dt.complexDelete(ctx, plan, stream)
+		if errors.Is(context.Cause(ctx), errDeleteCanceled) {
+			err = errors.Wrapf(errDeleteCanceled, "%d rows deleted before canceled", dt.result.GetDeleteCnt())
+		}
+		dt.jobManager.Finish(dt.job, err)
 		if err != nil {
-			log.Warn("complex delete failed,but delete some data", zap.Int("count", dt.count), zap.String("expr", dt.req.GetExpr()))
+			log.Warn("complex delete failed,but delete some data", zap.Int64("count", dt.result.GetDeleteCnt()), zap.String("expr", dt.req.GetExpr()))
 			return err
 		}
 	}
@@ -344,7 +353,7 @@ func (dt *deleteTask) getStreamingQueryAndDelteFunc(stream msgstream.MsgStream,
 				return err
 			}
 
-			err = dt.produce(ctx, stream, result.GetIds())
+			err = dt.produceInBatches(ctx, stream, result.GetIds())
 			if err != nil {
 				log.Warn("query stream for delete produce result failed", zap.Int64("msgID", dt.msgID), zap.Error(err))
 				return err
@@ -386,6 +395,42 @@ func (dt *deleteTask) simpleDelete(ctx context.Context, termExp *planpb.Expr_Ter
 	return nil
 }
 
+// produceInBatches produces the deletes of the primary keys in batches of proxy.delete.batchSize,
+// and stops once the delete is canceled.
+func (dt *deleteTask) produceInBatches(ctx context.Context, stream msgstream.MsgStream, primaryKeys *schemapb.IDs) error {
+	total := typeutil.GetSizeOfIDs(primaryKeys)
+	batchSize := paramtable.Get().ProxyCfg.DeleteBatchSize.GetAsInt()
+	if batchSize <= 0 {
+		batchSize = total
+	}
+	for start := 0; start < total; start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := start + batchSize
+		if end > total {
+			end = total
+		}
+		if err := dt.produce(ctx, stream, sliceIDs(primaryKeys, start, end)); err != nil {
+			return err
+		}
+		dt.jobManager.Progress(dt.job, int64(end-start))
+	}
+	return nil
+}
+
+// sliceIDs returns the ids in [start, end).
+func sliceIDs(ids *schemapb.IDs, start, end int) *schemapb.IDs {
+	switch ids.GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		return &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids.GetIntId().GetData()[start:end]}}}
+	case *schemapb.IDs_StrId:
+		return &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: ids.GetStrId().GetData()[start:end]}}}
+	default:
+		return ids
+	}
+}
+
 func (dt *deleteTask) produce(ctx context.Context, stream msgstream.MsgStream, primaryKeys *schemapb.IDs) error {
 	hashValues := typeutil.HashPK2Channels(primaryKeys, dt.vChannels)
 	// repack delete msg by dmChannel
@@ -0,0 +1,93 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+)
+
+// taskHistoryPurgeRequest purges the finished tasks of the kind before the time in unix seconds, e.g.
+// {"kind": "compaction", "before": 1700000000}.
+type taskHistoryPurgeRequest struct {
+	Kind   string `json:"kind"`
+	Before int64  `json:"before"`
+}
+
+// taskHistoryGetMetrics returns the GetMetrics of the coordinator keeping the tasks of the kind,
+// the import tasks are kept by rootcoord, and the others by datacoord.
+func (node *Proxy) taskHistoryGetMetrics(kind string) introspectionFunc {
+	if kind == metricsinfo.TaskKindImport {
+		return node.rootCoord.GetMetrics
+	}
+	return node.dataCoord.GetMetrics
+}
+
+// ListTaskHistory returns the finished tasks of the kind, e.g. compaction, index or import,
+// in the time range of start and end in unix seconds.
+func (node *Proxy) ListTaskHistory(w http.ResponseWriter, req *http.Request) {
+	introspect(metricsinfo.TaskHistoryMetrics, node.taskHistoryGetMetrics(req.URL.Query().Get(metricsinfo.MetricTaskKindKey)),
+		metricsinfo.MetricTaskKindKey, metricsinfo.MetricStartTimeKey, metricsinfo.MetricEndTimeKey)(w, req)
+}
+
+// PurgeTaskHistory removes the finished tasks of the kind ahead of the retention by POST,
+// returns the tasks removed.
+func (node *Proxy) PurgeTaskHistory(w http.ResponseWriter, req *http.Request) {
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+		return
+	}
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return
+	}
+	request := &taskHistoryPurgeRequest{}
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid request, %s"}`, err.Error())))
+		return
+	}
+	if request.Before <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"msg": "before is required"}`))
+		return
+	}
+
+	metricsReq, err := metricsinfo.ConstructRequestWithParams(metricsinfo.PurgeTaskHistoryMetrics, map[string]any{
+		metricsinfo.MetricTaskKindKey: request.Kind,
+		metricsinfo.MetricEndTimeKey:  request.Before,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+		return
+	}
+	resp, err := node.taskHistoryGetMetrics(request.Kind)(req.Context(), metricsReq)
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to purge task history, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(resp.GetResponse()))
+}
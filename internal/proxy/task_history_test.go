@@ -0,0 +1,94 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+)
+
+func TestProxy_TaskHistory(t *testing.T) {
+	datacoord := mocks.NewMockDataCoordClient(t)
+	rootcoord := mocks.NewMockRootCoordClient(t)
+	node := &Proxy{dataCoord: datacoord, rootCoord: rootcoord}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+
+	t.Run("list compaction", func(t *testing.T) {
+		datacoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
+				params := make(map[string]any)
+				assert.NoError(t, json.Unmarshal([]byte(req.GetRequest()), &params))
+				assert.Equal(t, metricsinfo.TaskHistoryMetrics, params[metricsinfo.MetricTypeKey])
+				assert.Equal(t, metricsinfo.TaskKindCompaction, params[metricsinfo.MetricTaskKindKey])
+				assert.Equal(t, float64(100), params[metricsinfo.MetricStartTimeKey])
+				assert.Equal(t, float64(200), params[metricsinfo.MetricEndTimeKey])
+				return &milvuspb.GetMetricsResponse{Status: merr.Success(), Response: `{"tasks":[]}`}, nil
+			}).Once()
+
+		w := httptest.NewRecorder()
+		node.ListTaskHistory(w, httptest.NewRequest(http.MethodGet, mgrRouteTaskHistory+"?kind=compaction&start=100&end=200", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"tasks":[]}`, w.Body.String())
+
+		w = httptest.NewRecorder()
+		node.ListTaskHistory(w, httptest.NewRequest(http.MethodGet, mgrRouteTaskHistory+"?kind=compaction&start=abc", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("purge import", func(t *testing.T) {
+		rootcoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
+				params := make(map[string]any)
+				assert.NoError(t, json.Unmarshal([]byte(req.GetRequest()), &params))
+				assert.Equal(t, metricsinfo.PurgeTaskHistoryMetrics, params[metricsinfo.MetricTypeKey])
+				assert.Equal(t, metricsinfo.TaskKindImport, params[metricsinfo.MetricTaskKindKey])
+				assert.Equal(t, float64(200), params[metricsinfo.MetricEndTimeKey])
+				return &milvuspb.GetMetricsResponse{Status: merr.Success(), Response: `{"tasks":[]}`}, nil
+			}).Once()
+
+		w := httptest.NewRecorder()
+		node.PurgeTaskHistory(w, httptest.NewRequest(http.MethodPost, mgrRouteTaskHistoryPurge,
+			strings.NewReader(`{"kind": "import", "before": 200}`)))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"tasks":[]}`, w.Body.String())
+	})
+
+	t.Run("purge without before", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		node.PurgeTaskHistory(w, httptest.NewRequest(http.MethodPost, mgrRouteTaskHistoryPurge,
+			strings.NewReader(`{"kind": "compaction"}`)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = httptest.NewRecorder()
+		node.PurgeTaskHistory(w, httptest.NewRequest(http.MethodGet, mgrRouteTaskHistoryPurge, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
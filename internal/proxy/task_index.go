@@ -19,6 +19,7 @@ package proxy
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
@@ -28,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
@@ -68,6 +70,8 @@ type createIndexTask struct {
 
 	collectionID UniqueID
 	fieldSchema  *schemapb.FieldSchema
+	// jsonPath is the keys to collect json key stats if the field name is like `$meta["category"]`
+	jsonPath []string
 }
 
 func (cit *createIndexTask) TraceCtx() context.Context {
@@ -159,10 +163,21 @@ func (cit *createIndexTask) parseIndexParams() error {
 			if exist && !validateArithmeticIndexType(specifyIndexType) {
 				return merr.WrapErrParameterInvalid(DefaultArithmeticIndexType, specifyIndexType, "index type not match")
 			}
+		} else if cit.fieldSchema.DataType == schemapb.DataType_JSON && len(cit.jsonPath) > 0 {
+			if !exist {
+				indexParamsMap[common.IndexTypeKey] = indexparamcheck.IndexJSONKeyStats
+			}
+			if exist && specifyIndexType != indexparamcheck.IndexJSONKeyStats {
+				return merr.WrapErrParameterInvalid(indexparamcheck.IndexJSONKeyStats, specifyIndexType, "index type not match")
+			}
+			indexParamsMap[common.JSONPathKey] = jsonkey.FormatPath(cit.jsonPath)
+			if err := indexparamcheck.CheckIndexValid(cit.fieldSchema.DataType, indexparamcheck.IndexJSONKeyStats, indexParamsMap); err != nil {
+				return merr.WrapErrParameterInvalidMsg(err.Error())
+			}
 		} else {
 			return merr.WrapErrParameterInvalid("supported field",
 				fmt.Sprintf("create index on %s field", cit.fieldSchema.DataType.String()),
-				"create index on json field is not supported")
+				"create index on json field is not supported, collect json key stats on keys like $meta[\"key\"] instead")
 		}
 	}
 
@@ -290,14 +305,27 @@ func (cit *createIndexTask) getIndexedField(ctx context.Context) (*schemapb.Fiel
 		log.Error("failed to parse collection schema", zap.Error(err))
 		return nil, fmt.Errorf("failed to parse collection schema: %s", err)
 	}
-	field, err := schemaHelper.GetFieldFromName(cit.req.GetFieldName())
+	fieldName, jsonPath, err := jsonkey.ParseFieldName(cit.req.GetFieldName())
+	if err != nil {
+		return nil, err
+	}
+	field, err := schemaHelper.GetFieldFromName(fieldName)
 	if err != nil {
 		log.Error("create index on non-exist field", zap.Error(err))
 		return nil, fmt.Errorf("cannot create index on non-exist field: %s", cit.req.GetFieldName())
 	}
+	if len(jsonPath) > 0 && field.GetDataType() != schemapb.DataType_JSON {
+		return nil, merr.WrapErrParameterInvalidMsg("cannot create index on keys of %s field %s", field.GetDataType().String(), fieldName)
+	}
+	cit.jsonPath = jsonPath
 	return field, nil
 }
 
+// jsonKeyStatsName returns the default name of json key stats, e.g. meta_category for $meta["category"].
+func jsonKeyStatsName(fieldName string, jsonPath []string) string {
+	return strings.TrimPrefix(fieldName, "$") + "_" + strings.Join(jsonPath, "_")
+}
+
 func fillDimension(field *schemapb.FieldSchema, indexParams map[string]string) error {
 	vecDataTypes := []schemapb.DataType{
 		schemapb.DataType_FloatVector,
@@ -386,10 +414,25 @@ func (cit *createIndexTask) PreExecute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if err = cit.checkVectorQuantization(ctx); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+func (cit *createIndexTask) checkVectorQuantization(ctx context.Context) error {
+	if cit.fieldSchema.GetDataType() != schemapb.DataType_FloatVector {
+		return nil
+	}
+	info, err := globalMetaCache.GetCollectionInfo(ctx, cit.req.GetDbName(), cit.req.GetCollectionName(), cit.collectionID)
+	if err != nil {
+		return err
+	}
+	indexType, _ := funcutil.GetAttrByKeyFromRepeatedKV(common.IndexTypeKey, cit.newIndexParams)
+	return checkQuantizedIndexType(cit.fieldSchema, info.properties, indexType)
+}
+
 func (cit *createIndexTask) Execute(ctx context.Context) error {
 	log.Ctx(ctx).Info("proxy create index", zap.Int64("collectionID", cit.collectionID), zap.Int64("fieldID", cit.fieldSchema.GetFieldID()),
 		zap.String("indexName", cit.req.GetIndexName()), zap.Any("typeParams", cit.fieldSchema.GetTypeParams()),
@@ -399,6 +442,9 @@ func (cit *createIndexTask) Execute(ctx context.Context) error {
 
 	if cit.req.GetIndexName() == "" {
 		cit.req.IndexName = cit.fieldSchema.GetName()
+		if len(cit.jsonPath) > 0 {
+			cit.req.IndexName = jsonKeyStatsName(cit.fieldSchema.GetName(), cit.jsonPath)
+		}
 	}
 	var err error
 	req := &indexpb.CreateIndexRequest{
@@ -33,9 +33,11 @@ import (
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/config"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -498,6 +500,32 @@ func Test_parseIndexParams(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("create index on json key", func(t *testing.T) {
+		cit := &createIndexTask{
+			req: &milvuspb.CreateIndexRequest{},
+			fieldSchema: &schemapb.FieldSchema{
+				FieldID:   101,
+				Name:      common.MetaFieldName,
+				DataType:  schemapb.DataType_JSON,
+				IsDynamic: true,
+			},
+			jsonPath: []string{"category"},
+		}
+		err := cit.parseIndexParams()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []*commonpb.KeyValuePair{
+			{Key: common.IndexTypeKey, Value: indexparamcheck.IndexJSONKeyStats},
+			{Key: common.JSONPathKey, Value: `["category"]`},
+		}, cit.newIndexParams)
+		assert.Equal(t, "meta_category", jsonKeyStatsName(common.MetaFieldName, cit.jsonPath))
+		assert.True(t, jsonkey.IsStats(cit.newIndexParams))
+
+		cit.req.ExtraParams = []*commonpb.KeyValuePair{{Key: common.IndexTypeKey, Value: DefaultStringIndexType}}
+		cit.newIndexParams = nil
+		err = cit.parseIndexParams()
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
 	t.Run("create index on VarChar field", func(t *testing.T) {
 		cit := &createIndexTask{
 			req: &milvuspb.CreateIndexRequest{
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/samber/lo"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 
@@ -32,10 +33,26 @@ type insertTask struct {
 	segIDAssigner *segIDAssigner
 	chMgr         channelsMgr
 	chTicker      channelsTimeTicker
+	batcher       *mutationBatcher
 	vChannels     []vChan
 	pChannels     []pChan
 	schema        *schemapb.CollectionSchema
 	partitionKeys *schemapb.FieldData
+
+	// partial skips the rows violating the schema instead of failing the whole batch
+	partial bool
+	// rowIndexes are the indexes in the request of the rows remaining after the invalid rows are skipped
+	rowIndexes []uint32
+	rowErrors  []*rowError
+
+	// dedupPolicy is the dedup at ingest of the collection, nil if disabled
+	dedupPolicy *dedupPolicy
+	// dedupCache is the dedup cache of the collection which the hashes are reserved in
+	dedupCache *dedupCache
+	// dedupHashes are the content hashes of the rows to insert
+	dedupHashes []string
+	// dedupDeletes are the primary keys of the rows duplicated to delete in upsert mode
+	dedupDeletes *schemapb.IDs
 }
 
 // TraceCtx returns insertTask context
@@ -118,6 +135,22 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 	}
 	it.schema = schema
 
+	validator := newValidateUtil(withNANCheck(), withOverflowCheck(), withMaxLenCheck(), withMaxCapCheck())
+	if it.partial {
+		if err := it.skipInvalidRows(validator); err != nil {
+			return err
+		}
+	}
+	if err := it.dedupRows(ctx); err != nil {
+		log.Warn("dedup insert rows failed", zap.String("collectionName", collectionName), zap.Error(err))
+		return err
+	}
+	if it.dedupPolicy != nil && it.insertMsg.NRows() == 0 {
+		// all the rows are duplicates
+		it.result.SuccIndex = []uint32{}
+		return nil
+	}
+
 	rowNums := uint32(it.insertMsg.NRows())
 	// set insertTask.rowIDs
 	var rowIDBegin UniqueID
@@ -185,10 +218,14 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 		}
 	} else {
 		// set default partition name if not use partition key
-		// insert to _default partition
+		// insert to _default partition, or the partition of the current period if the collection is time-partitioned
 		partitionTag := it.insertMsg.GetPartitionName()
 		if len(partitionTag) <= 0 {
-			partitionTag = Params.CommonCfg.DefaultPartitionName.GetValue()
+			partitionTag, _, err = getWritePartitionName(ctx, it.insertMsg.GetDbName(), collectionName)
+			if err != nil {
+				log.Warn("get partition to insert failed", zap.String("collectionName", collectionName), zap.Error(err))
+				return err
+			}
 			it.insertMsg.PartitionName = partitionTag
 		}
 
@@ -198,8 +235,10 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 		}
 	}
 
-	if err := newValidateUtil(withNANCheck(), withOverflowCheck(), withMaxLenCheck(), withMaxCapCheck()).
-		Validate(it.insertMsg.GetFieldsData(), schema, it.insertMsg.NRows()); err != nil {
+	if err := validator.Validate(it.insertMsg.GetFieldsData(), schema, it.insertMsg.NRows()); err != nil {
+		if !it.partial {
+			it.rowErrors, _ = validator.findRowErrors(it.insertMsg.GetFieldsData(), schema, it.insertMsg.NRows())
+		}
 		return err
 	}
 
@@ -208,12 +247,38 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 	return nil
 }
 
+// skipInvalidRows removes the rows violating the schema from the insert message.
+func (it *insertTask) skipInvalidRows(validator *validateUtil) error {
+	var err error
+	numRows := it.insertMsg.NRows()
+	it.rowErrors, err = validator.findRowErrors(it.insertMsg.GetFieldsData(), it.schema, numRows)
+	if err != nil || len(it.rowErrors) == 0 {
+		return err
+	}
+	it.insertMsg.FieldsData, it.rowIndexes, err = dropInvalidRows(it.insertMsg.GetFieldsData(), numRows, it.rowErrors)
+	if err != nil {
+		return err
+	}
+	if len(it.insertMsg.HashValues) == int(numRows) {
+		hashValues := it.insertMsg.HashValues
+		it.insertMsg.HashValues = lo.Map(it.rowIndexes, func(i uint32, _ int) uint32 { return hashValues[i] })
+	}
+	it.insertMsg.NumRows = uint64(len(it.rowIndexes))
+	return nil
+}
+
 func (it *insertTask) Execute(ctx context.Context) error {
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Insert-Execute")
 	defer sp.End()
 
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("proxy execute insert %d", it.ID()))
 
+	if it.dedupPolicy != nil && it.insertMsg.NRows() == 0 {
+		return nil
+	}
+	// the hashes are recorded once the rows are inserted, otherwise they're released
+	defer it.releaseDedup()
+
 	collectionName := it.insertMsg.CollectionName
 	collID, err := globalMetaCache.GetCollectionID(it.ctx, it.insertMsg.GetDbName(), collectionName)
 	log := log.Ctx(ctx)
@@ -261,12 +326,21 @@ func (it *insertTask) Execute(ctx context.Context) error {
 
 	log.Debug("assign segmentID for insert data success",
 		zap.Duration("assign segmentID duration", assignSegmentIDDur))
-	err = stream.Produce(msgPack)
+	if it.batcher != nil {
+		err = it.batcher.produce(ctx, collID, stream, msgPack)
+	} else {
+		err = stream.Produce(msgPack)
+	}
 	if err != nil {
 		log.Warn("fail to produce insert msg", zap.Error(err))
 		it.result.Status = merr.Status(err)
 		return err
 	}
+	if err := it.recordDedup(ctx, stream, channelNames); err != nil {
+		log.Warn("fail to delete the rows duplicated", zap.Error(err))
+		it.result.Status = merr.Status(err)
+		return err
+	}
 	sendMsgDur := tr.RecordSpan()
 	metrics.ProxySendMutationReqLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.InsertLabel).Observe(float64(sendMsgDur.Milliseconds()))
 	totalExecDur := tr.ElapseSpan()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/golang/protobuf/proto"
@@ -19,6 +20,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/featureflag"
 	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -61,6 +63,13 @@ type queryTask struct {
 	plan             *planpb.PlanNode
 	partitionKeyMode bool
 	lb               LBPolicy
+
+	// mvccTimestamp is specified by internal callers to read a consistent snapshot, BeginTs is used if it's zero
+	mvccTimestamp Timestamp
+	// vectorURLWriter replaces large vector output fields with pre-signed urls if the request asks for
+	vectorURLWriter *vectorURLWriter
+	// sessionClient is the identifier of the client whose Session consistency reads stick to the shard delegators
+	sessionClient int64
 }
 
 type queryParams struct {
@@ -206,14 +215,14 @@ func (t *queryTask) createPlan(ctx context.Context) error {
 	cntMatch := matchCountRule(t.request.GetOutputFields())
 	if cntMatch {
 		var err error
-		t.plan, err = createCntPlan(t.request.GetExpr(), schema)
+		t.plan, err = createCntPlan(t.request.GetExpr(), withSystemFields(ctx, schema, t.request.GetExpr()))
 		t.userOutputFields = []string{"count(*)"}
 		return err
 	}
 
 	var err error
 	if t.plan == nil {
-		t.plan, err = planparserv2.CreateRetrievePlan(schema, t.request.Expr)
+		t.plan, err = planparserv2.CreateRetrievePlan(withSystemFields(ctx, schema, t.request.GetExpr()), t.request.Expr)
 		if err != nil {
 			return err
 		}
@@ -298,6 +307,11 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// fallback to reduce all the results if any node doesn't support it, e.g. during the rolling upgrade
+	if queryParams.reduceStopForBest && !featureflag.Enabled(featureflag.ReduceStopForBest) {
+		log.RatedInfo(60, "reduce_stop_for_best is not supported by all the nodes, ignore it")
+		queryParams.reduceStopForBest = false
+	}
 	t.RetrieveRequest.ReduceStopForBest = queryParams.reduceStopForBest
 
 	t.queryParams = queryParams
@@ -361,6 +375,9 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	}
 
 	t.MvccTimestamp = t.BeginTs()
+	if t.mvccTimestamp > 0 && t.mvccTimestamp < t.BeginTs() {
+		t.MvccTimestamp = t.mvccTimestamp
+	}
 	collectionInfo, err2 := globalMetaCache.GetCollectionInfo(ctx, t.request.GetDbName(), collectionName, t.CollectionID)
 	if err2 != nil {
 		log.Warn("Proxy::queryTask::PreExecute failed to GetCollectionInfo from cache",
@@ -385,7 +402,15 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 			guaranteeTs = parseGuaranteeTsFromConsistency(guaranteeTs, t.BeginTs(), consistencyLevel)
 		}
 	}
+	// read the own writes of the client, from the same shard delegators as its prior reads
+	if consistencyLevel == commonpb.ConsistencyLevel_Session {
+		if client := sessionClient(ctx); client != 0 {
+			guaranteeTs = getSessionRouter().guaranteeTs(client, t.CollectionID, guaranteeTs)
+			t.sessionClient = client
+		}
+	}
 	t.GuaranteeTimestamp = guaranteeTs
+	t.ConsistencyLevel = consistencyLevel
 
 	deadline, ok := t.TraceCtx().Deadline()
 	if ok {
@@ -412,8 +437,10 @@ func (t *queryTask) Execute(ctx context.Context) error {
 		db:             t.request.GetDbName(),
 		collectionID:   t.CollectionID,
 		collectionName: t.collectionName,
+		partitionIDs:   t.RetrieveRequest.GetPartitionIDs(),
 		nq:             1,
 		exec:           t.queryShard,
+		sessionClient:  t.sessionClient,
 	})
 	if err != nil {
 		log.Warn("fail to execute query", zap.Error(err))
@@ -463,6 +490,21 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 	t.result.OutputFields = t.userOutputFields
 	metrics.ProxyReduceResultLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.QueryLabel).Observe(float64(tr.RecordSpan().Milliseconds()))
 
+	useURL, err := parseVectorOutputMode(t.request.GetQueryParams())
+	if err != nil {
+		return err
+	}
+	if useURL && t.vectorURLWriter != nil {
+		fieldsData, replaced, err := t.vectorURLWriter.Replace(ctx, t.GetCollectionID(), t.ID(), t.result.GetFieldsData())
+		if err != nil {
+			log.Warn("fail to replace vector output fields with urls", zap.Error(err))
+			return err
+		}
+		t.result.FieldsData = fieldsData
+		t.result.OutputFields = replaceVectorURLOutputFields(t.result.GetOutputFields(), replaced)
+		tr.CtxRecord(ctx, "replaceVectorWithURL")
+	}
+
 	log.Debug("Query PostExecute done")
 	return nil
 }
@@ -481,15 +523,14 @@ func (t *queryTask) queryShard(ctx context.Context, nodeID int64, qn types.Query
 		zap.Int64("nodeID", nodeID),
 		zap.Strings("channels", channelIDs))
 
+	start := time.Now()
 	result, err := qn.Query(ctx, req)
 	if err != nil {
 		log.Warn("QueryNode query return error", zap.Error(err))
-		globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
 		return err
 	}
 	if result.GetStatus().GetErrorCode() == commonpb.ErrorCode_NotShardLeader {
 		log.Warn("QueryNode is not shardLeader")
-		globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
 		return errInvalidShardLeaders
 	}
 	if result.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
@@ -497,9 +538,15 @@ func (t *queryTask) queryShard(ctx context.Context, nodeID int64, qn types.Query
 		return errors.Wrapf(merr.Error(result.GetStatus()), "fail to Query on QueryNode %d", nodeID)
 	}
 
+	if err := chargeQueryLimits(ctx, result.GetCostAggregation(), result); err != nil {
+		log.Warn("query exceeds the limit", zap.Error(err))
+		return err
+	}
+
 	log.Debug("get query result")
 	t.resultBuf.Insert(result)
 	t.lb.UpdateCostMetrics(nodeID, result.CostAggregation)
+	getSlowLogTrace(ctx).addNodeCost(t.Name(), nodeID, result.GetCostAggregation(), time.Since(start))
 	return nil
 }
 
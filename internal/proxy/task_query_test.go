@@ -34,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/util/featureflag"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
@@ -181,6 +182,16 @@ func TestQueryTask_all(t *testing.T) {
 	})
 	assert.Error(t, task.PreExecute(ctx))
 
+	// reduce_stop_for_best is ignored if it's not supported by all the nodes
+	task.request.QueryParams[len(task.request.QueryParams)-1].Value = "true"
+	assert.NoError(t, task.PreExecute(ctx))
+	assert.True(t, task.RetrieveRequest.GetReduceStopForBest())
+	paramtable.Get().Save(paramtable.Get().CommonCfg.FeatureGateDisabled.Key, featureflag.ReduceStopForBest)
+	assert.NoError(t, task.PreExecute(ctx))
+	assert.False(t, task.RetrieveRequest.GetReduceStopForBest())
+	paramtable.Get().Reset(paramtable.Get().CommonCfg.FeatureGateDisabled.Key)
+	task.request.QueryParams = task.request.QueryParams[:len(task.request.QueryParams)-1]
+
 	result1 := &internalpb.RetrieveResults{
 		Base:   &commonpb.MsgBase{MsgType: commonpb.MsgType_RetrieveResult},
 		Status: merr.Success(),
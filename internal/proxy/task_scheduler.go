@@ -440,11 +440,17 @@ func (sched *taskScheduler) processTask(t task, q taskQueue) {
 	}()
 	span.AddEvent("scheduler process PreExecute")
 
+	cost := getSlowLogTrace(ctx).startTask(t.Name())
+	tr := time.Now()
 	err := t.PreExecute(ctx)
 
 	defer func() {
 		t.Notify(err)
 	}()
+	if cost != nil {
+		cost.PreExecMs = time.Since(tr).Milliseconds()
+		cost.Interrupted = err != nil
+	}
 	if err != nil {
 		span.RecordError(err)
 		log.Ctx(ctx).Warn("Failed to pre-execute task: " + err.Error())
@@ -452,7 +458,12 @@ func (sched *taskScheduler) processTask(t task, q taskQueue) {
 	}
 
 	span.AddEvent("scheduler process Execute")
+	tr = time.Now()
 	err = t.Execute(ctx)
+	if cost != nil {
+		cost.ExecMs = time.Since(tr).Milliseconds()
+		cost.Interrupted = err != nil
+	}
 	if err != nil {
 		span.RecordError(err)
 		log.Ctx(ctx).Warn("Failed to execute task: ", zap.Error(err))
@@ -460,7 +471,12 @@ func (sched *taskScheduler) processTask(t task, q taskQueue) {
 	}
 
 	span.AddEvent("scheduler process PostExecute")
+	tr = time.Now()
 	err = t.PostExecute(ctx)
+	if cost != nil {
+		cost.PostExecMs = time.Since(tr).Milliseconds()
+		cost.Interrupted = err != nil
+	}
 
 	if err != nil {
 		span.RecordError(err)
@@ -2,10 +2,13 @@ package proxy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/golang/protobuf/proto"
@@ -21,11 +24,13 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/bufferpool"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metric"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -66,6 +71,11 @@ type searchTask struct {
 	qc   types.QueryCoordClient
 	node types.ProxyComponent
 	lb   LBPolicy
+
+	// vectorURLWriter replaces large vector output fields with pre-signed urls if the request asks for
+	vectorURLWriter *vectorURLWriter
+	// sessionClient is the identifier of the client whose Session consistency reads stick to the shard delegators
+	sessionClient int64
 }
 
 func getPartitionIDs(ctx context.Context, dbName string, collectionName string, partitionNames []string) (partitionIDs []UniqueID, err error) {
@@ -171,6 +181,11 @@ func parseSearchInfo(searchParamsPair []*commonpb.KeyValuePair) (*planpb.QueryIn
 	if err != nil {
 		searchParamStr = ""
 	}
+	if strings.EqualFold(metricType, metric.MHJACCARD) {
+		if err := checkMinHashSearchParams(searchParamStr); err != nil {
+			return nil, 0, err
+		}
+	}
 	return &planpb.QueryInfo{
 		Topk:         queryTopK,
 		MetricType:   metricType,
@@ -179,6 +194,27 @@ func parseSearchInfo(searchParamsPair []*commonpb.KeyValuePair) (*planpb.QueryIn
 	}, offset, nil
 }
 
+// checkMinHashSearchParams checks the bit width of the hash values of MinHash signatures in the search params,
+// the segments without index are searched by it, 32 bits by default.
+func checkMinHashSearchParams(searchParamStr string) error {
+	if searchParamStr == "" {
+		return nil
+	}
+	searchParams := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(searchParamStr), &searchParams); err != nil {
+		return fmt.Errorf("%s [%s] is invalid, %w", SearchParamsKey, searchParamStr, err)
+	}
+	value, ok := searchParams[indexparamcheck.MinHashElementBitWidth]
+	if !ok {
+		return nil
+	}
+	bitWidth := fmt.Sprint(value)
+	if bitWidth != "32" && bitWidth != "64" {
+		return fmt.Errorf("%s [%s] is invalid, should be 32 or 64", indexparamcheck.MinHashElementBitWidth, bitWidth)
+	}
+	return nil
+}
+
 func getOutputFieldIDs(schema *schemapb.CollectionSchema, outputFields []string) (outputFieldIDs []UniqueID, err error) {
 	outputFieldIDs = make([]UniqueID, 0, len(outputFields))
 	for _, name := range outputFields {
@@ -391,7 +427,15 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 			guaranteeTs = parseGuaranteeTsFromConsistency(guaranteeTs, t.BeginTs(), consistencyLevel)
 		}
 	}
+	// read the own writes of the client, from the same shard delegators as its prior reads
+	if consistencyLevel == commonpb.ConsistencyLevel_Session {
+		if client := sessionClient(ctx); client != 0 {
+			guaranteeTs = getSessionRouter().guaranteeTs(client, t.CollectionID, guaranteeTs)
+			t.sessionClient = client
+		}
+	}
 	t.SearchRequest.GuaranteeTimestamp = guaranteeTs
+	t.SearchRequest.ConsistencyLevel = consistencyLevel
 
 	if deadline, ok := t.TraceCtx().Deadline(); ok {
 		t.SearchRequest.TimeoutTimestamp = tsoutil.ComposeTSByTime(deadline, 0)
@@ -427,8 +471,10 @@ func (t *searchTask) Execute(ctx context.Context) error {
 		db:             t.request.GetDbName(),
 		collectionID:   t.SearchRequest.CollectionID,
 		collectionName: t.collectionName,
+		partitionIDs:   t.SearchRequest.GetPartitionIDs(),
 		nq:             t.Nq,
 		exec:           t.searchShard,
+		sessionClient:  t.sessionClient,
 	})
 	if err != nil {
 		log.Warn("search execute failed", zap.Error(err))
@@ -513,6 +559,21 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 	}
 	t.result.Results.OutputFields = t.userOutputFields
 
+	useURL, err := parseVectorOutputMode(t.request.GetSearchParams())
+	if err != nil {
+		return err
+	}
+	if useURL && t.vectorURLWriter != nil && t.result.GetResults() != nil {
+		fieldsData, replaced, err := t.vectorURLWriter.Replace(ctx, t.GetCollectionID(), t.ID(), t.result.GetResults().GetFieldsData())
+		if err != nil {
+			log.Warn("failed to replace vector output fields with urls", zap.Error(err))
+			return err
+		}
+		t.result.Results.FieldsData = fieldsData
+		t.result.Results.OutputFields = replaceVectorURLOutputFields(t.result.GetResults().GetOutputFields(), replaced)
+		tr.CtxRecord(ctx, "replaceVectorWithURL")
+	}
+
 	log.Debug("Search post execute done",
 		zap.Int64("collection", t.GetCollectionID()),
 		zap.Int64s("partitionIDs", t.GetPartitionIDs()))
@@ -537,6 +598,7 @@ func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.Que
 	var result *internalpb.SearchResults
 	var err error
 
+	start := time.Now()
 	result, err = qn.Search(ctx, req)
 	if err != nil {
 		log.Warn("QueryNode search return error", zap.Error(err))
@@ -551,8 +613,13 @@ func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.Que
 			zap.String("reason", result.GetStatus().GetReason()))
 		return errors.Wrapf(merr.Error(result.GetStatus()), "fail to search on QueryNode %d", nodeID)
 	}
+	if err := chargeQueryLimits(ctx, result.GetCostAggregation(), result); err != nil {
+		log.Warn("search exceeds the limit", zap.Error(err))
+		return err
+	}
 	t.resultBuf.Insert(result)
 	t.lb.UpdateCostMetrics(nodeID, result.CostAggregation)
+	getSlowLogTrace(ctx).addNodeCost(t.Name(), nodeID, result.GetCostAggregation(), time.Since(start))
 
 	return nil
 }
@@ -723,6 +790,9 @@ func decodeSearchResults(ctx context.Context, searchResults []*internalpb.Search
 		}
 
 		results = append(results, &partialResultData)
+		// the blob is not used after decoded, recycle it for the query node in the same process
+		bufferpool.SearchResultPool.Put(partialSearchResult.SlicedBlob)
+		partialSearchResult.SlicedBlob = nil
 	}
 	tr.CtxElapse(ctx, "decodeSearchResults done")
 	return results, nil
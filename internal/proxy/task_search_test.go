@@ -1765,6 +1765,15 @@ func TestTaskSearch_parseQueryInfo(t *testing.T) {
 			Value: strconv.FormatInt(targetOffset, 10),
 		})
 
+		minHashParams := getBaseSearchParams()
+		minHashParams = append(minHashParams, &commonpb.KeyValuePair{
+			Key:   common.MetricTypeKey,
+			Value: metric.MHJACCARD,
+		}, &commonpb.KeyValuePair{
+			Key:   SearchParamsKey,
+			Value: `{"mh_element_bit_width": 64}`,
+		})
+
 		tests := []struct {
 			description string
 			validParams []*commonpb.KeyValuePair
@@ -1773,6 +1782,7 @@ func TestTaskSearch_parseQueryInfo(t *testing.T) {
 			{"noSearchParams", noSearchParams},
 			{"normal", normalParam},
 			{"offsetParam", offsetParam},
+			{"minHashParams", minHashParams},
 		}
 
 		for _, test := range tests {
@@ -1849,6 +1859,14 @@ func TestTaskSearch_parseQueryInfo(t *testing.T) {
 			Value: "16386",
 		})
 
+		spInvalidMinHashBitWidth := append(spNoMetricType, &commonpb.KeyValuePair{
+			Key:   common.MetricTypeKey,
+			Value: metric.MHJACCARD,
+		}, &commonpb.KeyValuePair{
+			Key:   SearchParamsKey,
+			Value: `{"mh_element_bit_width": 16}`,
+		})
+
 		tests := []struct {
 			description   string
 			invalidParams []*commonpb.KeyValuePair
@@ -1862,6 +1880,7 @@ func TestTaskSearch_parseQueryInfo(t *testing.T) {
 			{"Invalid_offset_not_int", spInvalidOffsetNoInt},
 			{"Invalid_offset_negative", spInvalidOffsetNegative},
 			{"Invalid_offset_too_large", spInvalidOffsetTooLarge},
+			{"Invalid_minhash_bit_width", spInvalidMinHashBitWidth},
 		}
 
 		for _, test := range tests {
@@ -1931,6 +1950,7 @@ func TestSearchTask_Requery(t *testing.T) {
 	cache.EXPECT().GetPartitions(mock.Anything, mock.Anything, mock.Anything).Return(map[string]int64{"_default": UniqueID(1)}, nil).Maybe()
 	cache.EXPECT().GetCollectionInfo(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&collectionBasicInfo{}, nil).Maybe()
 	cache.EXPECT().GetShards(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string][]nodeInfo{}, nil).Maybe()
+	cache.EXPECT().GetPartitionReplicaNumbers(mock.Anything, mock.Anything).Return(nil).Maybe()
 	cache.EXPECT().DeprecateShardCache(mock.Anything, mock.Anything).Return().Maybe()
 	globalMetaCache = cache
 
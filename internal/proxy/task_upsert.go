@@ -21,6 +21,7 @@ import (
 	"strconv"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 
@@ -62,6 +63,15 @@ type upsertTask struct {
 	schema           *schemapb.CollectionSchema
 	partitionKeyMode bool
 	partitionKeys    *schemapb.FieldData
+	// timePartitioned is true if the rows are routed to the partition of the current period,
+	// the previous versions of which may be in any partition
+	timePartitioned bool
+
+	// partial skips the rows violating the schema instead of failing the whole batch
+	partial bool
+	// rowIndexes are the indexes in the request of the rows remaining after the invalid rows are skipped
+	rowIndexes []uint32
+	rowErrors  []*rowError
 }
 
 // TraceCtx returns upsertTask context
@@ -213,8 +223,11 @@ func (it *upsertTask) insertPreExecute(ctx context.Context) error {
 		}
 	}
 
-	if err := newValidateUtil(withNANCheck(), withOverflowCheck(), withMaxLenCheck()).
-		Validate(it.upsertMsg.InsertMsg.GetFieldsData(), it.schema, it.upsertMsg.InsertMsg.NRows()); err != nil {
+	validator := newValidateUtil(withNANCheck(), withOverflowCheck(), withMaxLenCheck())
+	if err := validator.Validate(it.upsertMsg.InsertMsg.GetFieldsData(), it.schema, it.upsertMsg.InsertMsg.NRows()); err != nil {
+		if !it.partial {
+			it.rowErrors, _ = validator.findRowErrors(it.upsertMsg.InsertMsg.GetFieldsData(), it.schema, it.upsertMsg.InsertMsg.NRows())
+		}
 		return err
 	}
 
@@ -240,8 +253,9 @@ func (it *upsertTask) deletePreExecute(ctx context.Context) error {
 	it.upsertMsg.DeleteMsg.CollectionID = collID
 	it.collectionID = collID
 
-	if it.partitionKeyMode {
-		// multi entities with same pk and diff partition keys may be hashed to multi physical partitions
+	if it.partitionKeyMode || it.timePartitioned {
+		// multi entities with same pk and diff partition keys may be hashed to multi physical partitions,
+		// and the entities of time-partitioned collection may be inserted in the previous periods,
 		// if deleteMsg.partitionID = common.InvalidPartition,
 		// all segments with this pk under the collection will have the delete record
 		it.upsertMsg.DeleteMsg.PartitionID = common.InvalidPartitionID
@@ -305,10 +319,14 @@ func (it *upsertTask) PreExecute(ctx context.Context) error {
 		}
 	} else {
 		// set default partition name if not use partition key
-		// insert to _default partition
+		// insert to _default partition, or the partition of the current period if the collection is time-partitioned
 		partitionTag := it.req.GetPartitionName()
 		if len(partitionTag) <= 0 {
-			partitionTag = Params.CommonCfg.DefaultPartitionName.GetValue()
+			partitionTag, it.timePartitioned, err = getWritePartitionName(ctx, it.req.GetDbName(), collectionName)
+			if err != nil {
+				log.Warn("get partition to upsert failed", zap.String("collectionName", collectionName), zap.Error(err))
+				return err
+			}
 			it.req.PartitionName = partitionTag
 		}
 	}
@@ -342,6 +360,12 @@ func (it *upsertTask) PreExecute(ctx context.Context) error {
 			},
 		},
 	}
+	if it.partial {
+		if err := it.skipInvalidRows(); err != nil {
+			return err
+		}
+	}
+
 	err = it.insertPreExecute(ctx)
 	if err != nil {
 		log.Warn("Fail to insertPreExecute", zap.Error(err))
@@ -366,6 +390,30 @@ func (it *upsertTask) PreExecute(ctx context.Context) error {
 	return nil
 }
 
+// skipInvalidRows removes the rows violating the schema from the upsert message,
+// the request is kept as it is.
+func (it *upsertTask) skipInvalidRows() error {
+	var err error
+	insertMsg := it.upsertMsg.InsertMsg
+	numRows := insertMsg.NRows()
+	validator := newValidateUtil(withNANCheck(), withOverflowCheck(), withMaxLenCheck())
+	it.rowErrors, err = validator.findRowErrors(insertMsg.GetFieldsData(), it.schema, numRows)
+	if err != nil || len(it.rowErrors) == 0 {
+		return err
+	}
+	insertMsg.FieldsData, it.rowIndexes, err = dropInvalidRows(insertMsg.GetFieldsData(), numRows, it.rowErrors)
+	if err != nil {
+		return err
+	}
+	if len(it.baseMsg.HashValues) == int(numRows) {
+		hashValues := it.baseMsg.HashValues
+		it.baseMsg.HashValues = lo.Map(it.rowIndexes, func(i uint32, _ int) uint32 { return hashValues[i] })
+	}
+	insertMsg.NumRows = uint64(len(it.rowIndexes))
+	it.upsertMsg.DeleteMsg.NumRows = int64(len(it.rowIndexes))
+	return nil
+}
+
 func (it *upsertTask) insertExecute(ctx context.Context, msgPack *msgstream.MsgPack) error {
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("proxy insertExecute upsert %d", it.ID()))
 	defer tr.Elapse("insert execute done when insertExecute")
@@ -0,0 +1,62 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/milvus-io/milvus/internal/http/tuning"
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// newTuner returns the tuner of the knobs which the proxy reads on the fly, for tuning during incidents.
+// The changes are persisted in etcd for auditing.
+func (node *Proxy) newTuner() *tuning.Tuner {
+	var store tuning.ChangeStore
+	if node.etcdCli != nil {
+		store = tuning.NewKVChangeStore(etcdkv.NewEtcdKV(node.etcdCli, Params.EtcdCfg.MetaRootPath.GetValue()), typeutil.ProxyRole)
+	}
+	return tuning.NewTuner(typeutil.ProxyRole, mgrTuningAuthenticate, store,
+		tuning.Knob{Key: Params.ProxyCfg.SlowLog.Enable.Key, Doc: "whether to record the slow requests"},
+		tuning.Knob{Key: Params.ProxyCfg.SlowLog.SearchThreshold.Key, Doc: "threshold in milliseconds of slow searches"},
+		tuning.Knob{Key: Params.ProxyCfg.SlowLog.QueryThreshold.Key, Doc: "threshold in milliseconds of slow queries"},
+		tuning.Knob{Key: Params.ProxyCfg.SlowLog.InsertThreshold.Key, Doc: "threshold in milliseconds of slow inserts"},
+		tuning.Knob{Key: Params.ProxyCfg.SlowLog.CollectionThresholds.Key, Doc: "thresholds of slow requests overridden per collection"},
+		tuning.Knob{Key: Params.ProxyCfg.SlowLog.SampleRatio.Key, Doc: "ratio of the slow requests recorded"},
+		tuning.Knob{Key: Params.ProxyCfg.ExprCacheEnabled.Key, Doc: "whether to cache the parsed filter expressions"},
+	)
+}
+
+// mgrTuningAuthenticate permits the admins to tune the knobs, and returns the user to audit.
+func mgrTuningAuthenticate(w http.ResponseWriter, req *http.Request) (string, bool) {
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return "", false
+	}
+	isAdmin, err := mgrIsAdmin(ctx)
+	if err == nil && !isAdmin {
+		err = merr.WrapErrPrivilegeNotPermitted("admin role is required")
+	}
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return "", false
+	}
+	return mgrCurUser(ctx), true
+}
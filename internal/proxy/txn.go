@@ -0,0 +1,795 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/allocator"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/retry"
+	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const TxnCommitTaskName = "TxnCommitTask"
+
+type txnState int32
+
+const (
+	txnOpen txnState = iota
+	txnCommitting
+	txnCommitted
+	txnAborted
+)
+
+func (s txnState) String() string {
+	switch s {
+	case txnOpen:
+		return "Open"
+	case txnCommitting:
+		return "Committing"
+	case txnCommitted:
+		return "Committed"
+	case txnAborted:
+		return "Aborted"
+	default:
+		return "Unknown"
+	}
+}
+
+// txn buffers the inserts and deletes of a transaction until it's committed.
+type txn struct {
+	id UniqueID
+	// owner is the user who began the transaction, empty if authorization is disabled
+	owner    string
+	deadline time.Time
+	state    txnState
+	inserts  []*milvuspb.InsertRequest
+	deletes  []*milvuspb.DeleteRequest
+}
+
+// txnManager is the coordinator of the transactions on proxy.
+//
+// The mutations of a transaction may span multiple collections, they are buffered until committed,
+// then written with one timestamp, see txnCommitTask for the guarantees.
+// The primary keys written by a committing transaction are locked,
+// the transaction writing any of them concurrently fails with conflict.
+//
+// Limits:
+//   - the transactions and the locked keys are kept in the memory of one proxy,
+//     the client must send all the requests of a transaction to the proxy which began it,
+//     and the transactions are lost if the proxy restarts.
+//   - conflicts are only detected among the transactions of the same proxy,
+//     the plain writes and the transactions of other proxies are not checked.
+//   - only inserts and deletes by primary keys are supported, upserts are rejected.
+//   - only the user who began the transaction could write into or commit it, the admins could abort or commit any.
+type txnManager struct {
+	mu   sync.Mutex
+	txns map[UniqueID]*txn
+	// lockedKeys maps collectionID/primaryKey to the transaction committing it
+	lockedKeys map[string]UniqueID
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newTxnManager() *txnManager {
+	return &txnManager{
+		txns:       make(map[UniqueID]*txn),
+		lockedKeys: make(map[string]UniqueID),
+		closeCh:    make(chan struct{}),
+	}
+}
+
+func (m *txnManager) start() {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.closeCh:
+				log.Info("txn manager quit")
+				return
+			case <-ticker.C:
+				m.expire()
+			}
+		}
+	}()
+}
+
+func (m *txnManager) close() {
+	m.closeOnce.Do(func() {
+		close(m.closeCh)
+		m.wg.Wait()
+	})
+}
+
+// begin opens a transaction, it's aborted if not committed before timeout.
+func (m *txnManager) begin(txnID UniqueID, owner string, timeout time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	maxNum := Params.ProxyCfg.TxnMaxNum.GetAsInt()
+	if len(m.txns) >= maxNum {
+		return merr.WrapErrServiceRequestLimitExceeded(int32(maxNum), "too many open transactions")
+	}
+	m.txns[txnID] = &txn{
+		id:       txnID,
+		owner:    owner,
+		deadline: time.Now().Add(timeout),
+		state:    txnOpen,
+	}
+	log.Info("transaction begins", zap.Int64("txnID", txnID), zap.String("owner", owner), zap.Duration("timeout", timeout))
+	return nil
+}
+
+// getTxn returns the transaction if it's accessible by the user, the caller must hold the lock.
+func (m *txnManager) getTxn(txnID UniqueID, user string, isAdmin bool) (*txn, error) {
+	t, ok := m.txns[txnID]
+	if !ok {
+		return nil, merr.WrapErrTxnNotFound(txnID)
+	}
+	if !isAdmin && t.owner != user {
+		return nil, merr.WrapErrPrivilegeNotPermitted("transaction %d is not owned by user %s", txnID, user)
+	}
+	return t, nil
+}
+
+// getOpenTxn returns the open transaction if it's accessible by the user, the caller must hold the lock.
+func (m *txnManager) getOpenTxn(txnID UniqueID, user string, isAdmin bool) (*txn, error) {
+	t, err := m.getTxn(txnID, user, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if t.state != txnOpen {
+		return nil, merr.WrapErrTxnNotOpen(txnID, t.state.String())
+	}
+	if time.Now().After(t.deadline) {
+		delete(m.txns, txnID)
+		return nil, merr.WrapErrTxnExpired(txnID)
+	}
+	return t, nil
+}
+
+// addInsert buffers the insert into the transaction, only the owner could write into it.
+func (m *txnManager) addInsert(txnID UniqueID, user string, req *milvuspb.InsertRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, err := m.getOpenTxn(txnID, user, false)
+	if err != nil {
+		return err
+	}
+	t.inserts = append(t.inserts, req)
+	return nil
+}
+
+// addDelete buffers the delete into the transaction, only the owner could write into it.
+func (m *txnManager) addDelete(txnID UniqueID, user string, req *milvuspb.DeleteRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, err := m.getOpenTxn(txnID, user, false)
+	if err != nil {
+		return err
+	}
+	t.deletes = append(t.deletes, req)
+	return nil
+}
+
+// startCommit moves the transaction into committing, no more mutations are accepted.
+func (m *txnManager) startCommit(txnID UniqueID, user string, isAdmin bool) (*txn, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, err := m.getOpenTxn(txnID, user, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	t.state = txnCommitting
+	return t, nil
+}
+
+// finish ends the committing transaction, and releases the keys locked by it.
+func (m *txnManager) finish(txnID UniqueID, committed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, holder := range m.lockedKeys {
+		if holder == txnID {
+			delete(m.lockedKeys, key)
+		}
+	}
+	if t, ok := m.txns[txnID]; ok {
+		t.state = txnAborted
+		if committed {
+			t.state = txnCommitted
+		}
+		delete(m.txns, txnID)
+	}
+	log.Info("transaction finished", zap.Int64("txnID", txnID), zap.Bool("committed", committed))
+}
+
+// abort discards the buffered mutations of the open transaction.
+func (m *txnManager) abort(txnID UniqueID, user string, isAdmin bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, err := m.getTxn(txnID, user, isAdmin)
+	if err != nil {
+		return err
+	}
+	if t.state != txnOpen {
+		return merr.WrapErrTxnNotOpen(txnID, t.state.String())
+	}
+	t.state = txnAborted
+	delete(m.txns, txnID)
+	log.Info("transaction aborted", zap.Int64("txnID", txnID))
+	return nil
+}
+
+// lock locks the keys for the committing transaction,
+// nothing is locked if any of them is locked by another transaction.
+func (m *txnManager) lock(txnID UniqueID, keys []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		if holder, ok := m.lockedKeys[key]; ok && holder != txnID {
+			return merr.WrapErrTxnConflict(txnID, holder, fmt.Sprintf("primary key %s is being written", key))
+		}
+	}
+	for _, key := range keys {
+		m.lockedKeys[key] = txnID
+	}
+	return nil
+}
+
+// expire aborts the open transactions out of deadline.
+func (m *txnManager) expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for id, t := range m.txns {
+		if t.state == txnOpen && now.After(t.deadline) {
+			delete(m.txns, id)
+			log.Info("transaction expired", zap.Int64("txnID", id))
+		}
+	}
+}
+
+// getTxnIDFromContext returns the transaction id carried in the metadata of request.
+func getTxnIDFromContext(ctx context.Context) (UniqueID, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false, nil
+	}
+	values := md[strings.ToLower(util.HeaderTxnID)]
+	if len(values) < 1 || values[0] == "" {
+		return 0, false, nil
+	}
+	txnID, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return 0, true, merr.WrapErrParameterInvalidMsg("invalid %s: %s", util.HeaderTxnID, values[0])
+	}
+	return txnID, true, nil
+}
+
+// txnOpResult is the result of a mutation of the committed transaction.
+type txnOpResult struct {
+	Op             string   `json:"op"`
+	DBName         string   `json:"db_name"`
+	CollectionName string   `json:"collection_name"`
+	InsertCount    int64    `json:"insert_count,omitempty"`
+	DeleteCount    int64    `json:"delete_count,omitempty"`
+	IntIDs         []int64  `json:"int_ids,omitempty"`
+	StrIDs         []string `json:"str_ids,omitempty"`
+}
+
+// txnCommitResult is the result of the committed transaction,
+// the mutations are visible to the reads with timestamp no less than Timestamp.
+type txnCommitResult struct {
+	TxnID     int64         `json:"txn_id"`
+	Timestamp uint64        `json:"timestamp"`
+	Results   []txnOpResult `json:"results"`
+}
+
+// txnCommitTask writes all the mutations of a transaction with one timestamp.
+//
+// The task holds the timeticks of all the physical channels it writes until it's done,
+// so no channel serves the timestamp of the transaction before all the mutations are written.
+// The inserts are written first, if any of them fails, the inserts written are deleted
+// with the timestamp right after the transaction, which no read could be assigned to.
+// The deletes can't be undone, so they are written after all the inserts succeeded, and retried on failure.
+//
+// So the commit is NOT atomic in general: if some deletes still fail after retrying,
+// the mutations written stay visible and ErrTxnPartialApplied is returned,
+// the client has to inspect and repair the data. It's all-or-nothing only if every delete is written.
+type txnCommitTask struct {
+	Condition
+	ctx context.Context
+
+	txn          *txn
+	manager      *txnManager
+	idAllocator  *allocator.IDAllocator
+	tsoAllocator *timestampAllocator
+	chMgr        channelsMgr
+
+	id         UniqueID
+	ts         Timestamp
+	rollbackTs Timestamp
+	inserts    []*insertTask
+	deletes    []*deleteTask
+	deleteKeys []*schemapb.IDs
+	pChannels  []pChan
+
+	result *txnCommitResult
+}
+
+func newTxnCommitTask(ctx context.Context, node *Proxy, t *txn) *txnCommitTask {
+	ct := &txnCommitTask{
+		Condition:    NewTaskCondition(ctx),
+		ctx:          ctx,
+		txn:          t,
+		manager:      node.txnManager,
+		idAllocator:  node.rowIDAllocator,
+		tsoAllocator: node.tsoAllocator,
+		chMgr:        node.chMgr,
+	}
+	for _, req := range t.inserts {
+		ct.inserts = append(ct.inserts, &insertTask{
+			ctx:       ctx,
+			Condition: NewTaskCondition(ctx),
+			insertMsg: &msgstream.InsertMsg{
+				BaseMsg: msgstream.BaseMsg{
+					HashValues: req.HashKeys,
+				},
+				InsertRequest: msgpb.InsertRequest{
+					Base: commonpbutil.NewMsgBase(
+						commonpbutil.WithMsgType(commonpb.MsgType_Insert),
+						commonpbutil.WithSourceID(paramtable.GetNodeID()),
+					),
+					DbName:         req.GetDbName(),
+					CollectionName: req.CollectionName,
+					PartitionName:  req.PartitionName,
+					FieldsData:     req.FieldsData,
+					NumRows:        uint64(req.NumRows),
+					Version:        msgpb.InsertDataVersion_ColumnBased,
+				},
+			},
+			idAllocator:   node.rowIDAllocator,
+			segIDAssigner: node.segAssigner,
+			chMgr:         node.chMgr,
+			chTicker:      node.chTicker,
+		})
+	}
+	for _, req := range t.deletes {
+		ct.deletes = append(ct.deletes, &deleteTask{
+			ctx:         ctx,
+			Condition:   NewTaskCondition(ctx),
+			req:         req,
+			idAllocator: node.rowIDAllocator,
+			chMgr:       node.chMgr,
+			chTicker:    node.chTicker,
+			lb:          node.lbPolicy,
+		})
+	}
+	return ct
+}
+
+func (ct *txnCommitTask) TraceCtx() context.Context {
+	return ct.ctx
+}
+
+func (ct *txnCommitTask) ID() UniqueID {
+	return ct.id
+}
+
+func (ct *txnCommitTask) SetID(uid UniqueID) {
+	ct.id = uid
+}
+
+func (ct *txnCommitTask) Name() string {
+	return TxnCommitTaskName
+}
+
+func (ct *txnCommitTask) Type() commonpb.MsgType {
+	return commonpb.MsgType_Insert
+}
+
+func (ct *txnCommitTask) BeginTs() Timestamp {
+	return ct.ts
+}
+
+func (ct *txnCommitTask) EndTs() Timestamp {
+	return ct.rollbackTs
+}
+
+// SetTs does nothing, the timestamps of transaction are allocated on enqueue.
+func (ct *txnCommitTask) SetTs(ts Timestamp) {}
+
+// OnEnqueue allocates two successive timestamps, the first one to write the mutations,
+// the second one to undo the inserts if the transaction fails.
+func (ct *txnCommitTask) OnEnqueue() error {
+	tss, err := ct.tsoAllocator.alloc(ct.ctx, 2)
+	if err != nil {
+		return err
+	}
+	if len(tss) != 2 {
+		return merr.WrapErrServiceInternal(fmt.Sprintf("expect 2 timestamps, but got %d", len(tss)))
+	}
+	ct.ts, ct.rollbackTs = tss[0], tss[1]
+	return nil
+}
+
+func (ct *txnCommitTask) setChannels() error {
+	channels := typeutil.NewSet[pChan]()
+	for _, it := range ct.inserts {
+		if err := it.setChannels(); err != nil {
+			return err
+		}
+		channels.Insert(it.getChannels()...)
+	}
+	for _, dt := range ct.deletes {
+		if err := dt.setChannels(); err != nil {
+			return err
+		}
+		channels.Insert(dt.getChannels()...)
+	}
+	ct.pChannels = channels.Collect()
+	return nil
+}
+
+func (ct *txnCommitTask) getChannels() []pChan {
+	return ct.pChannels
+}
+
+// PreExecute validates all the mutations and locks the primary keys, nothing is written if any of them fails.
+func (ct *txnCommitTask) PreExecute(ctx context.Context) error {
+	keys := make([]string, 0)
+	for _, it := range ct.inserts {
+		if err := checkCollectionFrozen(ctx, it.insertMsg.GetDbName(), it.insertMsg.GetCollectionName(), true); err != nil {
+			return err
+		}
+		it.SetID(ct.ID())
+		it.SetTs(ct.ts)
+		if err := it.PreExecute(ctx); err != nil {
+			return err
+		}
+		collID, err := globalMetaCache.GetCollectionID(ctx, it.insertMsg.GetDbName(), it.insertMsg.GetCollectionName())
+		if err != nil {
+			return err
+		}
+		keys = append(keys, buildTxnKeys(collID, it.result.GetIDs())...)
+	}
+
+	ct.deleteKeys = make([]*schemapb.IDs, 0, len(ct.deletes))
+	for _, dt := range ct.deletes {
+		if err := checkCollectionFrozen(ctx, dt.req.GetDbName(), dt.req.GetCollectionName(), true); err != nil {
+			return err
+		}
+		dt.SetID(ct.ID())
+		dt.SetTs(ct.ts)
+		if err := dt.PreExecute(ctx); err != nil {
+			return err
+		}
+		dt.tr = timerecord.NewTimeRecorder(fmt.Sprintf("proxy execute txn delete %d", ct.ID()))
+		plan, err := planparserv2.CreateRetrievePlan(dt.schema, dt.req.GetExpr())
+		if err != nil {
+			return merr.WrapErrParameterInvalidMsg("failed to create expr plan, expr = %s", dt.req.GetExpr())
+		}
+		// the deletes of transaction must specify the primary keys, or they can't be locked
+		isSimple, termExpr := getExpr(plan)
+		if !isSimple {
+			return merr.WrapErrParameterInvalidMsg("only the delete by primary keys is supported in transaction, expr = %s", dt.req.GetExpr())
+		}
+		pks, _, err := getPrimaryKeysFromExpr(dt.schema, termExpr)
+		if err != nil {
+			return err
+		}
+		ct.deleteKeys = append(ct.deleteKeys, pks)
+		keys = append(keys, buildTxnKeys(dt.collectionID, pks)...)
+	}
+
+	return ct.manager.lock(ct.txn.id, keys)
+}
+
+func buildTxnKeys(collectionID UniqueID, ids *schemapb.IDs) []string {
+	num := typeutil.GetSizeOfIDs(ids)
+	keys := make([]string, 0, num)
+	for i := 0; i < num; i++ {
+		keys = append(keys, fmt.Sprintf("%d/%v", collectionID, typeutil.GetPK(ids, int64(i))))
+	}
+	return keys
+}
+
+func (ct *txnCommitTask) Execute(ctx context.Context) error {
+	log := log.Ctx(ctx).With(zap.Int64("txnID", ct.txn.id), zap.Uint64("ts", ct.ts))
+
+	for i, it := range ct.inserts {
+		if err := it.Execute(ctx); err != nil {
+			log.Warn("failed to write the inserts of transaction, rollback", zap.String("collection", it.insertMsg.GetCollectionName()), zap.Error(err))
+			// the failed one may be written partially
+			if rollbackErr := ct.rollback(ctx, ct.inserts[:i+1]); rollbackErr != nil {
+				return merr.WrapErrTxnPartialApplied(ct.txn.id, rollbackErr.Error())
+			}
+			return err
+		}
+	}
+
+	for i, dt := range ct.deletes {
+		stream, err := dt.chMgr.getOrCreateDmlStream(dt.collectionID)
+		if err == nil {
+			err = retry.Do(ctx, func() error {
+				return dt.produce(ctx, stream, ct.deleteKeys[i])
+			}, retry.Attempts(5))
+		}
+		if err != nil {
+			log.Warn("failed to write the deletes of transaction, rollback", zap.String("collection", dt.req.GetCollectionName()), zap.Error(err))
+			if rollbackErr := ct.rollback(ctx, ct.inserts); rollbackErr != nil {
+				return merr.WrapErrTxnPartialApplied(ct.txn.id, rollbackErr.Error())
+			}
+			if i > 0 {
+				return merr.WrapErrTxnPartialApplied(ct.txn.id, fmt.Sprintf("the deletes before collection %s are applied", dt.req.GetCollectionName()))
+			}
+			return err
+		}
+	}
+	log.Info("transaction committed", zap.Int("inserts", len(ct.inserts)), zap.Int("deletes", len(ct.deletes)))
+	return nil
+}
+
+// rollback deletes the rows written by the inserts with the rollback timestamp.
+func (ct *txnCommitTask) rollback(ctx context.Context, inserts []*insertTask) error {
+	for _, it := range inserts {
+		if typeutil.GetSizeOfIDs(it.result.GetIDs()) == 0 {
+			continue
+		}
+		dt := &deleteTask{
+			ctx: ctx,
+			tr:  timerecord.NewTimeRecorder(fmt.Sprintf("proxy rollback txn %d", ct.txn.id)),
+			req: &milvuspb.DeleteRequest{
+				DbName:         it.insertMsg.GetDbName(),
+				CollectionName: it.insertMsg.GetCollectionName(),
+			},
+			result:       &milvuspb.MutationResult{},
+			chMgr:        ct.chMgr,
+			idAllocator:  ct.idAllocator,
+			ts:           ct.rollbackTs,
+			collectionID: it.insertMsg.GetCollectionID(),
+			partitionID:  common.InvalidPartitionID,
+		}
+		var err error
+		if dt.collectionID == 0 {
+			dt.collectionID, err = globalMetaCache.GetCollectionID(ctx, dt.req.GetDbName(), dt.req.GetCollectionName())
+			if err != nil {
+				return err
+			}
+		}
+		dt.vChannels, err = ct.chMgr.getVChannels(dt.collectionID)
+		if err != nil {
+			return err
+		}
+		stream, err := ct.chMgr.getOrCreateDmlStream(dt.collectionID)
+		if err != nil {
+			return err
+		}
+		err = retry.Do(ctx, func() error {
+			return dt.produce(ctx, stream, it.result.GetIDs())
+		}, retry.Attempts(10))
+		if err != nil {
+			log.Ctx(ctx).Warn("failed to rollback the inserts of transaction", zap.Int64("txnID", ct.txn.id),
+				zap.String("collection", it.insertMsg.GetCollectionName()), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (ct *txnCommitTask) PostExecute(ctx context.Context) error {
+	ct.result = &txnCommitResult{
+		TxnID:     ct.txn.id,
+		Timestamp: ct.ts,
+		Results:   make([]txnOpResult, 0, len(ct.inserts)+len(ct.deletes)),
+	}
+	for _, it := range ct.inserts {
+		ct.result.Results = append(ct.result.Results, txnOpResult{
+			Op:             "insert",
+			DBName:         it.insertMsg.GetDbName(),
+			CollectionName: it.insertMsg.GetCollectionName(),
+			InsertCount:    int64(it.insertMsg.NRows()),
+			IntIDs:         it.result.GetIDs().GetIntId().GetData(),
+			StrIDs:         it.result.GetIDs().GetStrId().GetData(),
+		})
+	}
+	for _, dt := range ct.deletes {
+		ct.result.Results = append(ct.result.Results, txnOpResult{
+			Op:             "delete",
+			DBName:         dt.req.GetDbName(),
+			CollectionName: dt.req.GetCollectionName(),
+			DeleteCount:    dt.result.GetDeleteCnt(),
+		})
+	}
+	return nil
+}
+
+// commitTxn writes the buffered mutations of the transaction with one timestamp.
+func (node *Proxy) commitTxn(ctx context.Context, txnID UniqueID, user string, isAdmin bool) (*txnCommitResult, error) {
+	t, err := node.txnManager.startCommit(txnID, user, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	ct := newTxnCommitTask(ctx, node, t)
+	if err := node.sched.dmQueue.Enqueue(ct); err != nil {
+		node.txnManager.finish(txnID, false)
+		return nil, err
+	}
+	err = ct.WaitToFinish()
+	node.txnManager.finish(txnID, err == nil)
+	if err != nil {
+		return nil, err
+	}
+	return ct.result, nil
+}
+
+// BeginTxn opens a transaction, the inserts and deletes with the transaction id in metadata are buffered into it.
+// The transaction is owned by the authenticated user and only lives on this proxy.
+func (node *Proxy) BeginTxn(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return
+	}
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to begin transaction, %s"}`, err.Error())))
+		return
+	}
+	timeout := Params.ProxyCfg.TxnTimeout.GetAsDuration(time.Second)
+	if seconds := req.URL.Query().Get("timeout_seconds"); seconds != "" {
+		n, err := strconv.ParseInt(seconds, 10, 64)
+		if err != nil || n <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "invalid timeout_seconds %s"}`, seconds)))
+			return
+		}
+		timeout = time.Duration(n) * time.Second
+	}
+	txnID, err := node.rowIDAllocator.AllocOne()
+	if err == nil {
+		err = node.txnManager.begin(txnID, mgrCurUser(ctx), timeout)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to begin transaction, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(fmt.Sprintf(`{"msg": "OK", "txn_id": %d}`, txnID)))
+}
+
+// CommitTxn commits the transaction, the mutations become visible atomically at the returned timestamp.
+func (node *Proxy) CommitTxn(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return
+	}
+	txnID, err := strconv.ParseInt(req.URL.Query().Get("txn_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid txn_id, %s"}`, err.Error())))
+		return
+	}
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	isAdmin, err := mgrIsAdmin(ctx)
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	result, err := node.commitTxn(ctx, txnID, mgrCurUser(ctx), isAdmin)
+	if err != nil {
+		w.WriteHeader(txnErrorToHTTPStatus(err))
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to commit transaction, %s"}`, err.Error())))
+		return
+	}
+	bs, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "%s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bs)
+}
+
+// AbortTxn discards the buffered mutations of the transaction.
+func (node *Proxy) AbortTxn(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return
+	}
+	txnID, err := strconv.ParseInt(req.URL.Query().Get("txn_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid txn_id, %s"}`, err.Error())))
+		return
+	}
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	isAdmin, err := mgrIsAdmin(ctx)
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	if err := node.txnManager.abort(txnID, mgrCurUser(ctx), isAdmin); err != nil {
+		w.WriteHeader(txnErrorToHTTPStatus(err))
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to abort transaction, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
+
+func txnErrorToHTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, merr.ErrTxnNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, merr.ErrTxnConflict), errors.Is(err, merr.ErrTxnNotOpen):
+		return http.StatusConflict
+	case errors.Is(err, merr.ErrTxnExpired):
+		return http.StatusGone
+	case errors.Is(err, merr.ErrParameterInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, merr.ErrPrivilegeNotPermitted):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,203 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestTxnManager(t *testing.T) {
+	paramtable.Init()
+	m := newTxnManager()
+
+	assert.NoError(t, m.begin(1, "", time.Minute))
+	assert.NoError(t, m.addInsert(1, "", &milvuspb.InsertRequest{CollectionName: "c1"}))
+	assert.NoError(t, m.addDelete(1, "", &milvuspb.DeleteRequest{CollectionName: "c2"}))
+	assert.ErrorIs(t, m.addInsert(2, "", &milvuspb.InsertRequest{}), merr.ErrTxnNotFound)
+
+	txn, err := m.startCommit(1, "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(txn.inserts))
+	assert.Equal(t, 1, len(txn.deletes))
+	// no more mutations are accepted after committing
+	assert.ErrorIs(t, m.addInsert(1, "", &milvuspb.InsertRequest{}), merr.ErrTxnNotOpen)
+	assert.ErrorIs(t, m.abort(1, "", false), merr.ErrTxnNotOpen)
+
+	// the keys locked by the committing transaction conflict with others
+	assert.NoError(t, m.begin(2, "", time.Minute))
+	assert.NoError(t, m.lock(1, []string{"100/1", "100/2"}))
+	assert.ErrorIs(t, m.lock(2, []string{"100/3", "100/2"}), merr.ErrTxnConflict)
+	assert.Equal(t, 2, len(m.lockedKeys))
+	m.finish(1, true)
+	assert.Empty(t, m.lockedKeys)
+	assert.NoError(t, m.lock(2, []string{"100/3", "100/2"}))
+	m.finish(2, false)
+	_, err = m.startCommit(1, "", false)
+	assert.ErrorIs(t, err, merr.ErrTxnNotFound)
+
+	assert.NoError(t, m.begin(3, "", time.Minute))
+	assert.NoError(t, m.abort(3, "", false))
+	assert.ErrorIs(t, m.abort(3, "", false), merr.ErrTxnNotFound)
+
+	// expired transactions
+	assert.NoError(t, m.begin(4, "", -time.Second))
+	_, err = m.startCommit(4, "", false)
+	assert.ErrorIs(t, err, merr.ErrTxnExpired)
+	assert.NoError(t, m.begin(5, "", -time.Second))
+	m.expire()
+	assert.Empty(t, m.txns)
+
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.TxnMaxNum.Key, "1")
+	defer params.Reset(params.ProxyCfg.TxnMaxNum.Key)
+	assert.NoError(t, m.begin(6, "", time.Minute))
+	assert.ErrorIs(t, m.begin(7, "", time.Minute), merr.ErrServiceRequestLimitExceeded)
+
+	m.start()
+	m.close()
+	m.close()
+}
+
+func TestTxnManager_Owner(t *testing.T) {
+	paramtable.Init()
+	m := newTxnManager()
+
+	assert.NoError(t, m.begin(1, "alice", time.Minute))
+	assert.NoError(t, m.addInsert(1, "alice", &milvuspb.InsertRequest{CollectionName: "c1"}))
+	assert.ErrorIs(t, m.addInsert(1, "bob", &milvuspb.InsertRequest{}), merr.ErrPrivilegeNotPermitted)
+	assert.ErrorIs(t, m.addDelete(1, "bob", &milvuspb.DeleteRequest{}), merr.ErrPrivilegeNotPermitted)
+	_, err := m.startCommit(1, "bob", false)
+	assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+	assert.ErrorIs(t, m.abort(1, "bob", false), merr.ErrPrivilegeNotPermitted)
+	assert.Equal(t, http.StatusForbidden, txnErrorToHTTPStatus(merr.WrapErrPrivilegeNotPermitted("")))
+
+	// admin could abort the transaction of others
+	assert.NoError(t, m.abort(1, "bob", true))
+
+	assert.NoError(t, m.begin(2, "alice", time.Minute))
+	txn, err := m.startCommit(2, "alice", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", txn.owner)
+}
+
+func TestProxy_TxnAuthentication(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer params.Reset(params.CommonCfg.AuthorizationEnabled.Key)
+	cache := globalMetaCache
+	globalMetaCache = NewMockCache(t)
+	defer func() { globalMetaCache = cache }()
+
+	node := &Proxy{txnManager: newTxnManager()}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+
+	// requests without credential are rejected
+	w := httptest.NewRecorder()
+	node.BeginTxn(w, httptest.NewRequest(http.MethodPost, mgrRouteTxnBegin, nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = httptest.NewRecorder()
+	node.CommitTxn(w, httptest.NewRequest(http.MethodPost, mgrRouteTxnCommit+"?txn_id=100", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = httptest.NewRecorder()
+	node.AbortTxn(w, httptest.NewRequest(http.MethodPost, mgrRouteTxnAbort+"?txn_id=100", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestGetTxnIDFromContext(t *testing.T) {
+	_, ok, err := getTxnIDFromContext(context.Background())
+	assert.False(t, ok)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(strings.ToLower(util.HeaderTxnID), "100"))
+	txnID, ok, err := getTxnIDFromContext(ctx)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, UniqueID(100), txnID)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(strings.ToLower(util.HeaderTxnID), "abc"))
+	_, ok, err = getTxnIDFromContext(ctx)
+	assert.True(t, ok)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestBuildTxnKeys(t *testing.T) {
+	keys := buildTxnKeys(100, &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2}}}})
+	assert.Equal(t, []string{"100/1", "100/2"}, keys)
+	keys = buildTxnKeys(100, &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: []string{"a"}}}})
+	assert.Equal(t, []string{"100/a"}, keys)
+}
+
+func TestProxy_MutationInTxn(t *testing.T) {
+	paramtable.Init()
+	node := &Proxy{txnManager: newTxnManager()}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+	assert.NoError(t, node.txnManager.begin(100, "", time.Minute))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(strings.ToLower(util.HeaderTxnID), "100"))
+	resp, err := node.Insert(ctx, &milvuspb.InsertRequest{CollectionName: "c1", NumRows: 10})
+	assert.NoError(t, err)
+	assert.NoError(t, merr.Error(resp.GetStatus()))
+	assert.Equal(t, int64(10), resp.GetInsertCnt())
+
+	resp, err = node.Delete(ctx, &milvuspb.DeleteRequest{CollectionName: "c2", Expr: "pk in [1]"})
+	assert.NoError(t, err)
+	assert.NoError(t, merr.Error(resp.GetStatus()))
+
+	resp, err = node.Upsert(ctx, &milvuspb.UpsertRequest{CollectionName: "c1"})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrParameterInvalid)
+
+	txn := node.txnManager.txns[100]
+	assert.Equal(t, 1, len(txn.inserts))
+	assert.Equal(t, 1, len(txn.deletes))
+
+	// the mutations with unknown transaction fail
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(strings.ToLower(util.HeaderTxnID), "101"))
+	resp, err = node.Insert(ctx, &milvuspb.InsertRequest{CollectionName: "c1", NumRows: 10})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrTxnNotFound)
+
+	// abort by http
+	w := httptest.NewRecorder()
+	node.AbortTxn(w, httptest.NewRequest(http.MethodPost, mgrRouteTxnAbort+"?txn_id=100", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = httptest.NewRecorder()
+	node.AbortTxn(w, httptest.NewRequest(http.MethodPost, mgrRouteTxnAbort+"?txn_id=100", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = httptest.NewRecorder()
+	node.CommitTxn(w, httptest.NewRequest(http.MethodPost, mgrRouteTxnCommit+"?txn_id=100", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = httptest.NewRecorder()
+	node.CommitTxn(w, httptest.NewRequest(http.MethodGet, mgrRouteTxnCommit+"?txn_id=100", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
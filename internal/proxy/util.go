@@ -35,7 +35,9 @@ import (
 	"github.com/milvus-io/milvus/internal/parser/planparserv2"
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/timepartition"
 	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -44,6 +46,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/util/contextutil"
 	"github.com/milvus-io/milvus/pkg/util/crypto"
+	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metric"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -361,6 +364,108 @@ func validateMaxCapacityPerRow(collectionName string, field *schemapb.FieldSchem
 	return nil
 }
 
+// fillBinlogCompression validates the binlog codec of each field,
+// fields without codec inherit the collection-wide default specified by the collection properties.
+func fillBinlogCompression(schema *schemapb.CollectionSchema, properties []*commonpb.KeyValuePair) error {
+	defaultValue, hasDefault := common.GetBinlogCompression(properties...)
+	if hasDefault {
+		if _, err := storage.ParseBinlogCompressType(defaultValue); err != nil {
+			return merr.WrapErrParameterInvalidMsg("invalid collection property %s: %s", common.BinlogCompressionKey, err.Error())
+		}
+	}
+
+	for _, field := range schema.GetFields() {
+		value, ok := common.GetBinlogCompression(field.GetTypeParams()...)
+		if ok {
+			if _, err := storage.ParseBinlogCompressType(value); err != nil {
+				return merr.WrapErrParameterInvalidMsg("invalid %s of field %s: %s", common.BinlogCompressionKey, field.GetName(), err.Error())
+			}
+			continue
+		}
+		if hasDefault {
+			field.TypeParams = append(field.TypeParams, &commonpb.KeyValuePair{
+				Key:   common.BinlogCompressionKey,
+				Value: defaultValue,
+			})
+		}
+	}
+	return nil
+}
+
+// fillStorageProfile validates the bucket profile of the collection,
+// if it's not specified, the collection follows the bucket profile its database belongs to.
+func fillStorageProfile(dbName string, properties []*commonpb.KeyValuePair) ([]*commonpb.KeyValuePair, error) {
+	profiles := paramtable.Get().MinioCfg.GetBucketProfiles()
+	if profile, ok := common.GetStorageProfile(properties...); ok {
+		if _, ok := profiles[profile]; !ok {
+			return nil, merr.WrapErrParameterInvalidMsg("bucket profile %s not found", profile)
+		}
+		return properties, nil
+	}
+
+	if dbName == "" {
+		dbName = util.DefaultDBName
+	}
+	for name, profile := range profiles {
+		for _, db := range strings.Split(profile["databases"], ",") {
+			if strings.TrimSpace(db) == dbName {
+				return append(properties, &commonpb.KeyValuePair{
+					Key:   common.StorageProfileKey,
+					Value: name,
+				}), nil
+			}
+		}
+	}
+	return properties, nil
+}
+
+// validateVectorQuantization validates the quantization of the raw vectors loaded, only SQ8 of the float vectors is supported,
+// which is specified as the collection property or as the type param of the vector fields.
+func validateVectorQuantization(schema *schemapb.CollectionSchema, properties []*commonpb.KeyValuePair) error {
+	check := func(quantization string) error {
+		if !strings.EqualFold(quantization, common.VectorQuantizationSQ8) {
+			return merr.WrapErrParameterInvalidMsg("unsupported %s %s, only %s is supported", common.VectorQuantizationKey, quantization, common.VectorQuantizationSQ8)
+		}
+		return nil
+	}
+	if quantization, ok := common.GetVectorQuantization(properties...); ok {
+		if err := check(quantization); err != nil {
+			return err
+		}
+	}
+	for _, field := range schema.GetFields() {
+		quantization, ok := common.GetVectorQuantization(field.GetTypeParams()...)
+		if !ok {
+			continue
+		}
+		if field.GetDataType() != schemapb.DataType_FloatVector {
+			return merr.WrapErrParameterInvalidMsg("%s applies to the float vector fields only, but field %s is %s",
+				common.VectorQuantizationKey, field.GetName(), field.GetDataType().String())
+		}
+		if err := check(quantization); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkQuantizedIndexType checks the index of the quantized float vector field is FLAT,
+// since the quantized vectors replace the FLAT index only, the other indexes are searched as is.
+func checkQuantizedIndexType(field *schemapb.FieldSchema, properties []*commonpb.KeyValuePair, indexType string) error {
+	if field.GetDataType() != schemapb.DataType_FloatVector {
+		return nil
+	}
+	quantization, ok := common.GetVectorQuantization(field.GetTypeParams()...)
+	if !ok {
+		quantization, ok = common.GetVectorQuantization(properties...)
+	}
+	if ok && indexType != indexparamcheck.IndexFaissIDMap {
+		return merr.WrapErrParameterInvalidMsg("field %s quantized by %s supports only the %s index, but got %s",
+			field.GetName(), quantization, indexparamcheck.IndexFaissIDMap, indexType)
+	}
+	return nil
+}
+
 func validateVectorFieldMetricType(field *schemapb.FieldSchema) error {
 	if !isVectorType(field.DataType) {
 		return nil
@@ -506,7 +611,7 @@ func validateMetricType(dataType schemapb.DataType, metricTypeStrRaw string) err
 		if dataType == schemapb.DataType_FloatVector || dataType == schemapb.DataType_Float16Vector {
 			return nil
 		}
-	case metric.JACCARD, metric.HAMMING, metric.SUBSTRUCTURE, metric.SUPERSTRUCTURE:
+	case metric.JACCARD, metric.HAMMING, metric.SUBSTRUCTURE, metric.SUPERSTRUCTURE, metric.MHJACCARD:
 		if dataType == schemapb.DataType_BinaryVector {
 			return nil
 		}
@@ -1367,6 +1472,28 @@ func isPartitionKeyMode(ctx context.Context, dbName string, colName string) (boo
 	return false, nil
 }
 
+// getWritePartitionName returns the partition the writes without partition are routed to,
+// which is the partition of the current period for the time-partitioned collection, or the default partition.
+// It also returns whether the collection is time-partitioned.
+func getWritePartitionName(ctx context.Context, dbName string, collectionName string) (string, bool, error) {
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, dbName, collectionName)
+	if err != nil {
+		return "", false, err
+	}
+	info, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, collectionID)
+	if err != nil {
+		return "", false, err
+	}
+	policy, err := timepartition.Parse(info.properties)
+	if err != nil {
+		return "", false, err
+	}
+	if policy == nil {
+		return Params.CommonCfg.DefaultPartitionName.GetValue(), false, nil
+	}
+	return policy.PartitionName(time.Now()), true, nil
+}
+
 // getDefaultPartitionNames only used in partition key mode
 func getDefaultPartitionsInPartitionKeyMode(ctx context.Context, dbName string, collectionName string) ([]string, error) {
 	partitions, err := globalMetaCache.GetPartitions(ctx, dbName, collectionName)
@@ -706,6 +706,114 @@ func TestValidateMultipleVectorFields(t *testing.T) {
 	}
 }
 
+func TestFillBinlogCompression(t *testing.T) {
+	newSchema := func() *schemapb.CollectionSchema {
+		return &schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{
+				{
+					Name:     "pk",
+					DataType: schemapb.DataType_Int64,
+				},
+				{
+					Name:       "vec",
+					DataType:   schemapb.DataType_FloatVector,
+					TypeParams: []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "none"}},
+				},
+			},
+		}
+	}
+
+	// no default, fields keep their own settings
+	schema := newSchema()
+	assert.NoError(t, fillBinlogCompression(schema, nil))
+	_, ok := common.GetBinlogCompression(schema.Fields[0].GetTypeParams()...)
+	assert.False(t, ok)
+
+	// fields without codec inherit the collection default
+	schema = newSchema()
+	assert.NoError(t, fillBinlogCompression(schema, []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "zstd"}}))
+	value, ok := common.GetBinlogCompression(schema.Fields[0].GetTypeParams()...)
+	assert.True(t, ok)
+	assert.Equal(t, "zstd", value)
+	value, _ = common.GetBinlogCompression(schema.Fields[1].GetTypeParams()...)
+	assert.Equal(t, "none", value)
+
+	// invalid collection default
+	schema = newSchema()
+	assert.Error(t, fillBinlogCompression(schema, []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "snappy"}}))
+	schema = newSchema()
+	assert.Error(t, fillBinlogCompression(schema, []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "lz4"}}))
+
+	// invalid field codec
+	schema = newSchema()
+	schema.Fields[1].TypeParams[0].Value = "snappy"
+	assert.Error(t, fillBinlogCompression(schema, nil))
+}
+
+func TestFillStorageProfile(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.SaveGroup(map[string]string{
+		"minio.bucketProfiles.eu.bucketName": "milvus-eu",
+		"minio.bucketProfiles.eu.databases":  "db1, db2",
+	})
+	defer params.Reset("minio.bucketProfiles.eu.bucketName")
+	defer params.Reset("minio.bucketProfiles.eu.databases")
+
+	// specified by the collection
+	properties, err := fillStorageProfile("db3", []*commonpb.KeyValuePair{{Key: common.StorageProfileKey, Value: "eu"}})
+	assert.NoError(t, err)
+	assert.Len(t, properties, 1)
+
+	_, err = fillStorageProfile("db3", []*commonpb.KeyValuePair{{Key: common.StorageProfileKey, Value: "us"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// inherit from the database
+	properties, err = fillStorageProfile("db2", nil)
+	assert.NoError(t, err)
+	profile, ok := common.GetStorageProfile(properties...)
+	assert.True(t, ok)
+	assert.Equal(t, "eu", profile)
+
+	properties, err = fillStorageProfile("", nil)
+	assert.NoError(t, err)
+	_, ok = common.GetStorageProfile(properties...)
+	assert.False(t, ok)
+}
+
+func TestValidateVectorQuantization(t *testing.T) {
+	quantization := func(value string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: common.VectorQuantizationKey, Value: value}}
+	}
+	schema := func(dataType schemapb.DataType, typeParams []*commonpb.KeyValuePair) *schemapb.CollectionSchema {
+		return &schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{{FieldID: 101, Name: "vector", DataType: dataType, TypeParams: typeParams}},
+		}
+	}
+
+	assert.NoError(t, validateVectorQuantization(schema(schemapb.DataType_FloatVector, nil), nil))
+	assert.NoError(t, validateVectorQuantization(schema(schemapb.DataType_FloatVector, nil), quantization("sq8")))
+	assert.NoError(t, validateVectorQuantization(schema(schemapb.DataType_FloatVector, quantization("SQ8")), nil))
+	err := validateVectorQuantization(schema(schemapb.DataType_FloatVector, nil), quantization("PQ"))
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	err = validateVectorQuantization(schema(schemapb.DataType_FloatVector, quantization("SQ4")), nil)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	err = validateVectorQuantization(schema(schemapb.DataType_BinaryVector, quantization("SQ8")), nil)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// only the FLAT index of the quantized float vectors is allowed
+	field := &schemapb.FieldSchema{Name: "vector", DataType: schemapb.DataType_FloatVector}
+	assert.NoError(t, checkQuantizedIndexType(field, nil, "HNSW"))
+	assert.NoError(t, checkQuantizedIndexType(field, quantization("SQ8"), "FLAT"))
+	err = checkQuantizedIndexType(field, quantization("SQ8"), "HNSW")
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	field.TypeParams = quantization("SQ8")
+	err = checkQuantizedIndexType(field, nil, "IVF_FLAT")
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	binary := &schemapb.FieldSchema{Name: "binary", DataType: schemapb.DataType_BinaryVector}
+	assert.NoError(t, checkQuantizedIndexType(binary, quantization("SQ8"), "BIN_IVF_FLAT"))
+}
+
 func TestFillFieldIDBySchema(t *testing.T) {
 	schema := &schemapb.CollectionSchema{}
 	columns := []*schemapb.FieldData{
@@ -67,38 +67,8 @@ func (v *validateUtil) Validate(data []*schemapb.FieldData, schema *schemapb.Col
 		if err != nil {
 			return err
 		}
-
-		switch fieldSchema.GetDataType() {
-		case schemapb.DataType_FloatVector:
-			if err := v.checkFloatVectorFieldData(field, fieldSchema); err != nil {
-				return err
-			}
-		case schemapb.DataType_Float16Vector:
-			if err := v.checkFloat16VectorFieldData(field, fieldSchema); err != nil {
-				return err
-			}
-		case schemapb.DataType_BinaryVector:
-			if err := v.checkBinaryVectorFieldData(field, fieldSchema); err != nil {
-				return err
-			}
-		case schemapb.DataType_VarChar:
-			if err := v.checkVarCharFieldData(field, fieldSchema); err != nil {
-				return err
-			}
-		case schemapb.DataType_JSON:
-			if err := v.checkJSONFieldData(field, fieldSchema); err != nil {
-				return err
-			}
-		case schemapb.DataType_Int8, schemapb.DataType_Int16:
-			if err := v.checkIntegerFieldData(field, fieldSchema); err != nil {
-				return err
-			}
-		case schemapb.DataType_Array:
-			if err := v.checkArrayFieldData(field, fieldSchema); err != nil {
-				return err
-			}
-
-		default:
+		if err := v.checkFieldData(field, fieldSchema); err != nil {
+			return err
 		}
 	}
 
@@ -114,6 +84,28 @@ func (v *validateUtil) Validate(data []*schemapb.FieldData, schema *schemapb.Col
 	return nil
 }
 
+// checkFieldData validates the data of field by its data type.
+func (v *validateUtil) checkFieldData(field *schemapb.FieldData, fieldSchema *schemapb.FieldSchema) error {
+	switch fieldSchema.GetDataType() {
+	case schemapb.DataType_FloatVector:
+		return v.checkFloatVectorFieldData(field, fieldSchema)
+	case schemapb.DataType_Float16Vector:
+		return v.checkFloat16VectorFieldData(field, fieldSchema)
+	case schemapb.DataType_BinaryVector:
+		return v.checkBinaryVectorFieldData(field, fieldSchema)
+	case schemapb.DataType_VarChar:
+		return v.checkVarCharFieldData(field, fieldSchema)
+	case schemapb.DataType_JSON:
+		return v.checkJSONFieldData(field, fieldSchema)
+	case schemapb.DataType_Int8, schemapb.DataType_Int16:
+		return v.checkIntegerFieldData(field, fieldSchema)
+	case schemapb.DataType_Array:
+		return v.checkArrayFieldData(field, fieldSchema)
+	default:
+		return nil
+	}
+}
+
 func (v *validateUtil) checkAligned(data []*schemapb.FieldData, schema *typeutil.SchemaHelper, numRows uint64) error {
 	errNumRowsMismatch := func(fieldName string, fieldNumRows, passedNumRows uint64) error {
 		msg := fmt.Sprintf("the num_rows (%d) of field (%s) is not equal to passed num_rows (%d)", fieldNumRows, fieldName, passedNumRows)
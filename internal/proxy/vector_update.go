@@ -0,0 +1,265 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+)
+
+// vectorUpdateRequest is the request of UpdateVectors, which replaces the values of a vector field,
+// e.g. by the embeddings of a new model, e.g.
+// {"db_name": "default", "collection_name": "coll", "field_name": "vec", "path": "reembed/v2"}.
+// The job is run by datacoord, which rewrites the vector binlogs of the flushed segments and keeps the other fields,
+// see metricsinfo.SubmitVectorUpdateMetrics.
+type vectorUpdateRequest struct {
+	DbName         string   `json:"db_name"`
+	CollectionName string   `json:"collection_name"`
+	PartitionNames []string `json:"partition_names"`
+	FieldName      string   `json:"field_name"`
+	// Profile is the bucket profile to read from, see minio.bucketProfiles, empty means the default bucket
+	Profile string `json:"profile"`
+	// Path is the directory of the parquet files with the columns of the primary key and the new vectors,
+	// named after the fields, relative to the export prefix under the root path of bucket like the exported files
+	Path string `json:"path"`
+}
+
+// resolveVectorUpdateRequest resolves the names in the request into the IDs of datacoord.
+func resolveVectorUpdateRequest(ctx context.Context, req *vectorUpdateRequest) (*metricsinfo.VectorUpdateRequest, error) {
+	if err := validateExportPath(req.Path); err != nil {
+		return nil, err
+	}
+	collectionID, err := globalMetaCache.GetCollectionID(ctx, req.DbName, req.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, req.DbName, req.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := lo.Find(schema.GetFields(), func(field *schemapb.FieldSchema) bool {
+		return field.GetName() == req.FieldName
+	})
+	if !ok {
+		return nil, merr.WrapErrFieldNotFound(req.FieldName)
+	}
+
+	partitionIDs := make([]int64, 0, len(req.PartitionNames))
+	if len(req.PartitionNames) > 0 {
+		partitionKeyMode, err := isPartitionKeyMode(ctx, req.DbName, req.CollectionName)
+		if err != nil {
+			return nil, err
+		}
+		if partitionKeyMode {
+			return nil, merr.WrapErrParameterInvalidMsg("not support manually specifying the partition names if partition key mode is used")
+		}
+		for _, name := range req.PartitionNames {
+			partitionID, err := globalMetaCache.GetPartitionID(ctx, req.DbName, req.CollectionName, name)
+			if err != nil {
+				return nil, err
+			}
+			partitionIDs = append(partitionIDs, partitionID)
+		}
+	}
+
+	return &metricsinfo.VectorUpdateRequest{
+		CollectionID: collectionID,
+		PartitionIDs: partitionIDs,
+		FieldID:      field.GetFieldID(),
+		Profile:      req.Profile,
+		Path:         req.Path,
+	}, nil
+}
+
+// callVectorUpdate relays the vector update request to datacoord.
+func (node *Proxy) callVectorUpdate(ctx context.Context, metricType string, params map[string]any) ([]*metricsinfo.VectorUpdateJob, error) {
+	request, err := metricsinfo.ConstructRequestWithParams(metricType, params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := node.dataCoord.GetMetrics(ctx, request)
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return nil, err
+	}
+	infos := &metricsinfo.VectorUpdateJobInfos{}
+	if err := metricsinfo.UnmarshalComponentInfos(resp.GetResponse(), infos); err != nil {
+		return nil, err
+	}
+	return infos.Jobs, nil
+}
+
+// UpdateVectors submits a job to replace the values of a vector field for the primary keys listed in parquet files.
+func (node *Proxy) UpdateVectors(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return
+	}
+	if err := merr.CheckHealthy(node.GetStateCode()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to update vectors, %s"}`, err.Error())))
+		return
+	}
+	updateReq := &vectorUpdateRequest{}
+	if err := json.NewDecoder(req.Body).Decode(updateReq); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to update vectors, %s"}`, err.Error())))
+		return
+	}
+	ctx, err := mgrAuthenticate(req, updateReq.DbName)
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	// the rows are read and rewritten, so it requires the privileges of both query and upsert
+	for _, privilegeReq := range []any{
+		&milvuspb.QueryRequest{DbName: updateReq.DbName, CollectionName: updateReq.CollectionName},
+		&milvuspb.UpsertRequest{DbName: updateReq.DbName, CollectionName: updateReq.CollectionName},
+	} {
+		if _, err := PrivilegeInterceptor(ctx, privilegeReq); err != nil {
+			mgrWriteAuthError(w, err)
+			return
+		}
+	}
+	request, err := resolveVectorUpdateRequest(ctx, updateReq)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to update vectors, %s"}`, err.Error())))
+		return
+	}
+	request.Owner = mgrCurUser(ctx)
+	jobs, err := node.callVectorUpdate(ctx, metricsinfo.SubmitVectorUpdateMetrics, map[string]any{
+		"collection_id": request.CollectionID,
+		"partition_ids": request.PartitionIDs,
+		"field_id":      request.FieldID,
+		"profile":       request.Profile,
+		"path":          request.Path,
+		"owner":         request.Owner,
+	})
+	if err == nil && len(jobs) == 0 {
+		err = merr.WrapErrServiceInternal("no job submitted")
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to update vectors, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(fmt.Sprintf(`{"msg": "OK", "job_id": %d}`, jobs[0].JobID)))
+}
+
+// getVisibleVectorUpdateJob returns the job of job_id in the request if it's visible to the user,
+// the response is written if it's not found.
+func (node *Proxy) getVisibleVectorUpdateJob(w http.ResponseWriter, req *http.Request) (*metricsinfo.VectorUpdateJob, bool) {
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return nil, false
+	}
+	jobID, err := strconv.ParseInt(req.URL.Query().Get(metricsinfo.MetricJobIDKey), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "invalid job_id, %s"}`, err.Error())))
+		return nil, false
+	}
+	jobs, err := node.callVectorUpdate(ctx, metricsinfo.VectorUpdateMetrics, map[string]any{metricsinfo.MetricJobIDKey: jobID})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to get vector update job, %s"}`, err.Error())))
+		return nil, false
+	}
+	ok := len(jobs) > 0
+	if ok {
+		isAdmin, err := mgrIsAdmin(ctx)
+		if err != nil {
+			mgrWriteAuthError(w, err)
+			return nil, false
+		}
+		// don't leak the existence of the jobs of others
+		ok = isAdmin || jobs[0].Owner == mgrCurUser(ctx)
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "vector update job %d not found"}`, jobID)))
+		return nil, false
+	}
+	return jobs[0], true
+}
+
+// GetVectorUpdateState returns the progress of the vector update.
+func (node *Proxy) GetVectorUpdateState(w http.ResponseWriter, req *http.Request) {
+	job, ok := node.getVisibleVectorUpdateJob(w, req)
+	if !ok {
+		return
+	}
+	mgrWriteJSON(w, job)
+}
+
+// ListVectorUpdateJobs lists the vector updates, which are visible to the user.
+func (node *Proxy) ListVectorUpdateJobs(w http.ResponseWriter, req *http.Request) {
+	ctx, err := mgrAuthenticate(req, "")
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	isAdmin, err := mgrIsAdmin(ctx)
+	if err != nil {
+		mgrWriteAuthError(w, err)
+		return
+	}
+	jobs, err := node.callVectorUpdate(ctx, metricsinfo.VectorUpdateMetrics, nil)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to list vector update jobs, %s"}`, err.Error())))
+		return
+	}
+	jobs = lo.Filter(jobs, func(job *metricsinfo.VectorUpdateJob, _ int) bool {
+		return isAdmin || job.Owner == mgrCurUser(ctx)
+	})
+	mgrWriteJSON(w, jobs)
+}
+
+// CancelVectorUpdate cancels the pending or running vector update, the segments rewritten already stay rewritten.
+func (node *Proxy) CancelVectorUpdate(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"msg": "only POST is allowed"}`))
+		return
+	}
+	job, ok := node.getVisibleVectorUpdateJob(w, req)
+	if !ok {
+		return
+	}
+	if _, err := node.callVectorUpdate(req.Context(), metricsinfo.CancelVectorUpdateMetrics,
+		map[string]any{metricsinfo.MetricJobIDKey: job.JobID}); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to cancel vector update job, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
@@ -0,0 +1,114 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestProxy_VectorUpdate(t *testing.T) {
+	paramtable.Init()
+	datacoord := mocks.NewMockDataCoordClient(t)
+	node := &Proxy{dataCoord: datacoord}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+
+	expectMetrics := func(metricType string, jobID float64, response string) {
+		datacoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
+				params := make(map[string]any)
+				assert.NoError(t, json.Unmarshal([]byte(req.GetRequest()), &params))
+				assert.Equal(t, metricType, params[metricsinfo.MetricTypeKey])
+				if jobID != 0 {
+					assert.Equal(t, jobID, params[metricsinfo.MetricJobIDKey])
+				}
+				return &milvuspb.GetMetricsResponse{Status: merr.Success(), Response: response}, nil
+			}).Once()
+	}
+
+	t.Run("invalid path", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := `{"collection_name": "coll", "field_name": "vec", "path": "../reembed"}`
+		node.UpdateVectors(w, httptest.NewRequest(http.MethodPost, mgrRouteVectorUpdate, strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = httptest.NewRecorder()
+		node.UpdateVectors(w, httptest.NewRequest(http.MethodGet, mgrRouteVectorUpdate, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("state", func(t *testing.T) {
+		expectMetrics(metricsinfo.VectorUpdateMetrics, 1, `{"jobs":[{"job_id":1,"state":"Running","segments":{"10":11}}]}`)
+		w := httptest.NewRecorder()
+		node.GetVectorUpdateState(w, httptest.NewRequest(http.MethodGet, mgrRouteVectorUpdateState+"?job_id=1", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		job := &metricsinfo.VectorUpdateJob{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), job))
+		assert.Equal(t, metricsinfo.VectorUpdateJobRunning, job.State)
+		assert.Equal(t, map[int64]int64{10: 11}, job.Segments)
+
+		expectMetrics(metricsinfo.VectorUpdateMetrics, 2, `{"jobs":[]}`)
+		w = httptest.NewRecorder()
+		node.GetVectorUpdateState(w, httptest.NewRequest(http.MethodGet, mgrRouteVectorUpdateState+"?job_id=2", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = httptest.NewRecorder()
+		node.GetVectorUpdateState(w, httptest.NewRequest(http.MethodGet, mgrRouteVectorUpdateState+"?job_id=abc", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		expectMetrics(metricsinfo.VectorUpdateMetrics, 0, `{"jobs":[{"job_id":1,"state":"Completed"},{"job_id":2,"state":"Failed"}]}`)
+		w := httptest.NewRecorder()
+		node.ListVectorUpdateJobs(w, httptest.NewRequest(http.MethodGet, mgrRouteVectorUpdateList, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		jobs := make([]*metricsinfo.VectorUpdateJob, 0)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &jobs))
+		assert.Len(t, jobs, 2)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		expectMetrics(metricsinfo.VectorUpdateMetrics, 1, `{"jobs":[{"job_id":1,"state":"Running"}]}`)
+		expectMetrics(metricsinfo.CancelVectorUpdateMetrics, 1, `{"jobs":[{"job_id":1,"state":"Running"}]}`)
+		w := httptest.NewRecorder()
+		node.CancelVectorUpdate(w, httptest.NewRequest(http.MethodPost, mgrRouteVectorUpdateCancel+"?job_id=1", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		expectMetrics(metricsinfo.VectorUpdateMetrics, 1, `{"jobs":[{"job_id":1,"state":"Completed"}]}`)
+		datacoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).Return(&milvuspb.GetMetricsResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalidMsg("vector update job 1 is Completed already")),
+		}, nil).Once()
+		w = httptest.NewRecorder()
+		node.CancelVectorUpdate(w, httptest.NewRequest(http.MethodPost, mgrRouteVectorUpdateCancel+"?job_id=1", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
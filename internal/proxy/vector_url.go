@@ -0,0 +1,252 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const (
+	// VectorOutputModeKey is the query param to choose how the vector output fields are returned
+	VectorOutputModeKey = "vector_output_mode"
+	// VectorOutputModeURL returns the large vector output fields as pre-signed urls
+	VectorOutputModeURL = "url"
+
+	// VectorURLFieldSuffix is appended to the name of the vector field to name its url output field,
+	// `$` is not allowed in field names, so the url field never conflicts with the fields of schema.
+	VectorURLFieldSuffix = "$url"
+
+	vectorURLPrefix = "retrieval"
+	// vectorURLBucketLayout is the layout of the hourly directories under the vectorURLPrefix
+	vectorURLBucketLayout = "2006010215"
+)
+
+// vectorURLFieldName returns the name of the url output field of the vector field.
+func vectorURLFieldName(fieldName string) string {
+	return fieldName + VectorURLFieldSuffix
+}
+
+// vectorURLWriter writes the large vector output fields to object storage, and replaces them with pre-signed urls.
+//
+// The vector field is removed from the output fields, and a VarChar field named "<field>$url" without field id is
+// returned instead, so the clients never decode the urls as vectors. Each row is the pre-signed url of the object
+// holding the vectors of the result, followed by the byte range of the row, e.g. "https://...#bytes=0-511".
+// Float vectors are encoded as little endian float32, binary and float16 vectors are written as is.
+//
+// The objects are written under <rootPath>/retrieval/<utc hour>/, the expired hourly directories are removed as a
+// whole without listing the objects. The removal is idempotent, so it's fine to run on every proxy,
+// or it can be disabled by proxy.vectorURL.removeExpired if a lifecycle rule of the bucket expires the prefix.
+type vectorURLWriter struct {
+	getCM func(ctx context.Context) (storage.ChunkManager, error)
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newVectorURLWriter(getCM func(ctx context.Context) (storage.ChunkManager, error)) *vectorURLWriter {
+	return &vectorURLWriter{
+		getCM:   getCM,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// start starts the loop removing the expired objects.
+func (w *vectorURLWriter) start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.closeCh:
+				return
+			case <-ticker.C:
+				w.removeExpired(context.Background())
+			}
+		}
+	}()
+}
+
+func (w *vectorURLWriter) close() {
+	w.closeOnce.Do(func() {
+		close(w.closeCh)
+		w.wg.Wait()
+	})
+}
+
+// removeExpired removes the hourly directories whose objects are all expired.
+func (w *vectorURLWriter) removeExpired(ctx context.Context) {
+	if !paramtable.Get().ProxyCfg.VectorURLRemoveExpired.GetAsBool() {
+		return
+	}
+	cm, err := w.getCM(ctx)
+	if err != nil {
+		log.Warn("failed to get chunk manager to remove expired vector objects", zap.Error(err))
+		return
+	}
+	expiry := paramtable.Get().ProxyCfg.VectorURLExpiry.GetAsDuration(time.Second)
+	dirs, _, err := cm.ListWithPrefix(ctx, path.Join(cm.RootPath(), vectorURLPrefix)+"/", false)
+	if err != nil {
+		log.Warn("failed to list vector object directories", zap.Error(err))
+		return
+	}
+	for _, dir := range dirs {
+		dir = strings.TrimSuffix(dir, "/")
+		bucket, err := time.ParseInLocation(vectorURLBucketLayout, path.Base(dir), time.UTC)
+		if err != nil {
+			continue
+		}
+		// the last object of the bucket is written before the end of the hour
+		if time.Since(bucket.Add(time.Hour)) <= expiry {
+			continue
+		}
+		if err := cm.RemoveWithPrefix(ctx, dir+"/"); err != nil {
+			log.Warn("failed to remove expired vector objects", zap.String("dir", dir), zap.Error(err))
+			continue
+		}
+		log.Info("removed expired vector objects", zap.String("dir", dir))
+	}
+}
+
+// Replace replaces the vector fields larger than threshold with their url fields,
+// returns the replaced fields data and the names of the vector fields replaced.
+func (w *vectorURLWriter) Replace(ctx context.Context, collectionID, reqID int64, fieldsData []*schemapb.FieldData) ([]*schemapb.FieldData, []string, error) {
+	threshold := paramtable.Get().ProxyCfg.VectorURLThreshold.GetAsInt()
+	expiry := paramtable.Get().ProxyCfg.VectorURLExpiry.GetAsDuration(time.Second)
+
+	var (
+		cm        storage.ChunkManager
+		presigner storage.PresignedURLProvider
+		replaced  = make([]string, 0)
+		result    = make([]*schemapb.FieldData, 0, len(fieldsData))
+	)
+	for _, fieldData := range fieldsData {
+		data, rowSize, err := encodeVectorFieldData(fieldData)
+		if err != nil {
+			return nil, nil, err
+		}
+		if data == nil || rowSize == 0 || len(data) < threshold {
+			result = append(result, fieldData)
+			continue
+		}
+
+		if cm == nil {
+			cm, err = w.getCM(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			var ok bool
+			presigner, ok = cm.(storage.PresignedURLProvider)
+			if !ok {
+				log.Ctx(ctx).Warn("pre-signed url is not supported by the object storage, return vectors inline")
+				return fieldsData, nil, nil
+			}
+		}
+
+		filePath := path.Join(cm.RootPath(), vectorURLPrefix, time.Now().UTC().Format(vectorURLBucketLayout),
+			strconv.FormatInt(collectionID, 10), strconv.FormatInt(reqID, 10), strconv.FormatInt(fieldData.GetFieldId(), 10))
+		if err := cm.Write(ctx, filePath, data); err != nil {
+			return nil, nil, err
+		}
+		url, err := presigner.PresignedURL(ctx, filePath, expiry)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows := len(data) / rowSize
+		urls := make([]string, 0, rows)
+		for row := 0; row < rows; row++ {
+			urls = append(urls, fmt.Sprintf("%s#bytes=%d-%d", url, row*rowSize, (row+1)*rowSize-1))
+		}
+		replaced = append(replaced, fieldData.GetFieldName())
+		result = append(result, &schemapb.FieldData{
+			Type:      schemapb.DataType_VarChar,
+			FieldName: vectorURLFieldName(fieldData.GetFieldName()),
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: urls}},
+			}},
+		})
+	}
+	return result, replaced, nil
+}
+
+// replaceVectorURLOutputFields renames the output fields replaced by url fields.
+func replaceVectorURLOutputFields(outputFields []string, replaced []string) []string {
+	if len(replaced) == 0 {
+		return outputFields
+	}
+	result := make([]string, 0, len(outputFields))
+	for _, name := range outputFields {
+		if funcutil.SliceContain(replaced, name) {
+			name = vectorURLFieldName(name)
+		}
+		result = append(result, name)
+	}
+	return result
+}
+
+// encodeVectorFieldData returns the raw bytes and the size of each row of the vector field, nil for other fields.
+func encodeVectorFieldData(fieldData *schemapb.FieldData) ([]byte, int, error) {
+	dim := int(fieldData.GetVectors().GetDim())
+	switch fieldData.GetType() {
+	case schemapb.DataType_FloatVector:
+		buf := new(bytes.Buffer)
+		if err := binary.Write(buf, common.Endian, fieldData.GetVectors().GetFloatVector().GetData()); err != nil {
+			return nil, 0, err
+		}
+		return buf.Bytes(), dim * 4, nil
+	case schemapb.DataType_BinaryVector:
+		return fieldData.GetVectors().GetBinaryVector(), dim / 8, nil
+	case schemapb.DataType_Float16Vector:
+		return fieldData.GetVectors().GetFloat16Vector(), dim * 2, nil
+	default:
+		return nil, 0, nil
+	}
+}
+
+// parseVectorOutputMode returns whether the vector output fields should be returned as urls.
+func parseVectorOutputMode(params []*commonpb.KeyValuePair) (bool, error) {
+	mode, err := funcutil.GetAttrByKeyFromRepeatedKV(VectorOutputModeKey, params)
+	if err != nil || mode == "" {
+		return false, nil
+	}
+	if mode != VectorOutputModeURL {
+		return false, merr.WrapErrParameterInvalid(VectorOutputModeURL, mode, "invalid vector output mode")
+	}
+	return true, nil
+}
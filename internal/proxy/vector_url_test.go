@@ -0,0 +1,146 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type presignedLocalChunkManager struct {
+	*storage.LocalChunkManager
+}
+
+func (cm *presignedLocalChunkManager) PresignedURL(ctx context.Context, filePath string, expiry time.Duration) (string, error) {
+	return "file://" + filePath, nil
+}
+
+func TestParseVectorOutputMode(t *testing.T) {
+	useURL, err := parseVectorOutputMode(nil)
+	assert.NoError(t, err)
+	assert.False(t, useURL)
+
+	useURL, err = parseVectorOutputMode([]*commonpb.KeyValuePair{{Key: VectorOutputModeKey, Value: VectorOutputModeURL}})
+	assert.NoError(t, err)
+	assert.True(t, useURL)
+
+	_, err = parseVectorOutputMode([]*commonpb.KeyValuePair{{Key: VectorOutputModeKey, Value: "ticket"}})
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+}
+
+func TestVectorURLWriter(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.VectorURLThreshold.Key, "16")
+	defer params.Reset(params.ProxyCfg.VectorURLThreshold.Key)
+
+	ctx := context.Background()
+	rootPath := t.TempDir()
+	cm := &presignedLocalChunkManager{storage.NewLocalChunkManager(storage.RootPath(rootPath))}
+	writer := newVectorURLWriter(func(ctx context.Context) (storage.ChunkManager, error) { return cm, nil })
+
+	fieldsData := []*schemapb.FieldData{
+		{
+			FieldName: "pk",
+			FieldId:   100,
+			Type:      schemapb.DataType_Int64,
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2}}},
+			}},
+		},
+		{
+			FieldName: "vec",
+			FieldId:   101,
+			Type:      schemapb.DataType_FloatVector,
+			Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+				Dim:  2,
+				Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: []float32{1, 2, 3, 4}}},
+			}},
+		},
+		{
+			FieldName: "small",
+			FieldId:   102,
+			Type:      schemapb.DataType_BinaryVector,
+			Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+				Dim:  8,
+				Data: &schemapb.VectorField_BinaryVector{BinaryVector: []byte{1, 2}},
+			}},
+		},
+	}
+	fieldsData, replaced, err := writer.Replace(ctx, 1, 2, fieldsData)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vec"}, replaced)
+	assert.Equal(t, []string{"pk", "vec$url", "small"}, replaceVectorURLOutputFields([]string{"pk", "vec", "small"}, replaced))
+
+	require.Len(t, fieldsData, 3)
+	assert.Equal(t, schemapb.DataType_Int64, fieldsData[0].GetType())
+	assert.Equal(t, schemapb.DataType_BinaryVector, fieldsData[2].GetType())
+
+	vec := fieldsData[1]
+	assert.Equal(t, schemapb.DataType_VarChar, vec.GetType())
+	assert.Equal(t, "vec$url", vec.GetFieldName())
+	assert.EqualValues(t, 0, vec.GetFieldId())
+	urls := vec.GetScalars().GetStringData().GetData()
+	require.Len(t, urls, 2)
+	assert.True(t, strings.HasSuffix(urls[0], "#bytes=0-7"))
+	assert.True(t, strings.HasSuffix(urls[1], "#bytes=8-15"))
+
+	filePath := strings.TrimPrefix(strings.Split(urls[1], "#")[0], "file://")
+	data, err := cm.ReadAt(ctx, filePath, 8, 8)
+	assert.NoError(t, err)
+	assert.Len(t, data, 8)
+
+	// the objects are written to the hourly directory
+	bucket := time.Now().UTC().Format(vectorURLBucketLayout)
+	assert.True(t, strings.HasPrefix(filePath, path.Join(rootPath, vectorURLPrefix, bucket)+"/"))
+
+	// the directory is kept until all its objects expire
+	params.Save(params.ProxyCfg.VectorURLExpiry.Key, "0")
+	defer params.Reset(params.ProxyCfg.VectorURLExpiry.Key)
+	writer.removeExpired(ctx)
+	exist, err := cm.Exist(ctx, filePath)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	expiredPath := path.Join(rootPath, vectorURLPrefix, time.Now().Add(-2*time.Hour).UTC().Format(vectorURLBucketLayout), "1", "3", "101")
+	require.NoError(t, cm.Write(ctx, expiredPath, []byte{1}))
+	writer.removeExpired(ctx)
+	exist, err = cm.Exist(ctx, expiredPath)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	// removal is disabled if the bucket lifecycle rule expires the objects
+	params.Save(params.ProxyCfg.VectorURLRemoveExpired.Key, "false")
+	defer params.Reset(params.ProxyCfg.VectorURLRemoveExpired.Key)
+	require.NoError(t, cm.Write(ctx, expiredPath, []byte{1}))
+	writer.removeExpired(ctx)
+	exist, err = cm.Exist(ctx, expiredPath)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+}
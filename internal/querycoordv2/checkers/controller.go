@@ -102,6 +102,7 @@ func NewCheckerController(
 		channelChecker: make(chan struct{}, 1),
 		segmentChecker: make(chan struct{}, 1),
 		balanceChecker: make(chan struct{}, 1),
+		indexChecker:   make(chan struct{}, 1),
 	}
 
 	return &CheckerController{
@@ -179,6 +180,15 @@ func (controller *CheckerController) Check() {
 	}
 }
 
+// NotifyIndexReady triggers the index checker to load the indexes of the segments, which are built.
+func (controller *CheckerController) NotifyIndexReady(segmentIDs ...int64) {
+	controller.checkers[indexChecker].(*IndexChecker).NotifyIndexReady(segmentIDs...)
+	select {
+	case controller.manualCheckChs[indexChecker] <- struct{}{}:
+	default:
+	}
+}
+
 // check is the real implementation of Check
 func (controller *CheckerController) check(ctx context.Context, checkType CheckerType) {
 	checker := controller.checkers[checkType]
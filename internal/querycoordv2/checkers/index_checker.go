@@ -18,6 +18,7 @@ package checkers
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/samber/lo"
@@ -41,8 +42,19 @@ type IndexChecker struct {
 	dist    *meta.DistributionManager
 	broker  meta.Broker
 	nodeMgr *session.NodeManager
+
+	mu sync.Mutex
+	// the segments whose indexes are notified ready by datacoord and the time notified,
+	// only these segments are checked between the full checks.
+	// The notifications are retained until the segments appear in the distribution.
+	readySegments map[int64]time.Time
+	lastFullCheck time.Time
 }
 
+// indexReadyNotificationTTL is how long the notification of a segment is retained at most,
+// in case the segment never appears in the distribution, e.g. it's compacted or released.
+const indexReadyNotificationTTL = 30 * time.Minute
+
 func NewIndexChecker(
 	meta *meta.Meta,
 	dist *meta.DistributionManager,
@@ -55,6 +67,7 @@ func NewIndexChecker(
 		dist:              dist,
 		broker:            broker,
 		nodeMgr:           nodeMgr,
+		readySegments:     make(map[int64]time.Time),
 	}
 }
 
@@ -66,12 +79,53 @@ func (c *IndexChecker) Description() string {
 	return "SegmentChecker checks index state change of segments and generates load index task"
 }
 
+// NotifyIndexReady marks the segments to check, whose indexes are built.
+func (c *IndexChecker) NotifyIndexReady(segmentIDs ...int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for _, segmentID := range segmentIDs {
+		if _, ok := c.readySegments[segmentID]; !ok {
+			c.readySegments[segmentID] = now
+		}
+	}
+}
+
+// takeReadySegments returns the notified segments to check,
+// or all is true if it's time to check the index states of all segments.
+func (c *IndexChecker) takeReadySegments() (segments typeutil.UniqueSet, all bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	segments = typeutil.NewUniqueSet(lo.Keys(c.readySegments)...)
+	if !params.Params.CommonCfg.IndexReadyNotifyEnable.GetAsBool() ||
+		time.Since(c.lastFullCheck) >= params.Params.QueryCoordCfg.IndexCheckFallbackInterval.GetAsDuration(time.Millisecond) {
+		c.lastFullCheck = time.Now()
+		all = true
+	}
+	return segments, all
+}
+
+// ackReadySegments removes the notifications of the checked segments and the expired ones,
+// the others are retained until their segments appear in the distribution.
+func (c *IndexChecker) ackReadySegments(checked typeutil.UniqueSet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for segmentID, notifiedAt := range c.readySegments {
+		if checked.Contain(segmentID) || time.Since(notifiedAt) > indexReadyNotificationTTL {
+			delete(c.readySegments, segmentID)
+		}
+	}
+}
+
 func (c *IndexChecker) Check(ctx context.Context) []task.Task {
 	if !c.IsActive() {
 		return nil
 	}
 	collectionIDs := c.meta.CollectionManager.GetAll()
 	var tasks []task.Task
+	readySegments, all := c.takeReadySegments()
+	checked := typeutil.NewUniqueSet()
+	defer c.ackReadySegments(checked)
 
 	for _, collectionID := range collectionIDs {
 		collection := c.meta.CollectionManager.GetCollection(collectionID)
@@ -81,14 +135,18 @@ func (c *IndexChecker) Check(ctx context.Context) []task.Task {
 		}
 		replicas := c.meta.ReplicaManager.GetByCollection(collectionID)
 		for _, replica := range replicas {
-			tasks = append(tasks, c.checkReplica(ctx, collection, replica)...)
+			tasks = append(tasks, c.checkReplica(ctx, collection, replica, readySegments, all, checked)...)
 		}
 	}
 
 	return tasks
 }
 
-func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collection, replica *meta.Replica) []task.Task {
+// checkReplica checks the notified segments of the replica, or all of them if all is true,
+// the notified segments found in the distribution are added into checked.
+func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collection, replica *meta.Replica,
+	readySegments typeutil.UniqueSet, all bool, checked typeutil.UniqueSet,
+) []task.Task {
 	log := log.Ctx(ctx).With(
 		zap.Int64("collectionID", collection.GetCollectionID()),
 	)
@@ -103,6 +161,11 @@ func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collec
 		if ok, _ := c.nodeMgr.IsStoppingNode(segment.Node); ok {
 			continue
 		}
+		// the index state of segment is unchanged unless datacoord notifies
+		if !all && !readySegments.Contain(segment.GetID()) {
+			continue
+		}
+		checked.Insert(segment.GetID())
 		missing := c.checkSegment(ctx, segment, collection)
 		if len(missing) > 0 {
 			targets[segment.GetID()] = missing
@@ -116,6 +179,8 @@ func (c *IndexChecker) checkReplica(ctx context.Context, collection *meta.Collec
 		infos, err := c.broker.GetIndexInfo(ctx, collection.GetCollectionID(), segment)
 		if err != nil {
 			log.Warn("failed to get indexInfo for segment", zap.Int64("segmentID", segment), zap.Error(err))
+			// check it again in the next round
+			checked.Remove(segment)
 			continue
 		}
 		for _, info := range infos {
@@ -195,6 +195,75 @@ func (suite *IndexCheckerSuite) TestGetIndexInfoFailed() {
 	suite.Require().Len(tasks, 0)
 }
 
+func (suite *IndexCheckerSuite) TestNotifyIndexReady() {
+	checker := suite.checker
+
+	// meta
+	coll := utils.CreateTestCollection(1, 1)
+	coll.FieldIndexID = map[int64]int64{101: 1000}
+	checker.meta.CollectionManager.PutCollection(coll)
+	checker.meta.ReplicaManager.Put(utils.CreateTestReplica(200, 1, []int64{1, 2}))
+	suite.nodeMgr.Add(session.NewNodeInfo(1, "localhost"))
+	suite.nodeMgr.Add(session.NewNodeInfo(2, "localhost"))
+	checker.meta.ResourceManager.AssignNode(meta.DefaultResourceGroupName, 1)
+	checker.meta.ResourceManager.AssignNode(meta.DefaultResourceGroupName, 2)
+
+	// dist
+	checker.dist.SegmentDistManager.Update(1, utils.CreateTestSegment(1, 1, 2, 1, 1, "test-insert-channel"))
+
+	// the first round checks all segments
+	suite.broker.EXPECT().GetIndexInfo(mock.Anything, int64(1), int64(2)).
+		Return(nil, nil).Once()
+	tasks := checker.Check(context.Background())
+	suite.Len(tasks, 0)
+
+	// the segment is not checked again until notified
+	tasks = checker.Check(context.Background())
+	suite.Len(tasks, 0)
+
+	suite.broker.EXPECT().GetIndexInfo(mock.Anything, int64(1), int64(2)).
+		Return([]*querypb.FieldIndexInfo{
+			{
+				FieldID:        101,
+				IndexID:        1000,
+				EnableIndex:    true,
+				IndexFilePaths: []string{"index"},
+			},
+		}, nil).Once()
+	checker.NotifyIndexReady(2)
+	tasks = checker.Check(context.Background())
+	suite.Require().Len(tasks, 1)
+	suite.EqualValues(2, tasks[0].Actions()[0].(*task.SegmentAction).SegmentID())
+
+	// the notification is retained until the segment appears in the distribution
+	checker.NotifyIndexReady(3)
+	tasks = checker.Check(context.Background())
+	suite.Len(tasks, 0)
+	checker.dist.SegmentDistManager.Update(2, utils.CreateTestSegment(1, 1, 3, 2, 1, "test-insert-channel"))
+	suite.broker.EXPECT().GetIndexInfo(mock.Anything, int64(1), int64(3)).
+		Return([]*querypb.FieldIndexInfo{
+			{
+				FieldID:        101,
+				IndexID:        1000,
+				EnableIndex:    true,
+				IndexFilePaths: []string{"index"},
+			},
+		}, nil).Once()
+	tasks = checker.Check(context.Background())
+	suite.Require().Len(tasks, 1)
+	suite.EqualValues(3, tasks[0].Actions()[0].(*task.SegmentAction).SegmentID())
+	tasks = checker.Check(context.Background())
+	suite.Len(tasks, 0)
+
+	// check all segments if the notification is disabled
+	paramtable.Get().Save(params.Params.CommonCfg.IndexReadyNotifyEnable.Key, "false")
+	defer paramtable.Get().Reset(params.Params.CommonCfg.IndexReadyNotifyEnable.Key)
+	suite.broker.EXPECT().GetIndexInfo(mock.Anything, int64(1), int64(2)).
+		Return(nil, nil).Once()
+	tasks = checker.Check(context.Background())
+	suite.Len(tasks, 0)
+}
+
 func TestIndexChecker(t *testing.T) {
 	suite.Run(t, new(IndexCheckerSuite))
 }
@@ -223,8 +223,12 @@ func (c *SegmentChecker) getSealedSegmentDiff(
 		distMap[s.GetID()] = s.Node
 	}
 
-	nextTargetMap := c.targetMgr.GetSealedSegmentsByCollection(collectionID, meta.NextTarget)
-	currentTargetMap := c.targetMgr.GetSealedSegmentsByCollection(collectionID, meta.CurrentTarget)
+	// the partitions loaded with fewer replicas are not served by all replicas
+	isServed := func(_ int64, segment *datapb.SegmentInfo) bool {
+		return c.meta.ReplicaServePartition(replica, segment.GetPartitionID())
+	}
+	nextTargetMap := lo.PickBy(c.targetMgr.GetSealedSegmentsByCollection(collectionID, meta.NextTarget), isServed)
+	currentTargetMap := lo.PickBy(c.targetMgr.GetSealedSegmentsByCollection(collectionID, meta.CurrentTarget), isServed)
 
 	// Segment which exist on next target, but not on dist
 	for segmentID, segment := range nextTargetMap {
@@ -380,10 +384,25 @@ func (c *SegmentChecker) createSegmentLoadTasks(ctx context.Context, segments []
 		}
 		plans = append(plans, shardPlans...)
 	}
+	if Params.QueryCoordCfg.EnableWarmupHints.GetAsBool() {
+		c.sortPlansByWarmupHints(plans)
+	}
 
 	return balance.CreateSegmentTasksFromPlans(ctx, c.ID(), Params.QueryCoordCfg.SegmentTaskTimeout.GetAsDuration(time.Millisecond), plans)
 }
 
+// sortPlansByWarmupHints puts the segments accessed most on the serving replicas first,
+// the tasks are scheduled in order, so that the hot segments are loaded first.
+func (c *SegmentChecker) sortPlansByWarmupHints(plans []balance.SegmentAssignPlan) {
+	accessCounts := make(map[int64]int64, len(plans))
+	for _, plan := range plans {
+		accessCounts[plan.Segment.GetID()] = c.dist.SegmentDistManager.GetWarmupHint(plan.Segment.GetID(), plan.To).GetAccessCount()
+	}
+	sort.SliceStable(plans, func(i, j int) bool {
+		return accessCounts[plans[i].Segment.GetID()] > accessCounts[plans[j].Segment.GetID()]
+	})
+}
+
 func (c *SegmentChecker) createSegmentReduceTasks(ctx context.Context, segments []*meta.Segment, replicaID int64, scope querypb.DataScope) []task.Task {
 	ret := make([]task.Task, 0, len(segments))
 	for _, s := range segments {
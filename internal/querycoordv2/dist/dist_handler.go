@@ -132,6 +132,8 @@ func (dh *distHandler) updateSegmentsDistribution(resp *querypb.GetDataDistribut
 				Version:            s.GetVersion(),
 				LastDeltaTimestamp: s.GetLastDeltaTimestamp(),
 				IndexInfo:          s.GetIndexInfo(),
+				AccessCount:        s.GetAccessCount(),
+				FieldAccess:        s.GetFieldAccess(),
 			}
 		} else {
 			segment = &meta.Segment{
@@ -140,6 +142,8 @@ func (dh *distHandler) updateSegmentsDistribution(resp *querypb.GetDataDistribut
 				Version:            s.GetVersion(),
 				LastDeltaTimestamp: s.GetLastDeltaTimestamp(),
 				IndexInfo:          s.GetIndexInfo(),
+				AccessCount:        s.GetAccessCount(),
+				FieldAccess:        s.GetFieldAccess(),
 			}
 		}
 		updates = append(updates, segment)
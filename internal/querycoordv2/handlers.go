@@ -200,6 +200,7 @@ func (s *Server) getSystemInfoMetrics(
 	metricsinfo.FillDeployMetricsWithEnv(&clusterTopology.Self.SystemInfo)
 	nodesMetrics := s.tryGetNodesMetrics(ctx, req, s.nodeMgr.GetAll()...)
 	s.fillMetricsWithNodes(&clusterTopology, nodesMetrics)
+	clusterTopology.ResourceGroups = s.getResourceGroupUsages(clusterTopology.ConnectedNodes)
 
 	coordTopology := metricsinfo.QueryCoordTopology{
 		Cluster: clusterTopology,
@@ -0,0 +1,136 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// getIntrospectionMetrics returns the read-only view of the targets, distributions and replicas,
+// so that operators don't need to read etcd or the memory of querycoord with external tools.
+func (s *Server) getIntrospectionMetrics(metricType string, request string) (string, error) {
+	params := struct {
+		CollectionID int64 `json:"collection_id"`
+	}{}
+	if err := json.Unmarshal([]byte(request), &params); err != nil {
+		return "", merr.WrapErrParameterInvalidMsg("invalid request: %s", err.Error())
+	}
+	collections := s.meta.CollectionManager.GetAll()
+	if params.CollectionID != 0 {
+		if !s.meta.CollectionManager.Exist(params.CollectionID) {
+			return "", merr.WrapErrCollectionNotLoaded(params.CollectionID)
+		}
+		collections = []int64{params.CollectionID}
+	}
+	sort.Slice(collections, func(i, j int) bool { return collections[i] < collections[j] })
+
+	var infos any
+	switch metricType {
+	case metricsinfo.TargetDistributionMetrics:
+		result := &metricsinfo.TargetDistributionInfos{Collections: make([]metricsinfo.TargetDistribution, 0, len(collections))}
+		for _, collectionID := range collections {
+			result.Collections = append(result.Collections, s.getTargetDistribution(collectionID))
+		}
+		infos = result
+	case metricsinfo.ReplicaAssignmentMetrics:
+		result := &metricsinfo.ReplicaAssignmentInfos{Replicas: make([]metricsinfo.ReplicaAssignment, 0)}
+		for _, collectionID := range collections {
+			for _, replica := range s.meta.ReplicaManager.GetByCollection(collectionID) {
+				nodes := replica.GetNodes()
+				sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+				result.Replicas = append(result.Replicas, metricsinfo.ReplicaAssignment{
+					ReplicaID:      replica.GetID(),
+					CollectionID:   replica.GetCollectionID(),
+					ResourceGroup:  replica.GetResourceGroup(),
+					Nodes:          nodes,
+					ChannelLeaders: s.dist.ChannelDistManager.GetShardLeadersByReplica(replica),
+				})
+			}
+		}
+		infos = result
+	default:
+		return "", merr.WrapErrMetricNotFound(metricType)
+	}
+	return metricsinfo.MarshalComponentInfos(infos)
+}
+
+func (s *Server) getTargetDistribution(collectionID int64) metricsinfo.TargetDistribution {
+	targetInfo := func(scope meta.TargetScope) metricsinfo.TargetInfo {
+		channels := lo.Keys(s.targetMgr.GetDmChannelsByCollection(collectionID, scope))
+		segments := lo.Keys(s.targetMgr.GetSealedSegmentsByCollection(collectionID, scope))
+		sort.Strings(channels)
+		sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+		return metricsinfo.TargetInfo{
+			Version:  s.targetMgr.GetCollectionTargetVersion(collectionID, scope),
+			Channels: channels,
+			Segments: segments,
+		}
+	}
+	info := metricsinfo.TargetDistribution{
+		CollectionID:  collectionID,
+		CurrentTarget: targetInfo(meta.CurrentTarget),
+		NextTarget:    targetInfo(meta.NextTarget),
+	}
+
+	dists := make(map[int64]*metricsinfo.NodeDistribution)
+	getDist := func(nodeID int64) *metricsinfo.NodeDistribution {
+		dist, ok := dists[nodeID]
+		if !ok {
+			dist = &metricsinfo.NodeDistribution{NodeID: nodeID, Channels: make([]string, 0), Segments: make([]int64, 0)}
+			dists[nodeID] = dist
+		}
+		return dist
+	}
+	for _, channel := range s.dist.ChannelDistManager.GetByCollection(collectionID) {
+		dist := getDist(channel.Node)
+		dist.Channels = append(dist.Channels, channel.GetChannelName())
+	}
+	served := typeutil.NewUniqueSet()
+	for _, segment := range s.dist.SegmentDistManager.GetByCollection(collectionID) {
+		dist := getDist(segment.Node)
+		dist.Segments = append(dist.Segments, segment.GetID())
+		served.Insert(segment.GetID())
+	}
+	info.Distributions = make([]metricsinfo.NodeDistribution, 0, len(dists))
+	for _, dist := range dists {
+		sort.Strings(dist.Channels)
+		sort.Slice(dist.Segments, func(i, j int) bool { return dist.Segments[i] < dist.Segments[j] })
+		info.Distributions = append(info.Distributions, *dist)
+	}
+	sort.Slice(info.Distributions, func(i, j int) bool {
+		return info.Distributions[i].NodeID < info.Distributions[j].NodeID
+	})
+
+	targets := typeutil.NewUniqueSet(info.CurrentTarget.Segments...)
+	targets.Insert(info.NextTarget.Segments...)
+	info.MissingSegments = lo.Filter(info.CurrentTarget.Segments, func(id int64, _ int) bool {
+		return !served.Contain(id)
+	})
+	info.RedundantSegments = lo.Filter(served.Collect(), func(id int64, _ int) bool {
+		return !targets.Contain(id)
+	})
+	sort.Slice(info.RedundantSegments, func(i, j int) bool { return info.RedundantSegments[i] < info.RedundantSegments[j] })
+	return info
+}
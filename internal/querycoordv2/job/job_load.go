@@ -270,10 +270,12 @@ func (job *LoadPartitionJob) PreExecute() error {
 		return nil
 	}
 
-	if collection.GetReplicaNumber() != req.GetReplicaNumber() {
-		msg := "collection with different replica number existed, release this collection first before changing its replica number"
+	// partitions could be loaded with fewer replicas than the collection,
+	// which are served by the first replicas of the collection only
+	if collection.GetReplicaNumber() < req.GetReplicaNumber() {
+		msg := "collection with fewer replicas existed, release this collection first before loading partitions with more replicas"
 		log.Warn(msg)
-		return merr.WrapErrParameterInvalid(collection.GetReplicaNumber(), req.GetReplicaNumber(), "can't change the replica number for loaded partitions")
+		return merr.WrapErrParameterInvalid(collection.GetReplicaNumber(), req.GetReplicaNumber(), "can't load partitions with more replicas than the loaded collection")
 	} else if !typeutil.MapEqual(collection.GetFieldIndexID(), req.GetFieldIndexID()) {
 		msg := fmt.Sprintf("collection with different index %v existed, release this collection first before changing its index",
 			job.meta.GetFieldIndex(req.GetCollectionID()))
@@ -281,6 +283,18 @@ func (job *LoadPartitionJob) PreExecute() error {
 		return merr.WrapErrParameterInvalid(collection.GetFieldIndexID(), req.GetFieldIndexID(), "can't change the index for loaded partitions")
 	}
 
+	for _, partitionID := range req.GetPartitionIDs() {
+		if job.meta.GetPartition(partitionID) == nil {
+			continue
+		}
+		replicaNumber := job.meta.GetPartitionReplicaNumber(req.GetCollectionID(), partitionID)
+		if replicaNumber != req.GetReplicaNumber() {
+			log.Warn("partition with different replica number existed, release this partition first before changing its replica number",
+				zap.Int64("partitionID", partitionID))
+			return merr.WrapErrParameterInvalid(replicaNumber, req.GetReplicaNumber(), "can't change the replica number for loaded partitions")
+		}
+	}
+
 	return nil
 }
 
@@ -272,6 +272,41 @@ func (m *CollectionManager) GetReplicaNumber(collectionID typeutil.UniqueID) int
 	return -1
 }
 
+// GetPartitionReplicaNumber returns the replica number of the partition,
+// which falls back to the collection's if the partition is not limited to fewer replicas.
+func (m *CollectionManager) GetPartitionReplicaNumber(collectionID, partitionID typeutil.UniqueID) int32 {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	collection, ok := m.collections[collectionID]
+	if !ok {
+		return -1
+	}
+	partition, ok := m.partitions[partitionID]
+	if ok && partition.GetReplicaNumber() > 0 && partition.GetReplicaNumber() < collection.GetReplicaNumber() {
+		return partition.GetReplicaNumber()
+	}
+	return collection.GetReplicaNumber()
+}
+
+// GetPartitionReplicaNumbers returns the partitions of the collection loaded with fewer replicas than the collection.
+func (m *CollectionManager) GetPartitionReplicaNumbers(collectionID typeutil.UniqueID) map[int64]int32 {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	collection, ok := m.collections[collectionID]
+	if !ok {
+		return nil
+	}
+	result := make(map[int64]int32)
+	for _, partition := range m.getPartitionsByCollection(collectionID) {
+		if partition.GetReplicaNumber() > 0 && partition.GetReplicaNumber() < collection.GetReplicaNumber() {
+			result[partition.GetPartitionID()] = partition.GetReplicaNumber()
+		}
+	}
+	return result
+}
+
 // CalculateLoadPercentage checks if collection is currently fully loaded.
 func (m *CollectionManager) CalculateLoadPercentage(collectionID typeutil.UniqueID) int32 {
 	m.rwmutex.RLock()
@@ -132,6 +132,35 @@ func (suite *CollectionManagerSuite) TestGetProperty() {
 	suite.False(exist)
 }
 
+func (suite *CollectionManagerSuite) TestGetPartitionReplicaNumber() {
+	mgr := suite.mgr
+
+	// partitions without replica number follow the collection
+	collection := suite.collections[2]
+	for _, partition := range suite.partitions[collection] {
+		suite.Equal(suite.replicaNumber[2], mgr.GetPartitionReplicaNumber(collection, partition))
+	}
+	suite.Empty(mgr.GetPartitionReplicaNumbers(collection))
+
+	err := mgr.PutPartition(&Partition{
+		PartitionLoadInfo: &querypb.PartitionLoadInfo{
+			CollectionID:  collection,
+			PartitionID:   16,
+			ReplicaNumber: 1,
+			Status:        querypb.LoadStatus_Loaded,
+		},
+		LoadPercentage: 100,
+		CreatedAt:      time.Now(),
+	})
+	suite.NoError(err)
+	suite.EqualValues(1, mgr.GetPartitionReplicaNumber(collection, 16))
+	suite.Equal(map[int64]int32{16: 1}, mgr.GetPartitionReplicaNumbers(collection))
+	suite.NoError(mgr.RemovePartition(collection, 16))
+
+	suite.EqualValues(-1, mgr.GetPartitionReplicaNumber(-1, 16))
+	suite.Nil(mgr.GetPartitionReplicaNumbers(-1))
+}
+
 func (suite *CollectionManagerSuite) TestPut() {
 	suite.releaseAll()
 	// test put collection with partitions
@@ -19,6 +19,7 @@ package meta
 import (
 	"github.com/milvus-io/milvus/internal/metastore"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/pkg/common"
 )
 
 type Meta struct {
@@ -38,3 +39,17 @@ func NewMeta(
 		NewResourceManager(catalog, nodeMgr),
 	}
 }
+
+// ReplicaServePartition returns whether the replica serves the partition,
+// the partition loaded with replica number k is served by the first k replicas of the collection ordered by ID.
+// The segments not belonging to any partition, like L0 segments, are served by all replicas.
+func (m *Meta) ReplicaServePartition(replica *Replica, partitionID int64) bool {
+	if partitionID == common.InvalidPartitionID {
+		return true
+	}
+	replicaNumber := m.CollectionManager.GetPartitionReplicaNumber(replica.GetCollectionID(), partitionID)
+	if replicaNumber <= 0 {
+		return true
+	}
+	return m.ReplicaManager.GetRank(replica.GetID()) < replicaNumber
+}
@@ -237,6 +237,24 @@ func (m *ReplicaManager) GetByCollection(collectionID typeutil.UniqueID) []*Repl
 	return replicas
 }
 
+// GetRank returns the rank of the replica in its collection ordered by replica ID, -1 if the replica not found.
+func (m *ReplicaManager) GetRank(replicaID typeutil.UniqueID) int32 {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	replica, ok := m.replicas[replicaID]
+	if !ok {
+		return -1
+	}
+	rank := int32(0)
+	for _, other := range m.replicas {
+		if other.CollectionID == replica.CollectionID && other.GetID() < replicaID {
+			rank++
+		}
+	}
+	return rank
+}
+
 func (m *ReplicaManager) GetByCollectionAndNode(collectionID, nodeID typeutil.UniqueID) *Replica {
 	m.rwmutex.RLock()
 	defer m.rwmutex.RUnlock()
@@ -17,6 +17,7 @@
 package meta
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -117,6 +118,23 @@ func (suite *ReplicaManagerSuite) TestGet() {
 	}
 }
 
+func (suite *ReplicaManagerSuite) TestGetRank() {
+	mgr := suite.mgr
+
+	for i, collection := range suite.collections {
+		replicas := mgr.GetByCollection(collection)
+		sort.Slice(replicas, func(i, j int) bool {
+			return replicas[i].GetID() < replicas[j].GetID()
+		})
+		suite.Len(replicas, int(suite.replicaNumbers[i]))
+		for rank, replica := range replicas {
+			suite.EqualValues(rank, mgr.GetRank(replica.GetID()))
+		}
+	}
+
+	suite.EqualValues(-1, mgr.GetRank(-1))
+}
+
 func (suite *ReplicaManagerSuite) TestRecover() {
 	mgr := suite.mgr
 
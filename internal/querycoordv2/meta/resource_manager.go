@@ -323,6 +323,11 @@ func (rm *ResourceManager) GetNodes(rgName string) ([]int64, error) {
 	return rm.groups[rgName].GetNodes(), nil
 }
 
+// GetNodeInfo returns the info of the node, nil if the node is not found.
+func (rm *ResourceManager) GetNodeInfo(node int64) *session.NodeInfo {
+	return rm.nodeMgr.Get(node)
+}
+
 // return all outbound node
 func (rm *ResourceManager) CheckOutboundNodes(replica *Replica) typeutil.UniqueSet {
 	rm.rwmutex.RLock()
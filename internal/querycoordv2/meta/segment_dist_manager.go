@@ -17,6 +17,7 @@
 package meta
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/golang/protobuf/proto"
@@ -32,6 +33,8 @@ type Segment struct {
 	Version            int64                             // Version is the timestamp of loading segment
 	LastDeltaTimestamp uint64                            // The timestamp of the last delta record
 	IndexInfo          map[int64]*querypb.FieldIndexInfo // index info of loaded segment
+	AccessCount        int64                             // The times of the segment accessed by search and query
+	FieldAccess        []*querypb.FieldAccessInfo        // The times of the fields accessed, ordered by count desc
 }
 
 func SegmentFromInfo(info *datapb.SegmentInfo) *Segment {
@@ -45,6 +48,8 @@ func (segment *Segment) Clone() *Segment {
 		SegmentInfo: proto.Clone(segment.SegmentInfo).(*datapb.SegmentInfo),
 		Node:        segment.Node,
 		Version:     segment.Version,
+		AccessCount: segment.AccessCount,
+		FieldAccess: segment.FieldAccess,
 	}
 }
 
@@ -195,3 +200,50 @@ func (m *SegmentDistManager) GetSegmentDist(segmentID int64) []int64 {
 	}
 	return ret
 }
+
+// GetWarmupHint returns the access stats of the segment on the nodes except the given one,
+// the max counts among replicas are taken, nil if the segment is never accessed.
+func (m *SegmentDistManager) GetWarmupHint(segmentID int64, excludedNode int64) *querypb.SegmentWarmupHint {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	var accessCount int64
+	fieldAccess := make(map[int64]int64)
+	for nodeID, segments := range m.segments {
+		if nodeID == excludedNode {
+			continue
+		}
+		for _, segment := range segments {
+			if segment.GetID() != segmentID {
+				continue
+			}
+			if segment.AccessCount > accessCount {
+				accessCount = segment.AccessCount
+			}
+			for _, field := range segment.FieldAccess {
+				if field.GetAccessCount() > fieldAccess[field.GetFieldID()] {
+					fieldAccess[field.GetFieldID()] = field.GetAccessCount()
+				}
+			}
+		}
+	}
+	if accessCount == 0 {
+		return nil
+	}
+
+	hint := &querypb.SegmentWarmupHint{
+		SegmentID:   segmentID,
+		AccessCount: accessCount,
+		FieldAccess: make([]*querypb.FieldAccessInfo, 0, len(fieldAccess)),
+	}
+	for fieldID, count := range fieldAccess {
+		hint.FieldAccess = append(hint.FieldAccess, &querypb.FieldAccessInfo{FieldID: fieldID, AccessCount: count})
+	}
+	sort.Slice(hint.FieldAccess, func(i, j int) bool {
+		if hint.FieldAccess[i].GetAccessCount() != hint.FieldAccess[j].GetAccessCount() {
+			return hint.FieldAccess[i].GetAccessCount() > hint.FieldAccess[j].GetAccessCount()
+		}
+		return hint.FieldAccess[i].GetFieldID() < hint.FieldAccess[j].GetFieldID()
+	})
+	return hint
+}
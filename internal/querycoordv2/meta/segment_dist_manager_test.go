@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
 )
 
 type SegmentDistManagerSuite struct {
@@ -123,6 +124,33 @@ func (suite *SegmentDistManagerSuite) TestGetBy() {
 	suite.Len(segments, 0)
 }
 
+func (suite *SegmentDistManagerSuite) TestGetWarmupHint() {
+	dist := NewSegmentDistManager()
+	hot := suite.segments[1].Clone()
+	hot.AccessCount = 10
+	hot.FieldAccess = []*querypb.FieldAccessInfo{{FieldID: 100, AccessCount: 10}, {FieldID: 101, AccessCount: 2}}
+	cold := suite.segments[1].Clone()
+	cold.AccessCount = 3
+	cold.FieldAccess = []*querypb.FieldAccessInfo{{FieldID: 101, AccessCount: 3}}
+	dist.Update(suite.nodes[0], hot, suite.segments[2].Clone())
+	dist.Update(suite.nodes[1], cold)
+
+	hint := dist.GetWarmupHint(1, suite.nodes[2])
+	suite.EqualValues(1, hint.GetSegmentID())
+	suite.EqualValues(10, hint.GetAccessCount())
+	suite.Len(hint.GetFieldAccess(), 2)
+	suite.EqualValues(100, hint.GetFieldAccess()[0].GetFieldID())
+	suite.EqualValues(3, hint.GetFieldAccess()[1].GetAccessCount())
+
+	// the stats of the excluded node are not taken
+	hint = dist.GetWarmupHint(1, suite.nodes[0])
+	suite.EqualValues(3, hint.GetAccessCount())
+
+	// never accessed
+	suite.Nil(dist.GetWarmupHint(2, suite.nodes[2]))
+	suite.Nil(dist.GetWarmupHint(1000, suite.nodes[2]))
+}
+
 func (suite *SegmentDistManagerSuite) AssertIDs(segments []*Segment, ids ...int64) bool {
 	for _, segment := range segments {
 		hasSegment := false
@@ -185,6 +185,8 @@ func (ob *CollectionObserver) observePartitionLoadStatus(ctx context.Context, pa
 	segmentTargets := ob.targetMgr.GetSealedSegmentsByPartition(partition.GetCollectionID(), partition.GetPartitionID(), meta.NextTarget)
 	channelTargets := ob.targetMgr.GetDmChannelsByCollection(partition.GetCollectionID(), meta.NextTarget)
 
+	// the segments of the partition loaded with fewer replicas are served by part of the replicas only
+	partitionReplicaNum := ob.meta.GetPartitionReplicaNumber(partition.GetCollectionID(), partition.GetPartitionID())
 	targetNum := len(segmentTargets) + len(channelTargets)
 	if targetNum == 0 {
 		log.Info("segments and channels in target are both empty, waiting for new target content")
@@ -196,6 +198,7 @@ func (ob *CollectionObserver) observePartitionLoadStatus(ctx context.Context, pa
 		zap.Int("channelTargetNum", len(channelTargets)),
 		zap.Int("totalTargetNum", targetNum),
 		zap.Int32("replicaNum", replicaNum),
+		zap.Int32("partitionReplicaNum", partitionReplicaNum),
 	)
 	loadedCount := 0
 	loadPercentage := int32(0)
@@ -211,14 +214,18 @@ func (ob *CollectionObserver) observePartitionLoadStatus(ctx context.Context, pa
 		group := utils.GroupNodesByReplica(ob.meta.ReplicaManager,
 			partition.GetCollectionID(),
 			ob.dist.LeaderViewManager.GetSealedSegmentDist(segment.GetID()))
-		loadedCount += len(group)
+		if len(group) > int(partitionReplicaNum) {
+			loadedCount += int(partitionReplicaNum)
+		} else {
+			loadedCount += len(group)
+		}
 	}
 	if loadedCount > 0 {
 		log.Info("partition load progress",
 			zap.Int("subChannelCount", subChannelCount),
 			zap.Int("loadSegmentCount", loadedCount-subChannelCount))
 	}
-	loadPercentage = int32(loadedCount * 100 / (targetNum * int(replicaNum)))
+	loadPercentage = int32(loadedCount * 100 / (len(channelTargets)*int(replicaNum) + len(segmentTargets)*int(partitionReplicaNum)))
 
 	if loadedCount <= ob.partitionLoadedCount[partition.GetPartitionID()] && loadPercentage != 100 {
 		ob.partitionLoadedCount[partition.GetPartitionID()] = loadedCount
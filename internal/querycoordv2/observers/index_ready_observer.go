@@ -0,0 +1,180 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+)
+
+const rewatchIndexReadyInterval = time.Second
+
+// IndexReadyObserver watches the segments whose indexes are built, which are notified by datacoord,
+// to update the targets and load the indexes without polling the index states of all segments.
+type IndexReadyObserver struct {
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	watchKV kv.WatchKV
+	meta    *meta.Meta
+	notify  func(collectionID int64, segmentIDs []int64)
+
+	stopOnce sync.Once
+}
+
+func NewIndexReadyObserver(
+	watchKV kv.WatchKV,
+	meta *meta.Meta,
+	notify func(collectionID int64, segmentIDs []int64),
+) *IndexReadyObserver {
+	return &IndexReadyObserver{
+		watchKV: watchKV,
+		meta:    meta,
+		notify:  notify,
+	}
+}
+
+func (ob *IndexReadyObserver) Start() {
+	if !params.Params.CommonCfg.IndexReadyNotifyEnable.GetAsBool() {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ob.cancel = cancel
+
+	ob.wg.Add(1)
+	go ob.schedule(ctx)
+}
+
+func (ob *IndexReadyObserver) Stop() {
+	ob.stopOnce.Do(func() {
+		if ob.cancel != nil {
+			ob.cancel()
+		}
+		ob.wg.Wait()
+	})
+}
+
+func (ob *IndexReadyObserver) schedule(ctx context.Context) {
+	defer ob.wg.Done()
+	log.Info("Start watch index ready loop")
+
+	for {
+		ob.watch(ctx)
+		select {
+		case <-ctx.Done():
+			log.Info("Close index ready observer")
+			return
+		case <-time.After(rewatchIndexReadyInterval):
+		}
+	}
+}
+
+// watch handles the notifications until the watch is broken, e.g. compacted or the etcd connection lost.
+func (ob *IndexReadyObserver) watch(ctx context.Context) {
+	watchCh := ob.watchKV.WatchWithPrefix(util.IndexReadyPrefix)
+	// handle the notifications before watching, the duplicated ones are harmless
+	keys, _, err := ob.watchKV.LoadWithPrefix(util.IndexReadyPrefix)
+	if err != nil {
+		log.Warn("failed to load index ready notifications", zap.Error(err))
+		return
+	}
+	ob.handle(keys)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case resp, ok := <-watchCh:
+			if !ok {
+				log.Warn("index ready watch channel closed, rewatch")
+				return
+			}
+			if err := resp.Err(); err != nil {
+				log.Warn("index ready watch failed, rewatch", zap.Error(err))
+				return
+			}
+			keys := make([]string, 0, len(resp.Events))
+			for _, event := range resp.Events {
+				if event.Type == mvccpb.PUT {
+					keys = append(keys, string(event.Kv.Key))
+				}
+			}
+			ob.handle(keys)
+		}
+	}
+}
+
+func (ob *IndexReadyObserver) handle(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	ready := make(map[int64][]int64) // collection -> segments
+	handled := make([]string, 0, len(keys))
+	for _, key := range keys {
+		collectionID, segmentID, err := parseIndexReadyKey(key)
+		if err != nil {
+			log.Warn("invalid index ready notification", zap.String("key", key), zap.Error(err))
+			continue
+		}
+		if ob.meta.Exist(collectionID) {
+			ready[collectionID] = append(ready[collectionID], segmentID)
+		}
+		handled = append(handled, path.Join(util.IndexReadyPrefix,
+			strconv.FormatInt(collectionID, 10), strconv.FormatInt(segmentID, 10)))
+	}
+
+	for collectionID, segmentIDs := range ready {
+		log.Info("index ready notified",
+			zap.Int64("collectionID", collectionID),
+			zap.Int64s("segmentIDs", segmentIDs))
+		ob.notify(collectionID, segmentIDs)
+	}
+
+	if err := ob.watchKV.MultiRemove(handled); err != nil {
+		log.Warn("failed to remove handled index ready notifications", zap.Error(err))
+	}
+}
+
+// parseIndexReadyKey parses the collection and segment from the key, which ends with {collectionID}/{segmentID}.
+func parseIndexReadyKey(key string) (collectionID int64, segmentID int64, err error) {
+	parts := strings.Split(key, "/")
+	if len(parts) < 3 || parts[len(parts)-3] != util.IndexReadyPrefix {
+		return 0, 0, errors.Newf("unexpected index ready key %s", key)
+	}
+	collectionID, err = strconv.ParseInt(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	segmentID, err = strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return collectionID, segmentID, nil
+}
@@ -0,0 +1,144 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observers
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	etcdKV "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/etcd"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type IndexReadyObserverSuite struct {
+	suite.Suite
+
+	kv       kv.WatchKV
+	store    *mocks.QueryCoordCatalog
+	meta     *meta.Meta
+	observer *IndexReadyObserver
+
+	mu       sync.Mutex
+	notified map[int64][]int64
+}
+
+func (suite *IndexReadyObserverSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (suite *IndexReadyObserverSuite) SetupTest() {
+	config := GenerateEtcdConfig()
+	cli, err := etcd.GetEtcdClient(
+		config.UseEmbedEtcd.GetAsBool(),
+		config.EtcdUseSSL.GetAsBool(),
+		config.Endpoints.GetAsStrings(),
+		config.EtcdTLSCert.GetValue(),
+		config.EtcdTLSKey.GetValue(),
+		config.EtcdTLSCACert.GetValue(),
+		config.EtcdTLSMinVersion.GetValue())
+	suite.Require().NoError(err)
+	suite.kv = etcdKV.NewEtcdKV(cli, config.MetaRootPath.GetValue())
+	suite.Require().NoError(suite.kv.RemoveWithPrefix(util.IndexReadyPrefix))
+
+	suite.store = mocks.NewQueryCoordCatalog(suite.T())
+	suite.store.EXPECT().SaveCollection(mock.Anything).Return(nil)
+	suite.meta = meta.NewMeta(RandomIncrementIDAllocator(), suite.store, session.NewNodeManager())
+	suite.meta.CollectionManager.PutCollection(utils.CreateTestCollection(1, 1))
+
+	suite.notified = make(map[int64][]int64)
+	suite.observer = NewIndexReadyObserver(suite.kv, suite.meta, func(collectionID int64, segmentIDs []int64) {
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+		suite.notified[collectionID] = append(suite.notified[collectionID], segmentIDs...)
+	})
+}
+
+func (suite *IndexReadyObserverSuite) TearDownTest() {
+	suite.observer.Stop()
+	suite.kv.RemoveWithPrefix(util.IndexReadyPrefix)
+	suite.kv.Close()
+}
+
+func (suite *IndexReadyObserverSuite) notifiedSegments(collectionID int64) []int64 {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	return suite.notified[collectionID]
+}
+
+func (suite *IndexReadyObserverSuite) TestNotify() {
+	// notified before start
+	suite.Require().NoError(suite.kv.Save("index-ready/1/100", "1000"))
+	// the collection not loaded
+	suite.Require().NoError(suite.kv.Save("index-ready/2/200", "1000"))
+
+	suite.observer.Start()
+	suite.Eventually(func() bool {
+		return len(suite.notifiedSegments(1)) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// notified after start
+	suite.Require().NoError(suite.kv.Save("index-ready/1/101", "1000"))
+	suite.Eventually(func() bool {
+		return len(suite.notifiedSegments(1)) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+	suite.ElementsMatch([]int64{100, 101}, suite.notifiedSegments(1))
+	suite.Empty(suite.notifiedSegments(2))
+
+	// the handled notifications are removed
+	suite.Eventually(func() bool {
+		keys, _, err := suite.kv.LoadWithPrefix(util.IndexReadyPrefix)
+		return err == nil && len(keys) == 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func (suite *IndexReadyObserverSuite) TestDisabled() {
+	paramtable.Get().Save(Params.CommonCfg.IndexReadyNotifyEnable.Key, "false")
+	defer paramtable.Get().Reset(Params.CommonCfg.IndexReadyNotifyEnable.Key)
+
+	suite.Require().NoError(suite.kv.Save("index-ready/1/100", "1000"))
+	suite.observer.Start()
+	time.Sleep(200 * time.Millisecond)
+	suite.Empty(suite.notifiedSegments(1))
+}
+
+func (suite *IndexReadyObserverSuite) TestParseKey() {
+	collectionID, segmentID, err := parseIndexReadyKey("by-dev/meta/index-ready/1/100")
+	suite.NoError(err)
+	suite.EqualValues(1, collectionID)
+	suite.EqualValues(100, segmentID)
+
+	for _, key := range []string{"by-dev/meta/index-ready/1", "by-dev/meta/other/1/100", "by-dev/meta/index-ready/a/100", "by-dev/meta/index-ready/1/b"} {
+		_, _, err = parseIndexReadyKey(key)
+		suite.Error(err, key)
+	}
+}
+
+func TestIndexReadyObserver(t *testing.T) {
+	suite.Run(t, new(IndexReadyObserverSuite))
+}
@@ -18,6 +18,7 @@ package observers
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"time"
 
@@ -27,6 +28,8 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 // check replica, find outbound nodes and remove it from replica if all segment/channel has been moved
@@ -36,13 +39,17 @@ type ReplicaObserver struct {
 	meta    *meta.Meta
 	distMgr *meta.DistributionManager
 
+	// collections whose failure domain violations are reported
+	reportedCollections typeutil.Set[int64]
+
 	stopOnce sync.Once
 }
 
 func NewReplicaObserver(meta *meta.Meta, distMgr *meta.DistributionManager) *ReplicaObserver {
 	return &ReplicaObserver{
-		meta:    meta,
-		distMgr: distMgr,
+		meta:                meta,
+		distMgr:             distMgr,
+		reportedCollections: typeutil.NewSet[int64](),
 	}
 }
 
@@ -77,6 +84,7 @@ func (ob *ReplicaObserver) schedule(ctx context.Context) {
 
 		case <-ticker.C:
 			ob.checkNodesInReplica()
+			ob.checkFailureDomains()
 		}
 	}
 }
@@ -128,3 +136,28 @@ func (ob *ReplicaObserver) checkNodesInReplica() {
 		}
 	}
 }
+
+// checkFailureDomains reports the failure domains serving more than one replica of each collection,
+// which are placed before the nodes advertise the failure domains, or with the soft constraint.
+func (ob *ReplicaObserver) checkFailureDomains() {
+	log := log.Ctx(context.Background()).WithRateGroup("qcv2.replicaObserver.failureDomain", 1, 60)
+	collections := typeutil.NewSet(ob.meta.GetAll()...)
+	for collectionID := range collections {
+		replicas := ob.meta.ReplicaManager.GetByCollection(collectionID)
+		violations := utils.CountFailureDomainViolations(ob.meta, replicas)
+		if violations > 0 {
+			log.RatedWarn(60, "replicas share failure domains",
+				zap.Int64("collectionID", collectionID),
+				zap.Int("violations", violations),
+			)
+		}
+		metrics.QueryCoordFailureDomainViolationNum.WithLabelValues(strconv.FormatInt(collectionID, 10)).Set(float64(violations))
+		ob.reportedCollections.Insert(collectionID)
+	}
+	for collectionID := range ob.reportedCollections {
+		if !collections.Contain(collectionID) {
+			metrics.QueryCoordFailureDomainViolationNum.DeleteLabelValues(strconv.FormatInt(collectionID, 10))
+			ob.reportedCollections.Remove(collectionID)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
@@ -222,6 +223,13 @@ func (ob *TargetObserver) UpdateNextTarget(collectionID int64) (chan struct{}, e
 	return readyCh, <-notifier
 }
 
+// NotifyIndexReady expires the next target of the collection and updates it asynchronously,
+// so that the segments whose indexes are built get into the next target without waiting for the expiration.
+func (ob *TargetObserver) NotifyIndexReady(collectionID int64) {
+	ob.nextTargetLastUpdate.Remove(collectionID)
+	ob.dispatcher.AddTask(collectionID)
+}
+
 func (ob *TargetObserver) ReleaseCollection(collectionID int64) {
 	ob.mut.Lock()
 	defer ob.mut.Unlock()
@@ -322,7 +330,7 @@ func (ob *TargetObserver) shouldUpdateCurrentTarget(ctx context.Context, collect
 		group := utils.GroupNodesByReplica(ob.meta.ReplicaManager,
 			collectionID,
 			ob.distMgr.LeaderViewManager.GetSealedSegmentDist(segment.GetID()))
-		if int32(len(group)) < replicaNum {
+		if int32(len(group)) < ob.meta.GetPartitionReplicaNumber(collectionID, segment.GetPartitionID()) {
 			log.RatedInfo(10, "segment not ready",
 				zap.Int("readyReplicaNum", len(group)),
 				zap.Int64("segmentID", segment.GetID()),
@@ -456,6 +464,12 @@ func (ob *TargetObserver) checkNeedUpdateTargetVersion(ctx context.Context, lead
 	)
 
 	sealedSegments := ob.targetMgr.GetSealedSegmentsByChannel(leaderView.CollectionID, leaderView.Channel, meta.NextTarget)
+	// the delegator only serves the segments of the partitions its replica serves
+	if replica := ob.meta.ReplicaManager.GetByCollectionAndNode(leaderView.CollectionID, leaderView.ID); replica != nil {
+		sealedSegments = lo.PickBy(sealedSegments, func(_ int64, segment *datapb.SegmentInfo) bool {
+			return ob.meta.ReplicaServePartition(replica, segment.GetPartitionID())
+		})
+	}
 	growingSegments := ob.targetMgr.GetGrowingSegmentsByChannel(leaderView.CollectionID, leaderView.Channel, meta.NextTarget)
 	droppedSegments := ob.targetMgr.GetDroppedSegmentsByChannel(leaderView.CollectionID, leaderView.Channel, meta.NextTarget)
 
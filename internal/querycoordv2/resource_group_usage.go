@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// getResourceGroupMetrics returns the usages of the resource groups, filtered by the resource group in request.
+func (s *Server) getResourceGroupMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (string, error) {
+	params := struct {
+		ResourceGroup string `json:"resource_group"`
+	}{}
+	if err := json.Unmarshal([]byte(req.GetRequest()), &params); err != nil {
+		return "", merr.WrapErrParameterInvalidMsg("invalid request: %s", err.Error())
+	}
+	if params.ResourceGroup != "" && !s.meta.ResourceManager.ContainResourceGroup(params.ResourceGroup) {
+		return "", merr.WrapErrResourceGroupNotFound(params.ResourceGroup)
+	}
+
+	// the querynodes only serve the system info metrics
+	nodesReq, err := metricsinfo.ConstructRequestByMetricType(metricsinfo.SystemInfoMetrics)
+	if err != nil {
+		return "", err
+	}
+	topology := &metricsinfo.QueryClusterTopology{}
+	s.fillMetricsWithNodes(topology, s.tryGetNodesMetrics(ctx, nodesReq, s.nodeMgr.GetAll()...))
+
+	usages := s.getResourceGroupUsages(topology.ConnectedNodes)
+	if params.ResourceGroup != "" {
+		for _, usage := range usages {
+			if usage.Name == params.ResourceGroup {
+				usages = []metricsinfo.ResourceGroupUsage{usage}
+				break
+			}
+		}
+	}
+	return metricsinfo.MarshalComponentInfos(&metricsinfo.ResourceGroupInfos{ResourceGroups: usages})
+}
+
+// getResourceGroupUsages returns the nodes, collections and utilization of all the resource groups,
+// the utilization is summed from the hardware metrics of the querynodes. The usages are recorded
+// in the metrics of querycoord as well.
+func (s *Server) getResourceGroupUsages(nodes []metricsinfo.QueryNodeInfos) []metricsinfo.ResourceGroupUsage {
+	hardwares := make(map[int64]metricsinfo.HardwareMetrics, len(nodes))
+	for _, node := range nodes {
+		if !node.HasError {
+			hardwares[node.ID] = node.HardwareInfos
+		}
+	}
+
+	names := s.meta.ResourceManager.ListResourceGroups()
+	sort.Strings(names)
+	usages := make([]metricsinfo.ResourceGroupUsage, 0, len(names))
+	for _, name := range names {
+		rg, err := s.meta.ResourceManager.GetResourceGroup(name)
+		if err != nil {
+			continue
+		}
+		usage := metricsinfo.ResourceGroupUsage{
+			Name:     name,
+			Capacity: rg.GetCapacity(),
+			Nodes:    rg.GetNodes(),
+		}
+		sort.Slice(usage.Nodes, func(i, j int) bool { return usage.Nodes[i] < usage.Nodes[j] })
+
+		replicas := s.meta.ReplicaManager.GetByResourceGroup(name)
+		collections := typeutil.NewUniqueSet()
+		for _, replica := range replicas {
+			collections.Insert(replica.GetCollectionID())
+		}
+		usage.Collections = collections.Collect()
+		sort.Slice(usage.Collections, func(i, j int) bool { return usage.Collections[i] < usage.Collections[j] })
+		usage.NumReplicas = len(replicas)
+
+		reported := 0
+		for _, node := range usage.Nodes {
+			hardware, ok := hardwares[node]
+			if !ok {
+				continue
+			}
+			usage.Memory += hardware.Memory
+			usage.MemoryUsage += hardware.MemoryUsage
+			usage.CPUUsage += hardware.CPUCoreUsage
+			reported++
+		}
+		if reported > 0 {
+			usage.CPUUsage /= float64(reported)
+		}
+
+		metrics.QueryCoordResourceGroupNodeNum.WithLabelValues(name).Set(float64(len(usage.Nodes)))
+		metrics.QueryCoordResourceGroupReplicaNum.WithLabelValues(name).Set(float64(usage.NumReplicas))
+		metrics.QueryCoordResourceGroupMemoryUsage.WithLabelValues(name).Set(float64(usage.MemoryUsage))
+		usages = append(usages, usage)
+	}
+	return usages
+}
@@ -52,6 +52,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/task"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/eventbus"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/tsoutil"
 	"github.com/milvus-io/milvus/pkg/common"
@@ -113,6 +114,7 @@ type Server struct {
 	targetObserver     *observers.TargetObserver
 	replicaObserver    *observers.ReplicaObserver
 	resourceObserver   *observers.ResourceObserver
+	indexReadyObserver *observers.IndexReadyObserver
 
 	balancer    balance.Balance
 	balancerMap map[string]balance.Balance
@@ -391,6 +393,14 @@ func (s *Server) initObserver() {
 	)
 
 	s.resourceObserver = observers.NewResourceObserver(s.meta)
+
+	// datacoord always notifies by etcd, even if the meta is stored in tikv
+	watchKV := etcdkv.NewEtcdKV(s.etcdCli, Params.EtcdCfg.MetaRootPath.GetValue(),
+		etcdkv.WithRequestTimeout(paramtable.Get().ServiceParam.EtcdCfg.RequestTimeout.GetAsDuration(time.Millisecond)))
+	s.indexReadyObserver = observers.NewIndexReadyObserver(watchKV, s.meta, func(collectionID int64, segmentIDs []int64) {
+		s.targetObserver.NotifyIndexReady(collectionID)
+		s.checkerController.NotifyIndexReady(segmentIDs...)
+	})
 }
 
 func (s *Server) afterStart() {
@@ -414,7 +424,7 @@ func (s *Server) startQueryCoord() error {
 		return err
 	}
 	for _, node := range sessions {
-		s.nodeMgr.Add(session.NewNodeInfo(node.ServerID, node.Address))
+		s.nodeMgr.Add(session.NewNodeInfo(node.ServerID, node.Address, session.WithFailureDomain(node.Zone, node.Rack)))
 		s.taskScheduler.AddExecutor(node.ServerID)
 
 		if node.Stopping {
@@ -452,6 +462,7 @@ func (s *Server) startServerLoop() {
 	s.targetObserver.Start()
 	s.replicaObserver.Start()
 	s.resourceObserver.Start()
+	s.indexReadyObserver.Start()
 
 	log.Info("start task scheduler...")
 	s.taskScheduler.Start()
@@ -504,6 +515,9 @@ func (s *Server) Stop() error {
 	if s.resourceObserver != nil {
 		s.resourceObserver.Stop()
 	}
+	if s.indexReadyObserver != nil {
+		s.indexReadyObserver.Stop()
+	}
 
 	if s.distController != nil {
 		log.Info("stop dist controller...")
@@ -632,8 +646,10 @@ func (s *Server) watchNodes(revision int64) {
 				log.Info("add node to NodeManager",
 					zap.Int64("nodeID", nodeID),
 					zap.String("nodeAddr", addr),
+					zap.String("zone", event.Session.Zone),
+					zap.String("rack", event.Session.Rack),
 				)
-				s.nodeMgr.Add(session.NewNodeInfo(nodeID, addr))
+				s.nodeMgr.Add(session.NewNodeInfo(nodeID, addr, session.WithFailureDomain(event.Session.Zone, event.Session.Rack)))
 				s.nodeUpEventChan <- nodeID
 				select {
 				case s.notifyNodeUp <- struct{}{}:
@@ -653,6 +669,11 @@ func (s *Server) watchNodes(revision int64) {
 			case sessionutil.SessionDelEvent:
 				nodeID := event.Session.ServerID
 				log.Info("a node down, remove it", zap.Int64("nodeID", nodeID))
+				eventbus.Publish(eventbus.NodeDown, 0, map[string]any{
+					"role":    typeutil.QueryNodeRole,
+					"node_id": nodeID,
+					"address": event.Session.Address,
+				})
 				s.nodeMgr.Remove(nodeID)
 				s.handleNodeDown(nodeID)
 				s.metricsCacheManager.InvalidateSystemInfoMetrics()
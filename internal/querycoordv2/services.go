@@ -757,6 +757,24 @@ func (s *Server) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest
 		return resp, nil
 	}
 
+	if metricType == metricsinfo.TargetDistributionMetrics || metricType == metricsinfo.ReplicaAssignmentMetrics {
+		resp.Response, err = s.getIntrospectionMetrics(metricType, req.GetRequest())
+		if err != nil {
+			log.Warn("failed to get introspection metrics", zap.String("metricType", metricType), zap.Error(err))
+			resp.Status = merr.Status(err)
+		}
+		return resp, nil
+	}
+
+	if metricType == metricsinfo.ResourceGroupMetrics {
+		resp.Response, err = s.getResourceGroupMetrics(ctx, req)
+		if err != nil {
+			log.Warn("failed to get resource group metrics", zap.Error(err))
+			resp.Status = merr.Status(err)
+		}
+		return resp, nil
+	}
+
 	if metricType != metricsinfo.SystemInfoMetrics {
 		msg := "invalid metric type"
 		err := errors.New(metricsinfo.MsgUnimplementedMetric)
@@ -884,6 +902,7 @@ func (s *Server) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeade
 		leaders = filterDupLeaders(s.meta.ReplicaManager, leaders)
 		ids := make([]int64, 0, len(leaders))
 		addrs := make([]string, 0, len(leaders))
+		ranks := make([]int32, 0, len(leaders))
 
 		var channelErr error
 		if len(leaders) == 0 {
@@ -926,9 +945,12 @@ func (s *Server) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeade
 				continue
 			}
 
-			// Check whether segments are fully loaded
+			// Check whether segments are fully loaded,
+			// the segments of partitions not served by the replica of leader are skipped
+			replica := s.meta.ReplicaManager.GetByCollectionAndNode(leader.CollectionID, leader.ID)
 			for segmentID, info := range currentTargets {
-				if info.GetInsertChannel() != leader.Channel {
+				if info.GetInsertChannel() != leader.Channel ||
+					!s.meta.ReplicaServePartition(replica, info.GetPartitionID()) {
 					continue
 				}
 
@@ -946,6 +968,7 @@ func (s *Server) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeade
 
 			ids = append(ids, info.ID())
 			addrs = append(addrs, info.Addr())
+			ranks = append(ranks, s.meta.ReplicaManager.GetRank(replica.GetID()))
 		}
 
 		if len(ids) == 0 {
@@ -958,11 +981,13 @@ func (s *Server) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeade
 		}
 
 		resp.Shards = append(resp.Shards, &querypb.ShardLeadersList{
-			ChannelName: channel.GetChannelName(),
-			NodeIds:     ids,
-			NodeAddrs:   addrs,
+			ChannelName:  channel.GetChannelName(),
+			NodeIds:      ids,
+			NodeAddrs:    addrs,
+			ReplicaRanks: ranks,
 		})
 	}
+	resp.PartitionReplicaNumbers = s.meta.CollectionManager.GetPartitionReplicaNumbers(req.GetCollectionID())
 
 	return resp, nil
 }
@@ -1346,6 +1346,28 @@ func (suite *ServiceSuite) TestGetMetrics() {
 	suite.Equal(resp.GetStatus().GetCode(), merr.Code(merr.ErrServiceNotReady))
 }
 
+func (suite *ServiceSuite) TestGetResourceGroupUsages() {
+	suite.loadAll()
+	server := suite.server
+
+	nodes := []metricsinfo.QueryNodeInfos{
+		{BaseComponentInfos: metricsinfo.BaseComponentInfos{ID: suite.nodes[0], HardwareInfos: metricsinfo.HardwareMetrics{Memory: 100, MemoryUsage: 40, CPUCoreUsage: 0.2}}},
+		{BaseComponentInfos: metricsinfo.BaseComponentInfos{ID: suite.nodes[1], HardwareInfos: metricsinfo.HardwareMetrics{Memory: 100, MemoryUsage: 60, CPUCoreUsage: 0.4}}},
+	}
+	usages := server.getResourceGroupUsages(nodes)
+	suite.Len(usages, len(server.meta.ResourceManager.ListResourceGroups()))
+	for _, usage := range usages {
+		if usage.Name != meta.DefaultResourceGroupName {
+			continue
+		}
+		suite.Len(usage.Nodes, len(suite.nodes))
+		suite.Len(usage.Collections, len(suite.collections))
+		suite.EqualValues(200, usage.Memory)
+		suite.EqualValues(100, usage.MemoryUsage)
+		suite.InDelta(0.3, usage.CPUUsage, 1e-6)
+	}
+}
+
 func (suite *ServiceSuite) TestGetReplicas() {
 	suite.loadAll()
 	ctx := context.Background()
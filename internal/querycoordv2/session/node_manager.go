@@ -106,6 +106,8 @@ type NodeInfo struct {
 	mu            sync.RWMutex
 	id            int64
 	addr          string
+	zone          string
+	rack          string
 	state         State
 	lastHeartbeat *atomic.Int64
 }
@@ -118,6 +120,16 @@ func (n *NodeInfo) Addr() string {
 	return n.addr
 }
 
+// Zone returns the zone advertised by the node, empty if it's not labeled.
+func (n *NodeInfo) Zone() string {
+	return n.zone
+}
+
+// Rack returns the rack advertised by the node, empty if it's not labeled.
+func (n *NodeInfo) Rack() string {
+	return n.rack
+}
+
 func (n *NodeInfo) SegmentCnt() int {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -158,13 +170,27 @@ func (n *NodeInfo) UpdateStats(opts ...StatsOption) {
 	n.mu.Unlock()
 }
 
-func NewNodeInfo(id int64, addr string) *NodeInfo {
-	return &NodeInfo{
+func NewNodeInfo(id int64, addr string, opts ...NodeOption) *NodeInfo {
+	node := &NodeInfo{
 		stats:         newStats(),
 		id:            id,
 		addr:          addr,
 		lastHeartbeat: atomic.NewInt64(0),
 	}
+	for _, opt := range opts {
+		opt(node)
+	}
+	return node
+}
+
+// NodeOption sets the properties advertised in the session of the node.
+type NodeOption func(*NodeInfo)
+
+func WithFailureDomain(zone, rack string) NodeOption {
+	return func(n *NodeInfo) {
+		n.zone = zone
+		n.rack = rack
+	}
 }
 
 type StatsOption func(*NodeInfo)
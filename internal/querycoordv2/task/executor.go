@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
@@ -195,6 +196,8 @@ func (ex *Executor) loadSegment(task *SegmentTask, step int) error {
 		channel = ex.targetMgr.GetDmChannel(task.CollectionID(), segment.GetInsertChannel(), meta.NextTarget)
 	}
 	loadInfo := utils.PackSegmentLoadInfo(resp.GetInfos()[0], channel.GetSeekPosition(), indexes)
+	// the nodes serving the segment could share the disk index files with the loading node
+	loadInfo.IndexPeers = lo.Without(ex.dist.SegmentDistManager.GetSegmentDist(segment.GetID()), action.Node())
 
 	// Get collection index info
 	indexInfo, err := ex.broker.DescribeIndex(ctx, task.CollectionID())
@@ -212,6 +215,12 @@ func (ex *Executor) loadSegment(task *SegmentTask, step int) error {
 		loadInfo,
 		indexInfo,
 	)
+	if Params.QueryCoordCfg.EnableWarmupHints.GetAsBool() {
+		// ship the access stats of serving replicas, so that the node warms the hot data first
+		if hint := ex.dist.SegmentDistManager.GetWarmupHint(segment.GetID(), action.Node()); hint != nil {
+			req.WarmupHints = []*querypb.SegmentWarmupHint{hint}
+		}
+	}
 
 	// Get shard leader for the given replica and segment
 	leaderID, ok := getShardLeader(ex.meta.ReplicaManager, ex.dist, task.CollectionID(), action.Node(), segment.GetInsertChannel())
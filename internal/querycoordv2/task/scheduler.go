@@ -32,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/internal/util/eventbus"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
@@ -666,6 +667,9 @@ func (scheduler *taskScheduler) preProcess(task Task) bool {
 
 	if task.IsFinished(scheduler.distMgr) {
 		task.SetStatus(TaskStatusSucceeded)
+		if GetTaskType(task) == TaskTypeMove {
+			publishBalanceExecuted(task)
+		}
 	} else {
 		if err := scheduler.check(task); err != nil {
 			task.Cancel(err)
@@ -768,6 +772,31 @@ func (scheduler *taskScheduler) remove(task Task) {
 	log.Info("task removed")
 }
 
+// publishBalanceExecuted publishes the event once the move task is done,
+// the plan may be given up or fail, so it's not published when generating the plan.
+func publishBalanceExecuted(task Task) {
+	attributes := map[string]any{
+		"task_id":    task.ID(),
+		"replica_id": task.ReplicaID(),
+		"source":     task.Source().String(),
+	}
+	for _, action := range task.Actions() {
+		switch action.Type() {
+		case ActionTypeGrow:
+			attributes["to_node"] = action.Node()
+		case ActionTypeReduce:
+			attributes["from_node"] = action.Node()
+		}
+	}
+	switch task := task.(type) {
+	case *SegmentTask:
+		attributes["segment_id"] = task.SegmentID()
+	case *ChannelTask:
+		attributes["channel"] = task.Channel()
+	}
+	eventbus.Publish(eventbus.BalanceExecuted, task.CollectionID(), attributes)
+}
+
 func (scheduler *taskScheduler) checkStale(task Task) error {
 	log := log.With(
 		zap.Int64("taskID", task.ID()),
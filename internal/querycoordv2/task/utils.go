@@ -123,6 +123,30 @@ func packLoadSegmentRequest(
 		}
 	}
 
+	// the collection-level gpu residency applies to the vector fields without their own
+	if residency, ok := common.GetGPUResidency(collectionProperties...); ok {
+		for _, field := range schema.GetFields() {
+			if _, set := common.GetGPUResidency(field.GetTypeParams()...); !set && typeutil.IsVectorType(field.GetDataType()) {
+				field.TypeParams = append(field.TypeParams, &commonpb.KeyValuePair{
+					Key:   common.GPUResidencyKey,
+					Value: residency,
+				})
+			}
+		}
+	}
+
+	// the collection-level vector quantization applies to the vector fields without their own
+	if quantization, ok := common.GetVectorQuantization(collectionProperties...); ok {
+		for _, field := range schema.GetFields() {
+			if _, set := common.GetVectorQuantization(field.GetTypeParams()...); !set && typeutil.IsVectorType(field.GetDataType()) {
+				field.TypeParams = append(field.TypeParams, &commonpb.KeyValuePair{
+					Key:   common.VectorQuantizationKey,
+					Value: quantization,
+				})
+			}
+		}
+	}
+
 	return &querypb.LoadSegmentsRequest{
 		Base: commonpbutil.NewMsgBase(
 			commonpbutil.WithMsgType(commonpb.MsgType_LoadSegments),
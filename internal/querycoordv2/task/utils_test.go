@@ -192,6 +192,67 @@ func (s *UtilsSuite) TestPackLoadSegmentRequestMmap() {
 	}
 }
 
+func (s *UtilsSuite) TestPackLoadSegmentRequestGPUResidency() {
+	action := NewSegmentAction(1, ActionTypeGrow, "test-ch", 100)
+	task, err := NewSegmentTask(context.Background(), time.Second, nil, 1, 10, action)
+	s.NoError(err)
+
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, DataType: schemapb.DataType_FloatVector},
+			{FieldID: 102, DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{
+				{Key: common.GPUResidencyKey, Value: "lru"},
+			}},
+		},
+	}
+	req := packLoadSegmentRequest(task, action, schema,
+		[]*commonpb.KeyValuePair{{Key: common.GPUResidencyKey, Value: "pin"}},
+		&querypb.LoadMetaInfo{LoadType: querypb.LoadType_LoadCollection},
+		&querypb.SegmentLoadInfo{},
+		nil,
+	)
+
+	fields := req.GetSchema().GetFields()
+	_, ok := common.GetGPUResidency(fields[0].GetTypeParams()...)
+	s.False(ok)
+	residency, _ := common.GetGPUResidency(fields[1].GetTypeParams()...)
+	s.Equal("pin", residency)
+	residency, _ = common.GetGPUResidency(fields[2].GetTypeParams()...)
+	s.Equal("lru", residency)
+}
+
+func (s *UtilsSuite) TestPackLoadSegmentRequestVectorQuantization() {
+	action := NewSegmentAction(1, ActionTypeGrow, "test-ch", 100)
+	task, err := NewSegmentTask(context.Background(), time.Second, nil, 1, 10, action)
+	s.NoError(err)
+
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, DataType: schemapb.DataType_FloatVector},
+			{FieldID: 102, DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{
+				{Key: common.VectorQuantizationKey, Value: ""},
+			}},
+		},
+	}
+	req := packLoadSegmentRequest(task, action, schema,
+		[]*commonpb.KeyValuePair{{Key: common.VectorQuantizationKey, Value: common.VectorQuantizationSQ8}},
+		&querypb.LoadMetaInfo{LoadType: querypb.LoadType_LoadCollection},
+		&querypb.SegmentLoadInfo{},
+		nil,
+	)
+
+	fields := req.GetSchema().GetFields()
+	_, ok := common.GetVectorQuantization(fields[0].GetTypeParams()...)
+	s.False(ok)
+	quantization, _ := common.GetVectorQuantization(fields[1].GetTypeParams()...)
+	s.Equal(common.VectorQuantizationSQ8, quantization)
+	// the field opts out
+	quantization, _ = common.GetVectorQuantization(fields[2].GetTypeParams()...)
+	s.Empty(quantization)
+}
+
 func TestUtils(t *testing.T) {
 	suite.Run(t, new(UtilsSuite))
 }
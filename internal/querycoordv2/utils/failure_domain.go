@@ -0,0 +1,136 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	FailureDomainLevelZone = "zone"
+	FailureDomainLevelRack = "rack"
+
+	FailureDomainConstraintNone = "none"
+	FailureDomainConstraintSoft = "soft"
+	FailureDomainConstraintHard = "hard"
+)
+
+var ErrFailureDomainNotEnough = errors.New("failure domains not enough to spread the replicas")
+
+func failureDomainConstraint() string {
+	return strings.ToLower(params.Params.QueryCoordCfg.FailureDomainConstraint.GetValue())
+}
+
+// nodeFailureDomain returns the failure domain of the node at the configured level,
+// empty if the node doesn't advertise it.
+func nodeFailureDomain(m *meta.Meta, node int64) string {
+	info := m.ResourceManager.GetNodeInfo(node)
+	if info == nil {
+		return ""
+	}
+	if strings.ToLower(params.Params.QueryCoordCfg.FailureDomainLevel.GetValue()) == FailureDomainLevelRack {
+		if info.Rack() == "" {
+			return ""
+		}
+		return info.Zone() + "/" + info.Rack()
+	}
+	return info.Zone()
+}
+
+// groupNodesByFailureDomain groups the nodes by failure domain, the largest group first,
+// each node not labeled is a group itself since it's unknown which nodes fail together.
+func groupNodesByFailureDomain(m *meta.Meta, nodes []int64) [][]int64 {
+	groups := make([][]int64, 0)
+	index := make(map[string]int)
+	for _, node := range nodes {
+		domain := nodeFailureDomain(m, node)
+		if domain == "" {
+			groups = append(groups, []int64{node})
+			continue
+		}
+		i, ok := index[domain]
+		if !ok {
+			i = len(groups)
+			index[domain] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], node)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i]) > len(groups[j])
+	})
+	return groups
+}
+
+// spreadNodesAcrossFailureDomains assigns the nodes to the replicas such that no failure domain
+// serves more than one replica, so a shard keeps serving after any failure domain is down.
+// If the failure domains are fewer than the replicas, it fails with the hard constraint,
+// and assigns the nodes round-robin with the soft one.
+func spreadNodesAcrossFailureDomains(m *meta.Meta, nodes []int64, replicas []*meta.Replica) error {
+	groups := groupNodesByFailureDomain(m, nodes)
+	if len(groups) < len(replicas) {
+		if failureDomainConstraint() == FailureDomainConstraintHard {
+			return errors.Wrapf(ErrFailureDomainNotEnough, "%d failure domains for %d replicas", len(groups), len(replicas))
+		}
+		for i, node := range lo.Flatten(groups) {
+			replicas[i%len(replicas)].AddNode(node)
+		}
+		return nil
+	}
+
+	// assign the largest failure domain to the replica with the fewest nodes first
+	counts := make([]int, len(replicas))
+	for _, group := range groups {
+		target := 0
+		for i := range replicas {
+			if counts[i] < counts[target] {
+				target = i
+			}
+		}
+		replicas[target].AddNode(group...)
+		counts[target] += len(group)
+	}
+	return nil
+}
+
+// CountFailureDomainViolations returns the number of the failure domains serving more than one of the replicas.
+func CountFailureDomainViolations(m *meta.Meta, replicas []*meta.Replica) int {
+	owners := make(map[string]typeutil.Set[int64])
+	for _, replica := range replicas {
+		for _, node := range replica.GetNodes() {
+			domain := nodeFailureDomain(m, node)
+			if domain == "" {
+				continue
+			}
+			if owners[domain] == nil {
+				owners[domain] = typeutil.NewSet[int64]()
+			}
+			owners[domain].Insert(replica.GetID())
+		}
+	}
+	return lo.CountBy(lo.Values(owners), func(set typeutil.Set[int64]) bool {
+		return set.Len() > 1
+	})
+}
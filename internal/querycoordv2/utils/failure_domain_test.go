@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func newFailureDomainMeta(t *testing.T, zones map[int64]string) (*meta.Meta, *session.NodeManager) {
+	store := mocks.NewQueryCoordCatalog(t)
+	store.EXPECT().SaveResourceGroup(mock.Anything).Return(nil).Maybe()
+	store.EXPECT().SaveReplica(mock.Anything).Return(nil).Maybe()
+	nodeMgr := session.NewNodeManager()
+	m := meta.NewMeta(RandomIncrementIDAllocator(), store, nodeMgr)
+	for node, zone := range zones {
+		nodeMgr.Add(session.NewNodeInfo(node, "localhost", session.WithFailureDomain(zone, "")))
+		m.ResourceManager.AssignNode(meta.DefaultResourceGroupName, node)
+	}
+	return m, nodeMgr
+}
+
+func newFailureDomainReplicas(num int) []*meta.Replica {
+	replicas := make([]*meta.Replica, 0, num)
+	for i := 0; i < num; i++ {
+		replicas = append(replicas, meta.NewReplica(&querypb.Replica{
+			ID:            int64(i + 1),
+			CollectionID:  1,
+			ResourceGroup: meta.DefaultResourceGroupName,
+		}, typeutil.NewUniqueSet()))
+	}
+	return replicas
+}
+
+func TestAssignNodesAcrossFailureDomains(t *testing.T) {
+	paramtable.Init()
+	defer paramtable.Get().Reset(Params.QueryCoordCfg.FailureDomainConstraint.Key)
+	zones := map[int64]string{1: "a", 2: "a", 3: "a", 4: "b", 5: "b", 6: "c"}
+
+	t.Run("hard", func(t *testing.T) {
+		paramtable.Get().Save(Params.QueryCoordCfg.FailureDomainConstraint.Key, FailureDomainConstraintHard)
+		m, nodeMgr := newFailureDomainMeta(t, zones)
+		replicas := newFailureDomainReplicas(3)
+		assert.NoError(t, AssignNodesToReplicas(m, meta.DefaultResourceGroupName, replicas...))
+		for _, replica := range replicas {
+			assert.NotZero(t, replica.Len())
+		}
+		assert.Equal(t, 0, CountFailureDomainViolations(m, replicas))
+
+		// the node joining later is assigned to the replica of its zone, though the others have fewer nodes
+		assert.NoError(t, m.ReplicaManager.Put(replicas...))
+		nodeMgr.Add(session.NewNodeInfo(7, "localhost", session.WithFailureDomain("a", "")))
+		AddNodesToReplicas(m, m.ReplicaManager.GetByCollection(1), 7)
+		assert.Equal(t, 0, CountFailureDomainViolations(m, m.ReplicaManager.GetByCollection(1)))
+		assert.Equal(t, 4, m.ReplicaManager.GetByCollectionAndNode(1, 7).Len())
+
+		err := AssignNodesToReplicas(m, meta.DefaultResourceGroupName, newFailureDomainReplicas(4)...)
+		assert.ErrorIs(t, err, ErrFailureDomainNotEnough)
+	})
+
+	t.Run("soft", func(t *testing.T) {
+		paramtable.Get().Save(Params.QueryCoordCfg.FailureDomainConstraint.Key, FailureDomainConstraintSoft)
+		m, _ := newFailureDomainMeta(t, zones)
+		replicas := newFailureDomainReplicas(4)
+		assert.NoError(t, AssignNodesToReplicas(m, meta.DefaultResourceGroupName, replicas...))
+		for _, replica := range replicas {
+			assert.NotZero(t, replica.Len())
+		}
+		assert.NotZero(t, CountFailureDomainViolations(m, replicas))
+	})
+}
@@ -138,6 +138,13 @@ func AssignNodesToReplicas(m *meta.Meta, rgName string, replicas ...*meta.Replic
 	log.Info("assign nodes to replicas",
 		zap.Int64s("nodes", nodeGroup),
 	)
+	if failureDomainConstraint() != FailureDomainConstraintNone {
+		err := spreadNodesAcrossFailureDomains(m, nodeGroup, replicas)
+		if err != nil {
+			log.Warn("failed to spread replicas across failure domains", zap.Error(err))
+		}
+		return err
+	}
 	for i, node := range nodeGroup {
 		replicas[i%len(replicas)].AddNode(node)
 	}
@@ -163,6 +170,17 @@ func AddNodesToReplicas(m *meta.Meta, replicas []*meta.Replica, node int64) {
 	if len(replicas) == 0 {
 		return
 	}
+	// keep the failure domain serving a single replica
+	if domain := nodeFailureDomain(m, node); domain != "" && failureDomainConstraint() != FailureDomainConstraintNone {
+		sameDomain := lo.Filter(replicas, func(replica *meta.Replica, _ int) bool {
+			return lo.ContainsBy(replica.GetNodes(), func(n int64) bool {
+				return nodeFailureDomain(m, n) == domain
+			})
+		})
+		if len(sameDomain) > 0 {
+			replicas = sameDomain
+		}
+	}
 	sort.Slice(replicas, func(i, j int) bool {
 		return replicas[i].Len() < replicas[j].Len()
 	})
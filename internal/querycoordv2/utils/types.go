@@ -26,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
@@ -94,7 +95,8 @@ func calculateSegmentSize(segmentLoadInfo *querypb.SegmentLoadInfo) int64 {
 
 	fieldIndex := make(map[int64]*querypb.FieldIndexInfo)
 	for _, index := range segmentLoadInfo.IndexInfos {
-		if index.EnableIndex {
+		// json key stats are not an index of json field, the field data is still loaded
+		if index.EnableIndex && !jsonkey.IsStats(index.GetIndexParams()) {
 			fieldID := index.FieldID
 			fieldIndex[fieldID] = index
 		}
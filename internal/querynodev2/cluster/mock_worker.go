@@ -69,6 +69,61 @@ func (_c *MockWorker_Delete_Call) RunAndReturn(run func(context.Context, *queryp
 	return _c
 }
 
+// FetchIndexFile provides a mock function with given fields: ctx, req
+func (_m *MockWorker) FetchIndexFile(ctx context.Context, req *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *querypb.FetchIndexFileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *querypb.FetchIndexFileRequest) *querypb.FetchIndexFileResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querypb.FetchIndexFileResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *querypb.FetchIndexFileRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorker_FetchIndexFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchIndexFile'
+type MockWorker_FetchIndexFile_Call struct {
+	*mock.Call
+}
+
+// FetchIndexFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *querypb.FetchIndexFileRequest
+func (_e *MockWorker_Expecter) FetchIndexFile(ctx interface{}, req interface{}) *MockWorker_FetchIndexFile_Call {
+	return &MockWorker_FetchIndexFile_Call{Call: _e.mock.On("FetchIndexFile", ctx, req)}
+}
+
+func (_c *MockWorker_FetchIndexFile_Call) Run(run func(ctx context.Context, req *querypb.FetchIndexFileRequest)) *MockWorker_FetchIndexFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*querypb.FetchIndexFileRequest))
+	})
+	return _c
+}
+
+func (_c *MockWorker_FetchIndexFile_Call) Return(_a0 *querypb.FetchIndexFileResponse, _a1 error) *MockWorker_FetchIndexFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorker_FetchIndexFile_Call) RunAndReturn(run func(context.Context, *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error)) *MockWorker_FetchIndexFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetStatistics provides a mock function with given fields: ctx, req
 func (_m *MockWorker) GetStatistics(ctx context.Context, req *querypb.GetStatisticsRequest) (*internalpb.GetStatisticsResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -43,6 +43,7 @@ type Worker interface {
 	QuerySegments(ctx context.Context, req *querypb.QueryRequest) (*internalpb.RetrieveResults, error)
 	QueryStreamSegments(ctx context.Context, req *querypb.QueryRequest, srv streamrpc.QueryStreamServer) error
 	GetStatistics(ctx context.Context, req *querypb.GetStatisticsRequest) (*internalpb.GetStatisticsResponse, error)
+	FetchIndexFile(ctx context.Context, req *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error)
 
 	IsHealthy() bool
 	Stop()
@@ -172,6 +173,10 @@ func (w *remoteWorker) GetStatistics(ctx context.Context, req *querypb.GetStatis
 	return w.client.GetStatistics(ctx, req)
 }
 
+func (w *remoteWorker) FetchIndexFile(ctx context.Context, req *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error) {
+	return w.client.FetchIndexFile(ctx, req)
+}
+
 func (w *remoteWorker) IsHealthy() bool {
 	return true
 }
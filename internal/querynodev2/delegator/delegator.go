@@ -203,7 +203,7 @@ func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest
 
 	// wait tsafe
 	waitTr := timerecord.NewTimeRecorder("wait tSafe")
-	err := sd.waitTSafe(ctx, req.Req.GuaranteeTimestamp)
+	err := sd.waitTSafe(ctx, req.Req.GuaranteeTimestamp, allowStaleRead(req.GetReq().GetConsistencyLevel()))
 	if err != nil {
 		log.Warn("delegator search failed to wait tsafe", zap.Error(err))
 		return nil, err
@@ -239,6 +239,15 @@ func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest
 		return nil, err
 	}
 
+	twoPhase := sd.searchInTwoPhases(sealedNum)
+	if twoPhase {
+		sealed, err = sd.selectCandidateSegments(ctx, req, sealed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tr := timerecord.NewTimeRecorder("search")
 	tasks, err := organizeSubTask(ctx, req, sealed, growing, sd, sd.modifySearchRequest)
 	if err != nil {
 		log.Warn("Search organizeSubTask failed", zap.Error(err))
@@ -252,6 +261,9 @@ func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest
 		log.Warn("Delegator search failed", zap.Error(err))
 		return nil, err
 	}
+	if twoPhase {
+		observeRefineSearch(tr)
+	}
 
 	log.Debug("Delegator search done")
 
@@ -278,7 +290,7 @@ func (sd *shardDelegator) QueryStream(ctx context.Context, req *querypb.QueryReq
 
 	// wait tsafe
 	waitTr := timerecord.NewTimeRecorder("wait tSafe")
-	err := sd.waitTSafe(ctx, req.Req.GuaranteeTimestamp)
+	err := sd.waitTSafe(ctx, req.Req.GuaranteeTimestamp, allowStaleRead(req.GetReq().GetConsistencyLevel()))
 	if err != nil {
 		log.Warn("delegator query failed to wait tsafe", zap.Error(err))
 		return err
@@ -346,7 +358,7 @@ func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest)
 
 	// wait tsafe
 	waitTr := timerecord.NewTimeRecorder("wait tSafe")
-	err := sd.waitTSafe(ctx, req.Req.GuaranteeTimestamp)
+	err := sd.waitTSafe(ctx, req.Req.GuaranteeTimestamp, allowStaleRead(req.GetReq().GetConsistencyLevel()))
 	if err != nil {
 		log.Warn("delegator query failed to wait tsafe", zap.Error(err))
 		return nil, err
@@ -409,7 +421,7 @@ func (sd *shardDelegator) GetStatistics(ctx context.Context, req *querypb.GetSta
 	}
 
 	// wait tsafe
-	err := sd.waitTSafe(ctx, req.Req.GuaranteeTimestamp)
+	err := sd.waitTSafe(ctx, req.Req.GuaranteeTimestamp, false)
 	if err != nil {
 		log.Warn("delegator GetStatistics failed to wait tsafe", zap.Error(err))
 		return nil, err
@@ -509,10 +521,7 @@ func executeSubTasks[T any, R interface {
 	for _, task := range tasks {
 		go func(task subTask[T]) {
 			defer wg.Done()
-			result, err := execute(ctx, task.req, task.worker)
-			if result.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
-				err = fmt.Errorf("worker(%d) query failed: %s", task.targetID, result.GetStatus().GetReason())
-			}
+			result, err := executeSubTask(ctx, task, execute, taskType, log)
 			if err != nil {
 				log.Warn("failed to execute sub task",
 					zap.String("taskType", taskType),
@@ -549,8 +558,55 @@ func executeSubTasks[T any, R interface {
 	return results, nil
 }
 
+// executeSubTask executes the sub task on the worker, and retries if it fails by the transient segcore errors,
+// e.g. out of memory or the index not loaded yet, which may succeed later on the same worker.
+// The typed error of the worker is kept, so that the caller may tell whether to retry on other replicas.
+func executeSubTask[T any, R interface {
+	GetStatus() *commonpb.Status
+}](ctx context.Context, task subTask[T], execute func(context.Context, T, cluster.Worker) (R, error), taskType string, log *log.MLogger) (R, error) {
+	attempts := paramtable.Get().QueryNodeCfg.SubTaskRetryAttempts.GetAsInt()
+	interval := paramtable.Get().QueryNodeCfg.SubTaskRetryInterval.GetAsDuration(time.Millisecond)
+	for i := 1; ; i++ {
+		result, err := execute(ctx, task.req, task.worker)
+		if err == nil {
+			err = merr.Error(result.GetStatus())
+		}
+		if err == nil {
+			return result, nil
+		}
+		err = errors.Wrapf(err, "worker(%d) query failed", task.targetID)
+		if i >= attempts || !isTransientSegcoreErr(err) {
+			return result, err
+		}
+		log.Warn("sub task failed by transient segcore error, retry later",
+			zap.String("taskType", taskType),
+			zap.Int64("nodeID", task.targetID),
+			zap.Int("attempt", i),
+			zap.Error(err),
+		)
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(interval):
+		}
+	}
+}
+
+// isTransientSegcoreErr returns whether the segcore error may disappear by retrying later.
+func isTransientSegcoreErr(err error) bool {
+	return errors.Is(err, merr.ErrSegcoreOutOfMemory) || errors.Is(err, merr.ErrSegcoreFieldNotLoaded)
+}
+
+// allowStaleRead returns whether the request accepts the data older than the guarantee ts,
+// only the bounded and eventually consistency reads are served by the loaded data in degraded mode.
+func allowStaleRead(level commonpb.ConsistencyLevel) bool {
+	return level == commonpb.ConsistencyLevel_Bounded || level == commonpb.ConsistencyLevel_Eventually
+}
+
 // waitTSafe returns when tsafe listener notifies a timestamp which meet the guarantee ts.
-func (sd *shardDelegator) waitTSafe(ctx context.Context, ts uint64) error {
+// If the tsafe is stale in degraded mode, which happens when the message queue is unavailable,
+// it returns immediately, with nil if the request allows stale read, otherwise ErrServiceUnavailable.
+func (sd *shardDelegator) waitTSafe(ctx context.Context, ts uint64, allowStale bool) error {
 	log := sd.getLogger(ctx)
 	// already safe to search
 	if sd.latestTsafe.Load() >= ts {
@@ -560,6 +616,26 @@ func (sd *shardDelegator) waitTSafe(ctx context.Context, ts uint64) error {
 	st, _ := tsoutil.ParseTS(sd.latestTsafe.Load())
 	gt, _ := tsoutil.ParseTS(ts)
 	lag := gt.Sub(st)
+	if paramtable.Get().CommonCfg.DegradedModeEnable.GetAsBool() {
+		staleThreshold := paramtable.Get().CommonCfg.DegradedModeTSafeStaleThreshold.GetAsDuration(time.Second)
+		if stale := time.Since(st); stale > staleThreshold {
+			if !allowStale {
+				log.RatedWarn(10, "tsafe is stale, reject the read requiring fresh data",
+					zap.Time("guaranteeTime", gt),
+					zap.Time("serviceableTime", st),
+					zap.Duration("stale", stale),
+				)
+				return merr.WrapErrServiceUnavailable(fmt.Sprintf("tsafe stale for %v", stale), "degraded mode")
+			}
+			log.RatedWarn(10, "tsafe is stale, serve by the loaded data without waiting",
+				zap.Time("guaranteeTime", gt),
+				zap.Time("serviceableTime", st),
+				zap.Duration("stale", stale),
+			)
+			metrics.QueryNodeDegradedReadCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Inc()
+			return nil
+		}
+	}
 	maxLag := paramtable.Get().QueryNodeCfg.MaxTimestampLag.GetAsDuration(time.Second)
 	if lag > maxLag {
 		log.Warn("guarantee and serviceable ts larger than MaxLag",
@@ -550,7 +550,7 @@ func (sd *shardDelegator) GenerateLevel0DeletionCache() {
 	}
 	metrics.QueryNodeLevelZeroSize.WithLabelValues(
 		fmt.Sprint(paramtable.GetNodeID()),
-		fmt.Sprint(sd.collectionID),
+		metrics.CollectionIDLabel(sd.collectionID),
 		sd.vchannelName,
 	).Set(float64(totalSize))
 	sd.level0Deletions = deletions
@@ -41,12 +41,14 @@ import (
 	"github.com/milvus-io/milvus/internal/querynodev2/tsafe"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/util/lifetime"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metric"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
 )
 
 type DelegatorSuite struct {
@@ -1180,3 +1182,72 @@ func TestDelegatorTSafeListenerClosed(t *testing.T) {
 	assert.Equal(t, sd.Serviceable(), false)
 	assert.Equal(t, sd.Stopped(), true)
 }
+
+func TestDelegatorWaitTSafeDegraded(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.CommonCfg.DegradedModeEnable.Key, "true")
+	params.Save(params.CommonCfg.DegradedModeTSafeStaleThreshold.Key, "1")
+	defer params.Reset(params.CommonCfg.DegradedModeEnable.Key)
+	defer params.Reset(params.CommonCfg.DegradedModeTSafeStaleThreshold.Key)
+
+	sd := &shardDelegator{
+		vchannelName: "default_dml_channel",
+		lifetime:     lifetime.NewLifetime(lifetime.Initializing),
+		latestTsafe:  atomic.NewUint64(tsoutil.ComposeTSByTime(time.Now().Add(-time.Minute), 0)),
+	}
+	sd.tsCond = sync.NewCond(&sync.Mutex{})
+	ts := tsoutil.ComposeTSByTime(time.Now(), 0)
+
+	// strong consistency read is rejected
+	err := sd.waitTSafe(context.Background(), ts, allowStaleRead(commonpb.ConsistencyLevel_Strong))
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+
+	// bounded and eventually consistency read are served by the loaded data
+	assert.NoError(t, sd.waitTSafe(context.Background(), ts, allowStaleRead(commonpb.ConsistencyLevel_Bounded)))
+	assert.NoError(t, sd.waitTSafe(context.Background(), ts, allowStaleRead(commonpb.ConsistencyLevel_Eventually)))
+
+	// not stale, wait tsafe as usual
+	sd.latestTsafe.Store(ts)
+	assert.NoError(t, sd.waitTSafe(context.Background(), ts, false))
+}
+
+func TestDelegatorExecuteSubTaskRetry(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.SubTaskRetryAttempts.Key, "3")
+	params.Save(params.QueryNodeCfg.SubTaskRetryInterval.Key, "1")
+	defer params.Reset(params.QueryNodeCfg.SubTaskRetryAttempts.Key)
+	defer params.Reset(params.QueryNodeCfg.SubTaskRetryInterval.Key)
+
+	task := subTask[*querypb.QueryRequest]{req: &querypb.QueryRequest{}, targetID: 1}
+	execute := func(errs ...error) (func(context.Context, *querypb.QueryRequest, cluster.Worker) (*internalpb.RetrieveResults, error), *atomic.Int32) {
+		calls := atomic.NewInt32(0)
+		return func(ctx context.Context, req *querypb.QueryRequest, worker cluster.Worker) (*internalpb.RetrieveResults, error) {
+			i := int(calls.Inc()) - 1
+			if i < len(errs) {
+				return &internalpb.RetrieveResults{Status: merr.Status(errs[i])}, nil
+			}
+			return &internalpb.RetrieveResults{Status: merr.Success()}, nil
+		}, calls
+	}
+	logger := log.With()
+
+	// the transient segcore errors are retried
+	fn, calls := execute(merr.WrapErrSegcoreOutOfMemory(2029, "search failed"), merr.WrapErrSegcoreFieldNotLoaded(2027, "search failed"))
+	_, err := executeSubTask(context.Background(), task, fn, "Query", logger)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, calls.Load())
+
+	// the attempts run out
+	fn, calls = execute(merr.ErrSegcoreOutOfMemory, merr.ErrSegcoreOutOfMemory, merr.ErrSegcoreOutOfMemory)
+	_, err = executeSubTask(context.Background(), task, fn, "Query", logger)
+	assert.ErrorIs(t, err, merr.ErrSegcoreOutOfMemory)
+	assert.EqualValues(t, 3, calls.Load())
+
+	// the other errors are returned at once, with the typed error kept
+	fn, calls = execute(merr.WrapErrSegcorePlanInvalid(2028, "search failed"))
+	_, err = executeSubTask(context.Background(), task, fn, "Query", logger)
+	assert.ErrorIs(t, err, merr.ErrSegcorePlanInvalid)
+	assert.EqualValues(t, 1, calls.Load())
+}
@@ -0,0 +1,202 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querynodev2/cluster"
+	"github.com/milvus-io/milvus/internal/querynodev2/pkoracle"
+	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const radiusKey = "radius"
+
+// coarseSearchParams are the search params relaxed by the coarse phase,
+// the value is whether the param mustn't be less than the topk.
+var coarseSearchParams = map[string]bool{
+	"nprobe":      false,
+	"ef":          true,
+	"search_list": true,
+	"itopk_size":  true,
+}
+
+// searchInTwoPhases returns whether to search the sealed segments in two phases,
+// it only pays off if there are enough sealed segments.
+func (sd *shardDelegator) searchInTwoPhases(sealedNum int) bool {
+	params := paramtable.Get().QueryNodeCfg
+	return params.TwoPhaseSearchEnabled.GetAsBool() &&
+		sealedNum >= params.TwoPhaseSearchMinSealedSegments.GetAsInt()
+}
+
+// selectCandidateSegments runs the coarse phase of the two-phase search,
+// which searches all the sealed segments with the relaxed params and the enlarged topk,
+// then returns only the sealed segments which the coarse results may belong to according to the pk oracle,
+// so the refine phase searches them with the full params.
+// The sealed segments are returned as they are if the request can't be searched in two phases, e.g. range search.
+func (sd *shardDelegator) selectCandidateSegments(ctx context.Context, req *querypb.SearchRequest, sealed []SnapshotItem) ([]SnapshotItem, error) {
+	log := sd.getLogger(ctx)
+	coarseReq, ok := buildCoarseSearchRequest(req)
+	if !ok {
+		return sealed, nil
+	}
+
+	tr := timerecord.NewTimeRecorder("coarseSearch")
+	tasks, err := organizeSubTask(ctx, coarseReq, sealed, nil, sd, sd.modifySearchRequest)
+	if err != nil {
+		log.Warn("coarse search organizeSubTask failed", zap.Error(err))
+		return nil, err
+	}
+	results, err := executeSubTasks(ctx, tasks, func(ctx context.Context, req *querypb.SearchRequest, worker cluster.Worker) (*internalpb.SearchResults, error) {
+		return worker.SearchSegments(ctx, req)
+	}, "CoarseSearch", log)
+	if err != nil {
+		log.Warn("delegator coarse search failed", zap.Error(err))
+		return nil, err
+	}
+	resultData, err := segments.DecodeSearchResults(results)
+	if err != nil {
+		log.Warn("failed to decode coarse search results", zap.Error(err))
+		return nil, err
+	}
+
+	candidates := typeutil.NewUniqueSet()
+	for _, data := range resultData {
+		if data.GetIds() == nil {
+			continue
+		}
+		for _, pk := range storage.ParseIDs2PrimaryKeys(data.GetIds()) {
+			segmentIDs, err := sd.pkOracle.Get(pk, pkoracle.WithSegmentType(commonpb.SegmentState_Sealed))
+			if err != nil {
+				return nil, err
+			}
+			candidates.Insert(segmentIDs...)
+		}
+	}
+
+	var total, selected int
+	selectedItems := make([]SnapshotItem, 0, len(sealed))
+	for _, item := range sealed {
+		entries := lo.Filter(item.Segments, func(entry SegmentEntry, _ int) bool {
+			return candidates.Contain(entry.SegmentID)
+		})
+		total += len(item.Segments)
+		selected += len(entries)
+		if len(entries) > 0 {
+			selectedItems = append(selectedItems, SnapshotItem{NodeID: item.NodeID, Segments: entries})
+		}
+	}
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.QueryNodeTwoPhaseSearchLatency.WithLabelValues(nodeID, metrics.CoarseSearchPhase).
+		Observe(float64(tr.ElapseSpan().Milliseconds()))
+	if total > 0 {
+		metrics.QueryNodeTwoPhaseSearchCandidateRatio.WithLabelValues(nodeID).Observe(float64(selected) / float64(total))
+	}
+	log.Debug("coarse search done",
+		zap.Int("sealedNum", total),
+		zap.Int("candidateNum", selected),
+	)
+	return selectedItems, nil
+}
+
+// buildCoarseSearchRequest returns the request of the coarse phase,
+// of which the search params are relaxed by coarseParamRatio and the topk is enlarged by coarseTopKRatio.
+// It returns false if the request can't be searched in two phases.
+func buildCoarseSearchRequest(req *querypb.SearchRequest) (*querypb.SearchRequest, bool) {
+	plan := planpb.PlanNode{}
+	if err := proto.Unmarshal(req.GetReq().GetSerializedExprPlan(), &plan); err != nil {
+		return nil, false
+	}
+	queryInfo := plan.GetVectorAnns().GetQueryInfo()
+	if queryInfo == nil {
+		return nil, false
+	}
+	searchParams := make(map[string]any)
+	if queryInfo.GetSearchParams() != "" {
+		if err := json.Unmarshal([]byte(queryInfo.GetSearchParams()), &searchParams); err != nil {
+			return nil, false
+		}
+	}
+	// the results of range search aren't limited by the topk
+	if _, ok := searchParams[radiusKey]; ok {
+		return nil, false
+	}
+
+	params := paramtable.Get()
+	paramRatio := params.QueryNodeCfg.TwoPhaseSearchCoarseParamRatio.GetAsFloat()
+	if paramRatio <= 0 || paramRatio > 1 {
+		paramRatio = 1
+	}
+	topKRatio := math.Max(params.QueryNodeCfg.TwoPhaseSearchCoarseTopKRatio.GetAsFloat(), 1)
+	topK := int64(math.Ceil(float64(queryInfo.GetTopk()) * topKRatio))
+	if limit := params.QuotaConfig.TopKLimit.GetAsInt64(); topK > limit {
+		topK = limit
+	}
+	if topK < queryInfo.GetTopk() {
+		topK = queryInfo.GetTopk()
+	}
+
+	for key, atLeastTopK := range coarseSearchParams {
+		value, ok := searchParams[key].(float64)
+		if !ok {
+			continue
+		}
+		lowerBound := float64(1)
+		if atLeastTopK {
+			lowerBound = float64(topK)
+		}
+		searchParams[key] = int64(math.Max(math.Floor(value*paramRatio), lowerBound))
+	}
+	bs, err := json.Marshal(searchParams)
+	if err != nil {
+		return nil, false
+	}
+	queryInfo.Topk = topK
+	queryInfo.SearchParams = string(bs)
+	serializedPlan, err := proto.Marshal(&plan)
+	if err != nil {
+		return nil, false
+	}
+
+	coarseReq := proto.Clone(req).(*querypb.SearchRequest)
+	coarseReq.Req.SerializedExprPlan = serializedPlan
+	coarseReq.Req.Topk = topK
+	return coarseReq, true
+}
+
+// observeRefineSearch records the latency of the refine phase.
+func observeRefineSearch(tr *timerecord.TimeRecorder) {
+	metrics.QueryNodeTwoPhaseSearchLatency.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.RefineSearchPhase).
+		Observe(float64(tr.ElapseSpan().Milliseconds()))
+}
@@ -0,0 +1,112 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestSearchInTwoPhases(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	sd := &shardDelegator{}
+
+	assert.False(t, sd.searchInTwoPhases(100))
+
+	params.Save(params.QueryNodeCfg.TwoPhaseSearchEnabled.Key, "true")
+	defer params.Reset(params.QueryNodeCfg.TwoPhaseSearchEnabled.Key)
+	assert.True(t, sd.searchInTwoPhases(8))
+	assert.False(t, sd.searchInTwoPhases(7))
+}
+
+func TestBuildCoarseSearchRequest(t *testing.T) {
+	paramtable.Init()
+
+	newRequest := func(topk int64, searchParams string) *querypb.SearchRequest {
+		plan := &planpb.PlanNode{
+			Node: &planpb.PlanNode_VectorAnns{
+				VectorAnns: &planpb.VectorANNS{
+					QueryInfo: &planpb.QueryInfo{
+						Topk:         topk,
+						SearchParams: searchParams,
+					},
+				},
+			},
+		}
+		bs, err := proto.Marshal(plan)
+		require.NoError(t, err)
+		return &querypb.SearchRequest{
+			Req: &internalpb.SearchRequest{
+				SerializedExprPlan: bs,
+				Topk:               topk,
+			},
+		}
+	}
+	queryInfo := func(req *querypb.SearchRequest) *planpb.QueryInfo {
+		plan := &planpb.PlanNode{}
+		require.NoError(t, proto.Unmarshal(req.GetReq().GetSerializedExprPlan(), plan))
+		return plan.GetVectorAnns().GetQueryInfo()
+	}
+
+	t.Run("relax params", func(t *testing.T) {
+		req := newRequest(10, `{"nprobe": 64, "ef": 30, "level": 1}`)
+		coarseReq, ok := buildCoarseSearchRequest(req)
+		require.True(t, ok)
+		info := queryInfo(coarseReq)
+		assert.EqualValues(t, 20, info.GetTopk())
+		assert.EqualValues(t, 20, coarseReq.GetReq().GetTopk())
+		// ef mustn't be less than the topk
+		assert.JSONEq(t, `{"nprobe": 16, "ef": 20, "level": 1}`, info.GetSearchParams())
+
+		// the origin request is untouched
+		assert.EqualValues(t, 10, queryInfo(req).GetTopk())
+		assert.EqualValues(t, 10, req.GetReq().GetTopk())
+	})
+
+	t.Run("topk limit", func(t *testing.T) {
+		coarseReq, ok := buildCoarseSearchRequest(newRequest(16384, `{"nprobe": 2}`))
+		require.True(t, ok)
+		info := queryInfo(coarseReq)
+		assert.EqualValues(t, 16384, info.GetTopk())
+		assert.JSONEq(t, `{"nprobe": 1}`, info.GetSearchParams())
+	})
+
+	t.Run("range search", func(t *testing.T) {
+		_, ok := buildCoarseSearchRequest(newRequest(10, `{"nprobe": 64, "radius": 0.5}`))
+		assert.False(t, ok)
+	})
+
+	t.Run("not vector search", func(t *testing.T) {
+		bs, err := proto.Marshal(&planpb.PlanNode{
+			Node: &planpb.PlanNode_Predicates{Predicates: &planpb.Expr{}},
+		})
+		require.NoError(t, err)
+		_, ok := buildCoarseSearchRequest(&querypb.SearchRequest{
+			Req: &internalpb.SearchRequest{SerializedExprPlan: bs},
+		})
+		assert.False(t, ok)
+	})
+}
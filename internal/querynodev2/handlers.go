@@ -30,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querynodev2/delegator"
+	"github.com/milvus-io/milvus/internal/querynodev2/rerankers"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
 	"github.com/milvus-io/milvus/internal/querynodev2/tasks"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
@@ -386,6 +387,10 @@ func (node *QueryNode) searchChannel(ctx context.Context, req *querypb.SearchReq
 	if err != nil {
 		return nil, err
 	}
+	resp, err = rerankers.RerankSearchResults(ctx, req, resp, node.rerankHook)
+	if err != nil {
+		return nil, err
+	}
 
 	tr.CtxElapse(ctx, fmt.Sprintf("do search with channel done , vChannel = %s, segmentIDs = %v",
 		channel,
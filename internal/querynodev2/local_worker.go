@@ -69,6 +69,10 @@ func (w *LocalWorker) GetStatistics(ctx context.Context, req *querypb.GetStatist
 	return w.node.GetStatistics(ctx, req)
 }
 
+func (w *LocalWorker) FetchIndexFile(ctx context.Context, req *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error) {
+	return w.node.FetchIndexFile(ctx, req)
+}
+
 func (w *LocalWorker) IsHealthy() bool {
 	return true
 }
@@ -0,0 +1,69 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynodev2
+
+import (
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/http/tuning"
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// this file contains query node management restful API handler
+
+var mgrRouteRegisterOnce sync.Once
+
+func RegisterMgrRoute(node *QueryNode) {
+	mgrRouteRegisterOnce.Do(func() {
+		node.newTuner().Register()
+	})
+}
+
+// newTuner returns the tuner of the pool sizes and cache reservations of the query node,
+// the query node can't authenticate users, so the requests are authenticated by the management token.
+// The changes are persisted in etcd for auditing.
+func (node *QueryNode) newTuner() *tuning.Tuner {
+	params := paramtable.Get()
+	var store tuning.ChangeStore
+	if node.etcdCli != nil {
+		store = tuning.NewKVChangeStore(etcdkv.NewEtcdKV(node.etcdCli, params.EtcdCfg.MetaRootPath.GetValue()), typeutil.QueryNodeRole)
+	}
+	return tuning.NewTuner(typeutil.QueryNodeRole, tuning.TokenAuthenticator, store,
+		tuning.Knob{
+			Key: params.QueryNodeCfg.MaxReadConcurrency.Key,
+			Doc: "ratio of the read concurrency to cpu cores, which sizes the search/query pool with cgoPoolSizeRatio",
+		},
+		tuning.Knob{
+			Key: params.QueryNodeCfg.CGOPoolSizeRatio.Key,
+			Doc: "ratio of the search/query pool size to the read concurrency",
+		},
+		tuning.Knob{
+			Key: params.CommonCfg.MiddlePriorityThreadCoreCoefficient.Key,
+			Doc: "ratio of the load pool size to cpu cores",
+		},
+		tuning.Knob{
+			Key: params.QueryNodeCfg.MemoryGovernorChunkCacheReservedRatio.Key,
+			Doc: "ratio of the memory budget reserved for the chunk cache",
+		},
+		tuning.Knob{
+			Key: params.QueryNodeCfg.MemoryGovernorQueryBufferReservedRatio.Key,
+			Doc: "ratio of the memory budget reserved for the buffers of in-flight queries",
+		},
+	)
+}
@@ -0,0 +1,102 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynodev2
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querynodev2/delegator"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// releaseSegmentsForMemory releases the largest sealed segments on the query node until size bytes freed,
+// it's the L4 action of the memory watermark.
+//
+// Only the segments of the shards led by the query node are released, which are removed from the distribution
+// by the delegator first, so the searches are not routed to them, and querycoord loads them on other query nodes
+// as they're missing. The segments served by the delegators on other query nodes are left to querycoord.
+func (node *QueryNode) releaseSegmentsForMemory(size uint64) uint64 {
+	type candidate struct {
+		shard        string
+		collectionID int64
+		segmentID    int64
+		size         uint64
+	}
+	nodeID := paramtable.GetNodeID()
+	candidates := make([]candidate, 0)
+	node.delegators.Range(func(shard string, sd delegator.ShardDelegator) bool {
+		sealed, _ := sd.GetSegmentInfo(false)
+		for _, item := range sealed {
+			if item.NodeID != nodeID {
+				continue
+			}
+			for _, entry := range item.Segments {
+				segment := node.manager.Segment.GetSealed(entry.SegmentID)
+				if segment == nil {
+					continue
+				}
+				candidates = append(candidates, candidate{
+					shard:        shard,
+					collectionID: sd.Collection(),
+					segmentID:    entry.SegmentID,
+					size:         uint64(segment.MemSize()),
+				})
+			}
+		}
+		return true
+	})
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].size > candidates[j].size
+	})
+
+	freed := uint64(0)
+	for _, c := range candidates {
+		if freed >= size {
+			break
+		}
+		status, err := node.ReleaseSegments(node.ctx, &querypb.ReleaseSegmentsRequest{
+			Base:         commonpbutil.NewMsgBase(commonpbutil.WithTargetID(nodeID)),
+			NodeID:       nodeID,
+			CollectionID: c.collectionID,
+			Shard:        c.shard,
+			SegmentIDs:   []int64{c.segmentID},
+			Scope:        querypb.DataScope_Historical,
+			NeedTransfer: true,
+		})
+		if err := merr.CheckRPCCall(status, err); err != nil {
+			log.Warn("failed to release segment for memory watermark",
+				zap.Int64("collectionID", c.collectionID),
+				zap.String("shard", c.shard),
+				zap.Int64("segmentID", c.segmentID),
+				zap.Error(err))
+			continue
+		}
+		log.Warn("segment released for memory watermark",
+			zap.Int64("collectionID", c.collectionID),
+			zap.String("shard", c.shard),
+			zap.Int64("segmentID", c.segmentID),
+			zap.Uint64("size", c.size))
+		freed += c.size
+	}
+	return freed
+}
@@ -115,7 +115,7 @@ func getQuotaMetrics(node *QueryNode) (*metricsinfo.QueryNodeQuotaMetrics, error
 		})
 		totalGrowingSize += size
 		metrics.QueryNodeEntitiesSize.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()),
-			fmt.Sprint(collection), segments.SegmentTypeGrowing.String()).Set(float64(size))
+			metrics.CollectionIDLabel(collection), segments.SegmentTypeGrowing.String()).Set(float64(size))
 	}
 
 	sealedSegments := node.manager.Segment.GetBy(segments.WithType(segments.SegmentTypeSealed))
@@ -127,7 +127,7 @@ func getQuotaMetrics(node *QueryNode) (*metricsinfo.QueryNodeQuotaMetrics, error
 			return seg.MemSize()
 		})
 		metrics.QueryNodeEntitiesSize.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()),
-			fmt.Sprint(collection), segments.SegmentTypeSealed.String()).Set(float64(size))
+			metrics.CollectionIDLabel(collection), segments.SegmentTypeSealed.String()).Set(float64(size))
 	}
 
 	allSegments := node.manager.Segment.GetBy()
@@ -0,0 +1,235 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+)
+
+const genericValueName = "milvus.proto.plan.GenericValue"
+
+// newSearchRecord records the search request, the placeholder group is kept until the record is written,
+// where its shape is extracted off the search path.
+func newSearchRecord(req *querypb.SearchRequest, admitted time.Time, err error) *Record {
+	return &Record{
+		Type:             TypeSearch,
+		Time:             admitted.UnixMilli(),
+		LatencyMs:        time.Since(admitted).Milliseconds(),
+		Failed:           err != nil,
+		CollectionID:     req.GetReq().GetCollectionID(),
+		PartitionIDs:     req.GetReq().GetPartitionIDs(),
+		Channels:         req.GetDmlChannels(),
+		Plan:             req.GetReq().GetSerializedExprPlan(),
+		OutputFieldIDs:   req.GetReq().GetOutputFieldsId(),
+		ConsistencyLevel: req.GetReq().GetConsistencyLevel().String(),
+		IgnoreGrowing:    req.GetReq().GetIgnoreGrowing(),
+		Nq:               req.GetReq().GetNq(),
+		TopK:             req.GetReq().GetTopk(),
+		MetricType:       req.GetReq().GetMetricType(),
+		Vectors:          req.GetReq().GetPlaceholderGroup(),
+	}
+}
+
+func newQueryRecord(req *querypb.QueryRequest, admitted time.Time, err error) *Record {
+	return &Record{
+		Type:             TypeQuery,
+		Time:             admitted.UnixMilli(),
+		LatencyMs:        time.Since(admitted).Milliseconds(),
+		Failed:           err != nil,
+		CollectionID:     req.GetReq().GetCollectionID(),
+		PartitionIDs:     req.GetReq().GetPartitionIDs(),
+		Channels:         req.GetDmlChannels(),
+		Plan:             req.GetReq().GetSerializedExprPlan(),
+		OutputFieldIDs:   req.GetReq().GetOutputFieldsId(),
+		ConsistencyLevel: req.GetReq().GetConsistencyLevel().String(),
+		IgnoreGrowing:    req.GetReq().GetIgnoreGrowing(),
+		Limit:            req.GetReq().GetLimit(),
+		IsCount:          req.GetReq().GetIsCount(),
+	}
+}
+
+// SearchRequest returns the search request to replay the record on the target node,
+// the query vectors are generated randomly in the same shape if they are not recorded.
+func (rec *Record) SearchRequest(targetID int64) (*querypb.SearchRequest, error) {
+	if rec.Type != TypeSearch {
+		return nil, errors.Newf("record of %s is not a search", rec.Type)
+	}
+	placeholder := rec.Vectors
+	if len(placeholder) == 0 {
+		var err error
+		placeholder, err = randomPlaceholderGroup(rec.VectorType, rec.VectorBytes, rec.Nq)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &querypb.SearchRequest{
+		Req: &internalpb.SearchRequest{
+			Base: commonpbutil.NewMsgBase(
+				commonpbutil.WithMsgType(commonpb.MsgType_Search),
+				commonpbutil.WithTargetID(targetID),
+			),
+			CollectionID:       rec.CollectionID,
+			PartitionIDs:       rec.PartitionIDs,
+			PlaceholderGroup:   placeholder,
+			DslType:            commonpb.DslType_BoolExprV1,
+			SerializedExprPlan: rec.Plan,
+			OutputFieldsId:     rec.OutputFieldIDs,
+			Nq:                 rec.Nq,
+			Topk:               rec.TopK,
+			MetricType:         rec.MetricType,
+			IgnoreGrowing:      rec.IgnoreGrowing,
+			ConsistencyLevel:   commonpb.ConsistencyLevel(commonpb.ConsistencyLevel_value[rec.ConsistencyLevel]),
+		},
+		DmlChannels: rec.Channels,
+		Scope:       querypb.DataScope_All,
+	}, nil
+}
+
+// QueryRequest returns the query request to replay the record on the target node.
+func (rec *Record) QueryRequest(targetID int64) (*querypb.QueryRequest, error) {
+	if rec.Type != TypeQuery {
+		return nil, errors.Newf("record of %s is not a query", rec.Type)
+	}
+	return &querypb.QueryRequest{
+		Req: &internalpb.RetrieveRequest{
+			Base: commonpbutil.NewMsgBase(
+				commonpbutil.WithMsgType(commonpb.MsgType_Retrieve),
+				commonpbutil.WithTargetID(targetID),
+			),
+			CollectionID:       rec.CollectionID,
+			PartitionIDs:       rec.PartitionIDs,
+			SerializedExprPlan: rec.Plan,
+			OutputFieldsId:     rec.OutputFieldIDs,
+			Limit:              rec.Limit,
+			IsCount:            rec.IsCount,
+			IgnoreGrowing:      rec.IgnoreGrowing,
+			ConsistencyLevel:   commonpb.ConsistencyLevel(commonpb.ConsistencyLevel_value[rec.ConsistencyLevel]),
+		},
+		DmlChannels: rec.Channels,
+		Scope:       querypb.DataScope_All,
+	}, nil
+}
+
+// redactPlan replaces the string literals of the filters in the plan by their hashes,
+// the hashes of the same literals are the same, so the selectivity of the equality filters is kept.
+func redactPlan(plan []byte) ([]byte, error) {
+	node := &planpb.PlanNode{}
+	if err := proto.Unmarshal(plan, node); err != nil {
+		return nil, err
+	}
+	redactMessage(proto.MessageReflect(node))
+	return proto.Marshal(node)
+}
+
+func redactMessage(msg protoreflect.Message) {
+	if msg.Descriptor().FullName() == genericValueName {
+		field := msg.Descriptor().Fields().ByName("string_val")
+		if msg.Has(field) {
+			msg.Set(field, protoreflect.ValueOfString(redactLiteral(msg.Get(field).String())))
+		}
+	}
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsMap():
+			if field.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					redactMessage(v.Message())
+					return true
+				})
+			}
+		case field.IsList():
+			if field.Message() != nil {
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					redactMessage(list.Get(i).Message())
+				}
+			}
+		case field.Message() != nil:
+			redactMessage(value.Message())
+		}
+		return true
+	})
+}
+
+func redactLiteral(literal string) string {
+	sum := sha256.Sum256([]byte(literal))
+	return hex.EncodeToString(sum[:8])
+}
+
+// placeholderShape returns the type and the size in bytes of each query vector in the placeholder group.
+func placeholderShape(data []byte) (string, int, error) {
+	group := &commonpb.PlaceholderGroup{}
+	if err := proto.Unmarshal(data, group); err != nil {
+		return "", 0, err
+	}
+	if len(group.GetPlaceholders()) == 0 || len(group.GetPlaceholders()[0].GetValues()) == 0 {
+		return "", 0, errors.New("empty placeholder group")
+	}
+	placeholder := group.GetPlaceholders()[0]
+	return placeholder.GetType().String(), len(placeholder.GetValues()[0]), nil
+}
+
+// randomPlaceholderGroup generates the nq random query vectors of the type and size.
+func randomPlaceholderGroup(vectorType string, vectorBytes int, nq int64) ([]byte, error) {
+	placeholderType := commonpb.PlaceholderType(commonpb.PlaceholderType_value[vectorType])
+	if vectorBytes <= 0 || nq <= 0 {
+		return nil, errors.Newf("invalid shape of query vectors, %d bytes, nq %d", vectorBytes, nq)
+	}
+
+	values := make([][]byte, 0, nq)
+	for i := int64(0); i < nq; i++ {
+		value := make([]byte, vectorBytes)
+		switch placeholderType {
+		case commonpb.PlaceholderType_FloatVector:
+			for j := 0; j+4 <= vectorBytes; j += 4 {
+				binary.LittleEndian.PutUint32(value[j:], math.Float32bits(rand.Float32()))
+			}
+		case commonpb.PlaceholderType_Float16Vector:
+			// positive halfs in [0.125, 1), which are neither NaN nor Inf
+			for j := 0; j+2 <= vectorBytes; j += 2 {
+				binary.LittleEndian.PutUint16(value[j:], 0x3000|uint16(rand.Intn(0x0C00)))
+			}
+		case commonpb.PlaceholderType_BinaryVector:
+			rand.Read(value)
+		default:
+			return nil, errors.Newf("unsupported type of query vectors %s", vectorType)
+		}
+		values = append(values, value)
+	}
+	return proto.Marshal(&commonpb.PlaceholderGroup{
+		Placeholders: []*commonpb.PlaceholderValue{{
+			Tag:    "$0",
+			Type:   placeholderType,
+			Values: values,
+		}},
+	})
+}
@@ -0,0 +1,248 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const (
+	TypeSearch = "search"
+	TypeQuery  = "query"
+
+	recordQueueSize = 1024
+	flushInterval   = time.Second
+)
+
+// Record is a search/query request admitted by the query node, which is logged as a json line.
+type Record struct {
+	Type      string `json:"type"`
+	Time      int64  `json:"ts"` // unix milliseconds of the admission
+	LatencyMs int64  `json:"latency_ms"`
+	Failed    bool   `json:"failed,omitempty"`
+
+	CollectionID     int64    `json:"collection"`
+	PartitionIDs     []int64  `json:"partitions,omitempty"`
+	Channels         []string `json:"channels,omitempty"`
+	Plan             []byte   `json:"plan,omitempty"` // serialized plan, whose string literals may be redacted
+	OutputFieldIDs   []int64  `json:"output_fields,omitempty"`
+	ConsistencyLevel string   `json:"consistency,omitempty"`
+	IgnoreGrowing    bool     `json:"ignore_growing,omitempty"`
+
+	// search only
+	Nq          int64  `json:"nq,omitempty"`
+	TopK        int64  `json:"topk,omitempty"`
+	MetricType  string `json:"metric_type,omitempty"`
+	VectorType  string `json:"vector_type,omitempty"`
+	VectorBytes int    `json:"vector_bytes,omitempty"` // size of each query vector
+	Vectors     []byte `json:"vectors,omitempty"`      // serialized placeholder group, recorded only if enabled
+
+	// query only
+	Limit   int64 `json:"limit,omitempty"`
+	IsCount bool  `json:"is_count,omitempty"`
+}
+
+// Recorder samples the search/query requests admitted into a local log, which could be replayed offline
+// by the Replayer. The requests are logged asynchronously and dropped if the log can't keep up.
+type Recorder struct {
+	path    string
+	maxSize int64
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *Record
+
+	file *os.File
+	w    *bufio.Writer
+	size int64
+
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewRecorder returns the recorder logging into the configured path, the log is opened at the first request sampled.
+func NewRecorder() *Recorder {
+	params := paramtable.Get()
+	path := params.QueryNodeCfg.RequestRecorderPath.GetValue()
+	if path == "" {
+		path = filepath.Join(params.LocalStorageCfg.Path.GetValue(), "requests.log")
+	}
+	r := &Recorder{
+		path:    path,
+		maxSize: params.QueryNodeCfg.RequestRecorderMaxFileSize.GetAsInt64() * 1024 * 1024,
+		queue:   make(chan *Record, recordQueueSize),
+	}
+	r.wg.Add(1)
+	go r.loop()
+	return r
+}
+
+// Sample returns whether to record the request admitted.
+func (r *Recorder) Sample() bool {
+	if r == nil {
+		return false
+	}
+	rate := paramtable.Get().QueryNodeCfg.RequestRecorderSampleRate.GetAsFloat()
+	return rate > 0 && rand.Float64() < rate
+}
+
+// RecordSearch records the search request sampled, which is admitted at the time.
+func (r *Recorder) RecordSearch(req *querypb.SearchRequest, admitted time.Time, err error) {
+	r.add(newSearchRecord(req, admitted, err))
+}
+
+// RecordQuery records the query request sampled, which is admitted at the time.
+func (r *Recorder) RecordQuery(req *querypb.QueryRequest, admitted time.Time, err error) {
+	r.add(newQueryRecord(req, admitted, err))
+}
+
+func (r *Recorder) add(record *Record) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return
+	}
+	select {
+	case r.queue <- record:
+	default:
+		// drop the record instead of blocking the request
+	}
+}
+
+// Close flushes the records queued and closes the log.
+func (r *Recorder) Close() {
+	if r == nil {
+		return
+	}
+	r.closeOnce.Do(func() {
+		r.mu.Lock()
+		r.closed = true
+		close(r.queue)
+		r.mu.Unlock()
+		r.wg.Wait()
+	})
+}
+
+func (r *Recorder) loop() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	defer r.closeFile()
+
+	for {
+		select {
+		case record, ok := <-r.queue:
+			if !ok {
+				return
+			}
+			if err := r.write(record); err != nil {
+				log.Warn("failed to record the request", zap.String("path", r.path), zap.Error(err))
+			}
+		case <-ticker.C:
+			if r.w != nil {
+				r.w.Flush()
+			}
+		}
+	}
+}
+
+func (r *Recorder) write(record *Record) error {
+	params := paramtable.Get()
+	if params.QueryNodeCfg.RequestRecorderRedactLiterals.GetAsBool() && len(record.Plan) > 0 {
+		plan, err := redactPlan(record.Plan)
+		if err != nil {
+			return err
+		}
+		record.Plan = plan
+	}
+	if len(record.Vectors) > 0 {
+		vectorType, vectorBytes, err := placeholderShape(record.Vectors)
+		if err != nil {
+			return err
+		}
+		record.VectorType, record.VectorBytes = vectorType, vectorBytes
+		if !params.QueryNodeCfg.RequestRecorderRecordVectors.GetAsBool() {
+			record.Vectors = nil
+		}
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if r.file != nil && r.size+int64(len(line)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+	if r.file == nil {
+		if err := r.openFile(); err != nil {
+			return err
+		}
+	}
+	n, err := r.w.Write(line)
+	r.size += int64(n)
+	return err
+}
+
+func (r *Recorder) openFile() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file, r.w, r.size = file, bufio.NewWriter(file), info.Size()
+	return nil
+}
+
+// rotate renames the log to the backup file, which overwrites the previous backup.
+func (r *Recorder) rotate() error {
+	r.closeFile()
+	return os.Rename(r.path, r.path+".1")
+}
+
+func (r *Recorder) closeFile() {
+	if r.file == nil {
+		return
+	}
+	if err := r.w.Flush(); err != nil {
+		log.Warn("failed to flush the requests recorded", zap.String("path", r.path), zap.Error(err))
+	}
+	r.file.Close()
+	r.file, r.w, r.size = nil, nil, 0
+}
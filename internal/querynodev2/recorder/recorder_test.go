@@ -0,0 +1,229 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type RecorderSuite struct {
+	suite.Suite
+
+	path string
+	plan []byte
+}
+
+func (s *RecorderSuite) SetupSuite() {
+	paramtable.Init()
+	plan, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_Query{Query: &planpb.QueryPlanNode{
+			Predicates: &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+				ColumnInfo: &planpb.ColumnInfo{FieldId: 101, DataType: 21},
+				Op:         planpb.OpType_Equal,
+				Value:      &planpb.GenericValue{Val: &planpb.GenericValue_StringVal{StringVal: "alice"}},
+			}}},
+		}},
+	})
+	s.Require().NoError(err)
+	s.plan = plan
+}
+
+func (s *RecorderSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "requests.log")
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.RequestRecorderPath.Key, s.path)
+	params.Save(params.QueryNodeCfg.RequestRecorderSampleRate.Key, "1")
+}
+
+func (s *RecorderSuite) TearDownTest() {
+	params := paramtable.Get()
+	params.Reset(params.QueryNodeCfg.RequestRecorderPath.Key)
+	params.Reset(params.QueryNodeCfg.RequestRecorderSampleRate.Key)
+	params.Reset(params.QueryNodeCfg.RequestRecorderRecordVectors.Key)
+	params.Reset(params.QueryNodeCfg.RequestRecorderMaxFileSize.Key)
+}
+
+func (s *RecorderSuite) searchRequest() *querypb.SearchRequest {
+	placeholder, err := randomPlaceholderGroup(commonpb.PlaceholderType_FloatVector.String(), 32, 2)
+	s.Require().NoError(err)
+	return &querypb.SearchRequest{
+		Req: &internalpb.SearchRequest{
+			CollectionID:       1,
+			PlaceholderGroup:   placeholder,
+			SerializedExprPlan: s.plan,
+			Nq:                 2,
+			Topk:               10,
+			MetricType:         "L2",
+			ConsistencyLevel:   commonpb.ConsistencyLevel_Bounded,
+			Username:           "alice",
+		},
+		DmlChannels: []string{"channel"},
+	}
+}
+
+func (s *RecorderSuite) read() []*Record {
+	file, err := os.Open(s.path)
+	s.Require().NoError(err)
+	defer file.Close()
+	records, err := ReadRecords(file)
+	s.Require().NoError(err)
+	return records
+}
+
+func (s *RecorderSuite) TestRecord() {
+	r := NewRecorder()
+	s.True(r.Sample())
+	r.RecordSearch(s.searchRequest(), time.Now(), nil)
+	r.RecordQuery(&querypb.QueryRequest{
+		Req:         &internalpb.RetrieveRequest{CollectionID: 1, SerializedExprPlan: s.plan, Limit: 5},
+		DmlChannels: []string{"channel"},
+	}, time.Now(), nil)
+	r.Close()
+
+	records := s.read()
+	s.Require().Len(records, 2)
+	search := records[0]
+	s.Equal(TypeSearch, search.Type)
+	s.Equal(int64(2), search.Nq)
+	s.Equal(int64(10), search.TopK)
+	s.Equal(commonpb.ConsistencyLevel_Bounded.String(), search.ConsistencyLevel)
+	s.Equal(commonpb.PlaceholderType_FloatVector.String(), search.VectorType)
+	s.Equal(32, search.VectorBytes)
+	// the vectors and the literals are not recorded by default
+	s.Empty(search.Vectors)
+	plan := &planpb.PlanNode{}
+	s.NoError(proto.Unmarshal(search.Plan, plan))
+	literal := plan.GetQuery().GetPredicates().GetUnaryRangeExpr().GetValue().GetStringVal()
+	s.NotEqual("alice", literal)
+	s.Equal(redactLiteral("alice"), literal)
+	s.NotContains(string(search.Plan), "alice")
+
+	query := records[1]
+	s.Equal(TypeQuery, query.Type)
+	s.Equal(int64(5), query.Limit)
+}
+
+func (s *RecorderSuite) TestRecordVectors() {
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.RequestRecorderRecordVectors.Key, "true")
+	r := NewRecorder()
+	req := s.searchRequest()
+	r.RecordSearch(req, time.Now(), nil)
+	r.Close()
+
+	records := s.read()
+	s.Require().Len(records, 1)
+	s.Equal(req.GetReq().GetPlaceholderGroup(), records[0].Vectors)
+}
+
+func (s *RecorderSuite) TestRotate() {
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.RequestRecorderMaxFileSize.Key, "0")
+	r := NewRecorder()
+	r.RecordSearch(s.searchRequest(), time.Now(), nil)
+	r.RecordSearch(s.searchRequest(), time.Now(), nil)
+	r.Close()
+
+	s.Len(s.read(), 1)
+	_, err := os.Stat(s.path + ".1")
+	s.NoError(err)
+}
+
+func (s *RecorderSuite) TestSampleDisabled() {
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.RequestRecorderSampleRate.Key, "0")
+	r := NewRecorder()
+	defer r.Close()
+	s.False(r.Sample())
+
+	var nilRecorder *Recorder
+	s.False(nilRecorder.Sample())
+	nilRecorder.Close()
+}
+
+func (s *RecorderSuite) TestReplay() {
+	r := NewRecorder()
+	r.RecordSearch(s.searchRequest(), time.Now(), nil)
+	r.RecordQuery(&querypb.QueryRequest{
+		Req: &internalpb.RetrieveRequest{CollectionID: 1, Limit: 5},
+	}, time.Now().Add(10*time.Millisecond), nil)
+	r.Close()
+
+	searched, queried := 0, 0
+	replayer := &Replayer{
+		TargetID: 10,
+		Speed:    1,
+		Search: func(ctx context.Context, req *querypb.SearchRequest) error {
+			s.Equal(int64(10), req.GetReq().GetBase().GetTargetID())
+			s.Equal(int64(2), req.GetReq().GetNq())
+			s.Equal(commonpb.ConsistencyLevel_Bounded, req.GetReq().GetConsistencyLevel())
+			shape, size, err := placeholderShape(req.GetReq().GetPlaceholderGroup())
+			s.NoError(err)
+			s.Equal(commonpb.PlaceholderType_FloatVector.String(), shape)
+			s.Equal(32, size)
+			searched++
+			return nil
+		},
+		Query: func(ctx context.Context, req *querypb.QueryRequest) error {
+			s.Equal(int64(5), req.GetReq().GetLimit())
+			queried++
+			return nil
+		},
+	}
+	results, err := replayer.Replay(context.Background(), s.read())
+	s.NoError(err)
+	s.Len(results, 2)
+	for _, result := range results {
+		s.NoError(result.Err)
+	}
+	s.Equal(1, searched)
+	s.Equal(1, queried)
+}
+
+func TestRecorder(t *testing.T) {
+	suite.Run(t, new(RecorderSuite))
+}
+
+func TestRandomPlaceholderGroup(t *testing.T) {
+	for _, placeholderType := range []commonpb.PlaceholderType{
+		commonpb.PlaceholderType_FloatVector,
+		commonpb.PlaceholderType_Float16Vector,
+		commonpb.PlaceholderType_BinaryVector,
+	} {
+		data, err := randomPlaceholderGroup(placeholderType.String(), 16, 3)
+		assert.NoError(t, err)
+		group := &commonpb.PlaceholderGroup{}
+		assert.NoError(t, proto.Unmarshal(data, group))
+		assert.Len(t, group.GetPlaceholders()[0].GetValues(), 3)
+	}
+
+	_, err := randomPlaceholderGroup(commonpb.PlaceholderType_Int64.String(), 16, 3)
+	assert.Error(t, err)
+	_, err = randomPlaceholderGroup(commonpb.PlaceholderType_FloatVector.String(), 0, 3)
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+// ReadRecords reads the records logged by the recorder in order.
+func ReadRecords(r io.Reader) ([]*Record, error) {
+	records := make([]*Record, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// Replayer replays the records against a query node by the hooks, e.g. the client of the query node under test,
+// which keeps the arrival pattern of the requests recorded.
+type Replayer struct {
+	TargetID int64
+	// Speed scales the pace of the requests recorded, e.g. 2 replays twice as fast, 0 replays as fast as possible
+	Speed  float64
+	Search func(ctx context.Context, req *querypb.SearchRequest) error
+	Query  func(ctx context.Context, req *querypb.QueryRequest) error
+}
+
+// ReplayResult is the result of a request replayed, which could be compared to its record.
+type ReplayResult struct {
+	Record  *Record
+	Latency time.Duration
+	Err     error
+}
+
+// Replay issues the requests of the records at the recorded pace without waiting for the previous ones,
+// and returns the results in the order of the records.
+func (r *Replayer) Replay(ctx context.Context, records []*Record) ([]*ReplayResult, error) {
+	results := make([]*ReplayResult, len(records))
+	if len(records) == 0 {
+		return results, nil
+	}
+
+	start := time.Now()
+	first := records[0].Time
+	wg := sync.WaitGroup{}
+	for i, record := range records {
+		if r.Speed > 0 {
+			offset := time.Duration(float64(time.Duration(record.Time-first)*time.Millisecond) / r.Speed)
+			if wait := time.Until(start.Add(offset)); wait > 0 {
+				select {
+				case <-ctx.Done():
+					wg.Wait()
+					return nil, ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+		}
+
+		wg.Add(1)
+		go func(i int, record *Record) {
+			defer wg.Done()
+			begin := time.Now()
+			err := r.replay(ctx, record)
+			results[i] = &ReplayResult{Record: record, Latency: time.Since(begin), Err: err}
+		}(i, record)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func (r *Replayer) replay(ctx context.Context, record *Record) error {
+	switch record.Type {
+	case TypeSearch:
+		if r.Search == nil {
+			return errors.New("no search hook to replay")
+		}
+		req, err := record.SearchRequest(r.TargetID)
+		if err != nil {
+			return err
+		}
+		return r.Search(ctx, req)
+	case TypeQuery:
+		if r.Query == nil {
+			return errors.New("no query hook to replay")
+		}
+		req, err := record.QueryRequest(r.TargetID)
+		if err != nil {
+			return err
+		}
+		return r.Query(ctx, req)
+	default:
+		return errors.Newf("unknown record type %s", record.Type)
+	}
+}
@@ -0,0 +1,117 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rerankers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// RerankHook is the interface for the custom rescoring of the search hits,
+// it's loaded from the plugin and applied on the results reduced by the shard delegator.
+type RerankHook interface {
+	// Init the hook with the config.
+	Init(string) error
+	// Rerank rescores or filters the hits of the collection, the output fields are
+	// carried in the fields data. The hits returned must be the subset of the input ones,
+	// and the hits of each query must be still sorted by the score.
+	Rerank(ctx context.Context, collectionID int64, data *schemapb.SearchResultData) (*schemapb.SearchResultData, error)
+}
+
+// RerankSearchResults applies the rerank hook on the search results reduced by the shard delegator.
+func RerankSearchResults(ctx context.Context, req *querypb.SearchRequest, result *internalpb.SearchResults, hook RerankHook) (*internalpb.SearchResults, error) {
+	// no hook applied, just return
+	if hook == nil || result.GetSlicedBlob() == nil {
+		return result, nil
+	}
+
+	log := log.Ctx(ctx).With(zap.Int64("collection", req.GetReq().GetCollectionID()))
+
+	data := &schemapb.SearchResultData{}
+	if err := proto.Unmarshal(result.GetSlicedBlob(), data); err != nil {
+		log.Warn("failed to decode search results for rerank", zap.Error(err))
+		return nil, err
+	}
+
+	reranked, err := hook.Rerank(ctx, req.GetReq().GetCollectionID(), data)
+	if err != nil {
+		log.Warn("failed to rerank search results", zap.Error(err))
+		return nil, merr.WrapErrServiceInternal("failed to rerank search results", err.Error())
+	}
+	if err := checkReranked(data, reranked); err != nil {
+		log.Warn("invalid reranked search results", zap.Error(err))
+		return nil, merr.WrapErrServiceInternal("invalid reranked search results", err.Error())
+	}
+
+	blob, err := proto.Marshal(reranked)
+	if err != nil {
+		return nil, err
+	}
+	result.SlicedBlob = blob
+	return result, nil
+}
+
+// checkReranked checks the hits of each query reranked are the subset of the origin ones.
+func checkReranked(origin, reranked *schemapb.SearchResultData) error {
+	if reranked == nil {
+		return fmt.Errorf("nil results")
+	}
+	if len(reranked.GetTopks()) != len(origin.GetTopks()) {
+		return fmt.Errorf("query number mismatch, expected %d, got %d", len(origin.GetTopks()), len(reranked.GetTopks()))
+	}
+	size := typeutil.GetSizeOfIDs(reranked.GetIds())
+	if len(reranked.GetScores()) != size {
+		return fmt.Errorf("score number mismatch, expected %d, got %d", size, len(reranked.GetScores()))
+	}
+
+	var originOffset, offset int64
+	for i, topk := range reranked.GetTopks() {
+		originTopk := origin.GetTopks()[i]
+		if topk > originTopk {
+			return fmt.Errorf("hits of query %d increased from %d to %d", i, originTopk, topk)
+		}
+		if offset+topk > int64(size) {
+			return fmt.Errorf("hit number mismatch, got %d ids", size)
+		}
+		hits := make(map[any]struct{}, originTopk)
+		for j := originOffset; j < originOffset+originTopk; j++ {
+			hits[typeutil.GetPK(origin.GetIds(), j)] = struct{}{}
+		}
+		for j := offset; j < offset+topk; j++ {
+			pk := typeutil.GetPK(reranked.GetIds(), j)
+			if _, ok := hits[pk]; !ok {
+				return fmt.Errorf("hit %v of query %d not found in origin results", pk, i)
+			}
+		}
+		originOffset += originTopk
+		offset += topk
+	}
+	if offset != int64(size) {
+		return fmt.Errorf("hit number mismatch, expected %d, got %d", offset, size)
+	}
+	return nil
+}
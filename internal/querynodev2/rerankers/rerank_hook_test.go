@@ -0,0 +1,149 @@
+package rerankers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+type fakeRerankHook struct {
+	rerank func(data *schemapb.SearchResultData) (*schemapb.SearchResultData, error)
+}
+
+func (h *fakeRerankHook) Init(string) error {
+	return nil
+}
+
+func (h *fakeRerankHook) Rerank(ctx context.Context, collectionID int64, data *schemapb.SearchResultData) (*schemapb.SearchResultData, error) {
+	return h.rerank(data)
+}
+
+type RerankHookSuite struct {
+	suite.Suite
+
+	req    *querypb.SearchRequest
+	result *internalpb.SearchResults
+}
+
+func (suite *RerankHookSuite) SetupTest() {
+	suite.req = &querypb.SearchRequest{
+		Req: &internalpb.SearchRequest{
+			CollectionID: 100,
+			Nq:           2,
+			Topk:         2,
+		},
+	}
+	data := &schemapb.SearchResultData{
+		NumQueries: 2,
+		TopK:       2,
+		Scores:     []float32{0.9, 0.8, 0.7, 0.6},
+		Ids: &schemapb.IDs{
+			IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3, 4}}},
+		},
+		Topks: []int64{2, 2},
+	}
+	blob, err := proto.Marshal(data)
+	suite.Require().NoError(err)
+	suite.result = &internalpb.SearchResults{
+		NumQueries: 2,
+		TopK:       2,
+		SlicedBlob: blob,
+	}
+}
+
+func (suite *RerankHookSuite) decode(result *internalpb.SearchResults) *schemapb.SearchResultData {
+	data := &schemapb.SearchResultData{}
+	suite.Require().NoError(proto.Unmarshal(result.GetSlicedBlob(), data))
+	return data
+}
+
+func (suite *RerankHookSuite) TestNoHook() {
+	result, err := RerankSearchResults(context.Background(), suite.req, suite.result, nil)
+	suite.NoError(err)
+	suite.Equal(suite.result, result)
+}
+
+func (suite *RerankHookSuite) TestRerank() {
+	hook := &fakeRerankHook{
+		rerank: func(data *schemapb.SearchResultData) (*schemapb.SearchResultData, error) {
+			// swap the hits of the first query and filter the last hit of the second one
+			return &schemapb.SearchResultData{
+				NumQueries: data.GetNumQueries(),
+				TopK:       data.GetTopK(),
+				Scores:     []float32{1.0, 0.9, 0.7},
+				Ids: &schemapb.IDs{
+					IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{2, 1, 3}}},
+				},
+				Topks: []int64{2, 1},
+			}, nil
+		},
+	}
+	result, err := RerankSearchResults(context.Background(), suite.req, suite.result, hook)
+	suite.NoError(err)
+	data := suite.decode(result)
+	suite.Equal([]int64{2, 1, 3}, data.GetIds().GetIntId().GetData())
+	suite.Equal([]int64{2, 1}, data.GetTopks())
+}
+
+func (suite *RerankHookSuite) TestRerankFailed() {
+	hook := &fakeRerankHook{
+		rerank: func(data *schemapb.SearchResultData) (*schemapb.SearchResultData, error) {
+			return nil, errors.New("mock error")
+		},
+	}
+	_, err := RerankSearchResults(context.Background(), suite.req, suite.result, hook)
+	suite.Error(err)
+}
+
+func (suite *RerankHookSuite) TestInvalidRerank() {
+	cases := map[string]*schemapb.SearchResultData{
+		"nil": nil,
+		"query_mismatch": {
+			Scores: []float32{0.9},
+			Ids:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1}}}},
+			Topks:  []int64{1},
+		},
+		"score_mismatch": {
+			Scores: []float32{0.9},
+			Ids:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 3}}}},
+			Topks:  []int64{1, 1},
+		},
+		"hits_increased": {
+			Scores: []float32{0.9, 0.8, 0.7},
+			Ids:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}}}},
+			Topks:  []int64{3, 0},
+		},
+		"hit_injected": {
+			Scores: []float32{0.9, 0.7},
+			Ids:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{3, 1}}}},
+			Topks:  []int64{1, 1},
+		},
+		"hit_number_mismatch": {
+			Scores: []float32{0.9, 0.8, 0.7},
+			Ids:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}}}},
+			Topks:  []int64{1, 1},
+		},
+	}
+	for name, reranked := range cases {
+		suite.Run(name, func() {
+			hook := &fakeRerankHook{
+				rerank: func(data *schemapb.SearchResultData) (*schemapb.SearchResultData, error) {
+					return reranked, nil
+				},
+			}
+			_, err := RerankSearchResults(context.Background(), suite.req, suite.result, hook)
+			suite.Error(err)
+		})
+	}
+}
+
+func TestRerankHook(t *testing.T) {
+	suite.Run(t, new(RerankHookSuite))
+}
@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+type segmentAccess struct {
+	count  int64
+	fields map[int64]int64
+}
+
+// AccessTracker counts the accesses of sealed segments and their fields by search and query,
+// the counts are reported to querycoord as warmup hints,
+// so that the replicas loading the same segments could warm the hot data first.
+type AccessTracker struct {
+	mu       sync.RWMutex
+	segments map[int64]*segmentAccess
+}
+
+func NewAccessTracker() *AccessTracker {
+	return &AccessTracker{
+		segments: make(map[int64]*segmentAccess),
+	}
+}
+
+// Record counts one access of the segments and fields.
+func (t *AccessTracker) Record(segments []Segment, fieldIDs ...int64) {
+	if t == nil || len(segments) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, segment := range segments {
+		access := t.getOrCreate(segment.ID())
+		access.count++
+		for _, fieldID := range fieldIDs {
+			access.fields[fieldID]++
+		}
+	}
+}
+
+// Seed merges the warmup hints collected from other replicas,
+// the larger counts are kept so that the hints are not amplified while passing among replicas.
+func (t *AccessTracker) Seed(hints ...*querypb.SegmentWarmupHint) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, hint := range hints {
+		access := t.getOrCreate(hint.GetSegmentID())
+		if hint.GetAccessCount() > access.count {
+			access.count = hint.GetAccessCount()
+		}
+		for _, field := range hint.GetFieldAccess() {
+			if field.GetAccessCount() > access.fields[field.GetFieldID()] {
+				access.fields[field.GetFieldID()] = field.GetAccessCount()
+			}
+		}
+	}
+}
+
+func (t *AccessTracker) getOrCreate(segmentID int64) *segmentAccess {
+	access, ok := t.segments[segmentID]
+	if !ok {
+		access = &segmentAccess{fields: make(map[int64]int64)}
+		t.segments[segmentID] = access
+	}
+	return access
+}
+
+// Get returns the access count of segment and the access infos of its fields ordered by count desc.
+func (t *AccessTracker) Get(segmentID int64) (int64, []*querypb.FieldAccessInfo) {
+	if t == nil {
+		return 0, nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	access, ok := t.segments[segmentID]
+	if !ok {
+		return 0, nil
+	}
+	fields := make([]*querypb.FieldAccessInfo, 0, len(access.fields))
+	for fieldID, count := range access.fields {
+		fields = append(fields, &querypb.FieldAccessInfo{FieldID: fieldID, AccessCount: count})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].GetAccessCount() != fields[j].GetAccessCount() {
+			return fields[i].GetAccessCount() > fields[j].GetAccessCount()
+		}
+		return fields[i].GetFieldID() < fields[j].GetFieldID()
+	})
+	return access.count, fields
+}
+
+// Remove removes the stats of released segments.
+func (t *AccessTracker) Remove(segmentIDs ...int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, segmentID := range segmentIDs {
+		delete(t.segments, segmentID)
+	}
+}
+
+// SortSegments sorts the segment load infos by access count desc, the hot ones come first.
+func (t *AccessTracker) SortSegments(infos []*querypb.SegmentLoadInfo) {
+	if t == nil {
+		return
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	sort.SliceStable(infos, func(i, j int) bool {
+		return t.countLocked(infos[i].GetSegmentID()) > t.countLocked(infos[j].GetSegmentID())
+	})
+}
+
+// SortFields sorts the fields of segment by access count desc, the hot ones come first.
+func (t *AccessTracker) SortFields(segmentID int64, fieldIDs []int64) {
+	if t == nil {
+		return
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	access, ok := t.segments[segmentID]
+	if !ok {
+		return
+	}
+	sort.SliceStable(fieldIDs, func(i, j int) bool {
+		return access.fields[fieldIDs[i]] > access.fields[fieldIDs[j]]
+	})
+}
+
+func (t *AccessTracker) countLocked(segmentID int64) int64 {
+	if access, ok := t.segments[segmentID]; ok {
+		return access.count
+	}
+	return 0
+}
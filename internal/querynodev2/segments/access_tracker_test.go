@@ -0,0 +1,104 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+func TestAccessTracker(t *testing.T) {
+	newSegment := func(id int64) Segment {
+		return &LocalSegment{baseSegment: baseSegment{segmentID: id}}
+	}
+
+	tracker := NewAccessTracker()
+	tracker.Record([]Segment{newSegment(1), newSegment(2)}, 100, 101)
+	tracker.Record([]Segment{newSegment(2)}, 100)
+
+	count, fields := tracker.Get(2)
+	assert.EqualValues(t, 2, count)
+	assert.Equal(t, []*querypb.FieldAccessInfo{
+		{FieldID: 100, AccessCount: 2},
+		{FieldID: 101, AccessCount: 1},
+	}, fields)
+
+	t.Run("seed", func(t *testing.T) {
+		tracker.Seed(&querypb.SegmentWarmupHint{
+			SegmentID:   1,
+			AccessCount: 10,
+			FieldAccess: []*querypb.FieldAccessInfo{{FieldID: 101, AccessCount: 10}},
+		}, &querypb.SegmentWarmupHint{
+			SegmentID:   2,
+			AccessCount: 1,
+		})
+		count, fields := tracker.Get(1)
+		assert.EqualValues(t, 10, count)
+		assert.EqualValues(t, 101, fields[0].GetFieldID())
+		// the smaller counts don't override the local ones
+		count, _ = tracker.Get(2)
+		assert.EqualValues(t, 2, count)
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		infos := []*querypb.SegmentLoadInfo{{SegmentID: 3}, {SegmentID: 2}, {SegmentID: 1}}
+		tracker.SortSegments(infos)
+		assert.EqualValues(t, 1, infos[0].GetSegmentID())
+		assert.EqualValues(t, 2, infos[1].GetSegmentID())
+		assert.EqualValues(t, 3, infos[2].GetSegmentID())
+
+		fieldIDs := []int64{102, 100, 101}
+		tracker.SortFields(1, fieldIDs)
+		assert.Equal(t, []int64{101, 100, 102}, fieldIDs)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		tracker.Remove(1)
+		count, fields := tracker.Get(1)
+		assert.Zero(t, count)
+		assert.Empty(t, fields)
+	})
+
+	t.Run("nil tracker", func(t *testing.T) {
+		var tracker *AccessTracker
+		tracker.Record([]Segment{newSegment(1)}, 100)
+		tracker.Seed(&querypb.SegmentWarmupHint{SegmentID: 1})
+		count, _ := tracker.Get(1)
+		assert.Zero(t, count)
+	})
+}
+
+func TestPlanAccessedFields(t *testing.T) {
+	plan := &planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{
+			VectorAnns: &planpb.VectorANNS{FieldId: 101},
+		},
+		OutputFieldIds: []int64{101, 102},
+	}
+	assert.Equal(t, []int64{101, 102}, planAccessedFields(plan))
+
+	plan = &planpb.PlanNode{
+		Node:           &planpb.PlanNode_Query{Query: &planpb.QueryPlanNode{}},
+		OutputFieldIds: []int64{100, 102},
+	}
+	assert.Equal(t, []int64{100, 102}, planAccessedFields(plan))
+	assert.Empty(t, planAccessedFields(nil))
+}
@@ -30,33 +30,26 @@ import (
 	"fmt"
 	"unsafe"
 
-	"github.com/cockroachdb/errors"
 	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
-	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/cgoconverter"
 )
 
-// HandleCStatus deals with the error returned from CGO
+// HandleCStatus deals with the error returned from CGO,
+// the error of segcore is mapped to the typed error with the extra info as context
 func HandleCStatus(status *C.CStatus, extraInfo string) error {
 	if status.error_code == 0 {
 		return nil
 	}
-	errorCode := status.error_code
-	errorName, ok := commonpb.ErrorCode_name[int32(errorCode)]
-	if !ok {
-		errorName = "UnknownError"
-	}
+	errorCode := int32(status.error_code)
 	errorMsg := C.GoString(status.error_msg)
 	defer C.free(unsafe.Pointer(status.error_msg))
 
-	finalMsg := fmt.Sprintf("%s: %s", errorName, errorMsg)
-	logMsg := fmt.Sprintf("%s, segcore error: %s\n", extraInfo, finalMsg)
 	log := log.With().WithOptions(zap.AddCallerSkip(1))
-	log.Warn(logMsg)
-	return errors.New(finalMsg)
+	log.Warn(fmt.Sprintf("%s, segcore error", extraInfo), zap.Int32("code", errorCode), zap.String("msg", errorMsg))
+	return segcoreError(errorCode, fmt.Sprintf("%s: %s", extraInfo, errorMsg))
 }
 
 // HandleCProto deal with the result proto returned from CGO
@@ -123,6 +123,8 @@ type Collection struct {
 	loadType      querypb.LoadType
 	metricType    atomic.String
 	schema        atomic.Pointer[schemapb.CollectionSchema]
+	// plans caches the segcore plans of the repeated serialized plans
+	plans *planCache
 
 	refCount *atomic.Uint32
 }
@@ -219,6 +221,7 @@ func NewCollection(collectionID int64, schema *schemapb.CollectionSchema, indexM
 		id:            collectionID,
 		partitions:    typeutil.NewConcurrentSet[int64](),
 		loadType:      loadType,
+		plans:         newPlanCache(),
 		refCount:      atomic.NewUint32(0),
 	}
 	coll.schema.Store(schema)
@@ -244,6 +247,10 @@ func DeleteCollection(collection *Collection) {
 	collection.mu.Lock()
 	defer collection.mu.Unlock()
 
+	// the plans reference the schema of the collection
+	if collection.plans != nil {
+		collection.plans.clear()
+	}
 	cPtr := collection.collectionPtr
 	if cPtr != nil {
 		C.DeleteCollection(cPtr)
@@ -0,0 +1,250 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/util/initcore"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// DiskCategory is the kind of data stored on the local disk of query node.
+type DiskCategory string
+
+const (
+	DiskCategoryIndex      DiskCategory = "index"
+	DiskCategoryMmap       DiskCategory = "mmap"
+	DiskCategoryChunkCache DiskCategory = "chunk_cache"
+)
+
+var diskCategories = []DiskCategory{DiskCategoryIndex, DiskCategoryMmap, DiskCategoryChunkCache}
+
+var (
+	diskCache     *DiskCache
+	diskCacheOnce sync.Once
+)
+
+// GetDiskCache returns the singleton disk cache of the query node,
+// the capacity is DiskCapacityLimit * MaxDiskUsagePercentage.
+func GetDiskCache() *DiskCache {
+	diskCacheOnce.Do(func() {
+		params := paramtable.Get()
+		capacity := uint64(float64(params.QueryNodeCfg.DiskCapacityLimit.GetAsInt64()) * params.QueryNodeCfg.MaxDiskUsagePercentage.GetAsFloat())
+		diskCache = NewDiskCache(capacity, map[DiskCategory]float64{
+			DiskCategoryIndex:      params.QueryNodeCfg.DiskCacheIndexReservedRatio.GetAsFloat(),
+			DiskCategoryMmap:       params.QueryNodeCfg.DiskCacheMmapReservedRatio.GetAsFloat(),
+			DiskCategoryChunkCache: params.QueryNodeCfg.DiskCacheChunkCacheReservedRatio.GetAsFloat(),
+		}, segcoreChunkCache{})
+	})
+	return diskCache
+}
+
+// GetChunkCachePath returns the directory of chunk cache files.
+func GetChunkCachePath() string {
+	mmapDirPath := paramtable.Get().QueryNodeCfg.MmapDirPath.GetValue()
+	if len(mmapDirPath) == 0 {
+		mmapDirPath = paramtable.Get().LocalStorageCfg.Path.GetValue()
+	}
+	return path.Join(mmapDirPath, "chunk_cache")
+}
+
+func segmentDiskKey(segmentType SegmentType, segmentID int64) string {
+	return fmt.Sprintf("%s-%d", segmentType.String(), segmentID)
+}
+
+// releaseSegmentDisk releases the disk of disk indexes and mmap fields reserved for the segment.
+func releaseSegmentDisk(segmentType SegmentType, segmentID int64) {
+	key := segmentDiskKey(segmentType, segmentID)
+	GetDiskCache().Release(DiskCategoryIndex, key)
+	GetDiskCache().Release(DiskCategoryMmap, key)
+}
+
+// ChunkCache is the cache of the columns read from remote storage and mmap-ed to local disk,
+// the columns are owned by segcore, so they could only be evicted through segcore.
+type ChunkCache interface {
+	// Size returns the disk size of the cached columns.
+	Size() uint64
+	// Evict evicts the least recently read columns until size bytes freed, returns the bytes freed.
+	Evict(size uint64) uint64
+	// SetCapacity limits the disk size of the cached columns,
+	// the least recently read columns are evicted by segcore to make room for new ones.
+	SetCapacity(capacity uint64)
+}
+
+type segcoreChunkCache struct{}
+
+func (segcoreChunkCache) Size() uint64 {
+	return initcore.GetChunkCacheSize()
+}
+
+func (segcoreChunkCache) Evict(size uint64) uint64 {
+	return initcore.EvictChunkCache(size)
+}
+
+func (segcoreChunkCache) SetCapacity(capacity uint64) {
+	initcore.SetChunkCacheCapacity(capacity)
+}
+
+// DiskCache accounts the local disk usage of disk indexes, mmap files and chunk cache under a total quota.
+// Disk indexes and mmap files are reserved per segment and held until the segment released,
+// the admission of them is done by the segment loader together with the other resources.
+// The chunk cache is limited to the quota not reserved by the other categories,
+// and is evicted through segcore to make room for loading segments, but never below its own reservation.
+type DiskCache struct {
+	mu       sync.Mutex
+	capacity uint64
+	reserved map[DiskCategory]uint64
+	used     map[DiskCategory]uint64
+	entries  map[DiskCategory]map[string]uint64
+
+	// capacityMu serializes the updates of the chunk cache capacity,
+	// which may evict columns in segcore, so it's not done with mu held
+	capacityMu sync.Mutex
+	chunkCache ChunkCache
+}
+
+func NewDiskCache(capacity uint64, reservedRatio map[DiskCategory]float64, chunkCache ChunkCache) *DiskCache {
+	cache := &DiskCache{
+		capacity:   capacity,
+		reserved:   make(map[DiskCategory]uint64),
+		used:       make(map[DiskCategory]uint64),
+		entries:    make(map[DiskCategory]map[string]uint64),
+		chunkCache: chunkCache,
+	}
+	for _, category := range diskCategories {
+		cache.reserved[category] = uint64(float64(capacity) * reservedRatio[category])
+		cache.used[category] = 0
+		cache.entries[category] = make(map[string]uint64)
+	}
+	cache.syncChunkCacheCapacity()
+	return cache
+}
+
+// Reserve accounts size bytes of disk for the key of the category until Release is called.
+// The chunk cache is accounted by segcore, it can't be reserved.
+func (c *DiskCache) Reserve(category DiskCategory, key string, size uint64) error {
+	if category == DiskCategoryChunkCache {
+		return merr.WrapErrParameterInvalidMsg("disk of chunk cache is accounted by segcore")
+	}
+
+	c.mu.Lock()
+	entries, ok := c.entries[category]
+	if !ok {
+		c.mu.Unlock()
+		return merr.WrapErrParameterInvalidMsg("unknown disk category %s", category)
+	}
+	c.used[category] += size - entries[key]
+	entries[key] = size
+	c.updateMetrics(category)
+	c.mu.Unlock()
+
+	c.syncChunkCacheCapacity()
+	return nil
+}
+
+// Release removes the accounting of the key, the caller is responsible for cleaning the data on disk.
+func (c *DiskCache) Release(category DiskCategory, key string) {
+	c.mu.Lock()
+	size, ok := c.entries[category][key]
+	if ok {
+		delete(c.entries[category], key)
+		c.used[category] -= size
+		c.updateMetrics(category)
+	}
+	c.mu.Unlock()
+
+	if ok {
+		c.syncChunkCacheCapacity()
+	}
+}
+
+// MakeRoom evicts the chunk cache until size bytes are free for disk indexes and mmap files,
+// the reservation of chunk cache is kept. Returns the bytes evicted.
+func (c *DiskCache) MakeRoom(size uint64) uint64 {
+	chunkCacheSize := c.chunkCache.Size()
+
+	c.mu.Lock()
+	occupied := c.used[DiskCategoryIndex] + c.used[DiskCategoryMmap] + chunkCacheSize
+	free := uint64(0)
+	if occupied < c.capacity {
+		free = c.capacity - occupied
+	}
+	evictable := uint64(0)
+	if chunkCacheSize > c.reserved[DiskCategoryChunkCache] {
+		evictable = chunkCacheSize - c.reserved[DiskCategoryChunkCache]
+	}
+	c.mu.Unlock()
+
+	if free >= size || evictable == 0 {
+		return 0
+	}
+	freed := c.chunkCache.Evict(funcutil.Min(size-free, evictable))
+	if freed > 0 {
+		metrics.QueryNodeDiskCacheEvictCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), string(DiskCategoryChunkCache)).Inc()
+	}
+	c.updateChunkCacheMetrics()
+	return freed
+}
+
+// Used returns the disk size of the category in bytes.
+func (c *DiskCache) Used(category DiskCategory) uint64 {
+	if category == DiskCategoryChunkCache {
+		return c.chunkCache.Size()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.used[category]
+}
+
+// syncChunkCacheCapacity limits the chunk cache to the quota not used or reserved by the other categories,
+// but no less than its own reservation.
+func (c *DiskCache) syncChunkCacheCapacity() {
+	c.capacityMu.Lock()
+	defer c.capacityMu.Unlock()
+
+	c.mu.Lock()
+	occupied := funcutil.Max(c.used[DiskCategoryIndex], c.reserved[DiskCategoryIndex]) +
+		funcutil.Max(c.used[DiskCategoryMmap], c.reserved[DiskCategoryMmap])
+	capacity := uint64(0)
+	if occupied < c.capacity {
+		capacity = c.capacity - occupied
+	}
+	// zero means unlimited for segcore, keep at least one byte
+	capacity = funcutil.Max(capacity, c.reserved[DiskCategoryChunkCache], 1)
+	c.mu.Unlock()
+
+	c.chunkCache.SetCapacity(capacity)
+	c.updateChunkCacheMetrics()
+}
+
+func (c *DiskCache) updateMetrics(category DiskCategory) {
+	metrics.QueryNodeDiskCacheUsedSize.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), string(category)).
+		Set(float64(c.used[category]) / 1024 / 1024)
+}
+
+func (c *DiskCache) updateChunkCacheMetrics() {
+	metrics.QueryNodeDiskCacheUsedSize.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), string(DiskCategoryChunkCache)).
+		Set(float64(c.chunkCache.Size()) / 1024 / 1024)
+}
@@ -0,0 +1,124 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// fakeChunkCache evicts columns of the same size in fifo order.
+type fakeChunkCache struct {
+	columns  []uint64
+	capacity uint64
+}
+
+func (c *fakeChunkCache) Size() uint64 {
+	size := uint64(0)
+	for _, column := range c.columns {
+		size += column
+	}
+	return size
+}
+
+func (c *fakeChunkCache) Evict(size uint64) uint64 {
+	freed := uint64(0)
+	for freed < size && len(c.columns) > 0 {
+		freed += c.columns[0]
+		c.columns = c.columns[1:]
+	}
+	return freed
+}
+
+func (c *fakeChunkCache) SetCapacity(capacity uint64) {
+	c.capacity = capacity
+	if size := c.Size(); size > capacity {
+		c.Evict(size - capacity)
+	}
+}
+
+type DiskCacheSuite struct {
+	suite.Suite
+
+	chunkCache *fakeChunkCache
+	cache      *DiskCache
+}
+
+func (suite *DiskCacheSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (suite *DiskCacheSuite) SetupTest() {
+	suite.chunkCache = &fakeChunkCache{}
+	suite.cache = NewDiskCache(100, map[DiskCategory]float64{
+		DiskCategoryIndex:      0.1,
+		DiskCategoryChunkCache: 0.2,
+	}, suite.chunkCache)
+}
+
+func (suite *DiskCacheSuite) TestReserveAndRelease() {
+	// the reservation of index is excluded from chunk cache
+	suite.EqualValues(90, suite.chunkCache.capacity)
+
+	suite.NoError(suite.cache.Reserve(DiskCategoryIndex, "1", 50))
+	suite.EqualValues(50, suite.cache.Used(DiskCategoryIndex))
+	suite.EqualValues(50, suite.chunkCache.capacity)
+
+	suite.NoError(suite.cache.Reserve(DiskCategoryMmap, "2", 30))
+	suite.EqualValues(20, suite.chunkCache.capacity)
+
+	// reserve again replaces the old one
+	suite.NoError(suite.cache.Reserve(DiskCategoryMmap, "2", 40))
+	suite.EqualValues(40, suite.cache.Used(DiskCategoryMmap))
+	// chunk cache keeps its reservation
+	suite.EqualValues(20, suite.chunkCache.capacity)
+
+	suite.cache.Release(DiskCategoryIndex, "1")
+	suite.EqualValues(0, suite.cache.Used(DiskCategoryIndex))
+	suite.EqualValues(50, suite.chunkCache.capacity)
+
+	err := suite.cache.Reserve("unknown", "4", 1)
+	suite.ErrorIs(err, merr.ErrParameterInvalid)
+	err = suite.cache.Reserve(DiskCategoryChunkCache, "5", 1)
+	suite.ErrorIs(err, merr.ErrParameterInvalid)
+}
+
+func (suite *DiskCacheSuite) TestMakeRoom() {
+	suite.chunkCache.columns = []uint64{20, 20, 20}
+	suite.EqualValues(60, suite.cache.Used(DiskCategoryChunkCache))
+
+	// enough free disk
+	suite.EqualValues(0, suite.cache.MakeRoom(40))
+	suite.EqualValues(60, suite.cache.Used(DiskCategoryChunkCache))
+
+	// evict the least recently read columns
+	suite.EqualValues(20, suite.cache.MakeRoom(50))
+	suite.EqualValues(40, suite.cache.Used(DiskCategoryChunkCache))
+
+	// the reservation of chunk cache is kept
+	suite.EqualValues(20, suite.cache.MakeRoom(100))
+	suite.EqualValues(20, suite.cache.Used(DiskCategoryChunkCache))
+	suite.EqualValues(0, suite.cache.MakeRoom(100))
+}
+
+func TestDiskCache(t *testing.T) {
+	suite.Run(t, new(DiskCacheSuite))
+}
@@ -0,0 +1,85 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/fieldstats"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// loadFieldStats loads the field stats logs of the sealed segment if any, which are saved in the statslogs of each field,
+// the stats are only used to prune segments and estimate the selectivity, so no cgo call is involved.
+func (loader *segmentLoader) loadFieldStats(ctx context.Context, segment *LocalSegment, statsLogs []*datapb.FieldBinlog) error {
+	stats, err := fieldstats.Load(ctx, loader.cm, segment.ID(), statsLogs)
+	if err != nil || stats == nil {
+		return err
+	}
+	segment.SetFieldStats(stats)
+	log.Ctx(ctx).Info("load field stats done",
+		zap.Int64("segmentID", segment.ID()),
+		zap.Int("fieldNum", len(stats.Fields)),
+		zap.Int64("rowCount", stats.RowCount),
+	)
+	return nil
+}
+
+// pruneSegmentsByFieldStats filters out the segments which have no row matching expr according to the field stats,
+// and records the selectivity of expr estimated over the segments with field stats.
+func pruneSegmentsByFieldStats(segments []Segment, expr *planpb.Expr, queryType string) []Segment {
+	if expr == nil {
+		return segments
+	}
+	var (
+		result  = make([]Segment, 0, len(segments))
+		pruned  int
+		rows    float64
+		matched float64
+	)
+	for _, segment := range segments {
+		local, ok := segment.(*LocalSegment)
+		if !ok || local.GetFieldStats() == nil {
+			result = append(result, segment)
+			continue
+		}
+		stats := local.GetFieldStats()
+		rows += float64(stats.RowCount)
+		if !fieldstats.MayMatch(expr, stats.Get) {
+			pruned++
+			continue
+		}
+		matched += fieldstats.EstimateSelectivity(expr, stats.Get) * float64(stats.RowCount)
+		result = append(result, segment)
+	}
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	if pruned > 0 {
+		metrics.QueryNodeFieldStatsPrunedSegments.WithLabelValues(nodeID, queryType).Add(float64(pruned))
+	}
+	if rows > 0 {
+		metrics.QueryNodeEstimatedFilterSelectivity.WithLabelValues(nodeID, queryType).Observe(matched / rows)
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/fieldstats"
+	"github.com/milvus-io/milvus/pkg/metrics"
+)
+
+func TestPruneSegmentsByFieldStats(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+		},
+	}
+	newSegment := func(id int64, pks ...int64) *LocalSegment {
+		collector := fieldstats.NewSegmentCollector(schema)
+		collector.AddInsertData(&storage.InsertData{Data: map[storage.FieldID]storage.FieldData{
+			100: &storage.Int64FieldData{Data: pks},
+		}})
+		segment := &LocalSegment{baseSegment: baseSegment{segmentID: id}}
+		segment.SetFieldStats(collector.Build(id))
+		return segment
+	}
+	expr := &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+		ColumnInfo: &planpb.ColumnInfo{FieldId: 100, DataType: schemapb.DataType_Int64},
+		Op:         planpb.OpType_GreaterThan,
+		Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: 10}},
+	}}}
+
+	segments := []Segment{
+		newSegment(1, 1, 2, 3),
+		newSegment(2, 5, 11, 20),
+		&LocalSegment{baseSegment: baseSegment{segmentID: 3}},
+	}
+	pruned := pruneSegmentsByFieldStats(segments, expr, metrics.SearchLabel)
+	assert.Equal(t, []int64{2, 3}, lo.Map(pruned, func(segment Segment, _ int) int64 { return segment.ID() }))
+	assert.Len(t, pruneSegmentsByFieldStats(segments, nil, metrics.SearchLabel), 3)
+}
@@ -0,0 +1,389 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// GPUResidency is the residency policy of GPU indexes.
+type GPUResidency string
+
+const (
+	// GPUResidencyPin keeps the index in GPU memory until the segment released.
+	GPUResidencyPin GPUResidency = "pin"
+	// GPUResidencyLRU allows the index to be spilled to host memory if it's the least recently searched one.
+	GPUResidencyLRU GPUResidency = "lru"
+)
+
+const (
+	gpuResidentLabel = "resident"
+	gpuSpilledLabel  = "spilled"
+	gpuSpillLabel    = "spill"
+	gpuRestoreLabel  = "restore"
+)
+
+// isGpuIndex returns whether the index is loaded into GPU memory.
+func isGpuIndex(indexInfo *querypb.FieldIndexInfo) bool {
+	indexType, err := funcutil.GetAttrByKeyFromRepeatedKV(common.IndexTypeKey, indexInfo.GetIndexParams())
+	return err == nil && indexparamcheck.IsGpuIndex(indexType)
+}
+
+// getGPUResidency returns the residency policy of the GPU index of the field,
+// the type param of the field overrides the configured one.
+func getGPUResidency(schema *schemapb.CollectionSchema, fieldID int64) GPUResidency {
+	policy := paramtable.Get().QueryNodeCfg.GPUResidencyPolicy.GetValue()
+	for _, field := range schema.GetFields() {
+		if value, ok := common.GetGPUResidency(field.GetTypeParams()...); ok && field.GetFieldID() == fieldID {
+			policy = value
+		}
+	}
+	if GPUResidency(strings.ToLower(policy)) == GPUResidencyPin {
+		return GPUResidencyPin
+	}
+	return GPUResidencyLRU
+}
+
+// GPUIndexMover moves the GPU index of the field between GPU memory and host memory.
+type GPUIndexMover interface {
+	SpillIndex(fieldID int64) error
+	RestoreIndex(fieldID int64) error
+}
+
+type gpuIndexKey struct {
+	segmentID int64
+	fieldID   int64
+}
+
+// gpuIndexState is the residency state of a GPU index.
+type gpuIndexState int
+
+const (
+	gpuIndexResident gpuIndexState = iota
+	gpuIndexSpilling
+	gpuIndexSpilled
+	gpuIndexRestoring
+)
+
+type gpuIndex struct {
+	key    gpuIndexKey
+	mover  GPUIndexMover
+	size   uint64
+	policy GPUResidency
+	state  gpuIndexState
+	// onGPU is true if the index is accounted as resident, the spilling index holds the GPU memory until spilled,
+	// and the restoring one holds the GPU memory once reserved.
+	onGPU   bool
+	removed bool          // the index is removed, maybe while it's moving
+	refs    int           // count of the searches using the index
+	elem    *list.Element // position in the lru list, nil if the index is pinned, moving or spilled
+}
+
+var (
+	gpuMemoryManager     *GPUMemoryManager
+	gpuMemoryManagerOnce sync.Once
+)
+
+// GetGPUMemoryManager returns the singleton GPU memory manager of the query node,
+// the capacity is GPUMemoryCapacity in MB.
+func GetGPUMemoryManager() *GPUMemoryManager {
+	gpuMemoryManagerOnce.Do(func() {
+		capacity := paramtable.Get().QueryNodeCfg.GPUMemoryCapacity.GetAsInt64() * 1024 * 1024
+		gpuMemoryManager = NewGPUMemoryManager(uint64(funcutil.Max(capacity, 0)))
+	})
+	return gpuMemoryManager
+}
+
+// GPUMemoryManager accounts the GPU memory of the GPU indexes of the query node under a capacity,
+// so that the collections with GPU indexes could share the devices predictably.
+// The pinned indexes stay in GPU memory until their segments are released,
+// the others are spilled to host memory in LRU order to make room for the loading and searched indexes,
+// and restored to GPU memory when they are searched again.
+// The indexes in use by searches are never spilled. The moves are done out of the lock of the manager,
+// the moving indexes are tracked by their states, and the requests for them wait until they are moved.
+// The host memory of the spilled indexes needs no charge, the loader admits the index size as segment memory.
+type GPUMemoryManager struct {
+	mu       sync.Mutex
+	moved    *sync.Cond // broadcast once an index is moved
+	capacity uint64     // 0 means unlimited
+	resident uint64     // including the moving indexes and the reserved memory
+	spilled  uint64
+	spilling int // count of the indexes being spilled
+	indexes  map[gpuIndexKey]*gpuIndex
+	lru      *list.List // the resident indexes could be spilled, the front is the most recently used
+}
+
+func NewGPUMemoryManager(capacity uint64) *GPUMemoryManager {
+	m := &GPUMemoryManager{
+		capacity: capacity,
+		indexes:  make(map[gpuIndexKey]*gpuIndex),
+		lru:      list.New(),
+	}
+	m.moved = sync.NewCond(&m.mu)
+	return m
+}
+
+// Reserve reserves GPU memory for the index of the field to load, spills the other indexes if needed,
+// returns ErrServiceMemoryLimitExceeded if there is no enough GPU memory.
+// The memory is held until Remove is called.
+func (m *GPUMemoryManager) Reserve(mover GPUIndexMover, segmentID, fieldID int64, size uint64, policy GPUResidency) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := gpuIndexKey{segmentID: segmentID, fieldID: fieldID}
+	m.remove(key)
+	if err := m.makeRoom(size); err != nil {
+		return err
+	}
+	// the lock is released while making room
+	m.remove(key)
+	index := &gpuIndex{key: key, mover: mover, size: size, policy: policy, onGPU: true}
+	m.indexes[key] = index
+	m.setResident(index)
+	return nil
+}
+
+// Acquire makes the GPU indexes of the fields of the segment resident, restores the spilled ones if needed.
+// The indexes are not spilled until the returned release is called.
+func (m *GPUMemoryManager) Acquire(segmentID int64, fieldIDs ...int64) (func(), error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var indexes []*gpuIndex
+	for _, fieldID := range fieldIDs {
+		if index, ok := m.indexes[gpuIndexKey{segmentID: segmentID, fieldID: fieldID}]; ok {
+			indexes = append(indexes, index)
+		}
+	}
+	if len(indexes) == 0 {
+		return func() {}, nil
+	}
+
+	// hold all of them first, so that restoring one doesn't spill the other
+	for _, index := range indexes {
+		index.refs++
+	}
+	unref := func() {
+		for _, index := range indexes {
+			index.refs--
+		}
+	}
+	for _, index := range indexes {
+		for !index.removed && (index.state == gpuIndexSpilling || index.state == gpuIndexRestoring) {
+			m.moved.Wait()
+		}
+		switch {
+		case index.removed:
+			// the segment is released, the search fails on it anyway
+		case index.state == gpuIndexResident:
+			m.touch(index)
+		default:
+			if err := m.restore(index); err != nil {
+				unref()
+				return nil, err
+			}
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			unref()
+		})
+	}, nil
+}
+
+// Remove removes the accounting of the GPU indexes of the fields of the segment, all of them if no field given.
+func (m *GPUMemoryManager) Remove(segmentID int64, fieldIDs ...int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(fieldIDs) > 0 {
+		for _, fieldID := range fieldIDs {
+			m.remove(gpuIndexKey{segmentID: segmentID, fieldID: fieldID})
+		}
+		return
+	}
+	for key := range m.indexes {
+		if key.segmentID == segmentID {
+			m.remove(key)
+		}
+	}
+}
+
+// Used returns the size of the resident and the spilled GPU indexes in bytes.
+func (m *GPUMemoryManager) Used() (resident uint64, spilled uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.resident, m.spilled
+}
+
+// makeRoom spills the least recently used indexes until there is size bytes of GPU memory available,
+// then reserves the memory as resident. The lock is released while spilling.
+func (m *GPUMemoryManager) makeRoom(size uint64) error {
+	for m.capacity > 0 && m.resident+size > m.capacity {
+		var victim *gpuIndex
+		for elem := m.lru.Back(); elem != nil; elem = elem.Prev() {
+			if index := elem.Value.(*gpuIndex); index.refs == 0 {
+				victim = index
+				break
+			}
+		}
+		if victim == nil {
+			if m.spilling > 0 {
+				// the memory is released once the spilling indexes are moved
+				m.moved.Wait()
+				continue
+			}
+			return merr.WrapErrServiceMemoryLimitExceeded(float32(m.resident+size), float32(m.capacity),
+				fmt.Sprintf("no enough GPU memory, the resident indexes are pinned or in use, resident = %d, request = %d", m.resident, size))
+		}
+		if err := m.spill(victim); err != nil {
+			return err
+		}
+	}
+	m.resident += size
+	return nil
+}
+
+// spill moves the index to host memory, the victim released concurrently is skipped,
+// so that the caller picks another one.
+func (m *GPUMemoryManager) spill(index *gpuIndex) error {
+	index.state = gpuIndexSpilling
+	m.lru.Remove(index.elem)
+	index.elem = nil
+	m.spilling++
+
+	m.mu.Unlock()
+	err := index.mover.SpillIndex(index.key.fieldID)
+	m.mu.Lock()
+
+	m.spilling--
+	defer m.moved.Broadcast()
+	if index.removed {
+		return nil
+	}
+	if errors.Is(err, merr.ErrSegmentNotLoaded) {
+		// the segment is released, so is the GPU memory of the index
+		m.remove(index.key)
+		return nil
+	}
+	if err != nil {
+		m.setResident(index)
+		return err
+	}
+	index.state, index.onGPU = gpuIndexSpilled, false
+	m.resident -= index.size
+	m.spilled += index.size
+	metrics.QueryNodeGPUIndexMoveCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), gpuSpillLabel).Inc()
+	m.updateMetrics()
+	return nil
+}
+
+// restore moves the spilled index back to GPU memory, the lock is released while moving.
+func (m *GPUMemoryManager) restore(index *gpuIndex) error {
+	index.state = gpuIndexRestoring
+	err := m.makeRoom(index.size)
+	if err != nil || index.removed {
+		if !index.removed {
+			index.state = gpuIndexSpilled
+		} else if err == nil {
+			m.resident -= index.size
+			m.updateMetrics()
+		}
+		m.moved.Broadcast()
+		return err
+	}
+	m.spilled -= index.size
+	index.onGPU = true
+
+	m.mu.Unlock()
+	err = index.mover.RestoreIndex(index.key.fieldID)
+	m.mu.Lock()
+
+	defer m.moved.Broadcast()
+	if index.removed {
+		return nil
+	}
+	if err != nil {
+		index.state, index.onGPU = gpuIndexSpilled, false
+		m.resident -= index.size
+		m.spilled += index.size
+		m.updateMetrics()
+		return err
+	}
+	metrics.QueryNodeGPUIndexMoveCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), gpuRestoreLabel).Inc()
+	m.setResident(index)
+	return nil
+}
+
+// setResident marks the index resident, whose memory is already accounted.
+func (m *GPUMemoryManager) setResident(index *gpuIndex) {
+	index.state = gpuIndexResident
+	if index.policy == GPUResidencyLRU {
+		index.elem = m.lru.PushFront(index)
+	}
+	m.updateMetrics()
+}
+
+func (m *GPUMemoryManager) touch(index *gpuIndex) {
+	if index.elem != nil {
+		m.lru.MoveToFront(index.elem)
+	}
+}
+
+func (m *GPUMemoryManager) remove(key gpuIndexKey) {
+	index, ok := m.indexes[key]
+	if !ok {
+		return
+	}
+	delete(m.indexes, key)
+	index.removed = true
+	if index.onGPU {
+		m.resident -= index.size
+	} else {
+		m.spilled -= index.size
+	}
+	if index.elem != nil {
+		m.lru.Remove(index.elem)
+		index.elem = nil
+	}
+	m.updateMetrics()
+}
+
+func (m *GPUMemoryManager) updateMetrics() {
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.QueryNodeGPUMemoryUsedSize.WithLabelValues(nodeID, gpuResidentLabel).Set(float64(m.resident) / 1024 / 1024)
+	metrics.QueryNodeGPUMemoryUsedSize.WithLabelValues(nodeID, gpuSpilledLabel).Set(float64(m.spilled) / 1024 / 1024)
+}
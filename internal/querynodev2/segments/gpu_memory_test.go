@@ -0,0 +1,225 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// fakeGPUIndexMover records the fields of the spilled indexes.
+type fakeGPUIndexMover struct {
+	mu      sync.Mutex
+	spilled map[int64]bool
+	// onSpill is called before spilling if set, the index isn't spilled if it returns an error
+	onSpill func(fieldID int64) error
+}
+
+func newFakeGPUIndexMover() *fakeGPUIndexMover {
+	return &fakeGPUIndexMover{spilled: make(map[int64]bool)}
+}
+
+func (m *fakeGPUIndexMover) SpillIndex(fieldID int64) error {
+	if m.onSpill != nil {
+		if err := m.onSpill(fieldID); err != nil {
+			return err
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spilled[fieldID] = true
+	return nil
+}
+
+func (m *fakeGPUIndexMover) RestoreIndex(fieldID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.spilled, fieldID)
+	return nil
+}
+
+type GPUMemoryManagerSuite struct {
+	suite.Suite
+
+	mover   *fakeGPUIndexMover
+	manager *GPUMemoryManager
+}
+
+func (suite *GPUMemoryManagerSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (suite *GPUMemoryManagerSuite) SetupTest() {
+	suite.mover = newFakeGPUIndexMover()
+	suite.manager = NewGPUMemoryManager(100)
+}
+
+func (suite *GPUMemoryManagerSuite) TestSpillLRU() {
+	suite.NoError(suite.manager.Reserve(suite.mover, 1, 101, 40, GPUResidencyLRU))
+	suite.NoError(suite.manager.Reserve(suite.mover, 1, 102, 40, GPUResidencyLRU))
+
+	// touch 101, so 102 is the least recently used one
+	release, err := suite.manager.Acquire(1, 101)
+	suite.NoError(err)
+	release()
+
+	suite.NoError(suite.manager.Reserve(suite.mover, 1, 103, 40, GPUResidencyLRU))
+	suite.True(suite.mover.spilled[102])
+	resident, spilled := suite.manager.Used()
+	suite.EqualValues(80, resident)
+	suite.EqualValues(40, spilled)
+
+	// searching 102 restores it and spills 101
+	release, err = suite.manager.Acquire(1, 102)
+	suite.NoError(err)
+	defer release()
+	suite.False(suite.mover.spilled[102])
+	suite.True(suite.mover.spilled[101])
+}
+
+func (suite *GPUMemoryManagerSuite) TestPinAndInUse() {
+	suite.NoError(suite.manager.Reserve(suite.mover, 1, 101, 40, GPUResidencyPin))
+	suite.NoError(suite.manager.Reserve(suite.mover, 2, 101, 40, GPUResidencyLRU))
+
+	// the index in use can't be spilled
+	release, err := suite.manager.Acquire(2, 101)
+	suite.NoError(err)
+	err = suite.manager.Reserve(suite.mover, 3, 101, 40, GPUResidencyLRU)
+	suite.ErrorIs(err, merr.ErrServiceMemoryLimitExceeded)
+
+	// the pinned index is never spilled
+	release()
+	suite.NoError(suite.manager.Reserve(suite.mover, 3, 101, 40, GPUResidencyLRU))
+	suite.Len(suite.mover.spilled, 1)
+	err = suite.manager.Reserve(suite.mover, 4, 101, 70, GPUResidencyLRU)
+	suite.ErrorIs(err, merr.ErrServiceMemoryLimitExceeded)
+}
+
+func (suite *GPUMemoryManagerSuite) TestRemove() {
+	suite.NoError(suite.manager.Reserve(suite.mover, 1, 101, 40, GPUResidencyLRU))
+	suite.NoError(suite.manager.Reserve(suite.mover, 1, 102, 40, GPUResidencyLRU))
+	suite.NoError(suite.manager.Reserve(suite.mover, 2, 101, 40, GPUResidencyLRU))
+
+	suite.manager.Remove(1, 102)
+	resident, spilled := suite.manager.Used()
+	suite.EqualValues(40, resident)
+	suite.EqualValues(40, spilled)
+
+	suite.manager.Remove(1)
+	resident, spilled = suite.manager.Used()
+	suite.EqualValues(40, resident)
+	suite.EqualValues(0, spilled)
+
+	// acquiring the removed or the non-gpu indexes is a no-op
+	release, err := suite.manager.Acquire(1, 101, 102)
+	suite.NoError(err)
+	release()
+}
+
+func (suite *GPUMemoryManagerSuite) TestVictimReleased() {
+	suite.NoError(suite.manager.Reserve(suite.mover, 1, 101, 40, GPUResidencyLRU))
+	suite.NoError(suite.manager.Reserve(suite.mover, 1, 102, 40, GPUResidencyLRU))
+
+	// 101 is released while spilling, so the manager picks 102 as another victim
+	suite.mover.onSpill = func(fieldID int64) error {
+		if fieldID == 101 {
+			suite.manager.Remove(1, 101)
+			return merr.WrapErrSegmentNotLoaded(1, "segment released")
+		}
+		return nil
+	}
+	suite.NoError(suite.manager.Reserve(suite.mover, 2, 101, 90, GPUResidencyLRU))
+	suite.True(suite.mover.spilled[102])
+	resident, spilled := suite.manager.Used()
+	suite.EqualValues(90, resident)
+	suite.EqualValues(40, spilled)
+}
+
+func (suite *GPUMemoryManagerSuite) TestConcurrentMoves() {
+	// the indexes of the segments are spilled and restored by turns
+	for i := int64(0); i < 4; i++ {
+		suite.NoError(suite.manager.Reserve(newFakeGPUIndexMover(), i, 101, 40, GPUResidencyLRU))
+	}
+
+	wg := sync.WaitGroup{}
+	for i := int64(0); i < 4; i++ {
+		wg.Add(1)
+		go func(segmentID int64) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				release, err := suite.manager.Acquire(segmentID, 101)
+				if err != nil {
+					// the other indexes are all in use
+					suite.ErrorIs(err, merr.ErrServiceMemoryLimitExceeded)
+					continue
+				}
+				resident, _ := suite.manager.Used()
+				suite.LessOrEqual(resident, uint64(100))
+				release()
+			}
+		}(i)
+	}
+	wg.Wait()
+	resident, spilled := suite.manager.Used()
+	suite.EqualValues(80, resident)
+	suite.EqualValues(80, spilled)
+}
+
+func (suite *GPUMemoryManagerSuite) TestIsGpuIndex() {
+	for _, indexType := range []string{"GPU_IVF_FLAT", "GPU_IVF_PQ", "GPU_CAGRA", "GPU_BRUTE_FORCE"} {
+		suite.True(isGpuIndex(&querypb.FieldIndexInfo{
+			IndexParams: []*commonpb.KeyValuePair{{Key: common.IndexTypeKey, Value: indexType}},
+		}))
+	}
+	suite.False(isGpuIndex(&querypb.FieldIndexInfo{
+		IndexParams: []*commonpb.KeyValuePair{{Key: common.IndexTypeKey, Value: "HNSW"}},
+	}))
+}
+
+func (suite *GPUMemoryManagerSuite) TestUnlimited() {
+	manager := NewGPUMemoryManager(0)
+	for i := int64(0); i < 10; i++ {
+		suite.NoError(manager.Reserve(suite.mover, i, 101, 40, GPUResidencyLRU))
+	}
+	suite.Empty(suite.mover.spilled)
+}
+
+func (suite *GPUMemoryManagerSuite) TestGetGPUResidency() {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 101, DataType: schemapb.DataType_FloatVector},
+			{FieldID: 102, DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{
+				{Key: common.GPUResidencyKey, Value: "pin"},
+			}},
+		},
+	}
+	suite.Equal(GPUResidencyLRU, getGPUResidency(schema, 101))
+	suite.Equal(GPUResidencyPin, getGPUResidency(schema, 102))
+}
+
+func TestGPUMemoryManager(t *testing.T) {
+	suite.Run(t, new(GPUMemoryManagerSuite))
+}
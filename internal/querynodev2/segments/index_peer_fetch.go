@@ -0,0 +1,174 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// IndexFileCRCTable is the crc32 table of the checksums of the index file chunks fetched from peers.
+var IndexFileCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// IndexFileFetcher fetches the disk index files cached by the peer querynode.
+type IndexFileFetcher func(ctx context.Context, nodeID int64, req *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error)
+
+// SetIndexFileFetcher sets the fetcher of the disk index files from the peers.
+func (loader *segmentLoader) SetIndexFileFetcher(fetcher IndexFileFetcher) {
+	loader.indexFetcher = fetcher
+}
+
+// fetchIndexFromPeers fetches the disk index files cached by the peers serving the segment to the local disk,
+// so that segcore reuses them instead of downloading from the object storage.
+// Returns false if the index is not fetched, then it's downloaded from the object storage as usual.
+func (loader *segmentLoader) fetchIndexFromPeers(ctx context.Context, segment *LocalSegment, indexInfo *querypb.FieldIndexInfo, peers []int64) bool {
+	if loader.indexFetcher == nil || len(peers) == 0 ||
+		!paramtable.Get().QueryNodeCfg.IndexPeerFetchEnabled.GetAsBool() {
+		return false
+	}
+	indexParams := funcutil.KeyValuePair2Map(indexInfo.GetIndexParams())
+	if indexParams["index_type"] != indexparamcheck.IndexDISKANN || GetLocalCatalog().Reusable(indexInfo) {
+		return false
+	}
+
+	log := log.Ctx(ctx).With(
+		zap.Int64("segmentID", segment.ID()),
+		zap.Int64("buildID", indexInfo.GetBuildID()),
+		zap.Int64("indexVersion", indexInfo.GetIndexVersion()),
+	)
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	for _, peer := range peers {
+		if peer == paramtable.GetNodeID() {
+			continue
+		}
+		size, err := loader.fetchIndexFromPeer(ctx, peer, segment, indexInfo)
+		if err != nil {
+			log.Warn("failed to fetch disk index from peer", zap.Int64("peer", peer), zap.Error(err))
+			continue
+		}
+		metrics.QueryNodeIndexPeerFetchTotal.WithLabelValues(nodeID, metrics.SuccessLabel).Inc()
+		metrics.QueryNodeIndexPeerFetchBytes.WithLabelValues(nodeID).Add(float64(size))
+		log.Info("fetch disk index from peer done", zap.Int64("peer", peer), zap.Int64("size", size))
+		return true
+	}
+	metrics.QueryNodeIndexPeerFetchTotal.WithLabelValues(nodeID, metrics.FailLabel).Inc()
+	return false
+}
+
+// fetchIndexFromPeer fetches all the files of the disk index into a temporary directory,
+// then moves it to where segcore caches the index, returns the total size fetched.
+func (loader *segmentLoader) fetchIndexFromPeer(ctx context.Context, peer int64, segment *LocalSegment, indexInfo *querypb.FieldIndexInfo) (int64, error) {
+	resp, err := loader.indexFetcher(ctx, peer, &querypb.FetchIndexFileRequest{
+		Base:         commonpbutil.NewMsgBase(commonpbutil.WithTargetID(peer)),
+		BuildID:      indexInfo.GetBuildID(),
+		IndexVersion: indexInfo.GetIndexVersion(),
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return 0, err
+	}
+	if len(resp.GetFiles()) == 0 {
+		return 0, merr.WrapErrServiceInternal("no disk index file listed by peer")
+	}
+
+	catalog := GetLocalCatalog()
+	target := catalog.indexVersionPath(indexInfo.GetBuildID(), indexInfo.GetIndexVersion())
+	tmp := target + ".fetching"
+	if err := os.RemoveAll(tmp); err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(tmp)
+
+	var total int64
+	for _, file := range resp.GetFiles() {
+		if err := loader.fetchIndexFile(ctx, peer, indexInfo, tmp, file); err != nil {
+			return 0, err
+		}
+		total += file.GetSize()
+	}
+
+	if err := os.RemoveAll(target); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		return 0, err
+	}
+	// record the files fetched, then segcore reuses them
+	if err := catalog.Save(segment.Collection(), segment.Partition(), segment.ID(), indexInfo); err != nil {
+		os.RemoveAll(target)
+		return 0, err
+	}
+	return total, nil
+}
+
+// fetchIndexFile fetches the file chunk by chunk, each chunk is verified by its checksum.
+func (loader *segmentLoader) fetchIndexFile(ctx context.Context, peer int64, indexInfo *querypb.FieldIndexInfo, dir string, file *querypb.IndexFile) error {
+	if !filepath.IsLocal(file.GetFile()) {
+		return merr.WrapErrParameterInvalidMsg("invalid disk index file %s listed by peer", file.GetFile())
+	}
+	path := filepath.Join(dir, file.GetFile())
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	chunkSize := paramtable.Get().QueryNodeCfg.IndexPeerFetchChunkSize.GetAsInt64() * 1024 * 1024
+	if chunkSize <= 0 {
+		chunkSize = 4 * 1024 * 1024
+	}
+	for offset := int64(0); offset < file.GetSize(); offset += chunkSize {
+		size := chunkSize
+		if offset+size > file.GetSize() {
+			size = file.GetSize() - offset
+		}
+		resp, err := loader.indexFetcher(ctx, peer, &querypb.FetchIndexFileRequest{
+			Base:         commonpbutil.NewMsgBase(commonpbutil.WithTargetID(peer)),
+			BuildID:      indexInfo.GetBuildID(),
+			IndexVersion: indexInfo.GetIndexVersion(),
+			File:         file.GetFile(),
+			Offset:       offset,
+			Size:         size,
+		})
+		if err := merr.CheckRPCCall(resp, err); err != nil {
+			return err
+		}
+		if int64(len(resp.GetData())) != size || crc32.Checksum(resp.GetData(), IndexFileCRCTable) != resp.GetChecksum() {
+			return merr.WrapErrServiceInternal(fmt.Sprintf("chunk [%d, %d) of disk index file %s corrupted", offset, offset+size, file.GetFile()))
+		}
+		if _, err := f.Write(resp.GetData()); err != nil {
+			return err
+		}
+	}
+	return f.Sync()
+}
@@ -0,0 +1,80 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// loadJSONKeyStats loads the json key stats of the index into segment,
+// the stats are only used to prune segments, so no cgo call is involved.
+func (loader *segmentLoader) loadJSONKeyStats(ctx context.Context, segment *LocalSegment, indexInfo *querypb.FieldIndexInfo) error {
+	for _, filePath := range indexInfo.GetIndexFilePaths() {
+		if path.Base(filePath) != jsonkey.StatsFileKey {
+			continue
+		}
+		data, err := loader.cm.Read(ctx, filePath)
+		if err != nil {
+			return err
+		}
+		stats, err := jsonkey.Unmarshal(data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal json key stats %s", filePath)
+		}
+		segment.AddJSONKeyStats(indexInfo.GetFieldID(), stats)
+		log.Ctx(ctx).Info("load json key stats done",
+			zap.Int64("segmentID", segment.ID()),
+			zap.Int64("fieldID", indexInfo.GetFieldID()),
+			zap.Strings("path", stats.Path),
+			zap.Int64("buildID", indexInfo.GetBuildID()),
+		)
+		return nil
+	}
+	return merr.WrapErrIndexNotFound(indexInfo.GetIndexName(), "json key stats file missing")
+}
+
+// pruneSegmentsByJSONKeys filters out the segments which have no row matching expr
+// according to the json key stats.
+func pruneSegmentsByJSONKeys(segments []Segment, expr *planpb.Expr) []Segment {
+	if expr == nil {
+		return segments
+	}
+	result := make([]Segment, 0, len(segments))
+	for _, segment := range segments {
+		local, ok := segment.(*LocalSegment)
+		if ok && !local.MayMatchJSONKeys(expr) {
+			continue
+		}
+		result = append(result, segment)
+	}
+	return result
+}
+
+func jsonKeyStatsKey(fieldID int64, path []string) string {
+	return fmt.Sprintf("%d/%s", fieldID, jsonkey.FormatPath(path))
+}
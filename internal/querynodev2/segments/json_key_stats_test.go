@@ -0,0 +1,67 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestPruneSegmentsByJSONKeys(t *testing.T) {
+	newSegment := func(id int64, rows ...string) *LocalSegment {
+		segment := &LocalSegment{
+			baseSegment:  baseSegment{segmentID: id},
+			jsonKeyStats: typeutil.NewConcurrentMap[string, *jsonkey.Stats](),
+		}
+		data := make([][]byte, 0, len(rows))
+		for _, row := range rows {
+			data = append(data, []byte(row))
+		}
+		segment.AddJSONKeyStats(101, jsonkey.Build([]string{"category"}, data))
+		return segment
+	}
+	expr := &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+		ColumnInfo: &planpb.ColumnInfo{FieldId: 101, DataType: schemapb.DataType_JSON, NestedPath: []string{"category"}},
+		Op:         planpb.OpType_Equal,
+		Value:      &planpb.GenericValue{Val: &planpb.GenericValue_StringVal{StringVal: "book"}},
+	}}}
+
+	segments := []Segment{
+		newSegment(1, `{"category": "book"}`),
+		newSegment(2, `{"category": "food"}`),
+		&LocalSegment{baseSegment: baseSegment{segmentID: 3}, jsonKeyStats: typeutil.NewConcurrentMap[string, *jsonkey.Stats]()},
+	}
+	pruned := pruneSegmentsByJSONKeys(segments, expr)
+	assert.Equal(t, []int64{1, 3}, lo.Map(pruned, func(segment Segment, _ int) int64 { return segment.ID() }))
+	assert.Len(t, pruneSegmentsByJSONKeys(segments, nil), 3)
+
+	t.Run("parse predicates", func(t *testing.T) {
+		plan := &planpb.PlanNode{Node: &planpb.PlanNode_Query{Query: &planpb.QueryPlanNode{Predicates: expr}}}
+		bs, err := proto.Marshal(plan)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expr, parsePredicates(bs)))
+		assert.Nil(t, parsePredicates([]byte("invalid")))
+	})
+}
@@ -71,6 +71,15 @@ func (ld *LoadFieldDataInfo) enableMmap(fieldID int64, enabled bool) {
 	C.EnableMmap(ld.cLoadFieldDataInfo, cFieldID, cEnabled)
 }
 
+// enableLocalRecovery keeps the mmap file of the field on local disk,
+// and reuses the file kept before instead of loading the binlogs if reuse is set.
+func (ld *LoadFieldDataInfo) enableLocalRecovery(fieldID int64, reuse bool) {
+	cFieldID := C.int64_t(fieldID)
+	cReuse := C.bool(reuse)
+
+	C.EnableLocalRecovery(ld.cLoadFieldDataInfo, cFieldID, cReuse)
+}
+
 func (ld *LoadFieldDataInfo) appendMMapDirPath(dir string) {
 	cDir := C.CString(dir)
 	defer C.free(unsafe.Pointer(cDir))
@@ -27,8 +27,11 @@ import "C"
 import (
 	"unsafe"
 
+	"go.uber.org/zap"
+
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
 	"github.com/milvus-io/milvus/pkg/util/indexparams"
@@ -73,12 +76,22 @@ func (li *LoadIndexInfo) appendLoadIndexInfo(indexInfo *querypb.FieldIndexInfo,
 
 	// some build params also exist in indexParams, which are useless during loading process
 	indexParams := funcutil.KeyValuePair2Map(indexInfo.IndexParams)
-	if indexParams["index_type"] == indexparamcheck.IndexDISKANN {
+	isDiskIndex := indexParams["index_type"] == indexparamcheck.IndexDISKANN
+	// the disk indexes cached on local disk are reused by the restarted node, and shared with the peers
+	localCache := isDiskIndex && (paramtable.Get().QueryNodeCfg.LocalRecoveryEnabled.GetAsBool() ||
+		paramtable.Get().QueryNodeCfg.IndexPeerFetchEnabled.GetAsBool())
+	if isDiskIndex {
 		err = indexparams.SetDiskIndexLoadParams(paramtable.Get(), indexParams, indexInfo.GetNumRows())
 		if err != nil {
 			return err
 		}
 	}
+	if localCache && GetLocalCatalog().Reusable(indexInfo) {
+		log.Info("reuse the disk index cached on local disk",
+			zap.Int64("segmentID", segmentID),
+			zap.Int64("buildID", indexInfo.GetBuildID()))
+		indexParams[reuseLocalIndexKey] = "true"
+	}
 
 	err = indexparams.AppendPrepareLoadParams(paramtable.Get(), indexParams)
 	if err != nil {
@@ -97,7 +110,19 @@ func (li *LoadIndexInfo) appendLoadIndexInfo(indexInfo *querypb.FieldIndexInfo,
 	}
 
 	err = li.appendIndexData(indexPaths)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if localCache {
+		if err := GetLocalCatalog().Save(collectionID, partitionID, segmentID, indexInfo); err != nil {
+			log.Warn("failed to save the disk index into local catalog",
+				zap.Int64("segmentID", segmentID),
+				zap.Int64("buildID", indexInfo.GetBuildID()),
+				zap.Error(err))
+		}
+	}
+	return nil
 }
 
 // appendIndexParam append indexParam to index
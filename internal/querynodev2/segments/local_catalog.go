@@ -0,0 +1,493 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	// localCatalogDir is the directory of the local catalog under the local root path of query node.
+	localCatalogDir = "catalog"
+	// localMmapCatalogDir is the directory of the mmap entries under the local catalog.
+	localMmapCatalogDir = "mmap"
+	// localIndexDir is the directory where segcore caches the disk indexes, see INDEX_ROOT_PATH.
+	localIndexDir = "index_files"
+	// reuseLocalIndexKey is the load param to reuse the disk index files cached on local disk,
+	// see REUSE_LOCAL_INDEX_KEY.
+	reuseLocalIndexKey = "reuse_local_index"
+)
+
+var (
+	localCatalog     *LocalCatalog
+	localCatalogOnce sync.Once
+)
+
+// GetLocalCatalog returns the singleton local catalog of the query node.
+func GetLocalCatalog() *LocalCatalog {
+	localCatalogOnce.Do(func() {
+		rootPath := filepath.Join(paramtable.Get().LocalStorageCfg.Path.GetValue(), typeutil.QueryNodeRole)
+		localCatalog = NewLocalCatalog(rootPath, paramtable.Get().QueryNodeCfg.MmapDirPath.GetValue())
+	})
+	return localCatalog
+}
+
+// LocalIndexEntry records a disk index cached on the local disk.
+type LocalIndexEntry struct {
+	CollectionID   int64            `json:"collection_id"`
+	PartitionID    int64            `json:"partition_id"`
+	SegmentID      int64            `json:"segment_id"`
+	FieldID        int64            `json:"field_id"`
+	BuildID        int64            `json:"build_id"`
+	IndexVersion   int64            `json:"index_version"`
+	IndexFilePaths []string         `json:"index_file_paths"`
+	Files          map[string]int64 `json:"files"`
+}
+
+// LocalMmapEntry records a field of sealed segment mmap-ed to the local disk.
+// Only the fixed width fields are kept by segcore, see keep_mmap_file of FieldDataInfo.
+type LocalMmapEntry struct {
+	CollectionID int64    `json:"collection_id"`
+	PartitionID  int64    `json:"partition_id"`
+	SegmentID    int64    `json:"segment_id"`
+	FieldID      int64    `json:"field_id"`
+	Binlogs      []string `json:"binlogs"`
+	File         string   `json:"file"`
+	Size         int64    `json:"size"`
+}
+
+type localMmapKey struct {
+	segmentID int64
+	fieldID   int64
+}
+
+// LocalCatalog persists the disk indexes and the mmap files of fields cached on the local disk,
+// so that a restarted query node on the same machine could reuse them instead of downloading again.
+// The entries recovered but not reused within the retention are removed with their files.
+type LocalCatalog struct {
+	mu       sync.Mutex
+	rootPath string
+	mmapPath string
+	entries  map[int64]*LocalIndexEntry
+	// recovered entries not reused yet
+	pending typeutil.UniqueSet
+
+	mmapEntries map[localMmapKey]*LocalMmapEntry
+	pendingMmap typeutil.Set[localMmapKey]
+}
+
+func NewLocalCatalog(rootPath string, mmapPath string) *LocalCatalog {
+	return &LocalCatalog{
+		rootPath:    rootPath,
+		mmapPath:    mmapPath,
+		entries:     make(map[int64]*LocalIndexEntry),
+		pending:     typeutil.NewUniqueSet(),
+		mmapEntries: make(map[localMmapKey]*LocalMmapEntry),
+		pendingMmap: typeutil.NewSet[localMmapKey](),
+	}
+}
+
+func (c *LocalCatalog) catalogPath() string {
+	return filepath.Join(c.rootPath, localCatalogDir)
+}
+
+func (c *LocalCatalog) entryPath(buildID int64) string {
+	return filepath.Join(c.catalogPath(), fmt.Sprintf("%d.json", buildID))
+}
+
+func (c *LocalCatalog) mmapCatalogPath() string {
+	return filepath.Join(c.catalogPath(), localMmapCatalogDir)
+}
+
+func (c *LocalCatalog) mmapEntryPath(key localMmapKey) string {
+	return filepath.Join(c.mmapCatalogPath(), fmt.Sprintf("%d_%d.json", key.segmentID, key.fieldID))
+}
+
+// mmapFilePath is the mmap file of the field written by segcore, see MapFieldData.
+func (c *LocalCatalog) mmapFilePath(key localMmapKey) string {
+	return filepath.Join(c.mmapPath, fmt.Sprint(key.segmentID), fmt.Sprint(key.fieldID))
+}
+
+func (c *LocalCatalog) indexPath(buildID int64) string {
+	return filepath.Join(c.rootPath, localIndexDir, fmt.Sprint(buildID))
+}
+
+func (c *LocalCatalog) indexVersionPath(buildID int64, indexVersion int64) string {
+	return filepath.Join(c.indexPath(buildID), fmt.Sprint(indexVersion))
+}
+
+// Recover loads the entries persisted, the entries with missing or modified files are dropped,
+// and the others not reused within the retention are removed with their files.
+func (c *LocalCatalog) Recover(retention time.Duration) error {
+	files, err := os.ReadDir(c.catalogPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	mmapFiles, err := os.ReadDir(c.mmapCatalogPath())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	c.mu.Lock()
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(c.catalogPath(), file.Name())
+		entry, err := readLocalIndexEntry(path)
+		if err != nil || !c.verify(entry) {
+			log.Warn("drop invalid local index entry", zap.String("path", path), zap.Error(err))
+			os.Remove(path)
+			if entry != nil {
+				os.RemoveAll(c.indexPath(entry.BuildID))
+			}
+			continue
+		}
+		c.entries[entry.BuildID] = entry
+		c.pending.Insert(entry.BuildID)
+	}
+	for _, file := range mmapFiles {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(c.mmapCatalogPath(), file.Name())
+		entry, err := readLocalMmapEntry(path)
+		if err != nil || !c.verifyMmap(entry) {
+			log.Warn("drop invalid local mmap entry", zap.String("path", path), zap.Error(err))
+			os.Remove(path)
+			if entry != nil {
+				os.Remove(entry.File)
+			}
+			continue
+		}
+		key := localMmapKey{segmentID: entry.SegmentID, fieldID: entry.FieldID}
+		c.mmapEntries[key] = entry
+		c.pendingMmap.Insert(key)
+	}
+	log.Info("recover local catalog done",
+		zap.Int("indexNum", len(c.entries)),
+		zap.Int("mmapNum", len(c.mmapEntries)))
+	c.mu.Unlock()
+
+	time.AfterFunc(retention, c.expire)
+	return nil
+}
+
+// expire removes the entries recovered but not reused.
+func (c *LocalCatalog) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, buildID := range c.pending.Collect() {
+		log.Info("remove expired local index", zap.Int64("buildID", buildID))
+		c.removeLocked(buildID, true)
+	}
+	c.pending.Clear()
+	for _, key := range c.pendingMmap.Collect() {
+		log.Info("remove expired local mmap file", zap.Int64("segmentID", key.segmentID), zap.Int64("fieldID", key.fieldID))
+		c.removeMmapLocked(key)
+	}
+	c.pendingMmap.Clear()
+}
+
+// verify checks the files of the entry are still on the local disk and not modified.
+func (c *LocalCatalog) verify(entry *LocalIndexEntry) bool {
+	if len(entry.Files) == 0 {
+		return false
+	}
+	for path, size := range entry.Files {
+		info, err := os.Stat(path)
+		if err != nil || info.Size() != size {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyMmap checks the mmap file of the entry is still on the local disk and not modified.
+func (c *LocalCatalog) verifyMmap(entry *LocalMmapEntry) bool {
+	info, err := os.Stat(entry.File)
+	return err == nil && info.Size() == entry.Size
+}
+
+// Reusable returns whether the local files of the index could be reused to load it.
+func (c *LocalCatalog) Reusable(indexInfo *querypb.FieldIndexInfo) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[indexInfo.GetBuildID()]
+	if !ok || entry.IndexVersion != indexInfo.GetIndexVersion() ||
+		!funcutil.SliceSetEqual(entry.IndexFilePaths, indexInfo.GetIndexFilePaths()) {
+		return false
+	}
+	return c.verify(entry)
+}
+
+// Save records the index of the segment loaded with the local files of segcore.
+func (c *LocalCatalog) Save(collectionID, partitionID, segmentID int64, indexInfo *querypb.FieldIndexInfo) error {
+	entry := &LocalIndexEntry{
+		CollectionID:   collectionID,
+		PartitionID:    partitionID,
+		SegmentID:      segmentID,
+		FieldID:        indexInfo.GetFieldID(),
+		BuildID:        indexInfo.GetBuildID(),
+		IndexVersion:   indexInfo.GetIndexVersion(),
+		IndexFilePaths: indexInfo.GetIndexFilePaths(),
+		Files:          make(map[string]int64),
+	}
+	err := filepath.Walk(c.indexVersionPath(entry.BuildID, entry.IndexVersion), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			entry.Files[path] = info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.catalogPath(), os.ModePerm); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(c.entryPath(entry.BuildID), data); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[entry.BuildID] = entry
+	c.pending.Remove(entry.BuildID)
+	return nil
+}
+
+// ListIndexFiles returns the files of the disk index cached on the local disk,
+// the paths are relative to the directory of the index version.
+func (c *LocalCatalog) ListIndexFiles(buildID int64, indexVersion int64) ([]*querypb.IndexFile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[buildID]
+	if !ok || entry.IndexVersion != indexVersion || !c.verify(entry) {
+		return nil, merr.WrapErrIoKeyNotFound(fmt.Sprintf("%d/%d", buildID, indexVersion), "disk index not cached")
+	}
+	dir := c.indexVersionPath(buildID, indexVersion)
+	files := make([]*querypb.IndexFile, 0, len(entry.Files))
+	for path, size := range entry.Files {
+		file, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &querypb.IndexFile{File: file, Size: size})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].GetFile() < files[j].GetFile() })
+	return files, nil
+}
+
+// ReadIndexFile reads the chunk of the disk index file cached on the local disk,
+// only the files recorded in the catalog could be read.
+func (c *LocalCatalog) ReadIndexFile(buildID int64, indexVersion int64, file string, offset int64, size int64) ([]byte, error) {
+	path := filepath.Join(c.indexVersionPath(buildID, indexVersion), file)
+	c.mu.Lock()
+	var fileSize int64
+	entry, ok := c.entries[buildID]
+	if ok && entry.IndexVersion == indexVersion {
+		fileSize, ok = entry.Files[path]
+	} else {
+		ok = false
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, merr.WrapErrIoKeyNotFound(path, "disk index file not cached")
+	}
+	if offset < 0 || size <= 0 || offset+size > fileSize {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid range [%d, %d) of file with size %d", offset, offset+size, fileSize)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, merr.WrapErrIoFailed(path, err)
+	}
+	defer f.Close()
+	data := make([]byte, size)
+	if _, err := f.ReadAt(data, offset); err != nil {
+		return nil, merr.WrapErrIoFailed(path, err)
+	}
+	return data, nil
+}
+
+// MmapReusable returns whether the mmap file of the field could be reused to load it.
+func (c *LocalCatalog) MmapReusable(segmentID int64, field *datapb.FieldBinlog) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.mmapEntries[localMmapKey{segmentID: segmentID, fieldID: field.GetFieldID()}]
+	if !ok || !funcutil.SliceSetEqual(entry.Binlogs, getBinlogPaths(field)) {
+		return false
+	}
+	return c.verifyMmap(entry)
+}
+
+// SaveMmap records the field of the segment mmap-ed with the local file kept by segcore,
+// nothing is recorded if the file is not kept, e.g. the field is variable length.
+func (c *LocalCatalog) SaveMmap(collectionID, partitionID, segmentID int64, field *datapb.FieldBinlog) error {
+	key := localMmapKey{segmentID: segmentID, fieldID: field.GetFieldID()}
+	info, err := os.Stat(c.mmapFilePath(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	entry := &LocalMmapEntry{
+		CollectionID: collectionID,
+		PartitionID:  partitionID,
+		SegmentID:    segmentID,
+		FieldID:      field.GetFieldID(),
+		Binlogs:      getBinlogPaths(field),
+		File:         c.mmapFilePath(key),
+		Size:         info.Size(),
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.mmapCatalogPath(), os.ModePerm); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(c.mmapEntryPath(key), data); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mmapEntries[key] = entry
+	c.pendingMmap.Remove(key)
+	return nil
+}
+
+// RemoveSegment removes the entries of the segment released,
+// the local index files are removed by segcore while releasing the segment,
+// and the mmap files kept for recovery are removed here.
+func (c *LocalCatalog) RemoveSegment(segmentID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for buildID, entry := range c.entries {
+		if entry.SegmentID == segmentID {
+			c.removeLocked(buildID, false)
+		}
+	}
+	for key := range c.mmapEntries {
+		if key.segmentID == segmentID {
+			c.removeMmapLocked(key)
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(c.mmapPath, fmt.Sprint(segmentID))); err != nil {
+		log.Warn("failed to remove local mmap files", zap.Int64("segmentID", segmentID), zap.Error(err))
+	}
+}
+
+func (c *LocalCatalog) removeLocked(buildID int64, removeFiles bool) {
+	delete(c.entries, buildID)
+	c.pending.Remove(buildID)
+	if err := os.Remove(c.entryPath(buildID)); err != nil && !os.IsNotExist(err) {
+		log.Warn("failed to remove local index entry", zap.Int64("buildID", buildID), zap.Error(err))
+	}
+	if removeFiles {
+		if err := os.RemoveAll(c.indexPath(buildID)); err != nil {
+			log.Warn("failed to remove local index files", zap.Int64("buildID", buildID), zap.Error(err))
+		}
+	}
+}
+
+func (c *LocalCatalog) removeMmapLocked(key localMmapKey) {
+	delete(c.mmapEntries, key)
+	c.pendingMmap.Remove(key)
+	if err := os.Remove(c.mmapEntryPath(key)); err != nil && !os.IsNotExist(err) {
+		log.Warn("failed to remove local mmap entry", zap.Int64("segmentID", key.segmentID), zap.Int64("fieldID", key.fieldID), zap.Error(err))
+	}
+	if err := os.Remove(c.mmapFilePath(key)); err != nil && !os.IsNotExist(err) {
+		log.Warn("failed to remove local mmap file", zap.Int64("segmentID", key.segmentID), zap.Int64("fieldID", key.fieldID), zap.Error(err))
+	}
+}
+
+func getBinlogPaths(field *datapb.FieldBinlog) []string {
+	paths := make([]string, 0, len(field.GetBinlogs()))
+	for _, binlog := range field.GetBinlogs() {
+		paths = append(paths, binlog.GetLogPath())
+	}
+	return paths
+}
+
+// writeFileAtomic writes to a temp file then renames it, so the entry is never partially written.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func readLocalMmapEntry(path string) (*LocalMmapEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entry := &LocalMmapEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func readLocalIndexEntry(path string) (*LocalIndexEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entry := &LocalIndexEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
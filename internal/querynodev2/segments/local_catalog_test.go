@@ -0,0 +1,264 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type LocalCatalogSuite struct {
+	suite.Suite
+
+	rootPath  string
+	mmapPath  string
+	catalog   *LocalCatalog
+	indexInfo *querypb.FieldIndexInfo
+	binlog    *datapb.FieldBinlog
+}
+
+func (suite *LocalCatalogSuite) SetupTest() {
+	suite.rootPath = suite.T().TempDir()
+	suite.mmapPath = suite.T().TempDir()
+	suite.catalog = NewLocalCatalog(suite.rootPath, suite.mmapPath)
+	suite.indexInfo = &querypb.FieldIndexInfo{
+		FieldID:        101,
+		BuildID:        1000,
+		IndexVersion:   1,
+		IndexFilePaths: []string{"files/index_files/1000/1/2/3/index_0", "files/index_files/1000/1/2/3/index_1"},
+	}
+	suite.writeIndexFile("index", 1024)
+	suite.binlog = &datapb.FieldBinlog{
+		FieldID: 102,
+		Binlogs: []*datapb.Binlog{{LogPath: "files/insert_log/1/2/3/102/1"}, {LogPath: "files/insert_log/1/2/3/102/2"}},
+	}
+}
+
+func (suite *LocalCatalogSuite) writeMmapFile(size int) string {
+	path := suite.catalog.mmapFilePath(localMmapKey{segmentID: 3, fieldID: suite.binlog.GetFieldID()})
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	suite.Require().NoError(os.WriteFile(path, make([]byte, size), 0o644))
+	return path
+}
+
+func (suite *LocalCatalogSuite) writeIndexFile(name string, size int) string {
+	dir := suite.catalog.indexVersionPath(suite.indexInfo.GetBuildID(), suite.indexInfo.GetIndexVersion())
+	suite.Require().NoError(os.MkdirAll(dir, os.ModePerm))
+	path := filepath.Join(dir, name)
+	suite.Require().NoError(os.WriteFile(path, make([]byte, size), 0o644))
+	return path
+}
+
+func (suite *LocalCatalogSuite) TestSaveAndRecover() {
+	suite.False(suite.catalog.Reusable(suite.indexInfo))
+	suite.NoError(suite.catalog.Save(1, 2, 3, suite.indexInfo))
+	suite.True(suite.catalog.Reusable(suite.indexInfo))
+
+	// the index changed
+	rebuilt := &querypb.FieldIndexInfo{
+		FieldID:        suite.indexInfo.GetFieldID(),
+		BuildID:        suite.indexInfo.GetBuildID(),
+		IndexVersion:   2,
+		IndexFilePaths: suite.indexInfo.GetIndexFilePaths(),
+	}
+	suite.False(suite.catalog.Reusable(rebuilt))
+
+	// restart
+	catalog := NewLocalCatalog(suite.rootPath, suite.mmapPath)
+	suite.NoError(catalog.Recover(time.Hour))
+	suite.True(catalog.Reusable(suite.indexInfo))
+	suite.True(catalog.pending.Contain(suite.indexInfo.GetBuildID()))
+
+	// reused after loaded again
+	suite.NoError(catalog.Save(1, 2, 3, suite.indexInfo))
+	suite.False(catalog.pending.Contain(suite.indexInfo.GetBuildID()))
+
+	catalog.RemoveSegment(3)
+	suite.False(catalog.Reusable(suite.indexInfo))
+	suite.NoFileExists(catalog.entryPath(suite.indexInfo.GetBuildID()))
+}
+
+func (suite *LocalCatalogSuite) TestRecoverModified() {
+	suite.NoError(suite.catalog.Save(1, 2, 3, suite.indexInfo))
+	suite.writeIndexFile("index", 512)
+
+	catalog := NewLocalCatalog(suite.rootPath, suite.mmapPath)
+	suite.NoError(catalog.Recover(time.Hour))
+	suite.False(catalog.Reusable(suite.indexInfo))
+	suite.NoFileExists(catalog.entryPath(suite.indexInfo.GetBuildID()))
+	suite.NoDirExists(catalog.indexPath(suite.indexInfo.GetBuildID()))
+}
+
+func (suite *LocalCatalogSuite) TestExpire() {
+	suite.NoError(suite.catalog.Save(1, 2, 3, suite.indexInfo))
+
+	catalog := NewLocalCatalog(suite.rootPath, suite.mmapPath)
+	suite.NoError(catalog.Recover(10 * time.Millisecond))
+	suite.Eventually(func() bool {
+		return !catalog.Reusable(suite.indexInfo)
+	}, time.Second, 10*time.Millisecond)
+	suite.NoDirExists(catalog.indexPath(suite.indexInfo.GetBuildID()))
+}
+
+func (suite *LocalCatalogSuite) TestMmap() {
+	// not kept by segcore
+	suite.NoError(suite.catalog.SaveMmap(1, 2, 3, suite.binlog))
+	suite.False(suite.catalog.MmapReusable(3, suite.binlog))
+
+	path := suite.writeMmapFile(1024)
+	suite.NoError(suite.catalog.SaveMmap(1, 2, 3, suite.binlog))
+	suite.True(suite.catalog.MmapReusable(3, suite.binlog))
+
+	// the binlogs changed
+	compacted := &datapb.FieldBinlog{
+		FieldID: suite.binlog.GetFieldID(),
+		Binlogs: []*datapb.Binlog{{LogPath: "files/insert_log/1/2/3/102/3"}},
+	}
+	suite.False(suite.catalog.MmapReusable(3, compacted))
+
+	// restart
+	catalog := NewLocalCatalog(suite.rootPath, suite.mmapPath)
+	suite.NoError(catalog.Recover(time.Hour))
+	suite.True(catalog.MmapReusable(3, suite.binlog))
+	suite.True(catalog.pendingMmap.Contain(localMmapKey{segmentID: 3, fieldID: suite.binlog.GetFieldID()}))
+
+	// reused after loaded again
+	suite.NoError(catalog.SaveMmap(1, 2, 3, suite.binlog))
+	suite.False(catalog.pendingMmap.Contain(localMmapKey{segmentID: 3, fieldID: suite.binlog.GetFieldID()}))
+
+	catalog.RemoveSegment(3)
+	suite.False(catalog.MmapReusable(3, suite.binlog))
+	suite.NoFileExists(path)
+}
+
+func (suite *LocalCatalogSuite) TestMmapRecoverModified() {
+	suite.writeMmapFile(1024)
+	suite.NoError(suite.catalog.SaveMmap(1, 2, 3, suite.binlog))
+	path := suite.writeMmapFile(512)
+
+	catalog := NewLocalCatalog(suite.rootPath, suite.mmapPath)
+	suite.NoError(catalog.Recover(time.Hour))
+	suite.False(catalog.MmapReusable(3, suite.binlog))
+	suite.NoFileExists(path)
+}
+
+func (suite *LocalCatalogSuite) TestMmapExpire() {
+	path := suite.writeMmapFile(1024)
+	suite.NoError(suite.catalog.SaveMmap(1, 2, 3, suite.binlog))
+
+	catalog := NewLocalCatalog(suite.rootPath, suite.mmapPath)
+	suite.NoError(catalog.Recover(10 * time.Millisecond))
+	suite.Eventually(func() bool {
+		return !catalog.MmapReusable(3, suite.binlog)
+	}, time.Second, 10*time.Millisecond)
+	suite.NoFileExists(path)
+}
+
+func (suite *LocalCatalogSuite) TestRecoverEmpty() {
+	suite.NoError(suite.catalog.Recover(time.Hour))
+}
+
+func (suite *LocalCatalogSuite) TestFetchIndexFromPeer() {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.IndexPeerFetchEnabled.Key, "true")
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.IndexPeerFetchChunkSize.Key, "1")
+	defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.IndexPeerFetchEnabled.Key)
+	defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.IndexPeerFetchChunkSize.Key)
+
+	// the peer serves the index loaded
+	content := make([]byte, 1536*1024)
+	for i := range content {
+		content[i] = byte(i)
+	}
+	dir := suite.catalog.indexVersionPath(suite.indexInfo.GetBuildID(), suite.indexInfo.GetIndexVersion())
+	suite.Require().NoError(os.MkdirAll(filepath.Join(dir, "sub"), os.ModePerm))
+	suite.Require().NoError(os.WriteFile(filepath.Join(dir, "sub", "data"), content, 0o644))
+	suite.NoError(suite.catalog.Save(1, 2, 3, suite.indexInfo))
+
+	files, err := suite.catalog.ListIndexFiles(suite.indexInfo.GetBuildID(), suite.indexInfo.GetIndexVersion())
+	suite.NoError(err)
+	suite.Equal([]*querypb.IndexFile{{File: "index", Size: 1024}, {File: filepath.Join("sub", "data"), Size: int64(len(content))}}, files)
+	_, err = suite.catalog.ListIndexFiles(suite.indexInfo.GetBuildID(), 2)
+	suite.ErrorIs(err, merr.ErrIoKeyNotFound)
+	_, err = suite.catalog.ReadIndexFile(suite.indexInfo.GetBuildID(), suite.indexInfo.GetIndexVersion(), "../../catalog/1000.json", 0, 10)
+	suite.ErrorIs(err, merr.ErrIoKeyNotFound)
+	_, err = suite.catalog.ReadIndexFile(suite.indexInfo.GetBuildID(), suite.indexInfo.GetIndexVersion(), "index", 1000, 100)
+	suite.ErrorIs(err, merr.ErrParameterInvalid)
+
+	corrupt := false
+	fetcher := func(ctx context.Context, nodeID int64, req *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error) {
+		if nodeID != 10 {
+			return nil, merr.ErrNodeNotFound
+		}
+		if req.GetFile() == "" {
+			files, err := suite.catalog.ListIndexFiles(req.GetBuildID(), req.GetIndexVersion())
+			return &querypb.FetchIndexFileResponse{Status: merr.Status(err), Files: files}, nil
+		}
+		data, err := suite.catalog.ReadIndexFile(req.GetBuildID(), req.GetIndexVersion(), req.GetFile(), req.GetOffset(), req.GetSize())
+		checksum := crc32.Checksum(data, IndexFileCRCTable)
+		if corrupt {
+			checksum++
+		}
+		return &querypb.FetchIndexFileResponse{Status: merr.Status(err), Data: data, Checksum: checksum}, nil
+	}
+
+	// the loading node
+	localCatalogOnce.Do(func() {})
+	backup := localCatalog
+	defer func() { localCatalog = backup }()
+	localCatalog = NewLocalCatalog(suite.T().TempDir(), suite.T().TempDir())
+
+	loader := &segmentLoader{}
+	loader.SetIndexFileFetcher(fetcher)
+	segment := &LocalSegment{baseSegment: baseSegment{segmentID: 3, partitionID: 2, collectionID: 1}}
+	indexInfo := &querypb.FieldIndexInfo{
+		FieldID:        suite.indexInfo.GetFieldID(),
+		BuildID:        suite.indexInfo.GetBuildID(),
+		IndexVersion:   suite.indexInfo.GetIndexVersion(),
+		IndexFilePaths: suite.indexInfo.GetIndexFilePaths(),
+		IndexParams:    []*commonpb.KeyValuePair{{Key: "index_type", Value: indexparamcheck.IndexDISKANN}},
+	}
+
+	corrupt = true
+	suite.False(loader.fetchIndexFromPeers(context.Background(), segment, indexInfo, []int64{10}))
+	suite.False(localCatalog.Reusable(indexInfo))
+	suite.NoDirExists(localCatalog.indexVersionPath(indexInfo.GetBuildID(), indexInfo.GetIndexVersion()) + ".fetching")
+
+	corrupt = false
+	suite.True(loader.fetchIndexFromPeers(context.Background(), segment, indexInfo, []int64{11, 10}))
+	suite.True(localCatalog.Reusable(indexInfo))
+	fetched, err := os.ReadFile(filepath.Join(localCatalog.indexVersionPath(indexInfo.GetBuildID(), indexInfo.GetIndexVersion()), "sub", "data"))
+	suite.NoError(err)
+	suite.Equal(content, fetched)
+}
+
+func TestLocalCatalog(t *testing.T) {
+	suite.Run(t, new(LocalCatalogSuite))
+}
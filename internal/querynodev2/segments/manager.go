@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"sync"
 
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/proto/datapb"
@@ -109,12 +110,14 @@ const (
 type Manager struct {
 	Collection CollectionManager
 	Segment    SegmentManager
+	Access     *AccessTracker
 }
 
 func NewManager() *Manager {
 	return &Manager{
 		Collection: NewCollectionManager(),
 		Segment:    NewSegmentManager(),
+		Access:     NewAccessTracker(),
 	}
 }
 
@@ -146,32 +149,86 @@ type SegmentManager interface {
 
 var _ SegmentManager = (*segmentManager)(nil)
 
-// Manager manages all collections and segments
-type segmentManager struct {
-	mu sync.RWMutex // guards all
-
+// segmentSnapshot is an immutable view of the segments,
+// a new snapshot with increased epoch is published by each load and release.
+type segmentSnapshot struct {
+	epoch           int64
 	growingSegments map[UniqueID]Segment
 	sealedSegments  map[UniqueID]Segment
 }
 
+// clone returns the next snapshot, only the segments of the given types are copied to be modified,
+// the others are shared with the current snapshot as they are immutable.
+func (s *segmentSnapshot) clone(types ...SegmentType) *segmentSnapshot {
+	next := &segmentSnapshot{
+		epoch:           s.epoch + 1,
+		growingSegments: s.growingSegments,
+		sealedSegments:  s.sealedSegments,
+	}
+	for _, typ := range types {
+		switch typ {
+		case SegmentTypeGrowing:
+			next.growingSegments = copySegments(s.growingSegments)
+		case SegmentTypeSealed:
+			next.sealedSegments = copySegments(s.sealedSegments)
+		}
+	}
+	return next
+}
+
+func copySegments(segments map[UniqueID]Segment) map[UniqueID]Segment {
+	copied := make(map[UniqueID]Segment, len(segments))
+	for id, segment := range segments {
+		copied[id] = segment
+	}
+	return copied
+}
+
+func (s *segmentSnapshot) segmentsOf(typ SegmentType) map[UniqueID]Segment {
+	switch typ {
+	case SegmentTypeGrowing:
+		return s.growingSegments
+	case SegmentTypeSealed:
+		return s.sealedSegments
+	default:
+		return nil
+	}
+}
+
+// Manager manages all collections and segments
+//
+// The readers load the current snapshot without any lock, so searches never wait for loads and releases,
+// the writers are serialized by mu, and publish a new snapshot as the fence of the change.
+// The segments removed from the snapshot are released after the in-flight readers unpin them,
+// and the readers pinning them after the fence fail fast, see LocalSegment.Release.
+type segmentManager struct {
+	mu       sync.Mutex // serializes the writers
+	snapshot atomic.Pointer[segmentSnapshot]
+}
+
 func NewSegmentManager() *segmentManager {
-	return &segmentManager{
+	mgr := &segmentManager{}
+	mgr.snapshot.Store(&segmentSnapshot{
 		growingSegments: make(map[int64]Segment),
 		sealedSegments:  make(map[int64]Segment),
-	}
+	})
+	return mgr
+}
+
+// Epoch returns the epoch of current snapshot, which increases on each load and release.
+func (mgr *segmentManager) Epoch() int64 {
+	return mgr.snapshot.Load().epoch
 }
 
+// Put publishes the segments in one snapshot, the segments loaded together should be put in batch,
+// as each call copies the segments of the type.
 func (mgr *segmentManager) Put(segmentType SegmentType, segments ...Segment) {
 	var replacedSegment []Segment
 	mgr.mu.Lock()
 	defer mgr.mu.Unlock()
-	var targetMap map[int64]Segment
-	switch segmentType {
-	case SegmentTypeGrowing:
-		targetMap = mgr.growingSegments
-	case SegmentTypeSealed:
-		targetMap = mgr.sealedSegments
-	default:
+	next := mgr.snapshot.Load().clone(segmentType)
+	targetMap := next.segmentsOf(segmentType)
+	if targetMap == nil {
 		panic("unexpected segment type")
 	}
 
@@ -196,7 +253,7 @@ func (mgr *segmentManager) Put(segmentType SegmentType, segments ...Segment) {
 		eventlog.Record(eventlog.NewRawEvt(eventlog.Level_Info, fmt.Sprintf("Segment %d[%d] loaded", segment.ID(), segment.Collection())))
 		metrics.QueryNodeNumSegments.WithLabelValues(
 			fmt.Sprint(paramtable.GetNodeID()),
-			fmt.Sprint(segment.Collection()),
+			metrics.CollectionIDLabel(segment.Collection()),
 			fmt.Sprint(segment.Partition()),
 			segment.Type().String(),
 			fmt.Sprint(len(segment.Indexes())),
@@ -205,14 +262,15 @@ func (mgr *segmentManager) Put(segmentType SegmentType, segments ...Segment) {
 		if segment.RowNum() > 0 {
 			metrics.QueryNodeNumEntities.WithLabelValues(
 				fmt.Sprint(paramtable.GetNodeID()),
-				fmt.Sprint(segment.Collection()),
+				metrics.CollectionIDLabel(segment.Collection()),
 				fmt.Sprint(segment.Partition()),
 				segment.Type().String(),
 				fmt.Sprint(len(segment.Indexes())),
 			).Add(float64(segment.RowNum()))
 		}
 	}
-	mgr.updateMetric()
+	mgr.snapshot.Store(next)
+	mgr.updateMetric(next)
 
 	// release replaced segment
 	if len(replacedSegment) > 0 {
@@ -225,11 +283,10 @@ func (mgr *segmentManager) Put(segmentType SegmentType, segments ...Segment) {
 }
 
 func (mgr *segmentManager) UpdateBy(action SegmentAction, filters ...SegmentFilter) int {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
+	snapshot := mgr.snapshot.Load()
 
 	updated := 0
-	for _, segment := range mgr.growingSegments {
+	for _, segment := range snapshot.growingSegments {
 		if filter(segment, filters...) {
 			if action(segment) {
 				updated++
@@ -237,7 +294,7 @@ func (mgr *segmentManager) UpdateBy(action SegmentAction, filters ...SegmentFilt
 		}
 	}
 
-	for _, segment := range mgr.sealedSegments {
+	for _, segment := range snapshot.sealedSegments {
 		if filter(segment, filters...) {
 			if action(segment) {
 				updated++
@@ -248,12 +305,11 @@ func (mgr *segmentManager) UpdateBy(action SegmentAction, filters ...SegmentFilt
 }
 
 func (mgr *segmentManager) Get(segmentID UniqueID) Segment {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
+	snapshot := mgr.snapshot.Load()
 
-	if segment, ok := mgr.growingSegments[segmentID]; ok {
+	if segment, ok := snapshot.growingSegments[segmentID]; ok {
 		return segment
-	} else if segment, ok = mgr.sealedSegments[segmentID]; ok {
+	} else if segment, ok = snapshot.sealedSegments[segmentID]; ok {
 		return segment
 	}
 
@@ -261,31 +317,24 @@ func (mgr *segmentManager) Get(segmentID UniqueID) Segment {
 }
 
 func (mgr *segmentManager) GetWithType(segmentID UniqueID, typ SegmentType) Segment {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
-
-	switch typ {
-	case SegmentTypeSealed:
-		return mgr.sealedSegments[segmentID]
-	case SegmentTypeGrowing:
-		return mgr.growingSegments[segmentID]
-	default:
+	segments := mgr.snapshot.Load().segmentsOf(typ)
+	if segments == nil {
 		return nil
 	}
+	return segments[segmentID]
 }
 
 func (mgr *segmentManager) GetBy(filters ...SegmentFilter) []Segment {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
+	snapshot := mgr.snapshot.Load()
 
 	ret := make([]Segment, 0)
-	for _, segment := range mgr.growingSegments {
+	for _, segment := range snapshot.growingSegments {
 		if filter(segment, filters...) {
 			ret = append(ret, segment)
 		}
 	}
 
-	for _, segment := range mgr.sealedSegments {
+	for _, segment := range snapshot.sealedSegments {
 		if filter(segment, filters...) {
 			ret = append(ret, segment)
 		}
@@ -293,9 +342,10 @@ func (mgr *segmentManager) GetBy(filters ...SegmentFilter) []Segment {
 	return ret
 }
 
+// GetAndPinBy pins the segments of the current snapshot matching the filters,
+// the segments removed after the snapshot loaded fail to be pinned like GetAndPin.
 func (mgr *segmentManager) GetAndPinBy(filters ...SegmentFilter) ([]Segment, error) {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
+	snapshot := mgr.snapshot.Load()
 
 	ret := make([]Segment, 0)
 	var err error
@@ -307,7 +357,7 @@ func (mgr *segmentManager) GetAndPinBy(filters ...SegmentFilter) ([]Segment, err
 		}
 	}()
 
-	for _, segment := range mgr.growingSegments {
+	for _, segment := range snapshot.growingSegments {
 		if filter(segment, filters...) {
 			err = segment.RLock()
 			if err != nil {
@@ -317,7 +367,7 @@ func (mgr *segmentManager) GetAndPinBy(filters ...SegmentFilter) ([]Segment, err
 		}
 	}
 
-	for _, segment := range mgr.sealedSegments {
+	for _, segment := range snapshot.sealedSegments {
 		if segment.Level() != datapb.SegmentLevel_L0 && filter(segment, filters...) {
 			err = segment.RLock()
 			if err != nil {
@@ -329,10 +379,16 @@ func (mgr *segmentManager) GetAndPinBy(filters ...SegmentFilter) ([]Segment, err
 	return ret, nil
 }
 
+// GetAndPin pins the segments of the current snapshot.
+//
+// The snapshot may be replaced by a Remove after it's loaded, the removed segments are still found in the snapshot,
+// but they are fenced before released, so pinning them fails with ErrSegmentNotLoaded instead of reading released data,
+// and the caller retries with the new distribution. The segments pinned before the fence are released after unpinned.
 func (mgr *segmentManager) GetAndPin(segments []int64, filters ...SegmentFilter) ([]Segment, error) {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
+	return mgr.getAndPin(mgr.snapshot.Load(), segments, filters...)
+}
 
+func (mgr *segmentManager) getAndPin(snapshot *segmentSnapshot, segments []int64, filters ...SegmentFilter) ([]Segment, error) {
 	lockedSegments := make([]Segment, 0, len(segments))
 	var err error
 	defer func() {
@@ -344,8 +400,8 @@ func (mgr *segmentManager) GetAndPin(segments []int64, filters ...SegmentFilter)
 	}()
 
 	for _, id := range segments {
-		growing, growingExist := mgr.growingSegments[id]
-		sealed, sealedExist := mgr.sealedSegments[id]
+		growing, growingExist := snapshot.growingSegments[id]
+		sealed, sealedExist := snapshot.sealedSegments[id]
 
 		// L0 Segment should not be queryable.
 		if sealedExist && sealed.Level() == datapb.SegmentLevel_L0 {
@@ -394,10 +450,7 @@ func filter(segment Segment, filters ...SegmentFilter) bool {
 }
 
 func (mgr *segmentManager) GetSealed(segmentID UniqueID) Segment {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
-
-	if segment, ok := mgr.sealedSegments[segmentID]; ok {
+	if segment, ok := mgr.snapshot.Load().sealedSegments[segmentID]; ok {
 		return segment
 	}
 
@@ -405,10 +458,7 @@ func (mgr *segmentManager) GetSealed(segmentID UniqueID) Segment {
 }
 
 func (mgr *segmentManager) GetGrowing(segmentID UniqueID) Segment {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
-
-	if segment, ok := mgr.growingSegments[segmentID]; ok {
+	if segment, ok := mgr.snapshot.Load().growingSegments[segmentID]; ok {
 		return segment
 	}
 
@@ -416,10 +466,9 @@ func (mgr *segmentManager) GetGrowing(segmentID UniqueID) Segment {
 }
 
 func (mgr *segmentManager) Empty() bool {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
+	snapshot := mgr.snapshot.Load()
 
-	return len(mgr.growingSegments)+len(mgr.sealedSegments) == 0
+	return len(snapshot.growingSegments)+len(snapshot.sealedSegments) == 0
 }
 
 // returns true if the segment exists,
@@ -429,31 +478,43 @@ func (mgr *segmentManager) Remove(segmentID UniqueID, scope querypb.DataScope) (
 
 	var removeGrowing, removeSealed int
 	var growing, sealed Segment
+	var next *segmentSnapshot
 	switch scope {
 	case querypb.DataScope_Streaming:
-		growing = mgr.removeSegmentWithType(SegmentTypeGrowing, segmentID)
+		next = mgr.snapshot.Load().clone(SegmentTypeGrowing)
+	case querypb.DataScope_Historical:
+		next = mgr.snapshot.Load().clone(SegmentTypeSealed)
+	default:
+		next = mgr.snapshot.Load().clone(SegmentTypeGrowing, SegmentTypeSealed)
+	}
+	switch scope {
+	case querypb.DataScope_Streaming:
+		growing = next.removeSegmentWithType(SegmentTypeGrowing, segmentID)
 		if growing != nil {
 			removeGrowing = 1
 		}
 
 	case querypb.DataScope_Historical:
-		sealed = mgr.removeSegmentWithType(SegmentTypeSealed, segmentID)
+		sealed = next.removeSegmentWithType(SegmentTypeSealed, segmentID)
 		if sealed != nil {
 			removeSealed = 1
 		}
 
 	case querypb.DataScope_All:
-		growing = mgr.removeSegmentWithType(SegmentTypeGrowing, segmentID)
+		growing = next.removeSegmentWithType(SegmentTypeGrowing, segmentID)
 		if growing != nil {
 			removeGrowing = 1
 		}
 
-		sealed = mgr.removeSegmentWithType(SegmentTypeSealed, segmentID)
+		sealed = next.removeSegmentWithType(SegmentTypeSealed, segmentID)
 		if sealed != nil {
 			removeSealed = 1
 		}
 	}
-	mgr.updateMetric()
+	if growing != nil || sealed != nil {
+		mgr.snapshot.Store(next)
+		mgr.updateMetric(next)
+	}
 	mgr.mu.Unlock()
 
 	if growing != nil {
@@ -467,25 +528,15 @@ func (mgr *segmentManager) Remove(segmentID UniqueID, scope querypb.DataScope) (
 	return removeGrowing, removeSealed
 }
 
-func (mgr *segmentManager) removeSegmentWithType(typ SegmentType, segmentID UniqueID) Segment {
-	switch typ {
-	case SegmentTypeGrowing:
-		s, ok := mgr.growingSegments[segmentID]
-		if ok {
-			delete(mgr.growingSegments, segmentID)
-			return s
-		}
-
-	case SegmentTypeSealed:
-		s, ok := mgr.sealedSegments[segmentID]
-		if ok {
-			delete(mgr.sealedSegments, segmentID)
-			return s
-		}
-	default:
+func (s *segmentSnapshot) removeSegmentWithType(typ SegmentType, segmentID UniqueID) Segment {
+	segments := s.segmentsOf(typ)
+	if segments == nil {
 		return nil
 	}
-
+	if segment, ok := segments[segmentID]; ok {
+		delete(segments, segmentID)
+		return segment
+	}
 	return nil
 }
 
@@ -493,24 +544,37 @@ func (mgr *segmentManager) RemoveBy(filters ...SegmentFilter) (int, int) {
 	mgr.mu.Lock()
 
 	var removeGrowing, removeSealed []Segment
-	for id, segment := range mgr.growingSegments {
+	current := mgr.snapshot.Load()
+	for _, segment := range current.growingSegments {
 		if filter(segment, filters...) {
-			s := mgr.removeSegmentWithType(SegmentTypeGrowing, id)
-			if s != nil {
-				removeGrowing = append(removeGrowing, s)
-			}
+			removeGrowing = append(removeGrowing, segment)
 		}
 	}
-
-	for id, segment := range mgr.sealedSegments {
+	for _, segment := range current.sealedSegments {
 		if filter(segment, filters...) {
-			s := mgr.removeSegmentWithType(SegmentTypeSealed, id)
-			if s != nil {
-				removeSealed = append(removeSealed, s)
-			}
+			removeSealed = append(removeSealed, segment)
 		}
 	}
-	mgr.updateMetric()
+
+	// only the segments of the types removed from are copied
+	types := make([]SegmentType, 0, 2)
+	if len(removeGrowing) > 0 {
+		types = append(types, SegmentTypeGrowing)
+	}
+	if len(removeSealed) > 0 {
+		types = append(types, SegmentTypeSealed)
+	}
+	if len(types) > 0 {
+		next := current.clone(types...)
+		for _, segment := range removeGrowing {
+			next.removeSegmentWithType(SegmentTypeGrowing, segment.ID())
+		}
+		for _, segment := range removeSealed {
+			next.removeSegmentWithType(SegmentTypeSealed, segment.ID())
+		}
+		mgr.snapshot.Store(next)
+		mgr.updateMetric(next)
+	}
 	mgr.mu.Unlock()
 
 	for _, s := range removeGrowing {
@@ -528,26 +592,32 @@ func (mgr *segmentManager) Clear() {
 	mgr.mu.Lock()
 	defer mgr.mu.Unlock()
 
-	for id, segment := range mgr.growingSegments {
-		delete(mgr.growingSegments, id)
+	old := mgr.snapshot.Load()
+	next := &segmentSnapshot{
+		epoch:           old.epoch + 1,
+		growingSegments: make(map[int64]Segment),
+		sealedSegments:  make(map[int64]Segment),
+	}
+	mgr.snapshot.Store(next)
+
+	for _, segment := range old.growingSegments {
 		remove(segment)
 	}
 
-	for id, segment := range mgr.sealedSegments {
-		delete(mgr.sealedSegments, id)
+	for _, segment := range old.sealedSegments {
 		remove(segment)
 	}
-	mgr.updateMetric()
+	mgr.updateMetric(next)
 }
 
-func (mgr *segmentManager) updateMetric() {
+func (mgr *segmentManager) updateMetric(snapshot *segmentSnapshot) {
 	// update collection and partiation metric
 	collections, partiations := make(Set[int64]), make(Set[int64])
-	for _, seg := range mgr.growingSegments {
+	for _, seg := range snapshot.growingSegments {
 		collections.Insert(seg.Collection())
 		partiations.Insert(seg.Partition())
 	}
-	for _, seg := range mgr.sealedSegments {
+	for _, seg := range snapshot.sealedSegments {
 		collections.Insert(seg.Collection())
 		partiations.Insert(seg.Partition())
 	}
@@ -561,7 +631,7 @@ func remove(segment Segment) bool {
 
 	metrics.QueryNodeNumSegments.WithLabelValues(
 		fmt.Sprint(paramtable.GetNodeID()),
-		fmt.Sprint(segment.Collection()),
+		metrics.CollectionIDLabel(segment.Collection()),
 		fmt.Sprint(segment.Partition()),
 		segment.Type().String(),
 		fmt.Sprint(len(segment.Indexes())),
@@ -570,7 +640,7 @@ func remove(segment Segment) bool {
 	if rowNum > 0 {
 		metrics.QueryNodeNumEntities.WithLabelValues(
 			fmt.Sprint(paramtable.GetNodeID()),
-			fmt.Sprint(segment.Collection()),
+			metrics.CollectionIDLabel(segment.Collection()),
 			fmt.Sprint(segment.Partition()),
 			segment.Type().String(),
 			fmt.Sprint(len(segment.Indexes())),
@@ -0,0 +1,165 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const benchSegmentNum = 64
+
+func newBenchSegments(b *testing.B, idOffset int64, num int) []Segment {
+	schema := GenTestCollectionSchema("manager-bench", schemapb.DataType_Int64)
+	collection := NewCollection(1, schema, GenTestIndexMeta(1, schema), querypb.LoadType_LoadCollection)
+	segments := make([]Segment, 0, num)
+	for i := 0; i < num; i++ {
+		segment, err := NewSegment(collection, idOffset+int64(i), 10, 1, "dml", SegmentTypeSealed, 0, nil, nil, datapb.SegmentLevel_L1)
+		if err != nil {
+			b.Fatal(err)
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+func newBenchSegmentManager(b *testing.B) (*segmentManager, []int64) {
+	paramtable.Init()
+	mgr := NewSegmentManager()
+	segments := newBenchSegments(b, 0, benchSegmentNum)
+	mgr.Put(SegmentTypeSealed, segments...)
+	ids := make([]int64, 0, len(segments))
+	for _, segment := range segments {
+		ids = append(ids, segment.ID())
+	}
+	b.Cleanup(mgr.Clear)
+	return mgr, ids
+}
+
+// BenchmarkSegmentManagerPin measures the searches pinning all segments.
+func BenchmarkSegmentManagerPin(b *testing.B) {
+	mgr, ids := newBenchSegmentManager(b)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			segments, err := mgr.GetAndPin(ids)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			mgr.Unpin(segments)
+		}
+	})
+}
+
+// BenchmarkSegmentManagerPinWithChurn measures the searches while the segments are loaded and released continually.
+func BenchmarkSegmentManagerPinWithChurn(b *testing.B) {
+	mgr, _ := newBenchSegmentManager(b)
+	churn := newBenchSegments(b, benchSegmentNum, benchSegmentNum)
+
+	stop := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			// put and remove the segments without releasing them, to keep the churn going
+			segment := churn[i%len(churn)]
+			mgr.mu.Lock()
+			next := mgr.snapshot.Load().clone()
+			if _, ok := next.sealedSegments[segment.ID()]; ok {
+				delete(next.sealedSegments, segment.ID())
+			} else {
+				next.sealedSegments[segment.ID()] = segment
+			}
+			mgr.snapshot.Store(next)
+			mgr.mu.Unlock()
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			segments, err := mgr.GetAndPinBy(WithType(SegmentTypeSealed))
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			mgr.Unpin(segments)
+		}
+	})
+	b.StopTimer()
+
+	close(stop)
+	wg.Wait()
+	for _, segment := range churn {
+		segment.Release()
+	}
+}
+
+// BenchmarkSegmentManagerPinWithDelete measures the searches while the deletes are applied to segments.
+func BenchmarkSegmentManagerPinWithDelete(b *testing.B) {
+	mgr, ids := newBenchSegmentManager(b)
+
+	stop := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		pks := []storage.PrimaryKey{storage.NewInt64PrimaryKey(1)}
+		tss := []typeutil.Timestamp{1}
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			for _, segment := range mgr.GetBy(WithType(SegmentTypeSealed)) {
+				_ = segment.Delete(pks, tss)
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			segments, err := mgr.GetAndPin(ids)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			mgr.Unpin(segments)
+		}
+	})
+	b.StopTimer()
+
+	close(stop)
+	wg.Wait()
+}
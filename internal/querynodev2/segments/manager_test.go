@@ -1,6 +1,7 @@
 package segments
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/samber/lo"
@@ -10,6 +11,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -125,6 +127,57 @@ func (s *ManagerSuite) TestRemoveBy() {
 	}
 }
 
+func (s *ManagerSuite) TestEpochAndFence() {
+	epoch := s.mgr.Epoch()
+	// nothing removed, no snapshot published
+	s.mgr.Remove(1000, querypb.DataScope_All)
+	s.Equal(epoch, s.mgr.Epoch())
+
+	segment := s.mgr.Get(s.segmentIDs[0])
+	snapshot := s.mgr.snapshot.Load()
+	s.mgr.Remove(s.segmentIDs[0], querypb.DataScope_All)
+	s.Equal(epoch+1, s.mgr.Epoch())
+	// the published snapshot is immutable
+	s.Contains(snapshot.sealedSegments, s.segmentIDs[0])
+
+	// the released segment can't be pinned any more
+	s.Error(segment.RLock())
+	_, err := s.mgr.GetAndPin([]int64{s.segmentIDs[0]})
+	s.Error(err)
+}
+
+func (s *ManagerSuite) TestPinStaleSnapshot() {
+	// the reader loads the snapshot before the segment removed
+	stale := s.mgr.snapshot.Load()
+	s.mgr.Remove(s.segmentIDs[0], querypb.DataScope_All)
+	s.Contains(stale.sealedSegments, s.segmentIDs[0])
+
+	// the removed segment is found but fenced
+	_, err := s.mgr.getAndPin(stale, []int64{s.segmentIDs[0], s.segmentIDs[1]})
+	s.ErrorIs(err, merr.ErrSegmentNotLoaded)
+
+	// the other segments of the stale snapshot are still readable
+	segments, err := s.mgr.getAndPin(stale, []int64{s.segmentIDs[1], s.segmentIDs[2]})
+	s.NoError(err)
+	s.Len(segments, 2)
+	s.mgr.Unpin(segments)
+}
+
+func (s *ManagerSuite) TestSnapshotCopyOnWrite() {
+	old := s.mgr.snapshot.Load()
+	s.mgr.Remove(s.segmentIDs[0], querypb.DataScope_Historical)
+	next := s.mgr.snapshot.Load()
+	// only the sealed segments are copied
+	s.Equal(reflect.ValueOf(old.growingSegments).Pointer(), reflect.ValueOf(next.growingSegments).Pointer())
+	s.NotEqual(reflect.ValueOf(old.sealedSegments).Pointer(), reflect.ValueOf(next.sealedSegments).Pointer())
+
+	s.mgr.RemoveBy(WithType(SegmentTypeGrowing))
+	last := s.mgr.snapshot.Load()
+	s.Equal(reflect.ValueOf(next.sealedSegments).Pointer(), reflect.ValueOf(last.sealedSegments).Pointer())
+	s.Len(last.growingSegments, 0)
+	s.Len(next.growingSegments, 1)
+}
+
 func (s *ManagerSuite) TestUpdateBy() {
 	action := IncreaseVersion(1)
 
@@ -0,0 +1,261 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/config"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/hardware"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// MemoryCategory is the kind of memory used by query node.
+type MemoryCategory string
+
+const (
+	MemoryCategorySegment MemoryCategory = "segment"
+	// MemoryCategoryChunkCache is accounted by segcore, the size of the cached columns is charged as they grow.
+	MemoryCategoryChunkCache  MemoryCategory = "chunk_cache"
+	MemoryCategoryQueryBuffer MemoryCategory = "query_buffer"
+)
+
+var memoryCategories = []MemoryCategory{MemoryCategorySegment, MemoryCategoryChunkCache, MemoryCategoryQueryBuffer}
+
+var (
+	memoryGovernor     *MemoryGovernor
+	memoryGovernorOnce sync.Once
+)
+
+// GetMemoryGovernor returns the singleton memory governor of the query node,
+// the budget is the total memory * MemoryGovernorBudgetRatio.
+func GetMemoryGovernor() *MemoryGovernor {
+	memoryGovernorOnce.Do(func() {
+		params := paramtable.Get()
+		budget := uint64(float64(hardware.GetMemoryCount()) * params.QueryNodeCfg.MemoryGovernorBudgetRatio.GetAsFloat())
+		memoryGovernor = NewMemoryGovernor(params.QueryNodeCfg.MemoryGovernorEnable.GetAsBool(), budget, map[MemoryCategory]float64{
+			MemoryCategoryChunkCache:  params.QueryNodeCfg.MemoryGovernorChunkCacheReservedRatio.GetAsFloat(),
+			MemoryCategoryQueryBuffer: params.QueryNodeCfg.MemoryGovernorQueryBufferReservedRatio.GetAsFloat(),
+		}, segcoreChunkCache{})
+
+		params.Watch(params.QueryNodeCfg.MemoryGovernorChunkCacheReservedRatio.Key,
+			config.NewHandler("qn.memorygovernor.chunkcache", func(*config.Event) {
+				memoryGovernor.SetReservedRatio(MemoryCategoryChunkCache, params.QueryNodeCfg.MemoryGovernorChunkCacheReservedRatio.GetAsFloat())
+			}))
+		params.Watch(params.QueryNodeCfg.MemoryGovernorQueryBufferReservedRatio.Key,
+			config.NewHandler("qn.memorygovernor.querybuffer", func(*config.Event) {
+				memoryGovernor.SetReservedRatio(MemoryCategoryQueryBuffer, params.QueryNodeCfg.MemoryGovernorQueryBufferReservedRatio.GetAsFloat())
+			}))
+	})
+	return memoryGovernor
+}
+
+func segmentMemoryKey(segmentType SegmentType, segmentID int64) string {
+	return segmentDiskKey(segmentType, segmentID)
+}
+
+// chargeSegmentMemory charges the memory grown by the segment, e.g. the rows inserted into growing segments.
+func chargeSegmentMemory(segmentType SegmentType, segmentID int64, size uint64) {
+	GetMemoryGovernor().Charge(MemoryCategorySegment, segmentMemoryKey(segmentType, segmentID), size)
+}
+
+// releaseSegmentMemory releases the memory admitted for the segment.
+func releaseSegmentMemory(segmentType SegmentType, segmentID int64) {
+	GetMemoryGovernor().Release(MemoryCategorySegment, segmentMemoryKey(segmentType, segmentID))
+}
+
+// MemoryGovernor accounts the memory of segments, chunk cache and in-flight query buffers under a budget,
+// and admits the loads and searches only if the memory they need is available,
+// so that the query node rejects them with ErrServiceMemoryLimitExceeded instead of being OOM killed.
+// The memory grown after admitted, like the inserts of growing segments, is charged without admission,
+// which makes the following loads and searches rejected earlier.
+// Each category could reserve part of the budget, which can't be occupied by other categories.
+// If the governor is disabled, the memory is still accounted but nothing is rejected.
+type MemoryGovernor struct {
+	mu       sync.Mutex
+	enabled  bool
+	budget   uint64
+	reserved map[MemoryCategory]uint64
+	used     map[MemoryCategory]uint64
+	entries  map[MemoryCategory]map[string]uint64
+
+	chunkCache ChunkCache
+}
+
+func NewMemoryGovernor(enabled bool, budget uint64, reservedRatio map[MemoryCategory]float64, chunkCache ChunkCache) *MemoryGovernor {
+	g := &MemoryGovernor{
+		enabled:    enabled,
+		budget:     budget,
+		reserved:   make(map[MemoryCategory]uint64),
+		used:       make(map[MemoryCategory]uint64),
+		entries:    make(map[MemoryCategory]map[string]uint64),
+		chunkCache: chunkCache,
+	}
+	for _, category := range memoryCategories {
+		g.reserved[category] = uint64(float64(budget) * reservedRatio[category])
+		g.used[category] = 0
+		g.entries[category] = make(map[string]uint64)
+	}
+	return g
+}
+
+// Admit adds size bytes of memory to the key of the category,
+// returns ErrServiceMemoryLimitExceeded if there is no enough memory.
+// The memory is held until Release is called.
+// The chunk cache is accounted by segcore, it can't be admitted.
+func (g *MemoryGovernor) Admit(category MemoryCategory, key string, size uint64) error {
+	if category == MemoryCategoryChunkCache {
+		return merr.WrapErrParameterInvalidMsg("memory of chunk cache is accounted by segcore")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.entries[category]; !ok {
+		return merr.WrapErrParameterInvalidMsg("unknown memory category %s", category)
+	}
+
+	g.syncChunkCache()
+	if available := g.available(category); g.enabled && available < size {
+		metrics.QueryNodeMemoryGovernorRejectCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), string(category)).Inc()
+		return merr.WrapErrServiceMemoryLimitExceeded(float32(g.total()+size), float32(g.budget),
+			fmt.Sprintf("no enough memory for %s, available = %d, request = %d", category, available, size))
+	}
+
+	g.add(category, key, size)
+	return nil
+}
+
+// Charge adds size bytes of memory to the key of the category without admission,
+// it's for the memory which has been allocated and can't be rejected.
+func (g *MemoryGovernor) Charge(category MemoryCategory, key string, size uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.entries[category]; !ok || category == MemoryCategoryChunkCache {
+		return
+	}
+	g.add(category, key, size)
+}
+
+// Shrink removes size bytes of memory from the key of the category, e.g. to roll back a failed admission.
+func (g *MemoryGovernor) Shrink(category MemoryCategory, key string, size uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	old, ok := g.entries[category][key]
+	if !ok {
+		return
+	}
+	size = funcutil.Min(size, old)
+	if size == old {
+		delete(g.entries[category], key)
+	} else {
+		g.entries[category][key] = old - size
+	}
+	g.used[category] -= size
+	g.updateMetrics(category)
+}
+
+// Release removes the accounting of the key.
+func (g *MemoryGovernor) Release(category MemoryCategory, key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if size, ok := g.entries[category][key]; ok {
+		delete(g.entries[category], key)
+		g.used[category] -= size
+		g.updateMetrics(category)
+	}
+}
+
+// SetReservedRatio changes the part of the budget reserved for the category,
+// the memory already used beyond the new reservation is kept.
+func (g *MemoryGovernor) SetReservedRatio(category MemoryCategory, ratio float64) {
+	if ratio < 0 || ratio > 1 {
+		log.Warn("invalid reserved ratio of memory category, ignore it",
+			zap.String("category", string(category)), zap.Float64("ratio", ratio))
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.reserved[category] = uint64(float64(g.budget) * ratio)
+	log.Info("reserved memory of category changed",
+		zap.String("category", string(category)), zap.Uint64("reserved", g.reserved[category]))
+}
+
+// Used returns the memory size of the category in bytes.
+func (g *MemoryGovernor) Used(category MemoryCategory) uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if category == MemoryCategoryChunkCache {
+		g.syncChunkCache()
+	}
+	return g.used[category]
+}
+
+// available returns the memory could be used by the category,
+// the reserved but unused memory of the other categories is excluded.
+func (g *MemoryGovernor) available(category MemoryCategory) uint64 {
+	occupied := g.used[category]
+	for other, reserved := range g.reserved {
+		if other == category {
+			continue
+		}
+		occupied += funcutil.Max(g.used[other], reserved)
+	}
+	if occupied >= g.budget {
+		return 0
+	}
+	return g.budget - occupied
+}
+
+func (g *MemoryGovernor) add(category MemoryCategory, key string, size uint64) {
+	g.entries[category][key] += size
+	g.used[category] += size
+	g.updateMetrics(category)
+}
+
+// syncChunkCache charges the current size of the chunk cache, which grows and shrinks inside segcore.
+func (g *MemoryGovernor) syncChunkCache() {
+	if g.chunkCache == nil {
+		return
+	}
+	g.used[MemoryCategoryChunkCache] = g.chunkCache.Size()
+	g.updateMetrics(MemoryCategoryChunkCache)
+}
+
+func (g *MemoryGovernor) total() uint64 {
+	total := uint64(0)
+	for _, used := range g.used {
+		total += used
+	}
+	return total
+}
+
+func (g *MemoryGovernor) updateMetrics(category MemoryCategory) {
+	metrics.QueryNodeMemoryGovernorUsedSize.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), string(category)).
+		Set(float64(g.used[category]) / 1024 / 1024)
+}
@@ -0,0 +1,129 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type MemoryGovernorSuite struct {
+	suite.Suite
+
+	chunkCache *fakeChunkCache
+	governor   *MemoryGovernor
+}
+
+func (suite *MemoryGovernorSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (suite *MemoryGovernorSuite) SetupTest() {
+	suite.chunkCache = &fakeChunkCache{}
+	suite.governor = NewMemoryGovernor(true, 100, map[MemoryCategory]float64{
+		MemoryCategoryChunkCache:  0.1,
+		MemoryCategoryQueryBuffer: 0.2,
+	}, suite.chunkCache)
+}
+
+func (suite *MemoryGovernorSuite) TestAdmitAndRelease() {
+	suite.NoError(suite.governor.Admit(MemoryCategorySegment, "1", 50))
+	suite.EqualValues(50, suite.governor.Used(MemoryCategorySegment))
+
+	// the reservations of chunk cache and query buffer can't be occupied
+	err := suite.governor.Admit(MemoryCategorySegment, "2", 30)
+	suite.ErrorIs(err, merr.ErrServiceMemoryLimitExceeded)
+	suite.NoError(suite.governor.Admit(MemoryCategorySegment, "2", 20))
+
+	// query buffers could use the reservation
+	suite.NoError(suite.governor.Admit(MemoryCategoryQueryBuffer, "q1", 20))
+	err = suite.governor.Admit(MemoryCategoryQueryBuffer, "q2", 1)
+	suite.ErrorIs(err, merr.ErrServiceMemoryLimitExceeded)
+
+	// admit again adds to the key, shrink rolls it back
+	suite.governor.Shrink(MemoryCategoryQueryBuffer, "q1", 10)
+	suite.EqualValues(10, suite.governor.Used(MemoryCategoryQueryBuffer))
+	suite.NoError(suite.governor.Admit(MemoryCategoryQueryBuffer, "q1", 5))
+	suite.EqualValues(15, suite.governor.Used(MemoryCategoryQueryBuffer))
+
+	suite.governor.Release(MemoryCategorySegment, "1")
+	suite.EqualValues(20, suite.governor.Used(MemoryCategorySegment))
+	suite.NoError(suite.governor.Admit(MemoryCategoryQueryBuffer, "q2", 30))
+
+	err = suite.governor.Admit("unknown", "1", 1)
+	suite.ErrorIs(err, merr.ErrParameterInvalid)
+	err = suite.governor.Admit(MemoryCategoryChunkCache, "1", 1)
+	suite.ErrorIs(err, merr.ErrParameterInvalid)
+}
+
+func (suite *MemoryGovernorSuite) TestCharge() {
+	// growing segments are admitted with the binlogs loaded, and charged as rows inserted
+	suite.NoError(suite.governor.Admit(MemoryCategorySegment, "growing-1", 0))
+	suite.governor.Charge(MemoryCategorySegment, "growing-1", 60)
+	suite.governor.Charge(MemoryCategorySegment, "growing-1", 20)
+	suite.EqualValues(80, suite.governor.Used(MemoryCategorySegment))
+
+	// the charged memory is never rejected, but the following admissions are
+	suite.governor.Charge(MemoryCategorySegment, "growing-1", 20)
+	err := suite.governor.Admit(MemoryCategorySegment, "2", 1)
+	suite.ErrorIs(err, merr.ErrServiceMemoryLimitExceeded)
+
+	suite.governor.Release(MemoryCategorySegment, "growing-1")
+	suite.EqualValues(0, suite.governor.Used(MemoryCategorySegment))
+}
+
+func (suite *MemoryGovernorSuite) TestChunkCache() {
+	suite.chunkCache.columns = []uint64{10, 10, 10}
+	suite.EqualValues(30, suite.governor.Used(MemoryCategoryChunkCache))
+
+	// the chunk cache exceeding its reservation is charged against the budget
+	err := suite.governor.Admit(MemoryCategorySegment, "1", 60)
+	suite.ErrorIs(err, merr.ErrServiceMemoryLimitExceeded)
+	suite.NoError(suite.governor.Admit(MemoryCategorySegment, "1", 50))
+
+	suite.chunkCache.Evict(20)
+	suite.NoError(suite.governor.Admit(MemoryCategorySegment, "2", 20))
+}
+
+func (suite *MemoryGovernorSuite) TestSetReservedRatio() {
+	// 70 available for segments with 30 reserved
+	err := suite.governor.Admit(MemoryCategorySegment, "1", 80)
+	suite.ErrorIs(err, merr.ErrServiceMemoryLimitExceeded)
+
+	suite.governor.SetReservedRatio(MemoryCategoryQueryBuffer, 0)
+	suite.NoError(suite.governor.Admit(MemoryCategorySegment, "1", 80))
+
+	// the invalid ratio is ignored
+	suite.governor.SetReservedRatio(MemoryCategoryQueryBuffer, 2)
+	suite.NoError(suite.governor.Admit(MemoryCategorySegment, "2", 10))
+}
+
+func (suite *MemoryGovernorSuite) TestDisabled() {
+	governor := NewMemoryGovernor(false, 100, nil, nil)
+	suite.NoError(governor.Admit(MemoryCategorySegment, "1", 200))
+	suite.EqualValues(200, governor.Used(MemoryCategorySegment))
+	governor.Release(MemoryCategorySegment, "1")
+	suite.EqualValues(0, governor.Used(MemoryCategorySegment))
+}
+
+func TestMemoryGovernor(t *testing.T) {
+	suite.Run(t, new(MemoryGovernorSuite))
+}
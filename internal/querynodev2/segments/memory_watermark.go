@@ -0,0 +1,277 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/hardware"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// MemoryLevel is the memory watermark level reached by the query node,
+// each level takes the actions of the lower levels as well.
+type MemoryLevel int32
+
+const (
+	MemoryLevelNormal MemoryLevel = iota
+	// MemoryLevelStopLoading rejects the loads of new segments
+	MemoryLevelStopLoading
+	// MemoryLevelEvictCache evicts the least recently read chunk cache
+	MemoryLevelEvictCache
+	// MemoryLevelRejectLowPriority rejects the low priority requests
+	MemoryLevelRejectLowPriority
+	// MemoryLevelReleaseSegments releases the sealed segments forcibly
+	MemoryLevelReleaseSegments
+)
+
+func (l MemoryLevel) String() string {
+	switch l {
+	case MemoryLevelNormal:
+		return "Normal"
+	case MemoryLevelStopLoading:
+		return "L1-StopLoading"
+	case MemoryLevelEvictCache:
+		return "L2-EvictCache"
+	case MemoryLevelRejectLowPriority:
+		return "L3-RejectLowPriority"
+	case MemoryLevelReleaseSegments:
+		return "L4-ReleaseSegments"
+	}
+	return fmt.Sprintf("L%d", int32(l))
+}
+
+// the actions of the memory watermark, as the metric label
+const (
+	watermarkActionRejectLoad    = "reject_load"
+	watermarkActionEvictCache    = "evict_cache"
+	watermarkActionRejectRequest = "reject_request"
+	watermarkActionRelease       = "release_segment"
+)
+
+// SegmentReleaser releases the sealed segments until size bytes freed, returns the bytes freed.
+type SegmentReleaser func(size uint64) uint64
+
+var (
+	memoryWatermark     *MemoryWatermark
+	memoryWatermarkOnce sync.Once
+)
+
+// GetMemoryWatermark returns the singleton memory watermark of the query node.
+func GetMemoryWatermark() *MemoryWatermark {
+	memoryWatermarkOnce.Do(func() {
+		// the memory of the container is what its memory limit applies to
+		memoryWatermark = NewMemoryWatermark(func() (uint64, uint64) {
+			return hardware.GetContainerUsedMemoryCount(), hardware.GetMemoryCount()
+		}, segcoreChunkCache{})
+	})
+	return memoryWatermark
+}
+
+// MemoryWatermark takes the tiered actions as the memory used grows,
+// the thresholds of the levels are the ratios of the memory used to the total memory:
+//
+//	L1 stops loading new segments,
+//	L2 evicts the least recently read chunk cache until the memory falls below its threshold,
+//	L3 rejects the low priority requests,
+//	L4 releases the sealed segments until the memory falls below its threshold.
+//
+// The level is checked periodically, the levels with zero threshold are disabled, which is the default.
+// The threshold of L1 replaces queryCoord.overloadedMemoryThresholdPercentage once enabled,
+// see LoadMemoryThreshold.
+type MemoryWatermark struct {
+	level      atomic.Int32
+	getUsage   func() (used uint64, total uint64)
+	chunkCache ChunkCache
+
+	releaserMu sync.RWMutex
+	releaser   SegmentReleaser
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewMemoryWatermark(getUsage func() (uint64, uint64), chunkCache ChunkCache) *MemoryWatermark {
+	return &MemoryWatermark{
+		getUsage:   getUsage,
+		chunkCache: chunkCache,
+		closeCh:    make(chan struct{}),
+	}
+}
+
+// SetReleaser sets how to release the sealed segments at L4.
+func (w *MemoryWatermark) SetReleaser(releaser SegmentReleaser) {
+	w.releaserMu.Lock()
+	defer w.releaserMu.Unlock()
+	w.releaser = releaser
+}
+
+func (w *MemoryWatermark) Start() {
+	w.startOnce.Do(func() {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			ticker := time.NewTicker(paramtable.Get().QueryNodeCfg.MemoryWatermarkCheckInterval.GetAsDuration(time.Second))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-w.closeCh:
+					log.Info("memory watermark quit")
+					return
+				case <-ticker.C:
+					w.Check()
+				}
+			}
+		}()
+	})
+}
+
+func (w *MemoryWatermark) Close() {
+	w.closeOnce.Do(func() {
+		close(w.closeCh)
+		w.wg.Wait()
+	})
+}
+
+// Level returns the level reached at the last check.
+func (w *MemoryWatermark) Level() MemoryLevel {
+	return MemoryLevel(w.level.Load())
+}
+
+// thresholds returns the thresholds of L1 to L4.
+func (w *MemoryWatermark) thresholds() []float64 {
+	params := paramtable.Get()
+	return []float64{
+		params.QueryNodeCfg.MemoryWatermarkStopLoading.GetAsFloat(),
+		params.QueryNodeCfg.MemoryWatermarkEvictCache.GetAsFloat(),
+		params.QueryNodeCfg.MemoryWatermarkRejectLowPriority.GetAsFloat(),
+		params.QueryNodeCfg.MemoryWatermarkReleaseSegments.GetAsFloat(),
+	}
+}
+
+// Check updates the level by the memory used, and takes the actions of the level reached.
+func (w *MemoryWatermark) Check() MemoryLevel {
+	used, total := w.getUsage()
+	if total == 0 {
+		return w.Level()
+	}
+	thresholds := w.thresholds()
+	ratio := float64(used) / float64(total)
+
+	level := MemoryLevelNormal
+	for i, threshold := range thresholds {
+		if threshold > 0 && ratio >= threshold {
+			level = MemoryLevel(i + 1)
+		}
+	}
+	if prev := MemoryLevel(w.level.Swap(int32(level))); prev != level {
+		log.Warn("memory watermark level changed",
+			zap.Stringer("from", prev),
+			zap.Stringer("to", level),
+			zap.Uint64("used", used),
+			zap.Uint64("total", total),
+			zap.Float64("ratio", ratio))
+	}
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.QueryNodeMemoryWatermarkLevel.WithLabelValues(nodeID).Set(float64(level))
+
+	// the memory to free is the memory used beyond the threshold
+	excess := func(threshold float64) uint64 {
+		target := uint64(float64(total) * threshold)
+		if used <= target {
+			return 0
+		}
+		return used - target
+	}
+	if threshold := thresholds[MemoryLevelEvictCache-1]; threshold > 0 && level >= MemoryLevelEvictCache && w.chunkCache != nil {
+		if freed := w.chunkCache.Evict(excess(threshold)); freed > 0 {
+			metrics.QueryNodeMemoryWatermarkActionCount.WithLabelValues(nodeID, watermarkActionEvictCache).Inc()
+			log.Info("chunk cache evicted by memory watermark", zap.Uint64("freed", freed))
+			if freed > used {
+				freed = used
+			}
+			used -= freed
+		}
+	}
+	if threshold := thresholds[MemoryLevelReleaseSegments-1]; threshold > 0 && level >= MemoryLevelReleaseSegments {
+		w.releaserMu.RLock()
+		releaser := w.releaser
+		w.releaserMu.RUnlock()
+		if size := excess(threshold); releaser != nil && size > 0 {
+			if freed := releaser(size); freed > 0 {
+				metrics.QueryNodeMemoryWatermarkActionCount.WithLabelValues(nodeID, watermarkActionRelease).Inc()
+				log.Warn("segments released by memory watermark", zap.Uint64("request", size), zap.Uint64("freed", freed))
+			}
+		}
+	}
+	return level
+}
+
+// LoadMemoryThreshold returns the ratio of the memory predicted after the segments loaded to the total memory
+// to reject the loads, which is the threshold of L1 if enabled, or queryCoord.overloadedMemoryThresholdPercentage.
+func LoadMemoryThreshold() float64 {
+	if threshold := paramtable.Get().QueryNodeCfg.MemoryWatermarkStopLoading.GetAsFloat(); threshold > 0 {
+		return threshold
+	}
+	return paramtable.Get().QueryNodeCfg.OverloadedMemoryThresholdPercentage.GetAsFloat()
+}
+
+// CheckLoad returns ErrServiceMemoryLimitExceeded if the loads of new segments are stopped.
+func (w *MemoryWatermark) CheckLoad() error {
+	if w.Level() < MemoryLevelStopLoading {
+		return nil
+	}
+	metrics.QueryNodeMemoryWatermarkActionCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), watermarkActionRejectLoad).Inc()
+	return w.limitExceeded(paramtable.Get().QueryNodeCfg.MemoryWatermarkStopLoading.GetAsFloat(), "stop loading segments")
+}
+
+// CheckRequest returns ErrServiceMemoryLimitExceeded if the request of the type, search or query, is low priority and rejected.
+func (w *MemoryWatermark) CheckRequest(requestType string) error {
+	if w.Level() < MemoryLevelRejectLowPriority {
+		return nil
+	}
+	lowPriority := false
+	for _, typ := range paramtable.Get().QueryNodeCfg.MemoryWatermarkLowPriorityRequests.GetAsStrings() {
+		if strings.EqualFold(strings.TrimSpace(typ), requestType) {
+			lowPriority = true
+			break
+		}
+	}
+	if !lowPriority {
+		return nil
+	}
+	metrics.QueryNodeMemoryWatermarkActionCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), watermarkActionRejectRequest).Inc()
+	return w.limitExceeded(paramtable.Get().QueryNodeCfg.MemoryWatermarkRejectLowPriority.GetAsFloat(),
+		fmt.Sprintf("reject low priority %s requests", requestType))
+}
+
+func (w *MemoryWatermark) limitExceeded(threshold float64, action string) error {
+	used, total := w.getUsage()
+	return merr.WrapErrServiceMemoryLimitExceeded(float32(used), float32(float64(total)*threshold),
+		fmt.Sprintf("memory watermark reached %s, %s", w.Level(), action))
+}
@@ -0,0 +1,132 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type MemoryWatermarkSuite struct {
+	suite.Suite
+
+	used       uint64
+	chunkCache *fakeChunkCache
+	released   uint64
+	watermark  *MemoryWatermark
+}
+
+func (suite *MemoryWatermarkSuite) SetupSuite() {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.MemoryWatermarkStopLoading.Key, "0.85")
+	params.Save(params.QueryNodeCfg.MemoryWatermarkEvictCache.Key, "0.9")
+	params.Save(params.QueryNodeCfg.MemoryWatermarkRejectLowPriority.Key, "0.95")
+	params.Save(params.QueryNodeCfg.MemoryWatermarkReleaseSegments.Key, "0.98")
+}
+
+func (suite *MemoryWatermarkSuite) TearDownSuite() {
+	params := paramtable.Get()
+	params.Reset(params.QueryNodeCfg.MemoryWatermarkStopLoading.Key)
+	params.Reset(params.QueryNodeCfg.MemoryWatermarkEvictCache.Key)
+	params.Reset(params.QueryNodeCfg.MemoryWatermarkRejectLowPriority.Key)
+	params.Reset(params.QueryNodeCfg.MemoryWatermarkReleaseSegments.Key)
+}
+
+func (suite *MemoryWatermarkSuite) SetupTest() {
+	suite.used = 0
+	suite.released = 0
+	suite.chunkCache = &fakeChunkCache{columns: []uint64{1, 1, 1}}
+	suite.watermark = NewMemoryWatermark(func() (uint64, uint64) {
+		return suite.used, 100
+	}, suite.chunkCache)
+	suite.watermark.SetReleaser(func(size uint64) uint64 {
+		suite.released += size
+		return size
+	})
+}
+
+func (suite *MemoryWatermarkSuite) TestLevels() {
+	// the thresholds are 0.85, 0.9, 0.95 and 0.98
+	for used, level := range map[uint64]MemoryLevel{
+		50: MemoryLevelNormal,
+		85: MemoryLevelStopLoading,
+		92: MemoryLevelEvictCache,
+		96: MemoryLevelRejectLowPriority,
+		99: MemoryLevelReleaseSegments,
+	} {
+		suite.SetupTest()
+		suite.used = used
+		suite.Equal(level, suite.watermark.Check(), used)
+		suite.Equal(level, suite.watermark.Level())
+	}
+}
+
+func (suite *MemoryWatermarkSuite) TestActions() {
+	suite.used = 50
+	suite.watermark.Check()
+	suite.NoError(suite.watermark.CheckLoad())
+	suite.NoError(suite.watermark.CheckRequest("query"))
+
+	suite.used = 86
+	suite.watermark.Check()
+	suite.ErrorIs(suite.watermark.CheckLoad(), merr.ErrServiceMemoryLimitExceeded)
+	suite.NoError(suite.watermark.CheckRequest("query"))
+	suite.Len(suite.chunkCache.columns, 3)
+
+	// evicts the chunk cache beyond the threshold of L2
+	suite.used = 92
+	suite.watermark.Check()
+	suite.Len(suite.chunkCache.columns, 1)
+
+	// only the low priority requests are rejected
+	suite.used = 96
+	suite.watermark.Check()
+	suite.ErrorIs(suite.watermark.CheckRequest("query"), merr.ErrServiceMemoryLimitExceeded)
+	suite.NoError(suite.watermark.CheckRequest("search"))
+	suite.Zero(suite.released)
+
+	// releases the segments beyond the threshold of L4 once the cache is drained
+	suite.used = 100
+	suite.watermark.Check()
+	suite.Empty(suite.chunkCache.columns)
+	suite.EqualValues(2, suite.released)
+
+	// recovers once the memory falls
+	suite.used = 50
+	suite.Equal(MemoryLevelNormal, suite.watermark.Check())
+	suite.NoError(suite.watermark.CheckLoad())
+}
+
+func (suite *MemoryWatermarkSuite) TestLoadMemoryThreshold() {
+	params := paramtable.Get()
+	suite.Equal(0.85, LoadMemoryThreshold())
+
+	// falls back to the overloaded memory threshold once L1 disabled
+	params.Save(params.QueryNodeCfg.MemoryWatermarkStopLoading.Key, "0")
+	defer params.Save(params.QueryNodeCfg.MemoryWatermarkStopLoading.Key, "0.85")
+	suite.Equal(params.QueryNodeCfg.OverloadedMemoryThresholdPercentage.GetAsFloat(), LoadMemoryThreshold())
+	suite.NoError(suite.watermark.CheckLoad())
+}
+
+func TestMemoryWatermark(t *testing.T) {
+	suite.Run(t, new(MemoryWatermarkSuite))
+}
@@ -1199,7 +1199,7 @@ func genSearchPlanAndRequests(collection *Collection, segments []int64, indexTyp
 		FromShardLeader: true,
 		Scope:           querypb.DataScope_Historical,
 	}
-	return NewSearchRequest(collection, queryReq, queryReq.Req.GetPlaceholderGroup())
+	return NewSearchRequest(context.Background(), collection, queryReq, queryReq.Req.GetPlaceholderGroup())
 }
 
 func genInsertMsg(collection *Collection, partitionID, segment int64, numRows int) (*msgstream.InsertMsg, error) {
@@ -1301,7 +1301,7 @@ func genSimpleRetrievePlan(collection *Collection) (*RetrievePlan, error) {
 		return nil, err
 	}
 
-	plan, err2 := NewRetrievePlan(collection, planBytes, timestamp, 100)
+	plan, err2 := NewRetrievePlan(context.Background(), collection, planBytes, timestamp, 100)
 	return plan, err2
 }
 
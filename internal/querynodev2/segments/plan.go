@@ -26,11 +26,14 @@ package segments
 import "C"
 
 import (
+	"context"
 	"fmt"
 	"unsafe"
 
 	"github.com/cockroachdb/errors"
+	"github.com/golang/protobuf/proto"
 
+	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	. "github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -89,46 +92,130 @@ type SearchRequest struct {
 	cPlaceholderGroup C.CPlaceholderGroup
 	msgID             UniqueID
 	searchFieldID     UniqueID
+	// predicates is used to prune segments by json key stats, nil if the plan has no filter
+	predicates *planpb.Expr
+	// accessedFields is used to record the access stats of fields
+	accessedFields []int64
+	// preFilter is the request the pre-filter hooks are applied to, nil if no hook registered
+	preFilter *PreFilterRequest
+	// shared is the reference of the plan cached by the collection, nil if the plan is owned by the request
+	shared *sharedPlan
+	// scanCounter counts the rows scanned of all the segments searched
+	scanCounter C.CScanCounter
 }
 
-func NewSearchRequest(collection *Collection, req *querypb.SearchRequest, placeholderGrp []byte) (*SearchRequest, error) {
-	var err error
-	var plan *SearchPlan
-	metricType := req.GetReq().GetMetricType()
-	expr := req.Req.SerializedExprPlan
-	plan, err = createSearchPlanByExpr(collection, expr, metricType)
+// cachedSearchPlan is the search plan with the values derived from its serialized plan,
+// which are shared by the requests of the same serialized plan.
+type cachedSearchPlan struct {
+	plan           *SearchPlan
+	searchFieldID  UniqueID
+	predicates     *planpb.Expr
+	accessedFields []int64
+}
+
+func newCachedSearchPlan(collection *Collection, expr []byte, metricType string) (*cachedSearchPlan, error) {
+	plan, err := createSearchPlanByExpr(collection, expr, metricType)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(placeholderGrp) == 0 {
+	var fieldID C.int64_t
+	status := C.GetFieldID(plan.cSearchPlan, &fieldID)
+	if err = HandleCStatus(&status, "get fieldID from plan failed"); err != nil {
 		plan.delete()
-		return nil, errors.New("empty search request")
+		return nil, err
 	}
 
-	blobPtr := unsafe.Pointer(&placeholderGrp[0])
-	blobSize := C.int64_t(len(placeholderGrp))
-	var cPlaceholderGroup C.CPlaceholderGroup
-	status := C.ParsePlaceholderGroup(plan.cSearchPlan, blobPtr, blobSize, &cPlaceholderGroup)
+	planNode := parsePlanNode(expr)
+	return &cachedSearchPlan{
+		plan:           plan,
+		searchFieldID:  int64(fieldID),
+		predicates:     planPredicates(planNode),
+		accessedFields: planAccessedFields(planNode),
+	}, nil
+}
 
-	if err := HandleCStatus(&status, "parser searchRequest failed"); err != nil {
-		plan.delete()
-		return nil, err
+// getSearchPlan returns the search plan of the serialized plan, which is cached by the collection if enabled,
+// the returned sharedPlan is nil if the plan is owned by the caller.
+func getSearchPlan(collection *Collection, expr []byte, metricType string) (*cachedSearchPlan, *sharedPlan, error) {
+	if !planCacheEnabled() || collection.plans == nil {
+		plan, err := newCachedSearchPlan(collection, expr, metricType)
+		return plan, nil, err
 	}
 
-	var fieldID C.int64_t
-	status = C.GetFieldID(plan.cSearchPlan, &fieldID)
-	if err = HandleCStatus(&status, "get fieldID from plan failed"); err != nil {
-		plan.delete()
-		return nil, err
+	// the metric type of collection may be changed, so the effective one is a part of the key
+	if len(metricType) == 0 {
+		metricType = collection.GetMetricType()
+	}
+	key := "search\x00" + metricType + "\x00" + string(expr)
+	if shared, value, ok := collection.plans.get(key); ok {
+		return value.(*cachedSearchPlan), shared, nil
+	}
+	plan, err := newCachedSearchPlan(collection, expr, metricType)
+	if err != nil {
+		return nil, nil, err
+	}
+	shared, ok := collection.plans.put(key, plan, plan.plan.delete)
+	if !ok {
+		return plan, nil, nil
+	}
+	return plan, shared, nil
+}
+
+func NewSearchRequest(ctx context.Context, collection *Collection, req *querypb.SearchRequest, placeholderGrp []byte) (*SearchRequest, error) {
+	var err error
+	metricType := req.GetReq().GetMetricType()
+	expr := req.Req.SerializedExprPlan
+
+	var preFilter *PreFilterRequest
+	if HasPreFilterHooks() {
+		planNode := parsePlanNode(expr)
+		if planNode == nil {
+			return nil, merr.WrapErrParameterInvalidMsg("failed to parse the search plan for pre-filter hooks")
+		}
+		preFilter = &PreFilterRequest{
+			CollectionID: req.GetReq().GetCollectionID(),
+			PartitionIDs: req.GetReq().GetPartitionIDs(),
+			Username:     req.GetReq().GetUsername(),
+			Predicates:   planPredicates(planNode),
+		}
+		changed, err := applyPreFilterPredicates(ctx, preFilter, planNode)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			if expr, err = proto.Marshal(planNode); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(placeholderGrp) == 0 {
+		return nil, errors.New("empty search request")
 	}
 
+	cached, shared, err := getSearchPlan(collection, expr, metricType)
+	if err != nil {
+		return nil, err
+	}
 	ret := &SearchRequest{
-		plan:              plan,
-		cPlaceholderGroup: cPlaceholderGroup,
-		msgID:             req.GetReq().GetBase().GetMsgID(),
-		searchFieldID:     int64(fieldID),
+		plan:           cached.plan,
+		msgID:          req.GetReq().GetBase().GetMsgID(),
+		searchFieldID:  cached.searchFieldID,
+		predicates:     cached.predicates,
+		accessedFields: cached.accessedFields,
+		preFilter:      preFilter,
+		shared:         shared,
+	}
+
+	blobPtr := unsafe.Pointer(&placeholderGrp[0])
+	blobSize := C.int64_t(len(placeholderGrp))
+	status := C.ParsePlaceholderGroup(cached.plan.cSearchPlan, blobPtr, blobSize, &ret.cPlaceholderGroup)
+	if err := HandleCStatus(&status, "parser searchRequest failed"); err != nil {
+		ret.releasePlan()
+		return nil, err
 	}
+	ret.scanCounter = newScanCounter(ctx)
 
 	return ret, nil
 }
@@ -142,11 +229,23 @@ func (req *SearchRequest) Plan() *SearchPlan {
 	return req.plan
 }
 
-func (req *SearchRequest) Delete() {
-	if req.plan != nil {
+// ScannedRows returns the rows scanned of the segments searched so far.
+func (req *SearchRequest) ScannedRows() int64 {
+	return getScannedRows(req.scanCounter)
+}
+
+func (req *SearchRequest) releasePlan() {
+	if req.shared != nil {
+		req.shared.unpin()
+	} else if req.plan != nil {
 		req.plan.delete()
 	}
+}
+
+func (req *SearchRequest) Delete() {
+	req.releasePlan()
 	C.DeletePlaceholderGroup(req.cPlaceholderGroup)
+	deleteScanCounter(req.scanCounter)
 }
 
 func parseSearchRequest(plan *SearchPlan, searchRequestBlob []byte) (*SearchRequest, error) {
@@ -168,35 +267,155 @@ func parseSearchRequest(plan *SearchPlan, searchRequestBlob []byte) (*SearchRequ
 
 // RetrievePlan is a wrapper of the underlying C-structure C.CRetrievePlan
 type RetrievePlan struct {
-	cRetrievePlan C.CRetrievePlan
-	Timestamp     Timestamp
-	msgID         UniqueID // only used to debug.
+	cRetrievePlan  C.CRetrievePlan
+	Timestamp      Timestamp
+	msgID          UniqueID // only used to debug.
+	predicates     *planpb.Expr
+	accessedFields []int64
+	systemFilter   *systemFilter
+	// shared is the reference of the plan cached by the collection, nil if the plan is owned by the request
+	shared *sharedPlan
+	// scanCounter counts the rows scanned of all the segments retrieved
+	scanCounter C.CScanCounter
 }
 
-func NewRetrievePlan(col *Collection, expr []byte, timestamp Timestamp, msgID UniqueID) (*RetrievePlan, error) {
-	col.mu.RLock()
-	defer col.mu.RUnlock()
+// cachedRetrievePlan is the retrieve plan with the values derived from its serialized plan,
+// which are shared by the requests of the same serialized plan.
+type cachedRetrievePlan struct {
+	cRetrievePlan  C.CRetrievePlan
+	predicates     *planpb.Expr
+	accessedFields []int64
+	systemFilter   *systemFilter
+}
 
-	if col.collectionPtr == nil {
-		return nil, merr.WrapErrCollectionNotFound(col.id, "collection released")
+func newCachedRetrievePlan(col *Collection, expr []byte) (*cachedRetrievePlan, error) {
+	planNode := parsePlanNode(expr)
+	filter, err := extractSystemFilter(planNode)
+	if err != nil {
+		return nil, err
+	}
+	// segcore evaluates the filter without the system fields
+	if filter != nil {
+		expr, err = proto.Marshal(planNode)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var cPlan C.CRetrievePlan
 	status := C.CreateRetrievePlanByExpr(col.collectionPtr, unsafe.Pointer(&expr[0]), (C.int64_t)(len(expr)), &cPlan)
 
-	err := HandleCStatus(&status, "Create retrieve plan by expr failed")
+	err = HandleCStatus(&status, "Create retrieve plan by expr failed")
 	if err != nil {
 		return nil, err
 	}
 
+	return &cachedRetrievePlan{
+		cRetrievePlan:  cPlan,
+		predicates:     planPredicates(planNode),
+		accessedFields: planAccessedFields(planNode),
+		systemFilter:   filter,
+	}, nil
+}
+
+func (plan *cachedRetrievePlan) delete() {
+	C.DeleteRetrievePlan(plan.cRetrievePlan)
+}
+
+func NewRetrievePlan(ctx context.Context, col *Collection, expr []byte, timestamp Timestamp, msgID UniqueID) (*RetrievePlan, error) {
+	col.mu.RLock()
+	defer col.mu.RUnlock()
+
+	if col.collectionPtr == nil {
+		return nil, merr.WrapErrCollectionNotFound(col.id, "collection released")
+	}
+
+	var (
+		cached *cachedRetrievePlan
+		shared *sharedPlan
+		err    error
+	)
+	key := "retrieve\x00" + string(expr)
+	if planCacheEnabled() && col.plans != nil {
+		if plan, value, ok := col.plans.get(key); ok {
+			cached, shared = value.(*cachedRetrievePlan), plan
+		}
+	}
+	if cached == nil {
+		cached, err = newCachedRetrievePlan(col, expr)
+		if err != nil {
+			return nil, err
+		}
+		if planCacheEnabled() && col.plans != nil {
+			shared, _ = col.plans.put(key, cached, cached.delete)
+		}
+	}
+
 	newPlan := &RetrievePlan{
-		cRetrievePlan: cPlan,
-		Timestamp:     timestamp,
-		msgID:         msgID,
+		cRetrievePlan:  cached.cRetrievePlan,
+		Timestamp:      timestamp,
+		msgID:          msgID,
+		predicates:     cached.predicates,
+		accessedFields: cached.accessedFields,
+		systemFilter:   cached.systemFilter,
+		shared:         shared,
+		scanCounter:    newScanCounter(ctx),
 	}
 	return newPlan, nil
 }
 
+// parsePlanNode returns the serialized plan, nil if failed to parse,
+// segcore has parsed the plan already, so the error is ignored.
+func parsePlanNode(serializedPlan []byte) *planpb.PlanNode {
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, plan); err != nil {
+		return nil
+	}
+	return plan
+}
+
+// parsePredicates returns the filter of serialized plan, nil if failed to parse.
+func parsePredicates(serializedPlan []byte) *planpb.Expr {
+	return planPredicates(parsePlanNode(serializedPlan))
+}
+
+// planAccessedFields returns the vector field searched and the output fields of plan.
+func planAccessedFields(plan *planpb.PlanNode) []int64 {
+	fieldIDs := make([]int64, 0, len(plan.GetOutputFieldIds())+1)
+	if anns := plan.GetVectorAnns(); anns != nil {
+		fieldIDs = append(fieldIDs, anns.GetFieldId())
+	}
+	for _, fieldID := range plan.GetOutputFieldIds() {
+		if len(fieldIDs) == 0 || fieldIDs[0] != fieldID {
+			fieldIDs = append(fieldIDs, fieldID)
+		}
+	}
+	return fieldIDs
+}
+
+func planPredicates(plan *planpb.PlanNode) *planpb.Expr {
+	switch node := plan.GetNode().(type) {
+	case *planpb.PlanNode_VectorAnns:
+		return node.VectorAnns.GetPredicates()
+	case *planpb.PlanNode_Query:
+		return node.Query.GetPredicates()
+	case *planpb.PlanNode_Predicates:
+		return node.Predicates
+	default:
+		return nil
+	}
+}
+
+// ScannedRows returns the rows scanned of the segments retrieved so far.
+func (plan *RetrievePlan) ScannedRows() int64 {
+	return getScannedRows(plan.scanCounter)
+}
+
 func (plan *RetrievePlan) Delete() {
+	deleteScanCounter(plan.scanCounter)
+	if plan.shared != nil {
+		plan.shared.unpin()
+		return
+	}
 	C.DeleteRetrievePlan(plan.cRetrievePlan)
 }
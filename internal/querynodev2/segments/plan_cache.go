@@ -0,0 +1,139 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// sharedPlan is a segcore plan shared by the requests of the same serialized plan,
+// the plan is deleted after it's evicted from the cache and released by all the requests.
+type sharedPlan struct {
+	refs    atomic.Int64
+	release func()
+}
+
+func newSharedPlan(release func()) *sharedPlan {
+	plan := &sharedPlan{release: release}
+	// the reference of the cache
+	plan.refs.Store(1)
+	return plan
+}
+
+// pin returns false if the plan is already deleted.
+func (p *sharedPlan) pin() bool {
+	for {
+		refs := p.refs.Load()
+		if refs <= 0 {
+			return false
+		}
+		if p.refs.CompareAndSwap(refs, refs+1) {
+			return true
+		}
+	}
+}
+
+func (p *sharedPlan) unpin() {
+	if p.refs.Dec() == 0 {
+		p.release()
+	}
+}
+
+type planCacheEntry struct {
+	key   string
+	plan  *sharedPlan
+	value any
+}
+
+// planCache is the LRU cache of the segcore plans of a collection, keyed by the serialized plans,
+// so the requests repeating the same filter, which the proxies bind into the plans of the same
+// expression template, skip parsing and planning on query node.
+type planCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+func newPlanCache() *planCache {
+	return &planCache{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func planCacheEnabled() bool {
+	return paramtable.Get().QueryNodeCfg.PlanCacheEnabled.GetAsBool()
+}
+
+// get returns the pinned plan cached by the key and the value cached with it.
+func (c *planCache) get(key string) (*sharedPlan, any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if ok {
+		entry := elem.Value.(*planCacheEntry)
+		if entry.plan.pin() {
+			c.lru.MoveToFront(elem)
+			metrics.QueryNodePlanCacheCounter.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.CacheHitLabel).Inc()
+			return entry.plan, entry.value, true
+		}
+	}
+	metrics.QueryNodePlanCacheCounter.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.CacheMissLabel).Inc()
+	return nil, nil, false
+}
+
+// put caches the plan created by the caller and returns it pinned for the caller,
+// the plan is released by the caller instead if the key was cached concurrently.
+func (c *planCache) put(key string, value any, release func()) (*sharedPlan, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		return nil, false
+	}
+
+	plan := newSharedPlan(release)
+	plan.pin()
+	c.entries[key] = c.lru.PushFront(&planCacheEntry{key: key, plan: plan, value: value})
+
+	size := paramtable.Get().QueryNodeCfg.PlanCacheSize.GetAsInt()
+	for c.lru.Len() > size {
+		c.evict(c.lru.Back())
+	}
+	return plan, true
+}
+
+// clear evicts all the plans, the pinned ones are deleted once released.
+func (c *planCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.lru.Len() > 0 {
+		c.evict(c.lru.Back())
+	}
+}
+
+func (c *planCache) evict(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*planCacheEntry)
+	delete(c.entries, entry.key)
+	entry.plan.unpin()
+}
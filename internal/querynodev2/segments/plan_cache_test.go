@@ -0,0 +1,83 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestPlanCache(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.QueryNodeCfg.PlanCacheSize.Key, "2")
+	defer params.Reset(params.QueryNodeCfg.PlanCacheSize.Key)
+
+	released := make(map[string]int)
+	releaseFunc := func(key string) func() {
+		return func() { released[key]++ }
+	}
+
+	cache := newPlanCache()
+	_, _, ok := cache.get("a")
+	assert.False(t, ok)
+
+	planA, ok := cache.put("a", "valueA", releaseFunc("a"))
+	require.True(t, ok)
+	// the key is cached concurrently, the caller owns its plan
+	_, ok = cache.put("a", "valueA", releaseFunc("a"))
+	assert.False(t, ok)
+
+	shared, value, ok := cache.get("a")
+	require.True(t, ok)
+	assert.Equal(t, "valueA", value)
+	assert.Same(t, planA, shared)
+	shared.unpin()
+	planA.unpin()
+	assert.Empty(t, released)
+
+	planB, ok := cache.put("b", "valueB", releaseFunc("b"))
+	require.True(t, ok)
+	planB.unpin()
+	// a is the least recently used one
+	planC, ok := cache.put("c", "valueC", releaseFunc("c"))
+	require.True(t, ok)
+	assert.Equal(t, map[string]int{"a": 1}, released)
+	_, _, ok = cache.get("a")
+	assert.False(t, ok)
+
+	// the pinned plan is released after evicted and unpinned
+	cache.clear()
+	assert.Equal(t, map[string]int{"a": 1, "b": 1}, released)
+	planC.unpin()
+	assert.Equal(t, map[string]int{"a": 1, "b": 1, "c": 1}, released)
+}
+
+func TestSharedPlan(t *testing.T) {
+	released := 0
+	plan := newSharedPlan(func() { released++ })
+	assert.True(t, plan.pin())
+	plan.unpin()
+	plan.unpin()
+	assert.Equal(t, 1, released)
+	// the deleted plan can't be pinned anymore
+	assert.False(t, plan.pin())
+}
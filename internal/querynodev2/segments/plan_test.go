@@ -17,6 +17,7 @@
 package segments
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -72,7 +73,7 @@ func (suite *PlanSuite) TestPlanFail() {
 
 func (suite *PlanSuite) TestQueryPlanCollectionReleased() {
 	collection := &Collection{id: suite.collectionID}
-	_, err := NewRetrievePlan(collection, nil, 0, 0)
+	_, err := NewRetrievePlan(context.Background(), collection, nil, 0, 0)
 	suite.Error(err)
 }
 
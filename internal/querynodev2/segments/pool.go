@@ -19,8 +19,8 @@ package segments
 import (
 	"context"
 	"math"
-	"runtime"
 	"sync"
+	"time"
 
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
@@ -32,6 +32,12 @@ import (
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
+const (
+	sqPoolTag      = "SQPool"
+	dynamicPoolTag = "DynamicPool"
+	loadPoolTag    = "LoadPool"
+)
+
 var (
 	// Use separate pool for search/query
 	// and other operations (insert/delete/statistics/etc.)
@@ -49,13 +55,12 @@ var (
 func initSQPool() {
 	sqOnce.Do(func() {
 		pt := paramtable.Get()
-		initPoolSize := int(math.Ceil(pt.QueryNodeCfg.MaxReadConcurrency.GetAsFloat() * pt.QueryNodeCfg.CGOPoolSizeRatio.GetAsFloat()))
 		pool := conc.NewPool[any](
-			initPoolSize,
+			sqPoolSize(),
 			conc.WithPreAlloc(false), // pre alloc must be false to resize pool dynamically, use warmup to alloc worker here
 			conc.WithDisablePurge(true),
 		)
-		conc.WarmupPool(pool, runtime.LockOSThread)
+		conc.WarmupPool(pool, conc.LockOSThread(sqPoolTag))
 		sqp.Store(pool)
 
 		pt.Watch(pt.QueryNodeCfg.MaxReadConcurrency.Key, config.NewHandler("qn.sqpool.maxconc", ResizeSQPool))
@@ -69,7 +74,7 @@ func initDynamicPool() {
 			hardware.GetCPUNum(),
 			conc.WithPreAlloc(false),
 			conc.WithDisablePurge(false),
-			conc.WithPreHandler(runtime.LockOSThread), // lock os thread for cgo thread disposal
+			conc.WithPreHandler(conc.LockOSThread(dynamicPoolTag)), // lock os thread for cgo thread disposal
 		)
 
 		dp.Store(pool)
@@ -80,10 +85,10 @@ func initLoadPool() {
 	loadOnce.Do(func() {
 		pt := paramtable.Get()
 		pool := conc.NewPool[any](
-			hardware.GetCPUNum()*pt.CommonCfg.MiddlePriorityThreadCoreCoefficient.GetAsInt(),
+			loadPoolSize(),
 			conc.WithPreAlloc(false),
 			conc.WithDisablePurge(false),
-			conc.WithPreHandler(runtime.LockOSThread), // lock os thread for cgo thread disposal
+			conc.WithPreHandler(conc.LockOSThread(loadPoolTag)), // lock os thread for cgo thread disposal
 		)
 
 		loadPool.Store(pool)
@@ -111,22 +116,65 @@ func GetLoadPool() *conc.Pool[any] {
 
 func ResizeSQPool(evt *config.Event) {
 	if evt.HasUpdated {
-		pt := paramtable.Get()
-		newSize := int(math.Ceil(pt.QueryNodeCfg.MaxReadConcurrency.GetAsFloat() * pt.QueryNodeCfg.CGOPoolSizeRatio.GetAsFloat()))
 		pool := GetSQPool()
-		resizePool(pool, newSize, "SQPool")
-		conc.WarmupPool(pool, runtime.LockOSThread)
+		resizePool(pool, sqPoolSize(), sqPoolTag)
+		conc.WarmupPool(pool, conc.LockOSThread(sqPoolTag))
 	}
 }
 
 func ResizeLoadPool(evt *config.Event) {
 	if evt.HasUpdated {
-		pt := paramtable.Get()
-		newSize := hardware.GetCPUNum() * pt.CommonCfg.MiddlePriorityThreadCoreCoefficient.GetAsInt()
-		resizePool(GetLoadPool(), newSize, "LoadPool")
+		resizePool(GetLoadPool(), loadPoolSize(), loadPoolTag)
+	}
+}
+
+// ResizePoolsByCPUNum resizes the initialized pools sized by the cpu cores, e.g. after the cpu quota of container changes.
+func ResizePoolsByCPUNum() {
+	if pool := sqp.Load(); pool != nil {
+		// the max read concurrency is the ratio of the cpu cores
+		resizePool(pool, sqPoolSize(), sqPoolTag)
+		conc.WarmupPool(pool, conc.LockOSThread(sqPoolTag))
+	}
+	if pool := dp.Load(); pool != nil {
+		resizePool(pool, hardware.GetCPUNum(), dynamicPoolTag)
+	}
+	if pool := loadPool.Load(); pool != nil {
+		resizePool(pool, loadPoolSize(), loadPoolTag)
 	}
 }
 
+// WatchCPUQuota refreshes the cpu num by the cpu quota of container periodically,
+// and resizes the pools once it changes, e.g. the pod is scaled vertically, until the context is done.
+func WatchCPUQuota(ctx context.Context) {
+	interval := paramtable.Get().QueryNodeCfg.CPUQuotaCheckInterval.GetAsDuration(time.Second)
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("cpu quota watcher quit")
+			return
+		case <-ticker.C:
+			if cpuNum, changed := hardware.RefreshCPUNum(); changed {
+				log.Info("cpu num changed, resize the pools", zap.Int("cpuNum", cpuNum))
+				ResizePoolsByCPUNum()
+			}
+		}
+	}
+}
+
+func sqPoolSize() int {
+	pt := paramtable.Get()
+	return int(math.Ceil(pt.QueryNodeCfg.MaxReadConcurrency.GetAsFloat() * pt.QueryNodeCfg.CGOPoolSizeRatio.GetAsFloat()))
+}
+
+func loadPoolSize() int {
+	return hardware.GetCPUNum() * paramtable.Get().CommonCfg.MiddlePriorityThreadCoreCoefficient.GetAsInt()
+}
+
 func resizePool(pool *conc.Pool[any], newSize int, tag string) {
 	log := log.Ctx(context.Background()).
 		With(
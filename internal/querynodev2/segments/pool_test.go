@@ -82,6 +82,17 @@ func TestResizePools(t *testing.T) {
 		assert.Equal(t, expectedCap, GetLoadPool().Cap())
 	})
 
+	t.Run("CPUNum", func(t *testing.T) {
+		pt.Reset(pt.QueryNodeCfg.CGOPoolSizeRatio.Key)
+		pt.Reset(pt.CommonCfg.MiddlePriorityThreadCoreCoefficient.Key)
+		GetDynamicPool().Resize(1)
+
+		ResizePoolsByCPUNum()
+		assert.Equal(t, hardware.GetCPUNum(), GetDynamicPool().Cap())
+		assert.Equal(t, hardware.GetCPUNum()*pt.CommonCfg.MiddlePriorityThreadCoreCoefficient.GetAsInt(), GetLoadPool().Cap())
+		assert.Equal(t, int(math.Ceil(pt.QueryNodeCfg.MaxReadConcurrency.GetAsFloat()*pt.QueryNodeCfg.CGOPoolSizeRatio.GetAsFloat())), GetSQPool().Cap())
+	})
+
 	t.Run("error_pool", func(*testing.T) {
 		pool := conc.NewDefaultPool[any]()
 		c := pool.Cap()
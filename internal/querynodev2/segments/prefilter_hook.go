@@ -0,0 +1,167 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/timerecord"
+)
+
+// PreFilterHook is the plugin of the custom filters applied before the vector search,
+// e.g. the external bloom filters, or the row-level security of tenants keyed by the caller.
+// The hooks must be safe for concurrent use, and any error of them fails the search.
+type PreFilterHook interface {
+	// Name returns the unique name of the hook, which labels the metrics of the hook.
+	Name() string
+	// Predicate returns the predicate the searched rows must satisfy besides the filter of request, nil if none.
+	Predicate(ctx context.Context, req *PreFilterRequest) (*planpb.Expr, error)
+	// Filter returns false if no row of the segment satisfies the request, then the segment is skipped.
+	Filter(ctx context.Context, req *PreFilterRequest, segment Segment) (bool, error)
+}
+
+// PreFilterRequest is the search request the pre-filter hooks are applied to.
+type PreFilterRequest struct {
+	CollectionID int64
+	PartitionIDs []int64
+	// Username is the caller of the request, empty if the authorization is disabled
+	Username string
+	// Predicates is the filter of the request, including the predicates of the hooks, nil if none
+	Predicates *planpb.Expr
+}
+
+var preFilterHooks struct {
+	sync.RWMutex
+	hooks []PreFilterHook
+}
+
+// RegisterPreFilterHook registers the pre-filter hook, the hooks are applied in the order registered.
+func RegisterPreFilterHook(hook PreFilterHook) error {
+	preFilterHooks.Lock()
+	defer preFilterHooks.Unlock()
+	for _, registered := range preFilterHooks.hooks {
+		if registered.Name() == hook.Name() {
+			return merr.WrapErrParameterInvalidMsg("pre-filter hook %s already registered", hook.Name())
+		}
+	}
+	preFilterHooks.hooks = append(preFilterHooks.hooks, hook)
+	return nil
+}
+
+// UnregisterPreFilterHook unregisters the pre-filter hook by name.
+func UnregisterPreFilterHook(name string) {
+	preFilterHooks.Lock()
+	defer preFilterHooks.Unlock()
+	hooks := make([]PreFilterHook, 0, len(preFilterHooks.hooks))
+	for _, hook := range preFilterHooks.hooks {
+		if hook.Name() != name {
+			hooks = append(hooks, hook)
+		}
+	}
+	preFilterHooks.hooks = hooks
+}
+
+// HasPreFilterHooks returns whether any pre-filter hook is registered.
+func HasPreFilterHooks() bool {
+	preFilterHooks.RLock()
+	defer preFilterHooks.RUnlock()
+	return len(preFilterHooks.hooks) > 0
+}
+
+func getPreFilterHooks() []PreFilterHook {
+	preFilterHooks.RLock()
+	defer preFilterHooks.RUnlock()
+	return preFilterHooks.hooks
+}
+
+// applyPreFilterPredicates appends the predicates of the hooks to the filter of the search plan,
+// returns whether the plan is changed.
+func applyPreFilterPredicates(ctx context.Context, req *PreFilterRequest, plan *planpb.PlanNode) (bool, error) {
+	anns := plan.GetVectorAnns()
+	if anns == nil {
+		return false, nil
+	}
+
+	changed := false
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	for _, hook := range getPreFilterHooks() {
+		tr := timerecord.NewTimeRecorder("preFilterPredicate")
+		predicate, err := hook.Predicate(ctx, req)
+		metrics.QueryNodePreFilterHookLatency.WithLabelValues(nodeID, hook.Name(), metrics.PreFilterPredicate).
+			Observe(float64(tr.ElapseSpan().Milliseconds()))
+		if err != nil {
+			return false, errors.Wrapf(err, "pre-filter hook %s failed", hook.Name())
+		}
+		if predicate == nil {
+			continue
+		}
+		if anns.Predicates == nil {
+			anns.Predicates = predicate
+		} else {
+			anns.Predicates = &planpb.Expr{
+				Expr: &planpb.Expr_BinaryExpr{
+					BinaryExpr: &planpb.BinaryExpr{
+						Op:    planpb.BinaryExpr_LogicalAnd,
+						Left:  anns.Predicates,
+						Right: predicate,
+					},
+				},
+			}
+		}
+		req.Predicates = anns.Predicates
+		changed = true
+	}
+	return changed, nil
+}
+
+// filterSegmentsByPreFilterHooks filters out the segments which any hook proves no row satisfies the request.
+func filterSegmentsByPreFilterHooks(ctx context.Context, segments []Segment, req *PreFilterRequest) ([]Segment, error) {
+	hooks := getPreFilterHooks()
+	if len(hooks) == 0 || req == nil {
+		return segments, nil
+	}
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	for _, hook := range hooks {
+		result := make([]Segment, 0, len(segments))
+		tr := timerecord.NewTimeRecorder("preFilterSegment")
+		for _, segment := range segments {
+			ok, err := hook.Filter(ctx, req, segment)
+			if err != nil {
+				return nil, errors.Wrapf(err, "pre-filter hook %s failed on segment %d", hook.Name(), segment.ID())
+			}
+			if ok {
+				result = append(result, segment)
+			}
+		}
+		metrics.QueryNodePreFilterHookLatency.WithLabelValues(nodeID, hook.Name(), metrics.PreFilterSegment).
+			Observe(float64(tr.ElapseSpan().Milliseconds()))
+		if pruned := len(segments) - len(result); pruned > 0 {
+			metrics.QueryNodePreFilterHookPrunedSegments.WithLabelValues(nodeID, hook.Name()).Add(float64(pruned))
+		}
+		segments = result
+	}
+	return segments, nil
+}
@@ -0,0 +1,121 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// tenantHook restricts the rows to the tenant of the caller, and skips the segments of other tenants.
+type tenantHook struct {
+	segments map[int64]string
+	err      error
+}
+
+func (h *tenantHook) Name() string {
+	return "tenant"
+}
+
+func (h *tenantHook) Predicate(ctx context.Context, req *PreFilterRequest) (*planpb.Expr, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+		ColumnInfo: &planpb.ColumnInfo{FieldId: 101, DataType: schemapb.DataType_VarChar},
+		Op:         planpb.OpType_Equal,
+		Value:      &planpb.GenericValue{Val: &planpb.GenericValue_StringVal{StringVal: req.Username}},
+	}}}, nil
+}
+
+func (h *tenantHook) Filter(ctx context.Context, req *PreFilterRequest, segment Segment) (bool, error) {
+	if h.err != nil {
+		return false, h.err
+	}
+	tenant, ok := h.segments[segment.ID()]
+	return !ok || tenant == req.Username, nil
+}
+
+func TestPreFilterHook(t *testing.T) {
+	hook := &tenantHook{segments: map[int64]string{1: "alice", 2: "bob"}}
+	require.NoError(t, RegisterPreFilterHook(hook))
+	defer UnregisterPreFilterHook(hook.Name())
+	assert.True(t, HasPreFilterHooks())
+	assert.ErrorIs(t, RegisterPreFilterHook(hook), merr.ErrParameterInvalid)
+
+	ctx := context.Background()
+	filter := &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+		ColumnInfo: &planpb.ColumnInfo{FieldId: 100, DataType: schemapb.DataType_Int64},
+		Op:         planpb.OpType_GreaterThan,
+		Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: 10}},
+	}}}
+	plan := &planpb.PlanNode{Node: &planpb.PlanNode_VectorAnns{VectorAnns: &planpb.VectorANNS{Predicates: filter}}}
+	req := &PreFilterRequest{CollectionID: 1, Username: "alice", Predicates: filter}
+
+	t.Run("predicate", func(t *testing.T) {
+		changed, err := applyPreFilterPredicates(ctx, req, plan)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		and := planPredicates(plan).GetBinaryExpr()
+		require.NotNil(t, and)
+		assert.Equal(t, planpb.BinaryExpr_LogicalAnd, and.GetOp())
+		assert.Equal(t, filter, and.GetLeft())
+		assert.Equal(t, "alice", and.GetRight().GetUnaryRangeExpr().GetValue().GetStringVal())
+		assert.Equal(t, planPredicates(plan), req.Predicates)
+
+		// the plans without vector search are left as is
+		changed, err = applyPreFilterPredicates(ctx, req, &planpb.PlanNode{})
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("segment", func(t *testing.T) {
+		segments := []Segment{
+			&LocalSegment{baseSegment: baseSegment{segmentID: 1}},
+			&LocalSegment{baseSegment: baseSegment{segmentID: 2}},
+			&LocalSegment{baseSegment: baseSegment{segmentID: 3}},
+		}
+		filtered, err := filterSegmentsByPreFilterHooks(ctx, segments, req)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 3}, lo.Map(filtered, func(segment Segment, _ int) int64 { return segment.ID() }))
+
+		filtered, err = filterSegmentsByPreFilterHooks(ctx, segments, nil)
+		assert.NoError(t, err)
+		assert.Len(t, filtered, 3)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		hook.err = errors.New("mock")
+		defer func() { hook.err = nil }()
+		_, err := applyPreFilterPredicates(ctx, req, plan)
+		assert.Error(t, err)
+		_, err = filterSegmentsByPreFilterHooks(ctx, []Segment{&LocalSegment{baseSegment: baseSegment{segmentID: 1}}}, req)
+		assert.Error(t, err)
+	})
+
+	UnregisterPreFilterHook(hook.Name())
+	assert.False(t, HasPreFilterHooks())
+}
@@ -0,0 +1,82 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+/*
+#cgo pkg-config: milvus_segcore
+
+#include "segcore/segment_c.h"
+*/
+import "C"
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// newScanCounter returns the counter of the rows scanned by segcore for the request, which is shared by all the segments
+// searched or retrieved by the request, segcore fails the request once the rows exceed its limit of scanned rows.
+// The rows scanned are reported to proxy, which aggregates them of all the shards.
+func newScanCounter(ctx context.Context) C.CScanCounter {
+	return C.NewScanCounter(C.int64_t(contextutil.GetQueryLimits(ctx).MaxScannedRows))
+}
+
+func deleteScanCounter(counter C.CScanCounter) {
+	if counter != nil {
+		C.DeleteScanCounter(counter)
+	}
+}
+
+func getScannedRows(counter C.CScanCounter) int64 {
+	if counter == nil {
+		return 0
+	}
+	return int64(C.GetScannedRows(counter))
+}
+
+// resultBytesTracker accumulates the size of the results retrieved from the segments,
+// so the request is aborted once they exceed its limit of result bytes, without retrieving the rest.
+// The nil tracker means unlimited.
+type resultBytesTracker struct {
+	limit int64
+	total atomic.Int64
+}
+
+func newResultBytesTracker(ctx context.Context) *resultBytesTracker {
+	limit := contextutil.GetQueryLimits(ctx).MaxResultBytes
+	if limit <= 0 {
+		return nil
+	}
+	return &resultBytesTracker{limit: limit}
+}
+
+func (t *resultBytesTracker) add(result *segcorepb.RetrieveResults) error {
+	if t == nil {
+		return nil
+	}
+	total := t.total.Add(int64(proto.Size(result)))
+	if total > t.limit {
+		return merr.WrapErrServiceQueryLimitExceeded("maxResultBytes", total, t.limit, "results too large")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestResultBytesTracker(t *testing.T) {
+	result := &segcorepb.RetrieveResults{
+		Ids: &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}}}},
+	}
+	size := int64(proto.Size(result))
+
+	ctx := context.Background()
+	tracker := newResultBytesTracker(ctx)
+	assert.Nil(t, tracker)
+	assert.NoError(t, tracker.add(result))
+
+	tracker = newResultBytesTracker(contextutil.WithQueryLimits(ctx, contextutil.QueryLimits{MaxResultBytes: 2 * size}))
+	assert.NoError(t, tracker.add(result))
+	assert.NoError(t, tracker.add(result))
+	assert.ErrorIs(t, tracker.add(result), merr.ErrServiceQueryLimitExceeded)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/util/bufferpool"
 	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
@@ -56,6 +57,7 @@ func ReduceSearchResults(ctx context.Context, results []*internalpb.SearchResult
 		log.Warn("shard leader decode search results errors", zap.Error(err))
 		return nil, err
 	}
+	recycleSlicedBlobs(results)
 	log.Debug("shard leader get valid search results", zap.Int("numbers", len(searchResultData)))
 
 	for i, sData := range searchResultData {
@@ -87,7 +89,10 @@ func ReduceSearchResults(ctx context.Context, results []*internalpb.SearchResult
 
 		return nil, false
 	})
-	searchResults.CostAggregation = mergeRequestCost(requestCosts)
+	searchResults.CostAggregation = mergeScannedRows(mergeRequestCost(requestCosts),
+		lo.SumBy(results, func(result *internalpb.SearchResults) int64 {
+			return result.GetCostAggregation().GetScannedRows()
+		}))
 
 	return searchResults, nil
 }
@@ -230,16 +235,25 @@ func EncodeSearchResultData(searchResultData *schemapb.SearchResultData, nq int6
 		MetricType: metricType,
 		SlicedBlob: nil,
 	}
-	slicedBlob, err := proto.Marshal(searchResultData)
-	if err != nil {
-		return nil, err
+	if searchResultData == nil || searchResultData.Ids == nil || typeutil.GetSizeOfIDs(searchResultData.Ids) == 0 {
+		return
 	}
-	if searchResultData != nil && searchResultData.Ids != nil && typeutil.GetSizeOfIDs(searchResultData.Ids) != 0 {
-		searchResults.SlicedBlob = slicedBlob
+	buf := proto.NewBuffer(bufferpool.SearchResultPool.Get(proto.Size(searchResultData)))
+	if err := buf.Marshal(searchResultData); err != nil {
+		return nil, err
 	}
+	searchResults.SlicedBlob = buf.Bytes()
 	return
 }
 
+// recycleSlicedBlobs recycles the sliced blobs of the search results which are decoded.
+func recycleSlicedBlobs(searchResults []*internalpb.SearchResults) {
+	for _, result := range searchResults {
+		bufferpool.SearchResultPool.Put(result.GetSlicedBlob())
+		result.SlicedBlob = nil
+	}
+}
+
 func MergeInternalRetrieveResult(ctx context.Context, retrieveResults []*internalpb.RetrieveResults, param *mergeParam) (*internalpb.RetrieveResults, error) {
 	log.Ctx(ctx).Debug("mergeInternelRetrieveResults",
 		zap.Int64("limit", param.limit),
@@ -327,7 +341,10 @@ func MergeInternalRetrieveResult(ctx context.Context, retrieveResults []*interna
 
 		return nil, false
 	})
-	ret.CostAggregation = mergeRequestCost(requestCosts)
+	ret.CostAggregation = mergeScannedRows(mergeRequestCost(requestCosts),
+		lo.SumBy(retrieveResults, func(result *internalpb.RetrieveResults) int64 {
+			return result.GetCostAggregation().GetScannedRows()
+		}))
 
 	return ret, nil
 }
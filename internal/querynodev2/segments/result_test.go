@@ -820,6 +820,19 @@ func TestResult_MergeRequestCost(t *testing.T) {
 	assert.Equal(t, int64(43), channelCost.TotalNQ)
 }
 
+func TestMergeScannedRows(t *testing.T) {
+	cost := &internalpb.CostAggregation{ResponseTime: 1, ServiceTime: 2, TotalNQ: 3}
+	assert.Same(t, cost, mergeScannedRows(cost, 0))
+
+	merged := mergeScannedRows(cost, 100)
+	assert.Equal(t, int64(1), merged.GetResponseTime())
+	assert.Equal(t, int64(2), merged.GetServiceTime())
+	assert.Equal(t, int64(100), merged.GetScannedRows())
+	assert.Equal(t, int64(0), cost.GetScannedRows())
+
+	assert.Equal(t, int64(100), mergeScannedRows(nil, 100).GetScannedRows())
+}
+
 func TestResult(t *testing.T) {
 	paramtable.Init()
 	suite.Run(t, new(ResultSuite))
@@ -45,6 +45,7 @@ func retrieveOnSegments(ctx context.Context, segments []Segment, segType Segment
 	if segType == commonpb.SegmentState_Growing {
 		label = metrics.GrowingSegmentLabel
 	}
+	tracker := newResultBytesTracker(ctx)
 
 	for i, segment := range segments {
 		wg.Add(1)
@@ -52,6 +53,12 @@ func retrieveOnSegments(ctx context.Context, segments []Segment, segType Segment
 			defer wg.Done()
 			tr := timerecord.NewTimeRecorder("retrieveOnSegments")
 			result, err := seg.Retrieve(ctx, plan)
+			if err == nil {
+				result, err = plan.systemFilter.filterResult(result)
+			}
+			if err == nil {
+				err = tracker.add(result)
+			}
 			if err != nil {
 				errs[i] = err
 				return
@@ -89,6 +96,7 @@ func retrieveOnSegmentsWithStream(ctx context.Context, segments []Segment, segTy
 	if segType == commonpb.SegmentState_Growing {
 		label = metrics.GrowingSegmentLabel
 	}
+	tracker := newResultBytesTracker(ctx)
 
 	for i, segment := range segments {
 		wg.Add(1)
@@ -96,6 +104,12 @@ func retrieveOnSegmentsWithStream(ctx context.Context, segments []Segment, segTy
 			defer wg.Done()
 			tr := timerecord.NewTimeRecorder("retrieveOnSegmentsWithStream")
 			result, err := segment.Retrieve(ctx, plan)
+			if err == nil {
+				result, err = plan.systemFilter.filterResult(result)
+			}
+			if err == nil {
+				err = tracker.add(result)
+			}
 			if err != nil {
 				errs[i] = err
 				return
@@ -140,7 +154,13 @@ func Retrieve(ctx context.Context, manager *Manager, plan *RetrievePlan, req *qu
 		return retrieveResults, retrieveSegments, err
 	}
 
-	retrieveResults, err = retrieveOnSegments(ctx, retrieveSegments, SegType, plan)
+	retrieved := plan.systemFilter.filterSegments(retrieveSegments)
+	retrieved = pruneSegmentsByJSONKeys(retrieved, plan.predicates)
+	retrieved = pruneSegmentsByFieldStats(retrieved, plan.predicates, metrics.QueryLabel)
+	if SegType == SegmentTypeSealed {
+		manager.Access.Record(retrieved, plan.accessedFields...)
+	}
+	retrieveResults, err = retrieveOnSegments(ctx, retrieved, SegType, plan)
 	return retrieveResults, retrieveSegments, err
 }
 
@@ -165,6 +185,12 @@ func RetrieveStream(ctx context.Context, manager *Manager, plan *RetrievePlan, r
 		return retrieveSegments, err
 	}
 
-	err = retrieveOnSegmentsWithStream(ctx, retrieveSegments, SegType, plan, srv)
+	retrieved := plan.systemFilter.filterSegments(retrieveSegments)
+	retrieved = pruneSegmentsByJSONKeys(retrieved, plan.predicates)
+	retrieved = pruneSegmentsByFieldStats(retrieved, plan.predicates, metrics.QueryLabel)
+	if SegType == SegmentTypeSealed {
+		manager.Access.Record(retrieved, plan.accessedFields...)
+	}
+	err = retrieveOnSegmentsWithStream(ctx, retrieved, SegType, plan, srv)
 	return retrieveSegments, err
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/initcore"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
@@ -180,6 +181,30 @@ func (suite *RetrieveSuite) TestRetrieveGrowing() {
 	suite.manager.Segment.Unpin(segments)
 }
 
+func (suite *RetrieveSuite) TestRetrieveScanLimit() {
+	planBytes, err := genSimpleRetrievePlanExpr(suite.collection.Schema())
+	suite.NoError(err)
+
+	req := &querypb.QueryRequest{
+		Req: &internalpb.RetrieveRequest{
+			CollectionID: suite.collectionID,
+			PartitionIDs: []int64{suite.partitionID},
+		},
+		SegmentIDs: []int64{suite.sealed.ID()},
+		Scope:      querypb.DataScope_Historical,
+	}
+
+	// the rows of the 3 primary keys at least are scanned
+	ctx := contextutil.WithQueryLimits(context.Background(), contextutil.QueryLimits{MaxScannedRows: 2})
+	plan, err := NewRetrievePlan(ctx, suite.collection, planBytes, 1000, 100)
+	suite.NoError(err)
+	defer plan.Delete()
+
+	_, segments, err := Retrieve(ctx, suite.manager, plan, req)
+	suite.ErrorIs(err, merr.ErrServiceQueryLimitExceeded)
+	suite.manager.Segment.Unpin(segments)
+}
+
 func (suite *RetrieveSuite) TestRetrieveStreamSealed() {
 	plan, err := genSimpleRetrievePlan(suite.collection)
 	suite.NoError(err)
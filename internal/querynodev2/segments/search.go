@@ -103,7 +103,15 @@ func SearchHistorical(ctx context.Context, manager *Manager, searchReq *SearchRe
 	if err != nil {
 		return nil, nil, err
 	}
-	searchResults, err := searchSegments(ctx, segments, SegmentTypeSealed, searchReq)
+	// pruned segments are still returned to be unpinned by the caller
+	searched := pruneSegmentsByJSONKeys(segments, searchReq.predicates)
+	searched = pruneSegmentsByFieldStats(searched, searchReq.predicates, metrics.SearchLabel)
+	searched, err = filterSegmentsByPreFilterHooks(ctx, searched, searchReq.preFilter)
+	if err != nil {
+		return nil, segments, err
+	}
+	manager.Access.Record(searched, searchReq.accessedFields...)
+	searchResults, err := searchSegments(ctx, searched, SegmentTypeSealed, searchReq)
 	return searchResults, segments, err
 }
 
@@ -114,6 +122,10 @@ func SearchStreaming(ctx context.Context, manager *Manager, searchReq *SearchReq
 	if err != nil {
 		return nil, nil, err
 	}
-	searchResults, err := searchSegments(ctx, segments, SegmentTypeGrowing, searchReq)
+	searched, err := filterSegmentsByPreFilterHooks(ctx, segments, searchReq.preFilter)
+	if err != nil {
+		return nil, segments, err
+	}
+	searchResults, err := searchSegments(ctx, searched, SegmentTypeGrowing, searchReq)
 	return searchResults, segments, err
 }
@@ -0,0 +1,56 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// The error codes of segcore, keep consistent with internal/core/src/common/EasyAssert.h
+const (
+	segcoreNotImplemented    int32 = 2002
+	segcoreUnsupported       int32 = 2003
+	segcoreConfigInvalid     int32 = 2006
+	segcoreDataTypeInvalid   int32 = 2007
+	segcoreFieldIDInvalid    int32 = 2020
+	segcoreOpTypeInvalid     int32 = 2022
+	segcoreJSONKeyInvalid    int32 = 2025
+	segcoreMetricTypeInvalid int32 = 2026
+	segcoreFieldNotLoaded    int32 = 2027
+	segcoreExprInvalid       int32 = 2028
+	segcoreMemAllocateFailed int32 = 2029
+	segcoreScanLimitExceeded int32 = 2031
+)
+
+// segcoreError maps the error code of segcore to the typed error,
+// so that the callers may tell whether to retry, and the users get actionable messages.
+func segcoreError(code int32, msg string) error {
+	switch code {
+	case segcoreNotImplemented, segcoreUnsupported, segcoreConfigInvalid, segcoreDataTypeInvalid,
+		segcoreFieldIDInvalid, segcoreOpTypeInvalid, segcoreJSONKeyInvalid, segcoreMetricTypeInvalid,
+		segcoreExprInvalid:
+		return merr.WrapErrSegcorePlanInvalid(code, msg)
+	case segcoreFieldNotLoaded:
+		return merr.WrapErrSegcoreFieldNotLoaded(code, msg)
+	case segcoreMemAllocateFailed:
+		return merr.WrapErrSegcoreOutOfMemory(code, msg)
+	case segcoreScanLimitExceeded:
+		return merr.WrapErrSegcoreScanLimitExceeded(code, msg)
+	default:
+		return merr.WrapErrSegcore(code, msg)
+	}
+}
@@ -0,0 +1,44 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestSegcoreError(t *testing.T) {
+	err := segcoreError(segcoreExprInvalid, "Search failed, segmentID=1, collectionID=2: invalid expr")
+	assert.ErrorIs(t, err, merr.ErrSegcorePlanInvalid)
+	assert.Contains(t, err.Error(), "segmentID=1, collectionID=2")
+
+	err = segcoreError(segcoreFieldNotLoaded, "Search failed: field not loaded")
+	assert.ErrorIs(t, err, merr.ErrSegcoreFieldNotLoaded)
+
+	err = segcoreError(segcoreMemAllocateFailed, "Search failed: std::bad_alloc")
+	assert.ErrorIs(t, err, merr.ErrSegcoreOutOfMemory)
+
+	err = segcoreError(segcoreScanLimitExceeded, "Retrieve failed: too many rows to scan")
+	assert.ErrorIs(t, err, merr.ErrServiceQueryLimitExceeded)
+
+	err = segcoreError(2001, "Search failed: unexpected")
+	assert.ErrorIs(t, err, merr.ErrSegcore)
+	assert.NotErrorIs(t, err, merr.ErrSegcorePlanInvalid)
+}
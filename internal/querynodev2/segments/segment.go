@@ -19,6 +19,7 @@ package segments
 /*
 #cgo pkg-config: milvus_segcore
 
+#include <stdlib.h>
 #include "segcore/collection_c.h"
 #include "segcore/plan_c.h"
 #include "segcore/reduce_c.h"
@@ -41,10 +42,13 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
 	"github.com/milvus-io/milvus/internal/querynodev2/pkoracle"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/fieldstats"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -147,6 +151,9 @@ type LocalSegment struct {
 	baseSegment
 	ptrLock sync.RWMutex // protects segmentPtr
 	ptr     C.CSegmentInterface
+	// fenced is set once the segment starts releasing, the new readers fail fast
+	// instead of queueing behind the release waiting for the in-flight readers
+	fenced atomic.Bool
 
 	// cached results, to avoid too many CGO calls
 	memSize     *atomic.Int64
@@ -155,6 +162,10 @@ type LocalSegment struct {
 
 	lastDeltaTimestamp *atomic.Uint64
 	fieldIndexes       *typeutil.ConcurrentMap[int64, *IndexedFieldInfo]
+	// json key stats loaded along with the segment, keyed by field id and json path
+	jsonKeyStats *typeutil.ConcurrentMap[string, *jsonkey.Stats]
+	// field stats loaded from the field stats log, nil if the segment has none
+	fieldStats atomic.Pointer[fieldstats.SegmentStats]
 }
 
 func NewSegment(collection *Collection,
@@ -203,6 +214,7 @@ func NewSegment(collection *Collection,
 		ptr:                newPtr,
 		lastDeltaTimestamp: atomic.NewUint64(0),
 		fieldIndexes:       typeutil.NewConcurrentMap[int64, *IndexedFieldInfo](),
+		jsonKeyStats:       typeutil.NewConcurrentMap[string, *jsonkey.Stats](),
 
 		memSize:     atomic.NewInt64(-1),
 		rowNum:      atomic.NewInt64(-1),
@@ -220,6 +232,9 @@ func (s *LocalSegment) isValid() bool {
 // Provide ONLY the read lock operations,
 // don't make `ptrLock` public to avoid abusing of the mutex.
 func (s *LocalSegment) RLock() error {
+	if s.fenced.Load() {
+		return merr.WrapErrSegmentNotLoaded(s.ID(), "segment released")
+	}
 	s.ptrLock.RLock()
 	if !s.isValid() {
 		s.ptrLock.RUnlock()
@@ -308,6 +323,30 @@ func (s *LocalSegment) ExistIndex(fieldID int64) bool {
 	return fieldInfo.IndexInfo != nil && fieldInfo.IndexInfo.EnableIndex
 }
 
+func (s *LocalSegment) AddJSONKeyStats(fieldID int64, stats *jsonkey.Stats) {
+	s.jsonKeyStats.Insert(jsonKeyStatsKey(fieldID, stats.Path), stats)
+}
+
+// MayMatchJSONKeys returns false only if the json key stats prove that no row of the segment matches expr
+func (s *LocalSegment) MayMatchJSONKeys(expr *planpb.Expr) bool {
+	if expr == nil || s.jsonKeyStats.Len() == 0 {
+		return true
+	}
+	return jsonkey.MayMatch(expr, func(fieldID int64, path []string) *jsonkey.Stats {
+		stats, _ := s.jsonKeyStats.Get(jsonKeyStatsKey(fieldID, path))
+		return stats
+	})
+}
+
+func (s *LocalSegment) SetFieldStats(stats *fieldstats.SegmentStats) {
+	s.fieldStats.Store(stats)
+}
+
+// GetFieldStats returns the statistics of scalar fields, nil if not loaded
+func (s *LocalSegment) GetFieldStats() *fieldstats.SegmentStats {
+	return s.fieldStats.Load()
+}
+
 func (s *LocalSegment) HasRawData(fieldID int64) bool {
 	s.ptrLock.RLock()
 	defer s.ptrLock.RUnlock()
@@ -327,10 +366,27 @@ func (s *LocalSegment) Indexes() []*IndexedFieldInfo {
 	return result
 }
 
+// gpuIndexFields returns the fields with GPU index.
+func (s *LocalSegment) gpuIndexFields() []int64 {
+	var fieldIDs []int64
+	s.fieldIndexes.Range(func(fieldID int64, info *IndexedFieldInfo) bool {
+		if isGpuIndex(info.IndexInfo) {
+			fieldIDs = append(fieldIDs, fieldID)
+		}
+		return true
+	})
+	return fieldIDs
+}
+
 func (s *LocalSegment) Type() SegmentType {
 	return s.typ
 }
 
+// handleCStatus deals with the error returned from segcore, with the segment and collection as context.
+func (s *LocalSegment) handleCStatus(status *C.CStatus, extraInfo string) error {
+	return HandleCStatus(status, fmt.Sprintf("%s, segmentID=%d, collectionID=%d", extraInfo, s.ID(), s.Collection()))
+}
+
 func (s *LocalSegment) Search(ctx context.Context, searchReq *SearchRequest) (*SearchResult, error) {
 	/*
 		CStatus
@@ -346,6 +402,13 @@ func (s *LocalSegment) Search(ctx context.Context, searchReq *SearchRequest) (*S
 		zap.Int64("segmentID", s.ID()),
 		zap.String("segmentType", s.typ.String()),
 	)
+	// restore the spilled GPU index before taking the lock, as it may spill the indexes of this segment
+	release, err := GetGPUMemoryManager().Acquire(s.ID(), searchReq.searchFieldID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	s.ptrLock.RLock()
 	defer s.ptrLock.RUnlock()
 
@@ -369,18 +432,28 @@ func (s *LocalSegment) Search(ctx context.Context, searchReq *SearchRequest) (*S
 
 	var searchResult SearchResult
 	var status C.CStatus
+	canceled := false
 	GetSQPool().Submit(func() (any, error) {
+		// the request may be canceled while waiting in the pool
+		if ctx.Err() != nil {
+			canceled = true
+			return nil, nil
+		}
 		tr := timerecord.NewTimeRecorder("cgoSearch")
 		status = C.Search(s.ptr,
 			searchReq.plan.cSearchPlan,
 			searchReq.cPlaceholderGroup,
 			traceCtx,
+			searchReq.scanCounter,
 			&searchResult.cSearchResult,
 		)
 		metrics.QueryNodeSQSegmentLatencyInCore.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.SearchLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
 		return nil, nil
 	}).Await()
-	if err := HandleCStatus(&status, "Search failed"); err != nil {
+	if canceled {
+		return nil, merr.WrapErrSegcoreRequestCanceled(s.ID(), ctx.Err().Error())
+	}
+	if err := s.handleCStatus(&status, "Search failed"); err != nil {
 		return nil, err
 	}
 	log.Debug("search segment done")
@@ -388,6 +461,13 @@ func (s *LocalSegment) Search(ctx context.Context, searchReq *SearchRequest) (*S
 }
 
 func (s *LocalSegment) Retrieve(ctx context.Context, plan *RetrievePlan) (*segcorepb.RetrieveResults, error) {
+	// the vectors may be read from the GPU indexes without raw data
+	release, err := GetGPUMemoryManager().Acquire(s.ID(), s.gpuIndexFields()...)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	s.ptrLock.RLock()
 	defer s.ptrLock.RUnlock()
 
@@ -416,13 +496,20 @@ func (s *LocalSegment) Retrieve(ctx context.Context, plan *RetrievePlan) (*segco
 	maxLimitSize := paramtable.Get().QuotaConfig.MaxOutputSize.GetAsInt64()
 	var retrieveResult RetrieveResult
 	var status C.CStatus
+	canceled := false
 	GetSQPool().Submit(func() (any, error) {
+		// the request may be canceled while waiting in the pool
+		if ctx.Err() != nil {
+			canceled = true
+			return nil, nil
+		}
 		ts := C.uint64_t(plan.Timestamp)
 		tr := timerecord.NewTimeRecorder("cgoRetrieve")
 		status = C.Retrieve(s.ptr,
 			plan.cRetrievePlan,
 			traceCtx,
 			ts,
+			plan.scanCounter,
 			&retrieveResult.cRetrieveResult,
 			C.int64_t(maxLimitSize))
 
@@ -432,7 +519,10 @@ func (s *LocalSegment) Retrieve(ctx context.Context, plan *RetrievePlan) (*segco
 		return nil, nil
 	}).Await()
 
-	if err := HandleCStatus(&status, "Retrieve failed"); err != nil {
+	if canceled {
+		return nil, merr.WrapErrSegcoreRequestCanceled(s.ID(), ctx.Err().Error())
+	}
+	if err := s.handleCStatus(&status, "Retrieve failed"); err != nil {
 		return nil, err
 	}
 
@@ -477,7 +567,7 @@ func (s *LocalSegment) preInsert(numOfRecords int) (int64, error) {
 		status = C.PreInsert(s.ptr, C.int64_t(int64(numOfRecords)), cOffset)
 		return nil, nil
 	}).Await()
-	if err := HandleCStatus(&status, "PreInsert failed"); err != nil {
+	if err := s.handleCStatus(&status, "PreInsert failed"); err != nil {
 		return 0, err
 	}
 	return offset, nil
@@ -524,16 +614,17 @@ func (s *LocalSegment) Insert(rowIDs []int64, timestamps []typeutil.Timestamp, r
 		)
 		return nil, nil
 	}).Await()
-	if err := HandleCStatus(&status, "Insert failed"); err != nil {
+	if err := s.handleCStatus(&status, "Insert failed"); err != nil {
 		return err
 	}
 
 	s.insertCount.Add(int64(numOfRow))
 	s.rowNum.Store(-1)
 	s.memSize.Store(-1)
+	chargeSegmentMemory(s.typ, s.ID(), uint64(len(insertRecordBlob)))
 	metrics.QueryNodeNumEntities.WithLabelValues(
 		fmt.Sprint(paramtable.GetNodeID()),
-		fmt.Sprint(s.collectionID),
+		metrics.CollectionIDLabel(s.collectionID),
 		fmt.Sprint(s.partitionID),
 		s.Type().String(),
 		fmt.Sprint(0),
@@ -609,7 +700,7 @@ func (s *LocalSegment) Delete(primaryKeys []storage.PrimaryKey, timestamps []typ
 		return nil, nil
 	}).Await()
 
-	if err := HandleCStatus(&status, "Delete failed"); err != nil {
+	if err := s.handleCStatus(&status, "Delete failed"); err != nil {
 		return err
 	}
 
@@ -662,7 +753,7 @@ func (s *LocalSegment) LoadMultiFieldData(rowCount int64, fields []*datapb.Field
 		status = C.LoadFieldData(s.ptr, loadFieldDataInfo.cLoadFieldDataInfo)
 		return nil, nil
 	}).Await()
-	if err := HandleCStatus(&status, "LoadMultiFieldData failed"); err != nil {
+	if err := s.handleCStatus(&status, "LoadMultiFieldData failed"); err != nil {
 		return err
 	}
 
@@ -708,8 +799,17 @@ func (s *LocalSegment) LoadFieldData(fieldID int64, rowCount int64, field *datap
 			return err
 		}
 	}
-	loadFieldDataInfo.appendMMapDirPath(paramtable.Get().QueryNodeCfg.MmapDirPath.GetValue())
+	mmapDirPath := paramtable.Get().QueryNodeCfg.MmapDirPath.GetValue()
+	loadFieldDataInfo.appendMMapDirPath(mmapDirPath)
 	loadFieldDataInfo.enableMmap(fieldID, mmapEnabled)
+	localRecovery := mmapEnabled && len(mmapDirPath) > 0 && paramtable.Get().QueryNodeCfg.LocalRecoveryEnabled.GetAsBool()
+	if localRecovery {
+		reuse := GetLocalCatalog().MmapReusable(s.ID(), field)
+		if reuse {
+			log.Info("reuse the mmap file cached on local disk")
+		}
+		loadFieldDataInfo.enableLocalRecovery(fieldID, reuse)
+	}
 
 	var status C.CStatus
 	GetLoadPool().Submit(func() (any, error) {
@@ -717,9 +817,14 @@ func (s *LocalSegment) LoadFieldData(fieldID int64, rowCount int64, field *datap
 		status = C.LoadFieldData(s.ptr, loadFieldDataInfo.cLoadFieldDataInfo)
 		return nil, nil
 	}).Await()
-	if err := HandleCStatus(&status, "LoadFieldData failed"); err != nil {
+	if err := s.handleCStatus(&status, "LoadFieldData failed"); err != nil {
 		return err
 	}
+	if localRecovery {
+		if err := GetLocalCatalog().SaveMmap(s.Collection(), s.Partition(), s.ID(), field); err != nil {
+			log.Warn("failed to save the mmap file into local catalog", zap.Error(err))
+		}
+	}
 
 	s.insertCount.Store(rowCount)
 	log.Info("load field done")
@@ -768,7 +873,7 @@ func (s *LocalSegment) AddFieldDataInfo(rowCount int64, fields []*datapb.FieldBi
 		status = C.AddFieldDataInfoForSealed(s.ptr, loadFieldDataInfo.cLoadFieldDataInfo)
 		return nil, nil
 	}).Await()
-	if err := HandleCStatus(&status, "AddFieldDataInfo failed"); err != nil {
+	if err := s.handleCStatus(&status, "AddFieldDataInfo failed"); err != nil {
 		return err
 	}
 
@@ -841,7 +946,7 @@ func (s *LocalSegment) LoadDeltaData(deltaData *storage.DeleteData) error {
 		return nil, nil
 	}).Await()
 
-	if err := HandleCStatus(&status, "LoadDeletedRecord failed"); err != nil {
+	if err := s.handleCStatus(&status, "LoadDeletedRecord failed"); err != nil {
 		return err
 	}
 
@@ -898,7 +1003,7 @@ func (s *LocalSegment) LoadIndexInfo(indexInfo *querypb.FieldIndexInfo, info *Lo
 		return nil, nil
 	}).Await()
 
-	if err := HandleCStatus(&status, "UpdateSealedSegmentIndex failed"); err != nil {
+	if err := s.handleCStatus(&status, "UpdateSealedSegmentIndex failed"); err != nil {
 		return err
 	}
 	log.Info("updateSegmentIndex done")
@@ -906,6 +1011,64 @@ func (s *LocalSegment) LoadIndexInfo(indexInfo *querypb.FieldIndexInfo, info *Lo
 	return nil
 }
 
+// SpillIndex serializes the index of the field to host memory and frees the GPU memory of it.
+func (s *LocalSegment) SpillIndex(fieldID int64) error {
+	return s.moveIndex(fieldID, "SpillSealedSegmentIndex", func() C.CStatus {
+		return C.SpillSealedSegmentIndex(s.ptr, C.int64_t(fieldID))
+	})
+}
+
+// RestoreIndex loads the spilled index of the field back to GPU memory.
+func (s *LocalSegment) RestoreIndex(fieldID int64) error {
+	return s.moveIndex(fieldID, "RestoreSealedSegmentIndex", func() C.CStatus {
+		return C.RestoreSealedSegmentIndex(s.ptr, C.int64_t(fieldID))
+	})
+}
+
+func (s *LocalSegment) moveIndex(fieldID int64, op string, move func() C.CStatus) error {
+	s.ptrLock.RLock()
+	defer s.ptrLock.RUnlock()
+
+	if s.ptr == nil {
+		return merr.WrapErrSegmentNotLoaded(s.segmentID, "segment released")
+	}
+
+	var status C.CStatus
+	GetLoadPool().Submit(func() (any, error) {
+		status = move()
+		return nil, nil
+	}).Await()
+	return s.handleCStatus(&status, fmt.Sprintf("%s failed, fieldID=%d", op, fieldID))
+}
+
+// QuantizeFieldData quantizes the loaded vectors of the field in memory,
+// the search on the field refines the candidates of the quantized vectors by the raw ones.
+func (s *LocalSegment) QuantizeFieldData(fieldID int64, quantization string) error {
+	s.ptrLock.RLock()
+	defer s.ptrLock.RUnlock()
+
+	if s.ptr == nil {
+		return merr.WrapErrSegmentNotLoaded(s.segmentID, "segment released")
+	}
+
+	cQuantization := C.CString(quantization)
+	defer C.free(unsafe.Pointer(cQuantization))
+	var status C.CStatus
+	GetLoadPool().Submit(func() (any, error) {
+		status = C.QuantizeSealedSegmentFieldData(s.ptr, C.int64_t(fieldID), cQuantization)
+		return nil, nil
+	}).Await()
+	if err := s.handleCStatus(&status, "QuantizeSealedSegmentFieldData failed"); err != nil {
+		return err
+	}
+	log.Info("quantize field data done",
+		zap.Int64("collectionID", s.Collection()),
+		zap.Int64("segmentID", s.ID()),
+		zap.Int64("fieldID", fieldID),
+		zap.String("quantization", quantization))
+	return nil
+}
+
 func (s *LocalSegment) UpdateFieldRawDataSize(numRows int64, fieldBinlog *datapb.FieldBinlog) error {
 	var status C.CStatus
 	fieldID := fieldBinlog.FieldID
@@ -918,7 +1081,7 @@ func (s *LocalSegment) UpdateFieldRawDataSize(numRows int64, fieldBinlog *datapb
 		return nil, nil
 	}).Await()
 
-	if err := HandleCStatus(&status, "updateFieldRawDataSize failed"); err != nil {
+	if err := s.handleCStatus(&status, "updateFieldRawDataSize failed"); err != nil {
 		return err
 	}
 
@@ -932,9 +1095,10 @@ func (s *LocalSegment) Release() {
 		void
 		deleteSegment(CSegmentInterface segment);
 	*/
-	// wait all read ops finished
+	// fence the new readers, then wait all read ops finished
 	var ptr C.CSegmentInterface
 
+	s.fenced.Store(true)
 	s.ptrLock.Lock()
 	ptr = s.ptr
 	s.ptr = nil
@@ -945,6 +1109,15 @@ func (s *LocalSegment) Release() {
 	}
 
 	C.DeleteSegment(ptr)
+	if s.typ == SegmentTypeSealed {
+		GetGPUMemoryManager().Remove(s.ID())
+	}
+	releaseSegmentDisk(s.typ, s.ID())
+	if s.typ == SegmentTypeSealed && (paramtable.Get().QueryNodeCfg.LocalRecoveryEnabled.GetAsBool() ||
+		paramtable.Get().QueryNodeCfg.IndexPeerFetchEnabled.GetAsBool()) {
+		GetLocalCatalog().RemoveSegment(s.ID())
+	}
+	releaseSegmentMemory(s.typ, s.ID())
 	log.Info("delete segment from memory",
 		zap.Int64("collectionID", s.collectionID),
 		zap.Int64("partitionID", s.partitionID),
@@ -161,4 +161,6 @@ func (s *L0Segment) Release() {
 
 	s.pks = nil
 	s.tss = nil
+	releaseSegmentDisk(s.Type(), s.ID())
+	releaseSegmentMemory(s.Type(), s.ID())
 }
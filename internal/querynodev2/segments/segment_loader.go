@@ -22,6 +22,7 @@ import (
 	"path"
 	"runtime/debug"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -29,7 +30,6 @@ import (
 	"github.com/samber/lo"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
@@ -37,9 +37,12 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querynodev2/pkoracle"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/initcore"
+	"github.com/milvus-io/milvus/internal/util/jsonkey"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/conc"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/hardware"
 	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
@@ -51,6 +54,8 @@ import (
 
 const (
 	UsedDiskMemoryRatio = 4
+	// SQ8CompressionRatio is the ratio of the raw float vectors to the SQ8 quantized ones
+	SQ8CompressionRatio = 4
 )
 
 type Loader interface {
@@ -146,6 +151,9 @@ type segmentLoader struct {
 	// The channel will be closed as the segment loaded
 	loadingSegments   *typeutil.ConcurrentMap[int64, *loadResult]
 	committedResource LoadResource
+
+	// indexFetcher fetches the disk index files from the peers, nil if not supported
+	indexFetcher IndexFileFetcher
 }
 
 var _ Loader = (*segmentLoader)(nil)
@@ -165,6 +173,10 @@ func (loader *segmentLoader) Load(ctx context.Context,
 		log.Info("no segment to load")
 		return nil, nil
 	}
+	if err := loader.routeCollection(ctx, collectionID); err != nil {
+		log.Warn("failed to route collection to its bucket", zap.Error(err))
+		return nil, err
+	}
 	// Filter out loaded & loading segments
 	infos := loader.prepare(segmentType, version, segments...)
 	defer loader.unregister(infos...)
@@ -177,10 +189,18 @@ func (loader *segmentLoader) Load(ctx context.Context,
 	// continue to wait other task done
 	log.Info("start loading...", zap.Int("segmentNum", len(segments)), zap.Int("afterFilter", len(infos)))
 
-	// Check memory & storage limit
-	resource, concurrencyLevel, err := loader.requestResource(ctx, infos...)
+	// reserve disk before checking segment size, it's held until the segments released,
+	// the chunk cache evicted to make room for the segments is not counted then
+	if err := loader.reserveDisk(ctx, segmentType, infos...); err != nil {
+		log.Warn("no sufficient disk to load segments", zap.Error(err))
+		return nil, err
+	}
+
+	// Check memory & storage limit, the memory of segments is admitted by the memory governor together
+	resource, concurrencyLevel, err := loader.requestResource(ctx, segmentType, infos...)
 	if err != nil {
 		log.Warn("request resource failed", zap.Error(err))
+		loader.releaseDisk(segmentType, infos...)
 		return nil, err
 	}
 	defer loader.freeRequest(resource)
@@ -192,6 +212,12 @@ func (loader *segmentLoader) Load(ctx context.Context,
 			s.Release()
 			return true
 		})
+		// the disk and memory of loaded segments are released along with the segments
+		failed := lo.Filter(infos, func(info *querypb.SegmentLoadInfo, _ int) bool {
+			return !loaded.Contain(info.GetSegmentID())
+		})
+		loader.releaseDisk(segmentType, failed...)
+		loader.releaseMemory(segmentType, failed...)
 		debug.FreeOSMemory()
 	}()
 
@@ -232,8 +258,7 @@ func (loader *segmentLoader) Load(ctx context.Context,
 		newSegments.Insert(segmentID, segment)
 	}
 
-	loadSegmentFunc := func(idx int) error {
-		loadInfo := infos[idx]
+	loadSegmentFunc := func(ctx context.Context, loadInfo *querypb.SegmentLoadInfo) (Segment, error) {
 		partitionID := loadInfo.PartitionID
 		segmentID := loadInfo.SegmentID
 		segment, _ := newSegments.Get(segmentID)
@@ -252,16 +277,13 @@ func (loader *segmentLoader) Load(ctx context.Context,
 				zap.Int64("segmentID", segmentID),
 				zap.Error(err),
 			)
-			return err
+			return nil, err
 		}
-		loader.manager.Segment.Put(segmentType, segment)
-		newSegments.GetAndRemove(segmentID)
 		loaded.Insert(segmentID, segment)
 		log.Info("load segment done", zap.Int64("segmentID", segmentID))
-		loader.notifyLoadFinish(loadInfo)
 
 		metrics.QueryNodeLoadSegmentLatency.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Observe(tr.ElapseSpan().Seconds())
-		return nil
+		return segment, nil
 	}
 
 	// Start to load,
@@ -269,8 +291,30 @@ func (loader *segmentLoader) Load(ctx context.Context,
 	log.Info("start to load segments in parallel",
 		zap.Int("segmentNum", len(infos)),
 		zap.Int("concurrencyLevel", concurrencyLevel))
-	err = funcutil.ProcessFuncParallel(len(infos),
-		concurrencyLevel, loadSegmentFunc, "loadSegmentFunc")
+	// load the hot segments first by the warmup hints
+	loader.manager.Access.SortSegments(infos)
+	group, _ := conc.NewGroup[Segment](ctx, concurrencyLevel)
+	for _, info := range infos {
+		loadInfo := info
+		group.Go(func(ctx context.Context) (Segment, error) {
+			return loadSegmentFunc(ctx, loadInfo)
+		})
+	}
+	_, err = group.Wait()
+
+	// publish the loaded segments in one snapshot, even if some of them failed
+	loadedSegments := make([]Segment, 0, loaded.Len())
+	loaded.Range(func(segmentID int64, segment Segment) bool {
+		newSegments.GetAndRemove(segmentID)
+		loadedSegments = append(loadedSegments, segment)
+		return true
+	})
+	if len(loadedSegments) > 0 {
+		loader.manager.Segment.Put(segmentType, loadedSegments...)
+		loader.notifyLoadFinish(lo.Filter(infos, func(info *querypb.SegmentLoadInfo, _ int) bool {
+			return loaded.Contain(info.GetSegmentID())
+		})...)
+	}
 	if err != nil {
 		log.Warn("failed to load some segments", zap.Error(err))
 		return nil, err
@@ -283,12 +327,17 @@ func (loader *segmentLoader) Load(ctx context.Context,
 	}
 
 	log.Info("all segment load done")
-	var result []Segment
-	loaded.Range(func(_ int64, s Segment) bool {
-		result = append(result, s)
-		return true
-	})
-	return result, nil
+	return loadedSegments, nil
+}
+
+// routeCollection passes the bucket profile the collection is routed to into segcore,
+// which reads the binlogs by itself.
+func (loader *segmentLoader) routeCollection(ctx context.Context, collectionID int64) error {
+	profile, err := storage.GetRoute(ctx, loader.cm, collectionID)
+	if err != nil || profile == "" {
+		return err
+	}
+	return initcore.RouteCollection(collectionID, profile)
 }
 
 func (loader *segmentLoader) prepare(segmentType SegmentType, version int64, segments ...*querypb.SegmentLoadInfo) []*querypb.SegmentLoadInfo {
@@ -339,7 +388,9 @@ func (loader *segmentLoader) notifyLoadFinish(segments ...*querypb.SegmentLoadIn
 
 // requestResource requests memory & storage to load segments,
 // returns the memory usage, disk usage and concurrency with the gained memory.
-func (loader *segmentLoader) requestResource(ctx context.Context, infos ...*querypb.SegmentLoadInfo) (LoadResource, int, error) {
+// The memory of each segment after loaded is admitted by the memory governor,
+// which is held until the segment released.
+func (loader *segmentLoader) requestResource(ctx context.Context, segmentType SegmentType, infos ...*querypb.SegmentLoadInfo) (LoadResource, int, error) {
 	resource := LoadResource{}
 	// we need to deal with empty infos case separately,
 	// because the following judgement for requested resources are based on current status and static config
@@ -375,11 +426,15 @@ func (loader *segmentLoader) requestResource(ctx context.Context, infos ...*quer
 	}
 
 	concurrencyLevel := funcutil.Min(hardware.GetCPUNum(), len(infos))
-	mu, du, err := loader.checkSegmentSize(ctx, infos)
+	mu, du, segmentMemSizes, err := loader.checkSegmentSize(ctx, infos)
 	if err != nil {
 		log.Warn("no sufficient resource to load segments", zap.Error(err))
 		return resource, 0, err
 	}
+	if err := loader.admitMemory(segmentType, infos, segmentMemSizes); err != nil {
+		log.Warn("no sufficient memory to load segments", zap.Error(err))
+		return resource, 0, err
+	}
 
 	resource.MemorySize += mu
 	resource.DiskSize += du
@@ -398,6 +453,74 @@ func (loader *segmentLoader) requestResource(ctx context.Context, infos ...*quer
 	return resource, concurrencyLevel, nil
 }
 
+// reserveDisk reserves the disk of disk indexes and mmap fields from the disk cache for the segments,
+// the least recently read chunk cache columns are evicted if there is no enough disk.
+// It doesn't check the disk limit, which is done by requestResource.
+// The reserved disk is held until the segment released.
+func (loader *segmentLoader) reserveDisk(ctx context.Context, segmentType SegmentType, infos ...*querypb.SegmentLoadInfo) error {
+	indexSizes := make([]uint64, len(infos))
+	mmapSizes := make([]uint64, len(infos))
+	total := uint64(0)
+	for i, info := range infos {
+		collection := loader.manager.Collection.Get(info.GetCollectionID())
+		if collection == nil {
+			return merr.WrapErrCollectionNotFound(info.GetCollectionID())
+		}
+
+		indexSize, mmapSize, err := getSegmentDiskUsage(collection.Schema(), info)
+		if err != nil {
+			return err
+		}
+		indexSizes[i], mmapSizes[i] = indexSize, mmapSize
+		total += indexSize + mmapSize
+	}
+
+	diskCache := GetDiskCache()
+	if freed := diskCache.MakeRoom(total); freed > 0 {
+		log.Ctx(ctx).Info("evicted chunk cache to load segments", zap.Uint64("freed", freed))
+	}
+	for i, info := range infos {
+		key := segmentDiskKey(segmentType, info.GetSegmentID())
+		err := diskCache.Reserve(DiskCategoryIndex, key, indexSizes[i])
+		if err == nil {
+			err = diskCache.Reserve(DiskCategoryMmap, key, mmapSizes[i])
+		}
+		if err != nil {
+			loader.releaseDisk(segmentType, infos[:i+1]...)
+			return err
+		}
+	}
+	return nil
+}
+
+func (loader *segmentLoader) releaseDisk(segmentType SegmentType, infos ...*querypb.SegmentLoadInfo) {
+	for _, info := range infos {
+		releaseSegmentDisk(segmentType, info.GetSegmentID())
+	}
+}
+
+// admitMemory admits the memory of the segments after loaded from the memory governor,
+// the admitted memory is added to the segment and held until the segment released.
+func (loader *segmentLoader) admitMemory(segmentType SegmentType, infos []*querypb.SegmentLoadInfo, segmentMemSizes map[int64]uint64) error {
+	governor := GetMemoryGovernor()
+	for i, info := range infos {
+		err := governor.Admit(MemoryCategorySegment, segmentMemoryKey(segmentType, info.GetSegmentID()), segmentMemSizes[info.GetSegmentID()])
+		if err != nil {
+			for _, admitted := range infos[:i] {
+				governor.Shrink(MemoryCategorySegment, segmentMemoryKey(segmentType, admitted.GetSegmentID()), segmentMemSizes[admitted.GetSegmentID()])
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (loader *segmentLoader) releaseMemory(segmentType SegmentType, infos ...*querypb.SegmentLoadInfo) {
+	for _, info := range infos {
+		releaseSegmentMemory(segmentType, info.GetSegmentID())
+	}
+}
+
 // freeRequest returns request memory & storage usage request.
 func (loader *segmentLoader) freeRequest(resource LoadResource) {
 	loader.mut.Lock()
@@ -478,10 +601,8 @@ func (loader *segmentLoader) LoadBloomFilterSet(ctx context.Context, collectionI
 
 	log.Info("start loading remote...", zap.Int("segmentNum", segmentNum))
 
-	loadedBfs := typeutil.NewConcurrentSet[*pkoracle.BloomFilterSet]()
 	// TODO check memory for bf size
-	loadRemoteFunc := func(idx int) error {
-		loadInfo := infos[idx]
+	loadRemoteFunc := func(ctx context.Context, loadInfo *querypb.SegmentLoadInfo) (*pkoracle.BloomFilterSet, error) {
 		partitionID := loadInfo.PartitionID
 		segmentID := loadInfo.SegmentID
 		bfs := pkoracle.NewBloomFilterSet(segmentID, partitionID, commonpb.SegmentState_Sealed)
@@ -495,21 +616,26 @@ func (loader *segmentLoader) LoadBloomFilterSet(ctx context.Context, collectionI
 				zap.Int64("segmentID", segmentID),
 				zap.Error(err),
 			)
-			return err
+			return nil, err
 		}
-		loadedBfs.Insert(bfs)
-
-		return nil
+		return bfs, nil
 	}
 
-	err := funcutil.ProcessFuncParallel(segmentNum, segmentNum, loadRemoteFunc, "loadRemoteFunc")
+	group, _ := conc.NewGroup[*pkoracle.BloomFilterSet](ctx, segmentNum)
+	for _, info := range infos {
+		loadInfo := info
+		group.Go(func(ctx context.Context) (*pkoracle.BloomFilterSet, error) {
+			return loadRemoteFunc(ctx, loadInfo)
+		})
+	}
+	loadedBfs, err := group.Wait()
 	if err != nil {
 		// no partial success here
 		log.Warn("failed to load remote segment", zap.Error(err))
 		return nil, err
 	}
 
-	return loadedBfs.Collect(), nil
+	return loadedBfs, nil
 }
 
 func (loader *segmentLoader) loadSegment(ctx context.Context,
@@ -540,11 +666,17 @@ func (loader *segmentLoader) loadSegment(ctx context.Context,
 
 	if segment.Type() == SegmentTypeSealed {
 		fieldID2IndexInfo := make(map[int64]*querypb.FieldIndexInfo)
+		jsonKeyStatsInfos := make([]*querypb.FieldIndexInfo, 0)
 		for _, indexInfo := range loadInfo.IndexInfos {
-			if len(indexInfo.GetIndexFilePaths()) > 0 {
-				fieldID := indexInfo.FieldID
-				fieldID2IndexInfo[fieldID] = indexInfo
+			if len(indexInfo.GetIndexFilePaths()) == 0 {
+				continue
+			}
+			// json key stats are loaded besides the field data
+			if jsonkey.IsStats(indexInfo.GetIndexParams()) {
+				jsonKeyStatsInfos = append(jsonKeyStatsInfos, indexInfo)
+				continue
 			}
+			fieldID2IndexInfo[indexInfo.FieldID] = indexInfo
 		}
 
 		indexedFieldInfos := make(map[int64]*IndexedFieldInfo)
@@ -552,6 +684,11 @@ func (loader *segmentLoader) loadSegment(ctx context.Context,
 
 		for _, fieldBinlog := range loadInfo.BinlogPaths {
 			fieldID := fieldBinlog.FieldID
+			// the FLAT index is replaced by the quantized raw vectors
+			if _, quantized := getFieldQuantization(collection.Schema(), fieldID, fieldID2IndexInfo[fieldID]); quantized {
+				fieldBinlogs = append(fieldBinlogs, fieldBinlog)
+				continue
+			}
 			// check num rows of data meta and index meta are consistent
 			if indexInfo, ok := fieldID2IndexInfo[fieldID]; ok {
 				fieldInfo := &IndexedFieldInfo{
@@ -569,7 +706,7 @@ func (loader *segmentLoader) loadSegment(ctx context.Context,
 		log.Info("load fields...",
 			zap.Int64s("indexedFields", lo.Keys(indexedFieldInfos)),
 		)
-		if err := loader.loadFieldsIndex(ctx, schemaHelper, segment, loadInfo.GetNumOfRows(), indexedFieldInfos); err != nil {
+		if err := loader.loadFieldsIndex(ctx, schemaHelper, segment, loadInfo.GetNumOfRows(), indexedFieldInfos, loadInfo.GetIndexPeers()); err != nil {
 			return err
 		}
 		for fieldID, info := range indexedFieldInfos {
@@ -591,6 +728,14 @@ func (loader *segmentLoader) loadSegment(ctx context.Context,
 		if err := segment.AddFieldDataInfo(loadInfo.GetNumOfRows(), loadInfo.GetBinlogPaths()); err != nil {
 			return err
 		}
+		for _, info := range jsonKeyStatsInfos {
+			if err := loader.loadJSONKeyStats(ctx, segment, info); err != nil {
+				return err
+			}
+		}
+		if err := loader.loadFieldStats(ctx, segment, loadInfo.GetStatslogs()); err != nil {
+			return err
+		}
 		// https://github.com/milvus-io/milvus/23654
 		// legacy entry num = 0
 		if err := loader.patchEntryNumber(ctx, segment, loadInfo); err != nil {
@@ -627,6 +772,9 @@ func (loader *segmentLoader) filterPKStatsBinlogs(fieldBinlogs []*datapb.FieldBi
 				switch logidx {
 				case storage.CompoundStatsType.LogIdx():
 					return []string{binlog.GetLogPath()}, storage.CompoundStatsType
+				case storage.FieldStatsType.LogIdx():
+					// field stats log has no bloom filter
+					continue
 				default:
 					result = append(result, binlog.GetLogPath())
 				}
@@ -642,19 +790,25 @@ func (loader *segmentLoader) loadSealedSegmentFields(ctx context.Context, segmen
 		return merr.WrapErrCollectionNotLoaded(segment.Collection(), "failed to load segment fields")
 	}
 
-	runningGroup, _ := errgroup.WithContext(ctx)
+	runningGroup, _ := conc.NewGroup[any](ctx, 0)
 	for _, field := range fields {
 		fieldBinLog := field
 		fieldID := field.FieldID
-		runningGroup.Go(func() error {
-			return segment.LoadFieldData(fieldID,
+		quantization, quantized := getFieldQuantization(collection.Schema(), fieldID, nil)
+		runningGroup.Go(func(ctx context.Context) (any, error) {
+			// the raw vectors of the quantized field are mmapped, only the candidates of search are read
+			err := segment.LoadFieldData(fieldID,
 				rowCount,
 				fieldBinLog,
-				common.IsFieldMmapEnabled(collection.Schema(), fieldID),
+				quantized || common.IsFieldMmapEnabled(collection.Schema(), fieldID),
 			)
+			if err != nil || !quantized {
+				return nil, err
+			}
+			return nil, segment.QuantizeFieldData(fieldID, quantization)
 		})
 	}
-	err := runningGroup.Wait()
+	_, err := runningGroup.Wait()
 	if err != nil {
 		return err
 	}
@@ -673,10 +827,15 @@ func (loader *segmentLoader) loadFieldsIndex(ctx context.Context,
 	segment *LocalSegment,
 	numRows int64,
 	indexedFieldInfos map[int64]*IndexedFieldInfo,
+	indexPeers []int64,
 ) error {
-	for fieldID, fieldInfo := range indexedFieldInfos {
+	// load the indexes of hot fields first by the warmup hints
+	fieldIDs := lo.Keys(indexedFieldInfos)
+	loader.manager.Access.SortFields(segment.ID(), fieldIDs)
+	for _, fieldID := range fieldIDs {
+		fieldInfo := indexedFieldInfos[fieldID]
 		indexInfo := fieldInfo.IndexInfo
-		err := loader.loadFieldIndex(ctx, segment, indexInfo)
+		err := loader.loadFieldIndex(ctx, segment, indexInfo, indexPeers)
 		if err != nil {
 			return err
 		}
@@ -707,7 +866,7 @@ func (loader *segmentLoader) loadFieldsIndex(ctx context.Context,
 	return nil
 }
 
-func (loader *segmentLoader) loadFieldIndex(ctx context.Context, segment *LocalSegment, indexInfo *querypb.FieldIndexInfo) error {
+func (loader *segmentLoader) loadFieldIndex(ctx context.Context, segment *LocalSegment, indexInfo *querypb.FieldIndexInfo, indexPeers []int64) error {
 	filteredPaths := make([]string, 0, len(indexInfo.IndexFilePaths))
 
 	for _, indexPath := range indexInfo.IndexFilePaths {
@@ -727,7 +886,23 @@ func (loader *segmentLoader) loadFieldIndex(ctx context.Context, segment *LocalS
 		return merr.WrapErrCollectionNotLoaded(segment.Collection(), "failed to load field index")
 	}
 
-	return segment.LoadIndex(indexInfo, fieldType, common.IsFieldMmapEnabled(collection.Schema(), indexInfo.GetFieldID()))
+	// segcore reuses the disk index files fetched from peers
+	loader.fetchIndexFromPeers(ctx, segment, indexInfo, indexPeers)
+	if !isGpuIndex(indexInfo) {
+		return segment.LoadIndex(indexInfo, fieldType, common.IsFieldMmapEnabled(collection.Schema(), indexInfo.GetFieldID()))
+	}
+
+	// reserve the GPU memory before the index is loaded into it
+	policy := getGPUResidency(collection.Schema(), indexInfo.GetFieldID())
+	err = GetGPUMemoryManager().Reserve(segment, segment.ID(), indexInfo.GetFieldID(), uint64(indexInfo.GetIndexSize()), policy)
+	if err != nil {
+		return err
+	}
+	err = segment.LoadIndex(indexInfo, fieldType, common.IsFieldMmapEnabled(collection.Schema(), indexInfo.GetFieldID()))
+	if err != nil {
+		GetGPUMemoryManager().Remove(segment.ID(), indexInfo.GetFieldID())
+	}
+	return err
 }
 
 func (loader *segmentLoader) loadBloomFilter(ctx context.Context, segmentID int64, bfs *pkoracle.BloomFilterSet,
@@ -910,12 +1085,65 @@ func GetIndexResourceUsage(indexInfo *querypb.FieldIndexInfo) (uint64, uint64, e
 	return uint64(indexInfo.IndexSize), 0, nil
 }
 
+// getFieldQuantization returns the quantization of the field loaded, if any.
+// The quantization applies to the float vectors searched by brute force,
+// i.e. loaded without index or with FLAT index, which is replaced by the quantized raw vectors,
+// the other indexes of the quantized fields are rejected by proxy.
+func getFieldQuantization(schema *schemapb.CollectionSchema, fieldID int64, indexInfo *querypb.FieldIndexInfo) (string, bool) {
+	field := typeutil.GetField(schema, fieldID)
+	if field == nil || field.GetDataType() != schemapb.DataType_FloatVector {
+		return "", false
+	}
+	quantization, _ := common.GetVectorQuantization(field.GetTypeParams()...)
+	if !strings.EqualFold(quantization, common.VectorQuantizationSQ8) {
+		return "", false
+	}
+	if indexInfo != nil {
+		indexType, _ := funcutil.GetAttrByKeyFromRepeatedKV(common.IndexTypeKey, indexInfo.GetIndexParams())
+		if indexType != indexparamcheck.IndexFaissIDMap {
+			return "", false
+		}
+	}
+	return common.VectorQuantizationSQ8, true
+}
+
+// getSegmentDiskUsage returns the disk usage of disk indexes and mmap fields after the segment loaded.
+func getSegmentDiskUsage(schema *schemapb.CollectionSchema, loadInfo *querypb.SegmentLoadInfo) (uint64, uint64, error) {
+	vecFieldID2IndexInfo := make(map[int64]*querypb.FieldIndexInfo)
+	for _, fieldIndexInfo := range loadInfo.IndexInfos {
+		if fieldIndexInfo.EnableIndex && !jsonkey.IsStats(fieldIndexInfo.GetIndexParams()) {
+			vecFieldID2IndexInfo[fieldIndexInfo.FieldID] = fieldIndexInfo
+		}
+	}
+
+	indexSize, mmapSize := uint64(0), uint64(0)
+	for _, fieldBinlog := range loadInfo.BinlogPaths {
+		fieldID := fieldBinlog.FieldID
+		_, quantized := getFieldQuantization(schema, fieldID, vecFieldID2IndexInfo[fieldID])
+		mmapEnabled := quantized || common.IsFieldMmapEnabled(schema, fieldID)
+		if fieldIndexInfo, ok := vecFieldID2IndexInfo[fieldID]; ok && !quantized {
+			neededMemSize, neededDiskSize, err := GetIndexResourceUsage(fieldIndexInfo)
+			if err != nil {
+				return 0, 0, err
+			}
+			if mmapEnabled {
+				mmapSize += neededMemSize + neededDiskSize
+			} else {
+				indexSize += neededDiskSize
+			}
+		} else if mmapEnabled {
+			mmapSize += uint64(getBinlogDataSize(fieldBinlog))
+		}
+	}
+	return indexSize, mmapSize, nil
+}
+
 // checkSegmentSize checks whether the memory & disk is sufficient to load the segments
-// returns the memory & disk usage while loading if possible to load,
+// returns the memory & disk usage while loading and the memory usage of each segment if possible to load,
 // otherwise, returns error
-func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadInfos []*querypb.SegmentLoadInfo) (uint64, uint64, error) {
+func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadInfos []*querypb.SegmentLoadInfo) (uint64, uint64, map[int64]uint64, error) {
 	if len(segmentLoadInfos) == 0 {
-		return 0, 0, nil
+		return 0, 0, nil, nil
 	}
 
 	log := log.Ctx(ctx).With(
@@ -929,12 +1157,12 @@ func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadIn
 	memUsage := hardware.GetUsedMemoryCount() + loader.committedResource.MemorySize
 	totalMem := hardware.GetMemoryCount()
 	if memUsage == 0 || totalMem == 0 {
-		return 0, 0, errors.New("get memory failed when checkSegmentSize")
+		return 0, 0, nil, errors.New("get memory failed when checkSegmentSize")
 	}
 
 	localDiskUsage, err := GetLocalUsedSize(paramtable.Get().LocalStorageCfg.Path.GetValue())
 	if err != nil {
-		return 0, 0, errors.Wrap(err, "get local used size failed")
+		return 0, 0, nil, errors.Wrap(err, "get local used size failed")
 	}
 
 	metrics.QueryNodeDiskUsedSize.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Set(toMB(uint64(localDiskUsage)))
@@ -944,13 +1172,14 @@ func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadIn
 	predictMemUsage := memUsage
 	predictDiskUsage := diskUsage
 	mmapFieldCount := 0
+	segmentMemSizes := make(map[int64]uint64, len(segmentLoadInfos))
 	for _, loadInfo := range segmentLoadInfos {
 		collection := loader.manager.Collection.Get(loadInfo.GetCollectionID())
 
 		oldUsedMem := predictMemUsage
 		vecFieldID2IndexInfo := make(map[int64]*querypb.FieldIndexInfo)
 		for _, fieldIndexInfo := range loadInfo.IndexInfos {
-			if fieldIndexInfo.EnableIndex {
+			if fieldIndexInfo.EnableIndex && !jsonkey.IsStats(fieldIndexInfo.GetIndexParams()) {
 				fieldID := fieldIndexInfo.FieldID
 				vecFieldID2IndexInfo[fieldID] = fieldIndexInfo
 			}
@@ -958,8 +1187,9 @@ func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadIn
 
 		for _, fieldBinlog := range loadInfo.BinlogPaths {
 			fieldID := fieldBinlog.FieldID
-			mmapEnabled := common.IsFieldMmapEnabled(collection.Schema(), fieldID)
-			if fieldIndexInfo, ok := vecFieldID2IndexInfo[fieldID]; ok {
+			_, quantized := getFieldQuantization(collection.Schema(), fieldID, vecFieldID2IndexInfo[fieldID])
+			mmapEnabled := quantized || common.IsFieldMmapEnabled(collection.Schema(), fieldID)
+			if fieldIndexInfo, ok := vecFieldID2IndexInfo[fieldID]; ok && !quantized {
 				neededMemSize, neededDiskSize, err := GetIndexResourceUsage(fieldIndexInfo)
 				if err != nil {
 					log.Warn("failed to get index size",
@@ -968,7 +1198,7 @@ func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadIn
 						zap.Int64("indexBuildID", fieldIndexInfo.BuildID),
 						zap.Error(err),
 					)
-					return 0, 0, err
+					return 0, 0, nil, err
 				}
 				if mmapEnabled {
 					predictDiskUsage += neededMemSize + neededDiskSize
@@ -987,6 +1217,9 @@ func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadIn
 						predictMemUsage += uint64(float32(getBinlogDataSize(fieldBinlog)) * float32(buildBinlogIndexRate))
 					}
 				}
+				if quantized {
+					predictMemUsage += uint64(getBinlogDataSize(fieldBinlog)) / SQ8CompressionRatio
+				}
 			}
 
 			if mmapEnabled {
@@ -1004,6 +1237,7 @@ func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadIn
 			predictMemUsage += uint64(getBinlogDataSize(fieldBinlog))
 		}
 
+		segmentMemSizes[loadInfo.GetSegmentID()] = predictMemUsage - oldUsedMem
 		if predictMemUsage-oldUsedMem > maxSegmentSize {
 			maxSegmentSize = predictMemUsage - oldUsedMem
 		}
@@ -1020,24 +1254,24 @@ func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadIn
 		zap.Int("mmapFieldCount", mmapFieldCount),
 	)
 
-	if predictMemUsage > uint64(float64(totalMem)*paramtable.Get().QueryNodeCfg.OverloadedMemoryThresholdPercentage.GetAsFloat()) {
-		return 0, 0, fmt.Errorf("load segment failed, OOM if load, maxSegmentSize = %v MB,  memUsage = %v MB, predictMemUsage = %v MB, totalMem = %v MB thresholdFactor = %f",
+	if threshold := LoadMemoryThreshold(); predictMemUsage > uint64(float64(totalMem)*threshold) {
+		return 0, 0, nil, fmt.Errorf("load segment failed, OOM if load, maxSegmentSize = %v MB,  memUsage = %v MB, predictMemUsage = %v MB, totalMem = %v MB thresholdFactor = %f",
 			toMB(maxSegmentSize),
 			toMB(memUsage),
 			toMB(predictMemUsage),
 			toMB(totalMem),
-			paramtable.Get().QueryNodeCfg.OverloadedMemoryThresholdPercentage.GetAsFloat())
+			threshold)
 	}
 
 	if predictDiskUsage > uint64(float64(paramtable.Get().QueryNodeCfg.DiskCapacityLimit.GetAsInt64())*paramtable.Get().QueryNodeCfg.MaxDiskUsagePercentage.GetAsFloat()) {
-		return 0, 0, fmt.Errorf("load segment failed, disk space is not enough, diskUsage = %v MB, predictDiskUsage = %v MB, totalDisk = %v MB, thresholdFactor = %f",
+		return 0, 0, nil, fmt.Errorf("load segment failed, disk space is not enough, diskUsage = %v MB, predictDiskUsage = %v MB, totalDisk = %v MB, thresholdFactor = %f",
 			toMB(diskUsage),
 			toMB(predictDiskUsage),
 			toMB(uint64(paramtable.Get().QueryNodeCfg.DiskCapacityLimit.GetAsInt64())),
 			paramtable.Get().QueryNodeCfg.MaxDiskUsagePercentage.GetAsFloat())
 	}
 
-	return predictMemUsage - memUsage, predictDiskUsage - diskUsage, nil
+	return predictMemUsage - memUsage, predictDiskUsage - diskUsage, segmentMemSizes, nil
 }
 
 func (loader *segmentLoader) getFieldType(collectionID, fieldID int64) (schemapb.DataType, error) {
@@ -1072,7 +1306,7 @@ func (loader *segmentLoader) LoadIndex(ctx context.Context, segment *LocalSegmen
 		info.Statslogs = nil
 		return info
 	})
-	resource, _, err := loader.requestResource(ctx, indexInfo...)
+	resource, _, err := loader.requestResource(ctx, SegmentTypeSealed, indexInfo...)
 	if err != nil {
 		return err
 	}
@@ -1091,11 +1325,19 @@ func (loader *segmentLoader) LoadIndex(ctx context.Context, segment *LocalSegmen
 				return merr.WrapErrIndexNotFound("index file list empty")
 			}
 
+			if jsonkey.IsStats(info.GetIndexParams()) {
+				if err := loader.loadJSONKeyStats(ctx, segment, info); err != nil {
+					log.Warn("failed to load json key stats for segment", zap.Error(err))
+					return err
+				}
+				continue
+			}
+
 			fieldInfo, ok := fieldInfos[info.GetFieldID()]
 			if !ok {
 				return merr.WrapErrParameterInvalid("index info with corresponding  field info", "missing field info", strconv.FormatInt(fieldInfo.GetFieldID(), 10))
 			}
-			err := loader.loadFieldIndex(ctx, segment, info)
+			err := loader.loadFieldIndex(ctx, segment, info, loadInfo.GetIndexPeers())
 			if err != nil {
 				log.Warn("failed to load index for segment", zap.Error(err))
 				return err
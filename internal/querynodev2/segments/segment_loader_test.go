@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
@@ -732,6 +733,33 @@ func (suite *SegmentLoaderDetailSuite) TestWaitSegmentLoadDone() {
 	})
 }
 
+func TestGetFieldQuantization(t *testing.T) {
+	sq8 := []*commonpb.KeyValuePair{{Key: common.VectorQuantizationKey, Value: "sq8"}}
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, DataType: schemapb.DataType_Int64, TypeParams: sq8},
+			{FieldID: 101, DataType: schemapb.DataType_FloatVector, TypeParams: sq8},
+			{FieldID: 102, DataType: schemapb.DataType_BinaryVector, TypeParams: sq8},
+			{FieldID: 103, DataType: schemapb.DataType_FloatVector},
+		},
+	}
+	indexInfo := func(indexType string) *querypb.FieldIndexInfo {
+		return &querypb.FieldIndexInfo{IndexParams: []*commonpb.KeyValuePair{{Key: common.IndexTypeKey, Value: indexType}}}
+	}
+
+	quantization, ok := getFieldQuantization(schema, 101, nil)
+	assert.True(t, ok)
+	assert.Equal(t, common.VectorQuantizationSQ8, quantization)
+	_, ok = getFieldQuantization(schema, 101, indexInfo("FLAT"))
+	assert.True(t, ok)
+	_, ok = getFieldQuantization(schema, 101, indexInfo("HNSW"))
+	assert.False(t, ok)
+	for _, fieldID := range []int64{100, 102, 103, 104} {
+		_, ok = getFieldQuantization(schema, fieldID, nil)
+		assert.False(t, ok)
+	}
+}
+
 func TestSegmentLoader(t *testing.T) {
 	suite.Run(t, &SegmentLoaderSuite{})
 	suite.Run(t, &SegmentLoaderDetailSuite{})
@@ -0,0 +1,261 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// systemFilter is the conjunctions of the query filter on the system fields, e.g.
+// "Timestamp > 449000000000000000 and SegmentID in [1, 2]", which segcore can't evaluate,
+// so they are extracted from the plan and evaluated by querynode.
+type systemFilter struct {
+	segment   []*planpb.Expr
+	timestamp []*planpb.Expr
+}
+
+// extractSystemFilter removes the conjunctions on the system fields from the query filter of plan,
+// returns nil if the filter references no system field.
+// The system fields are only supported in the top level conjunctions, and compared with constants.
+func extractSystemFilter(plan *planpb.PlanNode) (*systemFilter, error) {
+	query := plan.GetQuery()
+	if query == nil || !referencesSystemFields(query.GetPredicates()) {
+		return nil, nil
+	}
+
+	filter := &systemFilter{}
+	rest := make([]*planpb.Expr, 0)
+	for _, expr := range splitConjunctions(query.GetPredicates()) {
+		fieldID, ok := systemPredicateField(expr)
+		switch {
+		case !ok && referencesSystemFields(expr):
+			return nil, merr.WrapErrParameterInvalidMsg("system fields are only supported in the top level conjunctions compared with constants")
+		case !ok:
+			rest = append(rest, expr)
+		case fieldID == common.SegmentIDField:
+			filter.segment = append(filter.segment, expr)
+		case fieldID == common.TimeStampField:
+			if query.GetIsCount() {
+				return nil, merr.WrapErrParameterInvalidMsg("count entities filtered by %s is not supported", common.TimeStampFieldName)
+			}
+			filter.timestamp = append(filter.timestamp, expr)
+		default:
+			return nil, merr.WrapErrParameterInvalidMsg("system field %d is not supported in filter", fieldID)
+		}
+	}
+
+	query.Predicates = joinConjunctions(rest)
+	// the rows are filtered by timestamp after retrieved from segcore,
+	// the limit is applied when the results are reduced instead.
+	if len(filter.timestamp) > 0 {
+		query.Limit = typeutil.Unlimited
+	}
+	return filter, nil
+}
+
+// filterSegments filters out the segments not matching the filter on segment id.
+func (f *systemFilter) filterSegments(segments []Segment) []Segment {
+	if f == nil || len(f.segment) == 0 {
+		return segments
+	}
+	result := make([]Segment, 0, len(segments))
+	for _, segment := range segments {
+		if matchSystemPredicates(f.segment, segment.ID()) {
+			result = append(result, segment)
+		}
+	}
+	return result
+}
+
+// filterResult filters out the retrieved rows not matching the filter on timestamp.
+func (f *systemFilter) filterResult(result *segcorepb.RetrieveResults) (*segcorepb.RetrieveResults, error) {
+	if f == nil || len(f.timestamp) == 0 || len(result.GetOffset()) == 0 {
+		return result, nil
+	}
+	var timestamps []int64
+	for _, fieldData := range result.GetFieldsData() {
+		if fieldData.GetFieldId() == common.TimeStampField {
+			timestamps = fieldData.GetScalars().GetLongData().GetData()
+		}
+	}
+	if len(timestamps) != len(result.GetOffset()) {
+		return nil, merr.WrapErrServiceInternal("timestamps of the rows not retrieved")
+	}
+
+	offsets := make([]int, 0, len(timestamps))
+	for i, ts := range timestamps {
+		if matchSystemPredicates(f.timestamp, ts) {
+			offsets = append(offsets, i)
+		}
+	}
+	if len(offsets) == len(timestamps) {
+		return result, nil
+	}
+	if len(offsets) == 0 {
+		return &segcorepb.RetrieveResults{Ids: &schemapb.IDs{}}, nil
+	}
+
+	filtered := &segcorepb.RetrieveResults{
+		Ids:        &schemapb.IDs{},
+		Offset:     make([]int64, 0, len(offsets)),
+		FieldsData: make([]*schemapb.FieldData, len(result.GetFieldsData())),
+	}
+	for _, offset := range offsets {
+		typeutil.AppendPKs(filtered.Ids, typeutil.GetPK(result.GetIds(), int64(offset)))
+		filtered.Offset = append(filtered.Offset, result.GetOffset()[offset])
+	}
+	typeutil.AppendFieldDataByOffsets(filtered.FieldsData, result.GetFieldsData(), offsets)
+	return filtered, nil
+}
+
+func splitConjunctions(expr *planpb.Expr) []*planpb.Expr {
+	if binary := expr.GetBinaryExpr(); binary != nil && binary.GetOp() == planpb.BinaryExpr_LogicalAnd {
+		return append(splitConjunctions(binary.GetLeft()), splitConjunctions(binary.GetRight())...)
+	}
+	return []*planpb.Expr{expr}
+}
+
+func joinConjunctions(exprs []*planpb.Expr) *planpb.Expr {
+	if len(exprs) == 0 {
+		return &planpb.Expr{Expr: &planpb.Expr_AlwaysTrueExpr{AlwaysTrueExpr: &planpb.AlwaysTrueExpr{}}}
+	}
+	result := exprs[0]
+	for _, expr := range exprs[1:] {
+		result = &planpb.Expr{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{
+			Op:    planpb.BinaryExpr_LogicalAnd,
+			Left:  result,
+			Right: expr,
+		}}}
+	}
+	return result
+}
+
+// referencesSystemFields returns whether any column of expr is a system field.
+func referencesSystemFields(expr *planpb.Expr) bool {
+	isSystem := func(column *planpb.ColumnInfo) bool {
+		return column != nil && common.IsSystemField(column.GetFieldId())
+	}
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_TermExpr:
+		return isSystem(e.TermExpr.GetColumnInfo())
+	case *planpb.Expr_UnaryExpr:
+		return referencesSystemFields(e.UnaryExpr.GetChild())
+	case *planpb.Expr_BinaryExpr:
+		return referencesSystemFields(e.BinaryExpr.GetLeft()) || referencesSystemFields(e.BinaryExpr.GetRight())
+	case *planpb.Expr_CompareExpr:
+		return isSystem(e.CompareExpr.GetLeftColumnInfo()) || isSystem(e.CompareExpr.GetRightColumnInfo())
+	case *planpb.Expr_UnaryRangeExpr:
+		return isSystem(e.UnaryRangeExpr.GetColumnInfo())
+	case *planpb.Expr_BinaryRangeExpr:
+		return isSystem(e.BinaryRangeExpr.GetColumnInfo())
+	case *planpb.Expr_BinaryArithOpEvalRangeExpr:
+		return isSystem(e.BinaryArithOpEvalRangeExpr.GetColumnInfo())
+	case *planpb.Expr_BinaryArithExpr:
+		return referencesSystemFields(e.BinaryArithExpr.GetLeft()) || referencesSystemFields(e.BinaryArithExpr.GetRight())
+	case *planpb.Expr_ColumnExpr:
+		return isSystem(e.ColumnExpr.GetInfo())
+	case *planpb.Expr_ExistsExpr:
+		return isSystem(e.ExistsExpr.GetInfo())
+	case *planpb.Expr_JsonContainsExpr:
+		return isSystem(e.JsonContainsExpr.GetColumnInfo())
+	default:
+		return false
+	}
+}
+
+// systemPredicateField returns the system field compared by the predicate,
+// false if the predicate is not a comparison of a system field with constants.
+func systemPredicateField(expr *planpb.Expr) (int64, bool) {
+	var column *planpb.ColumnInfo
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_UnaryExpr:
+		if e.UnaryExpr.GetOp() != planpb.UnaryExpr_Not {
+			return 0, false
+		}
+		return systemPredicateField(e.UnaryExpr.GetChild())
+	case *planpb.Expr_UnaryRangeExpr:
+		switch e.UnaryRangeExpr.GetOp() {
+		case planpb.OpType_GreaterThan, planpb.OpType_GreaterEqual, planpb.OpType_LessThan,
+			planpb.OpType_LessEqual, planpb.OpType_Equal, planpb.OpType_NotEqual:
+			column = e.UnaryRangeExpr.GetColumnInfo()
+		default:
+			return 0, false
+		}
+	case *planpb.Expr_BinaryRangeExpr:
+		column = e.BinaryRangeExpr.GetColumnInfo()
+	case *planpb.Expr_TermExpr:
+		if e.TermExpr.GetIsInField() {
+			return 0, false
+		}
+		column = e.TermExpr.GetColumnInfo()
+	default:
+		return 0, false
+	}
+	if !common.IsSystemField(column.GetFieldId()) || len(column.GetNestedPath()) > 0 {
+		return 0, false
+	}
+	return column.GetFieldId(), true
+}
+
+func matchSystemPredicates(exprs []*planpb.Expr, value int64) bool {
+	for _, expr := range exprs {
+		if !matchSystemPredicate(expr, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchSystemPredicate evaluates the predicate returned by systemPredicateField on the value of the system field.
+func matchSystemPredicate(expr *planpb.Expr, value int64) bool {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_UnaryExpr:
+		return !matchSystemPredicate(e.UnaryExpr.GetChild(), value)
+	case *planpb.Expr_UnaryRangeExpr:
+		operand := e.UnaryRangeExpr.GetValue().GetInt64Val()
+		switch e.UnaryRangeExpr.GetOp() {
+		case planpb.OpType_GreaterThan:
+			return value > operand
+		case planpb.OpType_GreaterEqual:
+			return value >= operand
+		case planpb.OpType_LessThan:
+			return value < operand
+		case planpb.OpType_LessEqual:
+			return value <= operand
+		case planpb.OpType_Equal:
+			return value == operand
+		case planpb.OpType_NotEqual:
+			return value != operand
+		}
+	case *planpb.Expr_BinaryRangeExpr:
+		lower, upper := e.BinaryRangeExpr.GetLowerValue().GetInt64Val(), e.BinaryRangeExpr.GetUpperValue().GetInt64Val()
+		return (value > lower || (e.BinaryRangeExpr.GetLowerInclusive() && value == lower)) &&
+			(value < upper || (e.BinaryRangeExpr.GetUpperInclusive() && value == upper))
+	case *planpb.Expr_TermExpr:
+		for _, v := range e.TermExpr.GetValues() {
+			if v.GetInt64Val() == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func int64Column(fieldID int64) *planpb.ColumnInfo {
+	return &planpb.ColumnInfo{FieldId: fieldID, DataType: schemapb.DataType_Int64}
+}
+
+func int64Value(v int64) *planpb.GenericValue {
+	return &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: v}}
+}
+
+func andExpr(left, right *planpb.Expr) *planpb.Expr {
+	return &planpb.Expr{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{
+		Op: planpb.BinaryExpr_LogicalAnd, Left: left, Right: right,
+	}}}
+}
+
+func TestSystemFilter(t *testing.T) {
+	userExpr := &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+		ColumnInfo: int64Column(100), Op: planpb.OpType_GreaterThan, Value: int64Value(1),
+	}}}
+	tsExpr := &planpb.Expr{Expr: &planpb.Expr_BinaryRangeExpr{BinaryRangeExpr: &planpb.BinaryRangeExpr{
+		ColumnInfo: int64Column(common.TimeStampField), LowerInclusive: true, LowerValue: int64Value(10), UpperValue: int64Value(20),
+	}}}
+	segmentExpr := &planpb.Expr{Expr: &planpb.Expr_TermExpr{TermExpr: &planpb.TermExpr{
+		ColumnInfo: int64Column(common.SegmentIDField), Values: []*planpb.GenericValue{int64Value(1), int64Value(3)},
+	}}}
+	newPlan := func(expr *planpb.Expr) *planpb.PlanNode {
+		return &planpb.PlanNode{Node: &planpb.PlanNode_Query{Query: &planpb.QueryPlanNode{Predicates: expr, Limit: 10}}}
+	}
+
+	t.Run("extract", func(t *testing.T) {
+		filter, err := extractSystemFilter(newPlan(userExpr))
+		assert.NoError(t, err)
+		assert.Nil(t, filter)
+
+		plan := newPlan(andExpr(andExpr(userExpr, tsExpr), segmentExpr))
+		filter, err = extractSystemFilter(plan)
+		require.NoError(t, err)
+		assert.Equal(t, []*planpb.Expr{tsExpr}, filter.timestamp)
+		assert.Equal(t, []*planpb.Expr{segmentExpr}, filter.segment)
+		assert.Equal(t, userExpr, plan.GetQuery().GetPredicates())
+		assert.Equal(t, typeutil.Unlimited, plan.GetQuery().GetLimit())
+
+		plan = newPlan(segmentExpr)
+		_, err = extractSystemFilter(plan)
+		require.NoError(t, err)
+		assert.NotNil(t, plan.GetQuery().GetPredicates().GetAlwaysTrueExpr())
+		assert.Equal(t, int64(10), plan.GetQuery().GetLimit())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		or := &planpb.Expr{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{
+			Op: planpb.BinaryExpr_LogicalOr, Left: userExpr, Right: tsExpr,
+		}}}
+		_, err := extractSystemFilter(newPlan(or))
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+		count := newPlan(tsExpr)
+		count.GetQuery().IsCount = true
+		_, err = extractSystemFilter(count)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		filter := &systemFilter{
+			segment:   []*planpb.Expr{segmentExpr},
+			timestamp: []*planpb.Expr{tsExpr},
+		}
+		segments := []Segment{
+			&LocalSegment{baseSegment: baseSegment{segmentID: 1}},
+			&LocalSegment{baseSegment: baseSegment{segmentID: 2}},
+			&LocalSegment{baseSegment: baseSegment{segmentID: 3}},
+		}
+		assert.Equal(t, []int64{1, 3}, lo.Map(filter.filterSegments(segments), func(segment Segment, _ int) int64 { return segment.ID() }))
+
+		result := &segcorepb.RetrieveResults{
+			Ids:    &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}}}},
+			Offset: []int64{0, 1, 2},
+			FieldsData: []*schemapb.FieldData{{
+				Type:    schemapb.DataType_Int64,
+				FieldId: common.TimeStampField,
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{5, 10, 20}}},
+				}},
+			}},
+		}
+		filtered, err := filter.filterResult(result)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, filtered.GetIds().GetIntId().GetData())
+		assert.Equal(t, []int64{1}, filtered.GetOffset())
+		assert.Equal(t, []int64{10}, filtered.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+		// timestamps not retrieved
+		result.FieldsData = nil
+		_, err = filter.filterResult(result)
+		assert.Error(t, err)
+
+		var none *systemFilter
+		assert.Len(t, none.filterSegments(segments), 3)
+	})
+}
@@ -308,6 +308,22 @@ func mergeRequestCost(requestCosts []*internalpb.CostAggregation) *internalpb.Co
 	return result
 }
 
+// mergeScannedRows returns the merged cost with the rows scanned by all the results,
+// which are charged to the limit of scanned rows of the request by proxy.
+func mergeScannedRows(cost *internalpb.CostAggregation, scannedRows int64) *internalpb.CostAggregation {
+	if scannedRows == 0 {
+		return cost
+	}
+	merged := &internalpb.CostAggregation{}
+	if cost != nil {
+		merged.ResponseTime = cost.GetResponseTime()
+		merged.ServiceTime = cost.GetServiceTime()
+		merged.TotalNQ = cost.GetTotalNQ()
+	}
+	merged.ScannedRows = scannedRows
+	return merged
+}
+
 func getIndexEngineVersion() (minimal, current int32) {
 	cMinimal, cCurrent := C.GetMinimalIndexVersion(), C.GetCurrentIndexVersion()
 	return int32(cMinimal), int32(cCurrent)
@@ -51,6 +51,8 @@ import (
 	"github.com/milvus-io/milvus/internal/querynodev2/delegator"
 	"github.com/milvus-io/milvus/internal/querynodev2/optimizers"
 	"github.com/milvus-io/milvus/internal/querynodev2/pipeline"
+	"github.com/milvus-io/milvus/internal/querynodev2/recorder"
+	"github.com/milvus-io/milvus/internal/querynodev2/rerankers"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
 	"github.com/milvus-io/milvus/internal/querynodev2/tasks"
 	"github.com/milvus-io/milvus/internal/querynodev2/tsafe"
@@ -129,6 +131,12 @@ type QueryNode struct {
 
 	// parameter turning hook
 	queryHook optimizers.QueryHook
+
+	// search results rerank hook
+	rerankHook rerankers.RerankHook
+
+	// sampler of the search/query requests for the offline replay
+	recorder *recorder.Recorder
 }
 
 // NewQueryNode will return a QueryNode with abnormal state.
@@ -201,6 +209,12 @@ func (node *QueryNode) InitSegcore() error {
 	nprobe := C.int64_t(paramtable.Get().QueryNodeCfg.InterimIndexNProbe.GetAsInt64())
 	C.SegcoreSetNprobe(nprobe)
 
+	dictionaryMaxCardinality := C.int64_t(paramtable.Get().QueryNodeCfg.DictionaryMaxCardinality.GetAsInt64())
+	C.SegcoreSetDictionaryMaxCardinality(dictionaryMaxCardinality)
+
+	quantizationRefineRatio := C.int64_t(paramtable.Get().QueryNodeCfg.QuantizationRefineRatio.GetAsInt64())
+	C.SegcoreSetQuantizationRefineRatio(quantizationRefineRatio)
+
 	// override segcore SIMD type
 	cSimdType := C.CString(paramtable.Get().CommonCfg.SimdType.GetValue())
 	C.SegcoreSetSimdType(cSimdType)
@@ -229,11 +243,7 @@ func (node *QueryNode) InitSegcore() error {
 		return err
 	}
 
-	mmapDirPath := paramtable.Get().QueryNodeCfg.MmapDirPath.GetValue()
-	if len(mmapDirPath) == 0 {
-		mmapDirPath = paramtable.Get().LocalStorageCfg.Path.GetValue()
-	}
-	chunkCachePath := path.Join(mmapDirPath, "chunk_cache")
+	chunkCachePath := segments.GetChunkCachePath()
 	policy := paramtable.Get().QueryNodeCfg.ReadAheadPolicy.GetValue()
 	err = initcore.InitChunkCache(chunkCachePath, policy)
 	if err != nil {
@@ -279,8 +289,22 @@ func (node *QueryNode) Init() error {
 			}
 		}
 
+		err = node.initRerankHook()
+		if err != nil {
+			log.Error("QueryNode init rerank hook failed", zap.Error(err))
+			initError = err
+			return
+		}
+
 		node.factory.Init(paramtable.Get())
 
+		if paramtable.Get().QueryNodeCfg.LocalRecoveryEnabled.GetAsBool() {
+			retention := paramtable.Get().QueryNodeCfg.LocalRecoveryRetention.GetAsDuration(time.Second)
+			if err := segments.GetLocalCatalog().Recover(retention); err != nil {
+				log.Warn("failed to recover local catalog", zap.Error(err))
+			}
+		}
+
 		localRootPath := paramtable.Get().LocalStorageCfg.Path.GetValue()
 		localUsedSize, err := segments.GetLocalUsedSize(localRootPath)
 		if err != nil {
@@ -302,6 +326,8 @@ func (node *QueryNode) Init() error {
 			schedulePolicy,
 		)
 		log.Info("queryNode init scheduler", zap.String("policy", schedulePolicy))
+		node.recorder = recorder.NewRecorder()
+		segments.GetMemoryWatermark().SetReleaser(node.releaseSegmentsForMemory)
 
 		node.clusterManager = cluster.NewWorkerManager(func(ctx context.Context, nodeID int64) (cluster.Worker, error) {
 			if nodeID == paramtable.GetNodeID() {
@@ -332,7 +358,9 @@ func (node *QueryNode) Init() error {
 		node.subscribingChannels = typeutil.NewConcurrentSet[string]()
 		node.unsubscribingChannels = typeutil.NewConcurrentSet[string]()
 		node.manager = segments.NewManager()
-		node.loader = segments.NewLoader(node.manager, node.chunkManager)
+		loader := segments.NewLoader(node.manager, node.chunkManager)
+		loader.SetIndexFileFetcher(node.fetchIndexFile)
+		node.loader = loader
 		node.dispClient = msgdispatcher.NewClient(node.factory, typeutil.QueryNodeRole, paramtable.GetNodeID())
 		// init pipeline manager
 		node.pipelineManager = pipeline.NewManager(node.manager, node.tSafeManager, node.dispClient, node.delegators)
@@ -368,6 +396,8 @@ func (node *QueryNode) Init() error {
 func (node *QueryNode) Start() error {
 	node.startOnce.Do(func() {
 		node.scheduler.Start()
+		segments.GetMemoryWatermark().Start()
+		go segments.WatchCPUQuota(node.ctx)
 
 		paramtable.SetCreateTime(time.Now())
 		paramtable.SetUpdateTime(time.Now())
@@ -376,6 +406,7 @@ func (node *QueryNode) Start() error {
 		node.UpdateStateCode(commonpb.StateCode_Healthy)
 
 		registry.GetInMemoryResolver().RegisterQueryNode(paramtable.GetNodeID(), node)
+		RegisterMgrRoute(node)
 		log.Info("query node start successfully",
 			zap.Int64("queryNodeID", paramtable.GetNodeID()),
 			zap.String("Address", node.address),
@@ -435,6 +466,8 @@ func (node *QueryNode) Stop() error {
 		if node.scheduler != nil {
 			node.scheduler.Stop()
 		}
+		node.recorder.Close()
+		segments.GetMemoryWatermark().Close()
 		if node.pipelineManager != nil {
 			node.pipelineManager.Close()
 		}
@@ -446,7 +479,8 @@ func (node *QueryNode) Stop() error {
 		if node.dispClient != nil {
 			node.dispClient.Close()
 		}
-		if node.manager != nil {
+		// keep the segments loaded on local disk to recover them after restarted
+		if node.manager != nil && !paramtable.Get().QueryNodeCfg.LocalRecoveryEnabled.GetAsBool() {
 			node.manager.Segment.Clear()
 		}
 
@@ -509,6 +543,41 @@ func (node *QueryNode) initHook() error {
 	return nil
 }
 
+// initRerankHook initializes the rerank hook of the search results if configured.
+func (node *QueryNode) initRerankHook() error {
+	path := paramtable.Get().QueryNodeCfg.RerankSoPath.GetValue()
+	if path == "" {
+		return nil
+	}
+	log.Info("start to load rerank plugin", zap.String("path", path))
+
+	p, err := plugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("fail to open the rerank plugin, error: %s", err.Error())
+	}
+
+	h, err := p.Lookup("RerankPlugin")
+	if err != nil {
+		return fmt.Errorf("fail to find the 'RerankPlugin' object in the plugin, error: %s", err.Error())
+	}
+
+	hoo, ok := h.(rerankers.RerankHook)
+	if !ok {
+		return fmt.Errorf("fail to convert the `RerankHook` interface")
+	}
+	if err = hoo.Init(paramtable.Get().QueryNodeCfg.RerankConfig.GetValue()); err != nil {
+		return fmt.Errorf("fail to init configs for the rerank hook, error: %s", err.Error())
+	}
+
+	node.rerankHook = hoo
+	paramtable.Get().Watch(paramtable.Get().QueryNodeCfg.RerankConfig.Key, config.NewHandler("rerankHook", func(event *config.Event) {
+		if err := hoo.Init(event.Value); err != nil {
+			log.Warn("failed to refresh rerank hook config", zap.Error(err))
+		}
+	}))
+	return nil
+}
+
 func (node *QueryNode) handleQueryHookEvent() {
 	onEvent := func(event *config.Event) {
 		if node.queryHook != nil {
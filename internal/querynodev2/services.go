@@ -19,8 +19,10 @@ package querynodev2
 import (
 	"context"
 	"fmt"
+	"hash/crc32"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/samber/lo"
@@ -32,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/http/healthz"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
@@ -47,6 +50,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
@@ -479,18 +483,30 @@ func (node *QueryNode) LoadSegments(ctx context.Context, req *querypb.LoadSegmen
 	if req.GetLoadScope() == querypb.LoadScope_Delta {
 		return node.loadDeltaLogs(ctx, req), nil
 	}
+	// the delta logs and the indexes of the segments loaded are loaded anyway,
+	// the deletes of the segments loaded require the former, and the latter replace the interim indexes
 	if req.GetLoadScope() == querypb.LoadScope_Index {
 		return node.loadIndex(ctx, req), nil
 	}
+	if err := segments.GetMemoryWatermark().CheckLoad(); err != nil {
+		log.Warn("reject to load segments", zap.Error(err))
+		return merr.Status(err), nil
+	}
+
+	// the hints from the serving replicas make the hot data warmed first
+	node.manager.Access.Seed(req.GetWarmupHints()...)
 
 	// Actual load segment
 	log.Info("start to load segments...")
+	loadDone := healthz.Track(typeutil.QueryNodeRole, healthz.StageLoading, healthz.ReasonLoadingSegments,
+		fmt.Sprintf("collection %d, %d segments", req.GetCollectionID(), len(req.GetInfos())))
 	loaded, err := node.loader.Load(ctx,
 		req.GetCollectionID(),
 		segments.SegmentTypeSealed,
 		req.GetVersion(),
 		req.GetInfos()...,
 	)
+	loadDone()
 	if err != nil {
 		return merr.Status(err), nil
 	}
@@ -603,6 +619,9 @@ func (node *QueryNode) ReleaseSegments(ctx context.Context, req *querypb.Release
 	for _, id := range req.GetSegmentIDs() {
 		_, count := node.manager.Segment.Remove(id, req.GetScope())
 		sealedCount += count
+		if count > 0 {
+			node.manager.Access.Remove(id)
+		}
 	}
 	node.manager.Collection.Unref(req.GetCollectionID(), uint32(sealedCount))
 
@@ -744,7 +763,22 @@ func (node *QueryNode) Search(ctx context.Context, req *querypb.SearchRequest) (
 		// for compatible with rolling upgrade from version before v2.2.9
 		return node.SearchSegments(ctx, req)
 	}
+	if err := segments.GetMemoryWatermark().CheckRequest("search"); err != nil {
+		return &internalpb.SearchResults{Status: merr.Status(err)}, nil
+	}
+	// the limits of the request are carried to the workers
+	ctx = contextutil.WithQueryLimits(ctx, contextutil.GetQueryLimits(ctx))
+	if !node.recorder.Sample() {
+		return node.search(ctx, req)
+	}
+
+	admitted := time.Now()
+	resp, err := node.search(ctx, req)
+	node.recorder.RecordSearch(req, admitted, merr.CheckRPCCall(resp, err))
+	return resp, err
+}
 
+func (node *QueryNode) search(ctx context.Context, req *querypb.SearchRequest) (*internalpb.SearchResults, error) {
 	log := log.Ctx(ctx).With(
 		zap.Int64("collectionID", req.GetReq().GetCollectionID()),
 		zap.Strings("channels", req.GetDmlChannels()),
@@ -929,7 +963,22 @@ func (node *QueryNode) Query(ctx context.Context, req *querypb.QueryRequest) (*i
 		// for compatible with rolling upgrade from version before v2.2.9
 		return node.QuerySegments(ctx, req)
 	}
+	if err := segments.GetMemoryWatermark().CheckRequest("query"); err != nil {
+		return &internalpb.RetrieveResults{Status: merr.Status(err)}, nil
+	}
+	// the limits of the request are carried to the workers
+	ctx = contextutil.WithQueryLimits(ctx, contextutil.GetQueryLimits(ctx))
+	if !node.recorder.Sample() {
+		return node.query(ctx, req)
+	}
+
+	admitted := time.Now()
+	resp, err := node.query(ctx, req)
+	node.recorder.RecordQuery(req, admitted, merr.CheckRPCCall(resp, err))
+	return resp, err
+}
 
+func (node *QueryNode) query(ctx context.Context, req *querypb.QueryRequest) (*internalpb.RetrieveResults, error) {
 	log := log.Ctx(ctx).With(
 		zap.Int64("collectionID", req.GetReq().GetCollectionID()),
 		zap.Strings("shards", req.GetDmlChannels()),
@@ -1015,7 +1064,7 @@ func (node *QueryNode) Query(ctx context.Context, req *querypb.QueryRequest) (*i
 }
 
 func (node *QueryNode) QueryStream(req *querypb.QueryRequest, srv querypb.QueryNode_QueryStreamServer) error {
-	ctx := srv.Context()
+	ctx := contextutil.WithQueryLimits(srv.Context(), contextutil.GetQueryLimits(srv.Context()))
 	log := log.Ctx(ctx).With(
 		zap.Int64("collectionID", req.GetReq().GetCollectionID()),
 		zap.Strings("shards", req.GetDmlChannels()),
@@ -1250,6 +1299,7 @@ func (node *QueryNode) GetDataDistribution(ctx context.Context, req *querypb.Get
 	sealedSegments := node.manager.Segment.GetBy(segments.WithType(commonpb.SegmentState_Sealed))
 	segmentVersionInfos := make([]*querypb.SegmentVersionInfo, 0, len(sealedSegments))
 	for _, s := range sealedSegments {
+		accessCount, fieldAccess := node.manager.Access.Get(s.ID())
 		segmentVersionInfos = append(segmentVersionInfos, &querypb.SegmentVersionInfo{
 			ID:                 s.ID(),
 			Collection:         s.Collection(),
@@ -1260,6 +1310,8 @@ func (node *QueryNode) GetDataDistribution(ctx context.Context, req *querypb.Get
 			IndexInfo: lo.SliceToMap(s.Indexes(), func(info *segments.IndexedFieldInfo) (int64, *querypb.FieldIndexInfo) {
 				return info.IndexInfo.FieldID, info.IndexInfo
 			}),
+			AccessCount: accessCount,
+			FieldAccess: fieldAccess,
 		})
 	}
 
@@ -1461,3 +1513,48 @@ func (node *QueryNode) Delete(ctx context.Context, req *querypb.DeleteRequest) (
 
 	return merr.Success(), nil
 }
+
+// FetchIndexFile lists the files of the disk index cached on the local disk, or reads a chunk of them,
+// for the peers loading the same index.
+func (node *QueryNode) FetchIndexFile(ctx context.Context, req *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error) {
+	if err := node.lifetime.Add(merr.IsHealthy); err != nil {
+		return &querypb.FetchIndexFileResponse{Status: merr.Status(err)}, nil
+	}
+	defer node.lifetime.Done()
+
+	if err := merr.CheckTargetID(req.GetBase()); err != nil {
+		return &querypb.FetchIndexFileResponse{Status: merr.Status(err)}, nil
+	}
+
+	catalog := segments.GetLocalCatalog()
+	if req.GetFile() == "" {
+		files, err := catalog.ListIndexFiles(req.GetBuildID(), req.GetIndexVersion())
+		if err != nil {
+			return &querypb.FetchIndexFileResponse{Status: merr.Status(err)}, nil
+		}
+		return &querypb.FetchIndexFileResponse{Status: merr.Success(), Files: files}, nil
+	}
+
+	data, err := catalog.ReadIndexFile(req.GetBuildID(), req.GetIndexVersion(), req.GetFile(), req.GetOffset(), req.GetSize())
+	if err != nil {
+		log.Ctx(ctx).Warn("failed to read disk index file for peer",
+			zap.Int64("buildID", req.GetBuildID()),
+			zap.String("file", req.GetFile()),
+			zap.Error(err))
+		return &querypb.FetchIndexFileResponse{Status: merr.Status(err)}, nil
+	}
+	return &querypb.FetchIndexFileResponse{
+		Status:   merr.Success(),
+		Data:     data,
+		Checksum: crc32.Checksum(data, segments.IndexFileCRCTable),
+	}, nil
+}
+
+// fetchIndexFile fetches the disk index files cached by the peer through the worker of it.
+func (node *QueryNode) fetchIndexFile(ctx context.Context, nodeID int64, req *querypb.FetchIndexFileRequest) (*querypb.FetchIndexFileResponse, error) {
+	worker, err := node.clusterManager.GetWorker(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return worker.FetchIndexFile(ctx, req)
+}
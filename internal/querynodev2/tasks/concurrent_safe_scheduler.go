@@ -3,11 +3,13 @@ package tasks
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/querynodev2/collector"
+	"github.com/milvus-io/milvus/internal/querynodev2/segments"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/util/conc"
@@ -47,6 +49,17 @@ type scheduler struct {
 	execChan    chan Task
 	pool        *conc.Pool[any]
 
+	// batching is the task held in the batch window to merge the incoming tasks,
+	// the other tasks keep dispatching while it's held.
+	// batchTimer fires when the window closes, it's reused by the held tasks,
+	// and batchExpired is set then to dispatch the held task before the ones in policy.
+	// They're only accessed by the schedule goroutine.
+	batching     BatchTask
+	batchTimer   *time.Timer
+	batchExpired bool
+	// stopping is set once the receiveChan closed, no task is held then.
+	stopping bool
+
 	// wg is the waitgroup for internal worker goroutine
 	wg sync.WaitGroup
 	// lifetime controls scheduler State & make sure all requests accepted will be processed
@@ -118,11 +131,13 @@ func (s *scheduler) schedule() {
 		case req, ok := <-s.receiveChan:
 			if !ok {
 				log.Info("receiveChan closed, processing remaining request")
+				// dispatch the held task without waiting the batch window
+				s.stopping = true
 				// drain policy maintained task
 				for task != nil {
-					execChan <- task
+					s.execChan <- task
 					s.updateWaitingTaskCounter(-1, -nq)
-					task = s.produceExecChan()
+					task, nq, _ = s.setupExecListener(nil)
 				}
 				log.Info("all task put into exeChan, schedule worker exit")
 				close(s.execChan)
@@ -131,6 +146,9 @@ func (s *scheduler) schedule() {
 			// Receive add operation request and return the process result.
 			// And consume recv chan as much as possible.
 			s.consumeRecvChan(req, maxReceiveChanBatchConsumeNum)
+		case <-s.batchWindow():
+			// Batch window closed, the held task will be sent before the others.
+			s.batchExpired = true
 		case execChan <- task:
 			// Task sent, drop the ownership of sent task.
 			// Update waiting task counter.
@@ -172,8 +190,14 @@ func (s *scheduler) handleAddTaskRequest(req addTaskReq, maxWaitTaskNum int64) b
 		log.Warn("task canceled before enqueue", zap.Error(err))
 		req.err <- err
 	} else {
-		// Push the task into the policy to schedule and update the counter of the ready queue.
 		nq := req.task.NQ()
+		// Merge the task into the batching one if possible.
+		if s.batching != nil && s.batching.MergeWith(req.task) {
+			s.updateWaitingTaskCounter(0, nq)
+			req.err <- nil
+			return s.GetWaitingTaskTotal() < maxWaitTaskNum
+		}
+		// Push the task into the policy to schedule and update the counter of the ready queue.
 		newTaskAdded, err := s.policy.Push(req.task)
 		if err == nil {
 			s.updateWaitingTaskCounter(int64(newTaskAdded), nq)
@@ -226,6 +250,12 @@ func (s *scheduler) exec() {
 			t.Done(err)
 			continue
 		}
+		release, err := admitMemory(t)
+		if err != nil {
+			log.Warn("task rejected by memory governor", zap.Error(err))
+			t.Done(err)
+			continue
+		}
 
 		s.pool.Submit(func() (any, error) {
 			// Update concurrency metric and notify task done.
@@ -239,19 +269,50 @@ func (s *scheduler) exec() {
 			collector.Counter.Dec(metricsinfo.ExecuteQueueType, -1)
 
 			// Notify task done.
+			release()
 			t.Done(err)
 			return nil, err
 		})
 	}
 }
 
+// admitMemory admits the in-flight buffers of the task from the memory governor,
+// returns the function to release them after the task done.
+func admitMemory(t Task) (func(), error) {
+	estimator, ok := t.(MemoryEstimator)
+	if !ok {
+		return func() {}, nil
+	}
+	key := fmt.Sprintf("%p", t)
+	governor := segments.GetMemoryGovernor()
+	if err := governor.Admit(segments.MemoryCategoryQueryBuffer, key, estimator.EstimateMemory()); err != nil {
+		return nil, err
+	}
+	return func() {
+		governor.Release(segments.MemoryCategoryQueryBuffer, key)
+	}, nil
+}
+
 // setupExecListener setup the execChan and next task to run.
 func (s *scheduler) setupExecListener(lastWaitingTask Task) (Task, int64, chan Task) {
 	var execChan chan Task
 	nq := int64(0)
-	if lastWaitingTask == nil {
-		// No task is waiting to send to execChan, schedule a new one from queue.
+	for lastWaitingTask == nil {
+		// No task is waiting to send to execChan, the held task goes first once its batch window closed.
+		if s.batching != nil && (s.batchExpired || s.stopping) {
+			lastWaitingTask = s.batching
+			s.batching = nil
+			s.batchExpired = false
+			break
+		}
+		// Schedule a new one from queue, which may be held as the batch candidate.
 		lastWaitingTask = s.policy.Pop()
+		if lastWaitingTask == nil {
+			break
+		}
+		if s.holdBatchTask(lastWaitingTask) {
+			lastWaitingTask = nil
+		}
 	}
 	if lastWaitingTask != nil {
 		// Try to sent task to execChan if there is a task ready to run.
@@ -262,6 +323,40 @@ func (s *scheduler) setupExecListener(lastWaitingTask Task) (Task, int64, chan T
 	return lastWaitingTask, nq, execChan
 }
 
+// holdBatchTask holds the task in the batch window if it's a BatchTask and no task is held,
+// returns false if the task should be dispatched.
+func (s *scheduler) holdBatchTask(task Task) bool {
+	if s.batching != nil || s.stopping {
+		return false
+	}
+	bt := tryIntoBatchTask(task)
+	if bt == nil {
+		return false
+	}
+	wait := time.Until(bt.BatchDeadline())
+	if wait <= 0 {
+		return false
+	}
+
+	if s.batchTimer == nil {
+		s.batchTimer = time.NewTimer(wait)
+	} else {
+		// the timer is stopped or fired and drained since the last held task dispatched
+		s.batchTimer.Reset(wait)
+	}
+	s.batching = bt
+	return true
+}
+
+// batchWindow returns the channel fired when the batch window of the held task closes,
+// nil if no task is held or the window has closed.
+func (s *scheduler) batchWindow() <-chan time.Time {
+	if s.batching == nil || s.batchExpired {
+		return nil
+	}
+	return s.batchTimer.C
+}
+
 // setupReadyLenMetric update the read task ready len metric.
 func (s *scheduler) setupReadyLenMetric() {
 	waitingTaskCount := s.GetWaitingTaskTotal()
@@ -137,3 +137,81 @@ func (s *SchedulerSuite) TestConsumeRecvChan() {
 		})
 	})
 }
+
+func (s *SchedulerSuite) TestBatchWindow() {
+	scheduler := newScheduler(newFIFOPolicy())
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	var cnt atomic.Int32
+	execution := func(ctx context.Context) error {
+		cnt.Inc()
+		return nil
+	}
+	batch := &mockBatchTask{
+		MockTask: newMockTask(mockTaskConfig{
+			nq:          1,
+			mergeAble:   true,
+			executeCost: 10 * time.Millisecond,
+			execution:   execution,
+		}).(*MockTask),
+		deadline: time.Now().Add(200 * time.Millisecond),
+	}
+	s.NoError(scheduler.Add(batch))
+
+	// tasks arriving within the window are merged into the batching one.
+	for i := 0; i < 3; i++ {
+		task := newMockTask(mockTaskConfig{
+			nq:          1,
+			mergeAble:   true,
+			executeCost: 10 * time.Millisecond,
+			execution:   execution,
+		})
+		s.NoError(scheduler.Add(task))
+	}
+	s.EqualValues(1, scheduler.GetWaitingTaskTotal())
+	s.EqualValues(4, scheduler.GetWaitingTaskTotalNQ())
+	s.EqualValues(0, cnt.Load())
+
+	s.NoError(batch.Wait())
+	s.EqualValues(1, cnt.Load())
+	s.EqualValues(4, batch.NQ())
+	s.EqualValues(0, scheduler.GetWaitingTaskTotal())
+	s.EqualValues(0, scheduler.GetWaitingTaskTotalNQ())
+}
+
+func (s *SchedulerSuite) TestBatchWindowNotBlocking() {
+	scheduler := newScheduler(newFIFOPolicy())
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	newBatchTask := func() *mockBatchTask {
+		return &mockBatchTask{
+			MockTask: newMockTask(mockTaskConfig{
+				nq:          1,
+				executeCost: 10 * time.Millisecond,
+			}).(*MockTask),
+			deadline: time.Now().Add(500 * time.Millisecond),
+		}
+	}
+	batch := newBatchTask()
+	s.NoError(scheduler.Add(batch))
+
+	// the tasks not mergeable are dispatched while the batch candidate is held
+	task := newMockTask(mockTaskConfig{
+		nq:          1,
+		executeCost: 10 * time.Millisecond,
+	})
+	s.NoError(scheduler.Add(task))
+	start := time.Now()
+	s.NoError(task.Wait())
+	s.Less(time.Since(start), 400*time.Millisecond)
+	s.EqualValues(1, scheduler.GetWaitingTaskTotal())
+
+	// the timer is reused by the next held task
+	s.NoError(batch.Wait())
+	next := newBatchTask()
+	s.NoError(scheduler.Add(next))
+	s.NoError(next.Wait())
+	s.EqualValues(0, scheduler.GetWaitingTaskTotal())
+}
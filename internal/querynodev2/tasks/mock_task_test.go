@@ -11,6 +11,7 @@ import (
 var (
 	_ Task      = &MockTask{}
 	_ MergeTask = &MockTask{}
+	_ BatchTask = &mockBatchTask{}
 )
 
 type mockTaskConfig struct {
@@ -113,3 +114,13 @@ func (t *MockTask) MergeWith(t2 Task) bool {
 func (t *MockTask) NQ() int64 {
 	return t.nq
 }
+
+// mockBatchTask is a MockTask held until the deadline.
+type mockBatchTask struct {
+	*MockTask
+	deadline time.Time
+}
+
+func (t *mockBatchTask) BatchDeadline() time.Time {
+	return t.deadline
+}
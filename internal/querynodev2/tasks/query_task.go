@@ -12,13 +12,18 @@ import (
 	"github.com/milvus-io/milvus/internal/querynodev2/collector"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
 	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
-var _ Task = &QueryTask{}
+var (
+	_ Task            = &QueryTask{}
+	_ MemoryEstimator = &QueryTask{}
+)
 
 func NewQueryTask(ctx context.Context,
 	collection *segments.Collection,
@@ -80,6 +85,7 @@ func (t *QueryTask) Execute() error {
 	tr := timerecord.NewTimeRecorderWithTrace(t.ctx, "QueryTask")
 
 	retrievePlan, err := segments.NewRetrievePlan(
+		t.ctx,
 		t.collection,
 		t.req.Req.GetSerializedExprPlan(),
 		t.req.Req.GetMvccTimestamp(),
@@ -119,11 +125,36 @@ func (t *QueryTask) Execute() error {
 		FieldsData: reducedResult.FieldsData,
 		CostAggregation: &internalpb.CostAggregation{
 			ServiceTime: tr.ElapseSpan().Milliseconds(),
+			ScannedRows: retrievePlan.ScannedRows(),
 		},
 	}
 	return nil
 }
 
+// EstimateMemory estimates the memory of the retrieve results of all the segments,
+// the result of each segment is limited by the limit of request and MaxOutputSize.
+func (t *QueryTask) EstimateMemory() uint64 {
+	if t.req.GetReq().GetIsCount() {
+		return 0
+	}
+	rowSize, err := typeutil.EstimateSizePerRecord(t.collection.Schema())
+	if err != nil {
+		return 0
+	}
+
+	maxOutputSize := paramtable.Get().QuotaConfig.MaxOutputSize.GetAsInt64()
+	limit := t.req.GetReq().GetLimit()
+	size := int64(0)
+	for _, segment := range targetSegments(t.segmentManager, t.req.GetScope(), t.req.GetDmlChannels()[0], t.req.GetSegmentIDs()) {
+		rowNum := segment.RowNum()
+		if limit > 0 && limit < rowNum {
+			rowNum = limit
+		}
+		size += funcutil.Min(rowNum*int64(rowSize), maxOutputSize)
+	}
+	return uint64(size)
+}
+
 func (t *QueryTask) Done(err error) {
 	t.notifier <- err
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
@@ -16,20 +17,28 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querynodev2/collector"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/util/bufferpool"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/contextutil"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/timerecord"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 var (
-	_ Task      = &SearchTask{}
-	_ MergeTask = &SearchTask{}
+	_ Task            = &SearchTask{}
+	_ MergeTask       = &SearchTask{}
+	_ MemoryEstimator = &SearchTask{}
+	_ BatchTask       = &SearchTask{}
 )
 
+// searchResultRowSize is the size of a search result row, an int64 id and a float32 distance.
+const searchResultRowSize = 12
+
 type SearchTask struct {
 	ctx              context.Context
 	collection       *segments.Collection
@@ -118,7 +127,7 @@ func (t *SearchTask) Execute() error {
 
 	req := t.req
 	t.combinePlaceHolderGroups()
-	searchReq, err := segments.NewSearchRequest(t.collection, req, t.placeholderGroup)
+	searchReq, err := segments.NewSearchRequest(t.ctx, t.collection, req, t.placeholderGroup)
 	if err != nil {
 		return err
 	}
@@ -211,9 +220,9 @@ func (t *SearchTask) Execute() error {
 			task = t.others[i-1]
 		}
 
-		// Note: blob is unsafe because get from C
-		bs := make([]byte, len(blob))
-		copy(bs, blob)
+		// Note: blob is unsafe because get from C,
+		// the copy is recycled after decoded by the shard leader or proxy
+		bs := append(bufferpool.SearchResultPool.Get(len(blob)), blob...)
 
 		task.result = &internalpb.SearchResults{
 			Base: &commonpb.MsgBase{
@@ -228,6 +237,7 @@ func (t *SearchTask) Execute() error {
 			SlicedNumCount: 1,
 			CostAggregation: &internalpb.CostAggregation{
 				ServiceTime: tr.ElapseSpan().Milliseconds(),
+				ScannedRows: searchReq.ScannedRows(),
 			},
 		}
 	}
@@ -258,7 +268,11 @@ func (t *SearchTask) Merge(other *SearchTask) bool {
 		diffTopk && ratio > paramtable.Get().QueryNodeCfg.TopKMergeRatio.GetAsFloat() ||
 		!funcutil.SliceSetEqual(t.req.GetReq().GetPartitionIDs(), other.req.GetReq().GetPartitionIDs()) ||
 		!funcutil.SliceSetEqual(t.req.GetSegmentIDs(), other.req.GetSegmentIDs()) ||
-		!bytes.Equal(t.req.GetReq().GetSerializedExprPlan(), other.req.GetReq().GetSerializedExprPlan()) {
+		!bytes.Equal(t.req.GetReq().GetSerializedExprPlan(), other.req.GetReq().GetSerializedExprPlan()) ||
+		// the pre-filter hooks may filter by the caller
+		segments.HasPreFilterHooks() && t.req.GetReq().GetUsername() != other.req.GetReq().GetUsername() ||
+		// the merged requests share the counter of scanned rows
+		contextutil.GetQueryLimits(t.ctx) != contextutil.GetQueryLimits(other.ctx) {
 		return false
 	}
 
@@ -302,6 +316,12 @@ func (t *SearchTask) NQ() int64 {
 	return t.nq
 }
 
+// EstimateMemory estimates the memory of the placeholders and the search results of all the segments.
+func (t *SearchTask) EstimateMemory() uint64 {
+	segmentNum := int64(len(targetSegments(t.segmentManager, t.req.GetScope(), t.req.GetDmlChannels()[0], t.req.GetSegmentIDs())))
+	return uint64(len(t.placeholderGroup)) + uint64(t.nq*t.topk*segmentNum*searchResultRowSize)
+}
+
 func (t *SearchTask) MergeWith(other Task) bool {
 	switch other := other.(type) {
 	case *SearchTask:
@@ -310,6 +330,16 @@ func (t *SearchTask) MergeWith(other Task) bool {
 	return false
 }
 
+// BatchDeadline returns the end of the batch window since the task created,
+// the task is not held if the window is disabled or the nq is large enough.
+func (t *SearchTask) BatchDeadline() time.Time {
+	window := paramtable.Get().QueryNodeCfg.SearchBatchWindow.GetAsDuration(time.Millisecond)
+	if window <= 0 || t.nq >= paramtable.Get().QueryNodeCfg.SearchBatchMaxNQ.GetAsInt64() {
+		return time.Time{}
+	}
+	return time.Now().Add(window - t.tr.ElapseSpan())
+}
+
 // combinePlaceHolderGroups combine all the placeholder groups.
 func (t *SearchTask) combinePlaceHolderGroups() {
 	if len(t.others) > 0 {
@@ -323,3 +353,24 @@ func (t *SearchTask) combinePlaceHolderGroups() {
 		t.placeholderGroup, _ = proto.Marshal(ret)
 	}
 }
+
+// targetSegments returns the segments read by the request, which are not pinned and only used to estimate the cost,
+// all the segments of the channel in the scope are read if no segment specified.
+func targetSegments(manager *segments.Manager, scope querypb.DataScope, channel string, segmentIDs []int64) []segments.Segment {
+	filters := make([]segments.SegmentFilter, 0, 2)
+	switch scope {
+	case querypb.DataScope_Historical:
+		filters = append(filters, segments.WithType(segments.SegmentTypeSealed))
+	case querypb.DataScope_Streaming:
+		filters = append(filters, segments.WithType(segments.SegmentTypeGrowing))
+	}
+	if len(segmentIDs) > 0 {
+		ids := typeutil.NewSet(segmentIDs...)
+		filters = append(filters, func(segment segments.Segment) bool {
+			return ids.Contain(segment.ID())
+		})
+	} else {
+		filters = append(filters, segments.WithChannel(channel))
+	}
+	return manager.Segment.GetBy(filters...)
+}
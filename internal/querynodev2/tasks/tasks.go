@@ -1,5 +1,7 @@
 package tasks
 
+import "time"
+
 const (
 	schedulePolicyNameFIFO            = "fifo"
 	schedulePolicyNameUserTaskPolling = "user-task-polling"
@@ -76,6 +78,25 @@ type MergeTask interface {
 	MergeWith(Task) bool
 }
 
+// BatchTask is a MergeTask which may be held for a short window,
+// so the tasks arriving within the window could be merged into it.
+type BatchTask interface {
+	MergeTask
+
+	// BatchDeadline returns the time until which the task could be held,
+	// zero time if the task should be executed immediately.
+	BatchDeadline() time.Time
+}
+
+// tryIntoBatchTask convert inner task into BatchTask,
+// Return nil if inner task is not a BatchTask.
+func tryIntoBatchTask(t Task) BatchTask {
+	if bt, ok := t.(BatchTask); ok {
+		return bt
+	}
+	return nil
+}
+
 // A task is execute unit of scheduler.
 type Task interface {
 	// Return the username which task is belong to.
@@ -102,3 +123,9 @@ type Task interface {
 	// Return the NQ of task.
 	NQ() int64
 }
+
+// MemoryEstimator is a Task which estimates the memory of its in-flight buffers,
+// the task is admitted by the memory governor before executing.
+type MemoryEstimator interface {
+	EstimateMemory() uint64
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 )
 
 type alterAliasTask struct {
@@ -42,3 +43,13 @@ func (t *alterAliasTask) Execute(ctx context.Context) error {
 	// alter alias is atomic enough.
 	return t.core.meta.AlterAlias(ctx, t.Req.GetDbName(), t.Req.GetAlias(), t.Req.GetCollectionName(), t.GetTs())
 }
+
+func (t *alterAliasTask) ddlEvent() *metricsinfo.DDLEvent {
+	return &metricsinfo.DDLEvent{
+		Timestamp:      t.GetTs(),
+		Type:           commonpb.MsgType_AlterAlias.String(),
+		DBName:         getDDLEventDBName(t.Req.GetDbName()),
+		CollectionName: t.Req.GetCollectionName(),
+		Alias:          t.Req.GetAlias(),
+	}
+}
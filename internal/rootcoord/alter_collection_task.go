@@ -26,7 +26,11 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 )
 
 type alterCollectionTask struct {
@@ -38,6 +42,9 @@ func (a *alterCollectionTask) Prepare(ctx context.Context) error {
 	if a.Req.GetCollectionName() == "" {
 		return fmt.Errorf("alter collection failed, collection name does not exists")
 	}
+	if err := storage.ValidateBinlogCompression(nil, a.Req.GetProperties()); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
 
 	return nil
 }
@@ -106,3 +113,14 @@ func updateCollectionProperties(coll *model.Collection, updatedProps []*commonpb
 
 	coll.Properties = propKV
 }
+
+func (t *alterCollectionTask) ddlEvent() *metricsinfo.DDLEvent {
+	return &metricsinfo.DDLEvent{
+		Timestamp:      t.GetTs(),
+		Type:           commonpb.MsgType_AlterCollection.String(),
+		DBName:         getDDLEventDBName(t.Req.GetDbName()),
+		CollectionName: t.Req.GetCollectionName(),
+		CollectionID:   t.Req.GetCollectionID(),
+		Properties:     funcutil.KeyValuePair2Map(t.Req.GetProperties()),
+	}
+}
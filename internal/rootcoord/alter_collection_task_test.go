@@ -48,6 +48,18 @@ func Test_alterCollectionTask_Prepare(t *testing.T) {
 		err := task.Prepare(context.Background())
 		assert.NoError(t, err)
 	})
+
+	t.Run("unsupported binlog codec", func(t *testing.T) {
+		task := &alterCollectionTask{
+			Req: &milvuspb.AlterCollectionRequest{
+				Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_AlterCollection},
+				CollectionName: "cn",
+				Properties:     []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "lz4"}},
+			},
+		}
+		err := task.Prepare(context.Background())
+		assert.Error(t, err)
+	})
 }
 
 func Test_alterCollectionTask_Execute(t *testing.T) {
@@ -0,0 +1,154 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+type alterDatabaseTask struct {
+	baseTask
+	Req  *rootcoordpb.AlterDatabaseRequest
+	dbID UniqueID
+}
+
+func (t *alterDatabaseTask) Prepare(ctx context.Context) error {
+	if t.Req.GetDbName() == "" {
+		return merr.WrapErrParameterInvalidMsg("alter database failed, database name is empty")
+	}
+	if len(t.Req.GetProperties()) == 0 {
+		return merr.WrapErrParameterInvalidMsg("alter database failed, properties is empty")
+	}
+	return nil
+}
+
+func (t *alterDatabaseTask) Execute(ctx context.Context) error {
+	oldDB, err := t.core.meta.GetDatabaseByName(ctx, t.Req.GetDbName(), typeutil.MaxTimestamp)
+	if err != nil {
+		return err
+	}
+	t.dbID = oldDB.ID
+
+	newDB := oldDB.Clone()
+	newDB.Properties = updateDatabaseProperties(oldDB.Properties, t.Req.GetProperties())
+	if err := checkDatabaseIsolation(ctx, t.core.meta, newDB); err != nil {
+		return err
+	}
+
+	// reserve the channels before persisting, so that the ones reserved by others are rejected
+	if err := t.core.chanTimeTick.reserveDmlChannels(newDB.ID, common.GetDatabaseDmlChannels(newDB.Properties...)...); err != nil {
+		return merr.WrapErrParameterInvalidMsg(err.Error())
+	}
+	if err := t.core.meta.AlterDatabase(ctx, oldDB, newDB, t.GetTs()); err != nil {
+		_ = t.core.chanTimeTick.reserveDmlChannels(oldDB.ID, common.GetDatabaseDmlChannels(oldDB.Properties...)...)
+		return err
+	}
+	return nil
+}
+
+func (t *alterDatabaseTask) ddlEvent() *metricsinfo.DDLEvent {
+	return &metricsinfo.DDLEvent{
+		Timestamp:  t.GetTs(),
+		Type:       "AlterDatabase",
+		DBName:     t.Req.GetDbName(),
+		DBID:       t.dbID,
+		Properties: funcutil.KeyValuePair2Map(t.Req.GetProperties()),
+	}
+}
+
+// updateDatabaseProperties merges the updated properties, the ones with empty value are removed.
+func updateDatabaseProperties(props []*commonpb.KeyValuePair, updatedProps []*commonpb.KeyValuePair) []*commonpb.KeyValuePair {
+	merged := funcutil.KeyValuePair2Map(props)
+	for _, prop := range updatedProps {
+		if prop.GetValue() == "" {
+			delete(merged, prop.GetKey())
+			continue
+		}
+		merged[prop.GetKey()] = prop.GetValue()
+	}
+
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]*commonpb.KeyValuePair, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, &commonpb.KeyValuePair{Key: key, Value: merged[key]})
+	}
+	return result
+}
+
+// checkDatabaseIsolation checks the dml channels and datanodes reserved by the database
+// are disjoint from the ones of other databases.
+func checkDatabaseIsolation(ctx context.Context, meta IMetaTable, db *model.Database) error {
+	channels := typeutil.NewSet(common.GetDatabaseDmlChannels(db.Properties...)...)
+	nodeIDs, err := common.GetDatabaseDataNodes(db.Properties...)
+	if err != nil {
+		return merr.WrapErrParameterInvalidMsg(err.Error())
+	}
+	if len(nodeIDs) > 0 && channels.Len() == 0 {
+		return merr.WrapErrParameterInvalidMsg(fmt.Sprintf("%s requires %s", common.DatabaseDataNodesKey, common.DatabaseDmlChannelsKey))
+	}
+	if channels.Len() == 0 {
+		return nil
+	}
+	nodes := typeutil.NewUniqueSet(nodeIDs...)
+
+	dbs, err := meta.ListDatabases(ctx, typeutil.MaxTimestamp)
+	if err != nil {
+		return err
+	}
+	for _, other := range dbs {
+		if other.ID == db.ID {
+			continue
+		}
+		otherNodes, _ := common.GetDatabaseDataNodes(other.Properties...)
+		for _, nodeID := range otherNodes {
+			if nodes.Contain(nodeID) {
+				return merr.WrapErrParameterInvalidMsg(fmt.Sprintf("datanode %d has been reserved by database %s", nodeID, other.Name))
+			}
+		}
+	}
+
+	// the channels used by the collections of other databases can't be reserved
+	collChannels := meta.ListCollectionPhysicalChannels()
+	for dbID, collIDs := range meta.ListAllAvailCollections(ctx) {
+		if dbID == db.ID {
+			continue
+		}
+		for _, collID := range collIDs {
+			for _, channel := range collChannels[collID] {
+				if channels.Contain(channel) {
+					return merr.WrapErrParameterInvalidMsg(fmt.Sprintf("dml channel %s is used by collection %d of database %d", channel, collID, dbID))
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 )
 
 type createAliasTask struct {
@@ -42,3 +43,13 @@ func (t *createAliasTask) Execute(ctx context.Context) error {
 	// create alias is atomic enough.
 	return t.core.meta.CreateAlias(ctx, t.Req.GetDbName(), t.Req.GetAlias(), t.Req.GetCollectionName(), t.GetTs())
 }
+
+func (t *createAliasTask) ddlEvent() *metricsinfo.DDLEvent {
+	return &metricsinfo.DDLEvent{
+		Timestamp:      t.GetTs(),
+		Type:           commonpb.MsgType_CreateAlias.String(),
+		DBName:         getDDLEventDBName(t.Req.GetDbName()),
+		CollectionName: t.Req.GetCollectionName(),
+		Alias:          t.Req.GetAlias(),
+	}
+}
@@ -31,12 +31,14 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/metastore/model"
 	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	ms "github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	parameterutil "github.com/milvus-io/milvus/pkg/util/parameterutil.go"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
@@ -78,6 +80,10 @@ func (t *createCollectionTask) validate() error {
 		return fmt.Errorf("shard num (%d) exceeds system limit (%d)", shardsNum, cfgShardLimit)
 	}
 
+	if common.IsExclusiveTopicEnabled(t.Req.GetProperties()...) && Params.CommonCfg.PreCreatedTopicEnabled.GetAsBool() {
+		return merr.WrapErrParameterInvalidMsg("exclusive topic is not supported when the topics are pre-created")
+	}
+
 	db2CollIDs := t.core.meta.ListAllAvailCollections(t.ctx)
 
 	collIDs, ok := db2CollIDs[t.dbID]
@@ -195,6 +201,11 @@ func (t *createCollectionTask) validateSchema(schema *schemapb.CollectionSchema)
 		msg := fmt.Sprintf("schema contains system field: %s, %s, %s", RowIDFieldName, TimeStampFieldName, MetaFieldName)
 		return merr.WrapErrParameterInvalid("schema don't contains system field", "contains", msg)
 	}
+
+	// the parquet writer fails at runtime with the codecs it doesn't support, e.g. lz4
+	if err := storage.ValidateBinlogCompression(schema, t.Req.GetProperties()); err != nil {
+		return merr.WrapErrParameterInvalidMsg("%s", err.Error())
+	}
 	return nil
 }
 
@@ -305,7 +316,13 @@ func (t *createCollectionTask) assignPartitionIDs() error {
 func (t *createCollectionTask) assignChannels() error {
 	vchanNames := make([]string, t.Req.GetShardsNum())
 	// physical channel names
-	chanNames := t.core.chanTimeTick.getDmlChannelNames(int(t.Req.GetShardsNum()))
+	var chanNames []string
+	if common.IsExclusiveTopicEnabled(t.Req.GetProperties()...) {
+		chanNames = t.core.topicController.allocate(t.collID, int(t.Req.GetShardsNum()))
+	} else {
+		// the databases isolated by reserving dml channels don't share them with others
+		chanNames = t.core.chanTimeTick.getDmlChannelNamesOfDB(t.dbID, int(t.Req.GetShardsNum()))
+	}
 
 	if int32(len(chanNames)) < t.Req.GetShardsNum() {
 		return fmt.Errorf("no enough channels, want: %d, got: %d", t.Req.GetShardsNum(), len(chanNames))
@@ -410,10 +427,20 @@ func (t *createCollectionTask) Execute(ctx context.Context) error {
 	vchanNames := t.channels.virtualChannels
 	chanNames := t.channels.physicalChannels
 
+	exclusive := isExclusiveChannels(chanNames)
+	if exclusive {
+		if err := t.core.topicController.create(chanNames); err != nil {
+			return err
+		}
+	}
+
 	startPositions, err := t.addChannelsAndGetStartPositions(ctx, ts)
 	if err != nil {
 		// ugly here, since we must get start positions first.
 		t.core.chanTimeTick.removeDmlChannels(t.channels.physicalChannels...)
+		if exclusive {
+			_ = t.core.topicController.destroy(ctx, chanNames)
+		}
 		return err
 	}
 
@@ -471,6 +498,12 @@ func (t *createCollectionTask) Execute(ctx context.Context) error {
 		collectionID:    InvalidCollectionID,
 		ts:              ts,
 	}, &nullStep{})
+	if exclusive {
+		undoTask.AddStep(&nullStep{}, &deleteExclusiveTopicsStep{
+			baseStep:  baseStep{core: t.core},
+			pChannels: chanNames,
+		}) // delete the exclusive topics after dml channels removed.
+	}
 	undoTask.AddStep(&nullStep{}, &removeDmlChannelsStep{
 		baseStep:  baseStep{core: t.core},
 		pChannels: chanNames,
@@ -515,3 +548,23 @@ func (t *createCollectionTask) Execute(ctx context.Context) error {
 
 	return undoTask.Execute(ctx)
 }
+
+func (t *createCollectionTask) ddlEvent() *metricsinfo.DDLEvent {
+	schema, _ := proto.Marshal(t.schema)
+	partitions := make(map[string]int64, len(t.partIDs))
+	for i, partID := range t.partIDs {
+		partitions[t.partitionNames[i]] = partID
+	}
+	return &metricsinfo.DDLEvent{
+		Timestamp:       t.GetTs(),
+		Type:            commonpb.MsgType_CreateCollection.String(),
+		DBName:          getDDLEventDBName(t.Req.GetDbName()),
+		DBID:            t.dbID,
+		CollectionName:  t.Req.GetCollectionName(),
+		CollectionID:    t.collID,
+		Schema:          schema,
+		Properties:      funcutil.KeyValuePair2Map(t.Req.GetProperties()),
+		VirtualChannels: t.channels.virtualChannels,
+		Partitions:      partitions,
+	}
+}
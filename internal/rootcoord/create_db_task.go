@@ -19,10 +19,12 @@ package rootcoord
 import (
 	"context"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/metastore/model"
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 )
 
 type createDatabaseTask struct {
@@ -53,3 +55,12 @@ func (t *createDatabaseTask) Execute(ctx context.Context) error {
 	db := model.NewDatabase(t.dbID, t.Req.GetDbName(), etcdpb.DatabaseState_DatabaseCreated)
 	return t.core.meta.CreateDatabase(ctx, db, t.GetTs())
 }
+
+func (t *createDatabaseTask) ddlEvent() *metricsinfo.DDLEvent {
+	return &metricsinfo.DDLEvent{
+		Timestamp: t.GetTs(),
+		Type:      commonpb.MsgType_CreateDatabase.String(),
+		DBName:    t.Req.GetDbName(),
+		DBID:      t.dbID,
+	}
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/milvus-io/milvus/internal/metastore/model"
 	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type createPartitionTask struct {
@@ -117,3 +119,26 @@ func (t *createPartitionTask) Execute(ctx context.Context) error {
 
 	return undoTask.Execute(ctx)
 }
+
+func (t *createPartitionTask) ddlEvent() *metricsinfo.DDLEvent {
+	event := &metricsinfo.DDLEvent{
+		Timestamp:      t.GetTs(),
+		Type:           commonpb.MsgType_CreatePartition.String(),
+		DBName:         getDDLEventDBName(t.Req.GetDbName()),
+		CollectionName: t.Req.GetCollectionName(),
+		CollectionID:   t.collMeta.CollectionID,
+		PartitionName:  t.Req.GetPartitionName(),
+	}
+	// the id of partition is allocated on executing, so look it up from the meta.
+	coll, err := t.core.meta.GetCollectionByID(t.GetCtx(), t.Req.GetDbName(), t.collMeta.CollectionID, typeutil.MaxTimestamp, false)
+	if err != nil {
+		log.Warn("failed to get the partition created", zap.String("collection", t.Req.GetCollectionName()), zap.Error(err))
+		return event
+	}
+	for _, partition := range coll.Partitions {
+		if partition.PartitionName == t.Req.GetPartitionName() {
+			event.PartitionID = partition.PartitionID
+		}
+	}
+	return event
+}
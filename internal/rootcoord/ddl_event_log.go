@@ -0,0 +1,476 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/kv"
+	kvmetestore "github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/retry"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+)
+
+const (
+	ddlEventLogPrefix  = kvmetestore.ComponentPrefix + "/ddl-event-log"
+	ddlEventPrefix     = ddlEventLogPrefix + "/ddl"
+	dmlWatermarkPrefix = ddlEventLogPrefix + "/watermark"
+
+	// dmlWatermarkEvent is the type of the records of dml channel timeticks.
+	dmlWatermarkEvent = "DmlWatermark"
+	// ddlSnapshotEvent is the type of the record replacing the ddl events out of retention,
+	// it holds the databases and collections rebuilt from them.
+	ddlSnapshotEvent = "Snapshot"
+
+	ddlEventBufferSize = 1024
+)
+
+// ddlEventTask is implemented by the ddl tasks which change the meta,
+// the event is recorded after the task is executed successfully.
+type ddlEventTask interface {
+	task
+	ddlEvent() *metricsinfo.DDLEvent
+}
+
+// ddlEventLog persists a compact ordered log of the ddl events, interleaved with the watermarks of dml channels.
+//
+// The payloads of dml are not recorded, the watermarks are the synced timeticks of the dml channels,
+// which tell the restore tooling how to align the binlog data with the meta.
+// By replaying the events up to a timestamp, the collections, partitions and schemas as of it can be rebuilt.
+// The ddl events out of retention are compacted into a snapshot, so the meta can be rebuilt as of any timestamp
+// within the retention.
+//
+// The events are written by a background goroutine in the order they're appended,
+// so the ddl tasks are not blocked by the writes.
+type ddlEventLog struct {
+	kv            kv.BaseKV
+	getWatermarks func() map[string]Timestamp
+	events        chan *metricsinfo.DDLEvent
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newDDLEventLog(kv kv.BaseKV, getWatermarks func() map[string]Timestamp) *ddlEventLog {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ddlEventLog{
+		kv:            kv,
+		getWatermarks: getWatermarks,
+		events:        make(chan *metricsinfo.DDLEvent, ddlEventBufferSize),
+		ctx:           ctx,
+		cancel:        cancel,
+		closeCh:       make(chan struct{}),
+	}
+}
+
+func buildDDLEventKey(prefix string, ts Timestamp) string {
+	return path.Join(prefix, fmt.Sprintf("%020d", ts))
+}
+
+func (l *ddlEventLog) start() {
+	l.wg.Add(2)
+	go func() {
+		defer l.wg.Done()
+		for {
+			select {
+			case <-l.closeCh:
+				// write the events appended before closing
+				for {
+					select {
+					case event := <-l.events:
+						l.write(event)
+					default:
+						return
+					}
+				}
+			case event := <-l.events:
+				l.write(event)
+			}
+		}
+	}()
+	go func() {
+		defer l.wg.Done()
+		ticker := time.NewTicker(Params.RootCoordCfg.DDLEventLogWatermarkInterval.GetAsDuration(time.Second))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-l.closeCh:
+				log.Info("ddl event log quit")
+				return
+			case <-ticker.C:
+				l.recordWatermark()
+				l.expireWatermarks()
+				l.compact()
+			}
+		}
+	}()
+}
+
+func (l *ddlEventLog) close() {
+	l.closeOnce.Do(func() {
+		close(l.closeCh)
+		l.wg.Wait()
+		l.cancel()
+	})
+}
+
+// append queues the event to be persisted in background,
+// it only blocks if the writes fall behind by ddlEventBufferSize events.
+func (l *ddlEventLog) append(event *metricsinfo.DDLEvent) {
+	select {
+	case l.events <- event:
+	case <-l.closeCh:
+		log.Warn("ddl event log is closed, the event is not recorded", zap.String("type", event.Type), zap.Uint64("ts", event.Timestamp))
+	}
+}
+
+// write persists the event with a short bounded retry.
+func (l *ddlEventLog) write(event *metricsinfo.DDLEvent) {
+	value, err := json.Marshal(event)
+	if err != nil {
+		log.Error("failed to marshal ddl event", zap.String("type", event.Type), zap.Uint64("ts", event.Timestamp), zap.Error(err))
+		return
+	}
+	err = retry.Do(l.ctx, func() error {
+		return l.kv.Save(buildDDLEventKey(ddlEventPrefix, event.Timestamp), string(value))
+	}, retry.Attempts(3), retry.Sleep(100*time.Millisecond), retry.MaxSleepTime(time.Second))
+	if err != nil {
+		log.Error("failed to record ddl event, the point-in-time recovery may be inaccurate",
+			zap.String("type", event.Type), zap.Uint64("ts", event.Timestamp), zap.Error(err))
+		return
+	}
+	log.Info("ddl event recorded", zap.String("type", event.Type), zap.Uint64("ts", event.Timestamp),
+		zap.String("dbName", event.DBName), zap.String("collectionName", event.CollectionName))
+}
+
+// recordWatermark records the synced timeticks of dml channels, the record is keyed by the minimal timetick.
+// All the dml before the timetick of a channel have been produced to it, they are persisted in binlogs once
+// the checkpoint of the channel passes the timetick.
+func (l *ddlEventLog) recordWatermark() {
+	watermarks := l.getWatermarks()
+	if len(watermarks) == 0 {
+		return
+	}
+	event := &metricsinfo.DDLEvent{
+		Type:              dmlWatermarkEvent,
+		ChannelTimestamps: make(map[string]uint64, len(watermarks)),
+	}
+	for channel, ts := range watermarks {
+		if ts == 0 {
+			continue
+		}
+		event.ChannelTimestamps[channel] = ts
+		if event.Timestamp == 0 || ts < event.Timestamp {
+			event.Timestamp = ts
+		}
+	}
+	if event.Timestamp == 0 {
+		return
+	}
+	value, err := json.Marshal(event)
+	if err != nil {
+		log.Warn("failed to marshal dml watermark", zap.Error(err))
+		return
+	}
+	if err := l.kv.Save(buildDDLEventKey(dmlWatermarkPrefix, event.Timestamp), string(value)); err != nil {
+		log.Warn("failed to record dml watermark", zap.Uint64("ts", event.Timestamp), zap.Error(err))
+	}
+}
+
+// expireWatermarks removes the dml watermarks out of retention.
+func (l *ddlEventLog) expireWatermarks() {
+	retention := time.Duration(Params.RootCoordCfg.DDLEventLogWatermarkRetention.GetAsInt64()) * time.Hour
+	expireTs := tsoutil.ComposeTSByTime(time.Now().Add(-retention), 0)
+	events, err := l.load(dmlWatermarkPrefix)
+	if err != nil {
+		log.Warn("failed to load dml watermarks", zap.Error(err))
+		return
+	}
+	keys := make([]string, 0)
+	for _, event := range events {
+		if event.Timestamp < expireTs {
+			keys = append(keys, buildDDLEventKey(dmlWatermarkPrefix, event.Timestamp))
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if err := l.kv.MultiRemove(keys); err != nil {
+		log.Warn("failed to remove expired dml watermarks", zap.Error(err))
+		return
+	}
+	log.Info("expired dml watermarks removed", zap.Int("num", len(keys)))
+}
+
+// compact replaces the ddl events out of retention with a snapshot of the meta rebuilt from them.
+// The snapshot is saved at the key of the last expired event before the others are removed,
+// so the meta rebuilt is the same if it fails in between.
+func (l *ddlEventLog) compact() {
+	retention := time.Duration(Params.RootCoordCfg.DDLEventLogRetention.GetAsInt64()) * time.Hour
+	expireTs := tsoutil.ComposeTSByTime(time.Now().Add(-retention), 0)
+	events, err := l.load(ddlEventPrefix)
+	if err != nil {
+		log.Warn("failed to load ddl events", zap.Error(err))
+		return
+	}
+	expired := make([]metricsinfo.DDLEvent, 0)
+	for _, event := range events {
+		if event.Timestamp < expireTs {
+			expired = append(expired, event)
+		}
+	}
+	if len(expired) == 0 || (len(expired) == 1 && expired[0].Type == ddlSnapshotEvent) {
+		return
+	}
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].Timestamp < expired[j].Timestamp
+	})
+
+	snapshotTs := expired[len(expired)-1].Timestamp
+	state := replayDDLEvents(expired, snapshotTs)
+	snapshot := &metricsinfo.DDLEvent{
+		Timestamp:   snapshotTs,
+		Type:        ddlSnapshotEvent,
+		Databases:   state.Databases,
+		Collections: state.Collections,
+	}
+	value, err := json.Marshal(snapshot)
+	if err != nil {
+		log.Warn("failed to marshal ddl snapshot", zap.Error(err))
+		return
+	}
+	if err := l.kv.Save(buildDDLEventKey(ddlEventPrefix, snapshotTs), string(value)); err != nil {
+		log.Warn("failed to save ddl snapshot", zap.Uint64("ts", snapshotTs), zap.Error(err))
+		return
+	}
+	keys := make([]string, 0, len(expired)-1)
+	for _, event := range expired[:len(expired)-1] {
+		keys = append(keys, buildDDLEventKey(ddlEventPrefix, event.Timestamp))
+	}
+	if len(keys) > 0 {
+		if err := l.kv.MultiRemove(keys); err != nil {
+			log.Warn("failed to remove compacted ddl events", zap.Error(err))
+			return
+		}
+	}
+	log.Info("ddl events out of retention compacted", zap.Int("num", len(expired)), zap.Uint64("snapshotTs", snapshotTs))
+}
+
+func (l *ddlEventLog) load(prefix string) ([]metricsinfo.DDLEvent, error) {
+	_, values, err := l.kv.LoadWithPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]metricsinfo.DDLEvent, 0, len(values))
+	for _, value := range values {
+		event := metricsinfo.DDLEvent{}
+		if err := json.Unmarshal([]byte(value), &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// list returns the ddl events and dml watermarks up to ts in order.
+func (l *ddlEventLog) list(ts Timestamp) ([]metricsinfo.DDLEvent, error) {
+	ddlEvents, err := l.load(ddlEventPrefix)
+	if err != nil {
+		return nil, err
+	}
+	watermarks, err := l.load(dmlWatermarkPrefix)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]metricsinfo.DDLEvent, 0, len(ddlEvents)+len(watermarks))
+	for _, event := range append(ddlEvents, watermarks...) {
+		if event.Timestamp <= ts {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp < events[j].Timestamp
+	})
+	return events, nil
+}
+
+// replayDDLEvents rebuilds the databases and collections as of ts from the ordered events.
+func replayDDLEvents(events []metricsinfo.DDLEvent, ts Timestamp) *metricsinfo.DDLEventLogInfos {
+	collectionKey := func(dbName, name string) string {
+		return dbName + "/" + name
+	}
+	dbs := map[string]struct{}{util.DefaultDBName: {}}
+	collections := make(map[string]*metricsinfo.DDLCollection)
+	aliases := make(map[string]string)
+	watermarks := make(map[string]uint64)
+
+	for _, event := range events {
+		if event.Timestamp > ts {
+			break
+		}
+		key := collectionKey(event.DBName, event.CollectionName)
+		switch event.Type {
+		case ddlSnapshotEvent:
+			dbs = make(map[string]struct{}, len(event.Databases))
+			for _, db := range event.Databases {
+				dbs[db] = struct{}{}
+			}
+			collections = make(map[string]*metricsinfo.DDLCollection, len(event.Collections))
+			aliases = make(map[string]string)
+			for i := range event.Collections {
+				coll := event.Collections[i]
+				coll.Properties = make(map[string]string, len(event.Collections[i].Properties))
+				for k, v := range event.Collections[i].Properties {
+					coll.Properties[k] = v
+				}
+				coll.Partitions = make(map[string]int64, len(event.Collections[i].Partitions))
+				for name, id := range event.Collections[i].Partitions {
+					coll.Partitions[name] = id
+				}
+				target := collectionKey(coll.DBName, coll.CollectionName)
+				for _, alias := range coll.Aliases {
+					aliases[collectionKey(coll.DBName, alias)] = target
+				}
+				coll.Aliases = nil
+				collections[target] = &coll
+			}
+		case commonpb.MsgType_CreateDatabase.String():
+			dbs[event.DBName] = struct{}{}
+		case commonpb.MsgType_DropDatabase.String():
+			delete(dbs, event.DBName)
+		case commonpb.MsgType_CreateCollection.String():
+			if _, ok := collections[key]; ok {
+				continue
+			}
+			coll := &metricsinfo.DDLCollection{
+				DBName:           event.DBName,
+				CollectionID:     event.CollectionID,
+				CollectionName:   event.CollectionName,
+				Schema:           event.Schema,
+				Properties:       make(map[string]string),
+				VirtualChannels:  event.VirtualChannels,
+				Partitions:       make(map[string]int64),
+				CreatedTimestamp: event.Timestamp,
+			}
+			for k, v := range event.Properties {
+				coll.Properties[k] = v
+			}
+			for name, id := range event.Partitions {
+				coll.Partitions[name] = id
+			}
+			collections[key] = coll
+		case commonpb.MsgType_DropCollection.String():
+			delete(collections, key)
+			for alias, target := range aliases {
+				if target == key {
+					delete(aliases, alias)
+				}
+			}
+		case commonpb.MsgType_AlterCollection.String():
+			if coll, ok := collections[key]; ok {
+				for k, v := range event.Properties {
+					coll.Properties[k] = v
+				}
+			}
+		case commonpb.MsgType_RenameCollection.String():
+			coll, ok := collections[key]
+			if !ok {
+				continue
+			}
+			newDBName := event.NewDBName
+			if newDBName == "" {
+				newDBName = event.DBName
+			}
+			newKey := collectionKey(newDBName, event.NewName)
+			coll.DBName = newDBName
+			coll.CollectionName = event.NewName
+			delete(collections, key)
+			collections[newKey] = coll
+			for alias, target := range aliases {
+				if target == key {
+					aliases[alias] = newKey
+				}
+			}
+		case commonpb.MsgType_CreatePartition.String():
+			if coll, ok := collections[key]; ok {
+				coll.Partitions[event.PartitionName] = event.PartitionID
+			}
+		case commonpb.MsgType_DropPartition.String():
+			if coll, ok := collections[key]; ok {
+				delete(coll.Partitions, event.PartitionName)
+			}
+		case commonpb.MsgType_CreateAlias.String(), commonpb.MsgType_AlterAlias.String():
+			aliases[collectionKey(event.DBName, event.Alias)] = key
+		case commonpb.MsgType_DropAlias.String():
+			delete(aliases, collectionKey(event.DBName, event.Alias))
+		case dmlWatermarkEvent:
+			for channel, channelTs := range event.ChannelTimestamps {
+				watermarks[channel] = channelTs
+			}
+		default:
+			log.Warn("unknown ddl event", zap.String("type", event.Type), zap.Uint64("ts", event.Timestamp))
+		}
+	}
+
+	for alias, target := range aliases {
+		if coll, ok := collections[target]; ok {
+			coll.Aliases = append(coll.Aliases, path.Base(alias))
+		}
+	}
+
+	infos := &metricsinfo.DDLEventLogInfos{
+		Timestamp:         ts,
+		Events:            events,
+		Databases:         make([]string, 0, len(dbs)),
+		Collections:       make([]metricsinfo.DDLCollection, 0, len(collections)),
+		ChannelTimestamps: watermarks,
+	}
+	for db := range dbs {
+		infos.Databases = append(infos.Databases, db)
+	}
+	sort.Strings(infos.Databases)
+	for _, coll := range collections {
+		sort.Strings(coll.Aliases)
+		infos.Collections = append(infos.Collections, *coll)
+	}
+	sort.Slice(infos.Collections, func(i, j int) bool {
+		return infos.Collections[i].CollectionID < infos.Collections[j].CollectionID
+	})
+	return infos
+}
+
+func getDDLEventDBName(dbName string) string {
+	if dbName == "" {
+		return util.DefaultDBName
+	}
+	return dbName
+}
@@ -0,0 +1,183 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func Test_ddlEventLog_appendAndList(t *testing.T) {
+	paramtable.Init()
+	watermarks := map[string]Timestamp{"dml_0": 105, "dml_1": 103}
+	l := newDDLEventLog(memkv.NewMemoryKV(), func() map[string]Timestamp {
+		return watermarks
+	})
+
+	l.write(&metricsinfo.DDLEvent{Timestamp: 100, Type: commonpb.MsgType_CreateCollection.String(), DBName: "default", CollectionName: "c1", CollectionID: 1})
+	l.write(&metricsinfo.DDLEvent{Timestamp: 110, Type: commonpb.MsgType_DropCollection.String(), DBName: "default", CollectionName: "c1"})
+	l.recordWatermark()
+
+	events, err := l.list(typeutil.MaxTimestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, commonpb.MsgType_CreateCollection.String(), events[0].Type)
+	// the watermark takes effect at the minimal timetick of channels
+	assert.Equal(t, dmlWatermarkEvent, events[1].Type)
+	assert.Equal(t, uint64(103), events[1].Timestamp)
+	assert.Equal(t, uint64(105), events[1].ChannelTimestamps["dml_0"])
+	assert.Equal(t, commonpb.MsgType_DropCollection.String(), events[2].Type)
+
+	events, err = l.list(105)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(events))
+
+	// the watermarks out of retention are removed
+	watermarks = map[string]Timestamp{"dml_0": tsoutil.ComposeTSByTime(time.Now(), 0)}
+	l.recordWatermark()
+	l.expireWatermarks()
+	events, err = l.list(typeutil.MaxTimestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, commonpb.MsgType_CreateCollection.String(), events[0].Type)
+	assert.Equal(t, commonpb.MsgType_DropCollection.String(), events[1].Type)
+	assert.Equal(t, dmlWatermarkEvent, events[2].Type)
+
+	// nothing is recorded without synced timeticks
+	watermarks = map[string]Timestamp{"dml_0": 0}
+	l.recordWatermark()
+	events, err = l.list(typeutil.MaxTimestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(events))
+
+	l.start()
+	l.close()
+	l.close()
+}
+
+func Test_ddlEventLog_asyncAppend(t *testing.T) {
+	paramtable.Init()
+	l := newDDLEventLog(memkv.NewMemoryKV(), func() map[string]Timestamp { return nil })
+	// the events appended before start are written once started
+	l.append(&metricsinfo.DDLEvent{Timestamp: 100, Type: commonpb.MsgType_CreateDatabase.String(), DBName: "db1"})
+	l.start()
+	l.append(&metricsinfo.DDLEvent{Timestamp: 101, Type: commonpb.MsgType_DropDatabase.String(), DBName: "db1"})
+	l.close()
+
+	events, err := l.list(typeutil.MaxTimestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(events))
+
+	// nothing is recorded after closed
+	l.append(&metricsinfo.DDLEvent{Timestamp: 102, Type: commonpb.MsgType_CreateDatabase.String(), DBName: "db2"})
+	events, err = l.list(typeutil.MaxTimestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(events))
+}
+
+func Test_ddlEventLog_compact(t *testing.T) {
+	paramtable.Init()
+	l := newDDLEventLog(memkv.NewMemoryKV(), func() map[string]Timestamp { return nil })
+	now := time.Now()
+	ts := func(d time.Duration) Timestamp {
+		return tsoutil.ComposeTSByTime(now.Add(d), 0)
+	}
+	l.write(&metricsinfo.DDLEvent{Timestamp: ts(-800 * time.Hour), Type: commonpb.MsgType_CreateDatabase.String(), DBName: "db1"})
+	l.write(&metricsinfo.DDLEvent{
+		Timestamp: ts(-799 * time.Hour), Type: commonpb.MsgType_CreateCollection.String(), DBName: "db1", CollectionName: "c1", CollectionID: 1,
+		Partitions: map[string]int64{"_default": 10},
+	})
+	l.write(&metricsinfo.DDLEvent{Timestamp: ts(-798 * time.Hour), Type: commonpb.MsgType_CreateAlias.String(), DBName: "db1", CollectionName: "c1", Alias: "a1"})
+	l.write(&metricsinfo.DDLEvent{Timestamp: ts(-time.Hour), Type: commonpb.MsgType_CreatePartition.String(), DBName: "db1", CollectionName: "c1", PartitionName: "p1", PartitionID: 11})
+	before := replayDDLEvents(lo.Must(l.list(typeutil.MaxTimestamp)), typeutil.MaxTimestamp)
+
+	l.compact()
+	events, err := l.list(typeutil.MaxTimestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, ddlSnapshotEvent, events[0].Type)
+	assert.Equal(t, ts(-798*time.Hour), events[0].Timestamp)
+
+	// the meta rebuilt is the same after compacted
+	after := replayDDLEvents(events, typeutil.MaxTimestamp)
+	assert.Equal(t, before.Databases, after.Databases)
+	assert.Equal(t, before.Collections, after.Collections)
+	assert.Equal(t, []string{"a1"}, after.Collections[0].Aliases)
+	assert.Equal(t, map[string]int64{"_default": 10, "p1": 11}, after.Collections[0].Partitions)
+
+	// the snapshot alone is not compacted again
+	l.compact()
+	events, err = l.list(typeutil.MaxTimestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(events))
+}
+
+func Test_replayDDLEvents(t *testing.T) {
+	events := []metricsinfo.DDLEvent{
+		{Timestamp: 100, Type: commonpb.MsgType_CreateDatabase.String(), DBName: "db1"},
+		{
+			Timestamp: 101, Type: commonpb.MsgType_CreateCollection.String(), DBName: "db1", CollectionName: "c1", CollectionID: 1,
+			Schema: []byte("schema"), Properties: map[string]string{"k1": "v1"}, Partitions: map[string]int64{"_default": 10},
+		},
+		{Timestamp: 102, Type: commonpb.MsgType_CreateCollection.String(), DBName: "default", CollectionName: "c2", CollectionID: 2},
+		{Timestamp: 103, Type: commonpb.MsgType_CreatePartition.String(), DBName: "db1", CollectionName: "c1", PartitionName: "p1", PartitionID: 11},
+		{Timestamp: 104, Type: commonpb.MsgType_CreateAlias.String(), DBName: "db1", CollectionName: "c1", Alias: "a1"},
+		{Timestamp: 105, Type: commonpb.MsgType_AlterCollection.String(), DBName: "db1", CollectionName: "c1", Properties: map[string]string{"k2": "v2"}},
+		{Timestamp: 106, Type: dmlWatermarkEvent, ChannelTimestamps: map[string]uint64{"dml_0": 106}},
+		{Timestamp: 107, Type: commonpb.MsgType_RenameCollection.String(), DBName: "db1", CollectionName: "c1", NewName: "c3"},
+		{Timestamp: 108, Type: commonpb.MsgType_DropPartition.String(), DBName: "db1", CollectionName: "c3", PartitionName: "p1"},
+		{Timestamp: 109, Type: commonpb.MsgType_DropCollection.String(), DBName: "default", CollectionName: "c2"},
+		{Timestamp: 110, Type: commonpb.MsgType_DropAlias.String(), DBName: "db1", Alias: "a1"},
+	}
+
+	infos := replayDDLEvents(events, 99)
+	assert.Equal(t, []string{"default"}, infos.Databases)
+	assert.Empty(t, infos.Collections)
+
+	infos = replayDDLEvents(events, 106)
+	assert.Equal(t, []string{"db1", "default"}, infos.Databases)
+	assert.Equal(t, 2, len(infos.Collections))
+	c1 := infos.Collections[0]
+	assert.Equal(t, "c1", c1.CollectionName)
+	assert.Equal(t, []byte("schema"), c1.Schema)
+	assert.Equal(t, uint64(101), c1.CreatedTimestamp)
+	assert.Equal(t, map[string]int64{"_default": 10, "p1": 11}, c1.Partitions)
+	assert.Equal(t, map[string]string{"k1": "v1", "k2": "v2"}, c1.Properties)
+	assert.Equal(t, []string{"a1"}, c1.Aliases)
+	assert.Equal(t, uint64(106), infos.ChannelTimestamps["dml_0"])
+
+	infos = replayDDLEvents(events, 109)
+	assert.Equal(t, 1, len(infos.Collections))
+	c3 := infos.Collections[0]
+	assert.Equal(t, "c3", c3.CollectionName)
+	assert.Equal(t, int64(1), c3.CollectionID)
+	assert.Equal(t, map[string]int64{"_default": 10}, c3.Partitions)
+	assert.Equal(t, []string{"a1"}, c3.Aliases)
+
+	infos = replayDDLEvents(events, typeutil.MaxTimestamp)
+	assert.Empty(t, infos.Collections[0].Aliases)
+}
@@ -43,6 +43,11 @@ type dmlMsgStream struct {
 	used   int64 // total used counter in current run, not stored in meta so meant to be inaccurate
 	idx    int64 // idx for name
 	pos    int   // position in the heap slice
+
+	// exclusive streams are owned by a single collection, they are not in the heap
+	exclusive bool
+	// dbID is the database which reserves the channel, 0 means the channel is shared, protected by dmlChannels.mut
+	dbID int64
 }
 
 // RefCnt returns refcnt with mutex protection.
@@ -221,25 +226,90 @@ func newDmlChannels(ctx context.Context, factory msgstream.Factory, chanNamePref
 }
 
 func (d *dmlChannels) getChannelNames(count int) []string {
+	return d.getChannelNamesOfDB(0, count)
+}
+
+// getChannelNamesOfDB returns the least used channels reserved by the database,
+// the shared channels are returned if the database reserves none.
+func (d *dmlChannels) getChannelNamesOfDB(dbID int64, count int) []string {
 	d.mut.Lock()
 	defer d.mut.Unlock()
 	if count > len(d.channelsHeap) {
 		return nil
 	}
-	// get next count items from heap
+	owner := int64(0)
+	for _, item := range d.channelsHeap {
+		if dbID != 0 && item.dbID == dbID {
+			owner = dbID
+			break
+		}
+	}
+
+	// pop items from heap until enough channels of owner got
 	items := make([]*dmlMsgStream, 0, count)
-	result := make([]string, 0, count)
-	for i := 0; i < count; i++ {
+	selected := make([]*dmlMsgStream, 0, count)
+	for len(selected) < count && len(d.channelsHeap) > 0 {
 		item := heap.Pop(&d.channelsHeap).(*dmlMsgStream)
-		item.BookUsage()
 		items = append(items, item)
-		result = append(result, getChannelName(d.namePrefix, item.idx))
+		if item.dbID == owner {
+			selected = append(selected, item)
+		}
 	}
-
 	for _, item := range items {
 		heap.Push(&d.channelsHeap, item)
 	}
+	if len(selected) < count {
+		return nil
+	}
 
+	result := make([]string, 0, count)
+	for _, item := range selected {
+		item.BookUsage()
+		result = append(result, getChannelName(d.namePrefix, item.idx))
+	}
+	return result
+}
+
+// reserveChannels reserves the shared channels for the database, the ones reserved before are released,
+// empty names means releasing all the channels of the database.
+func (d *dmlChannels) reserveChannels(dbID int64, names ...string) error {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	streams := make([]*dmlMsgStream, 0, len(names))
+	for _, name := range names {
+		dms, ok := d.pool.Get(name)
+		if !ok || dms.exclusive {
+			return fmt.Errorf("invalid dml channel to reserve: %s", name)
+		}
+		if dms.dbID != 0 && dms.dbID != dbID {
+			return fmt.Errorf("dml channel %s has been reserved by database %d", name, dms.dbID)
+		}
+		streams = append(streams, dms)
+	}
+
+	for _, dms := range d.channelsHeap {
+		if dms.dbID == dbID {
+			dms.dbID = 0
+		}
+	}
+	for _, dms := range streams {
+		dms.dbID = dbID
+	}
+	return nil
+}
+
+// listReservedChannels returns the channels reserved by databases, channel name => dbID.
+func (d *dmlChannels) listReservedChannels() map[string]int64 {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	result := make(map[string]int64)
+	for _, dms := range d.channelsHeap {
+		if dms.dbID != 0 {
+			result[getChannelName(d.namePrefix, dms.idx)] = dms.dbID
+		}
+	}
 	return result
 }
 
@@ -249,7 +319,7 @@ func (d *dmlChannels) listChannels() []string {
 	d.pool.Range(
 		func(channel string, dms *dmlMsgStream) bool {
 			if dms.RefCnt() > 0 {
-				chanNames = append(chanNames, getChannelName(d.namePrefix, dms.idx))
+				chanNames = append(chanNames, channel)
 			}
 			return true
 		})
@@ -324,6 +394,10 @@ func (d *dmlChannels) addChannels(names ...string) {
 			continue
 		}
 
+		if dms.exclusive {
+			dms.IncRefcnt()
+			continue
+		}
 		d.mut.Lock()
 		dms.IncRefcnt()
 		heap.Fix(&d.channelsHeap, dms.pos)
@@ -338,6 +412,10 @@ func (d *dmlChannels) removeChannels(names ...string) {
 			continue
 		}
 
+		if dms.exclusive {
+			dms.DecRefCnt()
+			continue
+		}
 		d.mut.Lock()
 		dms.DecRefCnt()
 		heap.Fix(&d.channelsHeap, dms.pos)
@@ -345,6 +423,51 @@ func (d *dmlChannels) removeChannels(names ...string) {
 	}
 }
 
+// addExclusiveChannels creates the msgstreams of the channels owned by a single collection,
+// the existing ones are skipped.
+func (d *dmlChannels) addExclusiveChannels(names ...string) error {
+	for _, name := range names {
+		if _, ok := d.pool.Get(name); ok {
+			continue
+		}
+		ms, err := d.factory.NewMsgStream(d.ctx)
+		if err != nil {
+			log.Warn("failed to create exclusive msgstream", zap.String("name", name), zap.Error(err))
+			return err
+		}
+		ms.AsProducer([]string{name})
+		dms := &dmlMsgStream{
+			ms:        ms,
+			idx:       -1,
+			pos:       -1,
+			exclusive: true,
+		}
+		if _, loaded := d.pool.GetOrInsert(name, dms); loaded {
+			ms.Close()
+			continue
+		}
+		metrics.RootCoordNumOfDMLChannel.Inc()
+		metrics.RootCoordNumOfMsgStream.Inc()
+	}
+	return nil
+}
+
+// removeExclusiveChannels closes and removes the msgstreams of the exclusive channels.
+func (d *dmlChannels) removeExclusiveChannels(names ...string) {
+	for _, name := range names {
+		dms, ok := d.pool.Get(name)
+		if !ok || !dms.exclusive {
+			continue
+		}
+		d.pool.Remove(name)
+		dms.mutex.Lock()
+		dms.ms.Close()
+		dms.mutex.Unlock()
+		metrics.RootCoordNumOfDMLChannel.Dec()
+		metrics.RootCoordNumOfMsgStream.Dec()
+	}
+}
+
 func getChannelName(prefix string, idx int64) string {
 	params := &paramtable.Get().CommonCfg
 	if params.PreCreatedTopicEnabled.GetAsBool() {
@@ -353,6 +476,16 @@ func getChannelName(prefix string, idx int64) string {
 	return fmt.Sprintf("%s_%d", prefix, idx)
 }
 
+// getExclusiveChannelName returns the name of the idx-th physical channel owned by the collection.
+func getExclusiveChannelName(prefix string, collectionID int64, idx int) string {
+	return fmt.Sprintf("%s_exclusive%d_%d", prefix, collectionID, idx)
+}
+
+// isExclusiveChannel returns whether the physical channel is owned by a single collection.
+func isExclusiveChannel(prefix string, channelName string) bool {
+	return strings.HasPrefix(channelName, prefix+"_exclusive")
+}
+
 func genChannelNames(prefix string, num int64) []string {
 	var results []string
 	for idx := int64(0); idx < num; idx++ {
@@ -410,8 +543,12 @@ func getNeedChanNum(setNum int, chanMap map[typeutil.UniqueID][]string) int {
 		}
 	} else {
 		maxChanUsed = setNum
+		prefix := paramtable.Get().CommonCfg.RootCoordDml.GetValue()
 		for _, chanNames := range chanMap {
 			for _, chanName := range chanNames {
+				if isExclusiveChannel(prefix, chanName) {
+					continue
+				}
 				index := parseChannelNameIndex(chanName)
 				if maxChanUsed < index+1 {
 					maxChanUsed = index + 1
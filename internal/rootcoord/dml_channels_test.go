@@ -168,6 +168,38 @@ func TestDmlChannels(t *testing.T) {
 	assert.Panics(t, func() { newDmlChannels(ctx, factory, dmlChanPrefix, totalDmlChannelNum) })
 }
 
+func TestDmlChannelsReservation(t *testing.T) {
+	const (
+		dmlChanPrefix      = "rootcoord-dml-reserve"
+		totalDmlChannelNum = 4
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := dependency.NewDefaultFactory(true)
+	dml := newDmlChannels(ctx, factory, dmlChanPrefix, totalDmlChannelNum)
+
+	reserved := []string{getChannelName(dmlChanPrefix, 0), getChannelName(dmlChanPrefix, 1)}
+	assert.NoError(t, dml.reserveChannels(1, reserved...))
+	assert.Error(t, dml.reserveChannels(2, reserved[0]))
+	assert.Error(t, dml.reserveChannels(2, "not-exist"))
+	assert.Equal(t, map[string]int64{reserved[0]: 1, reserved[1]: 1}, dml.listReservedChannels())
+
+	chans := dml.getChannelNamesOfDB(1, 2)
+	assert.ElementsMatch(t, reserved, chans)
+	assert.Nil(t, dml.getChannelNamesOfDB(1, 3))
+
+	// the other databases share the rest channels
+	chans = dml.getChannelNamesOfDB(2, 2)
+	assert.ElementsMatch(t, []string{getChannelName(dmlChanPrefix, 2), getChannelName(dmlChanPrefix, 3)}, chans)
+	assert.Nil(t, dml.getChannelNames(3))
+
+	// release the reservation
+	assert.NoError(t, dml.reserveChannels(1))
+	assert.Empty(t, dml.listReservedChannels())
+	assert.Len(t, dml.getChannelNames(4), 4)
+}
+
 func TestDmChannelsFailure(t *testing.T) {
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -21,6 +21,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 )
 
 type dropAliasTask struct {
@@ -42,3 +43,12 @@ func (t *dropAliasTask) Execute(ctx context.Context) error {
 	}
 	return t.core.meta.DropAlias(ctx, t.Req.GetDbName(), t.Req.GetAlias(), t.GetTs())
 }
+
+func (t *dropAliasTask) ddlEvent() *metricsinfo.DDLEvent {
+	return &metricsinfo.DDLEvent{
+		Timestamp: t.GetTs(),
+		Type:      commonpb.MsgType_DropAlias.String(),
+		DBName:    getDDLEventDBName(t.Req.GetDbName()),
+		Alias:     t.Req.GetAlias(),
+	}
+}
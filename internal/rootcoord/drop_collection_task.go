@@ -28,6 +28,7 @@ import (
 	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -107,6 +108,13 @@ func (t *dropCollectionTask) Execute(ctx context.Context) error {
 		pChannels: collMeta.PhysicalChannelNames,
 	})
 	redoTask.AddAsyncStep(newConfirmGCStep(t.core, collMeta.CollectionID, allPartition))
+	if isExclusiveChannels(collMeta.PhysicalChannelNames) {
+		// the topics could be deleted only after the data is garbage collected.
+		redoTask.AddAsyncStep(&deleteExclusiveTopicsStep{
+			baseStep:  baseStep{core: t.core},
+			pChannels: collMeta.PhysicalChannelNames,
+		})
+	}
 	redoTask.AddAsyncStep(&deleteCollectionMetaStep{
 		baseStep:     baseStep{core: t.core},
 		collectionID: collMeta.CollectionID,
@@ -118,3 +126,12 @@ func (t *dropCollectionTask) Execute(ctx context.Context) error {
 
 	return redoTask.Execute(ctx)
 }
+
+func (t *dropCollectionTask) ddlEvent() *metricsinfo.DDLEvent {
+	return &metricsinfo.DDLEvent{
+		Timestamp:      t.GetTs(),
+		Type:           commonpb.MsgType_DropCollection.String(),
+		DBName:         getDDLEventDBName(t.Req.GetDbName()),
+		CollectionName: t.Req.GetCollectionName(),
+	}
+}
@@ -19,7 +19,9 @@ package rootcoord
 import (
 	"context"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 )
 
 type dropDatabaseTask struct {
@@ -34,3 +36,11 @@ func (t *dropDatabaseTask) Prepare(ctx context.Context) error {
 func (t *dropDatabaseTask) Execute(ctx context.Context) error {
 	return t.core.meta.DropDatabase(ctx, t.Req.GetDbName(), t.GetTs())
 }
+
+func (t *dropDatabaseTask) ddlEvent() *metricsinfo.DDLEvent {
+	return &metricsinfo.DDLEvent{
+		Timestamp: t.GetTs(),
+		Type:      commonpb.MsgType_DropDatabase.String(),
+		DBName:    t.Req.GetDbName(),
+	}
+}
@@ -28,6 +28,7 @@ import (
 	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 )
 
 type dropPartitionTask struct {
@@ -107,3 +108,20 @@ func (t *dropPartitionTask) Execute(ctx context.Context) error {
 
 	return redoTask.Execute(ctx)
 }
+
+func (t *dropPartitionTask) ddlEvent() *metricsinfo.DDLEvent {
+	event := &metricsinfo.DDLEvent{
+		Timestamp:      t.GetTs(),
+		Type:           commonpb.MsgType_DropPartition.String(),
+		DBName:         getDDLEventDBName(t.Req.GetDbName()),
+		CollectionName: t.Req.GetCollectionName(),
+		CollectionID:   t.collMeta.CollectionID,
+		PartitionName:  t.Req.GetPartitionName(),
+	}
+	for _, partition := range t.collMeta.Partitions {
+		if partition.PartitionName == t.Req.GetPartitionName() {
+			event.PartitionID = partition.PartitionID
+		}
+	}
+	return event
+}
@@ -68,6 +68,12 @@ func (c *bgGarbageCollector) ReDropCollection(collMeta *model.Collection, ts Tim
 		pChannels: collMeta.PhysicalChannelNames,
 	})
 	redo.AddAsyncStep(newConfirmGCStep(c.s, collMeta.CollectionID, allPartition))
+	if isExclusiveChannels(collMeta.PhysicalChannelNames) {
+		redo.AddAsyncStep(&deleteExclusiveTopicsStep{
+			baseStep:  baseStep{core: c.s},
+			pChannels: collMeta.PhysicalChannelNames,
+		})
+	}
 	redo.AddAsyncStep(&deleteCollectionMetaStep{
 		baseStep:     baseStep{core: c.s},
 		collectionID: collMeta.CollectionID,
@@ -100,6 +106,12 @@ func (c *bgGarbageCollector) RemoveCreatingCollection(collMeta *model.Collection
 		baseStep:  baseStep{core: c.s},
 		pChannels: collMeta.PhysicalChannelNames,
 	})
+	if isExclusiveChannels(collMeta.PhysicalChannelNames) {
+		redo.AddAsyncStep(&deleteExclusiveTopicsStep{
+			baseStep:  baseStep{core: c.s},
+			pChannels: collMeta.PhysicalChannelNames,
+		})
+	}
 	redo.AddAsyncStep(&deleteCollectionMetaStep{
 		baseStep:     baseStep{core: c.s},
 		collectionID: collMeta.CollectionID,
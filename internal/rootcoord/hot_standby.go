@@ -0,0 +1,243 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/metastore"
+	kvmetestore "github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util"
+)
+
+// hotStandby keeps the meta table of the standby rootcoord synced with etcd,
+// so that the standby takes over without a full meta reload once it's activated.
+//
+// The changes of the rootcoord meta are watched, and the meta table is refreshed
+// in background after the changes are observed, at most once per sync interval.
+// The catalog is created once without the snapshot gc, the standby never writes the meta.
+type hotStandby struct {
+	etcdCli     *clientv3.Client
+	rootPath    string
+	watchPrefix string
+	catalog     metastore.RootCoordCatalog
+	interval    time.Duration
+
+	mu        sync.Mutex
+	meta      *MetaTable
+	syncedRev int64 // the etcd revision which the meta reflects
+	latestRev int64 // the latest revision of the changes observed
+	// pendingSince is the time when the oldest change not applied is observed, zero means synced.
+	pendingSince time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newHotStandby(ctx context.Context, etcdCli *clientv3.Client, rootPath string, catalog metastore.RootCoordCatalog, interval time.Duration) *hotStandby {
+	ctx, cancel := context.WithCancel(ctx)
+	return &hotStandby{
+		etcdCli: etcdCli,
+		// only the meta of rootcoord is watched, the snapshots are always saved along with it
+		rootPath:    rootPath,
+		watchPrefix: path.Join(rootPath, kvmetestore.ComponentPrefix) + "/",
+		catalog:     catalog,
+		interval:    interval,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// initHotStandby starts syncing the meta if the hot standby is enabled, only etcd meta store is supported.
+func (c *Core) initHotStandby() {
+	if !Params.RootCoordCfg.HotStandbyEnable.GetAsBool() {
+		return
+	}
+	if Params.MetaStoreCfg.MetaStoreType.GetValue() != util.MetaStoreTypeEtcd || c.etcdCli == nil {
+		log.Warn("hot standby only works with etcd meta store, skip it",
+			zap.String("metaStoreType", Params.MetaStoreCfg.MetaStoreType.GetValue()))
+		return
+	}
+	c.initKVCreator()
+	catalog, err := c.newCatalog(kvmetestore.WithoutBackgroundGC())
+	if err != nil {
+		log.Warn("hot standby failed to create the catalog, skip it", zap.Error(err))
+		return
+	}
+	c.hotStandby = newHotStandby(c.ctx, c.etcdCli, Params.EtcdCfg.MetaRootPath.GetValue(), catalog,
+		Params.RootCoordCfg.HotStandbySyncInterval.GetAsDuration(time.Millisecond))
+	c.hotStandby.start()
+	log.Info("hot standby started", zap.String("watchPrefix", c.hotStandby.watchPrefix))
+}
+
+func (s *hotStandby) start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+func (s *hotStandby) run() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	var watchCh clientv3.WatchChan
+	for {
+		if watchCh == nil {
+			if err := s.sync(); err != nil {
+				log.Warn("hot standby failed to load the meta", zap.Error(err))
+				select {
+				case <-s.ctx.Done():
+					return
+				case <-ticker.C:
+					continue
+				}
+			}
+			s.mu.Lock()
+			rev := s.syncedRev
+			s.mu.Unlock()
+			watchCh = s.etcdCli.Watch(s.ctx, s.watchPrefix, clientv3.WithPrefix(), clientv3.WithRev(rev+1))
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case resp, ok := <-watchCh:
+			if !ok || resp.Err() != nil {
+				if s.ctx.Err() != nil {
+					return
+				}
+				// the watch is broken or compacted, reload the meta and watch again.
+				log.Warn("hot standby watch broken, resync the meta", zap.Bool("closed", !ok), zap.Error(resp.Err()))
+				watchCh = nil
+				continue
+			}
+			s.observe(resp.Events)
+		case <-ticker.C:
+			if s.dirty() {
+				if err := s.sync(); err != nil {
+					log.Warn("hot standby failed to sync the meta", zap.Error(err))
+				}
+			}
+			s.updateLag()
+		}
+	}
+}
+
+// sync reloads the meta table, the revision is taken before loading,
+// so the meta reflects at least the changes up to it.
+func (s *hotStandby) sync() error {
+	resp, err := s.etcdCli.Get(s.ctx, s.watchPrefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	rev := resp.Header.GetRevision()
+
+	// without the tso allocator, the standby never writes the meta.
+	mt, err := NewMetaTable(s.ctx, s.catalog, nil)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.meta = mt
+	s.syncedRev = rev
+	if s.latestRev <= rev {
+		s.pendingSince = time.Time{}
+	}
+	log.Debug("hot standby synced the meta", zap.Int64("revision", rev))
+	return nil
+}
+
+func (s *hotStandby) observe(events []*clientv3.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ev := range events {
+		if s.ignored(string(ev.Kv.Key)) || ev.Kv.ModRevision <= s.latestRev {
+			continue
+		}
+		s.latestRev = ev.Kv.ModRevision
+		if s.latestRev > s.syncedRev && s.pendingSince.IsZero() {
+			s.pendingSince = time.Now()
+		}
+	}
+}
+
+// ignored returns true if the key doesn't belong to the meta table, e.g. the ddl event log.
+func (s *hotStandby) ignored(key string) bool {
+	return strings.HasPrefix(key, path.Join(s.rootPath, ddlEventLogPrefix))
+}
+
+func (s *hotStandby) dirty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latestRev > s.syncedRev
+}
+
+func (s *hotStandby) updateLag() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lag := float64(0)
+	if !s.pendingSince.IsZero() {
+		lag = float64(time.Since(s.pendingSince).Milliseconds())
+	}
+	metrics.RootCoordStandbySyncLag.Set(lag)
+}
+
+// takeover stops syncing and returns the synced meta table, nil if it's not usable.
+//
+// It's called after the previous active rootcoord is gone, so no more changes would be made,
+// the changes after the synced revision are checked to make sure none of them is missed.
+func (s *hotStandby) takeover(ctx context.Context) *MetaTable {
+	s.stop()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.meta == nil || s.latestRev > s.syncedRev {
+		log.Info("hot standby not synced", zap.Int64("syncedRev", s.syncedRev), zap.Int64("latestRev", s.latestRev))
+		return nil
+	}
+	resp, err := s.etcdCli.Get(ctx, s.watchPrefix, clientv3.WithPrefix(), clientv3.WithKeysOnly(), clientv3.WithMinModRev(s.syncedRev+1))
+	if err != nil {
+		log.Warn("hot standby failed to check the meta changes", zap.Error(err))
+		return nil
+	}
+	for _, kv := range resp.Kvs {
+		if !s.ignored(string(kv.Key)) {
+			log.Info("hot standby missed meta changes", zap.Int64("syncedRev", s.syncedRev), zap.String("key", string(kv.Key)))
+			return nil
+		}
+	}
+	log.Info("hot standby takes over the meta", zap.Int64("revision", s.syncedRev))
+	return s.meta
+}
+
+func (s *hotStandby) stop() {
+	s.cancel()
+	s.wg.Wait()
+	metrics.RootCoordStandbySyncLag.Set(0)
+}
@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	mocktso "github.com/milvus-io/milvus/internal/tso/mocks"
+	"github.com/milvus-io/milvus/pkg/util"
+)
+
+func newTestEvent(key string, rev int64) *clientv3.Event {
+	return &clientv3.Event{Kv: &mvccpb.KeyValue{Key: []byte(key), ModRevision: rev}}
+}
+
+func TestHotStandby_Observe(t *testing.T) {
+	s := newHotStandby(context.Background(), nil, "by-dev/meta", nil, time.Second)
+	s.syncedRev = 10
+	assert.Equal(t, "by-dev/meta/root-coord/", s.watchPrefix)
+
+	s.observe([]*clientv3.Event{newTestEvent(path.Join(s.rootPath, ddlEventPrefix, "1"), 11)})
+	assert.False(t, s.dirty())
+	assert.True(t, s.pendingSince.IsZero())
+
+	s.observe([]*clientv3.Event{newTestEvent(path.Join(s.rootPath, "root-coord/collection/1"), 9)})
+	assert.False(t, s.dirty())
+
+	s.observe([]*clientv3.Event{newTestEvent(path.Join(s.rootPath, "root-coord/collection/1"), 12)})
+	assert.True(t, s.dirty())
+	assert.False(t, s.pendingSince.IsZero())
+	s.updateLag()
+
+	s.stop()
+}
+
+func TestMetaTable_Activate(t *testing.T) {
+	t.Run("default db exists", func(t *testing.T) {
+		mt := &MetaTable{
+			dbName2Meta: map[string]*model.Database{util.DefaultDBName: model.NewDefaultDatabase()},
+			names:       newNameDb(),
+			aliases:     newNameDb(),
+		}
+		tso := mocktso.NewAllocator(t)
+		catalog := mocks.NewRootCoordCatalog(t)
+		assert.NoError(t, mt.activate(tso, catalog))
+		assert.Equal(t, tso, mt.tsoAllocator)
+		assert.Equal(t, catalog, mt.catalog)
+	})
+
+	t.Run("create default db", func(t *testing.T) {
+		catalog := mocks.NewRootCoordCatalog(t)
+		catalog.On("CreateDatabase", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		tso := mocktso.NewAllocator(t)
+		tso.On("GenerateTSO", mock.Anything).Return(uint64(1), nil)
+
+		mt := &MetaTable{
+			ctx:         context.Background(),
+			dbName2Meta: make(map[string]*model.Database),
+			names:       newNameDb(),
+			aliases:     newNameDb(),
+		}
+		assert.NoError(t, mt.activate(tso, catalog))
+		assert.Contains(t, mt.dbName2Meta, util.DefaultDBName)
+	})
+}
@@ -314,6 +314,14 @@ func (m *importManager) loadAndFlipPersistedTasks(ctx context.Context) error {
 				}
 			}
 		}
+		// Checking if ImportFlushed --> ImportCompleted ready.
+		if task.GetState().GetStateCode() == commonpb.ImportState_ImportFlushed {
+			if err = m.flipTaskVisibleState(ctx, task, importTasks); err != nil {
+				log.Error("failed to flip task visible state",
+					zap.Int64("task ID", task.GetId()),
+					zap.Error(err))
+			}
+		}
 	}
 	return nil
 }
@@ -336,6 +344,21 @@ func (m *importManager) flipTaskFlushedState(ctx context.Context, importTask *mi
 				zap.Int64("dataNode ID", dataNodeID),
 				zap.Int64("task ID", importTask.GetId()))
 		}()
+		// The segments are kept invisible until the whole job is flushed or the visible timestamp is reached.
+		if visibility, _, _ := importutil.ParseVisibilityFromOptions(importTask.GetInfos()); visibility != importutil.VisibleOnFile {
+			if err := m.setImportTaskState(importTask.GetId(), commonpb.ImportState_ImportFlushed); err != nil {
+				log.Error("failed to set import task state",
+					zap.Int64("task ID", importTask.GetId()),
+					zap.Any("target state", commonpb.ImportState_ImportFlushed),
+					zap.Error(err))
+				return err
+			}
+			if err = m.sendOutTasks(m.ctx); err != nil {
+				log.Error("fail to send out import task to DataNodes",
+					zap.Int64("task ID", importTask.GetId()))
+			}
+			return nil
+		}
 		// Unset isImporting flag.
 		if m.callUnsetIsImportingState == nil {
 			log.Error("callUnsetIsImportingState function of importManager is nil")
@@ -365,6 +388,86 @@ func (m *importManager) flipTaskFlushedState(ctx context.Context, importTask *mi
 	return nil
 }
 
+// flipTaskVisibleState unsets the isImporting flag of the segments of the `ImportFlushed` task and flips it to
+// `ImportCompleted` once its visibility condition is met:
+// (1) visibility `timestamp`, the visible timestamp is reached.
+// (2) visibility `job`, all the tasks of the job are flushed, then the segments of them are made visible at once,
+// and the task fails if any task of the job fails.
+func (m *importManager) flipTaskVisibleState(ctx context.Context, task *datapb.ImportTaskInfo, tasks []*datapb.ImportTaskInfo) error {
+	visibility, visibleTs, err := importutil.ParseVisibilityFromOptions(task.GetInfos())
+	if err != nil {
+		return m.setImportTaskStateAndReason(task.GetId(), commonpb.ImportState_ImportFailed, err.Error())
+	}
+
+	toComplete := []*datapb.ImportTaskInfo{task}
+	switch visibility {
+	case importutil.VisibleOnTimestamp:
+		if time.Now().Unix() < visibleTs {
+			return nil
+		}
+	case importutil.VisibleOnJob:
+		if task.GetJobId() != 0 {
+			toComplete = lo.Filter(tasks, func(t *datapb.ImportTaskInfo, _ int) bool {
+				return t.GetJobId() == task.GetJobId()
+			})
+		}
+		for _, t := range toComplete {
+			switch t.GetState().GetStateCode() {
+			case commonpb.ImportState_ImportFailed, commonpb.ImportState_ImportFailedAndCleaned:
+				return m.setImportTaskStateAndReason(task.GetId(), commonpb.ImportState_ImportFailed,
+					fmt.Sprintf("import task %d of the same job failed", t.GetId()))
+			case commonpb.ImportState_ImportFlushed:
+			default:
+				// wait for the other tasks of the job
+				return nil
+			}
+		}
+	}
+
+	segmentIDs := lo.FlatMap(toComplete, func(t *datapb.ImportTaskInfo, _ int) []int64 {
+		return t.GetState().GetSegments()
+	})
+	log.Info("import task flushed and becomes visible",
+		zap.Int64("task ID", task.GetId()),
+		zap.Int64("job ID", task.GetJobId()),
+		zap.String("visibility", visibility),
+		zap.Int64s("segment IDs", segmentIDs))
+	if m.callUnsetIsImportingState == nil {
+		log.Error("callUnsetIsImportingState function of importManager is nil")
+		return fmt.Errorf("failed to unset importing state: segment state method of import manager is nil")
+	}
+	// The segments of all the tasks are unset at once, so they are visible to the same query target.
+	status, err := m.callUnsetIsImportingState(ctx, &datapb.UnsetIsImportingStateRequest{
+		SegmentIds: segmentIDs,
+	})
+	if err = merr.CheckRPCCall(status, err); err != nil {
+		return err
+	}
+	for _, t := range toComplete {
+		if err := m.setImportTaskState(t.GetId(), commonpb.ImportState_ImportCompleted); err != nil {
+			return err
+		}
+		// the other tasks of the job loaded in this round are done
+		t.State.StateCode = commonpb.ImportState_ImportCompleted
+	}
+	return nil
+}
+
+// checkImportVisibility checks the visibility options of the import request,
+// the task waiting for the visible timestamp must not be removed before it completes.
+func checkImportVisibility(options []*commonpb.KeyValuePair) error {
+	visibility, visibleTs, err := importutil.ParseVisibilityFromOptions(options)
+	if err != nil {
+		return err
+	}
+	if visibility == importutil.VisibleOnTimestamp &&
+		float64(visibleTs-time.Now().Unix()) >= Params.RootCoordCfg.ImportTaskRetention.GetAsFloat() {
+		return merr.WrapErrParameterInvalidMsg("visible_ts should be within %s seconds from now",
+			Params.RootCoordCfg.ImportTaskRetention.GetValue())
+	}
+	return nil
+}
+
 // checkFlushDone checks if flush is done on given segments.
 func (m *importManager) checkFlushDone(ctx context.Context, segIDs []UniqueID) (bool, error) {
 	resp, err := m.callGetSegmentStates(ctx, &datapb.GetSegmentStatesRequest{
@@ -441,6 +544,11 @@ func (m *importManager) importJob(ctx context.Context, req *milvuspb.ImportReque
 		zap.String("collectionName", req.GetCollectionName()),
 		zap.Int64("collectionID", cID),
 		zap.Int64("partitionID", pID))
+	if err := checkImportVisibility(req.GetOptions()); err != nil {
+		return &milvuspb.ImportResponse{
+			Status: merr.Status(err),
+		}
+	}
 	err := func() error {
 		m.pendingLock.Lock()
 		defer m.pendingLock.Unlock()
@@ -469,14 +577,20 @@ func (m *importManager) importJob(ctx context.Context, req *milvuspb.ImportReque
 		if isSingleFileTask {
 			// For row-based importing, each file makes a task.
 			taskList := make([]int64, len(req.Files))
+			// All tasks share the ID of the first task as the job ID, by which the segments are made visible together.
+			var jobID int64
 			for i := 0; i < len(req.Files); i++ {
 				tID, _, err := m.idAllocator(1)
 				if err != nil {
 					log.Error("failed to allocate ID for import task", zap.Error(err))
 					return err
 				}
+				if i == 0 {
+					jobID = tID
+				}
 				newTask := &datapb.ImportTaskInfo{
 					Id:           tID,
+					JobId:        jobID,
 					CollectionId: cID,
 					PartitionId:  pID,
 					ChannelNames: req.ChannelNames,
@@ -516,6 +630,7 @@ func (m *importManager) importJob(ctx context.Context, req *milvuspb.ImportReque
 			}
 			newTask := &datapb.ImportTaskInfo{
 				Id:           tID,
+				JobId:        tID,
 				CollectionId: cID,
 				PartitionId:  pID,
 				ChannelNames: req.ChannelNames,
@@ -829,6 +944,7 @@ func (m *importManager) loadFromTaskStore(load2Mem bool) ([]*datapb.ImportTaskIn
 				// can be cleaned up in `removeBadImportSegmentsLoop`.
 				if ti.GetState().GetStateCode() != commonpb.ImportState_ImportFailed &&
 					ti.GetState().GetStateCode() != commonpb.ImportState_ImportFailedAndCleaned &&
+					ti.GetState().GetStateCode() != commonpb.ImportState_ImportFlushed &&
 					ti.GetState().GetStateCode() != commonpb.ImportState_ImportCompleted {
 					ti.State.StateCode = commonpb.ImportState_ImportFailed
 					if ti.GetState().GetErrorMessage() == "" {
@@ -856,6 +972,9 @@ func (m *importManager) loadFromTaskStore(load2Mem bool) ([]*datapb.ImportTaskIn
 // persistTaskInfo stores or updates the import task info in Etcd.
 func (m *importManager) persistTaskInfo(ti *datapb.ImportTaskInfo) error {
 	log.Info("updating import task info in Etcd", zap.Int64("task ID", ti.GetId()))
+	if importTaskFinished(ti) && ti.GetEndTs() == 0 {
+		ti.EndTs = time.Now().Unix()
+	}
 	var taskInfo []byte
 	var err error
 	if taskInfo, err = proto.Marshal(ti); err != nil {
@@ -897,7 +1016,9 @@ func (m *importManager) expireOldTasksFromMem() {
 		defer m.workingLock.Unlock()
 		for _, v := range m.workingTasks {
 			taskExpiredAndStateUpdated := false
-			if v.GetState().GetStateCode() != commonpb.ImportState_ImportCompleted && taskExpired(v) {
+			// the flushed tasks are waiting to be visible, which is not limited by the expiration
+			if v.GetState().GetStateCode() != commonpb.ImportState_ImportCompleted &&
+				v.GetState().GetStateCode() != commonpb.ImportState_ImportFlushed && taskExpired(v) {
 				log.Info("a working task has expired and will be marked as failed",
 					zap.Int64("task ID", v.GetId()),
 					zap.Int64("startTs", v.GetStartTs()),
@@ -959,6 +1080,59 @@ func (m *importManager) expireOldTasksFromEtcd() {
 	}
 }
 
+// importTaskFinished returns true if the task is completed or failed, which won't change any more.
+func importTaskFinished(ti *datapb.ImportTaskInfo) bool {
+	switch ti.GetState().GetStateCode() {
+	case commonpb.ImportState_ImportCompleted, commonpb.ImportState_ImportFailed, commonpb.ImportState_ImportFailedAndCleaned:
+		return true
+	default:
+		return false
+	}
+}
+
+// importTaskEndTime returns the time the task finished,
+// the tasks finished before the end time is recorded fall back to the time created.
+func importTaskEndTime(ti *datapb.ImportTaskInfo) time.Time {
+	if ti.GetEndTs() > 0 {
+		return time.Unix(ti.GetEndTs(), 0)
+	}
+	return time.Unix(ti.GetCreateTs(), 0)
+}
+
+// listHistory returns the finished tasks in Etcd which ended in [start, end), zero time means unbounded.
+func (m *importManager) listHistory(start, end time.Time) ([]*datapb.ImportTaskInfo, error) {
+	importTasks, err := m.loadFromTaskStore(false)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*datapb.ImportTaskInfo, 0)
+	for _, ti := range importTasks {
+		endTime := importTaskEndTime(ti)
+		if !importTaskFinished(ti) || endTime.Before(start) || (!end.IsZero() && !endTime.Before(end)) {
+			continue
+		}
+		tasks = append(tasks, ti)
+	}
+	return tasks, nil
+}
+
+// purgeHistory removes the finished tasks which ended before the time from Etcd ahead of `ImportTaskRetention`,
+// returns the tasks removed.
+func (m *importManager) purgeHistory(before time.Time) ([]*datapb.ImportTaskInfo, error) {
+	tasks, err := m.listHistory(time.Time{}, before)
+	if err != nil {
+		return nil, err
+	}
+	purged := make([]*datapb.ImportTaskInfo, 0, len(tasks))
+	for _, ti := range tasks {
+		if err := m.yieldTaskInfo(ti.GetId()); err != nil {
+			return purged, err
+		}
+		purged = append(purged, ti)
+	}
+	return purged, nil
+}
+
 // releaseHangingBusyDataNode checks if a busy DataNode has been 'busy' for an unexpected long time.
 // We will then remove these DataNodes from `busy list`.
 func (m *importManager) releaseHangingBusyDataNode() {
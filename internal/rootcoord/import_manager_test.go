@@ -19,6 +19,7 @@ package rootcoord
 import (
 	"context"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/golang/protobuf/proto"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -380,6 +382,43 @@ func TestImportManager_TestEtcdCleanUp(t *testing.T) {
 	mgr.sendOutTasksLoop(&wgLoop)
 }
 
+func TestImportManager_History(t *testing.T) {
+	paramtable.Get().Save(Params.RootCoordCfg.ImportTaskSubPath.Key, "test_import_task")
+	mockKv := memkv.NewMemoryKV()
+	now := time.Now().Unix()
+	for _, ti := range []*datapb.ImportTaskInfo{
+		{Id: 100, State: &datapb.ImportTaskState{StateCode: commonpb.ImportState_ImportCompleted}, CreateTs: now - 500, EndTs: now - 300},
+		{Id: 200, State: &datapb.ImportTaskState{StateCode: commonpb.ImportState_ImportFailed}, CreateTs: now - 100},
+		{Id: 300, State: &datapb.ImportTaskState{StateCode: commonpb.ImportState_ImportPending}, CreateTs: now - 500},
+		// created long ago but ended recently
+		{Id: 400, State: &datapb.ImportTaskState{StateCode: commonpb.ImportState_ImportCompleted}, CreateTs: now - 500, EndTs: now - 50},
+	} {
+		taskInfo, err := proto.Marshal(ti)
+		assert.NoError(t, err)
+		mockKv.Save(BuildImportTaskKey(ti.GetId()), string(taskInfo))
+	}
+	mgr := newImportManager(context.TODO(), mockKv, nil, nil, nil, nil, nil)
+
+	tasks, err := mgr.listHistory(time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 3)
+	tasks, err = mgr.listHistory(time.Unix(now-200, 0), time.Time{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{200, 400}, lo.Map(tasks, func(ti *datapb.ImportTaskInfo, _ int) int64 { return ti.GetId() }))
+
+	tasks, err = mgr.purgeHistory(time.Unix(now-200, 0))
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.EqualValues(t, 100, tasks[0].GetId())
+	keys, _, _ := mockKv.LoadWithPrefix("")
+	assert.Len(t, keys, 3)
+
+	// the time the task finished is recorded once persisted
+	ti := &datapb.ImportTaskInfo{Id: 500, State: &datapb.ImportTaskState{StateCode: commonpb.ImportState_ImportCompleted}, CreateTs: now - 500}
+	assert.NoError(t, mgr.persistTaskInfo(ti))
+	assert.GreaterOrEqual(t, ti.GetEndTs(), now)
+}
+
 func TestImportManager_TestFlipTaskStateLoop(t *testing.T) {
 	var countLock sync.RWMutex
 	globalCount := typeutil.UniqueID(0)
@@ -1132,3 +1171,79 @@ func TestImportManager_mergeArray(t *testing.T) {
 	res = converter(mergeArray(arr1, arr2))
 	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, res)
 }
+
+func TestImportManager_FlipTaskVisibleState(t *testing.T) {
+	paramtable.Get().Save(Params.RootCoordCfg.ImportTaskSubPath.Key, "test_import_task")
+	paramtable.Get().Save(Params.RootCoordCfg.ImportTaskRetention.Key, "200")
+	defer paramtable.Get().Reset(Params.RootCoordCfg.ImportTaskRetention.Key)
+
+	now := time.Now().Unix()
+	jobVisible := []*commonpb.KeyValuePair{{Key: importutil2.Visibility, Value: importutil2.VisibleOnJob}}
+	tsVisible := func(ts int64) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{
+			{Key: importutil2.Visibility, Value: importutil2.VisibleOnTimestamp},
+			{Key: importutil2.VisibleTs, Value: strconv.FormatInt(ts, 10)},
+		}
+	}
+	newTask := func(id, jobID int64, state commonpb.ImportState, infos []*commonpb.KeyValuePair) *datapb.ImportTaskInfo {
+		return &datapb.ImportTaskInfo{
+			Id:    id,
+			JobId: jobID,
+			State: &datapb.ImportTaskState{
+				StateCode: state,
+				Segments:  []int64{id * 10},
+			},
+			Infos:    infos,
+			CreateTs: now,
+		}
+	}
+	tasks := []*datapb.ImportTaskInfo{
+		newTask(1, 1, commonpb.ImportState_ImportFlushed, jobVisible),
+		newTask(2, 1, commonpb.ImportState_ImportPersisted, jobVisible),
+		newTask(3, 3, commonpb.ImportState_ImportFlushed, tsVisible(now+100)),
+		newTask(4, 4, commonpb.ImportState_ImportFlushed, tsVisible(now-1)),
+		newTask(5, 5, commonpb.ImportState_ImportFlushed, jobVisible),
+		newTask(6, 5, commonpb.ImportState_ImportFailed, jobVisible),
+		newTask(7, 7, commonpb.ImportState_ImportPersisted, nil),
+	}
+	mockKv := memkv.NewMemoryKV()
+	for _, task := range tasks {
+		value, err := proto.Marshal(task)
+		assert.NoError(t, err)
+		mockKv.Save(BuildImportTaskKey(task.GetId()), string(value))
+	}
+
+	callGetSegmentStates := func(ctx context.Context, req *datapb.GetSegmentStatesRequest) (*datapb.GetSegmentStatesResponse, error) {
+		return &datapb.GetSegmentStatesResponse{
+			Status: merr.Success(),
+		}, nil
+	}
+	var unset [][]int64
+	callUnsetIsImportingState := func(ctx context.Context, req *datapb.UnsetIsImportingStateRequest) (*commonpb.Status, error) {
+		segmentIDs := append([]int64{}, req.GetSegmentIds()...)
+		sort.Slice(segmentIDs, func(i, j int) bool { return segmentIDs[i] < segmentIDs[j] })
+		unset = append(unset, segmentIDs)
+		return merr.Success(), nil
+	}
+	mgr := newImportManager(context.TODO(), mockKv, nil, nil, callGetSegmentStates, nil, callUnsetIsImportingState)
+	assert.NoError(t, mgr.loadAndFlipPersistedTasks(context.TODO()))
+	assert.NoError(t, mgr.loadAndFlipPersistedTasks(context.TODO()))
+
+	expected := map[int64]commonpb.ImportState{
+		1: commonpb.ImportState_ImportCompleted,
+		2: commonpb.ImportState_ImportCompleted,
+		3: commonpb.ImportState_ImportFlushed,
+		4: commonpb.ImportState_ImportCompleted,
+		5: commonpb.ImportState_ImportFailed,
+		6: commonpb.ImportState_ImportFailed,
+		7: commonpb.ImportState_ImportCompleted,
+	}
+	for id, state := range expected {
+		assert.Equal(t, state, mgr.getTaskState(id).GetState(), "task %d", id)
+	}
+	assert.ElementsMatch(t, [][]int64{{10, 20}, {40}, {70}}, unset)
+
+	assert.NoError(t, checkImportVisibility(tsVisible(now+100)))
+	assert.Error(t, checkImportVisibility(tsVisible(now+1000)))
+	assert.Error(t, checkImportVisibility([]*commonpb.KeyValuePair{{Key: importutil2.Visibility, Value: "segment"}}))
+}
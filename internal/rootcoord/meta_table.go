@@ -49,6 +49,7 @@ type IMetaTable interface {
 	CreateDatabase(ctx context.Context, db *model.Database, ts typeutil.Timestamp) error
 	DropDatabase(ctx context.Context, dbName string, ts typeutil.Timestamp) error
 	ListDatabases(ctx context.Context, ts typeutil.Timestamp) ([]*model.Database, error)
+	AlterDatabase(ctx context.Context, oldDB *model.Database, newDB *model.Database, ts typeutil.Timestamp) error
 
 	AddCollection(ctx context.Context, coll *model.Collection) error
 	ChangeCollectionState(ctx context.Context, collectionID UniqueID, state pb.CollectionState, ts Timestamp) error
@@ -152,9 +153,11 @@ func (mt *MetaTable) reload() error {
 		mt.dbName2Meta[db.Name] = db
 	}
 	dbNames := maps.Keys(mt.dbName2Meta)
-	// create default database.
+	// create default database, the hot standby never writes the meta, it's created once activated.
 	if !funcutil.SliceContain(dbNames, util.DefaultDBName) {
-		if err := mt.createDefaultDb(); err != nil {
+		if mt.tsoAllocator == nil {
+			log.Info("default database not exist, skip creating it on the standby")
+		} else if err := mt.createDefaultDb(); err != nil {
 			return err
 		}
 	} else {
@@ -249,6 +252,19 @@ func (mt *MetaTable) createDefaultDb() error {
 	return mt.createDatabasePrivate(mt.ctx, model.NewDefaultDatabase(), ts)
 }
 
+// activate makes the meta table loaded by the hot standby writable.
+func (mt *MetaTable) activate(tsoAllocator tso.Allocator, catalog metastore.RootCoordCatalog) error {
+	mt.ddLock.Lock()
+	defer mt.ddLock.Unlock()
+
+	mt.tsoAllocator = tsoAllocator
+	mt.catalog = catalog
+	if _, ok := mt.dbName2Meta[util.DefaultDBName]; ok {
+		return nil
+	}
+	return mt.createDefaultDb()
+}
+
 func (mt *MetaTable) CreateDatabase(ctx context.Context, db *model.Database, ts typeutil.Timestamp) error {
 	mt.ddLock.Lock()
 	defer mt.ddLock.Unlock()
@@ -308,6 +324,25 @@ func (mt *MetaTable) DropDatabase(ctx context.Context, dbName string, ts typeuti
 	return nil
 }
 
+func (mt *MetaTable) AlterDatabase(ctx context.Context, oldDB *model.Database, newDB *model.Database, ts typeutil.Timestamp) error {
+	mt.ddLock.Lock()
+	defer mt.ddLock.Unlock()
+
+	if oldDB.Name != newDB.Name || oldDB.ID != newDB.ID {
+		return fmt.Errorf("alter database name or id is not supported")
+	}
+	if _, ok := mt.dbName2Meta[oldDB.Name]; !ok {
+		return merr.WrapErrDatabaseNotFound(oldDB.Name)
+	}
+
+	if err := mt.catalog.AlterDatabase(ctx, newDB, ts); err != nil {
+		return err
+	}
+	mt.dbName2Meta[newDB.Name] = newDB
+	log.Ctx(ctx).Info("alter database", zap.String("db", newDB.Name), zap.Any("properties", newDB.Properties), zap.Uint64("ts", ts))
+	return nil
+}
+
 func (mt *MetaTable) ListDatabases(ctx context.Context, ts typeutil.Timestamp) ([]*model.Database, error) {
 	mt.ddLock.RLock()
 	defer mt.ddLock.RUnlock()
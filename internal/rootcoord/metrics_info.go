@@ -18,10 +18,14 @@ package rootcoord
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/hardware"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -80,3 +84,126 @@ func (c *Core) getSystemInfoMetrics(ctx context.Context, req *milvuspb.GetMetric
 		ComponentName: metricsinfo.ConstructComponentName(typeutil.RootCoordRole, c.session.ServerID),
 	}, nil
 }
+
+// getDDLEventLogMetrics returns the ddl events and dml watermarks up to the timestamp of request,
+// with the databases and collections rebuilt as of it.
+func (c *Core) getDDLEventLogMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+	resp := &milvuspb.GetMetricsResponse{
+		Status:        merr.Success(),
+		ComponentName: metricsinfo.ConstructComponentName(typeutil.RootCoordRole, c.session.ServerID),
+	}
+	if c.ddlEventLog == nil {
+		resp.Status = merr.Status(merr.WrapErrServiceUnavailable("ddl event log is not enabled"))
+		return resp, nil
+	}
+
+	params := struct {
+		Timestamp uint64 `json:"timestamp"`
+	}{}
+	if err := json.Unmarshal([]byte(req.GetRequest()), &params); err != nil {
+		resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("invalid %s: %s", metricsinfo.MetricTimestampKey, err.Error()))
+		return resp, nil
+	}
+	ts := params.Timestamp
+	if ts == 0 {
+		ts = typeutil.MaxTimestamp
+	}
+
+	events, err := c.ddlEventLog.list(ts)
+	if err != nil {
+		log.Warn("failed to list ddl events", zap.Uint64("ts", ts), zap.Error(err))
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+	resp.Response, err = metricsinfo.MarshalComponentInfos(replayDDLEvents(events, ts))
+	if err != nil {
+		resp.Status = merr.Status(err)
+	}
+	return resp, nil
+}
+
+func (c *Core) getTimeTickWatermarkMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+	resp := &milvuspb.GetMetricsResponse{
+		Status:        merr.Success(),
+		ComponentName: metricsinfo.ConstructComponentName(typeutil.RootCoordRole, c.session.ServerID),
+	}
+	vchannels := make([]string, 0)
+	for collectionID := range c.meta.ListCollectionPhysicalChannels() {
+		vchannels = append(vchannels, c.meta.GetCollectionVirtualChannels(collectionID)...)
+	}
+	var err error
+	resp.Response, err = metricsinfo.MarshalComponentInfos(c.chanTimeTick.getWatermarks(vchannels))
+	if err != nil {
+		resp.Status = merr.Status(err)
+	}
+	return resp, nil
+}
+
+// getTaskHistoryMetrics lists the finished import tasks in the time range, or purges the ones which ended before the end time.
+func (c *Core) getTaskHistoryMetrics(ctx context.Context, metricType string, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+	resp := &milvuspb.GetMetricsResponse{
+		Status:        merr.Success(),
+		ComponentName: metricsinfo.ConstructComponentName(typeutil.RootCoordRole, c.session.ServerID),
+	}
+	params := struct {
+		Kind  string `json:"kind"`
+		Start int64  `json:"start"`
+		End   int64  `json:"end"`
+	}{}
+	if err := json.Unmarshal([]byte(req.GetRequest()), &params); err != nil {
+		resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("invalid request: %s", err.Error()))
+		return resp, nil
+	}
+	if params.Kind != metricsinfo.TaskKindImport {
+		resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("invalid task kind: %s, should be %s", params.Kind, metricsinfo.TaskKindImport))
+		return resp, nil
+	}
+	var start, end time.Time
+	if params.Start > 0 {
+		start = time.Unix(params.Start, 0)
+	}
+	if params.End > 0 {
+		end = time.Unix(params.End, 0)
+	}
+
+	var tasks []*datapb.ImportTaskInfo
+	var err error
+	if metricType == metricsinfo.PurgeTaskHistoryMetrics {
+		if end.IsZero() {
+			resp.Status = merr.Status(merr.WrapErrParameterInvalidMsg("%s is required to purge", metricsinfo.MetricEndTimeKey))
+			return resp, nil
+		}
+		tasks, err = c.importManager.purgeHistory(end)
+	} else {
+		tasks, err = c.importManager.listHistory(start, end)
+	}
+	if err != nil {
+		log.Warn("failed to get import task history", zap.String("metricType", metricType), zap.Error(err))
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+
+	infos := &metricsinfo.TaskHistoryInfos{
+		Retention: Params.RootCoordCfg.ImportTaskRetention.GetAsInt64(),
+		Tasks:     make([]metricsinfo.TaskHistory, 0, len(tasks)),
+	}
+	for _, ti := range tasks {
+		infos.Tasks = append(infos.Tasks, metricsinfo.TaskHistory{
+			Kind:         metricsinfo.TaskKindImport,
+			TaskID:       ti.GetId(),
+			CollectionID: ti.GetCollectionId(),
+			State:        ti.GetState().GetStateCode().String(),
+			StartTime:    ti.GetCreateTs(),
+			EndTime:      importTaskEndTime(ti).Unix(),
+			Reason:       ti.GetState().GetErrorMessage(),
+		})
+	}
+	sort.Slice(infos.Tasks, func(i, j int) bool {
+		return infos.Tasks[i].TaskID < infos.Tasks[j].TaskID
+	})
+	resp.Response, err = metricsinfo.MarshalComponentInfos(infos)
+	if err != nil {
+		resp.Status = merr.Status(err)
+	}
+	return resp, nil
+}
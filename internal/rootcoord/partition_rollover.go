@@ -0,0 +1,289 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/kv"
+	kvmetestore "github.com/milvus-io/milvus/internal/metastore/kv/rootcoord"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	"github.com/milvus-io/milvus/internal/util/timepartition"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// partitionArchivedPrefix is the prefix of the partitions archived, keyed by their partition IDs,
+// so they're not released again after rootcoord restarts.
+const partitionArchivedPrefix = kvmetestore.ComponentPrefix + "/partition-rollover/archived"
+
+// partitionRollover manages the partitions of the time-partitioned collections,
+// see common.CollectionPartitionRolloverKey.
+//
+// It creates the partitions of the current period and the coming ones ahead, so the inserts routed by
+// the proxies always find their partition, and drops or archives the partitions past retention.
+// The partitions are created and dropped by the ddl tasks as the users do, so they're recorded and
+// broadcast as usual. The partition of the current period is also created once the collection is
+// created or altered to be time-partitioned, see prepare.
+type partitionRollover struct {
+	meta   IMetaTable
+	broker Broker
+	kv     kv.BaseKV
+
+	createPartition func(ctx context.Context, req *milvuspb.CreatePartitionRequest) (*commonpb.Status, error)
+	dropPartition   func(ctx context.Context, req *milvuspb.DropPartitionRequest) (*commonpb.Status, error)
+
+	// archived are the partitions archived by now, which are not released again,
+	// they're loaded from kv on the first check
+	archived       typeutil.UniqueSet
+	archivedLoaded bool
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newPartitionRollover(c *Core, kv kv.BaseKV) *partitionRollover {
+	return &partitionRollover{
+		meta:            c.meta,
+		broker:          c.broker,
+		kv:              kv,
+		createPartition: c.CreatePartition,
+		dropPartition:   c.DropPartition,
+		archived:        typeutil.NewUniqueSet(),
+		closeCh:         make(chan struct{}),
+	}
+}
+
+func (r *partitionRollover) start() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(Params.RootCoordCfg.PartitionRolloverCheckInterval.GetAsDuration(time.Second))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.closeCh:
+				log.Info("partition rollover quit")
+				return
+			case <-ticker.C:
+				r.check(context.Background(), time.Now())
+			}
+		}
+	}()
+}
+
+func (r *partitionRollover) close() {
+	r.closeOnce.Do(func() {
+		close(r.closeCh)
+		r.wg.Wait()
+	})
+}
+
+// prepare creates the partition of the current period of the collection if the properties requested
+// make it time-partitioned, so the writes routed to it right after the collection is created or altered succeed.
+func (r *partitionRollover) prepare(ctx context.Context, dbName string, collectionName string, properties []*commonpb.KeyValuePair) error {
+	if policy, err := timepartition.Parse(properties); err != nil || policy == nil {
+		return err
+	}
+	collection, err := r.meta.GetCollectionByName(ctx, dbName, collectionName, typeutil.MaxTimestamp)
+	if err != nil {
+		return err
+	}
+	policy, err := timepartition.Parse(collection.Properties)
+	if err != nil || policy == nil {
+		return err
+	}
+	return r.createPartitions(ctx, dbName, collection, policy, time.Now())
+}
+
+// check rolls over the partitions of all the time-partitioned collections as of now.
+func (r *partitionRollover) check(ctx context.Context, now time.Time) {
+	if !r.archivedLoaded {
+		if err := r.loadArchived(); err != nil {
+			log.Warn("failed to load archived partitions for partition rollover", zap.Error(err))
+			return
+		}
+		r.archivedLoaded = true
+	}
+
+	dbs, err := r.meta.ListDatabases(ctx, typeutil.MaxTimestamp)
+	if err != nil {
+		log.Warn("failed to list databases for partition rollover", zap.Error(err))
+		return
+	}
+	// the partitions alive, the archived ones not found anymore are forgotten
+	partitions := typeutil.NewUniqueSet()
+	listed := true
+	for _, db := range dbs {
+		collections, err := r.meta.ListCollections(ctx, db.Name, typeutil.MaxTimestamp, true)
+		if err != nil {
+			log.Warn("failed to list collections for partition rollover", zap.String("db", db.Name), zap.Error(err))
+			listed = false
+			continue
+		}
+		for _, collection := range collections {
+			for _, partition := range collection.Partitions {
+				partitions.Insert(partition.PartitionID)
+			}
+			policy, err := timepartition.Parse(collection.Properties)
+			if err != nil {
+				log.Warn("invalid partition rollover policy", zap.String("db", db.Name),
+					zap.String("collection", collection.Name), zap.Error(err))
+				continue
+			}
+			if policy == nil {
+				continue
+			}
+			r.rollover(ctx, db.Name, collection, policy, now)
+		}
+	}
+	if listed {
+		r.forgetArchived(partitions)
+	}
+}
+
+// rollover creates the partitions of the current and the coming periods of the collection,
+// and expires the partitions past retention.
+func (r *partitionRollover) rollover(ctx context.Context, dbName string, collection *model.Collection, policy *timepartition.Policy, now time.Time) {
+	log := log.Ctx(ctx).With(zap.String("db", dbName), zap.String("collection", collection.Name),
+		zap.Int64("collectionID", collection.CollectionID))
+
+	if err := r.createPartitions(ctx, dbName, collection, policy, now); err != nil {
+		log.Warn("failed to create partition of current period", zap.Error(err))
+	}
+
+	for _, partition := range collection.Partitions {
+		if !partition.Available() || !policy.Expired(partition.PartitionName, now) {
+			continue
+		}
+		if policy.Expiry == timepartition.ExpiryArchive && r.archived.Contain(partition.PartitionID) {
+			continue
+		}
+		// the partitions must be released before dropped
+		if err := r.broker.ReleasePartitions(ctx, collection.CollectionID, partition.PartitionID); err != nil {
+			log.Warn("failed to release expired partition", zap.String("partition", partition.PartitionName), zap.Error(err))
+			continue
+		}
+		if policy.Expiry == timepartition.ExpiryArchive {
+			if err := r.archive(collection.CollectionID, partition.PartitionID); err != nil {
+				log.Warn("failed to save archived partition", zap.String("partition", partition.PartitionName), zap.Error(err))
+				continue
+			}
+			log.Info("expired partition archived", zap.String("partition", partition.PartitionName))
+			continue
+		}
+		status, err := r.dropPartition(ctx, &milvuspb.DropPartitionRequest{
+			Base:           commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_DropPartition)),
+			DbName:         dbName,
+			CollectionName: collection.Name,
+			PartitionName:  partition.PartitionName,
+		})
+		if err = merr.CheckRPCCall(status, err); err != nil {
+			log.Warn("failed to drop expired partition", zap.String("partition", partition.PartitionName), zap.Error(err))
+			continue
+		}
+		log.Info("expired partition dropped", zap.String("partition", partition.PartitionName))
+	}
+}
+
+// createPartitions creates the partitions of the current and the coming periods of the collection,
+// only the failure of the current one is returned, the coming ones are retried by the next check.
+func (r *partitionRollover) createPartitions(ctx context.Context, dbName string, collection *model.Collection, policy *timepartition.Policy, now time.Time) error {
+	log := log.Ctx(ctx).With(zap.String("db", dbName), zap.String("collection", collection.Name),
+		zap.Int64("collectionID", collection.CollectionID))
+
+	existing := typeutil.NewSet[string]()
+	for _, partition := range collection.Partitions {
+		existing.Insert(partition.PartitionName)
+	}
+	precreate := Params.RootCoordCfg.PartitionRolloverPrecreate.GetAsInt()
+	for i := 0; i <= precreate; i++ {
+		name := policy.PartitionName(now.Add(time.Duration(i) * policy.Period()))
+		if existing.Contain(name) {
+			continue
+		}
+		status, err := r.createPartition(ctx, &milvuspb.CreatePartitionRequest{
+			Base:           commonpbutil.NewMsgBase(commonpbutil.WithMsgType(commonpb.MsgType_CreatePartition)),
+			DbName:         dbName,
+			CollectionName: collection.Name,
+			PartitionName:  name,
+		})
+		if err = merr.CheckRPCCall(status, err); err != nil {
+			if i == 0 {
+				return fmt.Errorf("failed to create partition %s: %w", name, err)
+			}
+			log.Warn("failed to create partition for rollover", zap.String("partition", name), zap.Error(err))
+			continue
+		}
+		log.Info("partition rolled over", zap.String("partition", name))
+	}
+	return nil
+}
+
+func (r *partitionRollover) loadArchived() error {
+	keys, _, err := r.kv.LoadWithPrefix(partitionArchivedPrefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		partitionID, err := strconv.ParseInt(path.Base(key), 10, 64)
+		if err != nil {
+			log.Warn("invalid archived partition key", zap.String("key", key))
+			continue
+		}
+		r.archived.Insert(partitionID)
+	}
+	return nil
+}
+
+func (r *partitionRollover) archive(collectionID, partitionID int64) error {
+	key := fmt.Sprintf("%s/%d", partitionArchivedPrefix, partitionID)
+	if err := r.kv.Save(key, strconv.FormatInt(collectionID, 10)); err != nil {
+		return err
+	}
+	r.archived.Insert(partitionID)
+	return nil
+}
+
+// forgetArchived removes the archived partitions not alive anymore, e.g. dropped with their collections.
+func (r *partitionRollover) forgetArchived(alive typeutil.UniqueSet) {
+	partitionIDs := r.archived.Complement(alive).Collect()
+	if len(partitionIDs) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(partitionIDs))
+	for _, partitionID := range partitionIDs {
+		keys = append(keys, fmt.Sprintf("%s/%d", partitionArchivedPrefix, partitionID))
+	}
+	if err := r.kv.MultiRemove(keys); err != nil {
+		log.Warn("failed to remove archived partitions", zap.Error(err))
+		return
+	}
+	r.archived.Remove(partitionIDs...)
+}
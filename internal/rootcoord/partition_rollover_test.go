@@ -0,0 +1,151 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/metastore/model"
+	pb "github.com/milvus-io/milvus/internal/proto/etcdpb"
+	mockrootcoord "github.com/milvus-io/milvus/internal/rootcoord/mocks"
+	"github.com/milvus-io/milvus/internal/util/timepartition"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestPartitionRollover(t *testing.T) {
+	paramtable.Init()
+	now := time.Date(2024, 1, 3, 15, 0, 0, 0, time.UTC)
+
+	newCollection := func(name string, expiry string) *model.Collection {
+		return &model.Collection{
+			CollectionID: int64(len(name)),
+			Name:         name,
+			Properties: []*commonpb.KeyValuePair{
+				{Key: common.CollectionPartitionRolloverKey, Value: timepartition.RolloverDaily},
+				{Key: common.CollectionPartitionRetentionKey, Value: "86400"},
+				{Key: common.CollectionPartitionExpiryKey, Value: expiry},
+			},
+			Partitions: []*model.Partition{
+				{PartitionID: 1, PartitionName: "_default", State: pb.PartitionState_PartitionCreated},
+				{PartitionID: 2, PartitionName: "p_20240101", State: pb.PartitionState_PartitionCreated},
+				{PartitionID: 3, PartitionName: "p_20240102", State: pb.PartitionState_PartitionCreated},
+				{PartitionID: 4, PartitionName: "p_20240103", State: pb.PartitionState_PartitionCreated},
+			},
+		}
+	}
+	meta := mockrootcoord.NewIMetaTable(t)
+	meta.EXPECT().ListDatabases(mock.Anything, mock.Anything).Return([]*model.Database{{Name: "default"}}, nil)
+	meta.EXPECT().ListCollections(mock.Anything, "default", mock.Anything, true).Return([]*model.Collection{
+		newCollection("dropped", timepartition.ExpiryDrop),
+		newCollection("archived", timepartition.ExpiryArchive),
+		{CollectionID: 100, Name: "plain"},
+	}, nil)
+
+	var released []int64
+	broker := newMockBroker()
+	broker.ReleasePartitionsFunc = func(ctx context.Context, collectionID UniqueID, partitionIDs ...UniqueID) error {
+		released = append(released, collectionID)
+		return nil
+	}
+	var created, dropped []string
+	kv := memkv.NewMemoryKV()
+	newRollover := func() *partitionRollover {
+		return &partitionRollover{
+			meta:   meta,
+			broker: broker,
+			kv:     kv,
+			createPartition: func(ctx context.Context, req *milvuspb.CreatePartitionRequest) (*commonpb.Status, error) {
+				created = append(created, req.GetCollectionName()+"/"+req.GetPartitionName())
+				return merr.Success(), nil
+			},
+			dropPartition: func(ctx context.Context, req *milvuspb.DropPartitionRequest) (*commonpb.Status, error) {
+				dropped = append(dropped, req.GetCollectionName()+"/"+req.GetPartitionName())
+				return merr.Success(), nil
+			},
+			archived: typeutil.NewUniqueSet(),
+		}
+	}
+	r := newRollover()
+
+	r.check(context.Background(), now)
+	assert.ElementsMatch(t, []string{"dropped/p_20240104", "archived/p_20240104"}, created)
+	assert.Equal(t, []string{"dropped/p_20240101"}, dropped)
+	assert.ElementsMatch(t, []int64{int64(len("dropped")), int64(len("archived"))}, released)
+
+	// the archived partitions are not released again, even after restarted
+	released = nil
+	r.check(context.Background(), now)
+	assert.Equal(t, []int64{int64(len("dropped"))}, released)
+	released = nil
+	newRollover().check(context.Background(), now)
+	assert.Equal(t, []int64{int64(len("dropped"))}, released)
+}
+
+func TestPartitionRolloverForgetArchived(t *testing.T) {
+	r := &partitionRollover{kv: memkv.NewMemoryKV(), archived: typeutil.NewUniqueSet()}
+	assert.NoError(t, r.archive(1, 10))
+	assert.NoError(t, r.archive(1, 11))
+
+	r.forgetArchived(typeutil.NewUniqueSet(11))
+	r.archived = typeutil.NewUniqueSet()
+	assert.NoError(t, r.loadArchived())
+	assert.Equal(t, []int64{11}, r.archived.Collect())
+}
+
+func TestPartitionRolloverPrepare(t *testing.T) {
+	paramtable.Init()
+	properties := []*commonpb.KeyValuePair{{Key: common.CollectionPartitionRolloverKey, Value: timepartition.RolloverDaily}}
+	meta := mockrootcoord.NewIMetaTable(t)
+	meta.EXPECT().GetCollectionByName(mock.Anything, "default", "events", mock.Anything).Return(&model.Collection{
+		Name:       "events",
+		Properties: properties,
+		Partitions: []*model.Partition{{PartitionID: 1, PartitionName: "_default"}},
+	}, nil)
+
+	var (
+		created   []string
+		createErr error
+	)
+	r := &partitionRollover{
+		meta: meta,
+		createPartition: func(ctx context.Context, req *milvuspb.CreatePartitionRequest) (*commonpb.Status, error) {
+			created = append(created, req.GetPartitionName())
+			return merr.Status(createErr), nil
+		},
+	}
+
+	// not time-partitioned by the request
+	assert.NoError(t, r.prepare(context.Background(), "default", "events", nil))
+	assert.Empty(t, created)
+
+	assert.NoError(t, r.prepare(context.Background(), "default", "events", properties))
+	assert.Contains(t, created, (&timepartition.Policy{Rollover: timepartition.RolloverDaily}).PartitionName(time.Now()))
+
+	createErr = merr.WrapErrServiceInternal("mock")
+	assert.Error(t, r.prepare(context.Background(), "default", "events", properties))
+}
@@ -30,6 +30,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/milvus-io/milvus/internal/util/eventbus"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -167,6 +168,11 @@ func (p *proxyManager) handleDeleteEvent(e *clientv3.Event) error {
 		return err
 	}
 	log.Debug("received proxy delete event with session", zap.Any("session", session))
+	eventbus.Publish(eventbus.NodeDown, 0, map[string]any{
+		"role":    typeutil.ProxyRole,
+		"node_id": session.ServerID,
+		"address": session.Address,
+	})
 	for _, f := range p.delSessionsFunc {
 		f(session)
 	}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/proxypb"
 	"github.com/milvus-io/milvus/internal/tso"
 	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/eventbus"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/metrics"
@@ -101,10 +103,15 @@ type QuotaCenter struct {
 
 	readableCollections []int64
 	writableCollections []int64
+	resourceGroups      []metricsinfo.ResourceGroupUsage
 
 	currentRates map[int64]collectionRates
+	// the bursts of the collection-level insert rate limiters in bytes, 0 means the burst is the same as the rate
+	insertBursts map[int64]float64
 	quotaStates  map[int64]collectionStates
-	tsoAllocator tso.Allocator
+	// quota states of the last round, to publish the newly triggered states only
+	lastQuotaStates map[int64]collectionStates
+	tsoAllocator    tso.Allocator
 
 	rateAllocateStrategy RateAllocateStrategy
 
@@ -119,7 +126,9 @@ func NewQuotaCenter(proxies *proxyClientManager, queryCoord types.QueryCoordClie
 		queryCoord:          queryCoord,
 		dataCoord:           dataCoord,
 		currentRates:        make(map[int64]map[internalpb.RateType]Limit),
+		insertBursts:        make(map[int64]float64),
 		quotaStates:         make(map[int64]map[milvuspb.QuotaState]commonpb.ErrorCode),
+		lastQuotaStates:     make(map[int64]map[milvuspb.QuotaState]commonpb.ErrorCode),
 		tsoAllocator:        tsoAllocator,
 		meta:                meta,
 		readableCollections: make([]int64, 0),
@@ -157,6 +166,7 @@ func (q *QuotaCenter) run() {
 				log.Warn("quotaCenter setRates failed", zap.Error(err))
 			}
 			q.recordMetrics()
+			q.publishQuotaEvents()
 		}
 	}
 }
@@ -210,6 +220,7 @@ func (q *QuotaCenter) syncMetrics() error {
 			}
 		}
 		q.readableCollections = collections.Collect()
+		q.resourceGroups = queryCoordTopology.Cluster.ResourceGroups
 		return nil
 	})
 	// get Data cluster metrics
@@ -419,6 +430,39 @@ func (q *QuotaCenter) calculateReadRates() {
 	realTimeSearchRate := q.getRealTimeRate(internalpb.RateType_DQLSearch)
 	realTimeQueryRate := q.getRealTimeRate(internalpb.RateType_DQLQuery)
 	coolOff(realTimeSearchRate, realTimeQueryRate, limitCollectionSet.Collect()...)
+
+	q.limitResourceGroupReads()
+}
+
+// limitResourceGroupReads shares the read rates of the resource groups equally among the collections loaded in them,
+// a collection loaded in several resource groups is limited by the least share.
+func (q *QuotaCenter) limitResourceGroupReads() {
+	quotas := Params.QuotaConfig.GetResourceGroupQuotas()
+	for _, rg := range q.resourceGroups {
+		quota, ok := quotas[strings.ToLower(rg.Name)]
+		if !ok || len(rg.Collections) == 0 {
+			continue
+		}
+		shares := map[internalpb.RateType]Limit{
+			internalpb.RateType_DQLSearch: Limit(quota.MaxSearchRate),
+			internalpb.RateType_DQLQuery:  Limit(quota.MaxQueryRate),
+		}
+		for rt, maxRate := range shares {
+			if maxRate == Inf {
+				continue
+			}
+			share := maxRate / Limit(len(rg.Collections))
+			for _, collection := range rg.Collections {
+				rates, ok := q.currentRates[collection]
+				if !ok {
+					continue
+				}
+				if rate, ok := rates[rt]; !ok || rate > share {
+					rates[rt] = share
+				}
+			}
+		}
+	}
 }
 
 // calculateWriteRates calculates and sets dml rates.
@@ -430,6 +474,7 @@ func (q *QuotaCenter) calculateWriteRates() error {
 	}
 
 	q.checkDiskQuota()
+	q.checkResourceGroupMemoryQuota()
 
 	ts, err := q.tsoAllocator.GenerateTSO(1)
 	if err != nil {
@@ -454,7 +499,7 @@ func (q *QuotaCenter) calculateWriteRates() error {
 	updateCollectionFactor(growingSegFactors)
 
 	for collection, factor := range collectionFactors {
-		metrics.RootCoordRateLimitRatio.WithLabelValues(fmt.Sprint(collection)).Set(1 - factor)
+		metrics.RootCoordRateLimitRatio.WithLabelValues(metrics.CollectionIDLabel(collection)).Set(1 - factor)
 		if factor <= 0 {
 			if _, ok := ttFactors[collection]; ok && factor == ttFactors[collection] {
 				// factor comes from ttFactor
@@ -695,6 +740,7 @@ func (q *QuotaCenter) calculateRates() error {
 func (q *QuotaCenter) resetAllCurrentRates() {
 	q.quotaStates = make(map[int64]map[milvuspb.QuotaState]commonpb.ErrorCode)
 	q.currentRates = map[int64]map[internalpb.RateType]ratelimitutil.Limit{}
+	q.insertBursts = make(map[int64]float64)
 	for _, collection := range q.writableCollections {
 		q.resetCurrentRate(internalpb.RateType_DMLInsert, collection)
 		q.resetCurrentRate(internalpb.RateType_DMLUpsert, collection)
@@ -722,6 +768,12 @@ func (q *QuotaCenter) resetCurrentRate(rt internalpb.RateType, collection int64)
 	switch rt {
 	case internalpb.RateType_DMLInsert:
 		q.currentRates[collection][rt] = Limit(getCollectionRateLimitConfig(collectionProps, common.CollectionInsertRateMaxKey))
+		q.insertBursts[collection] = getCollectionRateLimitConfig(collectionProps, common.CollectionInsertBurstKey)
+		// the temporary override takes precedence over the configured rate, e.g. during backfills
+		if rate, burst, ok := getInsertRateOverride(collectionProps, time.Now()); ok {
+			q.currentRates[collection][rt] = Limit(rate)
+			q.insertBursts[collection] = burst
+		}
 	case internalpb.RateType_DMLUpsert:
 		q.currentRates[collection][rt] = Limit(getCollectionRateLimitConfig(collectionProps, common.CollectionUpsertRateMaxKey))
 	case internalpb.RateType_DMLDelete:
@@ -794,6 +846,25 @@ func (q *QuotaCenter) checkDiskQuota() {
 	q.totalBinlogSize = total
 }
 
+// checkResourceGroupMemoryQuota denies the writes to the collections loaded in the resource groups
+// which exceed their memory quotas.
+func (q *QuotaCenter) checkResourceGroupMemoryQuota() {
+	quotas := Params.QuotaConfig.GetResourceGroupQuotas()
+	for _, rg := range q.resourceGroups {
+		quota, ok := quotas[strings.ToLower(rg.Name)]
+		if !ok || Limit(quota.MemoryQuota) == Inf || len(rg.Collections) == 0 {
+			continue
+		}
+		if float64(rg.MemoryUsage) >= quota.MemoryQuota {
+			log.RatedWarn(10, "resource group memory quota exceeded",
+				zap.String("resourceGroup", rg.Name),
+				zap.Uint64("memory usage", rg.MemoryUsage),
+				zap.Float64("memory quota", quota.MemoryQuota))
+			q.forceDenyWriting(commonpb.ErrorCode_MemoryQuotaExhausted, rg.Collections...)
+		}
+	}
+}
+
 // setRates notifies Proxies to set rates for different rate types.
 func (q *QuotaCenter) setRates() error {
 	ctx, cancel := context.WithTimeout(context.Background(), SetRatesTimeout)
@@ -811,7 +882,11 @@ func (q *QuotaCenter) setRates() error {
 				if r == Inf {
 					rates = append(rates, &internalpb.Rate{Rt: rt, R: float64(r)})
 				} else {
-					rates = append(rates, &internalpb.Rate{Rt: rt, R: float64(r) / float64(proxyNum)})
+					rate := &internalpb.Rate{Rt: rt, R: float64(r) / float64(proxyNum)}
+					if rt == internalpb.RateType_DMLInsert {
+						rate.Burst = q.insertBursts[collection] / float64(proxyNum)
+					}
+					rates = append(rates, rate)
 				}
 			}
 
@@ -860,6 +935,22 @@ func (q *QuotaCenter) recordMetrics() {
 	record(commonpb.ErrorCode_TimeTickLongDelay)
 }
 
+// publishQuotaEvents publishes the quota states triggered in this round.
+func (q *QuotaCenter) publishQuotaEvents() {
+	for collection, states := range q.quotaStates {
+		for state, code := range states {
+			if last, ok := q.lastQuotaStates[collection][state]; ok && last == code {
+				continue
+			}
+			eventbus.Publish(eventbus.QuotaTriggered, collection, map[string]any{
+				"state":  state.String(),
+				"reason": code.String(),
+			})
+		}
+	}
+	q.lastQuotaStates = q.quotaStates
+}
+
 func (q *QuotaCenter) diskAllowance(collection UniqueID) float64 {
 	q.diskMu.Lock()
 	defer q.diskMu.Unlock()
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 	"testing"
 	"time"
 
@@ -529,6 +530,38 @@ func TestQuotaCenter(t *testing.T) {
 		paramtable.Get().Save(Params.QuotaConfig.DiskQuotaPerCollection.Key, colQuotaBackup)
 	})
 
+	t.Run("test resource group quotas", func(t *testing.T) {
+		qc := mocks.NewMockQueryCoordClient(t)
+		meta := mockrootcoord.NewIMetaTable(t)
+		meta.EXPECT().GetCollectionByID(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, merr.ErrCollectionNotFound).Maybe()
+		quotaCenter := NewQuotaCenter(pcm, qc, dc, core.tsoAllocator, meta)
+		paramtable.Get().SaveGroup(map[string]string{
+			"quotaAndLimits.resourceGroups.RG1.searchRate.max": "100",
+			"quotaAndLimits.resourceGroups.RG1.memoryQuota":    "1",
+		})
+		defer paramtable.Get().Reset("quotaAndLimits.resourceGroups.RG1.searchRate.max")
+		defer paramtable.Get().Reset("quotaAndLimits.resourceGroups.RG1.memoryQuota")
+
+		quotaCenter.resourceGroups = []metricsinfo.ResourceGroupUsage{
+			{Name: "RG1", Collections: []int64{1, 2}, MemoryUsage: 1024 * 1024},
+			{Name: "rg2", Collections: []int64{3}, MemoryUsage: 1024 * 1024},
+		}
+		quotaCenter.readableCollections = []int64{1, 2, 3}
+		quotaCenter.writableCollections = []int64{1, 2, 3}
+		quotaCenter.resetAllCurrentRates()
+		quotaCenter.limitResourceGroupReads()
+		assert.Equal(t, Limit(50), quotaCenter.currentRates[1][internalpb.RateType_DQLSearch])
+		assert.Equal(t, Limit(50), quotaCenter.currentRates[2][internalpb.RateType_DQLSearch])
+		assert.NotEqual(t, Limit(50), quotaCenter.currentRates[3][internalpb.RateType_DQLSearch])
+		assert.NotEqual(t, Limit(50), quotaCenter.currentRates[1][internalpb.RateType_DQLQuery])
+
+		quotaCenter.checkResourceGroupMemoryQuota()
+		assert.Equal(t, Limit(0), quotaCenter.currentRates[1][internalpb.RateType_DMLInsert])
+		assert.Equal(t, Limit(0), quotaCenter.currentRates[2][internalpb.RateType_DMLInsert])
+		assert.NotEqual(t, Limit(0), quotaCenter.currentRates[3][internalpb.RateType_DMLInsert])
+		assert.Equal(t, commonpb.ErrorCode_MemoryQuotaExhausted, quotaCenter.quotaStates[1][milvuspb.QuotaState_DenyToWrite])
+	})
+
 	t.Run("test setRates", func(t *testing.T) {
 		qc := mocks.NewMockQueryCoordClient(t)
 		p1 := mocks.NewMockProxyClient(t)
@@ -669,4 +702,36 @@ func TestQuotaCenter(t *testing.T) {
 		assert.Equal(t, float64(quotaCenter.currentRates[1][internalpb.RateType_DQLSearch]), float64(5))
 		assert.Equal(t, float64(quotaCenter.currentRates[1][internalpb.RateType_DMLUpsert]), float64(6*1024*1024))
 	})
+
+	t.Run("test insert rate burst and override", func(t *testing.T) {
+		meta := mockrootcoord.NewIMetaTable(t)
+		properties := []*commonpb.KeyValuePair{
+			{Key: common.CollectionInsertRateMaxKey, Value: "1"},
+			{Key: common.CollectionInsertBurstKey, Value: "2"},
+		}
+		meta.EXPECT().GetCollectionByID(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, dbName string, collectionID int64, ts uint64, allowUnavailable bool) (*model.Collection, error) {
+				return &model.Collection{Properties: properties}, nil
+			})
+		quotaCenter := NewQuotaCenter(pcm, nil, dc, core.tsoAllocator, meta)
+		quotaCenter.writableCollections = []int64{1}
+		quotaCenter.resetAllCurrentRates()
+		assert.Equal(t, float64(1*1024*1024), float64(quotaCenter.currentRates[1][internalpb.RateType_DMLInsert]))
+		assert.Equal(t, float64(2*1024*1024), quotaCenter.insertBursts[1])
+
+		// the override takes precedence until it expires
+		properties = append(properties,
+			&commonpb.KeyValuePair{Key: common.CollectionInsertRateOverrideKey, Value: "10"},
+			&commonpb.KeyValuePair{Key: common.CollectionInsertBurstOverrideKey, Value: "20"},
+			&commonpb.KeyValuePair{Key: common.CollectionInsertRateOverrideUntilKey, Value: strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+		)
+		quotaCenter.resetAllCurrentRates()
+		assert.Equal(t, float64(10*1024*1024), float64(quotaCenter.currentRates[1][internalpb.RateType_DMLInsert]))
+		assert.Equal(t, float64(20*1024*1024), quotaCenter.insertBursts[1])
+
+		properties[len(properties)-1].Value = strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+		quotaCenter.resetAllCurrentRates()
+		assert.Equal(t, float64(1*1024*1024), float64(quotaCenter.currentRates[1][internalpb.RateType_DMLInsert]))
+		assert.Equal(t, float64(2*1024*1024), quotaCenter.insertBursts[1])
+	})
 }
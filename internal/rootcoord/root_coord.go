@@ -107,7 +107,11 @@ type Core struct {
 
 	metricsCacheManager *metricsinfo.MetricsCacheManager
 
-	chanTimeTick *timetickSync
+	chanTimeTick    *timetickSync
+	topicController *topicController
+	ddlEventLog     *ddlEventLog
+
+	partitionRollover *partitionRollover
 
 	idAllocator  allocator.Interface
 	tsoAllocator tso2.Allocator
@@ -128,6 +132,7 @@ type Core struct {
 
 	enableActiveStandBy bool
 	activateFunc        func() error
+	hotStandby          *hotStandby
 }
 
 // --------------------- function --------------------------
@@ -333,42 +338,52 @@ func (c *Core) initKVCreator() {
 	}
 }
 
-func (c *Core) initMetaTable() error {
-	fn := func() error {
-		var catalog metastore.RootCoordCatalog
-		var err error
-
-		switch Params.MetaStoreCfg.MetaStoreType.GetValue() {
-		case util.MetaStoreTypeEtcd:
-			log.Info("Using etcd as meta storage.")
-			var metaKV kv.MetaKv
-			var ss *kvmetestore.SuffixSnapshot
-			var err error
+func (c *Core) newCatalog(opts ...kvmetestore.SuffixSnapshotOption) (metastore.RootCoordCatalog, error) {
+	var rootPath string
+	switch Params.MetaStoreCfg.MetaStoreType.GetValue() {
+	case util.MetaStoreTypeEtcd:
+		rootPath = Params.EtcdCfg.MetaRootPath.GetValue()
+	case util.MetaStoreTypeTiKV:
+		rootPath = Params.TiKVCfg.MetaRootPath.GetValue()
+	default:
+		return nil, retry.Unrecoverable(fmt.Errorf("not supported meta store: %s", Params.MetaStoreCfg.MetaStoreType.GetValue()))
+	}
 
-			if metaKV, err = c.metaKVCreator(); err != nil {
-				return err
-			}
+	metaKV, err := c.metaKVCreator()
+	if err != nil {
+		return nil, err
+	}
+	ss, err := kvmetestore.NewSuffixSnapshot(metaKV, kvmetestore.SnapshotsSep, rootPath, kvmetestore.SnapshotPrefix, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &kvmetestore.Catalog{Txn: metaKV, Snapshot: ss}, nil
+}
 
-			if ss, err = kvmetestore.NewSuffixSnapshot(metaKV, kvmetestore.SnapshotsSep, Params.EtcdCfg.MetaRootPath.GetValue(), kvmetestore.SnapshotPrefix); err != nil {
-				return err
+func (c *Core) initMetaTable() error {
+	if c.hotStandby != nil {
+		mt := c.hotStandby.takeover(c.ctx)
+		c.hotStandby = nil
+		if mt != nil {
+			// the catalog of the standby is read only without the snapshot gc, replace it by a writable one
+			catalog, err := c.newCatalog()
+			if err == nil {
+				err = mt.activate(c.tsoAllocator, catalog)
 			}
-			catalog = &kvmetestore.Catalog{Txn: metaKV, Snapshot: ss}
-		case util.MetaStoreTypeTiKV:
-			log.Info("Using tikv as meta storage.")
-			var metaKV kv.MetaKv
-			var ss *kvmetestore.SuffixSnapshot
-			var err error
-
-			if metaKV, err = c.metaKVCreator(); err != nil {
-				return err
+			if err == nil {
+				c.meta = mt
+				return nil
 			}
+			log.Warn("failed to activate the meta of hot standby", zap.Error(err))
+		}
+		log.Warn("the meta of hot standby is not usable, reload it fully")
+	}
 
-			if ss, err = kvmetestore.NewSuffixSnapshot(metaKV, kvmetestore.SnapshotsSep, Params.TiKVCfg.MetaRootPath.GetValue(), kvmetestore.SnapshotPrefix); err != nil {
-				return err
-			}
-			catalog = &kvmetestore.Catalog{Txn: metaKV, Snapshot: ss}
-		default:
-			return retry.Unrecoverable(fmt.Errorf("not supported meta store: %s", Params.MetaStoreCfg.MetaStoreType.GetValue()))
+	log.Info("init meta table", zap.String("metaStoreType", Params.MetaStoreCfg.MetaStoreType.GetValue()))
+	fn := func() error {
+		catalog, err := c.newCatalog()
+		if err != nil {
+			return err
 		}
 
 		if c.meta, err = NewMetaTable(c.ctx, catalog, c.tsoAllocator); err != nil {
@@ -466,16 +481,34 @@ func (c *Core) initInternal() error {
 		return err
 	}
 
-	c.scheduler = newScheduler(c.ctx, c.idAllocator, c.tsoAllocator)
+	scheduler := newScheduler(c.ctx, c.idAllocator, c.tsoAllocator)
+	c.scheduler = scheduler
 
 	c.factory.Init(Params)
 	chanMap := c.meta.ListCollectionPhysicalChannels()
 	c.chanTimeTick = newTimeTickSync(c.ctx, c.session.ServerID, c.factory, chanMap)
+	c.topicController = newTopicController(c.factory, c.chanTimeTick.dmlChannels)
+	c.restoreDatabaseIsolation(c.ctx)
 	log.Info("create TimeTick sync done")
 
+	if Params.RootCoordCfg.DDLEventLogEnabled.GetAsBool() {
+		eventLogKV, err := c.metaKVCreator()
+		if err != nil {
+			return err
+		}
+		c.ddlEventLog = newDDLEventLog(eventLogKV, c.chanTimeTick.getSyncedTimeTicks)
+		scheduler.eventLog = c.ddlEventLog
+		log.Info("ddl event log enabled")
+	}
+
 	c.proxyClientManager = newProxyClientManager(c.proxyCreator)
 
 	c.broker = newServerBroker(c)
+	rolloverKV, err := c.metaKVCreator()
+	if err != nil {
+		return err
+	}
+	c.partitionRollover = newPartitionRollover(c, rolloverKV)
 	c.ddlTsLockManager = newDdlTsLockManager(c.tsoAllocator)
 	c.garbageCollector = newBgGarbageCollector(c)
 	c.stepExecutor = newBgStepExecutor(c.ctx)
@@ -542,6 +575,7 @@ func (c *Core) Init() error {
 			log.Info("RootCoord startup success", zap.String("address", c.session.Address))
 			return err
 		}
+		c.initHotStandby()
 		c.UpdateStateCode(commonpb.StateCode_StandBy)
 		log.Info("RootCoord enter standby mode successfully")
 	} else {
@@ -616,6 +650,25 @@ func (c *Core) initRbac() error {
 	return nil
 }
 
+// restoreDatabaseIsolation reserves the dml channels declared by the database properties.
+func (c *Core) restoreDatabaseIsolation(ctx context.Context) {
+	dbs, err := c.meta.ListDatabases(ctx, typeutil.MaxTimestamp)
+	if err != nil {
+		log.Warn("failed to list databases to restore isolation", zap.Error(err))
+		return
+	}
+	for _, db := range dbs {
+		channels := common.GetDatabaseDmlChannels(db.Properties...)
+		if len(channels) == 0 {
+			continue
+		}
+		// the channels may be invalid if the dml channel number changed, the database shares channels then
+		if err := c.chanTimeTick.reserveDmlChannels(db.ID, channels...); err != nil {
+			log.Warn("failed to restore the dml channels reserved by database", zap.String("db", db.Name), zap.Error(err))
+		}
+	}
+}
+
 func (c *Core) restore(ctx context.Context) error {
 	dbs, err := c.meta.ListDatabases(ctx, typeutil.MaxTimestamp)
 	if err != nil {
@@ -697,13 +750,20 @@ func (c *Core) startInternal() error {
 }
 
 func (c *Core) startServerLoop() {
-	c.wg.Add(6)
+	c.wg.Add(7)
 	go c.startTimeTickLoop()
 	go c.tsLoop()
 	go c.chanTimeTick.startWatch(&c.wg)
+	go c.chanTimeTick.startSkewCheck(&c.wg)
 	go c.importManager.cleanupLoop(&c.wg)
 	go c.importManager.sendOutTasksLoop(&c.wg)
 	go c.importManager.flipTaskStateLoop(&c.wg)
+	if c.ddlEventLog != nil {
+		c.ddlEventLog.start()
+	}
+	if c.partitionRollover != nil {
+		c.partitionRollover.start()
+	}
 }
 
 // Start starts RootCoord.
@@ -750,8 +810,17 @@ func (c *Core) revokeSession() {
 // Stop stops rootCoord.
 func (c *Core) Stop() error {
 	c.UpdateStateCode(commonpb.StateCode_Abnormal)
+	if c.hotStandby != nil {
+		c.hotStandby.stop()
+	}
 	c.stopExecutor()
 	c.stopScheduler()
+	if c.ddlEventLog != nil {
+		c.ddlEventLog.close()
+	}
+	if c.partitionRollover != nil {
+		c.partitionRollover.close()
+	}
 	if c.proxyManager != nil {
 		c.proxyManager.Stop()
 	}
@@ -942,6 +1011,78 @@ func (c *Core) ListDatabases(ctx context.Context, in *milvuspb.ListDatabasesRequ
 	return t.Resp, nil
 }
 
+// AlterDatabase alters the properties of database, e.g. the dml channels and datanodes reserved by it.
+func (c *Core) AlterDatabase(ctx context.Context, in *rootcoordpb.AlterDatabaseRequest) (*commonpb.Status, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return merr.Status(err), nil
+	}
+
+	method := "AlterDatabase"
+	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.TotalLabel).Inc()
+	tr := timerecord.NewTimeRecorder(method)
+
+	log := log.Ctx(ctx).With(zap.String("dbName", in.GetDbName()), zap.Int64("msgID", in.GetBase().GetMsgID()))
+	log.Info("received request to alter database", zap.Any("properties", in.GetProperties()))
+
+	t := &alterDatabaseTask{
+		baseTask: newBaseTask(ctx, c),
+		Req:      in,
+	}
+
+	if err := c.scheduler.AddTask(t); err != nil {
+		log.Info("failed to enqueue request to alter database", zap.Error(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+
+	if err := t.WaitToFinish(); err != nil {
+		log.Info("failed to alter database", zap.Error(err), zap.Uint64("ts", t.GetTs()))
+		metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.FailLabel).Inc()
+		return merr.Status(err), nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.SuccessLabel).Inc()
+	metrics.RootCoordDDLReqLatency.WithLabelValues(method).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	log.Info("done to alter database", zap.Uint64("ts", t.GetTs()))
+	return merr.Success(), nil
+}
+
+// DescribeDatabase returns the meta of database, including the properties.
+func (c *Core) DescribeDatabase(ctx context.Context, in *rootcoordpb.DescribeDatabaseRequest) (*rootcoordpb.DescribeDatabaseResponse, error) {
+	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
+		return &rootcoordpb.DescribeDatabaseResponse{Status: merr.Status(err)}, nil
+	}
+
+	method := "DescribeDatabase"
+	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.TotalLabel).Inc()
+	tr := timerecord.NewTimeRecorder(method)
+
+	log := log.Ctx(ctx).With(zap.String("dbName", in.GetDbName()), zap.Int64("msgID", in.GetBase().GetMsgID()))
+	log.Debug("received request to describe database")
+
+	t := &describeDatabaseTask{
+		baseTask: newBaseTask(ctx, c),
+		Req:      in,
+		Resp:     &rootcoordpb.DescribeDatabaseResponse{},
+	}
+
+	if err := c.scheduler.AddTask(t); err != nil {
+		log.Info("failed to enqueue request to describe database", zap.Error(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.FailLabel).Inc()
+		return &rootcoordpb.DescribeDatabaseResponse{Status: merr.Status(err)}, nil
+	}
+
+	if err := t.WaitToFinish(); err != nil {
+		log.Info("failed to describe database", zap.Error(err))
+		metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.FailLabel).Inc()
+		return &rootcoordpb.DescribeDatabaseResponse{Status: merr.Status(err)}, nil
+	}
+
+	metrics.RootCoordDDLReqCounter.WithLabelValues(method, metrics.SuccessLabel).Inc()
+	metrics.RootCoordDDLReqLatency.WithLabelValues(method).Observe(float64(tr.ElapseSpan().Milliseconds()))
+	return t.Resp, nil
+}
+
 // CreateCollection create collection
 func (c *Core) CreateCollection(ctx context.Context, in *milvuspb.CreateCollectionRequest) (*commonpb.Status, error) {
 	if err := merr.CheckHealthy(c.GetStateCode()); err != nil {
@@ -982,6 +1123,18 @@ func (c *Core) CreateCollection(ctx context.Context, in *milvuspb.CreateCollecti
 		return merr.Status(err), nil
 	}
 
+	if c.partitionRollover != nil {
+		if err := c.partitionRollover.prepare(ctx, in.GetDbName(), in.GetCollectionName(), in.GetProperties()); err != nil {
+			log.Ctx(ctx).Warn("failed to prepare partition of time-partitioned collection",
+				zap.String("role", typeutil.RootCoordRole),
+				zap.Error(err),
+				zap.String("name", in.GetCollectionName()))
+
+			metrics.RootCoordDDLReqCounter.WithLabelValues("CreateCollection", metrics.FailLabel).Inc()
+			return merr.Status(err), nil
+		}
+	}
+
 	metrics.RootCoordDDLReqCounter.WithLabelValues("CreateCollection", metrics.SuccessLabel).Inc()
 	metrics.RootCoordDDLReqLatency.WithLabelValues("CreateCollection").Observe(float64(tr.ElapseSpan().Milliseconds()))
 	metrics.RootCoordDDLReqLatencyInQueue.WithLabelValues("CreateCollection").Observe(float64(t.queueDur.Milliseconds()))
@@ -1267,6 +1420,18 @@ func (c *Core) AlterCollection(ctx context.Context, in *milvuspb.AlterCollection
 		return merr.Status(err), nil
 	}
 
+	if c.partitionRollover != nil {
+		if err := c.partitionRollover.prepare(ctx, in.GetDbName(), in.GetCollectionName(), in.GetProperties()); err != nil {
+			log.Ctx(ctx).Warn("failed to prepare partition of time-partitioned collection",
+				zap.String("role", typeutil.RootCoordRole),
+				zap.Error(err),
+				zap.String("name", in.GetCollectionName()))
+
+			metrics.RootCoordDDLReqCounter.WithLabelValues("AlterCollection", metrics.FailLabel).Inc()
+			return merr.Status(err), nil
+		}
+	}
+
 	metrics.RootCoordDDLReqCounter.WithLabelValues("AlterCollection", metrics.SuccessLabel).Inc()
 	metrics.RootCoordDDLReqLatency.WithLabelValues("AlterCollection").Observe(float64(tr.ElapseSpan().Milliseconds()))
 	metrics.RootCoordDDLReqLatencyInQueue.WithLabelValues("AlterCollection").Observe(float64(t.queueDur.Milliseconds()))
@@ -1647,6 +1812,18 @@ func (c *Core) GetMetrics(ctx context.Context, in *milvuspb.GetMetricsRequest) (
 		return metrics, err
 	}
 
+	if metricType == metricsinfo.DDLEventLogMetrics {
+		return c.getDDLEventLogMetrics(ctx, in)
+	}
+
+	if metricType == metricsinfo.TimeTickWatermarkMetrics {
+		return c.getTimeTickWatermarkMetrics(ctx, in)
+	}
+
+	if metricType == metricsinfo.TaskHistoryMetrics || metricType == metricsinfo.PurgeTaskHistoryMetrics {
+		return c.getTaskHistoryMetrics(ctx, metricType, in)
+	}
+
 	log.RatedWarn(60, "GetMetrics failed, metric type not implemented", zap.String("role", typeutil.RootCoordRole),
 		zap.String("metricType", metricType))
 
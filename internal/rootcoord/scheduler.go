@@ -49,6 +49,9 @@ type scheduler struct {
 	lock sync.Mutex
 
 	minDdlTs atomic.Uint64
+
+	// eventLog records the ddl events if it's enabled.
+	eventLog *ddlEventLog
 }
 
 func newScheduler(ctx context.Context, idAllocator allocator.Interface, tsoAllocator tso.Allocator) *scheduler {
@@ -86,6 +89,11 @@ func (s *scheduler) execute(task task) {
 		return
 	}
 	err := task.Execute(task.GetCtx())
+	if err == nil && s.eventLog != nil {
+		if t, ok := task.(ddlEventTask); ok {
+			s.eventLog.append(t.ddlEvent())
+		}
+	}
 	task.NotifyDone(err)
 }
 
@@ -27,7 +27,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 type mockFailTask struct {
@@ -140,6 +144,56 @@ func Test_scheduler_enqueue_normal_case(t *testing.T) {
 	assert.Equal(t, Timestamp(101), task.GetTs())
 }
 
+type mockDDLEventTask struct {
+	mockNormalTask
+	executeErr error
+}
+
+func (t *mockDDLEventTask) Execute(context.Context) error {
+	return t.executeErr
+}
+
+func (t *mockDDLEventTask) ddlEvent() *metricsinfo.DDLEvent {
+	return &metricsinfo.DDLEvent{Timestamp: t.GetTs(), Type: commonpb.MsgType_CreateDatabase.String(), DBName: "db1"}
+}
+
+func Test_scheduler_record_ddl_event(t *testing.T) {
+	paramtable.Init()
+	idAlloc := newMockIDAllocator()
+	tsoAlloc := newMockTsoAllocator()
+	idAlloc.AllocOneF = func() (UniqueID, error) {
+		return 100, nil
+	}
+	tsoAlloc.GenerateTSOF = func(count uint32) (uint64, error) {
+		return 101, nil
+	}
+	ctx := context.Background()
+	s := newScheduler(ctx, idAlloc, tsoAlloc)
+	s.eventLog = newDDLEventLog(memkv.NewMemoryKV(), func() map[string]Timestamp { return nil })
+	s.eventLog.start()
+	defer s.eventLog.close()
+	s.Start()
+	defer s.Stop()
+
+	task := &mockDDLEventTask{mockNormalTask: *newMockNormalTask()}
+	assert.NoError(t, s.AddTask(task))
+	assert.NoError(t, task.WaitToFinish())
+	// the failed task is not recorded
+	failed := &mockDDLEventTask{mockNormalTask: *newMockNormalTask(), executeErr: errors.New("error mock Execute")}
+	assert.NoError(t, s.AddTask(failed))
+	assert.Error(t, failed.WaitToFinish())
+
+	// the events are written in background
+	var events []metricsinfo.DDLEvent
+	assert.Eventually(t, func() bool {
+		events, _ = s.eventLog.list(typeutil.MaxTimestamp)
+		return len(events) > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, Timestamp(101), events[0].Timestamp)
+	assert.Equal(t, "db1", events[0].DBName)
+}
+
 func Test_scheduler_bg(t *testing.T) {
 	idAlloc := newMockIDAllocator()
 	tsoAlloc := newMockTsoAllocator()
@@ -106,6 +106,23 @@ func (s *removeDmlChannelsStep) Weight() stepPriority {
 	return stepPriorityUrgent
 }
 
+type deleteExclusiveTopicsStep struct {
+	baseStep
+	pChannels []string
+}
+
+func (s *deleteExclusiveTopicsStep) Execute(ctx context.Context) ([]nestedStep, error) {
+	return nil, s.core.topicController.destroy(ctx, s.pChannels)
+}
+
+func (s *deleteExclusiveTopicsStep) Desc() string {
+	return fmt.Sprintf("delete exclusive topics: %v", s.pChannels)
+}
+
+func (s *deleteExclusiveTopicsStep) Weight() stepPriority {
+	return stepPriorityNormal
+}
+
 type watchChannelsStep struct {
 	baseStep
 	info *watchInfo
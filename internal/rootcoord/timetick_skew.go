@@ -0,0 +1,166 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/util/eventbus"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// earliest returns the earliest timetick of the session over its channels.
+func (c *chanTsMsg) earliest() typeutil.Timestamp {
+	ts := c.defaultTs
+	for _, chanTs := range c.chanTsMap {
+		if chanTs < ts {
+			ts = chanTs
+		}
+	}
+	return ts
+}
+
+// getWatermarks returns the watermarks of the virtual channels and the skew of the sessions,
+// the global watermark is the latest timetick reported by all the sessions.
+func (t *timetickSync) getWatermarks(vchannels []string) *metricsinfo.TimeTickWatermarkInfos {
+	threshold := Params.CommonCfg.TimeTickSkewThreshold.GetAsDuration(time.Second)
+	now := time.Now()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	infos := &metricsinfo.TimeTickWatermarkInfos{
+		ThresholdMs: threshold.Milliseconds(),
+		Channels:    make([]metricsinfo.ChannelWatermark, 0, len(vchannels)),
+		Producers:   make([]metricsinfo.ProducerSkew, 0, len(t.lastReport)),
+	}
+	for _, msg := range t.latestTs {
+		if ts := msg.earliest(); ts > infos.GlobalWatermark {
+			infos.GlobalWatermark = ts
+		}
+	}
+
+	for id, reportTime := range t.lastReport {
+		producer := metricsinfo.ProducerSkew{
+			Role:         typeutil.ProxyRole,
+			NodeID:       id,
+			LastReportMs: now.Sub(reportTime).Milliseconds(),
+		}
+		if id == ddlSourceID {
+			producer.Role = typeutil.RootCoordRole
+			producer.NodeID = t.sourceID
+		}
+		if msg, ok := t.latestTs[id]; ok {
+			producer.Timestamp = msg.earliest()
+			producer.SkewMs = tsoutil.PhysicalTime(infos.GlobalWatermark).Sub(tsoutil.PhysicalTime(producer.Timestamp)).Milliseconds()
+		}
+		producer.Lagging = producer.SkewMs > threshold.Milliseconds() || producer.LastReportMs > threshold.Milliseconds()
+		infos.Producers = append(infos.Producers, producer)
+	}
+	sort.Slice(infos.Producers, func(i, j int) bool {
+		if infos.Producers[i].SkewMs != infos.Producers[j].SkewMs {
+			return infos.Producers[i].SkewMs > infos.Producers[j].SkewMs
+		}
+		return infos.Producers[i].LastReportMs > infos.Producers[j].LastReportMs
+	})
+
+	for _, vchannel := range vchannels {
+		pchannel := funcutil.ToPhysicalChannel(vchannel)
+		watermark := metricsinfo.ChannelWatermark{
+			Channel:         vchannel,
+			PhysicalChannel: pchannel,
+			Watermark:       t.syncedTtHistogram.get(pchannel),
+		}
+		if watermark.Watermark != 0 {
+			watermark.LagMs = now.Sub(tsoutil.PhysicalTime(watermark.Watermark)).Milliseconds()
+		}
+		// the session holding the watermark back is the one with the earliest timetick on the channel
+		var minTs typeutil.Timestamp
+		for id, msg := range t.latestTs {
+			if ts := msg.getTimetick(pchannel); minTs == 0 || ts < minTs {
+				minTs = ts
+				watermark.NodeID = id
+				if id == ddlSourceID {
+					watermark.NodeID = t.sourceID
+				}
+			}
+		}
+		infos.Channels = append(infos.Channels, watermark)
+	}
+	return infos
+}
+
+// startSkewCheck checks the skew of the sessions periodically, reports it by metrics,
+// and alerts the sessions which start lagging behind the global watermark.
+func (t *timetickSync) startSkewCheck(wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(Params.CommonCfg.TimeTickSkewCheckInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	lagging := make(map[int64]bool)
+	for {
+		select {
+		case <-t.ctx.Done():
+			log.Info("timetick skew checker exit")
+			return
+		case <-ticker.C:
+			lagging = t.checkSkew(lagging)
+		}
+	}
+}
+
+// checkSkew checks the skew of the sessions given the ones lagging at the last check,
+// returns the ones lagging now.
+func (t *timetickSync) checkSkew(prevLagging map[int64]bool) map[int64]bool {
+	infos := t.getWatermarks(nil)
+	lagging := make(map[int64]bool, len(infos.Producers))
+	for _, producer := range infos.Producers {
+		nodeID := strconv.FormatInt(producer.NodeID, 10)
+		metrics.RootCoordProducerTimeTickSkew.WithLabelValues(nodeID).Set(float64(producer.SkewMs))
+		lagging[producer.NodeID] = producer.Lagging
+		if producer.Lagging && !prevLagging[producer.NodeID] {
+			log.Warn("timetick of the producer lags behind the global watermark",
+				zap.String("role", producer.Role),
+				zap.Int64("nodeID", producer.NodeID),
+				zap.Uint64("globalWatermark", infos.GlobalWatermark),
+				zap.Uint64("timestamp", producer.Timestamp),
+				zap.Int64("skewMs", producer.SkewMs),
+				zap.Int64("lastReportMs", producer.LastReportMs))
+			eventbus.Publish(eventbus.TimeTickLagging, 0, map[string]any{
+				"role":    producer.Role,
+				"node_id": producer.NodeID,
+				"skew_ms": producer.SkewMs,
+			})
+		}
+	}
+	for nodeID := range prevLagging {
+		if _, ok := lagging[nodeID]; !ok {
+			metrics.RootCoordProducerTimeTickSkew.DeleteLabelValues(strconv.FormatInt(nodeID, 10))
+		}
+	}
+	return lagging
+}
@@ -0,0 +1,92 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/dependency"
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func TestTimetickSync_Watermarks(t *testing.T) {
+	paramtable.Get().Save(Params.RootCoordCfg.DmlChannelNum.Key, "2")
+	paramtable.Get().Save(Params.CommonCfg.RootCoordDml.Key, "rootcoord-dml")
+	defer paramtable.Get().Reset(Params.RootCoordCfg.DmlChannelNum.Key)
+	defer paramtable.Get().Reset(Params.CommonCfg.RootCoordDml.Key)
+
+	sourceID := int64(100)
+	ttSync := newTimeTickSync(context.Background(), sourceID, dependency.NewDefaultFactory(true), nil)
+	ttSync.initSessions([]*sessionutil.Session{
+		{SessionRaw: sessionutil.SessionRaw{ServerID: 1}},
+		{SessionRaw: sessionutil.SessionRaw{ServerID: 2}},
+	})
+
+	now := time.Now()
+	update := func(sourceID int64, ts time.Time) {
+		err := ttSync.updateTimeTick(&internalpb.ChannelTimeTickMsg{
+			Base:             &commonpb.MsgBase{MsgType: commonpb.MsgType_TimeTick, SourceID: sourceID},
+			ChannelNames:     []string{"rootcoord-dml_0"},
+			Timestamps:       []uint64{tsoutil.ComposeTSByTime(ts, 0)},
+			DefaultTimestamp: tsoutil.ComposeTSByTime(ts, 0),
+		}, "test")
+		assert.NoError(t, err)
+	}
+	update(ddlSourceID, now)
+	update(1, now)
+	// proxy 2 lags behind
+	update(2, now.Add(-time.Minute))
+
+	infos := ttSync.getWatermarks([]string{"rootcoord-dml_0_1v0"})
+	assert.Equal(t, tsoutil.ComposeTSByTime(now, 0), infos.GlobalWatermark)
+	assert.Equal(t, int64(10000), infos.ThresholdMs)
+	assert.Len(t, infos.Producers, 3)
+
+	lagging := infos.Producers[0]
+	assert.Equal(t, typeutil.ProxyRole, lagging.Role)
+	assert.Equal(t, int64(2), lagging.NodeID)
+	assert.Equal(t, time.Minute.Milliseconds(), lagging.SkewMs)
+	assert.True(t, lagging.Lagging)
+	for _, producer := range infos.Producers[1:] {
+		assert.Zero(t, producer.SkewMs)
+		assert.False(t, producer.Lagging)
+	}
+
+	assert.Len(t, infos.Channels, 1)
+	assert.Equal(t, "rootcoord-dml_0", infos.Channels[0].PhysicalChannel)
+	assert.Equal(t, int64(2), infos.Channels[0].NodeID)
+
+	// alerts once until it recovers
+	prev := ttSync.checkSkew(nil)
+	assert.True(t, prev[2])
+	update(2, now)
+	prev = ttSync.checkSkew(prev)
+	assert.False(t, prev[2])
+
+	ttSync.delSession(&sessionutil.Session{SessionRaw: sessionutil.SessionRaw{ServerID: 2}})
+	infos = ttSync.getWatermarks(nil)
+	assert.Len(t, infos.Producers, 2)
+}
@@ -84,6 +84,11 @@ type timetickSync struct {
 	sess2ChanTsMap map[typeutil.UniqueID]*chanTsMsg
 	sendChan       chan map[typeutil.UniqueID]*chanTsMsg
 
+	// latestTs and lastReport are the latest timeticks of the sessions and when they were reported,
+	// unlike sess2ChanTsMap they are kept after sent, to detect the skew of the sessions
+	latestTs   map[typeutil.UniqueID]*chanTsMsg
+	lastReport map[typeutil.UniqueID]time.Time
+
 	syncedTtHistogram *ttHistogram
 }
 
@@ -121,7 +126,17 @@ func newTimeTickSync(ctx context.Context, sourceID int64, factory msgstream.Fact
 	dmlChannels := newDmlChannels(ctx, factory, Params.CommonCfg.RootCoordDml.GetValue(), int64(chanNum))
 
 	// recover physical channels for all collections
+	prefix := Params.CommonCfg.RootCoordDml.GetValue()
 	for collID, chanNames := range chanMap {
+		for _, chanName := range chanNames {
+			if !isExclusiveChannel(prefix, chanName) {
+				continue
+			}
+			if err := dmlChannels.addExclusiveChannels(chanName); err != nil {
+				log.Error("failed to recover exclusive channel", zap.Int64("collectionID", collID), zap.String("channel", chanName), zap.Error(err))
+				panic("failed to recover exclusive channel")
+			}
+		}
 		dmlChannels.addChannels(chanNames...)
 		log.Info("recover physical channels", zap.Int64("collectionID", collID), zap.Strings("physical channels", chanNames))
 	}
@@ -134,6 +149,8 @@ func newTimeTickSync(ctx context.Context, sourceID int64, factory msgstream.Fact
 
 		lock:           sync.Mutex{},
 		sess2ChanTsMap: make(map[typeutil.UniqueID]*chanTsMsg),
+		latestTs:       make(map[typeutil.UniqueID]*chanTsMsg),
+		lastReport:     make(map[typeutil.UniqueID]time.Time),
 
 		// 1 is the most reasonable capacity. In fact, Milvus can only focus on the latest time tick.
 		sendChan: make(chan map[typeutil.UniqueID]*chanTsMsg, 1),
@@ -222,6 +239,8 @@ func (t *timetickSync) updateTimeTick(in *internalpb.ChannelTimeTickMsg, reason
 	} else {
 		t.sess2ChanTsMap[in.Base.SourceID] = newChanTsMsg(in, prev.cnt+1)
 	}
+	t.latestTs[in.Base.SourceID] = t.sess2ChanTsMap[in.Base.SourceID]
+	t.lastReport[in.Base.SourceID] = time.Now()
 	t.sendToChannel()
 	return nil
 }
@@ -230,6 +249,7 @@ func (t *timetickSync) addSession(sess *sessionutil.Session) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 	t.sess2ChanTsMap[sess.ServerID] = nil
+	t.lastReport[sess.ServerID] = time.Now()
 	log.Info("Add session for timeticksync", zap.Int64("serverID", sess.ServerID))
 }
 
@@ -238,6 +258,8 @@ func (t *timetickSync) delSession(sess *sessionutil.Session) {
 	defer t.lock.Unlock()
 	if _, ok := t.sess2ChanTsMap[sess.ServerID]; ok {
 		delete(t.sess2ChanTsMap, sess.ServerID)
+		delete(t.latestTs, sess.ServerID)
+		delete(t.lastReport, sess.ServerID)
 		log.Info("Remove session from timeticksync", zap.Int64("serverID", sess.ServerID))
 		t.sendToChannel()
 	}
@@ -247,10 +269,14 @@ func (t *timetickSync) initSessions(sess []*sessionutil.Session) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 	t.sess2ChanTsMap = make(map[typeutil.UniqueID]*chanTsMsg)
+	t.latestTs = make(map[typeutil.UniqueID]*chanTsMsg)
+	t.lastReport = make(map[typeutil.UniqueID]time.Time)
 	// Init DDL source
 	t.sess2ChanTsMap[ddlSourceID] = nil
+	t.lastReport[ddlSourceID] = time.Now()
 	for _, s := range sess {
 		t.sess2ChanTsMap[s.ServerID] = nil
+		t.lastReport[s.ServerID] = time.Now()
 		log.Info("Init proxy sessions for timeticksync", zap.Int64("serverID", s.ServerID))
 	}
 }
@@ -357,12 +383,36 @@ func (t *timetickSync) getSessionNum() int {
 	return len(t.sess2ChanTsMap)
 }
 
+// getSyncedTimeTicks returns the latest timeticks synced to the dml channels.
+func (t *timetickSync) getSyncedTimeTicks() map[string]Timestamp {
+	channels := t.listDmlChannels()
+	tts := make(map[string]Timestamp, len(channels))
+	for _, channel := range channels {
+		tts[channel] = t.syncedTtHistogram.get(channel)
+	}
+	return tts
+}
+
 // /////////////////////////////////////////////////////////////////////////////
 // getDmlChannelNames returns list of channel names.
 func (t *timetickSync) getDmlChannelNames(count int) []string {
 	return t.dmlChannels.getChannelNames(count)
 }
 
+// getDmlChannelNamesOfDB returns list of channel names reserved by the database, or the shared ones if it reserves none.
+func (t *timetickSync) getDmlChannelNamesOfDB(dbID int64, count int) []string {
+	return t.dmlChannels.getChannelNamesOfDB(dbID, count)
+}
+
+// reserveDmlChannels reserves the dml channels for the database exclusively.
+func (t *timetickSync) reserveDmlChannels(dbID int64, names ...string) error {
+	if err := t.dmlChannels.reserveChannels(dbID, names...); err != nil {
+		return err
+	}
+	log.Info("reserve dml channels", zap.Int64("dbID", dbID), zap.Strings("channels", names))
+	return nil
+}
+
 // GetDmlChannelNum return the num of dml channels
 func (t *timetickSync) getDmlChannelNum() int {
 	return t.dmlChannels.getChannelNum()
@@ -0,0 +1,101 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// topicController manages the lifecycle of the exclusive topics.
+//
+// A collection with exclusive topics enabled owns its physical channels instead of sharing the dml channels
+// with other collections, the topics are created with the collection,
+// and deleted after the data of the collection is garbage collected.
+type topicController struct {
+	factory     msgstream.Factory
+	dmlChannels *dmlChannels
+	prefix      string
+}
+
+func newTopicController(factory msgstream.Factory, dmlChannels *dmlChannels) *topicController {
+	return &topicController{
+		factory:     factory,
+		dmlChannels: dmlChannels,
+		prefix:      Params.CommonCfg.RootCoordDml.GetValue(),
+	}
+}
+
+// allocate returns the names of the exclusive physical channels of collection.
+func (c *topicController) allocate(collectionID UniqueID, num int) []string {
+	names := make([]string, 0, num)
+	for i := 0; i < num; i++ {
+		names = append(names, getExclusiveChannelName(c.prefix, collectionID, i))
+	}
+	return names
+}
+
+// isExclusiveChannels returns whether the physical channels of collection are exclusive ones.
+func isExclusiveChannels(pChannels []string) bool {
+	prefix := Params.CommonCfg.RootCoordDml.GetValue()
+	for _, name := range pChannels {
+		if !isExclusiveChannel(prefix, name) {
+			return false
+		}
+	}
+	return len(pChannels) > 0
+}
+
+// create creates the msgstreams of the exclusive channels,
+// the topics are created by message queue when producers are attached.
+func (c *topicController) create(pChannels []string) error {
+	if err := c.dmlChannels.addExclusiveChannels(pChannels...); err != nil {
+		c.dmlChannels.removeExclusiveChannels(pChannels...)
+		return err
+	}
+	log.Info("exclusive topics created", zap.Strings("topics", pChannels))
+	return nil
+}
+
+// destroy closes the msgstreams of the exclusive channels and deletes the topics,
+// the topics are kept if the message queue doesn't support deleting topics.
+func (c *topicController) destroy(ctx context.Context, pChannels []string) error {
+	c.dmlChannels.removeExclusiveChannels(pChannels...)
+
+	deleter, ok := c.factory.(msgstream.TopicDeleter)
+	if !ok {
+		log.Warn("the message queue doesn't support deleting topics, exclusive topics are kept", zap.Strings("topics", pChannels))
+		return nil
+	}
+	err := deleter.DeleteTopics(ctx, pChannels)
+	if errors.Is(err, merr.ErrServiceUnimplemented) {
+		log.Warn("the message queue doesn't support deleting topics, exclusive topics are kept", zap.Strings("topics", pChannels))
+		return nil
+	}
+	if err != nil {
+		log.Warn("failed to delete exclusive topics", zap.Strings("topics", pChannels), zap.Error(err))
+		return err
+	}
+	log.Info("exclusive topics deleted", zap.Strings("topics", pChannels))
+	return nil
+}
@@ -0,0 +1,68 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/util/dependency"
+)
+
+func TestTopicController(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	prefix := Params.CommonCfg.RootCoordDml.GetValue()
+	factory := dependency.NewDefaultFactory(true)
+	dml := newDmlChannels(ctx, factory, prefix, 2)
+	controller := newTopicController(factory, dml)
+
+	shared := dml.getChannelNames(2)
+	assert.False(t, isExclusiveChannels(shared))
+	assert.False(t, isExclusiveChannels(nil))
+
+	names := controller.allocate(100, 2)
+	assert.Equal(t, []string{prefix + "_exclusive100_0", prefix + "_exclusive100_1"}, names)
+	assert.True(t, isExclusiveChannels(names))
+	assert.Equal(t, 0, parseChannelNameIndex(names[0]))
+
+	require.NoError(t, controller.create(names))
+	// creating again is idempotent
+	require.NoError(t, controller.create(names))
+	dml.addChannels(names...)
+	assert.ElementsMatch(t, names, dml.listChannels())
+
+	// exclusive channels are never allocated to other collections
+	for _, name := range dml.getChannelNames(2) {
+		assert.False(t, isExclusiveChannel(prefix, name))
+	}
+
+	// the exclusive channels are ignored when computing the number of shared channels
+	assert.Equal(t, 2, getNeedChanNum(2, map[UniqueID][]string{100: controller.allocate(100, 8)}))
+
+	dml.removeChannels(names...)
+	assert.Empty(t, dml.listChannels())
+	assert.NoError(t, controller.destroy(ctx, names))
+	_, err := dml.getMsgStreamByName(names[0])
+	assert.Error(t, err)
+	// destroying again is ok
+	assert.NoError(t, controller.destroy(ctx, names))
+}
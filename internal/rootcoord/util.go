@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -116,6 +117,8 @@ func getCollectionRateLimitConfigDefaultValue(configKey string) float64 {
 		return Params.QuotaConfig.DMLMaxInsertRatePerCollection.GetAsFloat()
 	case common.CollectionInsertRateMinKey:
 		return Params.QuotaConfig.DMLMinInsertRatePerCollection.GetAsFloat()
+	case common.CollectionInsertBurstKey:
+		return Params.QuotaConfig.DMLInsertBurstPerCollection.GetAsFloat()
 	case common.CollectionUpsertRateMaxKey:
 		return Params.QuotaConfig.DMLMaxUpsertRatePerCollection.GetAsFloat()
 	case common.CollectionUpsertRateMinKey:
@@ -154,6 +157,8 @@ func getCollectionRateLimitConfig(properties map[string]string, configKey string
 			return megaBytes2Bytes(rate)
 		case common.CollectionInsertRateMinKey:
 			return megaBytes2Bytes(rate)
+		case common.CollectionInsertBurstKey:
+			return megaBytes2Bytes(rate)
 		case common.CollectionUpsertRateMaxKey:
 			return megaBytes2Bytes(rate)
 		case common.CollectionUpsertRateMinKey:
@@ -201,3 +206,22 @@ func getCollectionRateLimitConfig(properties map[string]string, configKey string
 
 	return getCollectionRateLimitConfigDefaultValue(configKey)
 }
+
+// getInsertRateOverride returns the insert rate and burst in bytes which override the configured ones temporarily,
+// ok is false if there is no override or it has expired.
+func getInsertRateOverride(properties map[string]string, now time.Time) (rate float64, burst float64, ok bool) {
+	until, err := strconv.ParseInt(properties[common.CollectionInsertRateOverrideUntilKey], 10, 64)
+	if err != nil || !now.Before(time.Unix(until, 0)) {
+		return 0, 0, false
+	}
+	rate, err = strconv.ParseFloat(properties[common.CollectionInsertRateOverrideKey], 64)
+	if err != nil || rate < 0 {
+		return 0, 0, false
+	}
+	// the configured burst applies if the override doesn't specify one
+	burst = getCollectionRateLimitConfig(properties, common.CollectionInsertBurstKey)
+	if v, err := strconv.ParseFloat(properties[common.CollectionInsertBurstOverrideKey], 64); err == nil && v > 0 {
+		burst = v * 1024.0 * 1024.0
+	}
+	return rate * 1024.0 * 1024.0, burst, true
+}
@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"bytes"
 	"fmt"
+	"hash/crc32"
 	"strconv"
 	"strings"
 
@@ -39,3 +41,17 @@ func ParseSegmentIDByBinlog(rootPath, path string) (UniqueID, error) {
 	}
 	return 0, fmt.Errorf("%s is not a valid binlog path", path)
 }
+
+// CheckBinlogMagicNumber checks whether the header of binlog starts with the magic number
+func CheckBinlogMagicNumber(header []byte) error {
+	_, err := readMagicNumber(bytes.NewReader(header))
+	return err
+}
+
+var binlogCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// BinlogChecksum returns the crc32c checksum of the binlog content,
+// it's recorded in the meta on write so that bit rot in object storage can be detected.
+func BinlogChecksum(data []byte) uint32 {
+	return crc32.Checksum(data, binlogCRCTable)
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 )
 
 func TestParseSegmentIDByBinlog(t *testing.T) {
@@ -82,3 +84,27 @@ func TestParseSegmentIDByBinlog(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckBinlogMagicNumber(t *testing.T) {
+	w := NewDeleteBinlogWriter(schemapb.DataType_String, 1, 2, 3)
+	defer w.Close()
+	ew, err := w.NextDeleteEventWriter()
+	assert.NoError(t, err)
+	ew.SetEventTimestamp(100, 200)
+	assert.NoError(t, ew.AddOneStringToPayload("1"))
+	w.SetEventTimeStamp(100, 200)
+	assert.NoError(t, w.Finish())
+	buffer, err := w.GetBuffer()
+	assert.NoError(t, err)
+
+	assert.NoError(t, CheckBinlogMagicNumber(buffer[:4]))
+	assert.Error(t, CheckBinlogMagicNumber([]byte{1, 2, 3, 4}))
+	assert.Error(t, CheckBinlogMagicNumber([]byte{1}))
+}
+
+func TestBinlogChecksum(t *testing.T) {
+	data := []byte("binlog")
+	assert.Equal(t, BinlogChecksum(data), BinlogChecksum([]byte("binlog")))
+	assert.NotEqual(t, BinlogChecksum(data), BinlogChecksum([]byte("binLog")))
+	assert.NotZero(t, BinlogChecksum(data))
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/compressor"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -147,6 +148,12 @@ func (writer *baseBinlogWriter) Close() {
 // InsertBinlogWriter is an object to write binlog file which saves insert data.
 type InsertBinlogWriter struct {
 	baseBinlogWriter
+	compressType compressor.CompressType
+}
+
+// SetCompressType sets the codec used by the event writers created afterwards.
+func (writer *InsertBinlogWriter) SetCompressType(compressType compressor.CompressType) {
+	writer.compressType = compressType
 }
 
 // NextInsertEventWriter returns an event writer to write insert data to an event.
@@ -161,9 +168,9 @@ func (writer *InsertBinlogWriter) NextInsertEventWriter(dim ...int) (*insertEven
 		if len(dim) != 1 {
 			return nil, fmt.Errorf("incorrect input numbers")
 		}
-		event, err = newInsertEventWriter(writer.PayloadDataType, dim[0])
+		event, err = newInsertEventWriterWithCompression(writer.PayloadDataType, writer.compressType, dim[0])
 	} else {
-		event, err = newInsertEventWriter(writer.PayloadDataType)
+		event, err = newInsertEventWriterWithCompression(writer.PayloadDataType, writer.compressType)
 	}
 	if err != nil {
 		return nil, err
@@ -287,6 +294,7 @@ func NewInsertBinlogWriter(dataType schemapb.DataType, collectionID, partitionID
 			eventWriters:    make([]EventWriter, 0),
 			buffer:          nil,
 		},
+		compressType: defaultCompressType(dataType),
 	}
 
 	return w
@@ -0,0 +1,113 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v12/parquet/compress"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/compressor"
+)
+
+// ParseBinlogCompressType converts the codec name into a CompressType supported by binlogs.
+// LZ4 is rejected, the parquet writer of arrow doesn't implement it.
+func ParseBinlogCompressType(s string) (compressor.CompressType, error) {
+	compressType, err := compressor.ParseCompressType(s)
+	if err != nil {
+		return "", err
+	}
+	if _, err := parquetCodec(compressType); err != nil {
+		return "", err
+	}
+	return compressType, nil
+}
+
+// defaultCompressType returns the binlog codec used when the field doesn't specify one.
+// Binary vectors are already quantized and barely compressible, so they are stored as is.
+func defaultCompressType(dataType schemapb.DataType) compressor.CompressType {
+	if dataType == schemapb.DataType_BinaryVector {
+		return compressor.CompressTypeNone
+	}
+	return compressor.DefaultCompressAlgorithm
+}
+
+// ValidateBinlogCompression checks the binlog codecs specified by the fields of schema and by the collection properties.
+func ValidateBinlogCompression(schema *schemapb.CollectionSchema, properties []*commonpb.KeyValuePair) error {
+	if value, ok := common.GetBinlogCompression(properties...); ok {
+		if _, err := ParseBinlogCompressType(value); err != nil {
+			return fmt.Errorf("invalid collection property %s: %w", common.BinlogCompressionKey, err)
+		}
+	}
+	for _, field := range schema.GetFields() {
+		if value, ok := common.GetBinlogCompression(field.GetTypeParams()...); ok {
+			if _, err := ParseBinlogCompressType(value); err != nil {
+				return fmt.Errorf("invalid %s of field %s: %w", common.BinlogCompressionKey, field.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetFieldCompressType returns the binlog codec of the field,
+// the codec is specified by the type param `binlog.compression`, or falls back to the default of the data type.
+func GetFieldCompressType(field *schemapb.FieldSchema) (compressor.CompressType, error) {
+	value, ok := common.GetBinlogCompression(field.GetTypeParams()...)
+	if !ok {
+		return defaultCompressType(field.GetDataType()), nil
+	}
+	return ParseBinlogCompressType(value)
+}
+
+// ApplyBinlogCompression fills the collection-wide binlog codec of properties
+// into the type params of the fields which don't specify one.
+// It's applied when the schema is read by the writer, so collections altered after creation also get the codec.
+func ApplyBinlogCompression(schema *schemapb.CollectionSchema, properties []*commonpb.KeyValuePair) error {
+	value, ok := common.GetBinlogCompression(properties...)
+	if !ok {
+		return nil
+	}
+	if _, err := ParseBinlogCompressType(value); err != nil {
+		return err
+	}
+	for _, field := range schema.GetFields() {
+		if _, ok := common.GetBinlogCompression(field.GetTypeParams()...); ok {
+			continue
+		}
+		field.TypeParams = append(field.TypeParams, &commonpb.KeyValuePair{
+			Key:   common.BinlogCompressionKey,
+			Value: value,
+		})
+	}
+	return nil
+}
+
+// parquetCodec maps the binlog codec to the parquet compression codec.
+// Readers don't need it, parquet records the codec in the column chunk metadata.
+func parquetCodec(compressType compressor.CompressType) (compress.Compression, error) {
+	switch compressType {
+	case compressor.CompressTypeZstd:
+		return compress.Codecs.Zstd, nil
+	case compressor.CompressTypeNone:
+		return compress.Codecs.Uncompressed, nil
+	default:
+		return compress.Codecs.Uncompressed, fmt.Errorf("unsupported binlog compress type: %s", compressType)
+	}
+}
@@ -0,0 +1,139 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/compressor"
+)
+
+func TestGetFieldCompressType(t *testing.T) {
+	ct, err := GetFieldCompressType(&schemapb.FieldSchema{DataType: schemapb.DataType_Int64})
+	assert.NoError(t, err)
+	assert.Equal(t, compressor.CompressTypeZstd, ct)
+
+	// binary vectors are already quantized
+	ct, err = GetFieldCompressType(&schemapb.FieldSchema{DataType: schemapb.DataType_BinaryVector})
+	assert.NoError(t, err)
+	assert.Equal(t, compressor.CompressTypeNone, ct)
+
+	ct, err = GetFieldCompressType(&schemapb.FieldSchema{
+		DataType:   schemapb.DataType_FloatVector,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "none"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, compressor.CompressTypeNone, ct)
+
+	// lz4 is not supported by the parquet writer
+	_, err = GetFieldCompressType(&schemapb.FieldSchema{
+		DataType:   schemapb.DataType_FloatVector,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "lz4"}},
+	})
+	assert.Error(t, err)
+
+	_, err = GetFieldCompressType(&schemapb.FieldSchema{
+		DataType:   schemapb.DataType_Int64,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "unknown"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestPayload_Compression(t *testing.T) {
+	for _, ct := range []compressor.CompressType{compressor.CompressTypeZstd, compressor.CompressTypeNone} {
+		t.Run(string(ct), func(t *testing.T) {
+			w, err := NewPayloadWriterWithCompression(schemapb.DataType_Int64, ct)
+			require.NoError(t, err)
+			defer w.ReleasePayloadWriter()
+
+			err = w.AddInt64ToPayload([]int64{1, 2, 3, 4})
+			assert.NoError(t, err)
+			err = w.FinishPayloadWriter()
+			assert.NoError(t, err)
+
+			buffer, err := w.GetPayloadBufferFromWriter()
+			assert.NoError(t, err)
+
+			r, err := NewPayloadReader(schemapb.DataType_Int64, buffer)
+			require.NoError(t, err)
+			defer r.Close()
+			values, err := r.GetInt64FromPayload()
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []int64{1, 2, 3, 4}, values)
+		})
+	}
+
+	_, err := NewPayloadWriterWithCompression(schemapb.DataType_Int64, compressor.CompressType("snappy"))
+	assert.Error(t, err)
+	_, err = NewPayloadWriterWithCompression(schemapb.DataType_Int64, compressor.CompressTypeLz4)
+	assert.Error(t, err)
+}
+
+func TestApplyBinlogCompression(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, DataType: schemapb.DataType_Int64},
+			{
+				FieldID:    101,
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "zstd"}},
+			},
+		},
+	}
+
+	assert.NoError(t, ApplyBinlogCompression(schema, nil))
+	ct, err := GetFieldCompressType(schema.GetFields()[0])
+	assert.NoError(t, err)
+	assert.Equal(t, compressor.CompressTypeZstd, ct)
+
+	assert.Error(t, ApplyBinlogCompression(schema, []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "lz4"}}))
+
+	assert.NoError(t, ApplyBinlogCompression(schema, []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "none"}}))
+	ct, err = GetFieldCompressType(schema.GetFields()[0])
+	assert.NoError(t, err)
+	assert.Equal(t, compressor.CompressTypeNone, ct)
+	// the codec specified by the field is kept
+	ct, err = GetFieldCompressType(schema.GetFields()[1])
+	assert.NoError(t, err)
+	assert.Equal(t, compressor.CompressTypeZstd, ct)
+}
+
+func TestValidateBinlogCompression(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64},
+			{
+				FieldID:    101,
+				Name:       "vec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "zstd"}},
+			},
+		},
+	}
+	assert.NoError(t, ValidateBinlogCompression(schema, nil))
+	assert.NoError(t, ValidateBinlogCompression(schema, []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "none"}}))
+	assert.Error(t, ValidateBinlogCompression(schema, []*commonpb.KeyValuePair{{Key: common.BinlogCompressionKey, Value: "lz4"}}))
+
+	schema.Fields[1].TypeParams[0].Value = "lz4"
+	assert.Error(t, ValidateBinlogCompression(schema, nil))
+}
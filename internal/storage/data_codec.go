@@ -237,8 +237,12 @@ func (insertCodec *InsertCodec) Serialize(partitionID UniqueID, segmentID Unique
 
 		// encode fields
 		writer = NewInsertBinlogWriter(field.DataType, insertCodec.Schema.ID, partitionID, segmentID, field.FieldID)
+		compressType, err := GetFieldCompressType(field)
+		if err != nil {
+			return nil, err
+		}
+		writer.SetCompressType(compressType)
 		var eventWriter *insertEventWriter
-		var err error
 		if typeutil.IsVectorType(field.DataType) {
 			switch field.DataType {
 			case schemapb.DataType_FloatVector:
@@ -404,6 +408,48 @@ func (insertCodec *InsertCodec) Serialize(partitionID UniqueID, segmentID Unique
 	return blobs, nil
 }
 
+// SerializeVectorBinlog serializes the vectors of the field into an insert binlog of the segment,
+// it's used to replace the vector binlog of a segment while the binlogs of the other fields are kept.
+func SerializeVectorBinlog(collectionID, partitionID, segmentID UniqueID, field *schemapb.FieldSchema,
+	data FieldData, startTs, endTs Timestamp,
+) ([]byte, error) {
+	writer := NewInsertBinlogWriter(field.GetDataType(), collectionID, partitionID, segmentID, field.GetFieldID())
+	defer writer.Close()
+	compressType, err := GetFieldCompressType(field)
+	if err != nil {
+		return nil, err
+	}
+	writer.SetCompressType(compressType)
+
+	var eventWriter *insertEventWriter
+	switch data := data.(type) {
+	case *FloatVectorFieldData:
+		if eventWriter, err = writer.NextInsertEventWriter(data.Dim); err == nil {
+			err = eventWriter.AddFloatVectorToPayload(data.Data, data.Dim)
+		}
+	case *BinaryVectorFieldData:
+		if eventWriter, err = writer.NextInsertEventWriter(data.Dim); err == nil {
+			err = eventWriter.AddBinaryVectorToPayload(data.Data, data.Dim)
+		}
+	case *Float16VectorFieldData:
+		if eventWriter, err = writer.NextInsertEventWriter(data.Dim); err == nil {
+			err = eventWriter.AddFloat16VectorToPayload(data.Data, data.Dim)
+		}
+	default:
+		return nil, fmt.Errorf("field %d of type %s is not a vector field", field.GetFieldID(), field.GetDataType().String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	eventWriter.SetEventTimestamp(startTs, endTs)
+	writer.AddExtra(originalSizeKey, fmt.Sprintf("%v", data.GetMemorySize()))
+	writer.SetEventTimeStamp(startTs, endTs)
+	if err := writer.Finish(); err != nil {
+		return nil, err
+	}
+	return writer.GetBuffer()
+}
+
 func (insertCodec *InsertCodec) DeserializeAll(blobs []*Blob) (
 	collectionID UniqueID,
 	partitionID UniqueID,
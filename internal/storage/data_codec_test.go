@@ -566,6 +566,34 @@ func TestTsError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSerializeVectorBinlog(t *testing.T) {
+	field := &schemapb.FieldSchema{
+		FieldID:    FloatVectorField,
+		Name:       "field_float_vector",
+		DataType:   schemapb.DataType_FloatVector,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+	}
+	data := &FloatVectorFieldData{Data: []float32{1, 2, 3, 4}, Dim: 2}
+	content, err := SerializeVectorBinlog(CollectionID, PartitionID, SegmentID, field, data, 10, 20)
+	assert.NoError(t, err)
+
+	reader, err := NewBinlogReader(content)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, reader.StartTimestamp)
+	assert.EqualValues(t, 20, reader.EndTimestamp)
+	assert.EqualValues(t, SegmentID, reader.SegmentID)
+	reader.Close()
+
+	insertData := &InsertData{Data: make(map[FieldID]FieldData)}
+	_, _, _, err = NewInsertCodec().DeserializeInto([]*Blob{{Key: "key", Value: content}}, 0, insertData)
+	assert.NoError(t, err)
+	assert.Equal(t, data, insertData.Data[FloatVectorField])
+
+	field.DataType = schemapb.DataType_Int64
+	_, err = SerializeVectorBinlog(CollectionID, PartitionID, SegmentID, field, &Int64FieldData{Data: []int64{1}}, 10, 20)
+	assert.Error(t, err)
+}
+
 func TestMemorySize(t *testing.T) {
 	insertData1 := &InsertData{
 		Data: map[int64]FieldData{
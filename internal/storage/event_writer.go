@@ -26,6 +26,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/compressor"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
@@ -213,15 +214,19 @@ func newDescriptorEvent() *descriptorEvent {
 }
 
 func newInsertEventWriter(dataType schemapb.DataType, dim ...int) (*insertEventWriter, error) {
+	return newInsertEventWriterWithCompression(dataType, defaultCompressType(dataType), dim...)
+}
+
+func newInsertEventWriterWithCompression(dataType schemapb.DataType, compressType compressor.CompressType, dim ...int) (*insertEventWriter, error) {
 	var payloadWriter PayloadWriterInterface
 	var err error
 	if typeutil.IsVectorType(dataType) {
 		if len(dim) != 1 {
 			return nil, fmt.Errorf("incorrect input numbers")
 		}
-		payloadWriter, err = NewPayloadWriter(dataType, dim[0])
+		payloadWriter, err = NewPayloadWriterWithCompression(dataType, compressType, dim[0])
 	} else {
-		payloadWriter, err = NewPayloadWriter(dataType)
+		payloadWriter, err = NewPayloadWriterWithCompression(dataType, compressType)
 	}
 	if err != nil {
 		return nil, err
@@ -2,22 +2,26 @@ package storage
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
 type ChunkManagerFactory struct {
 	persistentStorage string
 	config            *config
+	// configs of the extra buckets, see RoutingChunkManager
+	profiles map[string]*config
 }
 
 func NewChunkManagerFactoryWithParam(params *paramtable.ComponentParam) *ChunkManagerFactory {
 	if params.CommonCfg.StorageType.GetValue() == "local" {
 		return NewChunkManagerFactory("local", RootPath(params.LocalStorageCfg.Path.GetValue()))
 	}
-	return NewChunkManagerFactory(params.CommonCfg.StorageType.GetValue(),
+	opts := []Option{
 		RootPath(params.MinioCfg.RootPath.GetValue()),
 		Address(params.MinioCfg.Address.GetValue()),
 		AccessKeyID(params.MinioCfg.AccessKeyID.GetValue()),
@@ -30,7 +34,82 @@ func NewChunkManagerFactoryWithParam(params *paramtable.ComponentParam) *ChunkMa
 		UseVirtualHost(params.MinioCfg.UseVirtualHost.GetAsBool()),
 		Region(params.MinioCfg.Region.GetValue()),
 		RequestTimeout(params.MinioCfg.RequestTimeoutMs.GetAsInt64()),
-		CreateBucket(true))
+		CreateBucket(true),
+	}
+	f := NewChunkManagerFactory(params.CommonCfg.StorageType.GetValue(), opts...)
+	for name, profile := range params.MinioCfg.GetBucketProfiles() {
+		c := newDefaultConfig()
+		for _, opt := range opts {
+			opt(c)
+		}
+		applyBucketProfile(c, profile)
+		f.profiles[name] = c
+	}
+	return f
+}
+
+// applyBucketProfile overrides the config with the options specified by the bucket profile,
+// keys of the profile are in lower case.
+func applyBucketProfile(c *config, profile map[string]string) {
+	for key, value := range profile {
+		switch key {
+		case "address":
+			c.address = value
+		case "bucketname":
+			c.bucketName = value
+		case "rootpath":
+			c.rootPath = value
+		case "accesskeyid":
+			c.accessKeyID = value
+		case "secretaccesskey":
+			c.secretAccessKeyID = value
+		case "usessl":
+			c.useSSL, _ = strconv.ParseBool(value)
+		case "useiam":
+			c.useIAM, _ = strconv.ParseBool(value)
+		case "cloudprovider":
+			c.cloudProvider = value
+		case "iamendpoint":
+			c.iamEndpoint = value
+		case "region":
+			c.region = value
+		}
+	}
+}
+
+// BucketProfileStorageConfig returns the storage config of the bucket profile,
+// the options not specified by the profile inherit from the default storage config.
+func BucketProfileStorageConfig(base *indexpb.StorageConfig, profile map[string]string) *indexpb.StorageConfig {
+	c := &config{
+		address:           base.GetAddress(),
+		bucketName:        base.GetBucketName(),
+		accessKeyID:       base.GetAccessKeyID(),
+		secretAccessKeyID: base.GetSecretAccessKey(),
+		useSSL:            base.GetUseSSL(),
+		rootPath:          base.GetRootPath(),
+		useIAM:            base.GetUseIAM(),
+		cloudProvider:     base.GetCloudProvider(),
+		iamEndpoint:       base.GetIAMEndpoint(),
+		useVirtualHost:    base.GetUseVirtualHost(),
+		region:            base.GetRegion(),
+		requestTimeoutMs:  base.GetRequestTimeoutMs(),
+	}
+	applyBucketProfile(c, profile)
+	return &indexpb.StorageConfig{
+		Address:          c.address,
+		AccessKeyID:      c.accessKeyID,
+		SecretAccessKey:  c.secretAccessKeyID,
+		UseSSL:           c.useSSL,
+		BucketName:       c.bucketName,
+		RootPath:         c.rootPath,
+		UseIAM:           c.useIAM,
+		IAMEndpoint:      c.iamEndpoint,
+		StorageType:      base.GetStorageType(),
+		Region:           c.region,
+		UseVirtualHost:   c.useVirtualHost,
+		CloudProvider:    c.cloudProvider,
+		RequestTimeoutMs: c.requestTimeoutMs,
+	}
 }
 
 func NewChunkManagerFactory(persistentStorage string, opts ...Option) *ChunkManagerFactory {
@@ -41,24 +120,43 @@ func NewChunkManagerFactory(persistentStorage string, opts ...Option) *ChunkMana
 	return &ChunkManagerFactory{
 		persistentStorage: persistentStorage,
 		config:            c,
+		profiles:          make(map[string]*config),
 	}
 }
 
-func (f *ChunkManagerFactory) newChunkManager(ctx context.Context, engine string) (ChunkManager, error) {
+func (f *ChunkManagerFactory) newChunkManager(ctx context.Context, engine string, c *config) (ChunkManager, error) {
 	switch engine {
 	case "local":
-		return NewLocalChunkManager(RootPath(f.config.rootPath)), nil
+		return NewLocalChunkManager(RootPath(c.rootPath)), nil
 	case "minio", "opendal":
-		return newMinioChunkManagerWithConfig(ctx, f.config)
+		return newMinioChunkManagerWithConfig(ctx, c)
 	case "remote":
-		return NewRemoteChunkManager(ctx, f.config)
+		return NewRemoteChunkManager(ctx, c)
 	default:
 		return nil, errors.New("no chunk manager implemented with engine: " + engine)
 	}
 }
 
+// NewPersistentStorageChunkManager creates the chunk manager of the default bucket,
+// it's wrapped by RoutingChunkManager if any bucket profile is configured.
 func (f *ChunkManagerFactory) NewPersistentStorageChunkManager(ctx context.Context) (ChunkManager, error) {
-	return f.newChunkManager(ctx, f.persistentStorage)
+	cm, err := f.newChunkManager(ctx, f.persistentStorage, f.config)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.profiles) == 0 {
+		return cm, nil
+	}
+
+	profiles := make(map[string]ChunkManager, len(f.profiles))
+	for name, c := range f.profiles {
+		profileCM, err := f.newChunkManager(ctx, f.persistentStorage, c)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create chunk manager of bucket profile %s", name)
+		}
+		profiles[name] = profileCM
+	}
+	return NewRoutingChunkManager(cm, profiles), nil
 }
 
 type Factory interface {
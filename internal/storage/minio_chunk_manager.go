@@ -49,6 +49,8 @@ type MinioChunkManager struct {
 
 var _ ChunkManager = (*MinioChunkManager)(nil)
 
+var _ PresignedURLProvider = (*MinioChunkManager)(nil)
+
 // NewMinioChunkManager create a new local manager object.
 // Deprecated: Do not call this directly! Use factory.NewPersistentStorageChunkManager instead.
 func NewMinioChunkManager(ctx context.Context, opts ...Option) (*MinioChunkManager, error) {
@@ -124,6 +126,16 @@ func (mcm *MinioChunkManager) Size(ctx context.Context, filePath string) (int64,
 	return objectInfo.Size, nil
 }
 
+// PresignedURL returns the url to download the object without credentials.
+func (mcm *MinioChunkManager) PresignedURL(ctx context.Context, filePath string, expiry time.Duration) (string, error) {
+	u, err := mcm.Client.PresignedGetObject(ctx, mcm.bucketName, filePath, expiry, nil)
+	if err != nil {
+		log.Warn("failed to presign object", zap.String("bucket", mcm.bucketName), zap.String("path", filePath), zap.Error(err))
+		return "", checkObjectStorageError(filePath, err)
+	}
+	return u.String(), nil
+}
+
 // Write writes the data to minio storage.
 func (mcm *MinioChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
 	_, err := mcm.putMinioObject(ctx, mcm.bucketName, filePath, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
@@ -161,6 +161,14 @@ func (minioObjectStorage *MinioObjectStorage) GetObject(ctx context.Context, buc
 	return object, nil
 }
 
+func (minioObjectStorage *MinioObjectStorage) PresignGetObject(ctx context.Context, bucketName, objectName string, expiry time.Duration) (string, error) {
+	u, err := minioObjectStorage.Client.PresignedGetObject(ctx, bucketName, objectName, expiry, nil)
+	if err != nil {
+		return "", checkObjectStorageError(objectName, err)
+	}
+	return u.String(), nil
+}
+
 func (minioObjectStorage *MinioObjectStorage) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64) error {
 	_, err := minioObjectStorage.Client.PutObject(ctx, bucketName, objectName, reader, objectSize, minio.PutObjectOptions{})
 	return checkObjectStorageError(objectName, err)
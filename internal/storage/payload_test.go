@@ -17,8 +17,11 @@
 package storage
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
+	"github.com/apache/arrow/go/v12/parquet/file"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -1255,3 +1258,45 @@ func TestPayload_ReaderAndWriter(t *testing.T) {
 		w.ReleasePayloadWriter()
 	})
 }
+
+func TestPayload_DictionaryEncoding(t *testing.T) {
+	hasDictionaryPage := func(w PayloadWriterInterface) bool {
+		require.NoError(t, w.FinishPayloadWriter())
+		buffer, err := w.GetPayloadBufferFromWriter()
+		require.NoError(t, err)
+		reader, err := file.NewParquetReader(bytes.NewReader(buffer))
+		require.NoError(t, err)
+		defer reader.Close()
+		column, err := reader.MetaData().RowGroup(0).ColumnChunk(0)
+		require.NoError(t, err)
+		return column.HasDictionaryPage()
+	}
+
+	t.Run("low-cardinality strings", func(t *testing.T) {
+		w, err := NewPayloadWriter(schemapb.DataType_VarChar)
+		require.NoError(t, err)
+		defer w.ReleasePayloadWriter()
+		for i := 0; i < 1000; i++ {
+			require.NoError(t, w.AddOneStringToPayload(fmt.Sprintf("category-%d", i%4)))
+		}
+		assert.True(t, hasDictionaryPage(w))
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		w, err := NewPayloadWriter(schemapb.DataType_Int64)
+		require.NoError(t, err)
+		defer w.ReleasePayloadWriter()
+		for i := 0; i < 1000; i++ {
+			require.NoError(t, w.AddInt64ToPayload([]int64{int64(i % 4)}))
+		}
+		assert.False(t, hasDictionaryPage(w))
+	})
+
+	t.Run("vectors", func(t *testing.T) {
+		w, err := NewPayloadWriter(schemapb.DataType_FloatVector, 1)
+		require.NoError(t, err)
+		defer w.ReleasePayloadWriter()
+		require.NoError(t, w.AddFloatVectorToPayload([]float32{1, 1, 1, 1}, 1))
+		assert.False(t, hasDictionaryPage(w))
+	})
+}
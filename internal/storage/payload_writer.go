@@ -33,22 +33,34 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/compressor"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
 )
 
 var _ PayloadWriterInterface = (*NativePayloadWriter)(nil)
 
 type NativePayloadWriter struct {
-	dataType    schemapb.DataType
-	arrowType   arrow.DataType
-	builder     array.Builder
-	finished    bool
-	flushedRows int
-	output      *bytes.Buffer
-	releaseOnce sync.Once
+	dataType     schemapb.DataType
+	arrowType    arrow.DataType
+	builder      array.Builder
+	compressType compressor.CompressType
+	finished     bool
+	flushedRows  int
+	output       *bytes.Buffer
+	releaseOnce  sync.Once
 }
 
+// NewPayloadWriter creates a payload writer with the default codec of the column type
 func NewPayloadWriter(colType schemapb.DataType, dim ...int) (PayloadWriterInterface, error) {
+	return NewPayloadWriterWithCompression(colType, defaultCompressType(colType), dim...)
+}
+
+// NewPayloadWriterWithCompression creates a payload writer which compresses the column with compressType
+func NewPayloadWriterWithCompression(colType schemapb.DataType, compressType compressor.CompressType, dim ...int) (PayloadWriterInterface, error) {
+	if _, err := parquetCodec(compressType); err != nil {
+		return nil, err
+	}
+
 	var arrowType arrow.DataType
 	if typeutil.IsVectorType(colType) {
 		if len(dim) != 1 {
@@ -62,12 +74,13 @@ func NewPayloadWriter(colType schemapb.DataType, dim ...int) (PayloadWriterInter
 	builder := array.NewBuilder(memory.DefaultAllocator, arrowType)
 
 	return &NativePayloadWriter{
-		dataType:    colType,
-		arrowType:   arrowType,
-		builder:     builder,
-		finished:    false,
-		flushedRows: 0,
-		output:      new(bytes.Buffer),
+		dataType:     colType,
+		arrowType:    arrowType,
+		builder:      builder,
+		compressType: compressType,
+		finished:     false,
+		flushedRows:  0,
+		output:       new(bytes.Buffer),
 	}, nil
 }
 
@@ -468,10 +481,20 @@ func (w *NativePayloadWriter) FinishPayloadWriter() error {
 	table := array.NewTable(schema, []arrow.Column{column}, int64(column.Len()))
 	defer table.Release()
 
-	props := parquet.NewWriterProperties(
-		parquet.WithCompression(compress.Codecs.Zstd),
-		parquet.WithCompressionLevel(3),
-	)
+	codec, err := parquetCodec(w.compressType)
+	if err != nil {
+		return err
+	}
+	opts := []parquet.WriterProperty{
+		parquet.WithCompression(codec),
+		// parquet encodes all the columns by dictionary by default
+		parquet.WithDictionaryDefault(false),
+		parquet.WithDictionaryFor(field.Name, dictionaryEncodable(w.dataType)),
+	}
+	if codec == compress.Codecs.Zstd {
+		opts = append(opts, parquet.WithCompressionLevel(3))
+	}
+	props := parquet.NewWriterProperties(opts...)
 	return pqarrow.WriteTable(table,
 		w.output,
 		1024*1024*1024,
@@ -505,6 +528,14 @@ func (w *NativePayloadWriter) Close() {
 	w.ReleasePayloadWriter()
 }
 
+// dictionaryEncodable returns whether the column is dictionary encoded in binlogs,
+// which shrinks the low-cardinality strings, parquet falls back to plain encoding
+// once the dictionary page exceeds the limit. The other types are hardly repeated
+// or compressed well enough, dictionary encoding them only costs memory.
+func dictionaryEncodable(dataType schemapb.DataType) bool {
+	return typeutil.IsStringType(dataType)
+}
+
 func milvusDataTypeToArrowType(dataType schemapb.DataType, dim int) arrow.DataType {
 	switch dataType {
 	case schemapb.DataType_Bool:
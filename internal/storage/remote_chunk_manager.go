@@ -53,6 +53,11 @@ type ObjectStorage interface {
 	RemoveObject(ctx context.Context, bucketName, objectName string) error
 }
 
+// objectPresigner is implemented by the object storages supporting pre-signed urls.
+type objectPresigner interface {
+	PresignGetObject(ctx context.Context, bucketName, objectName string, expiry time.Duration) (string, error)
+}
+
 // RemoteChunkManager is responsible for read and write data stored in minio.
 type RemoteChunkManager struct {
 	client ObjectStorage
@@ -64,6 +69,8 @@ type RemoteChunkManager struct {
 
 var _ ChunkManager = (*RemoteChunkManager)(nil)
 
+var _ PresignedURLProvider = (*RemoteChunkManager)(nil)
+
 func NewRemoteChunkManager(ctx context.Context, c *config) (*RemoteChunkManager, error) {
 	var client ObjectStorage
 	var err error
@@ -111,6 +118,20 @@ func (mcm *RemoteChunkManager) Reader(ctx context.Context, filePath string) (Fil
 	return reader, nil
 }
 
+// PresignedURL returns the url to download the object without credentials.
+func (mcm *RemoteChunkManager) PresignedURL(ctx context.Context, filePath string, expiry time.Duration) (string, error) {
+	presigner, ok := mcm.client.(objectPresigner)
+	if !ok {
+		return "", merr.WrapErrServiceUnavailable("pre-signed url is not supported by the object storage")
+	}
+	url, err := presigner.PresignGetObject(ctx, mcm.bucketName, filePath, expiry)
+	if err != nil {
+		log.Warn("failed to presign object", zap.String("bucket", mcm.bucketName), zap.String("path", filePath), zap.Error(err))
+		return "", err
+	}
+	return url, nil
+}
+
 func (mcm *RemoteChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
 	objectInfo, err := mcm.getObjectSize(ctx, mcm.bucketName, filePath)
 	if err != nil {
@@ -0,0 +1,455 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/exp/mmap"
+
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// StorageRoutePath is the path of the objects recording which bucket profile a collection is routed to.
+const StorageRoutePath = "storage_routes"
+
+var _ ChunkManager = (*RoutingChunkManager)(nil)
+
+// RoutingChunkManager routes the binlogs of collections to different buckets.
+// The route of each collection is persisted in the default bucket, so that all the components share the same routes.
+// Paths are always under the root path of the default chunk manager, and they are
+// translated to the root path of the profile chunk manager on access.
+// Index files don't contain the collection ID in the path, so they are always stored in the default bucket.
+// Segcore reads the binlogs by itself, so the routes are passed to it on segment loading and index building.
+type RoutingChunkManager struct {
+	defaultCM ChunkManager
+	profiles  map[string]ChunkManager
+
+	mu     sync.RWMutex
+	routes map[int64]string // collectionID -> profile, empty profile means the default bucket
+}
+
+func NewRoutingChunkManager(defaultCM ChunkManager, profiles map[string]ChunkManager) *RoutingChunkManager {
+	return &RoutingChunkManager{
+		defaultCM: defaultCM,
+		profiles:  profiles,
+		routes:    make(map[int64]string),
+	}
+}
+
+// RouteCollection routes the collection to the profile if the chunk manager supports routing,
+// it's a no-op if the profile is empty.
+func RouteCollection(ctx context.Context, cm ChunkManager, collectionID int64, profile string) error {
+	rcm, ok := cm.(*RoutingChunkManager)
+	if !ok || profile == "" {
+		return nil
+	}
+	return rcm.SetRoute(ctx, collectionID, profile)
+}
+
+// GetRoute returns the bucket profile the collection is routed to,
+// empty if the chunk manager doesn't route or the collection is stored in the default bucket.
+func GetRoute(ctx context.Context, cm ChunkManager, collectionID int64) (string, error) {
+	rcm, ok := cm.(*RoutingChunkManager)
+	if !ok {
+		return "", nil
+	}
+	return rcm.getRoute(ctx, collectionID)
+}
+
+// HasProfile returns whether the bucket profile is configured.
+func (rcm *RoutingChunkManager) HasProfile(profile string) bool {
+	_, ok := rcm.profiles[profile]
+	return ok
+}
+
+// Profile returns the chunk manager of the bucket profile.
+func (rcm *RoutingChunkManager) Profile(profile string) (ChunkManager, bool) {
+	cm, ok := rcm.profiles[profile]
+	return cm, ok
+}
+
+// SetRoute routes the data of collection to the bucket profile, the route can't be changed once it's set.
+func (rcm *RoutingChunkManager) SetRoute(ctx context.Context, collectionID int64, profile string) error {
+	if !rcm.HasProfile(profile) {
+		return merr.WrapErrParameterInvalidMsg("bucket profile %s not found", profile)
+	}
+	current, err := rcm.getRoute(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	if current == profile {
+		return nil
+	}
+	if current != "" {
+		return merr.WrapErrParameterInvalidMsg("collection %d has been routed to bucket profile %s", collectionID, current)
+	}
+
+	if err := rcm.defaultCM.Write(ctx, rcm.routePath(collectionID), []byte(profile)); err != nil {
+		return err
+	}
+	rcm.mu.Lock()
+	rcm.routes[collectionID] = profile
+	rcm.mu.Unlock()
+	log.Info("route collection to bucket profile", zap.Int64("collectionID", collectionID), zap.String("profile", profile))
+	return nil
+}
+
+// PinRoute records the route in memory without persisting it,
+// it's used by the components which get the route from the others, e.g. index nodes.
+func (rcm *RoutingChunkManager) PinRoute(collectionID int64, profile string) {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	rcm.routes[collectionID] = profile
+}
+
+func (rcm *RoutingChunkManager) routePath(collectionID int64) string {
+	return path.Join(rcm.defaultCM.RootPath(), StorageRoutePath, strconv.FormatInt(collectionID, 10))
+}
+
+// getRoute returns the profile of the collection, empty if it's stored in the default bucket.
+// Only the routes found are cached, a route may be set by the other components after a miss.
+func (rcm *RoutingChunkManager) getRoute(ctx context.Context, collectionID int64) (string, error) {
+	rcm.mu.RLock()
+	profile, ok := rcm.routes[collectionID]
+	rcm.mu.RUnlock()
+	if ok {
+		return profile, nil
+	}
+
+	routePath := rcm.routePath(collectionID)
+	exist, err := rcm.defaultCM.Exist(ctx, routePath)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", nil
+	}
+	value, err := rcm.defaultCM.Read(ctx, routePath)
+	if err != nil {
+		return "", err
+	}
+	profile = string(value)
+	if !rcm.HasProfile(profile) {
+		return "", fmt.Errorf("collection %d is routed to unknown bucket profile %s", collectionID, profile)
+	}
+
+	rcm.mu.Lock()
+	rcm.routes[collectionID] = profile
+	rcm.mu.Unlock()
+	return profile, nil
+}
+
+// parseCollectionID parses the collection ID from the path of insert/delta/stats logs,
+// the collection ID must be a complete path element, so `insert_log/1` doesn't match.
+func (rcm *RoutingChunkManager) parseCollectionID(filePath string) (int64, bool) {
+	rel := strings.TrimPrefix(strings.TrimPrefix(filePath, rcm.defaultCM.RootPath()), "/")
+	parts := strings.Split(rel, "/")
+	if len(parts) < 3 {
+		return 0, false
+	}
+	switch parts[0] {
+	case common.SegmentInsertLogPath, common.SegmentDeltaLogPath, common.SegmentStatslogPath:
+	default:
+		return 0, false
+	}
+	collectionID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return collectionID, true
+}
+
+// resolve returns the chunk manager storing the path, nil means the path could be in any bucket.
+func (rcm *RoutingChunkManager) resolve(ctx context.Context, filePath string) (ChunkManager, error) {
+	collectionID, ok := rcm.parseCollectionID(filePath)
+	if !ok {
+		return nil, nil
+	}
+	profile, err := rcm.getRoute(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		return rcm.defaultCM, nil
+	}
+	return rcm.profiles[profile], nil
+}
+
+// resolveOrDefault returns the chunk manager storing the path, paths not belonging to any collection are in the default bucket.
+func (rcm *RoutingChunkManager) resolveOrDefault(ctx context.Context, filePath string) (ChunkManager, error) {
+	cm, err := rcm.resolve(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if cm == nil {
+		return rcm.defaultCM, nil
+	}
+	return cm, nil
+}
+
+func (rcm *RoutingChunkManager) all() []ChunkManager {
+	cms := make([]ChunkManager, 0, len(rcm.profiles)+1)
+	cms = append(cms, rcm.defaultCM)
+	for _, cm := range rcm.profiles {
+		cms = append(cms, cm)
+	}
+	return cms
+}
+
+// toRouted translates the path to the one under the root path of cm.
+func (rcm *RoutingChunkManager) toRouted(cm ChunkManager, filePath string) string {
+	if cm == rcm.defaultCM || cm.RootPath() == rcm.defaultCM.RootPath() {
+		return filePath
+	}
+	rel := strings.TrimPrefix(filePath, rcm.defaultCM.RootPath())
+	routed := path.Join(cm.RootPath(), rel)
+	if strings.HasSuffix(filePath, "/") {
+		routed += "/"
+	}
+	return routed
+}
+
+// fromRouted translates the path under the root path of cm back to the one under the default root path.
+func (rcm *RoutingChunkManager) fromRouted(cm ChunkManager, filePath string) string {
+	if cm == rcm.defaultCM || cm.RootPath() == rcm.defaultCM.RootPath() {
+		return filePath
+	}
+	rel := strings.TrimPrefix(filePath, cm.RootPath())
+	original := path.Join(rcm.defaultCM.RootPath(), rel)
+	if strings.HasSuffix(filePath, "/") {
+		original += "/"
+	}
+	return original
+}
+
+func (rcm *RoutingChunkManager) RootPath() string {
+	return rcm.defaultCM.RootPath()
+}
+
+func (rcm *RoutingChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return "", err
+	}
+	return cm.Path(ctx, rcm.toRouted(cm, filePath))
+}
+
+func (rcm *RoutingChunkManager) PresignedURL(ctx context.Context, filePath string, expiry time.Duration) (string, error) {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return "", err
+	}
+	presigner, ok := cm.(PresignedURLProvider)
+	if !ok {
+		return "", merr.WrapErrServiceUnavailable("pre-signed url is not supported by the object storage")
+	}
+	return presigner.PresignedURL(ctx, rcm.toRouted(cm, filePath), expiry)
+}
+
+func (rcm *RoutingChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return 0, err
+	}
+	return cm.Size(ctx, rcm.toRouted(cm, filePath))
+}
+
+func (rcm *RoutingChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	return cm.Write(ctx, rcm.toRouted(cm, filePath), content)
+}
+
+func (rcm *RoutingChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	groups := make(map[ChunkManager]map[string][]byte)
+	for filePath, content := range contents {
+		cm, err := rcm.resolveOrDefault(ctx, filePath)
+		if err != nil {
+			return err
+		}
+		if _, ok := groups[cm]; !ok {
+			groups[cm] = make(map[string][]byte)
+		}
+		groups[cm][rcm.toRouted(cm, filePath)] = content
+	}
+	for cm, group := range groups {
+		if err := cm.MultiWrite(ctx, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rcm *RoutingChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return false, err
+	}
+	return cm.Exist(ctx, rcm.toRouted(cm, filePath))
+}
+
+func (rcm *RoutingChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return cm.Read(ctx, rcm.toRouted(cm, filePath))
+}
+
+func (rcm *RoutingChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return cm.Reader(ctx, rcm.toRouted(cm, filePath))
+}
+
+func (rcm *RoutingChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	results := make([][]byte, len(filePaths))
+	for i, filePath := range filePaths {
+		content, err := rcm.Read(ctx, filePath)
+		if err != nil {
+			return results, err
+		}
+		results[i] = content
+	}
+	return results, nil
+}
+
+// ListWithPrefix lists the files in the bucket of the collection if the prefix belongs to one,
+// otherwise lists the files in all the buckets.
+func (rcm *RoutingChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	cms, err := rcm.candidates(ctx, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		filePaths []string
+		modTimes  []time.Time
+	)
+	for _, cm := range cms {
+		paths, times, err := cm.ListWithPrefix(ctx, rcm.toRouted(cm, prefix), recursive)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, p := range paths {
+			filePaths = append(filePaths, rcm.fromRouted(cm, p))
+		}
+		modTimes = append(modTimes, times...)
+	}
+	return filePaths, modTimes, nil
+}
+
+func (rcm *RoutingChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	cms, err := rcm.candidates(ctx, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		filePaths []string
+		contents  [][]byte
+	)
+	for _, cm := range cms {
+		paths, values, err := cm.ReadWithPrefix(ctx, rcm.toRouted(cm, prefix))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, p := range paths {
+			filePaths = append(filePaths, rcm.fromRouted(cm, p))
+		}
+		contents = append(contents, values...)
+	}
+	return filePaths, contents, nil
+}
+
+func (rcm *RoutingChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return cm.Mmap(ctx, rcm.toRouted(cm, filePath))
+}
+
+func (rcm *RoutingChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return cm.ReadAt(ctx, rcm.toRouted(cm, filePath), off, length)
+}
+
+func (rcm *RoutingChunkManager) Remove(ctx context.Context, filePath string) error {
+	cm, err := rcm.resolveOrDefault(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	return cm.Remove(ctx, rcm.toRouted(cm, filePath))
+}
+
+func (rcm *RoutingChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	groups := make(map[ChunkManager][]string)
+	for _, filePath := range filePaths {
+		cm, err := rcm.resolveOrDefault(ctx, filePath)
+		if err != nil {
+			return err
+		}
+		groups[cm] = append(groups[cm], rcm.toRouted(cm, filePath))
+	}
+	for cm, group := range groups {
+		if err := cm.MultiRemove(ctx, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rcm *RoutingChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	cms, err := rcm.candidates(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	for _, cm := range cms {
+		if err := cm.RemoveWithPrefix(ctx, rcm.toRouted(cm, prefix)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// candidates returns the chunk managers which may store files with the prefix.
+func (rcm *RoutingChunkManager) candidates(ctx context.Context, prefix string) ([]ChunkManager, error) {
+	cm, err := rcm.resolve(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if cm != nil {
+		return []ChunkManager{cm}, nil
+	}
+	return rcm.all(), nil
+}
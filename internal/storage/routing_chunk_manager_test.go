@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/metautil"
+)
+
+func TestRoutingChunkManager(t *testing.T) {
+	ctx := context.Background()
+	defaultRoot := t.TempDir()
+	euRoot := t.TempDir()
+	defaultCM := NewLocalChunkManager(RootPath(defaultRoot))
+	euCM := NewLocalChunkManager(RootPath(euRoot))
+
+	rcm := NewRoutingChunkManager(defaultCM, map[string]ChunkManager{"eu": euCM})
+	assert.Equal(t, defaultRoot, rcm.RootPath())
+	assert.True(t, rcm.HasProfile("eu"))
+	assert.False(t, rcm.HasProfile("us"))
+
+	err := rcm.SetRoute(ctx, 1, "us")
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	require.NoError(t, RouteCollection(ctx, rcm, 1, "eu"))
+	// no-op for empty profile and non-routing chunk manager
+	assert.NoError(t, RouteCollection(ctx, rcm, 2, ""))
+	assert.NoError(t, RouteCollection(ctx, defaultCM, 2, "eu"))
+
+	routed := metautil.BuildInsertLogPath(defaultRoot, 1, 10, 100, 101, 1000)
+	unrouted := metautil.BuildInsertLogPath(defaultRoot, 2, 20, 200, 101, 2000)
+	require.NoError(t, rcm.MultiWrite(ctx, map[string][]byte{
+		routed:   []byte("routed"),
+		unrouted: []byte("unrouted"),
+	}))
+
+	// the routed file is stored under the root path of the profile
+	exist, err := euCM.Exist(ctx, metautil.BuildInsertLogPath(euRoot, 1, 10, 100, 101, 1000))
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	exist, err = defaultCM.Exist(ctx, routed)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	exist, err = defaultCM.Exist(ctx, unrouted)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	contents, err := rcm.MultiRead(ctx, []string{routed, unrouted})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("routed"), []byte("unrouted")}, contents)
+
+	// listing the whole log type merges all the buckets
+	files, _, err := rcm.ListWithPrefix(ctx, path.Join(defaultRoot, "insert_log")+"/", true)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{routed, unrouted}, files)
+	files, _, err = rcm.ListWithPrefix(ctx, path.Join(defaultRoot, "insert_log", "1")+"/", true)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{routed}, files)
+
+	// a new routing chunk manager loads the persisted route
+	rcm2 := NewRoutingChunkManager(defaultCM, map[string]ChunkManager{"eu": euCM})
+	content, err := rcm2.Read(ctx, routed)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("routed"), content)
+	size, err := rcm2.Size(ctx, routed)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 6, size)
+
+	// the route can't be changed
+	rcm3 := NewRoutingChunkManager(defaultCM, map[string]ChunkManager{"eu": euCM, "us": NewLocalChunkManager(RootPath(t.TempDir()))})
+	err = rcm3.SetRoute(ctx, 1, "us")
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	// the route is unknown without the profile
+	rcm4 := NewRoutingChunkManager(defaultCM, map[string]ChunkManager{})
+	_, err = rcm4.Read(ctx, routed)
+	assert.Error(t, err)
+
+	require.NoError(t, rcm.MultiRemove(ctx, []string{routed, unrouted}))
+	files, _, err = rcm.ListWithPrefix(ctx, path.Join(defaultRoot, "insert_log")+"/", true)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestRoutingChunkManagerRouteMiss(t *testing.T) {
+	ctx := context.Background()
+	defaultRoot := t.TempDir()
+	defaultCM := NewLocalChunkManager(RootPath(defaultRoot))
+	euCM := NewLocalChunkManager(RootPath(t.TempDir()))
+
+	reader := NewRoutingChunkManager(defaultCM, map[string]ChunkManager{"eu": euCM})
+	profile, err := GetRoute(ctx, reader, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, profile)
+
+	// the route set by another component after a miss is visible
+	writer := NewRoutingChunkManager(defaultCM, map[string]ChunkManager{"eu": euCM})
+	require.NoError(t, writer.SetRoute(ctx, 1, "eu"))
+	profile, err = GetRoute(ctx, reader, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "eu", profile)
+
+	profile, err = GetRoute(ctx, defaultCM, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, profile)
+
+	// pinned route is not persisted
+	pinned := NewRoutingChunkManager(defaultCM, map[string]ChunkManager{"eu": euCM})
+	pinned.PinRoute(2, "eu")
+	profile, err = GetRoute(ctx, pinned, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "eu", profile)
+	profile, err = GetRoute(ctx, reader, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, profile)
+}
+
+func TestBucketProfileStorageConfig(t *testing.T) {
+	base := &indexpb.StorageConfig{
+		Address:     "localhost:9000",
+		BucketName:  "a-bucket",
+		AccessKeyID: "minioadmin",
+		RootPath:    "files",
+		StorageType: "minio",
+		Region:      "us-east-1",
+	}
+	config := BucketProfileStorageConfig(base, map[string]string{
+		"bucketname": "eu-bucket",
+		"region":     "eu-west-1",
+		"usessl":     "true",
+	})
+	assert.Equal(t, "eu-bucket", config.GetBucketName())
+	assert.Equal(t, "eu-west-1", config.GetRegion())
+	assert.True(t, config.GetUseSSL())
+	// inherited from the default bucket
+	assert.Equal(t, "localhost:9000", config.GetAddress())
+	assert.Equal(t, "minioadmin", config.GetAccessKeyID())
+	assert.Equal(t, "files", config.GetRootPath())
+	assert.Equal(t, "minio", config.GetStorageType())
+}
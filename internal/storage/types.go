@@ -28,6 +28,10 @@ const (
 	// CompundStatsType log save multiple stats
 	// and bloom filters to one file
 	CompoundStatsType
+
+	// FieldStatsType log saves the statistics of a scalar field
+	// of a flushed segment, e.g. min/max, ndv and histogram
+	FieldStatsType
 )
 
 func (s StatsLogType) LogIdx() string {
@@ -77,3 +81,9 @@ type ChunkManager interface {
 	// RemoveWithPrefix remove files with same @prefix.
 	RemoveWithPrefix(ctx context.Context, prefix string) error
 }
+
+// PresignedURLProvider is implemented by the chunk managers which could sign urls to download objects directly.
+type PresignedURLProvider interface {
+	// PresignedURL returns the url to download @filePath without credentials, it expires after @expiry.
+	PresignedURL(ctx context.Context, filePath string, expiry time.Duration) (string, error)
+}
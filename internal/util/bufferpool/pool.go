@@ -0,0 +1,93 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bufferpool provides the pools of byte slices reused across requests,
+// e.g. the serialized search results, to cut the GC pressure under high QPS.
+package bufferpool
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/milvus-io/milvus/pkg/metrics"
+)
+
+const (
+	// the size classes are the powers of two from 1KB to 64MB,
+	// the same as the buckets of the request size histogram.
+	minClassShift = 10
+	maxClassShift = 26
+	numClasses    = maxClassShift - minClassShift + 1
+)
+
+// SearchResultPool is the pool of the serialized search results shared by the query node and proxy,
+// the query node allocates the results from it, and the results are recycled after decoded.
+var SearchResultPool = NewPool("search_result")
+
+// Pool is a set of sync.Pool for each size class, the buffers larger than the max class are not pooled.
+type Pool struct {
+	name    string
+	classes [numClasses]sync.Pool
+}
+
+func NewPool(name string) *Pool {
+	return &Pool{name: name}
+}
+
+// Get returns an empty buffer of which the capacity is at least size.
+func (p *Pool) Get(size int) []byte {
+	metrics.BufferPoolRequestSize.WithLabelValues(p.name).Observe(float64(size))
+	idx := classOf(size)
+	if idx < 0 {
+		metrics.BufferPoolRequestCount.WithLabelValues(p.name, metrics.BufferPoolMissLabel).Inc()
+		return make([]byte, 0, size)
+	}
+	if buf, ok := p.classes[idx].Get().(*[]byte); ok {
+		metrics.BufferPoolRequestCount.WithLabelValues(p.name, metrics.BufferPoolHitLabel).Inc()
+		return (*buf)[:0]
+	}
+	metrics.BufferPoolRequestCount.WithLabelValues(p.name, metrics.BufferPoolMissLabel).Inc()
+	return make([]byte, 0, classSize(idx))
+}
+
+// Put recycles the buffer, the caller must not use it anymore.
+func (p *Pool) Put(buf []byte) {
+	size := cap(buf)
+	if size < 1<<minClassShift || size > 1<<maxClassShift {
+		return
+	}
+	// the buffer is put into the largest class it could serve.
+	idx := bits.Len(uint(size)) - 1 - minClassShift
+	buf = buf[:0]
+	p.classes[idx].Put(&buf)
+	metrics.BufferPoolRecycledSize.WithLabelValues(p.name).Add(float64(size))
+}
+
+// classOf returns the index of the smallest class not smaller than size, -1 if it's too large.
+func classOf(size int) int {
+	if size <= 1<<minClassShift {
+		return 0
+	}
+	shift := bits.Len(uint(size - 1))
+	if shift > maxClassShift {
+		return -1
+	}
+	return shift - minClassShift
+}
+
+func classSize(idx int) int {
+	return 1 << (idx + minClassShift)
+}
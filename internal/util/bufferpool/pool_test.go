@@ -0,0 +1,58 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassOf(t *testing.T) {
+	assert.Equal(t, 0, classOf(0))
+	assert.Equal(t, 0, classOf(1024))
+	assert.Equal(t, 1, classOf(1025))
+	assert.Equal(t, 1, classOf(2048))
+	assert.Equal(t, numClasses-1, classOf(1<<maxClassShift))
+	assert.Equal(t, -1, classOf(1<<maxClassShift+1))
+}
+
+func TestPool(t *testing.T) {
+	p := NewPool("test")
+
+	buf := p.Get(1500)
+	assert.Equal(t, 0, len(buf))
+	assert.Equal(t, 2048, cap(buf))
+
+	buf = append(buf, "hello"...)
+	p.Put(buf)
+	reused := p.Get(2000)
+	assert.Equal(t, 0, len(reused))
+	assert.GreaterOrEqual(t, cap(reused), 2000)
+
+	// too large buffers are not pooled
+	large := p.Get(1<<maxClassShift + 1)
+	assert.Equal(t, 1<<maxClassShift+1, cap(large))
+	p.Put(large)
+
+	// too small buffers are dropped
+	p.Put(make([]byte, 0, 100))
+
+	// the buffer serves the largest class not larger than its capacity
+	p.Put(make([]byte, 0, 3000))
+	assert.GreaterOrEqual(t, cap(p.Get(2048)), 2048)
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
 
@@ -19,6 +21,7 @@ const (
 	mqTypeRocksmq = "rocksmq"
 	mqTypeKafka   = "kafka"
 	mqTypePulsar  = "pulsar"
+	mqTypeWalmq   = "walmq"
 )
 
 type mqEnable struct {
@@ -26,6 +29,7 @@ type mqEnable struct {
 	Natsmq  bool
 	Pulsar  bool
 	Kafka   bool
+	Walmq   bool
 }
 
 // DefaultFactory is a factory that produces instances of storage.ChunkManager and message queue.
@@ -64,7 +68,8 @@ func NewFactory(standAlone bool) *DefaultFactory {
 // In order to guarantee backward compatibility of config file, we still support multiple mq configs.
 // The initialization of MQ follows the following rules, if the mq.type is default.
 // 1. standalone(local) mode: rocksmq(default) > natsmq > Pulsar > Kafka
-// 2. cluster mode:  Pulsar(default) > Kafka (rocksmq and natsmq is unsupported in cluster mode)
+// 2. cluster mode:  Pulsar(default) > Kafka (rocksmq, natsmq and walmq is unsupported in cluster mode)
+// walmq is never selected by default, it's used only if mq.type is walmq.
 func (f *DefaultFactory) Init(params *paramtable.ComponentParam) {
 	// skip if using default factory
 	if f.msgStreamFactory != nil {
@@ -80,7 +85,7 @@ func (f *DefaultFactory) Init(params *paramtable.ComponentParam) {
 }
 
 func (f *DefaultFactory) initMQ(standalone bool, params *paramtable.ComponentParam) error {
-	mqType := mustSelectMQType(standalone, params.MQCfg.Type.GetValue(), mqEnable{params.RocksmqEnable(), params.NatsmqEnable(), params.PulsarEnable(), params.KafkaEnable()})
+	mqType := mustSelectMQType(standalone, params.MQCfg.Type.GetValue(), mqEnable{params.RocksmqEnable(), params.NatsmqEnable(), params.PulsarEnable(), params.KafkaEnable(), params.WalmqEnable()})
 	log.Info("try to init mq", zap.Bool("standalone", standalone), zap.String("mqType", mqType))
 
 	switch mqType {
@@ -92,6 +97,8 @@ func (f *DefaultFactory) initMQ(standalone bool, params *paramtable.ComponentPar
 		f.msgStreamFactory = msgstream.NewPmsFactory(&params.ServiceParam)
 	case mqTypeKafka:
 		f.msgStreamFactory = msgstream.NewKmsFactory(&params.ServiceParam)
+	case mqTypeWalmq:
+		f.msgStreamFactory = smsgstream.NewWalmqFactory(params.WalmqCfg.Path.GetValue(), &params.ServiceParam)
 	}
 	if f.msgStreamFactory == nil {
 		return errors.New("failed to create MQ: check the milvus log for initialization failures")
@@ -105,6 +112,9 @@ func mustSelectMQType(standalone bool, mqType string, enable mqEnable) string {
 		if err := validateMQType(standalone, mqType); err != nil {
 			panic(err)
 		}
+		if mqType == mqTypeWalmq && !enable.Walmq {
+			panic(errors.New("mq walmq is selected but walmq.path is empty"))
+		}
 		return mqType
 	}
 
@@ -125,11 +135,11 @@ func mustSelectMQType(standalone bool, mqType string, enable mqEnable) string {
 
 // Validate mq type.
 func validateMQType(standalone bool, mqType string) error {
-	if mqType != mqTypeNatsmq && mqType != mqTypeRocksmq && mqType != mqTypeKafka && mqType != mqTypePulsar {
+	if mqType != mqTypeNatsmq && mqType != mqTypeRocksmq && mqType != mqTypeKafka && mqType != mqTypePulsar && mqType != mqTypeWalmq {
 		return errors.Newf("mq type %s is invalid", mqType)
 	}
-	if !standalone && (mqType == mqTypeRocksmq || mqType == mqTypeNatsmq) {
-		return errors.Newf("mq %s is only valid in standalone mode")
+	if !standalone && (mqType == mqTypeRocksmq || mqType == mqTypeNatsmq || mqType == mqTypeWalmq) {
+		return errors.Newf("mq %s is only valid in standalone mode", mqType)
 	}
 	return nil
 }
@@ -146,6 +156,24 @@ func (f *DefaultFactory) NewMsgStreamDisposer(ctx context.Context) func([]string
 	return f.msgStreamFactory.NewMsgStreamDisposer(ctx)
 }
 
+// DeleteTopics deletes the physical topics if the message queue supports it.
+func (f *DefaultFactory) DeleteTopics(ctx context.Context, topics []string) error {
+	deleter, ok := f.msgStreamFactory.(msgstream.TopicDeleter)
+	if !ok {
+		return merr.WrapErrServiceUnimplemented(errors.New("the message queue doesn't support deleting topics"))
+	}
+	return deleter.DeleteTopics(ctx, topics)
+}
+
+// NewClient creates a raw client of the message queue if the factory supports it.
+func (f *DefaultFactory) NewClient(ctx context.Context) (mqwrapper.Client, error) {
+	creator, ok := f.msgStreamFactory.(msgstream.ClientCreator)
+	if !ok {
+		return nil, merr.WrapErrServiceUnimplemented(errors.New("the message queue doesn't support creating raw clients"))
+	}
+	return creator.NewClient(ctx)
+}
+
 func (f *DefaultFactory) NewPersistentStorageChunkManager(ctx context.Context) (storage.ChunkManager, error) {
 	return f.chunkManagerFactory.NewPersistentStorageChunkManager(ctx)
 }
@@ -0,0 +1,130 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// Sink delivers the events to the outside.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event *Event) error
+	Close()
+}
+
+type sinkWorker struct {
+	sink Sink
+	ch   chan *Event
+}
+
+// Bus publishes the events to the sinks asynchronously,
+// each sink has its own queue so that a slow sink doesn't block the others.
+type Bus struct {
+	workers []*sinkWorker
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	closed    atomic.Bool
+	mu        sync.RWMutex
+}
+
+// NewBus creates a bus and starts to deliver the events to the sinks,
+// the events are dropped if the queue of a sink is full.
+func NewBus(bufferSize int, sinks ...Sink) *Bus {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &Bus{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for _, sink := range sinks {
+		w := &sinkWorker{sink: sink, ch: make(chan *Event, bufferSize)}
+		b.workers = append(b.workers, w)
+		b.wg.Add(1)
+		go b.work(w)
+	}
+	return b
+}
+
+func (b *Bus) work(w *sinkWorker) {
+	defer b.wg.Done()
+	for event := range w.ch {
+		if err := w.sink.Send(b.ctx, event); err != nil {
+			log.RatedWarn(10, "failed to send event", zap.String("sink", w.sink.Name()),
+				zap.String("type", string(event.Type)), zap.Error(err))
+		}
+	}
+}
+
+// Publish puts the event into the queues of the sinks without blocking.
+func (b *Bus) Publish(event *Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed.Load() {
+		return
+	}
+	for _, w := range b.workers {
+		select {
+		case w.ch <- event:
+		default:
+			log.RatedWarn(10, "event queue is full, drop the event", zap.String("sink", w.sink.Name()),
+				zap.String("type", string(event.Type)))
+		}
+	}
+}
+
+// Close delivers the queued events and closes the sinks.
+func (b *Bus) Close() {
+	b.closeOnce.Do(func() {
+		b.mu.Lock()
+		b.closed.Store(true)
+		for _, w := range b.workers {
+			close(w.ch)
+		}
+		b.mu.Unlock()
+		b.wg.Wait()
+		b.cancel()
+		for _, w := range b.workers {
+			w.sink.Close()
+		}
+	})
+}
+
+var defaultBus atomic.Pointer[Bus]
+
+// SetDefault sets the bus used by Publish, nil disables publishing.
+func SetDefault(b *Bus) {
+	defaultBus.Store(b)
+}
+
+// Publish publishes the event of the current node by the default bus,
+// it's a no-op if the event bus is disabled.
+func Publish(typ EventType, collectionID int64, attributes map[string]any) {
+	b := defaultBus.Load()
+	if b == nil {
+		return
+	}
+	b.Publish(NewEvent(typ, collectionID, attributes))
+}
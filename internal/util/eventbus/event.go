@@ -0,0 +1,58 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// EventType is the type of the cluster lifecycle event.
+type EventType string
+
+const (
+	SegmentSealed      EventType = "SegmentSealed"
+	CompactionFinished EventType = "CompactionFinished"
+	IndexBuilt         EventType = "IndexBuilt"
+	NodeDown           EventType = "NodeDown"
+	BalanceExecuted    EventType = "BalanceExecuted"
+	QuotaTriggered     EventType = "QuotaTriggered"
+	TimeTickLagging    EventType = "TimeTickLagging"
+)
+
+// Event is a structured cluster lifecycle event.
+type Event struct {
+	Type         EventType      `json:"type"`
+	Time         time.Time      `json:"time"`
+	Role         string         `json:"role"`
+	NodeID       int64          `json:"node_id"`
+	CollectionID int64          `json:"collection_id,omitempty"`
+	Attributes   map[string]any `json:"attributes,omitempty"`
+}
+
+// NewEvent returns an event published by the current node.
+func NewEvent(typ EventType, collectionID int64, attributes map[string]any) *Event {
+	return &Event{
+		Type:         typ,
+		Time:         time.Now(),
+		Role:         paramtable.GetRole(),
+		NodeID:       paramtable.GetNodeID(),
+		CollectionID: collectionID,
+		Attributes:   attributes,
+	}
+}
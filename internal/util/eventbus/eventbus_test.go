@@ -0,0 +1,129 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+type mockSink struct {
+	mu     sync.Mutex
+	events []*Event
+	block  chan struct{}
+	closed bool
+}
+
+func (s *mockSink) Name() string {
+	return "mock"
+}
+
+func (s *mockSink) Send(ctx context.Context, event *Event) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *mockSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.events)
+}
+
+func (s *mockSink) Close() {
+	s.closed = true
+}
+
+func TestBus(t *testing.T) {
+	sink := &mockSink{}
+	bus := NewBus(16, sink, NewLogSink())
+	SetDefault(bus)
+	defer SetDefault(nil)
+
+	Publish(SegmentSealed, 1, map[string]any{"segment_id": 100})
+	Publish(IndexBuilt, 1, nil)
+	bus.Close()
+
+	assert.True(t, sink.closed)
+	assert.Equal(t, 2, len(sink.events))
+	assert.Equal(t, SegmentSealed, sink.events[0].Type)
+	assert.Equal(t, int64(1), sink.events[0].CollectionID)
+	assert.Equal(t, IndexBuilt, sink.events[1].Type)
+
+	// publishing after closed is no-op
+	Publish(NodeDown, 0, nil)
+	assert.Equal(t, 2, len(sink.events))
+}
+
+func TestBusDropWhenFull(t *testing.T) {
+	slow := &mockSink{block: make(chan struct{})}
+	fast := &mockSink{}
+	bus := NewBus(2, slow, fast)
+	for i := 0; i < 5; i++ {
+		bus.Publish(NewEvent(QuotaTriggered, int64(i), nil))
+		assert.Eventually(t, func() bool {
+			return fast.count() == i+1
+		}, time.Second, 10*time.Millisecond)
+	}
+	close(slow.block)
+	bus.Close()
+
+	// the slow sink doesn't block the fast one
+	assert.Equal(t, 5, fast.count())
+	assert.LessOrEqual(t, slow.count(), 3)
+}
+
+func TestWebhookSink(t *testing.T) {
+	calls := atomic.NewInt32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("Authorization"))
+		event := &Event{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(event))
+		assert.Equal(t, CompactionFinished, event.Type)
+		if calls.Inc() == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, map[string]string{"Authorization": "token"}, 3, time.Second)
+	assert.NoError(t, sink.Send(context.Background(), NewEvent(CompactionFinished, 1, nil)))
+	assert.Equal(t, int32(2), calls.Load())
+
+	// the client errors are not retried
+	calls.Store(0)
+	badRequest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Inc()
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer badRequest.Close()
+	sink = NewWebhookSink(badRequest.URL, nil, 3, time.Second)
+	assert.Error(t, sink.Send(context.Background(), NewEvent(CompactionFinished, 1, nil)))
+	assert.Equal(t, int32(1), calls.Load())
+}
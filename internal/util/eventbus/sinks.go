@@ -0,0 +1,185 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/pkg/util/retry"
+)
+
+const (
+	SinkLog     = "log"
+	SinkMQ      = "mq"
+	SinkWebhook = "webhook"
+)
+
+// LogSink writes the events into the log.
+type LogSink struct{}
+
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) Name() string {
+	return SinkLog
+}
+
+func (s *LogSink) Send(ctx context.Context, event *Event) error {
+	log.Info("cluster event",
+		zap.String("type", string(event.Type)),
+		zap.String("role", event.Role),
+		zap.Int64("nodeID", event.NodeID),
+		zap.Int64("collectionID", event.CollectionID),
+		zap.Any("attributes", event.Attributes))
+	return nil
+}
+
+func (s *LogSink) Close() {}
+
+// MQSink produces the events in json into the topic of the message queue,
+// the producer is created at the first event so the sink never blocks the startup.
+type MQSink struct {
+	topic string
+	newer func(ctx context.Context) (mqwrapper.Client, error)
+
+	mu       sync.Mutex
+	client   mqwrapper.Client
+	producer mqwrapper.Producer
+}
+
+func NewMQSink(newer func(ctx context.Context) (mqwrapper.Client, error), topic string) *MQSink {
+	return &MQSink{
+		topic: topic,
+		newer: newer,
+	}
+}
+
+func (s *MQSink) Name() string {
+	return SinkMQ
+}
+
+func (s *MQSink) getProducer(ctx context.Context) (mqwrapper.Producer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.producer != nil {
+		return s.producer, nil
+	}
+	client, err := s.newer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := client.CreateProducer(mqwrapper.ProducerOptions{Topic: s.topic})
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	s.client, s.producer = client, producer
+	return producer, nil
+}
+
+func (s *MQSink) Send(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	producer, err := s.getProducer(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = producer.Send(ctx, &mqwrapper.ProducerMessage{
+		Payload:    payload,
+		Properties: map[string]string{"type": string(event.Type)},
+	})
+	return err
+}
+
+func (s *MQSink) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.producer != nil {
+		s.producer.Close()
+		s.client.Close()
+		s.producer, s.client = nil, nil
+	}
+}
+
+// WebhookSink posts the events in json to the url, and retries on failures.
+type WebhookSink struct {
+	url      string
+	headers  map[string]string
+	attempts uint
+	client   *http.Client
+}
+
+func NewWebhookSink(url string, headers map[string]string, attempts uint, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:      url,
+		headers:  headers,
+		attempts: attempts,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return SinkWebhook
+}
+
+func (s *WebhookSink) Send(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return retry.Do(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+		if err != nil {
+			return retry.Unrecoverable(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range s.headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode/100 == 2 {
+			return nil
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err = errors.Newf("webhook responded %d: %s", resp.StatusCode, string(body))
+		// the client errors except throttling won't succeed by retrying
+		if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusTooManyRequests {
+			return retry.Unrecoverable(err)
+		}
+		return err
+	}, retry.Attempts(s.attempts), retry.Sleep(100*time.Millisecond), retry.MaxSleepTime(3*time.Second))
+}
+
+func (s *WebhookSink) Close() {}
@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package featureflag negotiates the wire-level features across the cluster,
+// a feature is activated only when all the nodes advertise it in their sessions,
+// so that the mixed-version cluster during a rolling upgrade keeps the old behavior.
+package featureflag
+
+import (
+	"strings"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// Feature is the name of a wire-level feature advertised in the session.
+type Feature = string
+
+const (
+	// BinlogV2 writes the segments in the storage v2 format, which the old nodes can't read.
+	BinlogV2 Feature = "binlog_v2"
+	// ReduceStopForBest reduces the query results by stopping at the best results, the old query nodes
+	// ignore it and reduce all the results, which mismatches the reduce of the new proxies.
+	ReduceStopForBest Feature = "reduce_stop_for_best"
+	// SystemFieldFilter filters the rows by the system fields, e.g. the timestamp and the segment id,
+	// the old query nodes pass the filter to segcore, which fails to evaluate it.
+	SystemFieldFilter Feature = "system_field_filter"
+)
+
+// features are all the features this binary supports.
+var features = []Feature{
+	BinlogV2,
+	ReduceStopForBest,
+	SystemFieldFilter,
+}
+
+// Supported returns the features supported by this node, except the ones disabled by config.
+func Supported() []Feature {
+	disabled := typeutil.NewSet[string]()
+	for _, f := range paramtable.Get().CommonCfg.FeatureGateDisabled.GetAsStrings() {
+		disabled.Insert(strings.TrimSpace(f))
+	}
+	result := make([]Feature, 0, len(features))
+	for _, f := range features {
+		if !disabled.Contain(f) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// IsSupported returns true if the feature is supported by this node.
+func IsSupported(f Feature) bool {
+	for _, supported := range Supported() {
+		if supported == f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflag
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// NodeFeatures is the features advertised by a live node.
+type NodeFeatures struct {
+	ServerID int64
+	Role     string
+	Features []Feature
+}
+
+// ListFunc lists the features of all the live nodes in the cluster.
+type ListFunc func(ctx context.Context) ([]NodeFeatures, error)
+
+// Gate refreshes the features supported by all the live nodes periodically.
+//
+// Nothing is enabled before the first successful refresh, and a feature is disabled again
+// once a node without it joins, e.g. an old node is rolled back during the upgrade.
+type Gate struct {
+	list     ListFunc
+	interval time.Duration
+
+	mu      sync.RWMutex
+	enabled typeutil.Set[Feature]
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewGate(list ListFunc, interval time.Duration) *Gate {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Gate{
+		list:     list,
+		interval: interval,
+		enabled:  typeutil.NewSet[Feature](),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start refreshes the features once and keeps refreshing them in background.
+func (g *Gate) Start() {
+	g.refresh()
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		ticker := time.NewTicker(g.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-g.ctx.Done():
+				return
+			case <-ticker.C:
+				g.refresh()
+			}
+		}
+	}()
+}
+
+func (g *Gate) Stop() {
+	g.cancel()
+	g.wg.Wait()
+}
+
+// refresh keeps the previous result if the nodes fail to be listed.
+func (g *Gate) refresh() {
+	nodes, err := g.list(g.ctx)
+	if err != nil {
+		log.RatedWarn(60, "failed to list the features of the nodes", zap.Error(err))
+		return
+	}
+	enabled := typeutil.NewSet[Feature](Supported()...)
+	for _, node := range nodes {
+		advertised := typeutil.NewSet[Feature](node.Features...)
+		for _, f := range enabled.Collect() {
+			if !advertised.Contain(f) {
+				log.RatedInfo(60, "feature not supported by node", zap.String("feature", f),
+					zap.String("role", node.Role), zap.Int64("serverID", node.ServerID))
+				enabled.Remove(f)
+			}
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, f := range enabled.Collect() {
+		if !g.enabled.Contain(f) {
+			log.Info("feature enabled, all the nodes support it", zap.String("feature", f))
+		}
+	}
+	for _, f := range g.enabled.Collect() {
+		if !enabled.Contain(f) {
+			log.Warn("feature disabled, downgrade to the old behavior", zap.String("feature", f))
+		}
+	}
+	g.enabled = enabled
+}
+
+// Enabled returns true if all the live nodes support the feature.
+func (g *Gate) Enabled(f Feature) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.enabled.Contain(f)
+}
+
+var defaultGate atomic.Pointer[Gate]
+
+// SetDefault sets the gate used by Enabled.
+func SetDefault(g *Gate) {
+	defaultGate.Store(g)
+}
+
+// Enabled returns true if the feature is enabled by the default gate,
+// without the gate, e.g. in unit tests, the features supported by this node are enabled.
+func Enabled(f Feature) bool {
+	g := defaultGate.Load()
+	if g == nil {
+		return IsSupported(f)
+	}
+	return g.Enabled(f)
+}
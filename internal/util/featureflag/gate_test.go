@@ -0,0 +1,88 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflag
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+func TestSupported(t *testing.T) {
+	paramtable.Init()
+	assert.True(t, IsSupported(BinlogV2))
+
+	paramtable.Get().Save(paramtable.Get().CommonCfg.FeatureGateDisabled.Key, BinlogV2)
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.FeatureGateDisabled.Key)
+	assert.False(t, IsSupported(BinlogV2))
+	assert.Equal(t, []Feature{ReduceStopForBest, SystemFieldFilter}, Supported())
+}
+
+func TestGate(t *testing.T) {
+	paramtable.Init()
+
+	var nodes []NodeFeatures
+	var listErr error
+	gate := NewGate(func(ctx context.Context) ([]NodeFeatures, error) {
+		return nodes, listErr
+	}, time.Hour)
+
+	// nothing is enabled before the first refresh.
+	assert.False(t, gate.Enabled(BinlogV2))
+
+	nodes = []NodeFeatures{
+		{ServerID: 1, Role: "querynode", Features: []Feature{BinlogV2}},
+		{ServerID: 2, Role: "datanode", Features: []Feature{BinlogV2}},
+	}
+	gate.Start()
+	defer gate.Stop()
+	assert.True(t, gate.Enabled(BinlogV2))
+
+	// an old node joins, downgrade.
+	nodes = append(nodes, NodeFeatures{ServerID: 3, Role: "querynode"})
+	gate.refresh()
+	assert.False(t, gate.Enabled(BinlogV2))
+
+	// keep the previous result on error.
+	nodes = nodes[:2]
+	listErr = errors.New("mock")
+	gate.refresh()
+	assert.False(t, gate.Enabled(BinlogV2))
+
+	listErr = nil
+	gate.refresh()
+	assert.True(t, gate.Enabled(BinlogV2))
+}
+
+func TestDefaultGate(t *testing.T) {
+	paramtable.Init()
+	assert.True(t, Enabled(BinlogV2))
+
+	gate := NewGate(func(ctx context.Context) ([]NodeFeatures, error) {
+		return []NodeFeatures{{ServerID: 1}}, nil
+	}, time.Hour)
+	gate.Start()
+	defer gate.Stop()
+	SetDefault(gate)
+	defer SetDefault(nil)
+	assert.False(t, Enabled(BinlogV2))
+}
@@ -0,0 +1,348 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fieldstats
+
+import (
+	"math"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// the selectivity of the predicates which the statistics can't estimate well, e.g. string range
+const defaultSelectivity = 1.0 / 3
+
+// LookupFunc returns the statistics of the field in a segment, nil if not collected.
+type LookupFunc func(fieldID int64) *Stats
+
+// MayMatch returns false only if the statistics prove that no row matches the expression.
+// The expressions not on the scalar fields with statistics always may match.
+func MayMatch(expr *planpb.Expr, lookup LookupFunc) bool {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_BinaryExpr:
+		switch e.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			return MayMatch(e.BinaryExpr.GetLeft(), lookup) && MayMatch(e.BinaryExpr.GetRight(), lookup)
+		case planpb.BinaryExpr_LogicalOr:
+			return MayMatch(e.BinaryExpr.GetLeft(), lookup) || MayMatch(e.BinaryExpr.GetRight(), lookup)
+		}
+	case *planpb.Expr_UnaryRangeExpr:
+		stats := lookupColumn(e.UnaryRangeExpr.GetColumnInfo(), lookup)
+		if stats == nil {
+			return true
+		}
+		return stats.mayMatchUnaryRange(e.UnaryRangeExpr.GetOp(), e.UnaryRangeExpr.GetValue())
+	case *planpb.Expr_BinaryRangeExpr:
+		stats := lookupColumn(e.BinaryRangeExpr.GetColumnInfo(), lookup)
+		if stats == nil {
+			return true
+		}
+		lower, ok1 := stats.convert(e.BinaryRangeExpr.GetLowerValue())
+		upper, ok2 := stats.convert(e.BinaryRangeExpr.GetUpperValue())
+		if !ok1 || !ok2 {
+			return true
+		}
+		return stats.mayInRange(lower, e.BinaryRangeExpr.GetLowerInclusive(), upper, e.BinaryRangeExpr.GetUpperInclusive())
+	case *planpb.Expr_TermExpr:
+		if e.TermExpr.GetIsInField() {
+			return true
+		}
+		stats := lookupColumn(e.TermExpr.GetColumnInfo(), lookup)
+		if stats == nil {
+			return true
+		}
+		for _, value := range e.TermExpr.GetValues() {
+			v, ok := stats.convert(value)
+			if !ok || stats.mayInRange(v, true, v, true) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// EstimateSelectivity estimates the fraction of rows matching the expression in [0, 1],
+// the expressions not on the scalar fields with statistics are assumed to match all rows.
+func EstimateSelectivity(expr *planpb.Expr, lookup LookupFunc) float64 {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_BinaryExpr:
+		left := EstimateSelectivity(e.BinaryExpr.GetLeft(), lookup)
+		right := EstimateSelectivity(e.BinaryExpr.GetRight(), lookup)
+		switch e.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			// assume the predicates are independent
+			return left * right
+		case planpb.BinaryExpr_LogicalOr:
+			return left + right - left*right
+		}
+	case *planpb.Expr_UnaryExpr:
+		if e.UnaryExpr.GetOp() == planpb.UnaryExpr_Not {
+			return 1 - EstimateSelectivity(e.UnaryExpr.GetChild(), lookup)
+		}
+	case *planpb.Expr_UnaryRangeExpr:
+		stats := lookupColumn(e.UnaryRangeExpr.GetColumnInfo(), lookup)
+		if stats == nil {
+			return 1
+		}
+		return stats.estimateUnaryRange(e.UnaryRangeExpr.GetOp(), e.UnaryRangeExpr.GetValue())
+	case *planpb.Expr_BinaryRangeExpr:
+		stats := lookupColumn(e.BinaryRangeExpr.GetColumnInfo(), lookup)
+		if stats == nil {
+			return 1
+		}
+		lower, ok1 := stats.convert(e.BinaryRangeExpr.GetLowerValue())
+		upper, ok2 := stats.convert(e.BinaryRangeExpr.GetUpperValue())
+		if !ok1 || !ok2 {
+			return 1
+		}
+		if !stats.mayInRange(lower, e.BinaryRangeExpr.GetLowerInclusive(), upper, e.BinaryRangeExpr.GetUpperInclusive()) {
+			return 0
+		}
+		return stats.estimateRange(lower, upper)
+	case *planpb.Expr_TermExpr:
+		if e.TermExpr.GetIsInField() {
+			return 1
+		}
+		stats := lookupColumn(e.TermExpr.GetColumnInfo(), lookup)
+		if stats == nil {
+			return 1
+		}
+		matched := 0
+		for _, value := range e.TermExpr.GetValues() {
+			v, ok := stats.convert(value)
+			if !ok {
+				return 1
+			}
+			if stats.mayInRange(v, true, v, true) {
+				matched++
+			}
+		}
+		return clamp(float64(matched) * stats.equalSelectivity())
+	}
+	return 1
+}
+
+func lookupColumn(column *planpb.ColumnInfo, lookup LookupFunc) *Stats {
+	if len(column.GetNestedPath()) > 0 ||
+		column.GetDataType() == schemapb.DataType_JSON || column.GetDataType() == schemapb.DataType_Array {
+		return nil
+	}
+	stats := lookup(column.GetFieldId())
+	if stats == nil || stats.DataType != column.GetDataType() || stats.RowCount == 0 {
+		return nil
+	}
+	return stats
+}
+
+// convert converts the value to the type of the bounds, false if the types mismatch.
+func (s *Stats) convert(value *planpb.GenericValue) (any, bool) {
+	switch v := value.GetVal().(type) {
+	case *planpb.GenericValue_BoolVal:
+		if typeutil.IsBoolType(s.DataType) {
+			if v.BoolVal {
+				return int64(1), true
+			}
+			return int64(0), true
+		}
+	case *planpb.GenericValue_Int64Val:
+		if typeutil.IsIntegerType(s.DataType) {
+			return v.Int64Val, true
+		}
+		if typeutil.IsFloatingType(s.DataType) {
+			return s.toFloat(float64(v.Int64Val)), true
+		}
+	case *planpb.GenericValue_FloatVal:
+		if typeutil.IsFloatingType(s.DataType) {
+			return s.toFloat(v.FloatVal), true
+		}
+	case *planpb.GenericValue_StringVal:
+		if typeutil.IsStringType(s.DataType) {
+			return v.StringVal, true
+		}
+	}
+	return nil, false
+}
+
+// toFloat rounds the value as segcore does for float fields.
+func (s *Stats) toFloat(v float64) float64 {
+	if s.DataType == schemapb.DataType_Float {
+		return float64(float32(v))
+	}
+	return v
+}
+
+// compare compares the values of the same type, which are int64, float64 or string.
+func compare(a, b any) int {
+	switch a := a.(type) {
+	case int64:
+		return compareOrdered(a, b.(int64))
+	case float64:
+		return compareOrdered(a, b.(float64))
+	default:
+		return compareOrdered(a.(string), b.(string))
+	}
+}
+
+func compareOrdered[T int64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// mayInRange returns false if no value is in the range, nil bound means unbounded.
+func (s *Stats) mayInRange(lower any, lowerInclusive bool, upper any, upperInclusive bool) bool {
+	if s.ValueCount() == 0 {
+		return false
+	}
+	min, max := s.bounds()
+	if lower != nil {
+		if c := compare(max, lower); c < 0 || (c == 0 && !lowerInclusive) {
+			return false
+		}
+	}
+	if upper != nil {
+		if c := compare(min, upper); c > 0 || (c == 0 && !upperInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Stats) mayMatchUnaryRange(op planpb.OpType, value *planpb.GenericValue) bool {
+	v, ok := s.convert(value)
+	if !ok {
+		return true
+	}
+	switch op {
+	case planpb.OpType_Equal:
+		return s.mayInRange(v, true, v, true)
+	case planpb.OpType_NotEqual:
+		min, max := s.bounds()
+		return s.ValueCount() > 0 && (compare(min, v) != 0 || compare(max, v) != 0)
+	case planpb.OpType_GreaterThan:
+		return s.mayInRange(v, false, nil, false)
+	case planpb.OpType_GreaterEqual:
+		return s.mayInRange(v, true, nil, false)
+	case planpb.OpType_LessThan:
+		return s.mayInRange(nil, false, v, false)
+	case planpb.OpType_LessEqual:
+		return s.mayInRange(nil, false, v, true)
+	case planpb.OpType_PrefixMatch:
+		prefix, ok := v.(string)
+		if !ok {
+			return true
+		}
+		if s.ValueCount() == 0 || s.StringMax < prefix {
+			return false
+		}
+		return s.StringMin <= prefix || strings.HasPrefix(s.StringMin, prefix)
+	}
+	return true
+}
+
+func (s *Stats) estimateUnaryRange(op planpb.OpType, value *planpb.GenericValue) float64 {
+	v, ok := s.convert(value)
+	if !ok {
+		return 1
+	}
+	if !s.mayMatchUnaryRange(op, value) {
+		return 0
+	}
+	switch op {
+	case planpb.OpType_Equal:
+		return s.equalSelectivity()
+	case planpb.OpType_NotEqual:
+		return clamp(s.nonNullFraction() - s.equalSelectivity())
+	case planpb.OpType_GreaterThan, planpb.OpType_GreaterEqual:
+		return s.estimateRange(v, nil)
+	case planpb.OpType_LessThan, planpb.OpType_LessEqual:
+		return s.estimateRange(nil, v)
+	}
+	return defaultSelectivity * s.nonNullFraction()
+}
+
+func (s *Stats) nonNullFraction() float64 {
+	return float64(s.ValueCount()) / float64(s.RowCount)
+}
+
+// equalSelectivity returns the estimated fraction of rows equal to a value, assuming the values are uniform.
+func (s *Stats) equalSelectivity() float64 {
+	ndv := s.NDV
+	if ndv < 1 {
+		ndv = 1
+	}
+	return s.nonNullFraction() / float64(ndv)
+}
+
+// estimateRange estimates the fraction of rows in the range by the histogram, nil bound means unbounded.
+func (s *Stats) estimateRange(lower, upper any) float64 {
+	if len(s.Histogram) == 0 || s.ValueCount() == 0 {
+		return defaultSelectivity * s.nonNullFraction()
+	}
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if lower != nil {
+		lo = toFloat64(lower)
+	}
+	if upper != nil {
+		hi = toFloat64(upper)
+	}
+	if lo > hi {
+		return 0
+	}
+
+	min, _ := s.bounds()
+	prev := toFloat64(min)
+	matched := 0.0
+	for _, bucket := range s.Histogram {
+		bucketLo, bucketHi := prev, bucket.Upper
+		prev = bucket.Upper
+		if bucketHi < lo || bucketLo > hi {
+			continue
+		}
+		if bucketHi == bucketLo {
+			matched += float64(bucket.Count)
+			continue
+		}
+		// assume the values are uniform in the bucket
+		overlap := (math.Min(bucketHi, hi) - math.Max(bucketLo, lo)) / (bucketHi - bucketLo)
+		matched += overlap * float64(bucket.Count)
+	}
+	return clamp(matched / float64(s.RowCount))
+}
+
+func toFloat64(v any) float64 {
+	switch v := v.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		return math.NaN()
+	}
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
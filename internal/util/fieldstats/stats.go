@@ -0,0 +1,487 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fieldstats implements the statistics of scalar fields in segments, e.g. null count, min/max,
+// number of distinct values and histogram. The statistics are collected by datanode on flush and compaction,
+// saved as the field stats logs of the segment, and used by query node to estimate the selectivity
+// of filters and to skip the segments whose statistics prove no row matches the filter.
+//
+// The statistics of each field are saved in the statslogs of the field itself,
+// as stats_log/{collection}/{partition}/{segment}/{field}/{FieldStatsType}, next to the pk stats of pk field.
+package fieldstats
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"math/rand"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	// the precision of the NDV sketch, 2^12 registers with about 1.6% standard error
+	sketchPrecision = 12
+	sketchRegisters = 1 << sketchPrecision
+
+	// the values sampled to build the histogram
+	sampleSize = 1024
+	// HistogramBuckets is the max bucket number of histograms
+	HistogramBuckets = 64
+)
+
+// IsSupported returns whether the statistics are collected for the field.
+func IsSupported(field *schemapb.FieldSchema) bool {
+	dataType := field.GetDataType()
+	return typeutil.IsIntegerType(dataType) || typeutil.IsFloatingType(dataType) ||
+		typeutil.IsBoolType(dataType) || typeutil.IsStringType(dataType)
+}
+
+// Bucket is a bucket of the equi-depth histogram, which covers the values in (upper of previous bucket, Upper],
+// the first bucket starts from the min value inclusively.
+type Bucket struct {
+	Upper float64 `json:"upper"`
+	Count int64   `json:"count"`
+}
+
+// Stats is the statistics of a scalar field in a segment.
+// Integers and bools are compared as int64, floating-points as float64 and strings as string.
+type Stats struct {
+	FieldID  int64             `json:"fieldID"`
+	DataType schemapb.DataType `json:"dataType"`
+	RowCount int64             `json:"rowCount"`
+	// NullCount is always zero before nullable fields are supported
+	NullCount int64 `json:"nullCount"`
+
+	IntMin    int64   `json:"intMin,omitempty"`
+	IntMax    int64   `json:"intMax,omitempty"`
+	FloatMin  float64 `json:"floatMin,omitempty"`
+	FloatMax  float64 `json:"floatMax,omitempty"`
+	StringMin string  `json:"stringMin,omitempty"`
+	StringMax string  `json:"stringMax,omitempty"`
+
+	// NDV is the estimated number of distinct values, Sketch is the hyperloglog registers it's estimated from
+	NDV    int64   `json:"ndv"`
+	Sketch []uint8 `json:"sketch,omitempty"`
+	// Histogram is nil for strings
+	Histogram []Bucket `json:"histogram,omitempty"`
+}
+
+// ValueCount returns the number of non-null values.
+func (s *Stats) ValueCount() int64 {
+	return s.RowCount - s.NullCount
+}
+
+func (s *Stats) isInt() bool {
+	return typeutil.IsIntegerType(s.DataType) || typeutil.IsBoolType(s.DataType)
+}
+
+// bounds returns the min and max value, which are int64, float64 or string.
+func (s *Stats) bounds() (any, any) {
+	switch {
+	case s.isInt():
+		return s.IntMin, s.IntMax
+	case typeutil.IsFloatingType(s.DataType):
+		return s.FloatMin, s.FloatMax
+	default:
+		return s.StringMin, s.StringMax
+	}
+}
+
+// Collector collects the statistics of a field incrementally.
+type Collector struct {
+	stats  *Stats
+	sketch []uint8
+	// reservoir sampling of the numeric values for the histogram
+	sample []float64
+	rand   *rand.Rand
+}
+
+// NewCollector returns the collector of the field, nil if the field is not supported.
+func NewCollector(field *schemapb.FieldSchema) *Collector {
+	if !IsSupported(field) {
+		return nil
+	}
+	return &Collector{
+		stats:  &Stats{FieldID: field.GetFieldID(), DataType: field.GetDataType()},
+		sketch: make([]uint8, sketchRegisters),
+		rand:   rand.New(rand.NewSource(field.GetFieldID())),
+	}
+}
+
+// Add adds a value of the field, nil means null.
+func (c *Collector) Add(value any) {
+	s := c.stats
+	s.RowCount++
+	var (
+		v    any
+		hash uint64
+	)
+	switch value := value.(type) {
+	case nil:
+		s.NullCount++
+		return
+	case bool:
+		n := int64(0)
+		if value {
+			n = 1
+		}
+		v, hash = n, hashInt(n)
+	case int8:
+		v, hash = int64(value), hashInt(int64(value))
+	case int16:
+		v, hash = int64(value), hashInt(int64(value))
+	case int32:
+		v, hash = int64(value), hashInt(int64(value))
+	case int64:
+		v, hash = value, hashInt(value)
+	case float32:
+		v, hash = float64(value), hashFloat(float64(value))
+	case float64:
+		v, hash = value, hashFloat(value)
+	case string:
+		v, hash = value, hashString(value)
+	default:
+		// unexpected type, regard it as unknown value
+		s.NullCount++
+		return
+	}
+
+	first := s.ValueCount() == 1
+	switch v := v.(type) {
+	case int64:
+		if first || v < s.IntMin {
+			s.IntMin = v
+		}
+		if first || v > s.IntMax {
+			s.IntMax = v
+		}
+		c.sampleValue(float64(v))
+	case float64:
+		if first || v < s.FloatMin {
+			s.FloatMin = v
+		}
+		if first || v > s.FloatMax {
+			s.FloatMax = v
+		}
+		c.sampleValue(v)
+	case string:
+		if first || v < s.StringMin {
+			s.StringMin = v
+		}
+		if first || v > s.StringMax {
+			s.StringMax = v
+		}
+	}
+	sketchAdd(c.sketch, hash)
+}
+
+// AddFieldData adds all the rows of the field data.
+func (c *Collector) AddFieldData(data storage.FieldData) {
+	for i := 0; i < data.RowNum(); i++ {
+		c.Add(data.GetRow(i))
+	}
+}
+
+func (c *Collector) sampleValue(v float64) {
+	if len(c.sample) < sampleSize {
+		c.sample = append(c.sample, v)
+		return
+	}
+	// the value count includes v
+	if idx := c.rand.Int63n(c.stats.ValueCount()); idx < sampleSize {
+		c.sample[idx] = v
+	}
+}
+
+// Stats returns the statistics of the values added so far.
+func (c *Collector) Stats() *Stats {
+	stats := *c.stats
+	stats.Sketch = make([]uint8, len(c.sketch))
+	copy(stats.Sketch, c.sketch)
+	stats.NDV = sketchEstimate(stats.Sketch)
+	if stats.NDV > stats.ValueCount() {
+		stats.NDV = stats.ValueCount()
+	}
+	if !typeutil.IsStringType(stats.DataType) {
+		stats.Histogram = buildHistogram(c.sample, stats.ValueCount())
+		// the sample may miss the max value
+		if len(stats.Histogram) > 0 {
+			max := stats.FloatMax
+			if stats.isInt() {
+				max = float64(stats.IntMax)
+			}
+			stats.Histogram[len(stats.Histogram)-1].Upper = max
+		}
+	}
+	return &stats
+}
+
+// buildHistogram builds the equi-depth histogram from the sorted sample, the counts are scaled to total.
+func buildHistogram(sample []float64, total int64) []Bucket {
+	if len(sample) == 0 {
+		return nil
+	}
+	sorted := make([]float64, len(sample))
+	copy(sorted, sample)
+	sort.Float64s(sorted)
+
+	buckets := make([]Bucket, 0, HistogramBuckets)
+	var assigned int64
+	for i := 0; i < HistogramBuckets; i++ {
+		end := (i + 1) * len(sorted) / HistogramBuckets
+		if end == 0 {
+			continue
+		}
+		// the values equal to the upper bound all fall into this bucket
+		for end < len(sorted) && sorted[end] == sorted[end-1] {
+			end++
+		}
+		if len(buckets) > 0 && sorted[end-1] == buckets[len(buckets)-1].Upper {
+			continue
+		}
+		// the cumulative count is scaled so that the counts sum up to total
+		cumulative := int64(math.Round(float64(total) * float64(end) / float64(len(sorted))))
+		buckets = append(buckets, Bucket{Upper: sorted[end-1], Count: cumulative - assigned})
+		assigned = cumulative
+	}
+	return buckets
+}
+
+func mix(h uint64) uint64 {
+	// the finalizer of splitmix64, fnv hash of small integers is not random enough
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+func hashInt(v int64) uint64 {
+	return mix(uint64(v))
+}
+
+func hashFloat(v float64) uint64 {
+	if v == 0 {
+		// -0 equals to 0
+		v = 0
+	}
+	return mix(math.Float64bits(v))
+}
+
+func hashString(v string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(v))
+	return mix(h.Sum64())
+}
+
+func sketchAdd(sketch []uint8, hash uint64) {
+	idx := hash >> (64 - sketchPrecision)
+	rank := uint8(bits.LeadingZeros64(hash<<sketchPrecision|1<<(sketchPrecision-1))) + 1
+	if rank > sketch[idx] {
+		sketch[idx] = rank
+	}
+}
+
+// sketchEstimate estimates the cardinality by the hyperloglog registers,
+// with linear counting for small cardinalities.
+func sketchEstimate(sketch []uint8) int64 {
+	if len(sketch) == 0 {
+		return 0
+	}
+	m := float64(len(sketch))
+	sum := 0.0
+	zeros := 0
+	for _, rank := range sketch {
+		sum += math.Ldexp(1, -int(rank))
+		if rank == 0 {
+			zeros++
+		}
+	}
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int64(math.Round(estimate))
+}
+
+// Marshal serializes the statistics as the field stats log of the field.
+func (s *Stats) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// UnmarshalStats deserializes the statistics from the field stats log of a field.
+func UnmarshalStats(data []byte) (*Stats, error) {
+	stats := &Stats{}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal field stats")
+	}
+	return stats, nil
+}
+
+// IsStatsLog returns whether the stats log is a field stats log.
+func IsStatsLog(logPath string) bool {
+	return path.Base(logPath) == storage.FieldStatsType.LogIdx()
+}
+
+// StatsLogPaths returns the paths of the field stats logs in the statslogs of a segment.
+func StatsLogPaths(statslogs []*datapb.FieldBinlog) []string {
+	paths := make([]string, 0)
+	for _, fieldBinlog := range statslogs {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			if IsStatsLog(binlog.GetLogPath()) {
+				paths = append(paths, binlog.GetLogPath())
+			}
+		}
+	}
+	return paths
+}
+
+// HasStatsLog returns whether the segment has field stats logs.
+func HasStatsLog(statslogs []*datapb.FieldBinlog) bool {
+	return len(StatsLogPaths(statslogs)) > 0
+}
+
+// Load reads the field stats logs of the segment, returns nil if the segment has none.
+func Load(ctx context.Context, cm storage.ChunkManager, segmentID int64, statslogs []*datapb.FieldBinlog) (*SegmentStats, error) {
+	paths := StatsLogPaths(statslogs)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	values, err := cm.MultiRead(ctx, paths)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]*Stats, 0, len(values))
+	for i, value := range values {
+		stats, err := UnmarshalStats(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load field stats %s", paths[i])
+		}
+		fields = append(fields, stats)
+	}
+	return NewSegmentStats(segmentID, fields), nil
+}
+
+// SegmentStats is the statistics of all the supported fields of a segment, assembled from the field stats logs.
+type SegmentStats struct {
+	SegmentID int64    `json:"segmentID"`
+	RowCount  int64    `json:"rowCount"`
+	Fields    []*Stats `json:"fields"`
+}
+
+// Get returns the statistics of the field, nil if not collected.
+func (s *SegmentStats) Get(fieldID int64) *Stats {
+	for _, stats := range s.Fields {
+		if stats.FieldID == fieldID {
+			return stats
+		}
+	}
+	return nil
+}
+
+// NewSegmentStats assembles the statistics of the fields of a segment.
+func NewSegmentStats(segmentID int64, fields []*Stats) *SegmentStats {
+	stats := &SegmentStats{
+		SegmentID: segmentID,
+		Fields:    fields,
+	}
+	if len(fields) > 0 {
+		stats.RowCount = fields[0].RowCount
+	}
+	sort.Slice(stats.Fields, func(i, j int) bool {
+		return stats.Fields[i].FieldID < stats.Fields[j].FieldID
+	})
+	return stats
+}
+
+// SegmentCollector collects the statistics of all the supported fields of a segment, it's thread-safe.
+type SegmentCollector struct {
+	mu         sync.Mutex
+	rowCount   int64
+	collectors map[int64]*Collector
+}
+
+// NewSegmentCollector returns the collector of the supported fields in schema.
+func NewSegmentCollector(schema *schemapb.CollectionSchema) *SegmentCollector {
+	collectors := make(map[int64]*Collector)
+	for _, field := range schema.GetFields() {
+		if field.GetFieldID() < 100 {
+			// system fields
+			continue
+		}
+		if collector := NewCollector(field); collector != nil {
+			collectors[field.GetFieldID()] = collector
+		}
+	}
+	return &SegmentCollector{collectors: collectors}
+}
+
+// AddInsertData adds the rows of insert data.
+func (c *SegmentCollector) AddInsertData(data *storage.InsertData) {
+	if data == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for fieldID, collector := range c.collectors {
+		if fieldData, ok := data.Data[fieldID]; ok {
+			collector.AddFieldData(fieldData)
+		}
+	}
+	c.rowCount += int64(data.GetRowNum())
+}
+
+// AddRow adds a row, which is the map from field id to value.
+func (c *SegmentCollector) AddRow(row map[int64]any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for fieldID, collector := range c.collectors {
+		collector.Add(row[fieldID])
+	}
+	c.rowCount++
+}
+
+// RowCount returns the number of rows added.
+func (c *SegmentCollector) RowCount() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rowCount
+}
+
+// Build returns the statistics of the rows added so far.
+func (c *SegmentCollector) Build(segmentID int64) *SegmentStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fields := make([]*Stats, 0, len(c.collectors))
+	for _, collector := range c.collectors {
+		fields = append(fields, collector.Stats())
+	}
+	stats := NewSegmentStats(segmentID, fields)
+	stats.RowCount = c.rowCount
+	return stats
+}
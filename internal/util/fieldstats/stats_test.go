@@ -0,0 +1,255 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fieldstats
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func testSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 0, Name: "row_id", DataType: schemapb.DataType_Int64},
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "category", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "price", DataType: schemapb.DataType_Float},
+			{FieldID: 103, Name: "vec", DataType: schemapb.DataType_FloatVector},
+		},
+	}
+}
+
+func buildTestStats(t *testing.T) *SegmentStats {
+	collector := NewSegmentCollector(testSchema())
+	pks := make([]int64, 0, 10000)
+	categories := make([]string, 0, 10000)
+	prices := make([]float32, 0, 10000)
+	for i := 0; i < 10000; i++ {
+		pks = append(pks, int64(i))
+		categories = append(categories, fmt.Sprintf("c%02d", i%50))
+		prices = append(prices, float32(i%100)+0.1)
+	}
+	// flushed in two batches
+	for _, r := range [][2]int{{0, 4000}, {4000, 10000}} {
+		collector.AddInsertData(&storage.InsertData{Data: map[storage.FieldID]storage.FieldData{
+			100: &storage.Int64FieldData{Data: pks[r[0]:r[1]]},
+			101: &storage.StringFieldData{Data: categories[r[0]:r[1]]},
+			102: &storage.FloatFieldData{Data: prices[r[0]:r[1]]},
+		}})
+	}
+	require.EqualValues(t, 10000, collector.RowCount())
+	return collector.Build(1)
+}
+
+func TestCollect(t *testing.T) {
+	stats := buildTestStats(t)
+	assert.EqualValues(t, 1, stats.SegmentID)
+	assert.EqualValues(t, 10000, stats.RowCount)
+	// system fields and vectors are not collected
+	require.Len(t, stats.Fields, 3)
+	assert.Nil(t, stats.Get(0))
+	assert.Nil(t, stats.Get(103))
+
+	pk := stats.Get(100)
+	assert.EqualValues(t, 10000, pk.RowCount)
+	assert.EqualValues(t, 0, pk.NullCount)
+	assert.EqualValues(t, 0, pk.IntMin)
+	assert.EqualValues(t, 9999, pk.IntMax)
+	assert.InDelta(t, 10000, pk.NDV, 500)
+	var total int64
+	for i, bucket := range pk.Histogram {
+		total += bucket.Count
+		if i > 0 {
+			assert.Greater(t, bucket.Upper, pk.Histogram[i-1].Upper)
+		}
+	}
+	assert.EqualValues(t, 10000, total)
+	assert.LessOrEqual(t, len(pk.Histogram), HistogramBuckets)
+	assert.EqualValues(t, 9999, pk.Histogram[len(pk.Histogram)-1].Upper)
+
+	category := stats.Get(101)
+	assert.Equal(t, "c00", category.StringMin)
+	assert.Equal(t, "c49", category.StringMax)
+	assert.InDelta(t, 50, category.NDV, 2)
+	assert.Nil(t, category.Histogram)
+
+	price := stats.Get(102)
+	assert.EqualValues(t, float32(0.1), price.FloatMin)
+	assert.EqualValues(t, float32(99.1), price.FloatMax)
+	assert.InDelta(t, 100, price.NDV, 3)
+}
+
+func TestMarshal(t *testing.T) {
+	stats := buildTestStats(t)
+	fields := make([]*Stats, 0, len(stats.Fields))
+	for _, field := range stats.Fields {
+		data, err := field.Marshal()
+		require.NoError(t, err)
+		unmarshaled, err := UnmarshalStats(data)
+		require.NoError(t, err)
+		fields = append(fields, unmarshaled)
+	}
+	assert.Equal(t, stats, NewSegmentStats(stats.SegmentID, fields))
+
+	_, err := UnmarshalStats([]byte("{"))
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	stats := buildTestStats(t)
+
+	statslogs := []*datapb.FieldBinlog{{
+		FieldID: 100,
+		Binlogs: []*datapb.Binlog{{LogPath: path.Join(cm.RootPath(), "stats_log/1/2/3/100/1")}},
+	}}
+	loaded, err := Load(ctx, cm, stats.SegmentID, statslogs)
+	assert.NoError(t, err)
+	assert.Nil(t, loaded)
+	assert.False(t, HasStatsLog(statslogs))
+
+	for _, field := range stats.Fields {
+		data, err := field.Marshal()
+		require.NoError(t, err)
+		logPath := path.Join(cm.RootPath(), "stats_log/1/2/3", fmt.Sprint(field.FieldID), storage.FieldStatsType.LogIdx())
+		require.NoError(t, cm.Write(ctx, logPath, data))
+		statslogs = append(statslogs, &datapb.FieldBinlog{
+			FieldID: field.FieldID,
+			Binlogs: []*datapb.Binlog{{LogPath: logPath}},
+		})
+	}
+	assert.True(t, HasStatsLog(statslogs))
+	loaded, err = Load(ctx, cm, stats.SegmentID, statslogs)
+	assert.NoError(t, err)
+	assert.Equal(t, stats, loaded)
+}
+
+func column(fieldID int64, dataType schemapb.DataType) *planpb.ColumnInfo {
+	return &planpb.ColumnInfo{FieldId: fieldID, DataType: dataType}
+}
+
+func unaryRange(fieldID int64, dataType schemapb.DataType, op planpb.OpType, value *planpb.GenericValue) *planpb.Expr {
+	return &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+		ColumnInfo: column(fieldID, dataType),
+		Op:         op,
+		Value:      value,
+	}}}
+}
+
+func intValue(v int64) *planpb.GenericValue {
+	return &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: v}}
+}
+
+func floatValue(v float64) *planpb.GenericValue {
+	return &planpb.GenericValue{Val: &planpb.GenericValue_FloatVal{FloatVal: v}}
+}
+
+func stringValue(v string) *planpb.GenericValue {
+	return &planpb.GenericValue{Val: &planpb.GenericValue_StringVal{StringVal: v}}
+}
+
+func and(left, right *planpb.Expr) *planpb.Expr {
+	return &planpb.Expr{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{
+		Op: planpb.BinaryExpr_LogicalAnd, Left: left, Right: right,
+	}}}
+}
+
+func or(left, right *planpb.Expr) *planpb.Expr {
+	return &planpb.Expr{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{
+		Op: planpb.BinaryExpr_LogicalOr, Left: left, Right: right,
+	}}}
+}
+
+func TestMayMatch(t *testing.T) {
+	stats := buildTestStats(t)
+	lookup := stats.Get
+	pk := func(op planpb.OpType, v int64) *planpb.Expr {
+		return unaryRange(100, schemapb.DataType_Int64, op, intValue(v))
+	}
+
+	assert.True(t, MayMatch(pk(planpb.OpType_Equal, 9999), lookup))
+	assert.False(t, MayMatch(pk(planpb.OpType_Equal, 10000), lookup))
+	assert.False(t, MayMatch(pk(planpb.OpType_GreaterThan, 9999), lookup))
+	assert.True(t, MayMatch(pk(planpb.OpType_GreaterEqual, 9999), lookup))
+	assert.False(t, MayMatch(pk(planpb.OpType_LessThan, 0), lookup))
+	assert.True(t, MayMatch(pk(planpb.OpType_LessEqual, 0), lookup))
+	assert.True(t, MayMatch(pk(planpb.OpType_NotEqual, 0), lookup))
+
+	assert.False(t, MayMatch(and(pk(planpb.OpType_Equal, 1), pk(planpb.OpType_Equal, -1)), lookup))
+	assert.True(t, MayMatch(or(pk(planpb.OpType_Equal, 1), pk(planpb.OpType_Equal, -1)), lookup))
+
+	binaryRange := &planpb.Expr{Expr: &planpb.Expr_BinaryRangeExpr{BinaryRangeExpr: &planpb.BinaryRangeExpr{
+		ColumnInfo:     column(100, schemapb.DataType_Int64),
+		LowerInclusive: true,
+		UpperInclusive: false,
+		LowerValue:     intValue(-10),
+		UpperValue:     intValue(0),
+	}}}
+	assert.False(t, MayMatch(binaryRange, lookup))
+
+	term := &planpb.Expr{Expr: &planpb.Expr_TermExpr{TermExpr: &planpb.TermExpr{
+		ColumnInfo: column(101, schemapb.DataType_VarChar),
+		Values:     []*planpb.GenericValue{stringValue("a"), stringValue("d")},
+	}}}
+	assert.False(t, MayMatch(term, lookup))
+	term.GetTermExpr().Values = append(term.GetTermExpr().Values, stringValue("c10"))
+	assert.True(t, MayMatch(term, lookup))
+
+	assert.True(t, MayMatch(unaryRange(101, schemapb.DataType_VarChar, planpb.OpType_PrefixMatch, stringValue("c")), lookup))
+	assert.False(t, MayMatch(unaryRange(101, schemapb.DataType_VarChar, planpb.OpType_PrefixMatch, stringValue("d")), lookup))
+
+	// float values are rounded as float32
+	assert.True(t, MayMatch(unaryRange(102, schemapb.DataType_Float, planpb.OpType_Equal, floatValue(0.1)), lookup))
+	assert.False(t, MayMatch(unaryRange(102, schemapb.DataType_Float, planpb.OpType_LessThan, floatValue(0.1)), lookup))
+
+	// fields without stats and mismatched values always may match
+	assert.True(t, MayMatch(unaryRange(104, schemapb.DataType_Int64, planpb.OpType_Equal, intValue(-1)), lookup))
+	assert.True(t, MayMatch(unaryRange(100, schemapb.DataType_Int64, planpb.OpType_Equal, stringValue("a")), lookup))
+}
+
+func TestEstimateSelectivity(t *testing.T) {
+	stats := buildTestStats(t)
+	lookup := stats.Get
+	pk := func(op planpb.OpType, v int64) *planpb.Expr {
+		return unaryRange(100, schemapb.DataType_Int64, op, intValue(v))
+	}
+
+	assert.InDelta(t, 0.5, EstimateSelectivity(pk(planpb.OpType_LessThan, 5000), lookup), 0.05)
+	assert.InDelta(t, 0.1, EstimateSelectivity(pk(planpb.OpType_GreaterEqual, 9000), lookup), 0.05)
+	assert.Equal(t, 0.0, EstimateSelectivity(pk(planpb.OpType_GreaterThan, 9999), lookup))
+	assert.InDelta(t, 1.0/10000, EstimateSelectivity(pk(planpb.OpType_Equal, 1), lookup), 1e-5)
+
+	category := unaryRange(101, schemapb.DataType_VarChar, planpb.OpType_Equal, stringValue("c01"))
+	assert.InDelta(t, 0.02, EstimateSelectivity(category, lookup), 1e-3)
+	assert.InDelta(t, 0.01, EstimateSelectivity(and(category, pk(planpb.OpType_LessThan, 5000)), lookup), 2e-3)
+
+	not := &planpb.Expr{Expr: &planpb.Expr_UnaryExpr{UnaryExpr: &planpb.UnaryExpr{Op: planpb.UnaryExpr_Not, Child: category}}}
+	assert.InDelta(t, 0.98, EstimateSelectivity(not, lookup), 1e-3)
+
+	assert.Equal(t, 1.0, EstimateSelectivity(unaryRange(104, schemapb.DataType_Int64, planpb.OpType_Equal, intValue(1)), lookup))
+}
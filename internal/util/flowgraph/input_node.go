@@ -117,11 +117,11 @@ func (inNode *InputNode) Operate(in []Msg) []Msg {
 	sub := tsoutil.SubByNow(msgPack.EndTs)
 	if inNode.role == typeutil.QueryNodeRole {
 		metrics.QueryNodeConsumerMsgCount.
-			WithLabelValues(fmt.Sprint(inNode.nodeID), inNode.dataType, fmt.Sprint(inNode.collectionID)).
+			WithLabelValues(fmt.Sprint(inNode.nodeID), inNode.dataType, metrics.CollectionIDLabel(inNode.collectionID)).
 			Inc()
 
 		metrics.QueryNodeConsumeTimeTickLag.
-			WithLabelValues(fmt.Sprint(inNode.nodeID), inNode.dataType, fmt.Sprint(inNode.collectionID)).
+			WithLabelValues(fmt.Sprint(inNode.nodeID), inNode.dataType, metrics.CollectionIDLabel(inNode.collectionID)).
 			Set(float64(sub))
 	}
 
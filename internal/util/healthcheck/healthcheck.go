@@ -0,0 +1,279 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/conc"
+)
+
+// Severity is the severity of a diagnosis.
+type Severity string
+
+const (
+	SeverityOK       Severity = "ok"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// RemediationCode identifies the suggested remediation of a diagnosis.
+type RemediationCode string
+
+const (
+	CodeEtcdUnreachable         RemediationCode = "ETCD_UNREACHABLE"
+	CodeEtcdSlow                RemediationCode = "ETCD_SLOW"
+	CodeMQUnreachable           RemediationCode = "MQ_UNREACHABLE"
+	CodeMQSlow                  RemediationCode = "MQ_SLOW"
+	CodeStorageUnreachable      RemediationCode = "STORAGE_UNREACHABLE"
+	CodeStorageSlow             RemediationCode = "STORAGE_SLOW"
+	CodeComponentMissing        RemediationCode = "COMPONENT_MISSING"
+	CodeComponentHeartbeatStale RemediationCode = "COMPONENT_HEARTBEAT_STALE"
+	CodeTimeTickLag             RemediationCode = "TIMETICK_LAG"
+	CodeProbeFailed             RemediationCode = "PROBE_FAILED"
+)
+
+var suggestions = map[RemediationCode]string{
+	CodeEtcdUnreachable:         "check the etcd endpoints, the network between milvus and etcd, and whether the etcd cluster has quorum",
+	CodeEtcdSlow:                "check the disk latency and the db size of etcd, defragment or compact etcd if the db size is large",
+	CodeMQUnreachable:           "check the message queue endpoints, the network between milvus and the message queue, and the message queue service state",
+	CodeMQSlow:                  "check the load and the backlog of the message queue, scale out the message queue if it's overloaded",
+	CodeStorageUnreachable:      "check the object storage endpoint, the bucket, the credentials and the network between milvus and the object storage",
+	CodeStorageSlow:             "check the bandwidth and the load of the object storage",
+	CodeComponentMissing:        "start the missing component or check its logs for the reason it exits",
+	CodeComponentHeartbeatStale: "check the load and the network of the node, and the etcd latency, the node will be offline if the session expires",
+	CodeTimeTickLag:             "check the message queue latency and the load of the data nodes and query nodes consuming the channel",
+	CodeProbeFailed:             "check the logs of the node for the probe failure",
+}
+
+// Diagnosis is the result of a probe on a target.
+type Diagnosis struct {
+	Probe      string          `json:"probe"`
+	Target     string          `json:"target,omitempty"`
+	Severity   Severity        `json:"severity"`
+	Code       RemediationCode `json:"code,omitempty"`
+	Message    string          `json:"message"`
+	Suggestion string          `json:"suggestion,omitempty"`
+	LatencyMs  int64           `json:"latency_ms,omitempty"`
+}
+
+// String returns the diagnosis in the form of the health check reasons.
+func (d *Diagnosis) String() string {
+	if d.Target != "" {
+		return fmt.Sprintf("[%s] %s %s: %s", d.Code, d.Probe, d.Target, d.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s", d.Code, d.Probe, d.Message)
+}
+
+// Report is the result of all the probes.
+type Report struct {
+	Healthy   bool         `json:"healthy"`
+	Time      time.Time    `json:"time"`
+	Diagnoses []*Diagnosis `json:"diagnoses"`
+}
+
+// Problems returns the diagnoses which are not ok.
+func (r *Report) Problems() []*Diagnosis {
+	result := make([]*Diagnosis, 0)
+	for _, d := range r.Diagnoses {
+		if d.Severity != SeverityOK {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// ProbeFunc checks a dependency or the components, and returns the diagnoses.
+type ProbeFunc func(ctx context.Context) []*Diagnosis
+
+type namedProbe struct {
+	name  string
+	probe ProbeFunc
+}
+
+// Checker runs the registered probes concurrently.
+// The probes may write the dependencies, so they run one round at a time,
+// and the report of the latest round is cached for the frequent health checks.
+type Checker struct {
+	mu      sync.RWMutex
+	probes  []*namedProbe
+	timeout func() time.Duration
+	latest  *Report
+
+	checkMu   sync.Mutex
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewChecker returns a checker, each probe is canceled after the timeout.
+func NewChecker(timeout func() time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// Start runs the probes every interval in background until stopped,
+// the round is skipped if enabled returns false.
+func (c *Checker) Start(interval func() time.Duration, enabled func() bool) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		timer := time.NewTimer(interval())
+		defer timer.Stop()
+		for {
+			select {
+			case <-c.closeCh:
+				return
+			case <-timer.C:
+				if enabled() {
+					ctx, cancel := context.WithCancel(context.Background())
+					go func() {
+						select {
+						case <-c.closeCh:
+							cancel()
+						case <-ctx.Done():
+						}
+					}()
+					c.Check(ctx)
+					cancel()
+				}
+				timer.Reset(interval())
+			}
+		}
+	}()
+}
+
+// Stop stops the background probing.
+func (c *Checker) Stop() {
+	c.closeOnce.Do(func() {
+		close(c.closeCh)
+		c.wg.Wait()
+	})
+}
+
+// Latest returns the report of the latest round, nil if no probe has run.
+func (c *Checker) Latest() *Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.latest
+}
+
+// Register adds a probe to the checker.
+func (c *Checker) Register(name string, probe ProbeFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probes = append(c.probes, &namedProbe{name: name, probe: probe})
+}
+
+// Check runs all the probes and returns the report,
+// the report is healthy if no critical diagnosis is found.
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.checkMu.Lock()
+	defer c.checkMu.Unlock()
+
+	c.mu.RLock()
+	probes := c.probes
+	c.mu.RUnlock()
+
+	group, _ := conc.NewGroup[[]*Diagnosis](ctx, 0)
+	for _, p := range probes {
+		p := p
+		group.Go(func(ctx context.Context) ([]*Diagnosis, error) {
+			ctx, cancel := context.WithTimeout(ctx, c.timeout())
+			defer cancel()
+			diagnoses, err := runProbe(ctx, p)
+			if err != nil {
+				// report the failure as diagnosis, the other probes should keep running
+				log.Warn("health check probe failed", zap.String("probe", p.name), zap.Error(err))
+				diagnoses = []*Diagnosis{newDiagnosis(p.name, "", SeverityCritical, CodeProbeFailed, err.Error())}
+			}
+			return diagnoses, nil
+		})
+	}
+	results, _ := group.Wait()
+
+	report := &Report{
+		Healthy:   true,
+		Time:      time.Now(),
+		Diagnoses: make([]*Diagnosis, 0),
+	}
+	for _, diagnoses := range results {
+		for _, d := range diagnoses {
+			if d.Severity == SeverityCritical {
+				report.Healthy = false
+			}
+			report.Diagnoses = append(report.Diagnoses, d)
+		}
+	}
+	sort.SliceStable(report.Diagnoses, func(i, j int) bool {
+		if report.Diagnoses[i].Probe != report.Diagnoses[j].Probe {
+			return report.Diagnoses[i].Probe < report.Diagnoses[j].Probe
+		}
+		return report.Diagnoses[i].Target < report.Diagnoses[j].Target
+	})
+
+	c.mu.Lock()
+	c.latest = report
+	c.mu.Unlock()
+	return report
+}
+
+func runProbe(ctx context.Context, p *namedProbe) (diagnoses []*Diagnosis, err error) {
+	defer func() {
+		if x := recover(); x != nil {
+			err = fmt.Errorf("probe panicked: %v", x)
+		}
+	}()
+	return p.probe(ctx), nil
+}
+
+func newDiagnosis(probe, target string, severity Severity, code RemediationCode, message string) *Diagnosis {
+	d := &Diagnosis{
+		Probe:    probe,
+		Target:   target,
+		Severity: severity,
+		Code:     code,
+		Message:  message,
+	}
+	if code != "" {
+		d.Suggestion = suggestions[code]
+	}
+	return d
+}
+
+// latencyDiagnosis returns the diagnosis of a probe round trip,
+// which is warning if the latency exceeds the threshold.
+func latencyDiagnosis(probe, target string, latency, threshold time.Duration, code RemediationCode) *Diagnosis {
+	var d *Diagnosis
+	if threshold > 0 && latency > threshold {
+		d = newDiagnosis(probe, target, SeverityWarning, code,
+			fmt.Sprintf("round trip took %v, exceeds the threshold %v", latency, threshold))
+	} else {
+		d = newDiagnosis(probe, target, SeverityOK, "", "ok")
+	}
+	d.LatencyMs = latency.Milliseconds()
+	return d
+}
@@ -0,0 +1,237 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	mqfactory "github.com/milvus-io/milvus/internal/mq/msgstream"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+func constant(d time.Duration) func() time.Duration {
+	return func() time.Duration { return d }
+}
+
+func TestChecker(t *testing.T) {
+	checker := NewChecker(constant(50 * time.Millisecond))
+	checker.Register("b", func(ctx context.Context) []*Diagnosis {
+		return []*Diagnosis{newDiagnosis("b", "", SeverityWarning, CodeEtcdSlow, "slow")}
+	})
+	checker.Register("a", func(ctx context.Context) []*Diagnosis {
+		return []*Diagnosis{newDiagnosis("a", "", SeverityOK, "", "ok")}
+	})
+
+	report := checker.Check(context.Background())
+	assert.True(t, report.Healthy)
+	assert.Equal(t, 2, len(report.Diagnoses))
+	assert.Equal(t, "a", report.Diagnoses[0].Probe)
+	problems := report.Problems()
+	assert.Equal(t, 1, len(problems))
+	assert.Equal(t, CodeEtcdSlow, problems[0].Code)
+	assert.NotEmpty(t, problems[0].Suggestion)
+	assert.Equal(t, "[ETCD_SLOW] b: slow", problems[0].String())
+
+	// the probe is canceled after timeout
+	checker.Register("c", func(ctx context.Context) []*Diagnosis {
+		<-ctx.Done()
+		return []*Diagnosis{newDiagnosis("c", "x", SeverityCritical, CodeMQUnreachable, ctx.Err().Error())}
+	})
+	// the panic is reported as diagnosis
+	checker.Register("d", func(ctx context.Context) []*Diagnosis {
+		panic("mock panic")
+	})
+	report = checker.Check(context.Background())
+	assert.False(t, report.Healthy)
+	assert.Equal(t, 4, len(report.Diagnoses))
+	assert.Equal(t, CodeMQUnreachable, report.Diagnoses[2].Code)
+	assert.Equal(t, CodeProbeFailed, report.Diagnoses[3].Code)
+}
+
+func TestCheckerBackground(t *testing.T) {
+	checker := NewChecker(constant(time.Second))
+	checker.Register("a", func(ctx context.Context) []*Diagnosis {
+		return []*Diagnosis{newDiagnosis("a", "", SeverityCritical, CodeEtcdUnreachable, "down")}
+	})
+	assert.Nil(t, checker.Latest())
+
+	checker.Start(constant(10*time.Millisecond), func() bool { return true })
+	defer checker.Stop()
+	assert.Eventually(t, func() bool {
+		return checker.Latest() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, checker.Latest().Healthy)
+	checker.Stop()
+	checker.Stop()
+}
+
+func TestMQProbe(t *testing.T) {
+	paramtable.Init()
+	factory := mqfactory.NewRocksmqFactory(t.TempDir(), &paramtable.Get().ServiceParam)
+
+	probe := MQProbe(factory, "health-probe", "health-probe-sub", 1, constant(time.Minute))
+	// probe repeatedly, the messages of former rounds are skipped
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		diagnoses := probe(ctx)
+		cancel()
+		assert.Equal(t, 1, len(diagnoses))
+		assert.Equal(t, SeverityOK, diagnoses[0].Severity, diagnoses[0].Message)
+	}
+}
+
+type mockKV struct {
+	clientv3.KV
+	data   map[string]string
+	putErr error
+}
+
+func (kv *mockKV) Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	if kv.putErr != nil {
+		return nil, kv.putErr
+	}
+	kv.data[key] = val
+	return &clientv3.PutResponse{}, nil
+}
+
+func (kv *mockKV) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	resp := &clientv3.GetResponse{}
+	if v, ok := kv.data[key]; ok {
+		resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: []byte(key), Value: []byte(v)})
+	}
+	return resp, nil
+}
+
+func (kv *mockKV) Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
+	delete(kv.data, key)
+	return &clientv3.DeleteResponse{}, nil
+}
+
+func TestEtcdProbe(t *testing.T) {
+	kv := &mockKV{data: make(map[string]string)}
+	diagnoses := EtcdProbe(kv, "probe", constant(time.Second))(context.Background())
+	assert.Equal(t, SeverityOK, diagnoses[0].Severity)
+	assert.Empty(t, kv.data)
+
+	kv.putErr = errors.New("mock error")
+	diagnoses = EtcdProbe(kv, "probe", constant(time.Second))(context.Background())
+	assert.Equal(t, SeverityCritical, diagnoses[0].Severity)
+	assert.Equal(t, CodeEtcdUnreachable, diagnoses[0].Code)
+}
+
+func TestStorageProbe(t *testing.T) {
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	getter := func(ctx context.Context) (storage.ChunkManager, error) {
+		return cm, nil
+	}
+	diagnoses := StorageProbe(getter, "probe/1", constant(time.Second))(context.Background())
+	assert.Equal(t, SeverityOK, diagnoses[0].Severity)
+	exist, err := cm.Exist(context.Background(), cm.RootPath()+"/probe/1")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	getter = func(ctx context.Context) (storage.ChunkManager, error) {
+		return nil, errors.New("mock error")
+	}
+	diagnoses = StorageProbe(getter, "probe/1", constant(time.Second))(context.Background())
+	assert.Equal(t, CodeStorageUnreachable, diagnoses[0].Code)
+}
+
+type mockSessions map[string]*sessionutil.Session
+
+func (m mockSessions) GetSessions(prefix string) (map[string]*sessionutil.Session, int64, error) {
+	result := make(map[string]*sessionutil.Session)
+	for key, session := range m {
+		if strings.HasPrefix(key, prefix) {
+			result[key] = session
+		}
+	}
+	return result, 0, nil
+}
+
+type mockLease struct {
+	clientv3.Lease
+	ttl map[clientv3.LeaseID]int64
+}
+
+func (l *mockLease) TimeToLive(ctx context.Context, id clientv3.LeaseID, opts ...clientv3.LeaseOption) (*clientv3.LeaseTimeToLiveResponse, error) {
+	return &clientv3.LeaseTimeToLiveResponse{ID: id, TTL: l.ttl[id], GrantedTTL: 30}, nil
+}
+
+func TestHeartbeatProbe(t *testing.T) {
+	newSession := func(role string, leaseID clientv3.LeaseID) *sessionutil.Session {
+		session := &sessionutil.Session{}
+		session.ServerName = role
+		session.LeaseID = &leaseID
+		return session
+	}
+	sessions := mockSessions{
+		typeutil.RootCoordRole:        newSession(typeutil.RootCoordRole, 1),
+		typeutil.DataCoordRole:        newSession(typeutil.DataCoordRole, 2),
+		typeutil.QueryNodeRole + "-1": newSession(typeutil.QueryNodeRole, 3),
+	}
+	lease := &mockLease{ttl: map[clientv3.LeaseID]int64{1: 25, 2: 5, 3: 0}}
+
+	diagnoses := HeartbeatProbe(sessions, lease, constant(20*time.Second))(context.Background())
+	result := make(map[string]*Diagnosis)
+	for _, d := range diagnoses {
+		result[d.Target] = d
+	}
+	assert.Equal(t, 4, len(result))
+	assert.Equal(t, SeverityOK, result[typeutil.RootCoordRole].Severity)
+	assert.Equal(t, SeverityWarning, result[typeutil.DataCoordRole].Severity)
+	assert.Equal(t, CodeComponentHeartbeatStale, result[typeutil.DataCoordRole].Code)
+	assert.Equal(t, SeverityCritical, result[typeutil.QueryNodeRole+"-1"].Severity)
+	assert.Equal(t, CodeComponentMissing, result[typeutil.QueryCoordRole].Code)
+}
+
+func TestTimeTickProbe(t *testing.T) {
+	now := time.Now()
+	getter := func() (map[string]uint64, error) {
+		return map[string]uint64{
+			"ch1": tsoutil.ComposeTSByTime(now, 0),
+			"ch2": tsoutil.ComposeTSByTime(now.Add(-time.Minute), 0),
+		}, nil
+	}
+	diagnoses := TimeTickProbe(getter, constant(10*time.Second))(context.Background())
+	assert.Equal(t, 2, len(diagnoses))
+	for _, d := range diagnoses {
+		if d.Target == "ch1" {
+			assert.Equal(t, SeverityOK, d.Severity)
+		} else {
+			assert.Equal(t, CodeTimeTickLag, d.Code)
+		}
+	}
+
+	getter = func() (map[string]uint64, error) {
+		return nil, errors.New("mock error")
+	}
+	diagnoses = TimeTickProbe(getter, constant(10*time.Second))(context.Background())
+	assert.Equal(t, CodeProbeFailed, diagnoses[0].Code)
+}
@@ -0,0 +1,271 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/pkg/util/commonpbutil"
+	"github.com/milvus-io/milvus/pkg/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+const (
+	ProbeEtcd      = "etcd"
+	ProbeMQ        = "mq"
+	ProbeStorage   = "storage"
+	ProbeHeartbeat = "heartbeat"
+	ProbeTimeTick  = "timetick"
+)
+
+// EtcdProbe writes, reads and deletes the key in etcd.
+func EtcdProbe(kv clientv3.KV, key string, threshold func() time.Duration) ProbeFunc {
+	return func(ctx context.Context) []*Diagnosis {
+		start := time.Now()
+		value := strconv.FormatInt(start.UnixNano(), 10)
+		if _, err := kv.Put(ctx, key, value); err != nil {
+			return []*Diagnosis{newDiagnosis(ProbeEtcd, "", SeverityCritical, CodeEtcdUnreachable, "failed to put key: "+err.Error())}
+		}
+		resp, err := kv.Get(ctx, key)
+		if err != nil {
+			return []*Diagnosis{newDiagnosis(ProbeEtcd, "", SeverityCritical, CodeEtcdUnreachable, "failed to get key: "+err.Error())}
+		}
+		if len(resp.Kvs) == 0 || string(resp.Kvs[0].Value) != value {
+			return []*Diagnosis{newDiagnosis(ProbeEtcd, "", SeverityCritical, CodeEtcdUnreachable, "the value read is different from the one written")}
+		}
+		if _, err := kv.Delete(ctx, key); err != nil {
+			return []*Diagnosis{newDiagnosis(ProbeEtcd, "", SeverityCritical, CodeEtcdUnreachable, "failed to delete key: "+err.Error())}
+		}
+		return []*Diagnosis{latencyDiagnosis(ProbeEtcd, "", time.Since(start), threshold(), CodeEtcdSlow)}
+	}
+}
+
+// MQProbe produces a message into the dedicated probe channel and waits until it's consumed.
+// A marker message is produced first and the consumer seeks to it,
+// so the probe message is consumed no matter where the subscription starts,
+// and the subscription is removed after probing.
+func MQProbe(factory msgstream.Factory, channel string, subName string, sourceID int64, threshold func() time.Duration) ProbeFunc {
+	return func(ctx context.Context) []*Diagnosis {
+		fail := func(msg string, err error) []*Diagnosis {
+			return []*Diagnosis{newDiagnosis(ProbeMQ, channel, SeverityCritical, CodeMQUnreachable, fmt.Sprintf("%s: %s", msg, err.Error()))}
+		}
+		producer, err := factory.NewMsgStream(ctx)
+		if err != nil {
+			return fail("failed to create producer", err)
+		}
+		defer producer.Close()
+		producer.AsProducer([]string{channel})
+
+		ids, err := producer.Broadcast(newProbeMsgPack(tsoutil.ComposeTSByTime(time.Now(), 0), sourceID))
+		if err != nil {
+			return fail("failed to produce marker message", err)
+		}
+		if len(ids[channel]) == 0 {
+			return fail("failed to produce marker message", fmt.Errorf("no message id returned"))
+		}
+
+		consumer, err := factory.NewMsgStream(ctx)
+		if err != nil {
+			return fail("failed to create consumer", err)
+		}
+		defer consumer.Close()
+		defer func() {
+			if err := factory.NewMsgStreamDisposer(context.Background())([]string{channel}, subName); err != nil {
+				log.Warn("failed to remove the subscription of health probe", zap.String("channel", channel), zap.String("subName", subName), zap.Error(err))
+			}
+		}()
+		if err := consumer.AsConsumer(ctx, []string{channel}, subName, mqwrapper.SubscriptionPositionUnknown); err != nil {
+			return fail("failed to subscribe channel", err)
+		}
+		if err := consumer.Seek(ctx, []*msgpb.MsgPosition{{ChannelName: channel, MsgID: ids[channel][0].Serialize()}}); err != nil {
+			return fail("failed to seek to the marker message", err)
+		}
+
+		start := time.Now()
+		// the logical part distinguishes the probe message from the marker produced in the same millisecond
+		ts := tsoutil.ComposeTSByTime(start, 1)
+		if err := producer.Produce(newProbeMsgPack(ts, sourceID)); err != nil {
+			return fail("failed to produce message", err)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return fail("failed to consume the produced message", ctx.Err())
+			case pack, ok := <-consumer.Chan():
+				if !ok {
+					return fail("failed to consume the produced message", fmt.Errorf("consumer closed"))
+				}
+				for _, msg := range pack.Msgs {
+					if msg.EndTs() == ts && msg.SourceID() == sourceID {
+						return []*Diagnosis{latencyDiagnosis(ProbeMQ, channel, time.Since(start), threshold(), CodeMQSlow)}
+					}
+				}
+			}
+		}
+	}
+}
+
+func newProbeMsgPack(ts uint64, sourceID int64) *msgstream.MsgPack {
+	return &msgstream.MsgPack{
+		BeginTs: ts,
+		EndTs:   ts,
+		Msgs: []msgstream.TsMsg{
+			&msgstream.TimeTickMsg{
+				BaseMsg: msgstream.BaseMsg{
+					BeginTimestamp: ts,
+					EndTimestamp:   ts,
+					HashValues:     []uint32{0},
+				},
+				TimeTickMsg: msgpb.TimeTickMsg{
+					Base: commonpbutil.NewMsgBase(
+						commonpbutil.WithMsgType(commonpb.MsgType_TimeTick),
+						commonpbutil.WithTimeStamp(ts),
+						commonpbutil.WithSourceID(sourceID),
+					),
+				},
+			},
+		},
+	}
+}
+
+// StorageProbe writes, reads and removes the object in the object storage.
+func StorageProbe(getter func(ctx context.Context) (storage.ChunkManager, error), path string, threshold func() time.Duration) ProbeFunc {
+	return func(ctx context.Context) []*Diagnosis {
+		fail := func(msg string, err error) []*Diagnosis {
+			return []*Diagnosis{newDiagnosis(ProbeStorage, "", SeverityCritical, CodeStorageUnreachable, fmt.Sprintf("%s: %s", msg, err.Error()))}
+		}
+		cm, err := getter(ctx)
+		if err != nil {
+			return fail("failed to create chunk manager", err)
+		}
+
+		start := time.Now()
+		objectPath := cm.RootPath() + "/" + path
+		value := []byte(strconv.FormatInt(start.UnixNano(), 10))
+		if err := cm.Write(ctx, objectPath, value); err != nil {
+			return fail("failed to write object", err)
+		}
+		defer cm.Remove(context.Background(), objectPath)
+		read, err := cm.Read(ctx, objectPath)
+		if err != nil {
+			return fail("failed to read object", err)
+		}
+		if string(read) != string(value) {
+			return fail("failed to read object", fmt.Errorf("the content read is different from the one written"))
+		}
+		return []*Diagnosis{latencyDiagnosis(ProbeStorage, "", time.Since(start), threshold(), CodeStorageSlow)}
+	}
+}
+
+var (
+	heartbeatRoles = []string{
+		typeutil.RootCoordRole,
+		typeutil.DataCoordRole,
+		typeutil.QueryCoordRole,
+		typeutil.ProxyRole,
+		typeutil.DataNodeRole,
+		typeutil.QueryNodeRole,
+		typeutil.IndexNodeRole,
+	}
+	requiredRoles = typeutil.NewSet(typeutil.RootCoordRole, typeutil.DataCoordRole, typeutil.QueryCoordRole)
+)
+
+// SessionGetter lists the sessions of the components.
+type SessionGetter interface {
+	GetSessions(prefix string) (map[string]*sessionutil.Session, int64, error)
+}
+
+// HeartbeatProbe checks whether the coordinators are online,
+// and how long it has been since the sessions of the components were kept alive last time.
+func HeartbeatProbe(sessions SessionGetter, lease clientv3.Lease, staleThreshold func() time.Duration) ProbeFunc {
+	return func(ctx context.Context) []*Diagnosis {
+		diagnoses := make([]*Diagnosis, 0)
+		for _, role := range heartbeatRoles {
+			roleSessions, _, err := sessions.GetSessions(role)
+			if err != nil {
+				diagnoses = append(diagnoses, newDiagnosis(ProbeHeartbeat, role, SeverityCritical, CodeEtcdUnreachable, "failed to list sessions: "+err.Error()))
+				continue
+			}
+			if len(roleSessions) == 0 && requiredRoles.Contain(role) {
+				diagnoses = append(diagnoses, newDiagnosis(ProbeHeartbeat, role, SeverityCritical, CodeComponentMissing, "no online session of the component"))
+				continue
+			}
+			for key, session := range roleSessions {
+				if session.LeaseID == nil {
+					continue
+				}
+				resp, err := lease.TimeToLive(ctx, *session.LeaseID)
+				if err != nil {
+					diagnoses = append(diagnoses, newDiagnosis(ProbeHeartbeat, key, SeverityWarning, CodeEtcdUnreachable, "failed to get the lease: "+err.Error()))
+					continue
+				}
+				if resp.TTL <= 0 {
+					diagnoses = append(diagnoses, newDiagnosis(ProbeHeartbeat, key, SeverityCritical, CodeComponentHeartbeatStale, "the session has expired"))
+					continue
+				}
+				elapsed := time.Duration(resp.GrantedTTL-resp.TTL) * time.Second
+				if threshold := staleThreshold(); threshold > 0 && elapsed > threshold {
+					diagnoses = append(diagnoses, newDiagnosis(ProbeHeartbeat, key, SeverityWarning, CodeComponentHeartbeatStale,
+						fmt.Sprintf("the session was kept alive %v ago, exceeds the threshold %v", elapsed, threshold)))
+					continue
+				}
+				diagnoses = append(diagnoses, newDiagnosis(ProbeHeartbeat, key, SeverityOK, "", "ok"))
+			}
+		}
+		return diagnoses
+	}
+}
+
+// TimeTickProbe checks the lag between now and the time ticks of the channels.
+func TimeTickProbe(getter func() (map[string]uint64, error), threshold func() time.Duration) ProbeFunc {
+	return func(ctx context.Context) []*Diagnosis {
+		stats, err := getter()
+		if err != nil {
+			return []*Diagnosis{newDiagnosis(ProbeTimeTick, "", SeverityCritical, CodeProbeFailed, "failed to get time ticks: "+err.Error())}
+		}
+		diagnoses := make([]*Diagnosis, 0, len(stats))
+		now := time.Now()
+		for channel, ts := range stats {
+			t, _ := tsoutil.ParseTS(ts)
+			lag := now.Sub(t)
+			var d *Diagnosis
+			if threshold := threshold(); threshold > 0 && lag > threshold {
+				d = newDiagnosis(ProbeTimeTick, channel, SeverityWarning, CodeTimeTickLag,
+					fmt.Sprintf("the time tick lags %v, exceeds the threshold %v", lag, threshold))
+			} else {
+				d = newDiagnosis(ProbeTimeTick, channel, SeverityOK, "", "ok")
+			}
+			d.LatencyMs = lag.Milliseconds()
+			diagnoses = append(diagnoses, d)
+		}
+		return diagnoses
+	}
+}
@@ -17,6 +17,7 @@
 package importutil
 
 import (
+	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -33,8 +34,19 @@ const (
 	StartTs      = "start_ts" // start timestamp to filter data, only data between StartTs and EndTs will be imported
 	EndTs        = "end_ts"   // end timestamp to filter data, only data between StartTs and EndTs will be imported
 	OptionFormat = "start_ts: 10-digit physical timestamp, e.g. 1665995420, default 0 \n" +
-		"end_ts: 10-digit physical timestamp, e.g. 1665995420, default math.MaxInt \n"
+		"end_ts: 10-digit physical timestamp, e.g. 1665995420, default math.MaxInt \n" +
+		"visibility: when the imported segments become visible, file, job or timestamp, default file \n" +
+		"visible_ts: 10-digit physical timestamp the imported segments become visible at, e.g. 1665995420 \n"
 	BackupFlag = "backup"
+	Visibility = "visibility" // when the imported segments become visible
+	VisibleTs  = "visible_ts" // the physical timestamp the imported segments become visible at, required by VisibleOnTimestamp
+)
+
+// Values of the Visibility option
+const (
+	VisibleOnFile      = "file"      // the segments of each file become visible once the file is imported
+	VisibleOnJob       = "job"       // the segments of all the files become visible atomically once the whole job is imported
+	VisibleOnTimestamp = "timestamp" // the segments become visible once imported and the visible_ts is reached
 )
 
 type ImportOptions struct {
@@ -82,6 +94,9 @@ func ValidateOptions(options []*commonpb.KeyValuePair) error {
 	if startTs > endTs {
 		return merr.WrapErrImportFailed("start_ts shouldn't be larger than end_ts")
 	}
+	if _, _, err = ParseVisibilityFromOptions(options); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -112,6 +127,37 @@ func ParseTSFromOptions(options []*commonpb.KeyValuePair) (uint64, uint64, error
 	return tsStart, tsEnd, nil
 }
 
+// ParseVisibilityFromOptions returns the visibility and the visible physical timestamp of the imported segments.
+// The visible timestamp is 0 unless the visibility is VisibleOnTimestamp.
+func ParseVisibilityFromOptions(options []*commonpb.KeyValuePair) (string, int64, error) {
+	optionMap := funcutil.KeyValuePair2Map(options)
+	visibility, ok := optionMap[Visibility]
+	if !ok {
+		visibility = VisibleOnFile
+	}
+	visibility = strings.ToLower(visibility)
+	value, hasTs := optionMap[VisibleTs]
+	switch visibility {
+	case VisibleOnFile, VisibleOnJob:
+		if hasTs {
+			return "", 0, merr.WrapErrImportFailed(fmt.Sprintf("visible_ts is only allowed with visibility %s", VisibleOnTimestamp))
+		}
+		return visibility, 0, nil
+	case VisibleOnTimestamp:
+		if !hasTs {
+			return "", 0, merr.WrapErrImportFailed(fmt.Sprintf("visible_ts is required by visibility %s", VisibleOnTimestamp))
+		}
+		visibleTs, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || visibleTs < 0 {
+			return "", 0, merr.WrapErrImportFailed(fmt.Sprintf("invalid visible_ts %s", value))
+		}
+		return visibility, visibleTs, nil
+	default:
+		return "", 0, merr.WrapErrImportFailed(fmt.Sprintf("invalid visibility %s, should be %s, %s or %s",
+			visibility, VisibleOnFile, VisibleOnJob, VisibleOnTimestamp))
+	}
+}
+
 // IsBackup returns if the request is triggered by backup tool
 func IsBackup(options []*commonpb.KeyValuePair) bool {
 	isBackup, err := funcutil.GetAttrByKeyFromRepeatedKV(BackupFlag, options)
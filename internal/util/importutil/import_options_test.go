@@ -110,3 +110,39 @@ func Test_IsBackup(t *testing.T) {
 	})
 	assert.Equal(t, false, noBackup)
 }
+
+func Test_ParseVisibilityFromOptions(t *testing.T) {
+	visibility, visibleTs, err := ParseVisibilityFromOptions([]*commonpb.KeyValuePair{})
+	assert.NoError(t, err)
+	assert.Equal(t, VisibleOnFile, visibility)
+	assert.Equal(t, int64(0), visibleTs)
+
+	visibility, _, err = ParseVisibilityFromOptions([]*commonpb.KeyValuePair{{Key: Visibility, Value: "JOB"}})
+	assert.NoError(t, err)
+	assert.Equal(t, VisibleOnJob, visibility)
+
+	visibility, visibleTs, err = ParseVisibilityFromOptions([]*commonpb.KeyValuePair{
+		{Key: Visibility, Value: VisibleOnTimestamp},
+		{Key: VisibleTs, Value: "1666007457"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, VisibleOnTimestamp, visibility)
+	assert.Equal(t, int64(1666007457), visibleTs)
+
+	_, _, err = ParseVisibilityFromOptions([]*commonpb.KeyValuePair{{Key: Visibility, Value: "segment"}})
+	assert.Error(t, err)
+	_, _, err = ParseVisibilityFromOptions([]*commonpb.KeyValuePair{{Key: Visibility, Value: VisibleOnTimestamp}})
+	assert.Error(t, err)
+	_, _, err = ParseVisibilityFromOptions([]*commonpb.KeyValuePair{
+		{Key: Visibility, Value: VisibleOnTimestamp},
+		{Key: VisibleTs, Value: "3.14"},
+	})
+	assert.Error(t, err)
+	_, _, err = ParseVisibilityFromOptions([]*commonpb.KeyValuePair{
+		{Key: Visibility, Value: VisibleOnJob},
+		{Key: VisibleTs, Value: "1666007457"},
+	})
+	assert.Error(t, err)
+
+	assert.Error(t, ValidateOptions([]*commonpb.KeyValuePair{{Key: Visibility, Value: "segment"}}))
+}
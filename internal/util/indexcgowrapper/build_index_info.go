@@ -39,9 +39,8 @@ type BuildIndexInfo struct {
 	cBuildIndexInfo C.CBuildIndexInfo
 }
 
-func NewBuildIndexInfo(config *indexpb.StorageConfig) (*BuildIndexInfo, error) {
-	var cBuildIndexInfo C.CBuildIndexInfo
-
+// newCStorageConfig converts the storage config, the returned func frees the c strings.
+func newCStorageConfig(config *indexpb.StorageConfig) (C.CStorageConfig, func()) {
 	cAddress := C.CString(config.Address)
 	cBucketName := C.CString(config.BucketName)
 	cAccessKey := C.CString(config.AccessKeyID)
@@ -51,15 +50,17 @@ func NewBuildIndexInfo(config *indexpb.StorageConfig) (*BuildIndexInfo, error) {
 	cIamEndPoint := C.CString(config.IAMEndpoint)
 	cRegion := C.CString(config.Region)
 	cCloudProvider := C.CString(config.CloudProvider)
-	defer C.free(unsafe.Pointer(cAddress))
-	defer C.free(unsafe.Pointer(cBucketName))
-	defer C.free(unsafe.Pointer(cAccessKey))
-	defer C.free(unsafe.Pointer(cAccessValue))
-	defer C.free(unsafe.Pointer(cRootPath))
-	defer C.free(unsafe.Pointer(cStorageType))
-	defer C.free(unsafe.Pointer(cIamEndPoint))
-	defer C.free(unsafe.Pointer(cRegion))
-	defer C.free(unsafe.Pointer(cCloudProvider))
+	free := func() {
+		C.free(unsafe.Pointer(cAddress))
+		C.free(unsafe.Pointer(cBucketName))
+		C.free(unsafe.Pointer(cAccessKey))
+		C.free(unsafe.Pointer(cAccessValue))
+		C.free(unsafe.Pointer(cRootPath))
+		C.free(unsafe.Pointer(cStorageType))
+		C.free(unsafe.Pointer(cIamEndPoint))
+		C.free(unsafe.Pointer(cRegion))
+		C.free(unsafe.Pointer(cCloudProvider))
+	}
 	storageConfig := C.CStorageConfig{
 		address:          cAddress,
 		bucket_name:      cBucketName,
@@ -75,6 +76,14 @@ func NewBuildIndexInfo(config *indexpb.StorageConfig) (*BuildIndexInfo, error) {
 		useVirtualHost:   C.bool(config.UseVirtualHost),
 		requestTimeoutMs: C.int64_t(config.RequestTimeoutMs),
 	}
+	return storageConfig, free
+}
+
+func NewBuildIndexInfo(config *indexpb.StorageConfig) (*BuildIndexInfo, error) {
+	var cBuildIndexInfo C.CBuildIndexInfo
+
+	storageConfig, free := newCStorageConfig(config)
+	defer free()
 
 	status := C.NewBuildIndexInfo(&cBuildIndexInfo, storageConfig)
 	if err := HandleCStatus(&status, "NewBuildIndexInfo failed"); err != nil {
@@ -110,6 +119,15 @@ func (bi *BuildIndexInfo) AppendFieldMetaInfoV2(collectionID int64, partitionID
 	return HandleCStatus(&status, "appendFieldMetaInfo failed")
 }
 
+// AppendDataStorageConfig sets the bucket storing the binlogs if the collection is routed to a bucket profile.
+func (bi *BuildIndexInfo) AppendDataStorageConfig(config *indexpb.StorageConfig) error {
+	storageConfig, free := newCStorageConfig(config)
+	defer free()
+
+	status := C.AppendDataStorageConfig(bi.cBuildIndexInfo, storageConfig)
+	return HandleCStatus(&status, "appendDataStorageConfig failed")
+}
+
 func (bi *BuildIndexInfo) AppendIndexMetaInfo(indexID int64, buildID int64, indexVersion int64) error {
 	cIndexID := C.int64_t(indexID)
 	cBuildID := C.int64_t(buildID)
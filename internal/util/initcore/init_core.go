@@ -34,6 +34,8 @@ import (
 	"github.com/cockroachdb/errors"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
 )
@@ -94,7 +96,79 @@ func InitRemoteChunkManager(params *paramtable.ComponentParam) error {
 	}
 
 	status := C.InitRemoteChunkManagerSingleton(storageConfig)
-	return HandleCStatus(&status, "InitRemoteChunkManagerSingleton failed")
+	if err := HandleCStatus(&status, "InitRemoteChunkManagerSingleton failed"); err != nil {
+		return err
+	}
+	return initBucketProfiles(params)
+}
+
+// initBucketProfiles adds the bucket profiles to segcore, so that segcore could read the binlogs
+// of the collections routed to them, see RouteCollection.
+func initBucketProfiles(params *paramtable.ComponentParam) error {
+	if params.CommonCfg.StorageType.GetValue() == "local" {
+		return nil
+	}
+	base := &indexpb.StorageConfig{
+		Address:          params.MinioCfg.Address.GetValue(),
+		AccessKeyID:      params.MinioCfg.AccessKeyID.GetValue(),
+		SecretAccessKey:  params.MinioCfg.SecretAccessKey.GetValue(),
+		UseSSL:           params.MinioCfg.UseSSL.GetAsBool(),
+		BucketName:       params.MinioCfg.BucketName.GetValue(),
+		RootPath:         params.MinioCfg.RootPath.GetValue(),
+		UseIAM:           params.MinioCfg.UseIAM.GetAsBool(),
+		IAMEndpoint:      params.MinioCfg.IAMEndpoint.GetValue(),
+		StorageType:      params.CommonCfg.StorageType.GetValue(),
+		Region:           params.MinioCfg.Region.GetValue(),
+		UseVirtualHost:   params.MinioCfg.UseVirtualHost.GetAsBool(),
+		CloudProvider:    params.MinioCfg.CloudProvider.GetValue(),
+		RequestTimeoutMs: params.MinioCfg.RequestTimeoutMs.GetAsInt64(),
+	}
+	for name, profile := range params.MinioCfg.GetBucketProfiles() {
+		config := storage.BucketProfileStorageConfig(base, profile)
+		cProfile := C.CString(name)
+		cAddress := C.CString(config.GetAddress())
+		cBucketName := C.CString(config.GetBucketName())
+		cAccessKey := C.CString(config.GetAccessKeyID())
+		cAccessValue := C.CString(config.GetSecretAccessKey())
+		cRootPath := C.CString(config.GetRootPath())
+		cStorageType := C.CString(config.GetStorageType())
+		cIamEndPoint := C.CString(config.GetIAMEndpoint())
+		cCloudProvider := C.CString(config.GetCloudProvider())
+		cLogLevel := C.CString(params.MinioCfg.LogLevel.GetValue())
+		cRegion := C.CString(config.GetRegion())
+		storageConfig := C.CStorageConfig{
+			address:          cAddress,
+			bucket_name:      cBucketName,
+			access_key_id:    cAccessKey,
+			access_key_value: cAccessValue,
+			root_path:        cRootPath,
+			storage_type:     cStorageType,
+			iam_endpoint:     cIamEndPoint,
+			cloud_provider:   cCloudProvider,
+			useSSL:           C.bool(config.GetUseSSL()),
+			useIAM:           C.bool(config.GetUseIAM()),
+			log_level:        cLogLevel,
+			region:           cRegion,
+			useVirtualHost:   C.bool(config.GetUseVirtualHost()),
+			requestTimeoutMs: C.int64_t(config.GetRequestTimeoutMs()),
+		}
+		status := C.AddRemoteChunkManagerProfile(cProfile, storageConfig)
+		for _, cstr := range []*C.char{cProfile, cAddress, cBucketName, cAccessKey, cAccessValue, cRootPath, cStorageType, cIamEndPoint, cCloudProvider, cLogLevel, cRegion} {
+			C.free(unsafe.Pointer(cstr))
+		}
+		if err := HandleCStatus(&status, "AddRemoteChunkManagerProfile failed"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RouteCollection routes the binlogs of collection read by segcore to the bucket profile.
+func RouteCollection(collectionID int64, profile string) error {
+	cProfile := C.CString(profile)
+	defer C.free(unsafe.Pointer(cProfile))
+	status := C.SetRemoteChunkManagerRoute(C.int64_t(collectionID), cProfile)
+	return HandleCStatus(&status, "SetRemoteChunkManagerRoute failed")
 }
 
 func InitChunkCache(mmapDirPath string, readAheadPolicy string) error {
@@ -106,6 +180,21 @@ func InitChunkCache(mmapDirPath string, readAheadPolicy string) error {
 	return HandleCStatus(&status, "InitChunkCacheSingleton failed")
 }
 
+// SetChunkCacheCapacity limits the disk size of the chunk cache, 0 means unlimited.
+func SetChunkCacheCapacity(capacity uint64) {
+	C.SetChunkCacheCapacity(C.int64_t(capacity))
+}
+
+// GetChunkCacheSize returns the disk size of the columns held by the chunk cache.
+func GetChunkCacheSize() uint64 {
+	return uint64(C.GetChunkCacheSize())
+}
+
+// EvictChunkCache evicts the least recently read columns of the chunk cache, returns the bytes freed.
+func EvictChunkCache(size uint64) uint64 {
+	return uint64(C.EvictChunkCache(C.int64_t(size)))
+}
+
 func CleanRemoteChunkManager() {
 	C.CleanRemoteChunkManagerSingleton()
 }
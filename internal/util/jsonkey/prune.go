@@ -0,0 +1,114 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonkey
+
+import (
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+)
+
+// LookupFunc returns the statistics of the json key in a segment, nil if the key has no stats.
+type LookupFunc func(fieldID int64, path []string) *Stats
+
+// MayMatch returns false only if the statistics prove that no row matches the expression.
+// The expressions not on the json keys with stats always may match.
+func MayMatch(expr *planpb.Expr, lookup LookupFunc) bool {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_BinaryExpr:
+		switch e.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			return MayMatch(e.BinaryExpr.GetLeft(), lookup) && MayMatch(e.BinaryExpr.GetRight(), lookup)
+		case planpb.BinaryExpr_LogicalOr:
+			return MayMatch(e.BinaryExpr.GetLeft(), lookup) || MayMatch(e.BinaryExpr.GetRight(), lookup)
+		}
+	case *planpb.Expr_UnaryRangeExpr:
+		stats := lookupColumn(e.UnaryRangeExpr.GetColumnInfo(), lookup)
+		if stats == nil {
+			return true
+		}
+		value := genericValue(e.UnaryRangeExpr.GetValue())
+		switch e.UnaryRangeExpr.GetOp() {
+		case planpb.OpType_Equal:
+			return stats.MayEqual(value)
+		case planpb.OpType_GreaterThan:
+			return stats.MayInRange(Bound{Value: value}, Bound{})
+		case planpb.OpType_GreaterEqual:
+			return stats.MayInRange(Bound{Value: value, Inclusive: true}, Bound{})
+		case planpb.OpType_LessThan:
+			return stats.MayInRange(Bound{}, Bound{Value: value})
+		case planpb.OpType_LessEqual:
+			return stats.MayInRange(Bound{}, Bound{Value: value, Inclusive: true})
+		case planpb.OpType_PrefixMatch:
+			if prefix, ok := value.(string); ok {
+				return stats.MayHavePrefix(prefix)
+			}
+		}
+	case *planpb.Expr_BinaryRangeExpr:
+		stats := lookupColumn(e.BinaryRangeExpr.GetColumnInfo(), lookup)
+		if stats == nil {
+			return true
+		}
+		return stats.MayInRange(
+			Bound{Value: genericValue(e.BinaryRangeExpr.GetLowerValue()), Inclusive: e.BinaryRangeExpr.GetLowerInclusive()},
+			Bound{Value: genericValue(e.BinaryRangeExpr.GetUpperValue()), Inclusive: e.BinaryRangeExpr.GetUpperInclusive()},
+		)
+	case *planpb.Expr_TermExpr:
+		if e.TermExpr.GetIsInField() {
+			return true
+		}
+		stats := lookupColumn(e.TermExpr.GetColumnInfo(), lookup)
+		if stats == nil {
+			return true
+		}
+		for _, value := range e.TermExpr.GetValues() {
+			if stats.MayEqual(genericValue(value)) {
+				return true
+			}
+		}
+		return false
+	case *planpb.Expr_ExistsExpr:
+		stats := lookupColumn(e.ExistsExpr.GetInfo(), lookup)
+		if stats == nil {
+			return true
+		}
+		return stats.Present > 0
+	}
+	return true
+}
+
+func lookupColumn(column *planpb.ColumnInfo, lookup LookupFunc) *Stats {
+	if column.GetDataType() != schemapb.DataType_JSON || len(column.GetNestedPath()) == 0 {
+		return nil
+	}
+	return lookup(column.GetFieldId(), column.GetNestedPath())
+}
+
+// genericValue converts the value to float64, string or bool, nil for arrays.
+func genericValue(value *planpb.GenericValue) any {
+	switch v := value.GetVal().(type) {
+	case *planpb.GenericValue_BoolVal:
+		return v.BoolVal
+	case *planpb.GenericValue_Int64Val:
+		return float64(v.Int64Val)
+	case *planpb.GenericValue_FloatVal:
+		return v.FloatVal
+	case *planpb.GenericValue_StringVal:
+		return v.StringVal
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,315 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonkey implements the statistics on keys of json field, e.g. `$meta["category"]` of the dynamic field.
+// It's not an index: the value range and distinct values of the key in each sealed segment are collected by index node
+// through the index build of type JSON_KEY_STATS, and query node only skips the segments whose statistics
+// prove no row matches the filter, the rows of the other segments are still scanned.
+package jsonkey
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/indexparamcheck"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+const (
+	// StatsFileKey is the file name of json key stats
+	StatsFileKey = "json_key_stats"
+	// MaxValues is the max number of distinct values kept in the statistics
+	MaxValues = 1024
+)
+
+// IsStats returns whether the index params are of json key stats.
+func IsStats(indexParams []*commonpb.KeyValuePair) bool {
+	for _, param := range indexParams {
+		if param.GetKey() == common.IndexTypeKey {
+			return param.GetValue() == indexparamcheck.IndexJSONKeyStats
+		}
+	}
+	return false
+}
+
+// ParseFieldName parses the field name like `$meta["a"]["b"]` into the field name and the json path,
+// the path is empty if the name has no brackets.
+func ParseFieldName(name string) (string, []string, error) {
+	idx := strings.Index(name, "[")
+	if idx < 0 {
+		return name, nil, nil
+	}
+	field, rest := name[:idx], name[idx:]
+	var path []string
+	for rest != "" {
+		if rest[0] != '[' {
+			return "", nil, merr.WrapErrParameterInvalidMsg("invalid json path %s", name)
+		}
+		decoder := json.NewDecoder(strings.NewReader(rest[1:]))
+		token, err := decoder.Token()
+		key, ok := token.(string)
+		if err != nil || !ok {
+			return "", nil, merr.WrapErrParameterInvalidMsg("invalid json path %s, keys must be quoted", name)
+		}
+		rest = strings.TrimLeft(rest[1+int(decoder.InputOffset()):], " ")
+		if !strings.HasPrefix(rest, "]") {
+			return "", nil, merr.WrapErrParameterInvalidMsg("invalid json path %s", name)
+		}
+		rest = rest[1:]
+		path = append(path, key)
+	}
+	return field, path, nil
+}
+
+// FormatPath formats the json path as json array, which is the value of common.JSONPathKey.
+func FormatPath(path []string) string {
+	bs, _ := json.Marshal(path)
+	return string(bs)
+}
+
+// ParsePath parses the value of common.JSONPathKey.
+func ParsePath(value string) ([]string, error) {
+	var path []string
+	if err := json.Unmarshal([]byte(value), &path); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid json path %s: %s", value, err.Error())
+	}
+	if len(path) == 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("json path is empty")
+	}
+	return path, nil
+}
+
+// Stats is the statistics of a json key in a segment.
+// The values are compared as json types, numbers are compared as float64,
+// which keeps the order of int64 values though the precision may be lost.
+type Stats struct {
+	Path []string `json:"path"`
+	Rows int64    `json:"rows"`
+	// Present is the number of rows which have the key
+	Present int64 `json:"present"`
+
+	HasNumber bool    `json:"has_number,omitempty"`
+	NumberMin float64 `json:"number_min,omitempty"`
+	NumberMax float64 `json:"number_max,omitempty"`
+	HasString bool    `json:"has_string,omitempty"`
+	StringMin string  `json:"string_min,omitempty"`
+	StringMax string  `json:"string_max,omitempty"`
+	HasTrue   bool    `json:"has_true,omitempty"`
+	HasFalse  bool    `json:"has_false,omitempty"`
+	// HasOthers is set if some values are arrays, objects or null
+	HasOthers bool `json:"has_others,omitempty"`
+
+	// Values are the encoded distinct scalar values, ValuesOverflow is set and Values dropped
+	// once there are more than MaxValues distinct values.
+	Values         []string `json:"values,omitempty"`
+	ValuesOverflow bool     `json:"values_overflow,omitempty"`
+}
+
+// Build builds the statistics of the json key over the rows of json field.
+func Build(path []string, rows [][]byte) *Stats {
+	stats := &Stats{Path: path, Rows: int64(len(rows))}
+	values := make(map[string]struct{})
+	for _, row := range rows {
+		value, ok := lookup(row, path)
+		if !ok {
+			continue
+		}
+		stats.Present++
+		if encoded, ok := stats.add(value); ok && !stats.ValuesOverflow {
+			values[encoded] = struct{}{}
+			if len(values) > MaxValues {
+				stats.ValuesOverflow = true
+				values = nil
+			}
+		}
+	}
+	if !stats.ValuesOverflow {
+		stats.Values = make([]string, 0, len(values))
+		for value := range values {
+			stats.Values = append(stats.Values, value)
+		}
+		sort.Strings(stats.Values)
+	}
+	return stats
+}
+
+func lookup(row []byte, path []string) (any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(row))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+	for _, key := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		value, ok = object[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// add adds the value into statistics, returns the encoded value if it's a scalar.
+func (s *Stats) add(value any) (string, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		if err != nil {
+			s.HasOthers = true
+			return "", false
+		}
+		if !s.HasNumber || f < s.NumberMin {
+			s.NumberMin = f
+		}
+		if !s.HasNumber || f > s.NumberMax {
+			s.NumberMax = f
+		}
+		s.HasNumber = true
+		return encodeNumber(f), true
+	case string:
+		if !s.HasString || v < s.StringMin {
+			s.StringMin = v
+		}
+		if !s.HasString || v > s.StringMax {
+			s.StringMax = v
+		}
+		s.HasString = true
+		return encodeString(v), true
+	case bool:
+		if v {
+			s.HasTrue = true
+		} else {
+			s.HasFalse = true
+		}
+		return encodeBool(v), true
+	default:
+		s.HasOthers = true
+		return "", false
+	}
+}
+
+func encodeNumber(v float64) string { return "n:" + strconv.FormatFloat(v, 'g', -1, 64) }
+
+func encodeString(v string) string { return "s:" + v }
+
+func encodeBool(v bool) string { return "b:" + strconv.FormatBool(v) }
+
+func (s *Stats) hasValue(encoded string) bool {
+	idx := sort.SearchStrings(s.Values, encoded)
+	return idx < len(s.Values) && s.Values[idx] == encoded
+}
+
+// MayEqual returns false if no value of the key equals to v, v is float64, string or bool.
+func (s *Stats) MayEqual(v any) bool {
+	switch v := v.(type) {
+	case float64:
+		if !s.HasNumber || v < s.NumberMin || v > s.NumberMax {
+			return false
+		}
+		return s.ValuesOverflow || s.hasValue(encodeNumber(v))
+	case string:
+		if !s.HasString || v < s.StringMin || v > s.StringMax {
+			return false
+		}
+		return s.ValuesOverflow || s.hasValue(encodeString(v))
+	case bool:
+		if v {
+			return s.HasTrue
+		}
+		return s.HasFalse
+	default:
+		return true
+	}
+}
+
+// Bound is a bound of range, nil Value means unbounded.
+type Bound struct {
+	Value     any
+	Inclusive bool
+}
+
+// MayInRange returns false if no value of the key is in the range, the bounds must be of the same type.
+func (s *Stats) MayInRange(lower, upper Bound) bool {
+	v := lower.Value
+	if v == nil {
+		v = upper.Value
+	}
+	switch v.(type) {
+	case float64:
+		if !s.HasNumber {
+			return false
+		}
+		return mayOverlap(s.NumberMin, s.NumberMax, lower, upper)
+	case string:
+		if !s.HasString {
+			return false
+		}
+		return mayOverlap(s.StringMin, s.StringMax, lower, upper)
+	default:
+		return true
+	}
+}
+
+func mayOverlap[T float64 | string](min, max T, lower, upper Bound) bool {
+	if l, ok := lower.Value.(T); ok {
+		if max < l || (max == l && !lower.Inclusive) {
+			return false
+		}
+	}
+	if u, ok := upper.Value.(T); ok {
+		if min > u || (min == u && !upper.Inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// MayHavePrefix returns false if no string value of the key has the prefix.
+func (s *Stats) MayHavePrefix(prefix string) bool {
+	if !s.HasString || s.StringMax < prefix {
+		return false
+	}
+	return s.StringMin <= prefix || strings.HasPrefix(s.StringMin, prefix)
+}
+
+// Marshal serializes the statistics as the index file.
+func (s *Stats) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// Unmarshal deserializes the statistics from the index file.
+func Unmarshal(data []byte) (*Stats, error) {
+	stats := &Stats{}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal json key stats")
+	}
+	if len(stats.Path) == 0 {
+		return nil, fmt.Errorf("json key stats without path")
+	}
+	return stats, nil
+}
@@ -0,0 +1,154 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonkey
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestParseFieldName(t *testing.T) {
+	field, path, err := ParseFieldName(`$meta["category"]`)
+	require.NoError(t, err)
+	assert.Equal(t, "$meta", field)
+	assert.Equal(t, []string{"category"}, path)
+
+	field, path, err = ParseFieldName(`json["a"][ "b]\"c" ]`)
+	require.NoError(t, err)
+	assert.Equal(t, "json", field)
+	assert.Equal(t, []string{"a", `b]"c`}, path)
+
+	field, path, err = ParseFieldName("age")
+	require.NoError(t, err)
+	assert.Equal(t, "age", field)
+	assert.Empty(t, path)
+
+	for _, name := range []string{`$meta[category]`, `$meta["a"`, `$meta["a"]x`, `$meta[1]`} {
+		_, _, err = ParseFieldName(name)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid, name)
+	}
+
+	path, err = ParsePath(FormatPath([]string{"a", "b"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, path)
+	_, err = ParsePath("[]")
+	assert.Error(t, err)
+	_, err = ParsePath("a")
+	assert.Error(t, err)
+}
+
+func TestStats(t *testing.T) {
+	rows := [][]byte{
+		[]byte(`{"a": {"b": 3}}`),
+		[]byte(`{"a": {"b": 10.5}}`),
+		[]byte(`{"a": {"b": "red"}}`),
+		[]byte(`{"a": {"b": true}}`),
+		[]byte(`{"a": {"b": [1, 2]}}`),
+		[]byte(`{"a": 1}`),
+		[]byte(`{}`),
+		[]byte(`invalid`),
+	}
+	stats := Build([]string{"a", "b"}, rows)
+	assert.EqualValues(t, 8, stats.Rows)
+	assert.EqualValues(t, 5, stats.Present)
+	assert.True(t, stats.HasOthers)
+	assert.Len(t, stats.Values, 4)
+
+	assert.True(t, stats.MayEqual(float64(3)))
+	assert.False(t, stats.MayEqual(float64(4)))
+	assert.False(t, stats.MayEqual(float64(11)))
+	assert.True(t, stats.MayEqual("red"))
+	assert.False(t, stats.MayEqual("blue"))
+	assert.True(t, stats.MayEqual(true))
+	assert.False(t, stats.MayEqual(false))
+
+	assert.True(t, stats.MayInRange(Bound{Value: float64(10.5), Inclusive: true}, Bound{}))
+	assert.False(t, stats.MayInRange(Bound{Value: float64(10.5)}, Bound{}))
+	assert.False(t, stats.MayInRange(Bound{}, Bound{Value: float64(3)}))
+	assert.True(t, stats.MayInRange(Bound{Value: float64(4)}, Bound{Value: float64(5)}))
+	assert.False(t, stats.MayInRange(Bound{Value: "s"}, Bound{}))
+	assert.True(t, stats.MayHavePrefix("re"))
+	assert.False(t, stats.MayHavePrefix("b"))
+
+	data, err := stats.Marshal()
+	require.NoError(t, err)
+	loaded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, stats, loaded)
+	_, err = Unmarshal([]byte(`{}`))
+	assert.Error(t, err)
+
+	many := make([][]byte, 0, MaxValues+1)
+	for i := 0; i <= MaxValues; i++ {
+		many = append(many, []byte(fmt.Sprintf(`{"k": %d}`, i%10)), []byte(fmt.Sprintf(`{"k": "s%d"}`, i)))
+	}
+	stats = Build([]string{"k"}, many)
+	assert.True(t, stats.ValuesOverflow)
+	assert.Empty(t, stats.Values)
+	assert.True(t, stats.MayEqual(float64(5)))
+	assert.True(t, stats.MayEqual(float64(5.5)))
+}
+
+func TestMayMatch(t *testing.T) {
+	stats := Build([]string{"color"}, [][]byte{[]byte(`{"color": "red"}`), []byte(`{"size": 1}`)})
+	lookup := func(fieldID int64, path []string) *Stats {
+		if fieldID == 101 && len(path) == 1 && path[0] == "color" {
+			return stats
+		}
+		return nil
+	}
+	column := func(key string) *planpb.ColumnInfo {
+		return &planpb.ColumnInfo{FieldId: 101, DataType: schemapb.DataType_JSON, NestedPath: []string{key}}
+	}
+	str := func(v string) *planpb.GenericValue {
+		return &planpb.GenericValue{Val: &planpb.GenericValue_StringVal{StringVal: v}}
+	}
+	equal := func(key, v string) *planpb.Expr {
+		return &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+			ColumnInfo: column(key), Op: planpb.OpType_Equal, Value: str(v),
+		}}}
+	}
+	binary := func(op planpb.BinaryExpr_BinaryOp, left, right *planpb.Expr) *planpb.Expr {
+		return &planpb.Expr{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{Op: op, Left: left, Right: right}}}
+	}
+
+	assert.True(t, MayMatch(equal("color", "red"), lookup))
+	assert.False(t, MayMatch(equal("color", "blue"), lookup))
+	// not indexed
+	assert.True(t, MayMatch(equal("size", "blue"), lookup))
+	assert.False(t, MayMatch(binary(planpb.BinaryExpr_LogicalAnd, equal("size", "x"), equal("color", "blue")), lookup))
+	assert.True(t, MayMatch(binary(planpb.BinaryExpr_LogicalOr, equal("size", "x"), equal("color", "blue")), lookup))
+	assert.True(t, MayMatch(&planpb.Expr{Expr: &planpb.Expr_UnaryExpr{UnaryExpr: &planpb.UnaryExpr{
+		Op: planpb.UnaryExpr_Not, Child: equal("color", "blue"),
+	}}}, lookup))
+
+	assert.False(t, MayMatch(&planpb.Expr{Expr: &planpb.Expr_TermExpr{TermExpr: &planpb.TermExpr{
+		ColumnInfo: column("color"), Values: []*planpb.GenericValue{str("blue"), str("green")},
+	}}}, lookup))
+	assert.True(t, MayMatch(&planpb.Expr{Expr: &planpb.Expr_BinaryRangeExpr{BinaryRangeExpr: &planpb.BinaryRangeExpr{
+		ColumnInfo: column("color"), LowerValue: str("a"), UpperValue: str("z"),
+	}}}, lookup))
+	assert.True(t, MayMatch(&planpb.Expr{Expr: &planpb.Expr_ExistsExpr{ExistsExpr: &planpb.ExistsExpr{Info: column("color")}}}, lookup))
+	assert.True(t, MayMatch(nil, lookup))
+}
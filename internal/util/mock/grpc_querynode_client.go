@@ -130,6 +130,10 @@ func (m *GrpcQueryNodeClient) Delete(ctx context.Context, in *querypb.DeleteRequ
 	return &commonpb.Status{}, m.Err
 }
 
+func (m *GrpcQueryNodeClient) FetchIndexFile(ctx context.Context, in *querypb.FetchIndexFileRequest, opts ...grpc.CallOption) (*querypb.FetchIndexFileResponse, error) {
+	return &querypb.FetchIndexFileResponse{}, m.Err
+}
+
 func (m *GrpcQueryNodeClient) Close() error {
 	return m.Err
 }
@@ -0,0 +1,71 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessionutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+const failureDomainMetadataTimeout = 3 * time.Second
+
+// resolveFailureDomain returns the zone and rack advertised by this node,
+// the zone is requested from the cloud metadata if it's not configured.
+func resolveFailureDomain(ctx context.Context) (string, string) {
+	params := paramtable.Get()
+	zone := params.CommonCfg.FailureDomainZone.GetValue()
+	rack := params.CommonCfg.FailureDomainRack.GetValue()
+	if url := params.CommonCfg.FailureDomainMetadataURL.GetValue(); zone == "" && url != "" {
+		var err error
+		zone, err = requestZone(ctx, url)
+		if err != nil {
+			log.Warn("failed to request the zone from the cloud metadata", zap.String("url", url), zap.Error(err))
+		}
+	}
+	return zone, rack
+}
+
+func requestZone(ctx context.Context, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, failureDomainMetadataTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
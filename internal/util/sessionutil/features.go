@@ -0,0 +1,65 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessionutil
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/milvus-io/milvus/internal/util/featureflag"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// featureRoles are the roles registering sessions, the session root is listed by roles
+// since other keys, e.g. the server id, live under it too.
+var featureRoles = []string{
+	typeutil.RootCoordRole,
+	typeutil.DataCoordRole,
+	typeutil.QueryCoordRole,
+	typeutil.ProxyRole,
+	typeutil.DataNodeRole,
+	typeutil.QueryNodeRole,
+	typeutil.IndexNodeRole,
+}
+
+// NewFeatureLister returns the featureflag.ListFunc listing the features advertised by the live sessions.
+func NewFeatureLister(client *clientv3.Client, metaRoot string) featureflag.ListFunc {
+	return func(ctx context.Context) ([]featureflag.NodeFeatures, error) {
+		nodes := make([]featureflag.NodeFeatures, 0)
+		for _, role := range featureRoles {
+			resp, err := client.Get(ctx, path.Join(metaRoot, DefaultServiceRoot, role), clientv3.WithPrefix())
+			if err != nil {
+				return nil, err
+			}
+			for _, kv := range resp.Kvs {
+				session := &SessionRaw{}
+				if err := json.Unmarshal(kv.Value, session); err != nil {
+					return nil, err
+				}
+				nodes = append(nodes, featureflag.NodeFeatures{
+					ServerID: session.ServerID,
+					Role:     session.ServerName,
+					Features: session.Features,
+				})
+			}
+		}
+		return nodes, nil
+	}
+}
@@ -36,8 +36,10 @@ import (
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus/internal/http/healthz"
 	"github.com/milvus-io/milvus/internal/storage"
 	kvfactory "github.com/milvus-io/milvus/internal/util/dependency/kv"
+	"github.com/milvus-io/milvus/internal/util/featureflag"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -100,8 +102,11 @@ type SessionRaw struct {
 	IndexEngineVersion IndexEngineVersion `json:"IndexEngineVersion,omitempty"`
 	LeaseID            *clientv3.LeaseID  `json:"LeaseID,omitempty"`
 
-	HostName   string `json:"HostName,omitempty"`
-	EnableDisk bool   `json:"EnableDisk,omitempty"`
+	HostName   string   `json:"HostName,omitempty"`
+	EnableDisk bool     `json:"EnableDisk,omitempty"`
+	Features   []string `json:"Features,omitempty"`
+	Zone       string   `json:"Zone,omitempty"`
+	Rack       string   `json:"Rack,omitempty"`
 }
 
 func (s *SessionRaw) GetAddress() string {
@@ -225,6 +230,7 @@ func NewSessionWithEtcd(ctx context.Context, metaRoot string, client *clientv3.C
 	if hostNameErr != nil {
 		log.Error("get host name fail", zap.Error(hostNameErr))
 	}
+	zone, rack := resolveFailureDomain(ctx)
 
 	session := &Session{
 		ctx:      ctx,
@@ -233,6 +239,9 @@ func NewSessionWithEtcd(ctx context.Context, metaRoot string, client *clientv3.C
 
 		SessionRaw: SessionRaw{
 			HostName: hostName,
+			Features: featureflag.Supported(),
+			Zone:     zone,
+			Rack:     rack,
 		},
 
 		// options
@@ -277,8 +286,11 @@ func (s *Session) Init(serverName, address string, exclusive bool, triggerKill b
 	s.Address = address
 	s.Exclusive = exclusive
 	s.TriggerKill = triggerKill
+	// the server id is allocated in etcd, which is retried until etcd is reachable
+	done := healthz.Track(serverName, healthz.StageWaitingDependency, healthz.WaitingFor("etcd"), s.metaRoot)
 	s.checkIDExist()
 	serverID, err := s.getServerID()
+	done()
 	if err != nil {
 		panic(err)
 	}
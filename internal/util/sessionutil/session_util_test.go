@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path"
@@ -774,6 +776,35 @@ func TestSession_apply(t *testing.T) {
 	assert.Equal(t, int64(200), session.sessionRetryTimes)
 }
 
+func TestResolveFailureDomain(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	defer params.Reset(params.CommonCfg.FailureDomainZone.Key)
+	defer params.Reset(params.CommonCfg.FailureDomainRack.Key)
+	defer params.Reset(params.CommonCfg.FailureDomainMetadataURL.Key)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("us-east-1a\n"))
+	}))
+	defer server.Close()
+	params.Save(params.CommonCfg.FailureDomainMetadataURL.Key, server.URL)
+	params.Save(params.CommonCfg.FailureDomainRack.Key, "rack1")
+
+	zone, rack := resolveFailureDomain(context.Background())
+	assert.Equal(t, "us-east-1a", zone)
+	assert.Equal(t, "rack1", rack)
+
+	// the zone configured takes precedence over the cloud metadata
+	params.Save(params.CommonCfg.FailureDomainZone.Key, "zone1")
+	zone, _ = resolveFailureDomain(context.Background())
+	assert.Equal(t, "zone1", zone)
+
+	params.Reset(params.CommonCfg.FailureDomainZone.Key)
+	server.Close()
+	zone, _ = resolveFailureDomain(context.Background())
+	assert.Equal(t, "", zone)
+}
+
 func TestIntegrationMode(t *testing.T) {
 	ctx := context.Background()
 	paramtable.Init()
@@ -0,0 +1,144 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timepartition implements the policy of time-partitioned collections,
+// which have a partition per period named by the date the period starts in UTC, e.g. p_20240101.
+package timepartition
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// the periods of common.CollectionPartitionRolloverKey
+const (
+	RolloverDaily  = "daily"
+	RolloverWeekly = "weekly"
+)
+
+// the actions of common.CollectionPartitionExpiryKey
+const (
+	// ExpiryDrop drops the partitions past retention
+	ExpiryDrop = "drop"
+	// ExpiryArchive releases the partitions past retention from query nodes, their data is kept in storage
+	ExpiryArchive = "archive"
+)
+
+const (
+	partitionPrefix = "p_"
+	dateLayout      = "20060102"
+)
+
+// Policy is the rollover and retention policy of a time-partitioned collection.
+type Policy struct {
+	Rollover string
+	// Retention is how long the partitions are retained after their period ends, zero means forever
+	Retention time.Duration
+	Expiry    string
+}
+
+// Parse returns the policy in the collection properties, nil if the collection is not time-partitioned.
+func Parse(kvs []*commonpb.KeyValuePair) (*Policy, error) {
+	policy := &Policy{Expiry: ExpiryDrop}
+	for _, kv := range kvs {
+		switch kv.GetKey() {
+		case common.CollectionPartitionRolloverKey:
+			policy.Rollover = kv.GetValue()
+			if policy.Rollover != "" && policy.Rollover != RolloverDaily && policy.Rollover != RolloverWeekly {
+				return nil, merr.WrapErrParameterInvalidMsg("invalid collection property %s: %s, should be %s or %s",
+					common.CollectionPartitionRolloverKey, policy.Rollover, RolloverDaily, RolloverWeekly)
+			}
+		case common.CollectionPartitionRetentionKey:
+			if kv.GetValue() == "" {
+				continue
+			}
+			seconds, err := strconv.ParseInt(kv.GetValue(), 10, 64)
+			if err != nil || seconds < 0 {
+				return nil, merr.WrapErrParameterInvalidMsg("invalid collection property %s: %s, should be non-negative seconds",
+					common.CollectionPartitionRetentionKey, kv.GetValue())
+			}
+			policy.Retention = time.Duration(seconds) * time.Second
+		case common.CollectionPartitionExpiryKey:
+			if kv.GetValue() == "" {
+				continue
+			}
+			policy.Expiry = kv.GetValue()
+			if policy.Expiry != ExpiryDrop && policy.Expiry != ExpiryArchive {
+				return nil, merr.WrapErrParameterInvalidMsg("invalid collection property %s: %s, should be %s or %s",
+					common.CollectionPartitionExpiryKey, policy.Expiry, ExpiryDrop, ExpiryArchive)
+			}
+		}
+	}
+	if policy.Rollover == "" {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+// Period returns the length of the periods.
+func (p *Policy) Period() time.Duration {
+	if p.Rollover == RolloverWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// PeriodStart returns the start of the period the time falls in, the weekly periods start on Monday.
+func (p *Policy) PeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if p.Rollover == RolloverWeekly {
+		// Sunday is 0
+		days := (int(start.Weekday()) + 6) % 7
+		start = start.AddDate(0, 0, -days)
+	}
+	return start
+}
+
+// PartitionName returns the name of the partition of the period the time falls in.
+func (p *Policy) PartitionName(t time.Time) string {
+	return partitionPrefix + p.PeriodStart(t).Format(dateLayout)
+}
+
+// ParsePartitionName returns the start of the period of the partition,
+// false if the partition is not managed by the policy, e.g. the default partition.
+func (p *Policy) ParsePartitionName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, partitionPrefix) {
+		return time.Time{}, false
+	}
+	start, err := time.ParseInLocation(dateLayout, strings.TrimPrefix(name, partitionPrefix), time.UTC)
+	if err != nil || !start.Equal(p.PeriodStart(start)) {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+// Expired returns whether the partition is managed by the policy and past retention.
+func (p *Policy) Expired(name string, now time.Time) bool {
+	if p.Retention <= 0 {
+		return false
+	}
+	start, ok := p.ParsePartitionName(name)
+	if !ok {
+		return false
+	}
+	return !now.Before(start.Add(p.Period()).Add(p.Retention))
+}
@@ -0,0 +1,74 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timepartition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/pkg/common"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+func TestParse(t *testing.T) {
+	policy, err := Parse(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = Parse([]*commonpb.KeyValuePair{
+		{Key: common.CollectionPartitionRolloverKey, Value: RolloverWeekly},
+		{Key: common.CollectionPartitionRetentionKey, Value: "86400"},
+		{Key: common.CollectionPartitionExpiryKey, Value: ExpiryArchive},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &Policy{Rollover: RolloverWeekly, Retention: 24 * time.Hour, Expiry: ExpiryArchive}, policy)
+
+	for _, kv := range []*commonpb.KeyValuePair{
+		{Key: common.CollectionPartitionRolloverKey, Value: "monthly"},
+		{Key: common.CollectionPartitionRetentionKey, Value: "-1"},
+		{Key: common.CollectionPartitionExpiryKey, Value: "delete"},
+	} {
+		_, err = Parse([]*commonpb.KeyValuePair{{Key: common.CollectionPartitionRolloverKey, Value: RolloverDaily}, kv})
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid, kv.GetKey())
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 1, 3, 15, 0, 0, 0, time.UTC)
+
+	daily := &Policy{Rollover: RolloverDaily, Retention: 24 * time.Hour, Expiry: ExpiryDrop}
+	assert.Equal(t, "p_20240103", daily.PartitionName(now))
+	assert.Equal(t, "p_20240104", daily.PartitionName(now.Add(daily.Period())))
+	assert.False(t, daily.Expired("p_20240102", now))
+	assert.True(t, daily.Expired("p_20240101", now))
+	assert.False(t, daily.Expired("_default", now))
+
+	weekly := &Policy{Rollover: RolloverWeekly, Expiry: ExpiryDrop}
+	assert.Equal(t, "p_20240101", weekly.PartitionName(now))
+	start, ok := weekly.ParsePartitionName("p_20240101")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), start)
+	// not the start of a week
+	_, ok = weekly.ParsePartitionName("p_20240103")
+	assert.False(t, ok)
+	// retained forever
+	assert.False(t, weekly.Expired("p_20200106", now))
+}
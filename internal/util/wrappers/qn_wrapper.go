@@ -148,6 +148,10 @@ func (qn *qnServerWrapper) Delete(ctx context.Context, in *querypb.DeleteRequest
 	return qn.QueryNode.Delete(ctx, in)
 }
 
+func (qn *qnServerWrapper) FetchIndexFile(ctx context.Context, in *querypb.FetchIndexFileRequest, opts ...grpc.CallOption) (*querypb.FetchIndexFileResponse, error) {
+	return qn.QueryNode.FetchIndexFile(ctx, in)
+}
+
 func WrapQueryNodeServerAsClient(qn types.QueryNode) types.QueryNodeClient {
 	return &qnServerWrapper{
 		QueryNode: qn,
@@ -18,6 +18,9 @@ package common
 
 import (
 	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
@@ -26,6 +29,7 @@ import (
 // system field id:
 // 0: unique row id
 // 1: timestamp
+// 2: segment id, pseudo field only referenced by the filter expressions
 // 100: first user field id
 // 101: second user field id
 // 102: ...
@@ -40,12 +44,19 @@ const (
 	// TimeStampField is the ID of the Timestamp field reserved by the system
 	TimeStampField = 1
 
+	// SegmentIDField is the ID of the pseudo field of the segment owning the row,
+	// which is only referenced by the filter expressions for debugging
+	SegmentIDField = 2
+
 	// RowIDFieldName defines the name of the RowID field
 	RowIDFieldName = "RowID"
 
 	// TimeStampFieldName defines the name of the Timestamp field
 	TimeStampFieldName = "Timestamp"
 
+	// SegmentIDFieldName defines the name of the SegmentID pseudo field
+	SegmentIDFieldName = "SegmentID"
+
 	// MetaFieldName is the field name of dynamic schema
 	MetaFieldName = "$meta"
 
@@ -100,6 +111,8 @@ const (
 	DimKey         = "dim"
 	MaxLengthKey   = "max_length"
 	MaxCapacityKey = "max_capacity"
+	// JSONPathKey is the json path of json key stats, encoded as json array of keys
+	JSONPathKey = "json_path"
 )
 
 //  Collection properties key
@@ -107,6 +120,12 @@ const (
 const (
 	CollectionTTLConfigKey      = "collection.ttl.seconds"
 	CollectionAutoCompactionKey = "collection.autocompaction.enabled"
+	// CollectionExclusiveTopicKey makes the collection own its physical topics instead of sharing the dml channels
+	CollectionExclusiveTopicKey = "collection.exclusiveTopic.enabled"
+	// CollectionFreezeKey freezes the collection for maintenance, "write" rejects the writes and "all" rejects the reads as well
+	CollectionFreezeKey = "collection.freeze.mode"
+	// CollectionFreezeUntilKey is the unix seconds when the freeze expires, the collection is frozen until unfrozen if it's absent
+	CollectionFreezeUntilKey = "collection.freeze.until"
 
 	// rate limit
 	CollectionInsertRateMaxKey   = "collection.insertRate.max.mb"
@@ -122,13 +141,61 @@ const (
 	CollectionSearchRateMaxKey   = "collection.searchRate.max.vps"
 	CollectionSearchRateMinKey   = "collection.searchRate.min.vps"
 	CollectionDiskQuotaKey       = "collection.diskProtection.diskQuota.mb"
+
+	// CollectionInsertBurstKey is the bucket size of the token bucket limiting the insert rate
+	CollectionInsertBurstKey = "collection.insertRate.burst.mb"
+	// CollectionInsertRateOverrideKey overrides the max insert rate temporarily, e.g. during backfills
+	CollectionInsertRateOverrideKey = "collection.insertRate.override.mb"
+	// CollectionInsertBurstOverrideKey overrides the insert burst along with the override rate, optional
+	CollectionInsertBurstOverrideKey = "collection.insertRate.override.burst.mb"
+	// CollectionInsertRateOverrideUntilKey is the unix seconds when the insert rate override expires
+	CollectionInsertRateOverrideUntilKey = "collection.insertRate.override.until"
+
+	// CollectionPartitionRolloverKey makes the collection time-partitioned, rootcoord creates a partition per period,
+	// "daily" or "weekly", and the inserts without partition are routed to the partition of the current period
+	CollectionPartitionRolloverKey = "collection.partition.rollover"
+	// CollectionPartitionRetentionKey is the seconds the partitions of time-partitioned collection are retained after their period ends,
+	// they're retained forever if it's absent
+	CollectionPartitionRetentionKey = "collection.partition.retention.seconds"
+	// CollectionPartitionExpiryKey is the action on the partitions past retention, "drop" them, or "archive" them by releasing
+	// them from query nodes while keeping their data
+	CollectionPartitionExpiryKey = "collection.partition.expiry"
+
+	// CollectionDedupFieldsKey enables the dedup at ingest, the names of the fields separated by comma, e.g. "text,vector",
+	// the inserted rows with the same values in the fields as a row inserted within the window are duplicates
+	CollectionDedupFieldsKey = "collection.dedup.fields"
+	// CollectionDedupModeKey is the action on the duplicates, "drop" them, or "upsert" them by deleting the rows duplicated
+	CollectionDedupModeKey = "collection.dedup.mode"
+	// CollectionDedupWindowKey is the seconds the inserted rows are deduplicated against, proxy.dedup.window if it's absent
+	CollectionDedupWindowKey = "collection.dedup.window.seconds"
+)
+
+//  Database properties key
+
+const (
+	// DatabaseDmlChannelsKey reserves the physical dml channels, separated by comma, for the collections of database
+	DatabaseDmlChannelsKey = "database.dmlChannels"
+	// DatabaseDataNodesKey reserves the datanodes, separated by comma, to consume the dml channels of database
+	DatabaseDataNodesKey = "database.dataNodes"
 )
 
 // common properties
 const (
 	MmapEnabledKey = "mmap.enabled"
+	// BinlogCompressionKey selects the binlog codec, as a field type param or as the collection-wide default property
+	BinlogCompressionKey = "binlog.compression"
+	// StorageProfileKey routes the data of collection to the bucket profile, see minio.bucketProfiles
+	StorageProfileKey = "storage.profile"
+	// GPUResidencyKey sets the residency policy of the GPU indexes, pin or lru, as a field type param or as the collection-wide property
+	GPUResidencyKey = "gpu.residency"
+	// VectorQuantizationKey quantizes the raw vectors loaded by query nodes in memory, e.g. SQ8,
+	// as a field type param or as the collection-wide property, the quantized fields support only the FLAT index
+	VectorQuantizationKey = "vector.quantization"
 )
 
+// VectorQuantizationSQ8 quantizes each dimension of the float vectors to 8 bits.
+const VectorQuantizationSQ8 = "SQ8"
+
 const (
 	PropertiesKey string = "properties"
 	TraceIDKey    string = "uber-trace-id"
@@ -147,6 +214,95 @@ func IsMmapEnabled(kvs ...*commonpb.KeyValuePair) bool {
 	return false
 }
 
+// IsExclusiveTopicEnabled returns whether the collection owns its physical topics.
+func IsExclusiveTopicEnabled(kvs ...*commonpb.KeyValuePair) bool {
+	for _, kv := range kvs {
+		if kv.Key == CollectionExclusiveTopicKey && kv.Value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDatabaseDmlChannels returns the physical channels reserved by the database, nil if not isolated.
+func GetDatabaseDmlChannels(kvs ...*commonpb.KeyValuePair) []string {
+	for _, kv := range kvs {
+		if kv.Key == DatabaseDmlChannelsKey {
+			return splitList(kv.Value)
+		}
+	}
+	return nil
+}
+
+// GetDatabaseDataNodes returns the ids of datanodes reserved by the database, nil if not isolated.
+func GetDatabaseDataNodes(kvs ...*commonpb.KeyValuePair) ([]int64, error) {
+	for _, kv := range kvs {
+		if kv.Key != DatabaseDataNodesKey {
+			continue
+		}
+		var nodes []int64
+		for _, s := range splitList(kv.Value) {
+			nodeID, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %s", DatabaseDataNodesKey, kv.Value)
+			}
+			nodes = append(nodes, nodeID)
+		}
+		return nodes, nil
+	}
+	return nil, nil
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// GetBinlogCompression returns the binlog codec name specified in kvs, if any.
+func GetBinlogCompression(kvs ...*commonpb.KeyValuePair) (string, bool) {
+	for _, kv := range kvs {
+		if kv.Key == BinlogCompressionKey {
+			return kv.Value, true
+		}
+	}
+	return "", false
+}
+
+// GetStorageProfile returns the bucket profile name specified in kvs, if any.
+func GetStorageProfile(kvs ...*commonpb.KeyValuePair) (string, bool) {
+	for _, kv := range kvs {
+		if kv.Key == StorageProfileKey {
+			return kv.Value, true
+		}
+	}
+	return "", false
+}
+
+// GetGPUResidency returns the residency policy of GPU indexes specified in kvs, if any.
+func GetGPUResidency(kvs ...*commonpb.KeyValuePair) (string, bool) {
+	for _, kv := range kvs {
+		if kv.Key == GPUResidencyKey {
+			return kv.Value, true
+		}
+	}
+	return "", false
+}
+
+// GetVectorQuantization returns the quantization of vectors specified in kvs, if any.
+func GetVectorQuantization(kvs ...*commonpb.KeyValuePair) (string, bool) {
+	for _, kv := range kvs {
+		if kv.Key == VectorQuantizationKey {
+			return kv.Value, true
+		}
+	}
+	return "", false
+}
+
 func IsFieldMmapEnabled(schema *schemapb.CollectionSchema, fieldID int64) bool {
 	for _, field := range schema.GetFields() {
 		if field.GetFieldID() == fieldID {
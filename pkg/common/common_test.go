@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 )
 
 func TestIsSystemField(t *testing.T) {
@@ -38,3 +40,22 @@ func TestIsSystemField(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseIsolation(t *testing.T) {
+	kvs := []*commonpb.KeyValuePair{
+		{Key: DatabaseDmlChannelsKey, Value: "dml_0, dml_1,"},
+		{Key: DatabaseDataNodesKey, Value: "1,2"},
+	}
+	assert.Equal(t, []string{"dml_0", "dml_1"}, GetDatabaseDmlChannels(kvs...))
+	nodes, err := GetDatabaseDataNodes(kvs...)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, nodes)
+
+	assert.Nil(t, GetDatabaseDmlChannels())
+	nodes, err = GetDatabaseDataNodes()
+	assert.NoError(t, err)
+	assert.Nil(t, nodes)
+
+	_, err = GetDatabaseDataNodes(&commonpb.KeyValuePair{Key: DatabaseDataNodesKey, Value: "a"})
+	assert.Error(t, err)
+}
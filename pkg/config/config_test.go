@@ -138,3 +138,41 @@ func TestConfigFromRemote(t *testing.T) {
 		}, 300*time.Millisecond, 10*time.Millisecond)
 	})
 }
+
+func TestScopedConfigFromRemote(t *testing.T) {
+	cfg, _ := embed.ConfigFromFile("../../configs/advanced/etcd.yaml")
+	cfg.Dir = "/tmp/milvus/test_scope"
+	e, err := embed.StartEtcd(cfg)
+	assert.NoError(t, err)
+	defer e.Close()
+	defer os.RemoveAll(cfg.Dir)
+
+	client := v3client.New(e.Server)
+	ctx := context.Background()
+	client.KV.Put(ctx, "test/config/scoped/key", "global")
+	client.KV.Put(ctx, "test/config/@role/querynode/scoped/key", "role")
+	client.KV.Put(ctx, "test/config/@node/7/scoped/key", "node")
+
+	// refresh rarely, so the scoped configs must be applied by UpdateScope
+	mgr, _ := Init(WithEtcdSource(&EtcdInfo{
+		Endpoints:       []string{cfg.ACUrls[0].Host},
+		KeyPrefix:       "test",
+		RefreshInterval: time.Hour,
+	}))
+	defer mgr.Close()
+
+	v, err := mgr.GetConfig("scoped.key")
+	assert.NoError(t, err)
+	assert.Equal(t, "global", v)
+
+	assert.NoError(t, mgr.UpdateScope("querynode", 0))
+	v, err = mgr.GetConfig("scoped.key")
+	assert.NoError(t, err)
+	assert.Equal(t, "role", v)
+
+	assert.NoError(t, mgr.UpdateScope("querynode", 7))
+	v, err = mgr.GetConfig("scoped.key")
+	assert.NoError(t, err)
+	assert.Equal(t, "node", v)
+	assert.Equal(t, "node:7", mgr.GetEffectiveConfigs(WithPrefix("scoped"))["scoped.key"].Scope)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -33,6 +34,15 @@ import (
 
 const (
 	ReadConfigTimeout = 3 * time.Second
+
+	// ScopeGlobal is the scope of configs applying to all nodes
+	ScopeGlobal = "global"
+
+	// the configs scoped to role are stored as {rootPath}/config/@role/{role}/{key},
+	// the ones scoped to node are stored as {rootPath}/config/@node/{nodeID}/{key},
+	// the node scope takes precedence over the role scope, which takes precedence over the global one.
+	scopeRoleSegment = "@role"
+	scopeNodeSegment = "@node"
 )
 
 type EtcdSource struct {
@@ -40,7 +50,10 @@ type EtcdSource struct {
 	etcdCli       *clientv3.Client
 	ctx           context.Context
 	currentConfig map[string]string
+	currentScopes map[string]string
 	keyPrefix     string
+	role          string
+	nodeID        int64
 
 	configRefresher *refresher
 }
@@ -62,6 +75,7 @@ func NewEtcdSource(etcdInfo *EtcdInfo) (*EtcdSource, error) {
 		etcdCli:       etcdCli,
 		ctx:           context.Background(),
 		currentConfig: make(map[string]string),
+		currentScopes: make(map[string]string),
 		keyPrefix:     etcdInfo.KeyPrefix,
 	}
 	es.configRefresher = newRefresher(etcdInfo.RefreshInterval, es.refreshConfigurations)
@@ -79,6 +93,13 @@ func (es *EtcdSource) GetConfigurationByKey(key string) (string, error) {
 	return v, nil
 }
 
+// GetScopeByKey implements ScopedSource
+func (es *EtcdSource) GetScopeByKey(key string) string {
+	es.RLock()
+	defer es.RUnlock()
+	return es.currentScopes[key]
+}
+
 // GetConfigurations implements ConfigSource
 func (es *EtcdSource) GetConfigurations() (map[string]string, error) {
 	configMap := make(map[string]string)
@@ -116,11 +137,16 @@ func (es *EtcdSource) SetEventHandler(eh EventHandler) {
 }
 
 func (es *EtcdSource) UpdateOptions(opts Options) {
+	es.Lock()
+	defer es.Unlock()
+	if opts.ScopeInfo != nil {
+		// the scoped configs take effect in the next refreshing, or by Refresh
+		es.role = strings.ToLower(opts.ScopeInfo.Role)
+		es.nodeID = opts.ScopeInfo.NodeID
+	}
 	if opts.EtcdInfo == nil {
 		return
 	}
-	es.Lock()
-	defer es.Unlock()
 	es.keyPrefix = opts.EtcdInfo.KeyPrefix
 	if es.configRefresher.refreshInterval != opts.EtcdInfo.RefreshInterval {
 		es.configRefresher.stop()
@@ -131,9 +157,15 @@ func (es *EtcdSource) UpdateOptions(opts Options) {
 	}
 }
 
+// Refresh implements ScopedSource
+func (es *EtcdSource) Refresh() error {
+	return es.refreshConfigurations()
+}
+
 func (es *EtcdSource) refreshConfigurations() error {
 	es.RLock()
 	prefix := path.Join(es.keyPrefix, "config")
+	role, nodeID := es.role, es.nodeID
 	es.RUnlock()
 
 	ctx, cancel := context.WithTimeout(es.ctx, ReadConfigTimeout)
@@ -144,11 +176,24 @@ func (es *EtcdSource) refreshConfigurations() error {
 		return err
 	}
 	newConfig := make(map[string]string, len(response.Kvs))
+	newScopes := make(map[string]string, len(response.Kvs))
+	priorities := make(map[string]int, len(response.Kvs))
 	for _, kv := range response.Kvs {
 		key := string(kv.Key)
 		key = strings.TrimPrefix(key, prefix+"/")
+		key, scope, priority, ok := parseScopedKey(key, role, nodeID)
+		if !ok {
+			// scoped to other nodes
+			continue
+		}
+		if current, ok := priorities[formatKey(key)]; ok && current > priority {
+			continue
+		}
+		priorities[formatKey(key)] = priority
 		newConfig[key] = string(kv.Value)
 		newConfig[formatKey(key)] = string(kv.Value)
+		newScopes[key] = scope
+		newScopes[formatKey(key)] = scope
 		log.Debug("got config from etcd", zap.String("key", string(kv.Key)), zap.String("value", string(kv.Value)))
 	}
 	es.Lock()
@@ -158,5 +203,26 @@ func (es *EtcdSource) refreshConfigurations() error {
 		return err
 	}
 	es.currentConfig = newConfig
+	es.currentScopes = newScopes
 	return nil
 }
+
+// parseScopedKey parses the config key relative to the config path,
+// returns the key without scope, the scope and its priority,
+// and whether the config applies to the node with the role and node id.
+func parseScopedKey(key string, role string, nodeID int64) (string, string, int, bool) {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		return key, ScopeGlobal, 0, true
+	}
+	switch parts[0] {
+	case scopeRoleSegment:
+		scope := fmt.Sprintf("role:%s", strings.ToLower(parts[1]))
+		return parts[2], scope, 1, role != "" && strings.ToLower(parts[1]) == role
+	case scopeNodeSegment:
+		scope := fmt.Sprintf("node:%s", parts[1])
+		return parts[2], scope, 2, nodeID > 0 && parts[1] == strconv.FormatInt(nodeID, 10)
+	default:
+		return key, ScopeGlobal, 0, true
+	}
+}
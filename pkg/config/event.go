@@ -27,7 +27,9 @@ type Event struct {
 	EventType   string
 	Key         string
 	Value       string
-	HasUpdated  bool
+	// PrevValue is the value of the key in the event source before update
+	PrevValue  string
+	HasUpdated bool
 }
 
 func newEvent(eventSource, eventType string, key string, value string) *Event {
@@ -49,7 +51,9 @@ func PopulateEvents(source string, currentConfig, updatedConfig map[string]strin
 		if !ok { // if new configuration introduced
 			events = append(events, newEvent(source, CreateType, key, value))
 		} else if currentValue != value {
-			events = append(events, newEvent(source, UpdateType, key, value))
+			e := newEvent(source, UpdateType, key, value)
+			e.PrevValue = currentValue
+			events = append(events, e)
 		}
 	}
 
@@ -29,6 +29,9 @@ import (
 
 const (
 	TombValue = "TOMB_VAULE"
+
+	// RuntimeSource is the source of the events of the configs overridden at runtime
+	RuntimeSource = "RuntimeSource"
 )
 
 type Filter func(key string) (string, bool)
@@ -86,6 +89,10 @@ type Manager struct {
 	keySourceMap  map[string]string // store the key to config source, example: key is A.B.C and source is file which means the A.B.C's value is from file
 	overlays      map[string]string // store the highest priority configs which modified at runtime
 	forbiddenKeys typeutil.Set[string]
+
+	validators map[string]*keyValidator
+	pinned     map[string]string     // store the retained values of keys whose latest update is rejected
+	rejections map[string]*Rejection // store the latest rejected update of keys
 }
 
 func NewManager() *Manager {
@@ -95,23 +102,42 @@ func NewManager() *Manager {
 		keySourceMap:  make(map[string]string),
 		overlays:      make(map[string]string),
 		forbiddenKeys: typeutil.NewSet[string](),
+		validators:    make(map[string]*keyValidator),
+		pinned:        make(map[string]string),
+		rejections:    make(map[string]*Rejection),
 	}
 }
 
 func (m *Manager) GetConfig(key string) (string, error) {
 	m.RLock()
 	defer m.RUnlock()
-	realKey := formatKey(key)
+	return m.getConfig(formatKey(key))
+}
+
+func (m *Manager) getConfig(realKey string) (string, error) {
 	v, ok := m.overlays[realKey]
 	if ok {
 		if v == TombValue {
-			return "", fmt.Errorf("key not found %s", key)
+			return "", fmt.Errorf("key not found %s", realKey)
 		}
 		return v, nil
 	}
+	v, ok = m.pinned[realKey]
+	if ok {
+		if v == TombValue {
+			return "", fmt.Errorf("key not found %s", realKey)
+		}
+		return v, nil
+	}
+	return m.getDeclaredConfig(realKey)
+}
+
+// getDeclaredConfig returns the value of key from the config sources,
+// regardless of the runtime overlays and validation.
+func (m *Manager) getDeclaredConfig(realKey string) (string, error) {
 	sourceName, ok := m.keySourceMap[realKey]
 	if !ok {
-		return "", fmt.Errorf("key not found: %s", key)
+		return "", fmt.Errorf("key not found: %s", realKey)
 	}
 	return m.getConfigValueBySource(realKey, sourceName)
 }
@@ -221,6 +247,64 @@ func (m *Manager) ResetConfig(key string) {
 	m.Lock()
 	defer m.Unlock()
 	delete(m.overlays, formatKey(key))
+	// the map config set by SetMapConfig
+	delete(m.overlays, strings.ToLower(key))
+}
+
+// SetConfigAndNotify overrides the key at runtime like SetConfig,
+// but rejects the forbidden keys and the values failing the validator of the key,
+// and notifies the handlers watching the key so that the value takes effect immediately.
+func (m *Manager) SetConfigAndNotify(key, value string) error {
+	realKey := formatKey(key)
+	m.Lock()
+	if m.forbiddenKeys.Contain(realKey) {
+		m.Unlock()
+		return errors.Newf("config %s is forbidden to update", key)
+	}
+	if validator, ok := m.validators[realKey]; ok {
+		if err := validator.validate(value); err != nil {
+			m.Unlock()
+			return err
+		}
+	}
+	m.overlays[realKey] = value
+	m.Unlock()
+
+	// the handlers may read the configs, so dispatch without the lock
+	m.Dispatcher.Dispatch(&Event{
+		EventSource: RuntimeSource,
+		EventType:   UpdateType,
+		Key:         realKey,
+		Value:       value,
+		HasUpdated:  true,
+	})
+	return nil
+}
+
+// ResetConfigAndNotify removes the runtime override of the key,
+// and notifies the handlers watching the key of the value from sources.
+func (m *Manager) ResetConfigAndNotify(key string) {
+	realKey := formatKey(key)
+	m.Lock()
+	delete(m.overlays, realKey)
+	value, _ := m.getConfig(realKey)
+	m.Unlock()
+
+	m.Dispatcher.Dispatch(&Event{
+		EventSource: RuntimeSource,
+		EventType:   UpdateType,
+		Key:         realKey,
+		Value:       value,
+		HasUpdated:  true,
+	})
+}
+
+// IsOverridden returns whether the key is overridden at runtime.
+func (m *Manager) IsOverridden(key string) bool {
+	m.RLock()
+	defer m.RUnlock()
+	_, ok := m.overlays[formatKey(key)]
+	return ok
 }
 
 // Ignore any of update events, which means the config cannot auto refresh anymore
@@ -345,11 +429,18 @@ func (m *Manager) OnEvent(event *Event) {
 		log.Info("ignore event for forbidden key", zap.String("key", event.Key))
 		return
 	}
+	invalidErr := m.validateEvent(event)
 	err := m.updateEvent(event)
 	if err != nil {
 		log.Warn("failed in updating event with error", zap.Error(err), zap.Any("event", event))
 		return
 	}
+	if invalidErr != nil {
+		// the source is updated, but the previous value is retained and handlers are not notified
+		log.Warn("reject invalid config update, retain the previous value", zap.Error(invalidErr), zap.Any("event", event))
+		return
+	}
+	m.acceptEvent(event)
 
 	m.Dispatcher.Dispatch(event)
 }
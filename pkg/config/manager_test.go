@@ -98,3 +98,42 @@ func (e ErrSource) SetEventHandler(eh EventHandler) {
 
 func (e ErrSource) UpdateOptions(opt Options) {
 }
+
+func TestSetConfigAndNotify(t *testing.T) {
+	mgr, _ := Init()
+	var events []*Event
+	mgr.Dispatcher.Register("a.b", NewHandler("test", func(e *Event) {
+		// the handlers are allowed to read the configs
+		value, _ := mgr.GetConfig("a.b")
+		assert.Equal(t, e.Value, value)
+		events = append(events, e)
+	}))
+	mgr.RegisterValidator("a.b", func(value string) error {
+		if value == "invalid" {
+			return errors.New("invalid value")
+		}
+		return nil
+	})
+
+	assert.NoError(t, mgr.SetConfigAndNotify("a.b", "1"))
+	assert.True(t, mgr.IsOverridden("a.b"))
+	res, err := mgr.GetConfig("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", res)
+
+	assert.Error(t, mgr.SetConfigAndNotify("a.b", "invalid"))
+	res, _ = mgr.GetConfig("a.b")
+	assert.Equal(t, "1", res)
+
+	mgr.ForbidUpdate("c.d")
+	assert.Error(t, mgr.SetConfigAndNotify("c.d", "1"))
+
+	mgr.ResetConfigAndNotify("a.b")
+	assert.False(t, mgr.IsOverridden("a.b"))
+	_, err = mgr.GetConfig("a.b")
+	assert.Error(t, err)
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, RuntimeSource, events[0].EventSource)
+	assert.True(t, events[0].HasUpdated)
+}
@@ -0,0 +1,98 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// OverlaySourceName is the source name of the configs modified at runtime.
+const OverlaySourceName = "Overlay"
+
+// UpdateScope sets the role and node id of the sources supporting scoped configs,
+// and refreshes them synchronously, so the configs scoped to the node take effect before return.
+func (m *Manager) UpdateScope(role string, nodeID int64) error {
+	m.UpdateSourceOptions(WithScope(role, nodeID))
+
+	// refresh without lock, the events fired are handled by the manager
+	m.RLock()
+	sources := make([]ScopedSource, 0)
+	for _, source := range m.sources {
+		if scoped, ok := source.(ScopedSource); ok {
+			sources = append(sources, scoped)
+		}
+	}
+	m.RUnlock()
+	for _, source := range sources {
+		if err := source.Refresh(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EffectiveValue is a config value in effect, with the source and scope supplying it.
+type EffectiveValue struct {
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Scope  string `json:"scope,omitempty"`
+	// Retained is true if the latest update of source is rejected by validator
+	Retained bool `json:"retained,omitempty"`
+}
+
+// GetEffectiveConfigs returns the configs in effect matching the filters,
+// and where they are supplied.
+func (m *Manager) GetEffectiveConfigs(filters ...Filter) map[string]*EffectiveValue {
+	m.RLock()
+	defer m.RUnlock()
+
+	result := make(map[string]*EffectiveValue)
+	for key, sourceName := range m.keySourceMap {
+		value, err := m.getConfig(formatKey(key))
+		if err != nil {
+			continue
+		}
+		newKey, ok := filterate(key, filters...)
+		if !ok {
+			continue
+		}
+		effective := &EffectiveValue{
+			Value:  value,
+			Source: sourceName,
+		}
+		if _, ok := m.overlays[formatKey(key)]; ok {
+			effective.Source = OverlaySourceName
+			result[newKey] = effective
+			continue
+		}
+		if source, ok := m.sources[sourceName].(ScopedSource); ok {
+			effective.Scope = source.GetScopeByKey(key)
+		}
+		_, effective.Retained = m.pinned[formatKey(key)]
+		result[newKey] = effective
+	}
+	for key, value := range m.overlays {
+		if value == TombValue {
+			continue
+		}
+		newKey, ok := filterate(key, filters...)
+		if !ok {
+			continue
+		}
+		result[newKey] = &EffectiveValue{
+			Value:  value,
+			Source: OverlaySourceName,
+		}
+	}
+	return result
+}
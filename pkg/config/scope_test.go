@@ -0,0 +1,120 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScopedKey(t *testing.T) {
+	key, scope, priority, ok := parseScopedKey("queryNode.scheduler.maxReadConcurrentRatio", "querynode", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "queryNode.scheduler.maxReadConcurrentRatio", key)
+	assert.Equal(t, ScopeGlobal, scope)
+	assert.Equal(t, 0, priority)
+
+	key, scope, priority, ok = parseScopedKey("@role/queryNode/queryNode.scheduler.maxReadConcurrentRatio", "querynode", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "queryNode.scheduler.maxReadConcurrentRatio", key)
+	assert.Equal(t, "role:querynode", scope)
+	assert.Equal(t, 1, priority)
+
+	_, _, _, ok = parseScopedKey("@role/datanode/queryNode.scheduler.maxReadConcurrentRatio", "querynode", 1)
+	assert.False(t, ok)
+
+	key, scope, priority, ok = parseScopedKey("@node/1/queryNode.scheduler.maxReadConcurrentRatio", "querynode", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "queryNode.scheduler.maxReadConcurrentRatio", key)
+	assert.Equal(t, "node:1", scope)
+	assert.Equal(t, 2, priority)
+
+	_, _, _, ok = parseScopedKey("@node/2/queryNode.scheduler.maxReadConcurrentRatio", "querynode", 1)
+	assert.False(t, ok)
+	// the node scope is not applied before the node id is assigned
+	_, _, _, ok = parseScopedKey("@node/0/queryNode.scheduler.maxReadConcurrentRatio", "querynode", 0)
+	assert.False(t, ok)
+}
+
+type mockScopedSource struct {
+	configs   map[string]string
+	scopes    map[string]string
+	refreshed int
+}
+
+func (s *mockScopedSource) GetConfigurations() (map[string]string, error) {
+	return s.configs, nil
+}
+
+func (s *mockScopedSource) GetConfigurationByKey(key string) (string, error) {
+	v, ok := s.configs[key]
+	if !ok {
+		return "", fmt.Errorf("key not found: %s", key)
+	}
+	return v, nil
+}
+
+func (s *mockScopedSource) GetScopeByKey(key string) string {
+	return s.scopes[key]
+}
+
+func (s *mockScopedSource) Refresh() error {
+	s.refreshed++
+	return nil
+}
+
+func (s *mockScopedSource) GetPriority() int {
+	return HighPriority
+}
+
+func (s *mockScopedSource) GetSourceName() string {
+	return "MockScopedSource"
+}
+
+func (s *mockScopedSource) SetEventHandler(eh EventHandler) {}
+
+func (s *mockScopedSource) UpdateOptions(opt Options) {}
+
+func (s *mockScopedSource) Close() {}
+
+func TestGetEffectiveConfigs(t *testing.T) {
+	mgr, _ := Init()
+	assert.NoError(t, mgr.AddSource(&mockScopedSource{
+		configs: map[string]string{"a.b": "1", "a.c": "2", "d.e": "3"},
+		scopes:  map[string]string{"a.b": "node:1", "a.c": ScopeGlobal, "d.e": ScopeGlobal},
+	}))
+	mgr.SetConfig("a.c", "4")
+
+	configs := mgr.GetEffectiveConfigs(WithPrefix("a."))
+	assert.Equal(t, 2, len(configs))
+	assert.Equal(t, &EffectiveValue{Value: "1", Source: "MockScopedSource", Scope: "node:1"}, configs["a.b"])
+	assert.Equal(t, &EffectiveValue{Value: "4", Source: OverlaySourceName}, configs["a.c"])
+
+	configs = mgr.GetEffectiveConfigs()
+	assert.Equal(t, 4, len(configs))
+	assert.Equal(t, "3", configs["d.e"].Value)
+}
+
+func TestUpdateScope(t *testing.T) {
+	mgr, _ := Init()
+	source := &mockScopedSource{}
+	assert.NoError(t, mgr.AddSource(source))
+	assert.NoError(t, mgr.UpdateScope("querynode", 1))
+	assert.Equal(t, 1, source.refreshed)
+}
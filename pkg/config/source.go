@@ -54,11 +54,18 @@ type FileInfo struct {
 	RefreshInterval time.Duration
 }
 
+// ScopeInfo identifies the node, which the configs scoped to role or node apply to
+type ScopeInfo struct {
+	Role   string
+	NodeID int64
+}
+
 // Options hold options
 type Options struct {
 	FileInfo        *FileInfo
 	EtcdInfo        *EtcdInfo
 	EnvKeyFormatter func(string) string
+	ScopeInfo       *ScopeInfo
 }
 
 // Option is a func
@@ -78,6 +85,16 @@ func WithEtcdSource(ri *EtcdInfo) Option {
 	}
 }
 
+// WithScope sets the role and node id for the sources supporting scoped configs
+func WithScope(role string, nodeID int64) Option {
+	return func(options *Options) {
+		options.ScopeInfo = &ScopeInfo{
+			Role:   role,
+			NodeID: nodeID,
+		}
+	}
+}
+
 // WithEnvSource enable env source
 // archaius will read ENV as key value
 func WithEnvSource(keyFormatter func(string) string) Option {
@@ -86,6 +103,14 @@ func WithEnvSource(keyFormatter func(string) string) Option {
 	}
 }
 
+// ScopedSource is the source supporting the configs scoped to role or node
+type ScopedSource interface {
+	// GetScopeByKey returns the scope which supplies the value of key
+	GetScopeByKey(key string) string
+	// Refresh reloads the configs synchronously, so the configs of the updated scope take effect
+	Refresh() error
+}
+
 // EventHandler handles config change event
 type EventHandler interface {
 	OnEvent(event *Event)
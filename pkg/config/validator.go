@@ -0,0 +1,177 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// Validator checks whether the value is acceptable for a config key.
+type Validator func(value string) error
+
+// keyValidator is a validator registered for the key.
+type keyValidator struct {
+	key      string
+	validate Validator
+}
+
+// Rejection records an update which is rejected by the validator.
+type Rejection struct {
+	Value  string    `json:"value"`
+	Source string    `json:"source"`
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// ValueStatus describes the value declared in config sources and the value in effect of a key.
+type ValueStatus struct {
+	Key       string     `json:"key"`
+	Declared  string     `json:"declared"`
+	Effective string     `json:"effective"`
+	Rejection *Rejection `json:"rejection,omitempty"`
+}
+
+// RegisterValidator registers a validator for the key,
+// the dynamic updates of the key are validated before dispatched to the handlers,
+// the invalid ones are rejected and the previous value is retained.
+// The value loaded at registration is validated too, the key is treated as absent if it's invalid,
+// so the default value takes effect.
+func (m *Manager) RegisterValidator(key string, validator Validator) {
+	m.Lock()
+	defer m.Unlock()
+	realKey := formatKey(key)
+	m.validators[realKey] = &keyValidator{
+		key:      key,
+		validate: validator,
+	}
+
+	value, err := m.getDeclaredConfig(realKey)
+	if err != nil {
+		return
+	}
+	if err := validator(value); err != nil {
+		log.Warn("invalid config value, ignore it", zap.String("key", key), zap.String("value", value), zap.Error(err))
+		m.rejections[realKey] = &Rejection{
+			Value:  value,
+			Source: m.keySourceMap[realKey],
+			Reason: err.Error(),
+			Time:   time.Now(),
+		}
+		m.pinned[realKey] = TombValue
+	}
+}
+
+// validateEvent checks the value which the event will take effect,
+// and pins the previous value of the key if it's invalid.
+func (m *Manager) validateEvent(e *Event) error {
+	realKey := formatKey(e.Key)
+	validator, ok := m.validators[realKey]
+	if !ok || e.HasUpdated {
+		return nil
+	}
+
+	var (
+		value     string
+		prevValue string
+		err       error
+	)
+	sourceName, exist := m.keySourceMap[realKey]
+	switch e.EventType {
+	case CreateType, UpdateType:
+		if exist && sourceName != e.EventSource {
+			if prioritySrc := m.getHighPrioritySource(sourceName, e.EventSource); prioritySrc != nil && prioritySrc.GetSourceName() == sourceName {
+				// the event is ignored anyway
+				return nil
+			}
+			prevValue, err = m.getConfigValueBySource(realKey, sourceName)
+		} else if exist {
+			prevValue = e.PrevValue
+		} else {
+			prevValue = TombValue
+		}
+		if err != nil {
+			prevValue = TombValue
+		}
+		value = e.Value
+	case DeleteType:
+		if !exist || sourceName != e.EventSource {
+			return nil
+		}
+		prevValue = e.Value
+		source := m.findNextBestSource(realKey, sourceName)
+		if source == nil {
+			// fallback to the default value
+			return nil
+		}
+		value, err = source.GetConfigurationByKey(realKey)
+		if err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if err := validator.validate(value); err != nil {
+		m.rejections[realKey] = &Rejection{
+			Value:  value,
+			Source: e.EventSource,
+			Reason: err.Error(),
+			Time:   time.Now(),
+		}
+		// keep the value retained by the former rejection
+		if _, ok := m.pinned[realKey]; !ok {
+			m.pinned[realKey] = prevValue
+		}
+		return err
+	}
+	return nil
+}
+
+// acceptEvent clears the rejection of the key once an update is accepted.
+func (m *Manager) acceptEvent(e *Event) {
+	realKey := formatKey(e.Key)
+	delete(m.pinned, realKey)
+	delete(m.rejections, realKey)
+}
+
+// GetValueStatus returns the declared and effective values of all the validated keys.
+func (m *Manager) GetValueStatus() []*ValueStatus {
+	m.RLock()
+	defer m.RUnlock()
+
+	result := make([]*ValueStatus, 0, len(m.validators))
+	for key, validator := range m.validators {
+		status := &ValueStatus{
+			Key: validator.key,
+		}
+		status.Declared, _ = m.getDeclaredConfig(key)
+		status.Effective, _ = m.getConfig(key)
+		if rejection, ok := m.rejections[key]; ok {
+			status.Rejection = rejection
+		}
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,149 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func TestValidateConfigUpdate(t *testing.T) {
+	dir := t.TempDir()
+	file := path.Join(dir, "milvus.yaml")
+	os.WriteFile(file, []byte("a.b: 1\nc.d: 2"), 0o600)
+
+	fs := NewFileSource(&FileInfo{[]string{file}, 0})
+	mgr, _ := Init()
+	assert.NoError(t, mgr.AddSource(fs))
+	mgr.RegisterValidator("a.b", func(value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil || v <= 0 {
+			return errors.Newf("invalid value %s", value)
+		}
+		return nil
+	})
+
+	dispatched := atomic.NewInt32(0)
+	mgr.Dispatcher.Register("a.b", NewHandler("test", func(*Event) {
+		dispatched.Inc()
+	}))
+
+	// invalid update is rejected, the previous value is retained
+	os.WriteFile(file, []byte("a.b: -1\nc.d: 3"), 0o600)
+	assert.NoError(t, fs.loadFromFile())
+	res, err := mgr.GetConfig("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", res)
+	res, err = mgr.GetConfig("c.d")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", res)
+	assert.Equal(t, int32(0), dispatched.Load())
+
+	status := mgr.GetValueStatus()
+	assert.Equal(t, 1, len(status))
+	assert.Equal(t, "a.b", status[0].Key)
+	assert.Equal(t, "-1", status[0].Declared)
+	assert.Equal(t, "1", status[0].Effective)
+	assert.NotNil(t, status[0].Rejection)
+	assert.Equal(t, "-1", status[0].Rejection.Value)
+
+	// the retained value is kept for the consecutive invalid updates
+	os.WriteFile(file, []byte("a.b: abc\nc.d: 3"), 0o600)
+	assert.NoError(t, fs.loadFromFile())
+	res, err = mgr.GetConfig("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", res)
+
+	// valid update takes effect
+	os.WriteFile(file, []byte("a.b: 5\nc.d: 3"), 0o600)
+	assert.NoError(t, fs.loadFromFile())
+	res, err = mgr.GetConfig("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, "5", res)
+	assert.Less(t, int32(0), dispatched.Load())
+	status = mgr.GetValueStatus()
+	assert.Equal(t, "5", status[0].Declared)
+	assert.Equal(t, "5", status[0].Effective)
+	assert.Nil(t, status[0].Rejection)
+}
+
+func TestValidateConfigCreate(t *testing.T) {
+	dir := t.TempDir()
+	file := path.Join(dir, "milvus.yaml")
+	os.WriteFile(file, []byte("c.d: 2"), 0o600)
+
+	fs := NewFileSource(&FileInfo{[]string{file}, 0})
+	mgr, _ := Init()
+	assert.NoError(t, mgr.AddSource(fs))
+	mgr.RegisterValidator("a.b", func(value string) error {
+		return errors.New("mock error")
+	})
+
+	// the key stays absent if the created value is invalid
+	os.WriteFile(file, []byte("a.b: 1\nc.d: 2"), 0o600)
+	assert.NoError(t, fs.loadFromFile())
+	_, err := mgr.GetConfig("a.b")
+	assert.Error(t, err)
+	_, ok := mgr.GetConfigs()["a.b"]
+	assert.False(t, ok)
+}
+
+func TestValidateConfigAtRegister(t *testing.T) {
+	dir := t.TempDir()
+	file := path.Join(dir, "milvus.yaml")
+	os.WriteFile(file, []byte("a.b: -1\nc.d: 2"), 0o600)
+
+	fs := NewFileSource(&FileInfo{[]string{file}, 0})
+	mgr, _ := Init()
+	assert.NoError(t, mgr.AddSource(fs))
+	validator := func(value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil || v <= 0 {
+			return errors.Newf("invalid value %s", value)
+		}
+		return nil
+	}
+	mgr.RegisterValidator("a.b", validator)
+	mgr.RegisterValidator("c.d", validator)
+
+	// the invalid value loaded is ignored, so the default value takes effect
+	_, err := mgr.GetConfig("a.b")
+	assert.Error(t, err)
+	res, err := mgr.GetConfig("c.d")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", res)
+
+	status := mgr.GetValueStatus()
+	assert.Equal(t, 2, len(status))
+	assert.Equal(t, "-1", status[0].Declared)
+	assert.Equal(t, "", status[0].Effective)
+	assert.NotNil(t, status[0].Rejection)
+	assert.Nil(t, status[1].Rejection)
+
+	// valid update takes effect
+	os.WriteFile(file, []byte("a.b: 3\nc.d: 2"), 0o600)
+	assert.NoError(t, fs.loadFromFile())
+	res, err = mgr.GetConfig("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", res)
+}
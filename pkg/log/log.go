@@ -31,6 +31,7 @@ package log
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -151,6 +152,12 @@ func initFileLog(cfg *FileLogConfig) (*lumberjack.Logger, error) {
 	}, nil
 }
 
+// NewFileWriter returns a rotating file writer with the config,
+// which is used by the dedicated logs beside the global logger.
+func NewFileWriter(cfg *FileLogConfig) (io.WriteCloser, error) {
+	return initFileLog(cfg)
+}
+
 func newStdLogger() (*zap.Logger, *ZapProperties) {
 	conf := &Config{Level: "debug", Stdout: true, DisableErrorVerbose: true}
 	lg, r, _ := InitLogger(conf, zap.OnFatal(zapcore.WriteThenPanic))
@@ -0,0 +1,61 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	BufferPoolHitLabel  = "hit"
+	BufferPoolMissLabel = "miss"
+
+	bufferPoolNameLabelName   = "pool"
+	bufferPoolResultLabelName = "result"
+)
+
+var (
+	BufferPoolRequestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "buffer_pool",
+			Name:      "request_count",
+			Help:      "count of buffers requested from the pool, hit means reused",
+		}, []string{bufferPoolNameLabelName, bufferPoolResultLabelName})
+
+	BufferPoolRequestSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "buffer_pool",
+			Name:      "request_size",
+			Help:      "size in bytes of buffers requested from the pool",
+			Buckets:   prometheus.ExponentialBuckets(1024, 2, 17),
+		}, []string{bufferPoolNameLabelName})
+
+	BufferPoolRecycledSize = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "buffer_pool",
+			Name:      "recycled_size",
+			Help:      "total size in bytes of buffers recycled into the pool",
+		}, []string{bufferPoolNameLabelName})
+)
+
+// RegisterBufferPoolMetrics registers buffer pool metrics
+func RegisterBufferPoolMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(BufferPoolRequestCount)
+	registry.MustRegister(BufferPoolRequestSize)
+	registry.MustRegister(BufferPoolRecycledSize)
+}
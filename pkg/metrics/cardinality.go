@@ -0,0 +1,203 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// OtherLabelValue is the label value which the long-tail values are aggregated into.
+const OtherLabelValue = "other"
+
+var (
+	// MetricsLabelAggregated records the number of label values aggregated into the other bucket.
+	MetricsLabelAggregated = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Name:      "metrics_label_aggregated_total",
+			Help:      "count of label values aggregated into the other bucket",
+		}, []string{governedLabelName})
+
+	// MetricsLabelCardinality records the number of distinct values of the governed labels.
+	MetricsLabelCardinality = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Name:      "metrics_label_cardinality",
+			Help:      "number of distinct values of the governed labels",
+		}, []string{governedLabelName})
+
+	governor = newLabelGovernor()
+)
+
+// partialDeleter is the metric vector which deletes the series by partial labels.
+type partialDeleter interface {
+	DeletePartialMatch(labels prometheus.Labels) int
+}
+
+// labelGovernor caps the number of distinct values of the labels,
+// the values beyond the limit are aggregated into the other bucket.
+// For the collection labels, the collections in the opt-in list always have the detailed metrics,
+// and the others are aggregated if the opt-in list is not empty.
+type labelGovernor struct {
+	mu               sync.RWMutex
+	limits           map[string]int
+	values           map[string]map[string]struct{}
+	optIn            map[string]struct{}
+	vecs             map[string][]partialDeleter
+	collectionLabels map[string]bool
+}
+
+func newLabelGovernor() *labelGovernor {
+	return &labelGovernor{
+		limits:           make(map[string]int),
+		values:           make(map[string]map[string]struct{}),
+		optIn:            make(map[string]struct{}),
+		vecs:             make(map[string][]partialDeleter),
+		collectionLabels: map[string]bool{collectionName: true, collectionIDLabelName: true},
+	}
+}
+
+func (g *labelGovernor) register(label string, vecs ...partialDeleter) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.vecs[label] = append(g.vecs[label], vecs...)
+}
+
+func (g *labelGovernor) setLimit(label string, limit int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.limits[label] = limit
+}
+
+// setOptIn replaces the opt-in collections,
+// the series of the collections which are no longer detailed are removed.
+func (g *labelGovernor) setOptIn(collections []string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.optIn = make(map[string]struct{}, len(collections))
+	for _, collection := range collections {
+		if collection = strings.TrimSpace(collection); collection != "" {
+			g.optIn[collection] = struct{}{}
+		}
+	}
+	if len(g.optIn) == 0 {
+		return
+	}
+	for label := range g.collectionLabels {
+		for value := range g.values[label] {
+			if _, ok := g.optIn[value]; !ok {
+				g.release(label, value)
+			}
+		}
+	}
+}
+
+func (g *labelGovernor) govern(label, value string) string {
+	// fast path, the value is in use already
+	g.mu.RLock()
+	_, ok := g.values[label][value]
+	g.mu.RUnlock()
+	if ok {
+		return value
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	values, ok := g.values[label]
+	if !ok {
+		values = make(map[string]struct{})
+		g.values[label] = values
+	}
+	if _, ok := values[value]; ok {
+		return value
+	}
+
+	if g.collectionLabels[label] && len(g.optIn) > 0 {
+		if _, ok := g.optIn[value]; !ok {
+			MetricsLabelAggregated.WithLabelValues(label).Inc()
+			return OtherLabelValue
+		}
+		// the opt-in collections are not limited
+	} else if limit := g.limits[label]; limit > 0 && len(values) >= limit {
+		MetricsLabelAggregated.WithLabelValues(label).Inc()
+		return OtherLabelValue
+	}
+	values[value] = struct{}{}
+	MetricsLabelCardinality.WithLabelValues(label).Set(float64(len(values)))
+	return value
+}
+
+func (g *labelGovernor) release(label, value string) {
+	values, ok := g.values[label]
+	if !ok {
+		return
+	}
+	if _, ok := values[value]; !ok {
+		return
+	}
+	delete(values, value)
+	for _, vec := range g.vecs[label] {
+		vec.DeletePartialMatch(prometheus.Labels{label: value})
+	}
+	MetricsLabelCardinality.WithLabelValues(label).Set(float64(len(values)))
+}
+
+// SetLabelLimit sets the max number of distinct values of the label, unlimited if it's not positive.
+// The values which are already in use are kept when the limit is lowered.
+func SetLabelLimit(label string, limit int) {
+	governor.setLimit(label, limit)
+}
+
+// SetCollectionLabelLimit sets the max number of distinct collections in the metrics labels.
+func SetCollectionLabelLimit(limit int) {
+	SetLabelLimit(collectionName, limit)
+	SetLabelLimit(collectionIDLabelName, limit)
+}
+
+// SetDetailedCollections sets the collections which have the detailed metrics,
+// the collections are identified by name or id, and all of them are detailed if it's empty.
+func SetDetailedCollections(collections []string) {
+	governor.setOptIn(collections)
+}
+
+// GovernLabelValue returns the value used as the label,
+// which is the value itself or OtherLabelValue if it's beyond the limit of the label.
+func GovernLabelValue(label, value string) string {
+	return governor.govern(label, value)
+}
+
+// CollectionNameLabel returns the collection name label value under cardinality control.
+func CollectionNameLabel(collection string) string {
+	return GovernLabelValue(collectionName, collection)
+}
+
+// CollectionIDLabel returns the collection id label value under cardinality control.
+func CollectionIDLabel(collectionID int64) string {
+	return GovernLabelValue(collectionIDLabelName, strconv.FormatInt(collectionID, 10))
+}
+
+// ReleaseCollectionName frees the quota of the collection name and removes its governed series.
+func ReleaseCollectionName(collection string) {
+	governor.mu.Lock()
+	defer governor.mu.Unlock()
+	governor.release(collectionName, collection)
+}
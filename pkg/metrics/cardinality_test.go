@@ -0,0 +1,112 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelGovernorLimit(t *testing.T) {
+	g := newLabelGovernor()
+	g.setLimit("label", 2)
+
+	assert.Equal(t, "a", g.govern("label", "a"))
+	assert.Equal(t, "b", g.govern("label", "b"))
+	assert.Equal(t, OtherLabelValue, g.govern("label", "c"))
+	// the admitted values are kept
+	assert.Equal(t, "a", g.govern("label", "a"))
+
+	// release frees the quota
+	g.release("label", "a")
+	assert.Equal(t, "c", g.govern("label", "c"))
+	assert.Equal(t, OtherLabelValue, g.govern("label", "a"))
+
+	// unlimited
+	g.setLimit("label", 0)
+	assert.Equal(t, "a", g.govern("label", "a"))
+}
+
+func TestLabelGovernorOptIn(t *testing.T) {
+	g := newLabelGovernor()
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test"}, []string{nodeIDLabelName, collectionName})
+	g.register(collectionName, vec)
+	g.setLimit(collectionName, 1)
+
+	vec.WithLabelValues("1", g.govern(collectionName, "c1")).Inc()
+	vec.WithLabelValues("1", g.govern(collectionName, "c2")).Inc()
+	assert.Equal(t, 2, testutil.CollectAndCount(vec))
+	assert.Equal(t, float64(1), testutil.ToFloat64(vec.WithLabelValues("1", OtherLabelValue)))
+
+	// the opt-in collections are not limited, the series of the others are removed
+	g.setOptIn([]string{"c2", " c3"})
+	assert.Equal(t, 1, testutil.CollectAndCount(vec))
+	assert.Equal(t, "c2", g.govern(collectionName, "c2"))
+	assert.Equal(t, "c3", g.govern(collectionName, "c3"))
+	assert.Equal(t, OtherLabelValue, g.govern(collectionName, "c1"))
+
+	// the opt-in list doesn't apply to the other labels
+	assert.Equal(t, "c1", g.govern("label", "c1"))
+
+	// all the collections are detailed within the limit if the list is empty
+	g.setOptIn([]string{""})
+	g.setLimit(collectionName, 0)
+	assert.Equal(t, "c1", g.govern(collectionName, "c1"))
+}
+
+func TestCollectionNameLabel(t *testing.T) {
+	defer SetCollectionLabelLimit(0)
+	SetCollectionLabelLimit(1)
+	assert.Equal(t, "c1", CollectionNameLabel("c1"))
+	assert.Equal(t, OtherLabelValue, CollectionNameLabel("c2"))
+	CleanupCollectionMetrics(1, "c1")
+	assert.Equal(t, "c2", CollectionNameLabel("c2"))
+	ReleaseCollectionName("c2")
+}
+
+func TestCollectionIDLabel(t *testing.T) {
+	defer SetCollectionLabelLimit(0)
+	SetCollectionLabelLimit(1)
+	assert.Equal(t, "100", CollectionIDLabel(100))
+	assert.Equal(t, OtherLabelValue, CollectionIDLabel(101))
+	assert.Equal(t, "100", CollectionIDLabel(100))
+	governor.mu.Lock()
+	governor.release(collectionIDLabelName, "100")
+	governor.mu.Unlock()
+}
+
+func TestLabelGovernorConcurrent(t *testing.T) {
+	g := newLabelGovernor()
+	g.setLimit("label", 10)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				g.govern("label", fmt.Sprint(j%20))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 10, len(g.values["label"]))
+}
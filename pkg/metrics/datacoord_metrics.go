@@ -260,6 +260,90 @@ var (
 			Help:      "number of index tasks of each type",
 		}, []string{collectionIDLabelName, indexTaskStatusLabelName})
 
+	// DataCoordCorruptedObjectNum records the number of corrupted objects found by scrubber.
+	DataCoordCorruptedObjectNum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "corrupted_object_count",
+			Help:      "number of corrupted binlog and index files found by scrubber",
+		}, []string{collectionIDLabelName, objectTypeLabelName})
+
+	// DataCoordScrubbedSegmentNum records the number of segments verified by scrubber.
+	DataCoordScrubbedSegmentNum = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "scrubbed_segment_count",
+			Help:      "number of segments verified by scrubber",
+		})
+
+	// DataCoordSmallSegmentNum records the number of small sealed segments per collection.
+	DataCoordSmallSegmentNum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "small_segment_num",
+			Help:      "number of flushed segments smaller than the small proportion of max rows per collection",
+		}, []string{collectionIDLabelName})
+
+	// DataCoordSmallSegmentCompactionNum records the number of merge compactions triggered by the small segments.
+	DataCoordSmallSegmentCompactionNum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "small_segment_compaction_count",
+			Help:      "number of merge compactions triggered as the collection accumulates small segments",
+		}, []string{collectionIDLabelName})
+
+	DataCoordChannelLagRows = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "channel_lag_rows",
+			Help:      "number of rows allocated but not consumed by datanode yet per virtual channel",
+		}, []string{
+			channelNameLabelName,
+		})
+
+	DataCoordChannelLagBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "channel_lag_bytes",
+			Help:      "estimated size of the rows not consumed by datanode yet per virtual channel",
+		}, []string{
+			channelNameLabelName,
+		})
+
+	DataCoordChannelBacklog = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "channel_backlog_ms",
+			Help:      "now time minus the latest consumed timetick per virtual channel",
+		}, []string{
+			channelNameLabelName,
+		})
+
+	DataCoordChannelLagging = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "channel_lagging",
+			Help:      "whether the backlog of virtual channel exceeds the threshold, 1 for exceeded",
+		}, []string{
+			channelNameLabelName,
+		})
+
+	DataCoordDataNodeTimeTickSkew = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "datanode_timetick_skew_ms",
+			Help:      "the latest timetick consumed by all the datanodes minus the earliest one of the datanode",
+		}, []string{nodeIDLabelName})
+
 	// IndexNodeNum records the number of IndexNodes managed by IndexCoord.
 	IndexNodeNum = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -289,6 +373,24 @@ func RegisterDataCoord(registry *prometheus.Registry) {
 	registry.MustRegister(IndexRequestCounter)
 	registry.MustRegister(IndexTaskNum)
 	registry.MustRegister(IndexNodeNum)
+	registry.MustRegister(DataCoordCorruptedObjectNum)
+	registry.MustRegister(DataCoordScrubbedSegmentNum)
+	registry.MustRegister(DataCoordSmallSegmentNum)
+	registry.MustRegister(DataCoordSmallSegmentCompactionNum)
+	registry.MustRegister(DataCoordChannelLagRows)
+	registry.MustRegister(DataCoordChannelLagBytes)
+	registry.MustRegister(DataCoordChannelBacklog)
+	registry.MustRegister(DataCoordChannelLagging)
+	registry.MustRegister(DataCoordDataNodeTimeTickSkew)
+}
+
+// CleanupDataCoordChannelLatencyMetrics removes the latency metrics of the virtual channel.
+func CleanupDataCoordChannelLatencyMetrics(channel string) {
+	DataCoordConsumeDataNodeTimeTickLag.DeletePartialMatch(prometheus.Labels{channelNameLabelName: channel})
+	DataCoordChannelLagRows.DeleteLabelValues(channel)
+	DataCoordChannelLagBytes.DeleteLabelValues(channel)
+	DataCoordChannelBacklog.DeleteLabelValues(channel)
+	DataCoordChannelLagging.DeleteLabelValues(channel)
 }
 
 func CleanupDataCoordSegmentMetrics(collectionID int64, segmentID int64) {
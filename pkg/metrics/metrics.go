@@ -27,6 +27,7 @@ const (
 	milvusNamespace = "milvus"
 
 	AbandonLabel = "abandon"
+	RetryLabel   = "retry"
 	SuccessLabel = "success"
 	FailLabel    = "fail"
 	TotalLabel   = "total"
@@ -61,9 +62,19 @@ const (
 	HookAfter  = "after"
 	HookMock   = "mock"
 
+	PreFilterPredicate = "predicate"
+	PreFilterSegment   = "segment"
+
 	ReduceSegments = "segments"
 	ReduceShards   = "shards"
 
+	CoarseSearchPhase = "coarse"
+	RefineSearchPhase = "refine"
+
+	ShedConnection     = "connection"
+	ShedClientInFlight = "client_in_flight"
+	ShedUserInFlight   = "user_in_flight"
+
 	nodeIDLabelName          = "node_id"
 	statusLabelName          = "status"
 	indexTaskStatusLabelName = "index_task_status"
@@ -88,7 +99,22 @@ const (
 	lockName                 = "lock_name"
 	lockSource               = "lock_source"
 	lockType                 = "lock_type"
+	diskCategoryLabelName    = "disk_category"
+	memoryCategoryLabelName  = "memory_category"
+	gpuResidencyLabelName    = "gpu_residency"
+	gpuIndexMoveLabelName    = "gpu_index_move"
+	objectTypeLabelName      = "object_type"
+	governedLabelName        = "label"
 	lockOp                   = "lock_op"
+	errorClassLabelName      = "error_class"
+	subscriptionLabelName    = "subscription"
+	resourceGroupLabelName   = "resource_group"
+	preFilterHookLabelName   = "pre_filter_hook"
+	preFilterStageLabelName  = "pre_filter_stage"
+	shedReasonLabelName      = "shed_reason"
+	watermarkActionLabelName = "watermark_action"
+	dedupModeLabelName       = "dedup_mode"
+	searchPhaseLabelName     = "search_phase"
 )
 
 var (
@@ -135,5 +161,7 @@ func GetRegisterer() prometheus.Registerer {
 func Register(r prometheus.Registerer) {
 	r.MustRegister(NumNodes)
 	r.MustRegister(LockCosts)
+	r.MustRegister(MetricsLabelAggregated)
+	r.MustRegister(MetricsLabelCardinality)
 	metricRegisterer = r
 }
@@ -25,7 +25,12 @@ const (
 	CreateProducerLabel = "create_producer"
 	CreateConsumerLabel = "create_consumer"
 
-	msgStreamOpType = "message_op_type"
+	RawPayloadLabel        = "raw"
+	CompressedPayloadLabel = "compressed"
+
+	msgStreamOpType       = "message_op_type"
+	msgStreamCompressType = "compress_type"
+	msgStreamPayloadStage = "payload_stage"
 )
 
 var (
@@ -56,6 +61,31 @@ var (
 			Name:      "op_count",
 			Help:      "count of stream message operation",
 		}, []string{msgStreamOpType, statusLabelName})
+
+	MsgStreamConsumerLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "msgstream",
+			Name:      "consumer_lag",
+			Help:      "number of messages not consumed yet of the topic by the subscription",
+		}, []string{channelNameLabelName, subscriptionLabelName})
+
+	MsgStreamPayloadBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "msgstream",
+			Name:      "payload_bytes",
+			Help:      "bytes of the payloads produced before and after compression",
+		}, []string{msgStreamCompressType, msgStreamPayloadStage})
+
+	MsgStreamCompressionRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "msgstream",
+			Name:      "compression_ratio",
+			Help:      "ratio of the raw size to the compressed size of the payloads produced",
+			Buckets:   []float64{1, 1.1, 1.25, 1.5, 2, 3, 4, 6, 8, 16},
+		}, []string{msgStreamCompressType})
 )
 
 // RegisterMsgStreamMetrics registers msg stream metrics
@@ -63,4 +93,7 @@ func RegisterMsgStreamMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(NumConsumers)
 	registry.MustRegister(MsgStreamRequestLatency)
 	registry.MustRegister(MsgStreamOpCounter)
+	registry.MustRegister(MsgStreamConsumerLag)
+	registry.MustRegister(MsgStreamPayloadBytes)
+	registry.MustRegister(MsgStreamCompressionRatio)
 }
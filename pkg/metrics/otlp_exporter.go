@@ -0,0 +1,382 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	otlpcommonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+const (
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http"
+
+	otlpScopeName = "github.com/milvus-io/milvus/pkg/metrics"
+)
+
+// OTLPExporterConfig is the config of the OTLP metrics exporter.
+type OTLPExporterConfig struct {
+	// Endpoint is host:port for grpc, or the url of the metrics path for http, e.g. http://localhost:4318/v1/metrics.
+	Endpoint string
+	Protocol string
+	Secure   bool
+	Interval time.Duration
+	Timeout  time.Duration
+	Headers  map[string]string
+
+	// Roles are all the roles enabled in the process, e.g. every coord and node in standalone mode.
+	// NodeID returns the server id assigned by the session registration, the metrics are not exported
+	// until it's assigned, so that the resource never carries the node id 0.
+	Roles  []string
+	NodeID func() int64
+}
+
+// OTLPExporter pushes the metrics gathered from the prometheus registry to the OTLP endpoint periodically,
+// so the metrics are shared by the prometheus pull endpoint and the OTLP push.
+type OTLPExporter struct {
+	cfg       *OTLPExporterConfig
+	gatherer  prometheus.Gatherer
+	startTime time.Time
+
+	resourceMu sync.Mutex
+	resource   *resourcepb.Resource
+
+	conn       *grpc.ClientConn
+	client     colmetricpb.MetricsServiceClient
+	httpClient *http.Client
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewOTLPExporter returns an exporter of the metrics gathered by the gatherer.
+func NewOTLPExporter(gatherer prometheus.Gatherer, cfg *OTLPExporterConfig) (*OTLPExporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("empty otlp metrics endpoint")
+	}
+	if cfg.Interval <= 0 {
+		return nil, errors.Newf("invalid otlp metrics export interval %v", cfg.Interval)
+	}
+
+	e := &OTLPExporter{
+		cfg:       cfg,
+		gatherer:  gatherer,
+		startTime: time.Now(),
+		closeCh:   make(chan struct{}),
+	}
+	switch cfg.Protocol {
+	case OTLPProtocolGRPC, "":
+		creds := insecure.NewCredentials()
+		if cfg.Secure {
+			creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		}
+		conn, err := grpc.Dial(cfg.Endpoint, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, err
+		}
+		e.conn = conn
+		e.client = colmetricpb.NewMetricsServiceClient(conn)
+	case OTLPProtocolHTTP:
+		e.httpClient = &http.Client{}
+	default:
+		return nil, errors.Newf("unknown otlp metrics protocol %s", cfg.Protocol)
+	}
+	return e, nil
+}
+
+// getResource returns the resource of the exported metrics,
+// it returns nil if the node id is not assigned yet.
+func (e *OTLPExporter) getResource() *resourcepb.Resource {
+	e.resourceMu.Lock()
+	defer e.resourceMu.Unlock()
+	if e.resource != nil {
+		return e.resource
+	}
+	var nodeID int64
+	if e.cfg.NodeID != nil {
+		nodeID = e.cfg.NodeID()
+	}
+	if nodeID <= 0 {
+		return nil
+	}
+	e.resource = newOTLPResource(e.cfg.Roles, nodeID)
+	return e.resource
+}
+
+func newOTLPResource(roles []string, nodeID int64) *resourcepb.Resource {
+	roleValues := make([]*otlpcommonpb.AnyValue, 0, len(roles))
+	for _, role := range roles {
+		roleValues = append(roleValues, &otlpcommonpb.AnyValue{Value: &otlpcommonpb.AnyValue_StringValue{StringValue: role}})
+	}
+	return &resourcepb.Resource{
+		Attributes: []*otlpcommonpb.KeyValue{
+			otlpStringAttr("service.name", "milvus"),
+			otlpStringAttr("service.instance.id", fmt.Sprintf("%s-%d", strings.Join(roles, "+"), nodeID)),
+			otlpStringAttr("milvus.role", strings.Join(roles, ",")),
+			{Key: "milvus.roles", Value: &otlpcommonpb.AnyValue{Value: &otlpcommonpb.AnyValue_ArrayValue{ArrayValue: &otlpcommonpb.ArrayValue{Values: roleValues}}}},
+			{Key: "milvus.node_id", Value: &otlpcommonpb.AnyValue{Value: &otlpcommonpb.AnyValue_IntValue{IntValue: nodeID}}},
+		},
+	}
+}
+
+func otlpStringAttr(key, value string) *otlpcommonpb.KeyValue {
+	return &otlpcommonpb.KeyValue{
+		Key:   key,
+		Value: &otlpcommonpb.AnyValue{Value: &otlpcommonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// Start starts to push the metrics periodically.
+func (e *OTLPExporter) Start() {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(e.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-e.closeCh:
+				return
+			case <-ticker.C:
+				if err := e.Export(context.Background()); err != nil {
+					log.RatedWarn(60, "failed to export metrics by otlp", zap.String("endpoint", e.cfg.Endpoint), zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Stop pushes the metrics the last time and stops the exporter.
+func (e *OTLPExporter) Stop() {
+	e.closeOnce.Do(func() {
+		close(e.closeCh)
+		e.wg.Wait()
+		if err := e.Export(context.Background()); err != nil {
+			log.Warn("failed to export metrics by otlp before stopped", zap.Error(err))
+		}
+		if e.conn != nil {
+			e.conn.Close()
+		}
+	})
+}
+
+// Export gathers the metrics and pushes them to the endpoint.
+// It's a no-op before the node id is assigned by the session registration.
+func (e *OTLPExporter) Export(ctx context.Context) error {
+	resource := e.getResource()
+	if resource == nil {
+		log.RatedInfo(60, "skip exporting metrics by otlp, node id not assigned yet")
+		return nil
+	}
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		// the families gathered successfully are still exported
+		log.RatedWarn(60, "failed to gather some metrics", zap.Error(err))
+	}
+	req := &colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricpb.ResourceMetrics{{
+			Resource: resource,
+			ScopeMetrics: []*metricpb.ScopeMetrics{{
+				Scope:   &otlpcommonpb.InstrumentationScope{Name: otlpScopeName},
+				Metrics: ConvertToOTLP(families, e.startTime, time.Now()),
+			}},
+		}},
+	}
+
+	if e.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.cfg.Timeout)
+		defer cancel()
+	}
+	if e.client != nil {
+		for k, v := range e.cfg.Headers {
+			ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+		}
+		_, err := e.client.Export(ctx, req)
+		return err
+	}
+	return e.exportHTTP(ctx, req)
+}
+
+func (e *OTLPExporter) exportHTTP(ctx context.Context, req *colmetricpb.ExportMetricsServiceRequest) error {
+	bs, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	endpoint := e.cfg.Endpoint
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		scheme := "http://"
+		if e.cfg.Secure {
+			scheme = "https://"
+		}
+		endpoint = scheme + endpoint + "/v1/metrics"
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(bs))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range e.cfg.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	resp, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Newf("otlp endpoint responded %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// ConvertToOTLP converts the prometheus metric families into the OTLP metrics,
+// the counters and histograms are cumulative since the start time.
+func ConvertToOTLP(families []*dto.MetricFamily, start, now time.Time) []*metricpb.Metric {
+	startNano := uint64(start.UnixNano())
+	nowNano := uint64(now.UnixNano())
+	result := make([]*metricpb.Metric, 0, len(families))
+	for _, family := range families {
+		metric := &metricpb.Metric{
+			Name:        family.GetName(),
+			Description: family.GetHelp(),
+		}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			points := make([]*metricpb.NumberDataPoint, 0, len(family.GetMetric()))
+			for _, m := range family.GetMetric() {
+				points = append(points, newNumberDataPoint(m, m.GetCounter().GetValue(), startNano, nowNano))
+			}
+			metric.Data = &metricpb.Metric_Sum{Sum: &metricpb.Sum{
+				DataPoints:             points,
+				AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+			}}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			points := make([]*metricpb.NumberDataPoint, 0, len(family.GetMetric()))
+			for _, m := range family.GetMetric() {
+				value := m.GetGauge().GetValue()
+				if family.GetType() == dto.MetricType_UNTYPED {
+					value = m.GetUntyped().GetValue()
+				}
+				points = append(points, newNumberDataPoint(m, value, 0, nowNano))
+			}
+			metric.Data = &metricpb.Metric_Gauge{Gauge: &metricpb.Gauge{DataPoints: points}}
+		case dto.MetricType_HISTOGRAM:
+			points := make([]*metricpb.HistogramDataPoint, 0, len(family.GetMetric()))
+			for _, m := range family.GetMetric() {
+				points = append(points, newHistogramDataPoint(m, startNano, nowNano))
+			}
+			metric.Data = &metricpb.Metric_Histogram{Histogram: &metricpb.Histogram{
+				DataPoints:             points,
+				AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			}}
+		case dto.MetricType_SUMMARY:
+			points := make([]*metricpb.SummaryDataPoint, 0, len(family.GetMetric()))
+			for _, m := range family.GetMetric() {
+				summary := m.GetSummary()
+				point := &metricpb.SummaryDataPoint{
+					Attributes:        otlpAttributes(m),
+					StartTimeUnixNano: startNano,
+					TimeUnixNano:      nowNano,
+					Count:             summary.GetSampleCount(),
+					Sum:               summary.GetSampleSum(),
+				}
+				for _, q := range summary.GetQuantile() {
+					point.QuantileValues = append(point.QuantileValues, &metricpb.SummaryDataPoint_ValueAtQuantile{
+						Quantile: q.GetQuantile(),
+						Value:    q.GetValue(),
+					})
+				}
+				points = append(points, point)
+			}
+			metric.Data = &metricpb.Metric_Summary{Summary: &metricpb.Summary{DataPoints: points}}
+		default:
+			continue
+		}
+		result = append(result, metric)
+	}
+	return result
+}
+
+func otlpAttributes(m *dto.Metric) []*otlpcommonpb.KeyValue {
+	attrs := make([]*otlpcommonpb.KeyValue, 0, len(m.GetLabel()))
+	for _, label := range m.GetLabel() {
+		attrs = append(attrs, otlpStringAttr(label.GetName(), label.GetValue()))
+	}
+	return attrs
+}
+
+func newNumberDataPoint(m *dto.Metric, value float64, start, now uint64) *metricpb.NumberDataPoint {
+	return &metricpb.NumberDataPoint{
+		Attributes:        otlpAttributes(m),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      now,
+		Value:             &metricpb.NumberDataPoint_AsDouble{AsDouble: value},
+	}
+}
+
+// newHistogramDataPoint converts the cumulative prometheus buckets into the OTLP bucket counts,
+// the last bucket of OTLP is the count of the values greater than the last bound.
+func newHistogramDataPoint(m *dto.Metric, start, now uint64) *metricpb.HistogramDataPoint {
+	histogram := m.GetHistogram()
+	sum := histogram.GetSampleSum()
+	point := &metricpb.HistogramDataPoint{
+		Attributes:        otlpAttributes(m),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      now,
+		Count:             histogram.GetSampleCount(),
+		Sum:               &sum,
+	}
+	var prev uint64
+	for _, bucket := range histogram.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, bucket.GetCumulativeCount()-prev)
+		prev = bucket.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, histogram.GetSampleCount()-prev)
+	return point
+}
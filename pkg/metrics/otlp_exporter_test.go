@@ -0,0 +1,132 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"go.uber.org/atomic"
+)
+
+func newTestRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_counter", Help: "counter"}, []string{"collection"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge", Help: "gauge"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_histogram", Help: "histogram", Buckets: []float64{1, 10}})
+	registry.MustRegister(counter, gauge, histogram)
+
+	counter.WithLabelValues("c1").Add(3)
+	gauge.Set(5)
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+	histogram.Observe(50)
+	return registry
+}
+
+func TestConvertToOTLP(t *testing.T) {
+	families, err := newTestRegistry().Gather()
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Minute)
+	result := make(map[string]*metricpb.Metric)
+	for _, m := range ConvertToOTLP(families, start, time.Now()) {
+		result[m.GetName()] = m
+	}
+	assert.Equal(t, 3, len(result))
+
+	sum := result["test_counter"].GetSum()
+	assert.True(t, sum.GetIsMonotonic())
+	assert.Equal(t, metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, sum.GetAggregationTemporality())
+	assert.Equal(t, 3.0, sum.GetDataPoints()[0].GetAsDouble())
+	assert.Equal(t, uint64(start.UnixNano()), sum.GetDataPoints()[0].GetStartTimeUnixNano())
+	assert.Equal(t, "collection", sum.GetDataPoints()[0].GetAttributes()[0].GetKey())
+	assert.Equal(t, "c1", sum.GetDataPoints()[0].GetAttributes()[0].GetValue().GetStringValue())
+
+	assert.Equal(t, 5.0, result["test_gauge"].GetGauge().GetDataPoints()[0].GetAsDouble())
+
+	point := result["test_histogram"].GetHistogram().GetDataPoints()[0]
+	assert.Equal(t, uint64(3), point.GetCount())
+	assert.Equal(t, 55.5, point.GetSum())
+	assert.Equal(t, []float64{1, 10}, point.GetExplicitBounds())
+	assert.Equal(t, []uint64{1, 1, 1}, point.GetBucketCounts())
+}
+
+func TestOTLPExporterHTTP(t *testing.T) {
+	received := make(chan *colmetricpb.ExportMetricsServiceRequest, 1)
+	nodeID := atomic.NewInt64(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "token", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := &colmetricpb.ExportMetricsServiceRequest{}
+		assert.NoError(t, proto.Unmarshal(body, req))
+		received <- req
+	}))
+	defer server.Close()
+
+	exporter, err := NewOTLPExporter(newTestRegistry(), &OTLPExporterConfig{
+		Endpoint: server.URL + "/v1/metrics",
+		Protocol: OTLPProtocolHTTP,
+		Interval: time.Minute,
+		Headers:  map[string]string{"Authorization": "token"},
+		Roles:    []string{"rootcoord", "proxy"},
+		NodeID:   func() int64 { return nodeID.Load() },
+	})
+	require.NoError(t, err)
+
+	// not exported before the node id is assigned
+	require.NoError(t, exporter.Export(context.Background()))
+	select {
+	case <-received:
+		t.Fatal("metrics exported before node id assigned")
+	default:
+	}
+
+	nodeID.Store(1)
+	require.NoError(t, exporter.Export(context.Background()))
+
+	req := <-received
+	attrs := make(map[string]string)
+	for _, kv := range req.GetResourceMetrics()[0].GetResource().GetAttributes() {
+		attrs[kv.GetKey()] = kv.GetValue().GetStringValue()
+	}
+	assert.Equal(t, "milvus", attrs["service.name"])
+	assert.Equal(t, "rootcoord,proxy", attrs["milvus.role"])
+	assert.Equal(t, "rootcoord+proxy-1", attrs["service.instance.id"])
+	assert.Equal(t, 3, len(req.GetResourceMetrics()[0].GetScopeMetrics()[0].GetMetrics()))
+}
+
+func TestNewOTLPExporterInvalid(t *testing.T) {
+	_, err := NewOTLPExporter(prometheus.NewRegistry(), &OTLPExporterConfig{Interval: time.Second})
+	assert.Error(t, err)
+	_, err = NewOTLPExporter(prometheus.NewRegistry(), &OTLPExporterConfig{Endpoint: "localhost:4317"})
+	assert.Error(t, err)
+	_, err = NewOTLPExporter(prometheus.NewRegistry(), &OTLPExporterConfig{Endpoint: "localhost:4317", Interval: time.Second, Protocol: "udp"})
+	assert.Error(t, err)
+}
@@ -288,6 +288,88 @@ var (
 		}, []string{
 			nodeIDLabelName,
 		})
+
+	// ProxyReadOnly records whether the proxy rejects the writes due to dependency outage.
+	ProxyReadOnly = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "read_only",
+			Help:      "whether the proxy is read-only due to dependency outage, 1 means read-only",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	// ProxyShardRetryCount records the retries of search/query on shard delegators by error class.
+	ProxyShardRetryCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "shard_retry_count",
+			Help:      "count of retries of search/query on shard delegators by error class",
+		}, []string{
+			nodeIDLabelName,
+			errorClassLabelName,
+			statusLabelName,
+		})
+
+	// ProxyClientConnections records the connections from the clients accepted by the proxy.
+	ProxyClientConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "client_connections",
+			Help:      "number of connections from the clients accepted by the proxy",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	// ProxyClientInFlightRequests records the requests from the clients in processing.
+	ProxyClientInFlightRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "client_in_flight_requests",
+			Help:      "number of in-flight requests from the clients",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	// ProxyClientShedCount records the requests rejected by the per-client limits.
+	ProxyClientShedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "client_shed_count",
+			Help:      "count of requests rejected by the per-client connection and in-flight limits",
+		}, []string{
+			nodeIDLabelName,
+			shedReasonLabelName,
+		})
+
+	// ProxyDedupSuppressedRows records the duplicate rows suppressed by the dedup at ingest.
+	ProxyDedupSuppressedRows = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "dedup_suppressed_rows",
+			Help:      "count of duplicate rows dropped or upserted by the dedup at ingest",
+		}, []string{
+			nodeIDLabelName,
+			dedupModeLabelName,
+		})
+
+	// ProxyMutationBatchRequests records the insert requests coalesced into a batch.
+	ProxyMutationBatchRequests = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "mutation_batch_requests",
+			Help:      "number of insert requests coalesced into a batch",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{
+			nodeIDLabelName,
+		})
 )
 
 // RegisterProxy registers Proxy metrics
@@ -331,9 +413,25 @@ func RegisterProxy(registry *prometheus.Registry) {
 
 	registry.MustRegister(ProxyWorkLoadScore)
 	registry.MustRegister(ProxyExecutingTotalNq)
+	registry.MustRegister(ProxyReadOnly)
+	registry.MustRegister(ProxyShardRetryCount)
+	registry.MustRegister(ProxyClientConnections)
+	registry.MustRegister(ProxyClientInFlightRequests)
+	registry.MustRegister(ProxyClientShedCount)
+	registry.MustRegister(ProxyMutationBatchRequests)
+	registry.MustRegister(ProxyDedupSuppressedRows)
+
+	governor.register(collectionName,
+		ProxyReceivedNQ,
+		ProxyCollectionSQLatency,
+		ProxyCollectionMutationLatency,
+		ProxyReceiveBytes,
+	)
+	governor.register(collectionIDLabelName, ProxyLimiterRate)
 }
 
 func CleanupCollectionMetrics(nodeID int64, collection string) {
+	ReleaseCollectionName(collection)
 	ProxyCollectionSQLatency.Delete(prometheus.Labels{
 		nodeIDLabelName:    strconv.FormatInt(nodeID, 10),
 		queryTypeLabelName: SearchLabel, collectionName: collection,
@@ -104,6 +104,38 @@ var (
 			Name:      "querynode_num",
 			Help:      "number of QueryNodes managered by QueryCoord",
 		}, []string{})
+
+	QueryCoordResourceGroupNodeNum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryCoordRole,
+			Name:      "resource_group_node_num",
+			Help:      "number of QueryNodes in the resource group",
+		}, []string{resourceGroupLabelName})
+
+	QueryCoordResourceGroupReplicaNum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryCoordRole,
+			Name:      "resource_group_replica_num",
+			Help:      "number of replicas loaded in the resource group",
+		}, []string{resourceGroupLabelName})
+
+	QueryCoordResourceGroupMemoryUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryCoordRole,
+			Name:      "resource_group_memory_usage",
+			Help:      "memory used by the QueryNodes in the resource group, in bytes",
+		}, []string{resourceGroupLabelName})
+
+	QueryCoordFailureDomainViolationNum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryCoordRole,
+			Name:      "failure_domain_violation_num",
+			Help:      "number of the failure domains serving more than one replica of the collection",
+		}, []string{collectionIDLabelName})
 )
 
 // RegisterQueryCoord registers QueryCoord metrics
@@ -116,4 +148,8 @@ func RegisterQueryCoord(registry *prometheus.Registry) {
 	registry.MustRegister(QueryCoordReleaseLatency)
 	registry.MustRegister(QueryCoordTaskNum)
 	registry.MustRegister(QueryCoordNumQueryNodes)
+	registry.MustRegister(QueryCoordResourceGroupNodeNum)
+	registry.MustRegister(QueryCoordResourceGroupReplicaNum)
+	registry.MustRegister(QueryCoordResourceGroupMemoryUsage)
+	registry.MustRegister(QueryCoordFailureDomainViolationNum)
 }
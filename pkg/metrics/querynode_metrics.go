@@ -165,6 +165,17 @@ var (
 			queryTypeLabelName,
 		})
 
+	// QueryNodeDegradedReadCount counts the reads served without waiting for the stale tsafe.
+	QueryNodeDegradedReadCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "degraded_read_count",
+			Help:      "count of search or query served by the loaded data without waiting for the stale tsafe",
+		}, []string{
+			nodeIDLabelName,
+		})
+
 	QueryNodeSQLatencyInQueue = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: milvusNamespace,
@@ -455,6 +466,195 @@ var (
 		}, []string{
 			nodeIDLabelName,
 		})
+
+	QueryNodeDiskCacheUsedSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "disk_cache_used_size",
+			Help:      "disk size(MB) tracked by the disk cache manager of each category",
+		}, []string{
+			nodeIDLabelName,
+			diskCategoryLabelName,
+		})
+
+	QueryNodeDiskCacheEvictCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "disk_cache_evict_count",
+			Help:      "count of entries evicted by the disk cache manager of each category",
+		}, []string{
+			nodeIDLabelName,
+			diskCategoryLabelName,
+		})
+
+	QueryNodeMemoryGovernorUsedSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "memory_governor_used_size",
+			Help:      "memory size(MB) tracked by the memory governor of each category",
+		}, []string{
+			nodeIDLabelName,
+			memoryCategoryLabelName,
+		})
+
+	QueryNodeMemoryGovernorRejectCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "memory_governor_reject_count",
+			Help:      "count of requests rejected by the memory governor of each category",
+		}, []string{
+			nodeIDLabelName,
+			memoryCategoryLabelName,
+		})
+
+	QueryNodeMemoryWatermarkLevel = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "memory_watermark_level",
+			Help:      "the memory watermark level reached, 0 for normal, 1 to 4 for the tiered actions taken",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeMemoryWatermarkActionCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "memory_watermark_action_count",
+			Help:      "count of the actions taken by the memory watermark, e.g. the loads and requests rejected, the cache evicted and the segments released",
+		}, []string{
+			nodeIDLabelName,
+			watermarkActionLabelName,
+		})
+
+	QueryNodeGPUMemoryUsedSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "gpu_memory_used_size",
+			Help:      "size(MB) of the GPU indexes resident in GPU memory or spilled to host memory",
+		}, []string{
+			nodeIDLabelName,
+			gpuResidencyLabelName,
+		})
+
+	QueryNodeGPUIndexMoveCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "gpu_index_move_count",
+			Help:      "count of GPU indexes spilled to host memory or restored to GPU memory",
+		}, []string{
+			nodeIDLabelName,
+			gpuIndexMoveLabelName,
+		})
+
+	QueryNodeFieldStatsPrunedSegments = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "field_stats_pruned_segments",
+			Help:      "count of sealed segments skipped since the field stats prove no row matches the filter",
+		}, []string{
+			nodeIDLabelName,
+			queryTypeLabelName,
+		})
+
+	QueryNodePreFilterHookLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "pre_filter_hook_latency",
+			Help:      "latency of the pre-filter hooks applied before the vector search",
+			Buckets:   buckets,
+		}, []string{
+			nodeIDLabelName,
+			preFilterHookLabelName,
+			preFilterStageLabelName,
+		})
+
+	QueryNodePreFilterHookPrunedSegments = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "pre_filter_hook_pruned_segments",
+			Help:      "count of segments skipped by the pre-filter hooks",
+		}, []string{
+			nodeIDLabelName,
+			preFilterHookLabelName,
+		})
+
+	QueryNodeIndexPeerFetchTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "index_peer_fetch_total",
+			Help:      "count of disk indexes fetched from peers, the failed ones are downloaded from the object storage",
+		}, []string{
+			nodeIDLabelName,
+			statusLabelName,
+		})
+
+	QueryNodeIndexPeerFetchBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "index_peer_fetch_bytes",
+			Help:      "bytes of disk index files fetched from peers",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodeEstimatedFilterSelectivity = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "estimated_filter_selectivity",
+			Help:      "the fraction of rows matching the filter in sealed segments, estimated by the field stats",
+			Buckets:   prometheus.LinearBuckets(0.1, 0.1, 10),
+		}, []string{
+			nodeIDLabelName,
+			queryTypeLabelName,
+		})
+
+	QueryNodeTwoPhaseSearchLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "two_phase_search_latency",
+			Help:      "latency of each phase of the two-phase search on delegator",
+			Buckets:   buckets,
+		}, []string{
+			nodeIDLabelName,
+			searchPhaseLabelName,
+		})
+
+	QueryNodeTwoPhaseSearchCandidateRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "two_phase_search_candidate_ratio",
+			Help:      "the fraction of sealed segments selected by the coarse phase to be refined",
+			Buckets:   prometheus.LinearBuckets(0.1, 0.1, 10),
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	QueryNodePlanCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "plan_cache_count",
+			Help:      "count of the hits/misses of the segcore plans cached by the serialized plans",
+		}, []string{
+			nodeIDLabelName,
+			cacheStateLabelName,
+		})
 )
 
 // RegisterQueryNode registers QueryNode metrics
@@ -467,6 +667,7 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeSQCount)
 	registry.MustRegister(QueryNodeSQReqLatency)
 	registry.MustRegister(QueryNodeSQLatencyWaitTSafe)
+	registry.MustRegister(QueryNodeDegradedReadCount)
 	registry.MustRegister(QueryNodeSQLatencyInQueue)
 	registry.MustRegister(QueryNodeSQPerUserLatencyInQueue)
 	registry.MustRegister(QueryNodeSQSegmentLatency)
@@ -495,8 +696,34 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeSegmentSearchLatencyPerVector)
 	registry.MustRegister(QueryNodeWatchDmlChannelLatency)
 	registry.MustRegister(QueryNodeDiskUsedSize)
+	registry.MustRegister(QueryNodeDiskCacheUsedSize)
+	registry.MustRegister(QueryNodeDiskCacheEvictCount)
+	registry.MustRegister(QueryNodeMemoryGovernorUsedSize)
+	registry.MustRegister(QueryNodeMemoryGovernorRejectCount)
+	registry.MustRegister(QueryNodeMemoryWatermarkLevel)
+	registry.MustRegister(QueryNodeMemoryWatermarkActionCount)
+	registry.MustRegister(QueryNodeGPUMemoryUsedSize)
+	registry.MustRegister(QueryNodeGPUIndexMoveCount)
+	registry.MustRegister(QueryNodeFieldStatsPrunedSegments)
+	registry.MustRegister(QueryNodeEstimatedFilterSelectivity)
+	registry.MustRegister(QueryNodePreFilterHookLatency)
+	registry.MustRegister(QueryNodePreFilterHookPrunedSegments)
+	registry.MustRegister(QueryNodeTwoPhaseSearchLatency)
+	registry.MustRegister(QueryNodeTwoPhaseSearchCandidateRatio)
+	registry.MustRegister(QueryNodePlanCacheCounter)
+	registry.MustRegister(QueryNodeIndexPeerFetchTotal)
+	registry.MustRegister(QueryNodeIndexPeerFetchBytes)
 	registry.MustRegister(QueryNodeProcessCost)
 	registry.MustRegister(QueryNodeWaitProcessingMsgCount)
+
+	governor.register(collectionIDLabelName,
+		QueryNodeConsumeTimeTickLag,
+		QueryNodeConsumerMsgCount,
+		QueryNodeNumSegments,
+		QueryNodeNumEntities,
+		QueryNodeEntitiesSize,
+		QueryNodeLevelZeroSize,
+	)
 }
 
 func CleanupQueryNodeCollectionMetrics(nodeID int64, collectionID int64) {
@@ -185,6 +185,24 @@ var (
 			Name:      "ddl_req_latency_in_queue",
 			Help:      "latency of each DDL operations in queue",
 		}, []string{functionLabelName})
+
+	// RootCoordStandbySyncLag records how long the meta of the hot standby lags behind etcd.
+	RootCoordStandbySyncLag = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.RootCoordRole,
+			Name:      "standby_sync_lag_ms",
+			Help:      "milliseconds since the oldest meta change not applied to the hot standby, 0 means synced",
+		})
+
+	// RootCoordProducerTimeTickSkew records how far the timeticks of the proxies lag behind the global watermark.
+	RootCoordProducerTimeTickSkew = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.RootCoordRole,
+			Name:      "producer_timetick_skew_ms",
+			Help:      "the latest timetick reported by all the proxies minus the earliest one of the proxy",
+		}, []string{nodeIDLabelName})
 )
 
 // RegisterRootCoord registers RootCoord metrics
@@ -194,6 +212,7 @@ func RegisterRootCoord(registry *prometheus.Registry) {
 	// for time tick
 	registry.MustRegister(RootCoordInsertChannelTimeTick)
 	registry.MustRegister(RootCoordSyncTimeTickLatency)
+	registry.MustRegister(RootCoordProducerTimeTickSkew)
 
 	// for DDL
 	registry.MustRegister(RootCoordDDLReqCounter)
@@ -219,4 +238,7 @@ func RegisterRootCoord(registry *prometheus.Registry) {
 	registry.MustRegister(RootCoordQuotaStates)
 	registry.MustRegister(RootCoordRateLimitRatio)
 	registry.MustRegister(RootCoordDDLReqLatencyInQueue)
+	registry.MustRegister(RootCoordStandbySyncLag)
+
+	governor.register(collectionIDLabelName, RootCoordRateLimitRatio)
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/util/retry"
 	"github.com/milvus-io/milvus/pkg/util/tsoutil"
 	"github.com/milvus-io/milvus/pkg/util/typeutil"
@@ -166,14 +167,12 @@ func (c *dispatcherManager) Run() {
 			c.uploadMetric()
 		case <-ticker2.C:
 			c.tryMerge()
+			// retry the targets failed to split
+			if c.lagTargets.Len() > 0 {
+				c.splitLagTargets()
+			}
 		case <-c.lagNotifyChan:
-			c.mu.Lock()
-			c.lagTargets.Range(func(vchannel string, t *target) bool {
-				c.split(t)
-				c.lagTargets.GetAndRemove(vchannel)
-				return true
-			})
-			c.mu.Unlock()
+			c.splitLagTargets()
 		}
 	}
 }
@@ -219,17 +218,30 @@ func (c *dispatcherManager) tryMerge() {
 	log.Info("merge done", zap.Any("vchannel", candidates))
 }
 
-func (c *dispatcherManager) split(t *target) {
+func (c *dispatcherManager) splitLagTargets() {
+	c.lagTargets.Range(func(vchannel string, t *target) bool {
+		// the target is kept to retry later if failed, the message queue may be unavailable temporarily
+		c.split(t)
+		return true
+	})
+}
+
+// split moves the lagged target to a solo dispatcher,
+// the dispatcher is created without holding the lock, so that Add/Remove of other vchannels
+// are not blocked by the retries when the message queue is unavailable.
+func (c *dispatcherManager) split(t *target) error {
 	log := log.With(zap.String("role", c.role),
 		zap.Int64("nodeID", c.nodeID), zap.String("vchannel", t.vchannel))
 	log.Info("start splitting...")
 
-	// remove stale soloDispatcher if it existed
+	// remove stale soloDispatcher if it existed, it shares the subscription name with the new one
+	c.mu.Lock()
 	if _, ok := c.soloDispatchers[t.vchannel]; ok {
 		c.soloDispatchers[t.vchannel].Handle(terminate)
 		delete(c.soloDispatchers, t.vchannel)
 		c.deleteMetric(t.vchannel)
 	}
+	c.mu.Unlock()
 
 	var newSolo *Dispatcher
 	err := retry.Do(context.Background(), func() error {
@@ -239,13 +251,30 @@ func (c *dispatcherManager) split(t *target) {
 		return err
 	}, retry.Attempts(10))
 	if err != nil {
+		if paramtable.Get().CommonCfg.DegradedModeEnable.GetAsBool() {
+			log.Warn("split failed, retry later", zap.Error(err))
+			return err
+		}
 		log.Error("split failed", zap.Error(err))
 		panic(err)
 	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// the vchannel is removed or re-added during creating the dispatcher, drop the new one
+	if cur, ok := c.lagTargets.Get(t.vchannel); !ok || cur != t {
+		// the dispatcher must be started before terminated
+		newSolo.Handle(start)
+		newSolo.Handle(terminate)
+		log.Info("target removed while splitting, drop the new dispatcher")
+		return nil
+	}
 	newSolo.AddTarget(t)
 	c.soloDispatchers[t.vchannel] = newSolo
 	newSolo.Handle(start)
+	c.lagTargets.GetAndRemove(t.vchannel)
 	log.Info("split done")
+	return nil
 }
 
 // deleteMetric remove specific prometheus metric,
@@ -80,8 +80,14 @@ func TestManager(t *testing.T) {
 			pos:      nil,
 			ch:       nil,
 		}
-		c.(*dispatcherManager).split(info)
+		// the target removed before split is dropped
+		assert.NoError(t, c.(*dispatcherManager).split(info))
+		assert.Equal(t, 1, c.Num())
+
+		c.(*dispatcherManager).lagTargets.Insert(info.vchannel, info)
+		assert.NoError(t, c.(*dispatcherManager).split(info))
 		assert.Equal(t, 2, c.Num())
+		assert.Equal(t, 0, c.(*dispatcherManager).lagTargets.Len())
 	})
 
 	t.Run("test run and close", func(t *testing.T) {
@@ -6,9 +6,14 @@ import (
 	"github.com/cockroachdb/errors"
 
 	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/pkg/util/merr"
 )
 
-var _ Factory = &CommonFactory{}
+var (
+	_ Factory       = &CommonFactory{}
+	_ TopicDeleter  = &CommonFactory{}
+	_ ClientCreator = &CommonFactory{}
+)
 
 // CommonFactory is a Factory for creating message streams with common logic.
 //
@@ -57,6 +62,32 @@ func (f *CommonFactory) NewMsgStreamDisposer(ctx context.Context) func([]string,
 	}
 }
 
+// DeleteTopics deletes the topics if the client of message queue supports it.
+func (f *CommonFactory) DeleteTopics(ctx context.Context, topics []string) (err error) {
+	defer wrapError(&err, "DeleteTopics")
+	cli, err := f.Newer(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	deleter, ok := cli.(mqwrapper.TopicDeleter)
+	if !ok {
+		return merr.WrapErrServiceUnimplemented(errors.New("the message queue doesn't support deleting topics"))
+	}
+	for _, topic := range topics {
+		if err := deleter.DeleteTopic(topic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewClient creates a raw client of the message queue.
+func (f *CommonFactory) NewClient(ctx context.Context) (cli mqwrapper.Client, err error) {
+	defer wrapError(&err, "NewClient")
+	return f.Newer(ctx)
+}
+
 func wrapError(err *error, method string) {
 	if *err != nil {
 		*err = errors.Wrapf(*err, "in method: %s", method)
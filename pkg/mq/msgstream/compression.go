@@ -0,0 +1,91 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgstream
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
+	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/pkg/util/compressor"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+// payloadCompressionKey is the message property key of the codec compressing the payload,
+// the payload without it is not compressed.
+const payloadCompressionKey = "payload_compression"
+
+// compressPayload compresses the payload by the configured codec,
+// and records the codec in the message properties if compressed.
+func compressPayload(payload []byte, properties map[string]string) ([]byte, error) {
+	params := &paramtable.Get().MQCfg
+	compressType, err := compressor.ParseCompressType(params.CompressionType.GetValue())
+	if err != nil {
+		log.RatedWarn(60, "invalid msgstream compression type, skip compressing", zap.Error(err))
+		return payload, nil
+	}
+	if compressType == compressor.CompressTypeNone || len(payload) < params.CompressionMinSize.GetAsInt() {
+		return payload, nil
+	}
+
+	var compressed []byte
+	switch compressType {
+	case compressor.CompressTypeZstd:
+		compressed = compressor.ZstdCompressBytes(payload, nil)
+	case compressor.CompressTypeLz4:
+		compressed, err = compressor.Lz4CompressBytes(payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	codec := string(compressType)
+	metrics.MsgStreamPayloadBytes.WithLabelValues(codec, metrics.RawPayloadLabel).Add(float64(len(payload)))
+	// not worth compressing, send the raw payload
+	if len(compressed) >= len(payload) {
+		metrics.MsgStreamPayloadBytes.WithLabelValues(codec, metrics.CompressedPayloadLabel).Add(float64(len(payload)))
+		metrics.MsgStreamCompressionRatio.WithLabelValues(codec).Observe(1)
+		return payload, nil
+	}
+	metrics.MsgStreamPayloadBytes.WithLabelValues(codec, metrics.CompressedPayloadLabel).Add(float64(len(compressed)))
+	metrics.MsgStreamCompressionRatio.WithLabelValues(codec).Observe(float64(len(payload)) / float64(len(compressed)))
+
+	properties[payloadCompressionKey] = codec
+	return compressed, nil
+}
+
+// decompressPayload decompresses the payload of the message by the codec in its properties.
+func decompressPayload(msg mqwrapper.Message) ([]byte, error) {
+	codec, ok := msg.Properties()[payloadCompressionKey]
+	if !ok {
+		return msg.Payload(), nil
+	}
+
+	switch compressor.CompressType(codec) {
+	case compressor.CompressTypeZstd:
+		return compressor.ZstdDecompressBytes(msg.Payload(), nil)
+	case compressor.CompressTypeLz4:
+		return compressor.Lz4DecompressBytes(msg.Payload())
+	case compressor.CompressTypeNone:
+		return msg.Payload(), nil
+	default:
+		return nil, fmt.Errorf("unsupported payload compression: %s", codec)
+	}
+}
@@ -0,0 +1,88 @@
+package msgstream
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
+)
+
+type compressedMessage struct {
+	mqwrapper.Message
+	payload    []byte
+	properties map[string]string
+}
+
+func (m *compressedMessage) Payload() []byte {
+	return m.payload
+}
+
+func (m *compressedMessage) Properties() map[string]string {
+	return m.properties
+}
+
+func TestPayloadCompression(t *testing.T) {
+	params := paramtable.Get()
+	defer params.Reset(params.MQCfg.CompressionType.Key)
+	defer params.Reset(params.MQCfg.CompressionMinSize.Key)
+
+	payload := bytes.Repeat([]byte("milvus"), 1024)
+
+	for _, codec := range []string{"zstd", "lz4"} {
+		t.Run(codec, func(t *testing.T) {
+			params.Save(params.MQCfg.CompressionType.Key, codec)
+			properties := map[string]string{}
+			compressed, err := compressPayload(payload, properties)
+			assert.NoError(t, err)
+			assert.Less(t, len(compressed), len(payload))
+			assert.Equal(t, codec, properties[payloadCompressionKey])
+
+			decompressed, err := decompressPayload(&compressedMessage{payload: compressed, properties: properties})
+			assert.NoError(t, err)
+			assert.Equal(t, payload, decompressed)
+		})
+	}
+
+	t.Run("none", func(t *testing.T) {
+		params.Save(params.MQCfg.CompressionType.Key, "none")
+		properties := map[string]string{}
+		compressed, err := compressPayload(payload, properties)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, compressed)
+		assert.NotContains(t, properties, payloadCompressionKey)
+
+		decompressed, err := decompressPayload(&compressedMessage{payload: compressed, properties: properties})
+		assert.NoError(t, err)
+		assert.Equal(t, payload, decompressed)
+	})
+
+	t.Run("small_payload", func(t *testing.T) {
+		params.Save(params.MQCfg.CompressionType.Key, "zstd")
+		params.Save(params.MQCfg.CompressionMinSize.Key, "1048576")
+		defer params.Reset(params.MQCfg.CompressionMinSize.Key)
+		properties := map[string]string{}
+		compressed, err := compressPayload(payload, properties)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, compressed)
+		assert.NotContains(t, properties, payloadCompressionKey)
+	})
+
+	t.Run("invalid_type", func(t *testing.T) {
+		params.Save(params.MQCfg.CompressionType.Key, "snappy")
+		properties := map[string]string{}
+		compressed, err := compressPayload(payload, properties)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, compressed)
+	})
+
+	t.Run("unsupported_codec", func(t *testing.T) {
+		_, err := decompressPayload(&compressedMessage{
+			payload:    payload,
+			properties: map[string]string{payloadCompressionKey: "snappy"},
+		})
+		assert.Error(t, err)
+	})
+}
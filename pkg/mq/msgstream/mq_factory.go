@@ -18,6 +18,7 @@ package msgstream
 
 import (
 	"context"
+	"net/http"
 	"strings"
 	"time"
 
@@ -100,7 +101,12 @@ func (f *PmsFactory) NewMsgStream(ctx context.Context) (MsgStream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMqMsgStream(ctx, f.ReceiveBufSize, f.MQBufSize, pulsarClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	stream, err := NewMqMsgStream(ctx, f.ReceiveBufSize, f.MQBufSize, pulsarClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.compression = true
+	return stream, nil
 }
 
 // NewTtMsgStream is used to generate a new TtMsgstream object
@@ -127,7 +133,12 @@ func (f *PmsFactory) NewTtMsgStream(ctx context.Context) (MsgStream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMqTtMsgStream(ctx, f.ReceiveBufSize, f.MQBufSize, pulsarClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	stream, err := NewMqTtMsgStream(ctx, f.ReceiveBufSize, f.MQBufSize, pulsarClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.compression = true
+	return stream, nil
 }
 
 func (f *PmsFactory) getAuthentication() (pulsar.Authentication, error) {
@@ -175,6 +186,55 @@ func (f *PmsFactory) NewMsgStreamDisposer(ctx context.Context) func([]string, st
 	}
 }
 
+// DeleteTopics deletes the non-partitioned topics by the pulsar admin.
+func (f *PmsFactory) DeleteTopics(ctx context.Context, topics []string) error {
+	admin, err := pulsarmqwrapper.NewAdminClient(f.PulsarWebAddress, f.PulsarAuthPlugin, f.PulsarAuthParams)
+	if err != nil {
+		return err
+	}
+	for _, channel := range topics {
+		fullTopicName, err := pulsarmqwrapper.GetFullTopicName(f.PulsarTenant, f.PulsarNameSpace, channel)
+		if err != nil {
+			return err
+		}
+		topic, err := utils.GetTopicName(fullTopicName)
+		if err != nil {
+			return err
+		}
+		err = admin.Topics().Delete(*topic, true, true)
+		if err != nil {
+			pulsarErr, ok := err.(cli.Error)
+			// topic not found, ignore error
+			if ok && pulsarErr.Code == http.StatusNotFound {
+				continue
+			}
+			log.Warn("failed to delete topic", zap.String("pulsar web", f.PulsarWebAddress),
+				zap.String("topic", channel), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// NewClient creates a raw pulsar client.
+func (f *PmsFactory) NewClient(ctx context.Context) (mqwrapper.Client, error) {
+	auth, err := f.getAuthentication()
+	if err != nil {
+		return nil, err
+	}
+	clientOpts := pulsar.ClientOptions{
+		URL:               f.PulsarAddress,
+		Authentication:    auth,
+		OperationTimeout:  f.RequestTimeout,
+		MetricsRegisterer: f.metricRegisterer,
+	}
+	pulsarClient, err := pulsarmqwrapper.NewClient(f.PulsarTenant, f.PulsarNameSpace, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+	return pulsarClient, nil
+}
+
 type KmsFactory struct {
 	dispatcherFactory ProtoUDFactory
 	config            *paramtable.KafkaConfig
@@ -187,7 +247,12 @@ func (f *KmsFactory) NewMsgStream(ctx context.Context) (MsgStream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMqMsgStream(ctx, f.ReceiveBufSize, f.MQBufSize, kafkaClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	stream, err := NewMqMsgStream(ctx, f.ReceiveBufSize, f.MQBufSize, kafkaClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.compression = true
+	return stream, nil
 }
 
 func (f *KmsFactory) NewTtMsgStream(ctx context.Context) (MsgStream, error) {
@@ -195,7 +260,12 @@ func (f *KmsFactory) NewTtMsgStream(ctx context.Context) (MsgStream, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewMqTtMsgStream(ctx, f.ReceiveBufSize, f.MQBufSize, kafkaClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	stream, err := NewMqTtMsgStream(ctx, f.ReceiveBufSize, f.MQBufSize, kafkaClient, f.dispatcherFactory.NewUnmarshalDispatcher())
+	if err != nil {
+		return nil, err
+	}
+	stream.compression = true
+	return stream, nil
 }
 
 func (f *KmsFactory) NewMsgStreamDisposer(ctx context.Context) func([]string, string) error {
@@ -210,6 +280,29 @@ func (f *KmsFactory) NewMsgStreamDisposer(ctx context.Context) func([]string, st
 	}
 }
 
+// DeleteTopics deletes the topics by the kafka admin client.
+func (f *KmsFactory) DeleteTopics(ctx context.Context, topics []string) error {
+	kafkaClient, err := kafkawrapper.NewKafkaClientInstanceWithConfig(ctx, f.config)
+	if err != nil {
+		return err
+	}
+	for _, topic := range topics {
+		if err := kafkaClient.DeleteTopic(topic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewClient creates a raw kafka client.
+func (f *KmsFactory) NewClient(ctx context.Context) (mqwrapper.Client, error) {
+	kafkaClient, err := kafkawrapper.NewKafkaClientInstanceWithConfig(ctx, f.config)
+	if err != nil {
+		return nil, err
+	}
+	return kafkaClient, nil
+}
+
 func NewKmsFactory(config *paramtable.ServiceParam) Factory {
 	f := &KmsFactory{
 		dispatcherFactory: ProtoUDFactory{},
@@ -63,6 +63,9 @@ type mqMsgStream struct {
 	closed        int32
 	onceChan      sync.Once
 	enableProduce atomic.Value
+	// compression enables compressing the payloads produced,
+	// only for the mq which carries the message properties to the consumers.
+	compression bool
 }
 
 // NewMqMsgStream is used to generate a new mqMsgStream object
@@ -313,6 +316,11 @@ func (ms *mqMsgStream) Produce(msgPack *MsgPack) error {
 			}
 
 			msg := &mqwrapper.ProducerMessage{Payload: m, Properties: map[string]string{}}
+			if ms.compression {
+				if msg.Payload, err = compressPayload(m, msg.Properties); err != nil {
+					return err
+				}
+			}
 			InjectCtx(spanCtx, msg.Properties)
 
 			ms.producerLock.RLock()
@@ -356,6 +364,12 @@ func (ms *mqMsgStream) Broadcast(msgPack *MsgPack) (map[string][]MessageID, erro
 		}
 
 		msg := &mqwrapper.ProducerMessage{Payload: m, Properties: map[string]string{}}
+		if ms.compression {
+			if msg.Payload, err = compressPayload(m, msg.Properties); err != nil {
+				sp.End()
+				return ids, err
+			}
+		}
 		InjectCtx(spanCtx, msg.Properties)
 
 		ms.producerLock.Lock()
@@ -380,14 +394,18 @@ func (ms *mqMsgStream) getTsMsgFromConsumerMsg(msg mqwrapper.Message) (TsMsg, er
 	if msg.Payload() == nil {
 		return nil, fmt.Errorf("failed to unmarshal message header, payload is empty")
 	}
-	err := proto.Unmarshal(msg.Payload(), &header)
+	payload, err := decompressPayload(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress message payload, err %s", err.Error())
+	}
+	err = proto.Unmarshal(payload, &header)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal message header, err %s", err.Error())
 	}
 	if header.Base == nil {
 		return nil, fmt.Errorf("failed to unmarshal message, header is uncomplete")
 	}
-	tsMsg, err := ms.unmarshal.Unmarshal(msg.Payload(), header.Base.MsgType)
+	tsMsg, err := ms.unmarshal.Unmarshal(payload, header.Base.MsgType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal tsMsg, err %s", err.Error())
 	}
@@ -887,12 +905,16 @@ func (ms *MqTtMsgStream) Seek(ctx context.Context, msgPositions []*msgpb.MsgPosi
 				}
 				consumer.Ack(msg)
 
+				payload, err := decompressPayload(msg)
+				if err != nil {
+					return fmt.Errorf("failed to decompress message payload, err %s", err.Error())
+				}
 				headerMsg := commonpb.MsgHeader{}
-				err := proto.Unmarshal(msg.Payload(), &headerMsg)
+				err = proto.Unmarshal(payload, &headerMsg)
 				if err != nil {
 					return fmt.Errorf("failed to unmarshal message header, err %s", err.Error())
 				}
-				tsMsg, err := ms.unmarshal.Unmarshal(msg.Payload(), headerMsg.Base.MsgType)
+				tsMsg, err := ms.unmarshal.Unmarshal(payload, headerMsg.Base.MsgType)
 				if err != nil {
 					return fmt.Errorf("failed to unmarshal tsMsg, err %s", err.Error())
 				}
@@ -36,3 +36,9 @@ type Client interface {
 	// Close the client and free associated resources
 	Close()
 }
+
+// TopicDeleter is implemented by the clients which could delete topics
+type TopicDeleter interface {
+	// DeleteTopic deletes the topic and all its messages, it's ok if the topic doesn't exist
+	DeleteTopic(topic string) error
+}
@@ -27,6 +27,8 @@ var (
 
 var once sync.Once
 
+const saslMechanismOAuthBearer = "OAUTHBEARER"
+
 type kafkaClient struct {
 	// more configs you can see https://github.com/edenhill/librdkafka/blob/master/CONFIGURATION.md
 	basicConfig    kafka.ConfigMap
@@ -78,6 +80,20 @@ func NewKafkaClientInstanceWithConfig(ctx context.Context, config *paramtable.Ka
 		kafkaConfig.SetKey("security.protocol", config.SecurityProtocol.GetValue())
 		kafkaConfig.SetKey("sasl.username", config.SaslUsername.GetValue())
 		kafkaConfig.SetKey("sasl.password", config.SaslPassword.GetValue())
+	} else if config.SaslMechanisms.GetValue() == saslMechanismOAuthBearer {
+		kafkaConfig.SetKey("sasl.mechanisms", saslMechanismOAuthBearer)
+		kafkaConfig.SetKey("security.protocol", config.SecurityProtocol.GetValue())
+		// the token is fetched and refreshed by librdkafka with the client credentials grant,
+		// otherwise librdkafka uses the unsecured jws token
+		if config.SaslOauthbearerTokenEndpointURL.GetValue() != "" {
+			kafkaConfig.SetKey("sasl.oauthbearer.method", "oidc")
+			kafkaConfig.SetKey("sasl.oauthbearer.client.id", config.SaslOauthbearerClientID.GetValue())
+			kafkaConfig.SetKey("sasl.oauthbearer.client.secret", config.SaslOauthbearerClientSecret.GetValue())
+			kafkaConfig.SetKey("sasl.oauthbearer.token.endpoint.url", config.SaslOauthbearerTokenEndpointURL.GetValue())
+			if scope := config.SaslOauthbearerScope.GetValue(); scope != "" {
+				kafkaConfig.SetKey("sasl.oauthbearer.scope", scope)
+			}
+		}
 	}
 
 	specExtraConfig := func(config map[string]string) kafka.ConfigMap {
@@ -88,10 +104,15 @@ func NewKafkaClientInstanceWithConfig(ctx context.Context, config *paramtable.Ka
 		return kafkaConfigMap
 	}
 
-	return NewKafkaClientInstanceWithConfigMap(
-		kafkaConfig,
-		specExtraConfig(config.ConsumerExtraConfig.GetValue()),
-		specExtraConfig(config.ProducerExtraConfig.GetValue())), nil
+	consumerConfig := specExtraConfig(config.ConsumerExtraConfig.GetValue())
+	producerConfig := specExtraConfig(config.ProducerExtraConfig.GetValue())
+	if partitioner := config.Partitioner.GetValue(); partitioner != "" {
+		if _, ok := producerConfig["partitioner"]; !ok {
+			producerConfig.SetKey("partitioner", partitioner)
+		}
+	}
+
+	return NewKafkaClientInstanceWithConfigMap(kafkaConfig, consumerConfig, producerConfig), nil
 }
 
 func cloneKafkaConfig(config kafka.ConfigMap) *kafka.ConfigMap {
@@ -233,5 +254,29 @@ func (kc *kafkaClient) BytesToMsgID(id []byte) (mqwrapper.MessageID, error) {
 	return &kafkaID{messageID: offset}, nil
 }
 
+// DeleteTopic deletes the topic by the admin client, it's ok if the topic doesn't exist.
+func (kc *kafkaClient) DeleteTopic(topic string) error {
+	admin, err := kafka.NewAdminClient(cloneKafkaConfig(kc.basicConfig))
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout*time.Millisecond)
+	defer cancel()
+	results, err := admin.DeleteTopics(ctx, []string{topic})
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if code := result.Error.Code(); code != kafka.ErrNoError && code != kafka.ErrUnknownTopicOrPart {
+			log.Warn("failed to delete kafka topic", zap.String("topic", topic), zap.Error(result.Error))
+			return result.Error
+		}
+	}
+	log.Info("kafka topic deleted", zap.String("topic", topic))
+	return nil
+}
+
 func (kc *kafkaClient) Close() {
 }
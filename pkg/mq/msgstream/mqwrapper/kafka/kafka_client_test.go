@@ -354,8 +354,28 @@ func withProtocol(v string) kafkaCfgOption {
 	}
 }
 
+func withOauthbearer(tokenEndpointURL string) kafkaCfgOption {
+	return func(cfg *paramtable.KafkaConfig) {
+		initParamItem(&cfg.SaslMechanisms, "OAUTHBEARER")
+		initParamItem(&cfg.SaslOauthbearerClientID, "client")
+		initParamItem(&cfg.SaslOauthbearerClientSecret, "secret")
+		initParamItem(&cfg.SaslOauthbearerTokenEndpointURL, tokenEndpointURL)
+	}
+}
+
+func withPartitioner(v string) kafkaCfgOption {
+	return func(cfg *paramtable.KafkaConfig) {
+		initParamItem(&cfg.Partitioner, v)
+	}
+}
+
 func createKafkaConfig(opts ...kafkaCfgOption) *paramtable.KafkaConfig {
 	cfg := &paramtable.KafkaConfig{}
+	initParamItem(&cfg.SaslOauthbearerClientID, "")
+	initParamItem(&cfg.SaslOauthbearerClientSecret, "")
+	initParamItem(&cfg.SaslOauthbearerTokenEndpointURL, "")
+	initParamItem(&cfg.SaslOauthbearerScope, "")
+	initParamItem(&cfg.Partitioner, "")
 	for _, opt := range opts {
 		opt(cfg)
 	}
@@ -397,6 +417,36 @@ func TestKafkaClient_NewKafkaClientInstanceWithConfig(t *testing.T) {
 	assert.Equal(t, pClientID, "dc1")
 }
 
+func TestKafkaClient_NewKafkaClientInstanceWithOauthbearer(t *testing.T) {
+	config := createKafkaConfig(withAddr("addr"), withOauthbearer("https://idp/token"), withProtocol("SASL_SSL"),
+		withPartitioner("murmur2_random"))
+	client, err := NewKafkaClientInstanceWithConfig(context.Background(), config)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "OAUTHBEARER", client.basicConfig["sasl.mechanisms"])
+	assert.Equal(t, "oidc", client.basicConfig["sasl.oauthbearer.method"])
+	assert.Equal(t, "client", client.basicConfig["sasl.oauthbearer.client.id"])
+	assert.Equal(t, "https://idp/token", client.basicConfig["sasl.oauthbearer.token.endpoint.url"])
+	_, ok := client.basicConfig["sasl.oauthbearer.scope"]
+	assert.False(t, ok)
+	_, ok = client.basicConfig["sasl.username"]
+	assert.False(t, ok)
+
+	partitioner, err := client.newProducerConfig().Get("partitioner", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "murmur2_random", partitioner)
+
+	// the unsecured jws token is used without token endpoint
+	config = createKafkaConfig(withAddr("addr"), withOauthbearer(""), withProtocol("SASL_PLAINTEXT"))
+	client, err = NewKafkaClientInstanceWithConfig(context.Background(), config)
+	assert.NoError(t, err)
+	assert.Equal(t, "OAUTHBEARER", client.basicConfig["sasl.mechanisms"])
+	_, ok = client.basicConfig["sasl.oauthbearer.method"]
+	assert.False(t, ok)
+	_, ok = client.producerConfig["partitioner"]
+	assert.False(t, ok)
+}
+
 func createKafkaClient(t *testing.T) *kafkaClient {
 	kafkaAddress := getKafkaBrokerList()
 	kc := NewKafkaClientInstance(kafkaAddress)
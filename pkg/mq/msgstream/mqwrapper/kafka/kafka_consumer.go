@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/metrics"
 	"github.com/milvus-io/milvus/pkg/mq/msgstream/mqwrapper"
 	"github.com/milvus-io/milvus/pkg/util/merr"
 	"github.com/milvus-io/milvus/pkg/util/paramtable"
@@ -26,9 +27,18 @@ type Consumer struct {
 	closeOnce  sync.Once
 	closeCh    chan struct{}
 	wg         sync.WaitGroup
+
+	// lastOffset is the offset of the last message delivered to msgChannel,
+	// it's only accessed by the reading goroutine.
+	lastOffset    kafka.Offset
+	lagUpdateTime time.Time
 }
 
-const timeout = 3000
+const (
+	timeout = 3000
+
+	lagUpdateInterval = time.Second
+)
 
 func newKafkaConsumer(config *kafka.ConfigMap, bufSize int64, topic string, groupID string, position mqwrapper.SubscriptionInitialPosition) (*Consumer, error) {
 	msgChannel := make(chan mqwrapper.Message, bufSize)
@@ -38,6 +48,7 @@ func newKafkaConsumer(config *kafka.ConfigMap, bufSize int64, topic string, grou
 		topic:      topic,
 		groupID:    groupID,
 		closeCh:    make(chan struct{}),
+		lastOffset: kafka.OffsetInvalid,
 	}
 
 	err := kc.createKafkaConsumer()
@@ -136,6 +147,9 @@ func (kc *Consumer) Chan() <-chan mqwrapper.Message {
 					if err != nil {
 						// if we failed to read message in 30 Seconds, print out a warn message since there should always be a tt
 						log.Warn("consume msg failed", zap.Any("topic", kc.topic), zap.String("groupID", kc.groupID), zap.Error(err))
+						if isAssignmentLost(err) {
+							kc.reassign()
+						}
 					} else {
 						if kc.skipMsg {
 							kc.skipMsg = false
@@ -144,6 +158,8 @@ func (kc *Consumer) Chan() <-chan mqwrapper.Message {
 
 						select {
 						case kc.msgChannel <- &kafkaMessage{msg: e}:
+							kc.lastOffset = e.TopicPartition.Offset
+							kc.updateLag()
 						case <-kc.closeCh:
 						}
 					}
@@ -155,6 +171,54 @@ func (kc *Consumer) Chan() <-chan mqwrapper.Message {
 	return kc.msgChannel
 }
 
+// isAssignmentLost returns whether the partition is lost by the consumer,
+// e.g. the group is rebalanced or the partition leader is moved to another broker.
+func isAssignmentLost(err error) bool {
+	kafkaErr, ok := err.(kafka.Error)
+	if !ok {
+		return false
+	}
+	switch kafkaErr.Code() {
+	case kafka.ErrAssignmentLost, kafka.ErrNotLeaderForPartition, kafka.ErrUnknownPartition:
+		return true
+	default:
+		return false
+	}
+}
+
+// reassign assigns the partition again from the message next to the last delivered one,
+// so that the consumer resumes from where it stopped instead of replaying from the initial position.
+func (kc *Consumer) reassign() {
+	if kc.lastOffset < 0 {
+		return
+	}
+	offset := kc.lastOffset + 1
+	err := kc.c.Assign([]kafka.TopicPartition{{Topic: &kc.topic, Partition: mqwrapper.DefaultPartitionIdx, Offset: offset}})
+	if err != nil {
+		log.Warn("kafka consumer reassign failed", zap.String("topic", kc.topic), zap.String("groupID", kc.groupID),
+			zap.Any("offset", offset), zap.Error(err))
+		return
+	}
+	log.Info("kafka consumer reassigned", zap.String("topic", kc.topic), zap.String("groupID", kc.groupID), zap.Any("offset", offset))
+}
+
+// updateLag updates the lag of consumer with the cached high watermark, at most once per lagUpdateInterval.
+func (kc *Consumer) updateLag() {
+	if time.Since(kc.lagUpdateTime) < lagUpdateInterval {
+		return
+	}
+	kc.lagUpdateTime = time.Now()
+	_, high, err := kc.c.GetWatermarkOffsets(kc.topic, mqwrapper.DefaultPartitionIdx)
+	if err != nil || high < 0 {
+		return
+	}
+	lag := high - int64(kc.lastOffset) - 1
+	if lag < 0 {
+		lag = 0
+	}
+	metrics.MsgStreamConsumerLag.WithLabelValues(kc.topic, kc.groupID).Set(float64(lag))
+}
+
 func (kc *Consumer) Seek(id mqwrapper.MessageID, inclusive bool) error {
 	if kc.hasAssign {
 		return errors.New("kafka consumer is already assigned, can not seek again")
@@ -260,5 +324,6 @@ func (kc *Consumer) Close() {
 		kc.wg.Wait()
 		// close the client
 		kc.closeInternal()
+		metrics.MsgStreamConsumerLag.DeleteLabelValues(kc.topic, kc.groupID)
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"github.com/stretchr/testify/assert"
 
@@ -296,3 +297,39 @@ func TestKafkaConsumer_Close(t *testing.T) {
 		consumer.Close()
 	})
 }
+
+func TestKafkaConsumer_Reassign(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	groupID := fmt.Sprintf("test-groupid-%d", rand.Int())
+	topic := fmt.Sprintf("test-topicName-%d", rand.Int())
+
+	data1 := []int{111, 222, 333}
+	data2 := []string{"111", "222", "333"}
+	testKafkaConsumerProduceData(t, topic, data1, data2)
+
+	config := createConfig(groupID)
+	consumer, err := newKafkaConsumer(config, 16, topic, groupID, mqwrapper.SubscriptionPositionEarliest)
+	assert.NoError(t, err)
+	defer consumer.Close()
+
+	// nothing delivered, keep the assignment
+	consumer.reassign()
+
+	consumer.lastOffset = 1
+	consumer.reassign()
+	msg, err := consumer.c.ReadMessage(10 * time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, kafka.Offset(2), msg.TopicPartition.Offset)
+	assert.Equal(t, 333, BytesToInt(msg.Value))
+
+	consumer.lastOffset = msg.TopicPartition.Offset
+	consumer.updateLag()
+	assert.False(t, consumer.lagUpdateTime.IsZero())
+}
+
+func TestKafkaConsumer_IsAssignmentLost(t *testing.T) {
+	assert.True(t, isAssignmentLost(kafka.NewError(kafka.ErrAssignmentLost, "lost", false)))
+	assert.True(t, isAssignmentLost(kafka.NewError(kafka.ErrNotLeaderForPartition, "not leader", false)))
+	assert.False(t, isAssignmentLost(kafka.NewError(kafka.ErrTimedOut, "timeout", false)))
+	assert.False(t, isAssignmentLost(errors.New("mock error")))
+}
@@ -76,3 +76,14 @@ type Factory interface {
 	NewTtMsgStream(ctx context.Context) (MsgStream, error)
 	NewMsgStreamDisposer(ctx context.Context) func([]string, string) error
 }
+
+// TopicDeleter is implemented by the factories which could delete the physical topics.
+type TopicDeleter interface {
+	DeleteTopics(ctx context.Context, topics []string) error
+}
+
+// ClientCreator is implemented by the factories which could create the raw clients of the message queue,
+// which produce the messages not encoded as TsMsg.
+type ClientCreator interface {
+	NewClient(ctx context.Context) (mqwrapper.Client, error)
+}
@@ -1,19 +1,39 @@
 package compressor
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
 )
 
 type CompressType string
 
 const (
 	CompressTypeZstd CompressType = "zstd"
+	CompressTypeLz4  CompressType = "lz4"
+	CompressTypeNone CompressType = "none"
 
 	DefaultCompressAlgorithm CompressType = CompressTypeZstd
 )
 
+// ParseCompressType converts the user-provided codec name into a CompressType, case insensitive
+func ParseCompressType(s string) (CompressType, error) {
+	switch CompressType(strings.ToLower(strings.TrimSpace(s))) {
+	case CompressTypeZstd:
+		return CompressTypeZstd, nil
+	case CompressTypeLz4:
+		return CompressTypeLz4, nil
+	case CompressTypeNone:
+		return CompressTypeNone, nil
+	default:
+		return "", fmt.Errorf("unsupported compress type: %s", s)
+	}
+}
+
 type Compressor interface {
 	Compress(in io.Reader) error
 	CompressBytes(src, dst []byte) []byte
@@ -193,3 +213,27 @@ func ZstdCompressBytes(src, dst []byte) []byte {
 func ZstdDecompressBytes(src, dst []byte) ([]byte, error) {
 	return globalZstdDecompressor.DecodeAll(src, dst)
 }
+
+// Use case: compress small blocks with lz4 frame format
+// This can be called concurrently
+func Lz4CompressBytes(src []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(src)))
+	w := lz4.NewWriter(buf)
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Use case: decompress small blocks with lz4 frame format
+// This can be called concurrently
+func Lz4DecompressBytes(src []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(src)*2))
+	if _, err := io.Copy(buf, lz4.NewReader(bytes.NewReader(src))); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -175,3 +175,14 @@ type ErrWriter struct {
 func (w *ErrWriter) Write(p []byte) (n int, err error) {
 	return 0, w.Err
 }
+
+func TestParseCompressType(t *testing.T) {
+	for _, name := range []string{"zstd", "ZSTD", " lz4 ", "none"} {
+		ct, err := ParseCompressType(name)
+		assert.NoError(t, err)
+		assert.Equal(t, CompressType(strings.ToLower(strings.TrimSpace(name))), ct)
+	}
+
+	_, err := ParseCompressType("snappy")
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// Group is a collection of goroutines working on subtasks of a common task.
+// It limits the number of running goroutines, cancels the context of the subtasks
+// once any of them fails, turns panics into errors,
+// and collects the results of the succeeded subtasks.
+type Group[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	results []T
+	err     error
+}
+
+// NewGroup returns a group and the context derived from ctx,
+// the context is canceled when any subtask fails or Wait returns.
+// limit: the max number of running subtasks, unlimited if it's not positive.
+func NewGroup[T any](ctx context.Context, limit int) (*Group[T], context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	g := &Group[T]{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+	return g, ctx
+}
+
+// Go executes fn in a new goroutine.
+// This will block if the group has reached its limit,
+// fn is skipped if the group is canceled before it starts.
+func (g *Group[T]) Go(fn func(ctx context.Context) (T, error)) {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			g.setErr(g.ctx.Err())
+			return
+		}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		if err := g.ctx.Err(); err != nil {
+			g.setErr(err)
+			return
+		}
+		res, err := g.run(fn)
+		if err != nil {
+			g.setErr(err)
+			return
+		}
+		g.mu.Lock()
+		g.results = append(g.results, res)
+		g.mu.Unlock()
+	}()
+}
+
+func (g *Group[T]) run(fn func(ctx context.Context) (T, error)) (res T, err error) {
+	defer func() {
+		if x := recover(); x != nil {
+			log.Warn("conc group subtask panicked", zap.Any("panic", x))
+			err = fmt.Errorf("panicked with error: %v", x)
+		}
+	}()
+	return fn(g.ctx)
+}
+
+// setErr records the first error and cancels the other subtasks.
+func (g *Group[T]) setErr(err error) {
+	g.mu.Lock()
+	if g.err == nil {
+		g.err = err
+	}
+	g.mu.Unlock()
+	g.cancel()
+}
+
+// Wait blocks until all subtasks return,
+// returns the results of the succeeded subtasks in completion order,
+// and the first error if any subtask failed.
+func (g *Group[T]) Wait() ([]T, error) {
+	g.wg.Wait()
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.results, g.err
+}
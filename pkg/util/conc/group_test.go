@@ -0,0 +1,96 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conc
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func TestGroup(t *testing.T) {
+	g, _ := NewGroup[int](context.Background(), 2)
+
+	running := atomic.NewInt32(0)
+	for i := 0; i < 10; i++ {
+		i := i
+		g.Go(func(ctx context.Context) (int, error) {
+			assert.LessOrEqual(t, running.Inc(), int32(2))
+			defer running.Dec()
+			time.Sleep(10 * time.Millisecond)
+			return i, nil
+		})
+	}
+	results, err := g.Wait()
+	assert.NoError(t, err)
+	sort.Ints(results)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, results)
+}
+
+func TestGroupError(t *testing.T) {
+	mockErr := errors.New("mock error")
+	g, ctx := NewGroup[int](context.Background(), 0)
+
+	g.Go(func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	g.Go(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	g.Go(func(ctx context.Context) (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 0, mockErr
+	})
+	results, err := g.Wait()
+	assert.ErrorIs(t, err, mockErr)
+	// the partial results are collected
+	assert.Equal(t, []int{1}, results)
+	assert.Error(t, ctx.Err())
+}
+
+func TestGroupPanic(t *testing.T) {
+	g, _ := NewGroup[any](context.Background(), 1)
+	g.Go(func(ctx context.Context) (any, error) {
+		panic("mock panic")
+	})
+	_, err := g.Wait()
+	assert.Error(t, err)
+}
+
+func TestGroupCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g, _ := NewGroup[int](ctx, 1)
+
+	executed := atomic.NewBool(false)
+	for i := 0; i < 3; i++ {
+		g.Go(func(ctx context.Context) (int, error) {
+			executed.Store(true)
+			return 0, nil
+		})
+	}
+	results, err := g.Wait()
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, results)
+	assert.False(t, executed.Load())
+}
@@ -41,6 +41,9 @@ type poolOption struct {
 
 	// preHandler function executed before actual method executed
 	preHandler func()
+
+	// max number of the tasks with the same affinity key executed in one turn of a worker
+	affinityTasksPerTurn int
 }
 
 func (opt *poolOption) antsOptions() []ants.Option {
@@ -76,6 +79,8 @@ func defaultPoolOption() *poolOption {
 		expiryDuration: 0,
 		disablePurge:   false,
 		concealPanic:   false,
+
+		affinityTasksPerTurn: 16,
 	}
 }
 
@@ -114,3 +119,13 @@ func WithPreHandler(fn func()) PoolOption {
 		opt.preHandler = fn
 	}
 }
+
+// WithAffinityTasksPerTurn sets the max number of the tasks with the same key
+// a worker executes before requeuing the rest, non-positive value is ignored.
+func WithAffinityTasksPerTurn(n int) PoolOption {
+	return func(opt *poolOption) {
+		if n > 0 {
+			opt.affinityTasksPerTurn = n
+		}
+	}
+}
@@ -45,4 +45,11 @@ func TestPoolOption(t *testing.T) {
 	o = WithConcealPanic(true)
 	o(opt)
 	assert.True(t, opt.concealPanic)
+
+	o = WithAffinityTasksPerTurn(4)
+	o(opt)
+	assert.Equal(t, 4, opt.affinityTasksPerTurn)
+	o = WithAffinityTasksPerTurn(0)
+	o(opt)
+	assert.Equal(t, 4, opt.affinityTasksPerTurn)
 }
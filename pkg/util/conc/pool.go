@@ -22,7 +22,9 @@ import (
 	"sync"
 
 	ants "github.com/panjf2000/ants/v2"
+	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/generic"
 	"github.com/milvus-io/milvus/pkg/util/hardware"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -32,6 +34,17 @@ import (
 type Pool[T any] struct {
 	inner *ants.Pool
 	opt   *poolOption
+
+	// affinity tasks pending behind the running one of the same key,
+	// a key is present as long as one of its tasks is running.
+	affinityMu    sync.Mutex
+	affinityTasks map[any][]*affinityTask
+}
+
+// affinityTask is a task submitted with affinity key.
+type affinityTask struct {
+	run  func()
+	fail func(error)
 }
 
 // NewPool returns a goroutine pool.
@@ -49,8 +62,9 @@ func NewPool[T any](cap int, opts ...PoolOption) *Pool[T] {
 	}
 
 	return &Pool[T]{
-		inner: pool,
-		opt:   opt,
+		inner:         pool,
+		opt:           opt,
+		affinityTasks: make(map[any][]*affinityTask),
 	}
 }
 
@@ -66,7 +80,102 @@ func NewDefaultPool[T any]() *Pool[T] {
 // NOTE: As now golang doesn't support the member method being generic, we use Future[any]
 func (pool *Pool[T]) Submit(method func() (T, error)) *Future[T] {
 	future := newFuture[T]()
+	err := pool.inner.Submit(pool.wrap(future, method))
+	if err != nil {
+		future.err = err
+		close(future.ch)
+	}
+
+	return future
+}
+
+// SubmitWithKey submits a task with affinity key into the pool,
+// the tasks with the same key execute serially in submission order,
+// while the tasks with different keys execute in parallel.
+// The key must be comparable.
+// This will block if the pool has finite workers and no idle worker,
+// unless the task is queued behind the running task of the same key.
+// The panic of the task is recovered and returned as the error of future.
+func (pool *Pool[T]) SubmitWithKey(key any, method func() (T, error)) *Future[T] {
+	future := newFuture[T]()
+	task := &affinityTask{
+		run: pool.wrap(future, method),
+		fail: func(err error) {
+			future.err = err
+			close(future.ch)
+		},
+	}
+
+	pool.affinityMu.Lock()
+	if pending, ok := pool.affinityTasks[key]; ok {
+		pool.affinityTasks[key] = append(pending, task)
+		pool.affinityMu.Unlock()
+		return future
+	}
+	pool.affinityTasks[key] = nil
+	pool.affinityMu.Unlock()
+
+	pool.submitAffinity(key, task)
+	return future
+}
+
+// submitAffinity runs the task and then the pending tasks of the same key one by one in a worker.
+// The worker runs at most affinityTasksPerTurn tasks of the key in one turn,
+// then the rest are requeued, so a hot key doesn't occupy a worker forever.
+func (pool *Pool[T]) submitAffinity(key any, task *affinityTask) {
 	err := pool.inner.Submit(func() {
+		next := task
+		for i := 0; i < pool.opt.affinityTasksPerTurn && next != nil; i++ {
+			pool.runAffinity(key, next)
+			next = pool.nextAffinityTask(key)
+		}
+		if next != nil {
+			// submit asynchronously, the pool may be full until this worker returns
+			go pool.submitAffinity(key, next)
+		}
+	})
+	if err != nil {
+		// the pending tasks cannot be executed either
+		pool.affinityMu.Lock()
+		pending := pool.affinityTasks[key]
+		delete(pool.affinityTasks, key)
+		pool.affinityMu.Unlock()
+
+		task.fail(err)
+		for _, t := range pending {
+			t.fail(err)
+		}
+	}
+}
+
+// runAffinity runs the affinity task,
+// the panic is recovered after the future is resolved with error, so the pending tasks of the key keep going.
+func (pool *Pool[T]) runAffinity(key any, task *affinityTask) {
+	defer func() {
+		if x := recover(); x != nil {
+			log.Warn("Conc pool affinity task panicked", zap.Any("key", key), zap.Any("panic", x))
+		}
+	}()
+	task.run()
+}
+
+// nextAffinityTask pops the next pending task of the key,
+// returns nil and releases the key if there is no pending task.
+func (pool *Pool[T]) nextAffinityTask(key any) *affinityTask {
+	pool.affinityMu.Lock()
+	defer pool.affinityMu.Unlock()
+	pending := pool.affinityTasks[key]
+	if len(pending) == 0 {
+		delete(pool.affinityTasks, key)
+		return nil
+	}
+	pool.affinityTasks[key] = pending[1:]
+	return pending[0]
+}
+
+// wrap returns the function executing the method and setting the result into future.
+func (pool *Pool[T]) wrap(future *Future[T], method func() (T, error)) func() {
+	return func() {
 		defer close(future.ch)
 		defer func() {
 			if x := recover(); x != nil {
@@ -84,13 +193,7 @@ func (pool *Pool[T]) Submit(method func() (T, error)) *Future[T] {
 		} else {
 			future.value = res
 		}
-	})
-	if err != nil {
-		future.err = err
-		close(future.ch)
 	}
-
-	return future
 }
 
 // The number of workers
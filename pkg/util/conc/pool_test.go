@@ -17,6 +17,7 @@
 package conc
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -87,3 +88,126 @@ func TestPoolWithPanic(t *testing.T) {
 	_, err := future.Await()
 	assert.Error(t, err)
 }
+
+func TestPoolSubmitWithKey(t *testing.T) {
+	pool := NewPool[int](4)
+	defer pool.Release()
+
+	mu := sync.Mutex{}
+	results := make(map[int][]int)
+	futures := make([]*Future[int], 0)
+	for i := 0; i < 100; i++ {
+		key, seq := i%3, i
+		futures = append(futures, pool.SubmitWithKey(key, func() (int, error) {
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			results[key] = append(results[key], seq)
+			return seq, nil
+		}))
+	}
+	assert.NoError(t, AwaitAll(futures...))
+	for i, future := range futures {
+		assert.Equal(t, i, future.Value())
+	}
+
+	// tasks of the same key execute in submission order
+	for key, seqs := range results {
+		for i, seq := range seqs {
+			assert.Equal(t, key+i*3, seq)
+		}
+	}
+	assert.Eventually(t, func() bool {
+		pool.affinityMu.Lock()
+		defer pool.affinityMu.Unlock()
+		return len(pool.affinityTasks) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPoolSubmitWithKeyParallel(t *testing.T) {
+	pool := NewPool[any](2)
+	defer pool.Release()
+
+	ch := make(chan struct{})
+	f1 := pool.SubmitWithKey("k1", func() (any, error) {
+		<-ch
+		return nil, nil
+	})
+	f2 := pool.SubmitWithKey("k1", func() (any, error) {
+		return nil, nil
+	})
+	// tasks of other keys are not blocked
+	f3 := pool.SubmitWithKey("k2", func() (any, error) {
+		return nil, nil
+	})
+	_, err := f3.Await()
+	assert.NoError(t, err)
+	select {
+	case <-f2.Inner():
+		assert.Fail(t, "task executed before the previous task of the same key")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(ch)
+	assert.NoError(t, AwaitAll(f1, f2))
+}
+
+func TestPoolSubmitWithKeyPerTurn(t *testing.T) {
+	pool := NewPool[int](1, WithAffinityTasksPerTurn(2))
+	defer pool.Release()
+
+	ch := make(chan struct{})
+	first := pool.SubmitWithKey("k1", func() (int, error) {
+		<-ch
+		return 0, nil
+	})
+	mu := sync.Mutex{}
+	seqs := make([]int, 0)
+	futures := []*Future[int]{first}
+	for i := 1; i < 10; i++ {
+		seq := i
+		futures = append(futures, pool.SubmitWithKey("k1", func() (int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			seqs = append(seqs, seq)
+			return seq, nil
+		}))
+	}
+	close(ch)
+	assert.NoError(t, AwaitAll(futures...))
+	// the tasks requeued keep the submission order
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, seqs)
+	assert.Eventually(t, func() bool {
+		pool.affinityMu.Lock()
+		defer pool.affinityMu.Unlock()
+		return len(pool.affinityTasks) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPoolSubmitWithKeyPanic(t *testing.T) {
+	// the panic of affinity task is recovered even if it's not concealed
+	pool := NewPool[any](1)
+	defer pool.Release()
+
+	f1 := pool.SubmitWithKey(1, func() (any, error) {
+		panic("mocked panic")
+	})
+	f2 := pool.SubmitWithKey(1, func() (any, error) {
+		return 1, nil
+	})
+	_, err := f1.Await()
+	assert.Error(t, err)
+	res, err := f2.Await()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res)
+}
+
+func TestPoolSubmitWithKeyReleased(t *testing.T) {
+	pool := NewPool[any](1)
+	pool.Release()
+
+	_, err := pool.SubmitWithKey(1, func() (any, error) {
+		return nil, nil
+	}).Await()
+	assert.Error(t, err)
+	assert.Empty(t, pool.affinityTasks)
+}
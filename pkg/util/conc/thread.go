@@ -0,0 +1,91 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conc
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// lockedThread is an os thread locked by a worker of the pools.
+type lockedThread struct {
+	tid int
+	tag string
+}
+
+// lockedWorkers records the workers locking os threads, goroutine id -> *lockedThread
+var lockedWorkers sync.Map
+
+// LockOSThread returns a function wiring the calling goroutine to its current os thread,
+// and recording the thread as owned by the pool with the tag,
+// use it as the warmup function or pre handler of the pools executing cgo calls.
+// The thread is locked and recorded once per worker, the later calls of the same worker are no-op.
+// A worker exits along with its locked thread, the record is removed once the thread is gone.
+func LockOSThread(tag string) func() {
+	return func() {
+		tid := getThreadID()
+		if tid <= 0 {
+			// the worker cannot be tracked without thread id
+			runtime.LockOSThread()
+			return
+		}
+		goid := getGoroutineID()
+		if _, ok := lockedWorkers.Load(goid); ok {
+			return
+		}
+		runtime.LockOSThread()
+		pruneLockedWorkers()
+		lockedWorkers.Store(goid, &lockedThread{tid: tid, tag: tag})
+	}
+}
+
+// pruneLockedWorkers removes the records of the workers which have exited.
+func pruneLockedWorkers() {
+	lockedWorkers.Range(func(key, value any) bool {
+		if !threadAlive(value.(*lockedThread).tid) {
+			lockedWorkers.Delete(key)
+		}
+		return true
+	})
+}
+
+// GetLockedThreads returns the os threads locked by the workers of pools, thread id -> pool tag.
+// NOTE: the thread id is unknown on the platforms other than linux, so nothing is returned.
+func GetLockedThreads() map[int]string {
+	pruneLockedWorkers()
+	result := make(map[int]string)
+	lockedWorkers.Range(func(key, value any) bool {
+		thread := value.(*lockedThread)
+		result[thread.tid] = thread.tag
+		return true
+	})
+	return result
+}
+
+// getGoroutineID returns the id of the calling goroutine, parsed from the stack header "goroutine <id> [...".
+func getGoroutineID() int64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	fields := bytes.Fields(buf[:n])
+	if len(fields) < 2 {
+		return 0
+	}
+	id, _ := strconv.ParseInt(string(fields[1]), 10, 64)
+	return id
+}
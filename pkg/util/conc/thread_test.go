@@ -0,0 +1,69 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conc
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockOSThread(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("thread id is only available on linux")
+	}
+	lock := LockOSThread("mock")
+	locked := make(chan int)
+	exit := make(chan struct{})
+	go func() {
+		// the worker is recorded once however many tasks it runs
+		lock()
+		lock()
+		locked <- getThreadID()
+		<-exit
+	}()
+	tid := <-locked
+	threads := GetLockedThreads()
+	assert.Equal(t, "mock", threads[tid])
+	count := 0
+	lockedWorkers.Range(func(key, value any) bool {
+		if value.(*lockedThread).tag == "mock" {
+			count++
+		}
+		return true
+	})
+	assert.Equal(t, 1, count)
+
+	// the locked thread exits with the worker, so the record is removed
+	close(exit)
+	assert.Eventually(t, func() bool {
+		_, ok := GetLockedThreads()[tid]
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestGetGoroutineID(t *testing.T) {
+	id := getGoroutineID()
+	assert.Greater(t, id, int64(0))
+	ch := make(chan int64)
+	go func() {
+		ch <- getGoroutineID()
+	}()
+	assert.NotEqual(t, id, <-ch)
+}
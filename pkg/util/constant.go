@@ -40,6 +40,9 @@ const (
 
 	SegmentIndexPrefix = "segment-index"
 	FieldIndexPrefix   = "field-index"
+	// IndexReadyPrefix is the etcd path which datacoord notifies querycoord of the segments whose index is built,
+	// the keys are index-ready/{collectionID}/{segmentID}
+	IndexReadyPrefix = "index-ready"
 
 	HeaderAuthorize = "authorization"
 	// HeaderSourceID identify requests from Milvus members and client requests
@@ -61,6 +64,9 @@ const (
 
 	IdentifierKey = "identifier"
 	HeaderDBName  = "dbName"
+	HeaderTxnID   = "txnID"
+	// HeaderPartialMutation asks the insert/upsert to skip the rows violating the schema instead of failing the whole batch
+	HeaderPartialMutation = "partialMutation"
 )
 
 const (
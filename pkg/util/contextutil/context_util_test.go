@@ -42,3 +42,22 @@ func TestAppendToIncomingContext(t *testing.T) {
 		assert.Equal(t, "bar", md.Get("foo")[0])
 	})
 }
+
+func TestQueryLimits(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, GetQueryLimits(ctx).IsZero())
+	assert.Equal(t, ctx, WithQueryLimits(ctx, QueryLimits{}))
+
+	limits := QueryLimits{MaxScannedRows: 100, MaxResultBytes: 1024}
+	limited := WithQueryLimits(ctx, limits)
+	assert.Equal(t, limits, GetQueryLimits(limited))
+
+	// the limits are carried to the query nodes by the metadata
+	md, ok := metadata.FromOutgoingContext(limited)
+	assert.True(t, ok)
+	incoming := metadata.NewIncomingContext(ctx, md)
+	assert.Equal(t, limits, GetQueryLimits(incoming))
+
+	incoming = AppendToIncomingContext(ctx, queryMaxScannedRowsKey, "x")
+	assert.True(t, GetQueryLimits(incoming).IsZero())
+}
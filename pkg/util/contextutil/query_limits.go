@@ -0,0 +1,83 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contextutil
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	queryMaxScannedRowsKey = "query-max-scanned-rows"
+	queryMaxResultBytesKey = "query-max-result-bytes"
+)
+
+// QueryLimits are the resource limits of a search or query request, zero means unlimited.
+type QueryLimits struct {
+	// MaxScannedRows is the max number of rows of the segments scanned
+	MaxScannedRows int64
+	// MaxResultBytes is the max size of the results
+	MaxResultBytes int64
+}
+
+// IsZero returns whether there's no limit.
+func (l QueryLimits) IsZero() bool {
+	return l.MaxScannedRows <= 0 && l.MaxResultBytes <= 0
+}
+
+type ctxQueryLimitsKey struct{}
+
+// WithQueryLimits creates a new context that has the query limits injected,
+// they're carried by the outgoing metadata as well, so the rpcs to query nodes are limited too.
+func WithQueryLimits(ctx context.Context, limits QueryLimits) context.Context {
+	if limits.IsZero() {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, ctxQueryLimitsKey{}, limits)
+	return metadata.AppendToOutgoingContext(ctx,
+		queryMaxScannedRowsKey, strconv.FormatInt(limits.MaxScannedRows, 10),
+		queryMaxResultBytesKey, strconv.FormatInt(limits.MaxResultBytes, 10))
+}
+
+// GetQueryLimits tries to retrieve the query limits from the given context or the incoming metadata.
+// If they don't exist, zero limits are returned.
+func GetQueryLimits(ctx context.Context) QueryLimits {
+	if limits, ok := ctx.Value(ctxQueryLimitsKey{}).(QueryLimits); ok {
+		return limits
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return QueryLimits{}
+	}
+	parse := func(key string) int64 {
+		values := md.Get(key)
+		if len(values) == 0 {
+			return 0
+		}
+		value, err := strconv.ParseInt(values[0], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return value
+	}
+	return QueryLimits{
+		MaxScannedRows: parse(queryMaxScannedRowsKey),
+		MaxResultBytes: parse(queryMaxResultBytesKey),
+	}
+}
@@ -30,3 +30,8 @@ func getContainerMemLimit() (uint64, error) {
 func getContainerMemUsed() (uint64, error) {
 	return 0, errors.New("Not supported")
 }
+
+// getContainerCPUQuota returns the cpu cores limited by the quota of container
+func getContainerCPUQuota() (float64, error) {
+	return 0, errors.New("Not supported")
+}
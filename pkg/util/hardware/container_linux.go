@@ -12,12 +12,16 @@
 package hardware
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/containerd/cgroups"
 )
 
+const cgroupMountPoint = "/sys/fs/cgroup"
+
 // inContainer checks if the service is running inside a container.
 func inContainer() (bool, error) {
 	paths, err := cgroups.ParseCgroupFile("/proc/1/cgroup")
@@ -60,3 +64,23 @@ func getContainerMemUsed() (uint64, error) {
 	// ref: <https://github.com/docker/cli/blob/e57b5f78de635e6e2b688686d10b830c4747c4dc/cli/command/container/stats_helpers.go#L239>
 	return stats.Memory.Usage.Usage - stats.Memory.TotalActiveFile - stats.Memory.TotalInactiveFile, nil
 }
+
+// getContainerCPUQuota returns the cpu cores limited by the cfs quota of container, 0 means unlimited.
+// Both cgroup v2 and v1 are supported.
+func getContainerCPUQuota() (float64, error) {
+	if content, err := os.ReadFile(filepath.Join(cgroupMountPoint, "cpu.max")); err == nil {
+		return parseCgroupV2CPUMax(string(content))
+	}
+	for _, dir := range []string{"cpu", "cpu,cpuacct"} {
+		quota, err := os.ReadFile(filepath.Join(cgroupMountPoint, dir, "cpu.cfs_quota_us"))
+		if err != nil {
+			continue
+		}
+		period, err := os.ReadFile(filepath.Join(cgroupMountPoint, dir, "cpu.cfs_period_us"))
+		if err != nil {
+			return 0, err
+		}
+		return parseCgroupV1CPUQuota(string(quota), string(period))
+	}
+	return 0, errors.New("cannot find cpu quota from cGroups")
+}
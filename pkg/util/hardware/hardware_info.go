@@ -14,9 +14,14 @@ package hardware
 import (
 	"flag"
 	syslog "log"
+	"math"
+	"os"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 
+	"github.com/cockroachdb/errors"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/mem"
 	"go.uber.org/automaxprocs/maxprocs"
@@ -30,6 +35,8 @@ var (
 	icOnce sync.Once
 	ic     bool
 	icErr  error
+
+	cpuNumMu sync.Mutex
 )
 
 // Initialize maxprocs
@@ -43,7 +50,7 @@ func InitMaxprocs(serverType string, flags *flag.FlagSet) {
 	}
 }
 
-// GetCPUNum returns the count of cpu core.
+// GetCPUNum returns the count of cpu core, limited by the cpu quota of container, see RefreshCPUNum.
 func GetCPUNum() int {
 	//nolint
 	cur := runtime.GOMAXPROCS(0)
@@ -54,6 +61,72 @@ func GetCPUNum() int {
 	return cur
 }
 
+// RefreshCPUNum updates GOMAXPROCS by the cpu quota of container, which may change at runtime,
+// e.g. the pod is scaled vertically, so that GetCPUNum follows the cpu limit rather than the cores of host.
+// The GOMAXPROCS set in environment is honored.
+// It returns the cpu num and whether it changed.
+func RefreshCPUNum() (int, bool) {
+	cpuNumMu.Lock()
+	defer cpuNumMu.Unlock()
+
+	prev := GetCPUNum()
+	if _, ok := os.LookupEnv("GOMAXPROCS"); ok {
+		return prev, false
+	}
+	quota, err := getContainerCPUQuota()
+	if err != nil {
+		return prev, false
+	}
+	//nolint
+	num := runtime.NumCPU()
+	if quota > 0 {
+		num = int(math.Min(float64(num), math.Max(1, math.Floor(quota))))
+	}
+	if num == prev {
+		return prev, false
+	}
+	//nolint
+	runtime.GOMAXPROCS(num)
+	log.Info("cpu num refreshed by the cpu quota", zap.Int("prev", prev), zap.Int("cpuNum", num), zap.Float64("quota", quota))
+	return num, true
+}
+
+// parseCgroupV2CPUMax parses the cpu.max of cgroup v2, e.g. "200000 100000", or "max 100000" if unlimited.
+func parseCgroupV2CPUMax(content string) (float64, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 2 {
+		return 0, errors.Newf("invalid cpu.max: %s", content)
+	}
+	if fields[0] == "max" {
+		return 0, nil
+	}
+	return parseCPUQuota(fields[0], fields[1])
+}
+
+// parseCgroupV1CPUQuota parses the cpu.cfs_quota_us and cpu.cfs_period_us of cgroup v1, the quota is -1 if unlimited.
+func parseCgroupV1CPUQuota(quota, period string) (float64, error) {
+	quota = strings.TrimSpace(quota)
+	if quota == "-1" {
+		return 0, nil
+	}
+	return parseCPUQuota(quota, strings.TrimSpace(period))
+}
+
+func parseCPUQuota(quota, period string) (float64, error) {
+	q, err := strconv.ParseInt(quota, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	p, err := strconv.ParseInt(period, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if q <= 0 || p <= 0 {
+		return 0, errors.Newf("invalid cpu quota %d or period %d", q, p)
+	}
+	return float64(q) / float64(p), nil
+}
+
 // GetCPUUsage returns the cpu usage in percentage.
 func GetCPUUsage() float64 {
 	percents, err := cpu.Percent(0, false)
@@ -106,6 +179,23 @@ func GetMemoryCount() uint64 {
 	return stats.Total
 }
 
+// GetContainerUsedMemoryCount returns the memory used by the container in bytes if in container,
+// which is what the container memory limit applies to, or the memory used by the process otherwise.
+func GetContainerUsedMemoryCount() uint64 {
+	icOnce.Do(func() {
+		ic, icErr = inContainer()
+	})
+	if icErr != nil || !ic {
+		return GetUsedMemoryCount()
+	}
+	used, err := getContainerMemUsed()
+	if err != nil {
+		log.Warn("failed to get container memory usage", zap.Error(err))
+		return GetUsedMemoryCount()
+	}
+	return used
+}
+
 // GetFreeMemoryCount returns the free memory in bytes.
 func GetFreeMemoryCount() uint64 {
 	return GetMemoryCount() - GetUsedMemoryCount()
@@ -25,6 +25,31 @@ func Test_GetCPUCoreCount(t *testing.T) {
 		zap.Int("physical CPUCoreCount", GetCPUNum()))
 }
 
+func Test_ParseCPUQuota(t *testing.T) {
+	quota, err := parseCgroupV2CPUMax("250000 100000\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, quota)
+	quota, err = parseCgroupV2CPUMax("max 100000\n")
+	assert.NoError(t, err)
+	assert.Zero(t, quota)
+	_, err = parseCgroupV2CPUMax("max")
+	assert.Error(t, err)
+
+	quota, err = parseCgroupV1CPUQuota("400000\n", "100000\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, quota)
+	quota, err = parseCgroupV1CPUQuota("-1\n", "100000\n")
+	assert.NoError(t, err)
+	assert.Zero(t, quota)
+	_, err = parseCgroupV1CPUQuota("400000", "0")
+	assert.Error(t, err)
+}
+
+func Test_RefreshCPUNum(t *testing.T) {
+	num, _ := RefreshCPUNum()
+	assert.Equal(t, GetCPUNum(), num)
+}
+
 func Test_GetCPUUsage(t *testing.T) {
 	log.Info("TestGetCPUUsage",
 		zap.Float64("CPUUsage", GetCPUUsage()))
@@ -42,6 +67,12 @@ func Test_GetUsedMemoryCount(t *testing.T) {
 		zap.Uint64("UsedMemoryCount", GetUsedMemoryCount()))
 }
 
+func Test_GetContainerUsedMemoryCount(t *testing.T) {
+	log.Info("TestGetContainerUsedMemoryCount",
+		zap.Uint64("GetContainerUsedMemoryCount", GetContainerUsedMemoryCount()))
+	assert.NotZero(t, GetContainerUsedMemoryCount())
+}
+
 func Test_GetDiskCount(t *testing.T) {
 	log.Info("TestGetDiskCount",
 		zap.Uint64("DiskCount", GetDiskCount()))
@@ -1,17 +1,53 @@
 package indexparamcheck
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus/pkg/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/util/metric"
+)
+
 type binFlatChecker struct {
 	binaryVectorBaseChecker
 }
 
 func (c binFlatChecker) CheckTrain(params map[string]string) error {
-	return c.binaryVectorBaseChecker.CheckTrain(params)
+	if err := c.binaryVectorBaseChecker.CheckTrain(params); err != nil {
+		return err
+	}
+
+	return c.checkMinHash(params)
 }
 
 func (c binFlatChecker) StaticCheck(params map[string]string) error {
 	return c.staticCheck(params)
 }
 
+// checkMinHash checks the params of MinHash signatures, which are made of the hash values of
+// mh_element_bit_width bits, so the dim of the binary vectors must be a multiple of it.
+func (c binFlatChecker) checkMinHash(params map[string]string) error {
+	if !strings.EqualFold(params[Metric], metric.MHJACCARD) {
+		return nil
+	}
+
+	bitWidth := DefaultMinHashElementBitWidth
+	if value, ok := params[MinHashElementBitWidth]; ok {
+		var err error
+		bitWidth, err = strconv.Atoi(value)
+		if err != nil || !funcutil.SliceContain(supportMinHashBitWidth, bitWidth) {
+			return fmt.Errorf("%s should be one of %v, but got %s", MinHashElementBitWidth, supportMinHashBitWidth, value)
+		}
+	}
+
+	dim, _ := strconv.Atoi(params[DIM])
+	if dim%bitWidth != 0 {
+		return fmt.Errorf("dim %d of MinHash signatures should be a multiple of %s %d", dim, MinHashElementBitWidth, bitWidth)
+	}
+	return nil
+}
+
 func newBinFlatChecker() IndexChecker {
 	return &binFlatChecker{}
 }
@@ -2,6 +2,7 @@ package indexparamcheck
 
 import (
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,6 +50,30 @@ func Test_binFlatChecker_CheckTrain(t *testing.T) {
 		Metric: metric.SUPERSTRUCTURE,
 	}
 
+	p8 := map[string]string{
+		DIM:    strconv.Itoa(128),
+		Metric: metric.MHJACCARD,
+	}
+	p9 := map[string]string{
+		DIM:                    strconv.Itoa(128),
+		Metric:                 metric.MHJACCARD,
+		MinHashElementBitWidth: "64",
+	}
+	p10 := map[string]string{
+		DIM:    strconv.Itoa(120),
+		Metric: metric.MHJACCARD,
+	}
+	p11 := map[string]string{
+		DIM:                    strconv.Itoa(96),
+		Metric:                 metric.MHJACCARD,
+		MinHashElementBitWidth: "64",
+	}
+	p12 := map[string]string{
+		DIM:                    strconv.Itoa(128),
+		Metric:                 metric.MHJACCARD,
+		MinHashElementBitWidth: "16",
+	}
+
 	cases := []struct {
 		params   map[string]string
 		errIsNil bool
@@ -62,6 +87,11 @@ func Test_binFlatChecker_CheckTrain(t *testing.T) {
 		{p5, true},
 		{p6, true},
 		{p7, true},
+		{p8, true},
+		{p9, true},
+		{p10, false},
+		{p11, false},
+		{p12, false},
 	}
 
 	c := newBinFlatChecker()
@@ -73,6 +103,12 @@ func Test_binFlatChecker_CheckTrain(t *testing.T) {
 			assert.Error(t, err)
 		}
 	}
+
+	// the metric type is case insensitive
+	assert.Error(t, binFlatChecker{}.checkMinHash(map[string]string{
+		DIM:    strconv.Itoa(120),
+		Metric: strings.ToLower(metric.MHJACCARD),
+	}))
 }
 
 func Test_binFlatChecker_CheckValidDataType(t *testing.T) {
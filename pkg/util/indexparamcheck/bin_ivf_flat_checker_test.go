@@ -94,6 +94,11 @@ func Test_binIVFFlatChecker_CheckTrain(t *testing.T) {
 		IVFM:   strconv.Itoa(4),
 		NBITS:  strconv.Itoa(8),
 	}
+	p8 := map[string]string{
+		DIM:    strconv.Itoa(128),
+		Metric: metric.MHJACCARD,
+		NLIST:  strconv.Itoa(100),
+	}
 
 	cases := []struct {
 		params   map[string]string
@@ -113,6 +118,7 @@ func Test_binIVFFlatChecker_CheckTrain(t *testing.T) {
 		{p5, true},
 		{p6, false},
 		{p7, false},
+		{p8, false},
 	}
 
 	c := newBinIVFFlatChecker()
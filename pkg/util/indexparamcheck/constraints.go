@@ -36,6 +36,10 @@ const (
 
 	EFConstruction = "efConstruction"
 	HNSWM          = "M"
+
+	// MinHashElementBitWidth is the bit width of the hash values of MinHash signatures
+	MinHashElementBitWidth        = "mh_element_bit_width"
+	DefaultMinHashElementBitWidth = 32
 )
 
 // METRICS is a set of all metrics types supported for float vector.
@@ -43,11 +47,12 @@ var METRICS = []string{metric.L2, metric.IP, metric.COSINE} // const
 
 // BinIDMapMetrics is a set of all metric types supported for binary vector.
 var (
-	BinIDMapMetrics           = []string{metric.HAMMING, metric.JACCARD, metric.SUBSTRUCTURE, metric.SUPERSTRUCTURE} // const
-	BinIvfMetrics             = []string{metric.HAMMING, metric.JACCARD}                                             // const
-	HnswMetrics               = []string{metric.L2, metric.IP, metric.COSINE, metric.HAMMING, metric.JACCARD}        // const
-	supportDimPerSubQuantizer = []int{32, 28, 24, 20, 16, 12, 10, 8, 6, 4, 3, 2, 1}                                  // const
-	supportSubQuantizer       = []int{96, 64, 56, 48, 40, 32, 28, 24, 20, 16, 12, 8, 4, 3, 2, 1}                     // const
+	BinIDMapMetrics           = []string{metric.HAMMING, metric.JACCARD, metric.SUBSTRUCTURE, metric.SUPERSTRUCTURE, metric.MHJACCARD} // const
+	BinIvfMetrics             = []string{metric.HAMMING, metric.JACCARD}                                                               // const
+	HnswMetrics               = []string{metric.L2, metric.IP, metric.COSINE, metric.HAMMING, metric.JACCARD}                          // const
+	supportDimPerSubQuantizer = []int{32, 28, 24, 20, 16, 12, 10, 8, 6, 4, 3, 2, 1}                                                    // const
+	supportSubQuantizer       = []int{96, 64, 56, 48, 40, 32, 28, 24, 20, 16, 12, 8, 4, 3, 2, 1}                                       // const
+	supportMinHashBitWidth    = []int{32, 64}                                                                                          // const
 )
 
 const (
@@ -18,6 +18,8 @@ type IndexType = string
 const (
 	IndexRaftIvfFlat     IndexType = "GPU_IVF_FLAT"
 	IndexRaftIvfPQ       IndexType = "GPU_IVF_PQ"
+	IndexRaftCagra       IndexType = "GPU_CAGRA"
+	IndexRaftBruteForce  IndexType = "GPU_BRUTE_FORCE"
 	IndexFaissIDMap      IndexType = "FLAT" // no index is built.
 	IndexFaissIvfFlat    IndexType = "IVF_FLAT"
 	IndexFaissIvfPQ      IndexType = "IVF_PQ"
@@ -27,4 +29,15 @@ const (
 	IndexFaissBinIvfFlat IndexType = "BIN_IVF_FLAT"
 	IndexHNSW            IndexType = "HNSW"
 	IndexDISKANN         IndexType = "DISKANN"
+	IndexJSONKeyStats    IndexType = "JSON_KEY_STATS"
 )
+
+// IsGpuIndex returns whether the index is loaded into GPU memory.
+func IsGpuIndex(indexType IndexType) bool {
+	switch indexType {
+	case IndexRaftIvfFlat, IndexRaftIvfPQ, IndexRaftCagra, IndexRaftBruteForce:
+		return true
+	default:
+		return false
+	}
+}
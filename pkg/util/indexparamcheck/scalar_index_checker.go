@@ -1,8 +1,21 @@
 package indexparamcheck
 
-import "github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+import (
+	"fmt"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+)
 
 // TODO: check index parameters according to the index type & data type.
 func CheckIndexValid(dType schemapb.DataType, indexType IndexType, indexParams map[string]string) error {
+	if indexType == IndexJSONKeyStats {
+		if dType != schemapb.DataType_JSON {
+			return fmt.Errorf("%s is only supported on json field, but got %s", IndexJSONKeyStats, dType.String())
+		}
+		if indexParams[common.JSONPathKey] == "" {
+			return fmt.Errorf("%s is required by %s", common.JSONPathKey, IndexJSONKeyStats)
+		}
+	}
 	return nil
 }
@@ -6,8 +6,13 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
 )
 
 func TestCheckIndexValid(t *testing.T) {
 	assert.NoError(t, CheckIndexValid(schemapb.DataType_Int64, "inverted_index", nil))
+
+	assert.NoError(t, CheckIndexValid(schemapb.DataType_JSON, IndexJSONKeyStats, map[string]string{common.JSONPathKey: `["a"]`}))
+	assert.Error(t, CheckIndexValid(schemapb.DataType_JSON, IndexJSONKeyStats, nil))
+	assert.Error(t, CheckIndexValid(schemapb.DataType_VarChar, IndexJSONKeyStats, map[string]string{common.JSONPathKey: `["a"]`}))
 }
@@ -42,12 +42,15 @@ var (
 	ErrServiceRateLimit            = newMilvusError("rate limit exceeded", 8, true)
 	ErrServiceForceDeny            = newMilvusError("force deny", 9, false)
 	ErrServiceUnimplemented        = newMilvusError("service unimplemented", 10, false)
+	ErrServiceReadOnly             = newMilvusError("read-only due to dependency outage", 11, true)
+	ErrServiceQueryLimitExceeded   = newMilvusError("query limit exceeded", 12, false)
 
 	// Collection related
 	ErrCollectionNotFound         = newMilvusError("collection not found", 100, false)
 	ErrCollectionNotLoaded        = newMilvusError("collection not loaded", 101, false)
 	ErrCollectionNumLimitExceeded = newMilvusError("exceeded the limit number of collections", 102, false)
 	ErrCollectionNotFullyLoaded   = newMilvusError("collection not fully loaded", 103, true)
+	ErrCollectionFrozen           = newMilvusError("collection frozen", 104, false)
 
 	// Partition related
 	ErrPartitionNotFound       = newMilvusError("partition not found", 200, false)
@@ -137,7 +140,11 @@ var (
 	ErrInvalidStreamObj     = newMilvusError("invalid stream object", 1903, false)
 
 	// Segcore related
-	ErrSegcore = newMilvusError("segcore error", 2000, false)
+	ErrSegcore                = newMilvusError("segcore error", 2000, false)
+	ErrSegcorePlanInvalid     = newMilvusError("segcore plan invalid", 2001, false)
+	ErrSegcoreFieldNotLoaded  = newMilvusError("segcore field or index not loaded", 2002, true)
+	ErrSegcoreOutOfMemory     = newMilvusError("segcore out of memory", 2003, true)
+	ErrSegcoreRequestCanceled = newMilvusError("segcore request canceled", 2004, false)
 
 	// Do NOT export this,
 	// never allow programmer using this, keep only for converting unknown error to milvusError
@@ -145,6 +152,13 @@ var (
 
 	// import
 	ErrImportFailed = newMilvusError("importing data failed", 2100, false)
+
+	// transaction related
+	ErrTxnNotFound       = newMilvusError("transaction not found", 2200, false)
+	ErrTxnConflict       = newMilvusError("transaction conflict", 2201, true)
+	ErrTxnExpired        = newMilvusError("transaction expired", 2202, false)
+	ErrTxnNotOpen        = newMilvusError("transaction not open", 2203, false)
+	ErrTxnPartialApplied = newMilvusError("transaction partially applied", 2204, false)
 )
 
 type milvusError struct {
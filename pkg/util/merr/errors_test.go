@@ -20,6 +20,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/suite"
@@ -80,11 +81,16 @@ func (s *ErrSuite) TestWrap() {
 	s.ErrorIs(WrapErrServiceDiskLimitExceeded(110, 100, "DLE"), ErrServiceDiskLimitExceeded)
 	s.ErrorIs(WrapErrNodeNotMatch(0, 1, "SIM"), ErrNodeNotMatch)
 	s.ErrorIs(WrapErrServiceUnimplemented(errors.New("mock grpc err")), ErrServiceUnimplemented)
+	s.ErrorIs(WrapErrServiceReadOnly("mq unreachable"), ErrServiceReadOnly)
+	s.ErrorIs(WrapErrServiceQueryLimitExceeded("maxScannedRows", 200, 100), ErrServiceQueryLimitExceeded)
+	s.ErrorIs(WrapErrServiceRateLimitWithWait(100, 200, time.Second), ErrServiceRateLimit)
+	s.Contains(WrapErrServiceRateLimitWithWait(100, 200, time.Second).Error(), "suggestedWait=1s")
 
 	// Collection related
 	s.ErrorIs(WrapErrCollectionNotFound("test_collection", "failed to get collection"), ErrCollectionNotFound)
 	s.ErrorIs(WrapErrCollectionNotLoaded("test_collection", "failed to query"), ErrCollectionNotLoaded)
 	s.ErrorIs(WrapErrCollectionNotFullyLoaded("test_collection", "failed to query"), ErrCollectionNotFullyLoaded)
+	s.ErrorIs(WrapErrCollectionFrozen("test_collection", "write", "failed to insert"), ErrCollectionFrozen)
 
 	// Partition related
 	s.ErrorIs(WrapErrPartitionNotFound("test_partition", "failed to get partition"), ErrPartitionNotFound)
@@ -136,8 +142,25 @@ func (s *ErrSuite) TestWrap() {
 	s.ErrorIs(WrapErrMqTopicNotEmpty("unknown", "topic is not empty"), ErrMqTopicNotEmpty)
 	s.ErrorIs(WrapErrMqInternal(errors.New("unknown"), "failed to consume"), ErrMqInternal)
 
+	// segcore related
+	s.ErrorIs(WrapErrSegcore(2001, "failed to search"), ErrSegcore)
+	s.ErrorIs(WrapErrSegcorePlanInvalid(2028, "failed to search"), ErrSegcorePlanInvalid)
+	s.ErrorIs(WrapErrSegcoreFieldNotLoaded(2027, "failed to search"), ErrSegcoreFieldNotLoaded)
+	s.ErrorIs(WrapErrSegcoreOutOfMemory(2029, "failed to search"), ErrSegcoreOutOfMemory)
+	s.ErrorIs(WrapErrSegcoreRequestCanceled(1, "failed to search"), ErrSegcoreRequestCanceled)
+	s.ErrorIs(WrapErrSegcoreScanLimitExceeded(2031, "failed to search"), ErrServiceQueryLimitExceeded)
+	s.True(IsRetryableErr(ErrSegcoreOutOfMemory))
+	s.False(IsRetryableErr(ErrSegcorePlanInvalid))
+
 	// field related
 	s.ErrorIs(WrapErrFieldNotFound("meta", "failed to get field"), ErrFieldNotFound)
+
+	// transaction related
+	s.ErrorIs(WrapErrTxnNotFound(1, "failed to commit"), ErrTxnNotFound)
+	s.ErrorIs(WrapErrTxnConflict(1, 2, "failed to commit"), ErrTxnConflict)
+	s.ErrorIs(WrapErrTxnExpired(1, "failed to commit"), ErrTxnExpired)
+	s.ErrorIs(WrapErrTxnNotOpen(1, "committed", "failed to commit"), ErrTxnNotOpen)
+	s.ErrorIs(WrapErrTxnPartialApplied(1, "failed to delete"), ErrTxnPartialApplied)
 }
 
 func (s *ErrSuite) TestOldCode() {
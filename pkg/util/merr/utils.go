@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 
@@ -156,7 +157,7 @@ func oldCode(code int32) commonpb.ErrorCode {
 	case ErrServiceRateLimit.code():
 		return commonpb.ErrorCode_RateLimit
 
-	case ErrServiceForceDeny.code():
+	case ErrServiceForceDeny.code(), ErrServiceReadOnly.code(), ErrCollectionFrozen.code():
 		return commonpb.ErrorCode_ForceDeny
 
 	case ErrIndexNotFound.code():
@@ -328,6 +329,19 @@ func WrapErrServiceRequestLimitExceeded(limit int32, msg ...string) error {
 	return err
 }
 
+// WrapErrServiceQueryLimitExceeded wraps the error of the search or query aborted by its resource limit,
+// e.g. limit "maxScannedRows".
+func WrapErrServiceQueryLimitExceeded(limit string, actual, max int64, msg ...string) error {
+	err := wrapFields(ErrServiceQueryLimitExceeded,
+		value(limit, max),
+		value("actual", actual),
+	)
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 func WrapErrServiceInternal(reason string, msg ...string) error {
 	err := wrapFieldsWithDesc(ErrServiceInternal, reason)
 	if len(msg) > 0 {
@@ -362,6 +376,12 @@ func WrapErrServiceRateLimit(rate float64) error {
 	return wrapFields(ErrServiceRateLimit, value("rate", rate))
 }
 
+// WrapErrServiceRateLimitWithWait returns the rate limit error with the current throttle state,
+// the client is suggested to retry after the wait.
+func WrapErrServiceRateLimitWithWait(rate float64, burst float64, wait time.Duration) error {
+	return wrapFields(ErrServiceRateLimit, value("rate", rate), value("burst", burst), value("suggestedWait", wait))
+}
+
 func WrapErrServiceForceDeny(op string, reason error, method string) error {
 	return wrapFieldsWithDesc(ErrServiceForceDeny,
 		reason.Error(),
@@ -370,6 +390,10 @@ func WrapErrServiceForceDeny(op string, reason error, method string) error {
 	)
 }
 
+func WrapErrServiceReadOnly(reason string) error {
+	return wrapFieldsWithDesc(ErrServiceReadOnly, reason)
+}
+
 func WrapErrServiceUnimplemented(grpcErr error) error {
 	return wrapFieldsWithDesc(ErrServiceUnimplemented, grpcErr.Error())
 }
@@ -443,6 +467,14 @@ func WrapErrCollectionNotFullyLoaded(collection any, msg ...string) error {
 	return err
 }
 
+func WrapErrCollectionFrozen(collection any, mode string, msg ...string) error {
+	err := wrapFields(ErrCollectionFrozen, value("collection", collection), value("mode", mode))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 func WrapErrAliasNotFound(db any, alias any, msg ...string) error {
 	err := wrapFields(ErrAliasNotFound,
 		value("database", db),
@@ -800,6 +832,52 @@ func WrapErrSegcore(code int32, msg ...string) error {
 	return err
 }
 
+// WrapErrSegcorePlanInvalid wraps the segcore error caused by the plan segcore can't execute
+func WrapErrSegcorePlanInvalid(code int32, msg ...string) error {
+	err := wrapFields(ErrSegcorePlanInvalid, value("segcoreCode", code))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+// WrapErrSegcoreFieldNotLoaded wraps the segcore error caused by the field data or index not loaded yet
+func WrapErrSegcoreFieldNotLoaded(code int32, msg ...string) error {
+	err := wrapFields(ErrSegcoreFieldNotLoaded, value("segcoreCode", code))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+// WrapErrSegcoreOutOfMemory wraps the segcore error caused by failing to allocate memory
+func WrapErrSegcoreOutOfMemory(code int32, msg ...string) error {
+	err := wrapFields(ErrSegcoreOutOfMemory, value("segcoreCode", code))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+// WrapErrSegcoreScanLimitExceeded wraps the segcore error caused by scanning more rows than the limit of request,
+// which is the error of the query limit exceeded for the callers
+func WrapErrSegcoreScanLimitExceeded(code int32, msg ...string) error {
+	err := wrapFields(ErrServiceQueryLimitExceeded, value("segcoreCode", code))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+// WrapErrSegcoreRequestCanceled wraps the request canceled before executed by segcore
+func WrapErrSegcoreRequestCanceled(segmentID int64, msg ...string) error {
+	err := wrapFields(ErrSegcoreRequestCanceled, value("segment", segmentID))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 // field related
 func WrapErrFieldNotFound[T any](field T, msg ...string) error {
 	err := wrapFields(ErrFieldNotFound, value("field", field))
@@ -881,3 +959,44 @@ func WrapErrImportFailed(msg ...string) error {
 	}
 	return err
 }
+
+// transaction related
+func WrapErrTxnNotFound(txnID int64, msg ...string) error {
+	err := wrapFields(ErrTxnNotFound, value("txnID", txnID))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+func WrapErrTxnConflict(txnID int64, conflictTxnID int64, msg ...string) error {
+	err := wrapFields(ErrTxnConflict, value("txnID", txnID), value("conflictTxnID", conflictTxnID))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+func WrapErrTxnExpired(txnID int64, msg ...string) error {
+	err := wrapFields(ErrTxnExpired, value("txnID", txnID))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+func WrapErrTxnNotOpen(txnID int64, state string, msg ...string) error {
+	err := wrapFields(ErrTxnNotOpen, value("txnID", txnID), value("state", state))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+func WrapErrTxnPartialApplied(txnID int64, msg ...string) error {
+	err := wrapFields(ErrTxnPartialApplied, value("txnID", txnID))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
@@ -36,4 +36,8 @@ const (
 
 	// SUPERSTRUCTURE represents superstructure distance
 	SUPERSTRUCTURE MetricType = "SUPERSTRUCTURE"
+
+	// MHJACCARD represents the jaccard distance estimated by the MinHash signatures,
+	// which is the fraction of the different hash values of the signatures
+	MHJACCARD MetricType = "MHJACCARD"
 )
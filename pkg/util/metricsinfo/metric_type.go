@@ -27,6 +27,82 @@ const (
 
 	// SystemInfoMetrics means users request for system information metrics.
 	SystemInfoMetrics = "system_info"
+
+	// ChannelLatencyMetrics means the end-to-end latency and backlog of channels
+	ChannelLatencyMetrics = "channel_latency"
+
+	// TimeTickWatermarkMetrics means the timetick watermarks of virtual channels and the skew of their producers,
+	// i.e. the proxies in rootcoord and the datanodes in datacoord
+	TimeTickWatermarkMetrics = "timetick_watermark"
+
+	// DDLEventLogMetrics means the ordered ddl events and dml watermarks of rootcoord, for point-in-time recovery
+	DDLEventLogMetrics = "ddl_event_log"
+
+	// MetricTimestampKey is the key of timestamp in GetMetrics request, the events up to it are returned
+	MetricTimestampKey = "timestamp"
+
+	// ChannelCheckpointMetrics means the checkpoints of virtual channels in datacoord
+	ChannelCheckpointMetrics = "channel_checkpoint"
+
+	// SegmentMetaMetrics means the segment meta in datacoord, filtered by collection and state
+	SegmentMetaMetrics = "segment_meta"
+
+	// SegmentFieldStatsMetrics means the statistics of scalar fields of a flushed segment, e.g. min/max, ndv and histogram
+	SegmentFieldStatsMetrics = "segment_field_stats"
+
+	// SmallSegmentMetrics means the small sealed segments of collections in datacoord, which are to be merged by compaction
+	SmallSegmentMetrics = "small_segment"
+
+	// TargetDistributionMetrics means the targets of collections versus the distributions on querynodes
+	TargetDistributionMetrics = "target_distribution"
+
+	// ReplicaAssignmentMetrics means the replicas and the querynodes assigned to them
+	ReplicaAssignmentMetrics = "replica_assignment"
+
+	// ResourceGroupMetrics means the nodes, collections and utilization of the resource groups in querycoord
+	ResourceGroupMetrics = "resource_group"
+
+	// MetricResourceGroupKey is the key of resource group name in GetMetrics request, empty means all resource groups
+	MetricResourceGroupKey = "resource_group"
+
+	// MetricCollectionIDKey is the key of collection id in GetMetrics request, 0 or absent means all collections
+	MetricCollectionIDKey = "collection_id"
+
+	// MetricSegmentStateKey is the key of segment state in GetMetrics request, empty means all states
+	MetricSegmentStateKey = "state"
+
+	// MetricSegmentIDKey is the key of segment id in GetMetrics request
+	MetricSegmentIDKey = "segment_id"
+
+	// TaskHistoryMetrics means the history of the finished tasks in the coordinators, filtered by kind and time range,
+	// i.e. the compaction tasks and the index builds in datacoord, and the import tasks in rootcoord
+	TaskHistoryMetrics = "task_history"
+
+	// PurgeTaskHistoryMetrics purges the history of the finished tasks of the kind before the time,
+	// the tasks purged are returned
+	PurgeTaskHistoryMetrics = "purge_task_history"
+
+	// MetricTaskKindKey is the key of the task kind in GetMetrics request, see TaskKindCompaction etc.
+	MetricTaskKindKey = "kind"
+
+	// MetricStartTimeKey is the key of the start of time range in GetMetrics request, unix seconds, 0 or absent means unbounded
+	MetricStartTimeKey = "start"
+
+	// MetricEndTimeKey is the key of the end of time range in GetMetrics request, unix seconds, 0 or absent means unbounded
+	MetricEndTimeKey = "end"
+
+	// VectorUpdateMetrics means the vector update jobs in datacoord, the job of job_id or all the jobs
+	VectorUpdateMetrics = "vector_update"
+
+	// SubmitVectorUpdateMetrics submits a vector update job to datacoord, see VectorUpdateRequest,
+	// the job submitted is returned
+	SubmitVectorUpdateMetrics = "submit_vector_update"
+
+	// CancelVectorUpdateMetrics cancels the pending or running vector update job of job_id
+	CancelVectorUpdateMetrics = "cancel_vector_update"
+
+	// MetricJobIDKey is the key of job id in GetMetrics request
+	MetricJobIDKey = "job_id"
 )
 
 // ParseMetricType returns the metric type of req
@@ -43,6 +119,25 @@ func ParseMetricType(req string) (string, error) {
 	return metricType.(string), nil
 }
 
+// ConstructRequestWithParams constructs a request of the metric type with the extra params
+func ConstructRequestWithParams(metricType string, params map[string]any) (*milvuspb.GetMetricsRequest, error) {
+	m := make(map[string]any, len(params)+1)
+	for k, v := range params {
+		m[k] = v
+	}
+	m[MetricTypeKey] = metricType
+	binary, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct request by metric type %s: %s", metricType, err.Error())
+	}
+	return &milvuspb.GetMetricsRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_SystemInfo),
+		),
+		Request: string(binary),
+	}, nil
+}
+
 // ConstructRequestByMetricType constructs a request according to the metric type
 func ConstructRequestByMetricType(metricType string) (*milvuspb.GetMetricsRequest, error) {
 	m := make(map[string]interface{})
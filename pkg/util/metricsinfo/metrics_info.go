@@ -184,3 +184,309 @@ type RootCoordInfos struct {
 	BaseComponentInfos
 	SystemConfigurations RootCoordConfiguration `json:"system_configurations"`
 }
+
+// ChannelLatency records the end-to-end latency and backlog of a virtual channel.
+type ChannelLatency struct {
+	Channel string `json:"channel"`
+	NodeID  int64  `json:"node_id"`
+	// ConsumedTimestamp is the latest timetick consumed by the datanode
+	ConsumedTimestamp uint64 `json:"consumed_timestamp"`
+	// LatencyMs is the latency from the timetick produced to consumed, when it's reported
+	LatencyMs int64 `json:"latency_ms"`
+	// BacklogMs is the now time minus the consumed timetick
+	BacklogMs int64 `json:"backlog_ms"`
+	// CheckpointLagMs is the now time minus the channel checkpoint, the messages to replay on recovery
+	CheckpointLagMs int64 `json:"checkpoint_lag_ms"`
+	// LagRows is the number of rows allocated to the channel but not consumed by the datanode yet
+	LagRows int64 `json:"lag_rows"`
+	// LagBytes is the estimated size of the rows not consumed yet
+	LagBytes int64 `json:"lag_bytes"`
+	Lagging  bool  `json:"lagging"`
+}
+
+// ChannelLatencyInfos is the response of ChannelLatencyMetrics.
+type ChannelLatencyInfos struct {
+	ThresholdMs int64            `json:"threshold_ms"`
+	Channels    []ChannelLatency `json:"channels"`
+}
+
+// ChannelWatermark is the timetick watermark of a virtual channel, all the messages before it are produced,
+// or consumed by the datanode.
+type ChannelWatermark struct {
+	Channel         string `json:"channel"`
+	PhysicalChannel string `json:"physical_channel,omitempty"`
+	Watermark       uint64 `json:"watermark"`
+	// LagMs is the now time minus the watermark
+	LagMs int64 `json:"lag_ms"`
+	// NodeID is the producer holding the watermark back, i.e. the slowest one
+	NodeID int64 `json:"node_id"`
+}
+
+// ProducerSkew is how far the timeticks of a proxy or datanode lag behind the global watermark.
+type ProducerSkew struct {
+	Role   string `json:"role"`
+	NodeID int64  `json:"node_id"`
+	// Timestamp is the earliest timetick of the producer over its channels
+	Timestamp uint64 `json:"timestamp"`
+	// SkewMs is the global watermark minus the timestamp
+	SkewMs int64 `json:"skew_ms"`
+	// LastReportMs is how long ago the producer reported its timeticks
+	LastReportMs int64 `json:"last_report_ms"`
+	Lagging      bool  `json:"lagging"`
+}
+
+// TimeTickWatermarkInfos is the response of TimeTickWatermarkMetrics.
+type TimeTickWatermarkInfos struct {
+	// GlobalWatermark is the latest timetick reported by all the producers
+	GlobalWatermark uint64             `json:"global_watermark"`
+	ThresholdMs     int64              `json:"threshold_ms"`
+	Channels        []ChannelWatermark `json:"channels"`
+	// Producers are sorted by skew, the most lagging first
+	Producers []ProducerSkew `json:"producers"`
+}
+
+// the kinds of tasks of TaskHistoryMetrics
+const (
+	TaskKindCompaction = "compaction"
+	TaskKindIndex      = "index"
+	TaskKindImport     = "import"
+)
+
+// TaskHistory is a task finished in the coordinators.
+type TaskHistory struct {
+	Kind         string `json:"kind"`
+	TaskID       int64  `json:"task_id"`
+	CollectionID int64  `json:"collection_id"`
+	State        string `json:"state"`
+	// StartTime and EndTime are unix seconds, EndTime is when the task finished
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// TaskHistoryInfos is the response of TaskHistoryMetrics and PurgeTaskHistoryMetrics.
+type TaskHistoryInfos struct {
+	// Retention is the seconds the tasks are retained before purged automatically, 0 means forever
+	Retention int64         `json:"retention"`
+	Tasks     []TaskHistory `json:"tasks"`
+}
+
+// the states of the vector update jobs
+const (
+	VectorUpdateJobPending   = "Pending"
+	VectorUpdateJobRunning   = "Running"
+	VectorUpdateJobCompleted = "Completed"
+	VectorUpdateJobFailed    = "Failed"
+)
+
+// VectorUpdateRequest replaces the values of a vector field by the new vectors of the primary keys
+// listed in parquet files, e.g. the embeddings of a new model, see SubmitVectorUpdateMetrics.
+type VectorUpdateRequest struct {
+	CollectionID int64 `json:"collection_id"`
+	// PartitionIDs restricts the segments rewritten, empty means all the partitions
+	PartitionIDs []int64 `json:"partition_ids,omitempty"`
+	FieldID      int64   `json:"field_id"`
+	// Profile is the bucket profile to read from, empty means the default bucket
+	Profile string `json:"profile,omitempty"`
+	// Path is the directory of the parquet files with the columns of the primary key and the new vectors,
+	// named after the fields, relative to the export prefix under the root path of bucket
+	Path  string `json:"path"`
+	Owner string `json:"owner,omitempty"`
+}
+
+// VectorUpdateJob is the progress of a vector update job.
+type VectorUpdateJob struct {
+	VectorUpdateRequest
+	JobID  int64  `json:"job_id"`
+	State  string `json:"state"`
+	Reason string `json:"reason,omitempty"`
+	// FileRows is the number of rows in the files
+	FileRows int64 `json:"file_rows"`
+	// UpdatedRows is the number of rows of the segments rewritten with the new vectors
+	UpdatedRows int64 `json:"updated_rows"`
+	// Segments maps the segments rewritten to the new segments replacing them, whose indexes are built afterwards
+	Segments map[int64]int64 `json:"segments"`
+	// StartTime and EndTime are unix seconds
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time,omitempty"`
+}
+
+// VectorUpdateJobInfos is the response of VectorUpdateMetrics, SubmitVectorUpdateMetrics and CancelVectorUpdateMetrics.
+type VectorUpdateJobInfos struct {
+	Jobs []*VectorUpdateJob `json:"jobs"`
+}
+
+// DDLEvent is a record of the ddl event log of rootcoord, or a watermark of the dml channels.
+type DDLEvent struct {
+	// Timestamp is the ts of the ddl task, the event takes effect at it
+	Timestamp      uint64 `json:"timestamp"`
+	Type           string `json:"type"`
+	DBName         string `json:"db_name,omitempty"`
+	DBID           int64  `json:"db_id,omitempty"`
+	CollectionName string `json:"collection_name,omitempty"`
+	CollectionID   int64  `json:"collection_id,omitempty"`
+	PartitionName  string `json:"partition_name,omitempty"`
+	PartitionID    int64  `json:"partition_id,omitempty"`
+	Alias          string `json:"alias,omitempty"`
+	NewDBName      string `json:"new_db_name,omitempty"`
+	NewName        string `json:"new_name,omitempty"`
+	// Schema is the marshaled schemapb.CollectionSchema of the created collection
+	Schema          []byte            `json:"schema,omitempty"`
+	Properties      map[string]string `json:"properties,omitempty"`
+	VirtualChannels []string          `json:"virtual_channels,omitempty"`
+	// Partitions are the partitions created with the collection
+	Partitions map[string]int64 `json:"partitions,omitempty"`
+	// ChannelTimestamps are the synced timeticks of dml channels, all the dml before them are produced to the channels
+	ChannelTimestamps map[string]uint64 `json:"channel_timestamps,omitempty"`
+	// Databases and Collections are the meta as of the timestamp, only set in the snapshot of the compacted events
+	Databases   []string        `json:"databases,omitempty"`
+	Collections []DDLCollection `json:"collections,omitempty"`
+}
+
+// DDLCollection is the state of a collection rebuilt from the ddl event log.
+type DDLCollection struct {
+	DBName          string            `json:"db_name"`
+	CollectionID    int64             `json:"collection_id"`
+	CollectionName  string            `json:"collection_name"`
+	Schema          []byte            `json:"schema,omitempty"`
+	Properties      map[string]string `json:"properties,omitempty"`
+	VirtualChannels []string          `json:"virtual_channels,omitempty"`
+	Partitions      map[string]int64  `json:"partitions"`
+	Aliases         []string          `json:"aliases,omitempty"`
+	// CreatedTimestamp is used to align the collection with binlog data
+	CreatedTimestamp uint64 `json:"created_timestamp"`
+}
+
+// DDLEventLogInfos is the response of DDLEventLogMetrics.
+type DDLEventLogInfos struct {
+	Timestamp uint64     `json:"timestamp"`
+	Events    []DDLEvent `json:"events"`
+	// Databases and Collections are the state rebuilt by replaying the events up to Timestamp
+	Databases   []string        `json:"databases"`
+	Collections []DDLCollection `json:"collections"`
+	// ChannelTimestamps are the latest dml watermarks up to Timestamp
+	ChannelTimestamps map[string]uint64 `json:"channel_timestamps,omitempty"`
+}
+
+// ChannelCheckpoint is the checkpoint of a virtual channel, the position to replay the dml from on recovery.
+type ChannelCheckpoint struct {
+	Channel   string `json:"channel"`
+	Timestamp uint64 `json:"timestamp"`
+	MsgID     []byte `json:"msg_id"`
+	// LagMs is the now time minus the physical time of the checkpoint
+	LagMs int64 `json:"lag_ms"`
+}
+
+// ChannelCheckpointInfos is the response of ChannelCheckpointMetrics.
+type ChannelCheckpointInfos struct {
+	Checkpoints []ChannelCheckpoint `json:"checkpoints"`
+}
+
+// SegmentMeta is the meta of a segment in datacoord.
+type SegmentMeta struct {
+	SegmentID      int64   `json:"segment_id"`
+	CollectionID   int64   `json:"collection_id"`
+	PartitionID    int64   `json:"partition_id"`
+	Channel        string  `json:"channel"`
+	State          string  `json:"state"`
+	Level          string  `json:"level"`
+	NumRows        int64   `json:"num_rows"`
+	MaxRowNum      int64   `json:"max_row_num"`
+	StartTimestamp uint64  `json:"start_timestamp"`
+	DmlTimestamp   uint64  `json:"dml_timestamp"`
+	BinlogNum      int     `json:"binlog_num"`
+	StatslogNum    int     `json:"statslog_num"`
+	DeltalogNum    int     `json:"deltalog_num"`
+	CompactionFrom []int64 `json:"compaction_from,omitempty"`
+	Compacted      bool    `json:"compacted"`
+	IsImporting    bool    `json:"is_importing"`
+	DroppedAt      uint64  `json:"dropped_at,omitempty"`
+}
+
+// SegmentMetaInfos is the response of SegmentMetaMetrics.
+type SegmentMetaInfos struct {
+	Segments []SegmentMeta `json:"segments"`
+}
+
+// SmallSegmentReport is the small sealed segments of a collection, the segment is small if its number of rows
+// is less than the small proportion of its max rows.
+type SmallSegmentReport struct {
+	CollectionID     int64   `json:"collection_id"`
+	NumSegments      int     `json:"num_segments"`
+	NumSmallSegments int     `json:"num_small_segments"`
+	SmallSegmentRows int64   `json:"small_segment_rows"`
+	SmallSegments    []int64 `json:"small_segments"`
+	// Compacting is whether any of the small segments is being compacted
+	Compacting bool `json:"compacting"`
+}
+
+// SmallSegmentInfos is the response of SmallSegmentMetrics.
+type SmallSegmentInfos struct {
+	Collections []SmallSegmentReport `json:"collections"`
+}
+
+// TargetInfo is the channels and sealed segments of a target of collection.
+type TargetInfo struct {
+	Version  int64    `json:"version"`
+	Channels []string `json:"channels"`
+	Segments []int64  `json:"segments"`
+}
+
+// NodeDistribution is the channels and sealed segments of a collection served by a querynode.
+type NodeDistribution struct {
+	NodeID   int64    `json:"node_id"`
+	Channels []string `json:"channels"`
+	Segments []int64  `json:"segments"`
+}
+
+// TargetDistribution compares the targets of a collection with the distributions on querynodes.
+type TargetDistribution struct {
+	CollectionID  int64              `json:"collection_id"`
+	CurrentTarget TargetInfo         `json:"current_target"`
+	NextTarget    TargetInfo         `json:"next_target"`
+	Distributions []NodeDistribution `json:"distributions"`
+	// MissingSegments are in the current target but not served by any querynode
+	MissingSegments []int64 `json:"missing_segments"`
+	// RedundantSegments are served by querynodes but in neither the current target nor the next target
+	RedundantSegments []int64 `json:"redundant_segments"`
+}
+
+// TargetDistributionInfos is the response of TargetDistributionMetrics.
+type TargetDistributionInfos struct {
+	Collections []TargetDistribution `json:"collections"`
+}
+
+// ReplicaAssignment is a replica and the querynodes assigned to it.
+type ReplicaAssignment struct {
+	ReplicaID     int64   `json:"replica_id"`
+	CollectionID  int64   `json:"collection_id"`
+	ResourceGroup string  `json:"resource_group"`
+	Nodes         []int64 `json:"nodes"`
+	// ChannelLeaders are the querynodes serving the shard delegators of the channels in the replica
+	ChannelLeaders map[string]int64 `json:"channel_leaders"`
+}
+
+// ReplicaAssignmentInfos is the response of ReplicaAssignmentMetrics.
+type ReplicaAssignmentInfos struct {
+	Replicas []ReplicaAssignment `json:"replicas"`
+}
+
+// ResourceGroupUsage is the nodes, collections and utilization of a resource group of querynodes.
+type ResourceGroupUsage struct {
+	Name     string  `json:"name"`
+	Capacity int     `json:"capacity"`
+	Nodes    []int64 `json:"nodes"`
+	// Collections are the loaded collections which have replicas in the resource group
+	Collections []int64 `json:"collections"`
+	NumReplicas int     `json:"num_replicas"`
+	// Memory and MemoryUsage are the sums of the nodes in the resource group, bytes
+	Memory      uint64 `json:"memory"`
+	MemoryUsage uint64 `json:"memory_usage"`
+	// CPUUsage is the average cpu usage of the nodes in the resource group
+	CPUUsage float64 `json:"cpu_usage"`
+}
+
+// ResourceGroupInfos is the response of ResourceGroupMetrics.
+type ResourceGroupInfos struct {
+	ResourceGroups []ResourceGroupUsage `json:"resource_groups"`
+}
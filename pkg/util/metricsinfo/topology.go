@@ -42,8 +42,9 @@ func UnmarshalTopology(s string, topology Topology) error {
 
 // QueryClusterTopology shows the topology between QueryCoord and QueryNodes
 type QueryClusterTopology struct {
-	Self           QueryCoordInfos  `json:"self"`
-	ConnectedNodes []QueryNodeInfos `json:"connected_nodes"`
+	Self           QueryCoordInfos      `json:"self"`
+	ConnectedNodes []QueryNodeInfos     `json:"connected_nodes"`
+	ResourceGroups []ResourceGroupUsage `json:"resource_groups,omitempty"`
 }
 
 // ConnectionType is the type of connection between nodes
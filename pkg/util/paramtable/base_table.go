@@ -223,6 +223,23 @@ func (bt *BaseTable) FileConfigs() map[string]string {
 	return bt.mgr.FileConfigs()
 }
 
+// GetValueStatus returns the declared and effective values of the validated configs.
+func (bt *BaseTable) GetValueStatus() []*config.ValueStatus {
+	return bt.mgr.GetValueStatus()
+}
+
+// GetEffectiveConfigs returns the configs in effect with the prefix, and the source and scope supplying them.
+func (bt *BaseTable) GetEffectiveConfigs(prefix string) map[string]*config.EffectiveValue {
+	return bt.mgr.GetEffectiveConfigs(config.WithPrefix(prefix))
+}
+
+// UpdateScope applies the configs scoped to the role and node id synchronously.
+func (bt *BaseTable) UpdateScope(role string, nodeID int64) {
+	if err := bt.mgr.UpdateScope(role, nodeID); err != nil {
+		log.Warn("failed to refresh the scoped configs", zap.String("role", role), zap.Int64("nodeID", nodeID), zap.Error(err))
+	}
+}
+
 func (bt *BaseTable) UpdateSourceOptions(opts ...config.Option) {
 	bt.mgr.UpdateSourceOptions(opts...)
 }
@@ -273,3 +290,18 @@ func (bt *BaseTable) Reset(key string) error {
 	bt.mgr.ResetConfig(key)
 	return nil
 }
+
+// SaveAndNotify overrides the config at runtime after validated, and notifies the watchers of the key.
+func (bt *BaseTable) SaveAndNotify(key, value string) error {
+	return bt.mgr.SetConfigAndNotify(key, value)
+}
+
+// ResetAndNotify removes the runtime override of the config, and notifies the watchers of the key.
+func (bt *BaseTable) ResetAndNotify(key string) {
+	bt.mgr.ResetConfigAndNotify(key)
+}
+
+// IsOverridden returns whether the config is overridden at runtime.
+func (bt *BaseTable) IsOverridden(key string) bool {
+	return bt.mgr.IsOverridden(key)
+}
@@ -13,6 +13,7 @@ package paramtable
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -197,6 +198,7 @@ type commonConfig struct {
 
 	AuthorizationEnabled ParamItem `refreshable:"false"`
 	SuperUsers           ParamItem `refreshable:"true"`
+	ManagementToken      ParamItem `refreshable:"true"`
 
 	ClusterName ParamItem `refreshable:"false"`
 
@@ -218,6 +220,47 @@ type commonConfig struct {
 	LockSlowLogInfoThreshold ParamItem `refreshable:"true"`
 	LockSlowLogWarnThreshold ParamItem `refreshable:"true"`
 
+	// metrics related params
+	MetricsCollectionLabelLimit ParamItem `refreshable:"true"`
+	MetricsDetailedCollections  ParamItem `refreshable:"true"`
+	MetricsOTLPEnable           ParamItem `refreshable:"false"`
+	MetricsOTLPEndpoint         ParamItem `refreshable:"false"`
+	MetricsOTLPProtocol         ParamItem `refreshable:"false"`
+	MetricsOTLPSecure           ParamItem `refreshable:"false"`
+	MetricsOTLPInterval         ParamItem `refreshable:"false"`
+	MetricsOTLPHeaders          ParamItem `refreshable:"false"`
+
+	// event bus related params
+	EventBusEnable            ParamItem `refreshable:"false"`
+	EventBusSinks             ParamItem `refreshable:"false"`
+	EventBusBufferSize        ParamItem `refreshable:"false"`
+	EventBusMQTopic           ParamItem `refreshable:"false"`
+	EventBusWebhookURL        ParamItem `refreshable:"false"`
+	EventBusWebhookHeaders    ParamItem `refreshable:"false"`
+	EventBusWebhookRetryTimes ParamItem `refreshable:"false"`
+	EventBusWebhookTimeout    ParamItem `refreshable:"false"`
+
+	IndexReadyNotifyEnable ParamItem `refreshable:"false"`
+
+	// degraded mode related params
+	DegradedModeEnable              ParamItem `refreshable:"true"`
+	DegradedModeCheckInterval       ParamItem `refreshable:"false"`
+	DegradedModeRecoverRounds       ParamItem `refreshable:"true"`
+	DegradedModeTSafeStaleThreshold ParamItem `refreshable:"true"`
+
+	// feature gate related params
+	FeatureGateRefreshInterval ParamItem `refreshable:"false"`
+	FeatureGateDisabled        ParamItem `refreshable:"false"`
+
+	// failure domain related params
+	FailureDomainZone        ParamItem `refreshable:"false"`
+	FailureDomainRack        ParamItem `refreshable:"false"`
+	FailureDomainMetadataURL ParamItem `refreshable:"false"`
+
+	// timetick skew related params
+	TimeTickSkewThreshold     ParamItem `refreshable:"true"`
+	TimeTickSkewCheckInterval ParamItem `refreshable:"false"`
+
 	StorageScheme   ParamItem `refreshable:"false"`
 	EnableStorageV2 ParamItem `refreshable:"false"`
 	TTMsgEnabled    ParamItem `refreshable:"true"`
@@ -473,6 +516,7 @@ This configuration is only used by querynode and indexnode, it selects CPU instr
 		Version:      "2.0.0",
 		DefaultValue: strconv.Itoa(DefaultGracefulTime),
 		Doc:          "milliseconds. it represents the interval (in ms) by which the request arrival time needs to be subtracted in the case of Bounded Consistency.",
+		Validator:    validateIntRange(0, math.MaxInt64),
 		Export:       true,
 	}
 	p.GracefulTime.Init(base.mgr)
@@ -543,6 +587,16 @@ like the old password verification when updating the credential`,
 	}
 	p.SuperUsers.Init(base.mgr)
 
+	p.ManagementToken = ParamItem{
+		Key:     "common.security.managementToken",
+		Version: "2.4.0",
+		Doc: `The token required in the Authorization header by the management endpoints of the nodes which can't authenticate the users,
+e.g. the tuning endpoints of query nodes, the endpoints are disabled if it's empty`,
+		DefaultValue: "",
+		Export:       true,
+	}
+	p.ManagementToken.Init(base.mgr)
+
 	p.ClusterName = ParamItem{
 		Key:          "common.cluster.name",
 		Version:      "2.0.0",
@@ -635,6 +689,272 @@ like the old password verification when updating the credential`,
 	}
 	p.LockSlowLogWarnThreshold.Init(base.mgr)
 
+	p.MetricsCollectionLabelLimit = ParamItem{
+		Key:          "common.metrics.collectionLabelLimit",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc: `max number of distinct collections in the metrics labels of a node, 0 means unlimited,
+the metrics of the collections beyond the limit are aggregated into the "other" collection`,
+		Export: true,
+	}
+	p.MetricsCollectionLabelLimit.Init(base.mgr)
+
+	p.MetricsDetailedCollections = ParamItem{
+		Key:          "common.metrics.detailedCollections",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc: `the collections which have the detailed metrics, separated by comma, empty means all the collections,
+the metrics of the other collections are aggregated into the "other" collection`,
+		Export: true,
+	}
+	p.MetricsDetailedCollections.Init(base.mgr)
+
+	p.MetricsOTLPEnable = ParamItem{
+		Key:          "common.metrics.otlp.enable",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "whether to push the metrics to the OTLP endpoint besides the prometheus endpoint",
+		Export:       true,
+	}
+	p.MetricsOTLPEnable.Init(base.mgr)
+
+	p.MetricsOTLPEndpoint = ParamItem{
+		Key:          "common.metrics.otlp.endpoint",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          "host:port of the OTLP collector, or the full url of the metrics path if the protocol is http",
+		Export:       true,
+	}
+	p.MetricsOTLPEndpoint.Init(base.mgr)
+
+	p.MetricsOTLPProtocol = ParamItem{
+		Key:          "common.metrics.otlp.protocol",
+		Version:      "2.4.0",
+		DefaultValue: "grpc",
+		Doc:          "protocol of the OTLP exporter, grpc or http",
+		Export:       true,
+	}
+	p.MetricsOTLPProtocol.Init(base.mgr)
+
+	p.MetricsOTLPSecure = ParamItem{
+		Key:          "common.metrics.otlp.secure",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "whether to use TLS to connect the OTLP collector",
+		Export:       true,
+	}
+	p.MetricsOTLPSecure.Init(base.mgr)
+
+	p.MetricsOTLPInterval = ParamItem{
+		Key:          "common.metrics.otlp.interval",
+		Version:      "2.4.0",
+		DefaultValue: "30",
+		Doc:          "interval in seconds to push the metrics",
+		Export:       true,
+	}
+	p.MetricsOTLPInterval.Init(base.mgr)
+
+	p.MetricsOTLPHeaders = ParamItem{
+		Key:          "common.metrics.otlp.headers",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          `headers sent with the metrics in json, e.g. {"Authorization": "Bearer xxx"}`,
+		Export:       true,
+	}
+	p.MetricsOTLPHeaders.Init(base.mgr)
+
+	p.EventBusEnable = ParamItem{
+		Key:          "common.eventBus.enable",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "whether to publish the cluster lifecycle events, such as segment sealed, compaction finished, index built and node down",
+		Export:       true,
+	}
+	p.EventBusEnable.Init(base.mgr)
+
+	p.EventBusSinks = ParamItem{
+		Key:          "common.eventBus.sinks",
+		Version:      "2.4.0",
+		DefaultValue: "log",
+		Doc:          "the sinks of the events separated by comma, options: log, mq, webhook",
+		Export:       true,
+	}
+	p.EventBusSinks.Init(base.mgr)
+
+	p.EventBusBufferSize = ParamItem{
+		Key:          "common.eventBus.bufferSize",
+		Version:      "2.4.0",
+		DefaultValue: "1024",
+		Doc:          "max number of the events queued for each sink, the events are dropped if the queue is full",
+		Export:       true,
+	}
+	p.EventBusBufferSize.Init(base.mgr)
+
+	p.EventBusMQTopic = ParamItem{
+		Key:          "common.eventBus.mq.topic",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Formatter: func(v string) string {
+			if v == "" {
+				return p.ClusterPrefix.GetValue() + "-events"
+			}
+			return v
+		},
+		Doc:    "the topic which the events are produced into, default is {msgChannel.chanNamePrefix.cluster}-events",
+		Export: true,
+	}
+	p.EventBusMQTopic.Init(base.mgr)
+
+	p.EventBusWebhookURL = ParamItem{
+		Key:          "common.eventBus.webhook.url",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          "the url which the events are posted to in json",
+		Export:       true,
+	}
+	p.EventBusWebhookURL.Init(base.mgr)
+
+	p.EventBusWebhookHeaders = ParamItem{
+		Key:          "common.eventBus.webhook.headers",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          `headers sent with the events in json, e.g. {"Authorization": "Bearer xxx"}`,
+		Export:       true,
+	}
+	p.EventBusWebhookHeaders.Init(base.mgr)
+
+	p.EventBusWebhookRetryTimes = ParamItem{
+		Key:          "common.eventBus.webhook.retryTimes",
+		Version:      "2.4.0",
+		DefaultValue: "3",
+		Doc:          "max attempts to post an event",
+		Export:       true,
+	}
+	p.EventBusWebhookRetryTimes.Init(base.mgr)
+
+	p.EventBusWebhookTimeout = ParamItem{
+		Key:          "common.eventBus.webhook.timeout",
+		Version:      "2.4.0",
+		DefaultValue: "3000",
+		Doc:          "timeout in milliseconds of each post",
+		Export:       true,
+	}
+	p.EventBusWebhookTimeout.Init(base.mgr)
+
+	p.IndexReadyNotifyEnable = ParamItem{
+		Key:          "common.indexReadyNotify.enable",
+		Version:      "2.4.0",
+		DefaultValue: "true",
+		Doc: `whether datacoord notifies querycoord of the segments whose index is built by etcd,
+so that querycoord loads the indexes and hands off the segments without polling the index states`,
+		Export: true,
+	}
+	p.IndexReadyNotifyEnable.Init(base.mgr)
+
+	p.DegradedModeEnable = ParamItem{
+		Key:          "common.degradedMode.enable",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc: `whether to degrade instead of failing when the object storage or the message queue is unavailable,
+the proxies reject the writes as read-only and the query nodes keep serving the loaded data`,
+		Export: true,
+	}
+	p.DegradedModeEnable.Init(base.mgr)
+
+	p.DegradedModeCheckInterval = ParamItem{
+		Key:          "common.degradedMode.checkInterval",
+		Version:      "2.4.0",
+		DefaultValue: "10",
+		Doc:          "interval in seconds to probe the object storage and the message queue",
+		Export:       true,
+	}
+	p.DegradedModeCheckInterval.Init(base.mgr)
+
+	p.DegradedModeRecoverRounds = ParamItem{
+		Key:          "common.degradedMode.recoverRounds",
+		Version:      "2.4.0",
+		DefaultValue: "3",
+		Doc:          "number of the consecutive successful probes to recover from the read-only mode, which avoids flapping",
+		Export:       true,
+	}
+	p.DegradedModeRecoverRounds.Init(base.mgr)
+
+	p.DegradedModeTSafeStaleThreshold = ParamItem{
+		Key:          "common.degradedMode.tsafeStaleThreshold",
+		Version:      "2.4.0",
+		DefaultValue: "30",
+		Doc: `the query nodes serve the bounded and eventually consistency reads by the loaded data without waiting for the guarantee timestamp,
+and reject the strong and session consistency reads as unavailable, if the serviceable timestamp of the channel lags behind now more than the seconds`,
+		Export: true,
+	}
+	p.DegradedModeTSafeStaleThreshold.Init(base.mgr)
+
+	p.FeatureGateRefreshInterval = ParamItem{
+		Key:          "common.featureGate.refreshInterval",
+		Version:      "2.4.0",
+		DefaultValue: "10",
+		Doc:          "interval in seconds to refresh the features supported by all the live nodes",
+		Export:       true,
+	}
+	p.FeatureGateRefreshInterval.Init(base.mgr)
+
+	p.FeatureGateDisabled = ParamItem{
+		Key:          "common.featureGate.disabled",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc: `comma separated features not advertised by this node, i.e. binlog_v2 and reduce_stop_for_best,
+which keeps the features disabled in the whole cluster`,
+		Export: true,
+	}
+	p.FeatureGateDisabled.Init(base.mgr)
+
+	p.FailureDomainZone = ParamItem{
+		Key:          "common.failureDomain.zone",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          "the zone this node runs in, which is advertised in the session for the replica placement",
+		Export:       true,
+	}
+	p.FailureDomainZone.Init(base.mgr)
+
+	p.FailureDomainRack = ParamItem{
+		Key:          "common.failureDomain.rack",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          "the rack this node runs in, which is advertised in the session for the replica placement",
+		Export:       true,
+	}
+	p.FailureDomainRack.Init(base.mgr)
+
+	p.FailureDomainMetadataURL = ParamItem{
+		Key:          "common.failureDomain.metadataURL",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc: `the cloud metadata url responding the zone in plain text, which is requested if the zone is not configured,
+e.g. http://169.254.169.254/latest/meta-data/placement/availability-zone on AWS`,
+		Export: true,
+	}
+	p.FailureDomainMetadataURL.Init(base.mgr)
+
+	p.TimeTickSkewThreshold = ParamItem{
+		Key:          "common.timeTickSkew.threshold",
+		Version:      "2.4.0",
+		DefaultValue: "10",
+		Doc: `the proxy or datanode whose timetick lags behind the global watermark more than the seconds is alerted as lagging,
+the global watermark is the latest timetick reported by all the proxies in rootcoord, or by all the datanodes in datacoord`,
+		Export: true,
+	}
+	p.TimeTickSkewThreshold.Init(base.mgr)
+
+	p.TimeTickSkewCheckInterval = ParamItem{
+		Key:          "common.timeTickSkew.checkInterval",
+		Version:      "2.4.0",
+		DefaultValue: "10",
+		Doc:          "interval in seconds to check the timetick skew of the proxies and datanodes",
+		Export:       true,
+	}
+	p.TimeTickSkewCheckInterval.Init(base.mgr)
+
 	p.EnableStorageV2 = ParamItem{
 		Key:          "common.storage.enablev2",
 		Version:      "2.3.1",
@@ -735,6 +1055,7 @@ func (l *logConfig) init(base *BaseTable) {
 		DefaultValue: "info",
 		Version:      "2.0.0",
 		Doc:          "Only supports debug, info, warn, error, panic, or fatal. Default 'info'.",
+		Validator:    validateOneOf("debug", "info", "warn", "error", "panic", "fatal"),
 		Export:       true,
 	}
 	l.Level.Init(base.mgr)
@@ -812,6 +1133,17 @@ type rootCoordConfig struct {
 	ImportTaskSubPath           ParamItem `refreshable:"true"`
 	EnableActiveStandby         ParamItem `refreshable:"false"`
 	MaxDatabaseNum              ParamItem `refreshable:"false"`
+
+	DDLEventLogEnabled            ParamItem `refreshable:"false"`
+	DDLEventLogWatermarkInterval  ParamItem `refreshable:"false"`
+	DDLEventLogWatermarkRetention ParamItem `refreshable:"true"`
+	DDLEventLogRetention          ParamItem `refreshable:"true"`
+
+	HotStandbyEnable       ParamItem `refreshable:"false"`
+	HotStandbySyncInterval ParamItem `refreshable:"false"`
+
+	PartitionRolloverCheckInterval ParamItem `refreshable:"false"`
+	PartitionRolloverPrecreate     ParamItem `refreshable:"true"`
 }
 
 func (p *rootCoordConfig) init(base *BaseTable) {
@@ -891,6 +1223,78 @@ func (p *rootCoordConfig) init(base *BaseTable) {
 		Export:       true,
 	}
 	p.MaxDatabaseNum.Init(base.mgr)
+
+	p.DDLEventLogEnabled = ParamItem{
+		Key:          "rootCoord.ddlEventLog.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "Persist the ordered ddl events and dml watermarks, so that the meta can be rebuilt as of any timestamp",
+		Export:       true,
+	}
+	p.DDLEventLogEnabled.Init(base.mgr)
+
+	p.DDLEventLogWatermarkInterval = ParamItem{
+		Key:          "rootCoord.ddlEventLog.watermarkInterval",
+		Version:      "2.4.0",
+		DefaultValue: "60",
+		Doc:          "(in seconds) The interval to record the synced timeticks of dml channels into the ddl event log",
+		Export:       true,
+	}
+	p.DDLEventLogWatermarkInterval.Init(base.mgr)
+
+	p.DDLEventLogWatermarkRetention = ParamItem{
+		Key:          "rootCoord.ddlEventLog.watermarkRetention",
+		Version:      "2.4.0",
+		DefaultValue: "168",
+		Doc:          "(in hours) The dml watermarks older than it are removed",
+		Export:       true,
+	}
+	p.DDLEventLogWatermarkRetention.Init(base.mgr)
+
+	p.DDLEventLogRetention = ParamItem{
+		Key:          "rootCoord.ddlEventLog.retention",
+		Version:      "2.4.0",
+		DefaultValue: "720",
+		Doc:          "(in hours) The ddl events older than it are compacted into a snapshot, the meta can't be rebuilt as of the time before it",
+		Export:       true,
+	}
+	p.DDLEventLogRetention.Init(base.mgr)
+
+	p.HotStandbyEnable = ParamItem{
+		Key:          "rootCoord.hotStandby.enable",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "Keep the meta of the standby synced by watching etcd, so that it takes over without a full meta reload, works with enableActiveStandby",
+		Export:       true,
+	}
+	p.HotStandbyEnable.Init(base.mgr)
+
+	p.HotStandbySyncInterval = ParamItem{
+		Key:          "rootCoord.hotStandby.syncInterval",
+		Version:      "2.4.0",
+		DefaultValue: "1000",
+		Doc:          "(in milliseconds) The interval to apply the watched meta changes to the standby",
+		Export:       true,
+	}
+	p.HotStandbySyncInterval.Init(base.mgr)
+
+	p.PartitionRolloverCheckInterval = ParamItem{
+		Key:          "rootCoord.partitionRollover.checkInterval",
+		Version:      "2.4.0",
+		DefaultValue: "60",
+		Doc:          "(in seconds) The interval to create the partitions of the coming periods and expire the partitions past retention for the time-partitioned collections",
+		Export:       true,
+	}
+	p.PartitionRolloverCheckInterval.Init(base.mgr)
+
+	p.PartitionRolloverPrecreate = ParamItem{
+		Key:          "rootCoord.partitionRollover.precreate",
+		Version:      "2.4.0",
+		DefaultValue: "1",
+		Doc:          "The number of the coming periods to create the partitions ahead for the time-partitioned collections",
+		Export:       true,
+	}
+	p.PartitionRolloverPrecreate.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -909,6 +1313,31 @@ type AccessLogConfig struct {
 	Formatter     ParamGroup `refreshable:"false"`
 }
 
+type SlowLogConfig struct {
+	Enable               ParamItem `refreshable:"true"`
+	SearchThreshold      ParamItem `refreshable:"true"`
+	QueryThreshold       ParamItem `refreshable:"true"`
+	InsertThreshold      ParamItem `refreshable:"true"`
+	CollectionThresholds ParamItem `refreshable:"true"`
+	SampleRatio          ParamItem `refreshable:"true"`
+	RecentSize           ParamItem `refreshable:"false"`
+	LocalPath            ParamItem `refreshable:"false"`
+	Filename             ParamItem `refreshable:"false"`
+	MaxSize              ParamItem `refreshable:"false"`
+	MaxBackups           ParamItem `refreshable:"false"`
+}
+
+type HealthProbeConfig struct {
+	Enable                  ParamItem `refreshable:"true"`
+	Interval                ParamItem `refreshable:"true"`
+	Timeout                 ParamItem `refreshable:"true"`
+	EtcdLatencyThreshold    ParamItem `refreshable:"true"`
+	MQLatencyThreshold      ParamItem `refreshable:"true"`
+	StorageLatencyThreshold ParamItem `refreshable:"true"`
+	HeartbeatStaleThreshold ParamItem `refreshable:"true"`
+	TimeTickLagThreshold    ParamItem `refreshable:"true"`
+}
+
 type proxyConfig struct {
 	// Alias  string
 	SoPath ParamItem `refreshable:"false"`
@@ -935,8 +1364,49 @@ type proxyConfig struct {
 	RetryTimesOnReplica          ParamItem `refreshable:"true"`
 	RetryTimesOnHealthCheck      ParamItem `refreshable:"true"`
 	PartitionNameRegexp          ParamItem `refreshable:"true"`
+	ExportBatchSize              ParamItem `refreshable:"true"`
+	ExportRowsPerFile            ParamItem `refreshable:"true"`
+	ExportMaxRunningJobs         ParamItem `refreshable:"false"`
+	ExportJobRetention           ParamItem `refreshable:"true"`
+	AnalyzeSampleSize            ParamItem `refreshable:"true"`
+	AnalyzeMaxScanRows           ParamItem `refreshable:"true"`
+	AnalyzeMaxRunningJobs        ParamItem `refreshable:"false"`
+	VectorURLThreshold           ParamItem `refreshable:"true"`
+	VectorURLExpiry              ParamItem `refreshable:"true"`
+	VectorURLRemoveExpired       ParamItem `refreshable:"true"`
+	TxnTimeout                   ParamItem `refreshable:"true"`
+	TxnMaxNum                    ParamItem `refreshable:"true"`
+	ShardRetryBackoffInitial     ParamItem `refreshable:"true"`
+	ShardRetryBackoffMax         ParamItem `refreshable:"true"`
+	ShardRetryBackoffJitter      ParamItem `refreshable:"true"`
+	ShardRetryBudget             ParamItem `refreshable:"true"`
+	ArrowInsertPayloadEnabled    ParamItem `refreshable:"true"`
+	ExprCacheEnabled             ParamItem `refreshable:"true"`
+	ExprCacheSize                ParamItem `refreshable:"false"`
+	SystemFieldFilterEnabled     ParamItem `refreshable:"true"`
+	DeleteBatchSize              ParamItem `refreshable:"true"`
+	DeleteJobRetention           ParamItem `refreshable:"true"`
+	MaxConnectionsPerClient      ParamItem `refreshable:"true"`
+	MaxInFlightPerClient         ParamItem `refreshable:"true"`
+	MaxInFlightPerUser           ParamItem `refreshable:"true"`
+	ClientLimitRetryAfter        ParamItem `refreshable:"true"`
+	SessionStickyRouting         ParamItem `refreshable:"true"`
+	SessionStickyTTL             ParamItem `refreshable:"true"`
+	MaxFederatedCollections      ParamItem `refreshable:"true"`
+	MutationBatchingEnabled      ParamItem `refreshable:"true"`
+	MutationBatchingWindow       ParamItem `refreshable:"true"`
+	MutationBatchingMaxRows      ParamItem `refreshable:"true"`
+	DedupWindow                  ParamItem `refreshable:"true"`
+	DedupMaxEntries              ParamItem `refreshable:"true"`
+	QueryMaxScannedRows          ParamItem `refreshable:"true"`
+	QueryMaxResultBytes          ParamItem `refreshable:"true"`
+
+	QueryTimeoutClasses ParamGroup `refreshable:"true"`
 
 	AccessLog AccessLogConfig
+	SlowLog   SlowLogConfig
+
+	HealthProbe HealthProbeConfig
 }
 
 func (p *proxyConfig) init(base *BaseTable) {
@@ -1041,6 +1511,7 @@ So adjust at your risk!`,
 		Version:      "2.2.0",
 		DefaultValue: "10000",
 		Doc:          "max task number of proxy task queue",
+		Validator:    validatePositiveInt(),
 		Export:       true,
 	}
 	p.MaxTaskNum.Init(base.mgr)
@@ -1173,61 +1644,577 @@ please adjust in embedded Milvus: false`,
 	}
 	p.AccessLog.Formatter.Init(base.mgr)
 
-	p.ShardLeaderCacheInterval = ParamItem{
-		Key:          "proxy.shardLeaderCacheInterval",
-		Version:      "2.2.4",
-		DefaultValue: "3",
-		Doc:          "time interval to update shard leader cache, in seconds",
+	p.HealthProbe.Enable = ParamItem{
+		Key:          "proxy.healthProbe.enable",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "whether to run the active probes in background and report the result in CheckHealth, the probes are always available by the management api",
+		Export:       true,
 	}
-	p.ShardLeaderCacheInterval.Init(base.mgr)
+	p.HealthProbe.Enable.Init(base.mgr)
 
-	p.ReplicaSelectionPolicy = ParamItem{
-		Key:          "proxy.replicaSelectionPolicy",
-		Version:      "2.3.0",
-		DefaultValue: "look_aside",
-		Doc:          "replica selection policy in multiple replicas load balancing, support round_robin and look_aside",
+	p.HealthProbe.Interval = ParamItem{
+		Key:          "proxy.healthProbe.interval",
+		Version:      "2.4.0",
+		DefaultValue: "30000",
+		Doc:          "interval of running the probes in background, CheckHealth uses the latest result, in milliseconds",
+		Validator:    validatePositiveInt(),
+		Export:       true,
 	}
-	p.ReplicaSelectionPolicy.Init(base.mgr)
+	p.HealthProbe.Interval.Init(base.mgr)
 
-	p.CheckQueryNodeHealthInterval = ParamItem{
-		Key:          "proxy.checkQueryNodeHealthInterval",
-		Version:      "2.3.0",
+	p.HealthProbe.Timeout = ParamItem{
+		Key:          "proxy.healthProbe.timeout",
+		Version:      "2.4.0",
+		DefaultValue: "10000",
+		Doc:          "timeout of each probe, in milliseconds",
+		Export:       true,
+	}
+	p.HealthProbe.Timeout.Init(base.mgr)
+
+	p.HealthProbe.EtcdLatencyThreshold = ParamItem{
+		Key:          "proxy.healthProbe.etcdLatencyThreshold",
+		Version:      "2.4.0",
+		DefaultValue: "500",
+		Doc:          "the etcd round trip latency beyond the threshold is diagnosed as slow, in milliseconds",
+		Export:       true,
+	}
+	p.HealthProbe.EtcdLatencyThreshold.Init(base.mgr)
+
+	p.HealthProbe.MQLatencyThreshold = ParamItem{
+		Key:          "proxy.healthProbe.mqLatencyThreshold",
+		Version:      "2.4.0",
 		DefaultValue: "1000",
-		Doc:          "time interval to check health for query node, in ms",
+		Doc:          "the message queue produce-consume round trip latency beyond the threshold is diagnosed as slow, in milliseconds",
+		Export:       true,
 	}
-	p.CheckQueryNodeHealthInterval.Init(base.mgr)
+	p.HealthProbe.MQLatencyThreshold.Init(base.mgr)
 
-	p.CostMetricsExpireTime = ParamItem{
-		Key:          "proxy.costMetricsExpireTime",
-		Version:      "2.3.0",
+	p.HealthProbe.StorageLatencyThreshold = ParamItem{
+		Key:          "proxy.healthProbe.storageLatencyThreshold",
+		Version:      "2.4.0",
 		DefaultValue: "1000",
-		Doc:          "expire time for query node cost metrics, in ms",
+		Doc:          "the object storage write-read round trip latency beyond the threshold is diagnosed as slow, in milliseconds",
+		Export:       true,
 	}
-	p.CostMetricsExpireTime.Init(base.mgr)
+	p.HealthProbe.StorageLatencyThreshold.Init(base.mgr)
 
-	p.RetryTimesOnReplica = ParamItem{
-		Key:          "proxy.retryTimesOnReplica",
-		Version:      "2.3.0",
-		DefaultValue: "2",
-		Doc:          "retry times on each replica",
+	p.HealthProbe.HeartbeatStaleThreshold = ParamItem{
+		Key:          "proxy.healthProbe.heartbeatStaleThreshold",
+		Version:      "2.4.0",
+		DefaultValue: "20000",
+		Doc:          "the session not kept alive for longer than the threshold is diagnosed as stale, in milliseconds",
+		Export:       true,
 	}
-	p.RetryTimesOnReplica.Init(base.mgr)
+	p.HealthProbe.HeartbeatStaleThreshold.Init(base.mgr)
 
-	p.RetryTimesOnHealthCheck = ParamItem{
-		Key:          "proxy.retryTimesOnHealthCheck",
-		Version:      "2.3.0",
-		DefaultValue: "3",
-		Doc:          "set query node unavailable on proxy when heartbeat failures reach this limit",
+	p.HealthProbe.TimeTickLagThreshold = ParamItem{
+		Key:          "proxy.healthProbe.timeTickLagThreshold",
+		Version:      "2.4.0",
+		DefaultValue: "10000",
+		Doc:          "the channel time tick lagging behind now for longer than the threshold is diagnosed as lagging, in milliseconds",
+		Export:       true,
 	}
-	p.RetryTimesOnHealthCheck.Init(base.mgr)
+	p.HealthProbe.TimeTickLagThreshold.Init(base.mgr)
 
-	p.PartitionNameRegexp = ParamItem{
-		Key:          "proxy.partitionNameRegexp",
-		Version:      "2.3.4",
+	p.SlowLog.Enable = ParamItem{
+		Key:          "proxy.slowLog.enable",
+		Version:      "2.4.0",
 		DefaultValue: "false",
-		Doc:          "switch for whether proxy shall use partition name as regexp when searching",
+		Doc:          "whether to record the slow search, query and insert requests",
+		Export:       true,
+	}
+	p.SlowLog.Enable.Init(base.mgr)
+
+	p.SlowLog.SearchThreshold = ParamItem{
+		Key:          "proxy.slowLog.searchThreshold",
+		Version:      "2.4.0",
+		DefaultValue: "5000",
+		Doc:          "the latency threshold of slow search requests, in milliseconds",
+		Export:       true,
+	}
+	p.SlowLog.SearchThreshold.Init(base.mgr)
+
+	p.SlowLog.QueryThreshold = ParamItem{
+		Key:          "proxy.slowLog.queryThreshold",
+		Version:      "2.4.0",
+		DefaultValue: "5000",
+		Doc:          "the latency threshold of slow query requests, in milliseconds",
+		Export:       true,
+	}
+	p.SlowLog.QueryThreshold.Init(base.mgr)
+
+	p.SlowLog.InsertThreshold = ParamItem{
+		Key:          "proxy.slowLog.insertThreshold",
+		Version:      "2.4.0",
+		DefaultValue: "5000",
+		Doc:          "the latency threshold of slow insert requests, in milliseconds",
+		Export:       true,
+	}
+	p.SlowLog.InsertThreshold.Init(base.mgr)
+
+	p.SlowLog.CollectionThresholds = ParamItem{
+		Key:          "proxy.slowLog.collectionThresholds",
+		Version:      "2.4.0",
+		DefaultValue: "{}",
+		Doc: `the latency thresholds of collections in milliseconds, which override the thresholds of request types,
+formatted as json map, the key is "collection" or "db.collection", e.g. {"db1.c1": "100", "c2": "200"}`,
+		Export: true,
+	}
+	p.SlowLog.CollectionThresholds.Init(base.mgr)
+
+	p.SlowLog.SampleRatio = ParamItem{
+		Key:          "proxy.slowLog.sampleRatio",
+		Version:      "2.4.0",
+		DefaultValue: "1.0",
+		Doc:          "the ratio of slow requests to record, in (0, 1]",
+		Validator:    validateFloatRange(0, 1),
+		Export:       true,
+	}
+	p.SlowLog.SampleRatio.Init(base.mgr)
+
+	p.SlowLog.RecentSize = ParamItem{
+		Key:          "proxy.slowLog.recentSize",
+		Version:      "2.4.0",
+		DefaultValue: "200",
+		Doc:          "the number of recent slow logs kept in memory for querying",
+		Export:       true,
+	}
+	p.SlowLog.RecentSize.Init(base.mgr)
+
+	p.SlowLog.LocalPath = ParamItem{
+		Key:          "proxy.slowLog.localPath",
+		Version:      "2.4.0",
+		DefaultValue: "/tmp/milvus_slowlog",
+		Doc:          "the directory of slow log files",
+		Export:       true,
+	}
+	p.SlowLog.LocalPath.Init(base.mgr)
+
+	p.SlowLog.Filename = ParamItem{
+		Key:          "proxy.slowLog.filename",
+		Version:      "2.4.0",
+		DefaultValue: "slow.log",
+		Doc:          "the slow log filename, leave empty to keep the slow logs in memory only",
+		Export:       true,
+	}
+	p.SlowLog.Filename.Init(base.mgr)
+
+	p.SlowLog.MaxSize = ParamItem{
+		Key:          "proxy.slowLog.maxSize",
+		Version:      "2.4.0",
+		DefaultValue: "64",
+		Doc:          "max size for a single slow log file, in MB",
+		Export:       true,
+	}
+	p.SlowLog.MaxSize.Init(base.mgr)
+
+	p.SlowLog.MaxBackups = ParamItem{
+		Key:          "proxy.slowLog.maxBackups",
+		Version:      "2.4.0",
+		DefaultValue: "8",
+		Doc:          "maximum number of old slow log files to retain",
+		Export:       true,
+	}
+	p.SlowLog.MaxBackups.Init(base.mgr)
+
+	p.ShardLeaderCacheInterval = ParamItem{
+		Key:          "proxy.shardLeaderCacheInterval",
+		Version:      "2.2.4",
+		DefaultValue: "3",
+		Doc:          "time interval to update shard leader cache, in seconds",
+	}
+	p.ShardLeaderCacheInterval.Init(base.mgr)
+
+	p.ReplicaSelectionPolicy = ParamItem{
+		Key:          "proxy.replicaSelectionPolicy",
+		Version:      "2.3.0",
+		DefaultValue: "look_aside",
+		Doc:          "replica selection policy in multiple replicas load balancing, support round_robin and look_aside",
+	}
+	p.ReplicaSelectionPolicy.Init(base.mgr)
+
+	p.CheckQueryNodeHealthInterval = ParamItem{
+		Key:          "proxy.checkQueryNodeHealthInterval",
+		Version:      "2.3.0",
+		DefaultValue: "1000",
+		Doc:          "time interval to check health for query node, in ms",
+	}
+	p.CheckQueryNodeHealthInterval.Init(base.mgr)
+
+	p.CostMetricsExpireTime = ParamItem{
+		Key:          "proxy.costMetricsExpireTime",
+		Version:      "2.3.0",
+		DefaultValue: "1000",
+		Doc:          "expire time for query node cost metrics, in ms",
+	}
+	p.CostMetricsExpireTime.Init(base.mgr)
+
+	p.RetryTimesOnReplica = ParamItem{
+		Key:          "proxy.retryTimesOnReplica",
+		Version:      "2.3.0",
+		DefaultValue: "2",
+		Doc:          "retry times on each replica",
+	}
+	p.RetryTimesOnReplica.Init(base.mgr)
+
+	p.RetryTimesOnHealthCheck = ParamItem{
+		Key:          "proxy.retryTimesOnHealthCheck",
+		Version:      "2.3.0",
+		DefaultValue: "3",
+		Doc:          "set query node unavailable on proxy when heartbeat failures reach this limit",
+	}
+	p.RetryTimesOnHealthCheck.Init(base.mgr)
+
+	p.PartitionNameRegexp = ParamItem{
+		Key:          "proxy.partitionNameRegexp",
+		Version:      "2.3.4",
+		DefaultValue: "false",
+		Doc:          "switch for whether proxy shall use partition name as regexp when searching",
 	}
 	p.PartitionNameRegexp.Init(base.mgr)
+
+	p.ExportBatchSize = ParamItem{
+		Key:          "proxy.export.batchSize",
+		Version:      "2.4.0",
+		DefaultValue: "1000",
+		Doc:          "number of rows retrieved in each query of export job",
+		Export:       true,
+	}
+	p.ExportBatchSize.Init(base.mgr)
+
+	p.ExportRowsPerFile = ParamItem{
+		Key:          "proxy.export.rowsPerFile",
+		Version:      "2.4.0",
+		DefaultValue: "100000",
+		Doc:          "max number of rows in each exported parquet file",
+		Export:       true,
+	}
+	p.ExportRowsPerFile.Init(base.mgr)
+
+	p.ExportMaxRunningJobs = ParamItem{
+		Key:          "proxy.export.maxRunningJobs",
+		Version:      "2.4.0",
+		DefaultValue: "2",
+		Doc:          "max number of export jobs running concurrently on each proxy",
+		Export:       true,
+	}
+	p.ExportMaxRunningJobs.Init(base.mgr)
+
+	p.ExportJobRetention = ParamItem{
+		Key:          "proxy.export.jobRetention",
+		Version:      "2.4.0",
+		DefaultValue: "86400",
+		Doc:          "seconds to keep the finished export jobs in memory of proxy",
+		Export:       true,
+	}
+	p.ExportJobRetention.Init(base.mgr)
+
+	p.AnalyzeSampleSize = ParamItem{
+		Key:          "proxy.analyze.sampleSize",
+		Version:      "2.4.0",
+		DefaultValue: "10000",
+		Doc:          "number of rows sampled by analyze job to compute the vector distribution",
+		Export:       true,
+	}
+	p.AnalyzeSampleSize.Init(base.mgr)
+
+	p.AnalyzeMaxScanRows = ParamItem{
+		Key:          "proxy.analyze.maxScanRows",
+		Version:      "2.4.0",
+		DefaultValue: "1000000",
+		Doc:          "max number of rows scanned by analyze job, the rest rows are not analyzed",
+		Export:       true,
+	}
+	p.AnalyzeMaxScanRows.Init(base.mgr)
+
+	p.AnalyzeMaxRunningJobs = ParamItem{
+		Key:          "proxy.analyze.maxRunningJobs",
+		Version:      "2.4.0",
+		DefaultValue: "1",
+		Doc:          "max number of analyze jobs running concurrently on each proxy",
+		Export:       true,
+	}
+	p.AnalyzeMaxRunningJobs.Init(base.mgr)
+
+	p.VectorURLThreshold = ParamItem{
+		Key:          "proxy.vectorURL.threshold",
+		Version:      "2.4.0",
+		DefaultValue: "1048576",
+		Doc: `the min size in bytes of a vector output field to be returned as pre-signed urls,
+only applies to the queries with vector_output_mode=url`,
+		Export: true,
+	}
+	p.VectorURLThreshold.Init(base.mgr)
+
+	p.VectorURLExpiry = ParamItem{
+		Key:          "proxy.vectorURL.expiry",
+		Version:      "2.4.0",
+		DefaultValue: "3600",
+		Doc:          "expiry in seconds of the pre-signed urls of vector output fields, the objects are removed after expiry",
+		Export:       true,
+	}
+	p.VectorURLExpiry.Init(base.mgr)
+
+	p.VectorURLRemoveExpired = ParamItem{
+		Key:          "proxy.vectorURL.removeExpired",
+		Version:      "2.4.0",
+		DefaultValue: "true",
+		Doc: `whether proxies remove the expired objects of vector output fields,
+disable it if a lifecycle rule of the bucket expires the objects under <rootPath>/retrieval/`,
+		Export: true,
+	}
+	p.VectorURLRemoveExpired.Init(base.mgr)
+
+	p.TxnTimeout = ParamItem{
+		Key:          "proxy.txn.timeout",
+		Version:      "2.4.0",
+		DefaultValue: "60",
+		Doc:          "timeout in seconds of a transaction, the transaction is aborted if it's not committed before timeout",
+		Export:       true,
+	}
+	p.TxnTimeout.Init(base.mgr)
+
+	p.TxnMaxNum = ParamItem{
+		Key:          "proxy.txn.maxNum",
+		Version:      "2.4.0",
+		DefaultValue: "1024",
+		Doc: `max number of open transactions on each proxy,
+the transactions are kept in the memory of the proxy which began them, so the requests of a transaction must be sent to the same proxy`,
+		Export: true,
+	}
+	p.TxnMaxNum.Init(base.mgr)
+
+	p.ShardRetryBackoffInitial = ParamItem{
+		Key:          "proxy.shardRetry.backoffInitial",
+		Version:      "2.4.0",
+		DefaultValue: "10",
+		Doc:          "initial backoff before retrying search/query on another shard delegator, in milliseconds",
+		Export:       true,
+	}
+	p.ShardRetryBackoffInitial.Init(base.mgr)
+
+	p.ShardRetryBackoffMax = ParamItem{
+		Key:          "proxy.shardRetry.backoffMax",
+		Version:      "2.4.0",
+		DefaultValue: "3000",
+		Doc:          "max backoff between retries of search/query on shard delegators, in milliseconds",
+		Export:       true,
+	}
+	p.ShardRetryBackoffMax.Init(base.mgr)
+
+	p.ShardRetryBackoffJitter = ParamItem{
+		Key:          "proxy.shardRetry.backoffJitter",
+		Version:      "2.4.0",
+		DefaultValue: "0.2",
+		Doc:          "ratio in [0, 1] of the random jitter applied to the backoff",
+		Export:       true,
+	}
+	p.ShardRetryBackoffJitter.Init(base.mgr)
+
+	p.ShardRetryBudget = ParamItem{
+		Key:          "proxy.shardRetry.budget",
+		Version:      "2.4.0",
+		DefaultValue: "16",
+		Doc:          "max number of retries on shard delegators shared by all shards of one search/query request, 0 means no limit",
+		Export:       true,
+	}
+	p.ShardRetryBudget.Init(base.mgr)
+
+	p.ArrowInsertPayloadEnabled = ParamItem{
+		Key:          "proxy.arrowInsertPayload.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "true",
+		Doc:          "whether proxies accept the arrow IPC payloads of insert/upsert from the clients negotiated the arrow format on connect",
+		Export:       true,
+	}
+	p.ArrowInsertPayloadEnabled.Init(base.mgr)
+
+	p.ExprCacheEnabled = ParamItem{
+		Key:          "proxy.exprCache.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "true",
+		Doc:          "whether proxies cache the parsed filter expressions by the templates with the constants replaced by placeholders",
+		Export:       true,
+	}
+	p.ExprCacheEnabled.Init(base.mgr)
+
+	p.ExprCacheSize = ParamItem{
+		Key:          "proxy.exprCache.size",
+		Version:      "2.4.0",
+		DefaultValue: "1024",
+		Doc:          "max number of expression templates cached by each proxy",
+		Export:       true,
+	}
+	p.ExprCacheSize.Init(base.mgr)
+
+	p.SystemFieldFilterEnabled = ParamItem{
+		Key:          "proxy.systemFieldFilter.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "whether the admins are allowed to filter the queried rows by the system fields Timestamp and SegmentID, for debugging",
+		Export:       true,
+	}
+	p.SystemFieldFilterEnabled.Init(base.mgr)
+
+	p.DeleteBatchSize = ParamItem{
+		Key:          "proxy.delete.batchSize",
+		Version:      "2.4.0",
+		DefaultValue: "10000",
+		Doc:          "max number of primary keys in each batch of delete messages produced by the delete with filter expression",
+		Export:       true,
+	}
+	p.DeleteBatchSize.Init(base.mgr)
+
+	p.DeleteJobRetention = ParamItem{
+		Key:          "proxy.delete.jobRetention",
+		Version:      "2.4.0",
+		DefaultValue: "3600",
+		Doc:          "seconds to keep the progress of the finished deletes with filter expression in memory of proxy",
+		Export:       true,
+	}
+	p.DeleteJobRetention.Init(base.mgr)
+
+	p.MaxConnectionsPerClient = ParamItem{
+		Key:          "proxy.clientLimit.maxConnectionsPerIP",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc:          "max number of concurrent connections from each client ip, the requests over the excess connections are rejected, 0 means unlimited",
+		Export:       true,
+	}
+	p.MaxConnectionsPerClient.Init(base.mgr)
+
+	p.MaxInFlightPerClient = ParamItem{
+		Key:          "proxy.clientLimit.maxInFlightPerIP",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc:          "max number of in-flight requests from each client ip, the excess requests are rejected, 0 means unlimited",
+		Export:       true,
+	}
+	p.MaxInFlightPerClient.Init(base.mgr)
+
+	p.MaxInFlightPerUser = ParamItem{
+		Key:          "proxy.clientLimit.maxInFlightPerUser",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc:          "max number of in-flight requests of each user, the excess requests are rejected, 0 means unlimited",
+		Export:       true,
+	}
+	p.MaxInFlightPerUser.Init(base.mgr)
+
+	p.ClientLimitRetryAfter = ParamItem{
+		Key:          "proxy.clientLimit.retryAfter",
+		Version:      "2.4.0",
+		DefaultValue: "1",
+		Doc:          "seconds the clients are hinted to wait before retrying the rejected requests",
+		Export:       true,
+	}
+	p.ClientLimitRetryAfter.Init(base.mgr)
+
+	p.SessionStickyRouting = ParamItem{
+		Key:          "proxy.sessionConsistency.stickyRouting",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc: `whether to route the Session consistency reads of a client to the same shard delegators as its prior reads,
+and to wait for the timestamps of its prior writes, which gives read-your-own-writes without Strong consistency.
+The clients are identified by the identifiers assigned at connect`,
+		Export: true,
+	}
+	p.SessionStickyRouting.Init(base.mgr)
+
+	p.SessionStickyTTL = ParamItem{
+		Key:          "proxy.sessionConsistency.stickyTTL",
+		Version:      "2.4.0",
+		DefaultValue: "300",
+		Doc:          "seconds the routes and the write timestamps of an idle client are kept",
+		Export:       true,
+	}
+	p.SessionStickyTTL.Init(base.mgr)
+
+	p.MaxFederatedCollections = ParamItem{
+		Key:          "proxy.federatedSearch.maxCollections",
+		Version:      "2.4.0",
+		DefaultValue: "64",
+		Doc:          "max number of collections a federated search fans out to",
+		Export:       true,
+	}
+	p.MaxFederatedCollections.Init(base.mgr)
+
+	p.MutationBatchingEnabled = ParamItem{
+		Key:          "proxy.mutationBatching.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc: `whether to coalesce the small insert requests to the same shard within a window into larger messages,
+which reduces the messages produced for the writers sending few rows per request, at the cost of the window latency.
+Each request is still acknowledged by its own result`,
+		Export: true,
+	}
+	p.MutationBatchingEnabled.Init(base.mgr)
+
+	p.MutationBatchingWindow = ParamItem{
+		Key:          "proxy.mutationBatching.window",
+		Version:      "2.4.0",
+		DefaultValue: "5",
+		Doc:          "ms, the window the small insert requests are coalesced in",
+		Export:       true,
+	}
+	p.MutationBatchingWindow.Init(base.mgr)
+
+	p.MutationBatchingMaxRows = ParamItem{
+		Key:          "proxy.mutationBatching.maxRows",
+		Version:      "2.4.0",
+		DefaultValue: "1000",
+		Doc:          "the insert requests with more rows are produced directly, and a batch is produced once it reaches the rows",
+		Export:       true,
+	}
+	p.MutationBatchingMaxRows.Init(base.mgr)
+
+	p.DedupWindow = ParamItem{
+		Key:          "proxy.dedup.window",
+		Version:      "2.4.0",
+		DefaultValue: "3600",
+		Doc:          "seconds the inserted rows are deduplicated against, for the collections with collection.dedup.fields but no window",
+		Export:       true,
+	}
+	p.DedupWindow.Init(base.mgr)
+
+	p.DedupMaxEntries = ParamItem{
+		Key:          "proxy.dedup.maxEntries",
+		Version:      "2.4.0",
+		DefaultValue: "1000000",
+		Doc: `max number of the content hashes of the inserted rows kept per collection for the dedup at ingest,
+the earliest ones are forgotten beyond it. The hashes are kept by each proxy, so the duplicates inserted through different proxies are not found`,
+		Export: true,
+	}
+	p.DedupMaxEntries.Init(base.mgr)
+
+	p.QueryMaxScannedRows = ParamItem{
+		Key:          "proxy.queryLimits.maxScannedRows",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc: `max number of rows of the segments scanned by each search or query, 0 means unlimited.
+The requests may lower it by the max_scanned_rows param, and fail with the query limit exceeded error beyond it`,
+		Export: true,
+	}
+	p.QueryMaxScannedRows.Init(base.mgr)
+
+	p.QueryMaxResultBytes = ParamItem{
+		Key:          "proxy.queryLimits.maxResultBytes",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc: `max size in bytes of the results of each search or query, 0 means unlimited.
+The requests may lower it by the max_result_bytes param, and fail with the query limit exceeded error beyond it`,
+		Export: true,
+	}
+	p.QueryMaxResultBytes.Init(base.mgr)
+
+	p.QueryTimeoutClasses = ParamGroup{
+		KeyPrefix: "proxy.queryLimits.timeoutClasses.",
+		Version:   "2.4.0",
+		Doc:       "the timeouts in seconds of the classes the requests may choose by the timeout_class param",
+		Export:    true,
+	}
+	p.QueryTimeoutClasses.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -1260,6 +2247,7 @@ type queryCoordConfig struct {
 	ChannelCheckInterval       ParamItem `refreshable:"true"`
 	BalanceCheckInterval       ParamItem `refreshable:"true"`
 	IndexCheckInterval         ParamItem `refreshable:"true"`
+	IndexCheckFallbackInterval ParamItem `refreshable:"true"`
 	ChannelTaskTimeout         ParamItem `refreshable:"true"`
 	SegmentTaskTimeout         ParamItem `refreshable:"true"`
 	DistPullInterval           ParamItem `refreshable:"false"`
@@ -1286,6 +2274,9 @@ type queryCoordConfig struct {
 	ObserverTaskParallel           ParamItem `refreshable:"false"`
 	CheckAutoBalanceConfigInterval ParamItem `refreshable:"false"`
 	CheckNodeSessionInterval       ParamItem `refreshable:"false"`
+	EnableWarmupHints              ParamItem `refreshable:"true"`
+	FailureDomainLevel             ParamItem `refreshable:"true"`
+	FailureDomainConstraint        ParamItem `refreshable:"true"`
 }
 
 func (p *queryCoordConfig) init(base *BaseTable) {
@@ -1453,6 +2444,17 @@ func (p *queryCoordConfig) init(base *BaseTable) {
 	}
 	p.IndexCheckInterval.Init(base.mgr)
 
+	p.IndexCheckFallbackInterval = ParamItem{
+		Key:          "queryCoord.checkIndexFallbackInterval",
+		Version:      "2.4.0",
+		DefaultValue: "30000",
+		Doc: `interval in milliseconds to check the index states of all the loaded segments,
+only the segments notified by datacoord are checked between the full checks if common.indexReadyNotify.enable is true`,
+		PanicIfEmpty: true,
+		Export:       true,
+	}
+	p.IndexCheckFallbackInterval.Init(base.mgr)
+
 	p.ChannelTaskTimeout = ParamItem{
 		Key:          "queryCoord.channelTaskTimeout",
 		Version:      "2.0.0",
@@ -1636,6 +2638,34 @@ func (p *queryCoordConfig) init(base *BaseTable) {
 		Export:       true,
 	}
 	p.CheckNodeSessionInterval.Init(base.mgr)
+
+	p.EnableWarmupHints = ParamItem{
+		Key:          "queryCoord.enableWarmupHints",
+		Version:      "2.4.0",
+		DefaultValue: "true",
+		Doc:          "load the hot segments first and ship their access stats to the loading node, by the stats collected from the serving replicas",
+		Export:       true,
+	}
+	p.EnableWarmupHints.Init(base.mgr)
+
+	p.FailureDomainLevel = ParamItem{
+		Key:          "queryCoord.failureDomain.level",
+		Version:      "2.4.0",
+		DefaultValue: "zone",
+		Doc:          "the failure domain which the replicas of a collection are spread across, zone or rack",
+		Export:       true,
+	}
+	p.FailureDomainLevel.Init(base.mgr)
+
+	p.FailureDomainConstraint = ParamItem{
+		Key:          "queryCoord.failureDomain.constraint",
+		Version:      "2.4.0",
+		DefaultValue: "none",
+		Doc: `how the replicas are spread across the failure domains, options: none, soft, hard,
+soft spreads the replicas if possible, and hard fails the replica placement if the replicas would share a failure domain`,
+		Export: true,
+	}
+	p.FailureDomainConstraint.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -1643,6 +2673,32 @@ func (p *queryCoordConfig) init(base *BaseTable) {
 type queryNodeConfig struct {
 	SoPath ParamItem `refreshable:"false"`
 
+	// rerank plugin
+	RerankSoPath ParamItem `refreshable:"false"`
+	RerankConfig ParamItem `refreshable:"true"`
+
+	// local recovery
+	LocalRecoveryEnabled   ParamItem `refreshable:"false"`
+	LocalRecoveryRetention ParamItem `refreshable:"false"`
+
+	// fetch disk index files from peers
+	IndexPeerFetchEnabled   ParamItem `refreshable:"true"`
+	IndexPeerFetchChunkSize ParamItem `refreshable:"true"`
+
+	// retry the sub tasks of delegator failed by the transient segcore errors
+	SubTaskRetryAttempts ParamItem `refreshable:"true"`
+	SubTaskRetryInterval ParamItem `refreshable:"true"`
+
+	// search the candidate segments selected by a coarse search only
+	TwoPhaseSearchEnabled           ParamItem `refreshable:"true"`
+	TwoPhaseSearchMinSealedSegments ParamItem `refreshable:"true"`
+	TwoPhaseSearchCoarseParamRatio  ParamItem `refreshable:"true"`
+	TwoPhaseSearchCoarseTopKRatio   ParamItem `refreshable:"true"`
+
+	// reuse the segcore plans of the repeated filters
+	PlanCacheEnabled ParamItem `refreshable:"true"`
+	PlanCacheSize    ParamItem `refreshable:"false"`
+
 	FlowGraphMaxQueueLength ParamItem `refreshable:"false"`
 	FlowGraphMaxParallelism ParamItem `refreshable:"false"`
 
@@ -1657,6 +2713,8 @@ type queryNodeConfig struct {
 	InterimIndexNlist         ParamItem `refreshable:"false"`
 	InterimIndexNProbe        ParamItem `refreshable:"false"`
 	InterimIndexMemExpandRate ParamItem `refreshable:"false"`
+	DictionaryMaxCardinality  ParamItem `refreshable:"false"`
+	QuantizationRefineRatio   ParamItem `refreshable:"false"`
 
 	// memory limit
 	LoadMemoryUsageFactor               ParamItem `refreshable:"true"`
@@ -1675,12 +2733,46 @@ type queryNodeConfig struct {
 	// chunk cache
 	ReadAheadPolicy ParamItem `refreshable:"false"`
 
+	// disk cache
+	DiskCacheIndexReservedRatio      ParamItem `refreshable:"false"`
+	DiskCacheMmapReservedRatio       ParamItem `refreshable:"false"`
+	DiskCacheChunkCacheReservedRatio ParamItem `refreshable:"false"`
+
+	// memory governor
+	MemoryGovernorEnable                   ParamItem `refreshable:"false"`
+	MemoryGovernorBudgetRatio              ParamItem `refreshable:"false"`
+	MemoryGovernorChunkCacheReservedRatio  ParamItem `refreshable:"true"`
+	MemoryGovernorQueryBufferReservedRatio ParamItem `refreshable:"true"`
+
+	// gpu memory
+	GPUMemoryCapacity  ParamItem `refreshable:"false"`
+	GPUResidencyPolicy ParamItem `refreshable:"true"`
+
+	// request recorder
+	RequestRecorderSampleRate     ParamItem `refreshable:"true"`
+	RequestRecorderPath           ParamItem `refreshable:"false"`
+	RequestRecorderMaxFileSize    ParamItem `refreshable:"false"`
+	RequestRecorderRecordVectors  ParamItem `refreshable:"true"`
+	RequestRecorderRedactLiterals ParamItem `refreshable:"true"`
+
+	// memory watermark
+	MemoryWatermarkStopLoading         ParamItem `refreshable:"true"`
+	MemoryWatermarkEvictCache          ParamItem `refreshable:"true"`
+	MemoryWatermarkRejectLowPriority   ParamItem `refreshable:"true"`
+	MemoryWatermarkReleaseSegments     ParamItem `refreshable:"true"`
+	MemoryWatermarkLowPriorityRequests ParamItem `refreshable:"true"`
+	MemoryWatermarkCheckInterval       ParamItem `refreshable:"false"`
+
+	CPUQuotaCheckInterval ParamItem `refreshable:"false"`
+
 	GroupEnabled         ParamItem `refreshable:"true"`
 	MaxReceiveChanSize   ParamItem `refreshable:"false"`
 	MaxUnsolvedQueueSize ParamItem `refreshable:"true"`
 	MaxReadConcurrency   ParamItem `refreshable:"true"`
 	MaxGroupNQ           ParamItem `refreshable:"true"`
 	TopKMergeRatio       ParamItem `refreshable:"true"`
+	SearchBatchWindow    ParamItem `refreshable:"true"`
+	SearchBatchMaxNQ     ParamItem `refreshable:"true"`
 	CPURatio             ParamItem `refreshable:"true"`
 	MaxTimestampLag      ParamItem `refreshable:"true"`
 	GCEnabled            ParamItem `refreshable:"true"`
@@ -1690,31 +2782,160 @@ type queryNodeConfig struct {
 	MaximumGOGCConfig   ParamItem `refreshable:"false"`
 	GracefulStopTimeout ParamItem `refreshable:"false"`
 
-	// delete buffer
-	MaxSegmentDeleteBuffer ParamItem `refreshable:"false"`
+	// delete buffer
+	MaxSegmentDeleteBuffer ParamItem `refreshable:"false"`
+
+	// loader
+	IoPoolSize ParamItem `refreshable:"false"`
+
+	// schedule task policy.
+	SchedulePolicyName                    ParamItem `refreshable:"false"`
+	SchedulePolicyTaskQueueExpire         ParamItem `refreshable:"true"`
+	SchedulePolicyEnableCrossUserGrouping ParamItem `refreshable:"true"`
+	SchedulePolicyMaxPendingTaskPerUser   ParamItem `refreshable:"true"`
+
+	// CGOPoolSize ratio to MaxReadConcurrency
+	CGOPoolSizeRatio ParamItem `refreshable:"true"`
+
+	EnableWorkerSQCostMetrics ParamItem `refreshable:"true"`
+}
+
+func (p *queryNodeConfig) init(base *BaseTable) {
+	p.SoPath = ParamItem{
+		Key:          "queryNode.soPath",
+		Version:      "2.3.0",
+		DefaultValue: "",
+	}
+	p.SoPath.Init(base.mgr)
+
+	p.RerankSoPath = ParamItem{
+		Key:          "queryNode.rerank.soPath",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          "the path of the plugin to rerank the search results reduced by the shard delegator, empty to disable",
+		Export:       true,
+	}
+	p.RerankSoPath.Init(base.mgr)
+
+	p.RerankConfig = ParamItem{
+		Key:          "queryNode.rerank.config",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          "the config passed to the rerank plugin",
+		Export:       true,
+	}
+	p.RerankConfig.Init(base.mgr)
+
+	p.LocalRecoveryEnabled = ParamItem{
+		Key:          "queryNode.localRecovery.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc: `persist the disk indexes and the mmap files of fixed width fields loaded into a local catalog and keep them on stop,
+so that the query node restarted on the same machine reuses them instead of downloading again`,
+		Export: true,
+	}
+	p.LocalRecoveryEnabled.Init(base.mgr)
+
+	p.LocalRecoveryRetention = ParamItem{
+		Key:          "queryNode.localRecovery.retention",
+		Version:      "2.4.0",
+		DefaultValue: "600",
+		Doc:          "the disk indexes and mmap files recovered but not loaded again within the retention in seconds are removed",
+		Export:       true,
+	}
+	p.LocalRecoveryRetention.Init(base.mgr)
+
+	p.IndexPeerFetchEnabled = ParamItem{
+		Key:          "queryNode.indexPeerFetch.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc: `fetch the files of disk indexes from the query nodes serving the same segments, e.g. the other replicas,
+instead of downloading them from the object storage, fall back to the object storage if failed`,
+		Export: true,
+	}
+	p.IndexPeerFetchEnabled.Init(base.mgr)
+
+	p.IndexPeerFetchChunkSize = ParamItem{
+		Key:          "queryNode.indexPeerFetch.chunkSize",
+		Version:      "2.4.0",
+		DefaultValue: "4",
+		Doc:          "the size of the chunks of index files fetched from peers in MB, should be less than the max grpc message size",
+		Export:       true,
+	}
+	p.IndexPeerFetchChunkSize.Init(base.mgr)
+
+	p.SubTaskRetryAttempts = ParamItem{
+		Key:          "queryNode.subTaskRetry.attempts",
+		Version:      "2.4.0",
+		DefaultValue: "2",
+		Doc:          "max attempts of the search/query on each worker of delegator, if failed by the transient segcore errors, e.g. out of memory or index not loaded yet",
+		Export:       true,
+	}
+	p.SubTaskRetryAttempts.Init(base.mgr)
+
+	p.SubTaskRetryInterval = ParamItem{
+		Key:          "queryNode.subTaskRetry.interval",
+		Version:      "2.4.0",
+		DefaultValue: "100",
+		Doc:          "the interval in milliseconds before retrying the search/query on the worker of delegator",
+		Export:       true,
+	}
+	p.SubTaskRetryInterval.Init(base.mgr)
 
-	// loader
-	IoPoolSize ParamItem `refreshable:"false"`
+	p.TwoPhaseSearchEnabled = ParamItem{
+		Key:          "queryNode.twoPhaseSearch.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc: `search the sealed segments in two phases on delegator, the coarse phase searches all of them with the relaxed params,
+and the refine phase searches only the segments of the coarse results with the full params, which trades accuracy for latency`,
+		Export: true,
+	}
+	p.TwoPhaseSearchEnabled.Init(base.mgr)
 
-	// schedule task policy.
-	SchedulePolicyName                    ParamItem `refreshable:"false"`
-	SchedulePolicyTaskQueueExpire         ParamItem `refreshable:"true"`
-	SchedulePolicyEnableCrossUserGrouping ParamItem `refreshable:"true"`
-	SchedulePolicyMaxPendingTaskPerUser   ParamItem `refreshable:"true"`
+	p.TwoPhaseSearchMinSealedSegments = ParamItem{
+		Key:          "queryNode.twoPhaseSearch.minSealedSegments",
+		Version:      "2.4.0",
+		DefaultValue: "8",
+		Doc:          "the shards with less sealed segments are searched in one phase",
+		Export:       true,
+	}
+	p.TwoPhaseSearchMinSealedSegments.Init(base.mgr)
 
-	// CGOPoolSize ratio to MaxReadConcurrency
-	CGOPoolSizeRatio ParamItem `refreshable:"true"`
+	p.TwoPhaseSearchCoarseParamRatio = ParamItem{
+		Key:          "queryNode.twoPhaseSearch.coarseParamRatio",
+		Version:      "2.4.0",
+		DefaultValue: "0.25",
+		Doc:          "the ratio of the search params of the coarse phase to the full ones, e.g. nprobe, ef and search_list, the smaller the faster but less accurate",
+		Export:       true,
+	}
+	p.TwoPhaseSearchCoarseParamRatio.Init(base.mgr)
 
-	EnableWorkerSQCostMetrics ParamItem `refreshable:"true"`
-}
+	p.TwoPhaseSearchCoarseTopKRatio = ParamItem{
+		Key:          "queryNode.twoPhaseSearch.coarseTopKRatio",
+		Version:      "2.4.0",
+		DefaultValue: "2",
+		Doc:          "the ratio of the topk of the coarse phase to the one of the request, the larger the more candidate segments are refined",
+		Export:       true,
+	}
+	p.TwoPhaseSearchCoarseTopKRatio.Init(base.mgr)
 
-func (p *queryNodeConfig) init(base *BaseTable) {
-	p.SoPath = ParamItem{
-		Key:          "queryNode.soPath",
-		Version:      "2.3.0",
-		DefaultValue: "",
+	p.PlanCacheEnabled = ParamItem{
+		Key:          "queryNode.planCache.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "true",
+		Doc:          "whether query nodes reuse the segcore plans of the requests with the same serialized plan, the proxies bind the constants of the cached expression templates into the plans",
+		Export:       true,
 	}
-	p.SoPath.Init(base.mgr)
+	p.PlanCacheEnabled.Init(base.mgr)
+
+	p.PlanCacheSize = ParamItem{
+		Key:          "queryNode.planCache.size",
+		Version:      "2.4.0",
+		DefaultValue: "256",
+		Doc:          "max number of segcore plans cached by each collection on query node",
+		Export:       true,
+	}
+	p.PlanCacheSize.Init(base.mgr)
 
 	p.FlowGraphMaxQueueLength = ParamItem{
 		Key:          "queryNode.dataSync.flowGraph.maxQueueLength",
@@ -1823,6 +3044,25 @@ func (p *queryNodeConfig) init(base *BaseTable) {
 	}
 	p.InterimIndexNProbe.Init(base.mgr)
 
+	p.DictionaryMaxCardinality = ParamItem{
+		Key:          "queryNode.segcore.dictionaryMaxCardinality",
+		Version:      "2.4.0",
+		DefaultValue: "1024",
+		Doc:          "the string fields of sealed segments with no more distinct values are dictionary encoded in memory, 0 to disable",
+		Export:       true,
+	}
+	p.DictionaryMaxCardinality.Init(base.mgr)
+
+	p.QuantizationRefineRatio = ParamItem{
+		Key:          "queryNode.segcore.quantizationRefineRatio",
+		Version:      "2.4.0",
+		DefaultValue: "4",
+		Doc: `the search on the vectors quantized at load, see the vector.quantization property of collection,
+takes topk * refineRatio candidates by the quantized vectors, and refines them by the raw vectors`,
+		Export: true,
+	}
+	p.QuantizationRefineRatio.Init(base.mgr)
+
 	p.LoadMemoryUsageFactor = ParamItem{
 		Key:          "queryNode.loadMemoryUsageFactor",
 		Version:      "2.0.0",
@@ -1914,7 +3154,8 @@ Max read concurrency would be the value of ` + "hardware.GetCPUNum * maxReadConc
 It defaults to 2.0, which means max read concurrency would be the value of hardware.GetCPUNum * 2.
 Max read concurrency must greater than or equal to 1, and less than or equal to hardware.GetCPUNum * 100.
 (0, 100]`,
-		Export: true,
+		Validator: validateFloatRange(0, 100),
+		Export:    true,
 	}
 	p.MaxReadConcurrency.Init(base.mgr)
 
@@ -1942,6 +3183,25 @@ Max read concurrency must greater than or equal to 1, and less than or equal to
 	}
 	p.TopKMergeRatio.Init(base.mgr)
 
+	p.SearchBatchWindow = ParamItem{
+		Key:          "queryNode.grouping.batchWindow",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc: `the window in milliseconds to hold a small search request,
+so the requests arriving within the window could be merged into one segcore call, 0 to disable`,
+		Export: true,
+	}
+	p.SearchBatchWindow.Init(base.mgr)
+
+	p.SearchBatchMaxNQ = ParamItem{
+		Key:          "queryNode.grouping.batchMaxNQ",
+		Version:      "2.4.0",
+		DefaultValue: "16",
+		Doc:          "the search request with nq less than it is held in the batch window",
+		Export:       true,
+	}
+	p.SearchBatchMaxNQ.Init(base.mgr)
+
 	p.CPURatio = ParamItem{
 		Key:          "queryNode.scheduler.cpuRatio",
 		Version:      "2.0.0",
@@ -2054,6 +3314,7 @@ Max read concurrency must greater than or equal to 1, and less than or equal to
 		Version:      "2.3.0",
 		DefaultValue: "fifo",
 		Doc:          "Control how to schedule query/search read task in query node",
+		Validator:    validateOneOf("fifo", "user-task-polling"),
 	}
 	p.SchedulePolicyName.Init(base.mgr)
 	p.SchedulePolicyTaskQueueExpire = ParamItem{
@@ -2083,6 +3344,7 @@ Max read concurrency must greater than or equal to 1, and less than or equal to
 		Version:      "2.3.0",
 		DefaultValue: "2.0",
 		Doc:          "cgo pool size ratio to max read concurrency",
+		Validator:    validateFloatRange(0, math.MaxFloat64),
 	}
 	p.CGOPoolSizeRatio.Init(base.mgr)
 
@@ -2093,6 +3355,197 @@ Max read concurrency must greater than or equal to 1, and less than or equal to
 		Doc:          "whether use worker's cost to measure delegator's workload",
 	}
 	p.EnableWorkerSQCostMetrics.Init(base.mgr)
+
+	p.DiskCacheIndexReservedRatio = ParamItem{
+		Key:          "queryNode.diskCache.indexReservedRatio",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc:          "ratio of the disk capacity reserved for disk indexes, other categories can't occupy the reserved space",
+		Export:       true,
+	}
+	p.DiskCacheIndexReservedRatio.Init(base.mgr)
+
+	p.DiskCacheMmapReservedRatio = ParamItem{
+		Key:          "queryNode.diskCache.mmapReservedRatio",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc:          "ratio of the disk capacity reserved for mmap files, other categories can't occupy the reserved space",
+		Export:       true,
+	}
+	p.DiskCacheMmapReservedRatio.Init(base.mgr)
+
+	p.DiskCacheChunkCacheReservedRatio = ParamItem{
+		Key:          "queryNode.diskCache.chunkCacheReservedRatio",
+		Version:      "2.4.0",
+		DefaultValue: "0.1",
+		Doc:          "ratio of the disk capacity reserved for chunk cache, other categories can't occupy the reserved space",
+		Export:       true,
+	}
+	p.DiskCacheChunkCacheReservedRatio.Init(base.mgr)
+
+	p.MemoryGovernorEnable = ParamItem{
+		Key:          "queryNode.memoryGovernor.enable",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "whether to reject the loads and searches exceeding the memory budget instead of running into OOM",
+		Export:       true,
+	}
+	p.MemoryGovernorEnable.Init(base.mgr)
+
+	p.MemoryGovernorBudgetRatio = ParamItem{
+		Key:          "queryNode.memoryGovernor.budgetRatio",
+		Version:      "2.4.0",
+		DefaultValue: "0.9",
+		Doc:          "ratio of the total memory shared by segments, chunk cache and in-flight query buffers",
+		Export:       true,
+	}
+	p.MemoryGovernorBudgetRatio.Init(base.mgr)
+
+	p.MemoryGovernorChunkCacheReservedRatio = ParamItem{
+		Key:          "queryNode.memoryGovernor.chunkCacheReservedRatio",
+		Version:      "2.4.0",
+		DefaultValue: "0.1",
+		Doc:          "ratio of the memory budget reserved for chunk cache, other categories can't occupy the reserved memory",
+		Export:       true,
+	}
+	p.MemoryGovernorChunkCacheReservedRatio.Init(base.mgr)
+
+	p.MemoryGovernorQueryBufferReservedRatio = ParamItem{
+		Key:          "queryNode.memoryGovernor.queryBufferReservedRatio",
+		Version:      "2.4.0",
+		DefaultValue: "0.1",
+		Doc:          "ratio of the memory budget reserved for in-flight query buffers, other categories can't occupy the reserved memory",
+		Export:       true,
+	}
+	p.MemoryGovernorQueryBufferReservedRatio.Init(base.mgr)
+
+	p.GPUMemoryCapacity = ParamItem{
+		Key:          "queryNode.gpuMemory.capacity",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc:          "GPU memory in MB shared by the GPU indexes of all the devices, 0 means unlimited and no index is spilled",
+		Export:       true,
+	}
+	p.GPUMemoryCapacity.Init(base.mgr)
+
+	p.GPUResidencyPolicy = ParamItem{
+		Key:          "queryNode.gpuMemory.residencyPolicy",
+		Version:      "2.4.0",
+		DefaultValue: "lru",
+		Doc:          "residency policy of GPU indexes, pin keeps them in GPU memory, lru spills the least recently searched ones to host memory",
+		Export:       true,
+	}
+	p.GPUResidencyPolicy.Init(base.mgr)
+
+	p.RequestRecorderSampleRate = ParamItem{
+		Key:          "queryNode.requestRecorder.sampleRate",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc:          "ratio of the search/query requests recorded into the local log for the offline replay, 0 to disable",
+		Export:       true,
+	}
+	p.RequestRecorderSampleRate.Init(base.mgr)
+
+	p.RequestRecorderPath = ParamItem{
+		Key:          "queryNode.requestRecorder.path",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          "path of the log of the requests recorded, default is requests.log under localStorage.path",
+		Export:       true,
+	}
+	p.RequestRecorderPath.Init(base.mgr)
+
+	p.RequestRecorderMaxFileSize = ParamItem{
+		Key:          "queryNode.requestRecorder.maxFileSize",
+		Version:      "2.4.0",
+		DefaultValue: "256",
+		Doc:          "max size in MB of the log of the requests recorded, the log is rotated to the backup file once exceeded",
+		Export:       true,
+	}
+	p.RequestRecorderMaxFileSize.Init(base.mgr)
+
+	p.RequestRecorderRecordVectors = ParamItem{
+		Key:          "queryNode.requestRecorder.recordVectors",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "whether to record the query vectors, which are replayed by random vectors of the same shape if not recorded",
+		Export:       true,
+	}
+	p.RequestRecorderRecordVectors.Init(base.mgr)
+
+	p.RequestRecorderRedactLiterals = ParamItem{
+		Key:          "queryNode.requestRecorder.redactLiterals",
+		Version:      "2.4.0",
+		DefaultValue: "true",
+		Doc:          "whether to replace the string literals in the filters by their hashes, which keeps the distinct values distinct",
+		Export:       true,
+	}
+	p.RequestRecorderRedactLiterals.Init(base.mgr)
+
+	p.MemoryWatermarkStopLoading = ParamItem{
+		Key:          "queryNode.memoryWatermark.stopLoading",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc: `L1, ratio of the memory used to the total memory to stop loading new segments, 0 to disable the level,
+it replaces queryCoord.overloadedMemoryThresholdPercentage to check the memory predicted after loading once enabled`,
+		Export: true,
+	}
+	p.MemoryWatermarkStopLoading.Init(base.mgr)
+
+	p.MemoryWatermarkEvictCache = ParamItem{
+		Key:          "queryNode.memoryWatermark.evictCache",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc:          "L2, ratio of the memory used to the total memory to evict the least recently read chunk cache until the memory falls below it, 0 to disable the level",
+		Export:       true,
+	}
+	p.MemoryWatermarkEvictCache.Init(base.mgr)
+
+	p.MemoryWatermarkRejectLowPriority = ParamItem{
+		Key:          "queryNode.memoryWatermark.rejectLowPriority",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc:          "L3, ratio of the memory used to the total memory to reject the low priority requests, 0 to disable the level",
+		Export:       true,
+	}
+	p.MemoryWatermarkRejectLowPriority.Init(base.mgr)
+
+	p.MemoryWatermarkReleaseSegments = ParamItem{
+		Key:          "queryNode.memoryWatermark.releaseSegments",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Doc: `L4, ratio of the memory used to the total memory to release the largest sealed segments of the shards led by the query node
+until the memory falls below it, the released segments are loaded by other query nodes, 0 to disable the level`,
+		Export: true,
+	}
+	p.MemoryWatermarkReleaseSegments.Init(base.mgr)
+
+	p.MemoryWatermarkLowPriorityRequests = ParamItem{
+		Key:          "queryNode.memoryWatermark.lowPriorityRequests",
+		Version:      "2.4.0",
+		DefaultValue: "query",
+		Doc:          "the low priority requests rejected at L3, separated by comma, options: search, query",
+		Export:       true,
+	}
+	p.MemoryWatermarkLowPriorityRequests.Init(base.mgr)
+
+	p.MemoryWatermarkCheckInterval = ParamItem{
+		Key:          "queryNode.memoryWatermark.checkInterval",
+		Version:      "2.4.0",
+		DefaultValue: "5",
+		Doc:          "interval in seconds to check the memory watermark level",
+		Export:       true,
+	}
+	p.MemoryWatermarkCheckInterval.Init(base.mgr)
+
+	p.CPUQuotaCheckInterval = ParamItem{
+		Key:          "queryNode.cpuQuotaCheckInterval",
+		Version:      "2.4.0",
+		DefaultValue: "30",
+		Doc:          "interval in seconds to check the cpu quota of container, the pools sized by the cpu cores are resized once it changes, 0 to disable",
+		Export:       true,
+	}
+	p.CPUQuotaCheckInterval.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -2136,6 +3589,13 @@ type dataCoordConfig struct {
 	SingleCompactionExpiredLogMaxSize ParamItem `refreshable:"true"`
 	SingleCompactionDeltalogMaxNum    ParamItem `refreshable:"true"`
 	GlobalCompactionInterval          ParamItem `refreshable:"false"`
+	FieldStatsBackfill                ParamItem `refreshable:"true"`
+	CompactionTaskRetention           ParamItem `refreshable:"true"`
+
+	// Small segment compaction
+	SmallSegmentCompactionEnabled   ParamItem `refreshable:"true"`
+	SmallSegmentCompactionInterval  ParamItem `refreshable:"false"`
+	SmallSegmentCompactionThreshold ParamItem `refreshable:"true"`
 
 	// LevelZero Segment
 	EnableLevelZeroSegment                   ParamItem `refreshable:"false"`
@@ -2149,6 +3609,25 @@ type dataCoordConfig struct {
 	GCDropTolerance         ParamItem `refreshable:"false"`
 	EnableActiveStandby     ParamItem `refreshable:"false"`
 
+	// Scrubber
+	EnableScrubber        ParamItem `refreshable:"false"`
+	ScrubInterval         ParamItem `refreshable:"false"`
+	ScrubBatchSize        ParamItem `refreshable:"true"`
+	ScrubAutoRebuildIndex ParamItem `refreshable:"true"`
+	ScrubRestoreRootPath  ParamItem `refreshable:"true"`
+
+	// Channel latency
+	ChannelLatencyThreshold     ParamItem `refreshable:"true"`
+	ChannelLatencyCheckInterval ParamItem `refreshable:"false"`
+
+	// Database isolation
+	DatabaseIsolationRefreshInterval ParamItem `refreshable:"false"`
+
+	// Vector update
+	VectorUpdateMaxRunningJobs ParamItem `refreshable:"false"`
+	VectorUpdateJobRetention   ParamItem `refreshable:"true"`
+	VectorUpdateMemoryBudget   ParamItem `refreshable:"true"`
+
 	BindIndexNodeMode          ParamItem `refreshable:"false"`
 	IndexNodeAddress           ParamItem `refreshable:"false"`
 	WithCredential             ParamItem `refreshable:"false"`
@@ -2205,6 +3684,7 @@ func (p *dataCoordConfig) init(base *BaseTable) {
 		Version:      "2.0.0",
 		DefaultValue: "512",
 		Doc:          "Maximum size of a segment in MB",
+		Validator:    validatePositiveInt(),
 		Export:       true,
 	}
 	p.SegmentMaxSize.Init(base.mgr)
@@ -2418,6 +3898,51 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.SingleCompactionDeltalogMaxNum.Init(base.mgr)
 
+	p.FieldStatsBackfill = ParamItem{
+		Key:          "dataCoord.compaction.fieldStatsBackfill",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "whether to trigger single compaction on the flushed segments without field stats logs, so that their field stats are collected",
+		Export:       true,
+	}
+	p.FieldStatsBackfill.Init(base.mgr)
+
+	p.CompactionTaskRetention = ParamItem{
+		Key:          "dataCoord.compaction.taskRetention",
+		Version:      "2.4.0",
+		DefaultValue: "86400",
+		Doc:          "seconds the finished compaction tasks are retained in memory for inspection before purged, 0 means forever",
+		Export:       true,
+	}
+	p.CompactionTaskRetention.Init(base.mgr)
+
+	p.SmallSegmentCompactionEnabled = ParamItem{
+		Key:          "dataCoord.compaction.smallSegment.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "true",
+		Doc:          "whether to trigger merge compactions proactively on the collections accumulating many small sealed segments",
+		Export:       true,
+	}
+	p.SmallSegmentCompactionEnabled.Init(base.mgr)
+
+	p.SmallSegmentCompactionInterval = ParamItem{
+		Key:          "dataCoord.compaction.smallSegment.interval",
+		Version:      "2.4.0",
+		DefaultValue: "300",
+		Doc:          "the interval to report the small segments of collections, in seconds",
+		Export:       true,
+	}
+	p.SmallSegmentCompactionInterval.Init(base.mgr)
+
+	p.SmallSegmentCompactionThreshold = ParamItem{
+		Key:          "dataCoord.compaction.smallSegment.threshold",
+		Version:      "2.4.0",
+		DefaultValue: "16",
+		Doc:          "the merge compaction of a collection is triggered once its small sealed segments reach the threshold",
+		Export:       true,
+	}
+	p.SmallSegmentCompactionThreshold.Init(base.mgr)
+
 	p.GlobalCompactionInterval = ParamItem{
 		Key:          "dataCoord.compaction.global.interval",
 		Version:      "2.0.0",
@@ -2495,6 +4020,105 @@ During compaction, the size of segment # of rows is able to exceed segment max #
 	}
 	p.EnableActiveStandby.Init(base.mgr)
 
+	p.EnableScrubber = ParamItem{
+		Key:          "dataCoord.scrubber.enabled",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "whether to verify the binlog and index files of flushed segments in background",
+		Export:       true,
+	}
+	p.EnableScrubber.Init(base.mgr)
+
+	p.ScrubInterval = ParamItem{
+		Key:          "dataCoord.scrubber.interval",
+		Version:      "2.4.0",
+		DefaultValue: "600",
+		Doc:          "scrub interval in seconds",
+		Export:       true,
+	}
+	p.ScrubInterval.Init(base.mgr)
+
+	p.ScrubBatchSize = ParamItem{
+		Key:          "dataCoord.scrubber.batchSize",
+		Version:      "2.4.0",
+		DefaultValue: "10",
+		Doc:          "max number of segments verified in each scrub round",
+		Export:       true,
+	}
+	p.ScrubBatchSize.Init(base.mgr)
+
+	p.ScrubAutoRebuildIndex = ParamItem{
+		Key:          "dataCoord.scrubber.autoRebuildIndex",
+		Version:      "2.4.0",
+		DefaultValue: "true",
+		Doc:          "whether to rebuild the segment index once its files are found corrupted",
+		Export:       true,
+	}
+	p.ScrubAutoRebuildIndex.Init(base.mgr)
+
+	p.ScrubRestoreRootPath = ParamItem{
+		Key:          "dataCoord.scrubber.restoreRootPath",
+		Version:      "2.4.0",
+		DefaultValue: "",
+		Doc:          "root path of the binlog backup in the same bucket, corrupted binlogs are restored from the backup copy if its checksum matches",
+		Export:       true,
+	}
+	p.ScrubRestoreRootPath.Init(base.mgr)
+
+	p.ChannelLatencyThreshold = ParamItem{
+		Key:          "dataCoord.channelLatency.threshold",
+		Version:      "2.4.0",
+		DefaultValue: "60",
+		Doc:          "the channel is reported as lagging behind if its timetick is not consumed within the threshold, in seconds",
+		Export:       true,
+	}
+	p.ChannelLatencyThreshold.Init(base.mgr)
+
+	p.ChannelLatencyCheckInterval = ParamItem{
+		Key:          "dataCoord.channelLatency.checkInterval",
+		Version:      "2.4.0",
+		DefaultValue: "10",
+		Doc:          "the interval to refresh the backlog metrics of channels, in seconds",
+		Export:       true,
+	}
+	p.ChannelLatencyCheckInterval.Init(base.mgr)
+
+	p.DatabaseIsolationRefreshInterval = ParamItem{
+		Key:          "dataCoord.databaseIsolation.refreshInterval",
+		Version:      "2.4.0",
+		DefaultValue: "30",
+		Doc:          "the interval to reload the dml channels and datanodes reserved by databases, in seconds",
+		Export:       true,
+	}
+	p.DatabaseIsolationRefreshInterval.Init(base.mgr)
+
+	p.VectorUpdateMaxRunningJobs = ParamItem{
+		Key:          "dataCoord.vectorUpdate.maxRunningJobs",
+		Version:      "2.4.0",
+		DefaultValue: "2",
+		Doc:          "max number of vector updates running at the same time, the others are pending",
+		Export:       true,
+	}
+	p.VectorUpdateMaxRunningJobs.Init(base.mgr)
+
+	p.VectorUpdateJobRetention = ParamItem{
+		Key:          "dataCoord.vectorUpdate.jobRetention",
+		Version:      "2.4.0",
+		DefaultValue: "86400",
+		Doc:          "seconds the finished vector updates are retained in memory for the state queries",
+		Export:       true,
+	}
+	p.VectorUpdateJobRetention.Init(base.mgr)
+
+	p.VectorUpdateMemoryBudget = ParamItem{
+		Key:          "dataCoord.vectorUpdate.memoryBudget",
+		Version:      "2.4.0",
+		DefaultValue: "512",
+		Doc:          "max size of the new vectors held in memory by a vector update in MB, the segments are rewritten in rounds within it",
+		Export:       true,
+	}
+	p.VectorUpdateMemoryBudget.Init(base.mgr)
+
 	p.MinSegmentNumRowsToEnableIndex = ParamItem{
 		Key:          "indexCoord.segment.minSegmentNumRowsToEnableIndex",
 		Version:      "2.0.0",
@@ -2697,6 +4321,7 @@ func (p *dataNodeConfig) init(base *BaseTable) {
 		Version:      "2.3.4",
 		DefaultValue: "64",
 		Doc:          "The max concurrent sync task number of datanode sync mgr globally",
+		Validator:    validatePositiveInt(),
 		Export:       true,
 	}
 	p.MaxParallelSyncMgrTasks.Init(base.mgr)
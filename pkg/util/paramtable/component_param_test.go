@@ -108,6 +108,39 @@ func TestComponentParam(t *testing.T) {
 
 		params.Save("common.preCreatedTopic.timeticker", "timeticker")
 		assert.Equal(t, []string{"timeticker"}, Params.TimeTicker.GetAsStrings())
+
+		assert.Equal(t, 0, Params.MetricsCollectionLabelLimit.GetAsInt())
+		params.Save("common.metrics.detailedCollections", "c1,c2")
+		assert.Equal(t, []string{"c1", "c2"}, Params.MetricsDetailedCollections.GetAsStrings())
+
+		assert.False(t, Params.MetricsOTLPEnable.GetAsBool())
+		assert.Equal(t, "grpc", Params.MetricsOTLPProtocol.GetValue())
+		assert.Equal(t, 30*time.Second, Params.MetricsOTLPInterval.GetAsDuration(time.Second))
+		params.Save("common.metrics.otlp.headers", `{"Authorization": "Bearer token"}`)
+		assert.Equal(t, "Bearer token", Params.MetricsOTLPHeaders.GetAsJSONMap()["Authorization"])
+
+		assert.False(t, Params.EventBusEnable.GetAsBool())
+		assert.Equal(t, []string{"log"}, Params.EventBusSinks.GetAsStrings())
+		assert.Equal(t, Params.ClusterPrefix.GetValue()+"-events", Params.EventBusMQTopic.GetValue())
+		assert.Equal(t, uint(3), Params.EventBusWebhookRetryTimes.GetAsUint())
+
+		assert.True(t, Params.IndexReadyNotifyEnable.GetAsBool())
+		assert.Equal(t, "", Params.ManagementToken.GetValue())
+
+		assert.False(t, Params.DegradedModeEnable.GetAsBool())
+		assert.Equal(t, 10*time.Second, Params.DegradedModeCheckInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 3, Params.DegradedModeRecoverRounds.GetAsInt())
+		assert.Equal(t, 30*time.Second, Params.DegradedModeTSafeStaleThreshold.GetAsDuration(time.Second))
+
+		assert.Equal(t, 10*time.Second, Params.FeatureGateRefreshInterval.GetAsDuration(time.Second))
+		assert.Equal(t, "", Params.FeatureGateDisabled.GetValue())
+
+		assert.Equal(t, "", Params.FailureDomainZone.GetValue())
+		assert.Equal(t, "", Params.FailureDomainRack.GetValue())
+		assert.Equal(t, "", Params.FailureDomainMetadataURL.GetValue())
+
+		assert.Equal(t, 10*time.Second, Params.TimeTickSkewThreshold.GetAsDuration(time.Second))
+		assert.Equal(t, 10*time.Second, Params.TimeTickSkewCheckInterval.GetAsDuration(time.Second))
 	})
 
 	t.Run("test rootCoordConfig", func(t *testing.T) {
@@ -121,6 +154,14 @@ func TestComponentParam(t *testing.T) {
 		t.Logf("master ImportTaskRetention = %f", Params.ImportTaskRetention.GetAsFloat())
 		assert.Equal(t, Params.EnableActiveStandby.GetAsBool(), false)
 		t.Logf("rootCoord EnableActiveStandby = %t", Params.EnableActiveStandby.GetAsBool())
+		assert.False(t, Params.DDLEventLogEnabled.GetAsBool())
+		assert.Equal(t, 60*time.Second, Params.DDLEventLogWatermarkInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 168, Params.DDLEventLogWatermarkRetention.GetAsInt())
+		assert.Equal(t, 720, Params.DDLEventLogRetention.GetAsInt())
+		assert.False(t, Params.HotStandbyEnable.GetAsBool())
+		assert.Equal(t, time.Second, Params.HotStandbySyncInterval.GetAsDuration(time.Millisecond))
+		assert.Equal(t, time.Minute, Params.PartitionRolloverCheckInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 1, Params.PartitionRolloverPrecreate.GetAsInt())
 
 		SetCreateTime(time.Now())
 		SetUpdateTime(time.Now())
@@ -164,6 +205,59 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, Params.CostMetricsExpireTime.GetAsInt(), 1000)
 		assert.Equal(t, Params.RetryTimesOnReplica.GetAsInt(), 2)
 		assert.EqualValues(t, Params.HealthCheckTimeout.GetAsInt64(), 3000)
+		assert.Equal(t, 1000, Params.ExportBatchSize.GetAsInt())
+		assert.Equal(t, 100000, Params.ExportRowsPerFile.GetAsInt())
+		assert.Equal(t, 2, Params.ExportMaxRunningJobs.GetAsInt())
+		assert.Equal(t, 24*time.Hour, Params.ExportJobRetention.GetAsDuration(time.Second))
+		assert.Equal(t, 10000, Params.AnalyzeSampleSize.GetAsInt())
+		assert.Equal(t, int64(1000000), Params.AnalyzeMaxScanRows.GetAsInt64())
+		assert.Equal(t, 1, Params.AnalyzeMaxRunningJobs.GetAsInt())
+		assert.Equal(t, 1048576, Params.VectorURLThreshold.GetAsInt())
+		assert.Equal(t, 3600, Params.VectorURLExpiry.GetAsInt())
+		assert.True(t, Params.VectorURLRemoveExpired.GetAsBool())
+		assert.Equal(t, 60, Params.TxnTimeout.GetAsInt())
+		assert.Equal(t, 1024, Params.TxnMaxNum.GetAsInt())
+		assert.Equal(t, 30*time.Second, Params.HealthProbe.Interval.GetAsDuration(time.Millisecond))
+		assert.Equal(t, 10, Params.ShardRetryBackoffInitial.GetAsInt())
+		assert.Equal(t, 3000, Params.ShardRetryBackoffMax.GetAsInt())
+		assert.Equal(t, 0.2, Params.ShardRetryBackoffJitter.GetAsFloat())
+		assert.Equal(t, 16, Params.ShardRetryBudget.GetAsInt())
+		assert.True(t, Params.ArrowInsertPayloadEnabled.GetAsBool())
+		assert.True(t, Params.ExprCacheEnabled.GetAsBool())
+		assert.Equal(t, 1024, Params.ExprCacheSize.GetAsInt())
+		assert.False(t, Params.SystemFieldFilterEnabled.GetAsBool())
+		assert.Equal(t, 10000, Params.DeleteBatchSize.GetAsInt())
+		assert.Equal(t, 3600*time.Second, Params.DeleteJobRetention.GetAsDuration(time.Second))
+		assert.Equal(t, 0, Params.MaxConnectionsPerClient.GetAsInt())
+		assert.Equal(t, 0, Params.MaxInFlightPerClient.GetAsInt())
+		assert.Equal(t, 0, Params.MaxInFlightPerUser.GetAsInt())
+		assert.Equal(t, time.Second, Params.ClientLimitRetryAfter.GetAsDuration(time.Second))
+		assert.False(t, Params.SessionStickyRouting.GetAsBool())
+		assert.Equal(t, 300*time.Second, Params.SessionStickyTTL.GetAsDuration(time.Second))
+		assert.Equal(t, 64, Params.MaxFederatedCollections.GetAsInt())
+		assert.False(t, Params.MutationBatchingEnabled.GetAsBool())
+		assert.Equal(t, 5*time.Millisecond, Params.MutationBatchingWindow.GetAsDuration(time.Millisecond))
+		assert.Equal(t, 1000, Params.MutationBatchingMaxRows.GetAsInt())
+		assert.Equal(t, time.Hour, Params.DedupWindow.GetAsDuration(time.Second))
+		assert.Equal(t, 1000000, Params.DedupMaxEntries.GetAsInt())
+		assert.Equal(t, int64(0), Params.QueryMaxScannedRows.GetAsInt64())
+		assert.Equal(t, int64(0), Params.QueryMaxResultBytes.GetAsInt64())
+	})
+
+	t.Run("test proxy slow log config", func(t *testing.T) {
+		Params := &params.ProxyCfg
+		assert.False(t, Params.SlowLog.Enable.GetAsBool())
+		assert.Equal(t, 5000, Params.SlowLog.SearchThreshold.GetAsInt())
+		assert.Equal(t, 5000, Params.SlowLog.QueryThreshold.GetAsInt())
+		assert.Equal(t, 5000, Params.SlowLog.InsertThreshold.GetAsInt())
+		assert.Empty(t, Params.SlowLog.CollectionThresholds.GetAsJSONMap())
+		assert.Equal(t, 1.0, Params.SlowLog.SampleRatio.GetAsFloat())
+		assert.Equal(t, 200, Params.SlowLog.RecentSize.GetAsInt())
+		assert.Equal(t, "slow.log", Params.SlowLog.Filename.GetValue())
+
+		params.Save(Params.SlowLog.CollectionThresholds.Key, `{"db1.c1": "100"}`)
+		assert.Equal(t, map[string]string{"db1.c1": "100"}, Params.SlowLog.CollectionThresholds.GetAsJSONMap())
+		params.Reset(Params.SlowLog.CollectionThresholds.Key)
 	})
 
 	// t.Run("test proxyConfig panic", func(t *testing.T) {
@@ -278,9 +372,13 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 1000, Params.ChannelCheckInterval.GetAsInt())
 		assert.Equal(t, 10000, Params.BalanceCheckInterval.GetAsInt())
 		assert.Equal(t, 10000, Params.IndexCheckInterval.GetAsInt())
+		assert.Equal(t, 30000, Params.IndexCheckFallbackInterval.GetAsInt())
 		assert.Equal(t, 3, Params.CollectionRecoverTimesLimit.GetAsInt())
 		assert.Equal(t, false, Params.AutoBalance.GetAsBool())
 		assert.Equal(t, 10, Params.CheckAutoBalanceConfigInterval.GetAsInt())
+		assert.True(t, Params.EnableWarmupHints.GetAsBool())
+		assert.Equal(t, "zone", Params.FailureDomainLevel.GetValue())
+		assert.Equal(t, "none", Params.FailureDomainConstraint.GetValue())
 	})
 
 	t.Run("test queryNodeConfig", func(t *testing.T) {
@@ -345,6 +443,51 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, int64(100), gracefulStopTimeout.GetAsInt64())
 
 		assert.Equal(t, false, Params.EnableWorkerSQCostMetrics.GetAsBool())
+
+		assert.Equal(t, 0.0, Params.DiskCacheIndexReservedRatio.GetAsFloat())
+		assert.Equal(t, 0.0, Params.DiskCacheMmapReservedRatio.GetAsFloat())
+		assert.Equal(t, 0.1, Params.DiskCacheChunkCacheReservedRatio.GetAsFloat())
+
+		assert.False(t, Params.MemoryGovernorEnable.GetAsBool())
+		assert.Equal(t, 0.9, Params.MemoryGovernorBudgetRatio.GetAsFloat())
+		assert.Equal(t, 0.1, Params.MemoryGovernorChunkCacheReservedRatio.GetAsFloat())
+		assert.Equal(t, 0.1, Params.MemoryGovernorQueryBufferReservedRatio.GetAsFloat())
+		assert.Equal(t, int64(1024), Params.DictionaryMaxCardinality.GetAsInt64())
+		assert.Equal(t, int64(4), Params.QuantizationRefineRatio.GetAsInt64())
+		assert.Equal(t, int64(0), Params.GPUMemoryCapacity.GetAsInt64())
+		assert.Equal(t, "lru", Params.GPUResidencyPolicy.GetValue())
+		assert.Equal(t, 0.0, Params.RequestRecorderSampleRate.GetAsFloat())
+		assert.Equal(t, "", Params.RequestRecorderPath.GetValue())
+		assert.Equal(t, int64(256), Params.RequestRecorderMaxFileSize.GetAsInt64())
+		assert.False(t, Params.RequestRecorderRecordVectors.GetAsBool())
+		assert.True(t, Params.RequestRecorderRedactLiterals.GetAsBool())
+
+		assert.Equal(t, 0.0, Params.MemoryWatermarkStopLoading.GetAsFloat())
+		assert.Equal(t, 0.0, Params.MemoryWatermarkEvictCache.GetAsFloat())
+		assert.Equal(t, 0.0, Params.MemoryWatermarkRejectLowPriority.GetAsFloat())
+		assert.Equal(t, 0.0, Params.MemoryWatermarkReleaseSegments.GetAsFloat())
+		assert.Equal(t, []string{"query"}, Params.MemoryWatermarkLowPriorityRequests.GetAsStrings())
+		assert.Equal(t, 5*time.Second, Params.MemoryWatermarkCheckInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 30*time.Second, Params.CPUQuotaCheckInterval.GetAsDuration(time.Second))
+
+		assert.Equal(t, time.Duration(0), Params.SearchBatchWindow.GetAsDuration(time.Millisecond))
+		assert.Equal(t, int64(16), Params.SearchBatchMaxNQ.GetAsInt64())
+
+		assert.Equal(t, "", Params.RerankSoPath.GetValue())
+		assert.Equal(t, "", Params.RerankConfig.GetValue())
+
+		assert.False(t, Params.LocalRecoveryEnabled.GetAsBool())
+		assert.Equal(t, 600*time.Second, Params.LocalRecoveryRetention.GetAsDuration(time.Second))
+		assert.False(t, Params.IndexPeerFetchEnabled.GetAsBool())
+		assert.Equal(t, int64(4), Params.IndexPeerFetchChunkSize.GetAsInt64())
+		assert.Equal(t, 2, Params.SubTaskRetryAttempts.GetAsInt())
+		assert.Equal(t, 100*time.Millisecond, Params.SubTaskRetryInterval.GetAsDuration(time.Millisecond))
+		assert.False(t, Params.TwoPhaseSearchEnabled.GetAsBool())
+		assert.Equal(t, 8, Params.TwoPhaseSearchMinSealedSegments.GetAsInt())
+		assert.Equal(t, 0.25, Params.TwoPhaseSearchCoarseParamRatio.GetAsFloat())
+		assert.Equal(t, 2.0, Params.TwoPhaseSearchCoarseTopKRatio.GetAsFloat())
+		assert.True(t, Params.PlanCacheEnabled.GetAsBool())
+		assert.Equal(t, 256, Params.PlanCacheSize.GetAsInt())
 	})
 
 	t.Run("test dataCoordConfig", func(t *testing.T) {
@@ -357,6 +500,27 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, false, Params.AutoBalance.GetAsBool())
 		assert.Equal(t, 10, Params.CheckAutoBalanceConfigInterval.GetAsInt())
 		assert.Equal(t, false, Params.AutoUpgradeSegmentIndex.GetAsBool())
+
+		assert.False(t, Params.FieldStatsBackfill.GetAsBool())
+		assert.Equal(t, 24*time.Hour, Params.CompactionTaskRetention.GetAsDuration(time.Second))
+
+		assert.True(t, Params.SmallSegmentCompactionEnabled.GetAsBool())
+		assert.Equal(t, 300*time.Second, Params.SmallSegmentCompactionInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 16, Params.SmallSegmentCompactionThreshold.GetAsInt())
+
+		assert.False(t, Params.EnableScrubber.GetAsBool())
+		assert.Equal(t, 600*time.Second, Params.ScrubInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 10, Params.ScrubBatchSize.GetAsInt())
+		assert.True(t, Params.ScrubAutoRebuildIndex.GetAsBool())
+		assert.Equal(t, "", Params.ScrubRestoreRootPath.GetValue())
+
+		assert.Equal(t, 60*time.Second, Params.ChannelLatencyThreshold.GetAsDuration(time.Second))
+		assert.Equal(t, 10*time.Second, Params.ChannelLatencyCheckInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 30*time.Second, Params.DatabaseIsolationRefreshInterval.GetAsDuration(time.Second))
+
+		assert.Equal(t, 2, Params.VectorUpdateMaxRunningJobs.GetAsInt())
+		assert.Equal(t, 24*time.Hour, Params.VectorUpdateJobRetention.GetAsDuration(time.Second))
+		assert.Equal(t, 512, Params.VectorUpdateMemoryBudget.GetAsInt())
 	})
 
 	t.Run("test dataNodeConfig", func(t *testing.T) {
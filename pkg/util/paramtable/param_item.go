@@ -33,6 +33,8 @@ type ParamItem struct {
 
 	Formatter func(originValue string) string
 	Forbidden bool
+	// Validator checks the dynamic updates, the invalid ones are rejected
+	Validator config.Validator
 
 	manager *config.Manager
 
@@ -45,6 +47,9 @@ func (pi *ParamItem) Init(manager *config.Manager) {
 	if pi.Forbidden {
 		pi.manager.ForbidUpdate(pi.Key)
 	}
+	if pi.Validator != nil {
+		pi.manager.RegisterValidator(pi.Key, pi.Validator)
+	}
 }
 
 // Get original value with error
@@ -231,3 +236,67 @@ func getAndConvert[T any](v string, converter func(input string) (T, error), def
 	}
 	return t
 }
+
+// validateFloatRange returns a validator accepting the float values in (lower, upper].
+func validateFloatRange(lower, upper float64) config.Validator {
+	return func(value string) error {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s is not a float", value)
+		}
+		if v <= lower || v > upper {
+			return fmt.Errorf("%s is out of range (%v, %v]", value, lower, upper)
+		}
+		return nil
+	}
+}
+
+// validatePositiveInt returns a validator accepting the positive integers.
+func validatePositiveInt() config.Validator {
+	return func(value string) error {
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s is not an integer", value)
+		}
+		if v <= 0 {
+			return fmt.Errorf("%s is not positive", value)
+		}
+		return nil
+	}
+}
+
+// validateIntRange returns a validator accepting the integers in [lower, upper].
+func validateIntRange(lower, upper int64) config.Validator {
+	return func(value string) error {
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s is not an integer", value)
+		}
+		if v < lower || v > upper {
+			return fmt.Errorf("%s is out of range [%d, %d]", value, lower, upper)
+		}
+		return nil
+	}
+}
+
+// validateBool returns a validator accepting the boolean values.
+func validateBool() config.Validator {
+	return func(value string) error {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s is not a boolean", value)
+		}
+		return nil
+	}
+}
+
+// validateOneOf returns a validator accepting the candidates, case-insensitive.
+func validateOneOf(candidates ...string) config.Validator {
+	return func(value string) error {
+		for _, candidate := range candidates {
+			if strings.EqualFold(value, candidate) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s is not one of %v", value, candidates)
+	}
+}
@@ -0,0 +1,65 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamValidator(t *testing.T) {
+	validate := validateFloatRange(0, 100)
+	assert.NoError(t, validate("0.5"))
+	assert.NoError(t, validate("100"))
+	assert.Error(t, validate("0"))
+	assert.Error(t, validate("100.1"))
+	assert.Error(t, validate("abc"))
+
+	validate = validatePositiveInt()
+	assert.NoError(t, validate("1"))
+	assert.Error(t, validate("0"))
+	assert.Error(t, validate("1.5"))
+
+	validate = validateIntRange(0, 10)
+	assert.NoError(t, validate("0"))
+	assert.NoError(t, validate("10"))
+	assert.Error(t, validate("11"))
+	assert.Error(t, validate("-1"))
+	assert.Error(t, validate("abc"))
+
+	validate = validateBool()
+	assert.NoError(t, validate("true"))
+	assert.NoError(t, validate("False"))
+	assert.Error(t, validate("yes"))
+
+	validate = validateOneOf("fifo", "user-task-polling")
+	assert.NoError(t, validate("fifo"))
+	assert.NoError(t, validate("FIFO"))
+	assert.Error(t, validate("lifo"))
+
+	Init()
+	status := GetBaseTable().GetValueStatus()
+	keys := make([]string, 0, len(status))
+	for _, s := range status {
+		keys = append(keys, s.Key)
+	}
+	assert.Contains(t, keys, Get().QueryNodeCfg.MaxReadConcurrency.Key)
+	assert.Contains(t, keys, Get().DataNodeCfg.MaxParallelSyncMgrTasks.Key)
+	assert.Contains(t, keys, Get().LogCfg.Level.Key)
+	assert.Contains(t, keys, Get().QuotaConfig.QuotaAndLimitsEnabled.Key)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -72,6 +73,7 @@ type quotaConfig struct {
 	DMLMinBulkLoadRate              ParamItem `refreshable:"true"`
 	DMLMaxInsertRatePerCollection   ParamItem `refreshable:"true"`
 	DMLMinInsertRatePerCollection   ParamItem `refreshable:"true"`
+	DMLInsertBurstPerCollection     ParamItem `refreshable:"true"`
 	DMLMaxUpsertRatePerCollection   ParamItem `refreshable:"true"`
 	DMLMinUpsertRatePerCollection   ParamItem `refreshable:"true"`
 	DMLMaxDeleteRatePerCollection   ParamItem `refreshable:"true"`
@@ -123,6 +125,19 @@ type quotaConfig struct {
 	ResultProtectionEnabled ParamItem `refreshable:"true"`
 	MaxReadResultRate       ParamItem `refreshable:"true"`
 	CoolOffSpeed            ParamItem `refreshable:"true"`
+
+	// resource groups
+	ResourceGroupQuotas ParamGroup `refreshable:"true"`
+}
+
+// ResourceGroupQuota is the quota shared by the collections loaded in a resource group of querynodes.
+type ResourceGroupQuota struct {
+	// MaxSearchRate is the search rate of the group, vps
+	MaxSearchRate float64
+	// MaxQueryRate is the query rate of the group, qps
+	MaxQueryRate float64
+	// MemoryQuota is the memory the querynodes of the group could use, bytes
+	MemoryQuota float64
 }
 
 func (p *quotaConfig) init(base *BaseTable) {
@@ -131,6 +146,7 @@ func (p *quotaConfig) init(base *BaseTable) {
 		Version:      "2.2.0",
 		DefaultValue: "false",
 		Doc:          "`true` to enable quota and limits, `false` to disable.",
+		Validator:    validateBool(),
 		Export:       true,
 	}
 	p.QuotaAndLimitsEnabled.Init(base.mgr)
@@ -383,6 +399,28 @@ The maximum rate will not be greater than ` + "max" + `.`,
 	}
 	p.DMLMinInsertRatePerCollection.Init(base.mgr)
 
+	p.DMLInsertBurstPerCollection = ParamItem{
+		Key:          "quotaAndLimits.dml.insertRate.collection.burst",
+		Version:      "2.4.0",
+		DefaultValue: "0",
+		Formatter: func(v string) string {
+			if !p.DMLLimitEnabled.GetAsBool() {
+				return "0"
+			}
+			burst := megaBytes2Bytes(getAsFloat(v))
+			// [0, inf)
+			if burst < 0 {
+				return "0"
+			}
+			return fmt.Sprintf("%f", burst)
+		},
+		Doc: `MB, the bucket size of the token bucket limiting the insert rate of each collection,
+the inserts are allowed in bursts up to it, then shaped to the rate.
+0 means no token bucket, the burst is the same as the rate`,
+		Export: true,
+	}
+	p.DMLInsertBurstPerCollection.Init(base.mgr)
+
 	p.DMLMaxUpsertRate = ParamItem{
 		Key:          "quotaAndLimits.dml.upsertRate.max",
 		Version:      "2.3.0",
@@ -1252,6 +1290,50 @@ MB/s, default no limit`,
 		Export: true,
 	}
 	p.CoolOffSpeed.Init(base.mgr)
+
+	p.ResourceGroupQuotas = ParamGroup{
+		KeyPrefix: "quotaAndLimits.resourceGroups.",
+		Version:   "2.4.0",
+		Doc:       "the quotas of resource groups, keyed by the resource group name",
+	}
+	p.ResourceGroupQuotas.Init(base.mgr)
+}
+
+// GetResourceGroupQuotas returns the quotas of resource groups, keyed by the lower cased resource group name
+// as the config keys are case insensitive, the limits absent or negative are unlimited.
+func (p *quotaConfig) GetResourceGroupQuotas() map[string]ResourceGroupQuota {
+	quotas := make(map[string]ResourceGroupQuota)
+	for key, value := range p.ResourceGroupQuotas.GetValue() {
+		idx := strings.Index(key, ".")
+		if idx <= 0 {
+			continue
+		}
+		name := key[:idx]
+		quota, ok := quotas[name]
+		if !ok {
+			quota = ResourceGroupQuota{MaxSearchRate: defaultMax, MaxQueryRate: defaultMax, MemoryQuota: defaultMax}
+		}
+		limit, err := strconv.ParseFloat(value, 64)
+		if err != nil || limit < 0 {
+			limit = defaultMax
+		}
+		// the keys are lower cased by the config manager
+		switch strings.ToLower(key[idx+1:]) {
+		case "searchrate.max":
+			quota.MaxSearchRate = limit
+		case "queryrate.max":
+			quota.MaxQueryRate = limit
+		case "memoryquota":
+			if limit != defaultMax {
+				limit = megaBytes2Bytes(limit)
+			}
+			quota.MemoryQuota = limit
+		default:
+			continue
+		}
+		quotas[name] = quota
+	}
+	return quotas
 }
 
 func megaBytes2Bytes(f float64) float64 {
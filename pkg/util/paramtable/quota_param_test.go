@@ -90,6 +90,13 @@ func TestQuotaParam(t *testing.T) {
 		assert.Equal(t, float64(10)*1024*1024, params.QuotaConfig.DMLMaxBulkLoadRatePerCollection.GetAsFloat())
 		assert.Equal(t, float64(1)*1024*1024, params.QuotaConfig.DMLMinBulkLoadRatePerCollection.GetAsFloat())
 
+		// test insert burst
+		assert.Equal(t, float64(0), params.QuotaConfig.DMLInsertBurstPerCollection.GetAsFloat())
+		params.Save(params.QuotaConfig.DMLInsertBurstPerCollection.Key, "20")
+		assert.Equal(t, float64(20)*1024*1024, params.QuotaConfig.DMLInsertBurstPerCollection.GetAsFloat())
+		params.Save(params.QuotaConfig.DMLInsertBurstPerCollection.Key, "-1")
+		assert.Equal(t, float64(0), params.QuotaConfig.DMLInsertBurstPerCollection.GetAsFloat())
+
 		// test only set global rate limit
 		params.Save(params.QuotaConfig.DMLMaxInsertRatePerCollection.Key, "-1")
 		params.Save(params.QuotaConfig.DMLMinInsertRatePerCollection.Key, "-1")
@@ -205,6 +212,25 @@ func TestQuotaParam(t *testing.T) {
 		assert.Equal(t, 0.9, qc.CoolOffSpeed.GetAsFloat())
 	})
 
+	t.Run("test resource group quotas", func(t *testing.T) {
+		assert.Empty(t, qc.GetResourceGroupQuotas())
+		baseParams.SaveGroup(map[string]string{
+			"quotaAndLimits.resourceGroups.rg1.searchRate.max": "100",
+			"quotaAndLimits.resourceGroups.rg1.memoryQuota":    "1024",
+			"quotaAndLimits.resourceGroups.rg2.queryRate.max":  "-1",
+		})
+		defer baseParams.Reset("quotaAndLimits.resourceGroups.rg1.searchRate.max")
+		defer baseParams.Reset("quotaAndLimits.resourceGroups.rg1.memoryQuota")
+		defer baseParams.Reset("quotaAndLimits.resourceGroups.rg2.queryRate.max")
+
+		quotas := qc.GetResourceGroupQuotas()
+		assert.Len(t, quotas, 2)
+		assert.Equal(t, 100.0, quotas["rg1"].MaxSearchRate)
+		assert.Equal(t, defaultMax, quotas["rg1"].MaxQueryRate)
+		assert.Equal(t, 1024*MBSize, quotas["rg1"].MemoryQuota)
+		assert.Equal(t, defaultMax, quotas["rg2"].MaxQueryRate)
+	})
+
 	t.Run("test disk quota", func(t *testing.T) {
 		assert.Equal(t, defaultMax, qc.DiskQuota.GetAsFloat())
 		assert.Equal(t, defaultMax, qc.DiskQuotaPerCollection.GetAsFloat())
@@ -61,6 +61,8 @@ func GetHookParams() *hookConfig {
 
 func SetNodeID(newID UniqueID) {
 	params.baseTable.Save(runtimeNodeIDKey, strconv.FormatInt(newID, 10))
+	// apply the configs scoped to this node
+	params.baseTable.UpdateScope(GetRole(), newID)
 }
 
 func GetNodeID() UniqueID {
@@ -73,6 +75,8 @@ func GetNodeID() UniqueID {
 
 func SetRole(role string) {
 	params.baseTable.Save(runtimeRoleKey, role)
+	// apply the configs scoped to this role
+	params.baseTable.UpdateScope(role, GetNodeID())
 }
 
 func GetRole() string {
@@ -52,6 +52,8 @@ type ServiceParam struct {
 	RocksmqCfg      RocksmqConfig
 	NatsmqCfg       NatsmqConfig
 	MinioCfg        MinioConfig
+
+	WalmqCfg WalmqConfig
 }
 
 func (p *ServiceParam) init(bt *BaseTable) {
@@ -65,12 +67,18 @@ func (p *ServiceParam) init(bt *BaseTable) {
 	p.RocksmqCfg.Init(bt)
 	p.NatsmqCfg.Init(bt)
 	p.MinioCfg.Init(bt)
+	p.WalmqCfg.Init(bt)
 }
 
 func (p *ServiceParam) RocksmqEnable() bool {
 	return p.RocksmqCfg.Path.GetValue() != ""
 }
 
+// WalmqEnable checks if the embedded write-ahead log messaging queue is enabled.
+func (p *ServiceParam) WalmqEnable() bool {
+	return p.WalmqCfg.Path.GetValue() != ""
+}
+
 // NatsmqEnable checks if NATS messaging queue is enabled.
 func (p *ServiceParam) NatsmqEnable() bool {
 	return p.NatsmqCfg.ServerStoreDir.GetValue() != ""
@@ -434,6 +442,9 @@ type MQConfig struct {
 
 	MQBufSize      ParamItem `refreshable:"false"`
 	ReceiveBufSize ParamItem `refreshable:"false"`
+
+	CompressionType    ParamItem `refreshable:"true"`
+	CompressionMinSize ParamItem `refreshable:"true"`
 }
 
 // Init initializes the MQConfig object with a BaseTable.
@@ -443,7 +454,7 @@ func (p *MQConfig) Init(base *BaseTable) {
 		Version:      "2.3.0",
 		DefaultValue: "default",
 		Doc: `Default value: "default"
-Valid values: [default, pulsar, kafka, rocksmq, natsmq]`,
+Valid values: [default, pulsar, kafka, rocksmq, natsmq, walmq]`,
 		Export: true,
 	}
 	p.Type.Init(base.mgr)
@@ -491,6 +502,25 @@ Valid values: [default, pulsar, kafka, rocksmq, natsmq]`,
 		Doc:          "MQ consumer chan buffer length",
 	}
 	p.ReceiveBufSize.Init(base.mgr)
+
+	p.CompressionType = ParamItem{
+		Key:          "mq.compression.type",
+		Version:      "2.4.0",
+		DefaultValue: "none",
+		Doc: `compression of the msgstream payloads produced, the consumers decode the payloads by the codec carried in the message
+Valid values: [none, zstd, lz4]`,
+		Export: true,
+	}
+	p.CompressionType.Init(base.mgr)
+
+	p.CompressionMinSize = ParamItem{
+		Key:          "mq.compression.minSize",
+		Version:      "2.4.0",
+		DefaultValue: "4096",
+		Doc:          "the payloads smaller than it in bytes are not compressed",
+		Export:       true,
+	}
+	p.CompressionMinSize.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -648,6 +678,12 @@ type KafkaConfig struct {
 	ConsumerExtraConfig ParamGroup `refreshable:"false"`
 	ProducerExtraConfig ParamGroup `refreshable:"false"`
 	ReadTimeout         ParamItem  `refreshable:"true"`
+
+	SaslOauthbearerClientID         ParamItem `refreshable:"false"`
+	SaslOauthbearerClientSecret     ParamItem `refreshable:"false"`
+	SaslOauthbearerTokenEndpointURL ParamItem `refreshable:"false"`
+	SaslOauthbearerScope            ParamItem `refreshable:"false"`
+	Partitioner                     ParamItem `refreshable:"false"`
 }
 
 func (k *KafkaConfig) Init(base *BaseTable) {
@@ -711,6 +747,50 @@ func (k *KafkaConfig) Init(base *BaseTable) {
 		Export:       true,
 	}
 	k.ReadTimeout.Init(base.mgr)
+
+	k.SaslOauthbearerClientID = ParamItem{
+		Key:          "kafka.saslOauthbearerClientID",
+		DefaultValue: "",
+		Version:      "2.4.0",
+		Doc:          "the client id to fetch the token from the OIDC token endpoint, used if saslMechanisms is OAUTHBEARER",
+		Export:       true,
+	}
+	k.SaslOauthbearerClientID.Init(base.mgr)
+
+	k.SaslOauthbearerClientSecret = ParamItem{
+		Key:          "kafka.saslOauthbearerClientSecret",
+		DefaultValue: "",
+		Version:      "2.4.0",
+		Export:       true,
+	}
+	k.SaslOauthbearerClientSecret.Init(base.mgr)
+
+	k.SaslOauthbearerTokenEndpointURL = ParamItem{
+		Key:          "kafka.saslOauthbearerTokenEndpointURL",
+		DefaultValue: "",
+		Version:      "2.4.0",
+		Doc: `the OIDC token endpoint, e.g. the token endpoint of the IAM provider,
+OAUTHBEARER with the unsecured jws token is used if it's empty, which is only for testing`,
+		Export: true,
+	}
+	k.SaslOauthbearerTokenEndpointURL.Init(base.mgr)
+
+	k.SaslOauthbearerScope = ParamItem{
+		Key:          "kafka.saslOauthbearerScope",
+		DefaultValue: "",
+		Version:      "2.4.0",
+		Export:       true,
+	}
+	k.SaslOauthbearerScope.Init(base.mgr)
+
+	k.Partitioner = ParamItem{
+		Key:          "kafka.partitioner",
+		DefaultValue: "",
+		Version:      "2.4.0",
+		Doc:          "the partitioner of producer, [random, consistent, consistent_random, murmur2, murmur2_random, fnv1a, fnv1a_random], use the default of librdkafka if empty",
+		Export:       true,
+	}
+	k.Partitioner.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -804,6 +884,70 @@ please adjust in embedded Milvus: /tmp/milvus/rdb_data`,
 	r.CompressionTypes.Init(base.mgr)
 }
 
+// WalmqConfig describes the configuration options for the embedded write-ahead log message queue
+type WalmqConfig struct {
+	Path                   ParamItem `refreshable:"false"`
+	SegmentSize            ParamItem `refreshable:"false"`
+	SyncWrite              ParamItem `refreshable:"false"`
+	RetentionTimeInMinutes ParamItem `refreshable:"true"`
+	RetentionSizeInMB      ParamItem `refreshable:"true"`
+	TickerTimeInSeconds    ParamItem `refreshable:"false"`
+}
+
+func (w *WalmqConfig) Init(base *BaseTable) {
+	w.Path = ParamItem{
+		Key:          "walmq.path",
+		Version:      "2.4.0",
+		DefaultValue: "/var/lib/milvus/walmq",
+		Doc:          "The directory where the write-ahead log of walmq is stored",
+		Export:       true,
+	}
+	w.Path.Init(base.mgr)
+
+	w.SegmentSize = ParamItem{
+		Key:          "walmq.segmentSize",
+		Version:      "2.4.0",
+		DefaultValue: strconv.FormatInt(64<<20, 10),
+		Doc:          "64 MB, 64 * 1024 * 1024 bytes, The size of each log segment file of a topic",
+		Export:       true,
+	}
+	w.SegmentSize.Init(base.mgr)
+
+	w.SyncWrite = ParamItem{
+		Key:          "walmq.syncWrite",
+		Version:      "2.4.0",
+		DefaultValue: "false",
+		Doc:          "Whether to fsync the log segment after each produce, messages may be lost on power failure if disabled",
+		Export:       true,
+	}
+	w.SyncWrite.Init(base.mgr)
+
+	w.RetentionTimeInMinutes = ParamItem{
+		Key:          "walmq.retentionTimeInMinutes",
+		Version:      "2.4.0",
+		DefaultValue: "4320",
+		Doc:          "3 days, 3 * 24 * 60 minutes, The sealed segments older than it are removed.",
+		Export:       true,
+	}
+	w.RetentionTimeInMinutes.Init(base.mgr)
+
+	w.RetentionSizeInMB = ParamItem{
+		Key:          "walmq.retentionSizeInMB",
+		Version:      "2.4.0",
+		DefaultValue: "8192",
+		Doc:          "8 GB, 8 * 1024 MB, The oldest sealed segments of a topic are removed once its size exceeds it, -1 means unlimited.",
+		Export:       true,
+	}
+	w.RetentionSizeInMB.Init(base.mgr)
+
+	w.TickerTimeInSeconds = ParamItem{
+		Key:          "walmq.retentionCheckInterval",
+		Version:      "2.4.0",
+		DefaultValue: "600",
+	}
+	w.TickerTimeInSeconds.Init(base.mgr)
+}
+
 // NatsmqConfig describes the configuration options for the Nats message queue
 type NatsmqConfig struct {
 	ServerPort                ParamItem `refreshable:"false"`
@@ -956,6 +1100,8 @@ type MinioConfig struct {
 	Region           ParamItem `refreshable:"false"`
 	UseVirtualHost   ParamItem `refreshable:"false"`
 	RequestTimeoutMs ParamItem `refreshable:"false"`
+
+	BucketProfiles ParamGroup `refreshable:"false"`
 }
 
 func (p *MinioConfig) Init(base *BaseTable) {
@@ -1115,4 +1261,28 @@ Leave it empty if you want to use AWS default endpoint`,
 		Export:       true,
 	}
 	p.RequestTimeoutMs.Init(base.mgr)
+
+	p.BucketProfiles = ParamGroup{
+		KeyPrefix: "minio.bucketProfiles.",
+		Version:   "2.4.0",
+		Doc:       "extra buckets which databases or collections could be routed to",
+	}
+	p.BucketProfiles.Init(base.mgr)
+}
+
+// GetBucketProfiles returns the configs of each bucket profile, grouped by the profile name.
+func (p *MinioConfig) GetBucketProfiles() map[string]map[string]string {
+	profiles := make(map[string]map[string]string)
+	for key, value := range p.BucketProfiles.GetValue() {
+		idx := strings.Index(key, ".")
+		if idx <= 0 {
+			continue
+		}
+		name := key[:idx]
+		if _, ok := profiles[name]; !ok {
+			profiles[name] = make(map[string]string)
+		}
+		profiles[name][key[idx+1:]] = value
+	}
+	return profiles
 }
@@ -159,6 +159,24 @@ func TestServiceParam(t *testing.T) {
 		t.Logf("rocksmq path = %s", Params.Path.GetValue())
 	})
 
+	t.Run("test walmqConfig", func(t *testing.T) {
+		Params := &SParams.WalmqCfg
+
+		assert.Equal(t, "/var/lib/milvus/walmq", Params.Path.GetValue())
+		assert.EqualValues(t, 64<<20, Params.SegmentSize.GetAsInt64())
+		assert.False(t, Params.SyncWrite.GetAsBool())
+		assert.Equal(t, 4320, Params.RetentionTimeInMinutes.GetAsInt())
+		assert.Equal(t, 8192, Params.RetentionSizeInMB.GetAsInt())
+		assert.True(t, SParams.WalmqEnable())
+	})
+
+	t.Run("test mqConfig", func(t *testing.T) {
+		Params := &SParams.MQCfg
+
+		assert.Equal(t, "none", Params.CompressionType.GetValue())
+		assert.Equal(t, 4096, Params.CompressionMinSize.GetAsInt())
+	})
+
 	t.Run("test kafkaConfig", func(t *testing.T) {
 		// test default value
 		{
@@ -169,6 +187,8 @@ func TestServiceParam(t *testing.T) {
 			assert.Equal(t, kc.SaslMechanisms.GetValue(), "PLAIN")
 			assert.Equal(t, kc.SecurityProtocol.GetValue(), "SASL_SSL")
 			assert.Equal(t, kc.ReadTimeout.GetAsDuration(time.Second), 10*time.Second)
+			assert.Empty(t, kc.SaslOauthbearerTokenEndpointURL.GetValue())
+			assert.Empty(t, kc.Partitioner.GetValue())
 		}
 	})
 
@@ -195,5 +215,20 @@ func TestServiceParam(t *testing.T) {
 		t.Logf("Minio BucketName = %s", Params.BucketName.GetValue())
 
 		t.Logf("Minio rootpath = %s", Params.RootPath.GetValue())
+
+		assert.Empty(t, Params.GetBucketProfiles())
+		bt.SaveGroup(map[string]string{
+			"minio.bucketProfiles.eu.bucketName": "milvus-eu",
+			"minio.bucketProfiles.eu.databases":  "db1,db2",
+			"minio.bucketProfiles.us.bucketName": "milvus-us",
+		})
+		defer bt.Reset("minio.bucketProfiles.eu.bucketName")
+		defer bt.Reset("minio.bucketProfiles.eu.databases")
+		defer bt.Reset("minio.bucketProfiles.us.bucketName")
+		profiles := Params.GetBucketProfiles()
+		assert.Len(t, profiles, 2)
+		assert.Equal(t, "milvus-eu", profiles["eu"]["bucketname"])
+		assert.Equal(t, "db1,db2", profiles["eu"]["databases"])
+		assert.Equal(t, "milvus-us", profiles["us"]["bucketname"])
 	})
 }
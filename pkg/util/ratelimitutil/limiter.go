@@ -44,11 +44,16 @@ const Inf = Limit(math.MaxFloat64)
 // After these large number of events toke tokens from bucket, the number of tokens
 // in bucket may be negative, and the latter events would be "punished",
 // any event should wait for the tokens to be filled to greater or equal to 0.
+//
+// If the burst is set by SetBurst, Limiter works as a token-bucket of size burst,
+// the events are allowed only if there are enough tokens in bucket.
 type Limiter struct {
 	mu     sync.Mutex
 	limit  Limit
 	burst  float64
 	tokens float64
+	// tokenBucket is true if the burst is set, then Limiter works as a token-bucket
+	tokenBucket bool
 	// last is the last time the limiter's tokens field was updated
 	last time.Time
 }
@@ -86,7 +91,7 @@ func (lim *Limiter) AllowN(now time.Time, n int) bool {
 
 	now, last, tokens := lim.advance(now)
 
-	ok := tokens >= 0
+	ok := tokens >= lim.required(n)
 
 	// Calculate the remaining number of tokens resulting from the request.
 	tokens -= float64(n)
@@ -113,14 +118,75 @@ func (lim *Limiter) SetLimit(newLimit Limit) {
 	lim.last = now
 	lim.tokens = tokens
 	lim.limit = newLimit
-	if newLimit >= math.MaxFloat64 {
+	if !lim.tokenBucket {
+		lim.resetBurst()
+	}
+}
+
+// Burst returns the maximum burst size, the bucket size of the token-bucket.
+func (lim *Limiter) Burst() float64 {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.burst
+}
+
+// SetBurst sets the bucket size, Limiter works as a token-bucket if it's positive.
+// Otherwise, Limiter falls back to the punishment mechanism with the rate as burst.
+func (lim *Limiter) SetBurst(newBurst float64) {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+
+	now, _, tokens := lim.advance(time.Now())
+
+	lim.last = now
+	lim.tokens = tokens
+	lim.tokenBucket = newBurst > 0
+	if !lim.tokenBucket {
+		lim.resetBurst()
+		return
+	}
+	lim.burst = newBurst
+	if lim.tokens > newBurst {
+		lim.tokens = newBurst
+	}
+}
+
+// resetBurst sets the burst by the limit, requires that lim.mu is held.
+func (lim *Limiter) resetBurst() {
+	if lim.limit >= math.MaxFloat64 {
 		lim.burst = math.MaxInt
 	} else {
 		// use rate as burst, because Limiter is with punishment mechanism, burst is insignificant.
-		lim.burst = float64(newLimit)
+		lim.burst = float64(lim.limit)
 	}
 }
 
+// WaitN returns the suggested duration to wait until n events may happen since now,
+// zero if they may happen immediately or would never happen.
+func (lim *Limiter) WaitN(now time.Time, n int) time.Duration {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+
+	if lim.limit == Inf || lim.limit <= 0 {
+		return 0
+	}
+	_, _, tokens := lim.advance(now)
+	lack := lim.required(n) - tokens
+	if lack <= 0 {
+		return 0
+	}
+	return lim.limit.durationFromTokens(lack)
+}
+
+// required returns the tokens required to allow n events, requires that lim.mu is held.
+func (lim *Limiter) required(n int) float64 {
+	if !lim.tokenBucket {
+		return 0
+	}
+	// the events more than the bucket size are allowed once the bucket is full, then the tokens turn negative
+	return math.Min(float64(n), lim.burst)
+}
+
 // Cancel the AllowN operation and refund the tokens that have already been deducted by the limiter.
 func (lim *Limiter) Cancel(n int) {
 	lim.mu.Lock()
@@ -154,6 +220,15 @@ func (limit Limit) String() string {
 	return fmt.Sprintf("%v", float64(limit))
 }
 
+// durationFromTokens is a unit conversion function from the number of tokens to the duration
+// of time it takes to accumulate them at a rate of limit tokens per second.
+func (limit Limit) durationFromTokens(tokens float64) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / float64(limit) * float64(time.Second))
+}
+
 // tokensFromDuration is a unit conversion function from a time duration to the number of tokens
 // which could be accumulated during that duration at a rate of limit tokens per second.
 func (limit Limit) tokensFromDuration(d time.Duration) float64 {
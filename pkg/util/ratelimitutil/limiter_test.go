@@ -391,3 +391,47 @@ func TestLimiter_Cancel(t *testing.T) {
 		t.Errorf("numOK = %d, want %d (ideal %f)", numOK, want, ideal)
 	}
 }
+
+func TestLimiter_TokenBucket(t *testing.T) {
+	lim := NewLimiter(10, 10)
+	lim.SetBurst(20)
+	if burst := lim.Burst(); burst != 20 {
+		t.Errorf("lim.Burst() = %v want 20", burst)
+	}
+
+	// the bucket is full after a while
+	base := time.Now().Add(5 * time.Second)
+	run(t, lim, []allow{
+		{base, 15, true, 5},
+		{base, 10, false, 5},
+	})
+	if wait := lim.WaitN(base, 10); wait != 500*time.Millisecond {
+		t.Errorf("lim.WaitN(%v, 10) = %v want 500ms", base, wait)
+	}
+	run(t, lim, []allow{
+		{base.Add(500 * time.Millisecond), 10, true, 0},
+		// the events more than the burst are allowed once the bucket is full
+		{base.Add(500 * time.Millisecond), 30, false, 0},
+	})
+	if wait := lim.WaitN(base.Add(500*time.Millisecond), 30); wait != 2*time.Second {
+		t.Errorf("lim.WaitN(%v, 30) = %v want 2s", base, wait)
+	}
+	run(t, lim, []allow{
+		{base.Add(2500 * time.Millisecond), 30, true, -10},
+	})
+
+	// the limit changes keep the burst
+	lim.SetLimit(100)
+	if burst := lim.Burst(); burst != 20 {
+		t.Errorf("lim.Burst() = %v want 20", burst)
+	}
+
+	// fall back to the punishment mechanism
+	lim.SetBurst(0)
+	if burst := lim.Burst(); burst != 100 {
+		t.Errorf("lim.Burst() = %v want 100", burst)
+	}
+	if wait := NewLimiter(Inf, 0).WaitN(base, 100); wait != 0 {
+		t.Errorf("lim.WaitN(%v, 100) = %v want 0", base, wait)
+	}
+}
@@ -383,6 +383,60 @@ func IsVariableDataType(dataType schemapb.DataType) bool {
 	return IsStringType(dataType) || IsArrayType(dataType) || IsJSONType(dataType)
 }
 
+// EstimateEntitySizes estimates the size of the rows of the specified offsets column by column,
+// the result is the same as calling EstimateEntitySize for each offset.
+func EstimateEntitySizes(fieldsData []*schemapb.FieldData, rowOffsets []int) ([]int, error) {
+	sizes := make([]int, len(rowOffsets))
+	fixed := 0
+	for _, fs := range fieldsData {
+		switch fs.GetType() {
+		case schemapb.DataType_Bool, schemapb.DataType_Int8:
+			fixed++
+		case schemapb.DataType_Int16:
+			fixed += 2
+		case schemapb.DataType_Int32, schemapb.DataType_Float:
+			fixed += 4
+		case schemapb.DataType_Int64, schemapb.DataType_Double:
+			fixed += 8
+		case schemapb.DataType_BinaryVector:
+			fixed += int(fs.GetVectors().GetDim())
+		case schemapb.DataType_FloatVector:
+			fixed += int(fs.GetVectors().GetDim() * 4)
+		case schemapb.DataType_VarChar:
+			data := fs.GetScalars().GetStringData().GetData()
+			for i, offset := range rowOffsets {
+				if offset >= len(data) {
+					return nil, fmt.Errorf("offset out range of field datas")
+				}
+				sizes[i] += len(data[offset])
+			}
+		case schemapb.DataType_Array:
+			data := fs.GetScalars().GetArrayData().GetData()
+			for i, offset := range rowOffsets {
+				if offset >= len(data) {
+					return nil, fmt.Errorf("offset out range of field datas")
+				}
+				sizes[i] += CalcColumnSize(&schemapb.FieldData{
+					Field: &schemapb.FieldData_Scalars{Scalars: data[offset]},
+					Type:  fs.GetScalars().GetArrayData().GetElementType(),
+				})
+			}
+		case schemapb.DataType_JSON:
+			data := fs.GetScalars().GetJsonData().GetData()
+			for i, offset := range rowOffsets {
+				if offset >= len(data) {
+					return nil, fmt.Errorf("offset out range of field datas")
+				}
+				sizes[i] += len(data[offset])
+			}
+		}
+	}
+	for i := range sizes {
+		sizes[i] += fixed
+	}
+	return sizes, nil
+}
+
 // AppendFieldData appends fields data of specified index from src to dst
 func AppendFieldData(dst []*schemapb.FieldData, src []*schemapb.FieldData, idx int64) (appendSize int64) {
 	for i, fieldData := range src {
@@ -566,6 +620,128 @@ func AppendFieldData(dst []*schemapb.FieldData, src []*schemapb.FieldData, idx i
 	return
 }
 
+// gatherRows appends the rows of the specified offsets from src to dst, each row has width elements.
+func gatherRows[T any](dst []T, src []T, offsets []int, width int) []T {
+	if dst == nil {
+		dst = make([]T, 0, len(offsets)*width)
+	}
+	for _, offset := range offsets {
+		dst = append(dst, src[offset*width:(offset+1)*width]...)
+	}
+	return dst
+}
+
+// AppendFieldDataByOffsets appends fields data of the specified offsets from src to dst column by column,
+// the result is the same as calling AppendFieldData for each offset, without the row-wise conversion.
+func AppendFieldDataByOffsets(dst []*schemapb.FieldData, src []*schemapb.FieldData, offsets []int) {
+	if len(offsets) == 0 {
+		return
+	}
+	for i, fieldData := range src {
+		switch fieldType := fieldData.Field.(type) {
+		case *schemapb.FieldData_Scalars:
+			if dst[i] == nil || dst[i].GetScalars() == nil {
+				dst[i] = &schemapb.FieldData{
+					Type:      fieldData.Type,
+					FieldName: fieldData.FieldName,
+					FieldId:   fieldData.FieldId,
+					IsDynamic: fieldData.IsDynamic,
+					Field: &schemapb.FieldData_Scalars{
+						Scalars: &schemapb.ScalarField{},
+					},
+				}
+			}
+			dstScalar := dst[i].GetScalars()
+			switch srcScalar := fieldType.Scalars.Data.(type) {
+			case *schemapb.ScalarField_BoolData:
+				dstScalar.Data = &schemapb.ScalarField_BoolData{
+					BoolData: &schemapb.BoolArray{
+						Data: gatherRows(dstScalar.GetBoolData().GetData(), srcScalar.BoolData.Data, offsets, 1),
+					},
+				}
+			case *schemapb.ScalarField_IntData:
+				dstScalar.Data = &schemapb.ScalarField_IntData{
+					IntData: &schemapb.IntArray{
+						Data: gatherRows(dstScalar.GetIntData().GetData(), srcScalar.IntData.Data, offsets, 1),
+					},
+				}
+			case *schemapb.ScalarField_LongData:
+				dstScalar.Data = &schemapb.ScalarField_LongData{
+					LongData: &schemapb.LongArray{
+						Data: gatherRows(dstScalar.GetLongData().GetData(), srcScalar.LongData.Data, offsets, 1),
+					},
+				}
+			case *schemapb.ScalarField_FloatData:
+				dstScalar.Data = &schemapb.ScalarField_FloatData{
+					FloatData: &schemapb.FloatArray{
+						Data: gatherRows(dstScalar.GetFloatData().GetData(), srcScalar.FloatData.Data, offsets, 1),
+					},
+				}
+			case *schemapb.ScalarField_DoubleData:
+				dstScalar.Data = &schemapb.ScalarField_DoubleData{
+					DoubleData: &schemapb.DoubleArray{
+						Data: gatherRows(dstScalar.GetDoubleData().GetData(), srcScalar.DoubleData.Data, offsets, 1),
+					},
+				}
+			case *schemapb.ScalarField_StringData:
+				dstScalar.Data = &schemapb.ScalarField_StringData{
+					StringData: &schemapb.StringArray{
+						Data: gatherRows(dstScalar.GetStringData().GetData(), srcScalar.StringData.Data, offsets, 1),
+					},
+				}
+			case *schemapb.ScalarField_ArrayData:
+				dstScalar.Data = &schemapb.ScalarField_ArrayData{
+					ArrayData: &schemapb.ArrayArray{
+						Data:        gatherRows(dstScalar.GetArrayData().GetData(), srcScalar.ArrayData.Data, offsets, 1),
+						ElementType: srcScalar.ArrayData.ElementType,
+					},
+				}
+			case *schemapb.ScalarField_JsonData:
+				dstScalar.Data = &schemapb.ScalarField_JsonData{
+					JsonData: &schemapb.JSONArray{
+						Data: gatherRows(dstScalar.GetJsonData().GetData(), srcScalar.JsonData.Data, offsets, 1),
+					},
+				}
+			default:
+				log.Error("Not supported field type", zap.String("field type", fieldData.Type.String()))
+			}
+		case *schemapb.FieldData_Vectors:
+			dim := fieldType.Vectors.Dim
+			if dst[i] == nil || dst[i].GetVectors() == nil {
+				dst[i] = &schemapb.FieldData{
+					Type:      fieldData.Type,
+					FieldName: fieldData.FieldName,
+					FieldId:   fieldData.FieldId,
+					Field: &schemapb.FieldData_Vectors{
+						Vectors: &schemapb.VectorField{
+							Dim: dim,
+						},
+					},
+				}
+			}
+			dstVector := dst[i].GetVectors()
+			switch srcVector := fieldType.Vectors.Data.(type) {
+			case *schemapb.VectorField_BinaryVector:
+				dstVector.Data = &schemapb.VectorField_BinaryVector{
+					BinaryVector: gatherRows(dstVector.GetBinaryVector(), srcVector.BinaryVector, offsets, int(dim/8)),
+				}
+			case *schemapb.VectorField_FloatVector:
+				dstVector.Data = &schemapb.VectorField_FloatVector{
+					FloatVector: &schemapb.FloatArray{
+						Data: gatherRows(dstVector.GetFloatVector().GetData(), srcVector.FloatVector.Data, offsets, int(dim)),
+					},
+				}
+			case *schemapb.VectorField_Float16Vector:
+				dstVector.Data = &schemapb.VectorField_Float16Vector{
+					Float16Vector: gatherRows(dstVector.GetFloat16Vector(), srcVector.Float16Vector, offsets, int(dim*2)),
+				}
+			default:
+				log.Error("Not supported field type", zap.String("field type", fieldData.Type.String()))
+			}
+		}
+	}
+}
+
 // DeleteFieldData delete fields data appended last time
 func DeleteFieldData(dst []*schemapb.FieldData) {
 	for i, fieldData := range dst {
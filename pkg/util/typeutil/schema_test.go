@@ -726,6 +726,46 @@ func TestAppendFieldData(t *testing.T) {
 	assert.Equal(t, ArrayArray, result[8].GetScalars().GetArrayData().Data)
 }
 
+func TestAppendFieldDataByOffsets(t *testing.T) {
+	const dim = 8
+	src := []*schemapb.FieldData{
+		genFieldData("bool", 100, schemapb.DataType_Bool, []bool{true, false, true, false}, 1),
+		genFieldData("int64", 101, schemapb.DataType_Int64, []int64{1, 2, 3, 4}, 1),
+		genFieldData("double", 102, schemapb.DataType_Double, []float64{1.0, 2.0, 3.0, 4.0}, 1),
+		genFieldData("varchar", 103, schemapb.DataType_VarChar, []string{"a", "bb", "ccc", "dddd"}, 1),
+		genFieldData("binary_vector", 104, schemapb.DataType_BinaryVector, []byte{0x1, 0x2, 0x3, 0x4}, dim),
+		genFieldData("float_vector", 105, schemapb.DataType_FloatVector, make([]float32, 4*dim), dim),
+		genFieldData("float16_vector", 106, schemapb.DataType_Float16Vector, make([]byte, 4*dim*2), dim),
+	}
+	for i := range src[5].GetVectors().GetFloatVector().GetData() {
+		src[5].GetVectors().GetFloatVector().Data[i] = float32(i)
+	}
+	offsets := []int{3, 0, 2}
+
+	expected := make([]*schemapb.FieldData, len(src))
+	for _, offset := range offsets {
+		AppendFieldData(expected, src, int64(offset))
+	}
+	result := make([]*schemapb.FieldData, len(src))
+	AppendFieldDataByOffsets(result, src, offsets[:1])
+	AppendFieldDataByOffsets(result, src, offsets[1:])
+	assert.Equal(t, expected, result)
+
+	empty := make([]*schemapb.FieldData, len(src))
+	AppendFieldDataByOffsets(empty, src, nil)
+	assert.Equal(t, make([]*schemapb.FieldData, len(src)), empty)
+
+	sizes, err := EstimateEntitySizes(src, offsets)
+	assert.NoError(t, err)
+	for i, offset := range offsets {
+		size, err := EstimateEntitySize(src, offset)
+		assert.NoError(t, err)
+		assert.Equal(t, size, sizes[i])
+	}
+	_, err = EstimateEntitySizes(src, []int{4})
+	assert.Error(t, err)
+}
+
 func TestDeleteFieldData(t *testing.T) {
 	const (
 		Dim                    = 8